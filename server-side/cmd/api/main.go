@@ -2,114 +2,970 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
-	"path/filepath"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/ingunawandra/catetin/internal/config"
 	httpController "github.com/ingunawandra/catetin/internal/controller/http"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
 	v1 "github.com/ingunawandra/catetin/internal/controller/http/v1"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/infrastructure/cache"
 	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlc"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlite"
+	"github.com/ingunawandra/catetin/internal/infrastructure/email"
+	"github.com/ingunawandra/catetin/internal/infrastructure/exchangerate"
+	"github.com/ingunawandra/catetin/internal/infrastructure/fcm"
+	"github.com/ingunawandra/catetin/internal/infrastructure/google"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/metrics"
+	"github.com/ingunawandra/catetin/internal/infrastructure/openai"
 	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/sentry"
+	"github.com/ingunawandra/catetin/internal/infrastructure/slack"
+	"github.com/ingunawandra/catetin/internal/infrastructure/storage"
+	"github.com/ingunawandra/catetin/internal/infrastructure/telegram"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/infrastructure/webhook"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/realtime"
+	"github.com/ingunawandra/catetin/internal/repository"
 	"github.com/ingunawandra/catetin/internal/service"
+	"github.com/ingunawandra/catetin/internal/worker"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gorm.io/gorm"
 )
 
 func main() {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		fatal(slog.Default(), "Failed to load configuration", err)
 	}
 
-	log.Printf("Starting Catetin API Server on port %s...", cfg.Server.Port)
-
-	// Initialize database connection
-	db, err := postgresql.NewConnection(cfg.GetDatabaseDSN(), cfg.Server.Env)
+	// Initialize structured logger; packages without a request context log through the default
+	appLogger, logLevel, err := logger.New(logger.Config{Level: cfg.Log.Level, Format: cfg.Log.Format})
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal(slog.Default(), "Failed to initialize logger", err)
 	}
+	slog.SetDefault(appLogger)
+
+	appLogger.Info("Starting Catetin API Server", "port", cfg.Server.Port, "env", cfg.Server.Env)
 
-	// Run database migrations using golang-migrate
-	databaseURL, err := postgresql.ConvertDSNToURL(cfg.GetDatabaseDSN())
+	// Initialize tracing; spans are only exported when enabled
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		Endpoint:    cfg.Tracing.Endpoint,
+		ServiceName: cfg.Tracing.ServiceName,
+		Environment: cfg.Server.Env,
+		SampleRate:  cfg.Tracing.SampleRate,
+	})
 	if err != nil {
-		log.Fatalf("Failed to convert DSN to URL: %v", err)
+		fatal(appLogger, "Failed to initialize tracing", err)
+	}
+	if cfg.Tracing.Enabled {
+		appLogger.Info("Tracing enabled", "endpoint", cfg.Tracing.Endpoint, "sample_rate", cfg.Tracing.SampleRate)
 	}
 
-	// Get absolute path to migrations directory
-	migrationsPath, err := filepath.Abs("internal/infrastructure/database/postgresql/migrations")
+	// Errors answered with 500 are reported to Sentry when a DSN is set
+	sentryClient, err := sentry.NewClient(sentry.Config{
+		DSN:         cfg.Sentry.DSN,
+		Environment: cfg.Sentry.Environment,
+		Release:     cfg.Sentry.Release,
+		Timeout:     time.Duration(cfg.Sentry.Timeout) * time.Second,
+	})
 	if err != nil {
-		log.Fatalf("Failed to get migrations path: %v", err)
+		fatal(appLogger, "Failed to initialize Sentry", err)
+	}
+	var errorReporter middleware.ErrorReporter
+	if sentryClient.Enabled() {
+		errorReporter = sentryClient
+		appLogger.Info("Sentry error reporting enabled", "environment", cfg.Sentry.Environment)
 	}
 
-	// Run migrations
-	if err := postgresql.RunMigrations(databaseURL, migrationsPath); err != nil {
-		log.Fatalf("Failed to run database migrations: %v", err)
+	// Prometheus metrics, served at /metrics; recording is a no-op when disabled
+	var metricsRegistry *metrics.Registry
+	if cfg.Metrics.Enabled {
+		metricsRegistry = metrics.NewRegistry()
+	}
+
+	// Time every query, logging slow ones with the code that ran them
+	queryMonitor := postgresql.NewQueryMonitor(time.Duration(cfg.Database.SlowQueryThreshold)*time.Millisecond, metricsRegistry)
+
+	// Encrypt sensitive columns, like provider client secrets, at rest
+	if len(cfg.Database.EncryptionKeys) > 0 {
+		keys, err := security.ParseLocalKeys(cfg.Database.EncryptionKeys)
+		if err != nil {
+			fatal(appLogger, "Invalid DB_ENCRYPTION_KEYS", err)
+		}
+		fieldCipher, err := security.NewFieldCipher(keys...)
+		if err != nil {
+			fatal(appLogger, "Invalid DB_ENCRYPTION_KEYS", err)
+		}
+		postgresql.SetFieldCipher(fieldCipher)
+	} else if cfg.Server.Env == "production" {
+		appLogger.Warn("DB_ENCRYPTION_KEYS is not set, sensitive columns are stored as plaintext")
 	}
 
-	// Check migration version
-	version, dirty, err := postgresql.MigrationVersion(databaseURL, migrationsPath)
+	// Initialize database connection
+	var db *gorm.DB
+	if cfg.Database.Driver == config.DriverSQLite {
+		db, err = sqlite.NewConnection(cfg.Database.SQLitePath, cfg.Server.Env)
+	} else {
+		db, err = postgresql.NewConnection(cfg.GetDatabaseDSN(), cfg.Server.Env, cfg.DatabasePool())
+	}
 	if err != nil {
-		log.Printf("Warning: Failed to get migration version: %v", err)
+		fatal(appLogger, "Failed to connect to database", err)
+	}
+	if err := db.Use(queryMonitor); err != nil {
+		fatal(appLogger, "Failed to register database query monitor", err)
+	}
+	if cfg.Tracing.Enabled {
+		if err := db.Use(postgresql.NewTracingPlugin()); err != nil {
+			fatal(appLogger, "Failed to register database tracing", err)
+		}
+	}
+
+	var migrations fs.FS
+	if cfg.Database.Driver == config.DriverSQLite {
+		// Run the SQLite migrations embedded in the binary
+		migrations = sqlite.Migrations()
+		if err := sqlite.RunMigrations(db, migrations); err != nil {
+			fatal(appLogger, "Failed to run database migrations", err)
+		}
 	} else {
-		log.Printf("Current database migration version: %d (dirty: %v)", version, dirty)
+		// Run database migrations using golang-migrate
+		databaseURL, err := postgresql.ConvertDSNToURL(cfg.GetDatabaseDSN())
+		if err != nil {
+			fatal(appLogger, "Failed to convert DSN to URL", err)
+		}
+
+		// Run the migrations embedded in the binary
+		migrations = postgresql.Migrations()
+		if err := postgresql.RunMigrations(databaseURL, migrations); err != nil {
+			fatal(appLogger, "Failed to run database migrations", err)
+		}
+
+		// Check migration version
+		version, dirty, err := postgresql.MigrationVersion(databaseURL, migrations)
+		if err != nil {
+			appLogger.Warn("Failed to get migration version", "error", err)
+		} else {
+			appLogger.Info("Current database migration version", "version", version, "dirty", dirty)
+		}
+	}
+
+	// Connect to read replicas, which serve reads marked with repository.PreferReplica
+	replicas := make([]*gorm.DB, len(cfg.Database.ReplicaURLs))
+	replicaConns := make([]repository.DB, len(cfg.Database.ReplicaURLs))
+	for i, replicaURL := range cfg.Database.ReplicaURLs {
+		replica, err := postgresql.NewConnection(replicaURL, cfg.Server.Env, cfg.DatabasePool())
+		if err != nil {
+			fatal(appLogger, "Failed to connect to read replica", err)
+		}
+		if err := replica.Use(queryMonitor); err != nil {
+			fatal(appLogger, "Failed to register database query monitor", err)
+		}
+		if cfg.Tracing.Enabled {
+			if err := replica.Use(postgresql.NewTracingPlugin()); err != nil {
+				fatal(appLogger, "Failed to register database tracing", err)
+			}
+		}
+		replicas[i] = replica
+		replicaConns[i] = postgresql.NewDB(replica)
+	}
+	if len(replicas) > 0 {
+		appLogger.Info("Read replicas enabled", "count", len(replicas))
 	}
 
 	// Initialize repositories (use DB abstraction wrapper)
-	dbConn := postgresql.NewDB(db)
+	dbConn := postgresql.NewResolverDB(postgresql.NewDB(db), replicaConns...)
+	queryBudget := 0
+	if cfg.QueryBudgetEnabled() {
+		// Count queries per request to catch N+1 patterns outside production
+		dbConn = postgresql.NewCountingDB(dbConn)
+		queryBudget = cfg.Database.QueryBudget
+		appLogger.Info("Query budget guard enabled", "budget", queryBudget, "strict", cfg.Database.QueryBudgetStrict)
+	}
 	userRepo := postgresql.NewUserRepository(dbConn)
 	moneyFlowRepo := postgresql.NewMoneyFlowRepository(dbConn)
 	authProviderRepo := postgresql.NewAuthProviderRepository(dbConn)
 	userAuthRepo := postgresql.NewUserAuthRepository(dbConn)
+	apiKeyRepo := postgresql.NewAPIKeyRepository(dbConn)
+	refreshTokenRepo := postgresql.NewRefreshTokenRepository(dbConn)
+	moneyFlowNoteRepo := postgresql.NewMoneyFlowNoteRepository(dbConn)
+	notificationRepo := postgresql.NewNotificationRepository(dbConn)
+	deviceRepo := postgresql.NewDeviceRepository(dbConn)
+	broadcastRepo := postgresql.NewBroadcastRepository(dbConn)
+	userSettingsRepo := postgresql.NewUserSettingsRepository(dbConn)
+	analyticsEventRepo := postgresql.NewAnalyticsEventRepository(dbConn)
+	apiUsageRepo := postgresql.NewAPIUsageRepository(dbConn)
+	budgetRepo := postgresql.NewBudgetRepository(dbConn)
+	walletRepo := postgresql.NewWalletRepository(dbConn)
+	goalRepo := postgresql.NewGoalRepository(dbConn)
+	groupRepo := postgresql.NewGroupRepository(dbConn)
+	splitRepo := postgresql.NewSplitRepository(dbConn)
+	merchantRepo := postgresql.NewMerchantRepository(dbConn)
+	auditLogRepo := postgresql.NewAuditLogRepository(dbConn)
+	systemStatsRepo := postgresql.NewSystemStatsRepository(dbConn)
+	groupInvitationRepo := postgresql.NewGroupInvitationRepository(dbConn)
+	conversationRepo := postgresql.NewConversationRepository(dbConn)
+	systemSettingRepo := postgresql.NewSystemSettingRepository(dbConn)
+	exchangeRateRepo := postgresql.NewExchangeRateRepository(dbConn)
+	invitationRepo := postgresql.NewInvitationRepository(dbConn)
+	passwordResetRepo := postgresql.NewPasswordResetRepository(dbConn)
+	phoneVerificationRepo := postgresql.NewPhoneVerificationRepository(dbConn)
+	moneyFlowShareRepo := postgresql.NewMoneyFlowShareRepository(dbConn)
+	feedbackRepo := postgresql.NewFeedbackRepository(dbConn)
+	accountErasureRepo := postgresql.NewAccountErasureRepository(dbConn)
+	spendingRepo := postgresql.NewSpendingAnalyticsRepository(dbConn)
+	jobQueue := postgresql.NewJobQueue(dbConn)
+
+	var sqlcPool *pgxpool.Pool
+	if cfg.Database.QueryEngine == config.QueryEngineSQLC {
+		// Run the hottest reads with sqlc on a pgx pool of the primary
+		sqlcPool, err = sqlc.NewPool(context.Background(), cfg.GetDatabaseDSN(), cfg.DatabasePool())
+		if err != nil {
+			fatal(appLogger, "Failed to connect the sqlc query engine", err)
+		}
+		userRepo = sqlc.NewUserRepository(sqlcPool, userRepo)
+		moneyFlowRepo = sqlc.NewMoneyFlowRepository(sqlcPool, moneyFlowRepo)
+		refreshTokenRepo = sqlc.NewRefreshTokenRepository(sqlcPool, refreshTokenRepo)
+		appLogger.Info("sqlc query engine enabled")
+	}
 
-	_ = moneyFlowRepo
+	if cfg.Database.Driver == config.DriverSQLite {
+		// Run the queries written in PostgreSQL's dialect in SQLite's
+		userRepo = sqlite.NewUserRepository(dbConn, userRepo)
+		moneyFlowRepo = sqlite.NewMoneyFlowRepository(dbConn, moneyFlowRepo)
+		moneyFlowNoteRepo = sqlite.NewMoneyFlowNoteRepository(dbConn, moneyFlowNoteRepo)
+		spendingRepo = sqlite.NewSpendingAnalyticsRepository(dbConn)
+		appLogger.Info("SQLite database enabled", "path", cfg.Database.SQLitePath)
+	}
+
+	if cfg.Database.ReportReadModel {
+		// Read expense totals per month from the read model the money_flows triggers keep
+		moneyFlowRepo = postgresql.NewMonthlyCategoryTotalsRepository(dbConn, moneyFlowRepo)
+	}
+
+	// Cache hot reads in Redis when configured; writes invalidate them after commit
+	var redisClient *cache.Redis
+	if cfg.Cache.RedisURL != "" {
+		redisClient, err = cache.NewRedis(cache.RedisConfig{
+			URL:      cfg.Cache.RedisURL,
+			PoolSize: cfg.Cache.PoolSize,
+			Timeout:  time.Duration(cfg.Cache.Timeout) * time.Millisecond,
+		})
+		if err != nil {
+			fatal(appLogger, "Failed to configure Redis", err)
+		}
+		// Requests fall back to the database while Redis is unreachable
+		if err := redisClient.Ping(context.Background()); err != nil {
+			appLogger.Warn("Redis is unreachable, reads are not cached until it is back", "error", err)
+		}
+
+		cacheTTL := time.Duration(cfg.Cache.TTL) * time.Second
+		moneyFlowRepo = cache.NewMoneyFlowRepository(moneyFlowRepo, redisClient, cacheTTL)
+		userSettingsRepo = cache.NewUserSettingsRepository(userSettingsRepo, redisClient, cacheTTL)
+		userRepo = cache.NewUserRepository(userRepo, redisClient, cacheTTL)
+		appLogger.Info("Redis cache enabled", "ttl", cacheTTL)
+	}
 
 	// Initialize transaction manager
-	txManager := postgresql.NewTransactionManager(db)
+	txManager := postgresql.NewTransactionManagerFromDB(dbConn)
 
 	// Initialize security utilities
-	passwordHasher := security.NewPasswordHasher()
+	passwordHasher := security.NewPasswordHasher(cfg.PasswordHash())
 	jwtManager := security.NewJWTManager(
-		cfg.JWT.SecretKey,
+		cfg.JWT.SecretKeys,
 		time.Duration(cfg.JWT.AccessTokenDuration)*time.Minute,
 		time.Duration(cfg.JWT.RefreshTokenDuration)*24*time.Hour,
+		metricsRegistry,
 	)
 
+	// Self-test JWT key configuration (warnings do not block startup)
+	warnings, err := jwtManager.SelfTest()
+	for _, warning := range warnings {
+		appLogger.Warn(warning)
+	}
+	if err != nil {
+		fatal(appLogger, "JWT key self-test failed", err)
+	}
+	appLogger.Info("JWT signing key active", "kid", jwtManager.SigningKeyID(), "verification_keys", len(cfg.JWT.SecretKeys))
+
+	// Initialize the WhatsApp client used for outgoing replies
+	whatsappClient := whatsapp.NewClient(whatsapp.Config{
+		PhoneNumberID: cfg.WhatsApp.PhoneNumberID,
+		AccessToken:   cfg.WhatsApp.AccessToken,
+		APIVersion:    cfg.WhatsApp.APIVersion,
+		BaseURL:       cfg.WhatsApp.BaseURL,
+		Timeout:       time.Duration(cfg.WhatsApp.Timeout) * time.Second,
+		MaxRetries:    cfg.WhatsApp.MaxRetries,
+	})
+	if !whatsappClient.Enabled() {
+		appLogger.Warn("WhatsApp is not configured; outgoing WhatsApp messages are disabled")
+	}
+	if cfg.WhatsApp.AppSecret == "" {
+		appLogger.Warn("WHATSAPP_APP_SECRET is not set; WhatsApp webhook signatures are not verified")
+	}
+
+	// Initialize the OpenAI client that interprets chat messages
+	openaiClient := openai.NewClient(openai.Config{
+		APIKey:      cfg.OpenAI.APIKey,
+		Model:       cfg.OpenAI.Model,
+		VisionModel: cfg.OpenAI.VisionModel,
+		BaseURL:     cfg.OpenAI.BaseURL,
+		Timeout:     time.Duration(cfg.OpenAI.Timeout) * time.Second,
+	})
+	if !openaiClient.Enabled() {
+		appLogger.Warn("OpenAI is not configured; expenses cannot be recorded from chat messages")
+	}
+
+	// Background jobs; features register their handlers on the runner before it starts
+	jobRunner := worker.NewRunner(jobQueue, worker.Config{
+		Concurrency:  cfg.Worker.Concurrency,
+		PollInterval: time.Duration(cfg.Worker.PollInterval) * time.Second,
+		JobTimeout:   time.Duration(cfg.Worker.JobTimeout) * time.Second,
+		Retention:    time.Duration(cfg.Worker.Retention) * time.Hour,
+	})
+
+	// Storage for exports and other files kept outside the database, one per data
+	// region; files that belong to no user are kept in the default region
+	storageRegions, err := storage.NewRegions(storage.Config{
+		Driver:        cfg.Storage.Driver,
+		LocalDir:      cfg.Storage.LocalDir,
+		DefaultRegion: cfg.Storage.DefaultRegion,
+		Regions:       cfg.Storage.Regions,
+	})
+	if err != nil {
+		fatal(appLogger, "Failed to initialize storage", err)
+	}
+	fileStorage, err := storageRegions.Get(cfg.Storage.DefaultRegion)
+	if err != nil {
+		fatal(appLogger, "Failed to initialize storage", err)
+	}
+
+	emailClient := email.NewClient(email.Config{
+		Host:     cfg.Email.SMTPHost,
+		Port:     cfg.Email.SMTPPort,
+		Username: cfg.Email.SMTPUsername,
+		Password: cfg.Email.SMTPPassword,
+		From:     cfg.Email.From,
+	})
+	telegramClient := telegram.NewClient(telegram.Config{
+		BotToken: cfg.Telegram.BotToken,
+		BaseURL:  cfg.Telegram.BaseURL,
+		Timeout:  time.Duration(cfg.Telegram.Timeout) * time.Second,
+	})
+	fcmClient, err := fcm.NewClient(fcm.Config{
+		CredentialsFile: cfg.FCM.CredentialsFile,
+		ProjectID:       cfg.FCM.ProjectID,
+		Timeout:         time.Duration(cfg.FCM.Timeout) * time.Second,
+	})
+	if err != nil {
+		fatal(appLogger, "Failed to initialize FCM client", err)
+	}
+
+	// Notification channels of alerts and broadcasts; in-app is always available
+	notificationSenders := []service.NotificationSender{
+		service.NewInAppSender(notificationRepo),
+	}
+	if whatsappClient.Enabled() && cfg.Notify.WhatsAppTemplate != "" {
+		notificationSenders = append(notificationSenders,
+			service.NewWhatsAppNotificationSender(whatsappClient, cfg.Notify.WhatsAppTemplate, cfg.Notify.WhatsAppLanguage))
+	}
+	if emailClient.Enabled() {
+		notificationSenders = append(notificationSenders,
+			service.NewEmailNotificationSender(emailClient, userAuthRepo, authProviderRepo))
+	}
+	if telegramClient.Enabled() {
+		notificationSenders = append(notificationSenders,
+			service.NewTelegramNotificationSender(telegramClient, userSettingsRepo))
+	}
+	if fcmClient.Enabled() {
+		notificationSenders = append(notificationSenders,
+			service.NewPushNotificationSender(fcmClient, deviceRepo))
+	}
+	notifier := service.NewNotifier(userRepo, userSettingsRepo, jobRunner, notificationSenders...)
+	jobRunner.Handle(service.JobDeliverNotification, notifier.HandleDeliveryJob)
+
+	// Domain events are written to the outbox with the change and delivered to the
+	// subscribers registered below as background jobs
+	eventDispatcher := events.NewDispatcher(postgresql.NewOutboxStore(dbConn), jobRunner, txManager, events.Config{
+		PollInterval: time.Duration(cfg.Outbox.PollInterval) * time.Second,
+		Retention:    time.Duration(cfg.Outbox.Retention) * time.Hour,
+	})
+	jobRunner.Handle(events.JobDeliverEvent, eventDispatcher.HandleDeliveryJob)
+	eventDispatcher.Subscribe("welcome_notification", notifier.HandleUserRegistered, events.UserRegistered)
+	eventDispatcher.Subscribe("budget_alert", notifier.HandleBudgetExceeded, events.BudgetExceeded)
+
+	// Credentials besides email-password are linked once their provider proves them:
+	// Google accounts by an ID token, phone numbers by a code sent by WhatsApp
+	var phoneCodeSender service.WhatsAppTemplateSender
+	if whatsappClient.Enabled() && cfg.Phone.WhatsAppTemplate != "" {
+		phoneCodeSender = whatsappClient
+	}
+	phoneVerificationService := service.NewPhoneVerificationService(phoneVerificationRepo, userAuthRepo, authProviderRepo,
+		phoneCodeSender, service.PhoneVerificationConfig{
+			WhatsAppTemplate: cfg.Phone.WhatsAppTemplate,
+			WhatsAppLanguage: cfg.Phone.WhatsAppLanguage,
+			TTL:              time.Duration(cfg.Phone.TTL) * time.Minute,
+			MaxAttempts:      cfg.Phone.MaxAttempts,
+			MaxPerHour:       cfg.Phone.MaxPerHour,
+		})
+	var credentialVerifiers []service.CredentialVerifier
+	if phoneVerificationService.Enabled() {
+		credentialVerifiers = append(credentialVerifiers, phoneVerificationService)
+	}
+	if cfg.Google.ClientID != "" {
+		idTokens := google.NewVerifier(google.Config{
+			CertsURL: cfg.Google.CertsURL,
+			Timeout:  time.Duration(cfg.Google.Timeout) * time.Second,
+		})
+		credentialVerifiers = append(credentialVerifiers, service.NewGoogleCredentialVerifier(idTokens, cfg.Google.ClientID))
+	}
+
 	// Initialize services
+	auditor := service.NewAuditor(auditLogRepo)
 	authService := service.NewAuthService(
 		userRepo,
 		userAuthRepo,
 		authProviderRepo,
+		refreshTokenRepo,
 		passwordHasher,
 		jwtManager,
 		txManager,
+		notifier,
+		eventDispatcher,
+		auditor,
+		service.LockoutConfig{
+			MaxAttempts: cfg.Lockout.MaxAttempts,
+			Duration:    time.Duration(cfg.Lockout.Duration) * time.Minute,
+		},
+		credentialVerifiers...,
+	)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo)
+	deviceService := service.NewDeviceService(deviceRepo)
+	dataResidencyService := service.NewDataResidencyService(userRepo, storageRegions, jobRunner)
+	jobRunner.Handle(service.JobRelocateUserFiles, dataResidencyService.HandleRelocationJob)
+	userService := service.NewUserService(
+		userRepo,
+		userAuthRepo,
+		authProviderRepo,
+		moneyFlowRepo,
+		refreshTokenRepo,
+		apiKeyRepo,
+		userSettingsRepo,
+		txManager,
+		dataResidencyService,
+	)
+
+	// Push changes to the connected clients of a user once they commit
+	realtimeBus := realtime.NewBus(realtime.Config{MaxSubscriptionsPerUser: cfg.Realtime.MaxStreamsPerUser})
+	auditLogService := service.NewAuditLogService(auditLogRepo)
+	adminService := service.NewAdminService(userRepo, refreshTokenRepo, systemStatsRepo, auditor, txManager)
+	moneyFlowService := service.NewMoneyFlowService(moneyFlowRepo, moneyFlowNoteRepo, userSettingsRepo, budgetRepo, walletRepo, groupRepo, splitRepo, merchantRepo, auditor, realtimeBus, eventDispatcher, txManager)
+	budgetService := service.NewBudgetService(budgetRepo, moneyFlowRepo, userSettingsRepo, auditor, txManager)
+	walletService := service.NewWalletService(walletRepo, moneyFlowRepo, userSettingsRepo, auditor, txManager)
+	goalService := service.NewGoalService(goalRepo, userSettingsRepo, auditor, txManager)
+	groupService := service.NewGroupService(groupRepo, groupInvitationRepo, auditor, txManager)
+	splitService := service.NewSplitService(splitRepo, moneyFlowRepo, groupRepo, txManager)
+	tagService := service.NewTagService(moneyFlowRepo, txManager)
+	moneyFlowExportService := service.NewMoneyFlowExportService(moneyFlowRepo, userRepo,
+		service.CSVExporter{}, service.XLSXExporter{}, service.PDFExporter{})
+	notificationService := service.NewNotificationService(notificationRepo)
+	exchangeRateService := service.NewExchangeRateService(exchangeRateRepo,
+		exchangerate.NewECBClient(exchangerate.ECBConfig{URL: cfg.Rates.URL}),
+		service.ExchangeRateConfig{
+			Enabled:         cfg.Rates.Enabled,
+			RefreshInterval: time.Duration(cfg.Rates.RefreshInterval) * time.Minute,
+		},
+	)
+	reportService := service.NewReportService(moneyFlowRepo, userSettingsRepo, groupRepo,
+		spendingRepo, merchantRepo, exchangeRateService)
+
+	var analyticsSink service.AnalyticsSink = service.NewDatabaseAnalyticsSink(analyticsEventRepo)
+	if cfg.Analytics.Sink == "log" {
+		analyticsSink = service.LogAnalyticsSink{}
+	}
+	analyticsService := service.NewAnalyticsService(analyticsSink, userSettingsRepo, service.AnalyticsConfig{
+		Enabled:    cfg.Analytics.Enabled,
+		Salt:       cfg.Analytics.Salt,
+		SampleRate: cfg.Analytics.SampleRate,
+	})
+	eventDispatcher.Subscribe("analytics", analyticsService.HandleEvent, events.UserRegistered, events.MoneyFlowCreated)
+
+	if cfg.Hooks.AllowPrivateNetworks {
+		appLogger.Warn("OUTGOING_WEBHOOK_ALLOW_PRIVATE_NETWORKS is set; webhooks can reach this server's network")
+	}
+	webhookClient := webhook.NewClient(webhook.Config{
+		Timeout:              time.Duration(cfg.Hooks.Timeout) * time.Second,
+		AllowPrivateNetworks: cfg.Hooks.AllowPrivateNetworks,
+	})
+	webhookService := service.NewWebhookService(postgresql.NewWebhookRepository(dbConn), jobRunner, webhookClient, txManager, service.WebhookConfig{
+		MaxAttempts:       cfg.Hooks.MaxAttempts,
+		MaxPerUser:        cfg.Hooks.MaxPerUser,
+		DeliveryRetention: time.Duration(cfg.Hooks.DeliveryRetention) * 24 * time.Hour,
+	})
+	jobRunner.Handle(service.JobDeliverWebhook, webhookService.HandleDeliveryJob)
+	eventDispatcher.Subscribe("webhooks", webhookService.HandleEvent, events.MoneyFlowCreated, events.MoneyFlowUpdated, events.MoneyFlowDeleted)
+
+	digestService := service.NewDigestService(userRepo, userSettingsRepo, postgresql.NewDigestRepository(dbConn),
+		reportService, budgetService, notifier, jobRunner, txManager, service.DigestConfig{
+			Interval:  time.Duration(cfg.Digest.Interval) * time.Minute,
+			Hour:      cfg.Digest.Hour,
+			BatchSize: cfg.Digest.BatchSize,
+		})
+	jobRunner.Handle(service.JobSendDigest, digestService.HandleSendJob)
+
+	statementService := service.NewStatementService(userSettingsRepo, postgresql.NewStatementRepository(dbConn),
+		userAuthRepo, authProviderRepo, moneyFlowExportService, emailClient, jobRunner, txManager, service.StatementConfig{
+			Interval:  time.Duration(cfg.Statement.Interval) * time.Minute,
+			Hour:      cfg.Statement.Hour,
+			BatchSize: cfg.Statement.BatchSize,
+		})
+	jobRunner.Handle(service.JobSendStatement, statementService.HandleSendJob)
+
+	demoService := service.NewDemoService(userRepo, moneyFlowRepo, jwtManager, txManager, service.DemoConfig{
+		Enabled:         cfg.Demo.Enabled,
+		TTL:             time.Duration(cfg.Demo.TTL) * time.Minute,
+		CleanupInterval: time.Duration(cfg.Demo.CleanupInterval) * time.Minute,
+	})
+	apiUsageService := service.NewAPIUsageService(apiUsageRepo, service.APIUsageConfig{
+		Enabled:       cfg.APIUsage.Enabled,
+		FlushInterval: time.Duration(cfg.APIUsage.FlushInterval) * time.Second,
+	})
+
+	broadcastService := service.NewBroadcastService(broadcastRepo, userRepo, txManager, notificationSenders...)
+	broadcastDispatcher := service.NewBroadcastDispatcher(broadcastRepo, userRepo, userSettingsRepo, service.BroadcastDispatcherConfig{
+		RatePerSecond: cfg.Broadcast.RatePerSecond,
+		BatchSize:     cfg.Broadcast.BatchSize,
+		MaxAttempts:   cfg.Broadcast.MaxAttempts,
+	}, notificationSenders...)
+
+	// Invitations of imported users, sent by WhatsApp template or email when configured
+	var invitationSenders []service.InvitationSender
+	if whatsappClient.Enabled() && cfg.Invite.WhatsAppTemplate != "" {
+		invitationSenders = append(invitationSenders,
+			service.NewWhatsAppInvitationSender(whatsappClient, cfg.Invite.WhatsAppTemplate, cfg.Invite.WhatsAppLanguage))
+	}
+	if emailClient.Enabled() {
+		invitationSenders = append(invitationSenders, service.NewEmailInvitationSender(emailClient))
+	}
+	invitationService := service.NewInvitationService(userRepo, userAuthRepo, authProviderRepo, invitationRepo,
+		passwordHasher, authService, txManager, jobRunner, service.InvitationConfig{
+			URL: cfg.Invite.URL,
+			TTL: time.Duration(cfg.Invite.TTL) * time.Hour,
+		}, invitationSenders...)
+	jobRunner.Handle(service.JobSendInvitation, invitationService.HandleSendJob)
+
+	// Password resets are emailed, so they are refused when email is not configured
+	var resetEmail service.EmailSender
+	if emailClient.Enabled() {
+		resetEmail = emailClient
+	}
+	passwordResetService := service.NewPasswordResetService(userAuthRepo, authProviderRepo, passwordResetRepo,
+		passwordHasher, authService, auditor, notifier, txManager, jobRunner, resetEmail, service.PasswordResetConfig{
+			URL:        cfg.Reset.URL,
+			TTL:        time.Duration(cfg.Reset.TTL) * time.Minute,
+			MaxPerHour: cfg.Reset.MaxPerHour,
+		})
+	jobRunner.Handle(service.JobSendPasswordReset, passwordResetService.HandleSendJob)
+
+	// Feedback is posted to Slack when a webhook is configured
+	var feedbackForwarder service.FeedbackForwarder
+	if slackWebhook := slack.NewWebhook(slack.Config{WebhookURL: cfg.Feedback.SlackWebhookURL}); slackWebhook.Enabled() {
+		feedbackForwarder = slackWebhook
+	}
+	feedbackService := service.NewFeedbackService(feedbackRepo, txManager, jobRunner, feedbackForwarder)
+	jobRunner.Handle(service.JobForwardFeedback, feedbackService.HandleForwardJob)
+
+	analyticsExportService := service.NewAnalyticsExportService(moneyFlowRepo, fileStorage, jobRunner, service.AnalyticsExportConfig{
+		Enabled:  cfg.Export.Enabled,
+		Interval: time.Duration(cfg.Export.Interval) * time.Hour,
+	})
+	jobRunner.Handle(service.JobExportMoneyFlows, analyticsExportService.HandleExportJob)
+
+	accountExportService := service.NewAccountExportService(userRepo, userAuthRepo, authProviderRepo, userSettingsRepo,
+		moneyFlowRepo, budgetRepo, walletRepo, dataResidencyService, notifier, jobRunner, service.AccountExportConfig{
+			URL:        cfg.Account.URL,
+			TTL:        time.Duration(cfg.Account.TTL) * time.Hour,
+			SigningKey: cfg.Account.SigningKey,
+		})
+	jobRunner.Handle(service.JobExportAccount, accountExportService.HandleExportJob)
+	jobRunner.Handle(service.JobDeleteAccountExport, accountExportService.HandleDeleteJob)
+
+	accountErasureService := service.NewAccountErasureService(accountErasureRepo, userRepo, userAuthRepo, userSettingsRepo,
+		deviceRepo, refreshTokenRepo, apiKeyRepo, dataResidencyService, auditor, notifier, txManager, jobRunner,
+		service.AccountErasureConfig{GracePeriod: time.Duration(cfg.Erasure.GracePeriod) * 24 * time.Hour})
+	jobRunner.Handle(service.JobEraseAccount, accountErasureService.HandleEraseJob)
+
+	var (
+		chatMetrics         *service.ChatMetrics
+		tokenCleanupMetrics *service.TokenCleanupMetrics
+		metricsHandler      http.Handler
+	)
+	if metricsRegistry != nil {
+		chatMetrics = service.NewChatMetrics(metricsRegistry)
+		tokenCleanupMetrics = service.NewTokenCleanupMetrics(metricsRegistry)
+		metricsHandler = metricsRegistry.Handler()
+		if cfg.Metrics.Token == "" {
+			appLogger.Warn("METRICS_TOKEN is not set; /metrics is readable without authentication")
+		}
+	}
+
+	chatService := service.NewChatService(
+		userRepo,
+		conversationRepo,
+		userSettingsRepo,
+		moneyFlowService,
+		budgetService,
+		feedbackService,
+		service.NewAIExpenseParser(openaiClient, service.NewCurrencyDetector(cfg.Chat.CurrencyAliases)),
+		whatsappClient,
+		jobRunner,
+		txManager,
+		chatMetrics,
+		service.ChatConfig{
+			ConfirmationTTL: time.Duration(cfg.Chat.ConfirmationTTL) * time.Minute,
+		},
 	)
+	jobRunner.Handle(service.JobChatMessage, chatService.HandleMessageJob)
+	jobRunner.Handle(service.JobChatConversationExpiry, chatService.ExpireConversationJob)
 
-	// Ensure email-password auth provider exists
+	tokenCleanupService := service.NewTokenCleanupService(refreshTokenRepo, invitationRepo, passwordResetRepo, moneyFlowShareRepo, phoneVerificationRepo, tokenCleanupMetrics, service.TokenCleanupConfig{
+		Interval:  time.Duration(cfg.Cleanup.Interval) * time.Minute,
+		BatchSize: cfg.Cleanup.BatchSize,
+		Retention: time.Duration(cfg.Cleanup.Retention) * time.Hour,
+	})
+
+	// Ensure default auth providers exist
 	ctx := context.Background()
-	if err := authService.EnsureEmailPasswordProvider(ctx); err != nil {
-		log.Fatalf("Failed to ensure email-password auth provider: %v", err)
+	if err := authService.EnsureAuthProviders(ctx); err != nil {
+		fatal(appLogger, "Failed to ensure auth providers", err)
+	}
+	appLogger.Info("Authentication providers initialized")
+
+	// Load the read-only switch before serving; until it loads, only READ_ONLY applies
+	readOnlyService := service.NewReadOnlyService(systemSettingRepo, service.ReadOnlyConfig{
+		Forced:          cfg.ReadOnly.Forced,
+		RefreshInterval: time.Duration(cfg.ReadOnly.RefreshInterval) * time.Second,
+	})
+	if err := readOnlyService.Refresh(ctx); err != nil {
+		appLogger.Warn("Failed to load read-only mode", "error", err)
+	}
+	if readOnlyService.ReadOnly() {
+		appLogger.Warn("API is in read-only mode; writes are rejected", "forced", cfg.ReadOnly.Forced)
 	}
-	log.Println("Email-password authentication provider initialized")
+
+	// SIGHUP reloads LOG_LEVEL and READ_ONLY; other settings need a restart
+	configs := config.NewReloader(cfg)
+	configs.OnReload(func(reloaded *config.Config) {
+		if level, err := logger.ParseLevel(reloaded.Log.Level); err == nil && level != logLevel.Base() {
+			logLevel.SetBase(level)
+		}
+		if err := readOnlyService.SetForced(ctx, reloaded.ReadOnly.Forced); err != nil {
+			appLogger.Warn("Failed to apply READ_ONLY", "error", err)
+		}
+	})
+
+	// Readiness checks: only the database is critical. Requests fall back to the database
+	// without Redis, and external services are only needed by some features.
+	latestMigration, err := postgresql.LatestMigrationVersion(migrations)
+	if err != nil {
+		fatal(appLogger, "Failed to read migrations", err)
+	}
+	healthChecks := []service.HealthCheck{
+		{Name: "database", Critical: true, Check: func(ctx context.Context) error {
+			return postgresql.Ping(ctx, db)
+		}},
+		{Name: "migrations", Critical: true, Check: func(ctx context.Context) error {
+			return postgresql.CheckMigrations(ctx, db, latestMigration)
+		}},
+	}
+	for i, replica := range replicas {
+		// Reads marked for replicas fail while theirs is down, but every other request works
+		healthChecks = append(healthChecks, service.HealthCheck{Name: fmt.Sprintf("database_replica_%d", i+1), Check: func(ctx context.Context) error {
+			return postgresql.Ping(ctx, replica)
+		}})
+	}
+	if redisClient != nil {
+		healthChecks = append(healthChecks, service.HealthCheck{Name: "redis", Check: redisClient.Ping})
+	}
+	externalInterval := time.Duration(cfg.Health.ExternalInterval) * time.Second
+	for _, external := range []struct {
+		name    string
+		enabled bool
+		check   func(context.Context) error
+	}{
+		{"openai", openaiClient.Enabled(), openaiClient.Ping},
+		{"whatsapp", whatsappClient.Enabled(), whatsappClient.Ping},
+		{"email", emailClient.Enabled(), emailClient.Ping},
+		{"telegram", telegramClient.Enabled(), telegramClient.Ping},
+		{"fcm", fcmClient.Enabled(), fcmClient.Ping},
+	} {
+		if external.enabled {
+			healthChecks = append(healthChecks, service.HealthCheck{Name: external.name, Interval: externalInterval, Check: external.check})
+		}
+	}
+	healthService := service.NewHealthService(time.Duration(cfg.Health.Timeout)*time.Second, healthChecks...)
 
 	// Initialize HTTP handlers
+	healthHandler := v1.NewHealthHandler(healthService)
 	authHandler := v1.NewAuthHandler(authService)
+	userHandler := v1.NewUserHandler(authService, userService, phoneVerificationService)
+	apiKeyHandler := v1.NewAPIKeyHandler(apiKeyService)
+	webhookHandler := v1.NewWebhookHandler(webhookService)
+	deviceHandler := v1.NewDeviceHandler(deviceService)
+	moneyFlowHandler := v1.NewMoneyFlowHandler(moneyFlowService)
+	moneyFlowShareHandler := v1.NewMoneyFlowShareHandler(service.NewMoneyFlowShareService(moneyFlowService, moneyFlowShareRepo, userRepo, userSettingsRepo,
+		service.MoneyFlowShareConfig{
+			URL:        cfg.Share.URL,
+			TTL:        time.Duration(cfg.Share.TTL) * time.Hour,
+			SigningKey: cfg.Share.SigningKey,
+		}))
+	budgetHandler := v1.NewBudgetHandler(budgetService)
+	walletHandler := v1.NewWalletHandler(walletService)
+	goalHandler := v1.NewGoalHandler(goalService)
+	groupHandler := v1.NewGroupHandler(groupService)
+	splitHandler := v1.NewSplitHandler(splitService)
+	tagHandler := v1.NewTagHandler(tagService)
+	logLevelHandler := v1.NewLogLevelHandler(logLevel)
+	configHandler := v1.NewConfigHandler(configs)
+	notificationHandler := v1.NewNotificationHandler(notificationService)
+	eventHandler := v1.NewEventHandler(realtimeBus, time.Duration(cfg.Realtime.Heartbeat)*time.Second)
+	auditLogHandler := v1.NewAuditLogHandler(auditLogService)
+	adminHandler := v1.NewAdminHandler(adminService)
+	broadcastHandler := v1.NewBroadcastHandler(broadcastService)
+	merchantHandler := v1.NewMerchantHandler(service.NewMerchantService(merchantRepo))
+	apiUsageHandler := v1.NewAPIUsageHandler(apiUsageService)
+	demoHandler := v1.NewDemoHandler(demoService)
+	analyticsExportHandler := v1.NewAnalyticsExportHandler(analyticsExportService)
+	moneyFlowExportHandler := v1.NewMoneyFlowExportHandler(moneyFlowExportService)
+	accountExportHandler := v1.NewAccountExportHandler(accountExportService)
+	accountErasureHandler := v1.NewAccountErasureHandler(accountErasureService)
+	userAuthHandler := v1.NewUserAuthHandler(authService)
+	sessionHandler := v1.NewSessionHandler(authService)
+	invitationHandler := v1.NewInvitationHandler(invitationService)
+	passwordResetHandler := v1.NewPasswordResetHandler(passwordResetService)
+	feedbackHandler := v1.NewFeedbackHandler(feedbackService)
+	readOnlyHandler := v1.NewReadOnlyHandler(readOnlyService)
+	receiptHandler := v1.NewReceiptHandler(service.NewReceiptService(openaiClient, userSettingsRepo))
+	categorySuggestionHandler := v1.NewCategorySuggestionHandler(service.NewCategorySuggestionService(moneyFlowRepo, openaiClient))
+	reportHandler := v1.NewReportHandler(reportService, exchangeRateService)
+	digestHandler := v1.NewDigestHandler(digestService)
+	statementHandler := v1.NewStatementHandler(statementService)
+	dashboardHandler := v1.NewDashboardHandler(service.NewDashboardService(reportService, budgetService, moneyFlowService, goalService))
+	whatsappHandler := v1.NewWhatsAppWebhookHandler(chatService, cfg.Webhook.VerifyToken, cfg.WhatsApp.AppSecret)
+
+	requestTimeouts := make(map[string]time.Duration, len(cfg.Server.RequestTimeouts))
+	for path, seconds := range cfg.Server.RequestTimeouts {
+		requestTimeouts[path] = time.Duration(seconds) * time.Second
+	}
 
 	// Setup router
 	router := httpController.SetupRouter(&httpController.RouterConfig{
-		AuthHandler: authHandler,
+		AuthHandler:         authHandler,
+		UserHandler:         userHandler,
+		APIKeyHandler:       apiKeyHandler,
+		WebhookHandler:      webhookHandler,
+		DeviceHandler:       deviceHandler,
+		MoneyFlowHandler:    moneyFlowHandler,
+		MoneyFlowShare:      moneyFlowShareHandler,
+		BudgetHandler:       budgetHandler,
+		WalletHandler:       walletHandler,
+		GoalHandler:         goalHandler,
+		GroupHandler:        groupHandler,
+		SplitHandler:        splitHandler,
+		TagHandler:          tagHandler,
+		NotificationHandler: notificationHandler,
+		EventHandler:        eventHandler,
+		AuditLogHandler:     auditLogHandler,
+		AdminHandler:        adminHandler,
+		HealthHandler:       healthHandler,
+		ErrorCatalog:        v1.NewErrorCatalogHandler(),
+		Currencies:          v1.NewCurrencyHandler(),
+		BroadcastHandler:    broadcastHandler,
+		MerchantHandler:     merchantHandler,
+		InvitationHandler:   invitationHandler,
+		PasswordReset:       passwordResetHandler,
+		SessionHandler:      sessionHandler,
+		FeedbackHandler:     feedbackHandler,
+		APIUsageHandler:     apiUsageHandler,
+		DemoHandler:         demoHandler,
+		LogLevelHandler:     logLevelHandler,
+		ConfigHandler:       configHandler,
+		WhatsAppHandler:     whatsappHandler,
+		ExportHandler:       analyticsExportHandler,
+		MoneyFlowExport:     moneyFlowExportHandler,
+		AccountExport:       accountExportHandler,
+		AccountErasure:      accountErasureHandler,
+		UserAuthHandler:     userAuthHandler,
+		ReadOnlyHandler:     readOnlyHandler,
+		ReceiptHandler:      receiptHandler,
+		CategorySuggestion:  categorySuggestionHandler,
+		ReportHandler:       reportHandler,
+		DigestHandler:       digestHandler,
+		StatementHandler:    statementHandler,
+		DashboardHandler:    dashboardHandler,
+		JWTManager:          jwtManager,
+		APIKeyAuth:          apiKeyService,
+		TokenVersions:       userService,
+		RoleResolver:        userService,
+		UserLocales:         userService,
+		Analytics:           analyticsService,
+		APIUsage:            apiUsageService,
+		ReadOnly:            readOnlyService,
+		ErrorReporter:       errorReporter,
+		Logger:              appLogger,
+		Metrics:             metricsHandler,
+		MetricsToken:        cfg.Metrics.Token,
+		ServiceName:         cfg.Tracing.ServiceName,
+		CORS: middleware.CORSConfig{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		},
+		SecurityHeaders: middleware.SecurityHeadersConfig{
+			HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+			FrameOptions:          cfg.Security.FrameOptions,
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+		},
+		MaxBodySize:   int64(cfg.Security.MaxBodySize) * 1024,
+		MaxUploadSize: int64(cfg.Security.MaxUploadSize) * 1024,
+
+		RequestTimeout:  time.Duration(cfg.Server.RequestTimeout) * time.Second,
+		RequestTimeouts: requestTimeouts,
+
+		ReauthMaxAge:   time.Duration(cfg.JWT.ReauthMaxAge) * time.Minute,
+		AdminAudiences: cfg.JWT.AdminAudiences,
+
+		LogBodies: cfg.Log.BodiesEnabled,
+
+		QueryBudget:       queryBudget,
+		QueryBudgetStrict: cfg.Database.QueryBudgetStrict,
 	})
 
 	// Start HTTP server
 	serverAddr := fmt.Sprintf(":%s", cfg.Server.Port)
-	log.Printf("Starting HTTP server on %s...", serverAddr)
-	log.Println("Phase 1: Project Setup & Database Foundation - COMPLETED")
-	log.Println("Authentication endpoints available:")
-	log.Println("  POST /api/v1/authentications/register")
-	log.Println("  POST /api/v1/authentications/login")
-	log.Println("  GET  /health")
+	server := &http.Server{
+		Addr:              serverAddr,
+		Handler:           router,
+		ReadHeaderTimeout: time.Duration(cfg.Server.ReadHeaderTimeout) * time.Second,
+	}
+	// Event streams never finish on their own, so end them when shutdown starts
+	server.RegisterOnShutdown(realtimeBus.Close)
+
+	for _, route := range router.Routes() {
+		appLogger.Debug("Route registered", "method", route.Method, "path", route.Path)
+	}
+
+	// Start background workers; they stop when the server shuts down
+	workers := worker.NewGroup(logger.WithContext(context.Background(), appLogger))
+	workers.Go("job_runner", jobRunner.Run)
+	workers.Go("event_dispatcher", eventDispatcher.Run)
+	workers.Go("webhooks", webhookService.Run)
+	workers.Go("digests", digestService.Run)
+	workers.Go("broadcast_dispatcher", broadcastDispatcher.Run)
+	workers.Go("analytics", analyticsService.Run)
+	workers.Go("api_usage", apiUsageService.Run)
+	workers.Go("demo_cleanup", demoService.RunCleanup)
+	workers.Go("token_cleanup", tokenCleanupService.Run)
+	workers.Go("analytics_export", analyticsExportService.Run)
+	workers.Go("read_only", readOnlyService.Run)
+	workers.Go("exchange_rates", exchangeRateService.Run)
+	if emailClient.Enabled() {
+		workers.Go("statements", statementService.Run)
+	}
+	if sentryClient.Enabled() {
+		workers.Go("sentry", sentryClient.Run)
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		appLogger.Info("Starting HTTP server", "addr", serverAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			changed, restart, err := configs.Reload()
+			if err != nil {
+				appLogger.Error("Failed to reload configuration; keeping the current one", "error", err)
+				continue
+			}
+			appLogger.Warn("Configuration reloaded", "changed", changed, "restart_required", restart)
+		}
+	}()
 
-	if err := router.Run(serverAddr); err != nil {
-		log.Fatalf("Failed to start HTTP server: %v", err)
+	// Wait for an interrupt or termination signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-serverErr:
+		appLogger.Error("HTTP server error", "error", err)
+	case sig := <-quit:
+		appLogger.Info("Shutting down", "signal", sig.String())
+	}
+
+	// Drain in-flight requests before closing the database pool
+	shutdownTimeout := time.Duration(cfg.Server.ShutdownTimeout) * time.Second
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		appLogger.Warn("HTTP server did not shut down cleanly", "timeout", shutdownTimeout, "error", err)
+	} else {
+		appLogger.Info("HTTP server stopped")
 	}
+
+	workers.Stop()
+	appLogger.Info("Background workers stopped")
+
+	if err := postgresql.Close(db); err != nil {
+		appLogger.Warn("Failed to close database connection", "error", err)
+	} else {
+		appLogger.Info("Database connection closed")
+	}
+
+	for _, replica := range replicas {
+		if err := postgresql.Close(replica); err != nil {
+			appLogger.Warn("Failed to close read replica connection", "error", err)
+		}
+	}
+
+	if sqlcPool != nil {
+		sqlcPool.Close()
+	}
+
+	if redisClient != nil {
+		redisClient.Close()
+	}
+
+	// Flush spans recorded during shutdown last
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		appLogger.Warn("Failed to flush traces", "error", err)
+	}
+}
+
+// fatal logs an unrecoverable startup error and exits
+func fatal(log *slog.Logger, msg string, err error) {
+	log.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/config"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+func main() {
+	// Define subcommands
+	exportCmd := flag.NewFlagSet("export", flag.ExitOnError)
+	replayCmd := flag.NewFlagSet("replay", flag.ExitOnError)
+
+	// Flags for export command
+	exportSince := exportCmd.String("since", "", "First day to export, YYYY-MM-DD (default: 7 days ago)")
+	exportUntil := exportCmd.String("until", "", "Day after the last day to export, YYYY-MM-DD (default: tomorrow, so today is included)")
+	exportOut := exportCmd.String("out", "events.jsonl", "File to write the event log to")
+	exportSalt := exportCmd.String("salt", "", "Salt for pseudonymizing users (default: random, so exports are unlinkable)")
+	exportCategories := exportCmd.Bool("categories", false, "Keep category names (user-entered text)")
+
+	// Flags for replay command
+	replayIn := replayCmd.String("in", "events.jsonl", "Event log to replay")
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch os.Args[1] {
+	case "export":
+		exportCmd.Parse(os.Args[2:])
+
+		until := parseDay(*exportUntil, time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1))
+		since := parseDay(*exportSince, until.AddDate(0, 0, -7))
+		salt := *exportSalt
+		if salt == "" {
+			salt = randomSalt()
+		}
+
+		out, err := os.Create(*exportOut)
+		if err != nil {
+			log.Fatalf("Failed to create event log: %v", err)
+		}
+		defer out.Close()
+
+		eventLogService := newEventLogService(cfg)
+		count, err := eventLogService.Export(ctx, out, service.ExportOptions{
+			Since:          since,
+			Until:          until,
+			Salt:           salt,
+			KeepCategories: *exportCategories,
+		})
+		if err != nil {
+			log.Fatalf("Export failed after %d event(s): %v", count, err)
+		}
+		fmt.Printf("✅ Exported %d event(s) from %s to %s into %s\n",
+			count, since.Format(time.DateOnly), until.Format(time.DateOnly), *exportOut)
+
+	case "replay":
+		replayCmd.Parse(os.Args[2:])
+
+		// Replaying creates users and money flows; never point it at production
+		if cfg.Server.Env == "production" {
+			log.Fatal("Refusing to replay events into a production environment")
+		}
+
+		in, err := os.Open(*replayIn)
+		if err != nil {
+			log.Fatalf("Failed to open event log: %v", err)
+		}
+		defer in.Close()
+
+		eventLogService := newEventLogService(cfg)
+		result, err := eventLogService.Replay(ctx, in)
+		if err != nil {
+			log.Fatalf("Replay failed after %d event(s): %v", result.Events, err)
+		}
+		fmt.Printf("✅ Replayed %d event(s), created %d user(s)\n", result.Events, result.Users)
+
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+func newEventLogService(cfg *config.Config) *service.EventLogService {
+	// Use the production log level so every exported or replayed row is not logged
+	db, err := postgresql.NewConnection(cfg.GetDatabaseDSN(), "production", cfg.DatabasePool())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	dbConn := postgresql.NewDB(db)
+
+	return service.NewEventLogService(
+		postgresql.NewUserRepository(dbConn),
+		postgresql.NewMoneyFlowRepository(dbConn),
+	)
+}
+
+// parseDay parses a YYYY-MM-DD flag as a UTC day, or returns the fallback when empty
+func parseDay(value string, fallback time.Time) time.Time {
+	if value == "" {
+		return fallback
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		log.Fatalf("Invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return day
+}
+
+func randomSalt() string {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		log.Fatalf("Failed to generate salt: %v", err)
+	}
+	return hex.EncodeToString(salt)
+}
+
+func printUsage() {
+	fmt.Println("Event Log Tool")
+	fmt.Println()
+	fmt.Println("Exports a sanitized log of domain events and replays it into another environment,")
+	fmt.Println("to reproduce aggregation and notification bugs with realistic data volumes.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run cmd/eventlog/main.go <command> [options]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  export [-since DAY] [-until DAY] [-out FILE] [-salt SALT] [-categories]")
+	fmt.Println("                        Write events of the period as JSON lines (default: last 7 days)")
+	fmt.Println("  replay [-in FILE]     Apply an exported event log to the configured database")
+	fmt.Println()
+	fmt.Println("Sanitization:")
+	fmt.Println("  Users are replaced by a salted hash; names, phone numbers, emails, descriptions,")
+	fmt.Println("  notes and tags are never exported. Category names are only kept with -categories.")
+	fmt.Println("  Replay creates one placeholder user per hashed user and refuses to run in production.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/eventlog/main.go export -since 2026-09-01 -until 2026-10-01 -out september.jsonl")
+	fmt.Println("  go run cmd/eventlog/main.go replay -in september.jsonl")
+}
@@ -3,24 +3,50 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/ingunawandra/catetin/internal/config"
 	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
 )
 
+// sourceMigrationsPath is the migrations directory relative to the server-side root,
+// where create writes new migrations
+const sourceMigrationsPath = "internal/infrastructure/database/postgresql/migrations"
+
 func main() {
 	// Define subcommands
+	createCmd := flag.NewFlagSet("create", flag.ExitOnError)
 	upCmd := flag.NewFlagSet("up", flag.ExitOnError)
 	downCmd := flag.NewFlagSet("down", flag.ExitOnError)
+	gotoCmd := flag.NewFlagSet("goto", flag.ExitOnError)
 	versionCmd := flag.NewFlagSet("version", flag.ExitOnError)
+	statusCmd := flag.NewFlagSet("status", flag.ExitOnError)
 	forceCmd := flag.NewFlagSet("force", flag.ExitOnError)
 
+	// Database commands run the migrations embedded in the binary unless a directory is given
+	migrationsPaths := map[string]*string{}
+	for _, cmd := range []*flag.FlagSet{upCmd, downCmd, gotoCmd, versionCmd, statusCmd, forceCmd} {
+		migrationsPaths[cmd.Name()] = cmd.String("migrations-path", "", "Directory of the migration files (default: embedded)")
+	}
+	migrationsPaths["create"] = createCmd.String("migrations-path", sourceMigrationsPath, "Directory to create the migration files in")
+
+	// Every database command can be restricted to a single shard
+	upShard := upCmd.String("shard", "", "Only migrate the named shard")
+	downShard := downCmd.String("shard", "", "Only rollback the named shard")
+	gotoShard := gotoCmd.String("shard", "", "Only migrate the named shard")
+	versionShard := versionCmd.String("shard", "", "Only report the named shard")
+	statusShard := statusCmd.String("shard", "", "Only report the named shard")
+	forceShard := forceCmd.String("shard", "", "Shard to force (required when more than one shard is configured)")
+
 	// Flags for down command
 	downSteps := downCmd.Int("steps", 1, "Number of migrations to rollback")
 
+	// Flags for goto command
+	gotoVersion := gotoCmd.Int("version", -1, "Version to migrate up or down to")
+
 	// Flags for force command
 	forceVersion := forceCmd.Int("version", -1, "Version to force")
 
@@ -29,50 +55,85 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
-	}
-
-	// Convert DSN to URL
-	databaseURL, err := postgresql.ConvertDSNToURL(cfg.GetDatabaseDSN())
-	if err != nil {
-		log.Fatalf("Failed to convert DSN to URL: %v", err)
-	}
-
-	// Get migrations path
-	migrationsPath, err := filepath.Abs("internal/infrastructure/database/postgresql/migrations")
-	if err != nil {
-		log.Fatalf("Failed to get migrations path: %v", err)
-	}
-
 	// Parse subcommand
 	switch os.Args[1] {
+	case "create":
+		// create only writes files, so it needs neither configuration nor a database
+		createCmd.Parse(os.Args[2:])
+		if createCmd.NArg() != 1 {
+			log.Fatal("Please specify the migration name, e.g. create add_user_timezone")
+		}
+		up, down, err := postgresql.CreateMigration(*migrationsPaths["create"], createCmd.Arg(0), time.Now())
+		if err != nil {
+			log.Fatalf("Create migration failed: %v", err)
+		}
+		fmt.Printf("✅ Created %s\n", up)
+		fmt.Printf("✅ Created %s\n", down)
+
 	case "up":
 		upCmd.Parse(os.Args[2:])
-		if err := postgresql.RunMigrations(databaseURL, migrationsPath); err != nil {
+		migrator := newMigrator(*migrationsPaths["up"], *upShard)
+		if err := migrator.Up(); err != nil {
 			log.Fatalf("Migration failed: %v", err)
 		}
-		fmt.Println("✅ All migrations applied successfully")
+		fmt.Printf("✅ All migrations applied successfully to %d shard(s)\n", len(migrator.Shards()))
 
 	case "down":
 		downCmd.Parse(os.Args[2:])
-		if err := postgresql.RollbackMigration(databaseURL, migrationsPath, *downSteps); err != nil {
+		migrator := newMigrator(*migrationsPaths["down"], *downShard)
+		if err := migrator.Down(*downSteps); err != nil {
 			log.Fatalf("Rollback failed: %v", err)
 		}
-		fmt.Printf("✅ Successfully rolled back %d migration(s)\n", *downSteps)
+		fmt.Printf("✅ Successfully rolled back %d migration(s) on %d shard(s)\n", *downSteps, len(migrator.Shards()))
+
+	case "goto":
+		gotoCmd.Parse(os.Args[2:])
+		if *gotoVersion < 0 {
+			log.Fatal("Please specify a version using -version flag")
+		}
+		migrator := newMigrator(*migrationsPaths["goto"], *gotoShard)
+		if err := migrator.Goto(uint(*gotoVersion)); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		fmt.Printf("✅ Migrated %d shard(s) to version %d\n", len(migrator.Shards()), *gotoVersion)
 
 	case "version":
 		versionCmd.Parse(os.Args[2:])
-		version, dirty, err := postgresql.MigrationVersion(databaseURL, migrationsPath)
+		migrator := newMigrator(*migrationsPaths["version"], *versionShard)
+		failed := false
+		for _, v := range migrator.Versions() {
+			switch {
+			case v.Err != nil:
+				failed = true
+				fmt.Printf("❌ [%s] Failed to get version: %v\n", v.Shard, v.Err)
+			case v.Dirty:
+				fmt.Printf("⚠️  [%s] Current version: %d (DIRTY - needs manual intervention)\n", v.Shard, v.Version)
+			default:
+				fmt.Printf("✅ [%s] Current version: %d\n", v.Shard, v.Version)
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+
+	case "status":
+		statusCmd.Parse(os.Args[2:])
+		migrations, err := postgresql.ListMigrations(migrationFiles(*migrationsPaths["status"]))
 		if err != nil {
-			log.Fatalf("Failed to get version: %v", err)
+			log.Fatalf("Failed to list migrations: %v", err)
+		}
+		migrator := newMigrator(*migrationsPaths["status"], *statusShard)
+		failed := false
+		for _, v := range migrator.Versions() {
+			if v.Err != nil {
+				failed = true
+				fmt.Printf("❌ [%s] Failed to get version: %v\n", v.Shard, v.Err)
+				continue
+			}
+			printStatus(v, migrations)
 		}
-		if dirty {
-			fmt.Printf("⚠️  Current version: %d (DIRTY - needs manual intervention)\n", version)
-		} else {
-			fmt.Printf("✅ Current version: %d\n", version)
+		if failed {
+			os.Exit(1)
 		}
 
 	case "force":
@@ -80,7 +141,12 @@ func main() {
 		if *forceVersion < 0 {
 			log.Fatal("Please specify a version using -version flag")
 		}
-		if err := postgresql.ForceMigrationVersion(databaseURL, migrationsPath, *forceVersion); err != nil {
+		migrator := newMigrator(*migrationsPaths["force"], "")
+		if *forceShard == "" && len(migrator.Shards()) > 1 {
+			log.Fatal("Please specify a shard using -shard flag")
+		}
+		migrator = selectShard(migrator, *forceShard)
+		if err := migrator.Force(*forceVersion); err != nil {
 			log.Fatalf("Force version failed: %v", err)
 		}
 		fmt.Printf("✅ Forced version to %d\n", *forceVersion)
@@ -91,6 +157,74 @@ func main() {
 	}
 }
 
+// migrationFiles returns the migration files in migrationsPath, or the embedded ones
+// when it is empty
+func migrationFiles(migrationsPath string) fs.FS {
+	if migrationsPath == "" {
+		return postgresql.Migrations()
+	}
+	return os.DirFS(migrationsPath)
+}
+
+// newMigrator creates a migrator for the configured shards, restricted to the named
+// shard unless it is empty
+func newMigrator(migrationsPath, shard string) *postgresql.ShardMigrator {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Convert DSN to URL
+	databaseURL, err := postgresql.ConvertDSNToURL(cfg.GetDatabaseDSN())
+	if err != nil {
+		log.Fatalf("Failed to convert DSN to URL: %v", err)
+	}
+
+	// The primary database is always the first shard
+	shards := []postgresql.Shard{{Name: postgresql.PrimaryShardName, URL: databaseURL}}
+	for i, url := range cfg.Database.ShardURLs {
+		shards = append(shards, postgresql.Shard{Name: fmt.Sprintf("shard-%d", i+1), URL: url})
+	}
+	return selectShard(postgresql.NewShardMigrator(shards, migrationFiles(migrationsPath)), shard)
+}
+
+// selectShard restricts the migrator to the named shard, or returns it unchanged when name is empty
+func selectShard(migrator *postgresql.ShardMigrator, name string) *postgresql.ShardMigrator {
+	if name == "" {
+		return migrator
+	}
+	selected, err := migrator.Only(name)
+	if err != nil {
+		log.Fatalf("Failed to select shard: %v", err)
+	}
+	return selected
+}
+
+// printStatus lists the migrations of a shard as applied or pending. golang-migrate only
+// records the current version, so every migration up to it counts as applied.
+func printStatus(v postgresql.ShardVersion, migrations []postgresql.Migration) {
+	if v.Dirty {
+		fmt.Printf("⚠️  [%s] Current version: %d (DIRTY - needs manual intervention)\n", v.Shard, v.Version)
+	} else {
+		fmt.Printf("[%s] Current version: %d\n", v.Shard, v.Version)
+	}
+
+	pending := 0
+	for _, m := range migrations {
+		switch {
+		case m.Version == v.Version && v.Dirty:
+			fmt.Printf("  ⚠️  %06d_%s (dirty)\n", m.Version, m.Name)
+		case m.Version <= v.Version:
+			fmt.Printf("  ✅ %06d_%s\n", m.Version, m.Name)
+		default:
+			pending++
+			fmt.Printf("  ⏳ %06d_%s (pending)\n", m.Version, m.Name)
+		}
+	}
+	fmt.Printf("  %d applied, %d pending\n", len(migrations)-pending, pending)
+}
+
 func printUsage() {
 	fmt.Println("Database Migration Tool")
 	fmt.Println()
@@ -98,15 +232,34 @@ func printUsage() {
 	fmt.Println("  go run cmd/migrate/main.go <command> [options]")
 	fmt.Println()
 	fmt.Println("Commands:")
+	fmt.Println("  create NAME           Create empty up and down files for a new migration")
 	fmt.Println("  up                    Apply all pending migrations")
 	fmt.Println("  down [-steps N]       Rollback N migrations (default: 1)")
+	fmt.Println("  goto -version N       Migrate up or down to version N")
 	fmt.Println("  version               Show current migration version")
+	fmt.Println("  status                List applied and pending migrations")
 	fmt.Println("  force -version N      Force migration version (use with caution!)")
 	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -migrations-path DIR  Read migrations from DIR instead of those embedded in the")
+	fmt.Println("                        binary; for create, the directory to write to (default:")
+	fmt.Println("                        " + sourceMigrationsPath + ")")
+	fmt.Println("  Options go before the migration name of create.")
+	fmt.Println()
+	fmt.Println("Shards:")
+	fmt.Println("  Commands run against the primary database and every URL in DB_SHARD_URLS,")
+	fmt.Println("  in order, stopping at the first failure. Use -shard NAME (primary, shard-1, ...)")
+	fmt.Println("  to target a single shard.")
+	fmt.Println()
 	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/migrate/main.go create add_user_timezone")
 	fmt.Println("  go run cmd/migrate/main.go up")
 	fmt.Println("  go run cmd/migrate/main.go down")
 	fmt.Println("  go run cmd/migrate/main.go down -steps 2")
+	fmt.Println("  go run cmd/migrate/main.go goto -version 27")
 	fmt.Println("  go run cmd/migrate/main.go version")
+	fmt.Println("  go run cmd/migrate/main.go status")
+	fmt.Println("  go run cmd/migrate/main.go up -shard shard-1")
+	fmt.Println("  go run cmd/migrate/main.go up -migrations-path ./migrations")
 	fmt.Println("  go run cmd/migrate/main.go force -version 1")
 }
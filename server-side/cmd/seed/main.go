@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/ingunawandra/catetin/internal/config"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlite"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/service"
+	"gorm.io/gorm"
+)
+
+func main() {
+	demo := flag.Bool("demo", false, "Also create demo users with sample money flows and budgets")
+	demoUsers := flag.Int("demo-users", 3, "Number of demo users to create")
+	demoPassword := flag.String("demo-password", "catetin-demo", "Password of every demo user")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Demo users have a well-known password; never create them in production
+	if *demo && cfg.Server.Env == "production" {
+		log.Fatal("Refusing to seed demo users into a production environment")
+	}
+
+	// Read and write the encrypted columns like the API server
+	if len(cfg.Database.EncryptionKeys) > 0 {
+		keys, err := security.ParseLocalKeys(cfg.Database.EncryptionKeys)
+		if err != nil {
+			log.Fatalf("Invalid DB_ENCRYPTION_KEYS: %v", err)
+		}
+		fieldCipher, err := security.NewFieldCipher(keys...)
+		if err != nil {
+			log.Fatalf("Invalid DB_ENCRYPTION_KEYS: %v", err)
+		}
+		postgresql.SetFieldCipher(fieldCipher)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Use the production log level so every seeded row is not logged
+	var db *gorm.DB
+	if cfg.Database.Driver == config.DriverSQLite {
+		db, err = sqlite.NewConnection(cfg.Database.SQLitePath, "production")
+		if err == nil {
+			// Nothing else migrates a SQLite database before the API server starts
+			err = sqlite.RunMigrations(db, sqlite.Migrations())
+		}
+	} else {
+		db, err = postgresql.NewConnection(cfg.GetDatabaseDSN(), "production", cfg.DatabasePool())
+	}
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer postgresql.Close(db)
+	dbConn := postgresql.NewDB(db)
+
+	seedService := service.NewSeedService(
+		postgresql.NewUserRepository(dbConn),
+		postgresql.NewUserAuthRepository(dbConn),
+		postgresql.NewAuthProviderRepository(dbConn),
+		postgresql.NewMoneyFlowRepository(dbConn),
+		postgresql.NewBudgetRepository(dbConn),
+		security.NewPasswordHasher(cfg.PasswordHash()),
+		postgresql.NewTransactionManagerFromDB(dbConn),
+	)
+
+	result, err := seedService.Seed(ctx, service.SeedOptions{
+		Demo:         *demo,
+		DemoUsers:    *demoUsers,
+		DemoPassword: *demoPassword,
+	})
+	if err != nil {
+		log.Fatalf("Seeding failed: %v", err)
+	}
+
+	fmt.Printf("✅ Created %d auth provider(s)\n", result.AuthProviders)
+	if *demo {
+		fmt.Printf("✅ Created %d demo user(s) with %d money flow(s) and %d budget(s)\n", result.Users, result.MoneyFlows, result.Budgets)
+		if *demoUsers > 0 {
+			fmt.Printf("   Sign in as %s ... %s with password %q\n", service.SeedDemoEmail(1), service.SeedDemoEmail(*demoUsers), *demoPassword)
+		}
+	}
+}
+
+func printUsage() {
+	fmt.Println("Database Seeder")
+	fmt.Println()
+	fmt.Println("Creates the default auth providers and, with -demo, demo users with sample data.")
+	fmt.Println("Existing records are left as they are, so the seeder can be run repeatedly.")
+	fmt.Println("Run migrations first.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run cmd/seed/main.go [options]")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -demo                 Also create demo users (refused when ENV=production)")
+	fmt.Println("  -demo-users N         Number of demo users (default: 3)")
+	fmt.Println("  -demo-password PASS   Password of every demo user (default: catetin-demo)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/seed/main.go")
+	fmt.Println("  go run cmd/seed/main.go -demo")
+	fmt.Println("  go run cmd/seed/main.go -demo -demo-users 10")
+}
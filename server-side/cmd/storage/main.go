@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/config"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/storage"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+func main() {
+	// Define subcommands
+	relocateCmd := flag.NewFlagSet("relocate", flag.ExitOnError)
+
+	// Flags for relocate command
+	relocateUser := relocateCmd.String("user", "", "Only relocate the files of this user ID (default: every user)")
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch os.Args[1] {
+	case "regions":
+		regions := newRegions(cfg)
+		for _, region := range regions.Names() {
+			if region == regions.DefaultRegion() {
+				fmt.Printf("%s (default)\n", region)
+				continue
+			}
+			fmt.Println(region)
+		}
+
+	case "relocate":
+		relocateCmd.Parse(os.Args[2:])
+
+		dataResidencyService := newDataResidencyService(cfg)
+		if *relocateUser != "" {
+			userID, err := uuid.Parse(*relocateUser)
+			if err != nil {
+				log.Fatalf("Invalid user ID %q", *relocateUser)
+			}
+			moved, err := dataResidencyService.Relocate(ctx, userID)
+			if err != nil {
+				log.Fatalf("Relocation failed after %d file(s): %v", moved, err)
+			}
+			fmt.Printf("✅ Moved %d file(s) of user %s\n", moved, userID)
+			return
+		}
+
+		moved, err := dataResidencyService.RelocateAll(ctx)
+		if err != nil {
+			log.Fatalf("Relocation failed after %d file(s): %v", moved, err)
+		}
+		fmt.Printf("✅ Moved %d file(s) into their users' regions\n", moved)
+
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+func newRegions(cfg *config.Config) *storage.Regions {
+	regions, err := storage.NewRegions(storage.Config{
+		Driver:        cfg.Storage.Driver,
+		LocalDir:      cfg.Storage.LocalDir,
+		DefaultRegion: cfg.Storage.DefaultRegion,
+		Regions:       cfg.Storage.Regions,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+	return regions
+}
+
+func newDataResidencyService(cfg *config.Config) *service.DataResidencyService {
+	// Use the production log level so every user lookup is not logged
+	db, err := postgresql.NewConnection(cfg.GetDatabaseDSN(), "production", cfg.DatabasePool())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	dbConn := postgresql.NewDB(db)
+
+	// Files are moved directly, so no job queue is needed
+	return service.NewDataResidencyService(postgresql.NewUserRepository(dbConn), newRegions(cfg), nil)
+}
+
+func printUsage() {
+	fmt.Println("Storage Tool")
+	fmt.Println()
+	fmt.Println("Moves users' files, such as attachments and exports, into the data region they chose.")
+	fmt.Println("The API queues this whenever a user changes their region; run relocate after changing")
+	fmt.Println("STORAGE_DEFAULT_REGION or STORAGE_REGIONS, or when a relocation job was dead-lettered.")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run cmd/storage/main.go <command> [options]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  regions               List the configured data regions")
+	fmt.Println("  relocate [-user ID]   Move files stored outside their user's region (default: every user)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run cmd/storage/main.go regions")
+	fmt.Println("  go run cmd/storage/main.go relocate")
+	fmt.Println("  go run cmd/storage/main.go relocate -user 6f1c2d3e-0000-4000-8000-000000000000")
+}
@@ -1,94 +1,455 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
 	"github.com/joho/godotenv"
 )
 
+// Query engines of DB_QUERY_ENGINE
+const (
+	QueryEngineGORM = "gorm"
+	QueryEngineSQLC = "sqlc"
+)
+
+// Databases of DB_DRIVER
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// configFileEnv names the dotenv file settings are read from when they are not in the
+// environment
+const (
+	configFileEnv     = "CONFIG_FILE"
+	defaultConfigFile = ".env"
+)
+
 type Config struct {
 	Database  DatabaseConfig
+	Cache     CacheConfig
+	Health    HealthConfig
 	OpenAI    OpenAIConfig
 	WhatsApp  WhatsAppConfig
 	Server    ServerConfig
 	Webhook   WebhookConfig
+	Chat      ChatConfig
 	JWT       JWTConfig
+	Password  PasswordConfig
+	Lockout   LockoutConfig
+	Reset     PasswordResetConfig
+	Google    GoogleConfig
+	Phone     PhoneVerificationConfig
+	Broadcast BroadcastConfig
+	Analytics AnalyticsConfig
+	Tracing   TracingConfig
+	Sentry    SentryConfig
+	Metrics   MetricsConfig
+	ReadOnly  ReadOnlyConfig
+	Rates     ExchangeRateConfig
+	APIUsage  APIUsageConfig
+	Demo      DemoConfig
+	CORS      CORSConfig
+	Security  SecurityConfig
+	Worker    WorkerConfig
+	Log       LogConfig
+	Storage   StorageConfig
+	Export    ExportConfig
+	Account   AccountExportConfig
+	Erasure   AccountErasureConfig
+	Share     ShareConfig
+	Email     EmailConfig
+	Invite    InvitationConfig
+	Cleanup   TokenCleanupConfig
+	Notify    NotificationConfig
+	Telegram  TelegramConfig
+	FCM       FCMConfig
+	Feedback  FeedbackConfig
+	Realtime  RealtimeConfig
+	Outbox    OutboxConfig
+	Hooks     OutgoingWebhookConfig
+	Digest    DigestConfig
+	Statement StatementConfig
 }
 
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
+	// Driver stores data in PostgreSQL ("postgres") or in the SQLite file at SQLitePath
+	// ("sqlite"), for a single instance without a database server
+	Driver     string `env:"DB_DRIVER" default:"postgres" validate:"oneof=postgres sqlite"`
+	SQLitePath string `env:"DB_SQLITE_PATH" default:"catetin.db" validate:"required_if=Driver sqlite"`
+
+	Host     string `env:"DB_HOST" default:"localhost"`
+	Port     string `env:"DB_PORT" default:"5432"`
+	User     string `env:"DB_USER" default:"postgres"`
+	Password string `env:"DB_PASSWORD" validate:"required_if=Driver postgres" secret:"true"`
+	DBName   string `env:"DB_NAME" default:"catetin"`
+	SSLMode  string `env:"DB_SSLMODE" default:"disable"`
+
+	// ShardURLs lists additional database URLs that receive the same migrations
+	// as the primary database. Empty until tenant sharding is introduced.
+	ShardURLs []string `env:"DB_SHARD_URLS" secret:"true"`
+
+	// ReplicaURLs lists read replicas of the primary database. Reads that tolerate
+	// replication lag are spread over them; empty sends every query to the primary.
+	ReplicaURLs []string `env:"DB_REPLICA_URLS" secret:"true"`
+
+	// Connection pool of each database, primary and replicas alike
+	MaxIdleConns    int `env:"DB_MAX_IDLE_CONNS" default:"10" validate:"min=0,ltefield=MaxOpenConns"`
+	MaxOpenConns    int `env:"DB_MAX_OPEN_CONNS" default:"100" validate:"gt=0"`
+	ConnMaxLifetime int `env:"DB_CONN_MAX_LIFETIME" default:"60" validate:"gt=0"` // in minutes
+	ConnMaxIdleTime int `env:"DB_CONN_MAX_IDLE_TIME" validate:"min=0"`            // in minutes, 0 keeps idle connections until their lifetime ends
+
+	// QueryBudget is the maximum number of queries per request before it is
+	// flagged (0 disables the guard). Never enabled in production.
+	QueryBudget       int    `env:"DB_QUERY_BUDGET" validate:"min=0"`
+	QueryBudgetMode   string `env:"DB_QUERY_BUDGET_MODE" default:"log" validate:"oneof=log fail"`
+	QueryBudgetStrict bool   // fail requests exceeding the budget instead of logging
+
+	StatementTimeout   int `env:"DB_STATEMENT_TIMEOUT" default:"30" validate:"min=0"`     // in seconds, after which the server cancels a statement; 0 for none
+	SlowQueryThreshold int `env:"DB_SLOW_QUERY_THRESHOLD" default:"500" validate:"min=0"` // in milliseconds, queries taking longer are logged; 0 logs none
+
+	// QueryEngine runs the most frequent user, money flow, and refresh token reads
+	// with GORM ("gorm") or with the queries sqlc generates, on a pgx pool ("sqlc")
+	QueryEngine string `env:"DB_QUERY_ENGINE" default:"gorm" validate:"oneof=gorm sqlc"`
+
+	// ReportReadModel reads expense totals from the monthly_category_totals read model
+	// instead of aggregating every money flow
+	ReportReadModel bool `env:"DB_REPORT_READ_MODEL" default:"true"`
+
+	// EncryptionKeys encrypt sensitive columns at rest, as id=base64 pairs of 32-byte
+	// keys, newest first; the first key encrypts new values. Empty stores them as plaintext.
+	EncryptionKeys []string `env:"DB_ENCRYPTION_KEYS" secret:"true"`
+}
+
+type CacheConfig struct {
+	RedisURL string `env:"REDIS_URL" secret:"true"`                      // empty disables caching
+	PoolSize int    `env:"REDIS_POOL_SIZE" default:"10" validate:"gt=0"` // connections kept open
+	Timeout  int    `env:"REDIS_TIMEOUT" default:"500" validate:"gt=0"`  // in milliseconds, for dialing and a single command
+	TTL      int    `env:"CACHE_TTL" default:"300" validate:"gt=0"`      // in seconds
+}
+
+type HealthConfig struct {
+	Timeout          int `env:"HEALTH_CHECK_TIMEOUT" default:"2" validate:"gt=0"`            // in seconds, for each readiness check
+	ExternalInterval int `env:"HEALTH_EXTERNAL_CHECK_INTERVAL" default:"60" validate:"gt=0"` // in seconds between checks of optional external services
 }
 
+// OpenAI, WhatsApp, and webhook settings are optional; they are checked when the
+// features are used
+
 type OpenAIConfig struct {
-	APIKey string
-	Model  string
+	APIKey      string `env:"OPENAI_API_KEY" secret:"true"`
+	Model       string `env:"OPENAI_MODEL" default:"gpt-4o-mini"`
+	VisionModel string `env:"OPENAI_VISION_MODEL"` // reads receipt photos; empty uses Model
+	BaseURL     string `env:"OPENAI_BASE_URL" default:"https://api.openai.com/v1"`
+	Timeout     int    `env:"OPENAI_TIMEOUT" default:"30"` // in seconds
 }
 
 type WhatsAppConfig struct {
-	PhoneNumberID       string
-	BusinessAccountID   string
-	AccessToken         string
-	APIVersion          string
+	PhoneNumberID     string `env:"WHATSAPP_PHONE_NUMBER_ID"`
+	BusinessAccountID string `env:"WHATSAPP_BUSINESS_ACCOUNT_ID"`
+	AccessToken       string `env:"WHATSAPP_ACCESS_TOKEN" secret:"true"`
+	APIVersion        string `env:"WHATSAPP_API_VERSION" default:"v21.0"`
+	BaseURL           string `env:"WHATSAPP_API_BASE_URL" default:"https://graph.facebook.com"`
+	Timeout           int    `env:"WHATSAPP_TIMEOUT" default:"10"` // in seconds
+	MaxRetries        int    `env:"WHATSAPP_MAX_RETRIES" default:"3"`
+	AppSecret         string `env:"WHATSAPP_APP_SECRET" secret:"true"` // verifies webhook payload signatures
 }
 
 type ServerConfig struct {
-	Port string
-	Env  string
+	Port              string `env:"PORT" default:"8080"`
+	Env               string `env:"ENV" default:"development"`
+	ShutdownTimeout   int    `env:"SERVER_SHUTDOWN_TIMEOUT" default:"15"`          // in seconds
+	ReadHeaderTimeout int    `env:"SERVER_READ_HEADER_TIMEOUT" default:"10"`       // in seconds
+	RequestTimeout    int    `env:"REQUEST_TIMEOUT" default:"30" validate:"min=0"` // in seconds, the deadline of a request's context, 0 for none
+
+	// RequestTimeouts overrides RequestTimeout per route path, such as
+	// "/api/v1/money-flows/scan-receipt", in seconds
+	RequestTimeouts map[string]int `env:"REQUEST_TIMEOUT_OVERRIDES" validate:"dive,keys,startswith=/,endkeys,min=0"`
 }
 
 type WebhookConfig struct {
-	VerifyToken string
+	VerifyToken string `env:"WEBHOOK_VERIFY_TOKEN" secret:"true"`
+}
+
+type ChatConfig struct {
+	ConfirmationTTL int `env:"CHAT_CONFIRMATION_TTL" default:"10" validate:"gt=0"` // in minutes
+
+	// CurrencyAliases adds to or, with an empty code, removes from the built-in
+	// currency names and symbols recognized in chat messages
+	CurrencyAliases map[string]string `env:"CHAT_CURRENCY_ALIASES" validate:"dive,omitempty,len=3,alpha"`
 }
 
 type JWTConfig struct {
-	SecretKey            string
-	AccessTokenDuration  int // in minutes
-	RefreshTokenDuration int // in days
+	SecretKey            string   `env:"JWT_SECRET_KEY" secret:"true"`
+	SecretKeys           []string `env:"JWT_SECRET_KEYS" secret:"true"`                   // newest first; the first key signs new tokens
+	AccessTokenDuration  int      `env:"JWT_ACCESS_TOKEN_DURATION" default:"60"`          // in minutes
+	RefreshTokenDuration int      `env:"JWT_REFRESH_TOKEN_DURATION" default:"30"`         // in days
+	ReauthMaxAge         int      `env:"JWT_REAUTH_MAX_AGE" default:"10" validate:"gt=0"` // in minutes, how recent a sign-in sensitive endpoints accept
+	AdminAudiences       []string `env:"JWT_ADMIN_AUDIENCES"`                             // token audiences allowed on admin endpoints; empty allows all
+}
+
+type PasswordConfig struct {
+	Algorithm         string `env:"PASSWORD_HASH_ALGORITHM" default:"bcrypt"` // bcrypt or argon2id, for new password hashes
+	BcryptCost        int    `env:"BCRYPT_COST" default:"10" validate:"min=4,max=31"`
+	Argon2Memory      int    `env:"ARGON2_MEMORY" default:"19456"` // in KiB
+	Argon2Iterations  int    `env:"ARGON2_ITERATIONS" default:"2" validate:"gt=0"`
+	Argon2Parallelism int    `env:"ARGON2_PARALLELISM" default:"1" validate:"min=1,max=255"`
+}
+
+type LockoutConfig struct {
+	MaxAttempts int `env:"LOGIN_MAX_ATTEMPTS" default:"5" validate:"gt=0"`      // failed sign-ins in a row that lock a credential
+	Duration    int `env:"LOGIN_LOCKOUT_DURATION" default:"15" validate:"gt=0"` // in minutes
+}
+
+type PasswordResetConfig struct {
+	URL        string `env:"PASSWORD_RESET_URL" default:"http://localhost:3000/reset-password"` // page that sets the new password, e.g. https://app.catetin.id/reset-password
+	TTL        int    `env:"PASSWORD_RESET_TTL" default:"60" validate:"gt=0"`                   // in minutes
+	MaxPerHour int    `env:"PASSWORD_RESET_MAX_PER_HOUR" default:"3" validate:"gt=0"`           // reset emails sent per credential per hour
+}
+
+type GoogleConfig struct {
+	ClientID string `env:"GOOGLE_CLIENT_ID"` // OAuth client the apps' ID tokens are issued to; linking Google accounts is disabled when empty
+	CertsURL string `env:"GOOGLE_CERTS_URL" default:"https://www.googleapis.com/oauth2/v3/certs"`
+	Timeout  int    `env:"GOOGLE_TIMEOUT" default:"10"` // in seconds
+}
+
+type PhoneVerificationConfig struct {
+	// WhatsAppTemplate is the approved template codes are sent with, taking the code as
+	// {{1}}; linking phone numbers is disabled when empty
+	WhatsAppTemplate string `env:"PHONE_VERIFICATION_WHATSAPP_TEMPLATE"`
+	WhatsAppLanguage string `env:"PHONE_VERIFICATION_WHATSAPP_LANGUAGE" default:"id"`
+
+	TTL         int `env:"PHONE_VERIFICATION_TTL" default:"10" validate:"gt=0"`         // in minutes
+	MaxAttempts int `env:"PHONE_VERIFICATION_MAX_ATTEMPTS" default:"5" validate:"gt=0"` // wrong codes after which a code stops working
+	MaxPerHour  int `env:"PHONE_VERIFICATION_MAX_PER_HOUR" default:"5" validate:"gt=0"` // codes sent per user per hour
+}
+
+type BroadcastConfig struct {
+	RatePerSecond int `env:"BROADCAST_RATE_PER_SECOND" default:"10"` // maximum messages sent per second
+	BatchSize     int `env:"BROADCAST_BATCH_SIZE" default:"100"`     // pending deliveries loaded per poll
+	MaxAttempts   int `env:"BROADCAST_MAX_ATTEMPTS" default:"3"`     // send attempts before a delivery is marked failed
+}
+
+type AnalyticsConfig struct {
+	Enabled    bool    `env:"ANALYTICS_ENABLED"`
+	Salt       string  `env:"ANALYTICS_SALT" validate:"required_if=Enabled true" secret:"true"` // keys the hash that anonymizes user IDs; changing it breaks continuity
+	SampleRate float64 `env:"ANALYTICS_SAMPLE_RATE" default:"1" validate:"gte=0,lte=1"`         // fraction of events recorded, between 0 and 1
+	Sink       string  `env:"ANALYTICS_SINK" default:"database" validate:"oneof=database log"`
+}
+
+type APIUsageConfig struct {
+	Enabled       bool `env:"API_USAGE_ENABLED" default:"true"`
+	FlushInterval int  `env:"API_USAGE_FLUSH_INTERVAL" default:"10"` // in seconds
+}
+
+type DemoConfig struct {
+	Enabled         bool `env:"DEMO_ENABLED"`
+	TTL             int  `env:"DEMO_TTL" default:"60"`              // in minutes
+	CleanupInterval int  `env:"DEMO_CLEANUP_INTERVAL" default:"10"` // in minutes
+}
+
+type WorkerConfig struct {
+	Concurrency  int `env:"WORKER_CONCURRENCY" default:"4"`   // jobs processed at the same time
+	PollInterval int `env:"WORKER_POLL_INTERVAL" default:"1"` // in seconds
+	JobTimeout   int `env:"WORKER_JOB_TIMEOUT" default:"60"`  // in seconds
+	Retention    int `env:"WORKER_RETENTION" default:"168"`   // in hours, for succeeded jobs
+}
+
+type LogConfig struct {
+	Level  string `env:"LOG_LEVEL" default:"info" validate:"oneof=debug info warn error" reload:"true"`
+	Format string `env:"LOG_FORMAT" validate:"oneof=json console"` // empty is json in production and console elsewhere
+
+	// Bodies logs request and response bodies with secrets redacted: "true", "false", or
+	// empty for development only. BodiesEnabled is the outcome.
+	Bodies        string `env:"LOG_BODIES" validate:"omitempty,oneof=true false"`
+	BodiesEnabled bool
+}
+
+type StorageConfig struct {
+	Driver   string `env:"STORAGE_DRIVER" default:"local" validate:"oneof=local"`
+	LocalDir string `env:"STORAGE_LOCAL_DIR" default:"./data"`
+
+	// DefaultRegion is the data region of LocalDir, used for users without a preference;
+	// Regions maps the other regions users may choose to their storage directory
+	DefaultRegion string            `env:"STORAGE_DEFAULT_REGION" default:"default" validate:"region"`
+	Regions       map[string]string `env:"STORAGE_REGIONS" validate:"dive,keys,region,endkeys,required"`
+}
+
+type ExportConfig struct {
+	Enabled  bool `env:"ANALYTICS_EXPORT_ENABLED"`
+	Interval int  `env:"ANALYTICS_EXPORT_INTERVAL" default:"24" validate:"gt=0"` // in hours
+}
+
+type AccountExportConfig struct {
+	URL        string `env:"ACCOUNT_EXPORT_URL" default:"http://localhost:8080/api/v1/account-exports"` // download endpoint of the links, e.g. https://api.catetin.id/api/v1/account-exports
+	TTL        int    `env:"ACCOUNT_EXPORT_TTL" default:"72" validate:"gt=0"`                           // in hours a link stays valid; the export is deleted when it expires
+	SigningKey string `env:"ACCOUNT_EXPORT_SIGNING_KEY" secret:"true"`                                  // signs the links; empty uses the JWT signing key
+}
+
+type ShareConfig struct {
+	URL        string `env:"SHARE_URL" default:"http://localhost:8080/api/v1/shared/money-flows"` // endpoint the share links open, e.g. https://api.catetin.id/api/v1/shared/money-flows
+	TTL        int    `env:"SHARE_TTL" default:"168" validate:"gt=0,lte=720"`                     // in hours a link stays valid unless its creator picks otherwise
+	SigningKey string `env:"SHARE_SIGNING_KEY" secret:"true"`                                     // signs the links; empty uses the JWT signing key
+}
+
+type AccountErasureConfig struct {
+	GracePeriod int `env:"ACCOUNT_ERASURE_GRACE_PERIOD" default:"14" validate:"min=0"` // in days a user can cancel an erasure before their data is erased
+}
+
+type EmailConfig struct {
+	SMTPHost     string `env:"SMTP_HOST"` // email is disabled when empty
+	SMTPPort     int    `env:"SMTP_PORT" default:"587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD" secret:"true"`
+	From         string `env:"EMAIL_FROM" validate:"required_with=SMTPHost"`
+}
+
+type InvitationConfig struct {
+	URL string `env:"INVITATION_URL" default:"http://localhost:3000/invite"` // page that accepts invitations, e.g. https://app.catetin.id/invite
+	TTL int    `env:"INVITATION_TTL" default:"168" validate:"gt=0"`          // in hours
+
+	// WhatsAppTemplate is the approved template invitations are sent with; WhatsApp
+	// invitations are disabled when empty
+	WhatsAppTemplate string `env:"INVITATION_WHATSAPP_TEMPLATE"`
+	WhatsAppLanguage string `env:"INVITATION_WHATSAPP_LANGUAGE" default:"id"`
+}
+
+type NotificationConfig struct {
+	// WhatsAppTemplate is the approved template alerts are sent with; WhatsApp
+	// notifications are disabled when empty
+	WhatsAppTemplate string `env:"NOTIFICATION_WHATSAPP_TEMPLATE"`
+	WhatsAppLanguage string `env:"NOTIFICATION_WHATSAPP_LANGUAGE" default:"id"`
+}
+
+type TelegramConfig struct {
+	BotToken string `env:"TELEGRAM_BOT_TOKEN" secret:"true"` // Telegram notifications are disabled when empty
+	BaseURL  string `env:"TELEGRAM_API_BASE_URL" default:"https://api.telegram.org"`
+	Timeout  int    `env:"TELEGRAM_TIMEOUT" default:"10"` // in seconds
+}
+
+type FCMConfig struct {
+	CredentialsFile string `env:"FCM_CREDENTIALS_FILE"`     // service account JSON key; push notifications are disabled when empty
+	ProjectID       string `env:"FCM_PROJECT_ID"`           // defaults to the project of the service account
+	Timeout         int    `env:"FCM_TIMEOUT" default:"10"` // in seconds
+}
+
+type FeedbackConfig struct {
+	// SlackWebhookURL is the incoming webhook feedback is posted to; feedback is only
+	// stored when empty
+	SlackWebhookURL string `env:"FEEDBACK_SLACK_WEBHOOK_URL" secret:"true"`
+}
+
+type TokenCleanupConfig struct {
+	Interval  int `env:"TOKEN_CLEANUP_INTERVAL" default:"60" validate:"gt=0"`     // in minutes
+	BatchSize int `env:"TOKEN_CLEANUP_BATCH_SIZE" default:"1000" validate:"gt=0"` // rows purged per statement
+	Retention int `env:"TOKEN_CLEANUP_RETENTION" default:"168" validate:"min=0"`  // in hours, after a token expired or was revoked
+}
+
+type ReadOnlyConfig struct {
+	Forced          bool `env:"READ_ONLY" reload:"true"`                 // keeps the API read-only regardless of the admin switch
+	RefreshInterval int  `env:"READ_ONLY_REFRESH_INTERVAL" default:"10"` // in seconds, how often instances re-read the admin switch
+}
+
+type ExchangeRateConfig struct {
+	Enabled         bool   `env:"EXCHANGE_RATES_ENABLED" default:"true"`
+	URL             string `env:"EXCHANGE_RATES_URL" default:"https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"` // ECB daily reference rates feed
+	RefreshInterval int    `env:"EXCHANGE_RATES_REFRESH_INTERVAL" default:"360" validate:"gt=0"`                              // in minutes
+}
+
+type MetricsConfig struct {
+	Enabled bool   `env:"METRICS_ENABLED"`
+	Token   string `env:"METRICS_TOKEN" secret:"true"` // bearer token Prometheus sends when scraping; empty leaves /metrics open
+}
+
+type RealtimeConfig struct {
+	MaxStreamsPerUser int `env:"REALTIME_MAX_STREAMS_PER_USER" default:"5" validate:"gt=0"` // open event streams per user, e.g. browser tabs
+	Heartbeat         int `env:"REALTIME_HEARTBEAT_INTERVAL" default:"25" validate:"gt=0"`  // in seconds, between keep-alive comments on idle streams
+}
+
+type OutboxConfig struct {
+	PollInterval int `env:"OUTBOX_POLL_INTERVAL" default:"5" validate:"gt=0"` // in seconds, between polls for events emitted by other instances
+	Retention    int `env:"OUTBOX_RETENTION" default:"168" validate:"gt=0"`   // in hours, for dispatched events
+}
+
+// OutgoingWebhookConfig configures the webhooks users register to receive their
+// events; WebhookConfig is for the webhooks the API receives
+type OutgoingWebhookConfig struct {
+	Timeout              int  `env:"OUTGOING_WEBHOOK_TIMEOUT" default:"10" validate:"gt=0"`     // in seconds, per delivery attempt
+	MaxAttempts          int  `env:"OUTGOING_WEBHOOK_MAX_ATTEMPTS" default:"8" validate:"gt=0"` // per event, retried with exponential backoff
+	MaxPerUser           int  `env:"OUTGOING_WEBHOOK_MAX_PER_USER" default:"5" validate:"gt=0"`
+	AllowPrivateNetworks bool `env:"OUTGOING_WEBHOOK_ALLOW_PRIVATE_NETWORKS"`                          // lets webhooks reach loopback and private addresses, for development
+	DeliveryRetention    int  `env:"OUTGOING_WEBHOOK_DELIVERY_RETENTION" default:"30" validate:"gt=0"` // in days, for delivery logs
+}
+
+type DigestConfig struct {
+	Interval  int `env:"DIGEST_INTERVAL" default:"60" validate:"gt=0"`    // in minutes, between checks for digests that are due
+	Hour      int `env:"DIGEST_HOUR" default:"8" validate:"min=0,max=23"` // of the day in the user's time zone, from which digests are sent
+	BatchSize int `env:"DIGEST_BATCH_SIZE" default:"500" validate:"gt=0"` // users loaded per query
+}
+
+type StatementConfig struct {
+	Interval  int `env:"STATEMENT_INTERVAL" default:"60" validate:"gt=0"`    // in minutes, between checks for statements that are due
+	Hour      int `env:"STATEMENT_HOUR" default:"8" validate:"min=0,max=23"` // of the 1st in the user's time zone, from which statements are sent
+	BatchSize int `env:"STATEMENT_BATCH_SIZE" default:"500" validate:"gt=0"` // users loaded per query
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string `env:"CORS_ALLOWED_ORIGINS"` // empty disables CORS; "*" allows any origin
+	AllowedMethods   []string `env:"CORS_ALLOWED_METHODS" default:"GET,POST,PUT,PATCH,DELETE,OPTIONS"`
+	AllowedHeaders   []string `env:"CORS_ALLOWED_HEADERS" default:"Authorization,Content-Type,X-API-Key,X-Request-ID,If-Match,If-None-Match"`
+	ExposedHeaders   []string `env:"CORS_EXPOSED_HEADERS" default:"X-Request-ID,ETag"`
+	AllowCredentials bool     `env:"CORS_ALLOW_CREDENTIALS"`
+	MaxAge           int      `env:"CORS_MAX_AGE" default:"600"` // in seconds
 }
 
-// Load loads configuration from environment variables
+type SecurityConfig struct {
+	MaxBodySize           int    `env:"REQUEST_MAX_BODY_SIZE" default:"1024" validate:"gt=0"`   // in KiB, the largest request body accepted
+	MaxUploadSize         int    `env:"REQUEST_MAX_UPLOAD_SIZE" default:"6144" validate:"gt=0"` // in KiB, the largest body of file uploads such as receipt photos
+	HSTSMaxAge            int    `env:"SECURITY_HSTS_MAX_AGE" default:"-1"`                     // in seconds, 0 leaves out Strict-Transport-Security; -1 is a year in production
+	FrameOptions          string `env:"SECURITY_FRAME_OPTIONS" default:"DENY" validate:"oneof=DENY SAMEORIGIN"`
+	ContentSecurityPolicy string `env:"SECURITY_CONTENT_SECURITY_POLICY" default:"default-src 'none'; frame-ancestors 'none'"` // of API responses, empty leaves it out
+}
+
+type TracingConfig struct {
+	Enabled     bool    `env:"TRACING_ENABLED"`
+	Endpoint    string  `env:"OTEL_EXPORTER_OTLP_ENDPOINT" default:"http://localhost:4318"` // OTLP/HTTP collector URL
+	ServiceName string  `env:"OTEL_SERVICE_NAME" default:"catetin-api"`
+	SampleRate  float64 `env:"TRACING_SAMPLE_RATE" default:"1" validate:"gte=0,lte=1"` // fraction of new traces recorded, between 0 and 1
+}
+
+type SentryConfig struct {
+	DSN         string `env:"SENTRY_DSN" validate:"omitempty,url" secret:"true"` // errors answered with 500 are reported when set
+	Environment string `env:"SENTRY_ENVIRONMENT"`                                // empty uses ENV
+	Release     string `env:"SENTRY_RELEASE"`                                    // e.g. the Git commit being deployed
+	Timeout     int    `env:"SENTRY_TIMEOUT" default:"5" validate:"gt=0"`        // in seconds
+}
+
+// Load loads configuration from environment variables and the dotenv file named by
+// CONFIG_FILE (.env by default, for local development); the environment takes precedence
 func Load() (*Config, error) {
-	// Load .env file if it exists (for local development)
-	_ = godotenv.Load()
-
-	config := &Config{
-		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", ""),
-			DBName:   getEnv("DB_NAME", "catetin"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
-		},
-		OpenAI: OpenAIConfig{
-			APIKey: getEnv("OPENAI_API_KEY", ""),
-			Model:  getEnv("OPENAI_MODEL", "gpt-4o-mini"),
-		},
-		WhatsApp: WhatsAppConfig{
-			PhoneNumberID:     getEnv("WHATSAPP_PHONE_NUMBER_ID", ""),
-			BusinessAccountID: getEnv("WHATSAPP_BUSINESS_ACCOUNT_ID", ""),
-			AccessToken:       getEnv("WHATSAPP_ACCESS_TOKEN", ""),
-			APIVersion:        getEnv("WHATSAPP_API_VERSION", "v21.0"),
-		},
-		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Env:  getEnv("ENV", "development"),
-		},
-		Webhook: WebhookConfig{
-			VerifyToken: getEnv("WEBHOOK_VERIFY_TOKEN", ""),
-		},
-		JWT: JWTConfig{
-			SecretKey:            getEnv("JWT_SECRET_KEY", ""),
-			AccessTokenDuration:  getEnvAsInt("JWT_ACCESS_TOKEN_DURATION", 60),   // 60 minutes default
-			RefreshTokenDuration: getEnvAsInt("JWT_REFRESH_TOKEN_DURATION", 30), // 30 days default
-		},
+	file, err := readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	lookup := func(name string) string {
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+		return file[name]
+	}
+	if err := loadEnv(config, lookup); err != nil {
+		return nil, err
 	}
+	config.normalize()
 
 	// Validate required fields
 	if err := config.Validate(); err != nil {
@@ -98,22 +459,136 @@ func Load() (*Config, error) {
 	return config, nil
 }
 
-// Validate validates the configuration
+// readConfigFile reads the dotenv file without changing the environment, so that a
+// reload sees its current content; a missing default file is not an error
+func readConfigFile() (map[string]string, error) {
+	path := os.Getenv(configFileEnv)
+	named := path != ""
+	if !named {
+		path = defaultConfigFile
+	}
+
+	values, err := godotenv.Read(path)
+	if errors.Is(err, os.ErrNotExist) && !named {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configFileEnv, err)
+	}
+	return values, nil
+}
+
+// normalize derives the settings that depend on others
+func (c *Config) normalize() {
+	c.Log.Level = strings.ToLower(c.Log.Level)
+	c.Log.Format = strings.ToLower(c.Log.Format)
+	c.Security.FrameOptions = strings.ToUpper(c.Security.FrameOptions)
+	c.Database.QueryBudgetStrict = c.Database.QueryBudgetMode == "fail"
+
+	// Production emits JSON for log aggregation; other environments human-readable text
+	if c.Log.Format == "" {
+		c.Log.Format = "console"
+		if c.Server.Env == "production" {
+			c.Log.Format = "json"
+		}
+	}
+
+	// Bodies hold personal data, so they are only logged while developing unless asked
+	c.Log.BodiesEnabled = c.Log.Bodies == "true" || (c.Log.Bodies == "" && c.Server.Env == "development")
+
+	// Production is served over HTTPS, so browsers are told to stay on it for a year;
+	// other environments often run on plain HTTP
+	if c.Security.HSTSMaxAge < 0 {
+		c.Security.HSTSMaxAge = 0
+		if c.Server.Env == "production" {
+			c.Security.HSTSMaxAge = 365 * 24 * 60 * 60
+		}
+	}
+
+	// JWT_SECRET_KEYS takes precedence; JWT_SECRET_KEY remains supported as a single key
+	if len(c.JWT.SecretKeys) == 0 && c.JWT.SecretKey != "" {
+		c.JWT.SecretKeys = []string{c.JWT.SecretKey}
+	}
+	if c.Account.SigningKey == "" && len(c.JWT.SecretKeys) > 0 {
+		c.Account.SigningKey = c.JWT.SecretKeys[0]
+	}
+	if c.Share.SigningKey == "" && len(c.JWT.SecretKeys) > 0 {
+		c.Share.SigningKey = c.JWT.SecretKeys[0]
+	}
+	if c.Sentry.Environment == "" {
+		c.Sentry.Environment = c.Server.Env
+	}
+}
+
+// Validate validates the configuration: the validate tags of the settings, then the
+// rules spanning sections
 func (c *Config) Validate() error {
-	if c.Database.Password == "" {
-		return fmt.Errorf("DB_PASSWORD is required")
+	if err := newValidator().Struct(c); err != nil {
+		return validationError(err)
+	}
+
+	// A SQLite file belongs to one instance: there is nothing to replicate or shard,
+	// and the sqlc queries are written for PostgreSQL
+	if c.Database.Driver == DriverSQLite &&
+		(len(c.Database.ReplicaURLs) > 0 || len(c.Database.ShardURLs) > 0 || c.Database.QueryEngine == QueryEngineSQLC) {
+		return fmt.Errorf("DB_REPLICA_URLS, DB_SHARD_URLS, and DB_QUERY_ENGINE=sqlc require DB_DRIVER=postgres")
+	}
+
+	if len(c.JWT.SecretKeys) == 0 {
+		return fmt.Errorf("JWT_SECRET_KEY or JWT_SECRET_KEYS is required")
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" && c.CORS.AllowCredentials && c.Server.Env == "production" {
+			return fmt.Errorf("CORS_ALLOWED_ORIGINS must list explicit origins when CORS_ALLOW_CREDENTIALS is true in production")
+		}
 	}
 
-	if c.JWT.SecretKey == "" {
-		return fmt.Errorf("JWT_SECRET_KEY is required")
+	for _, audience := range c.JWT.AdminAudiences {
+		if !slices.Contains(security.Audiences, audience) {
+			return fmt.Errorf("JWT_ADMIN_AUDIENCES contains unknown audience %q", audience)
+		}
 	}
 
-	// Note: OpenAI, WhatsApp, and Webhook configs are optional
-	// They will be validated when those features are used
+	if !slices.Contains(security.HashAlgorithms, c.Password.Algorithm) {
+		return fmt.Errorf("PASSWORD_HASH_ALGORITHM must be one of %s", strings.Join(security.HashAlgorithms, ", "))
+	}
+
+	if c.Password.Argon2Memory < 8*c.Password.Argon2Parallelism {
+		return fmt.Errorf("ARGON2_MEMORY must be at least 8 KiB per lane of ARGON2_PARALLELISM")
+	}
 
 	return nil
 }
 
+// QueryBudgetEnabled checks if the per-request query budget guard should run
+func (c *Config) QueryBudgetEnabled() bool {
+	return c.Database.QueryBudget > 0 && c.Server.Env != "production"
+}
+
+// PasswordHash returns the settings of password hashing
+func (c *Config) PasswordHash() security.PasswordHashConfig {
+	return security.PasswordHashConfig{
+		Algorithm:         c.Password.Algorithm,
+		BcryptCost:        c.Password.BcryptCost,
+		Argon2Memory:      uint32(c.Password.Argon2Memory),
+		Argon2Iterations:  uint32(c.Password.Argon2Iterations),
+		Argon2Parallelism: uint8(c.Password.Argon2Parallelism),
+	}
+}
+
+// DatabasePool returns the connection pool settings of each database
+func (c *Config) DatabasePool() postgresql.PoolConfig {
+	return postgresql.PoolConfig{
+		MaxIdleConns:    c.Database.MaxIdleConns,
+		MaxOpenConns:    c.Database.MaxOpenConns,
+		ConnMaxLifetime: time.Duration(c.Database.ConnMaxLifetime) * time.Minute,
+		ConnMaxIdleTime: time.Duration(c.Database.ConnMaxIdleTime) * time.Minute,
+
+		StatementTimeout: time.Duration(c.Database.StatementTimeout) * time.Second,
+	}
+}
+
 // GetDatabaseDSN returns the PostgreSQL connection string
 func (c *Config) GetDatabaseDSN() string {
 	return fmt.Sprintf(
@@ -127,23 +602,15 @@ func (c *Config) GetDatabaseDSN() string {
 	)
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// isRegionName reports whether name is a valid data region, e.g. "ap-southeast-3"
+func isRegionName(name string) bool {
+	if name == "" || len(name) > 32 {
+		return false
 	}
-	return defaultValue
-}
-
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
-	}
-
-	var value int
-	_, err := fmt.Sscanf(valueStr, "%d", &value)
-	if err != nil {
-		return defaultValue
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
 	}
-	return value
+	return true
 }
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Settings are loaded into the fields of Config tagged with the environment variable
+// they come from:
+//
+//	env:"NAME"        the variable; fields without it are derived in Load
+//	default:"value"   used when the variable is unset or empty; lists are comma-separated
+//	validate:"..."    checked by Validate, see https://pkg.go.dev/github.com/go-playground/validator/v10
+//	secret:"true"     redacted by Settings
+//	reload:"true"     applied by Reloader without a restart
+//
+// Lists are comma-separated and maps comma-separated key=value pairs.
+//
+// This is a loader of its own rather than envconfig or viper. Settings and the
+// Reloader walk the same tags to redact secrets and to tell reloadable settings from
+// ones needing a restart, which neither library describes. Values are read through
+// a lookup function, so a reload sees the dotenv file's current content without
+// writing it into the process environment, which envconfig only reads. Validation
+// tags use go-playground/validator, the library Gin validates request bodies with.
+
+// redacted replaces the value of secret settings that are set
+const redacted = "[REDACTED]"
+
+// Setting is a configuration value with the environment variable it comes from
+type Setting struct {
+	Name       string
+	Value      string // redacted when Secret
+	Secret     bool
+	Reloadable bool
+}
+
+// lookupFunc returns the value of an environment variable, empty when unset
+type lookupFunc func(name string) string
+
+// field is a setting of Config: a struct field with an env tag
+type field struct {
+	name       string
+	defaultVal string
+	secret     bool
+	reloadable bool
+	value      reflect.Value
+}
+
+// fields returns the settings of a Config, or of one of its sections, in declaration
+// order
+func fields(v reflect.Value) []field {
+	var result []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		value := v.Field(i)
+
+		name, ok := structField.Tag.Lookup("env")
+		if !ok {
+			if structField.Type.Kind() == reflect.Struct {
+				result = append(result, fields(value)...)
+			}
+			continue
+		}
+		result = append(result, field{
+			name:       name,
+			defaultVal: structField.Tag.Get("default"),
+			secret:     structField.Tag.Get("secret") == "true",
+			reloadable: structField.Tag.Get("reload") == "true",
+			value:      value,
+		})
+	}
+	return result
+}
+
+// loadEnv sets every setting of config from lookup, or from its default
+func loadEnv(config *Config, lookup lookupFunc) error {
+	for _, f := range fields(reflect.ValueOf(config).Elem()) {
+		raw := strings.TrimSpace(lookup(f.name))
+		if raw == "" {
+			raw = f.defaultVal
+		}
+		if err := setValue(f.value, raw); err != nil {
+			return fmt.Errorf("%s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// setValue parses raw into a setting; an empty raw leaves the zero value
+func setValue(v reflect.Value, raw string) error {
+	if raw == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", raw)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		items := splitList(raw)
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for _, pair := range splitList(raw) {
+			key, value, _ := strings.Cut(pair, "=") // a pair without "=" maps to ""
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(elem, strings.TrimSpace(value)); err != nil {
+				return fmt.Errorf("%s: %w", strings.TrimSpace(key), err)
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)), elem)
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatValue writes a setting the way it is read from the environment
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(items, ",")
+	case reflect.Map:
+		pairs := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			pairs = append(pairs, key.String()+"="+formatValue(v.MapIndex(key)))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// Settings returns the effective settings, including derived ones like the log format,
+// with the values of secrets redacted
+func (c *Config) Settings() []Setting {
+	all := fields(reflect.ValueOf(c).Elem())
+	settings := make([]Setting, 0, len(all))
+	for _, f := range all {
+		value := formatValue(f.value)
+		if f.secret && value != "" {
+			value = redacted
+		}
+		settings = append(settings, Setting{Name: f.name, Value: value, Secret: f.secret, Reloadable: f.reloadable})
+	}
+	return settings
+}
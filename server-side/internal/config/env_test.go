@@ -0,0 +1,144 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// mapLookup looks environment variables up in a map
+func mapLookup(env map[string]string) lookupFunc {
+	return func(name string) string { return env[name] }
+}
+
+func TestLoadEnvParsesEachKind(t *testing.T) {
+	config := &Config{}
+	err := loadEnv(config, mapLookup(map[string]string{
+		"DB_HOST":                   " db.internal ",
+		"SERVER_SHUTDOWN_TIMEOUT":   "20",
+		"ANALYTICS_SAMPLE_RATE":     "0.25",
+		"ANALYTICS_ENABLED":         "true",
+		"CORS_ALLOWED_ORIGINS":      "https://catetin.id, ,https://app.catetin.id,",
+		"REQUEST_TIMEOUT_OVERRIDES": "/api/v1/money-flows/scan-receipt=120, /api/v1/exports = 300",
+		"CHAT_CURRENCY_ALIASES":     "ringgit=MYR,rp=",
+	}))
+	if err != nil {
+		t.Fatalf("loadEnv() error = %v", err)
+	}
+
+	if config.Database.Host != "db.internal" {
+		t.Errorf("DB_HOST = %q, want db.internal", config.Database.Host)
+	}
+	if config.Server.ShutdownTimeout != 20 {
+		t.Errorf("SERVER_SHUTDOWN_TIMEOUT = %d, want 20", config.Server.ShutdownTimeout)
+	}
+	if config.Analytics.SampleRate != 0.25 {
+		t.Errorf("ANALYTICS_SAMPLE_RATE = %v, want 0.25", config.Analytics.SampleRate)
+	}
+	if !config.Analytics.Enabled {
+		t.Error("ANALYTICS_ENABLED = false, want true")
+	}
+	if want := []string{"https://catetin.id", "https://app.catetin.id"}; !reflect.DeepEqual(config.CORS.AllowedOrigins, want) {
+		t.Errorf("CORS_ALLOWED_ORIGINS = %q, want %q", config.CORS.AllowedOrigins, want)
+	}
+	wantTimeouts := map[string]int{"/api/v1/money-flows/scan-receipt": 120, "/api/v1/exports": 300}
+	if !reflect.DeepEqual(config.Server.RequestTimeouts, wantTimeouts) {
+		t.Errorf("REQUEST_TIMEOUT_OVERRIDES = %v, want %v", config.Server.RequestTimeouts, wantTimeouts)
+	}
+	if want := map[string]string{"ringgit": "MYR", "rp": ""}; !reflect.DeepEqual(config.Chat.CurrencyAliases, want) {
+		t.Errorf("CHAT_CURRENCY_ALIASES = %v, want %v", config.Chat.CurrencyAliases, want)
+	}
+}
+
+func TestLoadEnvDefaults(t *testing.T) {
+	config := &Config{}
+	// An empty or blank variable counts as unset
+	if err := loadEnv(config, mapLookup(map[string]string{"PORT": "", "DB_DRIVER": "  "})); err != nil {
+		t.Fatalf("loadEnv() error = %v", err)
+	}
+
+	if config.Server.Port != "8080" {
+		t.Errorf("PORT = %q, want the default 8080", config.Server.Port)
+	}
+	if config.Database.Driver != "postgres" {
+		t.Errorf("DB_DRIVER = %q, want the default postgres", config.Database.Driver)
+	}
+	if config.Analytics.SampleRate != 1 {
+		t.Errorf("ANALYTICS_SAMPLE_RATE = %v, want the default 1", config.Analytics.SampleRate)
+	}
+	if want := []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}; !reflect.DeepEqual(config.CORS.AllowedMethods, want) {
+		t.Errorf("CORS_ALLOWED_METHODS = %q, want the default %q", config.CORS.AllowedMethods, want)
+	}
+	if config.CORS.AllowedOrigins != nil || config.Database.Password != "" || config.Analytics.Enabled {
+		t.Error("settings without a default are not their zero value")
+	}
+}
+
+func TestLoadEnvRejectsMalformedValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"SERVER_SHUTDOWN_TIMEOUT", "15s", `SERVER_SHUTDOWN_TIMEOUT: "15s" is not an integer`},
+		{"ANALYTICS_SAMPLE_RATE", "half", `ANALYTICS_SAMPLE_RATE: "half" is not a number`},
+		{"ANALYTICS_ENABLED", "yes", `ANALYTICS_ENABLED: "yes" is not true or false`},
+		{"REQUEST_TIMEOUT_OVERRIDES", "/api/v1/exports=long", `REQUEST_TIMEOUT_OVERRIDES: /api/v1/exports: "long" is not an integer`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadEnv(&Config{}, mapLookup(map[string]string{tt.name: tt.value}))
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("loadEnv() error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetValueRejectsUnsupportedTypes(t *testing.T) {
+	var timeout time.Duration
+	err := setValue(reflect.ValueOf(&timeout).Elem(), "30s")
+	if err == nil || err.Error() != "unsupported setting type time.Duration" {
+		t.Errorf("setValue() error = %v, want unsupported setting type", err)
+	}
+}
+
+func TestSettingsRedactsSecrets(t *testing.T) {
+	config := &Config{}
+	if err := loadEnv(config, mapLookup(map[string]string{
+		"DB_PASSWORD":               "hunter2",
+		"DB_REPLICA_URLS":           "postgres://replica-1,postgres://replica-2",
+		"REQUEST_TIMEOUT_OVERRIDES": "/b=2,/a=1",
+	})); err != nil {
+		t.Fatalf("loadEnv() error = %v", err)
+	}
+
+	settings := make(map[string]Setting)
+	for _, setting := range config.Settings() {
+		settings[setting.Name] = setting
+	}
+	tests := []struct {
+		name       string
+		value      string
+		secret     bool
+		reloadable bool
+	}{
+		{"DB_PASSWORD", redacted, true, false},
+		{"DB_REPLICA_URLS", redacted, true, false},
+		{"ANALYTICS_SALT", "", true, false}, // unset secrets show they are unset
+		{"DB_HOST", "localhost", false, false},
+		{"REQUEST_TIMEOUT_OVERRIDES", "/a=1,/b=2", false, false},
+		{"LOG_LEVEL", "info", false, true},
+		{"READ_ONLY", "false", false, true},
+	}
+	for _, tt := range tests {
+		got, ok := settings[tt.name]
+		if !ok {
+			t.Errorf("Settings() lacks %s", tt.name)
+			continue
+		}
+		if got.Value != tt.value || got.Secret != tt.secret || got.Reloadable != tt.reloadable {
+			t.Errorf("Settings() %s = %+v, want value %q, secret %v, reloadable %v", tt.name, got, tt.value, tt.secret, tt.reloadable)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Reloader holds the configuration of a running process and reloads it, e.g. on SIGHUP.
+// Only settings tagged reload:"true" take effect; the others keep their value until
+// the next restart.
+type Reloader struct {
+	mu       sync.RWMutex
+	current  *Config
+	handlers []func(*Config)
+}
+
+// NewReloader creates a reloader of the configuration the process started with
+func NewReloader(config *Config) *Reloader {
+	return &Reloader{current: config}
+}
+
+// Current returns the configuration in effect. It must not be modified.
+func (r *Reloader) Current() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// OnReload registers a function applying reloaded settings, called with the new
+// configuration after every reload that changed a reloadable setting
+func (r *Reloader) OnReload(handler func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, handler)
+}
+
+// Reload loads the configuration again and applies the reloadable settings that
+// changed. It returns their names, and the names of the changed settings that need a
+// restart. An invalid configuration is rejected as a whole and changes nothing.
+func (r *Reloader) Reload() (changed, restart []string, err error) {
+	loaded, err := Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	next := *r.current
+	nextFields := fields(reflect.ValueOf(&next).Elem())
+	for i, f := range fields(reflect.ValueOf(loaded).Elem()) {
+		if reflect.DeepEqual(f.value.Interface(), nextFields[i].value.Interface()) {
+			continue
+		}
+		if !f.reloadable {
+			restart = append(restart, f.name)
+			continue
+		}
+		nextFields[i].value.Set(f.value)
+		changed = append(changed, f.name)
+	}
+	if len(changed) > 0 {
+		r.current = &next
+	}
+	handlers := r.handlers
+	r.mu.Unlock()
+
+	if len(changed) > 0 {
+		for _, handler := range handlers {
+			handler(&next)
+		}
+	}
+	return changed, restart, nil
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// configFile writes a dotenv file that Load reads, and returns a function replacing
+// its content
+func configFile(t *testing.T, lines ...string) func(lines ...string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "catetin.env")
+	write := func(lines ...string) {
+		t.Helper()
+		content := "JWT_SECRET_KEY=test-secret-key-of-at-least-32-bytes\nDB_PASSWORD=postgres\n" + strings.Join(lines, "\n")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	write(lines...)
+	t.Setenv(configFileEnv, path)
+	return write
+}
+
+func TestLoadPrefersEnvironmentToFile(t *testing.T) {
+	configFile(t, "PORT=9090", "DB_HOST=db.internal")
+	t.Setenv("PORT", "7070")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if config.Server.Port != "7070" || config.Database.Host != "db.internal" {
+		t.Errorf("Load() PORT = %q, DB_HOST = %q, want 7070 from the environment and db.internal from the file", config.Server.Port, config.Database.Host)
+	}
+
+	t.Setenv(configFileEnv, filepath.Join(t.TempDir(), "missing.env"))
+	if _, err := Load(); err == nil {
+		t.Error("Load() with a missing CONFIG_FILE error = nil, want an error")
+	}
+}
+
+func TestReloadAppliesReloadableSettings(t *testing.T) {
+	write := configFile(t, "LOG_LEVEL=info", "PORT=8080")
+	initial, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	reloader := NewReloader(initial)
+	var reloaded []*Config
+	reloader.OnReload(func(config *Config) { reloaded = append(reloaded, config) })
+
+	write("LOG_LEVEL=DEBUG", "READ_ONLY=true", "PORT=9090")
+	changed, restart, err := reloader.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if want := []string{"READ_ONLY", "LOG_LEVEL"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("Reload() changed = %v, want %v", changed, want)
+	}
+	if want := []string{"PORT"}; !reflect.DeepEqual(restart, want) {
+		t.Errorf("Reload() restart = %v, want %v", restart, want)
+	}
+
+	current := reloader.Current()
+	if current.Log.Level != "debug" || !current.ReadOnly.Forced {
+		t.Errorf("Current() LOG_LEVEL = %q, READ_ONLY = %v, want the reloaded debug and true", current.Log.Level, current.ReadOnly.Forced)
+	}
+	if current.Server.Port != "8080" {
+		t.Errorf("Current() PORT = %q, want 8080 until a restart", current.Server.Port)
+	}
+	if initial.Log.Level != "info" {
+		t.Errorf("Reload() changed the initial configuration's LOG_LEVEL to %q", initial.Log.Level)
+	}
+	if len(reloaded) != 1 || reloaded[0] != current {
+		t.Errorf("OnReload handler called %d times, want once with the current configuration", len(reloaded))
+	}
+
+	// Reloading an unchanged file applies nothing and calls no handler
+	changed, _, err = reloader.Reload()
+	if err != nil || len(changed) != 0 {
+		t.Errorf("Reload() of an unchanged file = %v, %v, want nothing changed", changed, err)
+	}
+	if len(reloaded) != 1 {
+		t.Errorf("OnReload handler called %d times, want no call without changes", len(reloaded))
+	}
+}
+
+func TestReloadRejectsInvalidConfig(t *testing.T) {
+	write := configFile(t, "LOG_LEVEL=info")
+	initial, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	reloader := NewReloader(initial)
+	called := false
+	reloader.OnReload(func(*Config) { called = true })
+
+	// The valid LOG_LEVEL is not applied while another setting is invalid
+	write("LOG_LEVEL=debug", "READ_ONLY=true", "ANALYTICS_ENABLED=true")
+	_, _, err = reloader.Reload()
+	if err == nil || err.Error() != "ANALYTICS_SALT is required when ANALYTICS_ENABLED is true" {
+		t.Errorf("Reload() error = %v, want the ANALYTICS_SALT error", err)
+	}
+	if current := reloader.Current(); current != initial || current.Log.Level != "info" || current.ReadOnly.Forced {
+		t.Error("Reload() of an invalid file changed the configuration")
+	}
+	if called {
+		t.Error("OnReload handler called for an invalid file")
+	}
+}
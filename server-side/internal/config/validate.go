@@ -0,0 +1,90 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// newValidator returns a validator for the validate tags of Config that names settings
+// by their environment variable
+func newValidator() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		if name := field.Tag.Get("env"); name != "" {
+			return name
+		}
+		return field.Name
+	})
+	_ = validate.RegisterValidation("region", func(fl validator.FieldLevel) bool {
+		return isRegionName(fl.Field().String())
+	})
+	return validate
+}
+
+// validationError describes the first failed validate tag the way an operator sets it
+func validationError(err error) error {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) == 0 {
+		return err
+	}
+
+	fe := errs[0]
+	name, param := fe.Field(), fe.Param()
+	switch fe.Tag() {
+	case "required":
+		return fmt.Errorf("%s is required", name)
+	case "required_if":
+		field, value, _ := strings.Cut(param, " ")
+		return fmt.Errorf("%s is required when %s is %s", name, siblingName(fe, field), value)
+	case "required_with":
+		return fmt.Errorf("%s is required when %s is set", name, siblingName(fe, param))
+	case "gt":
+		if param == "0" {
+			return fmt.Errorf("%s must be positive", name)
+		}
+		return fmt.Errorf("%s must be greater than %s", name, param)
+	case "gte", "min":
+		if param == "0" {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+		return fmt.Errorf("%s must be at least %s", name, param)
+	case "lte", "max":
+		return fmt.Errorf("%s must be at most %s", name, param)
+	case "ltefield":
+		return fmt.Errorf("%s must not exceed %s", name, siblingName(fe, param))
+	case "oneof":
+		return fmt.Errorf("%s must be one of %s", name, strings.ReplaceAll(param, " ", ", "))
+	case "startswith":
+		return fmt.Errorf("%s must start with %s", name, param)
+	case "len", "alpha":
+		return fmt.Errorf("%s must be a 3-letter ISO 4217 code", name)
+	case "region":
+		return fmt.Errorf("%s must be lowercase letters, digits, and hyphens", name)
+	default:
+		return fmt.Errorf("%s is invalid (%s)", name, fe.Tag())
+	}
+}
+
+// siblingName returns the environment variable of another field of the struct fe
+// failed in, e.g. DB_DRIVER for the Driver in DB_PASSWORD's required_if=Driver postgres
+func siblingName(fe validator.FieldError, field string) string {
+	t := reflect.TypeOf(Config{})
+	path := strings.Split(fe.StructNamespace(), ".")
+	for _, name := range path[1 : len(path)-1] {
+		structField, ok := t.FieldByName(name)
+		if !ok {
+			return field
+		}
+		t = structField.Type
+	}
+	if structField, ok := t.FieldByName(field); ok {
+		if name := structField.Tag.Get("env"); name != "" {
+			return name
+		}
+	}
+	return field
+}
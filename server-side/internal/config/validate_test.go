@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+)
+
+// validEnv returns the least environment a valid configuration needs
+func validEnv() map[string]string {
+	return map[string]string{
+		"JWT_SECRET_KEY": "test-secret-key-of-at-least-32-bytes",
+		"DB_PASSWORD":    "postgres",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string // the error, empty when valid
+	}{
+		{"valid", nil, ""},
+		{"postgres without password", map[string]string{"DB_PASSWORD": ""}, "DB_PASSWORD is required when DB_DRIVER is postgres"},
+		{"sqlite without password", map[string]string{"DB_DRIVER": "sqlite", "DB_PASSWORD": ""}, ""},
+		{"analytics without salt", map[string]string{"ANALYTICS_ENABLED": "true"}, "ANALYTICS_SALT is required when ANALYTICS_ENABLED is true"},
+		{"analytics with salt", map[string]string{"ANALYTICS_ENABLED": "true", "ANALYTICS_SALT": "pepper"}, ""},
+		{"unknown driver", map[string]string{"DB_DRIVER": "mysql"}, "DB_DRIVER must be one of postgres, sqlite"},
+		{"unknown log level", map[string]string{"LOG_LEVEL": "verbose"}, "LOG_LEVEL must be one of debug, info, warn, error"},
+		{"log level in capitals", map[string]string{"LOG_LEVEL": "DEBUG"}, ""},
+		{"sample rate above 1", map[string]string{"ANALYTICS_SAMPLE_RATE": "1.5"}, "ANALYTICS_SAMPLE_RATE must be at most 1"},
+		{"zero reauth age", map[string]string{"JWT_REAUTH_MAX_AGE": "0"}, "JWT_REAUTH_MAX_AGE must be positive"},
+		{"without JWT key", map[string]string{"JWT_SECRET_KEY": ""}, "JWT_SECRET_KEY or JWT_SECRET_KEYS is required"},
+		{"sqlite replicas", map[string]string{"DB_DRIVER": "sqlite", "DB_REPLICA_URLS": "postgres://replica"}, "DB_REPLICA_URLS, DB_SHARD_URLS, and DB_QUERY_ENGINE=sqlc require DB_DRIVER=postgres"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := validEnv()
+			for name, value := range tt.env {
+				env[name] = value
+			}
+			config := &Config{}
+			if err := loadEnv(config, mapLookup(env)); err != nil {
+				t.Fatalf("loadEnv() error = %v", err)
+			}
+			config.normalize()
+
+			err := config.Validate()
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("Validate() error = %v, want none", err)
+			case tt.want != "" && (err == nil || err.Error() != tt.want):
+				t.Errorf("Validate() error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
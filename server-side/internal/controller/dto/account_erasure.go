@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// AccountErasureResponse represents a request to erase the personal data of an account
+type AccountErasureResponse struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	EraseAt     time.Time  `json:"erase_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
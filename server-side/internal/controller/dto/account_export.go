@@ -0,0 +1,13 @@
+package dto
+
+// AccountExportResponse represents a queued account export
+type AccountExportResponse struct {
+	ID string `json:"id"`
+}
+
+// AccountExportDownloadQuery represents the signed query of an account export's
+// download link
+type AccountExportDownloadQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required,hexadecimal"`
+}
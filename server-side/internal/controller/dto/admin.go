@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// ListUsersQuery represents the query parameters for listing and searching users
+type ListUsersQuery struct {
+	PageQuery
+	Query  string `form:"q" binding:"omitempty,max=200"`
+	Role   string `form:"role" binding:"omitempty,oneof=user support admin"`
+	Status string `form:"status" binding:"omitempty,oneof=active disabled"`
+}
+
+// UpdateUserRoleRequest represents the payload for changing the role of a user
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user support admin"`
+}
+
+// AdminUserResponse represents a user as shown to admins
+type AdminUserResponse struct {
+	ID          string     `json:"id"`
+	FullName    string     `json:"full_name"`
+	PhoneNumber string     `json:"phone_number"`
+	Role        string     `json:"role"`
+	Permissions []string   `json:"permissions"`
+	Demo        bool       `json:"demo"`
+	DisabledAt  *time.Time `json:"disabled_at"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// AdminUserListResponse represents a page of users
+type AdminUserListResponse struct {
+	Items  []*AdminUserResponse `json:"items"`
+	Total  int64                `json:"total"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// SystemStatsResponse represents counts across every user over the last days
+type SystemStatsResponse struct {
+	Since time.Time `json:"since"`
+	Days  int       `json:"days"`
+
+	Users         int64 `json:"users"`
+	NewUsers      int64 `json:"new_users"`
+	ActiveUsers   int64 `json:"active_users"`
+	DisabledUsers int64 `json:"disabled_users"`
+	StaffUsers    int64 `json:"staff_users"`
+
+	MoneyFlows    int64 `json:"money_flows"`
+	NewMoneyFlows int64 `json:"new_money_flows"`
+	Wallets       int64 `json:"wallets"`
+	Groups        int64 `json:"groups"`
+}
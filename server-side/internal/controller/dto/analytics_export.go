@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// CreateAnalyticsExportRequest represents the payload for exporting a month of money flows
+type CreateAnalyticsExportRequest struct {
+	Month string `json:"month" binding:"required,datetime=2006-01"`
+}
+
+// AnalyticsExportJobResponse represents a queued export
+type AnalyticsExportJobResponse struct {
+	JobID string    `json:"job_id"`
+	Month string    `json:"month"`
+	RunAt time.Time `json:"run_at"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// CreateAPIKeyRequest represents the payload for issuing a new API key
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required,min=1,max=100"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=read write export"`
+}
+
+// APIKeyResponse represents an API key in list responses
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	Revoked    bool       `json:"revoked"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse represents a newly issued API key.
+// The plaintext key is only returned once.
+type CreateAPIKeyResponse struct {
+	*APIKeyResponse
+	Key string `json:"key" secret:"true"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// APIUsageQuery represents the reporting period of usage endpoints
+type APIUsageQuery struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=90"`
+}
+
+// ListUserAPIUsageQuery represents the query parameters for listing usage per user
+type ListUserAPIUsageQuery struct {
+	APIUsageQuery
+	PageQuery
+}
+
+// EndpointUsageResponse represents the request counts of one endpoint
+type EndpointUsageResponse struct {
+	Method   string `json:"method"`
+	Route    string `json:"route"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"`
+}
+
+// DailyUsageResponse represents the request counts of one day
+type DailyUsageResponse struct {
+	Day      string `json:"day"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"`
+}
+
+// APIUsageResponse represents the current user's API usage over a period
+type APIUsageResponse struct {
+	Since     time.Time                `json:"since"`
+	Days      int                      `json:"days"`
+	Requests  int64                    `json:"requests"`
+	Errors    int64                    `json:"errors"`
+	Endpoints []*EndpointUsageResponse `json:"endpoints"`
+	Daily     []*DailyUsageResponse    `json:"daily"`
+}
+
+// UserUsageResponse represents the request counts of one user
+type UserUsageResponse struct {
+	UserID   string `json:"user_id"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"`
+}
+
+// UserUsageListResponse represents a page of users ordered by request count
+type UserUsageListResponse struct {
+	Since  time.Time            `json:"since"`
+	Days   int                  `json:"days"`
+	Items  []*UserUsageResponse `json:"items"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// EndpointUsageListResponse represents the request counts of every endpoint across users
+type EndpointUsageListResponse struct {
+	Since time.Time                `json:"since"`
+	Days  int                      `json:"days"`
+	Items []*EndpointUsageResponse `json:"items"`
+}
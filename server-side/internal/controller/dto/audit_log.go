@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ListAuditLogsQuery represents the query parameters for listing audit logs
+type ListAuditLogsQuery struct {
+	PageQuery
+	EntityType string `form:"entity_type" binding:"omitempty,oneof=money_flow wallet budget group goal user user_auth"`
+	EntityID   string `form:"entity_id" binding:"omitempty,uuid"`
+}
+
+// AuditLogResponse represents a change the user made to one of their entities
+type AuditLogResponse struct {
+	ID         string          `json:"id"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditLogListResponse represents a page of audit logs
+type AuditLogListResponse struct {
+	Items  []*AuditLogResponse `json:"items"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
@@ -1,22 +1,35 @@
 package dto
 
+import "time"
+
 // RegisterRequest represents the registration request payload
 type RegisterRequest struct {
 	FullName string `json:"full_name" binding:"required,min=2,max=100"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6,max=100"`
+	Password string `json:"password" binding:"required,min=6,max=100" secret:"true"`
+
+	// Client is the kind of app signing up; the tokens are issued to it (default web)
+	Client string `json:"client" binding:"omitempty,oneof=web web-admin mobile bot"`
 }
 
 // LoginRequest represents the login request payload
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Password string `json:"password" binding:"required" secret:"true"`
+
+	// Client is the kind of app signing in; the tokens are issued to it (default web)
+	Client string `json:"client" binding:"omitempty,oneof=web web-admin mobile bot"`
+}
+
+// RefreshTokenRequest represents the token refresh request payload
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" secret:"true"`
 }
 
 // AuthResponse represents the authentication response
 type AuthResponse struct {
-	AccessToken  string    `json:"access_token"`
-	RefreshToken string    `json:"refresh_token,omitempty"`
+	AccessToken  string    `json:"access_token" secret:"true"`
+	RefreshToken string    `json:"refresh_token,omitempty" secret:"true"`
 	TokenType    string    `json:"token_type"`
 	ExpiresIn    int64     `json:"expires_in"`
 	User         *UserInfo `json:"user"`
@@ -30,3 +43,10 @@ type UserInfo struct {
 	PhoneNumber *string `json:"phone_number,omitempty"`
 	Image       *string `json:"image,omitempty"`
 }
+
+// DemoAuthResponse represents a demo session; the account and its data are
+// deleted at expires_at and no refresh token is issued
+type DemoAuthResponse struct {
+	AuthResponse
+	ExpiresAt time.Time `json:"expires_at"`
+}
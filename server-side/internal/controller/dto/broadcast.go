@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+// BroadcastAudienceRequest filters the recipients of a broadcast.
+// Omitting it sends the broadcast to every user.
+type BroadcastAudienceRequest struct {
+	UserIDs          []string   `json:"user_ids" binding:"omitempty,dive,uuid"`
+	Roles            []string   `json:"roles" binding:"omitempty,dive,oneof=user support admin"`
+	RegisteredAfter  *time.Time `json:"registered_after"`
+	RegisteredBefore *time.Time `json:"registered_before"`
+}
+
+// CreateBroadcastRequest represents the payload for sending an announcement.
+// Channels are tried in order for each recipient until one succeeds.
+type CreateBroadcastRequest struct {
+	Title    string                   `json:"title" binding:"required,min=1,max=200"`
+	Body     string                   `json:"body" binding:"required,min=1,max=4000"`
+	Channels []string                 `json:"channels" binding:"required,min=1,dive,oneof=in_app whatsapp email telegram push"`
+	Audience BroadcastAudienceRequest `json:"audience"`
+}
+
+// ListBroadcastDeliveriesQuery represents the query parameters for listing deliveries
+type ListBroadcastDeliveriesQuery struct {
+	PageQuery
+	Status string `form:"status" binding:"omitempty,oneof=pending sending sent failed skipped"`
+}
+
+// BroadcastResponse represents a broadcast with its delivery counts by status
+type BroadcastResponse struct {
+	ID             string                   `json:"id"`
+	Title          string                   `json:"title"`
+	Body           string                   `json:"body"`
+	Channels       []string                 `json:"channels"`
+	Audience       BroadcastAudienceRequest `json:"audience"`
+	Status         string                   `json:"status"`
+	RecipientCount int                      `json:"recipient_count"`
+	DeliveryCounts map[string]int           `json:"delivery_counts,omitempty"`
+	CreatedBy      string                   `json:"created_by"`
+	CompletedAt    *time.Time               `json:"completed_at"`
+	CreatedAt      time.Time                `json:"created_at"`
+}
+
+// BroadcastDeliveryResponse represents the delivery of a broadcast to one recipient
+type BroadcastDeliveryResponse struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	Channel   *string    `json:"channel"`
+	Status    string     `json:"status"`
+	Attempts  int        `json:"attempts"`
+	LastError *string    `json:"last_error"`
+	SentAt    *time.Time `json:"sent_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// CreateBudgetRequest represents the payload for creating a category budget
+type CreateBudgetRequest struct {
+	Category string  `json:"category" binding:"required,max=100"`
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+	Currency string  `json:"currency" binding:"omitempty,currency"`
+	Hard     bool    `json:"hard"`
+}
+
+// UpdateBudgetRequest represents the payload for replacing a budget.
+// Version must match the stored version (optimistic locking).
+type UpdateBudgetRequest struct {
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+	Currency string  `json:"currency" binding:"omitempty,currency"`
+	Hard     bool    `json:"hard"`
+	Version  *int    `json:"version" binding:"required,min=0"`
+}
+
+// ListBudgetOverridesQuery represents the query parameters for listing budget overrides
+type ListBudgetOverridesQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
+
+// BudgetResponse represents a budget and its spending in the current month
+type BudgetResponse struct {
+	ID          string    `json:"id"`
+	Category    string    `json:"category"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	Hard        bool      `json:"hard"`
+	Spent       float64   `json:"spent"`
+	Remaining   float64   `json:"remaining"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BudgetOverrideResponse represents a money flow recorded over a hard budget
+type BudgetOverrideResponse struct {
+	ID          string    `json:"id"`
+	BudgetID    string    `json:"budget_id"`
+	MoneyFlowID string    `json:"money_flow_id"`
+	Currency    string    `json:"currency"`
+	Amount      float64   `json:"amount"`
+	Spent       float64   `json:"spent"`
+	Cap         float64   `json:"cap"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BudgetOverrideListResponse represents a page of budget overrides
+type BudgetOverrideListResponse struct {
+	Items  []*BudgetOverrideResponse `json:"items"`
+	Limit  int                       `json:"limit"`
+	Offset int                       `json:"offset"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// SuggestCategoryQuery represents the description a category is suggested for
+type SuggestCategoryQuery struct {
+	Text string `form:"text" binding:"required,max=500"`
+}
+
+// CategorySuggestionResponse represents a suggested category. Source is "history"
+// when similar money flows were filed in the category, or "ai" when a language
+// model picked it.
+type CategorySuggestionResponse struct {
+	Category   string  `json:"category"`
+	Confidence float64 `json:"confidence"`
+	Source     string  `json:"source"`
+}
+
+// CategorySuggestionsResponse represents the suggested categories, most likely first.
+// It is empty when nothing fits.
+type CategorySuggestionsResponse struct {
+	Suggestions []*CategorySuggestionResponse `json:"suggestions"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+// ConfigSettingResponse represents a setting of the effective configuration. The
+// value of a secret is redacted; a reloadable setting changes on SIGHUP without a
+// restart.
+type ConfigSettingResponse struct {
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Secret     bool   `json:"secret"`
+	Reloadable bool   `json:"reloadable"`
+}
+
+// ConfigResponse represents the effective configuration of this instance
+type ConfigResponse struct {
+	Settings []*ConfigSettingResponse `json:"settings"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// CurrencyResponse represents a currency money can be recorded in
+type CurrencyResponse struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Exponent int    `json:"exponent"` // decimal places of amounts, e.g. 2 for cents
+}
+
+// CurrencyCatalogResponse represents every supported currency
+type CurrencyCatalogResponse struct {
+	Currencies []*CurrencyResponse `json:"currencies"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// DashboardResponse represents the current user's spending this month converted to their
+// default currency, with the categories spent most on, their budgets, their latest
+// money flows, and their active goals. Unconverted lists currencies without an exchange rate, left out of total
+// and top_categories.
+type DashboardResponse struct {
+	Currency         string                    `json:"currency"`
+	PeriodStart      time.Time                 `json:"period_start"`
+	PeriodEnd        time.Time                 `json:"period_end"`
+	Total            float64                   `json:"total"`
+	Count            int64                     `json:"count"`
+	TopCategories    []*CategoryReportResponse `json:"top_categories"`
+	Budgets          []*BudgetResponse         `json:"budgets"`
+	RecentMoneyFlows []*MoneyFlowResponse      `json:"recent_money_flows"`
+	ActiveGoals      []*GoalResponse           `json:"active_goals"`
+	Unconverted      []string                  `json:"unconverted"`
+}
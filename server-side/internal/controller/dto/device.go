@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// RegisterDeviceRequest represents the payload for registering a device for push notifications
+type RegisterDeviceRequest struct {
+	Token    string  `json:"token" binding:"required,max=4096" secret:"true"`
+	Platform string  `json:"platform" binding:"required,oneof=android ios web"`
+	Name     *string `json:"name" binding:"omitempty,max=100"`
+}
+
+// DeviceResponse represents a device that receives push notifications. The token is
+// not returned.
+type DeviceResponse struct {
+	ID         string    `json:"id"`
+	Platform   string    `json:"platform"`
+	Name       *string   `json:"name"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// ErrorCodeResponse represents an error code clients may receive in errors.code
+type ErrorCodeResponse struct {
+	Code        string `json:"code"`
+	HTTPStatus  int    `json:"http_status"`
+	Description string `json:"description"`
+	DocURL      string `json:"doc_url"`
+}
+
+// ErrorCatalogResponse represents every error code of the API
+type ErrorCatalogResponse struct {
+	Errors []*ErrorCodeResponse `json:"errors"`
+}
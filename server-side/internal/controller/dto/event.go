@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// EventResponse represents a real-time event, sent as the data of a server-sent event
+type EventResponse struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
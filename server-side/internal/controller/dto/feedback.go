@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// SubmitFeedbackRequest represents a feedback or bug report sent by a user. Clients
+// send the request ID (X-Request-ID) of the call the user reports on, if any.
+type SubmitFeedbackRequest struct {
+	Message       string `json:"message" binding:"required,min=1,max=4000"`
+	Kind          string `json:"kind" binding:"omitempty,oneof=feedback bug"`
+	AppVersion    string `json:"app_version" binding:"omitempty,max=50"`
+	LastRequestID string `json:"last_request_id" binding:"omitempty,max=100"`
+}
+
+// ListFeedbackQuery represents the query parameters for listing feedback
+type ListFeedbackQuery struct {
+	PageQuery
+	Kind   string `form:"kind" binding:"omitempty,oneof=feedback bug"`
+	Source string `form:"source" binding:"omitempty,oneof=api chat"`
+	UserID string `form:"user_id" binding:"omitempty,uuid"`
+}
+
+// FeedbackResponse represents a feedback with its context
+type FeedbackResponse struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	Kind          string     `json:"kind"`
+	Source        string     `json:"source"`
+	Message       string     `json:"message"`
+	RequestID     string     `json:"request_id"`
+	LastRequestID *string    `json:"last_request_id"`
+	AppVersion    *string    `json:"app_version"`
+	UserAgent     *string    `json:"user_agent"`
+	ForwardedAt   *time.Time `json:"forwarded_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// CreateGoalRequest represents the payload for creating a savings goal. Deadline is a
+// date (YYYY-MM-DD).
+type CreateGoalRequest struct {
+	Name         string  `json:"name" binding:"required,max=100"`
+	Currency     string  `json:"currency" binding:"omitempty,currency"`
+	TargetAmount float64 `json:"target_amount" binding:"required,gt=0"`
+	SavedAmount  float64 `json:"saved_amount" binding:"omitempty,gte=0"`
+	Deadline     *string `json:"deadline" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateGoalRequest represents the payload for replacing a savings goal; archived goals
+// are left off the dashboard. The currency cannot be changed. Version must match the
+// stored version (optimistic locking).
+type UpdateGoalRequest struct {
+	Name         string  `json:"name" binding:"required,max=100"`
+	TargetAmount float64 `json:"target_amount" binding:"required,gt=0"`
+	SavedAmount  float64 `json:"saved_amount" binding:"omitempty,gte=0"`
+	Deadline     *string `json:"deadline" binding:"omitempty,datetime=2006-01-02"`
+	Archived     bool    `json:"archived"`
+	Version      *int    `json:"version" binding:"required,min=0"`
+}
+
+// ListGoalsQuery represents the query parameters for listing goals
+type ListGoalsQuery struct {
+	IncludeArchived bool `form:"include_archived"`
+}
+
+// GoalResponse represents a savings goal and what remains to be saved
+type GoalResponse struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Currency     string     `json:"currency"`
+	TargetAmount float64    `json:"target_amount"`
+	SavedAmount  float64    `json:"saved_amount"`
+	Remaining    float64    `json:"remaining"`
+	Reached      bool       `json:"reached"`
+	Deadline     *string    `json:"deadline"`
+	ArchivedAt   *time.Time `json:"archived_at"`
+	Version      int        `json:"version"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// CreateGroupRequest represents the payload for creating a group
+type CreateGroupRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// UpdateGroupRequest represents the payload for renaming a group.
+// Version must match the stored version (optimistic locking).
+type UpdateGroupRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	Version *int   `json:"version" binding:"required,min=0"`
+}
+
+// CreateGroupInvitationRequest represents the payload for inviting someone to a group
+type CreateGroupInvitationRequest struct {
+	Role string `json:"role" binding:"omitempty,oneof=admin member"`
+}
+
+// AcceptGroupInvitationRequest represents the payload for joining a group
+type AcceptGroupInvitationRequest struct {
+	Token string `json:"token" binding:"required,max=200" secret:"true"`
+}
+
+// UpdateGroupMemberRequest represents the payload for changing the role of a member
+type UpdateGroupMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member"`
+}
+
+// GroupMemberResponse represents a member of a group
+type GroupMemberResponse struct {
+	UserID   string    `json:"user_id"`
+	FullName string    `json:"full_name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// GroupResponse represents a group and the role of the current user in it.
+// Members is only populated on detail endpoints.
+type GroupResponse struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Role      string                 `json:"role"`
+	Members   []*GroupMemberResponse `json:"members,omitempty"`
+	Version   int                    `json:"version"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// GroupInvitationResponse represents a pending group invitation.
+// Token is only returned when the invitation is created.
+type GroupInvitationResponse struct {
+	ID        string    `json:"id"`
+	Role      string    `json:"role"`
+	Token     string    `json:"token,omitempty" secret:"true"`
+	InvitedBy string    `json:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
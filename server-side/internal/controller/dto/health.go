@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// HealthResponse represents the liveness or readiness of the API
+type HealthResponse struct {
+	Status  string                 `json:"status"`
+	Service string                 `json:"service"`
+	Checks  []*HealthCheckResponse `json:"checks,omitempty"`
+}
+
+// HealthCheckResponse represents the outcome of one readiness check
+type HealthCheckResponse struct {
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Critical   bool      `json:"critical"`
+	DurationMS int64     `json:"duration_ms"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
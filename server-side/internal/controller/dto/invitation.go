@@ -0,0 +1,42 @@
+package dto
+
+import "mime/multipart"
+
+// ImportUsersRequest represents a multipart CSV upload of users to invite. The file has
+// a header row with name (or full_name), email, and optionally phone (or phone_number).
+type ImportUsersRequest struct {
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+// UserImportRowResponse represents the outcome of one row of a user import.
+// UserID is set for created and existing rows, Channel for created rows.
+type UserImportRowResponse struct {
+	Line        int               `json:"line"`
+	Status      string            `json:"status"`
+	FullName    string            `json:"full_name,omitempty"`
+	Email       string            `json:"email,omitempty"`
+	PhoneNumber string            `json:"phone_number,omitempty"`
+	UserID      *string           `json:"user_id,omitempty"`
+	Channel     string            `json:"channel,omitempty"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// ImportUsersResponse represents the outcome of a user import
+type ImportUsersResponse struct {
+	TotalRows  int                      `json:"total_rows"`
+	Created    int                      `json:"created"`
+	Existing   int                      `json:"existing"`
+	Duplicates int                      `json:"duplicates"`
+	Invalid    int                      `json:"invalid"`
+	Rows       []*UserImportRowResponse `json:"rows"`
+}
+
+// AcceptInvitationRequest represents the payload for setting the password of an
+// invited account
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required,max=100" secret:"true"`
+	Password string `json:"password" binding:"required,min=6,max=100" secret:"true"`
+
+	// Client is the kind of app signing in; the tokens are issued to it (default web)
+	Client string `json:"client" binding:"omitempty,oneof=web web-admin mobile bot"`
+}
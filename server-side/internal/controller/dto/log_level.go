@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// UpdateLogLevelRequest represents the payload for changing the log level at runtime.
+// DurationMinutes reverts to the configured level once elapsed; omit it to keep the
+// level until the next change or restart.
+type UpdateLogLevelRequest struct {
+	Level           string `json:"level" binding:"required,oneof=debug info warn error"`
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=1,max=1440"`
+}
+
+// LogLevelResponse represents the current log level of this instance
+type LogLevelResponse struct {
+	Level        string     `json:"level"`
+	DefaultLevel string     `json:"default_level"`
+	ResetAt      *time.Time `json:"reset_at"`
+}
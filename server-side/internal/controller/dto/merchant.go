@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// ListMerchantsQuery represents the query parameters for listing and searching merchants
+type ListMerchantsQuery struct {
+	PageQuery
+	Query string `form:"q" binding:"omitempty,max=100"`
+}
+
+// UpdateMerchantRequest represents the payload for renaming a merchant
+type UpdateMerchantRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// MerchantRuleRequest represents the payload for creating or replacing a merchant rule.
+// Payee names containing the words of pattern are linked to the merchant named merchant,
+// created when new.
+type MerchantRuleRequest struct {
+	Pattern  string `json:"pattern" binding:"required,max=100"`
+	Merchant string `json:"merchant" binding:"required,max=100"`
+}
+
+// MerchantResponse represents a merchant
+type MerchantResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MerchantRuleResponse represents a merchant rule with its merchant
+type MerchantRuleResponse struct {
+	ID        string            `json:"id"`
+	Pattern   string            `json:"pattern"`
+	Merchant  *MerchantResponse `json:"merchant"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
@@ -0,0 +1,295 @@
+package dto
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"time"
+)
+
+// CreateMoneyFlowRequest represents the payload for recording a money flow
+type CreateMoneyFlowRequest struct {
+	Amount      float64  `json:"amount" binding:"required,gt=0"`
+	Currency    string   `json:"currency" binding:"omitempty,currency"`
+	Category    *string  `json:"category" binding:"omitempty,max=100"`
+	Description *string  `json:"description" binding:"omitempty,max=500"`
+	Tags        []string `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+	Note        *string  `json:"note" binding:"omitempty,max=10240"`
+	WalletID    *string  `json:"wallet_id" binding:"omitempty,uuid"`
+	GroupID     *string  `json:"group_id" binding:"omitempty,uuid"`
+
+	// Merchant is the payee as written, e.g. "INDOMARET TBK 0123", linked to the
+	// merchant it normalizes to
+	Merchant *string `json:"merchant" binding:"omitempty,max=100"`
+
+	// Latitude and Longitude locate where the money was spent, in WGS 84 degrees
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,min=-180,max=180"`
+	PlaceName *string  `json:"place_name" binding:"omitempty,max=100"`
+
+	// OverrideBudget confirms recording the money flow over a hard budget
+	OverrideBudget bool `json:"override_budget"`
+
+	// AllowDuplicate confirms recording a money flow that looks like one recorded the
+	// same day; only creation checks for duplicates
+	AllowDuplicate bool `json:"allow_duplicate"`
+}
+
+// UpdateMoneyFlowRequest represents the payload for replacing a money flow.
+// Version must match the stored version (optimistic locking); it is required unless
+// the request sends If-Match, which takes precedence.
+type UpdateMoneyFlowRequest struct {
+	CreateMoneyFlowRequest
+	Version *int `json:"version" binding:"omitempty,min=0"`
+}
+
+// PatchMoneyFlowRequest represents the payload for changing some fields of a money flow.
+// Fields left out are kept. Empty strings clear category, description, wallet_id,
+// group_id, merchant, and place_name, empty tags remove all tags, and an empty note
+// removes the note. Latitude and longitude are changed together.
+// Version must match the stored version (optimistic locking); it is required unless
+// the request sends If-Match, which takes precedence.
+type PatchMoneyFlowRequest struct {
+	Amount      *float64 `json:"amount" binding:"omitempty,gt=0"`
+	Currency    *string  `json:"currency" binding:"omitempty,currency"`
+	Category    *string  `json:"category" binding:"omitempty,max=100"`
+	Description *string  `json:"description" binding:"omitempty,max=500"`
+	Tags        []string `json:"tags" binding:"omitempty,max=20,dive,min=1,max=50"`
+	Note        *string  `json:"note" binding:"omitempty,max=10240"`
+	WalletID    *string  `json:"wallet_id" binding:"omitempty,uuid|len=0"`
+	GroupID     *string  `json:"group_id" binding:"omitempty,uuid|len=0"`
+	Merchant    *string  `json:"merchant" binding:"omitempty,max=100"`
+	Latitude    *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude   *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,min=-180,max=180"`
+	PlaceName   *string  `json:"place_name" binding:"omitempty,max=100"`
+	Version     *int     `json:"version" binding:"omitempty,min=0"`
+
+	// OverrideBudget confirms saving the change over a hard budget
+	OverrideBudget bool `json:"override_budget"`
+}
+
+// ListMoneyFlowsQuery represents the query parameters for listing money flows
+type ListMoneyFlowsQuery struct {
+	Query  string `form:"q" binding:"omitempty,max=200"`
+	Tag    string `form:"tag" binding:"omitempty,max=50"`
+	Wallet string `form:"wallet_id" binding:"omitempty,uuid"`
+	Group  string `form:"group_id" binding:"omitempty,uuid"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int    `form:"offset" binding:"omitempty,min=0"`
+}
+
+// MoneyFlowResponse represents a money flow.
+// Note and Merchant are only populated on detail endpoints.
+type MoneyFlowResponse struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	WalletID    *string   `json:"wallet_id"`
+	TransferID  *string   `json:"transfer_id,omitempty"`
+	GroupID     *string   `json:"group_id"`
+	MerchantID  *string   `json:"merchant_id"`
+	Merchant    *string   `json:"merchant,omitempty"`
+	UserID      string    `json:"user_id"`
+	Category    *string   `json:"category"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	Description *string   `json:"description"`
+	Tags        []string  `json:"tags"`
+	Latitude    *float64  `json:"latitude"`
+	Longitude   *float64  `json:"longitude"`
+	PlaceName   *string   `json:"place_name"`
+	Note        *string   `json:"note,omitempty"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Warning and DuplicateOf are set on creation when the money flow looks like one
+	// already recorded the same day
+	Warning     *string `json:"warning,omitempty"`
+	DuplicateOf *string `json:"duplicate_of,omitempty"`
+}
+
+// CurrencyTotalResponse represents the total of a user's money flows in one currency
+type CurrencyTotalResponse struct {
+	Currency string  `json:"currency"`
+	Total    float64 `json:"total"`
+	Count    int64   `json:"count"`
+}
+
+// MoneyFlowSummaryQuery represents the query parameters of a money flow summary. From
+// and to are the first and last day of an optional period, in the user's time zone.
+type MoneyFlowSummaryQuery struct {
+	From string `form:"from" binding:"required_with=To,omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"required_with=From,omitempty,datetime=2006-01-02"`
+}
+
+// MoneyFlowSummaryResponse represents a user's totals per currency.
+// Warning is set when mixed currencies are found outside single-currency mode.
+// Period is set when a period was requested.
+type MoneyFlowSummaryResponse struct {
+	Totals             []*CurrencyTotalResponse   `json:"totals"`
+	DefaultCurrency    string                     `json:"default_currency"`
+	SingleCurrencyMode bool                       `json:"single_currency_mode"`
+	MixedCurrencies    bool                       `json:"mixed_currencies"`
+	Warning            *string                    `json:"warning,omitempty"`
+	Period             *PeriodFlowSummaryResponse `json:"period,omitempty"`
+}
+
+// PeriodFlowSummaryResponse represents a user's headline numbers per currency in a period
+type PeriodFlowSummaryResponse struct {
+	PeriodStart time.Time              `json:"period_start"`
+	PeriodEnd   time.Time              `json:"period_end"`
+	Totals      []*FlowSummaryResponse `json:"totals"`
+}
+
+// FlowSummaryResponse represents a user's income, expense, and their difference in one
+// currency. Income stays zero while money flows record spending only.
+type FlowSummaryResponse struct {
+	Currency string  `json:"currency"`
+	Income   float64 `json:"income"`
+	Expense  float64 `json:"expense"`
+	Net      float64 `json:"net"`
+	Count    int64   `json:"count"`
+}
+
+// MoneyFlowListResponse represents a page of money flows.
+// Total is the number of matching money flows, left out of note searches.
+type MoneyFlowListResponse struct {
+	Items  []*MoneyFlowResponse `json:"items"`
+	Total  *int64               `json:"total,omitempty"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// ExportMoneyFlowsQuery represents the query parameters for exporting a month of money flows.
+// Format is checked by the export service against the registered exporters.
+type ExportMoneyFlowsQuery struct {
+	Format string `form:"format" binding:"omitempty,max=10"`
+	Month  string `form:"month" binding:"omitempty,datetime=2006-01"`
+}
+
+// ImportMoneyFlowsRequest represents a multipart CSV upload and how its columns map to
+// money flow fields. Columns are named by their header.
+type ImportMoneyFlowsRequest struct {
+	File              *multipart.FileHeader `form:"file" binding:"required"`
+	DateColumn        string                `form:"date_column" binding:"required,max=100"`
+	AmountColumn      string                `form:"amount_column" binding:"required,max=100"`
+	DescriptionColumn string                `form:"description_column" binding:"omitempty,max=100"`
+	CategoryColumn    string                `form:"category_column" binding:"omitempty,max=100"`
+	CurrencyColumn    string                `form:"currency_column" binding:"omitempty,max=100"`
+	DateFormat        string                `form:"date_format" binding:"omitempty,oneof=YYYY-MM-DD DD/MM/YYYY MM/DD/YYYY DD-MM-YYYY DD.MM.YYYY"`
+	Delimiter         string                `form:"delimiter" binding:"omitempty,oneof=comma semicolon tab pipe"`
+	DecimalSeparator  string                `form:"decimal_separator" binding:"omitempty,oneof=dot comma"`
+	Currency          string                `form:"currency" binding:"omitempty,currency"`
+
+	// NegativeExpenses imports negative amounts as expenses and skips positive ones
+	NegativeExpenses bool `form:"negative_expenses"`
+
+	// DryRun validates the file and previews the import without recording anything
+	DryRun bool `form:"dry_run"`
+}
+
+// ImportRowResponse represents the outcome of one row of an imported file.
+// MoneyFlowID is only set for imported rows.
+type ImportRowResponse struct {
+	Line        int               `json:"line"`
+	Status      string            `json:"status"`
+	Date        *time.Time        `json:"date,omitempty"`
+	Amount      *float64          `json:"amount,omitempty"`
+	Currency    string            `json:"currency,omitempty"`
+	Category    *string           `json:"category,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	MoneyFlowID *string           `json:"money_flow_id,omitempty"`
+	Errors      map[string]string `json:"errors,omitempty"`
+}
+
+// ImportMoneyFlowsResponse represents the outcome of an import
+type ImportMoneyFlowsResponse struct {
+	DryRun     bool                 `json:"dry_run"`
+	TotalRows  int                  `json:"total_rows"`
+	Imported   int                  `json:"imported"`
+	Valid      int                  `json:"valid"`
+	Duplicates int                  `json:"duplicates"`
+	Skipped    int                  `json:"skipped"`
+	Invalid    int                  `json:"invalid"`
+	Rows       []*ImportRowResponse `json:"rows"`
+}
+
+// BulkCreateMoneyFlowsRequest represents up to 100 money flows to record at once.
+// Each item has the fields of CreateMoneyFlowRequest and is validated on its own, so
+// an invalid item fails only itself.
+type BulkCreateMoneyFlowsRequest struct {
+	Items []json.RawMessage `json:"items" binding:"required,min=1,max=100"`
+}
+
+// BulkItemError represents why an item of a bulk request failed
+type BulkItemError struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// BulkItemResponse represents the outcome of one item of a bulk request, at the same
+// index as the item. Exactly one of MoneyFlow and Error is set.
+type BulkItemResponse struct {
+	Index     int                `json:"index"`
+	Status    string             `json:"status"` // created or failed
+	MoneyFlow *MoneyFlowResponse `json:"money_flow,omitempty"`
+	Error     *BulkItemError     `json:"error,omitempty"`
+}
+
+// BulkCreateMoneyFlowsResponse represents the outcome of a bulk request
+type BulkCreateMoneyFlowsResponse struct {
+	Created int                 `json:"created"`
+	Failed  int                 `json:"failed"`
+	Results []*BulkItemResponse `json:"results"`
+}
+
+// ReconcileMoneyFlowsRequest represents a bank statement to reconcile: its period and
+// balances, and optionally its lines as a CSV file mapped like ImportMoneyFlowsRequest
+type ReconcileMoneyFlowsRequest struct {
+	PeriodStart    string   `form:"period_start" binding:"required,datetime=2006-01-02"`
+	PeriodEnd      string   `form:"period_end" binding:"required,datetime=2006-01-02"`
+	Currency       string   `form:"currency" binding:"omitempty,currency"`
+	OpeningBalance *float64 `form:"opening_balance" binding:"required"`
+	ClosingBalance *float64 `form:"closing_balance" binding:"required"`
+
+	File              *multipart.FileHeader `form:"file"`
+	DateColumn        string                `form:"date_column" binding:"required_with=File,max=100"`
+	AmountColumn      string                `form:"amount_column" binding:"required_with=File,max=100"`
+	DescriptionColumn string                `form:"description_column" binding:"omitempty,max=100"`
+	CategoryColumn    string                `form:"category_column" binding:"omitempty,max=100"`
+	CurrencyColumn    string                `form:"currency_column" binding:"omitempty,max=100"`
+	DateFormat        string                `form:"date_format" binding:"omitempty,oneof=YYYY-MM-DD DD/MM/YYYY MM/DD/YYYY DD-MM-YYYY DD.MM.YYYY"`
+	Delimiter         string                `form:"delimiter" binding:"omitempty,oneof=comma semicolon tab pipe"`
+	DecimalSeparator  string                `form:"decimal_separator" binding:"omitempty,oneof=dot comma"`
+
+	// NegativeExpenses reads negative amounts as expenses and positive ones as income
+	NegativeExpenses bool `form:"negative_expenses"`
+}
+
+// StatementLineResponse represents a statement expense that matches no recorded money
+// flow. MoneyFlow can be sent to POST /api/v1/money-flows to record it.
+type StatementLineResponse struct {
+	Line      int                     `json:"line"`
+	Date      string                  `json:"date"`
+	MoneyFlow *MoneyFlowDraftResponse `json:"money_flow"`
+}
+
+// ReconciliationResponse represents a statement compared with the recorded money flows.
+// Statement fields are null when no statement file was uploaded.
+type ReconciliationResponse struct {
+	PeriodStart            string                   `json:"period_start"`
+	PeriodEnd              string                   `json:"period_end"`
+	Currency               string                   `json:"currency"`
+	OpeningBalance         float64                  `json:"opening_balance"`
+	ClosingBalance         float64                  `json:"closing_balance"`
+	RecordedTotal          float64                  `json:"recorded_total"`
+	RecordedCount          int                      `json:"recorded_count"`
+	StatementIncome        *float64                 `json:"statement_income"`
+	StatementExpenses      *float64                 `json:"statement_expenses"`
+	ExpectedClosingBalance float64                  `json:"expected_closing_balance"`
+	Discrepancy            float64                  `json:"discrepancy"`
+	Balanced               bool                     `json:"balanced"`
+	Suggestions            []*StatementLineResponse `json:"suggestions"`
+	UnmatchedMoneyFlows    []*MoneyFlowResponse     `json:"unmatched_money_flows"`
+	IgnoredRows            []*ImportRowResponse     `json:"ignored_rows"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+// CreateMoneyFlowShareRequest represents the request to share a money flow. TTLHours
+// defaults to SHARE_TTL.
+type CreateMoneyFlowShareRequest struct {
+	TTLHours    int  `json:"ttl_hours" binding:"omitempty,min=1,max=720"`
+	IncludeNote bool `json:"include_note"`
+}
+
+// MoneyFlowShareResponse represents a created share link
+type MoneyFlowShareResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	IncludeNote bool      `json:"include_note"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// RevokeMoneyFlowShareResponse represents the revoked share links of a money flow
+type RevokeMoneyFlowShareResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// SharedMoneyFlowQuery represents the signed query of a share link
+type SharedMoneyFlowQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required,hexadecimal"`
+}
+
+// SharedMoneyFlowResponse represents the read-only view of a shared money flow. It
+// leaves out the IDs and coordinates of the money flow.
+type SharedMoneyFlowResponse struct {
+	Kind        string    `json:"kind"`
+	Amount      float64   `json:"amount"`
+	Currency    string    `json:"currency"`
+	Category    *string   `json:"category"`
+	Description *string   `json:"description"`
+	Tags        []string  `json:"tags"`
+	Merchant    *string   `json:"merchant"`
+	PlaceName   *string   `json:"place_name"`
+	Note        *string   `json:"note,omitempty"`
+	SharedBy    string    `json:"shared_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// NotificationResponse represents an in-app notification
+type NotificationResponse struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationListResponse represents a page of notifications
+type NotificationListResponse struct {
+	Items  []*NotificationResponse `json:"items"`
+	Limit  int                     `json:"limit"`
+	Offset int                     `json:"offset"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+// RequestPasswordResetRequest represents the payload for emailing a password reset link
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ConfirmPasswordResetRequest represents the payload for setting a new password with
+// the token of a password reset link
+type ConfirmPasswordResetRequest struct {
+	Token       string `json:"token" binding:"required,max=100" secret:"true"`
+	NewPassword string `json:"new_password" binding:"required,min=6,max=100" secret:"true"`
+}
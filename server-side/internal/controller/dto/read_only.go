@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UpdateReadOnlyRequest represents the payload for turning read-only mode on or off.
+// Reason is shown to admins and logged; it is dropped when turning the mode off.
+type UpdateReadOnlyRequest struct {
+	Enabled *bool  `json:"enabled" binding:"required"`
+	Reason  string `json:"reason" binding:"max=500"`
+}
+
+// ReadOnlyResponse represents the state of read-only mode. Forced is set when the
+// READ_ONLY setting keeps the instance read-only whatever the switch says.
+type ReadOnlyResponse struct {
+	Enabled   bool       `json:"enabled"`
+	Forced    bool       `json:"forced"`
+	Reason    string     `json:"reason,omitempty"`
+	UpdatedBy *uuid.UUID `json:"updated_by"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "mime/multipart"
+
+// ScanReceiptRequest represents a multipart upload of a receipt photo
+type ScanReceiptRequest struct {
+	Image *multipart.FileHeader `form:"image" binding:"required"`
+}
+
+// ReceiptItemResponse represents a line of a scanned receipt
+type ReceiptItemResponse struct {
+	Name     string  `json:"name"`
+	Quantity float64 `json:"quantity"`
+	Amount   float64 `json:"amount"`
+}
+
+// ReceiptResponse represents what was read from a receipt. Date is YYYY-MM-DD.
+type ReceiptResponse struct {
+	Merchant *string                `json:"merchant"`
+	Date     *string                `json:"date"`
+	Total    float64                `json:"total"`
+	Currency string                 `json:"currency"`
+	Items    []*ReceiptItemResponse `json:"items"`
+}
+
+// MoneyFlowDraftResponse represents a suggested money flow, in the shape of
+// CreateMoneyFlowRequest
+type MoneyFlowDraftResponse struct {
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	Category    *string `json:"category"`
+	Description *string `json:"description"`
+	Note        *string `json:"note"`
+}
+
+// ScanReceiptResponse represents a scanned receipt and the money flow drafted from it.
+// Nothing is saved until the draft is sent to POST /api/v1/money-flows.
+type ScanReceiptResponse struct {
+	Receipt   *ReceiptResponse        `json:"receipt"`
+	MoneyFlow *MoneyFlowDraftResponse `json:"money_flow"`
+}
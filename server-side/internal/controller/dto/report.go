@@ -0,0 +1,199 @@
+package dto
+
+import "time"
+
+// ReportSummaryQuery represents the query parameters of a spending report. Without a
+// month or week, the report covers all time; without a currency, the user's default currency.
+type ReportSummaryQuery struct {
+	Currency string `form:"currency" binding:"omitempty,len=3,uppercase"`
+	Month    string `form:"month" binding:"omitempty,datetime=2006-01"`
+	Week     string `form:"week" binding:"omitempty,datetime=2006-01-02,excluded_with=Month"` // any day of the week
+	GroupID  string `form:"group_id" binding:"omitempty,uuid"`                                // a group's shared ledger
+}
+
+// ReportSummaryResponse represents spending converted to one currency.
+// Unconverted lists currencies without an exchange rate, left out of total and categories.
+type ReportSummaryResponse struct {
+	Currency    string                    `json:"currency"`
+	PeriodStart *time.Time                `json:"period_start"`
+	PeriodEnd   time.Time                 `json:"period_end"`
+	Total       float64                   `json:"total"`
+	Count       int64                     `json:"count"`
+	RateDate    *string                   `json:"rate_date"`
+	Categories  []*CategoryReportResponse `json:"categories"`
+	Currencies  []*CurrencyReportResponse `json:"currencies"`
+	Unconverted []string                  `json:"unconverted"`
+}
+
+// CategoryReportResponse represents the converted spending in a category
+type CategoryReportResponse struct {
+	Category *string `json:"category"`
+	Total    float64 `json:"total"`
+	Count    int64   `json:"count"`
+}
+
+// CurrencyReportResponse represents the spending recorded in one currency and its
+// converted value
+type CurrencyReportResponse struct {
+	Currency  string   `json:"currency"`
+	Total     float64  `json:"total"`
+	Count     int64    `json:"count"`
+	Rate      *float64 `json:"rate"`
+	Converted *float64 `json:"converted"`
+}
+
+// TrendQuery represents the query parameters of a spending trend. From and to are any
+// day of the first and last period; without them, the trend ends with the current period.
+type TrendQuery struct {
+	Interval string `form:"interval" binding:"omitempty,oneof=day week month"`
+	From     string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To       string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	Currency string `form:"currency" binding:"omitempty,len=3,uppercase"`
+	GroupID  string `form:"group_id" binding:"omitempty,uuid"` // a group's shared ledger
+}
+
+// TrendResponse represents spending per period converted to one currency.
+// Unconverted lists currencies without an exchange rate, left out of the points.
+type TrendResponse struct {
+	Currency    string                `json:"currency"`
+	Interval    string                `json:"interval"`
+	RateDate    *string               `json:"rate_date"`
+	Points      []*TrendPointResponse `json:"points"`
+	Unconverted []string              `json:"unconverted"`
+}
+
+// TrendPointResponse represents the spending in a period and its change from the period before
+type TrendPointResponse struct {
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+	Total         float64   `json:"total"`
+	Count         int64     `json:"count"`
+	Change        float64   `json:"change"`
+	ChangePercent *float64  `json:"change_percent"`
+}
+
+// PlaceReportQuery represents the query parameters of the spending per place. Latitude
+// and longitude narrow it down to the places within radius meters, 1000 by default.
+// Limit is the number of places listed, 20 by default.
+type PlaceReportQuery struct {
+	Currency  string   `form:"currency" binding:"omitempty,len=3,uppercase"`
+	Month     string   `form:"month" binding:"omitempty,datetime=2006-01"`
+	Week      string   `form:"week" binding:"omitempty,datetime=2006-01-02,excluded_with=Month"` // any day of the week
+	Latitude  *float64 `form:"latitude" binding:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude *float64 `form:"longitude" binding:"required_with=Latitude,omitempty,min=-180,max=180"`
+	Radius    float64  `form:"radius" binding:"omitempty,excluded_without=Latitude,gt=0,max=50000"`
+	Limit     int      `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// PlaceReportResponse represents spending per place converted to one currency.
+// Unconverted lists currencies without an exchange rate, left out of total and places.
+type PlaceReportResponse struct {
+	Currency    string                `json:"currency"`
+	PeriodStart *time.Time            `json:"period_start"`
+	PeriodEnd   time.Time             `json:"period_end"`
+	Near        *NearbyResponse       `json:"near"`
+	Total       float64               `json:"total"`
+	Count       int64                 `json:"count"`
+	RateDate    *string               `json:"rate_date"`
+	Places      []*PlaceTotalResponse `json:"places"`
+	Unconverted []string              `json:"unconverted"`
+}
+
+// NearbyResponse represents the area a report is narrowed down to
+type NearbyResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Radius    float64 `json:"radius"` // in meters
+}
+
+// PlaceTotalResponse represents the converted spending at a place. Distance is in
+// meters from the center of the report's area, when narrowed down to one.
+type PlaceTotalResponse struct {
+	PlaceName *string  `json:"place_name"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Distance  *float64 `json:"distance,omitempty"`
+	Total     float64  `json:"total"`
+	Count     int64    `json:"count"`
+}
+
+// MerchantReportQuery represents the query parameters of the spending per merchant.
+// Limit is the number of merchants listed, 20 by default.
+type MerchantReportQuery struct {
+	Currency string `form:"currency" binding:"omitempty,len=3,uppercase"`
+	Month    string `form:"month" binding:"omitempty,datetime=2006-01"`
+	Week     string `form:"week" binding:"omitempty,datetime=2006-01-02,excluded_with=Month"` // any day of the week
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// MerchantReportResponse represents spending per merchant converted to one currency.
+// Unconverted lists currencies without an exchange rate, left out of total and merchants.
+type MerchantReportResponse struct {
+	Currency    string                   `json:"currency"`
+	PeriodStart *time.Time               `json:"period_start"`
+	PeriodEnd   time.Time                `json:"period_end"`
+	Total       float64                  `json:"total"`
+	Count       int64                    `json:"count"`
+	RateDate    *string                  `json:"rate_date"`
+	Merchants   []*MerchantTotalResponse `json:"merchants"`
+	Unconverted []string                 `json:"unconverted"`
+}
+
+// MerchantTotalResponse represents the converted spending at a merchant
+type MerchantTotalResponse struct {
+	MerchantID string  `json:"merchant_id"`
+	Name       string  `json:"name"`
+	Total      float64 `json:"total"`
+	Count      int64   `json:"count"`
+}
+
+// ExchangeRatesQuery represents the query parameters for listing exchange rates
+type ExchangeRatesQuery struct {
+	Base string `form:"base" binding:"omitempty,len=3,uppercase"`
+}
+
+// ExchangeRatesResponse represents the price of one unit of base in other currencies.
+// Date is YYYY-MM-DD, null before any rates are fetched.
+type ExchangeRatesResponse struct {
+	Base   string             `json:"base"`
+	Date   *string            `json:"date"`
+	Source string             `json:"source"`
+	Rates  map[string]float64 `json:"rates"`
+}
+
+// DigestQuery represents the query parameters of a digest preview. Without a frequency,
+// the user's digest frequency is previewed.
+type DigestQuery struct {
+	Frequency string `form:"frequency" binding:"omitempty,oneof=weekly monthly"`
+}
+
+// StatementQuery represents the query parameters of a monthly statement preview: the
+// month (default: the month before the current one) and the part of the email shown
+type StatementQuery struct {
+	Month  string `form:"month" binding:"omitempty,datetime=2006-01"`
+	Format string `form:"format" binding:"omitempty,oneof=html text pdf"`
+}
+
+// DigestResponse represents a summary of the spending in a week or month and the
+// notification it is sent as
+type DigestResponse struct {
+	Frequency     string                    `json:"frequency"`
+	Currency      string                    `json:"currency"`
+	PeriodStart   time.Time                 `json:"period_start"`
+	PeriodEnd     time.Time                 `json:"period_end"`
+	Total         float64                   `json:"total"`
+	Count         int64                     `json:"count"`
+	PreviousTotal float64                   `json:"previous_total"`
+	TopCategories []*DigestCategoryResponse `json:"top_categories"`
+	Budgets       []*BudgetResponse         `json:"budgets"`
+	Unconverted   []string                  `json:"unconverted"`
+	Title         string                    `json:"title"`
+	Body          string                    `json:"body"`
+}
+
+// DigestCategoryResponse represents the spending in a category, and in the period before
+type DigestCategoryResponse struct {
+	Category      *string `json:"category"`
+	Total         float64 `json:"total"`
+	PreviousTotal float64 `json:"previous_total"`
+}
@@ -7,11 +7,13 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// ErrorResponse represents an error API response
+// ErrorResponse represents an error API response.
+// RequestID lets clients quote the failing request when contacting support.
 type ErrorResponse struct {
-	Status  string      `json:"status"`
-	Message string      `json:"message"`
-	Errors  interface{} `json:"errors,omitempty"`
+	Status    string      `json:"status"`
+	Message   string      `json:"message"`
+	Errors    interface{} `json:"errors,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
 }
 
 // NewSuccessResponse creates a new success response
@@ -31,3 +33,9 @@ func NewErrorResponse(message string, errors interface{}) *ErrorResponse {
 		Errors:  errors,
 	}
 }
+
+// PageQuery represents the limit/offset query parameters of list endpoints
+type PageQuery struct {
+	Limit  int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int `form:"offset" binding:"omitempty,min=0"`
+}
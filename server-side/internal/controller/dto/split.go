@@ -0,0 +1,80 @@
+package dto
+
+import "time"
+
+// SplitShareRequest represents the share of one member in a split. Percentage is used by
+// percentage splits and Amount by exact splits; equal splits only need the user.
+type SplitShareRequest struct {
+	UserID     string  `json:"user_id" binding:"required,uuid"`
+	Percentage float64 `json:"percentage" binding:"omitempty,gt=0,lte=100"`
+	Amount     float64 `json:"amount" binding:"omitempty,gt=0"`
+}
+
+// SplitMoneyFlowRequest represents the payload for splitting a money flow between group
+// members. Equal splits without shares are split between every member.
+type SplitMoneyFlowRequest struct {
+	Method string               `json:"method" binding:"required,oneof=equal percentage exact"`
+	Shares []*SplitShareRequest `json:"shares" binding:"omitempty,max=100,dive"`
+}
+
+// SplitResponse represents the share of one member in a money flow
+type SplitResponse struct {
+	UserID   string  `json:"user_id"`
+	Method   string  `json:"method"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// MoneyFlowSplitsResponse represents how a money flow is split and who paid it
+type MoneyFlowSplitsResponse struct {
+	MoneyFlowID string           `json:"money_flow_id"`
+	PaidBy      string           `json:"paid_by"`
+	Splits      []*SplitResponse `json:"splits"`
+}
+
+// CreateSettlementRequest represents the payload for recording a payment between members
+type CreateSettlementRequest struct {
+	FromUserID string  `json:"from_user_id" binding:"required,uuid,nefield=ToUserID"`
+	ToUserID   string  `json:"to_user_id" binding:"required,uuid"`
+	Amount     float64 `json:"amount" binding:"required,gt=0"`
+	Currency   string  `json:"currency" binding:"required,currency"`
+}
+
+// SettlementResponse represents a payment between members of a group
+type SettlementResponse struct {
+	ID         string    `json:"id"`
+	FromUserID string    `json:"from_user_id"`
+	ToUserID   string    `json:"to_user_id"`
+	Amount     float64   `json:"amount"`
+	Currency   string    `json:"currency"`
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SettlementListResponse represents a page of settlements
+type SettlementListResponse struct {
+	Items  []*SettlementResponse `json:"items"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+// MemberBalanceResponse represents what a member is owed (positive) or owes (negative)
+type MemberBalanceResponse struct {
+	UserID   string  `json:"user_id"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// DebtResponse represents a payment that settles part of the group's balances
+type DebtResponse struct {
+	FromUserID string  `json:"from_user_id"`
+	ToUserID   string  `json:"to_user_id"`
+	Amount     float64 `json:"amount"`
+	Currency   string  `json:"currency"`
+}
+
+// GroupBalancesResponse represents the balances of a group and the fewest payments that settle them
+type GroupBalancesResponse struct {
+	Balances []*MemberBalanceResponse `json:"balances"`
+	Debts    []*DebtResponse          `json:"debts"`
+}
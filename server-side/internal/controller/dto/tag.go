@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// TagResponse represents a tag with the number of money flows having it
+type TagResponse struct {
+	Tag        string    `json:"tag"`
+	Count      int64     `json:"count"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// RenameTagRequest represents the request body for renaming a tag
+type RenameTagRequest struct {
+	From string `json:"from" binding:"required,max=50"`
+	To   string `json:"to" binding:"required,max=50"`
+}
+
+// MergeTagsRequest represents the request body for merging tags into one
+type MergeTagsRequest struct {
+	From []string `json:"from" binding:"required,min=1,max=20,dive,required,max=50"`
+	To   string   `json:"to" binding:"required,max=50"`
+}
@@ -0,0 +1,116 @@
+package dto
+
+import "time"
+
+// UpdateProfileRequest represents the payload for updating the current user's profile.
+// Omitted fields are left unchanged; an empty image removes the current image.
+type UpdateProfileRequest struct {
+	FullName    *string `json:"full_name" binding:"omitempty,min=2,max=100"`
+	PhoneNumber *string `json:"phone_number" binding:"omitempty,min=6,max=20"`
+	Image       *string `json:"image" binding:"omitempty,max=2048"`
+	DataRegion  *string `json:"data_region" binding:"omitempty,max=32"`
+	Version     *int    `json:"version" binding:"omitempty,min=0"`
+}
+
+// UserProfileResponse represents the current user's profile
+type UserProfileResponse struct {
+	ID          string    `json:"id"`
+	FullName    string    `json:"full_name"`
+	Email       string    `json:"email,omitempty"`
+	PhoneNumber string    `json:"phone_number"`
+	Image       *string   `json:"image"`
+	DataRegion  string    `json:"data_region"`
+	Version     int       `json:"version"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ChangePasswordRequest represents the payload for changing the current user's password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" secret:"true"`
+	NewPassword     string `json:"new_password" binding:"required,min=6,max=100" secret:"true"`
+}
+
+// ReauthenticateRequest represents the payload for confirming the current user's password
+// before a sensitive action
+type ReauthenticateRequest struct {
+	Password string `json:"password" binding:"required" secret:"true"`
+}
+
+// LinkAuthProviderRequest represents the payload for linking an additional auth provider.
+// CredentialSecret proves the credential: the password for email-password, the Google
+// ID token for google (whose subject is the credential ID), or the code sent by
+// SendPhoneVerificationCodeRequest for phone-otp.
+type LinkAuthProviderRequest struct {
+	Provider         string `json:"provider" binding:"required,oneof=email-password google phone-otp"`
+	CredentialID     string `json:"credential_id" binding:"required,max=255"`
+	CredentialSecret string `json:"credential_secret" binding:"max=4096" secret:"true"`
+}
+
+// SendPhoneVerificationCodeRequest represents the payload for sending a code that proves
+// a phone number before it is linked
+type SendPhoneVerificationCodeRequest struct {
+	PhoneNumber string `json:"phone_number" binding:"required,min=6,max=20"`
+}
+
+// PhoneVerificationCodeResponse represents a code sent to a phone number
+type PhoneVerificationCodeResponse struct {
+	PhoneNumber string    `json:"phone_number"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// LinkedProviderResponse represents an auth provider linked to the user account
+type LinkedProviderResponse struct {
+	ID           string    `json:"id"`
+	Provider     string    `json:"provider"`
+	DisplayName  string    `json:"display_name"`
+	CredentialID string    `json:"credential_id"`
+	LinkedAt     time.Time `json:"linked_at"`
+}
+
+// UpdateUserSettingsRequest represents the payload for updating the current user's settings.
+// Omitted fields are left unchanged.
+type UpdateUserSettingsRequest struct {
+	AnalyticsOptOut    *bool   `json:"analytics_opt_out"`
+	DefaultCurrency    *string `json:"default_currency" binding:"omitempty,currency"`
+	SingleCurrencyMode *bool   `json:"single_currency_mode"`
+	Locale             *string `json:"locale" binding:"omitempty,bcp47_language_tag"`
+	Timezone           *string `json:"timezone" binding:"omitempty,timezone"`
+	WeekStart          *string `json:"week_start" binding:"omitempty,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	NotifyWhatsApp     *bool   `json:"notify_whatsapp"`
+	NotifyEmail        *bool   `json:"notify_email"`
+	NotifyTelegram     *bool   `json:"notify_telegram"`
+	NotifyPush         *bool   `json:"notify_push"`
+	TelegramChatID     *string `json:"telegram_chat_id" binding:"omitempty,max=32"`
+	DigestFrequency    *string `json:"digest_frequency" binding:"omitempty,oneof=off weekly monthly"`
+	DuplicateCheck     *string `json:"duplicate_check" binding:"omitempty,oneof=off warn block"`
+	MonthlyStatement   *bool   `json:"monthly_statement"`
+	Version            *int    `json:"version" binding:"omitempty,min=0"`
+
+	// NotificationChannels orders the channels notifications are tried on; an empty
+	// list restores the default order
+	NotificationChannels *[]string `json:"notification_channels" binding:"omitempty,max=5,dive,oneof=push whatsapp email telegram in_app"`
+}
+
+// UserSettingsResponse represents the current user's settings
+type UserSettingsResponse struct {
+	AnalyticsOptOut    bool   `json:"analytics_opt_out"`
+	DefaultCurrency    string `json:"default_currency"`
+	SingleCurrencyMode bool   `json:"single_currency_mode"`
+	Locale             string `json:"locale"`
+	Timezone           string `json:"timezone"`
+	WeekStart          string `json:"week_start"`
+	NotifyWhatsApp     bool   `json:"notify_whatsapp"`
+	NotifyEmail        bool   `json:"notify_email"`
+	NotifyTelegram     bool   `json:"notify_telegram"`
+	NotifyPush         bool   `json:"notify_push"`
+	TelegramChatID     string `json:"telegram_chat_id"`
+	DigestFrequency    string `json:"digest_frequency"`
+	DuplicateCheck     string `json:"duplicate_check"`
+	MonthlyStatement   bool   `json:"monthly_statement"`
+	Version            int    `json:"version"`
+
+	// NotificationChannels is the order notifications are tried in, leaving out the
+	// channels turned off
+	NotificationChannels []string `json:"notification_channels"`
+}
@@ -0,0 +1,87 @@
+package dto
+
+import "time"
+
+// ListUserAuthsQuery represents the query parameters for listing a user's credentials
+type ListUserAuthsQuery struct {
+	PageQuery
+	Provider       string `form:"provider" binding:"omitempty,max=50"`
+	IncludeDeleted bool   `form:"include_deleted"`
+}
+
+// ListSessionsQuery represents the query parameters for listing a user's sessions
+type ListSessionsQuery struct {
+	PageQuery
+	Status string `form:"status" binding:"omitempty,oneof=active revoked expired"`
+}
+
+// UserAuthResponse represents a user's credential and whether it can sign in.
+// LockReason is set when Locked is true; LockedUntil is set while failed sign-ins lock it.
+type UserAuthResponse struct {
+	ID           string     `json:"id"`
+	Provider     string     `json:"provider"`
+	DisplayName  string     `json:"display_name"`
+	CredentialID string     `json:"credential_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastUsedAt   *time.Time `json:"last_used_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`
+	Locked       bool       `json:"locked"`
+	LockReason   *string    `json:"lock_reason,omitempty"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+}
+
+// UserAuthListResponse represents a page of a user's credentials
+type UserAuthListResponse struct {
+	Items  []*UserAuthResponse `json:"items"`
+	Total  int64               `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+// SessionResponse represents a session, i.e. an issued refresh token. A session is
+// used by exchanging its refresh token, which ends it and starts a new one, so
+// CreatedAt is also the time the user was last active in it.
+type SessionResponse struct {
+	ID         string     `json:"id"`
+	SessionID  string     `json:"session_id"`
+	Status     string     `json:"status"`
+	UserAgent  *string    `json:"user_agent"`
+	IPAddress  *string    `json:"ip_address"`
+	SignedInAt *time.Time `json:"signed_in_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// UserSessionResponse represents a session the current user is signed in to.
+// Current is true for the session of the request.
+type UserSessionResponse struct {
+	ID         string     `json:"id"`
+	UserAgent  *string    `json:"user_agent"`
+	IPAddress  *string    `json:"ip_address"`
+	SignedInAt *time.Time `json:"signed_in_at"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Current    bool       `json:"current"`
+}
+
+// UserSessionListResponse represents a page of the current user's sessions
+type UserSessionListResponse struct {
+	Items  []*UserSessionResponse `json:"items"`
+	Total  int64                  `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
+
+// RevokeSessionsResponse represents the result of signing out of the other sessions
+type RevokeSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// SessionListResponse represents a page of a user's sessions
+type SessionListResponse struct {
+	Items  []*SessionResponse `json:"items"`
+	Total  int64              `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// CreateWalletRequest represents the payload for creating a wallet
+type CreateWalletRequest struct {
+	Name           string  `json:"name" binding:"required,max=100"`
+	Type           string  `json:"type" binding:"omitempty,oneof=cash bank ewallet other"`
+	Currency       string  `json:"currency" binding:"omitempty,currency"`
+	OpeningBalance float64 `json:"opening_balance"`
+}
+
+// UpdateWalletRequest represents the payload for replacing a wallet.
+// The currency cannot be changed. Version must match the stored version (optimistic locking).
+type UpdateWalletRequest struct {
+	Name           string  `json:"name" binding:"required,max=100"`
+	Type           string  `json:"type" binding:"omitempty,oneof=cash bank ewallet other"`
+	OpeningBalance float64 `json:"opening_balance"`
+	Version        *int    `json:"version" binding:"required,min=0"`
+}
+
+// TransferRequest represents the payload for moving money between two wallets.
+// ToAmount is the amount received, required between wallets of different currencies.
+type TransferRequest struct {
+	FromWalletID string  `json:"from_wallet_id" binding:"required,uuid"`
+	ToWalletID   string  `json:"to_wallet_id" binding:"required,uuid"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+	ToAmount     float64 `json:"to_amount" binding:"omitempty,gt=0"`
+	Description  *string `json:"description" binding:"omitempty,max=500"`
+}
+
+// WalletResponse represents a wallet and its balance
+type WalletResponse struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Currency       string    `json:"currency"`
+	OpeningBalance float64   `json:"opening_balance"`
+	Inflow         float64   `json:"inflow"`
+	Outflow        float64   `json:"outflow"`
+	Balance        float64   `json:"balance"`
+	MoneyFlowCount int64     `json:"money_flow_count"`
+	Version        int       `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TransferResponse represents the two money flows of a transfer between wallets
+type TransferResponse struct {
+	ID  string             `json:"id"`
+	Out *MoneyFlowResponse `json:"out"`
+	In  *MoneyFlowResponse `json:"in"`
+}
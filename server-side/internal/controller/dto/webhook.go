@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+// CreateWebhookRequest represents the payload for registering a webhook
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url,max=2048"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+// UpdateWebhookRequest represents the payload for replacing the settings of a webhook.
+// Version must match the stored version (optimistic locking).
+type UpdateWebhookRequest struct {
+	URL     string   `json:"url" binding:"required,url,max=2048"`
+	Events  []string `json:"events" binding:"required,min=1"`
+	Active  bool     `json:"active"`
+	Version *int     `json:"version" binding:"required,min=0"`
+}
+
+// WebhookResponse represents a webhook
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateWebhookResponse represents a newly registered webhook.
+// The signing secret is only returned once.
+type CreateWebhookResponse struct {
+	*WebhookResponse
+	Secret string `json:"secret" secret:"true"`
+}
+
+// WebhookDeliveryResponse represents one attempt to send an event to a webhook
+type WebhookDeliveryResponse struct {
+	ID         string    `json:"id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode *int      `json:"status_code"`
+	Error      *string   `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDeliveryListResponse represents a page of webhook deliveries
+type WebhookDeliveryListResponse struct {
+	Items  []*WebhookDeliveryResponse `json:"items"`
+	Limit  int                        `json:"limit"`
+	Offset int                        `json:"offset"`
+}
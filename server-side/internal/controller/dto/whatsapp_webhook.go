@@ -0,0 +1,38 @@
+package dto
+
+// WhatsAppWebhookPayload represents a WhatsApp Cloud API webhook notification.
+// Only the fields of incoming text and reply button messages are decoded.
+type WhatsAppWebhookPayload struct {
+	Object string `json:"object"`
+	Entry  []struct {
+		ID      string `json:"id"`
+		Changes []struct {
+			Field string               `json:"field"`
+			Value WhatsAppWebhookValue `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+// WhatsAppWebhookValue represents the content of a webhook change
+type WhatsAppWebhookValue struct {
+	MessagingProduct string                    `json:"messaging_product"`
+	Messages         []WhatsAppIncomingMessage `json:"messages"`
+}
+
+// WhatsAppIncomingMessage represents a message a user sent to the business number
+type WhatsAppIncomingMessage struct {
+	ID        string `json:"id"`
+	From      string `json:"from"`
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"`
+	Text      *struct {
+		Body string `json:"body"`
+	} `json:"text,omitempty"`
+	Interactive *struct {
+		Type        string `json:"type"`
+		ButtonReply *struct {
+			ID    string `json:"id"`
+			Title string `json:"title"`
+		} `json:"button_reply,omitempty"`
+	} `json:"interactive,omitempty"`
+}
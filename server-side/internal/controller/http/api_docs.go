@@ -0,0 +1,484 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/openapi"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// Paths of the API documentation
+const (
+	docsPath     = "/api/v1/docs"
+	docsSpecPath = "/api/v1/docs/openapi.json"
+)
+
+// apiInfo describes the API in its OpenAPI document
+var apiInfo = openapi.Info{
+	Title:       "Catetin API",
+	Description: "Personal and shared money tracking. Every JSON response uses the success or error envelope; errors carry a machine-readable code in errors.code and its documentation in errors.doc_url.",
+	Version:     "1.0.0",
+}
+
+// apiRoutes describes every route SetupRouter registers. A route missing here fails
+// TestAPIRoutesDocumentEveryRoute, so add new routes to both places.
+func apiRoutes(config *RouterConfig) []openapi.Route {
+	routes := []openapi.Route{
+		// Probes
+		{Method: http.MethodGet, Path: "/healthz", OperationID: "getLiveness", Tag: "Operations",
+			Summary: "Liveness probe", Description: "Reports whether the process is running.",
+			Raw: dto.HealthResponse{}},
+		{Method: http.MethodGet, Path: "/readyz", OperationID: "getReadiness", Tag: "Operations",
+			Summary: "Readiness probe", Description: "Checks the dependencies; answers 503 when one of them is down.",
+			Raw: dto.HealthResponse{}, AlsoStatus: []int{http.StatusServiceUnavailable}},
+
+		// Documentation
+		{Method: http.MethodGet, Path: docsPath, OperationID: "getAPIDocs", Tag: "Documentation",
+			Summary: "Swagger UI", ContentType: "text/html"},
+		{Method: http.MethodGet, Path: docsSpecPath, OperationID: "getOpenAPIDocument", Tag: "Documentation",
+			Summary: "OpenAPI document of the API", Raw: map[string]any{}},
+		{Method: http.MethodGet, Path: "/api/v1/meta/errors", OperationID: "listErrorCodes", Tag: "Documentation",
+			Summary: "List error codes", Description: "Every code of errors.code with its HTTP status and description.",
+			Data: dto.ErrorCatalogResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/meta/errors/:code", OperationID: "getErrorCode", Tag: "Documentation",
+			Summary: "Get an error code", Description: "The target of the doc_url of error responses.",
+			Data: dto.ErrorCodeResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/meta/currencies", OperationID: "listCurrencies", Tag: "Documentation",
+			Summary: "List currencies", Description: "Every ISO 4217 currency money can be recorded in, with its symbol and the decimal places of its amounts. Other codes are rejected.",
+			Data: dto.CurrencyCatalogResponse{}},
+
+		// Authentication
+		{Method: http.MethodPost, Path: "/api/v1/authentications/register", OperationID: "register", Tag: "Authentication",
+			Summary: "Register a user", Body: dto.RegisterRequest{}, Status: http.StatusCreated, Data: dto.AuthResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/authentications/login", OperationID: "login", Tag: "Authentication",
+			Summary: "Sign in", Body: dto.LoginRequest{}, Data: dto.AuthResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/authentications/refresh", OperationID: "refreshToken", Tag: "Authentication",
+			Summary: "Refresh an access token", Body: dto.RefreshTokenRequest{}, Data: dto.AuthResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/authentications/demo", OperationID: "createDemoSession", Tag: "Authentication",
+			Summary: "Start a demo session", Description: "Creates a sandbox user with sample data and a short-lived token.",
+			Status: http.StatusCreated, Data: dto.DemoAuthResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/authentications/invitations/accept", OperationID: "acceptInvitation", Tag: "Authentication",
+			Summary: "Accept an account invitation", Body: dto.AcceptInvitationRequest{}, Data: dto.AuthResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/authentications/password-reset", OperationID: "requestPasswordReset", Tag: "Authentication",
+			Summary: "Email a password reset link", Description: "Succeeds whether or not an account uses the email.",
+			Body: dto.RequestPasswordResetRequest{}, Status: http.StatusAccepted},
+		{Method: http.MethodPost, Path: "/api/v1/authentications/password-reset/confirm", OperationID: "confirmPasswordReset", Tag: "Authentication",
+			Summary: "Set a new password with a reset link", Description: "Also unlocks an account locked by failed sign-ins and signs it out everywhere.",
+			Body: dto.ConfirmPasswordResetRequest{}},
+
+		// Current user
+		{Method: http.MethodGet, Path: "/api/v1/users/me", OperationID: "getProfile", Tag: "Users",
+			Summary: "Get the profile", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.UserProfileResponse{}},
+		{Method: http.MethodPatch, Path: "/api/v1/users/me", OperationID: "updateProfile", Tag: "Users",
+			Summary: "Update the profile", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.UpdateProfileRequest{}, Data: dto.UserProfileResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me", OperationID: "deleteAccount", Tag: "Users",
+			Summary: "Delete the account", Auth: openapi.AuthSession, Recent: true},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/password", OperationID: "changePassword", Tag: "Users",
+			Summary: "Change the password", Auth: openapi.AuthSession, Body: dto.ChangePasswordRequest{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/reauthenticate", OperationID: "reauthenticate", Tag: "Users",
+			Summary: "Confirm the password", Description: "Returns a token that counts as a recent sign-in.",
+			Auth: openapi.AuthSession, Body: dto.ReauthenticateRequest{}, Data: dto.AuthResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/export", OperationID: "exportAccount", Tag: "Users",
+			Summary: "Export all account data", Description: "Queues a ZIP of the account's data; a signed download link is sent to the user once it is ready.",
+			Auth: openapi.AuthSession, Recent: true, Status: http.StatusAccepted, Data: dto.AccountExportResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/account-exports/:user_id/:id", OperationID: "downloadAccountExport", Tag: "Users",
+			Summary: "Download an account export", Description: "Authorized by the signature of the link sent to the user; answers 403 once it expired.",
+			Query: dto.AccountExportDownloadQuery{}, ContentType: "application/zip"},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/erasure", OperationID: "getAccountErasure", Tag: "Users",
+			Summary: "Get the pending account erasure", Description: "Answers 404 when no erasure is pending.",
+			Auth: openapi.AuthSession, Data: dto.AccountErasureResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/erasure", OperationID: "requestAccountErasure", Tag: "Users",
+			Summary: "Request the erasure of the account", Description: "Anonymizes the account's personal data after a grace period during which the erasure can be cancelled. Answers 409 when one is already pending.",
+			Auth: openapi.AuthSession, Recent: true, Status: http.StatusAccepted, Data: dto.AccountErasureResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/erasure", OperationID: "cancelAccountErasure", Tag: "Users",
+			Summary: "Cancel the pending account erasure", Auth: openapi.AuthSession},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/auth-providers", OperationID: "listAuthProviders", Tag: "Users",
+			Summary: "List linked sign-in methods", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Data: []*dto.LinkedProviderResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/auth-providers", OperationID: "linkAuthProvider", Tag: "Users",
+			Summary: "Link a sign-in method", Auth: openapi.AuthSession, Recent: true,
+			Body: dto.LinkAuthProviderRequest{}, Status: http.StatusCreated, Data: dto.LinkedProviderResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/auth-providers/phone-otp/code", OperationID: "sendPhoneVerificationCode", Tag: "Users",
+			Summary: "Send a code that links a phone number", Auth: openapi.AuthSession, Recent: true,
+			Body: dto.SendPhoneVerificationCodeRequest{}, Status: http.StatusAccepted, Data: dto.PhoneVerificationCodeResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/auth-providers/:id", OperationID: "unlinkAuthProvider", Tag: "Users",
+			Summary: "Unlink a sign-in method", Auth: openapi.AuthSession, Recent: true},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/settings", OperationID: "getSettings", Tag: "Users",
+			Summary: "Get the settings", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.UserSettingsResponse{}},
+		{Method: http.MethodPatch, Path: "/api/v1/users/me/settings", OperationID: "updateSettings", Tag: "Users",
+			Summary: "Update the settings", Auth: openapi.AuthSession,
+			Body: dto.UpdateUserSettingsRequest{}, Data: dto.UserSettingsResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/api-usage", OperationID: "getAPIUsage", Tag: "Users",
+			Summary: "Get the API usage", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.APIUsageQuery{}, Data: dto.APIUsageResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/devices", OperationID: "listDevices", Tag: "Users",
+			Summary: "List push notification devices", Auth: openapi.AuthSession, Data: []*dto.DeviceResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/devices", OperationID: "registerDevice", Tag: "Users",
+			Summary:     "Register a device for push notifications",
+			Description: "Call on every app start with the current FCM registration token; a token registered again is refreshed.",
+			Auth:        openapi.AuthSession, Body: dto.RegisterDeviceRequest{}, Status: http.StatusCreated, Data: dto.DeviceResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/devices/:id", OperationID: "unregisterDevice", Tag: "Users",
+			Summary: "Unregister a push notification device", Auth: openapi.AuthSession},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/sessions", OperationID: "listSessions", Tag: "Users",
+			Summary: "List signed-in sessions", Description: "Most recently used first, with the device each one signed in from.",
+			Auth: openapi.AuthSession, Query: dto.PageQuery{}, Data: dto.UserSessionListResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/sessions", OperationID: "revokeOtherSessions", Tag: "Users",
+			Summary: "Sign out of every other session", Auth: openapi.AuthSession, Data: dto.RevokeSessionsResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/sessions/:id", OperationID: "revokeSession", Tag: "Users",
+			Summary: "Sign out of a session", Auth: openapi.AuthSession},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/notifications", OperationID: "listNotifications", Tag: "Users",
+			Summary: "List notifications", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.PageQuery{}, Data: dto.NotificationListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/notifications/:id/read", OperationID: "markNotificationRead", Tag: "Users",
+			Summary: "Mark a notification as read", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/audit-logs", OperationID: "listAuditLogs", Tag: "Users",
+			Summary: "List account activity", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.ListAuditLogsQuery{}, Data: dto.AuditLogListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/events", OperationID: "streamEvents", Tag: "Users",
+			Summary: "Stream real-time events",
+			Description: "Server-sent events: money_flow.created, money_flow.updated, money_flow.deleted, and budget.exceeded. " +
+				"The data of each event is a JSON object with id, type, occurred_at, and data; reconnect and reload after the stream ends.",
+			Auth: openapi.AuthUser, Scope: domain.ScopeRead, ContentType: "text/event-stream"},
+
+		// API keys
+		{Method: http.MethodGet, Path: "/api/v1/users/me/api-keys", OperationID: "listAPIKeys", Tag: "API keys",
+			Summary: "List API keys", Auth: openapi.AuthSession, Data: []*dto.APIKeyResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/api-keys", OperationID: "createAPIKey", Tag: "API keys",
+			Summary: "Create an API key", Description: "The key is only returned in this response.",
+			Auth: openapi.AuthSession, Body: dto.CreateAPIKeyRequest{}, Status: http.StatusCreated, Data: dto.CreateAPIKeyResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/api-keys/:id", OperationID: "revokeAPIKey", Tag: "API keys",
+			Summary: "Revoke an API key", Auth: openapi.AuthSession},
+
+		// Webhooks
+		{Method: http.MethodGet, Path: "/api/v1/users/me/webhooks", OperationID: "listWebhooks", Tag: "Webhooks",
+			Summary: "List webhooks", Auth: openapi.AuthSession, Data: []*dto.WebhookResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/webhooks", OperationID: "createWebhook", Tag: "Webhooks",
+			Summary: "Register a webhook", Description: "Events: money_flow.created, money_flow.updated, and money_flow.deleted. " +
+				"The signing secret is only returned in this response.",
+			Auth: openapi.AuthSession, Body: dto.CreateWebhookRequest{}, Status: http.StatusCreated, Data: dto.CreateWebhookResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/webhooks/:id", OperationID: "getWebhook", Tag: "Webhooks",
+			Summary: "Get a webhook", Auth: openapi.AuthSession, Data: dto.WebhookResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/users/me/webhooks/:id", OperationID: "updateWebhook", Tag: "Webhooks",
+			Summary: "Replace the settings of a webhook", Auth: openapi.AuthSession,
+			Body: dto.UpdateWebhookRequest{}, Data: dto.WebhookResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/users/me/webhooks/:id", OperationID: "deleteWebhook", Tag: "Webhooks",
+			Summary: "Delete a webhook", Auth: openapi.AuthSession},
+		{Method: http.MethodGet, Path: "/api/v1/users/me/webhooks/:id/deliveries", OperationID: "listWebhookDeliveries", Tag: "Webhooks",
+			Summary: "List webhook deliveries", Description: "Every attempt to send an event, newest first.",
+			Auth: openapi.AuthSession, Query: dto.PageQuery{}, Data: dto.WebhookDeliveryListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/users/me/webhooks/:id/ping", OperationID: "pingWebhook", Tag: "Webhooks",
+			Summary: "Send a test ping", Description: "Sends a ping event right away and returns the delivery; a failed ping is not retried.",
+			Auth: openapi.AuthSession, Data: dto.WebhookDeliveryResponse{}},
+
+		// Money flows
+		{Method: http.MethodGet, Path: "/api/v1/money-flows", OperationID: "listMoneyFlows", Tag: "Money flows",
+			Summary: "List money flows", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.ListMoneyFlowsQuery{}, Data: dto.MoneyFlowListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/money-flows", OperationID: "createMoneyFlow", Tag: "Money flows",
+			Summary: "Record a money flow", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateMoneyFlowRequest{}, Status: http.StatusCreated, Data: dto.MoneyFlowResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/money-flows/bulk", OperationID: "bulkCreateMoneyFlows", Tag: "Money flows",
+			Summary: "Record money flows in bulk", Description: "Each item succeeds or fails on its own; the results follow the order of the items.",
+			Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.BulkCreateMoneyFlowsRequest{}, Data: dto.BulkCreateMoneyFlowsResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/money-flows/import", OperationID: "importMoneyFlows", Tag: "Money flows",
+			Summary: "Import money flows from CSV", Description: "Answers 200 with the parsed rows for a dry run and 201 once imported.",
+			Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Form: dto.ImportMoneyFlowsRequest{}, Status: http.StatusCreated, AlsoStatus: []int{http.StatusOK}, Data: dto.ImportMoneyFlowsResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/money-flows/reconcile", OperationID: "reconcileMoneyFlows", Tag: "Money flows",
+			Summary: "Reconcile a bank statement", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Form: dto.ReconcileMoneyFlowsRequest{}, Data: dto.ReconciliationResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/money-flows/scan-receipt", OperationID: "scanReceipt", Tag: "Money flows",
+			Summary: "Scan a receipt", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Form: dto.ScanReceiptRequest{}, Data: dto.ScanReceiptResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/money-flows/suggest-category", OperationID: "suggestCategory", Tag: "Money flows",
+			Summary: "Suggest a category", Description: "Suggests categories for a description from the user's past money flows, or from a language model when none match.",
+			Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.SuggestCategoryQuery{}, Data: dto.CategorySuggestionsResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/money-flows/summary", OperationID: "getMoneyFlowSummary", Tag: "Money flows",
+			Summary: "Summarize money flows", Description: "With from and to, also sums the income, expense, and net of that period.",
+			Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.MoneyFlowSummaryQuery{}, Data: dto.MoneyFlowSummaryResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/money-flows/export", OperationID: "exportMoneyFlows", Tag: "Money flows",
+			Summary: "Export a month of money flows", Description: "Downloads a CSV or XLSX file depending on the format.",
+			Auth: openapi.AuthUser, Scope: domain.ScopeExport,
+			Query: dto.ExportMoneyFlowsQuery{}, ContentType: "application/octet-stream"},
+		{Method: http.MethodGet, Path: "/api/v1/money-flows/:id", OperationID: "getMoneyFlow", Tag: "Money flows",
+			Summary: "Get a money flow", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.MoneyFlowResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/money-flows/:id", OperationID: "updateMoneyFlow", Tag: "Money flows",
+			Summary: "Update a money flow", Description: "The expected version is sent in the body or as If-Match; conflicts detail the current money flow.",
+			Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.UpdateMoneyFlowRequest{}, Data: dto.MoneyFlowResponse{}},
+		{Method: http.MethodPatch, Path: "/api/v1/money-flows/:id", OperationID: "patchMoneyFlow", Tag: "Money flows",
+			Summary:     "Change some fields of a money flow",
+			Description: "Fields left out are kept; empty strings clear category, description, wallet_id, and group_id. The expected version is sent in the body or as If-Match.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.PatchMoneyFlowRequest{}, Data: dto.MoneyFlowResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/money-flows/:id", OperationID: "deleteMoneyFlow", Tag: "Money flows",
+			Summary: "Delete a money flow", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+		{Method: http.MethodGet, Path: "/api/v1/money-flows/:id/splits", OperationID: "getMoneyFlowSplits", Tag: "Money flows",
+			Summary: "Get the splits of a money flow", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Data: dto.MoneyFlowSplitsResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/money-flows/:id/splits", OperationID: "splitMoneyFlow", Tag: "Money flows",
+			Summary: "Split a money flow with group members", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.SplitMoneyFlowRequest{}, Data: dto.MoneyFlowSplitsResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/money-flows/:id/share", OperationID: "shareMoneyFlow", Tag: "Money flows",
+			Summary:     "Share a money flow",
+			Description: "Creates a signed public link to a read-only view of the money flow that expires after ttl_hours; its note is shown only with include_note.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateMoneyFlowShareRequest{}, Status: http.StatusCreated, Data: dto.MoneyFlowShareResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/money-flows/:id/share", OperationID: "unshareMoneyFlow", Tag: "Money flows",
+			Summary: "Revoke the share links of a money flow", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Data: dto.RevokeMoneyFlowShareResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/shared/money-flows/:id", OperationID: "viewSharedMoneyFlow", Tag: "Money flows",
+			Summary:     "View a shared money flow",
+			Description: "Authorized by the signature of the share link; answers 403 once it expired or was revoked. Browsers asking for text/html get a page instead of JSON.",
+			Query:       dto.SharedMoneyFlowQuery{}, Data: dto.SharedMoneyFlowResponse{}},
+
+		// Reports
+		{Method: http.MethodGet, Path: "/api/v1/reports/summary", OperationID: "getReportSummary", Tag: "Reports",
+			Summary: "Report a period", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.ReportSummaryQuery{}, Data: dto.ReportSummaryResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/reports/trends", OperationID: "getReportTrends", Tag: "Reports",
+			Summary:     "Report spending per day, week, or month",
+			Description: "One point per period, including periods without spending, with the change from the period before.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeRead, Query: dto.TrendQuery{}, Data: dto.TrendResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/reports/merchants", OperationID: "getReportMerchants", Tag: "Reports",
+			Summary:     "Report spending per merchant",
+			Description: "The merchants spent at most first; money flows without a merchant are left out.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeRead, Query: dto.MerchantReportQuery{}, Data: dto.MerchantReportResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/reports/places", OperationID: "getReportPlaces", Tag: "Reports",
+			Summary:     "Report spending per place",
+			Description: "The places spent at most first, from the money flows with a latitude and longitude; with latitude and longitude, only the places within radius meters of the point.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeRead, Query: dto.PlaceReportQuery{}, Data: dto.PlaceReportResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/reports/digest", OperationID: "previewDigest", Tag: "Reports",
+			Summary:     "Preview the spending digest",
+			Description: "The digest of the last week or month that ended, with the notification it is sent as.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeRead, Query: dto.DigestQuery{}, Data: dto.DigestResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/reports/statement", OperationID: "previewStatement", Tag: "Reports",
+			Summary:     "Preview the monthly statement email",
+			Description: "The email of a month's statement as it is sent: the HTML body, the plain-text body, or the PDF attachment depending on the format.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeRead, Query: dto.StatementQuery{}, ContentType: "text/html"},
+		{Method: http.MethodGet, Path: "/api/v1/dashboard", OperationID: "getDashboard", Tag: "Reports",
+			Summary:     "Get the dashboard",
+			Description: "This month's spending, the top 5 categories, budgets, the 10 latest money flows, and active goals in one response.",
+			Auth:        openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.DashboardResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/exchange-rates", OperationID: "getExchangeRates", Tag: "Reports",
+			Summary: "Get exchange rates", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.ExchangeRatesQuery{}, Data: dto.ExchangeRatesResponse{}},
+
+		// Budgets
+		{Method: http.MethodGet, Path: "/api/v1/budgets", OperationID: "listBudgets", Tag: "Budgets",
+			Summary: "List budgets", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: []*dto.BudgetResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/budgets", OperationID: "createBudget", Tag: "Budgets",
+			Summary: "Create a budget", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateBudgetRequest{}, Status: http.StatusCreated, Data: dto.BudgetResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/budgets/overrides", OperationID: "listBudgetOverrides", Tag: "Budgets",
+			Summary: "List budget overrides", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.ListBudgetOverridesQuery{}, Data: dto.BudgetOverrideListResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/budgets/:id", OperationID: "updateBudget", Tag: "Budgets",
+			Summary: "Update a budget", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.UpdateBudgetRequest{}, Data: dto.BudgetResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/budgets/:id", OperationID: "deleteBudget", Tag: "Budgets",
+			Summary: "Delete a budget", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+
+		// Wallets
+		{Method: http.MethodGet, Path: "/api/v1/wallets", OperationID: "listWallets", Tag: "Wallets",
+			Summary: "List wallets", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: []*dto.WalletResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/wallets", OperationID: "createWallet", Tag: "Wallets",
+			Summary: "Create a wallet", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateWalletRequest{}, Status: http.StatusCreated, Data: dto.WalletResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/wallets/transfers", OperationID: "transferBetweenWallets", Tag: "Wallets",
+			Summary: "Transfer between wallets", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.TransferRequest{}, Status: http.StatusCreated, Data: dto.TransferResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/wallets/:id", OperationID: "getWallet", Tag: "Wallets",
+			Summary: "Get a wallet", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.WalletResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/wallets/:id", OperationID: "updateWallet", Tag: "Wallets",
+			Summary: "Update a wallet", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.UpdateWalletRequest{}, Data: dto.WalletResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/wallets/:id", OperationID: "deleteWallet", Tag: "Wallets",
+			Summary: "Delete a wallet", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+
+		// Goals
+		{Method: http.MethodGet, Path: "/api/v1/goals", OperationID: "listGoals", Tag: "Goals",
+			Summary: "List goals", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.ListGoalsQuery{}, Data: []*dto.GoalResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/goals", OperationID: "createGoal", Tag: "Goals",
+			Summary: "Create a goal", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateGoalRequest{}, Status: http.StatusCreated, Data: dto.GoalResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/goals/:id", OperationID: "getGoal", Tag: "Goals",
+			Summary: "Get a goal", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.GoalResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/goals/:id", OperationID: "updateGoal", Tag: "Goals",
+			Summary: "Update a goal", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.UpdateGoalRequest{}, Data: dto.GoalResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/goals/:id", OperationID: "deleteGoal", Tag: "Goals",
+			Summary: "Delete a goal", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+
+		// Groups
+		{Method: http.MethodGet, Path: "/api/v1/groups", OperationID: "listGroups", Tag: "Groups",
+			Summary: "List groups", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: []*dto.GroupResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/groups", OperationID: "createGroup", Tag: "Groups",
+			Summary: "Create a group", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateGroupRequest{}, Status: http.StatusCreated, Data: dto.GroupResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/groups/invitations/accept", OperationID: "acceptGroupInvitation", Tag: "Groups",
+			Summary: "Join a group by invitation", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.AcceptGroupInvitationRequest{}, Data: dto.GroupResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/groups/:id", OperationID: "getGroup", Tag: "Groups",
+			Summary: "Get a group", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.GroupResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/groups/:id", OperationID: "updateGroup", Tag: "Groups",
+			Summary: "Update a group", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.UpdateGroupRequest{}, Data: dto.GroupResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/groups/:id", OperationID: "deleteGroup", Tag: "Groups",
+			Summary: "Delete a group", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+		{Method: http.MethodGet, Path: "/api/v1/groups/:id/invitations", OperationID: "listGroupInvitations", Tag: "Groups",
+			Summary: "List pending invitations", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Data: []*dto.GroupInvitationResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/groups/:id/invitations", OperationID: "createGroupInvitation", Tag: "Groups",
+			Summary: "Invite someone to a group", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateGroupInvitationRequest{}, Status: http.StatusCreated, Data: dto.GroupInvitationResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/groups/:id/invitations/:invitationId", OperationID: "revokeGroupInvitation", Tag: "Groups",
+			Summary: "Revoke an invitation", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+		{Method: http.MethodPut, Path: "/api/v1/groups/:id/members/:userId", OperationID: "updateGroupMember", Tag: "Groups",
+			Summary: "Change the role of a member", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.UpdateGroupMemberRequest{}},
+		{Method: http.MethodDelete, Path: "/api/v1/groups/:id/members/:userId", OperationID: "removeGroupMember", Tag: "Groups",
+			Summary: "Remove a member or leave a group", Auth: openapi.AuthUser, Scope: domain.ScopeWrite},
+		{Method: http.MethodGet, Path: "/api/v1/groups/:id/balances", OperationID: "getGroupBalances", Tag: "Groups",
+			Summary: "Get who owes whom", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: dto.GroupBalancesResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/groups/:id/settlements", OperationID: "listSettlements", Tag: "Groups",
+			Summary: "List settlements", Auth: openapi.AuthUser, Scope: domain.ScopeRead,
+			Query: dto.PageQuery{}, Data: dto.SettlementListResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/groups/:id/settlements", OperationID: "createSettlement", Tag: "Groups",
+			Summary: "Record a settlement", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.CreateSettlementRequest{}, Status: http.StatusCreated, Data: dto.SettlementResponse{}},
+
+		// Tags
+		{Method: http.MethodGet, Path: "/api/v1/tags", OperationID: "listTags", Tag: "Tags",
+			Summary: "List tags with their usage", Auth: openapi.AuthUser, Scope: domain.ScopeRead, Data: []*dto.TagResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/tags/rename", OperationID: "renameTag", Tag: "Tags",
+			Summary: "Rename a tag", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.RenameTagRequest{}, Data: dto.TagResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/tags/merge", OperationID: "mergeTags", Tag: "Tags",
+			Summary: "Merge tags into one", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.MergeTagsRequest{}, Data: dto.TagResponse{}},
+
+		// Feedback
+		{Method: http.MethodPost, Path: "/api/v1/feedback", OperationID: "submitFeedback", Tag: "Feedback",
+			Summary: "Send feedback", Auth: openapi.AuthUser, Scope: domain.ScopeWrite,
+			Body: dto.SubmitFeedbackRequest{}, Status: http.StatusCreated, Data: dto.FeedbackResponse{}},
+
+		// Administration
+		{Method: http.MethodGet, Path: "/api/v1/admin/users", OperationID: "adminListUsers", Tag: "Admin",
+			Summary: "Search users", Auth: openapi.AuthAdmin, Permission: domain.PermissionUsersRead,
+			Query: dto.ListUsersQuery{}, Data: dto.AdminUserListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/users/:id", OperationID: "adminGetUser", Tag: "Admin",
+			Summary: "Get a user", Auth: openapi.AuthAdmin, Permission: domain.PermissionUsersRead, Data: dto.AdminUserResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/disable", OperationID: "adminDisableUser", Tag: "Admin",
+			Summary: "Disable a user", Auth: openapi.AuthAdmin, Permission: domain.PermissionUsersManage, Data: dto.AdminUserResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/users/:id/enable", OperationID: "adminEnableUser", Tag: "Admin",
+			Summary: "Enable a user", Auth: openapi.AuthAdmin, Permission: domain.PermissionUsersManage, Data: dto.AdminUserResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/admin/users/:id/role", OperationID: "adminUpdateUserRole", Tag: "Admin",
+			Summary: "Change the role of a user", Auth: openapi.AuthAdmin, Permission: domain.PermissionRolesManage,
+			Body: dto.UpdateUserRoleRequest{}, Data: dto.AdminUserResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/stats", OperationID: "adminGetStats", Tag: "Admin",
+			Summary: "Get system stats", Auth: openapi.AuthAdmin, Permission: domain.PermissionStatsRead,
+			Query: dto.APIUsageQuery{}, Data: dto.SystemStatsResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/broadcasts", OperationID: "adminListBroadcasts", Tag: "Admin",
+			Summary: "List broadcasts", Auth: openapi.AuthAdmin, Permission: domain.PermissionBroadcastsManage,
+			Query: dto.PageQuery{}, Data: []*dto.BroadcastResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/broadcasts", OperationID: "adminCreateBroadcast", Tag: "Admin",
+			Summary: "Queue a broadcast", Auth: openapi.AuthAdmin, Permission: domain.PermissionBroadcastsManage,
+			Body: dto.CreateBroadcastRequest{}, Status: http.StatusAccepted, Data: dto.BroadcastResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/broadcasts/:id", OperationID: "adminGetBroadcast", Tag: "Admin",
+			Summary: "Get a broadcast", Auth: openapi.AuthAdmin, Permission: domain.PermissionBroadcastsManage,
+			Data: dto.BroadcastResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/broadcasts/:id/deliveries", OperationID: "adminListBroadcastDeliveries", Tag: "Admin",
+			Summary: "List the deliveries of a broadcast", Auth: openapi.AuthAdmin, Permission: domain.PermissionBroadcastsManage,
+			Query: dto.ListBroadcastDeliveriesQuery{}, Data: []*dto.BroadcastDeliveryResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/feedback", OperationID: "adminListFeedback", Tag: "Admin",
+			Summary: "List feedback", Auth: openapi.AuthAdmin, Permission: domain.PermissionFeedbackRead,
+			Query: dto.ListFeedbackQuery{}, Data: []*dto.FeedbackResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/merchants", OperationID: "adminListMerchants", Tag: "Admin",
+			Summary: "List merchants", Auth: openapi.AuthAdmin, Permission: domain.PermissionMerchantsManage,
+			Query: dto.ListMerchantsQuery{}, Data: []*dto.MerchantResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/admin/merchants/:id", OperationID: "adminUpdateMerchant", Tag: "Admin",
+			Summary: "Rename a merchant", Auth: openapi.AuthAdmin, Permission: domain.PermissionMerchantsManage,
+			Body: dto.UpdateMerchantRequest{}, Data: dto.MerchantResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/merchant-rules", OperationID: "adminListMerchantRules", Tag: "Admin",
+			Summary: "List merchant rules", Auth: openapi.AuthAdmin, Permission: domain.PermissionMerchantsManage,
+			Data: []*dto.MerchantRuleResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/merchant-rules", OperationID: "adminCreateMerchantRule", Tag: "Admin",
+			Summary:     "Create a merchant rule",
+			Description: "Payee names of money flows recorded afterwards that contain the pattern's words are linked to the merchant.",
+			Auth:        openapi.AuthAdmin, Permission: domain.PermissionMerchantsManage,
+			Body: dto.MerchantRuleRequest{}, Status: http.StatusCreated, Data: dto.MerchantRuleResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/admin/merchant-rules/:id", OperationID: "adminUpdateMerchantRule", Tag: "Admin",
+			Summary: "Replace a merchant rule", Auth: openapi.AuthAdmin, Permission: domain.PermissionMerchantsManage,
+			Body: dto.MerchantRuleRequest{}, Data: dto.MerchantRuleResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/admin/merchant-rules/:id", OperationID: "adminDeleteMerchantRule", Tag: "Admin",
+			Summary: "Delete a merchant rule", Auth: openapi.AuthAdmin, Permission: domain.PermissionMerchantsManage},
+		{Method: http.MethodPost, Path: "/api/v1/admin/users/import", OperationID: "adminImportUsers", Tag: "Admin",
+			Summary: "Invite users from CSV", Auth: openapi.AuthAdmin, Permission: domain.PermissionUsersManage,
+			Form: dto.ImportUsersRequest{}, Data: dto.ImportUsersResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/users/:id/auths", OperationID: "adminListUserAuths", Tag: "Admin",
+			Summary: "List the sign-in methods of a user", Auth: openapi.AuthAdmin, Permission: domain.PermissionUsersRead,
+			Query: dto.ListUserAuthsQuery{}, Data: dto.UserAuthListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/users/:id/sessions", OperationID: "adminListUserSessions", Tag: "Admin",
+			Summary: "List the sessions of a user", Auth: openapi.AuthAdmin, Permission: domain.PermissionUsersRead,
+			Query: dto.ListSessionsQuery{}, Data: dto.SessionListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/api-usage/users", OperationID: "adminListUserAPIUsage", Tag: "Admin",
+			Summary: "List API usage by user", Auth: openapi.AuthAdmin, Permission: domain.PermissionStatsRead,
+			Query: dto.ListUserAPIUsageQuery{}, Data: dto.UserUsageListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/api-usage/endpoints", OperationID: "adminListEndpointAPIUsage", Tag: "Admin",
+			Summary: "List API usage by endpoint", Auth: openapi.AuthAdmin, Permission: domain.PermissionStatsRead,
+			Query: dto.APIUsageQuery{}, Data: dto.EndpointUsageListResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/log-level", OperationID: "adminGetLogLevel", Tag: "Admin",
+			Summary: "Get the log level", Auth: openapi.AuthAdmin, Permission: domain.PermissionSystemManage,
+			Data: dto.LogLevelResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/admin/log-level", OperationID: "adminUpdateLogLevel", Tag: "Admin",
+			Summary: "Change the log level", Auth: openapi.AuthAdmin, Permission: domain.PermissionSystemManage,
+			Body: dto.UpdateLogLevelRequest{}, Data: dto.LogLevelResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/admin/log-level", OperationID: "adminResetLogLevel", Tag: "Admin",
+			Summary: "Reset the log level", Auth: openapi.AuthAdmin, Permission: domain.PermissionSystemManage,
+			Data: dto.LogLevelResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/config", OperationID: "adminGetConfig", Tag: "Admin",
+			Summary: "Get the effective configuration", Auth: openapi.AuthAdmin, Permission: domain.PermissionSystemManage,
+			Data: dto.ConfigResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/read-only", OperationID: "adminGetReadOnly", Tag: "Admin",
+			Summary: "Get read-only mode", Auth: openapi.AuthAdmin, Permission: domain.PermissionSystemManage,
+			Data: dto.ReadOnlyResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/admin/read-only", OperationID: "adminUpdateReadOnly", Tag: "Admin",
+			Summary: "Turn read-only mode on or off", Auth: openapi.AuthAdmin, Permission: domain.PermissionSystemManage,
+			Body: dto.UpdateReadOnlyRequest{}, Data: dto.ReadOnlyResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/analytics-exports/money-flows", OperationID: "adminExportMoneyFlows", Tag: "Admin",
+			Summary: "Queue an analytics export of money flows", Auth: openapi.AuthAdmin, Permission: domain.PermissionSystemManage,
+			Body: dto.CreateAnalyticsExportRequest{}, Status: http.StatusAccepted, Data: dto.AnalyticsExportJobResponse{}},
+
+		// Webhooks
+		{Method: http.MethodGet, Path: "/api/v1/webhook/whatsapp", OperationID: "verifyWhatsAppWebhook", Tag: "Webhooks",
+			Summary: "Answer the WhatsApp subscription challenge", Description: "Echoes hub.challenge when hub.verify_token matches.",
+			Query: whatsAppVerifyQuery{}, ContentType: "text/plain"},
+		{Method: http.MethodPost, Path: "/api/v1/webhook/whatsapp", OperationID: "receiveWhatsAppWebhook", Tag: "Webhooks",
+			Summary: "Receive WhatsApp messages", Auth: openapi.AuthWebhook,
+			Body: dto.WhatsAppWebhookPayload{}, Empty: true},
+	}
+
+	if config.Metrics != nil {
+		routes = append(routes, openapi.Route{
+			Method: http.MethodGet, Path: "/metrics", OperationID: "getMetrics", Tag: "Operations",
+			Summary: "Prometheus metrics", Auth: openapi.AuthScrapeToken, ContentType: "text/plain",
+		})
+	}
+
+	return routes
+}
+
+// whatsAppVerifyQuery is the query of the WhatsApp subscription challenge, which the
+// handler reads without binding
+type whatsAppVerifyQuery struct {
+	Mode        string `form:"hub.mode" binding:"required,oneof=subscribe"`
+	VerifyToken string `form:"hub.verify_token" binding:"required"`
+	Challenge   string `form:"hub.challenge" binding:"required"`
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/http/openapi"
+)
+
+func TestAPIRoutesDocumentEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config := &RouterConfig{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Metrics: http.NotFoundHandler(),
+	}
+	router := SetupRouter(config)
+	routes := apiRoutes(config)
+
+	if missing := openapi.Undocumented(router.Routes(), routes); len(missing) > 0 {
+		t.Errorf("routes missing from apiRoutes: %v", missing)
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for _, route := range routes {
+		if !registered[route.Method+" "+route.Path] {
+			t.Errorf("apiRoutes describes %s %s, which is not registered", route.Method, route.Path)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, docsSpecPath, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET %s status = %d, want %d", docsSpecPath, w.Code, http.StatusOK)
+	}
+
+	// Every route of the router is an operation of the served document
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &document); err != nil {
+		t.Fatalf("GET %s body is not JSON: %v", docsSpecPath, err)
+	}
+	params := regexp.MustCompile(`:(\w+)`)
+	for _, route := range router.Routes() {
+		path := params.ReplaceAllString(route.Path, "{$1}")
+		if _, ok := document.Paths[path][strings.ToLower(route.Method)]; !ok {
+			t.Errorf("%s %s is registered but missing from %s", route.Method, path, docsSpecPath)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// FeatureTracker records anonymized analytics events
+type FeatureTracker interface {
+	Track(ctx context.Context, userID uuid.UUID, name string, properties map[string]string)
+}
+
+// TrackFeature is a middleware that records a feature_used event when an
+// authenticated request succeeds. It is a no-op when tracker is nil.
+func TrackFeature(tracker FeatureTracker, feature string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if tracker == nil || c.Writer.Status() >= 400 {
+			return
+		}
+
+		userID, ok := GetUserID(c)
+		if !ok {
+			return
+		}
+
+		client := "session"
+		if _, ok := GetAPIKey(c); ok {
+			client = "api_key"
+		}
+
+		tracker.Track(c.Request.Context(), userID, domain.EventFeatureUsed, map[string]string{
+			"feature": feature,
+			"client":  client,
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UsageRecorder counts API requests per user and endpoint
+type UsageRecorder interface {
+	Record(userID uuid.UUID, method, route string, status int)
+}
+
+// TrackAPIUsage is a middleware that counts every authenticated request against
+// its route template. Requests without a user or a matched route are not counted.
+// It is a no-op when recorder is nil.
+func TrackAPIUsage(recorder UsageRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if recorder == nil {
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+
+		userID, ok := GetUserID(c)
+		if !ok {
+			return
+		}
+
+		recorder.Record(userID, c.Request.Method, route, c.Writer.Status())
+	}
+}
@@ -0,0 +1,283 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const (
+	// ContextKeyUserID is the gin context key holding the authenticated user ID
+	ContextKeyUserID = "auth_user_id"
+
+	// ContextKeyClaims is the gin context key holding the validated JWT claims
+	ContextKeyClaims = "auth_claims"
+
+	// ContextKeyAPIKey is the gin context key holding the API key used for the request
+	ContextKeyAPIKey = "auth_api_key"
+
+	// ContextKeyRole is the gin context key holding the role resolved for the request
+	ContextKeyRole = "auth_role"
+
+	// APIKeyHeader is the header carrying an API key
+	APIKeyHeader = "X-API-Key"
+)
+
+// APIKeyAuthenticator resolves plaintext API keys into key records
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, plaintext string) (*domain.APIKey, error)
+}
+
+// RoleResolver resolves the current authorization role of a user
+type RoleResolver interface {
+	UserRole(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+// TokenVersionChecker rejects access tokens revoked since they were issued
+type TokenVersionChecker interface {
+	CheckTokenVersion(ctx context.Context, userID uuid.UUID, version int) error
+}
+
+// Authentication is a middleware that requires a valid Bearer access token or API key.
+// API keys are accepted from the X-API-Key header or as a Bearer token. Access tokens
+// are checked against the user's token version unless tokenVersions is nil.
+func Authentication(jwtManager *security.JWTManager, apiKeys APIKeyAuthenticator, tokenVersions TokenVersionChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, ok := extractBearerToken(c.GetHeader("Authorization"))
+		if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+			tokenString, ok = apiKey, true
+		}
+		if !ok {
+			AbortWithAppError(c, appErrors.ErrUnauthorized)
+			return
+		}
+
+		if security.IsAPIKey(tokenString) {
+			if apiKeys == nil {
+				AbortWithAppError(c, appErrors.ErrInvalidAPIKey)
+				return
+			}
+
+			apiKey, err := apiKeys.AuthenticateAPIKey(c.Request.Context(), tokenString)
+			if err != nil {
+				AbortWithError(c, err)
+				return
+			}
+
+			c.Set(ContextKeyUserID, apiKey.UserID)
+			c.Set(ContextKeyAPIKey, apiKey)
+			c.Next()
+			return
+		}
+
+		claims, err := jwtManager.ValidateAccessToken(tokenString)
+		if err != nil {
+			if errors.Is(err, security.ErrExpiredToken) {
+				AbortWithAppError(c, appErrors.ErrExpiredToken)
+				return
+			}
+			AbortWithAppError(c, appErrors.ErrInvalidToken)
+			return
+		}
+
+		userID, err := uuid.Parse(claims.UserID)
+		if err != nil {
+			AbortWithAppError(c, appErrors.ErrInvalidToken)
+			return
+		}
+
+		if tokenVersions != nil {
+			if err := tokenVersions.CheckTokenVersion(c.Request.Context(), userID, claims.TokenVersion); err != nil {
+				AbortWithError(c, err)
+				return
+			}
+		}
+
+		c.Set(ContextKeyUserID, userID)
+		c.Set(ContextKeyClaims, claims)
+		c.Next()
+	}
+}
+
+// GetUserID returns the authenticated user ID stored by the Authentication middleware
+func GetUserID(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get(ContextKeyUserID)
+	if !exists {
+		return uuid.Nil, false
+	}
+
+	userID, ok := value.(uuid.UUID)
+	return userID, ok
+}
+
+// GetClaims returns the JWT claims stored by the Authentication middleware
+func GetClaims(c *gin.Context) (*security.JWTClaims, bool) {
+	value, exists := c.Get(ContextKeyClaims)
+	if !exists {
+		return nil, false
+	}
+
+	claims, ok := value.(*security.JWTClaims)
+	return claims, ok
+}
+
+// GetAPIKey returns the API key used to authenticate the request, if any
+func GetAPIKey(c *gin.Context) (*domain.APIKey, bool) {
+	value, exists := c.Get(ContextKeyAPIKey)
+	if !exists {
+		return nil, false
+	}
+
+	apiKey, ok := value.(*domain.APIKey)
+	return apiKey, ok
+}
+
+// RequireScope is a middleware that restricts API key requests to keys granting the scope.
+// Requests authenticated with a user session token are not restricted.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey, ok := GetAPIKey(c)
+		if ok && !apiKey.HasScope(scope) {
+			AbortWithAppError(c, appErrors.ErrInsufficientScope.WithDetails(map[string]interface{}{
+				"required_scope": scope,
+			}))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession is a middleware that rejects requests authenticated with an API key,
+// used for account management endpoints that must only be reachable from a user session.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := GetAPIKey(c); ok {
+			AbortWithAppError(c, appErrors.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireRecentAuth is a middleware for sensitive endpoints ("sudo mode"): the session
+// token must carry an auth_time within maxAge. Clients that get
+// REAUTHENTICATION_REQUIRED confirm the password with POST /users/me/reauthenticate, or
+// sign in again, and retry with the new access token. Use after RequireSession.
+func RequireRecentAuth(maxAge time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetClaims(c)
+		if !ok || time.Since(claims.AuthenticatedAt()) > maxAge {
+			AbortWithAppError(c, appErrors.ErrReauthRequired.WithDetails(map[string]interface{}{
+				"max_age_seconds": int(maxAge.Seconds()),
+			}))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAudience is a middleware that restricts session tokens to those issued to one of
+// the client audiences, such as admin endpoints to web-admin tokens. An empty list allows
+// every client. API key requests are not restricted; use RequireSession to reject them.
+func RequireAudience(audiences ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(audiences) == 0 {
+			c.Next()
+			return
+		}
+		if claims, ok := GetClaims(c); ok && !slices.Contains(audiences, claims.Client()) {
+			AbortWithAppError(c, appErrors.ErrClientNotAllowed.WithDetails(map[string]interface{}{
+				"allowed_clients": audiences,
+			}))
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin is a middleware that restricts access to users whose role grants any
+// admin permission. Routes behind it check the specific permission with RequirePermission.
+func RequireAdmin(roles RoleResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, ok := resolveRole(c, roles)
+		if !ok {
+			return
+		}
+
+		if !domain.IsStaffRole(userRole) {
+			AbortWithAppError(c, appErrors.ErrForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission is a middleware that restricts access to users whose role grants
+// the permission. The role is resolved once per request, on every request.
+func RequirePermission(roles RoleResolver, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userRole, ok := resolveRole(c, roles)
+		if !ok {
+			return
+		}
+
+		if !domain.RoleHasPermission(userRole, permission) {
+			AbortWithAppError(c, appErrors.ErrForbidden.WithDetails(map[string]interface{}{
+				"required_permission": permission,
+			}))
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetRole returns the role resolved for the request by an authorization middleware
+func GetRole(c *gin.Context) (string, bool) {
+	value, exists := c.Get(ContextKeyRole)
+	if !exists {
+		return "", false
+	}
+	role, ok := value.(string)
+	return role, ok
+}
+
+// resolveRole returns the role of the authenticated user, resolving it on the first
+// call of the request. It aborts the request and returns false on failure.
+func resolveRole(c *gin.Context, roles RoleResolver) (string, bool) {
+	if role, ok := GetRole(c); ok {
+		return role, true
+	}
+
+	userID, ok := GetUserID(c)
+	if !ok {
+		AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return "", false
+	}
+
+	role, err := roles.UserRole(c.Request.Context(), userID)
+	if err != nil {
+		AbortWithError(c, err)
+		return "", false
+	}
+
+	c.Set(ContextKeyRole, role)
+	return role, true
+}
+
+func extractBearerToken(header string) (string, bool) {
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(parts[1])
+	return token, token != ""
+}
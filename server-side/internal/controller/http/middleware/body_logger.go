@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+)
+
+const (
+	// maxLoggedBody is the size of the largest body BodyLogger logs; larger ones are
+	// logged by their size only
+	maxLoggedBody = 64 << 10
+
+	// redactedValue replaces the value of secret fields in logged bodies
+	redactedValue = "[REDACTED]"
+)
+
+// SecretFields returns the JSON names of the fields tagged secret:"true", such as
+// passwords and tokens, in the given requests and responses and the structs they hold
+func SecretFields(values ...interface{}) map[string]bool {
+	secrets := make(map[string]bool)
+	seen := make(map[reflect.Type]bool)
+	for _, value := range values {
+		if value != nil {
+			collectSecretFields(reflect.TypeOf(value), secrets, seen)
+		}
+	}
+	return secrets
+}
+
+func collectSecretFields(t reflect.Type, secrets map[string]bool, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" && !field.Anonymous {
+			name = field.Name
+		}
+		if name != "-" && field.Tag.Get("secret") == "true" {
+			secrets[name] = true
+		}
+		collectSecretFields(field.Type, secrets, seen)
+	}
+}
+
+// BodyLogger is a middleware that logs the JSON request and response bodies of each
+// request, for debugging. The values of the secret fields, as returned by SecretFields,
+// are redacted wherever they appear in a body. Bodies that are not JSON or are larger
+// than 64 KiB are logged by their size only. Turn it on in development only: bodies
+// hold personal data.
+func BodyLogger(secrets map[string]bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestBody := "-"
+		if c.Request.Body != nil && c.Request.ContentLength != 0 {
+			data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxLoggedBody+1))
+			// Handlers read the body as if it was never read
+			rest := io.Reader(c.Request.Body)
+			if err != nil {
+				rest = errorReader{err}
+			}
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(data), rest), c.Request.Body}
+			requestBody = loggedBody(data, c.ContentType(), len(data) > maxLoggedBody, secrets)
+		}
+
+		writer := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		contentType, _, _ := mime.ParseMediaType(writer.Header().Get("Content-Type"))
+		logger.FromContext(c.Request.Context()).Info("request bodies",
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"status", writer.Status(),
+			"request_body", requestBody,
+			"response_body", loggedBody(writer.body.Bytes(), contentType, writer.truncated, secrets),
+		)
+	}
+}
+
+// loggedBody renders a body for the log: redacted JSON, or its size
+func loggedBody(data []byte, contentType string, truncated bool, secrets map[string]bool) string {
+	if len(data) == 0 {
+		return "-"
+	}
+	if truncated {
+		return fmt.Sprintf("[more than %d bytes of %s]", maxLoggedBody, contentType)
+	}
+
+	// Numbers are kept as written rather than rounded to float64
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if contentType != "application/json" || decoder.Decode(&value) != nil {
+		return fmt.Sprintf("[%d bytes of %s]", len(data), contentType)
+	}
+	redacted, err := json.Marshal(redact(value, secrets))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes of %s]", len(data), contentType)
+	}
+	return string(redacted)
+}
+
+// redact replaces the values of secret fields in a decoded JSON value, at any depth
+func redact(value interface{}, secrets map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if secrets[key] {
+				v[key] = redactedValue
+			} else {
+				v[key] = redact(field, secrets)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redact(item, secrets)
+		}
+	}
+	return value
+}
+
+// bodyRecorder copies a response body as it is written, unless it grows larger than
+// maxLoggedBody
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.record(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.record([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *bodyRecorder) record(data []byte) {
+	if w.truncated {
+		return
+	}
+	if w.body.Len()+len(data) > maxLoggedBody {
+		w.truncated = true
+		return
+	}
+	w.body.Write(data)
+}
+
+// readCloser reads from a reader and closes the original request body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errorReader fails every read with an error
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
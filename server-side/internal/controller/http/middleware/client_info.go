@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+)
+
+// maxUserAgentLength caps the user agent stored with a session
+const maxUserAgentLength = 512
+
+// ClientInfo is a middleware that stores the user agent and IP address of the request
+// in the request context, for the sessions signed in to by the request
+func ClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userAgent := c.Request.UserAgent()
+		if len(userAgent) > maxUserAgentLength {
+			userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+		}
+
+		ctx := security.WithClientInfo(c.Request.Context(), security.ClientInfo{
+			UserAgent: userAgent,
+			IPAddress: c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
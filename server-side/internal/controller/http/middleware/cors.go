@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig holds the cross-origin resource sharing policy
+type CORSConfig struct {
+	// AllowedOrigins lists exact origins, "*" for any origin, or wildcard
+	// subdomains such as "https://*.example.com"
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int // seconds browsers may cache a preflight response
+}
+
+// CORS is a middleware that applies the CORS policy and answers preflight requests.
+// Requests from origins that are not allowed get no CORS headers, so browsers block
+// them; non-browser clients are unaffected. It is a no-op when no origin is allowed.
+func CORS(config CORSConfig) gin.HandlerFunc {
+	allowAll := false
+	for _, origin := range config.AllowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+	}
+	allowedMethods := strings.Join(config.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(config.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(config.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(config.MaxAge)
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || len(config.AllowedOrigins) == 0 {
+			c.Next()
+			return
+		}
+
+		// Responses differ per origin, so shared caches must key on it
+		c.Writer.Header().Add("Vary", "Origin")
+
+		if !allowAll && !originAllowed(config.AllowedOrigins, origin) {
+			c.Next()
+			return
+		}
+
+		// Credentialed requests require the exact origin instead of "*"
+		if allowAll && !config.AllowCredentials {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if config.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !preflight {
+			if exposedHeaders != "" {
+				c.Header("Access-Control-Expose-Headers", exposedHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		c.Header("Access-Control-Allow-Methods", allowedMethods)
+		c.Header("Access-Control-Allow-Headers", allowedHeaders)
+		if config.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// originAllowed checks an origin against exact and wildcard subdomain entries
+func originAllowed(allowedOrigins []string, origin string) bool {
+	for _, allowed := range allowedOrigins {
+		if strings.EqualFold(allowed, origin) {
+			return true
+		}
+
+		// "https://*.example.com" matches "https://app.example.com" but not "https://example.com"
+		scheme, host, ok := strings.Cut(allowed, "://*.")
+		if ok {
+			prefix := scheme + "://"
+			suffix := "." + host
+			if len(origin) > len(prefix)+len(suffix) &&
+				strings.HasPrefix(strings.ToLower(origin), strings.ToLower(prefix)) &&
+				strings.HasSuffix(strings.ToLower(origin), strings.ToLower(suffix)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,16 +1,28 @@
 package middleware
 
 import (
-	"log"
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
 	appErrors "github.com/ingunawandra/catetin/pkg/errors"
 )
 
-// ErrorHandler is a middleware that handles errors returned by handlers
-func ErrorHandler() gin.HandlerFunc {
+// ErrorReporter sends the errors the API could not handle to an error tracker such as
+// Sentry
+type ErrorReporter interface {
+	ReportError(ctx context.Context, err error, request *http.Request, tags map[string]string)
+}
+
+// ErrorHandler is a middleware that handles errors returned by handlers, with the
+// message in the language of the request. Errors answered with 500 are sent to the
+// reporter, if any; other statuses, including the 503 of a disabled feature, are
+// expected.
+func ErrorHandler(reporter ErrorReporter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Process request
 		c.Next()
@@ -23,15 +35,24 @@ func ErrorHandler() gin.HandlerFunc {
 		// Get the last error (most recent)
 		err := c.Errors.Last().Err
 
+		// Whatever failed after the request's deadline passed, most likely failed because
+		// of it, such as a cancelled database query; the cause stays in c.Errors for the
+		// access log
+		if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) {
+			err = appErrors.ErrRequestTimeout
+		}
+
 		// Check if it's an AppError
 		if appErr, ok := appErrors.IsAppError(err); ok {
 			// Use AppError details
 			response := dto.ErrorResponse{
 				Status:  "error",
-				Message: appErr.Message,
+				Message: i18n.T(Language(c), appErr.Message),
 				Errors: map[string]interface{}{
-					"code": appErr.Code,
+					"code":    appErr.Code,
+					"doc_url": appErr.DocURL,
 				},
+				RequestID: GetRequestID(c),
 			}
 
 			// Add additional details if present
@@ -41,22 +62,48 @@ func ErrorHandler() gin.HandlerFunc {
 				}
 			}
 
+			if appErr.HTTPStatus == http.StatusInternalServerError {
+				reportError(c, reporter, err, appErr.Code)
+			}
 			c.JSON(appErr.HTTPStatus, response)
 			return
 		}
 
-		// Handle non-AppError as internal server error
-		log.Printf("Unhandled error: %v", err)
+		reportError(c, reporter, err, appErrors.ErrCodeInternal)
+
+		// Handle non-AppError as internal server error (logged by RequestLogger)
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Status:  "error",
-			Message: "An internal error occurred",
+			Message: i18n.T(Language(c), "An internal error occurred"),
 			Errors: map[string]interface{}{
-				"code": appErrors.ErrCodeInternal,
+				"code":    appErrors.ErrCodeInternal,
+				"doc_url": appErrors.DocURL(appErrors.ErrCodeInternal),
 			},
+			RequestID: GetRequestID(c),
 		})
 	}
 }
 
+// reportError sends an error to the reporter with the request it failed
+func reportError(c *gin.Context, reporter ErrorReporter, err error, code appErrors.ErrorCode) {
+	if reporter == nil {
+		return
+	}
+
+	tags := map[string]string{
+		"code":       string(code),
+		"route":      c.FullPath(),
+		"request_id": GetRequestID(c),
+	}
+	if userID, ok := GetUserID(c); ok {
+		tags["user_id"] = userID.String()
+	}
+	if traceID := tracing.TraceID(c.Request.Context()); traceID != "" {
+		tags["trace_id"] = traceID
+	}
+	reporter.ReportError(c.Request.Context(), err, c.Request, tags)
+}
+
 // AbortWithError is a helper to abort with an AppError
 func AbortWithError(c *gin.Context, err error) {
 	_ = c.Error(err)
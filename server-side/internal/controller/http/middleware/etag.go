@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// Precondition is the version of a resource a request expects with If-Match
+type Precondition struct {
+	Version int
+
+	// Any is set by If-Match: *, which matches whatever version the resource has
+	Any bool
+}
+
+// Matches reports whether a resource with the version meets the precondition
+func (p *Precondition) Matches(version int) bool {
+	return p.Any || p.Version == version
+}
+
+// ETag returns the entity tag of a resource with the version. Versions change on every
+// update, so they are strong validators.
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// SetETag sets the ETag header of a response with a versioned resource
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", ETag(version))
+}
+
+// IfMatch returns the version the If-Match header expects, or nil without the header.
+// Only one entity tag is accepted, since a resource has a single current version.
+func IfMatch(c *gin.Context) (*Precondition, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		return nil, nil
+	}
+	if header == "*" {
+		return &Precondition{Any: true}, nil
+	}
+
+	quoted := len(header) > 1 && header[0] == '"' && header[len(header)-1] == '"'
+	version, err := strconv.Atoi(strings.Trim(header, `"`))
+	if !quoted || err != nil || version < 0 {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"if_match": "If-Match must be * or the ETag of the resource",
+		})
+	}
+	return &Precondition{Version: version}, nil
+}
+
+// NotModified answers 304 Not Modified when If-None-Match lists the ETag of a resource
+// with the version, and reports whether it did
+func NotModified(c *gin.Context, version int) bool {
+	etag := ETag(version)
+	for _, candidate := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			SetETag(c, version)
+			c.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+)
+
+const (
+	// contextKeyUserLocales is the gin context key holding the UserLocales of Localization
+	contextKeyUserLocales = "user_locales"
+
+	// contextKeyLanguage is the gin context key caching the language of the request
+	contextKeyLanguage = "language"
+)
+
+// UserLocales resolves the locale a user chose in their settings
+type UserLocales interface {
+	UserLocale(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+// Localization is a middleware that lets Language fall back to the locale in the settings
+// of the authenticated user when the Accept-Language header names no supported
+// language. Responses vary by Accept-Language.
+func Localization(locales UserLocales) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if locales != nil {
+			c.Set(contextKeyUserLocales, locales)
+		}
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
+
+// Language returns the language to answer a request in: the supported language the
+// Accept-Language header prefers, else the language of the authenticated user's
+// locale setting, else i18n.DefaultLanguage. It is resolved once per request, when
+// first needed, since authentication runs after Localization.
+func Language(c *gin.Context) i18n.Language {
+	if language, ok := c.Get(contextKeyLanguage); ok {
+		return language.(i18n.Language)
+	}
+
+	language, ok := i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if !ok {
+		language = userLanguage(c)
+	}
+
+	c.Set(contextKeyLanguage, language)
+	c.Header("Content-Language", string(language))
+	return language
+}
+
+// userLanguage returns the language of the authenticated user's locale setting, or
+// i18n.DefaultLanguage for anonymous requests
+func userLanguage(c *gin.Context) i18n.Language {
+	value, ok := c.Get(contextKeyUserLocales)
+	if !ok {
+		return i18n.DefaultLanguage
+	}
+	userID, ok := GetUserID(c)
+	if !ok {
+		return i18n.DefaultLanguage
+	}
+
+	locale, err := value.(UserLocales).UserLocale(c.Request.Context(), userID)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).Warn("failed to find user locale", "user_id", userID, "error", err)
+		return i18n.DefaultLanguage
+	}
+	return i18n.FromLocale(locale)
+}
+
+// RespondWithSuccess writes a success response with the message in the language of the
+// request
+func RespondWithSuccess(c *gin.Context, status int, message string, data interface{}) {
+	c.JSON(status, dto.NewSuccessResponse(i18n.T(Language(c), message), data))
+}
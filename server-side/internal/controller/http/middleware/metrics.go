@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// RequireScrapeToken is a middleware that requires the Bearer token Prometheus is
+// configured to send. It lets every request through when token is empty.
+func RequireScrapeToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.Next()
+			return
+		}
+
+		presented, ok := extractBearerToken(c.GetHeader("Authorization"))
+		if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			AbortWithAppError(c, appErrors.ErrUnauthorized)
+			return
+		}
+		c.Next()
+	}
+}
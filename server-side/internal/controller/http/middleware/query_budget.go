@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// QueryBudget is a middleware that counts the database queries executed for each
+// request and logs requests exceeding the budget. In strict mode, queries beyond
+// the budget fail so the request errors out. Intended for development and staging.
+func QueryBudget(budget int, strict bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, counter := repository.WithQueryCounter(c.Request.Context(), budget, strict)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if counter.Exceeded() {
+			logger.FromContext(ctx).Warn("query budget exceeded",
+				"method", c.Request.Method,
+				"route", c.FullPath(),
+				"queries", counter.Count(),
+				"budget", counter.Budget(),
+			)
+		}
+	}
+}
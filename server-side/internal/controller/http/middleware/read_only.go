@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// ReadOnlySwitch reports whether the API only serves reads
+type ReadOnlySwitch interface {
+	ReadOnly() bool
+}
+
+// ReadOnly is a middleware that rejects every request that is not a GET, HEAD, or
+// OPTIONS with READ_ONLY while the switch is on. Routes listed in exemptRoutes, by
+// their route template, are let through, so the switch can be turned off again.
+// It is a no-op when readOnly is nil.
+func ReadOnly(readOnly ReadOnlySwitch, exemptRoutes ...string) gin.HandlerFunc {
+	exempt := make(map[string]bool, len(exemptRoutes))
+	for _, route := range exemptRoutes {
+		exempt[route] = true
+	}
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		if readOnly == nil || !readOnly.ReadOnly() || exempt[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		AbortWithAppError(c, appErrors.ErrReadOnly)
+	}
+}
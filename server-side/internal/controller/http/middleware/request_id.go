@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+)
+
+const (
+	// RequestIDHeader is the header carrying the request correlation ID
+	RequestIDHeader = "X-Request-ID"
+
+	// ContextKeyRequestID is the gin context key holding the request correlation ID
+	ContextKeyRequestID = "request_id"
+)
+
+// validRequestID limits propagated IDs to a safe charset so they can be logged verbatim
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID is a middleware that propagates the caller's X-Request-ID or generates one,
+// echoes it in the response, and attaches a request-scoped logger to the request context
+func RequestID(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Set(ContextKeyRequestID, requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		ctx := logger.WithContext(c.Request.Context(), log.With("request_id", requestID))
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the correlation ID stored by the RequestID middleware
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(ContextKeyRequestID)
+}
+
+// RequestLogger is a middleware that logs method, path, status, and latency of every request
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		case c.FullPath() == "/healthz" || c.FullPath() == "/readyz":
+			// Probes run every few seconds on every instance
+			level = slog.LevelDebug
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("error", c.Errors.Last().Error()))
+		}
+
+		logger.FromContext(c.Request.Context()).LogAttrs(c.Request.Context(), level, "request completed", attrs...)
+	}
+}
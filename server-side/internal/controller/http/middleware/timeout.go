@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout is a middleware that gives each request a context deadline, so database
+// queries and outgoing calls still running at the deadline are cancelled instead of
+// holding connections. Routes in overrides, keyed by their path pattern, get their own
+// timeout; 0 or less runs without a deadline, as event streams do.
+func Timeout(timeout time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := timeout
+		if override, ok := overrides[c.FullPath()]; ok {
+			limit = override
+		}
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), limit)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span for every request with otelgin, continuing the
+// caller's trace when a traceparent header is present, then tags the span with the
+// request ID and puts the trace ID on the request logger. It must run after
+// RequestID so the span carries the request ID and request logs carry the trace ID.
+func Tracing(service string) gin.HandlersChain {
+	return gin.HandlersChain{otelgin.Middleware(service), traceRequest}
+}
+
+func traceRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	if requestID := GetRequestID(c); requestID != "" {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("request_id", requestID))
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		ctx = logger.WithContext(ctx, logger.FromContext(ctx).With("trace_id", traceID))
+		c.Request = c.Request.WithContext(ctx)
+	}
+	c.Next()
+}
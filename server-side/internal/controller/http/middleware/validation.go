@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/http/validation"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// AbortWithValidationError aborts with ErrValidation whose "validation_errors" detail maps
+// each invalid field to its error code and a message in the language of the request
+func AbortWithValidationError(c *gin.Context, err error) {
+	// A body cut off by BodyLimit is too large rather than invalid
+	if limit, ok := isBodyTooLarge(err); ok {
+		AbortWithAppError(c, requestTooLarge(limit))
+		return
+	}
+
+	AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+		"validation_errors": validation.Translate(err, Language(c)),
+	}))
+}
@@ -0,0 +1,351 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/controller/http/validation"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+)
+
+// Names of the security schemes of the document
+const (
+	SchemeBearer           = "bearerAuth"
+	SchemeAPIKey           = "apiKeyAuth"
+	SchemeWebhookSignature = "webhookSignature"
+	SchemeScrapeToken      = "scrapeToken"
+)
+
+// Auth is how a route authenticates requests
+type Auth int
+
+const (
+	// AuthNone accepts anonymous requests
+	AuthNone Auth = iota
+
+	// AuthUser accepts a user's access token or an API key
+	AuthUser
+
+	// AuthSession accepts a user's access token only; API keys are rejected
+	AuthSession
+
+	// AuthAdmin accepts the access token of a staff member with the route's permission
+	AuthAdmin
+
+	// AuthWebhook requires the signature of the webhook provider
+	AuthWebhook
+
+	// AuthScrapeToken requires the metrics scrape token when one is configured
+	AuthScrapeToken
+)
+
+// Route describes one route of the router for the document
+type Route struct {
+	Method string
+	Path   string // as registered with gin, e.g. /api/v1/money-flows/:id
+
+	// OperationID names the operation in generated clients, so it must stay stable
+	OperationID string
+	Summary     string
+	Description string
+	Tag         string
+
+	Auth       Auth
+	Scope      string // API key scope required by AuthUser routes
+	Permission string // staff permission required by AuthAdmin routes
+	Recent     bool   // requires a recent sign-in or reauthentication
+
+	Query any // struct bound from the query string
+	Body  any // struct bound from a JSON body
+	Form  any // struct bound from a multipart/form-data body
+
+	// Status is the status of a successful response; 200 when zero. AlsoStatus lists
+	// other statuses returned with the same body.
+	Status     int
+	AlsoStatus []int
+
+	// Data is the data of the success envelope; nil when the envelope has none
+	Data any
+
+	// Raw is a JSON response body sent without the success envelope
+	Raw any
+
+	// ContentType is the content type of a response body that is not JSON
+	ContentType string
+
+	// Empty marks a successful response without a body
+	Empty bool
+}
+
+// Build builds the document of the routes. It fails when two routes share a method and
+// path or an operation ID.
+func Build(info Info, routes []Route) (*Document, error) {
+	g := newSchemaGenerator()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Servers: []Server{{URL: "/"}},
+		Paths:   make(map[string]PathItem),
+	}
+
+	operationIDs := make(map[string]bool)
+	tags := make(map[string]bool)
+	for _, route := range routes {
+		if operationIDs[route.OperationID] {
+			return nil, fmt.Errorf("duplicate operation ID %q", route.OperationID)
+		}
+		operationIDs[route.OperationID] = true
+
+		path, params := pathTemplate(route.Path)
+		method := strings.ToLower(route.Method)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+		if _, exists := item[method]; exists {
+			return nil, fmt.Errorf("duplicate route %s %s", route.Method, route.Path)
+		}
+		item[method] = g.operation(route, params)
+
+		if route.Tag != "" && !tags[route.Tag] {
+			tags[route.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: route.Tag})
+		}
+	}
+
+	doc.Components = g.components()
+	return doc, nil
+}
+
+// pathTemplate converts a gin path to an OpenAPI path template and returns the names of
+// its parameters
+func pathTemplate(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// operation builds the operation of a route
+func (g *schemaGenerator) operation(route Route, params []string) *Operation {
+	op := &Operation{
+		OperationID: route.OperationID,
+		Summary:     route.Summary,
+		Description: strings.Join(append([]string{route.Description}, authNotes(route)...), "\n\n"),
+		Responses:   make(map[string]*Response),
+		Security:    securityRequirements(route.Auth),
+	}
+	op.Description = strings.TrimSpace(op.Description)
+	if route.Tag != "" {
+		op.Tags = []string{route.Tag}
+	}
+
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string", Format: "uuid"},
+		})
+	}
+	if route.Query != nil {
+		op.Parameters = append(op.Parameters, g.parameters(structType(route.Query))...)
+	}
+
+	switch {
+	case route.Body != nil:
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(route.Body))}},
+		}
+	case route.Form != nil:
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"multipart/form-data": {Schema: g.object(structType(route.Form), "form")}},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := g.successResponse(route)
+	for _, code := range append([]int{status}, route.AlsoStatus...) {
+		op.Responses[strconv.Itoa(code)] = &Response{Description: http.StatusText(code), Content: success}
+	}
+
+	if route.Query != nil || route.Body != nil || route.Form != nil {
+		op.Responses["400"] = responseRef("ValidationError")
+	}
+	if route.Auth != AuthNone {
+		op.Responses["401"] = responseRef("Unauthorized")
+	}
+	if route.Scope != "" || route.Auth == AuthSession || route.Auth == AuthAdmin || route.Recent {
+		op.Responses["403"] = responseRef("Forbidden")
+	}
+	if len(params) > 0 {
+		op.Responses["404"] = responseRef("NotFound")
+	}
+	op.Responses["default"] = responseRef("Error")
+
+	return op
+}
+
+// successResponse returns the content of the successful response of a route
+func (g *schemaGenerator) successResponse(route Route) map[string]*MediaType {
+	switch {
+	case route.Empty:
+		return nil
+	case route.ContentType != "":
+		format := "binary"
+		if strings.HasPrefix(route.ContentType, "text/plain") {
+			format = ""
+		}
+		return map[string]*MediaType{route.ContentType: {Schema: &Schema{Type: "string", Format: format}}}
+	case route.Raw != nil:
+		return map[string]*MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(route.Raw))}}
+	case route.Data == nil:
+		return map[string]*MediaType{"application/json": {Schema: g.schema(reflect.TypeOf(dto.SuccessResponse{}))}}
+	default:
+		return map[string]*MediaType{"application/json": {Schema: &Schema{AllOf: []*Schema{
+			g.schema(reflect.TypeOf(dto.SuccessResponse{})),
+			{
+				Type:       "object",
+				Properties: map[string]*Schema{"data": g.schema(reflect.TypeOf(route.Data))},
+				Required:   []string{"data"},
+			},
+		}}}}
+	}
+}
+
+// authNotes explains the authorization rules of a route that security schemes cannot express
+func authNotes(route Route) []string {
+	var notes []string
+	switch route.Auth {
+	case AuthUser:
+		if route.Scope != "" {
+			notes = append(notes, fmt.Sprintf("API keys need the `%s` scope.", route.Scope))
+		}
+	case AuthSession:
+		notes = append(notes, "Requires a user session; API keys are rejected.")
+	case AuthAdmin:
+		notes = append(notes, fmt.Sprintf("Requires the session of a staff member with the `%s` permission.", route.Permission))
+	case AuthScrapeToken:
+		notes = append(notes, "Requires the scrape token as a Bearer token when METRICS_TOKEN is set.")
+	}
+	if route.Recent {
+		notes = append(notes, "Requires a recent sign-in; otherwise confirm the password with `POST /api/v1/users/me/reauthenticate` first.")
+	}
+	return notes
+}
+
+// securityRequirements returns the alternative ways of authenticating a request
+func securityRequirements(auth Auth) []SecurityRequirement {
+	switch auth {
+	case AuthUser:
+		return []SecurityRequirement{{SchemeBearer: {}}, {SchemeAPIKey: {}}}
+	case AuthSession, AuthAdmin:
+		return []SecurityRequirement{{SchemeBearer: {}}}
+	case AuthWebhook:
+		return []SecurityRequirement{{SchemeWebhookSignature: {}}}
+	case AuthScrapeToken:
+		return []SecurityRequirement{{SchemeScrapeToken: {}}, {}}
+	default:
+		return nil
+	}
+}
+
+// components returns the schemas collected while building the operations, with the
+// shared error responses and the security schemes
+func (g *schemaGenerator) components() Components {
+	errorSchema := g.schema(reflect.TypeOf(dto.ErrorResponse{}))
+	errorComponent := g.schemas[strings.TrimPrefix(errorSchema.Ref, schemaRefPrefix)]
+	errorComponent.Required = []string{"status", "message"}
+	errorComponent.Properties["status"].Enum = []any{"error"}
+	errorComponent.Properties["errors"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "string", Description: "Machine-readable error code, such as VALIDATION_ERROR or NOT_FOUND"},
+			"doc_url": {Type: "string", Description: "Documentation of the code, relative to the API's base URL: /api/v1/meta/errors/{code}"},
+			"validation_errors": {
+				Type:                 "object",
+				Description:          "The error of each invalid field, in the language of the Accept-Language header",
+				AdditionalProperties: g.schema(reflect.TypeOf(validation.FieldError{})),
+			},
+		},
+		Required:             []string{"code", "doc_url"},
+		AdditionalProperties: &Schema{},
+	}
+
+	successSchema := g.schemas[strings.TrimPrefix(g.schema(reflect.TypeOf(dto.SuccessResponse{})).Ref, schemaRefPrefix)]
+	successSchema.Required = []string{"status"}
+	successSchema.Properties["status"].Enum = []any{"success"}
+
+	errorResponse := func(description string) *Response {
+		return &Response{
+			Description: description,
+			Content:     map[string]*MediaType{"application/json": {Schema: errorSchema}},
+		}
+	}
+
+	return Components{
+		Schemas: g.schemas,
+		Responses: map[string]*Response{
+			"ValidationError": errorResponse("The request is invalid; errors.validation_errors lists the invalid fields"),
+			"Unauthorized":    errorResponse("Authentication is missing or invalid"),
+			"Forbidden":       errorResponse("The caller is not allowed to do this"),
+			"NotFound":        errorResponse("The resource does not exist"),
+			"Error":           errorResponse("Error"),
+		},
+		SecuritySchemes: map[string]*SecurityScheme{
+			SchemeBearer: {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+				Description:  "An access token from POST /api/v1/authentications/login, or an API key",
+			},
+			SchemeAPIKey: {
+				Type:        "apiKey",
+				In:          "header",
+				Name:        middleware.APIKeyHeader,
+				Description: "An API key created with POST /api/v1/users/me/api-keys",
+			},
+			SchemeWebhookSignature: {
+				Type:        "apiKey",
+				In:          "header",
+				Name:        whatsapp.SignatureHeader,
+				Description: "HMAC-SHA256 of the body with the WhatsApp app secret, as sha256=<hex>",
+			},
+			SchemeScrapeToken: {
+				Type:        "http",
+				Scheme:      "bearer",
+				Description: "The metrics scrape token (METRICS_TOKEN)",
+			},
+		},
+	}
+}
+
+// responseRef refers to a shared response
+func responseRef(name string) *Response {
+	return &Response{Ref: "#/components/responses/" + name}
+}
+
+// structType returns the struct type of a value or pointer to one
+func structType(v any) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
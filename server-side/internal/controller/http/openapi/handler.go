@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUIVersion is the swagger-ui-dist release loaded by the documentation page
+const swaggerUIVersion = "5.17.14"
+
+// swaggerUIPolicy is the Content-Security-Policy of the documentation page: Swagger UI
+// from the CDN, the inline script with the nonce, and requests for the spec to this origin
+const swaggerUIPolicy = "default-src 'none'; script-src https://cdn.jsdelivr.net 'nonce-%s'; " +
+	"style-src https://cdn.jsdelivr.net 'unsafe-inline'; img-src 'self' data:; connect-src 'self'; " +
+	"frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+var swaggerUIPage = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@{{.Version}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js" crossorigin></script>
+  <script nonce="{{.Nonce}}">
+    window.ui = SwaggerUIBundle({
+      url: {{.SpecURL}},
+      dom_id: "#swagger-ui",
+      deepLinking: true,
+      persistAuthorization: true
+    });
+  </script>
+</body>
+</html>
+`))
+
+// SpecHandler serves the document as JSON
+func SpecHandler(doc *Document) (gin.HandlerFunc, error) {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+	}, nil
+}
+
+// UIHandler serves Swagger UI for the document at specURL. The page loads Swagger UI
+// from the jsDelivr CDN, so the browser needs internet access. Its own
+// Content-Security-Policy replaces the one of API responses.
+func UIHandler(title, specURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		nonce := make([]byte, 16)
+		if _, err := rand.Read(nonce); err != nil {
+			_ = c.AbortWithError(http.StatusInternalServerError, err)
+			return
+		}
+		encodedNonce := base64.StdEncoding.EncodeToString(nonce)
+
+		c.Status(http.StatusOK)
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		c.Header("Content-Security-Policy", fmt.Sprintf(swaggerUIPolicy, encodedNonce))
+		_ = swaggerUIPage.Execute(c.Writer, map[string]string{
+			"Title":   title,
+			"Version": swaggerUIVersion,
+			"SpecURL": specURL,
+			"Nonce":   encodedNonce,
+		})
+	}
+}
+
+// Undocumented returns the registered routes that no Route describes, as "METHOD path"
+func Undocumented(registered gin.RoutesInfo, routes []Route) []string {
+	documented := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		documented[route.Method+" "+route.Path] = true
+	}
+
+	var missing []string
+	for _, route := range registered {
+		if key := route.Method + " " + route.Path; !documented[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,291 @@
+package openapi
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	uuidType        = reflect.TypeOf(uuid.UUID{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	fileHeaderType  = reflect.TypeOf(multipart.FileHeader{})
+	schemaRefPrefix = "#/components/schemas/"
+
+	// dateLayoutReplacer spells the Go date layouts of datetime rules as clients know them
+	dateLayoutReplacer = strings.NewReplacer("2006", "YYYY", "01", "MM", "02", "DD")
+)
+
+// schemaGenerator reflects Go types into schemas. Named structs become component
+// schemas that other schemas refer to, so each DTO is described once.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// field is a struct field as bound or serialized under one tag key
+type field struct {
+	name    string
+	typ     reflect.Type
+	binding string
+}
+
+// fields lists the fields of a struct named by the tag key ("json" or "form"), with the
+// fields of embedded structs promoted like encoding/json and gin binding do
+func fields(t reflect.Type, tagKey string) []field {
+	var result []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup(tagKey)
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && !hasTag {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				result = append(result, fields(embedded, tagKey)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		result = append(result, field{name: name, typ: f.Type, binding: f.Tag.Get("binding")})
+	}
+	return result
+}
+
+// schema returns the schema of a type, referring to the component schema of a named struct
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{}
+	case fileHeaderType:
+		return &Schema{Type: "string", Format: "binary"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem())
+		if t.Elem() == fileHeaderType {
+			return s
+		}
+		if s.Ref != "" {
+			// Siblings of $ref are ignored, so wrap the reference to make it nullable
+			return &Schema{AllOf: []*Schema{s}, Nullable: true}
+		}
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer"}
+	case reflect.Int32, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t, "json")
+		}
+		return &Schema{Ref: schemaRefPrefix + g.component(t)}
+	default:
+		// interface{} and anything else holds any value
+		return &Schema{}
+	}
+}
+
+// component registers the schema of a named struct and returns its component name
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		// Types of different packages may share a name, so prefix the package name
+		pkg := t.PkgPath()
+		pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+
+	// Register before reflecting the fields so recursive types refer to themselves
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.object(t, "json")
+	return name
+}
+
+// object returns the object schema of a struct whose fields are named by the tag key
+func (g *schemaGenerator) object(t reflect.Type, tagKey string) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, f := range fields(t, tagKey) {
+		property := g.schema(f.typ)
+		if applyBinding(property, f.typ, f.binding) {
+			s.Required = append(s.Required, f.name)
+		}
+		s.Properties[f.name] = property
+	}
+	return s
+}
+
+// parameters returns the query parameters bound from the form tags of a struct
+func (g *schemaGenerator) parameters(t reflect.Type) []*Parameter {
+	var params []*Parameter
+	for _, f := range fields(t, "form") {
+		s := g.schema(f.typ)
+		params = append(params, &Parameter{
+			Name:     f.name,
+			In:       "query",
+			Required: applyBinding(s, f.typ, f.binding),
+			Schema:   s,
+		})
+	}
+	return params
+}
+
+// applyBinding adds the constraints of gin binding rules to the schema of a value of
+// type t, and reports whether the rules require the value. Rules after dive constrain
+// the items of a slice.
+func applyBinding(s *Schema, t reflect.Type, rules string) bool {
+	if rules == "" {
+		return false
+	}
+
+	required := false
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			elem := t
+			for elem.Kind() == reflect.Pointer {
+				elem = elem.Elem()
+			}
+			if s.Items != nil && (elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array) {
+				_, rest, _ := strings.Cut(rules, "dive,")
+				applyBinding(s.Items, elem.Elem(), rest)
+			}
+			return required
+		default:
+			applyRule(s, t, name, param)
+		}
+	}
+	return required
+}
+
+// applyRule adds the constraint of one binding rule to a schema
+func applyRule(s *Schema, t reflect.Type, name, param string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	kind := t.Kind()
+	isNumber := kind >= reflect.Int && kind <= reflect.Float64
+	isList := kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map
+
+	switch name {
+	case "min", "max", "len":
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		switch {
+		case isNumber:
+			if name != "max" {
+				s.Minimum = &n
+			}
+			if name != "min" {
+				s.Maximum = &n
+			}
+		case isList:
+			count := int(n)
+			if name != "max" {
+				s.MinItems = &count
+			}
+			if name != "min" {
+				s.MaxItems = &count
+			}
+		case kind == reflect.String:
+			length := int(n)
+			if name != "max" {
+				s.MinLength = &length
+			}
+			if name != "min" {
+				s.MaxLength = &length
+			}
+		}
+	case "gt", "gte", "lt", "lte":
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil || !isNumber {
+			return
+		}
+		if strings.HasPrefix(name, "g") {
+			s.Minimum, s.ExclusiveMinimum = &n, name == "gt"
+		} else {
+			s.Maximum, s.ExclusiveMaximum = &n, name == "lt"
+		}
+	case "oneof":
+		for _, value := range strings.Fields(param) {
+			if isNumber {
+				if n, err := strconv.ParseFloat(value, 64); err == nil {
+					s.Enum = append(s.Enum, n)
+				}
+				continue
+			}
+			s.Enum = append(s.Enum, value)
+		}
+	case "currency":
+		length := 3
+		s.MinLength, s.MaxLength = &length, &length
+		s.Description = "ISO 4217 currency code, listed by GET /api/v1/meta/currencies"
+	case "email":
+		s.Format = "email"
+	case "uuid", "uuid4":
+		s.Format = "uuid"
+	case "datetime":
+		if param == time.DateOnly {
+			s.Format = "date"
+		} else {
+			s.Description = "Formatted as " + dateLayoutReplacer.Replace(param)
+		}
+	}
+}
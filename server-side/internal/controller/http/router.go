@@ -1,48 +1,498 @@
 package http
 
 import (
+	"log/slog"
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
-	v1 "github.com/ingunawandra/catetin/internal/controller/http/v1"
 	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/controller/http/openapi"
+	v1 "github.com/ingunawandra/catetin/internal/controller/http/v1"
+	"github.com/ingunawandra/catetin/internal/controller/http/validation"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
 )
 
 // RouterConfig holds the configuration for setting up routes
 type RouterConfig struct {
-	AuthHandler *v1.AuthHandler
+	AuthHandler         *v1.AuthHandler
+	UserHandler         *v1.UserHandler
+	APIKeyHandler       *v1.APIKeyHandler
+	WebhookHandler      *v1.WebhookHandler
+	DeviceHandler       *v1.DeviceHandler
+	MoneyFlowHandler    *v1.MoneyFlowHandler
+	MoneyFlowShare      *v1.MoneyFlowShareHandler
+	BudgetHandler       *v1.BudgetHandler
+	WalletHandler       *v1.WalletHandler
+	GoalHandler         *v1.GoalHandler
+	GroupHandler        *v1.GroupHandler
+	SplitHandler        *v1.SplitHandler
+	TagHandler          *v1.TagHandler
+	NotificationHandler *v1.NotificationHandler
+	EventHandler        *v1.EventHandler
+	AuditLogHandler     *v1.AuditLogHandler
+	AdminHandler        *v1.AdminHandler
+	HealthHandler       *v1.HealthHandler
+	ErrorCatalog        *v1.ErrorCatalogHandler
+	Currencies          *v1.CurrencyHandler
+	BroadcastHandler    *v1.BroadcastHandler
+	MerchantHandler     *v1.MerchantHandler
+	InvitationHandler   *v1.InvitationHandler
+	PasswordReset       *v1.PasswordResetHandler
+	SessionHandler      *v1.SessionHandler
+	FeedbackHandler     *v1.FeedbackHandler
+	APIUsageHandler     *v1.APIUsageHandler
+	DemoHandler         *v1.DemoHandler
+	LogLevelHandler     *v1.LogLevelHandler
+	ConfigHandler       *v1.ConfigHandler
+	WhatsAppHandler     *v1.WhatsAppWebhookHandler
+	ExportHandler       *v1.AnalyticsExportHandler
+	MoneyFlowExport     *v1.MoneyFlowExportHandler
+	AccountExport       *v1.AccountExportHandler
+	AccountErasure      *v1.AccountErasureHandler
+	UserAuthHandler     *v1.UserAuthHandler
+	ReadOnlyHandler     *v1.ReadOnlyHandler
+	ReceiptHandler      *v1.ReceiptHandler
+	CategorySuggestion  *v1.CategorySuggestionHandler
+	ReportHandler       *v1.ReportHandler
+	DigestHandler       *v1.DigestHandler
+	StatementHandler    *v1.StatementHandler
+	DashboardHandler    *v1.DashboardHandler
+	JWTManager          *security.JWTManager
+	APIKeyAuth          middleware.APIKeyAuthenticator
+	TokenVersions       middleware.TokenVersionChecker
+	RoleResolver        middleware.RoleResolver
+	UserLocales         middleware.UserLocales
+	Analytics           middleware.FeatureTracker
+	APIUsage            middleware.UsageRecorder
+	ReadOnly            middleware.ReadOnlySwitch
+	ErrorReporter       middleware.ErrorReporter // receives errors answered with 500; nil reports none
+	Logger              *slog.Logger
+	CORS                middleware.CORSConfig
+	SecurityHeaders     middleware.SecurityHeadersConfig
+
+	// MaxBodySize and MaxUploadSize limit request bodies in bytes, the latter on routes
+	// taking file uploads
+	MaxBodySize   int64
+	MaxUploadSize int64
+
+	// RequestTimeout is the deadline of each request's context, 0 for none;
+	// RequestTimeouts overrides it per route path
+	RequestTimeout  time.Duration
+	RequestTimeouts map[string]time.Duration
+
+	// Metrics serves Prometheus metrics at /metrics when set, behind MetricsToken if given
+	Metrics      http.Handler
+	MetricsToken string
+
+	// ServiceName names this server on request spans
+	ServiceName string
+
+	// ReauthMaxAge is how recently a session must have authenticated to use sensitive
+	// endpoints such as removing a credential
+	ReauthMaxAge time.Duration
+
+	// AdminAudiences are the token audiences (client types) allowed on admin endpoints;
+	// empty allows every client
+	AdminAudiences []string
+
+	// LogBodies logs request and response bodies, with secrets redacted, for debugging
+	LogBodies bool
+
+	// QueryBudget enables the per-request query budget guard when greater than 0
+	QueryBudget       int
+	QueryBudgetStrict bool
 	// Add more handlers here as needed
 }
 
 // SetupRouter sets up the HTTP router with all routes
 func SetupRouter(config *RouterConfig) *gin.Engine {
 	// Create Gin router
-	router := gin.Default()
+	router := gin.New()
+
+	// Name fields in validation errors as clients send them, in the client's language
+	if err := validation.Register(); err != nil {
+		config.Logger.Error("failed to register validation translations", "error", err)
+	}
+
+	// Security headers go on every response. CORS answers preflight requests before
+	// anything else runs. Request correlation, tracing, and structured access logs come
+	// next so every other middleware and handler logs with the request and trace IDs
+	router.Use(
+		middleware.SecurityHeaders(config.SecurityHeaders),
+		middleware.CORS(config.CORS),
+		middleware.RequestID(config.Logger),
+	)
+	router.Use(middleware.Tracing(config.ServiceName)...)
+	router.Use(
+		middleware.RequestLogger(),
+		gin.Recovery(),
+	)
+
+	// Log bodies with the fields the requests and responses tag secret:"true" redacted.
+	// It wraps the error handler to log error responses too.
+	if config.LogBodies {
+		var bodies []interface{}
+		for _, route := range apiRoutes(config) {
+			bodies = append(bodies, route.Body, route.Data, route.Raw)
+		}
+		router.Use(middleware.BodyLogger(middleware.SecretFields(bodies...)))
+	}
+
+	// Answer in the client's language, or the one in the user's settings
+	router.Use(middleware.Localization(config.UserLocales))
 
 	// Apply error handler middleware globally
-	router.Use(middleware.ErrorHandler())
+	router.Use(middleware.ErrorHandler(config.ErrorReporter))
+
+	// Cancel work still running at the request's deadline. Event streams stay open, and
+	// receipt scans and exports get longer unless configured otherwise.
+	timeouts := map[string]time.Duration{
+		"/api/v1/users/me/events":          0,
+		"/api/v1/money-flows/scan-receipt": time.Minute,
+		"/api/v1/money-flows/export":       5 * time.Minute,
+	}
+	for path, timeout := range config.RequestTimeouts {
+		timeouts[path] = timeout
+	}
+	router.Use(middleware.Timeout(config.RequestTimeout, timeouts))
+
+	// Reject oversized request bodies with 413
+	router.Use(middleware.BodyLimit(config.MaxBodySize, map[string]int64{
+		"/api/v1/money-flows/scan-receipt": config.MaxUploadSize,
+	}))
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "healthy",
-			"service": "catetin-api",
-		})
-	})
+	// Reject writes while read-only mode is on. Signing in stays possible so users can
+	// keep reading, and admins can turn the mode off again.
+	router.Use(middleware.ReadOnly(config.ReadOnly,
+		"/api/v1/authentications/login",
+		"/api/v1/authentications/refresh",
+		"/api/v1/admin/read-only",
+	))
+
+	// Count authenticated requests per user and endpoint
+	router.Use(middleware.TrackAPIUsage(config.APIUsage))
+
+	// Flag requests running more queries than expected (development/staging only)
+	if config.QueryBudget > 0 {
+		router.Use(middleware.QueryBudget(config.QueryBudget, config.QueryBudgetStrict))
+	}
+
+	// Kubernetes probes: liveness checks the process only, readiness its dependencies
+	router.GET("/healthz", config.HealthHandler.Liveness)
+	router.GET("/readyz", config.HealthHandler.Readiness)
+
+	// Prometheus scrape endpoint
+	if config.Metrics != nil {
+		router.GET("/metrics", middleware.RequireScrapeToken(config.MetricsToken), gin.WrapH(config.Metrics))
+	}
+
+	// Record anonymized feature usage on successful requests
+	track := func(feature string) gin.HandlerFunc {
+		return middleware.TrackFeature(config.Analytics, feature)
+	}
+
+	// Sensitive account changes require a recent sign-in or password confirmation
+	sudo := middleware.RequireRecentAuth(config.ReauthMaxAge)
+
+	// Admin endpoints are gated by the permissions of the staff member's role
+	can := func(permission string) gin.HandlerFunc {
+		return middleware.RequirePermission(config.RoleResolver, permission)
+	}
 
 	// API v1 routes
 	v1Group := router.Group("/api/v1")
 	{
-		// Authentication routes
-		authGroup := v1Group.Group("/authentications")
+		// Authentication routes; the device signing in is recorded with its session
+		authGroup := v1Group.Group("/authentications", middleware.ClientInfo())
 		{
 			authGroup.POST("/register", config.AuthHandler.Register)
 			authGroup.POST("/login", config.AuthHandler.Login)
+			authGroup.POST("/refresh", config.AuthHandler.Refresh)
+			authGroup.POST("/demo", config.DemoHandler.Create)
+			authGroup.POST("/invitations/accept", config.InvitationHandler.Accept)
+			authGroup.POST("/password-reset", config.PasswordReset.Request)
+			authGroup.POST("/password-reset/confirm", config.PasswordReset.Confirm)
+		}
+
+		// Account export downloads are authorized by the signature of their link
+		v1Group.GET("/account-exports/:user_id/:id", config.AccountExport.Download)
+
+		// Shared money flows are authorized by the signature of their link
+		v1Group.GET("/shared/money-flows/:id", config.MoneyFlowShare.View)
+
+		// Error codes; the doc_url of every error response points here
+		v1Group.GET("/meta/errors", config.ErrorCatalog.List)
+		v1Group.GET("/meta/errors/:code", config.ErrorCatalog.Get)
+		v1Group.GET("/meta/currencies", config.Currencies.List)
+
+		// Authenticated user routes
+		meGroup := v1Group.Group("/users/me")
+		meGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			meGroup.GET("", middleware.RequireScope(domain.ScopeRead), config.UserHandler.GetProfile)
+			meGroup.PATCH("", middleware.RequireScope(domain.ScopeWrite), config.UserHandler.UpdateProfile)
+			meGroup.DELETE("", middleware.RequireSession(), sudo, config.UserHandler.DeleteAccount)
+
+			meGroup.POST("/password", middleware.RequireSession(), config.UserHandler.ChangePassword)
+			meGroup.POST("/reauthenticate", middleware.RequireSession(), config.UserHandler.Reauthenticate)
+			meGroup.POST("/export", middleware.RequireSession(), sudo, track("account.export"), config.AccountExport.Request)
+
+			meGroup.GET("/erasure", middleware.RequireSession(), config.AccountErasure.Get)
+			meGroup.POST("/erasure", middleware.RequireSession(), sudo, track("account.erasure"), config.AccountErasure.Request)
+			meGroup.DELETE("/erasure", middleware.RequireSession(), config.AccountErasure.Cancel)
+
+			meGroup.GET("/auth-providers", middleware.RequireScope(domain.ScopeRead), config.UserHandler.ListAuthProviders)
+			meGroup.POST("/auth-providers", middleware.RequireSession(), sudo, track("auth_provider.link"), config.UserHandler.LinkAuthProvider)
+			meGroup.POST("/auth-providers/phone-otp/code", middleware.RequireSession(), sudo, track("auth_provider.phone_code"), config.UserHandler.SendPhoneVerificationCode)
+			meGroup.DELETE("/auth-providers/:id", middleware.RequireSession(), sudo, track("auth_provider.unlink"), config.UserHandler.UnlinkAuthProvider)
+
+			meGroup.GET("/settings", middleware.RequireScope(domain.ScopeRead), config.UserHandler.GetSettings)
+			meGroup.PATCH("/settings", middleware.RequireSession(), config.UserHandler.UpdateSettings)
+
+			// API key management is only available from a user session
+			apiKeyGroup := meGroup.Group("/api-keys")
+			apiKeyGroup.Use(middleware.RequireSession())
+			{
+				apiKeyGroup.GET("", config.APIKeyHandler.List)
+				apiKeyGroup.POST("", track("api_key.create"), config.APIKeyHandler.Create)
+				apiKeyGroup.DELETE("/:id", config.APIKeyHandler.Revoke)
+			}
+
+			// Webhooks receive the user's events, so only a user session may manage them
+			webhookGroup := meGroup.Group("/webhooks")
+			webhookGroup.Use(middleware.RequireSession())
+			{
+				webhookGroup.GET("", config.WebhookHandler.List)
+				webhookGroup.POST("", track("webhook.create"), config.WebhookHandler.Create)
+				webhookGroup.GET("/:id", config.WebhookHandler.Get)
+				webhookGroup.PUT("/:id", config.WebhookHandler.Update)
+				webhookGroup.DELETE("/:id", config.WebhookHandler.Delete)
+				webhookGroup.GET("/:id/deliveries", config.WebhookHandler.ListDeliveries)
+				webhookGroup.POST("/:id/ping", config.WebhookHandler.Ping)
+			}
+
+			meGroup.GET("/api-usage", middleware.RequireScope(domain.ScopeRead), config.APIUsageHandler.GetMine)
+
+			// Push notifications reach the user's devices, so only a user session may add one
+			meGroup.GET("/devices", middleware.RequireSession(), config.DeviceHandler.List)
+			meGroup.POST("/devices", middleware.RequireSession(), config.DeviceHandler.Register)
+			meGroup.DELETE("/devices/:id", middleware.RequireSession(), config.DeviceHandler.Unregister)
+
+			meGroup.GET("/sessions", middleware.RequireSession(), config.SessionHandler.List)
+			meGroup.DELETE("/sessions", middleware.RequireSession(), track("session.revoke_others"), config.SessionHandler.RevokeOthers)
+			meGroup.DELETE("/sessions/:id", middleware.RequireSession(), track("session.revoke"), config.SessionHandler.Revoke)
+
+			meGroup.GET("/notifications", middleware.RequireScope(domain.ScopeRead), config.NotificationHandler.List)
+			meGroup.POST("/notifications/:id/read", middleware.RequireScope(domain.ScopeWrite), config.NotificationHandler.MarkRead)
+
+			meGroup.GET("/audit-logs", middleware.RequireScope(domain.ScopeRead), config.AuditLogHandler.List)
+
+			// Server-sent events stream changes as they commit
+			meGroup.GET("/events", middleware.RequireScope(domain.ScopeRead), config.EventHandler.Stream)
+		}
+
+		// Money flow routes
+		moneyFlowGroup := v1Group.Group("/money-flows")
+		moneyFlowGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			moneyFlowGroup.GET("", middleware.RequireScope(domain.ScopeRead), track("money_flow.list"), config.MoneyFlowHandler.List)
+			moneyFlowGroup.POST("", middleware.RequireScope(domain.ScopeWrite), track("money_flow.create"), config.MoneyFlowHandler.Create)
+			moneyFlowGroup.POST("/bulk", middleware.RequireScope(domain.ScopeWrite), track("money_flow.bulk_create"), config.MoneyFlowHandler.BulkCreate)
+			moneyFlowGroup.POST("/import", middleware.RequireScope(domain.ScopeWrite), track("money_flow.import"), config.MoneyFlowHandler.Import)
+			moneyFlowGroup.POST("/reconcile", middleware.RequireScope(domain.ScopeRead), track("money_flow.reconcile"), config.MoneyFlowHandler.Reconcile)
+			moneyFlowGroup.POST("/scan-receipt", middleware.RequireScope(domain.ScopeWrite), track("money_flow.scan_receipt"), config.ReceiptHandler.ScanReceipt)
+			moneyFlowGroup.GET("/suggest-category", middleware.RequireScope(domain.ScopeRead), track("money_flow.suggest_category"), config.CategorySuggestion.Suggest)
+			moneyFlowGroup.GET("/summary", middleware.RequireScope(domain.ScopeRead), config.MoneyFlowHandler.Summary)
+			moneyFlowGroup.GET("/export", middleware.RequireScope(domain.ScopeExport), track("money_flow.export"), config.MoneyFlowExport.Export)
+			moneyFlowGroup.GET("/:id", middleware.RequireScope(domain.ScopeRead), config.MoneyFlowHandler.Get)
+			moneyFlowGroup.PUT("/:id", middleware.RequireScope(domain.ScopeWrite), track("money_flow.update"), config.MoneyFlowHandler.Update)
+			moneyFlowGroup.PATCH("/:id", middleware.RequireScope(domain.ScopeWrite), track("money_flow.patch"), config.MoneyFlowHandler.Patch)
+			moneyFlowGroup.DELETE("/:id", middleware.RequireScope(domain.ScopeWrite), track("money_flow.delete"), config.MoneyFlowHandler.Delete)
+			moneyFlowGroup.GET("/:id/splits", middleware.RequireScope(domain.ScopeRead), config.SplitHandler.GetSplits)
+			moneyFlowGroup.PUT("/:id/splits", middleware.RequireScope(domain.ScopeWrite), track("money_flow.split"), config.SplitHandler.Split)
+			moneyFlowGroup.POST("/:id/share", middleware.RequireScope(domain.ScopeWrite), track("money_flow.share"), config.MoneyFlowShare.Create)
+			moneyFlowGroup.DELETE("/:id/share", middleware.RequireScope(domain.ScopeWrite), track("money_flow.unshare"), config.MoneyFlowShare.Revoke)
+		}
+
+		// Report routes
+		reportGroup := v1Group.Group("/reports")
+		reportGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			reportGroup.GET("/summary", middleware.RequireScope(domain.ScopeRead), track("report.summary"), config.ReportHandler.Summary)
+			reportGroup.GET("/trends", middleware.RequireScope(domain.ScopeRead), track("report.trends"), config.ReportHandler.Trends)
+			reportGroup.GET("/merchants", middleware.RequireScope(domain.ScopeRead), track("report.merchants"), config.ReportHandler.Merchants)
+			reportGroup.GET("/places", middleware.RequireScope(domain.ScopeRead), track("report.places"), config.ReportHandler.Places)
+			reportGroup.GET("/digest", middleware.RequireScope(domain.ScopeRead), config.DigestHandler.Preview)
+			reportGroup.GET("/statement", middleware.RequireScope(domain.ScopeRead), config.StatementHandler.Preview)
+		}
+
+		v1Group.GET("/dashboard",
+			middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions),
+			middleware.RequireScope(domain.ScopeRead),
+			config.DashboardHandler.Get,
+		)
+
+		v1Group.GET("/exchange-rates",
+			middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions),
+			middleware.RequireScope(domain.ScopeRead),
+			config.ReportHandler.ExchangeRates,
+		)
+
+		// Budget routes
+		budgetGroup := v1Group.Group("/budgets")
+		budgetGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			budgetGroup.GET("", middleware.RequireScope(domain.ScopeRead), config.BudgetHandler.List)
+			budgetGroup.POST("", middleware.RequireScope(domain.ScopeWrite), track("budget.create"), config.BudgetHandler.Create)
+			budgetGroup.GET("/overrides", middleware.RequireScope(domain.ScopeRead), config.BudgetHandler.ListOverrides)
+			budgetGroup.PUT("/:id", middleware.RequireScope(domain.ScopeWrite), config.BudgetHandler.Update)
+			budgetGroup.DELETE("/:id", middleware.RequireScope(domain.ScopeWrite), config.BudgetHandler.Delete)
+		}
+
+		// Wallet routes
+		walletGroup := v1Group.Group("/wallets")
+		walletGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			walletGroup.GET("", middleware.RequireScope(domain.ScopeRead), config.WalletHandler.List)
+			walletGroup.POST("", middleware.RequireScope(domain.ScopeWrite), track("wallet.create"), config.WalletHandler.Create)
+			walletGroup.POST("/transfers", middleware.RequireScope(domain.ScopeWrite), track("wallet.transfer"), config.WalletHandler.Transfer)
+			walletGroup.GET("/:id", middleware.RequireScope(domain.ScopeRead), config.WalletHandler.Get)
+			walletGroup.PUT("/:id", middleware.RequireScope(domain.ScopeWrite), config.WalletHandler.Update)
+			walletGroup.DELETE("/:id", middleware.RequireScope(domain.ScopeWrite), config.WalletHandler.Delete)
+		}
+
+		// Goal routes
+		goalGroup := v1Group.Group("/goals")
+		goalGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			goalGroup.GET("", middleware.RequireScope(domain.ScopeRead), config.GoalHandler.List)
+			goalGroup.POST("", middleware.RequireScope(domain.ScopeWrite), track("goal.create"), config.GoalHandler.Create)
+			goalGroup.GET("/:id", middleware.RequireScope(domain.ScopeRead), config.GoalHandler.Get)
+			goalGroup.PUT("/:id", middleware.RequireScope(domain.ScopeWrite), config.GoalHandler.Update)
+			goalGroup.DELETE("/:id", middleware.RequireScope(domain.ScopeWrite), config.GoalHandler.Delete)
+		}
+
+		// Group routes
+		groupGroup := v1Group.Group("/groups")
+		groupGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			groupGroup.GET("", middleware.RequireScope(domain.ScopeRead), config.GroupHandler.List)
+			groupGroup.POST("", middleware.RequireScope(domain.ScopeWrite), track("group.create"), config.GroupHandler.Create)
+			groupGroup.POST("/invitations/accept", middleware.RequireScope(domain.ScopeWrite), track("group.join"), config.GroupHandler.AcceptInvitation)
+			groupGroup.GET("/:id", middleware.RequireScope(domain.ScopeRead), config.GroupHandler.Get)
+			groupGroup.PUT("/:id", middleware.RequireScope(domain.ScopeWrite), config.GroupHandler.Update)
+			groupGroup.DELETE("/:id", middleware.RequireScope(domain.ScopeWrite), config.GroupHandler.Delete)
+			groupGroup.GET("/:id/invitations", middleware.RequireScope(domain.ScopeRead), config.GroupHandler.ListInvitations)
+			groupGroup.POST("/:id/invitations", middleware.RequireScope(domain.ScopeWrite), config.GroupHandler.CreateInvitation)
+			groupGroup.DELETE("/:id/invitations/:invitationId", middleware.RequireScope(domain.ScopeWrite), config.GroupHandler.RevokeInvitation)
+			groupGroup.PUT("/:id/members/:userId", middleware.RequireScope(domain.ScopeWrite), config.GroupHandler.UpdateMember)
+			groupGroup.DELETE("/:id/members/:userId", middleware.RequireScope(domain.ScopeWrite), config.GroupHandler.RemoveMember)
+			groupGroup.GET("/:id/balances", middleware.RequireScope(domain.ScopeRead), config.SplitHandler.Balances)
+			groupGroup.GET("/:id/settlements", middleware.RequireScope(domain.ScopeRead), config.SplitHandler.ListSettlements)
+			groupGroup.POST("/:id/settlements", middleware.RequireScope(domain.ScopeWrite), track("group.settle"), config.SplitHandler.CreateSettlement)
+		}
+
+		// Tag routes
+		tagGroup := v1Group.Group("/tags")
+		tagGroup.Use(middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions))
+		{
+			tagGroup.GET("", middleware.RequireScope(domain.ScopeRead), config.TagHandler.List)
+			tagGroup.POST("/rename", middleware.RequireScope(domain.ScopeWrite), track("tag.rename"), config.TagHandler.Rename)
+			tagGroup.POST("/merge", middleware.RequireScope(domain.ScopeWrite), track("tag.merge"), config.TagHandler.Merge)
 		}
 
-		// Future routes
-		// userGroup := v1Group.Group("/users")
-		// expenseGroup := v1Group.Group("/expenses")
-		// webhookGroup := v1Group.Group("/webhook")
+		v1Group.POST("/feedback",
+			middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions),
+			middleware.RequireScope(domain.ScopeWrite),
+			track("feedback.submit"),
+			config.FeedbackHandler.Submit,
+		)
+
+		// Admin routes (user session with a staff role, each route gated by a permission)
+		adminGroup := v1Group.Group("/admin")
+		adminGroup.Use(
+			middleware.Authentication(config.JWTManager, config.APIKeyAuth, config.TokenVersions),
+			middleware.RequireSession(),
+			middleware.RequireAudience(config.AdminAudiences...),
+			middleware.RequireAdmin(config.RoleResolver),
+		)
+		{
+			adminGroup.GET("/users", can(domain.PermissionUsersRead), config.AdminHandler.ListUsers)
+			adminGroup.GET("/users/:id", can(domain.PermissionUsersRead), config.AdminHandler.GetUser)
+			adminGroup.POST("/users/:id/disable", can(domain.PermissionUsersManage), config.AdminHandler.DisableUser)
+			adminGroup.POST("/users/:id/enable", can(domain.PermissionUsersManage), config.AdminHandler.EnableUser)
+			adminGroup.PUT("/users/:id/role", can(domain.PermissionRolesManage), config.AdminHandler.UpdateRole)
+
+			adminGroup.GET("/stats", can(domain.PermissionStatsRead), config.AdminHandler.Stats)
+
+			adminGroup.GET("/broadcasts", can(domain.PermissionBroadcastsManage), config.BroadcastHandler.List)
+			adminGroup.POST("/broadcasts", can(domain.PermissionBroadcastsManage), config.BroadcastHandler.Create)
+			adminGroup.GET("/broadcasts/:id", can(domain.PermissionBroadcastsManage), config.BroadcastHandler.Get)
+			adminGroup.GET("/broadcasts/:id/deliveries", can(domain.PermissionBroadcastsManage), config.BroadcastHandler.ListDeliveries)
+
+			adminGroup.GET("/feedback", can(domain.PermissionFeedbackRead), config.FeedbackHandler.List)
+
+			adminGroup.GET("/merchants", can(domain.PermissionMerchantsManage), config.MerchantHandler.List)
+			adminGroup.PUT("/merchants/:id", can(domain.PermissionMerchantsManage), config.MerchantHandler.Update)
+			adminGroup.GET("/merchant-rules", can(domain.PermissionMerchantsManage), config.MerchantHandler.ListRules)
+			adminGroup.POST("/merchant-rules", can(domain.PermissionMerchantsManage), config.MerchantHandler.CreateRule)
+			adminGroup.PUT("/merchant-rules/:id", can(domain.PermissionMerchantsManage), config.MerchantHandler.UpdateRule)
+			adminGroup.DELETE("/merchant-rules/:id", can(domain.PermissionMerchantsManage), config.MerchantHandler.DeleteRule)
+
+			adminGroup.POST("/users/import", can(domain.PermissionUsersManage), config.InvitationHandler.ImportUsers)
+			adminGroup.GET("/users/:id/auths", can(domain.PermissionUsersRead), config.UserAuthHandler.ListAuths)
+			adminGroup.GET("/users/:id/sessions", can(domain.PermissionUsersRead), config.UserAuthHandler.ListSessions)
+
+			adminGroup.GET("/api-usage/users", can(domain.PermissionStatsRead), config.APIUsageHandler.ListUsers)
+			adminGroup.GET("/api-usage/endpoints", can(domain.PermissionStatsRead), config.APIUsageHandler.ListEndpoints)
+
+			adminGroup.GET("/log-level", can(domain.PermissionSystemManage), config.LogLevelHandler.Get)
+			adminGroup.PUT("/log-level", can(domain.PermissionSystemManage), config.LogLevelHandler.Update)
+			adminGroup.DELETE("/log-level", can(domain.PermissionSystemManage), config.LogLevelHandler.Reset)
+
+			adminGroup.GET("/config", can(domain.PermissionSystemManage), config.ConfigHandler.Get)
+
+			adminGroup.GET("/read-only", can(domain.PermissionSystemManage), config.ReadOnlyHandler.Get)
+			adminGroup.PUT("/read-only", can(domain.PermissionSystemManage), config.ReadOnlyHandler.Update)
+
+			adminGroup.POST("/analytics-exports/money-flows", can(domain.PermissionSystemManage), config.ExportHandler.ExportMoneyFlows)
+		}
+
+		// Webhook routes (authenticated by the provider's verify token and signature)
+		webhookGroup := v1Group.Group("/webhook")
+		{
+			webhookGroup.GET("/whatsapp", config.WhatsAppHandler.Verify)
+			webhookGroup.POST("/whatsapp", config.WhatsAppHandler.Receive)
+		}
 	}
 
+	// API documentation, built from the route descriptions and the DTOs
+	setupDocs(router, config)
+
 	return router
 }
+
+// setupDocs serves the OpenAPI document of the API and Swagger UI for it. A document
+// that cannot be built is logged rather than failing startup.
+func setupDocs(router *gin.Engine, config *RouterConfig) {
+	routes := apiRoutes(config)
+	doc, err := openapi.Build(apiInfo, routes)
+	if err != nil {
+		config.Logger.Error("failed to build the API documentation", "error", err)
+		return
+	}
+	spec, err := openapi.SpecHandler(doc)
+	if err != nil {
+		config.Logger.Error("failed to encode the API documentation", "error", err)
+		return
+	}
+
+	router.GET(docsPath, openapi.UIHandler(apiInfo.Title, docsSpecPath))
+	router.GET(docsSpecPath, spec)
+
+	if missing := openapi.Undocumented(router.Routes(), routes); len(missing) > 0 {
+		config.Logger.Warn("routes missing from the API documentation", "routes", missing)
+	}
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	v1 "github.com/ingunawandra/catetin/internal/controller/http/v1"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/memory"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+// stubAPIKeys authenticates every API key as key
+type stubAPIKeys struct {
+	key *domain.APIKey
+}
+
+func (s stubAPIKeys) AuthenticateAPIKey(ctx context.Context, plaintext string) (*domain.APIKey, error) {
+	return s.key, nil
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := memory.NewStore()
+	userRepo := memory.NewUserRepository(store)
+	user := domain.NewUser("Budi", "")
+	if err := userRepo.Create(context.Background(), user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	exports := service.NewMoneyFlowExportService(memory.NewMoneyFlowRepository(store), userRepo, service.CSVExporter{})
+
+	tests := []struct {
+		name   string
+		scopes []string
+		path   string
+		want   int
+	}{
+		{"export key exports", []string{domain.ScopeExport}, "/api/v1/money-flows/export", http.StatusOK},
+		{"export key cannot list", []string{domain.ScopeExport}, "/api/v1/money-flows", http.StatusForbidden},
+		{"read key exports", []string{domain.ScopeRead}, "/api/v1/money-flows/export", http.StatusOK},
+		{"write key exports", []string{domain.ScopeWrite}, "/api/v1/money-flows/export", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := SetupRouter(&RouterConfig{
+				Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+				APIKeyAuth:      stubAPIKeys{key: &domain.APIKey{ID: uuid.New(), UserID: user.ID, Scopes: tt.scopes}},
+				MoneyFlowExport: v1.NewMoneyFlowExportHandler(exports),
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-API-Key", "ctn_0123456789abcdef")
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("GET %s with scopes %v status = %d, want %d: %s", tt.path, tt.scopes, w.Code, tt.want, w.Body)
+			}
+			if tt.want == http.StatusForbidden && !strings.Contains(w.Body.String(), "INSUFFICIENT_SCOPE") {
+				t.Errorf("GET %s body = %s, want INSUFFICIENT_SCOPE", tt.path, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// AccountErasureHandler handles account erasure HTTP requests
+type AccountErasureHandler struct {
+	erasureService *service.AccountErasureService
+}
+
+// NewAccountErasureHandler creates a new account erasure handler
+func NewAccountErasureHandler(erasureService *service.AccountErasureService) *AccountErasureHandler {
+	return &AccountErasureHandler{
+		erasureService: erasureService,
+	}
+}
+
+// Request schedules the erasure of the current user's personal data after the grace
+// period
+// POST /api/v1/users/me/erasure
+func (h *AccountErasureHandler) Request(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	// Call service
+	erasure, err := h.erasureService.RequestErasure(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusAccepted, "Erasure scheduled", toAccountErasureResponse(erasure))
+}
+
+// Get returns the pending erasure of the current user
+// GET /api/v1/users/me/erasure
+func (h *AccountErasureHandler) Get(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	// Call service
+	erasure, err := h.erasureService.GetErasure(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Erasure retrieved successfully", toAccountErasureResponse(erasure))
+}
+
+// Cancel cancels the pending erasure of the current user
+// DELETE /api/v1/users/me/erasure
+func (h *AccountErasureHandler) Cancel(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	// Call service
+	if err := h.erasureService.CancelErasure(c.Request.Context(), userID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Erasure cancelled", nil)
+}
+
+func toAccountErasureResponse(erasure *domain.AccountErasure) *dto.AccountErasureResponse {
+	return &dto.AccountErasureResponse{
+		ID:          erasure.ID.String(),
+		Status:      erasure.Status,
+		EraseAt:     erasure.EraseAt,
+		CancelledAt: erasure.CancelledAt,
+		CompletedAt: erasure.CompletedAt,
+		CreatedAt:   erasure.CreatedAt,
+	}
+}
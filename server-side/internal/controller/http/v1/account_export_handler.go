@@ -0,0 +1,85 @@
+package v1
+
+import (
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// AccountExportHandler handles account export HTTP requests
+type AccountExportHandler struct {
+	exportService *service.AccountExportService
+}
+
+// NewAccountExportHandler creates a new account export handler
+func NewAccountExportHandler(exportService *service.AccountExportService) *AccountExportHandler {
+	return &AccountExportHandler{
+		exportService: exportService,
+	}
+}
+
+// Request queues the export of all of the current user's data; the download link is
+// sent to them once it is ready
+// POST /api/v1/users/me/export
+func (h *AccountExportHandler) Request(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	// Call service
+	exportID, err := h.exportService.RequestExport(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusAccepted, "Export queued; the download link will be sent to you",
+		dto.AccountExportResponse{ID: exportID.String()})
+}
+
+// Download serves an account export to the holder of its signed link, without
+// authentication
+// GET /api/v1/account-exports/:user_id/:id
+func (h *AccountExportHandler) Download(c *gin.Context) {
+	userID, errUser := uuid.Parse(c.Param("user_id"))
+	exportID, errExport := uuid.Parse(c.Param("id"))
+	if errUser != nil || errExport != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrInvalidDownloadLink)
+		return
+	}
+
+	var query dto.AccountExportDownloadQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrInvalidDownloadLink)
+		return
+	}
+
+	// Call service
+	file, err := h.exportService.Open(c.Request.Context(), userID, exportID, query.Expires, query.Signature)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+	defer file.Close()
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": service.AccountExportFilename(exportID),
+	}))
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, file); err != nil {
+		// The status is sent, so the client only sees a truncated download
+		logger.FromContext(c.Request.Context()).Warn("account export download failed", "export_id", exportID, "error", err)
+	}
+}
@@ -0,0 +1,217 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const (
+	defaultAdminUserPageSize = 20
+	defaultSystemStatsDays   = 30
+)
+
+// AdminHandler handles admin HTTP requests managing users and viewing system statistics
+type AdminHandler struct {
+	adminService *service.AdminService
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminService *service.AdminService) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// ListUsers lists and searches users, newest first
+// GET /api/v1/admin/users
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var query dto.ListUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultAdminUserPageSize
+	}
+
+	filter := repository.UserFilter{Query: query.Query, Role: query.Role}
+	if query.Status != "" {
+		disabled := query.Status == "disabled"
+		filter.Disabled = &disabled
+	}
+
+	users, total, err := h.adminService.ListUsers(c.Request.Context(), filter, repository.Page{Limit: query.Limit, Offset: query.Offset})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.AdminUserResponse, len(users))
+	for i, user := range users {
+		items[i] = toAdminUserResponse(user)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Users retrieved successfully", &dto.AdminUserListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// GetUser returns a user
+// GET /api/v1/admin/users/:id
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrUserNotFound)
+		return
+	}
+
+	user, err := h.adminService.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "User retrieved successfully", toAdminUserResponse(user))
+}
+
+// DisableUser disables a user's account
+// POST /api/v1/admin/users/:id/disable
+func (h *AdminHandler) DisableUser(c *gin.Context) {
+	adminID, userID, ok := adminUserParams(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.DisableUser(c.Request.Context(), adminID, userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "User disabled successfully", toAdminUserResponse(user))
+}
+
+// EnableUser enables a disabled account again
+// POST /api/v1/admin/users/:id/enable
+func (h *AdminHandler) EnableUser(c *gin.Context) {
+	adminID, userID, ok := adminUserParams(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminService.EnableUser(c.Request.Context(), adminID, userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "User enabled successfully", toAdminUserResponse(user))
+}
+
+// UpdateRole changes the role of a user
+// PUT /api/v1/admin/users/:id/role
+func (h *AdminHandler) UpdateRole(c *gin.Context) {
+	adminID, userID, ok := adminUserParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateUserRoleRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	user, err := h.adminService.SetRole(c.Request.Context(), adminID, userID, req.Role)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "User role updated successfully", toAdminUserResponse(user))
+}
+
+// Stats returns counts of users and their data over the last days
+// GET /api/v1/admin/stats
+func (h *AdminHandler) Stats(c *gin.Context) {
+	var query dto.APIUsageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Days == 0 {
+		query.Days = defaultSystemStatsDays
+	}
+
+	stats, err := h.adminService.Stats(c.Request.Context(), query.Days)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "System stats retrieved successfully", &dto.SystemStatsResponse{
+		Since:         stats.Since,
+		Days:          query.Days,
+		Users:         stats.Users,
+		NewUsers:      stats.NewUsers,
+		ActiveUsers:   stats.ActiveUsers,
+		DisabledUsers: stats.DisabledUsers,
+		StaffUsers:    stats.StaffUsers,
+		MoneyFlows:    stats.MoneyFlows,
+		NewMoneyFlows: stats.NewMoneyFlows,
+		Wallets:       stats.Wallets,
+		Groups:        stats.Groups,
+	})
+}
+
+// adminUserParams returns the current admin and the user of the :id path parameter.
+// It aborts the request and returns false when either is missing.
+func adminUserParams(c *gin.Context) (adminID, userID uuid.UUID, ok bool) {
+	adminID, ok = middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrUserNotFound)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return adminID, userID, true
+}
+
+func toAdminUserResponse(user *domain.User) *dto.AdminUserResponse {
+	permissions := domain.RolePermissions(user.Role)
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return &dto.AdminUserResponse{
+		ID:          user.ID.String(),
+		FullName:    user.FullName,
+		PhoneNumber: user.PhoneNumber,
+		Role:        user.Role,
+		Permissions: permissions,
+		Demo:        user.IsDemo(),
+		DisabledAt:  user.DisabledAt,
+		Version:     user.Version,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+	}
+}
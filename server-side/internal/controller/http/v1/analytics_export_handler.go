@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+// AnalyticsExportHandler handles admin analytics export HTTP requests
+type AnalyticsExportHandler struct {
+	exportService *service.AnalyticsExportService
+}
+
+// NewAnalyticsExportHandler creates a new analytics export handler
+func NewAnalyticsExportHandler(exportService *service.AnalyticsExportService) *AnalyticsExportHandler {
+	return &AnalyticsExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportMoneyFlows queues the Parquet export of a month of money flows
+// POST /api/v1/admin/analytics-exports/money-flows
+func (h *AnalyticsExportHandler) ExportMoneyFlows(c *gin.Context) {
+	var req dto.CreateAnalyticsExportRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	month, _ := time.Parse(service.ExportMonthLayout, req.Month) // validated by the datetime binding
+
+	// Call service
+	job, err := h.exportService.ScheduleMoneyFlowExport(c.Request.Context(), month)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusAccepted, "Export queued successfully", dto.AnalyticsExportJobResponse{
+		JobID: job.ID.String(),
+		Month: req.Month,
+		RunAt: job.RunAt,
+	})
+}
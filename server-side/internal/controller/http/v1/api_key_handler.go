@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// APIKeyHandler handles API key management HTTP requests
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Create issues a new API key for the current user
+// POST /api/v1/users/me/api-keys
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	result, err := h.apiKeyService.CreateKey(c.Request.Context(), userID, req.Name, req.Scopes)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(result.APIKey),
+		Key:            result.Plaintext,
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "API key created successfully", response)
+}
+
+// List lists API keys issued by the current user
+// GET /api/v1/users/me/api-keys
+func (h *APIKeyHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	apiKeys, err := h.apiKeyService.ListKeys(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.APIKeyResponse, len(apiKeys))
+	for i, apiKey := range apiKeys {
+		response[i] = toAPIKeyResponse(apiKey)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "API keys retrieved successfully", response)
+}
+
+// Revoke revokes an API key owned by the current user
+// DELETE /api/v1/users/me/api-keys/:id
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.apiKeyService.RevokeKey(c.Request.Context(), userID, keyID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "API key revoked successfully", nil)
+}
+
+func toAPIKeyResponse(apiKey *domain.APIKey) *dto.APIKeyResponse {
+	return &dto.APIKeyResponse{
+		ID:         apiKey.ID.String(),
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.Scopes,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+		Revoked:    apiKey.IsRevoked(),
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
@@ -0,0 +1,150 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const (
+	defaultAPIUsageDays     = 30
+	defaultAPIUsagePageSize = 20
+)
+
+// APIUsageHandler handles API usage statistics HTTP requests
+type APIUsageHandler struct {
+	usageService *service.APIUsageService
+}
+
+// NewAPIUsageHandler creates a new API usage handler
+func NewAPIUsageHandler(usageService *service.APIUsageService) *APIUsageHandler {
+	return &APIUsageHandler{
+		usageService: usageService,
+	}
+}
+
+// GetMine returns the current user's request counts per endpoint and per day
+// GET /api/v1/users/me/api-usage
+func (h *APIUsageHandler) GetMine(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.APIUsageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Days == 0 {
+		query.Days = defaultAPIUsageDays
+	}
+
+	report, err := h.usageService.GetUserUsage(c.Request.Context(), userID, query.Days)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	daily := make([]*dto.DailyUsageResponse, len(report.Daily))
+	for i, day := range report.Daily {
+		daily[i] = &dto.DailyUsageResponse{
+			Day:      day.Day.Format(time.DateOnly),
+			Requests: day.RequestCount,
+			Errors:   day.ErrorCount,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "API usage retrieved successfully", &dto.APIUsageResponse{
+		Since:     report.Since,
+		Days:      query.Days,
+		Requests:  report.RequestCount,
+		Errors:    report.ErrorCount,
+		Endpoints: toEndpointUsageResponses(report.Endpoints),
+		Daily:     daily,
+	})
+}
+
+// ListUsers lists users by request count, busiest first
+// GET /api/v1/admin/api-usage/users
+func (h *APIUsageHandler) ListUsers(c *gin.Context) {
+	var query dto.ListUserAPIUsageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Days == 0 {
+		query.Days = defaultAPIUsageDays
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultAPIUsagePageSize
+	}
+
+	summaries, err := h.usageService.ListUserUsage(c.Request.Context(), query.Days, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.UserUsageResponse, len(summaries))
+	for i, summary := range summaries {
+		items[i] = &dto.UserUsageResponse{
+			UserID:   summary.UserID.String(),
+			Requests: summary.RequestCount,
+			Errors:   summary.ErrorCount,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "API usage retrieved successfully", &dto.UserUsageListResponse{
+		Since:  service.UsagePeriodStart(query.Days),
+		Days:   query.Days,
+		Items:  items,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// ListEndpoints lists request counts per endpoint across all users, busiest first
+// GET /api/v1/admin/api-usage/endpoints
+func (h *APIUsageHandler) ListEndpoints(c *gin.Context) {
+	var query dto.APIUsageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Days == 0 {
+		query.Days = defaultAPIUsageDays
+	}
+
+	summaries, err := h.usageService.ListRouteUsage(c.Request.Context(), query.Days)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "API usage retrieved successfully", &dto.EndpointUsageListResponse{
+		Since: service.UsagePeriodStart(query.Days),
+		Days:  query.Days,
+		Items: toEndpointUsageResponses(summaries),
+	})
+}
+
+func toEndpointUsageResponses(summaries []*domain.RouteAPIUsage) []*dto.EndpointUsageResponse {
+	items := make([]*dto.EndpointUsageResponse, len(summaries))
+	for i, summary := range summaries {
+		items[i] = &dto.EndpointUsageResponse{
+			Method:   summary.Method,
+			Route:    summary.Route,
+			Requests: summary.RequestCount,
+			Errors:   summary.ErrorCount,
+		}
+	}
+	return items
+}
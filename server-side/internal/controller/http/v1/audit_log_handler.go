@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultAuditLogPageSize = 20
+
+// AuditLogHandler handles audit log HTTP requests
+type AuditLogHandler struct {
+	auditLogService *service.AuditLogService
+}
+
+// NewAuditLogHandler creates a new audit log handler
+func NewAuditLogHandler(auditLogService *service.AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditLogService: auditLogService,
+	}
+}
+
+// List lists the changes the current user made to their data, newest first
+// GET /api/v1/users/me/audit-logs
+func (h *AuditLogHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.ListAuditLogsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultAuditLogPageSize
+	}
+
+	filter := domain.AuditLogFilter{EntityType: query.EntityType}
+	if query.EntityID != "" {
+		entityID := uuid.MustParse(query.EntityID) // validated by the uuid binding
+		filter.EntityID = &entityID
+	}
+
+	logs, err := h.auditLogService.List(c.Request.Context(), userID, filter, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.AuditLogResponse, len(logs))
+	for i, log := range logs {
+		items[i] = toAuditLogResponse(log)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Audit logs retrieved successfully", &dto.AuditLogListResponse{
+		Items:  items,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+func toAuditLogResponse(log *domain.AuditLog) *dto.AuditLogResponse {
+	return &dto.AuditLogResponse{
+		ID:         log.ID.String(),
+		Action:     log.Action,
+		EntityType: log.EntityType,
+		EntityID:   log.EntityID.String(),
+		Before:     snapshotOrNull(log.Before),
+		After:      snapshotOrNull(log.After),
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+// snapshotOrNull returns a missing snapshot as JSON null
+func snapshotOrNull(snapshot json.RawMessage) json.RawMessage {
+	if len(snapshot) == 0 {
+		return json.RawMessage("null")
+	}
+	return snapshot
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/ingunawandra/catetin/internal/controller/dto"
 	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
 	"github.com/ingunawandra/catetin/internal/service"
-	appErrors "github.com/ingunawandra/catetin/pkg/errors"
 )
 
 // AuthHandler handles authentication HTTP requests
@@ -29,14 +28,12 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	// Bind and validate request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
-			"validation_errors": err.Error(),
-		}))
+		middleware.AbortWithValidationError(c, err)
 		return
 	}
 
 	// Call service
-	result, err := h.authService.Register(c.Request.Context(), req.FullName, req.Email, req.Password)
+	result, err := h.authService.Register(c.Request.Context(), req.FullName, req.Email, req.Password, req.Client)
 	if err != nil {
 		middleware.AbortWithError(c, err)
 		return
@@ -57,7 +54,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		},
 	}
 
-	c.JSON(http.StatusCreated, dto.NewSuccessResponse("User registered successfully", response))
+	middleware.RespondWithSuccess(c, http.StatusCreated, "User registered successfully", response)
 }
 
 // Login handles user login
@@ -67,14 +64,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Bind and validate request
 	if err := c.ShouldBindJSON(&req); err != nil {
-		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
-			"validation_errors": err.Error(),
-		}))
+		middleware.AbortWithValidationError(c, err)
 		return
 	}
 
 	// Call service
-	result, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
+	result, err := h.authService.Login(c.Request.Context(), req.Email, req.Password, req.Client)
 	if err != nil {
 		middleware.AbortWithError(c, err)
 		return
@@ -95,5 +90,41 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		},
 	}
 
-	c.JSON(http.StatusOK, dto.NewSuccessResponse("Login successful", response))
+	middleware.RespondWithSuccess(c, http.StatusOK, "Login successful", response)
+}
+
+// Refresh exchanges a refresh token for a new token pair
+// POST /api/v1/authentications/refresh
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	result, err := h.authService.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	// Build response
+	response := &dto.AuthResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    result.ExpiresIn,
+		User: &dto.UserInfo{
+			ID:          result.User.ID.String(),
+			FullName:    result.User.FullName,
+			Email:       result.Email,
+			PhoneNumber: &result.User.PhoneNumber,
+			Image:       result.User.Image,
+		},
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Token refreshed successfully", response)
 }
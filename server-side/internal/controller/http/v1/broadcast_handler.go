@@ -0,0 +1,180 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultBroadcastPageSize = 20
+
+// BroadcastHandler handles admin broadcast HTTP requests
+type BroadcastHandler struct {
+	broadcastService *service.BroadcastService
+}
+
+// NewBroadcastHandler creates a new broadcast handler
+func NewBroadcastHandler(broadcastService *service.BroadcastService) *BroadcastHandler {
+	return &BroadcastHandler{
+		broadcastService: broadcastService,
+	}
+}
+
+// Create queues an announcement for all or a filtered subset of users
+// POST /api/v1/admin/broadcasts
+func (h *BroadcastHandler) Create(c *gin.Context) {
+	adminID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateBroadcastRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	userIDs := make([]uuid.UUID, len(req.Audience.UserIDs))
+	for i, id := range req.Audience.UserIDs {
+		userIDs[i] = uuid.MustParse(id) // validated by the uuid binding
+	}
+
+	// Call service
+	summary, err := h.broadcastService.CreateBroadcast(c.Request.Context(), adminID, service.BroadcastInput{
+		Title:    req.Title,
+		Body:     req.Body,
+		Channels: req.Channels,
+		Audience: domain.BroadcastAudience{
+			UserIDs:          userIDs,
+			Roles:            req.Audience.Roles,
+			RegisteredAfter:  req.Audience.RegisteredAfter,
+			RegisteredBefore: req.Audience.RegisteredBefore,
+		},
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusAccepted, "Broadcast queued successfully", toBroadcastResponse(summary.Broadcast, summary.DeliveryCounts))
+}
+
+// List lists broadcasts, newest first
+// GET /api/v1/admin/broadcasts
+func (h *BroadcastHandler) List(c *gin.Context) {
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultBroadcastPageSize
+	}
+
+	broadcasts, err := h.broadcastService.ListBroadcasts(c.Request.Context(), query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.BroadcastResponse, len(broadcasts))
+	for i, broadcast := range broadcasts {
+		response[i] = toBroadcastResponse(broadcast, nil)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Broadcasts retrieved successfully", response)
+}
+
+// Get returns a broadcast with its delivery counts by status
+// GET /api/v1/admin/broadcasts/:id
+func (h *BroadcastHandler) Get(c *gin.Context) {
+	broadcastID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	summary, err := h.broadcastService.GetBroadcast(c.Request.Context(), broadcastID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Broadcast retrieved successfully", toBroadcastResponse(summary.Broadcast, summary.DeliveryCounts))
+}
+
+// ListDeliveries lists the per-recipient delivery status of a broadcast
+// GET /api/v1/admin/broadcasts/:id/deliveries
+func (h *BroadcastHandler) ListDeliveries(c *gin.Context) {
+	broadcastID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var query dto.ListBroadcastDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultBroadcastPageSize
+	}
+
+	deliveries, err := h.broadcastService.ListDeliveries(c.Request.Context(), broadcastID, query.Status, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.BroadcastDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i] = &dto.BroadcastDeliveryResponse{
+			ID:        delivery.ID.String(),
+			UserID:    delivery.UserID.String(),
+			Channel:   delivery.Channel,
+			Status:    delivery.Status,
+			Attempts:  delivery.Attempts,
+			LastError: delivery.LastError,
+			SentAt:    delivery.SentAt,
+			UpdatedAt: delivery.UpdatedAt,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Broadcast deliveries retrieved successfully", response)
+}
+
+func toBroadcastResponse(broadcast *domain.Broadcast, deliveryCounts map[string]int) *dto.BroadcastResponse {
+	userIDs := make([]string, len(broadcast.Audience.UserIDs))
+	for i, id := range broadcast.Audience.UserIDs {
+		userIDs[i] = id.String()
+	}
+
+	return &dto.BroadcastResponse{
+		ID:       broadcast.ID.String(),
+		Title:    broadcast.Title,
+		Body:     broadcast.Body,
+		Channels: broadcast.Channels,
+		Audience: dto.BroadcastAudienceRequest{
+			UserIDs:          userIDs,
+			Roles:            broadcast.Audience.Roles,
+			RegisteredAfter:  broadcast.Audience.RegisteredAfter,
+			RegisteredBefore: broadcast.Audience.RegisteredBefore,
+		},
+		Status:         broadcast.Status,
+		RecipientCount: broadcast.RecipientCount,
+		DeliveryCounts: deliveryCounts,
+		CreatedBy:      broadcast.CreatedBy.String(),
+		CompletedAt:    broadcast.CompletedAt,
+		CreatedAt:      broadcast.CreatedAt,
+	}
+}
@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultBudgetOverridePageSize = 20
+
+// BudgetHandler handles category budget HTTP requests
+type BudgetHandler struct {
+	budgetService *service.BudgetService
+}
+
+// NewBudgetHandler creates a new budget handler
+func NewBudgetHandler(budgetService *service.BudgetService) *BudgetHandler {
+	return &BudgetHandler{
+		budgetService: budgetService,
+	}
+}
+
+// Create creates a monthly budget for a category
+// POST /api/v1/budgets
+func (h *BudgetHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateBudgetRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	status, err := h.budgetService.Create(c.Request.Context(), userID, req.Category, service.BudgetInput{
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		Hard:     req.Hard,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Budget created successfully", toBudgetResponse(status))
+}
+
+// List lists the current user's budgets with their spending this month
+// GET /api/v1/budgets
+func (h *BudgetHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	statuses, err := h.budgetService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.BudgetResponse, len(statuses))
+	for i, status := range statuses {
+		response[i] = toBudgetResponse(status)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Budgets retrieved successfully", response)
+}
+
+// Update replaces the cap, currency, and hardness of a budget owned by the current user
+// PUT /api/v1/budgets/:id
+func (h *BudgetHandler) Update(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.UpdateBudgetRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	status, err := h.budgetService.Update(c.Request.Context(), userID, id, *req.Version, service.BudgetInput{
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		Hard:     req.Hard,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Budget updated successfully", toBudgetResponse(status))
+}
+
+// Delete deletes a budget owned by the current user
+// DELETE /api/v1/budgets/:id
+func (h *BudgetHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.budgetService.Delete(c.Request.Context(), userID, id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Budget deleted successfully", nil)
+}
+
+// ListOverrides lists the money flows the current user recorded over a hard budget
+// GET /api/v1/budgets/overrides
+func (h *BudgetHandler) ListOverrides(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.ListBudgetOverridesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultBudgetOverridePageSize
+	}
+
+	overrides, err := h.budgetService.ListOverrides(c.Request.Context(), userID, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.BudgetOverrideResponse, len(overrides))
+	for i, override := range overrides {
+		items[i] = &dto.BudgetOverrideResponse{
+			ID:          override.ID.String(),
+			BudgetID:    override.BudgetID.String(),
+			MoneyFlowID: override.MoneyFlowID.String(),
+			Amount:      domain.MajorUnits(override.Amount, override.Currency),
+			Spent:       domain.MajorUnits(override.Spent, override.Currency),
+			Cap:         domain.MajorUnits(override.Cap, override.Currency),
+			Currency:    override.Currency,
+			CreatedAt:   override.CreatedAt,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Budget overrides retrieved successfully", &dto.BudgetOverrideListResponse{
+		Items:  items,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+func toBudgetResponse(status *service.BudgetStatus) *dto.BudgetResponse {
+	budget := status.Budget
+	return &dto.BudgetResponse{
+		ID:          budget.ID.String(),
+		Category:    budget.Category,
+		Amount:      domain.MajorUnits(budget.Amount, budget.Currency),
+		Currency:    budget.Currency,
+		Hard:        budget.Hard,
+		Spent:       domain.MajorUnits(status.Spent, budget.Currency),
+		Remaining:   domain.MajorUnits(status.Remaining, budget.Currency),
+		PeriodStart: status.PeriodStart,
+		PeriodEnd:   status.PeriodEnd,
+		Version:     budget.Version,
+		CreatedAt:   budget.CreatedAt,
+		UpdatedAt:   budget.UpdatedAt,
+	}
+}
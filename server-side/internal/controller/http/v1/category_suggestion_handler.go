@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// CategorySuggestionHandler handles category suggestion HTTP requests
+type CategorySuggestionHandler struct {
+	suggestionService *service.CategorySuggestionService
+}
+
+// NewCategorySuggestionHandler creates a new category suggestion handler
+func NewCategorySuggestionHandler(suggestionService *service.CategorySuggestionService) *CategorySuggestionHandler {
+	return &CategorySuggestionHandler{
+		suggestionService: suggestionService,
+	}
+}
+
+// Suggest returns the categories a money flow with the given description likely belongs to
+// GET /api/v1/money-flows/suggest-category
+func (h *CategorySuggestionHandler) Suggest(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.SuggestCategoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	suggestions, err := h.suggestionService.Suggest(c.Request.Context(), userID, query.Text)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.CategorySuggestionsResponse{
+		Suggestions: make([]*dto.CategorySuggestionResponse, len(suggestions)),
+	}
+	for i, suggestion := range suggestions {
+		response.Suggestions[i] = &dto.CategorySuggestionResponse{
+			Category:   suggestion.Category,
+			Confidence: suggestion.Confidence,
+			Source:     suggestion.Source,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Category suggestions retrieved successfully", response)
+}
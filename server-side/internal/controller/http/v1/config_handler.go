@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/config"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+)
+
+// ConfigHandler handles effective configuration HTTP requests
+type ConfigHandler struct {
+	configs *config.Reloader
+}
+
+// NewConfigHandler creates a new configuration handler
+func NewConfigHandler(configs *config.Reloader) *ConfigHandler {
+	return &ConfigHandler{
+		configs: configs,
+	}
+}
+
+// Get returns the configuration this instance runs with, secrets redacted
+// GET /api/v1/admin/config
+func (h *ConfigHandler) Get(c *gin.Context) {
+	settings := h.configs.Current().Settings()
+	response := &dto.ConfigResponse{Settings: make([]*dto.ConfigSettingResponse, 0, len(settings))}
+	for _, setting := range settings {
+		response.Settings = append(response.Settings, &dto.ConfigSettingResponse{
+			Name:       setting.Name,
+			Value:      setting.Value,
+			Secret:     setting.Secret,
+			Reloadable: setting.Reloadable,
+		})
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Configuration retrieved successfully", response)
+}
@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// CurrencyHandler handles currency catalog HTTP requests
+type CurrencyHandler struct{}
+
+// NewCurrencyHandler creates a new currency handler
+func NewCurrencyHandler() *CurrencyHandler {
+	return &CurrencyHandler{}
+}
+
+// List returns every currency money can be recorded in, with its symbol and the
+// decimal places of its amounts
+// GET /api/v1/meta/currencies
+func (h *CurrencyHandler) List(c *gin.Context) {
+	currencies := domain.Currencies()
+	response := &dto.CurrencyCatalogResponse{Currencies: make([]*dto.CurrencyResponse, len(currencies))}
+	for i, currency := range currencies {
+		response.Currencies[i] = &dto.CurrencyResponse{
+			Code:     currency.Code,
+			Name:     currency.Name,
+			Symbol:   currency.Symbol,
+			Exponent: currency.Exponent,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Currencies retrieved successfully", response)
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// DashboardHandler handles dashboard HTTP requests
+type DashboardHandler struct {
+	dashboardService *service.DashboardService
+}
+
+// NewDashboardHandler creates a new dashboard handler
+func NewDashboardHandler(dashboardService *service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{
+		dashboardService: dashboardService,
+	}
+}
+
+// Get returns the current user's spending this month, top categories, budgets, latest
+// money flows, and active goals in one response
+// GET /api/v1/dashboard
+func (h *DashboardHandler) Get(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	// Call service
+	dashboard, err := h.dashboardService.Get(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.DashboardResponse{
+		Currency:         dashboard.Currency,
+		PeriodStart:      dashboard.PeriodStart,
+		PeriodEnd:        dashboard.PeriodEnd,
+		Total:            domain.MajorUnits(dashboard.Total, dashboard.Currency),
+		Count:            dashboard.Count,
+		TopCategories:    make([]*dto.CategoryReportResponse, len(dashboard.TopCategories)),
+		Budgets:          make([]*dto.BudgetResponse, len(dashboard.Budgets)),
+		RecentMoneyFlows: make([]*dto.MoneyFlowResponse, len(dashboard.RecentMoneyFlows)),
+		ActiveGoals:      make([]*dto.GoalResponse, len(dashboard.ActiveGoals)),
+		Unconverted:      dashboard.Unconverted,
+	}
+	for i, category := range dashboard.TopCategories {
+		response.TopCategories[i] = &dto.CategoryReportResponse{
+			Category: category.Category,
+			Total:    domain.MajorUnits(category.Total, dashboard.Currency),
+			Count:    category.Count,
+		}
+	}
+	for i, status := range dashboard.Budgets {
+		response.Budgets[i] = toBudgetResponse(status)
+	}
+	for i, moneyFlow := range dashboard.RecentMoneyFlows {
+		response.RecentMoneyFlows[i] = toMoneyFlowResponse(moneyFlow)
+	}
+	for i, goal := range dashboard.ActiveGoals {
+		response.ActiveGoals[i] = toGoalResponse(goal)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Dashboard retrieved successfully", response)
+}
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+// DemoHandler handles demo mode HTTP requests
+type DemoHandler struct {
+	demoService *service.DemoService
+}
+
+// NewDemoHandler creates a new demo handler
+func NewDemoHandler(demoService *service.DemoService) *DemoHandler {
+	return &DemoHandler{
+		demoService: demoService,
+	}
+}
+
+// Create creates an ephemeral sandbox user with sample data and a short-lived token
+// POST /api/v1/authentications/demo
+func (h *DemoHandler) Create(c *gin.Context) {
+	session, err := h.demoService.CreateSession(c.Request.Context())
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.DemoAuthResponse{
+		AuthResponse: dto.AuthResponse{
+			AccessToken: session.AccessToken,
+			TokenType:   "Bearer",
+			ExpiresIn:   session.ExpiresIn,
+			User: &dto.UserInfo{
+				ID:       session.User.ID.String(),
+				FullName: session.User.FullName,
+			},
+		},
+		ExpiresAt: session.ExpiresAt,
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Demo session created successfully", response)
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// DeviceHandler handles push notification device HTTP requests
+type DeviceHandler struct {
+	deviceService *service.DeviceService
+}
+
+// NewDeviceHandler creates a new device handler
+func NewDeviceHandler(deviceService *service.DeviceService) *DeviceHandler {
+	return &DeviceHandler{
+		deviceService: deviceService,
+	}
+}
+
+// Register registers a device of the current user for push notifications
+// POST /api/v1/users/me/devices
+func (h *DeviceHandler) Register(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.RegisterDeviceRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	device, err := h.deviceService.Register(c.Request.Context(), userID, req.Token, req.Platform, req.Name)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Device registered successfully", toDeviceResponse(device))
+}
+
+// List lists the devices of the current user
+// GET /api/v1/users/me/devices
+func (h *DeviceHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	devices, err := h.deviceService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.DeviceResponse, len(devices))
+	for i, device := range devices {
+		response[i] = toDeviceResponse(device)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Devices retrieved successfully", response)
+}
+
+// Unregister stops push notifications to a device of the current user
+// DELETE /api/v1/users/me/devices/:id
+func (h *DeviceHandler) Unregister(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.deviceService.Unregister(c.Request.Context(), userID, id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Device unregistered successfully", nil)
+}
+
+func toDeviceResponse(device *domain.Device) *dto.DeviceResponse {
+	return &dto.DeviceResponse{
+		ID:         device.ID.String(),
+		Platform:   device.Platform,
+		Name:       device.Name,
+		LastSeenAt: device.LastSeenAt,
+		CreatedAt:  device.CreatedAt,
+	}
+}
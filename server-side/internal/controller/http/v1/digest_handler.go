@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// DigestHandler handles spending digest HTTP requests
+type DigestHandler struct {
+	digestService *service.DigestService
+}
+
+// NewDigestHandler creates a new digest handler
+func NewDigestHandler(digestService *service.DigestService) *DigestHandler {
+	return &DigestHandler{
+		digestService: digestService,
+	}
+}
+
+// Preview returns the current user's digest of the last week or month that ended
+// GET /api/v1/reports/digest
+func (h *DigestHandler) Preview(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.DigestQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	digest, err := h.digestService.Preview(c.Request.Context(), userID, query.Frequency)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	notification := digest.Notification(middleware.Language(c))
+	response := &dto.DigestResponse{
+		Frequency:     digest.Frequency,
+		Currency:      digest.Currency,
+		PeriodStart:   digest.PeriodStart,
+		PeriodEnd:     digest.PeriodEnd,
+		Total:         domain.MajorUnits(digest.Total, digest.Currency),
+		Count:         digest.Count,
+		PreviousTotal: domain.MajorUnits(digest.PreviousTotal, digest.Currency),
+		TopCategories: make([]*dto.DigestCategoryResponse, len(digest.TopCategories)),
+		Budgets:       make([]*dto.BudgetResponse, len(digest.Budgets)),
+		Unconverted:   digest.Unconverted,
+		Title:         notification.Title,
+		Body:          notification.Body,
+	}
+	for i, category := range digest.TopCategories {
+		response.TopCategories[i] = &dto.DigestCategoryResponse{
+			Category:      category.Category,
+			Total:         domain.MajorUnits(category.Total, digest.Currency),
+			PreviousTotal: domain.MajorUnits(category.PreviousTotal, digest.Currency),
+		}
+	}
+	for i, status := range digest.Budgets {
+		response.Budgets[i] = toBudgetResponse(status)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Digest retrieved successfully", response)
+}
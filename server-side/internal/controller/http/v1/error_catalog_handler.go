@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// ErrorCatalogHandler handles error code documentation HTTP requests
+type ErrorCatalogHandler struct{}
+
+// NewErrorCatalogHandler creates a new error catalog handler
+func NewErrorCatalogHandler() *ErrorCatalogHandler {
+	return &ErrorCatalogHandler{}
+}
+
+// List returns every error code with its HTTP status and description
+// GET /api/v1/meta/errors
+func (h *ErrorCatalogHandler) List(c *gin.Context) {
+	catalog := appErrors.Catalog()
+	response := &dto.ErrorCatalogResponse{Errors: make([]*dto.ErrorCodeResponse, 0, len(catalog))}
+	for _, info := range catalog {
+		response.Errors = append(response.Errors, errorCodeResponse(info))
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Error codes retrieved successfully", response)
+}
+
+// Get returns an error code, the target of the doc_url of error responses
+// GET /api/v1/meta/errors/:code
+func (h *ErrorCatalogHandler) Get(c *gin.Context) {
+	info, ok := appErrors.Describe(appErrors.ErrorCode(c.Param("code")))
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrNotFound)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Error code retrieved successfully", errorCodeResponse(info))
+}
+
+func errorCodeResponse(info appErrors.CodeInfo) *dto.ErrorCodeResponse {
+	return &dto.ErrorCodeResponse{
+		Code:        string(info.Code),
+		HTTPStatus:  info.HTTPStatus,
+		Description: info.Description,
+		DocURL:      appErrors.DocURL(info.Code),
+	}
+}
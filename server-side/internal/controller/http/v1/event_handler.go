@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/realtime"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// eventStreamRetry is how long clients wait before reconnecting a dropped stream
+const eventStreamRetry = 5 * time.Second
+
+// EventHandler streams real-time events to clients
+type EventHandler struct {
+	bus       *realtime.Bus
+	heartbeat time.Duration
+}
+
+// NewEventHandler creates a new event handler. A comment is sent every heartbeat so
+// proxies keep idle streams open.
+func NewEventHandler(bus *realtime.Bus, heartbeat time.Duration) *EventHandler {
+	return &EventHandler{
+		bus:       bus,
+		heartbeat: heartbeat,
+	}
+}
+
+// Stream sends the current user's events as server-sent events until the client disconnects
+// GET /api/v1/users/me/events
+func (h *EventHandler) Stream(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	sub, err := h.bus.Subscribe(userID)
+	if err != nil {
+		if errors.Is(err, realtime.ErrTooManySubscriptions) {
+			middleware.AbortWithAppError(c, appErrors.ErrTooManyEventStreams)
+			return
+		}
+		middleware.AbortWithError(c, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Event stream unavailable", http.StatusServiceUnavailable))
+		return
+	}
+	defer sub.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // stop nginx from buffering the stream
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetry.Milliseconds())
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	log := logger.FromContext(c.Request.Context())
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Closed by a slow client or shutdown; the client reconnects
+				return false
+			}
+			data, err := json.Marshal(&dto.EventResponse{
+				ID:         event.ID.String(),
+				Type:       event.Type,
+				OccurredAt: event.OccurredAt,
+				Data:       event.Data,
+			})
+			if err != nil {
+				log.Error("failed to encode event", "event_type", event.Type, "error", err)
+				return true
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			return true
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			return true
+		}
+	})
+}
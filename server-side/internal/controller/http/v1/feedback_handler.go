@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultFeedbackPageSize = 20
+
+// FeedbackHandler handles user feedback HTTP requests
+type FeedbackHandler struct {
+	feedbackService *service.FeedbackService
+}
+
+// NewFeedbackHandler creates a new feedback handler
+func NewFeedbackHandler(feedbackService *service.FeedbackService) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackService: feedbackService,
+	}
+}
+
+// Submit records a feedback or bug report of the current user
+// POST /api/v1/feedback
+func (h *FeedbackHandler) Submit(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.SubmitFeedbackRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	feedback, err := h.feedbackService.Submit(c.Request.Context(), userID, service.FeedbackInput{
+		Kind:          req.Kind,
+		Source:        domain.FeedbackSourceAPI,
+		Message:       req.Message,
+		RequestID:     middleware.GetRequestID(c),
+		LastRequestID: req.LastRequestID,
+		AppVersion:    req.AppVersion,
+		UserAgent:     c.Request.UserAgent(),
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Feedback received, thank you", toFeedbackResponse(feedback))
+}
+
+// List lists feedback of all users, newest first
+// GET /api/v1/admin/feedback
+func (h *FeedbackHandler) List(c *gin.Context) {
+	var query dto.ListFeedbackQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultFeedbackPageSize
+	}
+
+	filter := domain.FeedbackFilter{
+		Kind:   query.Kind,
+		Source: query.Source,
+	}
+	if query.UserID != "" {
+		filter.UserID = uuid.MustParse(query.UserID) // validated by the uuid binding
+	}
+
+	feedback, err := h.feedbackService.List(c.Request.Context(), filter, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.FeedbackResponse, len(feedback))
+	for i, item := range feedback {
+		response[i] = toFeedbackResponse(item)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Feedback retrieved successfully", response)
+}
+
+func toFeedbackResponse(feedback *domain.Feedback) *dto.FeedbackResponse {
+	return &dto.FeedbackResponse{
+		ID:            feedback.ID.String(),
+		UserID:        feedback.UserID.String(),
+		Kind:          feedback.Kind,
+		Source:        feedback.Source,
+		Message:       feedback.Message,
+		RequestID:     feedback.RequestID,
+		LastRequestID: feedback.LastRequestID,
+		AppVersion:    feedback.AppVersion,
+		UserAgent:     feedback.UserAgent,
+		ForwardedAt:   feedback.ForwardedAt,
+		CreatedAt:     feedback.CreatedAt,
+	}
+}
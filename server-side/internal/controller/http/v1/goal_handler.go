@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// GoalHandler handles savings goal HTTP requests
+type GoalHandler struct {
+	goalService *service.GoalService
+}
+
+// NewGoalHandler creates a new goal handler
+func NewGoalHandler(goalService *service.GoalService) *GoalHandler {
+	return &GoalHandler{
+		goalService: goalService,
+	}
+}
+
+// Create creates a savings goal
+// POST /api/v1/goals
+func (h *GoalHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateGoalRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	goal, err := h.goalService.Create(c.Request.Context(), userID, req.Currency, service.GoalInput{
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		SavedAmount:  req.SavedAmount,
+		Deadline:     parseDeadline(req.Deadline),
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Goal created successfully", toGoalResponse(goal))
+}
+
+// List lists the current user's active goals, or all of them with include_archived
+// GET /api/v1/goals
+func (h *GoalHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.ListGoalsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	goals, err := h.goalService.List(c.Request.Context(), userID, query.IncludeArchived)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.GoalResponse, len(goals))
+	for i, goal := range goals {
+		response[i] = toGoalResponse(goal)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Goals retrieved successfully", response)
+}
+
+// Get returns a goal owned by the current user
+// GET /api/v1/goals/:id
+func (h *GoalHandler) Get(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	goal, err := h.goalService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Goal retrieved successfully", toGoalResponse(goal))
+}
+
+// Update replaces the name, amounts, deadline, and archived state of a goal owned by
+// the current user
+// PUT /api/v1/goals/:id
+func (h *GoalHandler) Update(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.UpdateGoalRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	goal, err := h.goalService.Update(c.Request.Context(), userID, id, *req.Version, service.GoalInput{
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		SavedAmount:  req.SavedAmount,
+		Deadline:     parseDeadline(req.Deadline),
+		Archived:     req.Archived,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Goal updated successfully", toGoalResponse(goal))
+}
+
+// Delete deletes a goal owned by the current user
+// DELETE /api/v1/goals/:id
+func (h *GoalHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.goalService.Delete(c.Request.Context(), userID, id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Goal deleted successfully", nil)
+}
+
+// parseDeadline parses a deadline validated by the datetime binding
+func parseDeadline(deadline *string) *time.Time {
+	if deadline == nil {
+		return nil
+	}
+	date, _ := time.Parse(time.DateOnly, *deadline)
+	return &date
+}
+
+func toGoalResponse(goal *domain.Goal) *dto.GoalResponse {
+	var deadline *string
+	if goal.Deadline != nil {
+		date := goal.Deadline.Format(time.DateOnly)
+		deadline = &date
+	}
+	return &dto.GoalResponse{
+		ID:           goal.ID.String(),
+		Name:         goal.Name,
+		Currency:     goal.Currency,
+		TargetAmount: domain.MajorUnits(goal.TargetAmount, goal.Currency),
+		SavedAmount:  domain.MajorUnits(goal.SavedAmount, goal.Currency),
+		Remaining:    domain.MajorUnits(max(goal.TargetAmount-goal.SavedAmount, 0), goal.Currency),
+		Reached:      goal.Reached(),
+		Deadline:     deadline,
+		ArchivedAt:   goal.ArchivedAt,
+		Version:      goal.Version,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	}
+}
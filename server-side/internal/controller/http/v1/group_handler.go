@@ -0,0 +1,336 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// GroupHandler handles shared ledger group HTTP requests
+type GroupHandler struct {
+	groupService *service.GroupService
+}
+
+// NewGroupHandler creates a new group handler
+func NewGroupHandler(groupService *service.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// Create creates a group owned by the current user
+// POST /api/v1/groups
+func (h *GroupHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateGroupRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	detail, err := h.groupService.Create(c.Request.Context(), userID, req.Name)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Group created successfully", toGroupResponse(detail))
+}
+
+// List lists the groups the current user is a member of
+// GET /api/v1/groups
+func (h *GroupHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	details, err := h.groupService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.GroupResponse, len(details))
+	for i, detail := range details {
+		response[i] = toGroupResponse(detail)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Groups retrieved successfully", response)
+}
+
+// Get returns a group the current user is a member of, with its members
+// GET /api/v1/groups/:id
+func (h *GroupHandler) Get(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	detail, err := h.groupService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Group retrieved successfully", toGroupResponse(detail))
+}
+
+// Update renames a group
+// PUT /api/v1/groups/:id
+func (h *GroupHandler) Update(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateGroupRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	detail, err := h.groupService.Rename(c.Request.Context(), userID, id, *req.Version, req.Name)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Group updated successfully", toGroupResponse(detail))
+}
+
+// Delete deletes a group owned by the current user
+// DELETE /api/v1/groups/:id
+func (h *GroupHandler) Delete(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.groupService.Delete(c.Request.Context(), userID, id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Group deleted successfully", nil)
+}
+
+// CreateInvitation creates an invitation token to share with someone joining the group
+// POST /api/v1/groups/:id/invitations
+func (h *GroupHandler) CreateInvitation(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateGroupInvitationRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	created, err := h.groupService.Invite(c.Request.Context(), userID, id, req.Role)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := toGroupInvitationResponse(created.Invitation)
+	response.Token = created.Token
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Invitation created successfully", response)
+}
+
+// ListInvitations lists the pending invitations of a group
+// GET /api/v1/groups/:id/invitations
+func (h *GroupHandler) ListInvitations(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.groupService.ListInvitations(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.GroupInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		response[i] = toGroupInvitationResponse(invitation)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Invitations retrieved successfully", response)
+}
+
+// RevokeInvitation deletes a pending invitation of a group
+// DELETE /api/v1/groups/:id/invitations/:invitationId
+func (h *GroupHandler) RevokeInvitation(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.groupService.RevokeInvitation(c.Request.Context(), userID, id, invitationID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Invitation revoked successfully", nil)
+}
+
+// AcceptInvitation adds the current user to the group of an invitation token
+// POST /api/v1/groups/invitations/accept
+func (h *GroupHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.AcceptGroupInvitationRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	detail, err := h.groupService.AcceptInvitation(c.Request.Context(), userID, req.Token)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Invitation accepted successfully", toGroupResponse(detail))
+}
+
+// UpdateMember changes the role of a member of a group
+// PUT /api/v1/groups/:id/members/:userId
+func (h *GroupHandler) UpdateMember(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.UpdateGroupMemberRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	if err := h.groupService.SetMemberRole(c.Request.Context(), userID, id, memberID, req.Role); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Group member updated successfully", nil)
+}
+
+// RemoveMember removes a member from a group, or lets the current user leave it
+// DELETE /api/v1/groups/:id/members/:userId
+func (h *GroupHandler) RemoveMember(c *gin.Context) {
+	userID, id, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.groupService.RemoveMember(c.Request.Context(), userID, id, memberID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Group member removed successfully", nil)
+}
+
+// groupParams returns the current user and the group of the :id parameter, aborting
+// the request when either is missing
+func groupParams(c *gin.Context) (userID, groupID uuid.UUID, ok bool) {
+	userID, ok = middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, groupID, true
+}
+
+func toGroupResponse(detail *service.GroupDetail) *dto.GroupResponse {
+	group := detail.Group
+	response := &dto.GroupResponse{
+		ID:        group.ID.String(),
+		Name:      group.Name,
+		Role:      detail.Role,
+		Version:   group.Version,
+		CreatedAt: group.CreatedAt,
+		UpdatedAt: group.UpdatedAt,
+	}
+	for _, member := range detail.Members {
+		response.Members = append(response.Members, &dto.GroupMemberResponse{
+			UserID:   member.UserID.String(),
+			FullName: member.FullName,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+	return response
+}
+
+func toGroupInvitationResponse(invitation *domain.GroupInvitation) *dto.GroupInvitationResponse {
+	return &dto.GroupInvitationResponse{
+		ID:        invitation.ID.String(),
+		Role:      invitation.Role,
+		InvitedBy: invitation.InvitedBy.String(),
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+// serviceName identifies the API in health responses
+const serviceName = "catetin-api"
+
+// HealthHandler handles the liveness and readiness probes
+type HealthHandler struct {
+	healthService *service.HealthService
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(healthService *service.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// Liveness reports that the process is running. It checks no dependency, so an outage
+// of the database makes instances unready instead of restarting them.
+// GET /healthz
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, &dto.HealthResponse{
+		Status:  "alive",
+		Service: serviceName,
+	})
+}
+
+// Readiness reports whether the instance can serve traffic, with the result of every
+// check. It responds 503 when a critical check fails.
+// GET /readyz
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	ctx := c.Request.Context()
+	report := h.healthService.Readiness(ctx)
+
+	resp := &dto.HealthResponse{
+		Status:  "ready",
+		Service: serviceName,
+		Checks:  make([]*dto.HealthCheckResponse, len(report.Checks)),
+	}
+	for i, result := range report.Checks {
+		// Errors can name hosts and are only logged, as the probe is public
+		if result.Status != service.HealthStatusOK {
+			logger.FromContext(ctx).Warn("health check failed", "check", result.Name, "critical", result.Critical, "error", result.Error)
+		}
+		resp.Checks[i] = &dto.HealthCheckResponse{
+			Name:       result.Name,
+			Status:     result.Status,
+			Critical:   result.Critical,
+			DurationMS: result.Duration.Milliseconds(),
+			CheckedAt:  result.CheckedAt,
+		}
+	}
+
+	status := http.StatusOK
+	if !report.Ready {
+		resp.Status = "not_ready"
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, resp)
+}
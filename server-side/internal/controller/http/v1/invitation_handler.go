@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// maxUserImportFileSize is the largest CSV file accepted by ImportUsers
+const maxUserImportFileSize = 1 << 20
+
+// InvitationHandler handles user import and invitation HTTP requests
+type InvitationHandler struct {
+	invitationService *service.InvitationService
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(invitationService *service.InvitationService) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+	}
+}
+
+// ImportUsers creates accounts for the users of a CSV file and invites them
+// POST /api/v1/admin/users/import
+func (h *InvitationHandler) ImportUsers(c *gin.Context) {
+	adminID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ImportUsersRequest
+
+	// Bind and validate request
+	if err := c.ShouldBind(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if req.File.Size > maxUserImportFileSize {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "must be at most 1 MB",
+		}))
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "could not be read",
+		}))
+		return
+	}
+	defer file.Close()
+
+	// Call service
+	result, err := h.invitationService.ImportUsers(c.Request.Context(), adminID, file)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Users imported successfully", toImportUsersResponse(result))
+}
+
+// Accept sets the password of an invited account and signs the user in
+// POST /api/v1/authentications/invitations/accept
+func (h *InvitationHandler) Accept(c *gin.Context) {
+	var req dto.AcceptInvitationRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	result, err := h.invitationService.Accept(c.Request.Context(), req.Token, req.Password, req.Client)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	// Build response
+	response := &dto.AuthResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    result.ExpiresIn,
+		User: &dto.UserInfo{
+			ID:          result.User.ID.String(),
+			FullName:    result.User.FullName,
+			Email:       result.Email,
+			PhoneNumber: &result.User.PhoneNumber,
+			Image:       result.User.Image,
+		},
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Invitation accepted successfully", response)
+}
+
+func toImportUsersResponse(result *service.UserImportResult) *dto.ImportUsersResponse {
+	rows := make([]*dto.UserImportRowResponse, len(result.Rows))
+	for i, row := range result.Rows {
+		rows[i] = &dto.UserImportRowResponse{
+			Line:        row.Line,
+			Status:      row.Status,
+			FullName:    row.FullName,
+			Email:       row.Email,
+			PhoneNumber: row.PhoneNumber,
+			Channel:     row.Channel,
+			Errors:      row.Errors,
+		}
+		if row.UserID != nil {
+			id := row.UserID.String()
+			rows[i].UserID = &id
+		}
+	}
+
+	return &dto.ImportUsersResponse{
+		TotalRows:  len(result.Rows),
+		Created:    result.Created,
+		Existing:   result.Existing,
+		Duplicates: result.Duplicates,
+		Invalid:    result.Invalid,
+		Rows:       rows,
+	}
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// LogLevelHandler handles runtime log level HTTP requests
+type LogLevelHandler struct {
+	levels *logger.LevelController
+}
+
+// NewLogLevelHandler creates a new log level handler
+func NewLogLevelHandler(levels *logger.LevelController) *LogLevelHandler {
+	return &LogLevelHandler{
+		levels: levels,
+	}
+}
+
+// Get returns the current log level of this instance
+// GET /api/v1/admin/log-level
+func (h *LogLevelHandler) Get(c *gin.Context) {
+	middleware.RespondWithSuccess(c, http.StatusOK, "Log level retrieved successfully", h.response())
+}
+
+// Update changes the log level of this instance, optionally for a limited time
+// PUT /api/v1/admin/log-level
+func (h *LogLevelHandler) Update(c *gin.Context) {
+	var req dto.UpdateLogLevelRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	level, err := logger.ParseLevel(req.Level)
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrInvalidInput)
+		return
+	}
+
+	h.levels.Set(level, time.Duration(req.DurationMinutes)*time.Minute)
+
+	// Logged at warn so the change is recorded whatever the new level is
+	userID, _ := middleware.GetUserID(c)
+	logger.FromContext(c.Request.Context()).Warn("log level changed",
+		"level", req.Level,
+		"duration_minutes", req.DurationMinutes,
+		"user_id", userID,
+	)
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Log level updated successfully", h.response())
+}
+
+// Reset restores the configured log level of this instance
+// DELETE /api/v1/admin/log-level
+func (h *LogLevelHandler) Reset(c *gin.Context) {
+	h.levels.Reset()
+
+	userID, _ := middleware.GetUserID(c)
+	logger.FromContext(c.Request.Context()).Warn("log level reset", "user_id", userID)
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Log level reset successfully", h.response())
+}
+
+func (h *LogLevelHandler) response() *dto.LogLevelResponse {
+	return &dto.LogLevelResponse{
+		Level:        strings.ToLower(h.levels.Level().String()),
+		DefaultLevel: strings.ToLower(h.levels.Base().String()),
+		ResetAt:      h.levels.ResetAt(),
+	}
+}
@@ -0,0 +1,187 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultMerchantPageSize = 50
+
+// MerchantHandler handles admin merchant and merchant rule HTTP requests
+type MerchantHandler struct {
+	merchantService *service.MerchantService
+}
+
+// NewMerchantHandler creates a new merchant handler
+func NewMerchantHandler(merchantService *service.MerchantService) *MerchantHandler {
+	return &MerchantHandler{
+		merchantService: merchantService,
+	}
+}
+
+// List lists merchants, optionally those whose name contains ?q=, ordered by name
+// GET /api/v1/admin/merchants
+func (h *MerchantHandler) List(c *gin.Context) {
+	var query dto.ListMerchantsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultMerchantPageSize
+	}
+
+	merchants, err := h.merchantService.List(c.Request.Context(), query.Query, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.MerchantResponse, len(merchants))
+	for i, merchant := range merchants {
+		response[i] = toMerchantResponse(merchant)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Merchants retrieved successfully", response)
+}
+
+// Update renames a merchant
+// PUT /api/v1/admin/merchants/:id
+func (h *MerchantHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.UpdateMerchantRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	merchant, err := h.merchantService.Rename(c.Request.Context(), id, req.Name)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Merchant updated successfully", toMerchantResponse(merchant))
+}
+
+// ListRules lists the merchant rules, ordered by pattern
+// GET /api/v1/admin/merchant-rules
+func (h *MerchantHandler) ListRules(c *gin.Context) {
+	rules, err := h.merchantService.ListRules(c.Request.Context())
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.MerchantRuleResponse, len(rules))
+	for i, rule := range rules {
+		response[i] = toMerchantRuleResponse(rule)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Merchant rules retrieved successfully", response)
+}
+
+// CreateRule creates a merchant rule
+// POST /api/v1/admin/merchant-rules
+func (h *MerchantHandler) CreateRule(c *gin.Context) {
+	var req dto.MerchantRuleRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	rule, err := h.merchantService.CreateRule(c.Request.Context(), service.MerchantRuleInput{
+		Pattern:  req.Pattern,
+		Merchant: req.Merchant,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Merchant rule created successfully", toMerchantRuleResponse(rule))
+}
+
+// UpdateRule replaces the pattern and merchant of a merchant rule
+// PUT /api/v1/admin/merchant-rules/:id
+func (h *MerchantHandler) UpdateRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.MerchantRuleRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	rule, err := h.merchantService.UpdateRule(c.Request.Context(), id, service.MerchantRuleInput{
+		Pattern:  req.Pattern,
+		Merchant: req.Merchant,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Merchant rule updated successfully", toMerchantRuleResponse(rule))
+}
+
+// DeleteRule deletes a merchant rule
+// DELETE /api/v1/admin/merchant-rules/:id
+func (h *MerchantHandler) DeleteRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.merchantService.DeleteRule(c.Request.Context(), id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Merchant rule deleted successfully", nil)
+}
+
+func toMerchantResponse(merchant *domain.Merchant) *dto.MerchantResponse {
+	return &dto.MerchantResponse{
+		ID:        merchant.ID.String(),
+		Name:      merchant.Name,
+		CreatedAt: merchant.CreatedAt,
+		UpdatedAt: merchant.UpdatedAt,
+	}
+}
+
+func toMerchantRuleResponse(rule *domain.MerchantRule) *dto.MerchantRuleResponse {
+	response := &dto.MerchantRuleResponse{
+		ID:        rule.ID.String(),
+		Pattern:   rule.Pattern,
+		CreatedAt: rule.CreatedAt,
+		UpdatedAt: rule.UpdatedAt,
+	}
+	if rule.Merchant != nil {
+		response.Merchant = toMerchantResponse(rule.Merchant)
+	}
+	return response
+}
@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultExportFormat = "csv"
+
+// MoneyFlowExportHandler handles money flow export HTTP requests
+type MoneyFlowExportHandler struct {
+	exportService *service.MoneyFlowExportService
+}
+
+// NewMoneyFlowExportHandler creates a new money flow export handler
+func NewMoneyFlowExportHandler(exportService *service.MoneyFlowExportService) *MoneyFlowExportHandler {
+	return &MoneyFlowExportHandler{
+		exportService: exportService,
+	}
+}
+
+// Export downloads the current user's money flows of a month (default: the current
+// month) in the requested format
+// GET /api/v1/money-flows/export
+func (h *MoneyFlowExportHandler) Export(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.ExportMoneyFlowsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Format == "" {
+		query.Format = defaultExportFormat
+	}
+
+	month := time.Now().UTC()
+	if query.Month != "" {
+		month, _ = time.Parse(service.ExportMonthLayout, query.Month) // validated by the datetime binding
+	}
+
+	// Call service
+	file, err := h.exportService.Export(c.Request.Context(), userID, query.Format, month)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Filename}))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
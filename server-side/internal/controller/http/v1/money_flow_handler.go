@@ -0,0 +1,801 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/controller/http/validation"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultMoneyFlowPageSize = 20
+
+// maxImportFileSize is the largest CSV file accepted by Import
+const maxImportFileSize = 5 << 20
+
+// importDelimiters maps the delimiter names of import requests to characters
+var importDelimiters = map[string]rune{
+	"comma":     ',',
+	"semicolon": ';',
+	"tab":       '\t',
+	"pipe":      '|',
+}
+
+// MoneyFlowHandler handles money flow HTTP requests
+type MoneyFlowHandler struct {
+	moneyFlowService *service.MoneyFlowService
+}
+
+// NewMoneyFlowHandler creates a new money flow handler
+func NewMoneyFlowHandler(moneyFlowService *service.MoneyFlowService) *MoneyFlowHandler {
+	return &MoneyFlowHandler{
+		moneyFlowService: moneyFlowService,
+	}
+}
+
+// Create records a new money flow
+// POST /api/v1/money-flows
+func (h *MoneyFlowHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateMoneyFlowRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	detail, err := h.moneyFlowService.Create(c.Request.Context(), userID, toMoneyFlowInput(&req))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := toMoneyFlowDetailResponse(detail)
+	if detail.Duplicate != nil {
+		warning := i18n.T(middleware.Language(c), "This looks like a money flow already recorded today")
+		response.Warning = &warning
+		response.DuplicateOf = uuidString(&detail.Duplicate.ID)
+	}
+
+	middleware.SetETag(c, detail.MoneyFlow.Version)
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Money flow created successfully", response)
+}
+
+// List lists the current user's money flows, optionally searching notes with ?q=,
+// filtering by one tag with ?tag=, or by wallet with ?wallet_id=. With ?group_id=,
+// it lists the shared ledger of a group the user is a member of instead.
+// GET /api/v1/money-flows
+func (h *MoneyFlowHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.ListMoneyFlowsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultMoneyFlowPageSize
+	}
+
+	var (
+		moneyFlows []*domain.MoneyFlow
+		filter     repository.MoneyFlowFilter
+		err        error
+	)
+	switch {
+	case query.Group != "":
+		groupID, _ := uuid.Parse(query.Group) // validated by the uuid binding
+		filter.GroupID = &groupID
+		moneyFlows, err = h.moneyFlowService.ListByGroup(c.Request.Context(), userID, groupID, query.Limit, query.Offset)
+	case query.Query != "":
+		moneyFlows, err = h.moneyFlowService.SearchByNote(c.Request.Context(), userID, query.Query, query.Limit, query.Offset)
+	case query.Tag != "":
+		filter.Tag = query.Tag
+		moneyFlows, err = h.moneyFlowService.ListByTag(c.Request.Context(), userID, query.Tag, query.Limit, query.Offset)
+	case query.Wallet != "":
+		walletID, _ := uuid.Parse(query.Wallet) // validated by the uuid binding
+		filter.WalletID = &walletID
+		moneyFlows, err = h.moneyFlowService.ListByWallet(c.Request.Context(), userID, walletID, query.Limit, query.Offset)
+	default:
+		moneyFlows, err = h.moneyFlowService.List(c.Request.Context(), userID, query.Limit, query.Offset)
+	}
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.MoneyFlowResponse, len(moneyFlows))
+	for i, moneyFlow := range moneyFlows {
+		items[i] = toMoneyFlowResponse(moneyFlow)
+	}
+
+	response := &dto.MoneyFlowListResponse{
+		Items:  items,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	// Note searches rank by relevance and are not counted
+	if query.Group != "" || query.Query == "" {
+		total, err := h.moneyFlowService.Count(c.Request.Context(), userID, filter)
+		if err != nil {
+			middleware.AbortWithError(c, err)
+			return
+		}
+		response.Total = &total
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Money flows retrieved successfully", response)
+}
+
+// Summary returns the current user's totals per currency and, with ?from= and ?to=,
+// their income, expense, and net per currency in that period
+// GET /api/v1/money-flows/summary
+func (h *MoneyFlowHandler) Summary(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.MoneyFlowSummaryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	summary, err := h.moneyFlowService.Summary(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	totals := make([]*dto.CurrencyTotalResponse, len(summary.Totals))
+	for i, total := range summary.Totals {
+		totals[i] = &dto.CurrencyTotalResponse{
+			Currency: total.Currency,
+			Total:    domain.MajorUnits(total.Total, total.Currency),
+			Count:    total.Count,
+		}
+	}
+
+	response := &dto.MoneyFlowSummaryResponse{
+		Totals:             totals,
+		DefaultCurrency:    summary.DefaultCurrency,
+		SingleCurrencyMode: summary.SingleCurrencyMode,
+		MixedCurrencies:    summary.MixedCurrencies,
+	}
+	if summary.Warning != "" {
+		response.Warning = &summary.Warning
+	}
+
+	if query.From != "" {
+		from, _ := time.Parse("2006-01-02", query.From) // validated by the datetime binding
+		to, _ := time.Parse("2006-01-02", query.To)     // validated by the datetime binding
+		period, err := h.moneyFlowService.SummaryBetween(c.Request.Context(), userID, from, to)
+		if err != nil {
+			middleware.AbortWithError(c, err)
+			return
+		}
+
+		response.Period = &dto.PeriodFlowSummaryResponse{
+			PeriodStart: period.PeriodStart,
+			PeriodEnd:   period.PeriodEnd,
+			Totals:      make([]*dto.FlowSummaryResponse, len(period.Totals)),
+		}
+		for i, total := range period.Totals {
+			response.Period.Totals[i] = &dto.FlowSummaryResponse{
+				Currency: total.Currency,
+				Income:   domain.MajorUnits(total.Income, total.Currency),
+				Expense:  domain.MajorUnits(total.Expense, total.Currency),
+				Net:      domain.MajorUnits(total.Net, total.Currency),
+				Count:    total.Count,
+			}
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Money flow summary retrieved successfully", response)
+}
+
+// BulkCreate records several money flows, reporting the outcome of each
+// POST /api/v1/money-flows/bulk
+func (h *MoneyFlowHandler) BulkCreate(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.BulkCreateMoneyFlowsRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Validate each item on its own so one bad item does not fail the others
+	language := middleware.Language(c)
+	results := make([]*dto.BulkItemResponse, len(req.Items))
+	var (
+		inputs  []service.MoneyFlowInput
+		indexes []int
+	)
+	for i, raw := range req.Items {
+		var item dto.CreateMoneyFlowRequest
+		err := json.Unmarshal(raw, &item)
+		if err == nil {
+			err = binding.Validator.ValidateStruct(&item)
+		}
+		if err != nil {
+			results[i] = toBulkItemError(i, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"validation_errors": validation.Translate(err, language),
+			}), language)
+			continue
+		}
+		inputs = append(inputs, toMoneyFlowInput(&item))
+		indexes = append(indexes, i)
+	}
+
+	// Call service
+	created, err := h.moneyFlowService.CreateBulk(c.Request.Context(), userID, inputs)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.BulkCreateMoneyFlowsResponse{Results: results}
+	for j, result := range created {
+		i := indexes[j]
+		if result.Err != nil {
+			results[i] = toBulkItemError(i, result.Err, language)
+			continue
+		}
+		results[i] = &dto.BulkItemResponse{
+			Index:     i,
+			Status:    "created",
+			MoneyFlow: toMoneyFlowDetailResponse(result.Detail),
+		}
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			response.Failed++
+		} else {
+			response.Created++
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Bulk request processed successfully", response)
+}
+
+// Import records the money flows of an uploaded CSV file, or previews them with dry_run
+// POST /api/v1/money-flows/import
+func (h *MoneyFlowHandler) Import(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ImportMoneyFlowsRequest
+
+	// Bind and validate request
+	if err := c.ShouldBind(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if req.File.Size > maxImportFileSize {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "must be at most 5 MB",
+		}))
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "could not be read",
+		}))
+		return
+	}
+	defer file.Close()
+
+	mapping := service.ImportMapping{
+		DateColumn:        req.DateColumn,
+		AmountColumn:      req.AmountColumn,
+		DescriptionColumn: req.DescriptionColumn,
+		CategoryColumn:    req.CategoryColumn,
+		CurrencyColumn:    req.CurrencyColumn,
+		DateFormat:        req.DateFormat,
+		Delimiter:         importDelimiters[req.Delimiter],
+		Currency:          req.Currency,
+		NegativeExpenses:  req.NegativeExpenses,
+	}
+	if req.DecimalSeparator == "comma" {
+		mapping.DecimalSeparator = ','
+	}
+
+	// Call service
+	result, err := h.moneyFlowService.Import(c.Request.Context(), userID, file, mapping, req.DryRun)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	if result.DryRun {
+		middleware.RespondWithSuccess(c, http.StatusOK, "Import previewed successfully", toImportResponse(result))
+		return
+	}
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Money flows imported successfully", toImportResponse(result))
+}
+
+// Reconcile compares a bank statement with the current user's recorded money flows
+// and suggests the expenses missing from them
+// POST /api/v1/money-flows/reconcile
+func (h *MoneyFlowHandler) Reconcile(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ReconcileMoneyFlowsRequest
+
+	// Bind and validate request
+	if err := c.ShouldBind(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Dates are validated by the datetime binding
+	periodStart, _ := time.Parse("2006-01-02", req.PeriodStart)
+	periodEnd, _ := time.Parse("2006-01-02", req.PeriodEnd)
+
+	input := service.ReconcileInput{
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		Currency:       req.Currency,
+		OpeningBalance: *req.OpeningBalance,
+		ClosingBalance: *req.ClosingBalance,
+		Mapping: service.ImportMapping{
+			DateColumn:        req.DateColumn,
+			AmountColumn:      req.AmountColumn,
+			DescriptionColumn: req.DescriptionColumn,
+			CategoryColumn:    req.CategoryColumn,
+			CurrencyColumn:    req.CurrencyColumn,
+			DateFormat:        req.DateFormat,
+			Delimiter:         importDelimiters[req.Delimiter],
+			NegativeExpenses:  req.NegativeExpenses,
+		},
+	}
+	if req.DecimalSeparator == "comma" {
+		input.Mapping.DecimalSeparator = ','
+	}
+
+	if req.File != nil {
+		if req.File.Size > maxImportFileSize {
+			middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"file": "must be at most 5 MB",
+			}))
+			return
+		}
+
+		file, err := req.File.Open()
+		if err != nil {
+			middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"file": "could not be read",
+			}))
+			return
+		}
+		defer file.Close()
+		input.Statement = file
+	}
+
+	// Call service
+	result, err := h.moneyFlowService.Reconcile(c.Request.Context(), userID, input)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Statement reconciled successfully", toReconciliationResponse(result))
+}
+
+// Get returns a single money flow including its note
+// GET /api/v1/money-flows/:id
+func (h *MoneyFlowHandler) Get(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	detail, err := h.moneyFlowService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+	if middleware.NotModified(c, detail.MoneyFlow.Version) {
+		return
+	}
+
+	middleware.SetETag(c, detail.MoneyFlow.Version)
+	middleware.RespondWithSuccess(c, http.StatusOK, "Money flow retrieved successfully", toMoneyFlowDetailResponse(detail))
+}
+
+// Update replaces a money flow
+// PUT /api/v1/money-flows/:id
+func (h *MoneyFlowHandler) Update(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.UpdateMoneyFlowRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	version, ok := h.expectedVersion(c, userID, id, req.Version)
+	if !ok {
+		return
+	}
+
+	// Call service
+	detail, err := h.moneyFlowService.Update(c.Request.Context(), userID, id, version, toMoneyFlowInput(&req.CreateMoneyFlowRequest))
+	if err != nil {
+		h.abortWithUpdateError(c, userID, id, err)
+		return
+	}
+
+	middleware.SetETag(c, detail.MoneyFlow.Version)
+	middleware.RespondWithSuccess(c, http.StatusOK, "Money flow updated successfully", toMoneyFlowDetailResponse(detail))
+}
+
+// Patch changes only the fields sent, so clients can sync the fields they changed
+// PATCH /api/v1/money-flows/:id
+func (h *MoneyFlowHandler) Patch(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.PatchMoneyFlowRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	version, ok := h.expectedVersion(c, userID, id, req.Version)
+	if !ok {
+		return
+	}
+
+	// Call service
+	detail, err := h.moneyFlowService.Patch(c.Request.Context(), userID, id, version, toMoneyFlowPatch(&req))
+	if err != nil {
+		h.abortWithUpdateError(c, userID, id, err)
+		return
+	}
+
+	middleware.SetETag(c, detail.MoneyFlow.Version)
+	middleware.RespondWithSuccess(c, http.StatusOK, "Money flow updated successfully", toMoneyFlowDetailResponse(detail))
+}
+
+// Delete soft deletes a money flow
+// DELETE /api/v1/money-flows/:id
+func (h *MoneyFlowHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.moneyFlowService.Delete(c.Request.Context(), userID, id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Money flow deleted successfully", nil)
+}
+
+// expectedVersion returns the version an update of a money flow expects: the one of
+// If-Match, or else the version in the body. It aborts the request when neither is sent
+// or the stored money flow does not match If-Match.
+func (h *MoneyFlowHandler) expectedVersion(c *gin.Context, userID, id uuid.UUID, version *int) (int, bool) {
+	precondition, err := middleware.IfMatch(c)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return 0, false
+	}
+	if precondition == nil {
+		if version == nil {
+			middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"version": "version is required without If-Match",
+			}))
+			return 0, false
+		}
+		return *version, true
+	}
+
+	current, err := h.moneyFlowService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return 0, false
+	}
+	if !precondition.Matches(current.MoneyFlow.Version) {
+		abortWithConflict(c, appErrors.ErrPreconditionFailed, current)
+		return 0, false
+	}
+	return current.MoneyFlow.Version, true
+}
+
+// abortWithUpdateError aborts an update with err, adding the money flow as stored now to
+// version conflicts so the client can merge its change and retry
+func (h *MoneyFlowHandler) abortWithUpdateError(c *gin.Context, userID, id uuid.UUID, err error) {
+	if appErrors.GetErrorCode(err) != appErrors.ErrCodeVersionConflict {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	current, getErr := h.moneyFlowService.Get(c.Request.Context(), userID, id)
+	if getErr != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+	abortWithConflict(c, appErrors.ErrVersionConflict, current)
+}
+
+// abortWithConflict aborts with a failed version check, detailing the current version
+// of the money flow and its ETag
+func abortWithConflict(c *gin.Context, conflict *appErrors.AppError, current *service.MoneyFlowDetail) {
+	middleware.SetETag(c, current.MoneyFlow.Version)
+	middleware.AbortWithAppError(c, conflict.WithDetails(map[string]interface{}{
+		"current_version": current.MoneyFlow.Version,
+		"current":         toMoneyFlowDetailResponse(current),
+	}))
+}
+
+func toMoneyFlowInput(req *dto.CreateMoneyFlowRequest) service.MoneyFlowInput {
+	input := service.MoneyFlowInput{
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Category:    req.Category,
+		Description: req.Description,
+		Tags:        req.Tags,
+		Note:        req.Note,
+		Merchant:    req.Merchant,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		PlaceName:   req.PlaceName,
+
+		OverrideBudget: req.OverrideBudget,
+		AllowDuplicate: req.AllowDuplicate,
+	}
+	// IDs are validated by the uuid binding
+	if req.WalletID != nil {
+		if walletID, err := uuid.Parse(*req.WalletID); err == nil {
+			input.WalletID = &walletID
+		}
+	}
+	if req.GroupID != nil {
+		if groupID, err := uuid.Parse(*req.GroupID); err == nil {
+			input.GroupID = &groupID
+		}
+	}
+	return input
+}
+
+func toMoneyFlowPatch(req *dto.PatchMoneyFlowRequest) service.MoneyFlowPatch {
+	patch := service.MoneyFlowPatch{
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Category:    req.Category,
+		Description: req.Description,
+		Tags:        req.Tags,
+		Note:        req.Note,
+		Merchant:    req.Merchant,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		PlaceName:   req.PlaceName,
+
+		OverrideBudget: req.OverrideBudget,
+	}
+	// IDs are validated by the uuid binding; an empty ID parses to uuid.Nil, which clears it
+	if req.WalletID != nil {
+		walletID, _ := uuid.Parse(*req.WalletID)
+		patch.WalletID = &walletID
+	}
+	if req.GroupID != nil {
+		groupID, _ := uuid.Parse(*req.GroupID)
+		patch.GroupID = &groupID
+	}
+	return patch
+}
+
+func toMoneyFlowResponse(moneyFlow *domain.MoneyFlow) *dto.MoneyFlowResponse {
+	return &dto.MoneyFlowResponse{
+		ID:          moneyFlow.ID.String(),
+		Kind:        moneyFlow.Kind,
+		WalletID:    uuidString(moneyFlow.WalletID),
+		TransferID:  uuidString(moneyFlow.TransferID),
+		GroupID:     uuidString(moneyFlow.GroupID),
+		MerchantID:  uuidString(moneyFlow.MerchantID),
+		UserID:      moneyFlow.UserID.String(),
+		Category:    moneyFlow.Category,
+		Amount:      moneyFlow.Money().Float64(),
+		Currency:    moneyFlow.Currency,
+		Description: moneyFlow.Description,
+		Tags:        moneyFlow.Tags,
+		Latitude:    moneyFlow.Latitude,
+		Longitude:   moneyFlow.Longitude,
+		PlaceName:   moneyFlow.PlaceName,
+		Version:     moneyFlow.Version,
+		CreatedAt:   moneyFlow.CreatedAt,
+		UpdatedAt:   moneyFlow.UpdatedAt,
+	}
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func toBulkItemError(index int, appErr *appErrors.AppError, language i18n.Language) *dto.BulkItemResponse {
+	return &dto.BulkItemResponse{
+		Index:  index,
+		Status: "failed",
+		Error: &dto.BulkItemError{
+			Code:    string(appErr.Code),
+			Message: i18n.T(language, appErr.Message),
+			Details: appErr.Details,
+		},
+	}
+}
+
+func toImportResponse(result *service.ImportResult) *dto.ImportMoneyFlowsResponse {
+	rows := make([]*dto.ImportRowResponse, len(result.Rows))
+	for i, row := range result.Rows {
+		rows[i] = toImportRowResponse(row)
+	}
+
+	return &dto.ImportMoneyFlowsResponse{
+		DryRun:     result.DryRun,
+		TotalRows:  len(result.Rows),
+		Imported:   result.Imported,
+		Valid:      result.Valid,
+		Duplicates: result.Duplicates,
+		Skipped:    result.Skipped,
+		Invalid:    result.Invalid,
+		Rows:       rows,
+	}
+}
+
+func toImportRowResponse(row *service.ImportRow) *dto.ImportRowResponse {
+	response := &dto.ImportRowResponse{
+		Line:   row.Line,
+		Status: row.Status,
+		Errors: row.Errors,
+	}
+	if moneyFlow := row.MoneyFlow; moneyFlow != nil {
+		response.Date = &moneyFlow.CreatedAt
+		amount := moneyFlow.Money().Float64()
+		response.Amount = &amount
+		response.Currency = moneyFlow.Currency
+		response.Category = moneyFlow.Category
+		response.Description = moneyFlow.Description
+		if row.Status == service.ImportRowImported {
+			id := moneyFlow.ID.String()
+			response.MoneyFlowID = &id
+		}
+	}
+	return response
+}
+
+func toReconciliationResponse(result *service.Reconciliation) *dto.ReconciliationResponse {
+	response := &dto.ReconciliationResponse{
+		PeriodStart:            result.PeriodStart.Format("2006-01-02"),
+		PeriodEnd:              result.PeriodEnd.Format("2006-01-02"),
+		Currency:               result.Currency,
+		OpeningBalance:         domain.MajorUnits(result.OpeningBalance, result.Currency),
+		ClosingBalance:         domain.MajorUnits(result.ClosingBalance, result.Currency),
+		RecordedTotal:          domain.MajorUnits(result.RecordedTotal, result.Currency),
+		RecordedCount:          result.RecordedCount,
+		ExpectedClosingBalance: domain.MajorUnits(result.ExpectedClosingBalance, result.Currency),
+		Discrepancy:            domain.MajorUnits(result.Discrepancy, result.Currency),
+		Balanced:               result.Balanced,
+		Suggestions:            make([]*dto.StatementLineResponse, len(result.Suggestions)),
+		UnmatchedMoneyFlows:    make([]*dto.MoneyFlowResponse, len(result.Unmatched)),
+		IgnoredRows:            make([]*dto.ImportRowResponse, len(result.Ignored)),
+	}
+	if result.HasStatement {
+		income := domain.MajorUnits(result.StatementIncome, result.Currency)
+		expenses := domain.MajorUnits(result.StatementExpenses, result.Currency)
+		response.StatementIncome = &income
+		response.StatementExpenses = &expenses
+	}
+	for i, line := range result.Suggestions {
+		response.Suggestions[i] = &dto.StatementLineResponse{
+			Line: line.Line,
+			Date: line.Date.Format("2006-01-02"),
+			MoneyFlow: &dto.MoneyFlowDraftResponse{
+				Amount:      line.Draft.Amount,
+				Currency:    line.Draft.Currency,
+				Category:    line.Draft.Category,
+				Description: line.Draft.Description,
+			},
+		}
+	}
+	for i, moneyFlow := range result.Unmatched {
+		response.UnmatchedMoneyFlows[i] = toMoneyFlowResponse(moneyFlow)
+	}
+	for i, row := range result.Ignored {
+		response.IgnoredRows[i] = toImportRowResponse(row)
+	}
+	return response
+}
+
+func toMoneyFlowDetailResponse(detail *service.MoneyFlowDetail) *dto.MoneyFlowResponse {
+	response := toMoneyFlowResponse(detail.MoneyFlow)
+	if detail.Note != nil {
+		response.Note = &detail.Note.Content
+	}
+	if detail.Merchant != nil {
+		response.Merchant = &detail.Merchant.Name
+	}
+	return response
+}
@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// sharedMoneyFlowPolicy is the Content-Security-Policy of the shared money flow page:
+// nothing but its inline styles
+const sharedMoneyFlowPolicy = "default-src 'none'; style-src 'unsafe-inline'; " +
+	"frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+var sharedMoneyFlowPage = template.Must(template.New("shared-money-flow").Parse(`<!DOCTYPE html>
+<html lang="{{.Language}}">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <meta name="robots" content="noindex, nofollow">
+  <title>{{.Title}}</title>
+  <style>
+    body { font-family: system-ui, sans-serif; margin: 0; padding: 2rem 1rem; background: #f5f5f5; color: #222; }
+    main { max-width: 32rem; margin: 0 auto; padding: 1.5rem; background: #fff; border-radius: 8px; }
+    h1 { font-size: 1rem; font-weight: normal; color: #555; margin: 0 0 1rem; }
+    .amount { font-size: 2rem; font-weight: bold; margin: 0; }
+    .kind { color: #555; margin: 0 0 1.5rem; }
+    dl { display: grid; grid-template-columns: max-content 1fr; gap: 0.5rem 1rem; margin: 0; }
+    dt { color: #555; }
+    dd { margin: 0; white-space: pre-wrap; overflow-wrap: anywhere; }
+    footer { max-width: 32rem; margin: 1rem auto 0; font-size: 0.875rem; color: #777; }
+  </style>
+</head>
+<body>
+  <main>
+    <h1>{{.Title}}</h1>
+    <p class="amount">{{.Amount}}</p>
+    <p class="kind">{{.Kind}}</p>
+    <dl>
+      {{- range .Fields}}
+      <dt>{{.Label}}</dt>
+      <dd>{{.Value}}</dd>
+      {{- end}}
+    </dl>
+  </main>
+  <footer>{{.Expires}}</footer>
+</body>
+</html>
+`))
+
+// sharedMoneyFlowField is a labeled line of the shared money flow page
+type sharedMoneyFlowField struct {
+	Label string
+	Value string
+}
+
+// MoneyFlowShareHandler handles money flow share link HTTP requests
+type MoneyFlowShareHandler struct {
+	shareService *service.MoneyFlowShareService
+}
+
+// NewMoneyFlowShareHandler creates a new money flow share handler
+func NewMoneyFlowShareHandler(shareService *service.MoneyFlowShareService) *MoneyFlowShareHandler {
+	return &MoneyFlowShareHandler{
+		shareService: shareService,
+	}
+}
+
+// Create creates a signed public link to a read-only view of a money flow of the
+// current user
+// POST /api/v1/money-flows/:id/share
+func (h *MoneyFlowShareHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.CreateMoneyFlowShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	link, err := h.shareService.Create(c.Request.Context(), userID, id, time.Duration(req.TTLHours)*time.Hour, req.IncludeNote)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Share link created successfully", dto.MoneyFlowShareResponse{
+		ID:          link.Share.ID.String(),
+		URL:         link.URL,
+		IncludeNote: link.Share.IncludeNote,
+		ExpiresAt:   link.Share.ExpiresAt,
+	})
+}
+
+// Revoke revokes every share link to a money flow of the current user
+// DELETE /api/v1/money-flows/:id/share
+func (h *MoneyFlowShareHandler) Revoke(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	// Call service
+	revoked, err := h.shareService.Revoke(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Share links revoked successfully",
+		dto.RevokeMoneyFlowShareResponse{Revoked: revoked})
+}
+
+// View shows a shared money flow to the holder of its signed link, without
+// authentication: as an HTML page to browsers asking for one, else as JSON
+// GET /api/v1/shared/money-flows/:id
+func (h *MoneyFlowShareHandler) View(c *gin.Context) {
+	shareID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrInvalidShareLink)
+		return
+	}
+
+	var query dto.SharedMoneyFlowQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrInvalidShareLink)
+		return
+	}
+
+	// Call service
+	shared, err := h.shareService.Open(c.Request.Context(), shareID, query.Expires, query.Signature)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex, nofollow")
+	c.Writer.Header().Add("Vary", "Accept")
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		h.renderPage(c, shared)
+		return
+	}
+
+	moneyFlow := shared.MoneyFlow
+	response := dto.SharedMoneyFlowResponse{
+		Kind:        moneyFlow.Kind,
+		Amount:      moneyFlow.Money().Float64(),
+		Currency:    moneyFlow.Currency,
+		Category:    moneyFlow.Category,
+		Description: moneyFlow.Description,
+		Tags:        moneyFlow.Tags,
+		PlaceName:   moneyFlow.PlaceName,
+		SharedBy:    shared.SharedBy,
+		CreatedAt:   moneyFlow.CreatedAt,
+		ExpiresAt:   shared.ExpiresAt,
+	}
+	if shared.Merchant != nil {
+		response.Merchant = &shared.Merchant.Name
+	}
+	if shared.Note != nil {
+		response.Note = &shared.Note.Content
+	}
+	middleware.RespondWithSuccess(c, http.StatusOK, "Shared money flow retrieved successfully", response)
+}
+
+// renderPage writes the HTML page of a shared money flow, in the language of the request
+// and the time zone of its owner
+func (h *MoneyFlowShareHandler) renderPage(c *gin.Context, shared *service.SharedMoneyFlow) {
+	language := middleware.Language(c)
+	moneyFlow := shared.MoneyFlow
+	formatTime := func(t time.Time) string {
+		t = t.In(shared.Location)
+		return fmt.Sprintf("%d %s %d %s", t.Day(), i18n.T(language, fmt.Sprintf("month.short.%d", t.Month())), t.Year(), t.Format("15:04 MST"))
+	}
+
+	fields := []sharedMoneyFlowField{{Label: i18n.T(language, "share.date"), Value: formatTime(moneyFlow.CreatedAt)}}
+	add := func(key string, value *string) {
+		if value != nil && *value != "" {
+			fields = append(fields, sharedMoneyFlowField{Label: i18n.T(language, key), Value: *value})
+		}
+	}
+	add("share.category", moneyFlow.Category)
+	add("share.description", moneyFlow.Description)
+	if shared.Merchant != nil {
+		add("share.merchant", &shared.Merchant.Name)
+	}
+	add("share.place", moneyFlow.PlaceName)
+	if len(moneyFlow.Tags) > 0 {
+		tags := strings.Join(moneyFlow.Tags, ", ")
+		add("share.tags", &tags)
+	}
+	if shared.Note != nil {
+		add("share.note", &shared.Note.Content)
+	}
+
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Content-Security-Policy", sharedMoneyFlowPolicy)
+	err := sharedMoneyFlowPage.Execute(c.Writer, map[string]interface{}{
+		"Language": language,
+		"Title":    i18n.T(language, "share.title", shared.SharedBy),
+		"Amount":   moneyFlow.Currency + " " + moneyFlow.Money().String(),
+		"Kind":     i18n.T(language, "share.kind."+moneyFlow.Kind),
+		"Fields":   fields,
+		"Expires":  i18n.T(language, "share.expires", formatTime(shared.ExpiresAt)),
+	})
+	if err != nil {
+		// The status is sent, so the client only sees a truncated page
+		logger.FromContext(c.Request.Context()).Warn("shared money flow page failed", "money_flow_id", moneyFlow.ID, "error", err)
+	}
+}
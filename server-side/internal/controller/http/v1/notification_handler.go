@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultNotificationPageSize = 20
+
+// NotificationHandler handles in-app notification HTTP requests
+type NotificationHandler struct {
+	notificationService *service.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// List lists the current user's notifications, newest first
+// GET /api/v1/users/me/notifications
+func (h *NotificationHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultNotificationPageSize
+	}
+
+	notifications, err := h.notificationService.List(c.Request.Context(), userID, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		items[i] = toNotificationResponse(notification)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Notifications retrieved successfully", &dto.NotificationListResponse{
+		Items:  items,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// MarkRead marks one of the current user's notifications as read
+// POST /api/v1/users/me/notifications/:id/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), userID, notificationID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Notification marked as read", nil)
+}
+
+func toNotificationResponse(notification *domain.Notification) *dto.NotificationResponse {
+	return &dto.NotificationResponse{
+		ID:        notification.ID.String(),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Read:      notification.IsRead(),
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
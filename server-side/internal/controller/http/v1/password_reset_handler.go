@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+// PasswordResetHandler handles password reset HTTP requests
+type PasswordResetHandler struct {
+	resetService *service.PasswordResetService
+}
+
+// NewPasswordResetHandler creates a new password reset handler
+func NewPasswordResetHandler(resetService *service.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		resetService: resetService,
+	}
+}
+
+// Request emails a password reset link if an account signs in with the email
+// POST /api/v1/authentications/password-reset
+func (h *PasswordResetHandler) Request(c *gin.Context) {
+	var req dto.RequestPasswordResetRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	if err := h.resetService.Request(c.Request.Context(), req.Email); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusAccepted, "If an account uses this email, a password reset link has been sent", nil)
+}
+
+// Confirm sets a new password with the token of a password reset link
+// POST /api/v1/authentications/password-reset/confirm
+func (h *PasswordResetHandler) Confirm(c *gin.Context) {
+	var req dto.ConfirmPasswordResetRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	if err := h.resetService.Confirm(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Password reset successfully", nil)
+}
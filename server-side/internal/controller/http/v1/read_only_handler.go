@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// ReadOnlyHandler handles read-only mode HTTP requests
+type ReadOnlyHandler struct {
+	readOnlyService *service.ReadOnlyService
+}
+
+// NewReadOnlyHandler creates a new read-only mode handler
+func NewReadOnlyHandler(readOnlyService *service.ReadOnlyService) *ReadOnlyHandler {
+	return &ReadOnlyHandler{
+		readOnlyService: readOnlyService,
+	}
+}
+
+// Get returns the state of read-only mode
+// GET /api/v1/admin/read-only
+func (h *ReadOnlyHandler) Get(c *gin.Context) {
+	status, err := h.readOnlyService.Status(c.Request.Context())
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Read-only mode retrieved successfully", toReadOnlyResponse(status))
+}
+
+// Update turns read-only mode on or off for every instance
+// PUT /api/v1/admin/read-only
+func (h *ReadOnlyHandler) Update(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.UpdateReadOnlyRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	status, err := h.readOnlyService.Set(c.Request.Context(), userID, *req.Enabled, req.Reason)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	// Logged at warn so the change is recorded whatever the log level is
+	logger.FromContext(c.Request.Context()).Warn("read-only mode switched",
+		"enabled", *req.Enabled,
+		"reason", req.Reason,
+		"user_id", userID,
+	)
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Read-only mode updated successfully", toReadOnlyResponse(status))
+}
+
+func toReadOnlyResponse(status *service.ReadOnlyStatus) *dto.ReadOnlyResponse {
+	return &dto.ReadOnlyResponse{
+		Enabled:   status.Enabled,
+		Forced:    status.Forced,
+		Reason:    status.Reason,
+		UpdatedBy: status.UpdatedBy,
+		UpdatedAt: status.UpdatedAt,
+	}
+}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// maxReceiptImageSize is the largest image accepted by ScanReceipt
+const maxReceiptImageSize = 5 << 20
+
+// receiptImageTypes are the image types accepted by ScanReceipt, as detected from the content
+var receiptImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+	"image/gif":  true,
+}
+
+// ReceiptHandler handles receipt scanning HTTP requests
+type ReceiptHandler struct {
+	receiptService *service.ReceiptService
+}
+
+// NewReceiptHandler creates a new receipt handler
+func NewReceiptHandler(receiptService *service.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{
+		receiptService: receiptService,
+	}
+}
+
+// ScanReceipt reads a receipt photo and returns a money flow draft for the user to confirm
+// POST /api/v1/money-flows/scan-receipt
+func (h *ReceiptHandler) ScanReceipt(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ScanReceiptRequest
+
+	// Bind and validate request
+	if err := c.ShouldBind(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if req.Image.Size > maxReceiptImageSize {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"image": "must be at most 5 MB",
+		}))
+		return
+	}
+
+	file, err := req.Image.Open()
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"image": "could not be read",
+		}))
+		return
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(file)
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"image": "could not be read",
+		}))
+		return
+	}
+	mediaType := http.DetectContentType(image)
+	if !receiptImageTypes[mediaType] {
+		middleware.AbortWithAppError(c, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"image": "must be a JPEG, PNG, WebP, or GIF image",
+		}))
+		return
+	}
+
+	// Call service
+	scan, err := h.receiptService.Scan(c.Request.Context(), userID, image, mediaType)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Receipt scanned successfully", toScanReceiptResponse(scan))
+}
+
+func toScanReceiptResponse(scan *service.ReceiptScan) *dto.ScanReceiptResponse {
+	receipt := &dto.ReceiptResponse{
+		Merchant: scan.Merchant,
+		Total:    scan.Total,
+		Currency: scan.Currency,
+		Items:    make([]*dto.ReceiptItemResponse, 0, len(scan.Items)),
+	}
+	if scan.Date != nil {
+		date := scan.Date.Format("2006-01-02")
+		receipt.Date = &date
+	}
+	for _, item := range scan.Items {
+		receipt.Items = append(receipt.Items, &dto.ReceiptItemResponse{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Amount:   item.Amount,
+		})
+	}
+
+	return &dto.ScanReceiptResponse{
+		Receipt: receipt,
+		MoneyFlow: &dto.MoneyFlowDraftResponse{
+			Amount:      scan.Draft.Amount,
+			Currency:    scan.Draft.Currency,
+			Category:    scan.Draft.Category,
+			Description: scan.Draft.Description,
+			Note:        scan.Draft.Note,
+		},
+	}
+}
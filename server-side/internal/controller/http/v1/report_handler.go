@@ -0,0 +1,340 @@
+package v1
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// defaultMerchantReportSize is the number of merchants the merchant report lists by default
+const defaultMerchantReportSize = 20
+
+const (
+	// defaultPlaceReportSize is the number of places the place report lists by default
+	defaultPlaceReportSize = 20
+
+	// defaultNearbyRadius is the radius in meters of a place report near a point by default
+	defaultNearbyRadius = 1000
+)
+
+// ReportHandler handles spending report and exchange rate HTTP requests
+type ReportHandler struct {
+	reportService       *service.ReportService
+	exchangeRateService *service.ExchangeRateService
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportService *service.ReportService, exchangeRateService *service.ExchangeRateService) *ReportHandler {
+	return &ReportHandler{
+		reportService:       reportService,
+		exchangeRateService: exchangeRateService,
+	}
+}
+
+// Summary returns the current user's spending, or that of a group with ?group_id=,
+// converted to one currency
+// GET /api/v1/reports/summary
+func (h *ReportHandler) Summary(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.ReportSummaryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	var period service.ReportPeriod
+	if query.Month != "" {
+		month, _ := time.Parse(service.ExportMonthLayout, query.Month) // validated by the datetime binding
+		period.Month = &month
+	}
+	if query.Week != "" {
+		week, _ := time.Parse("2006-01-02", query.Week) // validated by the datetime binding
+		period.Week = &week
+	}
+	if query.GroupID != "" {
+		groupID, _ := uuid.Parse(query.GroupID) // validated by the uuid binding
+		period.GroupID = &groupID
+	}
+
+	// Call service
+	report, err := h.reportService.Summary(c.Request.Context(), userID, query.Currency, period)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.ReportSummaryResponse{
+		Currency:    report.Currency,
+		PeriodStart: report.PeriodStart,
+		PeriodEnd:   report.PeriodEnd,
+		Total:       domain.MajorUnits(report.Total, report.Currency),
+		Count:       report.Count,
+		RateDate:    formatDate(report.RateDate),
+		Categories:  make([]*dto.CategoryReportResponse, len(report.Categories)),
+		Currencies:  make([]*dto.CurrencyReportResponse, len(report.Currencies)),
+		Unconverted: report.Unconverted,
+	}
+	for i, category := range report.Categories {
+		response.Categories[i] = &dto.CategoryReportResponse{
+			Category: category.Category,
+			Total:    domain.MajorUnits(category.Total, report.Currency),
+			Count:    category.Count,
+		}
+	}
+	for i, currency := range report.Currencies {
+		response.Currencies[i] = &dto.CurrencyReportResponse{
+			Currency: currency.Currency,
+			Total:    domain.MajorUnits(currency.Total, currency.Currency),
+			Count:    currency.Count,
+			Rate:     currency.Rate,
+		}
+		if currency.Converted != nil {
+			converted := domain.MajorUnits(*currency.Converted, report.Currency)
+			response.Currencies[i].Converted = &converted
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Report retrieved successfully", response)
+}
+
+// Trends returns the current user's spending per day, week, or month, or that of a
+// group with ?group_id=, converted to one currency
+// GET /api/v1/reports/trends
+func (h *ReportHandler) Trends(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.TrendQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	input := service.TrendQuery{Interval: query.Interval, Currency: query.Currency}
+	if input.Interval == "" {
+		input.Interval = domain.TrendMonthly
+	}
+	if query.From != "" {
+		from, _ := time.Parse("2006-01-02", query.From) // validated by the datetime binding
+		input.From = &from
+	}
+	if query.To != "" {
+		to, _ := time.Parse("2006-01-02", query.To) // validated by the datetime binding
+		input.To = &to
+	}
+	if query.GroupID != "" {
+		groupID, _ := uuid.Parse(query.GroupID) // validated by the uuid binding
+		input.GroupID = &groupID
+	}
+
+	// Call service
+	report, err := h.reportService.Trends(c.Request.Context(), userID, input)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.TrendResponse{
+		Currency:    report.Currency,
+		Interval:    report.Interval,
+		RateDate:    formatDate(report.RateDate),
+		Points:      make([]*dto.TrendPointResponse, len(report.Points)),
+		Unconverted: report.Unconverted,
+	}
+	for i, point := range report.Points {
+		response.Points[i] = &dto.TrendPointResponse{
+			PeriodStart:   point.PeriodStart,
+			PeriodEnd:     point.PeriodEnd,
+			Total:         domain.MajorUnits(point.Total, report.Currency),
+			Count:         point.Count,
+			Change:        domain.MajorUnits(point.Change, report.Currency),
+			ChangePercent: point.ChangePercent,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Trends retrieved successfully", response)
+}
+
+// Merchants returns the current user's spending per merchant, converted to one currency
+// GET /api/v1/reports/merchants
+func (h *ReportHandler) Merchants(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.MerchantReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultMerchantReportSize
+	}
+
+	var period service.ReportPeriod
+	if query.Month != "" {
+		month, _ := time.Parse(service.ExportMonthLayout, query.Month) // validated by the datetime binding
+		period.Month = &month
+	}
+	if query.Week != "" {
+		week, _ := time.Parse("2006-01-02", query.Week) // validated by the datetime binding
+		period.Week = &week
+	}
+
+	// Call service
+	report, err := h.reportService.Merchants(c.Request.Context(), userID, query.Currency, period, query.Limit)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.MerchantReportResponse{
+		Currency:    report.Currency,
+		PeriodStart: report.PeriodStart,
+		PeriodEnd:   report.PeriodEnd,
+		Total:       domain.MajorUnits(report.Total, report.Currency),
+		Count:       report.Count,
+		RateDate:    formatDate(report.RateDate),
+		Merchants:   make([]*dto.MerchantTotalResponse, len(report.Merchants)),
+		Unconverted: report.Unconverted,
+	}
+	for i, merchant := range report.Merchants {
+		response.Merchants[i] = &dto.MerchantTotalResponse{
+			MerchantID: merchant.Merchant.ID.String(),
+			Name:       merchant.Merchant.Name,
+			Total:      domain.MajorUnits(merchant.Total, report.Currency),
+			Count:      merchant.Count,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Report retrieved successfully", response)
+}
+
+// ExchangeRates returns the latest exchange rates against a base currency, by default
+// domain.DefaultCurrency
+// GET /api/v1/exchange-rates
+func (h *ReportHandler) ExchangeRates(c *gin.Context) {
+	var query dto.ExchangeRatesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Base == "" {
+		query.Base = domain.DefaultCurrency
+	}
+
+	// Call service
+	quote, err := h.exchangeRateService.Quote(c.Request.Context(), query.Base)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Exchange rates retrieved successfully", &dto.ExchangeRatesResponse{
+		Base:   quote.Base,
+		Date:   formatDate(quote.Date),
+		Source: quote.Source,
+		Rates:  quote.Rates,
+	})
+}
+
+// formatDate formats a day as YYYY-MM-DD, keeping nil
+func formatDate(day *time.Time) *string {
+	if day == nil {
+		return nil
+	}
+	formatted := day.Format("2006-01-02")
+	return &formatted
+}
+
+// Places returns the current user's spending per place, converted to one currency,
+// optionally near a point
+// GET /api/v1/reports/places
+func (h *ReportHandler) Places(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.PlaceReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultPlaceReportSize
+	}
+
+	var period service.ReportPeriod
+	if query.Month != "" {
+		month, _ := time.Parse(service.ExportMonthLayout, query.Month) // validated by the datetime binding
+		period.Month = &month
+	}
+	if query.Week != "" {
+		week, _ := time.Parse("2006-01-02", query.Week) // validated by the datetime binding
+		period.Week = &week
+	}
+
+	var near *service.Nearby
+	if query.Latitude != nil && query.Longitude != nil {
+		near = &service.Nearby{Latitude: *query.Latitude, Longitude: *query.Longitude, Radius: query.Radius}
+		if near.Radius == 0 {
+			near.Radius = defaultNearbyRadius
+		}
+	}
+
+	// Call service
+	report, err := h.reportService.Places(c.Request.Context(), userID, query.Currency, period, near, query.Limit)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.PlaceReportResponse{
+		Currency:    report.Currency,
+		PeriodStart: report.PeriodStart,
+		PeriodEnd:   report.PeriodEnd,
+		Total:       domain.MajorUnits(report.Total, report.Currency),
+		Count:       report.Count,
+		RateDate:    formatDate(report.RateDate),
+		Places:      make([]*dto.PlaceTotalResponse, len(report.Places)),
+		Unconverted: report.Unconverted,
+	}
+	if near != nil {
+		response.Near = &dto.NearbyResponse{Latitude: near.Latitude, Longitude: near.Longitude, Radius: near.Radius}
+	}
+	for i, place := range report.Places {
+		response.Places[i] = &dto.PlaceTotalResponse{
+			PlaceName: place.Name,
+			Latitude:  place.Latitude,
+			Longitude: place.Longitude,
+			Total:     domain.MajorUnits(place.Total, report.Currency),
+			Count:     place.Count,
+		}
+		if place.Distance != nil {
+			distance := math.Round(*place.Distance)
+			response.Places[i].Distance = &distance
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Report retrieved successfully", response)
+}
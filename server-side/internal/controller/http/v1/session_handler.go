@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// defaultSessionPageSize is the number of sessions listed when no limit is given
+const defaultSessionPageSize = 20
+
+// SessionHandler handles the current user's session HTTP requests
+type SessionHandler struct {
+	authService *service.AuthService
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(authService *service.AuthService) *SessionHandler {
+	return &SessionHandler{
+		authService: authService,
+	}
+}
+
+// List lists the sessions the current user is signed in to, most recently used first
+// GET /api/v1/users/me/sessions
+func (h *SessionHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSessionPageSize
+	}
+
+	// Call service
+	sessions, total, err := h.authService.ListActiveSessions(c.Request.Context(), userID, repository.Page{Limit: query.Limit, Offset: query.Offset})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	currentSessionID := currentSession(c)
+	items := make([]*dto.UserSessionResponse, len(sessions))
+	for i, session := range sessions {
+		items[i] = &dto.UserSessionResponse{
+			ID:         session.SessionID.String(),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			SignedInAt: session.SignedInAt,
+			LastUsedAt: session.CreatedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.SessionID == currentSessionID,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Sessions retrieved successfully", &dto.UserSessionListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// Revoke signs the current user out of one of their sessions
+// DELETE /api/v1/users/me/sessions/:id
+func (h *SessionHandler) Revoke(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	// Call service
+	if err := h.authService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Session revoked successfully", nil)
+}
+
+// RevokeOthers signs the current user out of every session but the current one
+// DELETE /api/v1/users/me/sessions
+func (h *SessionHandler) RevokeOthers(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	// Call service
+	revoked, err := h.authService.RevokeOtherSessions(c.Request.Context(), userID, currentSession(c))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Other sessions revoked successfully", &dto.RevokeSessionsResponse{
+		Revoked: revoked,
+	})
+}
+
+// currentSession returns the session of the request's access token, or uuid.Nil
+func currentSession(c *gin.Context) uuid.UUID {
+	if claims, ok := middleware.GetClaims(c); ok {
+		return claims.Session()
+	}
+	return uuid.Nil
+}
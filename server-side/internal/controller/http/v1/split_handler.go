@@ -0,0 +1,227 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultSettlementPageSize = 20
+
+// SplitHandler handles split bill HTTP requests
+type SplitHandler struct {
+	splitService *service.SplitService
+}
+
+// NewSplitHandler creates a new split handler
+func NewSplitHandler(splitService *service.SplitService) *SplitHandler {
+	return &SplitHandler{
+		splitService: splitService,
+	}
+}
+
+// Split splits a money flow the current user recorded in a group between its members,
+// replacing any earlier split
+// PUT /api/v1/money-flows/:id/splits
+func (h *SplitHandler) Split(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	moneyFlowID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.SplitMoneyFlowRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	shares := make([]domain.SplitShare, len(req.Shares))
+	for i, share := range req.Shares {
+		shares[i] = domain.SplitShare{
+			UserID:     uuid.MustParse(share.UserID), // validated by the uuid binding
+			Percentage: share.Percentage,
+			Amount:     share.Amount,
+		}
+	}
+
+	// Call service
+	splits, err := h.splitService.Split(c.Request.Context(), userID, moneyFlowID, req.Method, shares)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Money flow split successfully", toMoneyFlowSplitsResponse(splits))
+}
+
+// GetSplits returns how a money flow in a group of the current user is split
+// GET /api/v1/money-flows/:id/splits
+func (h *SplitHandler) GetSplits(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	moneyFlowID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	splits, err := h.splitService.GetSplits(c.Request.Context(), userID, moneyFlowID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Splits retrieved successfully", toMoneyFlowSplitsResponse(splits))
+}
+
+// Balances returns what each member of a group owes or is owed and the fewest payments
+// that settle everyone up
+// GET /api/v1/groups/:id/balances
+func (h *SplitHandler) Balances(c *gin.Context) {
+	userID, groupID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	balances, err := h.splitService.Balances(c.Request.Context(), userID, groupID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.GroupBalancesResponse{
+		Balances: make([]*dto.MemberBalanceResponse, len(balances.Balances)),
+		Debts:    make([]*dto.DebtResponse, len(balances.Debts)),
+	}
+	for i, balance := range balances.Balances {
+		response.Balances[i] = &dto.MemberBalanceResponse{
+			UserID:   balance.UserID.String(),
+			Amount:   domain.MajorUnits(balance.Amount, balance.Currency),
+			Currency: balance.Currency,
+		}
+	}
+	for i, debt := range balances.Debts {
+		response.Debts[i] = &dto.DebtResponse{
+			FromUserID: debt.FromUserID.String(),
+			ToUserID:   debt.ToUserID.String(),
+			Amount:     domain.MajorUnits(debt.Amount, debt.Currency),
+			Currency:   debt.Currency,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Balances retrieved successfully", response)
+}
+
+// CreateSettlement records a payment the current user made to or received from another member
+// POST /api/v1/groups/:id/settlements
+func (h *SplitHandler) CreateSettlement(c *gin.Context) {
+	userID, groupID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateSettlementRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	settlement, err := h.splitService.Settle(c.Request.Context(), userID, groupID, service.SettlementInput{
+		FromUserID: uuid.MustParse(req.FromUserID), // validated by the uuid binding
+		ToUserID:   uuid.MustParse(req.ToUserID),
+		Amount:     req.Amount,
+		Currency:   req.Currency,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Settlement recorded successfully", toSettlementResponse(settlement))
+}
+
+// ListSettlements lists the payments recorded in a group, newest first
+// GET /api/v1/groups/:id/settlements
+func (h *SplitHandler) ListSettlements(c *gin.Context) {
+	userID, groupID, ok := groupParams(c)
+	if !ok {
+		return
+	}
+
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSettlementPageSize
+	}
+
+	settlements, err := h.splitService.ListSettlements(c.Request.Context(), userID, groupID, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.SettlementResponse, len(settlements))
+	for i, settlement := range settlements {
+		items[i] = toSettlementResponse(settlement)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Settlements retrieved successfully", &dto.SettlementListResponse{
+		Items:  items,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+func toMoneyFlowSplitsResponse(splits *service.MoneyFlowSplits) *dto.MoneyFlowSplitsResponse {
+	response := &dto.MoneyFlowSplitsResponse{
+		MoneyFlowID: splits.MoneyFlowID.String(),
+		PaidBy:      splits.PaidBy.String(),
+		Splits:      make([]*dto.SplitResponse, len(splits.Splits)),
+	}
+	for i, split := range splits.Splits {
+		response.Splits[i] = &dto.SplitResponse{
+			UserID:   split.UserID.String(),
+			Method:   split.Method,
+			Amount:   domain.MajorUnits(split.Amount, split.Currency),
+			Currency: split.Currency,
+		}
+	}
+	return response
+}
+
+func toSettlementResponse(settlement *domain.Settlement) *dto.SettlementResponse {
+	return &dto.SettlementResponse{
+		ID:         settlement.ID.String(),
+		FromUserID: settlement.FromUserID.String(),
+		ToUserID:   settlement.ToUserID.String(),
+		Amount:     domain.MajorUnits(settlement.Amount, settlement.Currency),
+		Currency:   settlement.Currency,
+		CreatedBy:  settlement.CreatedBy.String(),
+		CreatedAt:  settlement.CreatedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// statementPreviewPolicy is the Content-Security-Policy of the statement email preview:
+// nothing but its inline styles
+const statementPreviewPolicy = "default-src 'none'; style-src 'unsafe-inline'; " +
+	"frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+
+// StatementHandler handles monthly statement HTTP requests
+type StatementHandler struct {
+	statementService *service.StatementService
+}
+
+// NewStatementHandler creates a new statement handler
+func NewStatementHandler(statementService *service.StatementService) *StatementHandler {
+	return &StatementHandler{
+		statementService: statementService,
+	}
+}
+
+// Preview renders the current user's monthly statement email as it is sent, for
+// developers working on its templates: the HTML body, the plain-text body, or the PDF
+// attachment
+// GET /api/v1/reports/statement
+func (h *StatementHandler) Preview(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var query dto.StatementQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	if query.Month != "" {
+		month, _ = time.Parse(service.ExportMonthLayout, query.Month) // validated by the datetime binding
+	}
+
+	// Call service
+	statement, err := h.statementService.Preview(c.Request.Context(), userID, month, middleware.Language(c))
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	switch query.Format {
+	case "text":
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte("Subject: "+statement.Subject+"\n\n"+statement.Text))
+	case "pdf":
+		c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": statement.PDF.Filename}))
+		c.Data(http.StatusOK, statement.PDF.ContentType, statement.PDF.Content)
+	default:
+		c.Header("Content-Security-Policy", statementPreviewPolicy)
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(statement.HTML))
+	}
+}
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// TagHandler handles money flow tag HTTP requests
+type TagHandler struct {
+	tagService *service.TagService
+}
+
+// NewTagHandler creates a new tag handler
+func NewTagHandler(tagService *service.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// List lists the current user's tags with their usage counts
+// GET /api/v1/tags
+func (h *TagHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	usage, err := h.tagService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.TagResponse, len(usage))
+	for i, tag := range usage {
+		response[i] = toTagResponse(tag)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Tags retrieved successfully", response)
+}
+
+// Rename renames a tag on all of the current user's money flows
+// POST /api/v1/tags/rename
+func (h *TagHandler) Rename(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.RenameTagRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	usage, err := h.tagService.Rename(c.Request.Context(), userID, req.From, req.To)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Tag renamed successfully", toTagResponse(usage))
+}
+
+// Merge replaces several tags with one on all of the current user's money flows
+// POST /api/v1/tags/merge
+func (h *TagHandler) Merge(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.MergeTagsRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	usage, err := h.tagService.Merge(c.Request.Context(), userID, req.From, req.To)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Tags merged successfully", toTagResponse(usage))
+}
+
+func toTagResponse(usage *domain.TagUsage) *dto.TagResponse {
+	return &dto.TagResponse{
+		Tag:        usage.Tag,
+		Count:      usage.Count,
+		LastUsedAt: usage.LastUsedAt,
+	}
+}
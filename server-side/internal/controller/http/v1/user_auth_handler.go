@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultUserAuthPageSize = 20
+
+// UserAuthHandler handles admin HTTP requests inspecting users' credentials and sessions
+type UserAuthHandler struct {
+	authService *service.AuthService
+}
+
+// NewUserAuthHandler creates a new user auth handler
+func NewUserAuthHandler(authService *service.AuthService) *UserAuthHandler {
+	return &UserAuthHandler{
+		authService: authService,
+	}
+}
+
+// ListAuths lists a user's credentials with their provider, last use, and lock state
+// GET /api/v1/admin/users/:id/auths
+func (h *UserAuthHandler) ListAuths(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrUserNotFound)
+		return
+	}
+
+	var query dto.ListUserAuthsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultUserAuthPageSize
+	}
+
+	filter := service.UserAuthFilter{Provider: query.Provider, IncludeDeleted: query.IncludeDeleted}
+	infos, total, err := h.authService.ListUserAuths(c.Request.Context(), userID, filter, repository.Page{Limit: query.Limit, Offset: query.Offset})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.UserAuthResponse, len(infos))
+	for i, info := range infos {
+		item := &dto.UserAuthResponse{
+			ID:           info.UserAuth.ID.String(),
+			CredentialID: info.UserAuth.CredentialID,
+			CreatedAt:    info.UserAuth.CreatedAt,
+			LastUsedAt:   info.UserAuth.LastUsedAt,
+			DeletedAt:    info.UserAuth.DeletedAt,
+			Locked:       info.LockReason != "",
+		}
+		if info.Provider != nil {
+			item.DisplayName = info.Provider.DisplayName
+			if info.Provider.Name != nil {
+				item.Provider = *info.Provider.Name
+			}
+		}
+		if info.LockReason != "" {
+			item.LockReason = &info.LockReason
+		}
+		if info.LockReason == service.LockReasonTooManyAttempts {
+			item.LockedUntil = info.UserAuth.LockedUntil
+		}
+		items[i] = item
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "User auths retrieved successfully", &dto.UserAuthListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// ListSessions lists a user's sessions, newest first
+// GET /api/v1/admin/users/:id/sessions
+func (h *UserAuthHandler) ListSessions(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrUserNotFound)
+		return
+	}
+
+	var query dto.ListSessionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultUserAuthPageSize
+	}
+
+	sessions, total, err := h.authService.ListSessions(c.Request.Context(), userID, query.Status, repository.Page{Limit: query.Limit, Offset: query.Offset})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	now := time.Now()
+	items := make([]*dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		status := repository.RefreshTokenActive
+		switch {
+		case session.RevokedAt != nil:
+			status = repository.RefreshTokenRevoked
+		case !session.IsActive(now):
+			status = repository.RefreshTokenExpired
+		}
+
+		items[i] = &dto.SessionResponse{
+			ID:         session.ID.String(),
+			SessionID:  session.SessionID.String(),
+			Status:     status,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			SignedInAt: session.SignedInAt,
+			CreatedAt:  session.CreatedAt,
+			ExpiresAt:  session.ExpiresAt,
+			RevokedAt:  session.RevokedAt,
+		}
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Sessions retrieved successfully", &dto.SessionListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
@@ -0,0 +1,396 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// UserHandler handles HTTP requests for the authenticated user's account
+type UserHandler struct {
+	authService              *service.AuthService
+	userService              *service.UserService
+	phoneVerificationService *service.PhoneVerificationService
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(authService *service.AuthService, userService *service.UserService, phoneVerificationService *service.PhoneVerificationService) *UserHandler {
+	return &UserHandler{
+		authService:              authService,
+		userService:              userService,
+		phoneVerificationService: phoneVerificationService,
+	}
+}
+
+// GetProfile returns the current user's profile
+// GET /api/v1/users/me
+func (h *UserHandler) GetProfile(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	profile, err := h.userService.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Profile retrieved successfully", toUserProfileResponse(profile))
+}
+
+// UpdateProfile updates the current user's profile
+// PATCH /api/v1/users/me
+func (h *UserHandler) UpdateProfile(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	profile, err := h.userService.UpdateProfile(c.Request.Context(), userID, service.UpdateProfileInput{
+		FullName:    req.FullName,
+		PhoneNumber: req.PhoneNumber,
+		Image:       req.Image,
+		DataRegion:  req.DataRegion,
+		Version:     req.Version,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Profile updated successfully", toUserProfileResponse(profile))
+}
+
+// GetSettings returns the current user's settings
+// GET /api/v1/users/me/settings
+func (h *UserHandler) GetSettings(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	settings, err := h.userService.GetSettings(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Settings retrieved successfully", toUserSettingsResponse(settings))
+}
+
+// UpdateSettings updates the current user's settings
+// PATCH /api/v1/users/me/settings
+func (h *UserHandler) UpdateSettings(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.UpdateUserSettingsRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	var weekStart *time.Weekday
+	if req.WeekStart != nil {
+		day, _ := domain.ParseWeekday(*req.WeekStart) // validated by the oneof binding
+		weekStart = &day
+	}
+
+	// Call service
+	settings, err := h.userService.UpdateSettings(c.Request.Context(), userID, service.UpdateSettingsInput{
+		AnalyticsOptOut:    req.AnalyticsOptOut,
+		DefaultCurrency:    req.DefaultCurrency,
+		SingleCurrencyMode: req.SingleCurrencyMode,
+		Locale:             req.Locale,
+		Timezone:           req.Timezone,
+		WeekStart:          weekStart,
+		NotifyWhatsApp:     req.NotifyWhatsApp,
+		NotifyEmail:        req.NotifyEmail,
+		NotifyTelegram:     req.NotifyTelegram,
+		NotifyPush:         req.NotifyPush,
+		TelegramChatID:     req.TelegramChatID,
+		DigestFrequency:    req.DigestFrequency,
+		DuplicateCheck:     req.DuplicateCheck,
+		MonthlyStatement:   req.MonthlyStatement,
+		Channels:           req.NotificationChannels,
+		Version:            req.Version,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Settings updated successfully", toUserSettingsResponse(settings))
+}
+
+// DeleteAccount deletes the current user's account
+// DELETE /api/v1/users/me
+func (h *UserHandler) DeleteAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	if err := h.userService.DeleteAccount(c.Request.Context(), userID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Account deleted successfully", nil)
+}
+
+// ChangePassword changes the current user's password after verifying the current one
+// POST /api/v1/users/me/password
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	if err := h.authService.ChangePassword(c.Request.Context(), userID, req.CurrentPassword, req.NewPassword); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Password changed successfully", nil)
+}
+
+// Reauthenticate confirms the current user's password and returns an access token that
+// passes the recent authentication check of sensitive endpoints
+// POST /api/v1/users/me/reauthenticate
+func (h *UserHandler) Reauthenticate(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.ReauthenticateRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// The new token is issued to the same client and session as the current one
+	var client string
+	sessionID := uuid.Nil
+	if claims, ok := middleware.GetClaims(c); ok {
+		client = claims.Client()
+		sessionID = claims.Session()
+	}
+
+	// Call service
+	result, err := h.authService.Reauthenticate(c.Request.Context(), userID, req.Password, client, sessionID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	// Build response; the refresh token of the session stays in use
+	response := &dto.AuthResponse{
+		AccessToken: result.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   result.ExpiresIn,
+		User: &dto.UserInfo{
+			ID:          result.User.ID.String(),
+			FullName:    result.User.FullName,
+			Email:       result.Email,
+			PhoneNumber: &result.User.PhoneNumber,
+			Image:       result.User.Image,
+		},
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Reauthenticated successfully", response)
+}
+
+// LinkAuthProvider links an additional auth provider to the current user
+// POST /api/v1/users/me/auth-providers
+func (h *UserHandler) LinkAuthProvider(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.LinkAuthProviderRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	linked, err := h.authService.LinkProvider(c.Request.Context(), userID, req.Provider, req.CredentialID, req.CredentialSecret)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Auth provider linked successfully", toLinkedProviderResponse(linked))
+}
+
+// SendPhoneVerificationCode sends a code by WhatsApp that links the phone number as a
+// phone-otp credential
+// POST /api/v1/users/me/auth-providers/phone-otp/code
+func (h *UserHandler) SendPhoneVerificationCode(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.SendPhoneVerificationCodeRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	verification, err := h.phoneVerificationService.SendCode(c.Request.Context(), userID, req.PhoneNumber)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusAccepted, "Verification code sent successfully", &dto.PhoneVerificationCodeResponse{
+		PhoneNumber: verification.PhoneNumber,
+		ExpiresAt:   verification.ExpiresAt,
+	})
+}
+
+// ListAuthProviders lists auth providers linked to the current user
+// GET /api/v1/users/me/auth-providers
+func (h *UserHandler) ListAuthProviders(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	linked, err := h.authService.ListLinkedProviders(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.LinkedProviderResponse, len(linked))
+	for i, l := range linked {
+		response[i] = toLinkedProviderResponse(l)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Linked auth providers retrieved successfully", response)
+}
+
+// UnlinkAuthProvider removes one of the current user's credentials
+// DELETE /api/v1/users/me/auth-providers/:id
+func (h *UserHandler) UnlinkAuthProvider(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	userAuthID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.authService.UnlinkProvider(c.Request.Context(), userID, userAuthID); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Auth provider unlinked successfully", nil)
+}
+
+func toUserProfileResponse(profile *service.Profile) *dto.UserProfileResponse {
+	return &dto.UserProfileResponse{
+		ID:          profile.User.ID.String(),
+		FullName:    profile.User.FullName,
+		Email:       profile.Email,
+		PhoneNumber: profile.User.PhoneNumber,
+		Image:       profile.User.Image,
+		DataRegion:  profile.DataRegion,
+		Version:     profile.User.Version,
+		CreatedAt:   profile.User.CreatedAt,
+		UpdatedAt:   profile.User.UpdatedAt,
+	}
+}
+
+func toLinkedProviderResponse(linked *service.LinkedProvider) *dto.LinkedProviderResponse {
+	var providerName string
+	if linked.Provider.Name != nil {
+		providerName = *linked.Provider.Name
+	}
+
+	return &dto.LinkedProviderResponse{
+		ID:           linked.UserAuth.ID.String(),
+		Provider:     providerName,
+		DisplayName:  linked.Provider.DisplayName,
+		CredentialID: linked.UserAuth.CredentialID,
+		LinkedAt:     linked.UserAuth.CreatedAt,
+	}
+}
+
+func toUserSettingsResponse(settings *domain.UserSettings) *dto.UserSettingsResponse {
+	return &dto.UserSettingsResponse{
+		AnalyticsOptOut:      settings.AnalyticsOptOut,
+		DefaultCurrency:      settings.DefaultCurrency,
+		SingleCurrencyMode:   settings.SingleCurrencyMode,
+		Locale:               settings.Locale,
+		Timezone:             settings.Timezone,
+		WeekStart:            strings.ToLower(settings.WeekStart.String()),
+		NotifyWhatsApp:       settings.NotifyWhatsApp,
+		NotifyEmail:          settings.NotifyEmail,
+		NotifyTelegram:       settings.NotifyTelegram,
+		NotifyPush:           settings.NotifyPush,
+		TelegramChatID:       settings.TelegramChatID,
+		DigestFrequency:      settings.DigestFrequency,
+		DuplicateCheck:       settings.DuplicateCheck,
+		MonthlyStatement:     settings.MonthlyStatement,
+		NotificationChannels: settings.ChannelOrder(),
+		Version:              settings.Version,
+	}
+}
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// WalletHandler handles wallet HTTP requests
+type WalletHandler struct {
+	walletService *service.WalletService
+}
+
+// NewWalletHandler creates a new wallet handler
+func NewWalletHandler(walletService *service.WalletService) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+	}
+}
+
+// Create creates a wallet
+// POST /api/v1/wallets
+func (h *WalletHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateWalletRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	status, err := h.walletService.Create(c.Request.Context(), userID, req.Currency, service.WalletInput{
+		Name:           req.Name,
+		Type:           req.Type,
+		OpeningBalance: req.OpeningBalance,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Wallet created successfully", toWalletResponse(status))
+}
+
+// List lists the current user's wallets with their balances
+// GET /api/v1/wallets
+func (h *WalletHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	statuses, err := h.walletService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.WalletResponse, len(statuses))
+	for i, status := range statuses {
+		response[i] = toWalletResponse(status)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Wallets retrieved successfully", response)
+}
+
+// Get returns a wallet owned by the current user with its balance
+// GET /api/v1/wallets/:id
+func (h *WalletHandler) Get(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	status, err := h.walletService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Wallet retrieved successfully", toWalletResponse(status))
+}
+
+// Update replaces the name, type, and opening balance of a wallet owned by the current user
+// PUT /api/v1/wallets/:id
+func (h *WalletHandler) Update(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.UpdateWalletRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	status, err := h.walletService.Update(c.Request.Context(), userID, id, *req.Version, service.WalletInput{
+		Name:           req.Name,
+		Type:           req.Type,
+		OpeningBalance: req.OpeningBalance,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Wallet updated successfully", toWalletResponse(status))
+}
+
+// Delete deletes a wallet owned by the current user that has no money flows
+// DELETE /api/v1/wallets/:id
+func (h *WalletHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.walletService.Delete(c.Request.Context(), userID, id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Wallet deleted successfully", nil)
+}
+
+// Transfer moves money between two wallets of the current user
+// POST /api/v1/wallets/transfers
+func (h *WalletHandler) Transfer(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.TransferRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service; the IDs are validated by the uuid binding
+	transfer, err := h.walletService.Transfer(c.Request.Context(), userID, service.TransferInput{
+		FromWalletID: uuid.MustParse(req.FromWalletID),
+		ToWalletID:   uuid.MustParse(req.ToWalletID),
+		Amount:       req.Amount,
+		ToAmount:     req.ToAmount,
+		Description:  req.Description,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Transfer recorded successfully", &dto.TransferResponse{
+		ID:  transfer.ID.String(),
+		Out: toMoneyFlowResponse(transfer.Out),
+		In:  toMoneyFlowResponse(transfer.In),
+	})
+}
+
+func toWalletResponse(status *service.WalletStatus) *dto.WalletResponse {
+	wallet := status.Wallet
+	return &dto.WalletResponse{
+		ID:             wallet.ID.String(),
+		Name:           wallet.Name,
+		Type:           wallet.Type,
+		Currency:       wallet.Currency,
+		OpeningBalance: domain.MajorUnits(wallet.OpeningBalance, wallet.Currency),
+		Inflow:         domain.MajorUnits(status.Inflow, wallet.Currency),
+		Outflow:        domain.MajorUnits(status.Outflow, wallet.Currency),
+		Balance:        domain.MajorUnits(status.Balance, wallet.Currency),
+		MoneyFlowCount: status.Count,
+		Version:        wallet.Version,
+		CreatedAt:      wallet.CreatedAt,
+		UpdatedAt:      wallet.UpdatedAt,
+	}
+}
@@ -0,0 +1,258 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+const defaultWebhookDeliveryPageSize = 20
+
+// WebhookHandler handles outgoing webhook management HTTP requests
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// Create registers a webhook for the current user
+// POST /api/v1/users/me/webhooks
+func (h *WebhookHandler) Create(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	hook, err := h.webhookService.Create(c.Request.Context(), userID, req.URL, req.Events)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := &dto.CreateWebhookResponse{
+		WebhookResponse: toWebhookResponse(hook),
+		Secret:          hook.Secret,
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusCreated, "Webhook created successfully", response)
+}
+
+// List lists the webhooks of the current user
+// GET /api/v1/users/me/webhooks
+func (h *WebhookHandler) List(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	hooks, err := h.webhookService.List(c.Request.Context(), userID)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	response := make([]*dto.WebhookResponse, len(hooks))
+	for i, hook := range hooks {
+		response[i] = toWebhookResponse(hook)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Webhooks retrieved successfully", response)
+}
+
+// Get retrieves a webhook of the current user
+// GET /api/v1/users/me/webhooks/:id
+func (h *WebhookHandler) Get(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	hook, err := h.webhookService.Get(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Webhook retrieved successfully", toWebhookResponse(hook))
+}
+
+// Update replaces the settings of a webhook of the current user
+// PUT /api/v1/users/me/webhooks/:id
+func (h *WebhookHandler) Update(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+
+	// Call service
+	hook, err := h.webhookService.Update(c.Request.Context(), userID, id, *req.Version, service.WebhookInput{
+		URL:    req.URL,
+		Events: req.Events,
+		Active: req.Active,
+	})
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Webhook updated successfully", toWebhookResponse(hook))
+}
+
+// Delete deletes a webhook of the current user
+// DELETE /api/v1/users/me/webhooks/:id
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	if err := h.webhookService.Delete(c.Request.Context(), userID, id); err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Webhook deleted successfully", nil)
+}
+
+// ListDeliveries lists the delivery logs of a webhook of the current user, newest first
+// GET /api/v1/users/me/webhooks/:id/deliveries
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	var query dto.PageQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		middleware.AbortWithValidationError(c, err)
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultWebhookDeliveryPageSize
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), userID, id, query.Limit, query.Offset)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	items := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = toWebhookDeliveryResponse(delivery)
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Webhook deliveries retrieved successfully", &dto.WebhookDeliveryListResponse{
+		Items:  items,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+}
+
+// Ping sends a test event to a webhook of the current user and returns the outcome
+// POST /api/v1/users/me/webhooks/:id/ping
+func (h *WebhookHandler) Ping(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrResourceNotFound)
+		return
+	}
+
+	delivery, err := h.webhookService.Ping(c.Request.Context(), userID, id)
+	if err != nil {
+		middleware.AbortWithError(c, err)
+		return
+	}
+
+	middleware.RespondWithSuccess(c, http.StatusOK, "Ping sent", toWebhookDeliveryResponse(delivery))
+}
+
+func toWebhookResponse(hook *domain.Webhook) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:        hook.ID.String(),
+		URL:       hook.URL,
+		Events:    hook.Events,
+		Active:    hook.Active,
+		Version:   hook.Version,
+		CreatedAt: hook.CreatedAt,
+		UpdatedAt: hook.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery *domain.WebhookDelivery) *dto.WebhookDeliveryResponse {
+	return &dto.WebhookDeliveryResponse{
+		ID:         delivery.ID.String(),
+		EventID:    delivery.EventID.String(),
+		EventType:  delivery.EventType,
+		Attempt:    delivery.Attempt,
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		DurationMs: delivery.Duration.Milliseconds(),
+		Succeeded:  delivery.Succeeded,
+		CreatedAt:  delivery.CreatedAt,
+	}
+}
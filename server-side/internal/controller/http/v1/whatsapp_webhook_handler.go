@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ingunawandra/catetin/internal/controller/dto"
+	"github.com/ingunawandra/catetin/internal/controller/http/middleware"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// maxWebhookBodySize bounds the webhook payloads read into memory
+const maxWebhookBodySize = 1 << 20
+
+// WhatsAppWebhookHandler handles WhatsApp Cloud API webhook requests
+type WhatsAppWebhookHandler struct {
+	chatService *service.ChatService
+	verifyToken string
+	appSecret   string
+}
+
+// NewWhatsAppWebhookHandler creates a new WhatsApp webhook handler. Payload signatures
+// are only checked when appSecret is set.
+func NewWhatsAppWebhookHandler(chatService *service.ChatService, verifyToken, appSecret string) *WhatsAppWebhookHandler {
+	return &WhatsAppWebhookHandler{
+		chatService: chatService,
+		verifyToken: verifyToken,
+		appSecret:   appSecret,
+	}
+}
+
+// Verify answers the subscription challenge Meta sends when the webhook is registered
+// GET /api/v1/webhook/whatsapp
+func (h *WhatsAppWebhookHandler) Verify(c *gin.Context) {
+	if h.verifyToken == "" ||
+		c.Query("hub.mode") != "subscribe" ||
+		c.Query("hub.verify_token") != h.verifyToken {
+		middleware.AbortWithAppError(c, appErrors.ErrForbidden)
+		return
+	}
+
+	c.String(http.StatusOK, c.Query("hub.challenge"))
+}
+
+// Receive queues the messages of a webhook notification for processing
+// POST /api/v1/webhook/whatsapp
+func (h *WhatsAppWebhookHandler) Receive(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrInvalidInput)
+		return
+	}
+
+	if h.appSecret != "" && !whatsapp.VerifySignature(h.appSecret, body, c.GetHeader(whatsapp.SignatureHeader)) {
+		middleware.AbortWithAppError(c, appErrors.ErrUnauthorized)
+		return
+	}
+
+	var payload dto.WhatsAppWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		middleware.AbortWithAppError(c, appErrors.ErrInvalidInput)
+		return
+	}
+
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			for _, message := range change.Value.Messages {
+				msg := service.IncomingMessage{ID: message.ID, From: message.From}
+				switch {
+				case message.Text != nil:
+					msg.Text = message.Text.Body
+				case message.Interactive != nil && message.Interactive.ButtonReply != nil:
+					msg.ButtonID = message.Interactive.ButtonReply.ID
+				}
+
+				if err := h.chatService.Receive(c.Request.Context(), msg); err != nil {
+					// Meta redelivers the notification when it does not get a 200
+					middleware.AbortWithError(c, err)
+					return
+				}
+			}
+		}
+	}
+
+	// Delivery status notifications carry no messages and are acknowledged as is
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,235 @@
+// Package validation turns request binding errors into localized, per-field messages
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	idTranslations "github.com/go-playground/validator/v10/translations/id"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/i18n"
+)
+
+// Language is a supported message language
+type Language = i18n.Language
+
+const (
+	English    = i18n.English
+	Indonesian = i18n.Indonesian
+)
+
+// DefaultLanguage is used when the language has no catalog
+const DefaultLanguage = i18n.DefaultLanguage
+
+// requestField is the key of errors that do not belong to a single field
+const requestField = "request"
+
+// Codes of errors that are not validation rules. Rule failures use the
+// validator tag (e.g. "required", "min") as their code.
+const (
+	CodeInvalidType   = "invalid_type"
+	CodeInvalidNumber = "invalid_number"
+	CodeMalformed     = "malformed"
+)
+
+// FieldError is the error of one field: a stable code clients can branch on,
+// the localized message to show, and the rule parameter, if any
+type FieldError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+}
+
+// translators holds the translator of each supported language once Register has run
+var translators = map[Language]ut.Translator{}
+
+// Register sets up gin's validator: errors name fields by their json (or form) tag
+// instead of the Go field name, and rule messages are translated into every supported
+// language. Call it once before serving requests.
+func Register() error {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+	// currency accepts the codes of the currency catalog, like the domain rule of the
+	// same name
+	if err := engine.RegisterValidation("currency", func(fl validator.FieldLevel) bool {
+		return domain.IsCurrency(fl.Field().String())
+	}); err != nil {
+		return err
+	}
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return ""
+	})
+
+	universal := ut.New(en.New(), en.New(), id.New())
+	registered := make(map[Language]ut.Translator, len(catalogs))
+	for language, catalog := range catalogs {
+		translator, _ := universal.GetTranslator(string(language))
+		if err := catalog.registerDefaults(engine, translator); err != nil {
+			return err
+		}
+		for tag, text := range catalog.rules {
+			if err := engine.RegisterTranslation(tag, translator, addTranslation(tag, text), translateRule); err != nil {
+				return err
+			}
+		}
+		registered[language] = translator
+	}
+
+	translators = registered
+	return nil
+}
+
+// Translate converts a binding error into a map of field name to error in the language.
+// Errors that are not about a single field, such as malformed JSON, are keyed "request".
+func Translate(err error, language Language) map[string]FieldError {
+	catalog, ok := catalogs[language]
+	if !ok {
+		language, catalog = DefaultLanguage, catalogs[DefaultLanguage]
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		translator := translators[language]
+		fields := make(map[string]FieldError, len(validationErrors))
+		for _, fieldError := range validationErrors {
+			field := fieldError.Field()
+			// Report the first failing rule of each field only
+			if _, exists := fields[field]; exists {
+				continue
+			}
+
+			// Translate renders rules without a translation as the raw validator error;
+			// show a generic message instead
+			message := field + " " + catalog.fallback
+			if translator != nil {
+				if translated := fieldError.Translate(translator); translated != fieldError.Error() {
+					message = translated
+				}
+			}
+
+			fields[field] = FieldError{
+				Code:    fieldError.Tag(),
+				Message: message,
+				Param:   fieldError.Param(),
+			}
+		}
+		return fields
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		expected := typeClass(typeError.Type.Kind())
+		return map[string]FieldError{typeError.Field: {
+			Code:    CodeInvalidType,
+			Message: typeError.Field + " " + catalog.types[expected],
+			Param:   expected,
+		}}
+	}
+
+	var numError *strconv.NumError
+	if errors.As(err, &numError) {
+		return map[string]FieldError{requestField: {Code: CodeInvalidNumber, Message: catalog.invalidNumber}}
+	}
+
+	return map[string]FieldError{requestField: {Code: CodeMalformed, Message: catalog.malformed}}
+}
+
+// catalog holds the messages of one language that the validator translations do not
+// provide. Rules are keyed by validator tag; {0} is the field name and {1} the parameter.
+type catalog struct {
+	registerDefaults func(v *validator.Validate, translator ut.Translator) error
+	rules            map[string]string
+	types            map[string]string
+	fallback         string
+	invalidNumber    string
+	malformed        string
+}
+
+var catalogs = map[Language]*catalog{
+	English: {
+		registerDefaults: enTranslations.RegisterDefaultTranslations,
+		rules: map[string]string{
+			"e164":     "{0} must be a phone number in E.164 format",
+			"datetime": "{0} must be a date in the format {1}",
+			"currency": "{0} must be a supported ISO 4217 currency code",
+		},
+		types: map[string]string{
+			"string": "must be text",
+			"number": "must be a number",
+			"bool":   "must be true or false",
+			"list":   "must be a list",
+			"object": "must be an object",
+		},
+		fallback:      "is invalid",
+		invalidNumber: "contains an invalid number",
+		malformed:     "request body is malformed",
+	},
+	Indonesian: {
+		registerDefaults: idTranslations.RegisterDefaultTranslations,
+		rules: map[string]string{
+			"uppercase": "{0} harus menggunakan huruf kapital",
+			"e164":      "{0} harus berupa nomor telepon dengan format E.164",
+			"datetime":  "{0} harus berupa tanggal dengan format {1}",
+			"currency":  "{0} harus berupa kode mata uang ISO 4217 yang didukung",
+		},
+		types: map[string]string{
+			"string": "harus berupa teks",
+			"number": "harus berupa angka",
+			"bool":   "harus bernilai true atau false",
+			"list":   "harus berupa daftar",
+			"object": "harus berupa objek",
+		},
+		fallback:      "tidak valid",
+		invalidNumber: "berisi angka yang tidak valid",
+		malformed:     "format body permintaan tidak valid",
+	},
+}
+
+// addTranslation registers a rule message, replacing the validator's own if it has one
+func addTranslation(tag, text string) validator.RegisterTranslationsFunc {
+	return func(translator ut.Translator) error {
+		return translator.Add(tag, text, true)
+	}
+}
+
+// translateRule renders a rule message registered by addTranslation
+func translateRule(translator ut.Translator, fieldError validator.FieldError) string {
+	message, err := translator.T(fieldError.Tag(), fieldError.Field(), fieldError.Param())
+	if err != nil {
+		return fieldError.Error()
+	}
+	return message
+}
+
+// typeClass names the JSON type expected for a Go kind
+func typeClass(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "number"
+	}
+}
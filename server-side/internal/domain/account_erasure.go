@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Account erasure statuses
+const (
+	ErasurePending   = "pending"
+	ErasureCancelled = "cancelled"
+	ErasureCompleted = "completed"
+)
+
+// AccountErasure is a user's request to erase their personal data. It waits for a grace
+// period, during which the user can cancel it, before the data is anonymized.
+type AccountErasure struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Status      string // ErasurePending, ErasureCancelled, or ErasureCompleted
+	EraseAt     time.Time
+	CancelledAt *time.Time
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewAccountErasure creates a new pending AccountErasure that is carried out after grace
+func NewAccountErasure(userID uuid.UUID, grace time.Duration) *AccountErasure {
+	now := time.Now()
+	return &AccountErasure{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Status:    ErasurePending,
+		EraseAt:   now.Add(grace),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsPending checks if the erasure is neither cancelled nor carried out yet
+func (e *AccountErasure) IsPending() bool {
+	return e.Status == ErasurePending
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Analytics event names
+const (
+	// EventFeatureUsed records that a user successfully used a feature
+	EventFeatureUsed = "feature_used"
+
+	// EventParseFailed records that a chat message could not be parsed into a transaction
+	EventParseFailed = "parse_failed"
+
+	// EventCommandUnrecognized records a chat command that matched no known command
+	EventCommandUnrecognized = "command_unrecognized"
+
+	// EventSignedUp records that a user registered
+	EventSignedUp = "signed_up"
+
+	// EventMoneyFlowRecorded records that a user recorded a money flow, from any client
+	EventMoneyFlowRecorded = "money_flow_recorded"
+)
+
+// AnalyticsEvent is an anonymized product analytics event.
+// It never carries the user ID or message content, only a keyed hash of the user.
+type AnalyticsEvent struct {
+	ID          uuid.UUID
+	Name        string
+	AnonymousID string
+	Properties  map[string]string
+	OccurredAt  time.Time
+}
+
+// NewAnalyticsEvent creates a new AnalyticsEvent. The time is truncated to the
+// hour so events cannot be correlated with request logs.
+func NewAnalyticsEvent(name, anonymousID string, properties map[string]string, occurredAt time.Time) *AnalyticsEvent {
+	return &AnalyticsEvent{
+		ID:          uuid.New(),
+		Name:        name,
+		AnonymousID: anonymousID,
+		Properties:  properties,
+		OccurredAt:  occurredAt.UTC().Truncate(time.Hour),
+	}
+}
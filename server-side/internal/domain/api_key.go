@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key scopes
+const (
+	// ScopeRead allows read-only access to the user's data (implies export)
+	ScopeRead = "read"
+
+	// ScopeWrite allows creating, updating, and deleting data (implies read and export)
+	ScopeWrite = "write"
+
+	// ScopeExport allows access to export endpoints only
+	ScopeExport = "export"
+)
+
+// ValidScopes lists every scope that can be granted to an API key
+var ValidScopes = []string{ScopeRead, ScopeWrite, ScopeExport}
+
+// APIKey represents a user-issued key for programmatic API access
+type APIKey struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	Version    int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time
+}
+
+// NewAPIKey creates a new APIKey entity
+func NewAPIKey(userID uuid.UUID, name, prefix, keyHash string, scopes []string) *APIKey {
+	now := time.Now()
+	return &APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		Version:   0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsValidScope checks if the scope is a known API key scope
+func IsValidScope(scope string) bool {
+	for _, s := range ValidScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope checks if the key grants the given scope, see ScopesAllow
+func (k *APIKey) HasScope(scope string) bool {
+	return ScopesAllow(k.Scopes, scope)
+}
+
+// IsRevoked checks if the API key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Revoke marks the API key as revoked
+func (k *APIKey) Revoke() {
+	now := time.Now()
+	k.RevokedAt = &now
+	k.UpdatedAt = now
+}
+
+// impliedScopes lists the scopes each scope grants besides itself: what a key may read
+// it may also export
+var impliedScopes = map[string][]string{
+	ScopeWrite: {ScopeRead, ScopeExport},
+	ScopeRead:  {ScopeExport},
+}
+
+// ScopesAllow checks if a set of granted scopes allows the required scope, directly or
+// through a scope that implies it
+func ScopesAllow(granted []string, required string) bool {
+	for _, s := range granted {
+		if s == required || slices.Contains(impliedScopes[s], required) {
+			return true
+		}
+	}
+	return false
+}
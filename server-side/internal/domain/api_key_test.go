@@ -0,0 +1,29 @@
+package domain
+
+import "testing"
+
+func TestScopesAllow(t *testing.T) {
+	tests := []struct {
+		granted  []string
+		required string
+		want     bool
+	}{
+		{[]string{ScopeRead}, ScopeRead, true},
+		{[]string{ScopeRead}, ScopeExport, true},
+		{[]string{ScopeRead}, ScopeWrite, false},
+		{[]string{ScopeWrite}, ScopeWrite, true},
+		{[]string{ScopeWrite}, ScopeRead, true},
+		{[]string{ScopeWrite}, ScopeExport, true},
+		{[]string{ScopeExport}, ScopeExport, true},
+		{[]string{ScopeExport}, ScopeRead, false},
+		{[]string{ScopeExport}, ScopeWrite, false},
+		{[]string{ScopeExport, ScopeRead}, ScopeRead, true},
+		{nil, ScopeExport, false},
+		{[]string{"admin"}, ScopeRead, false},
+	}
+	for _, tt := range tests {
+		if got := ScopesAllow(tt.granted, tt.required); got != tt.want {
+			t.Errorf("ScopesAllow(%v, %s) = %v, want %v", tt.granted, tt.required, got, tt.want)
+		}
+	}
+}
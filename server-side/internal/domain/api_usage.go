@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIUsage counts a user's requests to one endpoint on one day
+type APIUsage struct {
+	UserID       uuid.UUID
+	Day          time.Time
+	Method       string
+	Route        string
+	RequestCount int64
+	ErrorCount   int64
+}
+
+// NewAPIUsage creates a new APIUsage for a single request at the given time
+func NewAPIUsage(userID uuid.UUID, method, route string, failed bool, at time.Time) *APIUsage {
+	usage := &APIUsage{
+		UserID:       userID,
+		Day:          at.UTC().Truncate(24 * time.Hour),
+		Method:       method,
+		Route:        route,
+		RequestCount: 1,
+	}
+	if failed {
+		usage.ErrorCount = 1
+	}
+	return usage
+}
+
+// UserAPIUsage is the total usage of one user over a period
+type UserAPIUsage struct {
+	UserID       uuid.UUID
+	RequestCount int64
+	ErrorCount   int64
+}
+
+// RouteAPIUsage is the total usage of one endpoint over a period
+type RouteAPIUsage struct {
+	Method       string
+	Route        string
+	RequestCount int64
+	ErrorCount   int64
+}
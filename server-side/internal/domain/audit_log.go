@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit log actions
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// Audited entity types
+const (
+	AuditEntityMoneyFlow = "money_flow"
+	AuditEntityWallet    = "wallet"
+	AuditEntityBudget    = "budget"
+	AuditEntityGroup     = "group"
+	AuditEntityGoal      = "goal"
+
+	// AuditEntityUser records admins changing the role or disabling the account of a user
+	AuditEntityUser = "user"
+
+	// AuditEntityUserAuth records credentials locked by failed sign-ins and unlocked by
+	// password resets
+	AuditEntityUserAuth = "user_auth"
+
+	// AuditEntityAccountErasure records users requesting and cancelling the erasure of
+	// their personal data, and the erasure being carried out
+	AuditEntityAccountErasure = "account_erasure"
+)
+
+// AuditLog records a change a user made to one of their entities, with the entity as
+// it was before and after the change
+type AuditLog struct {
+	ID         uuid.UUID
+	ActorID    uuid.UUID
+	Action     string // AuditActionCreate, AuditActionUpdate, or AuditActionDelete
+	EntityType string
+	EntityID   uuid.UUID
+
+	// Before and After are JSON snapshots of the entity; Before is nil on create
+	// and After is nil on delete
+	Before json.RawMessage
+	After  json.RawMessage
+
+	CreatedAt time.Time
+}
+
+// NewAuditLog creates a new AuditLog entity of a change from before to after, either
+// of which may be nil
+func NewAuditLog(actorID uuid.UUID, entityType string, entityID uuid.UUID, before, after interface{}) (*AuditLog, error) {
+	log := &AuditLog{
+		ID:         uuid.New(),
+		ActorID:    actorID,
+		Action:     AuditActionUpdate,
+		EntityType: entityType,
+		EntityID:   entityID,
+		CreatedAt:  time.Now(),
+	}
+
+	var err error
+	if before == nil {
+		log.Action = AuditActionCreate
+	} else if log.Before, err = json.Marshal(before); err != nil {
+		return nil, err
+	}
+	if after == nil {
+		log.Action = AuditActionDelete
+	} else if log.After, err = json.Marshal(after); err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// AuditLogFilter narrows an audit log listing; empty fields match everything
+type AuditLogFilter struct {
+	EntityType string
+	EntityID   *uuid.UUID
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification channels. Broadcasts list them in the order admins prefer; other
+// notifications follow the user's NotificationChannels.
+const (
+	// ChannelInApp stores the message as an in-app notification
+	ChannelInApp = "in_app"
+
+	// ChannelWhatsApp sends the message as a WhatsApp template message
+	ChannelWhatsApp = "whatsapp"
+
+	// ChannelEmail sends the message by email
+	ChannelEmail = "email"
+
+	// ChannelTelegram sends the message to the user's chat with the Telegram bot
+	ChannelTelegram = "telegram"
+
+	// ChannelPush sends the message as a push notification to the user's devices
+	ChannelPush = "push"
+)
+
+// NotificationChannels lists every notification channel, in the default order of preference
+var NotificationChannels = []string{ChannelPush, ChannelWhatsApp, ChannelEmail, ChannelTelegram, ChannelInApp}
+
+// Broadcast statuses
+const (
+	BroadcastStatusQueued    = "queued"
+	BroadcastStatusSending   = "sending"
+	BroadcastStatusCompleted = "completed"
+)
+
+// Broadcast delivery statuses
+const (
+	// DeliveryStatusPending is waiting to be picked up by the dispatcher
+	DeliveryStatusPending = "pending"
+
+	// DeliveryStatusSending has been claimed by a dispatcher
+	DeliveryStatusSending = "sending"
+
+	// DeliveryStatusSent was delivered through one of the broadcast channels
+	DeliveryStatusSent = "sent"
+
+	// DeliveryStatusFailed could not be delivered after the maximum attempts
+	DeliveryStatusFailed = "failed"
+
+	// DeliveryStatusSkipped has no channel the recipient can be reached on
+	DeliveryStatusSkipped = "skipped"
+)
+
+// BroadcastAudience filters the users receiving a broadcast.
+// An empty audience targets every user.
+type BroadcastAudience struct {
+	UserIDs          []uuid.UUID `json:"user_ids,omitempty"`
+	Roles            []string    `json:"roles,omitempty"`
+	RegisteredAfter  *time.Time  `json:"registered_after,omitempty"`
+	RegisteredBefore *time.Time  `json:"registered_before,omitempty"`
+}
+
+// Broadcast represents an announcement sent by an admin to many users
+type Broadcast struct {
+	ID             uuid.UUID
+	CreatedBy      uuid.UUID
+	Title          string
+	Body           string
+	Channels       []string
+	Audience       BroadcastAudience
+	Status         string
+	RecipientCount int
+	CompletedAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewBroadcast creates a new Broadcast entity
+func NewBroadcast(createdBy uuid.UUID, title, body string, channels []string, audience BroadcastAudience) *Broadcast {
+	now := time.Now()
+	return &Broadcast{
+		ID:        uuid.New(),
+		CreatedBy: createdBy,
+		Title:     title,
+		Body:      body,
+		Channels:  channels,
+		Audience:  audience,
+		Status:    BroadcastStatusQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// BroadcastDelivery tracks the delivery of a broadcast to a single recipient
+type BroadcastDelivery struct {
+	ID          uuid.UUID
+	BroadcastID uuid.UUID
+	UserID      uuid.UUID
+	Channel     *string
+	Status      string
+	Attempts    int
+	LastError   *string
+	SentAt      *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewBroadcastDelivery creates a pending delivery for a recipient
+func NewBroadcastDelivery(broadcastID, userID uuid.UUID) *BroadcastDelivery {
+	now := time.Now()
+	return &BroadcastDelivery{
+		ID:          uuid.New(),
+		BroadcastID: broadcastID,
+		UserID:      userID,
+		Status:      DeliveryStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// MarkSent records a successful delivery through the given channel
+func (d *BroadcastDelivery) MarkSent(channel string) {
+	now := time.Now()
+	d.Channel = &channel
+	d.Status = DeliveryStatusSent
+	d.LastError = nil
+	d.SentAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkSkipped records that no channel could reach the recipient
+func (d *BroadcastDelivery) MarkSkipped(reason string) {
+	d.Status = DeliveryStatusSkipped
+	d.LastError = &reason
+	d.UpdatedAt = time.Now()
+}
+
+// MarkAttemptFailed records a failed attempt. The delivery goes back to pending
+// for a retry until maxAttempts is reached, after which it is marked failed.
+func (d *BroadcastDelivery) MarkAttemptFailed(reason string, maxAttempts int) {
+	d.LastError = &reason
+	d.UpdatedAt = time.Now()
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryStatusFailed
+		return
+	}
+	d.Status = DeliveryStatusPending
+}
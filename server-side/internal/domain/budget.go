@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Budget is a monthly spending cap on a category. Only money flows in the
+// budget's currency count towards it.
+type Budget struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Category string
+	Amount   int64 // in minor units of Currency
+	Currency string
+
+	// Hard budgets block money flows that would exceed the cap unless overridden
+	Hard bool
+
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewBudget creates a new Budget entity of an amount in major units of the currency.
+// An invalid field returns a *ValidationError.
+func NewBudget(userID uuid.UUID, category string, amount float64, currency string, hard bool) (*Budget, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	now := time.Now()
+	budget := &Budget{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Category:  strings.TrimSpace(category),
+		Hard:      hard,
+		Version:   0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := budget.SetAmount(amount, currency); err != nil {
+		return nil, err
+	}
+	if err := budget.Validate(); err != nil {
+		return nil, err
+	}
+	return budget, nil
+}
+
+// Validate checks the business rules of a budget, returning a *ValidationError naming
+// the fields that break them
+func (b *Budget) Validate() error {
+	var v validation
+	v.required("category", b.Category)
+	v.maxLength("category", b.Category, MaxCategoryLength)
+	v.positive("amount", b.Amount)
+	v.currency("currency", b.Currency)
+	return v.err()
+}
+
+// Applies checks if a money flow in the currency counts towards the budget
+func (b *Budget) Applies(currency string) bool {
+	return b.Currency == currency
+}
+
+// Exceeded checks if adding amount to what was already spent goes over the cap.
+// Amounts are in minor units of the budget's currency.
+func (b *Budget) Exceeded(spent, amount int64) bool {
+	return spent+amount > b.Amount
+}
+
+// SetAmount sets the cap and currency, with the cap in major units of the currency. An
+// amount that does not fit the currency returns a *ValidationError; Validate checks the
+// rest.
+func (b *Budget) SetAmount(amount float64, currency string) error {
+	minor, err := MinorUnits(amount, currency)
+	if err != nil {
+		return invalidAmount("amount", currency, err)
+	}
+	b.Amount = minor
+	b.Currency = currency
+	return nil
+}
+
+// IncrementVersion increments the version for optimistic locking
+func (b *Budget) IncrementVersion() {
+	b.Version++
+	b.UpdatedAt = time.Now()
+}
+
+// BudgetPeriod returns the calendar month containing t in the user's time zone as [start, end)
+func BudgetPeriod(t time.Time, settings *UserSettings) (time.Time, time.Time) {
+	return settings.MonthRange(t)
+}
+
+// BudgetOverride records a money flow the user confirmed despite exceeding a hard budget
+type BudgetOverride struct {
+	ID          uuid.UUID
+	BudgetID    uuid.UUID
+	UserID      uuid.UUID
+	MoneyFlowID uuid.UUID
+
+	// Amounts are in minor units of Currency, the budget's currency at the time
+	Currency  string
+	Amount    int64
+	Spent     int64 // total in the period before the money flow
+	Cap       int64 // budget amount at the time of the override
+	CreatedAt time.Time
+}
+
+// NewBudgetOverride creates a new BudgetOverride entity
+func NewBudgetOverride(budget *Budget, moneyFlow *MoneyFlow, spent int64) *BudgetOverride {
+	return &BudgetOverride{
+		ID:          uuid.New(),
+		BudgetID:    budget.ID,
+		UserID:      budget.UserID,
+		MoneyFlowID: moneyFlow.ID,
+		Currency:    budget.Currency,
+		Amount:      moneyFlow.Amount,
+		Spent:       spent,
+		Cap:         budget.Amount,
+		CreatedAt:   time.Now(),
+	}
+}
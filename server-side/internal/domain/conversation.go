@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Conversation flows
+const (
+	// FlowExpenseConfirmation asks the user to confirm an expense parsed from a chat message
+	FlowExpenseConfirmation = "expense_confirmation"
+
+	// FlowBudgetSetup walks the user through setting monthly category budgets
+	FlowBudgetSetup = "budget_setup"
+)
+
+// Conversation statuses
+const (
+	ConversationActive    = "active"
+	ConversationCompleted = "completed"
+	ConversationCancelled = "cancelled"
+	ConversationExpired   = "expired"
+)
+
+// ErrConversationClosed is returned when moving a conversation that is no longer active
+var ErrConversationClosed = errors.New("conversation is no longer active")
+
+// Conversation is the state of a multi-turn chat flow with a user. Only active
+// conversations move; completed, cancelled, and expired are final.
+type Conversation struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Flow   string
+	Step   string
+	Status string
+
+	// Data holds flow-specific state as JSON
+	Data json.RawMessage
+
+	// SourceMessageID is the chat message that started the conversation
+	SourceMessageID *string
+
+	ExpiresAt time.Time
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewConversation starts an active conversation that expires after ttl
+func NewConversation(userID uuid.UUID, flow, step string, data interface{}, ttl time.Duration) (*Conversation, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Conversation{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Flow:      flow,
+		Step:      step,
+		Status:    ConversationActive,
+		Data:      encoded,
+		ExpiresAt: now.Add(ttl),
+		Version:   0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsActive checks if the conversation can still move at the given time
+func (c *Conversation) IsActive(now time.Time) bool {
+	return c.Status == ConversationActive && now.Before(c.ExpiresAt)
+}
+
+// Decode unmarshals the flow data into v
+func (c *Conversation) Decode(v interface{}) error {
+	return json.Unmarshal(c.Data, v)
+}
+
+// Advance moves the conversation to the next step of its flow with new data.
+// A positive ttl gives the user that long from now to answer the new step.
+func (c *Conversation) Advance(step string, data interface{}, ttl time.Duration) error {
+	if c.Status != ConversationActive {
+		return ErrConversationClosed
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.Step = step
+	c.Data = encoded
+	if ttl > 0 {
+		c.ExpiresAt = time.Now().Add(ttl)
+	}
+	c.touch()
+	return nil
+}
+
+// Complete ends the conversation successfully with its final data
+func (c *Conversation) Complete(data interface{}) error {
+	if c.Status != ConversationActive {
+		return ErrConversationClosed
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.Data = encoded
+	return c.close(ConversationCompleted)
+}
+
+// Cancel ends the conversation at the user's request or because a new one started
+func (c *Conversation) Cancel() error {
+	return c.close(ConversationCancelled)
+}
+
+// Expire ends a conversation the user did not finish in time
+func (c *Conversation) Expire() error {
+	return c.close(ConversationExpired)
+}
+
+func (c *Conversation) close(status string) error {
+	if c.Status != ConversationActive {
+		return ErrConversationClosed
+	}
+	c.Status = status
+	c.touch()
+	return nil
+}
+
+func (c *Conversation) touch() {
+	c.Version++
+	c.UpdatedAt = time.Now()
+}
+
+// PendingExpense is the data of an expense_confirmation conversation
+type PendingExpense struct {
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency"`
+	Category    *string `json:"category,omitempty"`
+	Description *string `json:"description,omitempty"`
+
+	// MoneyFlowID is set once the user confirms and the money flow is recorded
+	MoneyFlowID *uuid.UUID `json:"money_flow_id,omitempty"`
+}
+
+// BudgetSetup is the data of a budget_setup conversation
+type BudgetSetup struct {
+	// Category and Amount of the budget being set up
+	Category string  `json:"category,omitempty"`
+	Amount   float64 `json:"amount,omitempty"`
+
+	// Saved lists the budgets set so far, for the closing summary
+	Saved []BudgetSetupItem `json:"saved,omitempty"`
+
+	// LastMessageID is the latest chat message applied, so redelivered messages are skipped
+	LastMessageID string `json:"last_message_id,omitempty"`
+}
+
+// BudgetSetupItem is a budget set during a budget_setup conversation
+type BudgetSetupItem struct {
+	Category string  `json:"category"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+	Hard     bool    `json:"hard"`
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Device platforms
+const (
+	DevicePlatformAndroid = "android"
+	DevicePlatformIOS     = "ios"
+	DevicePlatformWeb     = "web"
+)
+
+// Device is an installation of the app that receives push notifications. The token
+// is issued by Firebase Cloud Messaging and identifies the installation.
+type Device struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Token      string
+	Platform   string
+	Name       *string // e.g. the phone model, to tell devices apart
+	LastSeenAt time.Time
+	CreatedAt  time.Time
+}
+
+// NewDevice creates a new Device entity
+func NewDevice(userID uuid.UUID, token, platform string, name *string) *Device {
+	now := time.Now()
+	return &Device{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Token:      token,
+		Platform:   platform,
+		Name:       name,
+		LastSeenAt: now,
+		CreatedAt:  now,
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Digest frequencies, how often a user receives a summary of their spending
+const (
+	DigestOff     = "off"
+	DigestWeekly  = "weekly"
+	DigestMonthly = "monthly"
+)
+
+// DefaultDigestFrequency is the digest frequency of users who have not changed it
+const DefaultDigestFrequency = DigestWeekly
+
+// DigestFrequencies lists every digest frequency
+var DigestFrequencies = []string{DigestOff, DigestWeekly, DigestMonthly}
+
+// DigestPeriod returns the period a weekly or monthly digest sent at t covers, the
+// last week or month that ended by t in the user's calendar, as [start, end)
+func DigestPeriod(frequency string, t time.Time, settings *UserSettings) (time.Time, time.Time) {
+	if frequency == DigestMonthly {
+		end, _ := settings.MonthRange(t)
+		start, _ := settings.MonthRange(end.AddDate(0, 0, -1))
+		return start, end
+	}
+	end, _ := settings.WeekRange(t)
+	start, _ := settings.WeekRange(end.AddDate(0, 0, -1))
+	return start, end
+}
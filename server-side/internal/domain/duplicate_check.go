@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Duplicate checks, what happens when a new money flow looks like one already recorded
+const (
+	DuplicateCheckOff   = "off"   // record it without checking
+	DuplicateCheckWarn  = "warn"  // record it with a warning naming the likely duplicate
+	DuplicateCheckBlock = "block" // refuse it unless the user confirms it is not a duplicate
+)
+
+// DefaultDuplicateCheck is the duplicate check of users who have not changed it
+const DefaultDuplicateCheck = DuplicateCheckWarn
+
+// DuplicateChecks lists every duplicate check
+var DuplicateChecks = []string{DuplicateCheckOff, DuplicateCheckWarn, DuplicateCheckBlock}
+
+// duplicateDescriptionSimilarity is the share of words two descriptions must have in
+// common to be similar
+const duplicateDescriptionSimilarity = 0.5
+
+// LikelyDuplicateOf reports whether the money flow looks like other entered twice: an
+// expense of the same amount and currency on the same day in location, with a similar
+// description or none on either
+func (mf *MoneyFlow) LikelyDuplicateOf(other *MoneyFlow, location *time.Location) bool {
+	if mf.Kind != MoneyFlowKindExpense || other.Kind != MoneyFlowKindExpense || mf.ID == other.ID {
+		return false
+	}
+	if mf.Amount != other.Amount || mf.Currency != other.Currency {
+		return false
+	}
+
+	year, month, day := mf.CreatedAt.In(location).Date()
+	otherYear, otherMonth, otherDay := other.CreatedAt.In(location).Date()
+	if year != otherYear || month != otherMonth || day != otherDay {
+		return false
+	}
+
+	return similarDescriptions(descriptionWords(mf.Description), descriptionWords(other.Description))
+}
+
+// descriptionWords returns the distinct lowercased words of a description
+func descriptionWords(description *string) map[string]bool {
+	words := make(map[string]bool)
+	if description != nil {
+		for _, word := range strings.Fields(strings.ToLower(*description)) {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// similarDescriptions reports whether two descriptions, as sets of words, share at least
+// duplicateDescriptionSimilarity of their words. Two empty descriptions are similar; an
+// empty and a written one are not.
+func similarDescriptions(a, b map[string]bool) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared)/float64(len(a)+len(b)-shared) >= duplicateDescriptionSimilarity
+}
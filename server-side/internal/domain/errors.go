@@ -17,4 +17,7 @@ var (
 
 	// ErrDuplicatePhoneNumber indicates a phone number already exists
 	ErrDuplicatePhoneNumber = errors.New("phone number already exists")
+
+	// ErrDuplicateCredential indicates a credential is already registered with the provider
+	ErrDuplicateCredential = errors.New("credential already exists")
 )
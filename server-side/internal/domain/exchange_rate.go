@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// ExchangeRate is the price of one unit of Base in Currency on a day, as published by
+// a rate provider
+type ExchangeRate struct {
+	Base      string
+	Currency  string
+	Date      time.Time
+	Rate      float64
+	Source    string
+	FetchedAt time.Time
+}
+
+// ExchangeRates are the rates of one day against a common base currency, used to
+// convert between any two of their currencies
+type ExchangeRates struct {
+	Base  string
+	Date  time.Time
+	rates map[string]float64
+}
+
+// NewExchangeRates collects the rates of one base currency
+func NewExchangeRates(base string, date time.Time, rates []*ExchangeRate) *ExchangeRates {
+	table := &ExchangeRates{Base: base, Date: date, rates: map[string]float64{base: 1}}
+	for _, rate := range rates {
+		if rate.Base == base && rate.Rate > 0 {
+			table.rates[rate.Currency] = rate.Rate
+		}
+	}
+	return table
+}
+
+// Rate returns the price of one unit of from in to. It reports false when either
+// currency has no rate.
+func (r *ExchangeRates) Rate(from, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	if r == nil {
+		return 0, false
+	}
+	fromRate, ok := r.rates[from]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r.rates[to]
+	if !ok {
+		return 0, false
+	}
+	return toRate / fromRate, true
+}
+
+// Convert converts an amount between currencies. It reports false when either
+// currency has no rate.
+func (r *ExchangeRates) Convert(amount float64, from, to string) (float64, bool) {
+	rate, ok := r.Rate(from, to)
+	if !ok {
+		return 0, false
+	}
+	return amount * rate, true
+}
+
+// Currencies returns the currencies that can be converted, including the base
+func (r *ExchangeRates) Currencies() []string {
+	if r == nil {
+		return nil
+	}
+	currencies := make([]string, 0, len(r.rates))
+	for currency := range r.rates {
+		currencies = append(currencies, currency)
+	}
+	return currencies
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Feedback kinds
+const (
+	FeedbackKindFeedback = "feedback"
+	FeedbackKindBug      = "bug"
+)
+
+// Feedback sources
+const (
+	FeedbackSourceAPI  = "api"  // POST /api/v1/feedback
+	FeedbackSourceChat = "chat" // the "lapor" chat command
+)
+
+// Feedback is a comment or bug report sent by a user, with the context needed to
+// follow it up
+type Feedback struct {
+	ID      uuid.UUID
+	UserID  uuid.UUID
+	Kind    string // FeedbackKindFeedback or FeedbackKindBug
+	Source  string // FeedbackSourceAPI or FeedbackSourceChat
+	Message string
+
+	// RequestID identifies what carried the feedback: the request ID of the API call,
+	// or the ID of the chat message
+	RequestID string
+
+	// LastRequestID is the request ID of the call the user reports on, as sent by the client
+	LastRequestID *string
+	AppVersion    *string
+	UserAgent     *string
+
+	// ForwardedAt is when the feedback was posted to the team's Slack channel
+	ForwardedAt *time.Time
+	CreatedAt   time.Time
+}
+
+// NewFeedback creates a new Feedback entity
+func NewFeedback(userID uuid.UUID, kind, source, message, requestID string) *Feedback {
+	return &Feedback{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Kind:      kind,
+		Source:    source,
+		Message:   message,
+		RequestID: requestID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// FeedbackFilter narrows a feedback listing; empty fields match everything
+type FeedbackFilter struct {
+	Kind   string
+	Source string
+	UserID uuid.UUID
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Goal is an amount a user saves toward, such as a holiday or an emergency fund,
+// optionally by a deadline. A goal is active until the user archives it.
+type Goal struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Name     string
+	Currency string
+
+	// TargetAmount and SavedAmount are in minor units of Currency
+	TargetAmount int64
+	SavedAmount  int64
+
+	// Deadline is the date the user wants to reach the target by, if any
+	Deadline *time.Time
+
+	ArchivedAt *time.Time
+	Version    int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewGoal creates a new active Goal entity of a target in major units of the currency.
+// An invalid field returns a *ValidationError.
+func NewGoal(userID uuid.UUID, name, currency string, target float64, deadline *time.Time) (*Goal, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	now := time.Now()
+	goal := &Goal{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Currency:  currency,
+		Version:   0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := goal.Set(name, target, 0, deadline); err != nil {
+		return nil, err
+	}
+	if err := goal.Validate(); err != nil {
+		return nil, err
+	}
+	return goal, nil
+}
+
+// Validate checks the business rules of a goal, returning a *ValidationError naming
+// the fields that break them
+func (g *Goal) Validate() error {
+	var v validation
+	v.required("name", g.Name)
+	v.maxLength("name", g.Name, MaxGoalNameLength)
+	v.positive("target_amount", g.TargetAmount)
+	v.check(g.SavedAmount >= 0, "saved_amount", "gte", "0", "saved_amount must be 0 or greater")
+	v.currency("currency", g.Currency)
+	return v.err()
+}
+
+// Set sets the name, the target and saved amounts in major units of the goal's
+// currency, and the deadline, truncated to its date. An amount that does not fit the
+// currency returns a *ValidationError; Validate checks the rest.
+func (g *Goal) Set(name string, target, saved float64, deadline *time.Time) error {
+	targetMinor, err := MinorUnits(target, g.Currency)
+	if err != nil {
+		return invalidAmount("target_amount", g.Currency, err)
+	}
+	savedMinor, err := MinorUnits(saved, g.Currency)
+	if err != nil {
+		return invalidAmount("saved_amount", g.Currency, err)
+	}
+	if deadline != nil {
+		date := time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.UTC)
+		deadline = &date
+	}
+
+	g.Name = strings.TrimSpace(name)
+	g.TargetAmount = targetMinor
+	g.SavedAmount = savedMinor
+	g.Deadline = deadline
+	g.UpdatedAt = time.Now()
+	return nil
+}
+
+// Archive moves the goal out of or back into the active goals
+func (g *Goal) Archive(archived bool) {
+	now := time.Now()
+	switch {
+	case archived && g.ArchivedAt == nil:
+		g.ArchivedAt = &now
+	case !archived:
+		g.ArchivedAt = nil
+	}
+	g.UpdatedAt = now
+}
+
+// Active checks if the goal is not archived
+func (g *Goal) Active() bool {
+	return g.ArchivedAt == nil
+}
+
+// Reached checks if the saved amount has reached the target
+func (g *Goal) Reached() bool {
+	return g.SavedAmount >= g.TargetAmount
+}
+
+// IncrementVersion increments the version for optimistic locking
+func (g *Goal) IncrementVersion() {
+	g.Version++
+	g.UpdatedAt = time.Now()
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Group member roles
+const (
+	// GroupRoleOwner created the group; the owner can delete it and change roles
+	GroupRoleOwner = "owner"
+
+	// GroupRoleAdmin can rename the group, invite members, and remove members
+	GroupRoleAdmin = "admin"
+
+	// GroupRoleMember records money flows in the group and sees its ledger
+	GroupRoleMember = "member"
+)
+
+// Group is a shared ledger, such as a household, whose members record money flows together
+type Group struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedBy uuid.UUID
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GroupMember is a user's membership of a group
+type GroupMember struct {
+	GroupID   uuid.UUID
+	UserID    uuid.UUID
+	Role      string
+	CreatedAt time.Time
+
+	// FullName is the member's name, filled in when listing members
+	FullName string
+}
+
+// GroupInvitation lets whoever holds its token join a group with Role. Only the hash
+// of the token is kept.
+type GroupInvitation struct {
+	ID         uuid.UUID
+	GroupID    uuid.UUID
+	InvitedBy  uuid.UUID
+	Role       string
+	TokenHash  string
+	ExpiresAt  time.Time
+	AcceptedBy *uuid.UUID
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+}
+
+// NewGroup creates a new Group entity
+func NewGroup(name string, createdBy uuid.UUID) (*Group, error) {
+	now := time.Now()
+	group := &Group{
+		ID:        uuid.New(),
+		CreatedBy: createdBy,
+		Version:   0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := group.Rename(name); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// Rename sets the name of the group
+func (g *Group) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	g.Name = name
+	g.UpdatedAt = time.Now()
+	return nil
+}
+
+// IncrementVersion increments the version for optimistic locking
+func (g *Group) IncrementVersion() {
+	g.Version++
+	g.UpdatedAt = time.Now()
+}
+
+// NewGroupMember creates a membership of a user in a group
+func NewGroupMember(groupID, userID uuid.UUID, role string) *GroupMember {
+	return &GroupMember{
+		GroupID:   groupID,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: time.Now(),
+	}
+}
+
+// CanManage checks if the member can rename the group and invite or remove members
+func (m *GroupMember) CanManage() bool {
+	return m.Role == GroupRoleOwner || m.Role == GroupRoleAdmin
+}
+
+// IsOwner checks if the member owns the group
+func (m *GroupMember) IsOwner() bool {
+	return m.Role == GroupRoleOwner
+}
+
+// NewGroupInvitation creates a new GroupInvitation entity for the token hash that
+// expires after ttl. Invitations can grant the admin or member role.
+func NewGroupInvitation(groupID, invitedBy uuid.UUID, role, tokenHash string, ttl time.Duration) (*GroupInvitation, error) {
+	if role == "" {
+		role = GroupRoleMember
+	}
+	if role != GroupRoleAdmin && role != GroupRoleMember {
+		return nil, errors.New("role must be admin or member")
+	}
+
+	now := time.Now()
+	return &GroupInvitation{
+		ID:        uuid.New(),
+		GroupID:   groupID,
+		InvitedBy: invitedBy,
+		Role:      role,
+		TokenHash: tokenHash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, nil
+}
+
+// IsAccepted checks if a user joined the group with the invitation
+func (i *GroupInvitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
+
+// IsExpired checks if the invitation can no longer be accepted at the given time
+func (i *GroupInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation lets a user created by an admin, e.g. in a bulk import, set a password
+// and sign in with their email. The token is sent on Channel and only its hash is kept.
+type Invitation struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	InvitedBy uuid.UUID
+	Email     string
+	Channel   string // ChannelWhatsApp or ChannelEmail
+
+	// TokenHash is empty until the invitation is sent; sending again replaces it
+	TokenHash string
+
+	SentAt     *time.Time
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewInvitation creates a new Invitation entity that expires after ttl
+func NewInvitation(userID, invitedBy uuid.UUID, email, channel string, ttl time.Duration) *Invitation {
+	now := time.Now()
+	return &Invitation{
+		ID:        uuid.New(),
+		UserID:    userID,
+		InvitedBy: invitedBy,
+		Email:     email,
+		Channel:   channel,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsAccepted checks if the invitee has set their password
+func (i *Invitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
+
+// IsExpired checks if the invitation can no longer be accepted at the given time
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "math"
+
+// Limits of coordinates, in WGS 84 degrees
+const (
+	MaxLatitude  = 90
+	MaxLongitude = 180
+)
+
+// earthRadius is the mean radius of the Earth in meters
+const earthRadius = 6371008.8
+
+// metersPerDegree is the length of a degree of latitude, and of longitude on the equator
+const metersPerDegree = earthRadius * math.Pi / 180
+
+// PlaceTotal sums the expenses of a user at one point and place name in one currency,
+// in minor units of Currency. PlaceName is nil for money flows located without one.
+type PlaceTotal struct {
+	PlaceName *string
+	Latitude  float64
+	Longitude float64
+	Currency  string
+	Total     int64
+	Count     int64
+}
+
+// BoundingBox is the area between two latitudes and two longitudes, inclusive
+type BoundingBox struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// BoundingBoxAround returns a box holding every point within radius meters of a point.
+// Its longitudes span the whole globe when the circle reaches a pole or crosses the
+// antimeridian, so the box never needs to wrap around.
+func BoundingBoxAround(latitude, longitude, radius float64) BoundingBox {
+	latitudeDelta := radius / metersPerDegree
+	box := BoundingBox{
+		MinLatitude:  math.Max(latitude-latitudeDelta, -MaxLatitude),
+		MaxLatitude:  math.Min(latitude+latitudeDelta, MaxLatitude),
+		MinLongitude: -MaxLongitude,
+		MaxLongitude: MaxLongitude,
+	}
+	if box.MinLatitude == -MaxLatitude || box.MaxLatitude == MaxLatitude {
+		return box
+	}
+
+	// The circle is widest at the latitude farthest from the equator it reaches
+	widest := math.Max(math.Abs(box.MinLatitude), math.Abs(box.MaxLatitude))
+	longitudeDelta := radius / (metersPerDegree * math.Cos(widest*math.Pi/180))
+	if longitude-longitudeDelta >= -MaxLongitude && longitude+longitudeDelta <= MaxLongitude {
+		box.MinLongitude = longitude - longitudeDelta
+		box.MaxLongitude = longitude + longitudeDelta
+	}
+	return box
+}
+
+// Distance returns the great-circle distance in meters between two points, by the
+// haversine formula
+func Distance(latitude1, longitude1, latitude2, longitude2 float64) float64 {
+	phi1 := latitude1 * math.Pi / 180
+	phi2 := latitude2 * math.Pi / 180
+	deltaPhi := (latitude2 - latitude1) * math.Pi / 180
+	deltaLambda := (longitude2 - longitude1) * math.Pi / 180
+
+	a := math.Sin(deltaPhi/2)*math.Sin(deltaPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(deltaLambda/2)*math.Sin(deltaLambda/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Merchant is a shop or payee that money flows are paid to, shared by all users under
+// one normalized name, e.g. "Indomaret" for "INDOMARET TBK 0123"
+type Merchant struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MerchantRule maps the payee names containing Pattern to a merchant. Rules are
+// maintained by admins for names the automatic cleanup gets wrong, such as brands
+// written in several ways.
+type MerchantRule struct {
+	ID         uuid.UUID
+	Pattern    string // lowercased words, matched as whole words
+	MerchantID uuid.UUID
+	Merchant   *Merchant // loaded with the rule
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// MerchantTotal sums the expenses of a user at one merchant in one currency, in minor
+// units of Currency
+type MerchantTotal struct {
+	MerchantID uuid.UUID
+	Currency   string
+	Total      int64
+	Count      int64
+}
+
+// merchantNoise are the words dropped from payee names by CleanMerchantName: legal
+// entity forms that banks and card terminals print after the brand
+var merchantNoise = []string{"pt", "tbk", "cv", "ud", "persero", "ltd", "llc", "inc", "corp", "co"}
+
+// NewMerchant creates a new Merchant entity. An invalid name returns a *ValidationError.
+func NewMerchant(name string) (*Merchant, error) {
+	now := time.Now()
+	merchant := &Merchant{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := merchant.Rename(name); err != nil {
+		return nil, err
+	}
+	return merchant, nil
+}
+
+// Rename changes the name of the merchant, trimmed. An invalid name returns a
+// *ValidationError.
+func (m *Merchant) Rename(name string) error {
+	name = strings.Join(strings.Fields(name), " ")
+
+	var v validation
+	v.required("name", name)
+	v.maxLength("name", name, MaxMerchantLength)
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	m.Name = name
+	m.UpdatedAt = time.Now()
+	return nil
+}
+
+// NewMerchantRule creates a rule mapping the payee names containing the words of pattern
+// to a merchant. An invalid pattern returns a *ValidationError.
+func NewMerchantRule(pattern string, merchant *Merchant) (*MerchantRule, error) {
+	now := time.Now()
+	rule := &MerchantRule{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := rule.Change(pattern, merchant); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Change replaces the pattern and merchant of the rule. An invalid pattern returns a
+// *ValidationError.
+func (r *MerchantRule) Change(pattern string, merchant *Merchant) error {
+	pattern = merchantKey(pattern)
+
+	var v validation
+	v.required("pattern", pattern)
+	v.maxLength("pattern", pattern, MaxMerchantLength)
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	r.Pattern = pattern
+	r.MerchantID = merchant.ID
+	r.Merchant = merchant
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// MatchMerchantRule returns the rule whose pattern a payee name contains as whole words,
+// the longest when several do, or nil if none does
+func MatchMerchantRule(payee string, rules []*MerchantRule) *MerchantRule {
+	key := " " + merchantKey(payee) + " "
+
+	var match *MerchantRule
+	for _, rule := range rules {
+		if strings.Contains(key, " "+rule.Pattern+" ") && (match == nil || len(rule.Pattern) > len(match.Pattern)) {
+			match = rule
+		}
+	}
+	return match
+}
+
+// CleanMerchantName derives a merchant name from a payee name as printed on statements
+// and receipts: store numbers and legal entity forms are dropped and the rest is title
+// cased, so "INDOMARET TBK 0123" becomes "Indomaret". It returns "" when nothing is left.
+func CleanMerchantName(payee string) string {
+	var words []string
+	for _, word := range strings.Fields(merchantKey(payee)) {
+		if slices.Contains(merchantNoise, word) || strings.ContainsFunc(word, unicode.IsDigit) {
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words = append(words, string(runes))
+	}
+
+	name := strings.Join(words, " ")
+	if len([]rune(name)) > MaxMerchantLength {
+		name = strings.TrimSpace(string([]rune(name)[:MaxMerchantLength]))
+	}
+	return name
+}
+
+// merchantKey lowercases a payee name and keeps its words of letters and digits
+func merchantKey(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxCurrencyExponent is the largest number of minor unit digits of any currency, and
+// the scale of the amount columns in the database
+const MaxCurrencyExponent = 4
+
+// Errors of amounts that do not fit a currency
+var (
+	ErrAmountPrecision = errors.New("amount has more decimal places than the currency allows")
+	ErrAmountOverflow  = errors.New("amount is too large")
+)
+
+// Money is an amount in the minor units of its currency, such as cents, so that sums
+// and comparisons are exact. Amounts cross the API as decimal numbers in major units.
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+// NewMoney converts an amount in major units, such as 12.5 dollars, to Money. Amounts
+// with more decimal places than the currency has are rejected rather than rounded.
+func NewMoney(amount float64, currency string) (Money, error) {
+	minor, err := MinorUnits(amount, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+// MinorUnits converts an amount in major units to minor units of the currency
+func MinorUnits(amount float64, currency string) (int64, error) {
+	scaled := amount * math.Pow10(CurrencyExponent(currency))
+	rounded := math.Round(scaled)
+	if math.IsNaN(rounded) || math.Abs(rounded) >= 1<<62 {
+		return 0, ErrAmountOverflow
+	}
+	// Decimal fractions are inexact in binary; allow for the representation error only
+	if math.Abs(scaled-rounded) > 1e-6*math.Max(1, math.Abs(scaled)) {
+		return 0, ErrAmountPrecision
+	}
+	return int64(rounded), nil
+}
+
+// ParseMoney reads a decimal string in major units, such as "12.50" or a numeric column
+// of the database, without going through floating point. Digits past the currency's
+// exponent must be zeros.
+func ParseMoney(value, currency string) (Money, error) {
+	exponent := CurrencyExponent(currency)
+
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole+fraction == "" || strings.Trim(whole+fraction, "0123456789") != "" {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > exponent {
+		return Money{}, ErrAmountPrecision
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	digits := strings.TrimLeft(whole+fraction, "0")
+	if digits == "" {
+		return Money{Currency: currency}, nil
+	}
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return Money{}, ErrAmountOverflow
+		}
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+// Float64 returns the amount in major units, for JSON and display. It is exact up to
+// about 2^53 minor units.
+func (m Money) Float64() float64 {
+	return float64(m.Minor) / math.Pow10(CurrencyExponent(m.Currency))
+}
+
+// String returns the amount in major units with the currency's decimal places, e.g.
+// "25000.50", as stored in the database
+func (m Money) String() string {
+	exponent := CurrencyExponent(m.Currency)
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	digits := strconv.FormatInt(minor, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// Add returns the sum of two amounts in the same currency
+func (m Money) Add(other Money) Money {
+	return Money{Minor: m.Minor + other.Minor, Currency: m.Currency}
+}
+
+// Sub returns the difference of two amounts in the same currency
+func (m Money) Sub(other Money) Money {
+	return Money{Minor: m.Minor - other.Minor, Currency: m.Currency}
+}
+
+// MajorUnits converts minor units of the currency to major units, for JSON and display
+func MajorUnits(minor int64, currency string) float64 {
+	return Money{Minor: minor, Currency: currency}.Float64()
+}
@@ -2,18 +2,34 @@ package domain
 
 import (
 	"encoding/json"
-	"errors"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// DefaultCurrency is the currency of money flows recorded without one
+const DefaultCurrency = "IDR" // Indonesian Rupiah
+
+// Kinds of money flows. Transfers move money between two wallets of a user as a pair
+// of money flows sharing a TransferID; they are not spending.
+const (
+	MoneyFlowKindExpense     = "expense"
+	MoneyFlowKindTransferOut = "transfer_out"
+	MoneyFlowKindTransferIn  = "transfer_in"
+)
+
 // MoneyFlow represents the core expense/money flow entity
 type MoneyFlow struct {
 	ID          uuid.UUID
 	UserID      uuid.UUID
+	WalletID    *uuid.UUID // nil when not tracked in a wallet
+	Kind        string
+	TransferID  *uuid.UUID // set on both money flows of a transfer
+	GroupID     *uuid.UUID // set when recorded in the shared ledger of a group
+	MerchantID  *uuid.UUID // nil when the merchant is unknown
 	Category    *string
-	Amount      float64
+	Amount      int64 // in minor units of Currency
 	Currency    string
 	Description *string
 	Tags        []string
@@ -21,29 +37,89 @@ type MoneyFlow struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
+
+	// Latitude and Longitude locate where the money was spent, both or neither set
+	Latitude  *float64
+	Longitude *float64
+	PlaceName *string
 }
 
-// NewMoneyFlow creates a new MoneyFlow entity
+// NewMoneyFlow creates a new MoneyFlow entity of an amount in major units of the
+// currency. An amount that does not fit the currency returns a *ValidationError; call
+// Validate once the other fields are set.
 func NewMoneyFlow(userID uuid.UUID, amount float64, currency string) (*MoneyFlow, error) {
-	if amount <= 0 {
-		return nil, errors.New("amount must be greater than 0")
-	}
-
 	if currency == "" {
-		currency = "IDR" // Default to Indonesian Rupiah
+		currency = DefaultCurrency
 	}
 
 	now := time.Now()
-	return &MoneyFlow{
+	moneyFlow := &MoneyFlow{
 		ID:        uuid.New(),
 		UserID:    userID,
-		Amount:    amount,
+		Kind:      MoneyFlowKindExpense,
 		Currency:  currency,
 		Version:   0,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Tags:      []string{},
-	}, nil
+	}
+	if err := moneyFlow.SetAmount(amount); err != nil {
+		return nil, err
+	}
+	return moneyFlow, nil
+}
+
+// Validate checks the business rules of a money flow, returning a *ValidationError
+// naming the fields that break them
+func (mf *MoneyFlow) Validate() error {
+	var v validation
+	v.positive("amount", mf.Amount)
+	v.currency("currency", mf.Currency)
+	v.oneOf("kind", mf.Kind, MoneyFlowKindExpense, MoneyFlowKindTransferOut, MoneyFlowKindTransferIn)
+	if mf.Category != nil {
+		v.maxLength("category", *mf.Category, MaxCategoryLength)
+	}
+	if mf.Description != nil {
+		v.maxLength("description", *mf.Description, MaxDescriptionLength)
+	}
+	v.check(len(mf.Tags) <= MaxTags, "tags", "max", strconv.Itoa(MaxTags),
+		"tags must contain at maximum "+strconv.Itoa(MaxTags)+" items")
+	for i, tag := range mf.Tags {
+		field := "tags[" + strconv.Itoa(i) + "]"
+		v.check(tag != "", field, "min", "1", field+" must be at least 1 character in length")
+		v.maxLength(field, tag, MaxTagLength)
+	}
+	v.check(mf.Latitude != nil || mf.Longitude == nil, "latitude", "required_with", "Longitude",
+		"latitude is required when longitude is present")
+	v.check(mf.Longitude != nil || mf.Latitude == nil, "longitude", "required_with", "Latitude",
+		"longitude is required when latitude is present")
+	if mf.Latitude != nil {
+		v.between("latitude", *mf.Latitude, -MaxLatitude, MaxLatitude)
+	}
+	if mf.Longitude != nil {
+		v.between("longitude", *mf.Longitude, -MaxLongitude, MaxLongitude)
+	}
+	if mf.PlaceName != nil {
+		v.maxLength("place_name", *mf.PlaceName, MaxPlaceNameLength)
+	}
+	return v.err()
+}
+
+// Money returns the amount of the money flow in its currency
+func (mf *MoneyFlow) Money() Money {
+	return Money{Minor: mf.Amount, Currency: mf.Currency}
+}
+
+// SetAmount sets the amount, in major units of the money flow's currency. An amount
+// that does not fit the currency returns a *ValidationError; Validate checks the rest.
+func (mf *MoneyFlow) SetAmount(amount float64) error {
+	minor, err := MinorUnits(amount, mf.Currency)
+	if err != nil {
+		return invalidAmount("amount", mf.Currency, err)
+	}
+	mf.Amount = minor
+	mf.UpdatedAt = time.Now()
+	return nil
 }
 
 // SetCategory sets the category for the money flow
@@ -73,11 +149,59 @@ func (mf *MoneyFlow) SetTags(tags []string) {
 	mf.UpdatedAt = time.Now()
 }
 
+// IsTransfer checks if the money flow is one side of a transfer between wallets
+func (mf *MoneyFlow) IsTransfer() bool {
+	return mf.TransferID != nil
+}
+
 // IsDeleted checks if the money flow is soft deleted
 func (mf *MoneyFlow) IsDeleted() bool {
 	return mf.DeletedAt != nil
 }
 
+// CurrencyTotal is the sum of a user's money flows in one currency
+type CurrencyTotal struct {
+	Currency string
+	Total    int64 // in minor units of Currency
+	Count    int64
+}
+
+// CategoryTotal is the sum of a user's money flows in one category and currency.
+// Category is nil for uncategorized money flows.
+type CategoryTotal struct {
+	Category *string
+	Currency string
+	Total    int64 // in minor units of Currency
+	Count    int64
+}
+
+// FlowSummary is the headline of a user's money flows in one currency over a period.
+// Money flows record spending only, so Income stays zero until income can be recorded;
+// transfers between the user's own wallets cancel out and are left out.
+type FlowSummary struct {
+	Currency string
+	Income   int64 // in minor units of Currency
+	Expense  int64 // in minor units of Currency
+	Net      int64 // Income minus Expense
+	Count    int64
+}
+
+// TagUsage is how often a user tagged money flows with one tag
+type TagUsage struct {
+	Tag        string
+	Count      int64
+	LastUsedAt time.Time
+}
+
+// DescriptionCategory is how often a user filed money flows with one description in a
+// category. Description is lowercase, so descriptions differing in case count together.
+type DescriptionCategory struct {
+	Description string
+	Category    string
+	Count       int64
+	LastUsedAt  time.Time
+}
+
 // IncrementVersion increments the version for optimistic locking
 func (mf *MoneyFlow) IncrementVersion() {
 	mf.Version++
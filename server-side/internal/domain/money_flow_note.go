@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxNoteLength is the maximum size of a money flow note in bytes (10KB)
+const MaxNoteLength = 10 * 1024
+
+// MoneyFlowNote represents a long-form markdown note attached to a money flow
+type MoneyFlowNote struct {
+	MoneyFlowID uuid.UUID
+	Content     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewMoneyFlowNote creates a new MoneyFlowNote entity. A note longer than MaxNoteLength
+// returns a *ValidationError.
+func NewMoneyFlowNote(moneyFlowID uuid.UUID, content string) (*MoneyFlowNote, error) {
+	if len(content) > MaxNoteLength {
+		return nil, invalid("note", "max", strconv.Itoa(MaxNoteLength), "note must be a maximum of 10KB in length")
+	}
+
+	now := time.Now()
+	return &MoneyFlowNote{
+		MoneyFlowID: moneyFlowID,
+		Content:     content,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MoneyFlowShare is a public link to a read-only view of a money flow, for the owner to
+// send to family or an accountant. It works until it expires or is revoked.
+type MoneyFlowShare struct {
+	ID          uuid.UUID
+	MoneyFlowID uuid.UUID
+	UserID      uuid.UUID
+	IncludeNote bool // the view shows the money flow's note
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// NewMoneyFlowShare creates a share of a money flow by its owner, valid for ttl
+func NewMoneyFlowShare(moneyFlow *MoneyFlow, ttl time.Duration, includeNote bool) *MoneyFlowShare {
+	now := time.Now()
+	return &MoneyFlowShare{
+		ID:          uuid.New(),
+		MoneyFlowID: moneyFlow.ID,
+		UserID:      moneyFlow.UserID,
+		IncludeNote: includeNote,
+		ExpiresAt:   now.Add(ttl).Truncate(time.Second),
+		CreatedAt:   now,
+	}
+}
+
+// IsExpired checks if the share no longer works at the given time
+func (s *MoneyFlowShare) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
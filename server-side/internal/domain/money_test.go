@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMinorUnits(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     int64
+		wantErr  error
+	}{
+		{12.5, "USD", 1250, nil},
+		{25000, "IDR", 2500000, nil},
+		{1200, "JPY", 1200, nil},
+		{1.234, "KWD", 1234, nil},
+		{-12.5, "USD", -1250, nil},
+		{0, "USD", 0, nil},
+
+		// Decimal fractions are inexact in binary but still convert exactly
+		{0.1 + 0.2, "USD", 30, nil},
+		{19.99, "USD", 1999, nil},
+		{4503599627370.49, "USD", 450359962737049, nil},
+
+		// More decimal places than the currency has are rejected, not rounded
+		{12.345, "USD", 0, ErrAmountPrecision},
+		{1.005, "USD", 0, ErrAmountPrecision},
+		{1200.5, "JPY", 0, ErrAmountPrecision},
+		{1.2345, "KWD", 0, ErrAmountPrecision},
+
+		{1e17, "USD", 0, ErrAmountOverflow},
+		{-1e17, "USD", 0, ErrAmountOverflow},
+		{math.Inf(1), "USD", 0, ErrAmountOverflow},
+		{math.NaN(), "USD", 0, ErrAmountOverflow},
+	}
+	for _, tt := range tests {
+		got, err := MinorUnits(tt.amount, tt.currency)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("MinorUnits(%v, %s) = %d, %v, want %d, %v", tt.amount, tt.currency, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     int64
+		wantErr  error
+		invalid  bool // a malformed amount
+	}{
+		{"12.50", "USD", 1250, nil, false},
+		{"12.5", "USD", 1250, nil, false},
+		{"12", "USD", 1200, nil, false},
+		{".5", "USD", 50, nil, false},
+		{"12.", "USD", 1200, nil, false},
+		{"  7 ", "USD", 700, nil, false},
+		{"+5", "USD", 500, nil, false},
+		{"-0.01", "USD", -1, nil, false},
+		{"-0", "USD", 0, nil, false},
+		{"0012.3400", "USD", 1234, nil, false},
+		{"25000.0000", "IDR", 2500000, nil, false},
+		{"1200.0000", "JPY", 1200, nil, false},
+		{"1.234", "KWD", 1234, nil, false},
+		{"9223372036854775807", "JPY", math.MaxInt64, nil, false},
+
+		// Digits past the currency's exponent must be zeros
+		{"12.345", "USD", 0, ErrAmountPrecision, false},
+		{"12.3450", "USD", 0, ErrAmountPrecision, false},
+		{"1200.5", "JPY", 0, ErrAmountPrecision, false},
+
+		{"92233720368547758.08", "USD", 0, ErrAmountOverflow, false},
+		{"99999999999999999999", "JPY", 0, ErrAmountOverflow, false},
+
+		{"", "USD", 0, nil, true},
+		{".", "USD", 0, nil, true},
+		{"-", "USD", 0, nil, true},
+		{"abc", "USD", 0, nil, true},
+		{"1.2.3", "USD", 0, nil, true},
+		{"1,000", "USD", 0, nil, true},
+		{"1e3", "USD", 0, nil, true},
+		{"--1", "USD", 0, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value, tt.currency)
+		switch {
+		case tt.invalid:
+			if err == nil || errors.Is(err, ErrAmountPrecision) || errors.Is(err, ErrAmountOverflow) {
+				t.Errorf("ParseMoney(%q, %s) error = %v, want an invalid amount", tt.value, tt.currency, err)
+			}
+		case !errors.Is(err, tt.wantErr) || got.Minor != tt.want:
+			t.Errorf("ParseMoney(%q, %s) = %d, %v, want %d, %v", tt.value, tt.currency, got.Minor, err, tt.want, tt.wantErr)
+		case err == nil && got.Currency != tt.currency:
+			t.Errorf("ParseMoney(%q, %s) currency = %s", tt.value, tt.currency, got.Currency)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{Money{Minor: 2500050, Currency: "IDR"}, "25000.50"},
+		{Money{Minor: 5, Currency: "USD"}, "0.05"},
+		{Money{Minor: -1250, Currency: "USD"}, "-12.50"},
+		{Money{Minor: 1200, Currency: "JPY"}, "1200"},
+		{Money{Minor: 1234, Currency: "KWD"}, "1.234"},
+		{Money{Minor: 0, Currency: "USD"}, "0.00"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.money, got, tt.want)
+		}
+		// Strings are read back as the same amount
+		if parsed, err := ParseMoney(tt.want, tt.money.Currency); err != nil || parsed != tt.money {
+			t.Errorf("ParseMoney(%q) = %+v, %v, want %+v", tt.want, parsed, err, tt.money)
+		}
+	}
+}
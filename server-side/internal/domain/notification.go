@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification represents an in-app notification shown to a user
+type Notification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Title     string
+	Body      string
+	ReadAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewNotification creates a new unread Notification entity
+func NewNotification(userID uuid.UUID, title, body string) *Notification {
+	now := time.Now()
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Title:     title,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsRead checks if the notification has been read
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordReset lets a user who forgot their password set a new one through a link
+// emailed to the address they sign in with. Only the hash of the token is kept.
+type PasswordReset struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	UserAuthID uuid.UUID
+	Email      string
+
+	// TokenHash is empty until the reset is sent
+	TokenHash string
+
+	SentAt    *time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewPasswordReset creates a new PasswordReset entity that expires after ttl
+func NewPasswordReset(userID, userAuthID uuid.UUID, email string, ttl time.Duration) *PasswordReset {
+	now := time.Now()
+	return &PasswordReset{
+		ID:         uuid.New(),
+		UserID:     userID,
+		UserAuthID: userAuthID,
+		Email:      email,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// IsUsed checks if the reset has set a new password
+func (r *PasswordReset) IsUsed() bool {
+	return r.UsedAt != nil
+}
+
+// IsExpired checks if the reset can no longer be used at the given time
+func (r *PasswordReset) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
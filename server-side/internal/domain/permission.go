@@ -0,0 +1,74 @@
+package domain
+
+import "slices"
+
+// Permissions of admin endpoints, granted to users through their role
+const (
+	// PermissionUsersRead allows listing users and inspecting their credentials and sessions
+	PermissionUsersRead = "users:read"
+
+	// PermissionUsersManage allows disabling, enabling, and importing users
+	PermissionUsersManage = "users:manage"
+
+	// PermissionRolesManage allows changing the role of users
+	PermissionRolesManage = "roles:manage"
+
+	// PermissionStatsRead allows viewing system statistics and API usage
+	PermissionStatsRead = "stats:read"
+
+	// PermissionFeedbackRead allows reading user feedback
+	PermissionFeedbackRead = "feedback:read"
+
+	// PermissionBroadcastsManage allows sending and inspecting broadcasts
+	PermissionBroadcastsManage = "broadcasts:manage"
+
+	// PermissionMerchantsManage allows renaming merchants and maintaining the rules that
+	// normalize payee names to them
+	PermissionMerchantsManage = "merchants:manage"
+
+	// PermissionSystemManage allows changing the log level and read-only mode and
+	// running analytics exports
+	PermissionSystemManage = "system:manage"
+)
+
+// rolePermissions lists the permissions of each role; roles not listed have none
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionUsersRead,
+		PermissionUsersManage,
+		PermissionRolesManage,
+		PermissionStatsRead,
+		PermissionFeedbackRead,
+		PermissionBroadcastsManage,
+		PermissionMerchantsManage,
+		PermissionSystemManage,
+	},
+	RoleSupport: {
+		PermissionUsersRead,
+		PermissionStatsRead,
+		PermissionFeedbackRead,
+	},
+}
+
+// ValidRoles lists every role that can be given to a user
+var ValidRoles = []string{RoleUser, RoleSupport, RoleAdmin}
+
+// IsValidRole checks if the role is a known user role
+func IsValidRole(role string) bool {
+	return slices.Contains(ValidRoles, role)
+}
+
+// RolePermissions returns the permissions granted by a role
+func RolePermissions(role string) []string {
+	return slices.Clone(rolePermissions[role])
+}
+
+// RoleHasPermission checks if a role grants the permission
+func RoleHasPermission(role, permission string) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// IsStaffRole checks if a role grants access to the admin endpoints at all
+func IsStaffRole(role string) bool {
+	return len(rolePermissions[role]) > 0
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PhoneVerification is a code sent to a phone number that the user types back to prove
+// they receive messages there, e.g. before the number is linked as a credential. Only
+// the hash of the code is kept.
+type PhoneVerification struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	PhoneNumber string
+	CodeHash    string
+
+	// Attempts counts the wrong codes entered
+	Attempts int
+
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// NewPhoneVerification creates a new PhoneVerification entity that expires after ttl.
+// The code hash is set once the ID it is keyed by is known.
+func NewPhoneVerification(userID uuid.UUID, phoneNumber string, ttl time.Duration) *PhoneVerification {
+	now := time.Now()
+	return &PhoneVerification{
+		ID:          uuid.New(),
+		UserID:      userID,
+		PhoneNumber: phoneNumber,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+// IsExpired checks if the code can no longer be used at the given time
+func (v *PhoneVerification) IsExpired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
@@ -0,0 +1,293 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Split methods
+const (
+	// SplitMethodEqual divides the amount equally between the members
+	SplitMethodEqual = "equal"
+
+	// SplitMethodPercentage gives each member a percentage of the amount
+	SplitMethodPercentage = "percentage"
+
+	// SplitMethodExact gives each member an exact amount
+	SplitMethodExact = "exact"
+)
+
+// Split is a member's share of a money flow recorded in a group. The author of the
+// money flow paid it, so every other member owes the author their share.
+type Split struct {
+	ID          uuid.UUID
+	MoneyFlowID uuid.UUID
+	GroupID     uuid.UUID
+	UserID      uuid.UUID
+	Method      string
+	Amount      int64 // in minor units of Currency
+	Currency    string
+	CreatedAt   time.Time
+
+	// PayerID is the author of the money flow, filled in when computing balances
+	PayerID uuid.UUID
+}
+
+// SplitShare is a member's part of a money flow to split: a percentage for
+// SplitMethodPercentage, an amount in major units for SplitMethodExact, and
+// nothing for SplitMethodEqual
+type SplitShare struct {
+	UserID     uuid.UUID
+	Percentage float64
+	Amount     float64
+}
+
+// Settlement is a payment between two members of a group that pays off what one owes
+// the other
+type Settlement struct {
+	ID         uuid.UUID
+	GroupID    uuid.UUID
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+	Amount     int64 // in minor units of Currency
+	Currency   string
+	CreatedBy  uuid.UUID
+	CreatedAt  time.Time
+}
+
+// Debt is an amount, in minor units of Currency, that one member owes another
+type Debt struct {
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+	Amount     int64
+	Currency   string
+}
+
+// NewSplits divides a money flow recorded in a group between the members of shares.
+// Amounts that do not divide evenly give the remaining minor units to the first shares.
+func NewSplits(moneyFlow *MoneyFlow, method string, shares []SplitShare) ([]*Split, error) {
+	if moneyFlow.GroupID == nil {
+		return nil, errors.New("only money flows recorded in a group can be split")
+	}
+	if len(shares) == 0 {
+		return nil, errors.New("at least one member is required")
+	}
+	seen := make(map[uuid.UUID]bool, len(shares))
+	for _, share := range shares {
+		if seen[share.UserID] {
+			return nil, errors.New("each member can only have one share")
+		}
+		seen[share.UserID] = true
+	}
+
+	var amounts []int64
+	switch method {
+	case SplitMethodEqual:
+		weights := make([]int64, len(shares))
+		for i := range weights {
+			weights[i] = 1
+		}
+		amounts = allocate(moneyFlow.Amount, weights)
+	case SplitMethodPercentage:
+		// Percentages have up to two decimals, so basis points are exact
+		weights := make([]int64, len(shares))
+		var sum int64
+		for i, share := range shares {
+			weights[i] = int64(math.Round(share.Percentage * 100))
+			if weights[i] <= 0 {
+				return nil, errors.New("percentages must be greater than 0")
+			}
+			sum += weights[i]
+		}
+		if sum != 10000 {
+			return nil, fmt.Errorf("percentages must add up to 100, got %.2f", float64(sum)/100)
+		}
+		amounts = allocate(moneyFlow.Amount, weights)
+	case SplitMethodExact:
+		amounts = make([]int64, len(shares))
+		var sum int64
+		for i, share := range shares {
+			minor, err := MinorUnits(share.Amount, moneyFlow.Currency)
+			if err != nil {
+				return nil, err
+			}
+			if minor <= 0 {
+				return nil, errors.New("amounts must be greater than 0")
+			}
+			amounts[i] = minor
+			sum += minor
+		}
+		if sum != moneyFlow.Amount {
+			return nil, fmt.Errorf("amounts must add up to %v, got %v",
+				MajorUnits(moneyFlow.Amount, moneyFlow.Currency), MajorUnits(sum, moneyFlow.Currency))
+		}
+	default:
+		return nil, errors.New("method must be equal, percentage, or exact")
+	}
+
+	now := time.Now()
+	splits := make([]*Split, len(shares))
+	for i, share := range shares {
+		splits[i] = &Split{
+			ID:          uuid.New(),
+			MoneyFlowID: moneyFlow.ID,
+			GroupID:     *moneyFlow.GroupID,
+			UserID:      share.UserID,
+			Method:      method,
+			Amount:      amounts[i],
+			Currency:    moneyFlow.Currency,
+			CreatedAt:   now,
+			PayerID:     moneyFlow.UserID,
+		}
+	}
+	return splits, nil
+}
+
+// allocate divides total in proportion to the positive weights, handing out the minor
+// units lost to rounding toward zero one at a time in order. The amounts add up to total
+// and have its sign.
+func allocate(total int64, weights []int64) []int64 {
+	var sum uint64
+	for _, weight := range weights {
+		sum += uint64(weight)
+	}
+
+	// Shares are computed on the magnitude; negating in uint64 also covers MinInt64
+	magnitude := uint64(total)
+	if total < 0 {
+		magnitude = -magnitude
+	}
+
+	shares := make([]uint64, len(weights))
+	remaining := magnitude
+	for i, weight := range weights {
+		// magnitude * weight may not fit 64 bits, but the quotient does since weight <= sum
+		hi, lo := bits.Mul64(magnitude, uint64(weight))
+		shares[i], _ = bits.Div64(hi, lo, sum)
+		remaining -= shares[i]
+	}
+	for i := 0; remaining > 0; i = (i + 1) % len(shares) {
+		shares[i]++
+		remaining--
+	}
+
+	amounts := make([]int64, len(shares))
+	for i, share := range shares {
+		amounts[i] = int64(share)
+		if total < 0 {
+			amounts[i] = -amounts[i]
+		}
+	}
+	return amounts
+}
+
+// NewSettlement creates a new Settlement entity paying amount, in major units of
+// currency, from one member to another
+func NewSettlement(groupID, fromUserID, toUserID, createdBy uuid.UUID, amount float64, currency string) (*Settlement, error) {
+	if fromUserID == toUserID {
+		return nil, errors.New("cannot settle with yourself")
+	}
+	minor, err := MinorUnits(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	if minor <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+
+	return &Settlement{
+		ID:         uuid.New(),
+		GroupID:    groupID,
+		FromUserID: fromUserID,
+		ToUserID:   toUserID,
+		Amount:     minor,
+		Currency:   currency,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// NetBalances returns what each member is owed (positive) or owes (negative) in each
+// currency after the splits and settlements of a group
+func NetBalances(splits []*Split, settlements []*Settlement) map[string]map[uuid.UUID]int64 {
+	net := map[string]map[uuid.UUID]int64{}
+	add := func(currency string, userID uuid.UUID, amount int64) {
+		if net[currency] == nil {
+			net[currency] = map[uuid.UUID]int64{}
+		}
+		net[currency][userID] += amount
+	}
+
+	for _, split := range splits {
+		if split.UserID == split.PayerID {
+			continue // the payer's own share
+		}
+		add(split.Currency, split.PayerID, split.Amount)
+		add(split.Currency, split.UserID, -split.Amount)
+	}
+	for _, settlement := range settlements {
+		add(settlement.Currency, settlement.FromUserID, settlement.Amount)
+		add(settlement.Currency, settlement.ToUserID, -settlement.Amount)
+	}
+	return net
+}
+
+// SimplifyDebts turns the balances of one currency into few payments that settle them:
+// the member owing the most pays the member owed the most until one of them is even,
+// which needs at most one payment less than the number of members with a balance
+func SimplifyDebts(balances map[uuid.UUID]int64, currency string) []*Debt {
+	type balance struct {
+		userID uuid.UUID
+		amount int64
+	}
+	var creditors, debtors []*balance
+	for userID, amount := range balances {
+		switch {
+		case amount > 0:
+			creditors = append(creditors, &balance{userID, amount})
+		case amount < 0:
+			debtors = append(debtors, &balance{userID, -amount})
+		}
+	}
+	// Largest first, by user ID on ties so the result does not depend on map order
+	byAmount := func(balances []*balance) func(i, j int) bool {
+		return func(i, j int) bool {
+			if balances[i].amount != balances[j].amount {
+				return balances[i].amount > balances[j].amount
+			}
+			return balances[i].userID.String() < balances[j].userID.String()
+		}
+	}
+	sort.Slice(creditors, byAmount(creditors))
+	sort.Slice(debtors, byAmount(debtors))
+
+	var debts []*Debt
+	for len(creditors) > 0 && len(debtors) > 0 {
+		creditor, debtor := creditors[0], debtors[0]
+		amount := min(creditor.amount, debtor.amount)
+		debts = append(debts, &Debt{
+			FromUserID: debtor.userID,
+			ToUserID:   creditor.userID,
+			Amount:     amount,
+			Currency:   currency,
+		})
+
+		creditor.amount -= amount
+		debtor.amount -= amount
+		if creditor.amount == 0 {
+			creditors = creditors[1:]
+		}
+		if debtor.amount == 0 {
+			debtors = debtors[1:]
+		}
+		sort.Slice(creditors, byAmount(creditors))
+		sort.Slice(debtors, byAmount(debtors))
+	}
+	return debts
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		weights []int64
+		want    []int64
+	}{
+		{"even", 90, []int64{1, 1, 1}, []int64{30, 30, 30}},
+		{"remainder to the first shares", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"less than one unit each", 2, []int64{1, 1, 1}, []int64{1, 1, 0}},
+		{"zero", 0, []int64{1, 1}, []int64{0, 0}},
+		{"single share", 12345, []int64{1}, []int64{12345}},
+		{"percentages", 100, []int64{3333, 3333, 3334}, []int64{34, 33, 33}},
+		{"uneven percentages", 99999, []int64{2500, 7500}, []int64{25000, 74999}},
+		{"negative", -100, []int64{1, 1, 1}, []int64{-34, -33, -33}},
+		{"negative percentages", -99999, []int64{2500, 7500}, []int64{-25000, -74999}},
+
+		// total * weight does not fit 64 bits
+		{"largest amount", 1<<62 - 1, []int64{5000, 5000}, []int64{1 << 61, 1<<61 - 1}},
+		{"largest amount in thirds", math.MaxInt64, []int64{3333, 3333, 3334}, []int64{3074149899883696777, 3074149899883696776, 3075072237087382254}},
+		{"smallest amount", math.MinInt64, []int64{1}, []int64{math.MinInt64}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allocate(tt.total, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocate(%d, %v) = %v, want %v", tt.total, tt.weights, got, tt.want)
+			}
+			var sum int64
+			for _, amount := range got {
+				sum += amount
+			}
+			if sum != tt.total {
+				t.Errorf("allocate(%d, %v) adds up to %d", tt.total, tt.weights, sum)
+			}
+		})
+	}
+}
+
+func TestNewSplits(t *testing.T) {
+	groupID := uuid.New()
+	payer, member := uuid.New(), uuid.New()
+	moneyFlow := &MoneyFlow{ID: uuid.New(), UserID: payer, GroupID: &groupID, Amount: 10001, Currency: "USD"}
+
+	tests := []struct {
+		name    string
+		method  string
+		shares  []SplitShare
+		want    []int64
+		wantErr string
+	}{
+		{"equal", SplitMethodEqual, []SplitShare{{UserID: payer}, {UserID: member}}, []int64{5001, 5000}, ""},
+		{"percentage", SplitMethodPercentage, []SplitShare{{UserID: payer, Percentage: 33.33}, {UserID: member, Percentage: 66.67}}, []int64{3334, 6667}, ""},
+		{"percentages short of 100", SplitMethodPercentage, []SplitShare{{UserID: payer, Percentage: 50}, {UserID: member, Percentage: 49.99}}, nil, "percentages must add up to 100, got 99.99"},
+		{"zero percentage", SplitMethodPercentage, []SplitShare{{UserID: payer, Percentage: 100}, {UserID: member}}, nil, "percentages must be greater than 0"},
+		{"exact", SplitMethodExact, []SplitShare{{UserID: payer, Amount: 40.01}, {UserID: member, Amount: 60}}, []int64{4001, 6000}, ""},
+		{"exact short of the amount", SplitMethodExact, []SplitShare{{UserID: payer, Amount: 40}, {UserID: member, Amount: 60}}, nil, "amounts must add up to 100.01, got 100"},
+		{"exact with too many decimals", SplitMethodExact, []SplitShare{{UserID: payer, Amount: 40.005}, {UserID: member, Amount: 60.005}}, nil, ErrAmountPrecision.Error()},
+		{"negative exact", SplitMethodExact, []SplitShare{{UserID: payer, Amount: 110.01}, {UserID: member, Amount: -10}}, nil, "amounts must be greater than 0"},
+		{"member twice", SplitMethodEqual, []SplitShare{{UserID: member}, {UserID: member}}, nil, "each member can only have one share"},
+		{"unknown method", "shares", []SplitShare{{UserID: member}}, nil, "method must be equal, percentage, or exact"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			splits, err := NewSplits(moneyFlow, tt.method, tt.shares)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("NewSplits() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewSplits() error = %v", err)
+			}
+			got := make([]int64, len(splits))
+			for i, split := range splits {
+				got[i] = split.Amount
+				if split.UserID != tt.shares[i].UserID || split.PayerID != payer || split.Currency != "USD" {
+					t.Errorf("split %d = %+v, want the share's member paid by the author in USD", i, split)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSplits() amounts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimplifyDebts(t *testing.T) {
+	// IDs that sort in the order of their names, for the tie-break
+	a := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	b := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	c := uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	d := uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+
+	tests := []struct {
+		name     string
+		balances map[uuid.UUID]int64
+		want     []Debt
+	}{
+		{"no balances", nil, nil},
+		{"settled", map[uuid.UUID]int64{a: 0, b: 0}, nil},
+		{"one debt", map[uuid.UUID]int64{a: 50, b: -50}, []Debt{{b, a, 50, "IDR"}}},
+		{
+			// b owed a 30 and c owed b 30: c pays a directly
+			"chain", map[uuid.UUID]int64{a: 30, b: 0, c: -30},
+			[]Debt{{c, a, 30, "IDR"}},
+		},
+		{
+			"one debtor", map[uuid.UUID]int64{a: 40, b: 60, c: -100},
+			[]Debt{{c, b, 60, "IDR"}, {c, a, 40, "IDR"}},
+		},
+		{
+			"ties by user ID", map[uuid.UUID]int64{a: 50, b: 50, c: -50, d: -50},
+			[]Debt{{c, a, 50, "IDR"}, {d, b, 50, "IDR"}},
+		},
+		{
+			// The largest debtor pays the largest creditor, then the largest that remain
+			"largest first", map[uuid.UUID]int64{a: 100, b: 20, c: -70, d: -50},
+			[]Debt{{c, a, 70, "IDR"}, {d, a, 30, "IDR"}, {d, b, 20, "IDR"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debts := SimplifyDebts(tt.balances, "IDR")
+			var got []Debt
+			for _, debt := range debts {
+				got = append(got, *debt)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SimplifyDebts() = %v, want %v", got, tt.want)
+			}
+
+			// The payments settle every balance, in fewer payments than members
+			remaining := make(map[uuid.UUID]int64, len(tt.balances))
+			for userID, amount := range tt.balances {
+				remaining[userID] = amount
+			}
+			for _, debt := range debts {
+				if debt.Amount <= 0 {
+					t.Errorf("payment %+v is not positive", debt)
+				}
+				remaining[debt.FromUserID] += debt.Amount
+				remaining[debt.ToUserID] -= debt.Amount
+			}
+			for userID, amount := range remaining {
+				if amount != 0 {
+					t.Errorf("balance of %s is %d after the payments, want 0", userID, amount)
+				}
+			}
+			if len(tt.balances) > 0 && len(debts) >= len(tt.balances) {
+				t.Errorf("%d payments for %d members", len(debts), len(tt.balances))
+			}
+		})
+	}
+}
+
+func TestNetBalances(t *testing.T) {
+	payer, member, other := uuid.New(), uuid.New(), uuid.New()
+	splits := []*Split{
+		{UserID: payer, PayerID: payer, Amount: 5000, Currency: "IDR"},
+		{UserID: member, PayerID: payer, Amount: 3000, Currency: "IDR"},
+		{UserID: other, PayerID: payer, Amount: 2000, Currency: "IDR"},
+		{UserID: payer, PayerID: member, Amount: 700, Currency: "USD"},
+	}
+	settlements := []*Settlement{{FromUserID: member, ToUserID: payer, Amount: 1000, Currency: "IDR"}}
+
+	want := map[string]map[uuid.UUID]int64{
+		"IDR": {payer: 4000, member: -2000, other: -2000},
+		"USD": {member: 700, payer: -700},
+	}
+	if got := NetBalances(splits, settlements); !reflect.DeepEqual(got, want) {
+		t.Errorf("NetBalances() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Keys of system settings
+const (
+	// SettingReadOnly holds the ReadOnlyMode switch
+	SettingReadOnly = "read_only"
+)
+
+// SystemSetting is an operator setting of the whole service, stored as JSON
+type SystemSetting struct {
+	Key       string
+	Value     string
+	UpdatedBy *uuid.UUID
+	UpdatedAt time.Time
+}
+
+// ReadOnlyMode is the emergency switch that rejects every write to the API while
+// reads keep working
+type ReadOnlyMode struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason,omitempty"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// SystemStats are counts across every user, shown to admins. Demo users are left out.
+type SystemStats struct {
+	// Since is the start of the period the New and Active counts cover
+	Since time.Time
+
+	Users         int64
+	NewUsers      int64
+	ActiveUsers   int64 // users who recorded a money flow since Since
+	DisabledUsers int64
+	StaffUsers    int64 // users with a role other than RoleUser
+
+	MoneyFlows    int64
+	NewMoneyFlows int64
+	Wallets       int64
+	Groups        int64
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// Trend intervals, the length of the periods spending trends are split into
+const (
+	TrendDaily   = "day"
+	TrendWeekly  = "week"
+	TrendMonthly = "month"
+)
+
+// PeriodTotal is the sum of expenses in one period and currency
+type PeriodTotal struct {
+	// PeriodStart is the calendar day the period starts on in the user's time zone;
+	// only its date is significant
+	PeriodStart time.Time
+	Currency    string
+	Total       int64 // in minor units of Currency
+	Count       int64
+}
@@ -6,16 +6,42 @@ import (
 	"github.com/google/uuid"
 )
 
+// User roles
+const (
+	// RoleUser is the default role of every registered user
+	RoleUser = "user"
+
+	// RoleSupport grants read access to the admin endpoints for helping users
+	RoleSupport = "support"
+
+	// RoleAdmin grants access to every admin endpoint
+	RoleAdmin = "admin"
+)
+
 // User represents the core user entity
 type User struct {
 	ID          uuid.UUID
 	FullName    string
 	PhoneNumber string
 	Image       *string
+	Role        string
 	Version     int
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   *time.Time
+
+	// DemoExpiresAt is set for ephemeral demo users, which are deleted after this time
+	DemoExpiresAt *time.Time
+
+	// DataRegion is the storage region of the user's files; empty for the default region
+	DataRegion string
+
+	// DisabledAt is set when an admin disabled the account, which then cannot sign in
+	DisabledAt *time.Time
+
+	// TokenVersion is carried by the access tokens issued to the user. Incrementing it
+	// revokes every access token issued before.
+	TokenVersion int
 }
 
 // NewUser creates a new User entity
@@ -25,17 +51,52 @@ func NewUser(fullName, phoneNumber string) *User {
 		ID:          uuid.New(),
 		FullName:    fullName,
 		PhoneNumber: phoneNumber,
+		Role:        RoleUser,
 		Version:     0,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
 }
 
+// NewDemoUser creates an ephemeral sandbox User that expires at the given time.
+// Demo users have no credentials; the phone number is a unique placeholder.
+func NewDemoUser(expiresAt time.Time) *User {
+	user := NewUser("Demo User", "")
+	user.PhoneNumber = "demo-" + user.ID.String()
+	user.DemoExpiresAt = &expiresAt
+	return user
+}
+
+// IsDemo checks if the user is an ephemeral demo user
+func (u *User) IsDemo() bool {
+	return u.DemoExpiresAt != nil
+}
+
 // IsDeleted checks if the user is soft deleted
 func (u *User) IsDeleted() bool {
 	return u.DeletedAt != nil
 }
 
+// IsAdmin checks if the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// IsDisabled checks if an admin disabled the account
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+// Disable disables the account
+func (u *User) Disable(at time.Time) {
+	u.DisabledAt = &at
+}
+
+// Enable enables a disabled account again
+func (u *User) Enable() {
+	u.DisabledAt = nil
+}
+
 // IncrementVersion increments the version for optimistic locking
 func (u *User) IncrementVersion() {
 	u.Version++
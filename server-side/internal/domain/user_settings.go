@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults of the locale and calendar preferences
+const (
+	DefaultLocale    = "id-ID"
+	DefaultTimezone  = "UTC"
+	DefaultWeekStart = time.Monday
+)
+
+// UserSettings holds the preferences of a user
+type UserSettings struct {
+	UserID          uuid.UUID
+	AnalyticsOptOut bool
+
+	// DefaultCurrency is used for money flows recorded without a currency, and is the
+	// base currency of reports
+	DefaultCurrency string
+
+	// SingleCurrencyMode rejects money flows in any other currency than DefaultCurrency
+	SingleCurrencyMode bool
+
+	// Locale is a BCP 47 language tag clients format dates and amounts with
+	Locale string
+
+	// Timezone is the IANA time zone that report periods and budget months follow
+	Timezone string
+
+	// WeekStart is the first day of weekly report periods
+	WeekStart time.Weekday
+
+	// Notification preferences; a channel turned off is never used
+	NotifyWhatsApp bool
+	NotifyEmail    bool
+	NotifyTelegram bool
+	NotifyPush     bool
+
+	// NotificationChannels orders the channels notifications are tried on until one
+	// reaches the user; empty uses the default order of NotificationChannels
+	NotificationChannels []string
+
+	// TelegramChatID is the user's chat with the Telegram bot; empty until linked
+	TelegramChatID string
+
+	// DigestFrequency is how often the user is sent a summary of their spending:
+	// DigestWeekly, DigestMonthly, or DigestOff
+	DigestFrequency string
+
+	// DuplicateCheck is what happens when a new money flow looks like one recorded the
+	// same day: DuplicateCheckOff, DuplicateCheckWarn, or DuplicateCheckBlock
+	DuplicateCheck string
+
+	// MonthlyStatement is whether the user is emailed a statement of each month
+	MonthlyStatement bool
+
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DefaultUserSettings returns the settings of a user who has not changed any preference
+func DefaultUserSettings(userID uuid.UUID) *UserSettings {
+	now := time.Now()
+	return &UserSettings{
+		UserID:          userID,
+		DefaultCurrency: DefaultCurrency,
+		Locale:          DefaultLocale,
+		Timezone:        DefaultTimezone,
+		WeekStart:       DefaultWeekStart,
+		NotifyWhatsApp:  true,
+		NotifyEmail:     true,
+		NotifyTelegram:  true,
+		NotifyPush:      true,
+		DigestFrequency: DefaultDigestFrequency,
+		DuplicateCheck:  DefaultDuplicateCheck,
+		Version:         0,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// Validate checks the business rules of the settings, returning a *ValidationError
+// naming the fields that break them
+func (s *UserSettings) Validate() error {
+	var v validation
+	v.currency("default_currency", s.DefaultCurrency)
+	return v.err()
+}
+
+// AcceptsCurrency checks if a money flow in the currency may be recorded
+func (s *UserSettings) AcceptsCurrency(currency string) bool {
+	return !s.SingleCurrencyMode || currency == s.DefaultCurrency
+}
+
+// AcceptsChannel checks if broadcasts may be delivered to the user through the channel
+func (s *UserSettings) AcceptsChannel(channel string) bool {
+	switch channel {
+	case ChannelWhatsApp:
+		return s.NotifyWhatsApp
+	case ChannelEmail:
+		return s.NotifyEmail
+	case ChannelTelegram:
+		return s.NotifyTelegram
+	case ChannelPush:
+		return s.NotifyPush
+	default:
+		return true
+	}
+}
+
+// ChannelOrder returns the channels the user accepts, in their order of preference.
+// In-app notifications are the last resort unless the user ordered them earlier.
+func (s *UserSettings) ChannelOrder() []string {
+	order := s.NotificationChannels
+	if len(order) == 0 {
+		order = NotificationChannels
+	}
+
+	channels := make([]string, 0, len(order)+1)
+	for _, channel := range order {
+		if s.AcceptsChannel(channel) && !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	if !slices.Contains(channels, ChannelInApp) {
+		channels = append(channels, ChannelInApp)
+	}
+	return channels
+}
+
+// Location returns the user's time zone, or UTC if it cannot be loaded
+func (s *UserSettings) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// MonthRange returns the calendar month containing t in the user's time zone as [start, end)
+func (s *UserSettings) MonthRange(t time.Time) (time.Time, time.Time) {
+	t = t.In(s.Location())
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 1, 0)
+}
+
+// WeekRange returns the week containing t, starting on WeekStart in the user's time zone,
+// as [start, end)
+func (s *UserSettings) WeekRange(t time.Time) (time.Time, time.Time) {
+	t = t.In(s.Location())
+	offset := (int(t.Weekday()) - int(s.WeekStart) + 7) % 7
+	start := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+	return start, start.AddDate(0, 0, 7)
+}
+
+// IncrementVersion increments the version for optimistic locking
+func (s *UserSettings) IncrementVersion() {
+	s.Version++
+	s.UpdatedAt = time.Now()
+}
+
+// ParseWeekday parses an English day name such as "monday", ignoring case
+func ParseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
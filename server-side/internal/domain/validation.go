@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits of the text fields of money flows, budgets, wallets, goals, and merchants, in characters
+const (
+	MaxCategoryLength    = 100
+	MaxDescriptionLength = 500
+	MaxTags              = 20
+	MaxTagLength         = 50
+	MaxWalletNameLength  = 100
+	MaxGoalNameLength    = 100
+	MaxMerchantLength    = 100
+	MaxPlaceNameLength   = 100
+)
+
+// FieldError is a business rule broken by one field of an entity. Code names the rule
+// the way the binding tags of requests do ("gt", "max", "oneof", ...), so that clients
+// handle both alike; Param is the parameter of the rule, if it has one.
+type FieldError struct {
+	Field   string
+	Code    string
+	Param   string
+	Message string
+}
+
+// ValidationError lists the fields of an entity that break business rules, with the
+// first rule each of them broke
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// rename names a field differently, e.g. after the request field it was set from
+func (e *ValidationError) rename(field, name string) {
+	for i := range e.Fields {
+		if e.Fields[i].Field == field {
+			e.Fields[i].Field = name
+			e.Fields[i].Message = name + strings.TrimPrefix(e.Fields[i].Message, field)
+		}
+	}
+}
+
+// invalid returns the ValidationError of a single field
+func invalid(field, code, param, message string) *ValidationError {
+	return &ValidationError{Fields: []FieldError{{Field: field, Code: code, Param: param, Message: message}}}
+}
+
+// invalidAmount describes an amount that does not fit its currency, as returned by
+// MinorUnits
+func invalidAmount(field, currency string, err error) *ValidationError {
+	if errors.Is(err, ErrAmountPrecision) {
+		return invalid(field, "precision", strconv.Itoa(CurrencyExponent(currency)),
+			field+" has more decimal places than "+currency+" allows")
+	}
+	return invalid(field, "overflow", "", field+" is too large")
+}
+
+// validation collects the broken rules of an entity for its Validate method
+type validation struct {
+	fields []FieldError
+}
+
+// check records a broken rule unless ok, or unless the field already broke one
+func (v *validation) check(ok bool, field, code, param, message string) {
+	if ok {
+		return
+	}
+	for _, existing := range v.fields {
+		if existing.Field == field {
+			return
+		}
+	}
+	v.fields = append(v.fields, FieldError{Field: field, Code: code, Param: param, Message: message})
+}
+
+// required checks that a text field is not blank
+func (v *validation) required(field, value string) {
+	v.check(strings.TrimSpace(value) != "", field, "required", "", field+" is a required field")
+}
+
+// maxLength checks that a text field has at most max characters
+func (v *validation) maxLength(field, value string, max int) {
+	v.check(utf8.RuneCountInString(value) <= max, field, "max", strconv.Itoa(max),
+		field+" must be a maximum of "+strconv.Itoa(max)+" characters in length")
+}
+
+// positive checks that an amount in minor units is greater than zero
+func (v *validation) positive(field string, amount int64) {
+	v.check(amount > 0, field, "gt", "0", field+" must be greater than 0")
+}
+
+// between checks that a number is within [min, max]
+func (v *validation) between(field string, value, min, max float64) {
+	minText := strconv.FormatFloat(min, 'f', -1, 64)
+	maxText := strconv.FormatFloat(max, 'f', -1, 64)
+	v.check(value >= min, field, "min", minText, field+" must be "+minText+" or greater")
+	v.check(value <= max, field, "max", maxText, field+" must be "+maxText+" or less")
+}
+
+// currency checks that a currency is in the currency catalog
+func (v *validation) currency(field, currency string) {
+	v.check(IsCurrency(currency), field, "currency", "",
+		field+" must be a supported ISO 4217 currency code, not \""+currency+"\"")
+}
+
+// oneOf checks that a field has one of the allowed values
+func (v *validation) oneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, field, "oneof", strings.Join(allowed, " "),
+		field+" must be one of ["+strings.Join(allowed, " ")+"]")
+}
+
+// err returns the broken rules as a *ValidationError, or nil if there are none
+func (v *validation) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Wallet types
+const (
+	WalletTypeCash    = "cash"
+	WalletTypeBank    = "bank"
+	WalletTypeEWallet = "ewallet"
+	WalletTypeOther   = "other"
+)
+
+// Wallet is where a user keeps money, such as cash, a bank account, or an e-wallet.
+// Money flows recorded in a wallet are in its currency.
+type Wallet struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Name     string
+	Type     string
+	Currency string
+
+	// OpeningBalance is the balance before the first money flow recorded in the
+	// wallet, in minor units of Currency
+	OpeningBalance int64
+
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WalletTotal sums the money flows recorded in one wallet, in minor units of Currency.
+// Inflow counts incoming transfers; Outflow counts expenses and outgoing transfers.
+type WalletTotal struct {
+	WalletID uuid.UUID
+	Currency string
+	Inflow   int64
+	Outflow  int64
+	Count    int64
+}
+
+// NewWallet creates a new Wallet entity with an opening balance in major units of the
+// currency. An invalid field returns a *ValidationError.
+func NewWallet(userID uuid.UUID, name, walletType, currency string, openingBalance float64) (*Wallet, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	now := time.Now()
+	wallet := &Wallet{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Currency:  currency,
+		Version:   0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := wallet.Rename(name, walletType); err != nil {
+		return nil, err
+	}
+	if err := wallet.SetOpeningBalance(openingBalance); err != nil {
+		return nil, err
+	}
+	if err := wallet.Validate(); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// Validate checks the business rules of a wallet, returning a *ValidationError naming
+// the fields that break them
+func (w *Wallet) Validate() error {
+	var v validation
+	v.required("name", w.Name)
+	v.maxLength("name", w.Name, MaxWalletNameLength)
+	v.oneOf("type", w.Type, WalletTypeCash, WalletTypeBank, WalletTypeEWallet, WalletTypeOther)
+	v.currency("currency", w.Currency)
+	return v.err()
+}
+
+// Rename sets the name and type of the wallet; an empty type is other. An invalid name
+// or type returns a *ValidationError.
+func (w *Wallet) Rename(name, walletType string) error {
+	if walletType == "" {
+		walletType = WalletTypeOther
+	}
+
+	var v validation
+	v.required("name", name)
+	v.oneOf("type", walletType, WalletTypeCash, WalletTypeBank, WalletTypeEWallet, WalletTypeOther)
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	w.Name = strings.TrimSpace(name)
+	w.Type = walletType
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetOpeningBalance sets the opening balance, in major units of the wallet's currency.
+// It may be negative, e.g. for an overdrawn account. An amount that does not fit the
+// currency returns a *ValidationError.
+func (w *Wallet) SetOpeningBalance(amount float64) error {
+	minor, err := MinorUnits(amount, w.Currency)
+	if err != nil {
+		return invalidAmount("opening_balance", w.Currency, err)
+	}
+	w.OpeningBalance = minor
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// Balance returns the balance of the wallet after the money flows summed in total,
+// in minor units of its currency. A nil total means the wallet has no money flows.
+func (w *Wallet) Balance(total *WalletTotal) int64 {
+	if total == nil {
+		return w.OpeningBalance
+	}
+	return w.OpeningBalance + total.Inflow - total.Outflow
+}
+
+// IncrementVersion increments the version for optimistic locking
+func (w *Wallet) IncrementVersion() {
+	w.Version++
+	w.UpdatedAt = time.Now()
+}
+
+// NewTransfer creates the pair of money flows moving amount out of from and toAmount
+// into to, both in major units of the wallets' currencies. Between wallets of the same
+// currency, toAmount must be 0 or equal to amount.
+func NewTransfer(from, to *Wallet, amount, toAmount float64, description *string) (out, in *MoneyFlow, err error) {
+	if from.ID == to.ID {
+		return nil, nil, invalid("to_wallet_id", "nefield", "from_wallet_id", "to_wallet_id cannot be the same wallet as from_wallet_id")
+	}
+	if toAmount == 0 {
+		if from.Currency != to.Currency {
+			return nil, nil, invalid("to_amount", "required", "", "to_amount is required between wallets of different currencies")
+		}
+		toAmount = amount
+	}
+
+	out, err = NewMoneyFlow(from.UserID, amount, from.Currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	in, err = NewMoneyFlow(to.UserID, toAmount, to.Currency)
+	if err != nil {
+		return nil, nil, renameToAmount(err)
+	}
+	if from.Currency == to.Currency && in.Amount != out.Amount {
+		return nil, nil, invalid("to_amount", "eqfield", "amount", "to_amount must equal amount between wallets of the same currency")
+	}
+
+	transferID := uuid.New()
+	out.Kind, out.WalletID, out.TransferID = MoneyFlowKindTransferOut, &from.ID, &transferID
+	in.Kind, in.WalletID, in.TransferID = MoneyFlowKindTransferIn, &to.ID, &transferID
+	out.Description, in.Description = description, description
+	in.CreatedAt, in.UpdatedAt = out.CreatedAt, out.UpdatedAt
+	if err := out.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := in.Validate(); err != nil {
+		return nil, nil, renameToAmount(err)
+	}
+	return out, in, nil
+}
+
+// renameToAmount names the amount errors of the incoming money flow of a transfer after
+// the to_amount it was created from
+func renameToAmount(err error) error {
+	var invalidIn *ValidationError
+	if errors.As(err, &invalidIn) {
+		invalidIn.rename("amount", "to_amount")
+	}
+	return err
+}
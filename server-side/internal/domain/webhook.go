@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook event types
+const (
+	WebhookEventMoneyFlowCreated = "money_flow.created"
+	WebhookEventMoneyFlowUpdated = "money_flow.updated"
+	WebhookEventMoneyFlowDeleted = "money_flow.deleted"
+
+	// WebhookEventPing is sent by the test ping only; webhooks cannot subscribe to it
+	WebhookEventPing = "ping"
+)
+
+// WebhookEvents lists every event type a webhook can subscribe to
+var WebhookEvents = []string{WebhookEventMoneyFlowCreated, WebhookEventMoneyFlowUpdated, WebhookEventMoneyFlowDeleted}
+
+// Webhook is a URL a user registered to receive their events, e.g. to sync money flows
+// to a spreadsheet or another app. Requests are signed with the secret.
+type Webhook struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	URL       string
+	Secret    string
+	Events    []string
+	Active    bool
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewWebhook creates a new active Webhook entity; set its URL and events before saving it
+func NewWebhook(userID uuid.UUID, secret string) *Webhook {
+	now := time.Now()
+	return &Webhook{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Secret:    secret,
+		Active:    true,
+		Version:   0,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// SetURL sets the URL events are sent to. It must be an absolute http or https URL
+// without credentials.
+func (w *Webhook) SetURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if parsed.User != nil {
+		return errors.New("url must not contain credentials")
+	}
+
+	w.URL = parsed.String()
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetEvents sets the event types the webhook receives, dropping duplicates
+func (w *Webhook) SetEvents(events []string) error {
+	if len(events) == 0 {
+		return errors.New("at least one event is required")
+	}
+	unique := make([]string, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return errors.New("unknown event: " + event)
+		}
+		if !slices.Contains(unique, event) {
+			unique = append(unique, event)
+		}
+	}
+
+	w.Events = unique
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// Subscribes checks if the webhook is active and receives the event type
+func (w *Webhook) Subscribes(eventType string) bool {
+	return w.Active && slices.Contains(w.Events, eventType)
+}
+
+// IncrementVersion increments the version for optimistic locking
+func (w *Webhook) IncrementVersion() {
+	w.Version++
+	w.UpdatedAt = time.Now()
+}
+
+// WebhookDelivery is the outcome of one attempt to send an event to a webhook
+type WebhookDelivery struct {
+	ID         uuid.UUID
+	WebhookID  uuid.UUID
+	EventID    uuid.UUID
+	EventType  string
+	Attempt    int
+	StatusCode *int    // nil when no response was received
+	Error      *string // why the attempt failed
+	Duration   time.Duration
+	Succeeded  bool
+	CreatedAt  time.Time
+}
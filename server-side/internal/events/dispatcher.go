@@ -0,0 +1,256 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+)
+
+// JobDeliverEvent delivers an event to one subscriber
+const JobDeliverEvent = "event.deliver"
+
+// cleanupInterval is how often old dispatched events are deleted
+const cleanupInterval = time.Hour
+
+// Handler reacts to an event. Returning an error retries the delivery with backoff;
+// wrap the error with worker.Permanent to dead-letter it immediately. An event can be
+// delivered more than once, so handlers must tolerate duplicates.
+type Handler func(ctx context.Context, event *Event) error
+
+// JobEnqueuer stores background jobs
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...worker.EnqueueOption) (*worker.Job, error)
+}
+
+// Config holds the settings of the dispatcher
+type Config struct {
+	// BatchSize is the number of events loaded from the outbox per poll
+	BatchSize int
+
+	// PollInterval is how long to wait before polling again when the outbox is empty.
+	// Events emitted by this process are dispatched right after they commit; polling
+	// picks up the events of other processes.
+	PollInterval time.Duration
+
+	// Retention is how long dispatched events are kept before deletion
+	Retention time.Duration
+}
+
+// subscriber is a feature reacting to events of some types
+type subscriber struct {
+	handler Handler
+	types   []string
+}
+
+// Dispatcher writes domain events to the outbox and delivers them to their subscribers.
+// A nil *Dispatcher emits nothing.
+type Dispatcher struct {
+	store       Store
+	jobs        JobEnqueuer
+	txManager   repository.TransactionManager
+	config      Config
+	subscribers map[string]*subscriber
+	wake        chan struct{}
+}
+
+// NewDispatcher creates a new dispatcher
+func NewDispatcher(store Store, jobs JobEnqueuer, txManager repository.TransactionManager, config Config) *Dispatcher {
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+
+	return &Dispatcher{
+		store:       store,
+		jobs:        jobs,
+		txManager:   txManager,
+		config:      config,
+		subscribers: make(map[string]*subscriber),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registers the handler of a subscriber for the given event types. The name
+// identifies the deliveries of the subscriber in the job queue, so keep it stable: the
+// queued deliveries of a renamed subscriber are dead-lettered. Register every subscriber
+// before Run; events dispatched before a subscriber is registered are not delivered to it.
+func (d *Dispatcher) Subscribe(name string, handler Handler, eventTypes ...string) {
+	d.subscribers[name] = &subscriber{
+		handler: handler,
+		types:   eventTypes,
+	}
+}
+
+// Emit writes an event to the outbox. Called in a transaction, the event is only
+// delivered once the transaction commits.
+func (d *Dispatcher) Emit(ctx context.Context, eventType string, userID *uuid.UUID, payload interface{}) error {
+	if d == nil {
+		return nil
+	}
+
+	event, err := NewEvent(eventType, userID, payload)
+	if err != nil {
+		return err
+	}
+	if err := d.store.Append(ctx, event); err != nil {
+		return err
+	}
+
+	repository.AfterCommit(ctx, d.notify)
+	return nil
+}
+
+// notify wakes Run to dispatch a committed event without waiting for the next poll
+func (d *Dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches committed events until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	log := logger.FromContext(ctx).With("component", "event_dispatcher")
+
+	lastCleanup := time.Time{}
+	for {
+		if time.Since(lastCleanup) >= cleanupInterval {
+			d.cleanup(ctx, log)
+			lastCleanup = time.Now()
+		}
+
+		// A full batch means more events may be waiting
+		if d.dispatchBatch(ctx, log) == d.config.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-time.After(d.config.PollInterval):
+		}
+	}
+}
+
+// dispatchBatch dispatches the oldest undispatched events and returns how many were
+// dispatched. Events that fail stay in the outbox and are tried again on the next poll.
+func (d *Dispatcher) dispatchBatch(ctx context.Context, log *slog.Logger) int {
+	events, err := d.store.FindUndispatched(ctx, d.config.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Warn("failed to poll events", "error", err)
+		}
+		return 0
+	}
+
+	dispatched := 0
+	for _, event := range events {
+		err := d.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			return d.dispatch(txCtx, event)
+		})
+		if err != nil {
+			if !errors.Is(err, domain.ErrConflict) && ctx.Err() == nil {
+				log.Warn("failed to dispatch event", "event_id", event.ID, "event_type", event.Type, "error", err)
+			}
+			continue
+		}
+		dispatched++
+	}
+	return dispatched
+}
+
+// dispatch marks an event dispatched and queues a delivery to each of its subscribers,
+// in the transaction of ctx so either both happen or neither does
+func (d *Dispatcher) dispatch(ctx context.Context, event *Event) error {
+	if err := d.store.MarkDispatched(ctx, event, time.Now()); err != nil {
+		return err
+	}
+
+	for _, name := range d.subscriberNames(event.Type) {
+		payload := delivery{
+			Subscriber: name,
+			EventID:    event.ID,
+			Type:       event.Type,
+			UserID:     event.UserID,
+			Payload:    event.Payload,
+			OccurredAt: event.OccurredAt,
+		}
+		if _, err := d.jobs.Enqueue(ctx, JobDeliverEvent, payload); err != nil {
+			return fmt.Errorf("failed to queue delivery to %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// subscriberNames returns the names of the subscribers of an event type, sorted
+func (d *Dispatcher) subscriberNames(eventType string) []string {
+	var names []string
+	for name, sub := range d.subscribers {
+		if slices.Contains(sub.types, eventType) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// cleanup deletes events dispatched before the retention period
+func (d *Dispatcher) cleanup(ctx context.Context, log *slog.Logger) {
+	deleted, err := d.store.DeleteDispatched(ctx, time.Now().Add(-d.config.Retention), 1000)
+	if err != nil && ctx.Err() == nil {
+		log.Warn("failed to delete dispatched events", "error", err)
+	} else if deleted > 0 {
+		log.Info("deleted dispatched events", "count", deleted)
+	}
+}
+
+// delivery is the payload of a JobDeliverEvent. It carries the event, so a delivery
+// does not depend on the event still being in the outbox.
+type delivery struct {
+	Subscriber string          `json:"subscriber"`
+	EventID    uuid.UUID       `json:"event_id"`
+	Type       string          `json:"type"`
+	UserID     *uuid.UUID      `json:"user_id,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// HandleDeliveryJob processes a JobDeliverEvent by calling the handler of its subscriber
+func (d *Dispatcher) HandleDeliveryJob(ctx context.Context, job *worker.Job) error {
+	var payload delivery
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	sub, ok := d.subscribers[payload.Subscriber]
+	if !ok {
+		return worker.Permanent(fmt.Errorf("no event subscriber named %q", payload.Subscriber))
+	}
+
+	return sub.handler(ctx, &Event{
+		ID:         payload.EventID,
+		Type:       payload.Type,
+		UserID:     payload.UserID,
+		Payload:    payload.Payload,
+		OccurredAt: payload.OccurredAt,
+	})
+}
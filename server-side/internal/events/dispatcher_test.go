@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/memory"
+	"github.com/ingunawandra/catetin/internal/worker"
+)
+
+// fakeStore keeps the outbox in memory
+type fakeStore struct {
+	mu     sync.Mutex
+	events []*Event
+}
+
+func (s *fakeStore) Append(ctx context.Context, event *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *fakeStore) FindUndispatched(ctx context.Context, limit int) ([]*Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var found []*Event
+	for _, event := range s.events {
+		if event.DispatchedAt == nil && len(found) < limit {
+			copied := *event
+			found = append(found, &copied)
+		}
+	}
+	return found, nil
+}
+
+func (s *fakeStore) MarkDispatched(ctx context.Context, event *Event, dispatchedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, stored := range s.events {
+		if stored.ID == event.ID {
+			if stored.DispatchedAt != nil {
+				return domain.ErrConflict
+			}
+			stored.DispatchedAt = &dispatchedAt
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (s *fakeStore) DeleteDispatched(ctx context.Context, dispatchedBefore time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// fakeJobs records enqueued jobs
+type fakeJobs struct {
+	jobs []*worker.Job
+}
+
+func (q *fakeJobs) Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...worker.EnqueueOption) (*worker.Job, error) {
+	job, err := worker.NewJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	q.jobs = append(q.jobs, job)
+	return job, nil
+}
+
+func TestDispatcherDeliversEventsToTheirSubscribers(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	store := &fakeStore{}
+	jobs := &fakeJobs{}
+	dispatcher := NewDispatcher(store, jobs, memory.NewTransactionManager(memory.NewStore()), Config{})
+
+	received := make(map[string][]*Event)
+	subscribe := func(name string, eventTypes ...string) {
+		dispatcher.Subscribe(name, func(ctx context.Context, event *Event) error {
+			received[name] = append(received[name], event)
+			return nil
+		}, eventTypes...)
+	}
+	subscribe("analytics", UserRegistered, MoneyFlowCreated)
+	subscribe("welcome", UserRegistered)
+
+	userID := uuid.New()
+	if err := dispatcher.Emit(ctx, MoneyFlowCreated, &userID, MoneyFlowPayload{UserID: userID, Kind: "expense"}); err != nil {
+		t.Fatalf("Emit() error = %v", err)
+	}
+
+	if n := dispatcher.dispatchBatch(ctx, log); n != 1 {
+		t.Fatalf("dispatchBatch() = %d, want 1", n)
+	}
+	if n := dispatcher.dispatchBatch(ctx, log); n != 0 {
+		t.Errorf("dispatchBatch(again) = %d, want the event dispatched only once", n)
+	}
+	if len(jobs.jobs) != 1 {
+		t.Fatalf("%d deliveries queued, want 1 for the only subscriber of %s", len(jobs.jobs), MoneyFlowCreated)
+	}
+
+	if err := dispatcher.HandleDeliveryJob(ctx, jobs.jobs[0]); err != nil {
+		t.Fatalf("HandleDeliveryJob() error = %v", err)
+	}
+	if len(received["analytics"]) != 1 || len(received["welcome"]) != 0 {
+		t.Fatalf("received = %v, want one event for analytics only", received)
+	}
+
+	event := received["analytics"][0]
+	var payload MoneyFlowPayload
+	if err := event.Decode(&payload); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if event.Type != MoneyFlowCreated || event.UserID == nil || *event.UserID != userID || payload.Kind != "expense" {
+		t.Errorf("delivered event = %+v with payload %+v, want the emitted event", event, payload)
+	}
+}
+
+func TestDeliveryToUnknownSubscriberIsPermanent(t *testing.T) {
+	dispatcher := NewDispatcher(&fakeStore{}, &fakeJobs{}, memory.NewTransactionManager(memory.NewStore()), Config{})
+
+	job, err := worker.NewJob(JobDeliverEvent, delivery{Subscriber: "renamed", EventID: uuid.New(), Type: UserRegistered})
+	if err != nil {
+		t.Fatalf("NewJob() error = %v", err)
+	}
+	if err := dispatcher.HandleDeliveryJob(context.Background(), job); !worker.IsPermanent(err) {
+		t.Errorf("HandleDeliveryJob() error = %v, want a permanent error", err)
+	}
+
+	var nilDispatcher *Dispatcher
+	if err := nilDispatcher.Emit(context.Background(), UserRegistered, nil, UserRegisteredPayload{}); err != nil {
+		t.Errorf("nil Dispatcher Emit() error = %v, want nil", err)
+	}
+}
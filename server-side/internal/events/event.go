@@ -0,0 +1,130 @@
+// Package events carries domain events, such as a user registering or a money flow being
+// recorded, from the services that emit them to the features that react to them:
+// notifications, analytics, and webhooks.
+//
+// Services emit events in the transaction of the change, which writes them to an outbox
+// table together with the change itself (the transactional outbox). The Dispatcher reads
+// committed events from the outbox and hands each to its subscribers as background jobs,
+// so an event is delivered at least once to every subscriber, retried with backoff and
+// dead-lettered like any other job, and a failing subscriber does not hold up the others.
+// Events of a transaction that rolls back are never delivered.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types
+const (
+	// UserRegistered is emitted when a user signs up; the payload is a UserRegisteredPayload
+	UserRegistered = "user.registered"
+
+	// MoneyFlowCreated is emitted when a user records a money flow; the payload is a
+	// MoneyFlowPayload
+	MoneyFlowCreated = "money_flow.created"
+
+	// MoneyFlowUpdated is emitted when a user changes a money flow; the payload is a
+	// MoneyFlowPayload of the money flow after the change
+	MoneyFlowUpdated = "money_flow.updated"
+
+	// MoneyFlowDeleted is emitted when a user deletes a money flow; the payload is a
+	// MoneyFlowPayload of the deleted money flow
+	MoneyFlowDeleted = "money_flow.deleted"
+
+	// BudgetExceeded is emitted when a money flow first takes the spending of a budget
+	// over its cap in a period; the payload is a BudgetExceededPayload
+	BudgetExceeded = "budget.exceeded"
+)
+
+// Event is something that happened in the domain. Payload is the JSON document
+// subscribers decode.
+type Event struct {
+	ID           uuid.UUID
+	Type         string
+	UserID       *uuid.UUID // the user the event is about, if any
+	Payload      json.RawMessage
+	OccurredAt   time.Time
+	DispatchedAt *time.Time
+}
+
+// NewEvent creates a new undispatched Event
+func NewEvent(eventType string, userID *uuid.UUID, payload interface{}) (*Event, error) {
+	if eventType == "" {
+		return nil, errors.New("event type is required")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		UserID:     userID,
+		Payload:    data,
+		OccurredAt: time.Now(),
+	}, nil
+}
+
+// Decode unmarshals the payload into v
+func (e *Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// UserRegisteredPayload is the payload of a UserRegistered event
+type UserRegisteredPayload struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Provider string    `json:"provider"` // auth provider the user signed up with
+}
+
+// MoneyFlowPayload is the payload of the money flow events
+type MoneyFlowPayload struct {
+	MoneyFlowID uuid.UUID  `json:"money_flow_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Kind        string     `json:"kind"`
+	Amount      float64    `json:"amount"`
+	Currency    string     `json:"currency"`
+	Category    *string    `json:"category"`
+	Description *string    `json:"description"`
+	Tags        []string   `json:"tags"`
+	WalletID    *uuid.UUID `json:"wallet_id"`
+	GroupID     *uuid.UUID `json:"group_id"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// BudgetExceededPayload is the payload of a BudgetExceeded event. Amounts are in major
+// units of the budget's currency.
+type BudgetExceededPayload struct {
+	BudgetID    uuid.UUID `json:"budget_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Category    string    `json:"category"`
+	Currency    string    `json:"currency"`
+	Hard        bool      `json:"hard"`
+	Cap         float64   `json:"cap"`
+	Spent       float64   `json:"spent"` // including the money flow
+	MoneyFlowID uuid.UUID `json:"money_flow_id"`
+}
+
+// Store keeps the outbox. Append must write through the transaction of the context, so
+// an event commits or rolls back together with the change it describes.
+type Store interface {
+	// Append stores a new event
+	Append(ctx context.Context, event *Event) error
+
+	// FindUndispatched finds events not dispatched yet, oldest first
+	FindUndispatched(ctx context.Context, limit int) ([]*Event, error)
+
+	// MarkDispatched records that an event was handed to its subscribers.
+	// Returns domain.ErrConflict if it was already marked, e.g. by another instance.
+	MarkDispatched(ctx context.Context, event *Event, dispatchedAt time.Time) error
+
+	// DeleteDispatched deletes up to limit events dispatched before the given time
+	DeleteDispatched(ctx context.Context, dispatchedBefore time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,132 @@
+// Package i18n localizes the texts users read, such as API messages, WhatsApp replies,
+// and spending digests, with message catalogs embedded in the binary
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Language is a supported message language
+type Language string
+
+const (
+	English    Language = "en"
+	Indonesian Language = "id"
+)
+
+// DefaultLanguage is used when neither the client nor the user settings name a
+// supported language
+const DefaultLanguage = English
+
+// locales holds a catalog per language, locales/<language>.json, mapping message keys to
+// texts. API messages are keyed by their English text, so the English catalog only
+// holds the texts whose key is an identifier such as "chat.unlinked".
+//
+//go:embed locales/*.json
+var locales embed.FS
+
+// catalogs holds the catalog of each supported language
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[Language]map[string]string {
+	entries, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[Language]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := locales.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: locales/%s: %v", entry.Name(), err))
+		}
+		loaded[Language(strings.TrimSuffix(entry.Name(), ".json"))] = catalog
+	}
+	return loaded
+}
+
+// Supported reports whether a language has a catalog
+func Supported(language Language) bool {
+	_, ok := catalogs[language]
+	return ok
+}
+
+// T returns the text of a message in a language. A text missing from the catalog of the
+// language falls back to the English one, then to the key itself, so an API message that
+// is not translated yet is shown in English. Args fill in the verbs of the text like
+// fmt.Sprintf.
+func T(language Language, key string, args ...interface{}) string {
+	text, ok := catalogs[language][key]
+	if !ok {
+		if text, ok = catalogs[DefaultLanguage][key]; !ok {
+			text = key
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// ParseAcceptLanguage returns the supported language the client prefers most, following
+// the q-values of an Accept-Language header, and false if the header names none
+func ParseAcceptLanguage(header string) (Language, bool) {
+	best, bestQ := DefaultLanguage, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if language := baseLanguage(tag); Supported(language) && q > bestQ {
+			best, bestQ = language, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// FromLocale returns the language of a BCP 47 locale such as "id-ID", or DefaultLanguage
+// if it is not supported
+func FromLocale(locale string) Language {
+	if language := baseLanguage(locale); Supported(language) {
+		return language
+	}
+	return DefaultLanguage
+}
+
+// baseLanguage returns the primary language subtag of a language tag, in lower case
+func baseLanguage(tag string) Language {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	base, _, _ = strings.Cut(base, "_")
+	return Language(base)
+}
+
+type contextKey struct{}
+
+// WithLanguage returns a context carrying the language of the user being served
+func WithLanguage(ctx context.Context, language Language) context.Context {
+	return context.WithValue(ctx, contextKey{}, language)
+}
+
+// FromContext returns the language stored by WithLanguage, or DefaultLanguage
+func FromContext(ctx context.Context) Language {
+	if language, ok := ctx.Value(contextKey{}).(Language); ok {
+		return language
+	}
+	return DefaultLanguage
+}
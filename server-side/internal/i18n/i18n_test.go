@@ -0,0 +1,158 @@
+package i18n
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// verb matches the fmt verbs of a text
+var verb = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+func TestCatalogsTranslateTheSameMessages(t *testing.T) {
+	if !Supported(English) || !Supported(Indonesian) {
+		t.Fatalf("catalogs = %v, want en and id", catalogs)
+	}
+
+	for language, catalog := range catalogs {
+		for key, text := range catalog {
+			// Texts keyed by an identifier must exist in every language
+			if !isIdentifier(key) {
+				continue
+			}
+			for other, otherCatalog := range catalogs {
+				translated, ok := otherCatalog[key]
+				if !ok {
+					t.Errorf("%s is in locales/%s.json but not in locales/%s.json", key, language, other)
+					continue
+				}
+				if got, want := verb.FindAllString(translated, -1), verb.FindAllString(text, -1); strings.Join(got, " ") != strings.Join(want, " ") {
+					t.Errorf("%s has verbs %v in %s but %v in %s", key, got, other, want, language)
+				}
+			}
+		}
+	}
+}
+
+func TestAPIMessagesAreTranslated(t *testing.T) {
+	var messages []string
+
+	// Success messages of the handlers, as passed to RespondWithSuccess
+	handlers, err := filepath.Glob("../controller/http/v1/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range handlers {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		messages = append(messages, stringArguments(t, path, "RespondWithSuccess", 2)...)
+	}
+	if len(messages) == 0 {
+		t.Fatal("found no success messages")
+	}
+
+	// Messages of the predefined errors
+	errorMessages := stringArguments(t, "../../pkg/errors/errors.go", "New", 1)
+	if len(errorMessages) == 0 {
+		t.Fatal("found no error messages")
+	}
+	messages = append(messages, errorMessages...)
+
+	for _, message := range messages {
+		if _, ok := catalogs[Indonesian][message]; !ok {
+			t.Errorf("%q has no translation in locales/id.json", message)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got, want := T(Indonesian, "chat.expense.recorded", "x"), "Tercatat: x"; got != want {
+		t.Errorf("T(id) = %q, want %q", got, want)
+	}
+	if got, want := T(Language("fr"), "chat.expense.valid_for", 5), "Valid for 5 minutes"; got != want {
+		t.Errorf("T(fr) = %q, want the English %q", got, want)
+	}
+	if got, want := T(Indonesian, "Failed to do something"), "Failed to do something"; got != want {
+		t.Errorf("T(untranslated) = %q, want the key %q", got, want)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Language
+		ok     bool
+	}{
+		{header: "id-ID,id;q=0.9,en;q=0.8", want: Indonesian, ok: true},
+		{header: "en-US;q=0.5, id;q=0.7", want: Indonesian, ok: true},
+		{header: "fr-FR, en;q=0.1", want: English, ok: true},
+		{header: "fr-FR", want: DefaultLanguage},
+		{header: "", want: DefaultLanguage},
+	}
+	for _, tt := range tests {
+		if got, ok := ParseAcceptLanguage(tt.header); got != tt.want || ok != tt.ok {
+			t.Errorf("ParseAcceptLanguage(%q) = %s, %v; want %s, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if got := FromLocale("id-ID"); got != Indonesian {
+		t.Errorf("FromLocale(id-ID) = %s, want id", got)
+	}
+	if got := FromLocale("ja-JP"); got != DefaultLanguage {
+		t.Errorf("FromLocale(ja-JP) = %s, want %s", got, DefaultLanguage)
+	}
+	if got := FromContext(WithLanguage(context.Background(), Indonesian)); got != Indonesian {
+		t.Errorf("FromContext() = %s, want id", got)
+	}
+}
+
+// isIdentifier reports whether a message key is an identifier such as "chat.unlinked"
+// rather than an English text
+func isIdentifier(key string) bool {
+	return !strings.ContainsAny(key, " ")
+}
+
+// stringArguments returns the string literals passed as the argument at index to calls
+// of a function or method named name in a Go file
+func stringArguments(t *testing.T, path, name string, index int) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) <= index {
+			return true
+		}
+		switch fun := call.Fun.(type) {
+		case *ast.Ident:
+			if fun.Name != name {
+				return true
+			}
+		case *ast.SelectorExpr:
+			if fun.Sel.Name != name {
+				return true
+			}
+		default:
+			return true
+		}
+		if literal, ok := call.Args[index].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+			if value, err := strconv.Unquote(literal.Value); err == nil {
+				values = append(values, value)
+			}
+		}
+		return true
+	})
+	return values
+}
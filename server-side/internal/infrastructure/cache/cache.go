@@ -0,0 +1,106 @@
+// Package cache caches the results of expensive queries, wrapping repositories so
+// services are unaware of it. Cached values are invalidated when the repository writes
+// the data they were read from.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// ErrMiss is returned by Cache.Get when a key is not set
+var ErrMiss = errors.New("cache miss")
+
+// keyPrefix namespaces the keys of this service in a shared Redis
+const keyPrefix = "catetin:"
+
+// Cache stores values under keys for a limited time
+type Cache interface {
+	// Get returns the value of a key, or ErrMiss if it is not set or expired
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set sets the value of a key, expiring after ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+
+	// Incr increments the integer value of a key, starting from 0, and returns the new value
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// load returns the cached value of a key, or calls fetch and caches its result.
+// Cache failures are logged and fall back to fetch, so an unavailable cache only makes
+// requests slower. Reads inside a transaction bypass the cache, as they may see
+// uncommitted writes.
+func load[T any](ctx context.Context, c Cache, key string, ttl time.Duration, fetch func() (T, error)) (T, error) {
+	if repository.GetTransactionFromContext(ctx) != nil {
+		return fetch()
+	}
+
+	var value T
+	data, err := c.Get(ctx, key)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		logger.FromContext(ctx).Warn("cached value is invalid", "key", key, "error", err)
+	case !errors.Is(err, ErrMiss):
+		logger.FromContext(ctx).Warn("cache read failed", "key", key, "error", err)
+	}
+
+	value, err = fetch()
+	if err != nil {
+		return value, err
+	}
+
+	if data, err := json.Marshal(value); err != nil {
+		logger.FromContext(ctx).Warn("failed to encode cached value", "key", key, "error", err)
+	} else if err := c.Set(ctx, key, data, ttl); err != nil {
+		logger.FromContext(ctx).Warn("cache write failed", "key", key, "error", err)
+	}
+	return value, nil
+}
+
+// invalidate deletes keys once the transaction of ctx commits, so no request can cache
+// the data they held again before the write is visible. A failed delete leaves the
+// value stale until it expires.
+func invalidate(ctx context.Context, c Cache, keys ...string) {
+	repository.AfterCommit(ctx, func() {
+		ctx := context.WithoutCancel(ctx)
+		if err := c.Delete(ctx, keys...); err != nil {
+			logger.FromContext(ctx).Warn("cache invalidation failed", "keys", keys, "error", err)
+		}
+	})
+}
+
+// bump increments generation keys once the transaction of ctx commits, moving every
+// key built from them to a fresh namespace; the old keys expire on their own
+func bump(ctx context.Context, c Cache, keys ...string) {
+	repository.AfterCommit(ctx, func() {
+		ctx := context.WithoutCancel(ctx)
+		for _, key := range keys {
+			if _, err := c.Incr(ctx, key); err != nil {
+				logger.FromContext(ctx).Warn("cache invalidation failed", "key", key, "error", err)
+			}
+		}
+	})
+}
+
+// generation returns the current value of a generation key, "0" until it is first bumped
+func generation(ctx context.Context, c Cache, key string) (string, error) {
+	data, err := c.Get(ctx, key)
+	if errors.Is(err, ErrMiss) {
+		return "0", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// moneyFlowRepository caches the totals behind summaries, reports, and wallet balances,
+// which aggregate every money flow of a user or group. The totals of a user or group
+// are keyed by a generation that every write to their money flows bumps, so one write
+// invalidates all periods at once. Other methods pass through uncached.
+type moneyFlowRepository struct {
+	repository.MoneyFlowRepository
+	cache Cache
+	ttl   time.Duration
+}
+
+// NewMoneyFlowRepository wraps a money flow repository with a cache of its totals
+func NewMoneyFlowRepository(next repository.MoneyFlowRepository, cache Cache, ttl time.Duration) repository.MoneyFlowRepository {
+	return &moneyFlowRepository{MoneyFlowRepository: next, cache: cache, ttl: ttl}
+}
+
+func (r *moneyFlowRepository) Create(ctx context.Context, moneyFlow *domain.MoneyFlow) error {
+	if err := r.MoneyFlowRepository.Create(ctx, moneyFlow); err != nil {
+		return err
+	}
+	r.invalidate(ctx, moneyFlow)
+	return nil
+}
+
+func (r *moneyFlowRepository) CreateBatch(ctx context.Context, moneyFlows []*domain.MoneyFlow) error {
+	if err := r.MoneyFlowRepository.CreateBatch(ctx, moneyFlows); err != nil {
+		return err
+	}
+	r.invalidate(ctx, moneyFlows...)
+	return nil
+}
+
+func (r *moneyFlowRepository) Update(ctx context.Context, moneyFlow *domain.MoneyFlow) error {
+	// The money flow may move out of a group, whose totals change as well
+	before, err := r.MoneyFlowRepository.FindByID(ctx, moneyFlow.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	if err := r.MoneyFlowRepository.Update(ctx, moneyFlow); err != nil {
+		return err
+	}
+	if before != nil {
+		r.invalidate(ctx, before)
+	}
+	r.invalidate(ctx, moneyFlow)
+	return nil
+}
+
+func (r *moneyFlowRepository) PartialUpdate(ctx context.Context, moneyFlow *domain.MoneyFlow, fields []string) error {
+	before, err := r.MoneyFlowRepository.FindByID(ctx, moneyFlow.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	if err := r.MoneyFlowRepository.PartialUpdate(ctx, moneyFlow, fields); err != nil {
+		return err
+	}
+	if before != nil {
+		r.invalidate(ctx, before)
+	}
+	r.invalidate(ctx, moneyFlow)
+	return nil
+}
+
+func (r *moneyFlowRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	before, err := r.MoneyFlowRepository.FindByID(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	if err := r.MoneyFlowRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	if before != nil {
+		r.invalidate(ctx, before)
+	}
+	return nil
+}
+
+func (r *moneyFlowRepository) DeleteByTransferID(ctx context.Context, transferID uuid.UUID) error {
+	before, err := r.MoneyFlowRepository.FindByTransferID(ctx, transferID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.MoneyFlowRepository.DeleteByTransferID(ctx, transferID); err != nil {
+		return err
+	}
+	r.invalidate(ctx, before...)
+	return nil
+}
+
+// DeleteByUserID invalidates the totals of the user only; the totals of the groups the
+// user recorded money flows in catch up when they expire
+func (r *moneyFlowRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	if err := r.MoneyFlowRepository.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+	bump(ctx, r.cache, userGenerationKey(userID))
+	return nil
+}
+
+func (r *moneyFlowRepository) GetTotalsByCurrency(ctx context.Context, userID uuid.UUID) ([]*domain.CurrencyTotal, error) {
+	key, ok := r.key(ctx, userGenerationKey(userID), "currency")
+	if !ok {
+		return r.MoneyFlowRepository.GetTotalsByCurrency(ctx, userID)
+	}
+	return load(ctx, r.cache, key, r.ttl, func() ([]*domain.CurrencyTotal, error) {
+		return r.MoneyFlowRepository.GetTotalsByCurrency(ctx, userID)
+	})
+}
+
+func (r *moneyFlowRepository) GetTotalsByCategory(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	key, ok := r.periodKey(ctx, userGenerationKey(userID), start, end)
+	if !ok {
+		return r.MoneyFlowRepository.GetTotalsByCategory(ctx, userID, start, end)
+	}
+	return load(ctx, r.cache, key, r.ttl, func() ([]*domain.CategoryTotal, error) {
+		return r.MoneyFlowRepository.GetTotalsByCategory(ctx, userID, start, end)
+	})
+}
+
+func (r *moneyFlowRepository) GetGroupTotalsByCategory(ctx context.Context, groupID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	key, ok := r.periodKey(ctx, groupGenerationKey(groupID), start, end)
+	if !ok {
+		return r.MoneyFlowRepository.GetGroupTotalsByCategory(ctx, groupID, start, end)
+	}
+	return load(ctx, r.cache, key, r.ttl, func() ([]*domain.CategoryTotal, error) {
+		return r.MoneyFlowRepository.GetGroupTotalsByCategory(ctx, groupID, start, end)
+	})
+}
+
+func (r *moneyFlowRepository) GetTotalsByWallet(ctx context.Context, userID uuid.UUID) ([]*domain.WalletTotal, error) {
+	key, ok := r.key(ctx, userGenerationKey(userID), "wallet")
+	if !ok {
+		return r.MoneyFlowRepository.GetTotalsByWallet(ctx, userID)
+	}
+	return load(ctx, r.cache, key, r.ttl, func() ([]*domain.WalletTotal, error) {
+		return r.MoneyFlowRepository.GetTotalsByWallet(ctx, userID)
+	})
+}
+
+// invalidate bumps the generations of the users and groups of the money flows
+func (r *moneyFlowRepository) invalidate(ctx context.Context, moneyFlows ...*domain.MoneyFlow) {
+	seen := map[string]bool{}
+	var keys []string
+	for _, moneyFlow := range moneyFlows {
+		owners := []string{userGenerationKey(moneyFlow.UserID)}
+		if moneyFlow.GroupID != nil {
+			owners = append(owners, groupGenerationKey(*moneyFlow.GroupID))
+		}
+		for _, key := range owners {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	bump(ctx, r.cache, keys...)
+}
+
+// key builds the key of a query under the current generation of its owner. It reports
+// false when the generation cannot be read, and the query is not cached.
+func (r *moneyFlowRepository) key(ctx context.Context, generationKey, query string) (string, bool) {
+	if repository.GetTransactionFromContext(ctx) != nil {
+		return "", false
+	}
+
+	gen, err := generation(ctx, r.cache, generationKey)
+	if err != nil {
+		logger.FromContext(ctx).Warn("cache read failed", "key", generationKey, "error", err)
+		return "", false
+	}
+	return fmt.Sprintf("%s:%s:%s", generationKey, gen, query), true
+}
+
+// periodKey is key for totals in [start, end). All-time totals end at the time of the
+// request, so they would never be read again and are not cached.
+func (r *moneyFlowRepository) periodKey(ctx context.Context, generationKey string, start, end time.Time) (string, bool) {
+	if start.IsZero() {
+		return "", false
+	}
+	return r.key(ctx, generationKey, fmt.Sprintf("category:%d:%d", start.Unix(), end.Unix()))
+}
+
+func userGenerationKey(userID uuid.UUID) string {
+	return keyPrefix + "money_flows:user:" + userID.String()
+}
+
+func groupGenerationKey(groupID uuid.UUID) string {
+	return keyPrefix + "money_flows:group:" + groupID.String()
+}
@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// RedisConfig holds the Redis connection settings
+type RedisConfig struct {
+	// URL of the server, e.g. redis://:password@localhost:6379/0; rediss:// connects over TLS
+	URL string
+
+	// PoolSize is the number of connections kept open
+	PoolSize int
+
+	// Timeout bounds dialing and a single command
+	Timeout time.Duration
+}
+
+// Redis is a Cache backed by Redis
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis creates a new Redis client. Connections are opened on first use.
+func NewRedis(config RedisConfig) (*Redis, error) {
+	options, err := redis.ParseURL(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	if config.PoolSize <= 0 {
+		config.PoolSize = 10
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = time.Second
+	}
+
+	options.PoolSize = config.PoolSize
+	options.MaxIdleConns = config.PoolSize
+	options.DialTimeout = config.Timeout
+	options.ReadTimeout = config.Timeout
+	options.WriteTimeout = config.Timeout
+	options.PoolTimeout = config.Timeout
+	// Commands stop at the request's deadline when it comes before the timeout
+	options.ContextTimeoutEnabled = true
+
+	return &Redis{client: redis.NewClient(options)}, nil
+}
+
+// Get returns the value of a key, or ErrMiss if it is not set
+func (r *Redis) Get(ctx context.Context, key string) (value []byte, err error) {
+	ctx, span := tracing.Start(ctx, "Redis.GET")
+	defer func() { tracing.End(span, err) }()
+
+	value, err = r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return value, err
+}
+
+// Set sets the value of a key, expiring after ttl
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) (err error) {
+	ctx, span := tracing.Start(ctx, "Redis.SET")
+	defer func() { tracing.End(span, err) }()
+
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete removes keys; missing keys are ignored
+func (r *Redis) Delete(ctx context.Context, keys ...string) (err error) {
+	if len(keys) == 0 {
+		return nil
+	}
+	ctx, span := tracing.Start(ctx, "Redis.DEL")
+	defer func() { tracing.End(span, err) }()
+
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// Incr increments the integer value of a key, starting from 0, and returns the new value
+func (r *Redis) Incr(ctx context.Context, key string) (value int64, err error) {
+	ctx, span := tracing.Start(ctx, "Redis.INCR")
+	defer func() { tracing.End(span, err) }()
+
+	return r.client.Incr(ctx, key).Result()
+}
+
+// Ping checks that the server is reachable
+func (r *Redis) Ping(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "Redis.PING")
+	defer func() { tracing.End(span, err) }()
+
+	return r.client.Ping(ctx).Err()
+}
+
+// Close closes the connections
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestRedis(t *testing.T) (*Redis, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	r, err := NewRedis(RedisConfig{URL: "redis://" + server.Addr() + "/0", Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r, server
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	r, server := newTestRedis(t)
+
+	if _, err := r.Get(ctx, "missing"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get(missing) error = %v, want ErrMiss", err)
+	}
+
+	if err := r.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if value, err := r.Get(ctx, "key"); err != nil || string(value) != "value" {
+		t.Errorf("Get(key) = %q, %v, want value", value, err)
+	}
+	server.FastForward(time.Minute)
+	if _, err := r.Get(ctx, "key"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get(key) after its TTL error = %v, want ErrMiss", err)
+	}
+
+	for want := int64(1); want <= 2; want++ {
+		if value, err := r.Incr(ctx, "counter"); err != nil || value != want {
+			t.Errorf("Incr() = %d, %v, want %d", value, err, want)
+		}
+	}
+	if err := r.Delete(ctx, "counter", "missing"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if server.Exists("counter") {
+		t.Error("Delete() left the key")
+	}
+
+	// Error replies are returned without breaking the connection
+	server.Set("text", "not a number")
+	if _, err := r.Incr(ctx, "text"); err == nil {
+		t.Error("Incr() of a non-integer succeeded")
+	}
+	if err := r.Ping(ctx); err != nil {
+		t.Errorf("Ping() after an error reply = %v", err)
+	}
+}
+
+func TestRedisReconnects(t *testing.T) {
+	ctx := context.Background()
+	r, server := newTestRedis(t)
+	if err := r.Ping(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	server.Close()
+	if err := r.Ping(ctx); err == nil {
+		t.Fatal("Ping() succeeded while the server is down")
+	}
+
+	if err := server.Restart(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Errorf("Set() after the server is back = %v", err)
+	}
+}
+
+func TestRedisHonoursContextDeadline(t *testing.T) {
+	r, _ := newTestRedis(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+
+	if _, err := r.Get(ctx, "key"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get() with an expired context error = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestNewRedisRejectsInvalidURLs(t *testing.T) {
+	for _, url := range []string{"localhost:6379", "http://localhost:6379", "redis://localhost:6379/db"} {
+		if _, err := NewRedis(RedisConfig{URL: url}); err == nil {
+			t.Errorf("NewRedis(%q) succeeded", url)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// userRepository caches the token versions of users, which are checked on every request
+// authenticated with an access token but only change when sessions are revoked. Other
+// methods pass through uncached.
+//
+// A token version is keyed by a generation that revoking bumps after commit. Deleting
+// the key instead would let a request that read the old version before the commit cache
+// it again afterwards, keeping revoked tokens valid until it expires; under a generation
+// that late write lands on a key no one reads anymore.
+type userRepository struct {
+	repository.UserRepository
+	cache Cache
+	ttl   time.Duration
+}
+
+// NewUserRepository wraps a user repository with a cache of token versions
+func NewUserRepository(next repository.UserRepository, cache Cache, ttl time.Duration) repository.UserRepository {
+	return &userRepository{UserRepository: next, cache: cache, ttl: ttl}
+}
+
+func (r *userRepository) FindTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	// The generation is read before the version, so a version read before a revocation
+	// commits is cached under the generation the revocation replaces
+	gen, err := generation(ctx, r.cache, tokenVersionGenerationKey(id))
+	if err != nil {
+		logger.FromContext(ctx).Warn("cache read failed", "key", tokenVersionGenerationKey(id), "error", err)
+		return r.UserRepository.FindTokenVersion(ctx, id)
+	}
+
+	// Deleted users are cached as null, so their tokens keep being rejected cheaply
+	version, err := load(ctx, r.cache, tokenVersionGenerationKey(id)+":"+gen, r.ttl, func() (*int, error) {
+		version, err := r.UserRepository.FindTokenVersion(ctx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return &version, err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if version == nil {
+		return 0, domain.ErrNotFound
+	}
+	return *version, nil
+}
+
+func (r *userRepository) IncrementTokenVersion(ctx context.Context, id uuid.UUID) error {
+	if err := r.UserRepository.IncrementTokenVersion(ctx, id); err != nil {
+		return err
+	}
+	bump(ctx, r.cache, tokenVersionGenerationKey(id))
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	bump(ctx, r.cache, tokenVersionGenerationKey(id))
+	return nil
+}
+
+func tokenVersionGenerationKey(userID uuid.UUID) string {
+	return keyPrefix + "token_version_generation:" + userID.String()
+}
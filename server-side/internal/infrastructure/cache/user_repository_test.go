@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// tokenVersions is a user repository holding token versions, calling during in the
+// middle of FindTokenVersion
+type tokenVersions struct {
+	repository.UserRepository
+	versions map[uuid.UUID]int
+	during   func()
+}
+
+func (t *tokenVersions) FindTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	version, ok := t.versions[id]
+	if during := t.during; during != nil {
+		t.during = nil
+		during()
+	}
+	if !ok {
+		return 0, domain.ErrNotFound
+	}
+	return version, nil
+}
+
+func (t *tokenVersions) IncrementTokenVersion(ctx context.Context, id uuid.UUID) error {
+	t.versions[id]++
+	return nil
+}
+
+func (t *tokenVersions) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(t.versions, id)
+	return nil
+}
+
+func TestUserRepositoryRevokesDuringRead(t *testing.T) {
+	ctx := context.Background()
+	redis, _ := newTestRedis(t)
+	id := uuid.New()
+	db := &tokenVersions{versions: map[uuid.UUID]int{id: 1}}
+	repo := NewUserRepository(db, redis, time.Hour)
+
+	// A request reads version 1 from the database; the revocation commits before it
+	// caches what it read
+	db.during = func() {
+		if err := repo.IncrementTokenVersion(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if version, err := repo.FindTokenVersion(ctx, id); err != nil || version != 1 {
+		t.Fatalf("FindTokenVersion() = %d, %v, want the version read before the revocation", version, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if version, err := repo.FindTokenVersion(ctx, id); err != nil || version != 2 {
+			t.Errorf("FindTokenVersion() after the revocation = %d, %v, want 2", version, err)
+		}
+	}
+
+	if err := repo.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.FindTokenVersion(ctx, id); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindTokenVersion() of a deleted user error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestUserRepositoryWithoutRedis(t *testing.T) {
+	ctx := context.Background()
+	redis, server := newTestRedis(t)
+	server.Close()
+	id := uuid.New()
+	repo := NewUserRepository(&tokenVersions{versions: map[uuid.UUID]int{id: 3}}, redis, time.Hour)
+
+	if version, err := repo.FindTokenVersion(ctx, id); err != nil || version != 3 {
+		t.Errorf("FindTokenVersion() = %d, %v, want the database's version", version, err)
+	}
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// userSettingsRepository caches user settings, which are read by most requests and
+// chat messages but rarely change
+type userSettingsRepository struct {
+	next  repository.UserSettingsRepository
+	cache Cache
+	ttl   time.Duration
+}
+
+// NewUserSettingsRepository wraps a user settings repository with a cache
+func NewUserSettingsRepository(next repository.UserSettingsRepository, cache Cache, ttl time.Duration) repository.UserSettingsRepository {
+	return &userSettingsRepository{next: next, cache: cache, ttl: ttl}
+}
+
+func (r *userSettingsRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	// Users who never changed a preference have no settings; that is cached as null
+	settings, err := load(ctx, r.cache, userSettingsKey(userID), r.ttl, func() (*domain.UserSettings, error) {
+		settings, err := r.next.FindByUserID(ctx, userID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return settings, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, domain.ErrNotFound
+	}
+	return settings, nil
+}
+
+func (r *userSettingsRepository) Create(ctx context.Context, settings *domain.UserSettings) error {
+	if err := r.next.Create(ctx, settings); err != nil {
+		return err
+	}
+	invalidate(ctx, r.cache, userSettingsKey(settings.UserID))
+	return nil
+}
+
+func (r *userSettingsRepository) Update(ctx context.Context, settings *domain.UserSettings) error {
+	if err := r.next.Update(ctx, settings); err != nil {
+		return err
+	}
+	invalidate(ctx, r.cache, userSettingsKey(settings.UserID))
+	return nil
+}
+
+func userSettingsKey(userID uuid.UUID) string {
+	return keyPrefix + "user_settings:" + userID.String()
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// errDuplicateAuthProviderName is returned when a provider is created with the name of
+// another provider, like the unique index on name
+var errDuplicateAuthProviderName = errors.New("memory: an auth provider with this name already exists")
+
+type authProviderRepositoryImpl struct {
+	store *Store
+}
+
+// NewAuthProviderRepository creates an auth provider repository storing providers in the store
+func NewAuthProviderRepository(store *Store) repository.AuthProviderRepository {
+	return &authProviderRepositoryImpl{store: store}
+}
+
+func (r *authProviderRepositoryImpl) FindByName(ctx context.Context, name string) (*repository.AuthProvider, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, record := range r.store.authProviders {
+		if record.Name != nil && *record.Name == name {
+			return cloneAuthProvider(record), nil
+		}
+	}
+
+	return nil, nil // Return nil if not found (not an error for this case)
+}
+
+func (r *authProviderRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*repository.AuthProvider, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	record, ok := r.store.authProviders[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return cloneAuthProvider(record), nil
+}
+
+func (r *authProviderRepositoryImpl) Create(ctx context.Context, provider *repository.AuthProvider) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	record := cloneAuthProvider(provider)
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+	if _, exists := r.store.authProviders[record.ID]; exists {
+		return errDuplicateID
+	}
+	if record.Name != nil {
+		for _, other := range r.store.authProviders {
+			if other.Name != nil && *other.Name == *record.Name {
+				return errDuplicateAuthProviderName
+			}
+		}
+	}
+	r.store.authProviders[record.ID] = record
+
+	provider.ID = record.ID
+	return nil
+}
+
+// cloneAuthProvider copies a provider so callers cannot modify stored records
+func cloneAuthProvider(provider *repository.AuthProvider) *repository.AuthProvider {
+	clone := *provider
+	clone.Name = clonePtr(provider.Name)
+	clone.Image = clonePtr(provider.Image)
+	clone.ClientID = clonePtr(provider.ClientID)
+	clone.ClientSecret = clonePtr(provider.ClientSecret)
+	return &clone
+}
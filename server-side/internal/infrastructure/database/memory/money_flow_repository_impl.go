@@ -0,0 +1,607 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type moneyFlowRepositoryImpl struct {
+	store *Store
+}
+
+// NewMoneyFlowRepository creates a money flow repository storing money flows in the store
+func NewMoneyFlowRepository(store *Store) repository.MoneyFlowRepository {
+	return &moneyFlowRepositoryImpl{store: store}
+}
+
+func (r *moneyFlowRepositoryImpl) Create(ctx context.Context, moneyFlow *domain.MoneyFlow) error {
+	return r.CreateBatch(ctx, []*domain.MoneyFlow{moneyFlow})
+}
+
+func (r *moneyFlowRepositoryImpl) CreateBatch(ctx context.Context, moneyFlows []*domain.MoneyFlow) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Check every money flow first so a failing batch stores none of them
+	records := make([]*domain.MoneyFlow, len(moneyFlows))
+	now := time.Now()
+	for i, moneyFlow := range moneyFlows {
+		record := cloneMoneyFlow(moneyFlow)
+		if record.ID == uuid.Nil {
+			record.ID = uuid.New()
+		}
+		if _, exists := r.store.moneyFlows[record.ID]; exists {
+			return errDuplicateID
+		}
+		if record.Kind == "" {
+			record.Kind = domain.MoneyFlowKindExpense
+		}
+		if record.Tags == nil {
+			record.Tags = []string{}
+		}
+		if record.CreatedAt.IsZero() {
+			record.CreatedAt = now
+		}
+		if record.UpdatedAt.IsZero() {
+			record.UpdatedAt = now
+		}
+		records[i] = record
+	}
+
+	// Update domain entities with generated values
+	for i, record := range records {
+		r.store.moneyFlows[record.ID] = record
+		moneyFlows[i].ID = record.ID
+		moneyFlows[i].CreatedAt = record.CreatedAt
+		moneyFlows[i].UpdatedAt = record.UpdatedAt
+	}
+
+	return nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.MoneyFlow, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	record, ok := r.store.moneyFlows[id]
+	if !ok || record.DeletedAt != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return cloneMoneyFlow(record), nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return slices.Contains(ids, mf.ID)
+	}, false, true)
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID
+	}, false, true)
+
+	return paginate(moneyFlows, limit, offset), nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByUserIDAndTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && slices.Contains(mf.Tags, tag)
+	}, false, true)
+
+	return paginate(moneyFlows, limit, offset), nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByUserIDAndWallet(ctx context.Context, userID, walletID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && mf.WalletID != nil && *mf.WalletID == walletID
+	}, false, true)
+
+	return paginate(moneyFlows, limit, offset), nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByGroupID(ctx context.Context, groupID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.GroupID != nil && *mf.GroupID == groupID
+	}, false, true)
+
+	return paginate(moneyFlows, limit, offset), nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByTransferID(ctx context.Context, transferID uuid.UUID) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.TransferID != nil && *mf.TransferID == transferID
+	}, false, false)
+
+	// transfer_out first
+	sort.SliceStable(moneyFlows, func(i, j int) bool {
+		return moneyFlows[i].Kind > moneyFlows[j].Kind
+	})
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && !mf.CreatedAt.Before(startDate) && !mf.CreatedAt.After(endDate)
+	}, false, true)
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.CountByFilter(ctx, repository.MoneyFlowFilter{UserID: userID})
+}
+
+func (r *moneyFlowRepositoryImpl) CountByFilter(ctx context.Context, filter repository.MoneyFlowFilter) (int64, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		if filter.GroupID != nil {
+			if mf.GroupID == nil || *mf.GroupID != *filter.GroupID {
+				return false
+			}
+		} else if mf.UserID != filter.UserID {
+			return false
+		}
+		if filter.WalletID != nil && (mf.WalletID == nil || *mf.WalletID != *filter.WalletID) {
+			return false
+		}
+		return filter.Tag == "" || slices.Contains(mf.Tags, filter.Tag)
+	}, false, false)
+
+	return int64(len(moneyFlows)), nil
+}
+
+func (r *moneyFlowRepositoryImpl) SummaryByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.FlowSummary, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && mf.Kind == domain.MoneyFlowKindExpense && createdIn(mf, start, end)
+	}, false, false)
+
+	byCurrency := make(map[string]*domain.FlowSummary)
+	summaries := []*domain.FlowSummary{}
+	for _, mf := range moneyFlows {
+		summary, ok := byCurrency[mf.Currency]
+		if !ok {
+			summary = &domain.FlowSummary{Currency: mf.Currency}
+			byCurrency[mf.Currency] = summary
+			summaries = append(summaries, summary)
+		}
+		summary.Expense += mf.Amount
+		summary.Net -= mf.Amount
+		summary.Count++
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		return summaries[i].Currency < summaries[j].Currency
+	})
+
+	return summaries, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindCreatedBetween(ctx context.Context, start, end time.Time, limit, offset int) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return createdIn(mf, start, end)
+	}, false, false)
+
+	return paginate(moneyFlows, limit, offset), nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindCreatedBetweenWithDeleted(ctx context.Context, start, end time.Time, limit, offset int) ([]*domain.MoneyFlow, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return createdIn(mf, start, end)
+	}, true, false)
+
+	return paginate(moneyFlows, limit, offset), nil
+}
+
+func (r *moneyFlowRepositoryImpl) Update(ctx context.Context, moneyFlow *domain.MoneyFlow) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Optimistic locking: check version
+	current, ok := r.store.moneyFlows[moneyFlow.ID]
+	if !ok || current.DeletedAt != nil || current.Version != moneyFlow.Version-1 {
+		return domain.ErrConflict
+	}
+
+	record := cloneMoneyFlow(current)
+	record.WalletID = clonePtr(moneyFlow.WalletID)
+	record.GroupID = clonePtr(moneyFlow.GroupID)
+	record.MerchantID = clonePtr(moneyFlow.MerchantID)
+	record.Latitude = clonePtr(moneyFlow.Latitude)
+	record.Longitude = clonePtr(moneyFlow.Longitude)
+	record.PlaceName = clonePtr(moneyFlow.PlaceName)
+	record.Category = clonePtr(moneyFlow.Category)
+	record.Amount = moneyFlow.Amount
+	record.Currency = moneyFlow.Currency
+	record.Description = clonePtr(moneyFlow.Description)
+	record.Tags = slices.Clone(moneyFlow.Tags)
+	if record.Tags == nil {
+		record.Tags = []string{}
+	}
+	record.Version = moneyFlow.Version
+	record.UpdatedAt = moneyFlow.UpdatedAt
+	r.store.moneyFlows[record.ID] = record
+
+	return nil
+}
+
+func (r *moneyFlowRepositoryImpl) PartialUpdate(ctx context.Context, moneyFlow *domain.MoneyFlow, fields []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Optimistic locking: check version
+	current, ok := r.store.moneyFlows[moneyFlow.ID]
+	if !ok || current.DeletedAt != nil || current.Version != moneyFlow.Version-1 {
+		return domain.ErrConflict
+	}
+
+	record := cloneMoneyFlow(current)
+	for _, field := range fields {
+		switch field {
+		case repository.MoneyFlowFieldWalletID:
+			record.WalletID = clonePtr(moneyFlow.WalletID)
+		case repository.MoneyFlowFieldGroupID:
+			record.GroupID = clonePtr(moneyFlow.GroupID)
+		case repository.MoneyFlowFieldMerchantID:
+			record.MerchantID = clonePtr(moneyFlow.MerchantID)
+		case repository.MoneyFlowFieldLatitude:
+			record.Latitude = clonePtr(moneyFlow.Latitude)
+		case repository.MoneyFlowFieldLongitude:
+			record.Longitude = clonePtr(moneyFlow.Longitude)
+		case repository.MoneyFlowFieldPlaceName:
+			record.PlaceName = clonePtr(moneyFlow.PlaceName)
+		case repository.MoneyFlowFieldCategory:
+			record.Category = clonePtr(moneyFlow.Category)
+		case repository.MoneyFlowFieldAmount:
+			record.Amount = moneyFlow.Amount
+		case repository.MoneyFlowFieldCurrency:
+			record.Currency = moneyFlow.Currency
+		case repository.MoneyFlowFieldDescription:
+			record.Description = clonePtr(moneyFlow.Description)
+		case repository.MoneyFlowFieldTags:
+			record.Tags = slices.Clone(moneyFlow.Tags)
+			if record.Tags == nil {
+				record.Tags = []string{}
+			}
+		default:
+			return fmt.Errorf("unknown money flow field %q", field)
+		}
+	}
+	record.Version = moneyFlow.Version
+	record.UpdatedAt = moneyFlow.UpdatedAt
+	r.store.moneyFlows[record.ID] = record
+
+	return nil
+}
+
+func (r *moneyFlowRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	if r.softDelete(func(mf *domain.MoneyFlow) bool { return mf.ID == id }) == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *moneyFlowRepositoryImpl) DeleteByTransferID(ctx context.Context, transferID uuid.UUID) error {
+	deleted := r.softDelete(func(mf *domain.MoneyFlow) bool {
+		return mf.TransferID != nil && *mf.TransferID == transferID
+	})
+	if deleted == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *moneyFlowRepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.softDelete(func(mf *domain.MoneyFlow) bool { return mf.UserID == userID })
+	return nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetCategoryTotalInPeriod(ctx context.Context, userID uuid.UUID, category, currency string, start, end time.Time, excludeID uuid.UUID) (int64, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && mf.Kind == domain.MoneyFlowKindExpense &&
+			mf.Category != nil && *mf.Category == category && mf.Currency == currency &&
+			createdIn(mf, start, end) && mf.ID != excludeID
+	}, false, false)
+
+	var total int64
+	for _, mf := range moneyFlows {
+		total += mf.Amount
+	}
+
+	return total, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetTotalsByCurrency(ctx context.Context, userID uuid.UUID) ([]*domain.CurrencyTotal, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && mf.Kind == domain.MoneyFlowKindExpense
+	}, false, false)
+
+	byCurrency := make(map[string]*domain.CurrencyTotal)
+	var totals []*domain.CurrencyTotal
+	for _, mf := range moneyFlows {
+		total, ok := byCurrency[mf.Currency]
+		if !ok {
+			total = &domain.CurrencyTotal{Currency: mf.Currency}
+			byCurrency[mf.Currency] = total
+			totals = append(totals, total)
+		}
+		total.Total += mf.Amount
+		total.Count++
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Count != totals[j].Count {
+			return totals[i].Count > totals[j].Count
+		}
+		return totals[i].Currency < totals[j].Currency
+	})
+
+	return totals, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetTotalsByCategory(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	return r.totalsByCategory(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID
+	}, start, end), nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetGroupTotalsByCategory(ctx context.Context, groupID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	return r.totalsByCategory(func(mf *domain.MoneyFlow) bool {
+		return mf.GroupID != nil && *mf.GroupID == groupID
+	}, start, end), nil
+}
+
+// totalsByCategory sums the expenses matching the owner predicate per category and currency
+func (r *moneyFlowRepositoryImpl) totalsByCategory(owner func(*domain.MoneyFlow) bool, start, end time.Time) []*domain.CategoryTotal {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return owner(mf) && mf.Kind == domain.MoneyFlowKindExpense && createdIn(mf, start, end)
+	}, false, false)
+
+	type key struct {
+		category    string
+		categorized bool
+		currency    string
+	}
+	byKey := make(map[key]*domain.CategoryTotal)
+	var totals []*domain.CategoryTotal
+	for _, mf := range moneyFlows {
+		k := key{categorized: mf.Category != nil, currency: mf.Currency}
+		if mf.Category != nil {
+			k.category = *mf.Category
+		}
+		total, ok := byKey[k]
+		if !ok {
+			total = &domain.CategoryTotal{Category: clonePtr(mf.Category), Currency: mf.Currency}
+			byKey[k] = total
+			totals = append(totals, total)
+		}
+		total.Total += mf.Amount
+		total.Count++
+	}
+
+	// Uncategorized last, like NULLS LAST
+	sort.Slice(totals, func(i, j int) bool {
+		a, b := totals[i], totals[j]
+		if (a.Category == nil) != (b.Category == nil) {
+			return b.Category == nil
+		}
+		if a.Category != nil && *a.Category != *b.Category {
+			return *a.Category < *b.Category
+		}
+		return a.Currency < b.Currency
+	})
+
+	return totals
+}
+
+func (r *moneyFlowRepositoryImpl) GetTotalsByWallet(ctx context.Context, userID uuid.UUID) ([]*domain.WalletTotal, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && mf.WalletID != nil
+	}, false, false)
+
+	type key struct {
+		walletID uuid.UUID
+		currency string
+	}
+	byKey := make(map[key]*domain.WalletTotal)
+	var totals []*domain.WalletTotal
+	for _, mf := range moneyFlows {
+		k := key{walletID: *mf.WalletID, currency: mf.Currency}
+		total, ok := byKey[k]
+		if !ok {
+			total = &domain.WalletTotal{WalletID: k.walletID, Currency: k.currency}
+			byKey[k] = total
+			totals = append(totals, total)
+		}
+		if mf.Kind == domain.MoneyFlowKindTransferIn {
+			total.Inflow += mf.Amount
+		} else {
+			total.Outflow += mf.Amount
+		}
+		total.Count++
+	}
+
+	return totals, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetTagUsage(ctx context.Context, userID uuid.UUID) ([]*domain.TagUsage, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID
+	}, false, false)
+
+	byTag := make(map[string]*domain.TagUsage)
+	var usage []*domain.TagUsage
+	for _, mf := range moneyFlows {
+		seen := make(map[string]bool, len(mf.Tags))
+		for _, tag := range mf.Tags {
+			// Count each money flow once per tag, like COUNT(DISTINCT id)
+			if seen[tag] {
+				continue
+			}
+			seen[tag] = true
+
+			u, ok := byTag[tag]
+			if !ok {
+				u = &domain.TagUsage{Tag: tag}
+				byTag[tag] = u
+				usage = append(usage, u)
+			}
+			u.Count++
+			if mf.CreatedAt.After(u.LastUsedAt) {
+				u.LastUsedAt = mf.CreatedAt
+			}
+		}
+	}
+
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		return usage[i].Tag < usage[j].Tag
+	})
+
+	return usage, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetDescriptionCategories(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DescriptionCategory, error) {
+	moneyFlows := r.find(func(mf *domain.MoneyFlow) bool {
+		return mf.UserID == userID && mf.Kind == domain.MoneyFlowKindExpense &&
+			mf.Category != nil && mf.Description != nil && *mf.Description != ""
+	}, false, false)
+
+	type key struct{ description, category string }
+	byKey := make(map[key]*domain.DescriptionCategory)
+	var history []*domain.DescriptionCategory
+	for _, mf := range moneyFlows {
+		k := key{strings.ToLower(*mf.Description), *mf.Category}
+		h, ok := byKey[k]
+		if !ok {
+			h = &domain.DescriptionCategory{Description: k.description, Category: k.category}
+			byKey[k] = h
+			history = append(history, h)
+		}
+		h.Count++
+		if mf.CreatedAt.After(h.LastUsedAt) {
+			h.LastUsedAt = mf.CreatedAt
+		}
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].LastUsedAt.After(history[j].LastUsedAt)
+	})
+	if len(history) > limit {
+		history = history[:limit]
+	}
+
+	return history, nil
+}
+
+func (r *moneyFlowRepositoryImpl) RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Keep the position of the first occurrence of each tag after the rename, and bump
+	// the version so clients holding the old tags get a conflict on update
+	var renamed int64
+	now := time.Now()
+	for id, current := range r.store.moneyFlows {
+		if current.UserID != userID || current.DeletedAt != nil || !slices.Contains(current.Tags, from) {
+			continue
+		}
+
+		record := cloneMoneyFlow(current)
+		record.Tags = make([]string, 0, len(current.Tags))
+		for _, tag := range current.Tags {
+			if tag == from {
+				tag = to
+			}
+			if !slices.Contains(record.Tags, tag) {
+				record.Tags = append(record.Tags, tag)
+			}
+		}
+		record.Version++
+		record.UpdatedAt = now
+		r.store.moneyFlows[id] = record
+		renamed++
+	}
+
+	return renamed, nil
+}
+
+// find returns copies of the money flows matching the predicate, ordered by creation
+// time. Soft-deleted money flows are skipped unless withDeleted is set.
+func (r *moneyFlowRepositoryImpl) find(match func(*domain.MoneyFlow) bool, withDeleted, desc bool) []*domain.MoneyFlow {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	moneyFlows := []*domain.MoneyFlow{}
+	for _, record := range r.store.moneyFlows {
+		if (withDeleted || record.DeletedAt == nil) && match(record) {
+			moneyFlows = append(moneyFlows, cloneMoneyFlow(record))
+		}
+	}
+	sortByCreatedAt(moneyFlows,
+		func(mf *domain.MoneyFlow) time.Time { return mf.CreatedAt },
+		func(mf *domain.MoneyFlow) uuid.UUID { return mf.ID },
+		desc)
+
+	return moneyFlows
+}
+
+// softDelete marks the money flows matching the predicate as deleted and returns how
+// many it deleted
+func (r *moneyFlowRepositoryImpl) softDelete(match func(*domain.MoneyFlow) bool) int {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var deleted int
+	now := time.Now()
+	for id, current := range r.store.moneyFlows {
+		if current.DeletedAt != nil || !match(current) {
+			continue
+		}
+
+		record := cloneMoneyFlow(current)
+		record.DeletedAt = &now
+		r.store.moneyFlows[id] = record
+		deleted++
+	}
+
+	return deleted
+}
+
+// createdIn reports whether a money flow was created in [start, end)
+func createdIn(moneyFlow *domain.MoneyFlow, start, end time.Time) bool {
+	return !moneyFlow.CreatedAt.Before(start) && moneyFlow.CreatedAt.Before(end)
+}
+
+// cloneMoneyFlow copies a money flow so callers cannot modify stored records
+func cloneMoneyFlow(moneyFlow *domain.MoneyFlow) *domain.MoneyFlow {
+	clone := *moneyFlow
+	clone.WalletID = clonePtr(moneyFlow.WalletID)
+	clone.TransferID = clonePtr(moneyFlow.TransferID)
+	clone.GroupID = clonePtr(moneyFlow.GroupID)
+	clone.MerchantID = clonePtr(moneyFlow.MerchantID)
+	clone.Latitude = clonePtr(moneyFlow.Latitude)
+	clone.Longitude = clonePtr(moneyFlow.Longitude)
+	clone.PlaceName = clonePtr(moneyFlow.PlaceName)
+	clone.Category = clonePtr(moneyFlow.Category)
+	clone.Description = clonePtr(moneyFlow.Description)
+	clone.Tags = slices.Clone(moneyFlow.Tags)
+	clone.DeletedAt = clonePtr(moneyFlow.DeletedAt)
+	return &clone
+}
@@ -0,0 +1,127 @@
+// Package memory implements repositories that keep their records in memory, so services
+// can be unit-tested without a database or hand-written mocks. The repositories follow
+// the PostgreSQL implementations: they generate IDs and timestamps, hide soft-deleted
+// records, enforce the unique indexes and optimistic locking, and return the same domain
+// errors. Foreign keys are not checked, except that hard-deleting a user removes the
+// records it owns.
+//
+// Repositories of one Store see each other's records, like tables of one database:
+//
+//	store := memory.NewStore()
+//	userRepo := memory.NewUserRepository(store)
+//	userAuthRepo := memory.NewUserAuthRepository(store)
+//	txManager := memory.NewTransactionManager(store)
+//
+// The repositories are safe for concurrent use. They are not meant for production: nothing
+// is persisted and every query scans all records.
+package memory
+
+import (
+	"errors"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// errDuplicateID is returned when a record is created with the ID of a stored record,
+// like a primary key violation
+var errDuplicateID = errors.New("memory: a record with this ID already exists")
+
+// Store holds the records of the memory repositories
+type Store struct {
+	mu            sync.RWMutex
+	users         map[uuid.UUID]*domain.User
+	userAuths     map[uuid.UUID]*repository.UserAuth
+	authProviders map[uuid.UUID]*repository.AuthProvider
+	moneyFlows    map[uuid.UUID]*domain.MoneyFlow
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{
+		users:         make(map[uuid.UUID]*domain.User),
+		userAuths:     make(map[uuid.UUID]*repository.UserAuth),
+		authProviders: make(map[uuid.UUID]*repository.AuthProvider),
+		moneyFlows:    make(map[uuid.UUID]*domain.MoneyFlow),
+	}
+}
+
+// snapshot is a copy of the records of a store, restored when a transaction rolls back
+type snapshot struct {
+	users         map[uuid.UUID]*domain.User
+	userAuths     map[uuid.UUID]*repository.UserAuth
+	authProviders map[uuid.UUID]*repository.AuthProvider
+	moneyFlows    map[uuid.UUID]*domain.MoneyFlow
+}
+
+// snapshot copies the records of the store. Stored records are replaced rather than
+// modified in place, so copying the maps is enough.
+func (s *Store) snapshot() *snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &snapshot{
+		users:         maps.Clone(s.users),
+		userAuths:     maps.Clone(s.userAuths),
+		authProviders: maps.Clone(s.authProviders),
+		moneyFlows:    maps.Clone(s.moneyFlows),
+	}
+}
+
+// restore replaces the records of the store with the snapshot
+func (s *Store) restore(snap *snapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users = snap.users
+	s.userAuths = snap.userAuths
+	s.authProviders = snap.authProviders
+	s.moneyFlows = snap.moneyFlows
+}
+
+// clonePtr returns a copy of the value p points to, or nil
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// paginate returns the page of records at offset, of at most limit records. A negative
+// limit returns every record after offset, like LIMIT -1 in GORM.
+func paginate[T any](records []T, limit, offset int) []T {
+	if offset > 0 {
+		if offset >= len(records) {
+			return []T{}
+		}
+		records = records[offset:]
+	}
+	if limit >= 0 && limit < len(records) {
+		records = records[:limit]
+	}
+	return records
+}
+
+// sortByCreatedAt orders records by creation time, breaking ties by ID so results do not
+// depend on map iteration order
+func sortByCreatedAt[T any](records []T, createdAt func(T) time.Time, id func(T) uuid.UUID, desc bool) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := createdAt(records[i]), createdAt(records[j])
+		if !a.Equal(b) {
+			if desc {
+				return a.After(b)
+			}
+			return a.Before(b)
+		}
+		if desc {
+			return id(records[i]).String() > id(records[j]).String()
+		}
+		return id(records[i]).String() < id(records[j]).String()
+	})
+}
@@ -0,0 +1,99 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/memory"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+func TestTransactionRollbackRestoresRecords(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	userRepo := memory.NewUserRepository(store)
+	txManager := memory.NewTransactionManager(store)
+
+	kept := domain.NewUser("Kept", "+6281100000001")
+	if err := userRepo.Create(ctx, kept); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	errAbort := errors.New("abort")
+	committed := false
+	err := txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		repository.AfterCommit(txCtx, func() { committed = true })
+		if err := userRepo.Create(txCtx, domain.NewUser("Discarded", "+6281100000002")); err != nil {
+			return err
+		}
+		if err := userRepo.Delete(txCtx, kept.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, errAbort)
+	}
+	if committed {
+		t.Error("after commit hook ran for a rolled back transaction")
+	}
+
+	if _, err := userRepo.FindByID(ctx, kept.ID); err != nil {
+		t.Errorf("FindByID(kept) error = %v, want the delete rolled back", err)
+	}
+	if _, err := userRepo.FindByPhoneNumber(ctx, "+6281100000002"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindByPhoneNumber(discarded) error = %v, want %v", err, domain.ErrNotFound)
+	}
+}
+
+func TestUserRepositoryEnforcesIndexesAndVersions(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	userRepo := memory.NewUserRepository(store)
+	userAuthRepo := memory.NewUserAuthRepository(store)
+
+	user := domain.NewUser("Budi", "+6281100000001")
+	if err := userRepo.Create(ctx, user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := userRepo.Create(ctx, domain.NewUser("Other", user.PhoneNumber)); !errors.Is(err, domain.ErrDuplicatePhoneNumber) {
+		t.Errorf("Create(same phone) error = %v, want %v", err, domain.ErrDuplicatePhoneNumber)
+	}
+
+	// A stale copy loses to the update that was stored first
+	stale, err := userRepo.FindByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	user.FullName = "Budi Santoso"
+	user.IncrementVersion()
+	if err := userRepo.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	stale.IncrementVersion()
+	if err := userRepo.Update(ctx, stale); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Update(stale) error = %v, want %v", err, domain.ErrConflict)
+	}
+
+	providerID := uuid.New()
+	userAuth := &repository.UserAuth{UserID: user.ID, AuthProviderID: providerID, CredentialID: "budi@example.com"}
+	if err := userAuthRepo.Create(ctx, userAuth); err != nil {
+		t.Fatalf("Create(user auth) error = %v", err)
+	}
+	duplicate := &repository.UserAuth{UserID: uuid.New(), AuthProviderID: providerID, CredentialID: "budi@example.com"}
+	if err := userAuthRepo.Create(ctx, duplicate); !errors.Is(err, domain.ErrDuplicateCredential) {
+		t.Errorf("Create(same credential) error = %v, want %v", err, domain.ErrDuplicateCredential)
+	}
+
+	// Search matches the credentials of the user, like the SQL subquery
+	users, err := userRepo.Search(ctx, repository.UserFilter{Query: "BUDI@example"}, repository.Page{Limit: 10})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(users) != 1 || users[0].ID != user.ID || users[0].FullName != "Budi Santoso" {
+		t.Errorf("Search() = %+v, want the updated user", users)
+	}
+}
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// transaction is the state of a transaction stored in its context
+type transaction struct {
+	snapshot *snapshot
+	done     bool
+}
+
+// transactionManager implements the TransactionManager interface for a Store. A
+// transaction takes a snapshot of the store and rolls back by restoring it. Transactions
+// run one at a time, but writes outside a transaction are not isolated from them: a
+// rollback also undoes the writes other goroutines made while the transaction ran.
+type transactionManager struct {
+	store *Store
+
+	// mu is held while a transaction runs
+	mu sync.Mutex
+}
+
+// NewTransactionManager creates a transaction manager for the repositories of the store
+func NewTransactionManager(store *Store) repository.TransactionManager {
+	return &transactionManager{store: store}
+}
+
+// WithTransaction executes a function within a transaction
+func (tm *transactionManager) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	// If already in a transaction, just execute the function
+	if tm.IsInTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	txCtx, err := tm.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(txCtx); err != nil {
+		if rbErr := tm.RollbackTransaction(txCtx); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	return tm.CommitTransaction(txCtx)
+}
+
+// BeginTransaction starts a new transaction
+func (tm *transactionManager) BeginTransaction(ctx context.Context) (context.Context, error) {
+	if tm.IsInTransaction(ctx) {
+		return ctx, nil
+	}
+
+	tm.mu.Lock()
+	tx := &transaction{snapshot: tm.store.snapshot()}
+
+	txCtx := repository.WithAfterCommit(repository.SetTransactionInContext(ctx, tx))
+	return txCtx, nil
+}
+
+// CommitTransaction commits the active transaction
+func (tm *transactionManager) CommitTransaction(ctx context.Context) error {
+	tx, err := tm.activeTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	tx.done = true
+	tm.mu.Unlock()
+
+	repository.RunAfterCommit(ctx)
+	return nil
+}
+
+// RollbackTransaction rolls back the active transaction
+func (tm *transactionManager) RollbackTransaction(ctx context.Context) error {
+	tx, err := tm.activeTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to rollback transaction: %w", err)
+	}
+
+	tm.store.restore(tx.snapshot)
+	tx.done = true
+	tm.mu.Unlock()
+
+	return nil
+}
+
+// IsInTransaction checks if there's an active transaction in the context
+func (tm *transactionManager) IsInTransaction(ctx context.Context) bool {
+	return repository.GetTransactionFromContext(ctx) != nil
+}
+
+// activeTransaction returns the transaction of the context that has not yet been
+// committed or rolled back
+func (tm *transactionManager) activeTransaction(ctx context.Context) (*transaction, error) {
+	value := repository.GetTransactionFromContext(ctx)
+	if value == nil {
+		return nil, fmt.Errorf("no active transaction")
+	}
+
+	tx, ok := value.(*transaction)
+	if !ok {
+		return nil, fmt.Errorf("invalid transaction type in context")
+	}
+	if tx.done {
+		return nil, fmt.Errorf("transaction has already been committed or rolled back")
+	}
+	return tx, nil
+}
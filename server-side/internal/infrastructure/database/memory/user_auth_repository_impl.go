@@ -0,0 +1,293 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type userAuthRepositoryImpl struct {
+	store *Store
+}
+
+// NewUserAuthRepository creates a user auth repository storing user auths in the store
+func NewUserAuthRepository(store *Store) repository.UserAuthRepository {
+	return &userAuthRepositoryImpl{store: store}
+}
+
+func (r *userAuthRepositoryImpl) Create(ctx context.Context, userAuth *repository.UserAuth) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	record := cloneUserAuth(userAuth)
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+	if _, exists := r.store.userAuths[record.ID]; exists {
+		return errDuplicateID
+	}
+	if r.duplicate(record) {
+		return domain.ErrDuplicateCredential
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	r.store.userAuths[record.ID] = record
+
+	userAuth.ID = record.ID
+	userAuth.CreatedAt = record.CreatedAt
+	return nil
+}
+
+func (r *userAuthRepositoryImpl) FindByCredentialID(ctx context.Context, credentialID string, authProviderID uuid.UUID) (*repository.UserAuth, error) {
+	return r.first(func(userAuth *repository.UserAuth) bool {
+		return userAuth.CredentialID == credentialID && userAuth.AuthProviderID == authProviderID
+	})
+}
+
+func (r *userAuthRepositoryImpl) FindByUserIDAndProvider(ctx context.Context, userID, authProviderID uuid.UUID) (*repository.UserAuth, error) {
+	return r.first(func(userAuth *repository.UserAuth) bool {
+		return userAuth.UserID == userID && userAuth.AuthProviderID == authProviderID
+	})
+}
+
+func (r *userAuthRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*repository.UserAuth, error) {
+	return r.List(ctx, repository.UserAuthFilter{UserID: userID}, repository.Page{Limit: -1})
+}
+
+func (r *userAuthRepositoryImpl) List(ctx context.Context, filter repository.UserAuthFilter, page repository.Page) ([]*repository.UserAuth, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	userAuths := []*repository.UserAuth{}
+	for _, record := range r.store.userAuths {
+		if r.matches(record, filter) {
+			userAuths = append(userAuths, cloneUserAuth(record))
+		}
+	}
+	sortByCreatedAt(userAuths,
+		func(a *repository.UserAuth) time.Time { return a.CreatedAt },
+		func(a *repository.UserAuth) uuid.UUID { return a.ID },
+		false)
+
+	return paginate(userAuths, page.Limit, page.Offset), nil
+}
+
+func (r *userAuthRepositoryImpl) Count(ctx context.Context, filter repository.UserAuthFilter) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, record := range r.store.userAuths {
+		if r.matches(record, filter) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *userAuthRepositoryImpl) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.userAuths[id]
+	if !ok || current.DeletedAt != nil {
+		return nil
+	}
+
+	record := cloneUserAuth(current)
+	record.LastUsedAt = &lastUsedAt
+	r.store.userAuths[id] = record
+
+	return nil
+}
+
+func (r *userAuthRepositoryImpl) RecordLoginFailure(ctx context.Context, id uuid.UUID, maxAttempts int, lockedUntil time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.userAuths[id]
+	if !ok {
+		return false, nil
+	}
+
+	record := cloneUserAuth(current)
+	record.FailedLoginAttempts++
+	locked := record.FailedLoginAttempts >= maxAttempts
+	if locked {
+		record.FailedLoginAttempts = 0
+		record.LockedUntil = &lockedUntil
+	}
+	r.store.userAuths[id] = record
+
+	return locked, nil
+}
+
+func (r *userAuthRepositoryImpl) ResetLoginFailures(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.userAuths[id]
+	if !ok {
+		return nil
+	}
+
+	record := cloneUserAuth(current)
+	record.FailedLoginAttempts = 0
+	record.LockedUntil = nil
+	r.store.userAuths[id] = record
+
+	return nil
+}
+
+func (r *userAuthRepositoryImpl) ReplaceSecret(ctx context.Context, id uuid.UUID, oldSecret, newSecret string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.userAuths[id]
+	if !ok || current.DeletedAt != nil || current.CredentialSecret != oldSecret {
+		return domain.ErrConflict
+	}
+
+	record := cloneUserAuth(current)
+	record.CredentialSecret = newSecret
+	r.store.userAuths[id] = record
+
+	return nil
+}
+
+func (r *userAuthRepositoryImpl) Update(ctx context.Context, userAuth *repository.UserAuth) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.userAuths[userAuth.ID]
+	if !ok || current.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+
+	record := cloneUserAuth(current)
+	record.CredentialID = userAuth.CredentialID
+	record.CredentialSecret = userAuth.CredentialSecret
+	record.CredentialRefresh = clonePtr(userAuth.CredentialRefresh)
+	if r.duplicate(record) {
+		return domain.ErrDuplicateCredential
+	}
+	r.store.userAuths[record.ID] = record
+
+	return nil
+}
+
+func (r *userAuthRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.userAuths[id]
+	if !ok || current.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+
+	r.softDelete(current, time.Now())
+	return nil
+}
+
+func (r *userAuthRepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	for _, record := range r.store.userAuths {
+		if record.UserID == userID && record.DeletedAt == nil {
+			r.softDelete(record, now)
+		}
+	}
+
+	return nil
+}
+
+func (r *userAuthRepositoryImpl) AnonymizeByUserID(ctx context.Context, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, current := range r.store.userAuths {
+		if current.UserID != userID {
+			continue
+		}
+		record := cloneUserAuth(current)
+		record.CredentialID = "erased-" + id.String()
+		record.CredentialSecret = ""
+		record.CredentialRefresh = nil
+		r.store.userAuths[id] = record
+	}
+
+	return nil
+}
+
+// first returns a copy of the first user auth matching the predicate, oldest first
+func (r *userAuthRepositoryImpl) first(match func(*repository.UserAuth) bool) (*repository.UserAuth, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var found *repository.UserAuth
+	for _, record := range r.store.userAuths {
+		if record.DeletedAt != nil || !match(record) {
+			continue
+		}
+		if found == nil || record.CreatedAt.Before(found.CreatedAt) {
+			found = record
+		}
+	}
+	if found == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return cloneUserAuth(found), nil
+}
+
+// matches reports whether a user auth is selected by the filter
+func (r *userAuthRepositoryImpl) matches(userAuth *repository.UserAuth, filter repository.UserAuthFilter) bool {
+	if userAuth.DeletedAt != nil && !filter.IncludeDeleted {
+		return false
+	}
+	if userAuth.UserID != filter.UserID {
+		return false
+	}
+	if filter.AuthProviderID != nil && userAuth.AuthProviderID != *filter.AuthProviderID {
+		return false
+	}
+	return true
+}
+
+// duplicate reports whether another user auth has the same user and provider, or the
+// same provider and credential, like the unique indexes on user_auths
+func (r *userAuthRepositoryImpl) duplicate(userAuth *repository.UserAuth) bool {
+	for _, record := range r.store.userAuths {
+		if record.ID == userAuth.ID || record.DeletedAt != nil || record.AuthProviderID != userAuth.AuthProviderID {
+			continue
+		}
+		if record.UserID == userAuth.UserID || record.CredentialID == userAuth.CredentialID {
+			return true
+		}
+	}
+	return false
+}
+
+// softDelete replaces a stored user auth with a copy deleted at now
+func (r *userAuthRepositoryImpl) softDelete(userAuth *repository.UserAuth, now time.Time) {
+	record := cloneUserAuth(userAuth)
+	record.DeletedAt = &now
+	r.store.userAuths[record.ID] = record
+}
+
+// cloneUserAuth copies a user auth so callers cannot modify stored records
+func cloneUserAuth(userAuth *repository.UserAuth) *repository.UserAuth {
+	clone := *userAuth
+	clone.CredentialRefresh = clonePtr(userAuth.CredentialRefresh)
+	clone.LastUsedAt = clonePtr(userAuth.LastUsedAt)
+	clone.DeletedAt = clonePtr(userAuth.DeletedAt)
+	clone.LockedUntil = clonePtr(userAuth.LockedUntil)
+	return &clone
+}
@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type userRepositoryImpl struct {
+	store *Store
+}
+
+// NewUserRepository creates a user repository storing users in the store
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepositoryImpl{store: store}
+}
+
+func (r *userRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	record := cloneUser(user)
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+	if _, exists := r.store.users[record.ID]; exists {
+		return errDuplicateID
+	}
+	if r.phoneNumberTaken(record.PhoneNumber, record.ID) {
+		return domain.ErrDuplicatePhoneNumber
+	}
+	now := time.Now()
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = now
+	}
+	if record.UpdatedAt.IsZero() {
+		record.UpdatedAt = now
+	}
+	r.store.users[record.ID] = record
+
+	// Update domain entity with generated values
+	user.ID = record.ID
+	user.CreatedAt = record.CreatedAt
+	user.UpdatedAt = record.UpdatedAt
+
+	return nil
+}
+
+func (r *userRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	record, ok := r.store.users[id]
+	if !ok || record.DeletedAt != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	return cloneUser(record), nil
+}
+
+func (r *userRepositoryImpl) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, record := range r.store.users {
+		if record.DeletedAt == nil && record.PhoneNumber == phoneNumber {
+			return cloneUser(record), nil
+		}
+	}
+
+	return nil, domain.ErrNotFound
+}
+
+func (r *userRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Optimistic locking: check version
+	current, ok := r.store.users[user.ID]
+	if !ok || current.DeletedAt != nil || current.Version != user.Version-1 {
+		return domain.ErrConflict
+	}
+	if r.phoneNumberTaken(user.PhoneNumber, user.ID) {
+		return domain.ErrDuplicatePhoneNumber
+	}
+
+	record := cloneUser(current)
+	record.FullName = user.FullName
+	record.PhoneNumber = user.PhoneNumber
+	record.Image = clonePtr(user.Image)
+	record.DataRegion = user.DataRegion
+	record.Role = user.Role
+	record.DisabledAt = clonePtr(user.DisabledAt)
+	record.Version = user.Version
+	record.UpdatedAt = user.UpdatedAt
+	r.store.users[record.ID] = record
+
+	return nil
+}
+
+func (r *userRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.users[id]
+	if !ok || current.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+
+	record := cloneUser(current)
+	now := time.Now()
+	record.DeletedAt = &now
+	r.store.users[id] = record
+
+	return nil
+}
+
+func (r *userRepositoryImpl) Anonymize(ctx context.Context, id uuid.UUID, fullName, phoneNumber string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.users[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	record := cloneUser(current)
+	record.FullName = fullName
+	record.PhoneNumber = phoneNumber
+	record.Image = nil
+	record.Version++
+	record.UpdatedAt = time.Now()
+	r.store.users[id] = record
+
+	return nil
+}
+
+func (r *userRepositoryImpl) FindTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	record, ok := r.store.users[id]
+	if !ok || record.DeletedAt != nil {
+		return 0, domain.ErrNotFound
+	}
+
+	return record.TokenVersion, nil
+}
+
+func (r *userRepositoryImpl) IncrementTokenVersion(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current, ok := r.store.users[id]
+	if !ok || current.DeletedAt != nil {
+		return domain.ErrNotFound
+	}
+
+	record := cloneUser(current)
+	record.TokenVersion++
+	r.store.users[id] = record
+
+	return nil
+}
+
+func (r *userRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+	return r.find(func(*domain.User) bool { return true }, limit, offset), nil
+}
+
+func (r *userRepositoryImpl) Search(ctx context.Context, filter repository.UserFilter, page repository.Page) ([]*domain.User, error) {
+	return r.find(r.filter(filter), page.Limit, page.Offset), nil
+}
+
+func (r *userRepositoryImpl) Count(ctx context.Context, filter repository.UserFilter) (int64, error) {
+	return int64(len(r.find(r.filter(filter), -1, 0))), nil
+}
+
+func (r *userRepositoryImpl) FindByAudience(ctx context.Context, audience domain.BroadcastAudience) ([]*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []*domain.User{}
+	for _, record := range r.store.users {
+		// Demo users are throwaway sandboxes and never receive broadcasts
+		if record.DeletedAt != nil || record.DemoExpiresAt != nil {
+			continue
+		}
+		if len(audience.UserIDs) > 0 && !slices.Contains(audience.UserIDs, record.ID) {
+			continue
+		}
+		if len(audience.Roles) > 0 && !slices.Contains(audience.Roles, record.Role) {
+			continue
+		}
+		if audience.RegisteredAfter != nil && record.CreatedAt.Before(*audience.RegisteredAfter) {
+			continue
+		}
+		if audience.RegisteredBefore != nil && !record.CreatedAt.Before(*audience.RegisteredBefore) {
+			continue
+		}
+		users = append(users, cloneUser(record))
+	}
+	sortUsers(users, false)
+
+	return users, nil
+}
+
+func (r *userRepositoryImpl) DeleteExpiredDemoUsers(ctx context.Context, now time.Time, limit int) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Hard delete, cascading to the records owned by the demo user
+	var deleted int64
+	for id, record := range r.store.users {
+		if deleted >= int64(limit) {
+			break
+		}
+		if record.DemoExpiresAt == nil || record.DemoExpiresAt.After(now) {
+			continue
+		}
+
+		delete(r.store.users, id)
+		for authID, userAuth := range r.store.userAuths {
+			if userAuth.UserID == id {
+				delete(r.store.userAuths, authID)
+			}
+		}
+		for flowID, moneyFlow := range r.store.moneyFlows {
+			if moneyFlow.UserID == id {
+				delete(r.store.moneyFlows, flowID)
+			}
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// find returns a page of the users matching the predicate, newest first
+func (r *userRepositoryImpl) find(match func(*domain.User) bool, limit, offset int) []*domain.User {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := []*domain.User{}
+	for _, record := range r.store.users {
+		if record.DeletedAt == nil && match(record) {
+			users = append(users, cloneUser(record))
+		}
+	}
+	sortUsers(users, true)
+
+	return paginate(users, limit, offset)
+}
+
+// filter returns a predicate matching the users of the filter. It reads the user auths
+// of the store, so it must be called with the store locked.
+func (r *userRepositoryImpl) filter(filter repository.UserFilter) func(*domain.User) bool {
+	query := strings.ToLower(filter.Query)
+
+	return func(user *domain.User) bool {
+		if query != "" && !strings.Contains(strings.ToLower(user.FullName), query) &&
+			!strings.Contains(strings.ToLower(user.PhoneNumber), query) &&
+			!r.hasCredentialContaining(user.ID, query) {
+			return false
+		}
+		if filter.Role != "" && user.Role != filter.Role {
+			return false
+		}
+		if filter.Disabled != nil && *filter.Disabled != (user.DisabledAt != nil) {
+			return false
+		}
+		return true
+	}
+}
+
+// hasCredentialContaining reports whether a user auth of the user has a credential ID
+// containing the lower-cased query
+func (r *userRepositoryImpl) hasCredentialContaining(userID uuid.UUID, query string) bool {
+	for _, userAuth := range r.store.userAuths {
+		if userAuth.UserID == userID && userAuth.DeletedAt == nil &&
+			strings.Contains(strings.ToLower(userAuth.CredentialID), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// phoneNumberTaken reports whether another user has the phone number, like the unique
+// index on phone_number
+func (r *userRepositoryImpl) phoneNumberTaken(phoneNumber string, id uuid.UUID) bool {
+	for _, record := range r.store.users {
+		if record.ID != id && record.DeletedAt == nil && record.PhoneNumber == phoneNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// sortUsers orders users by creation time
+func sortUsers(users []*domain.User, desc bool) {
+	sortByCreatedAt(users,
+		func(u *domain.User) time.Time { return u.CreatedAt },
+		func(u *domain.User) uuid.UUID { return u.ID },
+		desc)
+}
+
+// cloneUser copies a user so callers cannot modify stored records
+func cloneUser(user *domain.User) *domain.User {
+	clone := *user
+	clone.Image = clonePtr(user.Image)
+	clone.DeletedAt = clonePtr(user.DeletedAt)
+	clone.DemoExpiresAt = clonePtr(user.DemoExpiresAt)
+	clone.DisabledAt = clonePtr(user.DisabledAt)
+	return &clone
+}
@@ -0,0 +1,126 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type accountErasureRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewAccountErasureRepository creates a new account erasure repository implementation
+func NewAccountErasureRepository(db repository.DB) repository.AccountErasureRepository {
+	return &accountErasureRepositoryImpl{db: db}
+}
+
+func (r *accountErasureRepositoryImpl) Create(ctx context.Context, erasure *domain.AccountErasure) error {
+	model := r.domainToModel(erasure)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		// One pending erasure per user
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	// Update domain entity with generated values
+	erasure.ID = model.ID
+	erasure.CreatedAt = model.CreatedAt
+	erasure.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *accountErasureRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.AccountErasure, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *accountErasureRepositoryImpl) FindPendingByUserID(ctx context.Context, userID uuid.UUID) (*domain.AccountErasure, error) {
+	return r.findOne(ctx, "user_id = ? AND status = ?", userID, domain.ErasurePending)
+}
+
+func (r *accountErasureRepositoryImpl) Finish(ctx context.Context, id uuid.UUID, status string, at time.Time) error {
+	updates := map[string]interface{}{
+		"status":     status,
+		"updated_at": at,
+	}
+	switch status {
+	case domain.ErasureCancelled:
+		updates["cancelled_at"] = at
+	case domain.ErasureCompleted:
+		updates["completed_at"] = at
+	default:
+		return errors.New("unknown account erasure status " + status)
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&AccountErasureModel{}).
+		Where("id = ? AND status = ?", id, domain.ErasurePending).
+		Updates(updates)
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *accountErasureRepositoryImpl) findOne(ctx context.Context, query string, args ...interface{}) (*domain.AccountErasure, error) {
+	var model AccountErasureModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where(query, args...).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *accountErasureRepositoryImpl) domainToModel(erasure *domain.AccountErasure) *AccountErasureModel {
+	return &AccountErasureModel{
+		ID:          erasure.ID,
+		UserID:      erasure.UserID,
+		Status:      erasure.Status,
+		EraseAt:     erasure.EraseAt,
+		CancelledAt: erasure.CancelledAt,
+		CompletedAt: erasure.CompletedAt,
+		CreatedAt:   erasure.CreatedAt,
+		UpdatedAt:   erasure.UpdatedAt,
+	}
+}
+
+func (r *accountErasureRepositoryImpl) modelToDomain(model *AccountErasureModel) *domain.AccountErasure {
+	return &domain.AccountErasure{
+		ID:          model.ID,
+		UserID:      model.UserID,
+		Status:      model.Status,
+		EraseAt:     model.EraseAt,
+		CancelledAt: model.CancelledAt,
+		CompletedAt: model.CompletedAt,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
@@ -0,0 +1,39 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type analyticsEventRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewAnalyticsEventRepository creates a new analytics event repository implementation
+func NewAnalyticsEventRepository(db repository.DB) repository.AnalyticsEventRepository {
+	return &analyticsEventRepositoryImpl{db: db}
+}
+
+func (r *analyticsEventRepositoryImpl) CreateBatch(ctx context.Context, events []*domain.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	models := make([]*AnalyticsEventModel, len(events))
+	for i, event := range events {
+		models[i] = &AnalyticsEventModel{
+			ID:          event.ID,
+			Name:        event.Name,
+			AnonymousID: event.AnonymousID,
+			Properties:  JSONMap(event.Properties),
+			OccurredAt:  event.OccurredAt,
+		}
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Create(&models).Error()
+}
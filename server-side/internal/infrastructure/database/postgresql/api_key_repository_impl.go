@@ -0,0 +1,203 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository implementation
+func NewAPIKeyRepository(db repository.DB) repository.APIKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
+
+func (r *apiKeyRepositoryImpl) Create(ctx context.Context, apiKey *domain.APIKey) error {
+	model := r.domainToModel(apiKey)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	// Update domain entity with generated values
+	apiKey.ID = model.ID
+	apiKey.CreatedAt = model.CreatedAt
+	apiKey.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	var model APIKeyModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *apiKeyRepositoryImpl) FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	var model APIKeyModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("key_hash = ?", keyHash).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *apiKeyRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.APIKey, error) {
+	var models []APIKeyModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]*domain.APIKey, len(models))
+	for i, model := range models {
+		apiKeys[i] = r.modelToDomain(&model)
+	}
+
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepositoryImpl) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&APIKeyModel{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&APIKeyModel{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+
+	return result.Error()
+}
+
+func (r *apiKeyRepositoryImpl) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&APIKeyModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": lastUsedAt,
+		})
+
+	return result.Error()
+}
+
+// Helper methods for conversion between domain and model
+
+func (r *apiKeyRepositoryImpl) domainToModel(apiKey *domain.APIKey) *APIKeyModel {
+	var deletedAt gorm.DeletedAt
+	if apiKey.DeletedAt != nil {
+		deletedAt = gorm.DeletedAt{
+			Time:  *apiKey.DeletedAt,
+			Valid: true,
+		}
+	}
+
+	scopes := JSONB(apiKey.Scopes)
+	if scopes == nil {
+		scopes = JSONB([]string{})
+	}
+
+	return &APIKeyModel{
+		ID:         apiKey.ID,
+		UserID:     apiKey.UserID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		KeyHash:    apiKey.KeyHash,
+		Scopes:     scopes,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+		Version:    apiKey.Version,
+		CreatedAt:  apiKey.CreatedAt,
+		UpdatedAt:  apiKey.UpdatedAt,
+		DeletedAt:  deletedAt,
+	}
+}
+
+func (r *apiKeyRepositoryImpl) modelToDomain(model *APIKeyModel) *domain.APIKey {
+	var deletedAt *time.Time
+	if model.DeletedAt.Valid {
+		deletedAt = &model.DeletedAt.Time
+	}
+
+	scopes := []string(model.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return &domain.APIKey{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Name:       model.Name,
+		Prefix:     model.Prefix,
+		KeyHash:    model.KeyHash,
+		Scopes:     scopes,
+		LastUsedAt: model.LastUsedAt,
+		RevokedAt:  model.RevokedAt,
+		Version:    model.Version,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+		DeletedAt:  deletedAt,
+	}
+}
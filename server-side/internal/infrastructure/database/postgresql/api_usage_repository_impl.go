@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type apiUsageRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewAPIUsageRepository creates a new API usage repository implementation
+func NewAPIUsageRepository(db repository.DB) repository.APIUsageRepository {
+	return &apiUsageRepositoryImpl{db: db}
+}
+
+// incrementAPIUsageSQL adds to existing counters so concurrent flushes from several
+// instances never overwrite each other
+const incrementAPIUsageSQL = `
+INSERT INTO api_usage_daily (user_id, day, method, route, request_count, error_count)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (user_id, day, method, route) DO UPDATE SET
+  request_count = api_usage_daily.request_count + EXCLUDED.request_count,
+  error_count = api_usage_daily.error_count + EXCLUDED.error_count`
+
+func (r *apiUsageRepositoryImpl) Increment(ctx context.Context, usages []*domain.APIUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Transaction(func(tx repository.DB) error {
+		for _, usage := range usages {
+			res := tx.Exec(incrementAPIUsageSQL,
+				usage.UserID, usage.Day, usage.Method, usage.Route, usage.RequestCount, usage.ErrorCount)
+			if err := res.Error(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *apiUsageRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID, since time.Time) ([]*domain.APIUsage, error) {
+	var models []APIUsageModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ? AND day >= ?", userID, since).
+		Order("day ASC, method ASC, route ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	usages := make([]*domain.APIUsage, len(models))
+	for i := range models {
+		usages[i] = r.modelToDomain(&models[i])
+	}
+
+	return usages, nil
+}
+
+func (r *apiUsageRepositoryImpl) SummarizeByUser(ctx context.Context, since time.Time, limit, offset int) ([]*domain.UserAPIUsage, error) {
+	var rows []struct {
+		UserID       uuid.UUID
+		RequestCount int64
+		ErrorCount   int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&APIUsageModel{}).
+		Select("user_id, SUM(request_count) AS request_count, SUM(error_count) AS error_count").
+		Where("day >= ?", since).
+		Group("user_id").
+		Order("request_count DESC, user_id ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*domain.UserAPIUsage, len(rows))
+	for i, row := range rows {
+		summaries[i] = &domain.UserAPIUsage{
+			UserID:       row.UserID,
+			RequestCount: row.RequestCount,
+			ErrorCount:   row.ErrorCount,
+		}
+	}
+
+	return summaries, nil
+}
+
+func (r *apiUsageRepositoryImpl) SummarizeByRoute(ctx context.Context, since time.Time) ([]*domain.RouteAPIUsage, error) {
+	var rows []struct {
+		Method       string
+		Route        string
+		RequestCount int64
+		ErrorCount   int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&APIUsageModel{}).
+		Select("method, route, SUM(request_count) AS request_count, SUM(error_count) AS error_count").
+		Where("day >= ?", since).
+		Group("method, route").
+		Order("request_count DESC, route ASC, method ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*domain.RouteAPIUsage, len(rows))
+	for i, row := range rows {
+		summaries[i] = &domain.RouteAPIUsage{
+			Method:       row.Method,
+			Route:        row.Route,
+			RequestCount: row.RequestCount,
+			ErrorCount:   row.ErrorCount,
+		}
+	}
+
+	return summaries, nil
+}
+
+// Helper methods for conversion
+
+func (r *apiUsageRepositoryImpl) modelToDomain(model *APIUsageModel) *domain.APIUsage {
+	return &domain.APIUsage{
+		UserID:       model.UserID,
+		Day:          model.Day,
+		Method:       model.Method,
+		Route:        model.Route,
+		RequestCount: model.RequestCount,
+		ErrorCount:   model.ErrorCount,
+	}
+}
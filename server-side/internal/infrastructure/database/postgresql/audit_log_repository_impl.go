@@ -0,0 +1,106 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type auditLogRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository implementation
+func NewAuditLogRepository(db repository.DB) repository.AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+func (r *auditLogRepositoryImpl) Create(ctx context.Context, log *domain.AuditLog) error {
+	model := r.domainToModel(log)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	log.ID = model.ID
+	log.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *auditLogRepositoryImpl) FindByActorID(ctx context.Context, actorID uuid.UUID, filter domain.AuditLogFilter, limit, offset int) ([]*domain.AuditLog, error) {
+	var models []AuditLogModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	query := db.Where("actor_id = ?", actorID)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+
+	res := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	logs := make([]*domain.AuditLog, len(models))
+	for i, model := range models {
+		logs[i] = r.modelToDomain(&model)
+	}
+
+	return logs, nil
+}
+
+// Helper methods for conversion
+
+func (r *auditLogRepositoryImpl) domainToModel(log *domain.AuditLog) *AuditLogModel {
+	return &AuditLogModel{
+		ID:         log.ID,
+		ActorID:    log.ActorID,
+		Action:     log.Action,
+		EntityType: log.EntityType,
+		EntityID:   log.EntityID,
+		Before:     snapshotOrNil(log.Before),
+		After:      snapshotOrNil(log.After),
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+func (r *auditLogRepositoryImpl) modelToDomain(model *AuditLogModel) *domain.AuditLog {
+	log := &domain.AuditLog{
+		ID:         model.ID,
+		ActorID:    model.ActorID,
+		Action:     model.Action,
+		EntityType: model.EntityType,
+		EntityID:   model.EntityID,
+		CreatedAt:  model.CreatedAt,
+	}
+	if model.Before != nil {
+		log.Before = json.RawMessage(*model.Before)
+	}
+	if model.After != nil {
+		log.After = json.RawMessage(*model.After)
+	}
+	return log
+}
+
+func snapshotOrNil(snapshot json.RawMessage) *string {
+	if len(snapshot) == 0 {
+		return nil
+	}
+	value := string(snapshot)
+	return &value
+}
@@ -76,7 +76,7 @@ func (r *authProviderRepositoryImpl) domainToModel(provider *repository.AuthProv
 		Name:         provider.Name,
 		Image:        provider.Image,
 		ClientID:     provider.ClientID,
-		ClientSecret: provider.ClientSecret,
+		ClientSecret: NewEncryptedString(provider.ClientSecret),
 	}
 }
 
@@ -87,6 +87,6 @@ func (r *authProviderRepositoryImpl) modelToDomain(model *AuthProviderModel) *re
 		Name:         model.Name,
 		Image:        model.Image,
 		ClientID:     model.ClientID,
-		ClientSecret: model.ClientSecret,
+		ClientSecret: model.ClientSecret.Ptr(),
 	}
 }
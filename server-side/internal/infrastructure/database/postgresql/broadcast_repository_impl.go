@@ -0,0 +1,324 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type broadcastRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewBroadcastRepository creates a new broadcast repository implementation
+func NewBroadcastRepository(db repository.DB) repository.BroadcastRepository {
+	return &broadcastRepositoryImpl{db: db}
+}
+
+func (r *broadcastRepositoryImpl) Create(ctx context.Context, broadcast *domain.Broadcast) error {
+	model := r.domainToModel(broadcast)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	broadcast.ID = model.ID
+	broadcast.CreatedAt = model.CreatedAt
+	broadcast.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *broadcastRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Broadcast, error) {
+	var model BroadcastModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *broadcastRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*domain.Broadcast, error) {
+	var models []BroadcastModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	broadcasts := make([]*domain.Broadcast, len(models))
+	for i, model := range models {
+		broadcasts[i] = r.modelToDomain(&model)
+	}
+
+	return broadcasts, nil
+}
+
+func (r *broadcastRepositoryImpl) UpdateStatus(ctx context.Context, broadcast *domain.Broadcast) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&BroadcastModel{}).
+		Where("id = ?", broadcast.ID).
+		Updates(map[string]interface{}{
+			"status":       broadcast.Status,
+			"completed_at": broadcast.CompletedAt,
+			"updated_at":   broadcast.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *broadcastRepositoryImpl) CreateDeliveries(ctx context.Context, deliveries []*domain.BroadcastDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	models := make([]*BroadcastDeliveryModel, len(deliveries))
+	for i, delivery := range deliveries {
+		models[i] = r.deliveryToModel(delivery)
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Create(&models).Error()
+}
+
+func (r *broadcastRepositoryImpl) FindPendingDeliveries(ctx context.Context, limit int) ([]*domain.BroadcastDelivery, error) {
+	var models []BroadcastDeliveryModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("status = ?", domain.DeliveryStatusPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*domain.BroadcastDelivery, len(models))
+	for i, model := range models {
+		deliveries[i] = r.modelToDelivery(&model)
+	}
+
+	return deliveries, nil
+}
+
+func (r *broadcastRepositoryImpl) ClaimDelivery(ctx context.Context, delivery *domain.BroadcastDelivery) error {
+	now := time.Now()
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Only one dispatcher wins the pending -> sending transition
+	result := db.Model(&BroadcastDeliveryModel{}).
+		Where("id = ? AND status = ?", delivery.ID, domain.DeliveryStatusPending).
+		Updates(map[string]interface{}{
+			"status":     domain.DeliveryStatusSending,
+			"attempts":   delivery.Attempts + 1,
+			"updated_at": now,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	delivery.Status = domain.DeliveryStatusSending
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+	return nil
+}
+
+func (r *broadcastRepositoryImpl) ReleaseStaleDeliveries(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&BroadcastDeliveryModel{}).
+		Where("status = ? AND updated_at < ?", domain.DeliveryStatusSending, claimedBefore).
+		Updates(map[string]interface{}{
+			"status":     domain.DeliveryStatusPending,
+			"updated_at": time.Now(),
+		})
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (r *broadcastRepositoryImpl) UpdateDelivery(ctx context.Context, delivery *domain.BroadcastDelivery) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&BroadcastDeliveryModel{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"channel":    delivery.Channel,
+			"status":     delivery.Status,
+			"attempts":   delivery.Attempts,
+			"last_error": delivery.LastError,
+			"sent_at":    delivery.SentAt,
+			"updated_at": delivery.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *broadcastRepositoryImpl) ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string, limit, offset int) ([]*domain.BroadcastDelivery, error) {
+	var models []BroadcastDeliveryModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	query := db.Where("broadcast_id = ?", broadcastID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	res := query.Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*domain.BroadcastDelivery, len(models))
+	for i, model := range models {
+		deliveries[i] = r.modelToDelivery(&model)
+	}
+
+	return deliveries, nil
+}
+
+func (r *broadcastRepositoryImpl) CountDeliveriesByStatus(ctx context.Context, broadcastID uuid.UUID) (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&BroadcastDeliveryModel{}).
+		Select("status, COUNT(*) AS count").
+		Where("broadcast_id = ?", broadcastID).
+		Group("status").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// Helper methods for conversion
+
+func (r *broadcastRepositoryImpl) domainToModel(broadcast *domain.Broadcast) *BroadcastModel {
+	return &BroadcastModel{
+		ID:             broadcast.ID,
+		CreatedBy:      broadcast.CreatedBy,
+		Title:          broadcast.Title,
+		Body:           broadcast.Body,
+		Channels:       JSONB(broadcast.Channels),
+		Audience:       AudienceJSON(broadcast.Audience),
+		Status:         broadcast.Status,
+		RecipientCount: broadcast.RecipientCount,
+		CompletedAt:    broadcast.CompletedAt,
+		CreatedAt:      broadcast.CreatedAt,
+		UpdatedAt:      broadcast.UpdatedAt,
+	}
+}
+
+func (r *broadcastRepositoryImpl) modelToDomain(model *BroadcastModel) *domain.Broadcast {
+	return &domain.Broadcast{
+		ID:             model.ID,
+		CreatedBy:      model.CreatedBy,
+		Title:          model.Title,
+		Body:           model.Body,
+		Channels:       []string(model.Channels),
+		Audience:       domain.BroadcastAudience(model.Audience),
+		Status:         model.Status,
+		RecipientCount: model.RecipientCount,
+		CompletedAt:    model.CompletedAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func (r *broadcastRepositoryImpl) deliveryToModel(delivery *domain.BroadcastDelivery) *BroadcastDeliveryModel {
+	return &BroadcastDeliveryModel{
+		ID:          delivery.ID,
+		BroadcastID: delivery.BroadcastID,
+		UserID:      delivery.UserID,
+		Channel:     delivery.Channel,
+		Status:      delivery.Status,
+		Attempts:    delivery.Attempts,
+		LastError:   delivery.LastError,
+		SentAt:      delivery.SentAt,
+		CreatedAt:   delivery.CreatedAt,
+		UpdatedAt:   delivery.UpdatedAt,
+	}
+}
+
+func (r *broadcastRepositoryImpl) modelToDelivery(model *BroadcastDeliveryModel) *domain.BroadcastDelivery {
+	return &domain.BroadcastDelivery{
+		ID:          model.ID,
+		BroadcastID: model.BroadcastID,
+		UserID:      model.UserID,
+		Channel:     model.Channel,
+		Status:      model.Status,
+		Attempts:    model.Attempts,
+		LastError:   model.LastError,
+		SentAt:      model.SentAt,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
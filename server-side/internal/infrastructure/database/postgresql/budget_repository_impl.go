@@ -0,0 +1,226 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type budgetRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewBudgetRepository creates a new budget repository implementation
+func NewBudgetRepository(db repository.DB) repository.BudgetRepository {
+	return &budgetRepositoryImpl{db: db}
+}
+
+func (r *budgetRepositoryImpl) Create(ctx context.Context, budget *domain.Budget) error {
+	model := r.domainToModel(budget)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		// One budget per category
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	budget.ID = model.ID
+	budget.CreatedAt = model.CreatedAt
+	budget.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *budgetRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Budget, error) {
+	var model BudgetModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *budgetRepositoryImpl) FindByUserIDAndCategory(ctx context.Context, userID uuid.UUID, category string) (*domain.Budget, error) {
+	var model BudgetModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ? AND category = ?", userID, category).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *budgetRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error) {
+	var models []BudgetModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("category ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	budgets := make([]*domain.Budget, len(models))
+	for i, model := range models {
+		budgets[i] = r.modelToDomain(&model)
+	}
+
+	return budgets, nil
+}
+
+func (r *budgetRepositoryImpl) Update(ctx context.Context, budget *domain.Budget) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&BudgetModel{}).
+		Where("id = ? AND version = ?", budget.ID, budget.Version-1).
+		Updates(map[string]interface{}{
+			"amount":     moneyDecimal(budget.Amount, budget.Currency),
+			"currency":   budget.Currency,
+			"hard":       budget.Hard,
+			"version":    budget.Version,
+			"updated_at": budget.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *budgetRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("id = ?", id).Delete(&BudgetModel{})
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *budgetRepositoryImpl) CreateOverride(ctx context.Context, override *domain.BudgetOverride) error {
+	model := &BudgetOverrideModel{
+		ID:          override.ID,
+		BudgetID:    override.BudgetID,
+		UserID:      override.UserID,
+		MoneyFlowID: override.MoneyFlowID,
+		Currency:    override.Currency,
+		Amount:      moneyDecimal(override.Amount, override.Currency),
+		Spent:       moneyDecimal(override.Spent, override.Currency),
+		Cap:         moneyDecimal(override.Cap, override.Currency),
+		CreatedAt:   override.CreatedAt,
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	override.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *budgetRepositoryImpl) FindOverridesByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.BudgetOverride, error) {
+	var models []BudgetOverrideModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	overrides := make([]*domain.BudgetOverride, len(models))
+	for i, model := range models {
+		overrides[i] = &domain.BudgetOverride{
+			ID:          model.ID,
+			BudgetID:    model.BudgetID,
+			UserID:      model.UserID,
+			MoneyFlowID: model.MoneyFlowID,
+			Currency:    model.Currency,
+			Amount:      model.Amount.Minor(model.Currency),
+			Spent:       model.Spent.Minor(model.Currency),
+			Cap:         model.Cap.Minor(model.Currency),
+			CreatedAt:   model.CreatedAt,
+		}
+	}
+
+	return overrides, nil
+}
+
+// Helper methods for conversion
+
+func (r *budgetRepositoryImpl) domainToModel(budget *domain.Budget) *BudgetModel {
+	return &BudgetModel{
+		ID:        budget.ID,
+		UserID:    budget.UserID,
+		Category:  budget.Category,
+		Amount:    moneyDecimal(budget.Amount, budget.Currency),
+		Currency:  budget.Currency,
+		Hard:      budget.Hard,
+		Version:   budget.Version,
+		CreatedAt: budget.CreatedAt,
+		UpdatedAt: budget.UpdatedAt,
+	}
+}
+
+func (r *budgetRepositoryImpl) modelToDomain(model *BudgetModel) *domain.Budget {
+	return &domain.Budget{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		Category:  model.Category,
+		Amount:    model.Amount.Minor(model.Currency),
+		Currency:  model.Currency,
+		Hard:      model.Hard,
+		Version:   model.Version,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -1,8 +1,13 @@
 package postgresql
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -10,8 +15,20 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// PoolConfig holds the connection pool settings of a database
+type PoolConfig struct {
+	MaxIdleConns    int
+	MaxOpenConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration // zero keeps idle connections until ConnMaxLifetime
+
+	// StatementTimeout makes the server cancel statements running longer, so a wedged
+	// query cannot hold a connection forever; zero leaves statements unlimited
+	StatementTimeout time.Duration
+}
+
 // NewConnection creates a new PostgreSQL database connection
-func NewConnection(dsn string, env string) (*gorm.DB, error) {
+func NewConnection(dsn string, env string, pool PoolConfig) (*gorm.DB, error) {
 	// Configure GORM logger based on environment
 	logLevel := logger.Info
 	if env == "production" {
@@ -19,14 +36,20 @@ func NewConnection(dsn string, env string) (*gorm.DB, error) {
 	}
 
 	config := &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
+		// Slow queries are logged by the QueryMonitor instead, with the request's logger
+		Logger: logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+			LogLevel: logLevel,
+			Colorful: true,
+		}),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
+		// Translate driver errors (e.g. unique violations) into gorm.ErrDuplicatedKey
+		TranslateError: true,
 	}
 
 	// Open connection
-	db, err := gorm.Open(postgres.Open(dsn), config)
+	db, err := gorm.Open(postgres.Open(withStatementTimeout(dsn, pool.StatementTimeout)), config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -38,24 +61,50 @@ func NewConnection(dsn string, env string) (*gorm.DB, error) {
 	}
 
 	// Set connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(pool.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(pool.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 
 	// Test connection
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("Successfully connected to PostgreSQL database")
+	slog.Info("Successfully connected to PostgreSQL database")
 
 	return db, nil
 }
 
+// withStatementTimeout sets the statement_timeout run-time parameter of every
+// connection, in the keyword/value or the URL form of the DSN
+func withStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return dsn
+	}
+	milliseconds := strconv.FormatInt(timeout.Milliseconds(), 10)
+	if !strings.Contains(dsn, "://") {
+		return dsn + " statement_timeout=" + milliseconds
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&statement_timeout=" + milliseconds
+	}
+	return dsn + "?statement_timeout=" + milliseconds
+}
+
+// Close closes the underlying connection pool
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	return sqlDB.Close()
+}
+
 // AutoMigrate runs GORM auto-migration for all models
 // NOTE: This is deprecated in favor of golang-migrate. Use only for development/testing.
 func AutoMigrate(db *gorm.DB) error {
-	log.Println("Running GORM auto-migrations (deprecated - use golang-migrate instead)...")
+	slog.Warn("Running GORM auto-migrations (deprecated - use golang-migrate instead)")
 
 	err := db.AutoMigrate(
 		&UserModel{},
@@ -68,7 +117,7 @@ func AutoMigrate(db *gorm.DB) error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	log.Println("GORM auto-migrations completed successfully")
+	slog.Info("GORM auto-migrations completed successfully")
 	return nil
 }
 
@@ -126,3 +175,12 @@ func ConvertDSNToURL(dsn string) (string, error) {
 
 	return url, nil
 }
+
+// Ping checks that the database accepts connections
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,161 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type conversationRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewConversationRepository creates a new conversation repository implementation
+func NewConversationRepository(db repository.DB) repository.ConversationRepository {
+	return &conversationRepositoryImpl{db: db}
+}
+
+func (r *conversationRepositoryImpl) Create(ctx context.Context, conversation *domain.Conversation) error {
+	model := r.domainToModel(conversation)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		// Another active conversation, or a redelivered source message
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	conversation.ID = model.ID
+	conversation.CreatedAt = model.CreatedAt
+	conversation.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *conversationRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Conversation, error) {
+	var model ConversationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *conversationRepositoryImpl) FindBySourceMessageID(ctx context.Context, messageID string) (*domain.Conversation, error) {
+	var model ConversationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("source_message_id = ?", messageID).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *conversationRepositoryImpl) FindActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.Conversation, error) {
+	var model ConversationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ? AND status = ?", userID, domain.ConversationActive).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *conversationRepositoryImpl) Update(ctx context.Context, conversation *domain.Conversation) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&ConversationModel{}).
+		Where("id = ? AND version = ?", conversation.ID, conversation.Version-1).
+		Updates(map[string]interface{}{
+			"step":       conversation.Step,
+			"status":     conversation.Status,
+			"data":       dataOrEmpty(conversation.Data),
+			"expires_at": conversation.ExpiresAt,
+			"version":    conversation.Version,
+			"updated_at": conversation.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *conversationRepositoryImpl) domainToModel(conversation *domain.Conversation) *ConversationModel {
+	return &ConversationModel{
+		ID:              conversation.ID,
+		UserID:          conversation.UserID,
+		Flow:            conversation.Flow,
+		Step:            conversation.Step,
+		Status:          conversation.Status,
+		Data:            dataOrEmpty(conversation.Data),
+		SourceMessageID: conversation.SourceMessageID,
+		ExpiresAt:       conversation.ExpiresAt,
+		Version:         conversation.Version,
+		CreatedAt:       conversation.CreatedAt,
+		UpdatedAt:       conversation.UpdatedAt,
+	}
+}
+
+func (r *conversationRepositoryImpl) modelToDomain(model *ConversationModel) *domain.Conversation {
+	return &domain.Conversation{
+		ID:              model.ID,
+		UserID:          model.UserID,
+		Flow:            model.Flow,
+		Step:            model.Step,
+		Status:          model.Status,
+		Data:            json.RawMessage(model.Data),
+		SourceMessageID: model.SourceMessageID,
+		ExpiresAt:       model.ExpiresAt,
+		Version:         model.Version,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+}
+
+func dataOrEmpty(data json.RawMessage) string {
+	if len(data) == 0 {
+		return "{}"
+	}
+	return string(data)
+}
@@ -0,0 +1,183 @@
+package postgresql
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// countingDB is a repository.DB decorator that records every executed query on
+// the QueryCounter carried by the request context. It is used in development and
+// staging to catch N+1 query patterns before they reach production.
+type countingDB struct {
+	db      repository.DB
+	counter *repository.QueryCounter
+}
+
+// NewCountingDB wraps a repository.DB so executed queries are counted per request
+func NewCountingDB(db repository.DB) repository.DB {
+	return &countingDB{db: db}
+}
+
+func (c *countingDB) wrap(db repository.DB) repository.DB {
+	return &countingDB{db: db, counter: c.counter}
+}
+
+// record counts a query and reports whether it may proceed
+func (c *countingDB) record() error {
+	if c.counter == nil {
+		return nil
+	}
+	return c.counter.Add()
+}
+
+func (c *countingDB) WithContext(ctx context.Context) repository.DB {
+	return &countingDB{
+		db:      c.db.WithContext(ctx),
+		counter: repository.QueryCounterFromContext(ctx),
+	}
+}
+
+func (c *countingDB) Create(value interface{}) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.Create(value)
+}
+
+// CreateInBatches counts one query per batch
+func (c *countingDB) CreateInBatches(value interface{}, batchSize int) repository.Result {
+	rows := reflect.Indirect(reflect.ValueOf(value)).Len()
+	for i := 0; i < rows; i += batchSize {
+		if err := c.record(); err != nil {
+			return &errorResult{err: err}
+		}
+	}
+	return c.db.CreateInBatches(value, batchSize)
+}
+
+func (c *countingDB) Where(query interface{}, args ...interface{}) repository.DB {
+	return c.wrap(c.db.Where(query, args...))
+}
+
+func (c *countingDB) First(dest interface{}) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.First(dest)
+}
+
+func (c *countingDB) Limit(limit int) repository.DB {
+	return c.wrap(c.db.Limit(limit))
+}
+
+func (c *countingDB) Offset(offset int) repository.DB {
+	return c.wrap(c.db.Offset(offset))
+}
+
+func (c *countingDB) Order(value interface{}) repository.DB {
+	return c.wrap(c.db.Order(value))
+}
+
+func (c *countingDB) Group(name string) repository.DB {
+	return c.wrap(c.db.Group(name))
+}
+
+func (c *countingDB) Having(query interface{}, args ...interface{}) repository.DB {
+	return c.wrap(c.db.Having(query, args...))
+}
+
+func (c *countingDB) Joins(query string, args ...interface{}) repository.DB {
+	return c.wrap(c.db.Joins(query, args...))
+}
+
+func (c *countingDB) Unscoped() repository.DB {
+	return c.wrap(c.db.Unscoped())
+}
+
+// Preload counts nothing by itself; each preloaded association runs one more query
+// that is not counted
+func (c *countingDB) Preload(query string, args ...interface{}) repository.DB {
+	return c.wrap(c.db.Preload(query, args...))
+}
+
+func (c *countingDB) Find(dest interface{}) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.Find(dest)
+}
+
+func (c *countingDB) Model(value interface{}) repository.DB {
+	return c.wrap(c.db.Model(value))
+}
+
+func (c *countingDB) Select(query interface{}) repository.DB {
+	return c.wrap(c.db.Select(query))
+}
+
+func (c *countingDB) Scan(dest interface{}) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.Scan(dest)
+}
+
+func (c *countingDB) Count(count *int64) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.Count(count)
+}
+
+func (c *countingDB) Updates(values interface{}) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.Updates(values)
+}
+
+func (c *countingDB) Delete(value interface{}, conds ...interface{}) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.Delete(value, conds...)
+}
+
+func (c *countingDB) Exec(sql string, values ...interface{}) repository.Result {
+	if err := c.record(); err != nil {
+		return &errorResult{err: err}
+	}
+	return c.db.Exec(sql, values...)
+}
+
+func (c *countingDB) Transaction(fn func(tx repository.DB) error) error {
+	return c.db.Transaction(func(tx repository.DB) error {
+		return fn(c.wrap(tx))
+	})
+}
+
+func (c *countingDB) Begin() (repository.DB, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(tx), nil
+}
+
+func (c *countingDB) Commit() error {
+	return c.db.Commit()
+}
+
+func (c *countingDB) Rollback() error {
+	return c.db.Rollback()
+}
+
+// errorResult is a Result for queries rejected before reaching the database
+type errorResult struct {
+	err error
+}
+
+func (r *errorResult) Error() error        { return r.err }
+func (r *errorResult) RowsAffected() int64 { return 0 }
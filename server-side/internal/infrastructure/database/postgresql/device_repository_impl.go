@@ -0,0 +1,127 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type deviceRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewDeviceRepository creates a new device repository implementation
+func NewDeviceRepository(db repository.DB) repository.DeviceRepository {
+	return &deviceRepositoryImpl{db: db}
+}
+
+// registerDeviceSQL keeps one row per token: an app that signs in to another account
+// keeps its token, which then belongs to the new user
+const registerDeviceSQL = `
+INSERT INTO devices (id, user_id, token, platform, name, last_seen_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (token) DO UPDATE SET
+  user_id = EXCLUDED.user_id,
+  platform = EXCLUDED.platform,
+  name = EXCLUDED.name,
+  last_seen_at = EXCLUDED.last_seen_at`
+
+func (r *deviceRepositoryImpl) Register(ctx context.Context, device *domain.Device) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Exec(registerDeviceSQL,
+		device.ID, device.UserID, device.Token, device.Platform, device.Name, device.LastSeenAt, device.CreatedAt)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	var model DeviceModel
+	if err := db.Where("token = ?", device.Token).First(&model).Error(); err != nil {
+		return err
+	}
+
+	device.ID = model.ID
+	device.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *deviceRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Device, error) {
+	var model DeviceModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *deviceRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error) {
+	var models []DeviceModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("last_seen_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	devices := make([]*domain.Device, len(models))
+	for i, model := range models {
+		devices[i] = r.modelToDomain(&model)
+	}
+
+	return devices, nil
+}
+
+func (r *deviceRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("id = ?", id).Delete(&DeviceModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *deviceRepositoryImpl) DeleteByToken(ctx context.Context, token string) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Where("token = ?", token).Delete(&DeviceModel{}).Error()
+}
+
+// Helper methods for conversion
+
+func (r *deviceRepositoryImpl) modelToDomain(model *DeviceModel) *domain.Device {
+	return &domain.Device{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Token:      model.Token,
+		Platform:   model.Platform,
+		Name:       model.Name,
+		LastSeenAt: model.LastSeenAt,
+		CreatedAt:  model.CreatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type digestRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewDigestRepository creates a new digest repository implementation
+func NewDigestRepository(db repository.DB) repository.DigestRepository {
+	return &digestRepositoryImpl{db: db}
+}
+
+// claimDigestSQL inserts nothing when the digest of the period was already claimed
+const claimDigestSQL = `
+INSERT INTO digests (user_id, frequency, period_start, created_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (user_id, frequency, period_start) DO NOTHING`
+
+func (r *digestRepositoryImpl) Claim(ctx context.Context, userID uuid.UUID, frequency string, periodStart time.Time) (bool, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Exec(claimDigestSQL, userID, frequency, periodStart, time.Now())
+	if err := res.Error(); err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
@@ -0,0 +1,88 @@
+package postgresql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+)
+
+// fieldCipher encrypts the EncryptedString columns; nil stores them as plaintext
+var fieldCipher atomic.Pointer[security.FieldCipher]
+
+// SetFieldCipher sets the cipher EncryptedString columns are encrypted with. Call it
+// before the first query; nil writes plaintext again, e.g. in development.
+func SetFieldCipher(cipher *security.FieldCipher) {
+	fieldCipher.Store(cipher)
+}
+
+// EncryptedString is a nullable string column encrypted at rest with the cipher set by
+// SetFieldCipher. It is a valuer, so the value is encrypted however it is written:
+// by a model, in a map of Updates, or as a query argument. Values written before the
+// cipher was set are read as they are and encrypted when they are written next.
+type EncryptedString struct {
+	String string
+	Valid  bool // false for NULL
+}
+
+// NewEncryptedString returns the column value of s, NULL when s is nil
+func NewEncryptedString(s *string) EncryptedString {
+	if s == nil {
+		return EncryptedString{}
+	}
+	return EncryptedString{String: *s, Valid: true}
+}
+
+// Ptr returns the plaintext, or nil for NULL
+func (s EncryptedString) Ptr() *string {
+	if !s.Valid {
+		return nil
+	}
+	value := s.String
+	return &value
+}
+
+// Value implements driver.Valuer
+func (s EncryptedString) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	cipher := fieldCipher.Load()
+	if cipher == nil {
+		return s.String, nil
+	}
+	return cipher.Encrypt(s.String)
+}
+
+// Scan implements sql.Scanner
+func (s *EncryptedString) Scan(src interface{}) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*s = EncryptedString{}
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EncryptedString", src)
+	}
+
+	if !security.IsEncryptedField(value) {
+		*s = EncryptedString{String: value, Valid: true}
+		return nil
+	}
+	cipher := fieldCipher.Load()
+	if cipher == nil {
+		return errors.New("encrypted column read without DB_ENCRYPTION_KEYS")
+	}
+	plaintext, err := cipher.Decrypt(value)
+	if err != nil {
+		return err
+	}
+	*s = EncryptedString{String: plaintext, Valid: true}
+	return nil
+}
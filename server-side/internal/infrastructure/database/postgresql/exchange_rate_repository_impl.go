@@ -0,0 +1,95 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type exchangeRateRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewExchangeRateRepository creates a new exchange rate repository implementation
+func NewExchangeRateRepository(db repository.DB) repository.ExchangeRateRepository {
+	return &exchangeRateRepositoryImpl{db: db}
+}
+
+// saveExchangeRateSQL replaces the rate of a day, as providers may correct a published rate
+const saveExchangeRateSQL = `
+INSERT INTO exchange_rates (base_currency, currency, rate_date, rate, source, fetched_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (base_currency, currency, rate_date) DO UPDATE SET
+  rate = EXCLUDED.rate,
+  source = EXCLUDED.source,
+  fetched_at = EXCLUDED.fetched_at`
+
+func (r *exchangeRateRepositoryImpl) Save(ctx context.Context, rates []*domain.ExchangeRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Transaction(func(tx repository.DB) error {
+		for _, rate := range rates {
+			res := tx.Exec(saveExchangeRateSQL,
+				rate.Base, rate.Currency, rate.Date, rate.Rate, rate.Source, rate.FetchedAt)
+			if err := res.Error(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *exchangeRateRepositoryImpl) FindLatest(ctx context.Context, base string, on time.Time) ([]*domain.ExchangeRate, error) {
+	var latest struct {
+		RateDate *time.Time
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&ExchangeRateModel{}).
+		Select("MAX(rate_date) AS rate_date").
+		Where("base_currency = ? AND rate_date <= ?", base, on).
+		Scan(&latest)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+	if latest.RateDate == nil {
+		return nil, domain.ErrNotFound
+	}
+
+	var models []ExchangeRateModel
+	res = db.Where("base_currency = ? AND rate_date = ?", base, *latest.RateDate).
+		Order("currency ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	rates := make([]*domain.ExchangeRate, len(models))
+	for i := range models {
+		rates[i] = r.modelToDomain(&models[i])
+	}
+
+	return rates, nil
+}
+
+// Helper methods for conversion
+
+func (r *exchangeRateRepositoryImpl) modelToDomain(model *ExchangeRateModel) *domain.ExchangeRate {
+	return &domain.ExchangeRate{
+		Base:      model.BaseCurrency,
+		Currency:  model.Currency,
+		Date:      model.RateDate,
+		Rate:      model.Rate,
+		Source:    model.Source,
+		FetchedAt: model.FetchedAt,
+	}
+}
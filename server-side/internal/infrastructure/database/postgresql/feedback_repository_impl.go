@@ -0,0 +1,146 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type feedbackRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewFeedbackRepository creates a new feedback repository implementation
+func NewFeedbackRepository(db repository.DB) repository.FeedbackRepository {
+	return &feedbackRepositoryImpl{db: db}
+}
+
+func (r *feedbackRepositoryImpl) Create(ctx context.Context, feedback *domain.Feedback) error {
+	model := r.domainToModel(feedback)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		// A redelivered chat message
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	feedback.ID = model.ID
+	feedback.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *feedbackRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Feedback, error) {
+	var model FeedbackModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *feedbackRepositoryImpl) List(ctx context.Context, filter domain.FeedbackFilter, limit, offset int) ([]*domain.Feedback, error) {
+	var models []FeedbackModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	query := db.Model(&FeedbackModel{})
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Source != "" {
+		query = query.Where("source = ?", filter.Source)
+	}
+	if filter.UserID != uuid.Nil {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	res := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	feedback := make([]*domain.Feedback, len(models))
+	for i, model := range models {
+		feedback[i] = r.modelToDomain(&model)
+	}
+
+	return feedback, nil
+}
+
+func (r *feedbackRepositoryImpl) MarkForwarded(ctx context.Context, id uuid.UUID, forwardedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&FeedbackModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"forwarded_at": forwardedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *feedbackRepositoryImpl) domainToModel(feedback *domain.Feedback) *FeedbackModel {
+	return &FeedbackModel{
+		ID:            feedback.ID,
+		UserID:        feedback.UserID,
+		Kind:          feedback.Kind,
+		Source:        feedback.Source,
+		Message:       feedback.Message,
+		RequestID:     feedback.RequestID,
+		LastRequestID: feedback.LastRequestID,
+		AppVersion:    feedback.AppVersion,
+		UserAgent:     feedback.UserAgent,
+		ForwardedAt:   feedback.ForwardedAt,
+		CreatedAt:     feedback.CreatedAt,
+	}
+}
+
+func (r *feedbackRepositoryImpl) modelToDomain(model *FeedbackModel) *domain.Feedback {
+	return &domain.Feedback{
+		ID:            model.ID,
+		UserID:        model.UserID,
+		Kind:          model.Kind,
+		Source:        model.Source,
+		Message:       model.Message,
+		RequestID:     model.RequestID,
+		LastRequestID: model.LastRequestID,
+		AppVersion:    model.AppVersion,
+		UserAgent:     model.UserAgent,
+		ForwardedAt:   model.ForwardedAt,
+		CreatedAt:     model.CreatedAt,
+	}
+}
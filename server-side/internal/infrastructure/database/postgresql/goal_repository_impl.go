@@ -0,0 +1,156 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type goalRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewGoalRepository creates a new goal repository implementation
+func NewGoalRepository(db repository.DB) repository.GoalRepository {
+	return &goalRepositoryImpl{db: db}
+}
+
+func (r *goalRepositoryImpl) Create(ctx context.Context, goal *domain.Goal) error {
+	model := r.domainToModel(goal)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	goal.ID = model.ID
+	goal.CreatedAt = model.CreatedAt
+	goal.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *goalRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Goal, error) {
+	var model GoalModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *goalRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*domain.Goal, error) {
+	var models []GoalModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	query := db.Where("user_id = ?", userID)
+	if !includeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	res := query.Order("deadline IS NULL, deadline ASC, created_at ASC").Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	goals := make([]*domain.Goal, len(models))
+	for i, model := range models {
+		goals[i] = r.modelToDomain(&model)
+	}
+
+	return goals, nil
+}
+
+func (r *goalRepositoryImpl) Update(ctx context.Context, goal *domain.Goal) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&GoalModel{}).
+		Where("id = ? AND version = ?", goal.ID, goal.Version-1).
+		Updates(map[string]interface{}{
+			"name":          goal.Name,
+			"target_amount": moneyDecimal(goal.TargetAmount, goal.Currency),
+			"saved_amount":  moneyDecimal(goal.SavedAmount, goal.Currency),
+			"deadline":      goal.Deadline,
+			"archived_at":   goal.ArchivedAt,
+			"version":       goal.Version,
+			"updated_at":    goal.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *goalRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("id = ?", id).Delete(&GoalModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *goalRepositoryImpl) domainToModel(goal *domain.Goal) *GoalModel {
+	return &GoalModel{
+		ID:           goal.ID,
+		UserID:       goal.UserID,
+		Name:         goal.Name,
+		Currency:     goal.Currency,
+		TargetAmount: moneyDecimal(goal.TargetAmount, goal.Currency),
+		SavedAmount:  moneyDecimal(goal.SavedAmount, goal.Currency),
+		Deadline:     goal.Deadline,
+		ArchivedAt:   goal.ArchivedAt,
+		Version:      goal.Version,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	}
+}
+
+func (r *goalRepositoryImpl) modelToDomain(model *GoalModel) *domain.Goal {
+	return &domain.Goal{
+		ID:           model.ID,
+		UserID:       model.UserID,
+		Name:         model.Name,
+		Currency:     model.Currency,
+		TargetAmount: model.TargetAmount.Minor(model.Currency),
+		SavedAmount:  model.SavedAmount.Minor(model.Currency),
+		Deadline:     model.Deadline,
+		ArchivedAt:   model.ArchivedAt,
+		Version:      model.Version,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
@@ -27,6 +27,11 @@ func (g *gormDB) Create(value interface{}) repository.Result {
 	return &gormResult{db: res}
 }
 
+func (g *gormDB) CreateInBatches(value interface{}, batchSize int) repository.Result {
+	res := g.db.CreateInBatches(value, batchSize)
+	return &gormResult{db: res}
+}
+
 func (g *gormDB) Where(query interface{}, args ...interface{}) repository.DB {
 	return &gormDB{db: g.db.Where(query, args...)}
 }
@@ -48,6 +53,26 @@ func (g *gormDB) Order(value interface{}) repository.DB {
 	return &gormDB{db: g.db.Order(value)}
 }
 
+func (g *gormDB) Group(name string) repository.DB {
+	return &gormDB{db: g.db.Group(name)}
+}
+
+func (g *gormDB) Having(query interface{}, args ...interface{}) repository.DB {
+	return &gormDB{db: g.db.Having(query, args...)}
+}
+
+func (g *gormDB) Joins(query string, args ...interface{}) repository.DB {
+	return &gormDB{db: g.db.Joins(query, args...)}
+}
+
+func (g *gormDB) Unscoped() repository.DB {
+	return &gormDB{db: g.db.Unscoped()}
+}
+
+func (g *gormDB) Preload(query string, args ...interface{}) repository.DB {
+	return &gormDB{db: g.db.Preload(query, args...)}
+}
+
 func (g *gormDB) Find(dest interface{}) repository.Result {
 	res := g.db.Find(dest)
 	return &gormResult{db: res}
@@ -66,6 +91,11 @@ func (g *gormDB) Scan(dest interface{}) repository.Result {
 	return &gormResult{db: res}
 }
 
+func (g *gormDB) Count(count *int64) repository.Result {
+	res := g.db.Count(count)
+	return &gormResult{db: res}
+}
+
 func (g *gormDB) Updates(values interface{}) repository.Result {
 	res := g.db.Updates(values)
 	return &gormResult{db: res}
@@ -76,6 +106,11 @@ func (g *gormDB) Delete(value interface{}, conds ...interface{}) repository.Resu
 	return &gormResult{db: res}
 }
 
+func (g *gormDB) Exec(sql string, values ...interface{}) repository.Result {
+	res := g.db.Exec(sql, values...)
+	return &gormResult{db: res}
+}
+
 func (g *gormDB) Transaction(fn func(tx repository.DB) error) error {
 	return g.db.Transaction(func(tx *gorm.DB) error {
 		return fn(&gormDB{db: tx})
@@ -0,0 +1,143 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type groupInvitationRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewGroupInvitationRepository creates a new group invitation repository implementation
+func NewGroupInvitationRepository(db repository.DB) repository.GroupInvitationRepository {
+	return &groupInvitationRepositoryImpl{db: db}
+}
+
+func (r *groupInvitationRepositoryImpl) Create(ctx context.Context, invitation *domain.GroupInvitation) error {
+	model := r.domainToModel(invitation)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		return err
+	}
+
+	invitation.ID = model.ID
+	invitation.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *groupInvitationRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.GroupInvitation, error) {
+	var model GroupInvitationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("token_hash = ?", tokenHash).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *groupInvitationRepositoryImpl) FindPendingByGroupID(ctx context.Context, groupID uuid.UUID, now time.Time) ([]*domain.GroupInvitation, error) {
+	var models []GroupInvitationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("group_id = ? AND accepted_at IS NULL AND expires_at > ?", groupID, now).
+		Order("created_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	invitations := make([]*domain.GroupInvitation, len(models))
+	for i, model := range models {
+		invitations[i] = r.modelToDomain(&model)
+	}
+
+	return invitations, nil
+}
+
+func (r *groupInvitationRepositoryImpl) MarkAccepted(ctx context.Context, id, userID uuid.UUID, acceptedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&GroupInvitationModel{}).
+		Where("id = ? AND accepted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"accepted_by": userID,
+			"accepted_at": acceptedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *groupInvitationRepositoryImpl) Delete(ctx context.Context, groupID, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("id = ? AND group_id = ?", id, groupID).Delete(&GroupInvitationModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *groupInvitationRepositoryImpl) domainToModel(invitation *domain.GroupInvitation) *GroupInvitationModel {
+	return &GroupInvitationModel{
+		ID:         invitation.ID,
+		GroupID:    invitation.GroupID,
+		InvitedBy:  invitation.InvitedBy,
+		Role:       invitation.Role,
+		TokenHash:  invitation.TokenHash,
+		ExpiresAt:  invitation.ExpiresAt,
+		AcceptedBy: invitation.AcceptedBy,
+		AcceptedAt: invitation.AcceptedAt,
+		CreatedAt:  invitation.CreatedAt,
+	}
+}
+
+func (r *groupInvitationRepositoryImpl) modelToDomain(model *GroupInvitationModel) *domain.GroupInvitation {
+	return &domain.GroupInvitation{
+		ID:         model.ID,
+		GroupID:    model.GroupID,
+		InvitedBy:  model.InvitedBy,
+		Role:       model.Role,
+		TokenHash:  model.TokenHash,
+		ExpiresAt:  model.ExpiresAt,
+		AcceptedBy: model.AcceptedBy,
+		AcceptedAt: model.AcceptedAt,
+		CreatedAt:  model.CreatedAt,
+	}
+}
@@ -0,0 +1,253 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type groupRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewGroupRepository creates a new group repository implementation
+func NewGroupRepository(db repository.DB) repository.GroupRepository {
+	return &groupRepositoryImpl{db: db}
+}
+
+func (r *groupRepositoryImpl) Create(ctx context.Context, group *domain.Group) error {
+	model := r.domainToModel(group)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		return err
+	}
+
+	group.ID = model.ID
+	group.CreatedAt = model.CreatedAt
+	group.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *groupRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Group, error) {
+	var model GroupModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *groupRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Group, error) {
+	var models []GroupModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Joins("JOIN group_members ON group_members.group_id = groups.id").
+		Where("group_members.user_id = ?", userID).
+		Order("lower(groups.name) ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	groups := make([]*domain.Group, len(models))
+	for i, model := range models {
+		groups[i] = r.modelToDomain(&model)
+	}
+
+	return groups, nil
+}
+
+func (r *groupRepositoryImpl) Update(ctx context.Context, group *domain.Group) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&GroupModel{}).
+		Where("id = ? AND version = ?", group.ID, group.Version-1).
+		Updates(map[string]interface{}{
+			"name":       group.Name,
+			"version":    group.Version,
+			"updated_at": group.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *groupRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Members and invitations are deleted by cascade; money flows lose their group
+	result := db.Where("id = ?", id).Delete(&GroupModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *groupRepositoryImpl) AddMember(ctx context.Context, member *domain.GroupMember) error {
+	model := &GroupMemberModel{
+		GroupID:   member.GroupID,
+		UserID:    member.UserID,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *groupRepositoryImpl) FindMember(ctx context.Context, groupID, userID uuid.UUID) (*domain.GroupMember, error) {
+	var model GroupMemberModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("group_id = ? AND user_id = ?", groupID, userID).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &domain.GroupMember{
+		GroupID:   model.GroupID,
+		UserID:    model.UserID,
+		Role:      model.Role,
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+func (r *groupRepositoryImpl) FindMembers(ctx context.Context, groupID uuid.UUID) ([]*domain.GroupMember, error) {
+	var rows []struct {
+		GroupMemberModel
+		FullName string
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&GroupMemberModel{}).
+		Select("group_members.*, users.full_name").
+		Joins("JOIN users ON users.id = group_members.user_id AND users.deleted_at IS NULL").
+		Where("group_members.group_id = ?", groupID).
+		Order("group_members.created_at ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	members := make([]*domain.GroupMember, len(rows))
+	for i, row := range rows {
+		members[i] = &domain.GroupMember{
+			GroupID:   row.GroupID,
+			UserID:    row.UserID,
+			Role:      row.Role,
+			CreatedAt: row.CreatedAt,
+			FullName:  row.FullName,
+		}
+	}
+
+	return members, nil
+}
+
+func (r *groupRepositoryImpl) UpdateMemberRole(ctx context.Context, groupID, userID uuid.UUID, role string) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&GroupMemberModel{}).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Updates(map[string]interface{}{"role": role})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *groupRepositoryImpl) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&GroupMemberModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *groupRepositoryImpl) domainToModel(group *domain.Group) *GroupModel {
+	return &GroupModel{
+		ID:        group.ID,
+		Name:      group.Name,
+		CreatedBy: group.CreatedBy,
+		Version:   group.Version,
+		CreatedAt: group.CreatedAt,
+		UpdatedAt: group.UpdatedAt,
+	}
+}
+
+func (r *groupRepositoryImpl) modelToDomain(model *GroupModel) *domain.Group {
+	return &domain.Group{
+		ID:        model.ID,
+		Name:      model.Name,
+		CreatedBy: model.CreatedBy,
+		Version:   model.Version,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type invitationRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewInvitationRepository creates a new invitation repository implementation
+func NewInvitationRepository(db repository.DB) repository.InvitationRepository {
+	return &invitationRepositoryImpl{db: db}
+}
+
+func (r *invitationRepositoryImpl) Create(ctx context.Context, invitation *domain.Invitation) error {
+	model := r.domainToModel(invitation)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	// Update domain entity with generated values
+	invitation.ID = model.ID
+	invitation.CreatedAt = model.CreatedAt
+	invitation.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *invitationRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *invitationRepositoryImpl) FindByEmail(ctx context.Context, email string) (*domain.Invitation, error) {
+	return r.findOne(ctx, "lower(email) = ?", strings.ToLower(email))
+}
+
+func (r *invitationRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	return r.findOne(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *invitationRepositoryImpl) MarkSent(ctx context.Context, id uuid.UUID, tokenHash string, sentAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&InvitationModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"token_hash": tokenHash,
+			"sent_at":    sentAt,
+			"updated_at": sentAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *invitationRepositoryImpl) MarkAccepted(ctx context.Context, id uuid.UUID, acceptedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&InvitationModel{}).
+		Where("id = ? AND accepted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"accepted_at": acceptedAt,
+			"updated_at":  acceptedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *invitationRepositoryImpl) ClearTokens(ctx context.Context, before time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Exec(`UPDATE invitations SET token_hash = NULL, updated_at = NOW() WHERE id IN (
+		SELECT id FROM invitations WHERE token_hash IS NOT NULL AND (accepted_at < ? OR expires_at < ?) LIMIT ?
+	)`, before, before, limit)
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (r *invitationRepositoryImpl) findOne(ctx context.Context, query string, args ...interface{}) (*domain.Invitation, error) {
+	var model InvitationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where(query, args...).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *invitationRepositoryImpl) domainToModel(invitation *domain.Invitation) *InvitationModel {
+	var invitedBy *uuid.UUID
+	if invitation.InvitedBy != uuid.Nil {
+		invitedBy = &invitation.InvitedBy
+	}
+
+	var tokenHash *string
+	if invitation.TokenHash != "" {
+		tokenHash = &invitation.TokenHash
+	}
+
+	return &InvitationModel{
+		ID:         invitation.ID,
+		UserID:     invitation.UserID,
+		InvitedBy:  invitedBy,
+		Email:      invitation.Email,
+		Channel:    invitation.Channel,
+		TokenHash:  tokenHash,
+		SentAt:     invitation.SentAt,
+		ExpiresAt:  invitation.ExpiresAt,
+		AcceptedAt: invitation.AcceptedAt,
+		CreatedAt:  invitation.CreatedAt,
+		UpdatedAt:  invitation.UpdatedAt,
+	}
+}
+
+func (r *invitationRepositoryImpl) modelToDomain(model *InvitationModel) *domain.Invitation {
+	invitation := &domain.Invitation{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Email:      model.Email,
+		Channel:    model.Channel,
+		SentAt:     model.SentAt,
+		ExpiresAt:  model.ExpiresAt,
+		AcceptedAt: model.AcceptedAt,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+	if model.InvitedBy != nil {
+		invitation.InvitedBy = *model.InvitedBy
+	}
+	if model.TokenHash != nil {
+		invitation.TokenHash = *model.TokenHash
+	}
+	return invitation
+}
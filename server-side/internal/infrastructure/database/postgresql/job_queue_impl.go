@@ -0,0 +1,188 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+)
+
+type jobQueueImpl struct {
+	db repository.DB
+}
+
+// NewJobQueue creates a new PostgreSQL-backed job queue.
+// Enqueueing inside a transaction commits the job together with the caller's writes.
+func NewJobQueue(db repository.DB) worker.Queue {
+	return &jobQueueImpl{db: db}
+}
+
+func (q *jobQueueImpl) Enqueue(ctx context.Context, job *worker.Job) error {
+	model := q.domainToModel(job)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, q.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	job.ID = model.ID
+	job.CreatedAt = model.CreatedAt
+	job.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (q *jobQueueImpl) FindDue(ctx context.Context, types []string, now time.Time, limit int) ([]*worker.Job, error) {
+	var models []JobModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, q.db)
+
+	res := db.Where("status = ? AND type IN ? AND run_at <= ?", worker.StatusPending, types, now).
+		Order("run_at ASC").
+		Limit(limit).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*worker.Job, len(models))
+	for i, model := range models {
+		jobs[i] = q.modelToDomain(&model)
+	}
+
+	return jobs, nil
+}
+
+func (q *jobQueueImpl) Claim(ctx context.Context, job *worker.Job) error {
+	now := time.Now()
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, q.db)
+
+	// Only one worker wins the pending -> running transition
+	result := db.Model(&JobModel{}).
+		Where("id = ? AND status = ?", job.ID, worker.StatusPending).
+		Updates(map[string]interface{}{
+			"status":     worker.StatusRunning,
+			"attempts":   job.Attempts + 1,
+			"locked_at":  now,
+			"updated_at": now,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	job.Status = worker.StatusRunning
+	job.Attempts++
+	job.LockedAt = &now
+	job.UpdatedAt = now
+	return nil
+}
+
+func (q *jobQueueImpl) Update(ctx context.Context, job *worker.Job) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, q.db)
+
+	// Skip jobs that were released as stale and claimed again meanwhile
+	result := db.Model(&JobModel{}).
+		Where("id = ? AND status = ? AND attempts = ?", job.ID, worker.StatusRunning, job.Attempts).
+		Updates(map[string]interface{}{
+			"status":      job.Status,
+			"run_at":      job.RunAt,
+			"last_error":  job.LastError,
+			"locked_at":   job.LockedAt,
+			"finished_at": job.FinishedAt,
+			"updated_at":  job.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+func (q *jobQueueImpl) ReleaseStale(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, q.db)
+
+	result := db.Model(&JobModel{}).
+		Where("status = ? AND locked_at < ?", worker.StatusRunning, claimedBefore).
+		Updates(map[string]interface{}{
+			"status":     worker.StatusPending,
+			"locked_at":  nil,
+			"updated_at": time.Now(),
+		})
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (q *jobQueueImpl) DeleteSucceeded(ctx context.Context, finishedBefore time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, q.db)
+
+	result := db.Exec(
+		`DELETE FROM jobs WHERE id IN (
+			SELECT id FROM jobs WHERE status = ? AND finished_at < ? LIMIT ?
+		)`,
+		worker.StatusSucceeded, finishedBefore, limit,
+	)
+
+	return result.RowsAffected(), result.Error()
+}
+
+// Helper methods for conversion
+
+func (q *jobQueueImpl) domainToModel(job *worker.Job) *JobModel {
+	payload := string(job.Payload)
+	if payload == "" {
+		payload = "{}"
+	}
+
+	return &JobModel{
+		ID:          job.ID,
+		Type:        job.Type,
+		Payload:     payload,
+		Status:      job.Status,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LastError:   job.LastError,
+		LockedAt:    job.LockedAt,
+		FinishedAt:  job.FinishedAt,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+	}
+}
+
+func (q *jobQueueImpl) modelToDomain(model *JobModel) *worker.Job {
+	return &worker.Job{
+		ID:          model.ID,
+		Type:        model.Type,
+		Payload:     json.RawMessage(model.Payload),
+		Status:      model.Status,
+		Attempts:    model.Attempts,
+		MaxAttempts: model.MaxAttempts,
+		RunAt:       model.RunAt,
+		LastError:   model.LastError,
+		LockedAt:    model.LockedAt,
+		FinishedAt:  model.FinishedAt,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
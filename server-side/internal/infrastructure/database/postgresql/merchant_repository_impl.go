@@ -0,0 +1,335 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type merchantRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewMerchantRepository creates a new merchant repository implementation
+func NewMerchantRepository(db repository.DB) repository.MerchantRepository {
+	return &merchantRepositoryImpl{db: db}
+}
+
+// createMerchantSQL inserts nothing when a merchant already has the name
+const createMerchantSQL = `
+INSERT INTO merchants (id, name, created_at, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT DO NOTHING`
+
+func (r *merchantRepositoryImpl) FindOrCreate(ctx context.Context, name string) (*domain.Merchant, error) {
+	merchant, err := domain.NewMerchant(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Concurrent requests may create the same merchant; the loser reads the winner's
+	res := db.Exec(createMerchantSQL, merchant.ID, merchant.Name, merchant.CreatedAt, merchant.UpdatedAt)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	var model MerchantModel
+	res = db.Where("lower(name) = ?", strings.ToLower(merchant.Name)).First(&model)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *merchantRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Merchant, error) {
+	var model MerchantModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *merchantRepositoryImpl) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Merchant, error) {
+	if len(ids) == 0 {
+		return []*domain.Merchant{}, nil
+	}
+
+	var models []MerchantModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id IN ?", ids).Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	merchants := make([]*domain.Merchant, len(models))
+	for i, model := range models {
+		merchants[i] = r.modelToDomain(&model)
+	}
+
+	return merchants, nil
+}
+
+func (r *merchantRepositoryImpl) List(ctx context.Context, query string, limit, offset int) ([]*domain.Merchant, error) {
+	var models []MerchantModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// LIKE with an explicit escape character works on SQLite too
+	if query != "" {
+		db = db.Where(`lower(name) LIKE ? ESCAPE '\'`, "%"+escapeLike(strings.ToLower(query))+"%")
+	}
+	res := db.Order("lower(name) ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	merchants := make([]*domain.Merchant, len(models))
+	for i, model := range models {
+		merchants[i] = r.modelToDomain(&model)
+	}
+
+	return merchants, nil
+}
+
+func (r *merchantRepositoryImpl) Update(ctx context.Context, merchant *domain.Merchant) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&MerchantModel{}).
+		Where("id = ?", merchant.ID).
+		Updates(map[string]interface{}{
+			"name":       merchant.Name,
+			"updated_at": merchant.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		// Merchant names are unique, case-insensitive
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *merchantRepositoryImpl) CreateRule(ctx context.Context, rule *domain.MerchantRule) error {
+	model := r.ruleToModel(rule)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		// Rule patterns are unique
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	rule.ID = model.ID
+	rule.CreatedAt = model.CreatedAt
+	rule.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *merchantRepositoryImpl) FindRuleByID(ctx context.Context, id uuid.UUID) (*domain.MerchantRule, error) {
+	var model MerchantRuleModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	rules, err := r.withMerchants(ctx, []MerchantRuleModel{model})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules[0], nil
+}
+
+func (r *merchantRepositoryImpl) ListRules(ctx context.Context) ([]*domain.MerchantRule, error) {
+	var models []MerchantRuleModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Order("pattern ASC").Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	return r.withMerchants(ctx, models)
+}
+
+// withMerchants converts rules to domain entities with their merchants, loaded in one
+// query
+func (r *merchantRepositoryImpl) withMerchants(ctx context.Context, models []MerchantRuleModel) ([]*domain.MerchantRule, error) {
+	ids := make([]uuid.UUID, len(models))
+	for i, model := range models {
+		ids[i] = model.MerchantID
+	}
+	merchants, err := r.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*domain.Merchant, len(merchants))
+	for _, merchant := range merchants {
+		byID[merchant.ID] = merchant
+	}
+
+	rules := make([]*domain.MerchantRule, len(models))
+	for i, model := range models {
+		rules[i] = r.ruleToDomain(&model)
+		rules[i].Merchant = byID[model.MerchantID]
+	}
+
+	return rules, nil
+}
+
+func (r *merchantRepositoryImpl) UpdateRule(ctx context.Context, rule *domain.MerchantRule) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&MerchantRuleModel{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]interface{}{
+			"pattern":     rule.Pattern,
+			"merchant_id": rule.MerchantID,
+			"updated_at":  rule.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *merchantRepositoryImpl) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("id = ?", id).Delete(&MerchantRuleModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *merchantRepositoryImpl) GetTotals(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.MerchantTotal, error) {
+	var rows []struct {
+		MerchantID uuid.UUID
+		Currency   string
+		Total      Decimal
+		Count      int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MoneyFlowModel{}).
+		Select("merchant_id, currency, COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where("user_id = ? AND merchant_id IS NOT NULL", userID).
+		Where("kind = ? AND created_at >= ? AND created_at < ?", domain.MoneyFlowKindExpense, start, end).
+		Group("merchant_id, currency").
+		Order("total DESC, merchant_id ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.MerchantTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.MerchantTotal{
+			MerchantID: row.MerchantID,
+			Currency:   row.Currency,
+			Total:      row.Total.Minor(row.Currency),
+			Count:      row.Count,
+		}
+	}
+
+	return totals, nil
+}
+
+// Helper methods for conversion
+
+func (r *merchantRepositoryImpl) modelToDomain(model *MerchantModel) *domain.Merchant {
+	return &domain.Merchant{
+		ID:        model.ID,
+		Name:      model.Name,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+func (r *merchantRepositoryImpl) ruleToModel(rule *domain.MerchantRule) *MerchantRuleModel {
+	return &MerchantRuleModel{
+		ID:         rule.ID,
+		Pattern:    rule.Pattern,
+		MerchantID: rule.MerchantID,
+		CreatedAt:  rule.CreatedAt,
+		UpdatedAt:  rule.UpdatedAt,
+	}
+}
+
+func (r *merchantRepositoryImpl) ruleToDomain(model *MerchantRuleModel) *domain.MerchantRule {
+	return &domain.MerchantRule{
+		ID:         model.ID,
+		Pattern:    model.Pattern,
+		MerchantID: model.MerchantID,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
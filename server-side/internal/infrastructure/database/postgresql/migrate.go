@@ -1,32 +1,68 @@
 package postgresql
 
 import (
+	"context"
+	"embed"
 	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"gorm.io/gorm"
 )
 
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migrations returns the migration files compiled into the binary, so migrations run
+// the same from any working directory or container image
+func Migrations() fs.FS {
+	migrations, err := fs.Sub(embeddedMigrations, "migrations")
+	if err != nil {
+		// fs.Sub only fails on an invalid directory name
+		panic(err)
+	}
+	return migrations
+}
+
+// newMigrate creates a migrate instance reading the migration files from migrations
+func newMigrate(databaseURL string, migrations fs.FS) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrations, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", source, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+	return m, nil
+}
+
 // RunMigrations runs all pending database migrations
-func RunMigrations(databaseURL string, migrationsPath string) error {
-	log.Println("Running database migrations...")
+func RunMigrations(databaseURL string, migrations fs.FS) error {
+	slog.Info("Running database migrations")
 
-	m, err := migrate.New(
-		fmt.Sprintf("file://%s", migrationsPath),
-		databaseURL,
-	)
+	m, err := newMigrate(databaseURL, migrations)
 	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
+		return err
 	}
 	defer m.Close()
 
 	// Run all pending migrations
 	if err := m.Up(); err != nil {
 		if errors.Is(err, migrate.ErrNoChange) {
-			log.Println("No new migrations to apply")
+			slog.Info("No new migrations to apply")
 			return nil
 		}
 		return fmt.Errorf("failed to run migrations: %w", err)
@@ -38,30 +74,27 @@ func RunMigrations(databaseURL string, migrationsPath string) error {
 	}
 
 	if dirty {
-		log.Printf("WARNING: Database is in dirty state at version %d", version)
+		slog.Warn("Database is in dirty state", "version", version)
 		return fmt.Errorf("database is in dirty state")
 	}
 
-	log.Printf("Successfully applied migrations. Current version: %d", version)
+	slog.Info("Successfully applied migrations", "version", version)
 	return nil
 }
 
 // RollbackMigration rolls back the last migration
-func RollbackMigration(databaseURL string, migrationsPath string, steps int) error {
-	log.Printf("Rolling back %d migration(s)...", steps)
+func RollbackMigration(databaseURL string, migrations fs.FS, steps int) error {
+	slog.Info("Rolling back migrations", "steps", steps)
 
-	m, err := migrate.New(
-		fmt.Sprintf("file://%s", migrationsPath),
-		databaseURL,
-	)
+	m, err := newMigrate(databaseURL, migrations)
 	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
+		return err
 	}
 	defer m.Close()
 
 	if err := m.Steps(-steps); err != nil {
 		if errors.Is(err, migrate.ErrNoChange) {
-			log.Println("No migrations to rollback")
+			slog.Info("No migrations to rollback")
 			return nil
 		}
 		return fmt.Errorf("failed to rollback migrations: %w", err)
@@ -73,22 +106,19 @@ func RollbackMigration(databaseURL string, migrationsPath string, steps int) err
 	}
 
 	if dirty {
-		log.Printf("WARNING: Database is in dirty state at version %d", version)
+		slog.Warn("Database is in dirty state", "version", version)
 		return fmt.Errorf("database is in dirty state")
 	}
 
-	log.Printf("Successfully rolled back %d migration(s). Current version: %d", steps, version)
+	slog.Info("Successfully rolled back migrations", "steps", steps, "version", version)
 	return nil
 }
 
 // MigrationVersion returns the current migration version
-func MigrationVersion(databaseURL string, migrationsPath string) (uint, bool, error) {
-	m, err := migrate.New(
-		fmt.Sprintf("file://%s", migrationsPath),
-		databaseURL,
-	)
+func MigrationVersion(databaseURL string, migrations fs.FS) (uint, bool, error) {
+	m, err := newMigrate(databaseURL, migrations)
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to create migrate instance: %w", err)
+		return 0, false, err
 	}
 	defer m.Close()
 
@@ -104,15 +134,12 @@ func MigrationVersion(databaseURL string, migrationsPath string) (uint, bool, er
 }
 
 // ForceMigrationVersion forces the migration version (use with caution)
-func ForceMigrationVersion(databaseURL string, migrationsPath string, version int) error {
-	log.Printf("Forcing migration version to %d...", version)
+func ForceMigrationVersion(databaseURL string, migrations fs.FS, version int) error {
+	slog.Warn("Forcing migration version", "version", version)
 
-	m, err := migrate.New(
-		fmt.Sprintf("file://%s", migrationsPath),
-		databaseURL,
-	)
+	m, err := newMigrate(databaseURL, migrations)
 	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
+		return err
 	}
 	defer m.Close()
 
@@ -120,6 +147,123 @@ func ForceMigrationVersion(databaseURL string, migrationsPath string, version in
 		return fmt.Errorf("failed to force migration version: %w", err)
 	}
 
-	log.Printf("Successfully forced migration version to %d", version)
+	slog.Info("Successfully forced migration version", "version", version)
+	return nil
+}
+
+// MigrateTo migrates up or down to the given version
+func MigrateTo(databaseURL string, migrations fs.FS, version uint) error {
+	slog.Info("Migrating to version", "version", version)
+
+	m, err := newMigrate(databaseURL, migrations)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Migrate(version); err != nil {
+		if errors.Is(err, migrate.ErrNoChange) {
+			slog.Info("Already at version", "version", version)
+			return nil
+		}
+		return fmt.Errorf("failed to migrate to version %d: %w", version, err)
+	}
+
+	slog.Info("Successfully migrated to version", "version", version)
+	return nil
+}
+
+// Migration is a pair of up and down migration files
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// ListMigrations returns the migrations in migrations, ordered by version
+func ListMigrations(migrations fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(migrations, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var list []Migration
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".up.sql")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		prefix, name, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		list = append(list, Migration{Version: uint(version), Name: name})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	return list, nil
+}
+
+// LatestMigrationVersion returns the highest version among the migration files
+func LatestMigrationVersion(migrations fs.FS) (uint, error) {
+	list, err := ListMigrations(migrations)
+	if err != nil {
+		return 0, err
+	}
+	if len(list) == 0 {
+		return 0, nil
+	}
+	return list[len(list)-1].Version, nil
+}
+
+// migrationNamePattern restricts migration names to what reads well in a file name
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// CreateMigration creates empty up and down files for a new migration, versioned by
+// the UTC timestamp of now, and returns their paths
+func CreateMigration(migrationsPath, name string, now time.Time) (string, string, error) {
+	if !migrationNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid migration name %q: use lowercase letters, digits, and underscores", name)
+	}
+
+	version := now.UTC().Format("20060102150405")
+	base := filepath.Join(migrationsPath, fmt.Sprintf("%s_%s", version, name))
+	up, down := base+".up.sql", base+".down.sql"
+
+	for _, path := range []string{up, down} {
+		// O_EXCL keeps a second create within the same second from emptying the first
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create migration file: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return "", "", fmt.Errorf("failed to create migration file: %w", err)
+		}
+	}
+	return up, down, nil
+}
+
+// CheckMigrations checks that the database schema is at least at version latest and
+// not dirty. It reads the version table directly instead of opening a migrate instance,
+// so it is cheap enough for readiness probes.
+func CheckMigrations(ctx context.Context, db *gorm.DB, latest uint) error {
+	var row struct {
+		Version int64
+		Dirty   bool
+	}
+	res := db.WithContext(ctx).Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row)
+	if res.Error != nil {
+		return fmt.Errorf("failed to read migration version: %w", res.Error)
+	}
+
+	switch {
+	case res.RowsAffected == 0:
+		return fmt.Errorf("no migrations applied, expected version %d", latest)
+	case row.Dirty:
+		return fmt.Errorf("database is in dirty state at version %d", row.Version)
+	case row.Version < int64(latest):
+		// A newer version means another instance of a newer release migrated already
+		return fmt.Errorf("database is at version %d, expected %d", row.Version, latest)
+	}
 	return nil
 }
@@ -0,0 +1,24 @@
+-- Create api_keys table
+CREATE TABLE IF NOT EXISTS "api_keys" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "name" varchar NOT NULL,
+  "prefix" varchar NOT NULL,
+  "key_hash" varchar NOT NULL,
+  "scopes" jsonb NOT NULL DEFAULT '[]'::jsonb,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  "deleted_at" timestamptz,
+  CONSTRAINT fk_api_keys_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash_unique ON "api_keys" ("key_hash");
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON "api_keys" ("user_id");
+CREATE INDEX IF NOT EXISTS idx_api_keys_deleted_at ON "api_keys" ("deleted_at");
+
+COMMENT ON TABLE "api_keys" IS 'User-issued API keys for programmatic access';
+COMMENT ON COLUMN "api_keys"."key_hash" IS 'SHA-256 hash of the API key; the plaintext key is never stored';
+COMMENT ON COLUMN "api_keys"."scopes" IS 'JSONB array of granted scopes (read, write, export)';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_expires_at;
+DROP INDEX IF EXISTS idx_refresh_tokens_user_id;
+DROP INDEX IF EXISTS idx_refresh_tokens_token_hash_unique;
+
+DROP TABLE IF EXISTS "refresh_tokens" CASCADE;
@@ -0,0 +1,18 @@
+-- Create refresh_tokens table
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "token_hash" varchar NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_refresh_tokens_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash_unique ON "refresh_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON "refresh_tokens" ("user_id") WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON "refresh_tokens" ("expires_at");
+
+COMMENT ON TABLE "refresh_tokens" IS 'Issued refresh tokens used for rotation and revocation';
+COMMENT ON COLUMN "refresh_tokens"."token_hash" IS 'SHA-256 hash of the refresh token; the token itself is never stored';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_money_flow_notes_search_vector;
+
+DROP TABLE IF EXISTS "money_flow_notes" CASCADE;
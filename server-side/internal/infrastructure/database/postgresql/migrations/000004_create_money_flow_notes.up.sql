@@ -0,0 +1,17 @@
+-- Create money_flow_notes table
+-- Long-form markdown notes live outside money_flows to keep the main row small.
+CREATE TABLE IF NOT EXISTS "money_flow_notes" (
+  "money_flow_id" uuid PRIMARY KEY,
+  "content" text NOT NULL,
+  "search_vector" tsvector GENERATED ALWAYS AS (to_tsvector('simple', "content")) STORED,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_money_flow_notes_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE,
+  CONSTRAINT chk_money_flow_notes_content_length CHECK (octet_length("content") <= 10240)
+);
+
+CREATE INDEX IF NOT EXISTS idx_money_flow_notes_search_vector ON "money_flow_notes" USING GIN ("search_vector");
+
+COMMENT ON TABLE "money_flow_notes" IS 'Long-form markdown notes attached to money flows';
+COMMENT ON COLUMN "money_flow_notes"."content" IS 'Markdown note content, up to 10KB';
+COMMENT ON COLUMN "money_flow_notes"."search_vector" IS 'Full-text search vector generated from content';
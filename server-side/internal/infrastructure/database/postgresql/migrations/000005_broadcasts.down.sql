@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_broadcast_deliveries_pending;
+DROP INDEX IF EXISTS idx_broadcast_deliveries_broadcast_user;
+DROP INDEX IF EXISTS idx_broadcasts_created_at;
+DROP INDEX IF EXISTS idx_notifications_user_id_created_at;
+
+DROP TABLE IF EXISTS "broadcast_deliveries" CASCADE;
+DROP TABLE IF EXISTS "broadcasts" CASCADE;
+DROP TABLE IF EXISTS "notifications" CASCADE;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,63 @@
+-- Add role to users for admin-only endpoints
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "role" varchar NOT NULL DEFAULT 'user';
+
+COMMENT ON COLUMN "users"."role" IS 'Authorization role (user, admin)';
+
+-- Create notifications table
+CREATE TABLE IF NOT EXISTS "notifications" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "title" varchar NOT NULL,
+  "body" text NOT NULL,
+  "read_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_notifications_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_created_at ON "notifications" ("user_id", "created_at" DESC);
+
+-- Create broadcasts table
+CREATE TABLE IF NOT EXISTS "broadcasts" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "created_by" uuid NOT NULL,
+  "title" varchar NOT NULL,
+  "body" text NOT NULL,
+  "channels" jsonb NOT NULL DEFAULT '[]'::jsonb,
+  "audience" jsonb NOT NULL DEFAULT '{}'::jsonb,
+  "status" varchar NOT NULL DEFAULT 'queued',
+  "recipient_count" integer NOT NULL DEFAULT 0,
+  "completed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_broadcasts_created_by FOREIGN KEY ("created_by") REFERENCES "users" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcasts_created_at ON "broadcasts" ("created_at");
+
+-- Create broadcast_deliveries table
+-- Rows are written together with the broadcast and act as the outbox drained by the dispatcher.
+CREATE TABLE IF NOT EXISTS "broadcast_deliveries" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "broadcast_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "channel" varchar,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "last_error" text,
+  "sent_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_broadcast_deliveries_broadcast FOREIGN KEY ("broadcast_id") REFERENCES "broadcasts" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_broadcast_deliveries_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_broadcast_deliveries_broadcast_user ON "broadcast_deliveries" ("broadcast_id", "user_id");
+CREATE INDEX IF NOT EXISTS idx_broadcast_deliveries_pending ON "broadcast_deliveries" ("created_at") WHERE status = 'pending';
+
+COMMENT ON TABLE "notifications" IS 'In-app notifications shown to users';
+COMMENT ON TABLE "broadcasts" IS 'Admin announcements sent to all or a filtered subset of users';
+COMMENT ON COLUMN "broadcasts"."channels" IS 'JSONB array of channels in order of preference (in_app, whatsapp, email)';
+COMMENT ON COLUMN "broadcasts"."audience" IS 'JSONB recipient filter; empty means all users';
+COMMENT ON TABLE "broadcast_deliveries" IS 'Per-recipient delivery status of a broadcast';
+COMMENT ON COLUMN "broadcast_deliveries"."channel" IS 'Channel the message was delivered through, set once sent';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_analytics_events_name_occurred_at;
+
+DROP TABLE IF EXISTS "analytics_events" CASCADE;
+DROP TABLE IF EXISTS "user_settings" CASCADE;
@@ -0,0 +1,27 @@
+-- Create user_settings table
+CREATE TABLE IF NOT EXISTS "user_settings" (
+  "user_id" uuid PRIMARY KEY,
+  "analytics_opt_out" boolean NOT NULL DEFAULT false,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_user_settings_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+-- Create analytics_events table
+-- Events are anonymized: there is intentionally no foreign key to users.
+CREATE TABLE IF NOT EXISTS "analytics_events" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "name" varchar NOT NULL,
+  "anonymous_id" varchar NOT NULL,
+  "properties" jsonb NOT NULL DEFAULT '{}'::jsonb,
+  "occurred_at" timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_events_name_occurred_at ON "analytics_events" ("name", "occurred_at");
+
+COMMENT ON TABLE "user_settings" IS 'Per-user preferences';
+COMMENT ON COLUMN "user_settings"."analytics_opt_out" IS 'When true, no analytics events are recorded for the user';
+COMMENT ON TABLE "analytics_events" IS 'Anonymized product analytics events';
+COMMENT ON COLUMN "analytics_events"."anonymous_id" IS 'Keyed hash of the user ID; cannot be reversed without ANALYTICS_SALT';
+COMMENT ON COLUMN "analytics_events"."occurred_at" IS 'Event time truncated to the hour';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_api_usage_daily_day;
+
+DROP TABLE IF EXISTS "api_usage_daily" CASCADE;
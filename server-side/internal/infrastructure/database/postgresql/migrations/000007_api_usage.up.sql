@@ -0,0 +1,18 @@
+-- Create api_usage_daily table
+-- Counters are pre-aggregated per user, day, and endpoint; route is the route
+-- template (e.g. /api/v1/money-flows/:id), never the raw path.
+CREATE TABLE IF NOT EXISTS "api_usage_daily" (
+  "user_id" uuid NOT NULL,
+  "day" date NOT NULL,
+  "method" varchar(10) NOT NULL,
+  "route" varchar NOT NULL,
+  "request_count" bigint NOT NULL DEFAULT 0,
+  "error_count" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("user_id", "day", "method", "route"),
+  CONSTRAINT fk_api_usage_daily_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_usage_daily_day ON "api_usage_daily" ("day");
+
+COMMENT ON TABLE "api_usage_daily" IS 'Per-user API request counters by day and endpoint';
+COMMENT ON COLUMN "api_usage_daily"."error_count" IS 'Requests answered with a 4xx or 5xx status';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_demo_expires_at;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "demo_expires_at";
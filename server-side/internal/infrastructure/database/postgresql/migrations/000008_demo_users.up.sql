@@ -0,0 +1,6 @@
+-- Mark ephemeral demo users; they are hard deleted together with their data once expired
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "demo_expires_at" timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_users_demo_expires_at ON "users" ("demo_expires_at") WHERE demo_expires_at IS NOT NULL;
+
+COMMENT ON COLUMN "users"."demo_expires_at" IS 'Set for demo sandbox users only; the cleanup job deletes the user after this time';
@@ -0,0 +1,2 @@
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "single_currency_mode";
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "default_currency";
@@ -0,0 +1,6 @@
+-- Add currency preferences to user_settings
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "default_currency" varchar(3) NOT NULL DEFAULT 'IDR';
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "single_currency_mode" boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN "user_settings"."default_currency" IS 'ISO 4217 code used when a money flow omits its currency';
+COMMENT ON COLUMN "user_settings"."single_currency_mode" IS 'When true, money flows in any currency other than default_currency are rejected';
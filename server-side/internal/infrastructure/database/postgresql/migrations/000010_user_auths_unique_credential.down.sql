@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_user_auths_provider_credential_unique;
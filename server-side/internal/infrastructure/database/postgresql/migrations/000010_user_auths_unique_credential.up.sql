@@ -0,0 +1,3 @@
+-- Enforce one account per credential and provider, so concurrent registrations
+-- with the same email cannot both succeed
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_auths_provider_credential_unique ON "user_auths" ("auth_provider_id", "credential_id") WHERE deleted_at IS NULL;
@@ -0,0 +1,25 @@
+-- Create jobs table
+-- Background jobs drained by the worker runner. Jobs that exhaust their attempts are kept
+-- with status 'dead' (the dead-letter queue) until requeued or deleted by an operator.
+CREATE TABLE IF NOT EXISTS "jobs" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "type" varchar NOT NULL,
+  "payload" jsonb NOT NULL DEFAULT '{}'::jsonb,
+  "status" varchar NOT NULL DEFAULT 'pending',
+  "attempts" integer NOT NULL DEFAULT 0,
+  "max_attempts" integer NOT NULL DEFAULT 5,
+  "run_at" timestamptz NOT NULL DEFAULT NOW(),
+  "last_error" text,
+  "locked_at" timestamptz,
+  "finished_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+-- Due jobs are polled by type in run_at order
+CREATE INDEX IF NOT EXISTS idx_jobs_pending_type_run_at ON "jobs" ("type", "run_at") WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_running_locked_at ON "jobs" ("locked_at") WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_succeeded_finished_at ON "jobs" ("finished_at") WHERE status = 'succeeded';
+
+COMMENT ON COLUMN "jobs"."status" IS 'pending, running, succeeded, or dead (dead-lettered after max_attempts)';
+COMMENT ON COLUMN "jobs"."run_at" IS 'Earliest time the job may run; pushed back by the retry backoff';
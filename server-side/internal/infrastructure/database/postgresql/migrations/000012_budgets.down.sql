@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "budget_overrides";
+DROP TABLE IF EXISTS "budgets";
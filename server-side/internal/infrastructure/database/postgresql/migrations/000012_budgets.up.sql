@@ -0,0 +1,41 @@
+-- Create budgets table
+-- A monthly spending cap per category. Hard budgets block money flows that would exceed
+-- the cap unless the user overrides; other budgets are only reported.
+CREATE TABLE IF NOT EXISTS "budgets" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "category" varchar(100) NOT NULL,
+  "amount" decimal NOT NULL,
+  "currency" varchar(3) NOT NULL DEFAULT 'IDR',
+  "hard" boolean NOT NULL DEFAULT false,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_budgets_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_user_category_unique ON "budgets" ("user_id", "category");
+
+COMMENT ON COLUMN "budgets"."amount" IS 'Cap on the monthly total of money flows in the category and currency';
+COMMENT ON COLUMN "budgets"."hard" IS 'When true, money flows exceeding the cap require an explicit override';
+
+-- Create budget_overrides table
+-- Audit trail of money flows recorded over a hard budget cap
+CREATE TABLE IF NOT EXISTS "budget_overrides" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "budget_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "money_flow_id" uuid NOT NULL,
+  "amount" decimal NOT NULL,
+  "spent" decimal NOT NULL,
+  "cap" decimal NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_budget_overrides_budget FOREIGN KEY ("budget_id") REFERENCES "budgets" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_budget_overrides_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_budget_overrides_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_budget_overrides_user_id_created_at ON "budget_overrides" ("user_id", "created_at" DESC);
+
+COMMENT ON COLUMN "budget_overrides"."spent" IS 'Total spent in the budget period before the overriding money flow';
+COMMENT ON COLUMN "budget_overrides"."cap" IS 'Budget amount at the time of the override';
@@ -0,0 +1,24 @@
+-- Create conversations table
+-- Multi-turn chat flows (e.g. confirming an expense parsed from a WhatsApp message).
+-- A user has at most one active conversation; starting a new one cancels the previous.
+CREATE TABLE IF NOT EXISTS "conversations" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "flow" varchar(50) NOT NULL,
+  "step" varchar(50) NOT NULL,
+  "status" varchar(20) NOT NULL DEFAULT 'active',
+  "data" jsonb NOT NULL DEFAULT '{}'::jsonb,
+  "source_message_id" varchar,
+  "expires_at" timestamptz NOT NULL,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_conversations_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_conversations_user_active_unique ON "conversations" ("user_id") WHERE status = 'active';
+-- WhatsApp may deliver a message more than once; it starts at most one conversation
+CREATE UNIQUE INDEX IF NOT EXISTS idx_conversations_source_message_id_unique ON "conversations" ("source_message_id") WHERE source_message_id IS NOT NULL;
+
+COMMENT ON COLUMN "conversations"."status" IS 'active, completed, cancelled, or expired';
+COMMENT ON COLUMN "conversations"."data" IS 'Flow-specific state, e.g. the parsed expense awaiting confirmation';
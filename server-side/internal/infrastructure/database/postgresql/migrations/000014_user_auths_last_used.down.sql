@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_user_created;
+ALTER TABLE "user_auths" DROP COLUMN IF EXISTS "last_used_at";
@@ -0,0 +1,6 @@
+-- Track when each credential was last used to sign in
+ALTER TABLE "user_auths" ADD COLUMN IF NOT EXISTS "last_used_at" timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_created ON "refresh_tokens" ("user_id", "created_at" DESC);
+
+COMMENT ON COLUMN "user_auths"."last_used_at" IS 'Time of the last successful sign-in with this credential';
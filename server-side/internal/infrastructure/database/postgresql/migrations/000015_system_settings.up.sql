@@ -0,0 +1,13 @@
+-- Create system_settings table
+-- Operator settings of the whole service, such as the read-only switch. Every
+-- instance re-reads them periodically, so a change applies to all of them.
+CREATE TABLE IF NOT EXISTS "system_settings" (
+  "key" varchar(100) PRIMARY KEY,
+  "value" text NOT NULL,
+  "updated_by" uuid,
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_system_settings_updated_by FOREIGN KEY ("updated_by") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+COMMENT ON COLUMN "system_settings"."value" IS 'JSON value of the setting';
+COMMENT ON COLUMN "system_settings"."updated_by" IS 'Admin who last changed the setting';
@@ -0,0 +1,18 @@
+-- Create exchange_rates table
+-- Daily reference rates fetched by the API from a rate provider. Each row is the
+-- price of one unit of the provider's base currency in another currency.
+CREATE TABLE IF NOT EXISTS "exchange_rates" (
+  "base_currency" varchar(3) NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "rate_date" date NOT NULL,
+  "rate" numeric(24,10) NOT NULL,
+  "source" varchar(50) NOT NULL,
+  "fetched_at" timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY ("base_currency", "currency", "rate_date")
+);
+
+-- Finding the latest day of a base currency
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_base_date ON "exchange_rates" ("base_currency", "rate_date" DESC);
+
+COMMENT ON COLUMN "exchange_rates"."rate" IS 'Units of currency per one unit of base_currency';
+COMMENT ON COLUMN "exchange_rates"."source" IS 'Rate provider, e.g. ecb';
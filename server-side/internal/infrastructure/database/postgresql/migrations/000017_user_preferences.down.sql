@@ -0,0 +1,5 @@
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "notify_email";
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "notify_whatsapp";
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "week_start";
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "timezone";
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "locale";
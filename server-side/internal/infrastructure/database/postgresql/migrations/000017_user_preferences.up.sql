@@ -0,0 +1,12 @@
+-- Add locale, calendar, and notification preferences to user_settings
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "locale" varchar(35) NOT NULL DEFAULT 'id-ID';
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "timezone" varchar(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "week_start" smallint NOT NULL DEFAULT 1;
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "notify_whatsapp" boolean NOT NULL DEFAULT true;
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "notify_email" boolean NOT NULL DEFAULT true;
+
+COMMENT ON COLUMN "user_settings"."locale" IS 'BCP 47 language tag used to format dates and amounts';
+COMMENT ON COLUMN "user_settings"."timezone" IS 'IANA time zone that report periods and budget months follow';
+COMMENT ON COLUMN "user_settings"."week_start" IS 'First day of the week, 0 (Sunday) to 6 (Saturday)';
+COMMENT ON COLUMN "user_settings"."notify_whatsapp" IS 'When false, broadcasts are not sent to the user by WhatsApp';
+COMMENT ON COLUMN "user_settings"."notify_email" IS 'When false, broadcasts are not sent to the user by email';
@@ -0,0 +1,7 @@
+ALTER TABLE "budget_overrides" ALTER COLUMN "cap" TYPE decimal;
+ALTER TABLE "budget_overrides" ALTER COLUMN "spent" TYPE decimal;
+ALTER TABLE "budget_overrides" ALTER COLUMN "amount" TYPE decimal;
+ALTER TABLE "budget_overrides" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "budgets" ALTER COLUMN "amount" TYPE decimal;
+ALTER TABLE "money_flows" ALTER COLUMN "amount" TYPE decimal;
@@ -0,0 +1,29 @@
+-- Store money amounts as numeric with a fixed scale
+-- The API keeps amounts as integer minor units of their currency; four decimal places
+-- hold the minor units of every ISO 4217 currency. Existing amounts are first rounded to
+-- the decimal places of their currency (0 for e.g. JPY, 3 for e.g. KWD, 2 for most).
+UPDATE "money_flows" SET "amount" = ROUND("amount", CASE
+  WHEN "currency" IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 0
+  WHEN "currency" IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 3
+  WHEN "currency" IN ('CLF', 'UYW') THEN 4
+  ELSE 2
+END);
+ALTER TABLE "money_flows" ALTER COLUMN "amount" TYPE numeric(19,4);
+
+UPDATE "budgets" SET "amount" = ROUND("amount", CASE
+  WHEN "currency" IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG', 'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 0
+  WHEN "currency" IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 3
+  WHEN "currency" IN ('CLF', 'UYW') THEN 4
+  ELSE 2
+END);
+ALTER TABLE "budgets" ALTER COLUMN "amount" TYPE numeric(19,4);
+
+-- Overrides keep the currency of their amounts, since the budget's currency can change
+ALTER TABLE "budget_overrides" ADD COLUMN IF NOT EXISTS "currency" varchar(3) NOT NULL DEFAULT 'IDR';
+UPDATE "budget_overrides" o SET "currency" = b."currency" FROM "budgets" b WHERE b."id" = o."budget_id";
+ALTER TABLE "budget_overrides" ALTER COLUMN "amount" TYPE numeric(19,4);
+ALTER TABLE "budget_overrides" ALTER COLUMN "spent" TYPE numeric(19,4);
+ALTER TABLE "budget_overrides" ALTER COLUMN "cap" TYPE numeric(19,4);
+
+COMMENT ON COLUMN "money_flows"."amount" IS 'Amount in major units of currency, with at most the decimal places of the currency';
+COMMENT ON COLUMN "budget_overrides"."currency" IS 'Currency of amount, spent, and cap: the budget''s currency at the time';
@@ -0,0 +1,4 @@
+-- Add the data region preference of users
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "data_region" varchar(32);
+
+COMMENT ON COLUMN "users"."data_region" IS 'Storage region of the user''s files; NULL for the default region';
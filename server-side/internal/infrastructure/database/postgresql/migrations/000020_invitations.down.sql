@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_invitations_user_id;
+DROP INDEX IF EXISTS idx_invitations_token_hash_unique;
+DROP INDEX IF EXISTS idx_invitations_email_unique;
+
+DROP TABLE IF EXISTS "invitations" CASCADE;
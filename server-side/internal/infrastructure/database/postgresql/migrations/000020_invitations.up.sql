@@ -0,0 +1,24 @@
+-- Create invitations table
+CREATE TABLE IF NOT EXISTS "invitations" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "invited_by" uuid,
+  "email" varchar NOT NULL,
+  "channel" varchar(20) NOT NULL,
+  "token_hash" varchar,
+  "sent_at" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "accepted_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_invitations_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_invited_by FOREIGN KEY ("invited_by") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_email_unique ON "invitations" (lower("email"));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_token_hash_unique ON "invitations" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_invitations_user_id ON "invitations" ("user_id");
+
+COMMENT ON TABLE "invitations" IS 'Invitations of admin-created users to set a password and sign in';
+COMMENT ON COLUMN "invitations"."channel" IS 'Channel the invitation is sent on (whatsapp, email)';
+COMMENT ON COLUMN "invitations"."token_hash" IS 'SHA-256 hash of the last sent token; NULL until sent';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_invitations_token_expires_at;
+DROP INDEX IF EXISTS idx_refresh_tokens_revoked_at;
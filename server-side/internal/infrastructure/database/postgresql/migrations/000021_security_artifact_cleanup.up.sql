@@ -0,0 +1,4 @@
+-- Let the token cleanup find revoked refresh tokens and spent invitation tokens
+-- without scanning the tables; expired refresh tokens use idx_refresh_tokens_expires_at
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_revoked_at ON "refresh_tokens" ("revoked_at") WHERE revoked_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_invitations_token_expires_at ON "invitations" ("expires_at") WHERE token_hash IS NOT NULL;
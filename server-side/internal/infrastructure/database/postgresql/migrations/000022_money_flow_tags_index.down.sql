@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_money_flows_tags;
@@ -0,0 +1,2 @@
+-- Index tags for containment queries (tags @> '["food"]'), used to filter money flows by tag
+CREATE INDEX IF NOT EXISTS idx_money_flows_tags ON "money_flows" USING GIN ("tags" jsonb_path_ops);
@@ -0,0 +1,3 @@
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "notification_channels";
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "telegram_chat_id";
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "notify_telegram";
@@ -0,0 +1,8 @@
+-- Add Telegram and the order notification channels are tried in to user_settings
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "notify_telegram" boolean NOT NULL DEFAULT true;
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "telegram_chat_id" varchar(32);
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "notification_channels" jsonb NOT NULL DEFAULT '[]'::jsonb;
+
+COMMENT ON COLUMN "user_settings"."notify_telegram" IS 'When false, notifications are not sent to the user by Telegram';
+COMMENT ON COLUMN "user_settings"."telegram_chat_id" IS 'Chat ID of the user with the Telegram bot; NULL until linked';
+COMMENT ON COLUMN "user_settings"."notification_channels" IS 'JSONB array of channels in the order notifications are tried; empty uses the default order';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_feedback_chat_request_id_unique;
+DROP INDEX IF EXISTS idx_feedback_user_id;
+DROP INDEX IF EXISTS idx_feedback_created_at;
+
+DROP TABLE IF EXISTS "feedback" CASCADE;
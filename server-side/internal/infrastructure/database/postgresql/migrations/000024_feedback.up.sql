@@ -0,0 +1,27 @@
+-- Create feedback table
+CREATE TABLE IF NOT EXISTS "feedback" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "kind" varchar(20) NOT NULL,
+  "source" varchar(20) NOT NULL,
+  "message" text NOT NULL,
+  "request_id" varchar NOT NULL,
+  "last_request_id" varchar,
+  "app_version" varchar(50),
+  "user_agent" varchar,
+  "forwarded_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_feedback_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_created_at ON "feedback" ("created_at" DESC);
+CREATE INDEX IF NOT EXISTS idx_feedback_user_id ON "feedback" ("user_id");
+-- WhatsApp may deliver a message more than once; it is recorded once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feedback_chat_request_id_unique ON "feedback" ("request_id") WHERE source = 'chat';
+
+COMMENT ON TABLE "feedback" IS 'Feedback and bug reports sent by users';
+COMMENT ON COLUMN "feedback"."kind" IS 'feedback or bug';
+COMMENT ON COLUMN "feedback"."source" IS 'api (POST /api/v1/feedback) or chat (the lapor command)';
+COMMENT ON COLUMN "feedback"."request_id" IS 'Request ID of the API call, or ID of the chat message, that carried the feedback';
+COMMENT ON COLUMN "feedback"."last_request_id" IS 'Request ID of the call the user reports on, as sent by the client';
+COMMENT ON COLUMN "feedback"."forwarded_at" IS 'When the feedback was posted to Slack; NULL if not forwarded';
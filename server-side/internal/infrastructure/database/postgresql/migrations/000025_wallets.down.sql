@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_money_flows_transfer_id;
+DROP INDEX IF EXISTS idx_money_flows_wallet_id;
+
+-- Transfers are not expenses; drop them with the wallets they moved money between
+DELETE FROM "money_flows" WHERE "kind" <> 'expense';
+
+ALTER TABLE "money_flows" DROP CONSTRAINT IF EXISTS fk_money_flows_wallet;
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "transfer_id";
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "kind";
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "wallet_id";
+
+DROP INDEX IF EXISTS idx_wallets_user_name_unique;
+
+DROP TABLE IF EXISTS "wallets" CASCADE;
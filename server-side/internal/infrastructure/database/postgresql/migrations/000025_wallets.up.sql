@@ -0,0 +1,33 @@
+-- Create wallets table
+CREATE TABLE IF NOT EXISTS "wallets" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "type" varchar(20) NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "opening_balance" numeric(19,4) NOT NULL DEFAULT 0,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_wallets_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_user_name_unique ON "wallets" ("user_id", lower("name"));
+
+COMMENT ON TABLE "wallets" IS 'Where users keep money: cash, bank accounts, e-wallets';
+COMMENT ON COLUMN "wallets"."type" IS 'cash, bank, ewallet, or other';
+COMMENT ON COLUMN "wallets"."opening_balance" IS 'Balance before the first money flow in the wallet, in major units of currency';
+
+-- Track money flows per wallet; transfers are a transfer_out and a transfer_in money flow
+-- sharing a transfer_id
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "wallet_id" uuid;
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "kind" varchar(20) NOT NULL DEFAULT 'expense';
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "transfer_id" uuid;
+ALTER TABLE "money_flows" ADD CONSTRAINT fk_money_flows_wallet FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_money_flows_wallet_id ON "money_flows" ("wallet_id") WHERE wallet_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_money_flows_transfer_id ON "money_flows" ("transfer_id") WHERE transfer_id IS NOT NULL;
+
+COMMENT ON COLUMN "money_flows"."wallet_id" IS 'Wallet the money flow was paid from or into; NULL when not tracked in a wallet';
+COMMENT ON COLUMN "money_flows"."kind" IS 'expense, transfer_out, or transfer_in';
+COMMENT ON COLUMN "money_flows"."transfer_id" IS 'Shared by the two money flows of a transfer between wallets';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_money_flows_group_id;
+ALTER TABLE "money_flows" DROP CONSTRAINT IF EXISTS fk_money_flows_group;
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "group_id";
+
+DROP TABLE IF EXISTS "group_invitations";
+DROP TABLE IF EXISTS "group_members";
+DROP TABLE IF EXISTS "groups";
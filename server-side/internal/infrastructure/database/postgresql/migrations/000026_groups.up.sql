@@ -0,0 +1,53 @@
+-- Create groups table
+CREATE TABLE IF NOT EXISTS "groups" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "name" varchar(100) NOT NULL,
+  "created_by" uuid NOT NULL,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE "groups" IS 'Shared ledgers, such as households, whose members record money flows together';
+
+-- Create group_members table
+CREATE TABLE IF NOT EXISTS "group_members" (
+  "group_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "role" varchar(20) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY ("group_id", "user_id"),
+  CONSTRAINT fk_group_members_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_group_members_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_members_user_id ON "group_members" ("user_id");
+
+COMMENT ON COLUMN "group_members"."role" IS 'owner, admin, or member';
+
+-- Create group_invitations table
+CREATE TABLE IF NOT EXISTS "group_invitations" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "group_id" uuid NOT NULL,
+  "invited_by" uuid NOT NULL,
+  "role" varchar(20) NOT NULL,
+  "token_hash" varchar(64) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "accepted_by" uuid,
+  "accepted_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_group_invitations_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_group_invitations_token_hash_unique ON "group_invitations" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_group_invitations_group_id ON "group_invitations" ("group_id");
+
+COMMENT ON COLUMN "group_invitations"."token_hash" IS 'SHA-256 of the invitation token; the token itself is only shown once';
+
+-- Money flows can be recorded in a group's shared ledger
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "group_id" uuid;
+ALTER TABLE "money_flows" ADD CONSTRAINT fk_money_flows_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_money_flows_group_id ON "money_flows" ("group_id", "created_at" DESC) WHERE group_id IS NOT NULL;
+
+COMMENT ON COLUMN "money_flows"."group_id" IS 'Group whose shared ledger the money flow is in; NULL for personal money flows';
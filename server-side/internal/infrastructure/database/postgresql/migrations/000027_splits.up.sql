@@ -0,0 +1,35 @@
+-- Create money_flow_splits table: each member's share of a money flow in a group
+CREATE TABLE IF NOT EXISTS "money_flow_splits" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "money_flow_id" uuid NOT NULL,
+  "group_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "method" varchar(20) NOT NULL,
+  "amount" numeric(19,4) NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_money_flow_splits_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_money_flow_splits_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_money_flow_splits_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_money_flow_splits_money_flow_user_unique ON "money_flow_splits" ("money_flow_id", "user_id");
+CREATE INDEX IF NOT EXISTS idx_money_flow_splits_group_id ON "money_flow_splits" ("group_id");
+
+COMMENT ON TABLE "money_flow_splits" IS 'Shares of group money flows; members owe the author of the money flow their share';
+COMMENT ON COLUMN "money_flow_splits"."method" IS 'equal, percentage, or exact';
+
+-- Create group_settlements table: payments between members paying off their balances
+CREATE TABLE IF NOT EXISTS "group_settlements" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "group_id" uuid NOT NULL,
+  "from_user_id" uuid NOT NULL,
+  "to_user_id" uuid NOT NULL,
+  "amount" numeric(19,4) NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "created_by" uuid NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_group_settlements_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_settlements_group_id ON "group_settlements" ("group_id", "created_at" DESC);
@@ -0,0 +1,20 @@
+-- Create audit_logs table: changes users made to their entities, for transparency
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "actor_id" uuid NOT NULL,
+  "action" varchar(20) NOT NULL,
+  "entity_type" varchar(50) NOT NULL,
+  "entity_id" uuid NOT NULL,
+  "before" jsonb,
+  "after" jsonb,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_audit_logs_actor FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_created_at ON "audit_logs" ("actor_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON "audit_logs" ("entity_type", "entity_id");
+
+COMMENT ON TABLE "audit_logs" IS 'Create, update, and delete actions of users, listed by GET /api/v1/users/me/audit-logs';
+COMMENT ON COLUMN "audit_logs"."action" IS 'create, update, or delete';
+COMMENT ON COLUMN "audit_logs"."before" IS 'JSON snapshot of the entity before the change; NULL on create';
+COMMENT ON COLUMN "audit_logs"."after" IS 'JSON snapshot of the entity after the change; NULL on delete';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_role;
+ALTER TABLE "users" DROP COLUMN IF EXISTS "disabled_at";
+
+UPDATE "users" SET "role" = 'user' WHERE "role" = 'support';
+COMMENT ON COLUMN "users"."role" IS 'Authorization role (user, admin)';
@@ -0,0 +1,7 @@
+-- Let admins disable accounts, and add the support role
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "disabled_at" timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON "users" ("role") WHERE "role" <> 'user';
+
+COMMENT ON COLUMN "users"."role" IS 'Authorization role (user, support, admin); the permissions of each role are defined in code';
+COMMENT ON COLUMN "users"."disabled_at" IS 'When an admin disabled the account; NULL while it is enabled';
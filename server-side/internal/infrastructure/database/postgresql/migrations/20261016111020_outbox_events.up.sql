@@ -0,0 +1,18 @@
+-- Create outbox_events table
+-- Domain events written by services in the transaction of the change (the transactional
+-- outbox). The event dispatcher fans each event out to its subscribers as background jobs
+-- and marks it dispatched; dispatched events are deleted after the retention period.
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "type" varchar NOT NULL,
+  "user_id" uuid,
+  "payload" jsonb NOT NULL DEFAULT '{}'::jsonb,
+  "occurred_at" timestamptz NOT NULL DEFAULT NOW(),
+  "dispatched_at" timestamptz
+);
+
+-- Undispatched events are polled in the order they occurred
+CREATE INDEX IF NOT EXISTS idx_outbox_events_undispatched ON "outbox_events" ("occurred_at") WHERE dispatched_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched_at ON "outbox_events" ("dispatched_at") WHERE dispatched_at IS NOT NULL;
+
+COMMENT ON COLUMN "outbox_events"."user_id" IS 'User the event is about; not a foreign key so events outlive deleted users';
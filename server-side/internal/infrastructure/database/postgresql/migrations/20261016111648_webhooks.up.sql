@@ -0,0 +1,40 @@
+-- Create webhooks table
+-- URLs users register to receive their events, signed with a per-webhook secret
+CREATE TABLE IF NOT EXISTS "webhooks" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "url" varchar(2048) NOT NULL,
+  "secret" varchar(100) NOT NULL,
+  "events" jsonb NOT NULL DEFAULT '[]'::jsonb,
+  "active" boolean NOT NULL DEFAULT true,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_webhooks_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON "webhooks" ("user_id");
+
+COMMENT ON COLUMN "webhooks"."secret" IS 'HMAC-SHA256 key of the X-Catetin-Signature header; stored in plaintext because signing needs it';
+COMMENT ON COLUMN "webhooks"."events" IS 'JSONB array of the event types sent to the URL';
+
+-- Create webhook_deliveries table
+-- One row per attempt to send an event to a webhook, kept for the delivery log
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "webhook_id" uuid NOT NULL,
+  "event_id" uuid NOT NULL,
+  "event_type" varchar NOT NULL,
+  "attempt" integer NOT NULL,
+  "status_code" integer,
+  "error" text,
+  "duration_ms" integer NOT NULL DEFAULT 0,
+  "succeeded" boolean NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY ("webhook_id") REFERENCES "webhooks" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_created_at ON "webhook_deliveries" ("webhook_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON "webhook_deliveries" ("created_at");
+
+COMMENT ON COLUMN "webhook_deliveries"."status_code" IS 'HTTP status of the response; NULL when no response was received';
@@ -0,0 +1,23 @@
+-- Create devices table
+-- App installations that receive push notifications through Firebase Cloud Messaging
+CREATE TABLE IF NOT EXISTS "devices" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "token" varchar(4096) NOT NULL,
+  "platform" varchar(16) NOT NULL,
+  "name" varchar(100),
+  "last_seen_at" timestamptz NOT NULL DEFAULT NOW(),
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_devices_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_token ON "devices" ("token");
+CREATE INDEX IF NOT EXISTS idx_devices_user_id ON "devices" ("user_id");
+
+COMMENT ON COLUMN "devices"."token" IS 'FCM registration token; a token registered again moves to the latest user';
+COMMENT ON COLUMN "devices"."platform" IS 'android, ios, or web';
+
+-- Add push notifications to user_settings
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "notify_push" boolean NOT NULL DEFAULT true;
+
+COMMENT ON COLUMN "user_settings"."notify_push" IS 'When false, notifications are not sent to the user by push';
@@ -0,0 +1,2 @@
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "digest_frequency";
+DROP TABLE IF EXISTS "digests";
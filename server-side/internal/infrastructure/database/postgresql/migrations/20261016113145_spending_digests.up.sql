@@ -0,0 +1,17 @@
+-- Create digests table
+-- Records the spending digests sent, so each period is summarized once per user
+CREATE TABLE IF NOT EXISTS "digests" (
+  "user_id" uuid NOT NULL,
+  "frequency" varchar(16) NOT NULL,
+  "period_start" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY ("user_id", "frequency", "period_start"),
+  CONSTRAINT fk_digests_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN "digests"."frequency" IS 'weekly or monthly';
+
+-- Add the digest frequency to user_settings
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "digest_frequency" varchar(16) NOT NULL DEFAULT 'weekly';
+
+COMMENT ON COLUMN "user_settings"."digest_frequency" IS 'How often a spending digest is sent: weekly, monthly, or off';
@@ -0,0 +1,4 @@
+-- Add the token version to users
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "token_version" integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN "users"."token_version" IS 'Carried by access tokens; incremented to revoke the access tokens issued before';
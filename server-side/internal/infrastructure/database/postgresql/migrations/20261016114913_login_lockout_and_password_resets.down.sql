@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_password_resets_expires_at;
+DROP INDEX IF EXISTS idx_password_resets_user_auth_id;
+DROP INDEX IF EXISTS idx_password_resets_token_hash_unique;
+
+DROP TABLE IF EXISTS "password_resets" CASCADE;
+
+ALTER TABLE "user_auths" DROP COLUMN IF EXISTS "locked_until";
+ALTER TABLE "user_auths" DROP COLUMN IF EXISTS "failed_login_attempts";
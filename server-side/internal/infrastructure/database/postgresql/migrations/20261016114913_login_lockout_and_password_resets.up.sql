@@ -0,0 +1,30 @@
+-- Track failed sign-ins per credential
+ALTER TABLE "user_auths" ADD COLUMN IF NOT EXISTS "failed_login_attempts" integer NOT NULL DEFAULT 0;
+ALTER TABLE "user_auths" ADD COLUMN IF NOT EXISTS "locked_until" timestamptz;
+
+COMMENT ON COLUMN "user_auths"."failed_login_attempts" IS 'Failed sign-ins since the last successful one or lockout';
+COMMENT ON COLUMN "user_auths"."locked_until" IS 'Sign-ins are refused until then after too many failures';
+
+-- Create password_resets table
+CREATE TABLE IF NOT EXISTS "password_resets" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "user_auth_id" uuid NOT NULL,
+  "email" varchar NOT NULL,
+  "token_hash" varchar,
+  "sent_at" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_password_resets_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_password_resets_user_auth FOREIGN KEY ("user_auth_id") REFERENCES "user_auths" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_resets_token_hash_unique ON "password_resets" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_password_resets_user_auth_id ON "password_resets" ("user_auth_id", "created_at");
+CREATE INDEX IF NOT EXISTS idx_password_resets_expires_at ON "password_resets" ("expires_at");
+
+COMMENT ON TABLE "password_resets" IS 'Requests to reset a forgotten password by email';
+COMMENT ON COLUMN "password_resets"."token_hash" IS 'SHA-256 hash of the sent token; NULL until sent';
+COMMENT ON COLUMN "password_resets"."used_at" IS 'When the token was used to set a new password';
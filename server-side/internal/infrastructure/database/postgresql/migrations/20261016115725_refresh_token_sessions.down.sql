@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_refresh_tokens_session_id;
+
+ALTER TABLE "refresh_tokens" DROP COLUMN IF EXISTS "signed_in_at";
+ALTER TABLE "refresh_tokens" DROP COLUMN IF EXISTS "ip_address";
+ALTER TABLE "refresh_tokens" DROP COLUMN IF EXISTS "user_agent";
+ALTER TABLE "refresh_tokens" DROP COLUMN IF EXISTS "session_id";
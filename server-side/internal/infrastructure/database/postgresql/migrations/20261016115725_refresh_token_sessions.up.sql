@@ -0,0 +1,16 @@
+-- Group rotated refresh tokens into sessions and record the device they were issued to
+ALTER TABLE "refresh_tokens" ADD COLUMN IF NOT EXISTS "session_id" uuid;
+ALTER TABLE "refresh_tokens" ADD COLUMN IF NOT EXISTS "user_agent" varchar(512);
+ALTER TABLE "refresh_tokens" ADD COLUMN IF NOT EXISTS "ip_address" varchar(45);
+ALTER TABLE "refresh_tokens" ADD COLUMN IF NOT EXISTS "signed_in_at" timestamptz;
+
+-- Tokens issued before are sessions of their own
+UPDATE "refresh_tokens" SET "session_id" = "id" WHERE "session_id" IS NULL;
+ALTER TABLE "refresh_tokens" ALTER COLUMN "session_id" SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON "refresh_tokens" ("session_id");
+
+COMMENT ON COLUMN "refresh_tokens"."session_id" IS 'Shared by the refresh tokens rotated from one sign-in';
+COMMENT ON COLUMN "refresh_tokens"."user_agent" IS 'User agent of the request that issued the token';
+COMMENT ON COLUMN "refresh_tokens"."ip_address" IS 'IP address of the request that issued the token';
+COMMENT ON COLUMN "refresh_tokens"."signed_in_at" IS 'When the user signed in to the session';
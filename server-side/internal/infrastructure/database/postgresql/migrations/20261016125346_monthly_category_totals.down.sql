@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals_update ON "money_flows";
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals ON "money_flows";
+DROP FUNCTION IF EXISTS update_monthly_category_totals();
+DROP FUNCTION IF EXISTS add_monthly_category_total(varchar, uuid, timestamptz, varchar, varchar, numeric, bigint);
+DROP TABLE IF EXISTS "monthly_category_totals";
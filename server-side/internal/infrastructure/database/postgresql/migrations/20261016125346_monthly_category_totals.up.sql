@@ -0,0 +1,95 @@
+-- Keep the expense totals of every user and group per UTC month, category, and currency,
+-- so reports over long periods read a few rows per month instead of every money flow
+CREATE TABLE IF NOT EXISTS "monthly_category_totals" (
+  "owner_kind" varchar(8) NOT NULL,
+  "owner_id" uuid NOT NULL,
+  "month" date NOT NULL,
+  "currency" varchar NOT NULL,
+  "category" varchar NOT NULL DEFAULT '',
+  "total" numeric(19,4) NOT NULL,
+  "count" bigint NOT NULL,
+  PRIMARY KEY ("owner_kind", "owner_id", "month", "currency", "category")
+);
+
+COMMENT ON TABLE "monthly_category_totals" IS 'Read model of the expense totals, maintained by triggers on money_flows';
+COMMENT ON COLUMN "monthly_category_totals"."owner_kind" IS 'user for the money flows a user recorded, group for those of a group''s ledger';
+COMMENT ON COLUMN "monthly_category_totals"."owner_id" IS 'ID of the user or group';
+COMMENT ON COLUMN "monthly_category_totals"."month" IS 'First day of the UTC calendar month the money flows were created in';
+COMMENT ON COLUMN "monthly_category_totals"."category" IS 'Category of the money flows; empty for uncategorized';
+COMMENT ON COLUMN "monthly_category_totals"."total" IS 'Sum of the amounts in major units of currency';
+
+-- Adds an expense to, or with a negative amount and count removes it from, the totals of
+-- its month. Rows left without money flows are deleted.
+CREATE OR REPLACE FUNCTION add_monthly_category_total(
+  p_owner_kind varchar, p_owner_id uuid, p_created_at timestamptz,
+  p_currency varchar, p_category varchar, p_amount numeric, p_count bigint
+) RETURNS void AS $$
+DECLARE
+  v_month date := date_trunc('month', p_created_at AT TIME ZONE 'UTC')::date;
+  v_category varchar := COALESCE(p_category, '');
+BEGIN
+  INSERT INTO "monthly_category_totals" AS t ("owner_kind", "owner_id", "month", "currency", "category", "total", "count")
+  VALUES (p_owner_kind, p_owner_id, v_month, p_currency, v_category, p_amount, p_count)
+  ON CONFLICT ("owner_kind", "owner_id", "month", "currency", "category")
+  DO UPDATE SET "total" = t."total" + EXCLUDED."total", "count" = t."count" + EXCLUDED."count";
+
+  DELETE FROM "monthly_category_totals"
+  WHERE "owner_kind" = p_owner_kind AND "owner_id" = p_owner_id AND "month" = v_month
+    AND "currency" = p_currency AND "category" = v_category AND "count" = 0;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Moves the old version of a changed money flow out of the totals and the new one in.
+-- Soft-deleted money flows and transfers are not counted, like in the reports.
+CREATE OR REPLACE FUNCTION update_monthly_category_totals() RETURNS trigger AS $$
+BEGIN
+  -- OLD is unassigned on insert and NEW on delete, so check TG_OP before reading them
+  IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    IF OLD."kind" = 'expense' AND OLD."deleted_at" IS NULL THEN
+      PERFORM add_monthly_category_total('user', OLD."user_id", OLD."created_at", OLD."currency", OLD."category", -OLD."amount", -1);
+      IF OLD."group_id" IS NOT NULL THEN
+        PERFORM add_monthly_category_total('group', OLD."group_id", OLD."created_at", OLD."currency", OLD."category", -OLD."amount", -1);
+      END IF;
+    END IF;
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') THEN
+    IF NEW."kind" = 'expense' AND NEW."deleted_at" IS NULL THEN
+      PERFORM add_monthly_category_total('user', NEW."user_id", NEW."created_at", NEW."currency", NEW."category", NEW."amount", 1);
+      IF NEW."group_id" IS NOT NULL THEN
+        PERFORM add_monthly_category_total('group', NEW."group_id", NEW."created_at", NEW."currency", NEW."category", NEW."amount", 1);
+      END IF;
+    END IF;
+  END IF;
+
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals ON "money_flows";
+CREATE TRIGGER money_flows_monthly_category_totals
+  AFTER INSERT OR DELETE ON "money_flows"
+  FOR EACH ROW EXECUTE FUNCTION update_monthly_category_totals();
+
+-- Updates that change none of the counted columns, like editing a description, skip it
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals_update ON "money_flows";
+CREATE TRIGGER money_flows_monthly_category_totals_update
+  AFTER UPDATE ON "money_flows"
+  FOR EACH ROW
+  WHEN ((OLD."kind", OLD."user_id", OLD."group_id", OLD."created_at", OLD."currency", OLD."category", OLD."amount", OLD."deleted_at")
+    IS DISTINCT FROM (NEW."kind", NEW."user_id", NEW."group_id", NEW."created_at", NEW."currency", NEW."category", NEW."amount", NEW."deleted_at"))
+  EXECUTE FUNCTION update_monthly_category_totals();
+
+-- Backfill the existing money flows. Creating the triggers locks money_flows against
+-- writes until the migration commits, so none is missed or counted twice.
+DELETE FROM "monthly_category_totals";
+INSERT INTO "monthly_category_totals" ("owner_kind", "owner_id", "month", "currency", "category", "total", "count")
+SELECT 'user', "user_id", date_trunc('month', "created_at" AT TIME ZONE 'UTC')::date, "currency", COALESCE("category", ''), SUM("amount"), COUNT(*)
+FROM "money_flows"
+WHERE "kind" = 'expense' AND "deleted_at" IS NULL
+GROUP BY 2, 3, 4, 5;
+INSERT INTO "monthly_category_totals" ("owner_kind", "owner_id", "month", "currency", "category", "total", "count")
+SELECT 'group', "group_id", date_trunc('month', "created_at" AT TIME ZONE 'UTC')::date, "currency", COALESCE("category", ''), SUM("amount"), COUNT(*)
+FROM "money_flows"
+WHERE "kind" = 'expense' AND "deleted_at" IS NULL AND "group_id" IS NOT NULL
+GROUP BY 2, 3, 4, 5;
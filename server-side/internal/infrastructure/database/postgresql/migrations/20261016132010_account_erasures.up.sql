@@ -0,0 +1,19 @@
+-- Create account_erasures table
+CREATE TABLE IF NOT EXISTS "account_erasures" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "status" varchar(16) NOT NULL,
+  "erase_at" timestamptz NOT NULL,
+  "cancelled_at" timestamptz,
+  "completed_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_account_erasures_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_erasures_user_id ON "account_erasures" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_erasures_pending_unique ON "account_erasures" ("user_id") WHERE "status" = 'pending';
+
+COMMENT ON TABLE "account_erasures" IS 'Requests of users to anonymize their personal data after a grace period';
+COMMENT ON COLUMN "account_erasures"."status" IS 'pending until erase_at, then completed; cancelled by the user before';
+COMMENT ON COLUMN "account_erasures"."erase_at" IS 'When the grace period ends and the data is anonymized';
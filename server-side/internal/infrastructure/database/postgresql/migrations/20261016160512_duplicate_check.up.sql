@@ -0,0 +1,4 @@
+-- Add the duplicate check to user_settings
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "duplicate_check" varchar(16) NOT NULL DEFAULT 'warn';
+
+COMMENT ON COLUMN "user_settings"."duplicate_check" IS 'What happens when a new money flow looks like one recorded the same day: off, warn, or block';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_money_flows_user_merchant;
+ALTER TABLE "money_flows" DROP CONSTRAINT IF EXISTS fk_money_flows_merchant;
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "merchant_id";
+
+DROP TABLE IF EXISTS "merchant_rules";
+DROP TABLE IF EXISTS "merchants";
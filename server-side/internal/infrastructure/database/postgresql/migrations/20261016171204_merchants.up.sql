@@ -0,0 +1,34 @@
+-- Create merchants table
+CREATE TABLE IF NOT EXISTS "merchants" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "name" varchar(100) NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_merchants_name_unique ON "merchants" (lower("name"));
+
+COMMENT ON TABLE "merchants" IS 'Payees money flows are paid to, shared by every user, under their normalized names';
+
+-- Create merchant_rules table
+CREATE TABLE IF NOT EXISTS "merchant_rules" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "pattern" varchar(100) NOT NULL,
+  "merchant_id" uuid NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_merchant_rules_merchant FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_merchant_rules_pattern_unique ON "merchant_rules" ("pattern");
+CREATE INDEX IF NOT EXISTS idx_merchant_rules_merchant_id ON "merchant_rules" ("merchant_id");
+
+COMMENT ON COLUMN "merchant_rules"."pattern" IS 'Lowercased words a payee must contain to be normalized to the merchant';
+
+-- Money flows can be linked to the merchant they were paid to
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "merchant_id" uuid;
+ALTER TABLE "money_flows" ADD CONSTRAINT fk_money_flows_merchant FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_money_flows_user_merchant ON "money_flows" ("user_id", "merchant_id", "created_at") WHERE merchant_id IS NOT NULL;
+
+COMMENT ON COLUMN "money_flows"."merchant_id" IS 'Merchant the money flow was paid to; NULL when unknown';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_money_flows_location;
+ALTER TABLE "money_flows" DROP CONSTRAINT IF EXISTS chk_money_flows_location;
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "place_name";
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "money_flows" DROP COLUMN IF EXISTS "latitude";
@@ -0,0 +1,15 @@
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "latitude" DOUBLE PRECISION;
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "longitude" DOUBLE PRECISION;
+ALTER TABLE "money_flows" ADD COLUMN IF NOT EXISTS "place_name" VARCHAR;
+
+ALTER TABLE "money_flows" ADD CONSTRAINT chk_money_flows_location CHECK (
+  ("latitude" IS NULL) = ("longitude" IS NULL)
+  AND "latitude" BETWEEN -90 AND 90
+  AND "longitude" BETWEEN -180 AND 180
+);
+
+-- Nearby spending is looked up by bounding box; the built-in point type needs no extension
+CREATE INDEX IF NOT EXISTS idx_money_flows_location ON "money_flows" USING GIST (point("longitude", "latitude")) WHERE latitude IS NOT NULL;
+
+COMMENT ON COLUMN "money_flows"."latitude" IS 'Where the money flow was spent, in WGS 84 degrees; NULL when not located';
+COMMENT ON COLUMN "money_flows"."place_name" IS 'Name of the place the user gave, e.g. "Kopi Kenangan Sudirman"';
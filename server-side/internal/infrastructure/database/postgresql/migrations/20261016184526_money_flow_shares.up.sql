@@ -0,0 +1,17 @@
+-- Create money_flow_shares table
+CREATE TABLE IF NOT EXISTS "money_flow_shares" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "money_flow_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "include_note" boolean NOT NULL DEFAULT false,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_money_flow_shares_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_money_flow_shares_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_money_flow_shares_money_flow_id ON "money_flow_shares" ("money_flow_id");
+CREATE INDEX IF NOT EXISTS idx_money_flow_shares_expires_at ON "money_flow_shares" ("expires_at");
+
+COMMENT ON TABLE "money_flow_shares" IS 'Public links to read-only views of money flows; deleting a row revokes its link';
+COMMENT ON COLUMN "money_flow_shares"."expires_at" IS 'Signed into the link; the link stops working then';
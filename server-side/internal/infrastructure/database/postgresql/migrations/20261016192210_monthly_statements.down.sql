@@ -0,0 +1,3 @@
+ALTER TABLE "user_settings" DROP COLUMN IF EXISTS "monthly_statement";
+
+DROP TABLE IF EXISTS "statements";
@@ -0,0 +1,14 @@
+-- Create statements table
+-- Records the monthly statements emailed, so each month is sent once per user
+CREATE TABLE IF NOT EXISTS "statements" (
+  "user_id" uuid NOT NULL,
+  "period_start" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  PRIMARY KEY ("user_id", "period_start"),
+  CONSTRAINT fk_statements_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+-- Add the monthly statement opt-in to user_settings
+ALTER TABLE "user_settings" ADD COLUMN IF NOT EXISTS "monthly_statement" boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN "user_settings"."monthly_statement" IS 'Whether the user is emailed a statement of each month';
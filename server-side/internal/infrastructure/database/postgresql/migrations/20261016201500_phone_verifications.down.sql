@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_phone_verifications_expires_at;
+DROP INDEX IF EXISTS idx_phone_verifications_user_id;
+
+DROP TABLE IF EXISTS "phone_verifications" CASCADE;
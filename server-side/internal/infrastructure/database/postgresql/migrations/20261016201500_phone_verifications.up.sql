@@ -0,0 +1,18 @@
+-- Create phone_verifications table
+CREATE TABLE IF NOT EXISTS "phone_verifications" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "phone_number" varchar(20) NOT NULL,
+  "code_hash" varchar NOT NULL,
+  "attempts" integer NOT NULL DEFAULT 0,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_phone_verifications_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_phone_verifications_user_id ON "phone_verifications" ("user_id", "created_at");
+CREATE INDEX IF NOT EXISTS idx_phone_verifications_expires_at ON "phone_verifications" ("expires_at");
+
+COMMENT ON TABLE "phone_verifications" IS 'One-time codes sent to prove a phone number before linking it';
+COMMENT ON COLUMN "phone_verifications"."code_hash" IS 'SHA-256 hash of the code, keyed by the verification ID';
+COMMENT ON COLUMN "phone_verifications"."attempts" IS 'Wrong codes entered; the code stops working after too many';
@@ -0,0 +1,22 @@
+-- Create goals table
+CREATE TABLE IF NOT EXISTS "goals" (
+  "id" uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "user_id" uuid NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "target_amount" numeric(19,4) NOT NULL,
+  "saved_amount" numeric(19,4) NOT NULL DEFAULT 0,
+  "deadline" date,
+  "archived_at" timestamptz,
+  "version" integer NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT NOW(),
+  "updated_at" timestamptz NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_goals_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_goals_user_id ON "goals" ("user_id", "deadline");
+
+COMMENT ON TABLE "goals" IS 'Amounts users save toward, shown on the dashboard while active';
+COMMENT ON COLUMN "goals"."target_amount" IS 'Amount to save, in major units of currency';
+COMMENT ON COLUMN "goals"."saved_amount" IS 'Amount saved so far, in major units of currency';
+COMMENT ON COLUMN "goals"."archived_at" IS 'When the user archived the goal; NULL while active';
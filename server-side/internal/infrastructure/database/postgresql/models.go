@@ -4,9 +4,13 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
 	"gorm.io/gorm"
 )
 
@@ -20,7 +24,7 @@ func (j *JSONB) Scan(value interface{}) error {
 		return nil
 	}
 
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
 		return errors.New("failed to unmarshal JSONB value")
 	}
@@ -36,16 +40,78 @@ func (j JSONB) Value() (driver.Value, error) {
 	return json.Marshal(j)
 }
 
+// jsonBytes returns the JSON text of a JSON column: bytes from PostgreSQL, and a string
+// from SQLite when the column holds its text default
+func jsonBytes(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return v, true
+	case string:
+		return []byte(v), true
+	}
+	return nil, false
+}
+
+// Decimal holds a PostgreSQL numeric column as its exact decimal text, such as money
+// amounts in major units
+type Decimal string
+
+// Scan implements the sql.Scanner interface
+func (d *Decimal) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = "0"
+	case []byte:
+		*d = Decimal(v)
+	case string:
+		*d = Decimal(v)
+	case int64:
+		*d = Decimal(strconv.FormatInt(v, 10))
+	case float64:
+		*d = Decimal(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (d Decimal) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+// moneyDecimal converts minor units of the currency to the decimal stored in amount columns
+func moneyDecimal(minor int64, currency string) Decimal {
+	return Decimal(domain.Money{Minor: minor, Currency: currency}.String())
+}
+
+// Minor converts the decimal to minor units of the currency. Digits the currency does
+// not have, possible only in rows written outside the API, are rounded.
+func (d Decimal) Minor(currency string) int64 {
+	money, err := domain.ParseMoney(string(d), currency)
+	if err == nil {
+		return money.Minor
+	}
+	amount, _ := strconv.ParseFloat(string(d), 64)
+	return int64(math.Round(amount * math.Pow10(domain.CurrencyExponent(currency))))
+}
+
 // UserModel represents the users table
 type UserModel struct {
 	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	FullName    string         `gorm:"type:varchar;not null"`
 	PhoneNumber string         `gorm:"type:varchar;uniqueIndex;not null"`
 	Image       *string        `gorm:"type:varchar"`
+	Role        string         `gorm:"type:varchar;not null;default:'user'"`
 	Version     int            `gorm:"type:integer;not null;default:0"`
 	CreatedAt   time.Time      `gorm:"type:timestamptz"`
 	UpdatedAt   time.Time      `gorm:"type:timestamptz"`
 	DeletedAt   gorm.DeletedAt `gorm:"type:timestamptz;index"`
+
+	DemoExpiresAt *time.Time `gorm:"type:timestamptz"`
+	DataRegion    *string    `gorm:"type:varchar(32)"`
+	DisabledAt    *time.Time `gorm:"type:timestamptz"`
+	TokenVersion  int        `gorm:"type:integer;not null;default:0"`
 }
 
 // TableName specifies the table name for UserModel
@@ -55,16 +121,16 @@ func (UserModel) TableName() string {
 
 // AuthProviderModel represents the auth_providers table
 type AuthProviderModel struct {
-	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	DisplayName  string         `gorm:"type:varchar;not null"`
-	Name         *string        `gorm:"type:varchar;uniqueIndex"`
-	Image        *string        `gorm:"type:varchar"`
-	ClientID     *string        `gorm:"type:varchar"`
-	ClientSecret *string        `gorm:"type:varchar"`
-	Version      int            `gorm:"type:integer;not null;default:0"`
-	CreatedAt    time.Time      `gorm:"type:timestamptz"`
-	UpdatedAt    time.Time      `gorm:"type:timestamptz"`
-	DeletedAt    gorm.DeletedAt `gorm:"type:timestamptz;index"`
+	ID           uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	DisplayName  string          `gorm:"type:varchar;not null"`
+	Name         *string         `gorm:"type:varchar;uniqueIndex"`
+	Image        *string         `gorm:"type:varchar"`
+	ClientID     *string         `gorm:"type:varchar"`
+	ClientSecret EncryptedString `gorm:"type:varchar"`
+	Version      int             `gorm:"type:integer;not null;default:0"`
+	CreatedAt    time.Time       `gorm:"type:timestamptz"`
+	UpdatedAt    time.Time       `gorm:"type:timestamptz"`
+	DeletedAt    gorm.DeletedAt  `gorm:"type:timestamptz;index"`
 }
 
 // TableName specifies the table name for AuthProviderModel
@@ -74,16 +140,19 @@ func (AuthProviderModel) TableName() string {
 
 // UserAuthModel represents the user_auths table
 type UserAuthModel struct {
-	ID                 uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	UserID             uuid.UUID      `gorm:"type:uuid;not null;index:idx_user_auth_provider"`
-	AuthProviderID     uuid.UUID      `gorm:"type:uuid;not null;index:idx_user_auth_provider"`
-	CredentialID       string         `gorm:"type:varchar;not null"`
-	CredentialSecret   string         `gorm:"type:varchar;not null"`
-	CredentialRefresh  *string        `gorm:"type:varchar"`
-	Version            int            `gorm:"type:integer;not null;default:0"`
-	CreatedAt          time.Time      `gorm:"type:timestamptz"`
-	UpdatedAt          time.Time      `gorm:"type:timestamptz"`
-	DeletedAt          gorm.DeletedAt `gorm:"type:timestamptz;index"`
+	ID                uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID            uuid.UUID       `gorm:"type:uuid;not null;index:idx_user_auth_provider"`
+	AuthProviderID    uuid.UUID       `gorm:"type:uuid;not null;index:idx_user_auth_provider"`
+	CredentialID      string          `gorm:"type:varchar;not null"`
+	CredentialSecret  string          `gorm:"type:varchar;not null"`
+	CredentialRefresh EncryptedString `gorm:"type:varchar"`
+	LastUsedAt        *time.Time      `gorm:"type:timestamptz"`
+	FailedLoginCount  int             `gorm:"column:failed_login_attempts;type:integer;not null;default:0"`
+	LockedUntil       *time.Time      `gorm:"type:timestamptz"`
+	Version           int             `gorm:"type:integer;not null;default:0"`
+	CreatedAt         time.Time       `gorm:"type:timestamptz"`
+	UpdatedAt         time.Time       `gorm:"type:timestamptz"`
+	DeletedAt         gorm.DeletedAt  `gorm:"type:timestamptz;index"`
 
 	// Foreign key relationships
 	User         UserModel         `gorm:"foreignKey:UserID;references:ID"`
@@ -99,11 +168,19 @@ func (UserAuthModel) TableName() string {
 type MoneyFlowModel struct {
 	ID          uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	UserID      uuid.UUID      `gorm:"type:uuid;not null;index"`
+	WalletID    *uuid.UUID     `gorm:"type:uuid"`
+	Kind        string         `gorm:"type:varchar(20);not null;default:'expense'"`
+	TransferID  *uuid.UUID     `gorm:"type:uuid"`
+	GroupID     *uuid.UUID     `gorm:"type:uuid"`
+	MerchantID  *uuid.UUID     `gorm:"type:uuid"`
 	Category    *string        `gorm:"type:varchar"`
-	Amount      float64        `gorm:"type:decimal;not null"`
+	Amount      Decimal        `gorm:"type:numeric(19,4);not null"`
 	Currency    string         `gorm:"type:varchar;not null;default:'IDR'"`
 	Description *string        `gorm:"type:text"`
 	Tags        JSONB          `gorm:"type:jsonb"`
+	Latitude    *float64       `gorm:"type:double precision"`
+	Longitude   *float64       `gorm:"type:double precision"`
+	PlaceName   *string        `gorm:"type:varchar"`
 	Version     int            `gorm:"type:integer;not null;default:0"`
 	CreatedAt   time.Time      `gorm:"type:timestamptz"`
 	UpdatedAt   time.Time      `gorm:"type:timestamptz"`
@@ -117,3 +194,684 @@ type MoneyFlowModel struct {
 func (MoneyFlowModel) TableName() string {
 	return "money_flows"
 }
+
+// MonthlyCategoryTotalModel represents the monthly_category_totals table, which triggers
+// on money_flows keep up to date
+type MonthlyCategoryTotalModel struct {
+	OwnerKind string    `gorm:"type:varchar(8);primary_key"`
+	OwnerID   uuid.UUID `gorm:"type:uuid;primary_key"`
+	Month     time.Time `gorm:"type:date;primary_key"`
+	Currency  string    `gorm:"type:varchar;primary_key"`
+	Category  string    `gorm:"type:varchar;primary_key"`
+	Total     Decimal   `gorm:"type:numeric(19,4);not null"`
+	Count     int64     `gorm:"type:bigint;not null"`
+}
+
+// TableName specifies the table name for MonthlyCategoryTotalModel
+func (MonthlyCategoryTotalModel) TableName() string {
+	return "monthly_category_totals"
+}
+
+// APIKeyModel represents the api_keys table
+type APIKeyModel struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Name       string         `gorm:"type:varchar;not null"`
+	Prefix     string         `gorm:"type:varchar;not null"`
+	KeyHash    string         `gorm:"type:varchar;not null;uniqueIndex"`
+	Scopes     JSONB          `gorm:"type:jsonb"`
+	LastUsedAt *time.Time     `gorm:"type:timestamptz"`
+	RevokedAt  *time.Time     `gorm:"type:timestamptz"`
+	Version    int            `gorm:"type:integer;not null;default:0"`
+	CreatedAt  time.Time      `gorm:"type:timestamptz"`
+	UpdatedAt  time.Time      `gorm:"type:timestamptz"`
+	DeletedAt  gorm.DeletedAt `gorm:"type:timestamptz;index"`
+
+	// Foreign key relationship
+	User UserModel `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// TableName specifies the table name for APIKeyModel
+func (APIKeyModel) TableName() string {
+	return "api_keys"
+}
+
+// RefreshTokenModel represents the refresh_tokens table
+type RefreshTokenModel struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	SessionID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TokenHash  string     `gorm:"type:varchar;not null;uniqueIndex"`
+	ExpiresAt  time.Time  `gorm:"type:timestamptz;not null"`
+	RevokedAt  *time.Time `gorm:"type:timestamptz"`
+	UserAgent  *string    `gorm:"type:varchar(512)"`
+	IPAddress  *string    `gorm:"type:varchar(45)"`
+	SignedInAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt  time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt  time.Time  `gorm:"type:timestamptz"`
+
+	// Foreign key relationship
+	User UserModel `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// TableName specifies the table name for RefreshTokenModel
+func (RefreshTokenModel) TableName() string {
+	return "refresh_tokens"
+}
+
+// MoneyFlowNoteModel represents the money_flow_notes table.
+// search_vector is a generated column and is not mapped.
+type MoneyFlowNoteModel struct {
+	MoneyFlowID uuid.UUID `gorm:"type:uuid;primary_key"`
+	Content     string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"type:timestamptz"`
+	UpdatedAt   time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for MoneyFlowNoteModel
+func (MoneyFlowNoteModel) TableName() string {
+	return "money_flow_notes"
+}
+
+// AudienceJSON stores a broadcast audience filter in a JSONB column
+type AudienceJSON domain.BroadcastAudience
+
+// Scan implements the sql.Scanner interface
+func (a *AudienceJSON) Scan(value interface{}) error {
+	if value == nil {
+		*a = AudienceJSON{}
+		return nil
+	}
+
+	bytes, ok := jsonBytes(value)
+	if !ok {
+		return errors.New("failed to unmarshal audience value")
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+// Value implements the driver.Valuer interface
+func (a AudienceJSON) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// NotificationModel represents the notifications table
+type NotificationModel struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Title     string     `gorm:"type:varchar;not null"`
+	Body      string     `gorm:"type:text;not null"`
+	ReadAt    *time.Time `gorm:"type:timestamptz"`
+	CreatedAt time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for NotificationModel
+func (NotificationModel) TableName() string {
+	return "notifications"
+}
+
+// BroadcastModel represents the broadcasts table
+type BroadcastModel struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedBy      uuid.UUID    `gorm:"type:uuid;not null"`
+	Title          string       `gorm:"type:varchar;not null"`
+	Body           string       `gorm:"type:text;not null"`
+	Channels       JSONB        `gorm:"type:jsonb"`
+	Audience       AudienceJSON `gorm:"type:jsonb"`
+	Status         string       `gorm:"type:varchar;not null"`
+	RecipientCount int          `gorm:"type:integer;not null;default:0"`
+	CompletedAt    *time.Time   `gorm:"type:timestamptz"`
+	CreatedAt      time.Time    `gorm:"type:timestamptz"`
+	UpdatedAt      time.Time    `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for BroadcastModel
+func (BroadcastModel) TableName() string {
+	return "broadcasts"
+}
+
+// BroadcastDeliveryModel represents the broadcast_deliveries table
+type BroadcastDeliveryModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BroadcastID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null"`
+	Channel     *string    `gorm:"type:varchar"`
+	Status      string     `gorm:"type:varchar;not null"`
+	Attempts    int        `gorm:"type:integer;not null;default:0"`
+	LastError   *string    `gorm:"type:text"`
+	SentAt      *time.Time `gorm:"type:timestamptz"`
+	CreatedAt   time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt   time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for BroadcastDeliveryModel
+func (BroadcastDeliveryModel) TableName() string {
+	return "broadcast_deliveries"
+}
+
+// JobModel represents the jobs table
+type JobModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Type        string     `gorm:"type:varchar;not null"`
+	Payload     string     `gorm:"type:jsonb;not null"`
+	Status      string     `gorm:"type:varchar;not null"`
+	Attempts    int        `gorm:"type:integer;not null;default:0"`
+	MaxAttempts int        `gorm:"type:integer;not null"`
+	RunAt       time.Time  `gorm:"type:timestamptz;not null"`
+	LastError   *string    `gorm:"type:text"`
+	LockedAt    *time.Time `gorm:"type:timestamptz"`
+	FinishedAt  *time.Time `gorm:"type:timestamptz"`
+	CreatedAt   time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt   time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for JobModel
+func (JobModel) TableName() string {
+	return "jobs"
+}
+
+// OutboxEventModel represents the outbox_events table
+type OutboxEventModel struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Type         string     `gorm:"type:varchar;not null"`
+	UserID       *uuid.UUID `gorm:"type:uuid"`
+	Payload      string     `gorm:"type:jsonb;not null"`
+	OccurredAt   time.Time  `gorm:"type:timestamptz;not null"`
+	DispatchedAt *time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for OutboxEventModel
+func (OutboxEventModel) TableName() string {
+	return "outbox_events"
+}
+
+// DeviceModel represents the devices table
+type DeviceModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Token      string    `gorm:"type:varchar(4096);not null;uniqueIndex"`
+	Platform   string    `gorm:"type:varchar(16);not null"`
+	Name       *string   `gorm:"type:varchar(100)"`
+	LastSeenAt time.Time `gorm:"type:timestamptz;not null"`
+	CreatedAt  time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for DeviceModel
+func (DeviceModel) TableName() string {
+	return "devices"
+}
+
+// WebhookModel represents the webhooks table
+type WebhookModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	URL       string    `gorm:"type:varchar(2048);not null"`
+	Secret    string    `gorm:"type:varchar(100);not null"`
+	Events    JSONB     `gorm:"type:jsonb"`
+	Active    bool      `gorm:"type:boolean;not null;default:true"`
+	Version   int       `gorm:"type:integer;not null;default:0"`
+	CreatedAt time.Time `gorm:"type:timestamptz"`
+	UpdatedAt time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for WebhookModel
+func (WebhookModel) TableName() string {
+	return "webhooks"
+}
+
+// WebhookDeliveryModel represents the webhook_deliveries table
+type WebhookDeliveryModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	WebhookID  uuid.UUID `gorm:"type:uuid;not null"`
+	EventID    uuid.UUID `gorm:"type:uuid;not null"`
+	EventType  string    `gorm:"type:varchar;not null"`
+	Attempt    int       `gorm:"type:integer;not null"`
+	StatusCode *int      `gorm:"type:integer"`
+	Error      *string   `gorm:"type:text"`
+	DurationMs int64     `gorm:"type:integer;not null;default:0"`
+	Succeeded  bool      `gorm:"type:boolean;not null"`
+	CreatedAt  time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for WebhookDeliveryModel
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+// BudgetModel represents the budgets table
+type BudgetModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null"`
+	Category  string    `gorm:"type:varchar(100);not null"`
+	Amount    Decimal   `gorm:"type:numeric(19,4);not null"`
+	Currency  string    `gorm:"type:varchar(3);not null;default:'IDR'"`
+	Hard      bool      `gorm:"type:boolean;not null;default:false"`
+	Version   int       `gorm:"type:integer;not null;default:0"`
+	CreatedAt time.Time `gorm:"type:timestamptz"`
+	UpdatedAt time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for BudgetModel
+func (BudgetModel) TableName() string {
+	return "budgets"
+}
+
+// BudgetOverrideModel represents the budget_overrides table
+type BudgetOverrideModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BudgetID    uuid.UUID `gorm:"type:uuid;not null"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null"`
+	MoneyFlowID uuid.UUID `gorm:"type:uuid;not null"`
+	Currency    string    `gorm:"type:varchar(3);not null;default:'IDR'"`
+	Amount      Decimal   `gorm:"type:numeric(19,4);not null"`
+	Spent       Decimal   `gorm:"type:numeric(19,4);not null"`
+	Cap         Decimal   `gorm:"type:numeric(19,4);not null"`
+	CreatedAt   time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for BudgetOverrideModel
+func (BudgetOverrideModel) TableName() string {
+	return "budget_overrides"
+}
+
+// ConversationModel represents the conversations table
+type ConversationModel struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null"`
+	Flow            string    `gorm:"type:varchar(50);not null"`
+	Step            string    `gorm:"type:varchar(50);not null"`
+	Status          string    `gorm:"type:varchar(20);not null"`
+	Data            string    `gorm:"type:jsonb;not null"`
+	SourceMessageID *string   `gorm:"type:varchar"`
+	ExpiresAt       time.Time `gorm:"type:timestamptz;not null"`
+	Version         int       `gorm:"type:integer;not null;default:0"`
+	CreatedAt       time.Time `gorm:"type:timestamptz"`
+	UpdatedAt       time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for ConversationModel
+func (ConversationModel) TableName() string {
+	return "conversations"
+}
+
+// JSONMap type for PostgreSQL JSONB object columns with string values
+type JSONMap map[string]string
+
+// Scan implements the sql.Scanner interface
+func (j *JSONMap) Scan(value interface{}) error {
+	if value == nil {
+		*j = map[string]string{}
+		return nil
+	}
+
+	bytes, ok := jsonBytes(value)
+	if !ok {
+		return errors.New("failed to unmarshal JSONB value")
+	}
+
+	return json.Unmarshal(bytes, j)
+}
+
+// Value implements the driver.Valuer interface
+func (j JSONMap) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return json.Marshal(map[string]string{})
+	}
+	return json.Marshal(map[string]string(j))
+}
+
+// UserSettingsModel represents the user_settings table
+type UserSettingsModel struct {
+	UserID               uuid.UUID `gorm:"type:uuid;primary_key"`
+	AnalyticsOptOut      bool      `gorm:"type:boolean;not null;default:false"`
+	DefaultCurrency      string    `gorm:"type:varchar(3);not null;default:'IDR'"`
+	SingleCurrencyMode   bool      `gorm:"type:boolean;not null;default:false"`
+	Locale               string    `gorm:"type:varchar(35);not null;default:'id-ID'"`
+	Timezone             string    `gorm:"type:varchar(64);not null;default:'UTC'"`
+	WeekStart            int       `gorm:"type:smallint;not null;default:1"`
+	NotifyWhatsApp       bool      `gorm:"column:notify_whatsapp;type:boolean;not null;default:true"`
+	NotifyEmail          bool      `gorm:"type:boolean;not null;default:true"`
+	NotifyTelegram       bool      `gorm:"type:boolean;not null;default:true"`
+	NotifyPush           bool      `gorm:"type:boolean;not null;default:true"`
+	TelegramChatID       *string   `gorm:"type:varchar(32)"`
+	DigestFrequency      string    `gorm:"type:varchar(16);not null;default:'weekly'"`
+	DuplicateCheck       string    `gorm:"type:varchar(16);not null;default:'warn'"`
+	MonthlyStatement     bool      `gorm:"type:boolean;not null;default:false"`
+	NotificationChannels JSONB     `gorm:"type:jsonb;not null;default:'[]'"`
+	Version              int       `gorm:"type:integer;not null;default:0"`
+	CreatedAt            time.Time `gorm:"type:timestamptz"`
+	UpdatedAt            time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for UserSettingsModel
+func (UserSettingsModel) TableName() string {
+	return "user_settings"
+}
+
+// AnalyticsEventModel represents the analytics_events table
+type AnalyticsEventModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name        string    `gorm:"type:varchar;not null"`
+	AnonymousID string    `gorm:"type:varchar;not null"`
+	Properties  JSONMap   `gorm:"type:jsonb"`
+	OccurredAt  time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName specifies the table name for AnalyticsEventModel
+func (AnalyticsEventModel) TableName() string {
+	return "analytics_events"
+}
+
+// APIUsageModel represents the database model for daily API usage counters
+type APIUsageModel struct {
+	UserID       uuid.UUID `gorm:"type:uuid;primary_key"`
+	Day          time.Time `gorm:"type:date;primary_key"`
+	Method       string    `gorm:"type:varchar(10);primary_key"`
+	Route        string    `gorm:"type:varchar;primary_key"`
+	RequestCount int64     `gorm:"type:bigint;not null;default:0"`
+	ErrorCount   int64     `gorm:"type:bigint;not null;default:0"`
+}
+
+// TableName specifies the table name for APIUsageModel
+func (APIUsageModel) TableName() string {
+	return "api_usage_daily"
+}
+
+// SystemSettingModel represents the system_settings table
+type SystemSettingModel struct {
+	Key       string     `gorm:"type:varchar(100);primary_key"`
+	Value     string     `gorm:"type:text;not null"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid"`
+	UpdatedAt time.Time  `gorm:"type:timestamptz;not null"`
+}
+
+// TableName specifies the table name for SystemSettingModel
+func (SystemSettingModel) TableName() string {
+	return "system_settings"
+}
+
+// ExchangeRateModel represents the exchange_rates table
+type ExchangeRateModel struct {
+	BaseCurrency string    `gorm:"type:varchar(3);primary_key"`
+	Currency     string    `gorm:"type:varchar(3);primary_key"`
+	RateDate     time.Time `gorm:"type:date;primary_key"`
+	Rate         float64   `gorm:"type:numeric(24,10);not null"`
+	Source       string    `gorm:"type:varchar(50);not null"`
+	FetchedAt    time.Time `gorm:"type:timestamptz;not null"`
+}
+
+// TableName specifies the table name for ExchangeRateModel
+func (ExchangeRateModel) TableName() string {
+	return "exchange_rates"
+}
+
+// InvitationModel represents the invitations table
+type InvitationModel struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	InvitedBy  *uuid.UUID `gorm:"type:uuid"`
+	Email      string     `gorm:"type:varchar;not null"`
+	Channel    string     `gorm:"type:varchar(20);not null"`
+	TokenHash  *string    `gorm:"type:varchar;uniqueIndex"`
+	SentAt     *time.Time `gorm:"type:timestamptz"`
+	ExpiresAt  time.Time  `gorm:"type:timestamptz;not null"`
+	AcceptedAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt  time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt  time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for InvitationModel
+func (InvitationModel) TableName() string {
+	return "invitations"
+}
+
+// FeedbackModel represents the feedback table
+type FeedbackModel struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Kind          string     `gorm:"type:varchar(20);not null"`
+	Source        string     `gorm:"type:varchar(20);not null"`
+	Message       string     `gorm:"type:text;not null"`
+	RequestID     string     `gorm:"type:varchar;not null"`
+	LastRequestID *string    `gorm:"type:varchar"`
+	AppVersion    *string    `gorm:"type:varchar(50)"`
+	UserAgent     *string    `gorm:"type:varchar"`
+	ForwardedAt   *time.Time `gorm:"type:timestamptz"`
+	CreatedAt     time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for FeedbackModel
+func (FeedbackModel) TableName() string {
+	return "feedback"
+}
+
+// WalletModel represents the wallets table
+type WalletModel struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name           string    `gorm:"type:varchar(100);not null"`
+	Type           string    `gorm:"type:varchar(20);not null"`
+	Currency       string    `gorm:"type:varchar(3);not null"`
+	OpeningBalance Decimal   `gorm:"type:numeric(19,4);not null;default:0"`
+	Version        int       `gorm:"type:integer;not null;default:0"`
+	CreatedAt      time.Time `gorm:"type:timestamptz"`
+	UpdatedAt      time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for WalletModel
+func (WalletModel) TableName() string {
+	return "wallets"
+}
+
+// GoalModel represents the goals table
+type GoalModel struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name         string     `gorm:"type:varchar(100);not null"`
+	Currency     string     `gorm:"type:varchar(3);not null"`
+	TargetAmount Decimal    `gorm:"type:numeric(19,4);not null"`
+	SavedAmount  Decimal    `gorm:"type:numeric(19,4);not null;default:0"`
+	Deadline     *time.Time `gorm:"type:date"`
+	ArchivedAt   *time.Time `gorm:"type:timestamptz"`
+	Version      int        `gorm:"type:integer;not null;default:0"`
+	CreatedAt    time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt    time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for GoalModel
+func (GoalModel) TableName() string {
+	return "goals"
+}
+
+// GroupModel represents the groups table
+type GroupModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	CreatedBy uuid.UUID `gorm:"type:uuid;not null"`
+	Version   int       `gorm:"type:integer;not null;default:0"`
+	CreatedAt time.Time `gorm:"type:timestamptz"`
+	UpdatedAt time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for GroupModel
+func (GroupModel) TableName() string {
+	return "groups"
+}
+
+// GroupMemberModel represents the group_members table
+type GroupMemberModel struct {
+	GroupID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Role      string    `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for GroupMemberModel
+func (GroupMemberModel) TableName() string {
+	return "group_members"
+}
+
+// GroupInvitationModel represents the group_invitations table
+type GroupInvitationModel struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	GroupID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	InvitedBy  uuid.UUID  `gorm:"type:uuid;not null"`
+	Role       string     `gorm:"type:varchar(20);not null"`
+	TokenHash  string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt  time.Time  `gorm:"type:timestamptz;not null"`
+	AcceptedBy *uuid.UUID `gorm:"type:uuid"`
+	AcceptedAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt  time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for GroupInvitationModel
+func (GroupInvitationModel) TableName() string {
+	return "group_invitations"
+}
+
+// SplitModel represents the money_flow_splits table
+type SplitModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	MoneyFlowID uuid.UUID `gorm:"type:uuid;not null"`
+	GroupID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null"`
+	Method      string    `gorm:"type:varchar(20);not null"`
+	Amount      Decimal   `gorm:"type:numeric(19,4);not null"`
+	Currency    string    `gorm:"type:varchar(3);not null"`
+	CreatedAt   time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for SplitModel
+func (SplitModel) TableName() string {
+	return "money_flow_splits"
+}
+
+// SettlementModel represents the group_settlements table
+type SettlementModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	GroupID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	FromUserID uuid.UUID `gorm:"type:uuid;not null"`
+	ToUserID   uuid.UUID `gorm:"type:uuid;not null"`
+	Amount     Decimal   `gorm:"type:numeric(19,4);not null"`
+	Currency   string    `gorm:"type:varchar(3);not null"`
+	CreatedBy  uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt  time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for SettlementModel
+func (SettlementModel) TableName() string {
+	return "group_settlements"
+}
+
+// AuditLogModel represents the audit_logs table
+type AuditLogModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActorID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Action     string    `gorm:"type:varchar(20);not null"`
+	EntityType string    `gorm:"type:varchar(50);not null"`
+	EntityID   uuid.UUID `gorm:"type:uuid;not null"`
+	Before     *string   `gorm:"type:jsonb"`
+	After      *string   `gorm:"type:jsonb"`
+	CreatedAt  time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for AuditLogModel
+func (AuditLogModel) TableName() string {
+	return "audit_logs"
+}
+
+// PasswordResetModel represents the password_resets table
+type PasswordResetModel struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null"`
+	UserAuthID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Email      string     `gorm:"type:varchar;not null"`
+	TokenHash  *string    `gorm:"type:varchar;uniqueIndex"`
+	SentAt     *time.Time `gorm:"type:timestamptz"`
+	ExpiresAt  time.Time  `gorm:"type:timestamptz;not null"`
+	UsedAt     *time.Time `gorm:"type:timestamptz"`
+	CreatedAt  time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt  time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for PasswordResetModel
+func (PasswordResetModel) TableName() string {
+	return "password_resets"
+}
+
+// PhoneVerificationModel represents the phone_verifications table
+type PhoneVerificationModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	PhoneNumber string    `gorm:"type:varchar(20);not null"`
+	CodeHash    string    `gorm:"type:varchar;not null"`
+	Attempts    int       `gorm:"type:integer;not null;default:0"`
+	ExpiresAt   time.Time `gorm:"type:timestamptz;not null"`
+	CreatedAt   time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for PhoneVerificationModel
+func (PhoneVerificationModel) TableName() string {
+	return "phone_verifications"
+}
+
+// MerchantModel represents the merchants table
+type MerchantModel struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	CreatedAt time.Time `gorm:"type:timestamptz"`
+	UpdatedAt time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for MerchantModel
+func (MerchantModel) TableName() string {
+	return "merchants"
+}
+
+// MerchantRuleModel represents the merchant_rules table
+type MerchantRuleModel struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Pattern    string    `gorm:"type:varchar(100);not null"`
+	MerchantID uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedAt  time.Time `gorm:"type:timestamptz"`
+	UpdatedAt  time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for MerchantRuleModel
+func (MerchantRuleModel) TableName() string {
+	return "merchant_rules"
+}
+
+// AccountErasureModel represents the account_erasures table
+type AccountErasureModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Status      string     `gorm:"type:varchar(16);not null"`
+	EraseAt     time.Time  `gorm:"type:timestamptz;not null"`
+	CancelledAt *time.Time `gorm:"type:timestamptz"`
+	CompletedAt *time.Time `gorm:"type:timestamptz"`
+	CreatedAt   time.Time  `gorm:"type:timestamptz"`
+	UpdatedAt   time.Time  `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for AccountErasureModel
+func (AccountErasureModel) TableName() string {
+	return "account_erasures"
+}
+
+// MoneyFlowShareModel represents the money_flow_shares table
+type MoneyFlowShareModel struct {
+	ID          uuid.UUID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	MoneyFlowID uuid.UUID `gorm:"type:uuid;not null;index"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null"`
+	IncludeNote bool      `gorm:"not null;default:false"`
+	ExpiresAt   time.Time `gorm:"type:timestamptz;not null;index"`
+	CreatedAt   time.Time `gorm:"type:timestamptz"`
+}
+
+// TableName specifies the table name for MoneyFlowShareModel
+func (MoneyFlowShareModel) TableName() string {
+	return "money_flow_shares"
+}
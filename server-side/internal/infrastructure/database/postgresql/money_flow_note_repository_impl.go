@@ -0,0 +1,130 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type moneyFlowNoteRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewMoneyFlowNoteRepository creates a new money flow note repository implementation
+func NewMoneyFlowNoteRepository(db repository.DB) repository.MoneyFlowNoteRepository {
+	return &moneyFlowNoteRepositoryImpl{db: db}
+}
+
+func (r *moneyFlowNoteRepositoryImpl) Save(ctx context.Context, note *domain.MoneyFlowNote) error {
+	model := r.domainToModel(note)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&MoneyFlowNoteModel{}).
+		Where("money_flow_id = ?", note.MoneyFlowID).
+		Updates(map[string]interface{}{
+			"content":    model.Content,
+			"updated_at": model.UpdatedAt,
+		})
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	note.CreatedAt = model.CreatedAt
+	note.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *moneyFlowNoteRepositoryImpl) FindByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) (*domain.MoneyFlowNote, error) {
+	var model MoneyFlowNoteModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("money_flow_id = ?", moneyFlowID).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *moneyFlowNoteRepositoryImpl) Delete(ctx context.Context, moneyFlowID uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Delete(&MoneyFlowNoteModel{}, "money_flow_id = ?", moneyFlowID)
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *moneyFlowNoteRepositoryImpl) SearchMoneyFlowIDs(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]uuid.UUID, error) {
+	var models []MoneyFlowNoteModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MoneyFlowNoteModel{}).
+		Select("money_flow_id").
+		Where("search_vector @@ plainto_tsquery('simple', ?)", query).
+		Where("money_flow_id IN (SELECT id FROM money_flows WHERE user_id = ? AND deleted_at IS NULL)", userID).
+		Order("updated_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(models))
+	for i, model := range models {
+		ids[i] = model.MoneyFlowID
+	}
+
+	return ids, nil
+}
+
+// Helper methods for conversion
+
+func (r *moneyFlowNoteRepositoryImpl) domainToModel(note *domain.MoneyFlowNote) *MoneyFlowNoteModel {
+	return &MoneyFlowNoteModel{
+		MoneyFlowID: note.MoneyFlowID,
+		Content:     note.Content,
+		CreatedAt:   note.CreatedAt,
+		UpdatedAt:   note.UpdatedAt,
+	}
+}
+
+func (r *moneyFlowNoteRepositoryImpl) modelToDomain(model *MoneyFlowNoteModel) *domain.MoneyFlowNote {
+	return &domain.MoneyFlowNote{
+		MoneyFlowID: model.MoneyFlowID,
+		Content:     model.Content,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
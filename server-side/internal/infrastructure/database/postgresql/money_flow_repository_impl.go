@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// moneyFlowInsertBatchSize is the number of rows per INSERT statement in CreateBatch
+const moneyFlowInsertBatchSize = 100
+
 type moneyFlowRepositoryImpl struct {
 	db repository.DB
 }
@@ -39,6 +43,34 @@ func (r *moneyFlowRepositoryImpl) Create(ctx context.Context, moneyFlow *domain.
 	return nil
 }
 
+func (r *moneyFlowRepositoryImpl) CreateBatch(ctx context.Context, moneyFlows []*domain.MoneyFlow) error {
+	if len(moneyFlows) == 0 {
+		return nil
+	}
+
+	models := make([]*MoneyFlowModel, len(moneyFlows))
+	for i, moneyFlow := range moneyFlows {
+		models[i] = r.domainToModel(moneyFlow)
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.CreateInBatches(&models, moneyFlowInsertBatchSize)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	// Update domain entities with generated values
+	for i, model := range models {
+		moneyFlows[i].ID = model.ID
+		moneyFlows[i].CreatedAt = model.CreatedAt
+		moneyFlows[i].UpdatedAt = model.UpdatedAt
+	}
+
+	return nil
+}
+
 func (r *moneyFlowRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.MoneyFlow, error) {
 	var model MoneyFlowModel
 
@@ -56,6 +88,29 @@ func (r *moneyFlowRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*
 	return r.modelToDomain(&model), nil
 }
 
+func (r *moneyFlowRepositoryImpl) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.MoneyFlow, error) {
+	if len(ids) == 0 {
+		return []*domain.MoneyFlow{}, nil
+	}
+
+	var models []MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id IN ?", ids).Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(models))
+	for i, model := range models {
+		moneyFlows[i] = r.modelToDomain(&model)
+	}
+
+	return moneyFlows, nil
+}
+
 func (r *moneyFlowRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
 	var models []MoneyFlowModel
 
@@ -79,6 +134,97 @@ func (r *moneyFlowRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.
 	return moneyFlows, nil
 }
 
+func (r *moneyFlowRepositoryImpl) FindByUserIDAndTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.MoneyFlow, error) {
+	var models []MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Containment is served by the GIN index on tags
+	res := db.Where("user_id = ? AND tags @> jsonb_build_array(CAST(? AS text))", userID, tag).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(models))
+	for i, model := range models {
+		moneyFlows[i] = r.modelToDomain(&model)
+	}
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByUserIDAndWallet(ctx context.Context, userID, walletID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	var models []MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ? AND wallet_id = ?", userID, walletID).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(models))
+	for i, model := range models {
+		moneyFlows[i] = r.modelToDomain(&model)
+	}
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByGroupID(ctx context.Context, groupID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	var models []MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("group_id = ?", groupID).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(models))
+	for i, model := range models {
+		moneyFlows[i] = r.modelToDomain(&model)
+	}
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindByTransferID(ctx context.Context, transferID uuid.UUID) ([]*domain.MoneyFlow, error) {
+	var models []MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("transfer_id = ?", transferID).
+		Order("kind DESC"). // transfer_out first
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(models))
+	for i, model := range models {
+		moneyFlows[i] = r.modelToDomain(&model)
+	}
+
+	return moneyFlows, nil
+}
+
 func (r *moneyFlowRepositoryImpl) FindByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*domain.MoneyFlow, error) {
 	var models []MoneyFlowModel
 
@@ -100,6 +246,123 @@ func (r *moneyFlowRepositoryImpl) FindByUserIDAndDateRange(ctx context.Context,
 	return moneyFlows, nil
 }
 
+func (r *moneyFlowRepositoryImpl) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.CountByFilter(ctx, repository.MoneyFlowFilter{UserID: userID})
+}
+
+func (r *moneyFlowRepositoryImpl) CountByFilter(ctx context.Context, filter repository.MoneyFlowFilter) (int64, error) {
+	var count int64
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := r.applyFilter(db.Model(&MoneyFlowModel{}), filter).
+		Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// applyFilter restricts a query to the money flows matching the filter
+func (r *moneyFlowRepositoryImpl) applyFilter(db repository.DB, filter repository.MoneyFlowFilter) repository.DB {
+	if filter.GroupID != nil {
+		db = db.Where("group_id = ?", *filter.GroupID)
+	} else {
+		db = db.Where("user_id = ?", filter.UserID)
+	}
+	if filter.WalletID != nil {
+		db = db.Where("wallet_id = ?", *filter.WalletID)
+	}
+	if filter.Tag != "" {
+		// Containment is served by the GIN index on tags
+		db = db.Where("tags @> jsonb_build_array(CAST(? AS text))", filter.Tag)
+	}
+	return db
+}
+
+func (r *moneyFlowRepositoryImpl) SummaryByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.FlowSummary, error) {
+	var rows []struct {
+		Currency string
+		Expense  Decimal
+		Count    int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MoneyFlowModel{}).
+		Select("currency, COALESCE(SUM(amount), 0) AS expense, COUNT(*) AS count").
+		Where("user_id = ? AND kind = ? AND created_at >= ? AND created_at < ?", userID, domain.MoneyFlowKindExpense, start, end).
+		Group("currency").
+		Order("count DESC, currency ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*domain.FlowSummary, len(rows))
+	for i, row := range rows {
+		expense := row.Expense.Minor(row.Currency)
+		summaries[i] = &domain.FlowSummary{
+			Currency: row.Currency,
+			Expense:  expense,
+			Net:      -expense,
+			Count:    row.Count,
+		}
+	}
+
+	return summaries, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindCreatedBetween(ctx context.Context, start, end time.Time, limit, offset int) ([]*domain.MoneyFlow, error) {
+	var models []MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("created_at >= ? AND created_at < ?", start, end).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(models))
+	for i, model := range models {
+		moneyFlows[i] = r.modelToDomain(&model)
+	}
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepositoryImpl) FindCreatedBetweenWithDeleted(ctx context.Context, start, end time.Time, limit, offset int) ([]*domain.MoneyFlow, error) {
+	var models []MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Unscoped().
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(models))
+	for i, model := range models {
+		moneyFlows[i] = r.modelToDomain(&model)
+	}
+
+	return moneyFlows, nil
+}
+
 func (r *moneyFlowRepositoryImpl) Update(ctx context.Context, moneyFlow *domain.MoneyFlow) error {
 	model := r.domainToModel(moneyFlow)
 
@@ -110,11 +373,17 @@ func (r *moneyFlowRepositoryImpl) Update(ctx context.Context, moneyFlow *domain.
 	result := db.Model(&MoneyFlowModel{}).
 		Where("id = ? AND version = ?", moneyFlow.ID, moneyFlow.Version-1).
 		Updates(map[string]any{
+			"wallet_id":   model.WalletID,
+			"group_id":    model.GroupID,
+			"merchant_id": model.MerchantID,
 			"category":    model.Category,
 			"amount":      model.Amount,
 			"currency":    model.Currency,
 			"description": model.Description,
 			"tags":        model.Tags,
+			"latitude":    model.Latitude,
+			"longitude":   model.Longitude,
+			"place_name":  model.PlaceName,
 			"version":     model.Version,
 			"updated_at":  model.UpdatedAt,
 		})
@@ -130,6 +399,53 @@ func (r *moneyFlowRepositoryImpl) Update(ctx context.Context, moneyFlow *domain.
 	return nil
 }
 
+func (r *moneyFlowRepositoryImpl) PartialUpdate(ctx context.Context, moneyFlow *domain.MoneyFlow, fields []string) error {
+	model := r.domainToModel(moneyFlow)
+	columns := map[string]any{
+		repository.MoneyFlowFieldWalletID:    model.WalletID,
+		repository.MoneyFlowFieldGroupID:     model.GroupID,
+		repository.MoneyFlowFieldMerchantID:  model.MerchantID,
+		repository.MoneyFlowFieldCategory:    model.Category,
+		repository.MoneyFlowFieldAmount:      model.Amount,
+		repository.MoneyFlowFieldCurrency:    model.Currency,
+		repository.MoneyFlowFieldDescription: model.Description,
+		repository.MoneyFlowFieldTags:        model.Tags,
+		repository.MoneyFlowFieldLatitude:    model.Latitude,
+		repository.MoneyFlowFieldLongitude:   model.Longitude,
+		repository.MoneyFlowFieldPlaceName:   model.PlaceName,
+	}
+
+	updates := map[string]any{
+		"version":    model.Version,
+		"updated_at": model.UpdatedAt,
+	}
+	for _, field := range fields {
+		value, ok := columns[field]
+		if !ok {
+			return fmt.Errorf("unknown money flow field %q", field)
+		}
+		updates[field] = value
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&MoneyFlowModel{}).
+		Where("id = ? AND version = ?", moneyFlow.ID, moneyFlow.Version-1).
+		Updates(updates)
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
 func (r *moneyFlowRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	// Use GetDB to support transactions
 	db := GetDB(ctx, r.db)
@@ -147,38 +463,254 @@ func (r *moneyFlowRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) erro
 	return nil
 }
 
-func (r *moneyFlowRepositoryImpl) GetTotalByUserID(ctx context.Context, userID uuid.UUID) (float64, error) {
-	var total float64
+func (r *moneyFlowRepositoryImpl) DeleteByTransferID(ctx context.Context, transferID uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Delete(&MoneyFlowModel{}, "transfer_id = ?", transferID)
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *moneyFlowRepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Delete(&MoneyFlowModel{}, "user_id = ?", userID)
+
+	return result.Error()
+}
+
+func (r *moneyFlowRepositoryImpl) GetCategoryTotalInPeriod(ctx context.Context, userID uuid.UUID, category, currency string, start, end time.Time, excludeID uuid.UUID) (int64, error) {
+	var total Decimal
 
 	// Use GetDB to support transactions
 	db := GetDB(ctx, r.db)
 
 	res := db.Model(&MoneyFlowModel{}).
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND kind = ? AND category = ? AND currency = ? AND created_at >= ? AND created_at < ? AND id <> ?",
+			userID, domain.MoneyFlowKindExpense, category, currency, start, end, excludeID).
 		Select("COALESCE(SUM(amount), 0)").
 		Scan(&total)
 	if err := res.Error(); err != nil {
 		return 0, err
 	}
 
-	return total, nil
+	return total.Minor(currency), nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetTotalsByCurrency(ctx context.Context, userID uuid.UUID) ([]*domain.CurrencyTotal, error) {
+	var rows []struct {
+		Currency string
+		Total    Decimal
+		Count    int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MoneyFlowModel{}).
+		Select("currency, COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where("user_id = ? AND kind = ?", userID, domain.MoneyFlowKindExpense).
+		Group("currency").
+		Order("count DESC, currency ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.CurrencyTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.CurrencyTotal{
+			Currency: row.Currency,
+			Total:    row.Total.Minor(row.Currency),
+			Count:    row.Count,
+		}
+	}
+
+	return totals, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetTotalsByCategory(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	return r.totalsByCategory(ctx, "user_id = ?", userID, start, end)
 }
 
-func (r *moneyFlowRepositoryImpl) GetTotalByUserIDAndCategory(ctx context.Context, userID uuid.UUID, category string) (float64, error) {
-	var total float64
+func (r *moneyFlowRepositoryImpl) GetGroupTotalsByCategory(ctx context.Context, groupID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	return r.totalsByCategory(ctx, "group_id = ?", groupID, start, end)
+}
+
+// totalsByCategory sums the expenses matching the owner condition per category and currency
+func (r *moneyFlowRepositoryImpl) totalsByCategory(ctx context.Context, owner string, ownerID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	var rows []struct {
+		Category *string
+		Currency string
+		Total    Decimal
+		Count    int64
+	}
 
 	// Use GetDB to support transactions
 	db := GetDB(ctx, r.db)
 
 	res := db.Model(&MoneyFlowModel{}).
-		Where("user_id = ? AND category = ?", userID, category).
-		Select("COALESCE(SUM(amount), 0)").
-		Scan(&total)
+		Select("category, currency, COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where(owner, ownerID).
+		Where("kind = ? AND created_at >= ? AND created_at < ?", domain.MoneyFlowKindExpense, start, end).
+		Group("category, currency").
+		Order("category ASC NULLS LAST, currency ASC").
+		Scan(&rows)
 	if err := res.Error(); err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	totals := make([]*domain.CategoryTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.CategoryTotal{
+			Category: row.Category,
+			Currency: row.Currency,
+			Total:    row.Total.Minor(row.Currency),
+			Count:    row.Count,
+		}
 	}
 
-	return total, nil
+	return totals, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetTotalsByWallet(ctx context.Context, userID uuid.UUID) ([]*domain.WalletTotal, error) {
+	var rows []struct {
+		WalletID uuid.UUID
+		Currency string
+		Inflow   Decimal
+		Outflow  Decimal
+		Count    int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MoneyFlowModel{}).
+		Select("wallet_id, currency, "+
+			"COALESCE(SUM(amount) FILTER (WHERE kind = '"+domain.MoneyFlowKindTransferIn+"'), 0) AS inflow, "+
+			"COALESCE(SUM(amount) FILTER (WHERE kind <> '"+domain.MoneyFlowKindTransferIn+"'), 0) AS outflow, "+
+			"COUNT(*) AS count").
+		Where("user_id = ? AND wallet_id IS NOT NULL", userID).
+		Group("wallet_id, currency").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.WalletTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.WalletTotal{
+			WalletID: row.WalletID,
+			Currency: row.Currency,
+			Inflow:   row.Inflow.Minor(row.Currency),
+			Outflow:  row.Outflow.Minor(row.Currency),
+			Count:    row.Count,
+		}
+	}
+
+	return totals, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetTagUsage(ctx context.Context, userID uuid.UUID) ([]*domain.TagUsage, error) {
+	var rows []struct {
+		Tag        string
+		Count      int64
+		LastUsedAt time.Time
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MoneyFlowModel{}).
+		Joins("CROSS JOIN LATERAL jsonb_array_elements_text(money_flows.tags) AS tag(name)").
+		Select("tag.name AS tag, COUNT(DISTINCT money_flows.id) AS count, MAX(money_flows.created_at) AS last_used_at").
+		Where("money_flows.user_id = ?", userID).
+		Group("tag.name").
+		Order("count DESC, tag ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	usage := make([]*domain.TagUsage, len(rows))
+	for i, row := range rows {
+		usage[i] = &domain.TagUsage{
+			Tag:        row.Tag,
+			Count:      row.Count,
+			LastUsedAt: row.LastUsedAt,
+		}
+	}
+
+	return usage, nil
+}
+
+func (r *moneyFlowRepositoryImpl) GetDescriptionCategories(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DescriptionCategory, error) {
+	var rows []struct {
+		Description string
+		Category    string
+		Count       int64
+		LastUsedAt  time.Time
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MoneyFlowModel{}).
+		Select("LOWER(description) AS description, category, COUNT(*) AS count, MAX(created_at) AS last_used_at").
+		Where("user_id = ? AND kind = ? AND category IS NOT NULL AND description IS NOT NULL AND description <> ''",
+			userID, domain.MoneyFlowKindExpense).
+		Group("LOWER(description), category").
+		Order("last_used_at DESC").
+		Limit(limit).
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	history := make([]*domain.DescriptionCategory, len(rows))
+	for i, row := range rows {
+		history[i] = &domain.DescriptionCategory{
+			Description: row.Description,
+			Category:    row.Category,
+			Count:       row.Count,
+			LastUsedAt:  row.LastUsedAt,
+		}
+	}
+
+	return history, nil
+}
+
+func (r *moneyFlowRepositoryImpl) RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Keep the position of the first occurrence of each tag after the rename, and bump
+	// the version so clients holding the old tags get a conflict on update
+	result := db.Exec(`UPDATE money_flows SET
+		tags = (
+			SELECT COALESCE(jsonb_agg(renamed.name ORDER BY renamed.position), '[]'::jsonb)
+			FROM (
+				SELECT CASE WHEN tag.name = ? THEN ? ELSE tag.name END AS name, MIN(tag.position) AS position
+				FROM jsonb_array_elements_text(money_flows.tags) WITH ORDINALITY AS tag(name, position)
+				GROUP BY 1
+			) renamed
+		),
+		version = version + 1,
+		updated_at = NOW()
+	WHERE user_id = ? AND deleted_at IS NULL AND tags @> jsonb_build_array(CAST(? AS text))`,
+		from, to, userID, from)
+
+	return result.RowsAffected(), result.Error()
 }
 
 // Helper methods for conversion between domain and model
@@ -197,14 +729,27 @@ func (r *moneyFlowRepositoryImpl) domainToModel(moneyFlow *domain.MoneyFlow) *Mo
 		tags = JSONB([]string{})
 	}
 
+	kind := moneyFlow.Kind
+	if kind == "" {
+		kind = domain.MoneyFlowKindExpense
+	}
+
 	return &MoneyFlowModel{
 		ID:          moneyFlow.ID,
 		UserID:      moneyFlow.UserID,
+		WalletID:    moneyFlow.WalletID,
+		Kind:        kind,
+		TransferID:  moneyFlow.TransferID,
+		GroupID:     moneyFlow.GroupID,
+		MerchantID:  moneyFlow.MerchantID,
 		Category:    moneyFlow.Category,
-		Amount:      moneyFlow.Amount,
+		Amount:      moneyDecimal(moneyFlow.Amount, moneyFlow.Currency),
 		Currency:    moneyFlow.Currency,
 		Description: moneyFlow.Description,
 		Tags:        tags,
+		Latitude:    moneyFlow.Latitude,
+		Longitude:   moneyFlow.Longitude,
+		PlaceName:   moneyFlow.PlaceName,
 		Version:     moneyFlow.Version,
 		CreatedAt:   moneyFlow.CreatedAt,
 		UpdatedAt:   moneyFlow.UpdatedAt,
@@ -226,11 +771,19 @@ func (r *moneyFlowRepositoryImpl) modelToDomain(model *MoneyFlowModel) *domain.M
 	return &domain.MoneyFlow{
 		ID:          model.ID,
 		UserID:      model.UserID,
+		WalletID:    model.WalletID,
+		Kind:        model.Kind,
+		TransferID:  model.TransferID,
+		GroupID:     model.GroupID,
+		MerchantID:  model.MerchantID,
 		Category:    model.Category,
-		Amount:      model.Amount,
+		Amount:      model.Amount.Minor(model.Currency),
 		Currency:    model.Currency,
 		Description: model.Description,
 		Tags:        tags,
+		Latitude:    model.Latitude,
+		Longitude:   model.Longitude,
+		PlaceName:   model.PlaceName,
 		Version:     model.Version,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
@@ -0,0 +1,97 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type moneyFlowShareRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewMoneyFlowShareRepository creates a new money flow share repository implementation
+func NewMoneyFlowShareRepository(db repository.DB) repository.MoneyFlowShareRepository {
+	return &moneyFlowShareRepositoryImpl{db: db}
+}
+
+func (r *moneyFlowShareRepositoryImpl) Create(ctx context.Context, share *domain.MoneyFlowShare) error {
+	model := r.domainToModel(share)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		return err
+	}
+
+	// Update domain entity with generated values
+	share.ID = model.ID
+	share.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *moneyFlowShareRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.MoneyFlowShare, error) {
+	var model MoneyFlowShareModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *moneyFlowShareRepositoryImpl) DeleteByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("money_flow_id = ?", moneyFlowID).Delete(&MoneyFlowShareModel{})
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (r *moneyFlowShareRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Exec(`DELETE FROM money_flow_shares WHERE id IN (
+		SELECT id FROM money_flow_shares WHERE expires_at < ? LIMIT ?
+	)`, before, limit)
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (r *moneyFlowShareRepositoryImpl) domainToModel(share *domain.MoneyFlowShare) *MoneyFlowShareModel {
+	return &MoneyFlowShareModel{
+		ID:          share.ID,
+		MoneyFlowID: share.MoneyFlowID,
+		UserID:      share.UserID,
+		IncludeNote: share.IncludeNote,
+		ExpiresAt:   share.ExpiresAt,
+		CreatedAt:   share.CreatedAt,
+	}
+}
+
+func (r *moneyFlowShareRepositoryImpl) modelToDomain(model *MoneyFlowShareModel) *domain.MoneyFlowShare {
+	return &domain.MoneyFlowShare{
+		ID:          model.ID,
+		MoneyFlowID: model.MoneyFlowID,
+		UserID:      model.UserID,
+		IncludeNote: model.IncludeNote,
+		ExpiresAt:   model.ExpiresAt,
+		CreatedAt:   model.CreatedAt,
+	}
+}
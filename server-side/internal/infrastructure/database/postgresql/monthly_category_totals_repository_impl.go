@@ -0,0 +1,208 @@
+package postgresql
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// Owners of the rows of monthly_category_totals
+const (
+	totalsOwnerUser  = "user"
+	totalsOwnerGroup = "group"
+)
+
+// monthlyCategoryTotalsRepository reads expense totals from monthly_category_totals,
+// which triggers on money_flows keep per UTC month, so reports over long periods read
+// a few rows per month instead of every money flow. Other methods pass through.
+type monthlyCategoryTotalsRepository struct {
+	repository.MoneyFlowRepository
+	db repository.DB
+}
+
+// NewMonthlyCategoryTotalsRepository wraps a money flow repository with the read model of
+// its expense totals. The parts of a period not covering whole UTC months, and every
+// period when reading the read model fails, are aggregated live by next.
+func NewMonthlyCategoryTotalsRepository(db repository.DB, next repository.MoneyFlowRepository) repository.MoneyFlowRepository {
+	return &monthlyCategoryTotalsRepository{MoneyFlowRepository: next, db: db}
+}
+
+func (r *monthlyCategoryTotalsRepository) GetTotalsByCurrency(ctx context.Context, userID uuid.UUID) ([]*domain.CurrencyTotal, error) {
+	var rows []struct {
+		Currency string
+		Total    Decimal
+		Count    int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MonthlyCategoryTotalModel{}).
+		Select("currency, SUM(total) AS total, SUM(count) AS count").
+		Where("owner_kind = ? AND owner_id = ?", totalsOwnerUser, userID).
+		Group("currency").
+		Order("count DESC, currency ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		logger.FromContext(ctx).Warn("monthly category totals read failed, aggregating live", "error", err)
+		return r.MoneyFlowRepository.GetTotalsByCurrency(ctx, userID)
+	}
+
+	totals := make([]*domain.CurrencyTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.CurrencyTotal{
+			Currency: row.Currency,
+			Total:    row.Total.Minor(row.Currency),
+			Count:    row.Count,
+		}
+	}
+
+	return totals, nil
+}
+
+func (r *monthlyCategoryTotalsRepository) GetTotalsByCategory(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	return r.totalsByCategory(ctx, totalsOwnerUser, userID, start, end, r.MoneyFlowRepository.GetTotalsByCategory)
+}
+
+func (r *monthlyCategoryTotalsRepository) GetGroupTotalsByCategory(ctx context.Context, groupID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error) {
+	return r.totalsByCategory(ctx, totalsOwnerGroup, groupID, start, end, r.MoneyFlowRepository.GetGroupTotalsByCategory)
+}
+
+// totalsByCategory reads the whole UTC months of [start, end) from the read model and
+// aggregates the partial months before and after them with live
+func (r *monthlyCategoryTotalsRepository) totalsByCategory(
+	ctx context.Context,
+	ownerKind string,
+	ownerID uuid.UUID,
+	start, end time.Time,
+	live func(ctx context.Context, ownerID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error),
+) ([]*domain.CategoryTotal, error) {
+	first, last := wholeMonths(start, end)
+	if !first.Before(last) {
+		return live(ctx, ownerID, start, end)
+	}
+
+	stored, err := r.storedTotalsByCategory(ctx, ownerKind, ownerID, first, last)
+	if err != nil {
+		logger.FromContext(ctx).Warn("monthly category totals read failed, aggregating live", "error", err)
+		return live(ctx, ownerID, start, end)
+	}
+
+	parts := [][]*domain.CategoryTotal{stored}
+	if start.Before(first) {
+		head, err := live(ctx, ownerID, start, first)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, head)
+	}
+	if last.Before(end) {
+		tail, err := live(ctx, ownerID, last, end)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, tail)
+	}
+
+	return mergeCategoryTotals(parts...), nil
+}
+
+// storedTotalsByCategory sums the read model's rows of the months in [first, last)
+func (r *monthlyCategoryTotalsRepository) storedTotalsByCategory(ctx context.Context, ownerKind string, ownerID uuid.UUID, first, last time.Time) ([]*domain.CategoryTotal, error) {
+	var rows []struct {
+		Category string
+		Currency string
+		Total    Decimal
+		Count    int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&MonthlyCategoryTotalModel{}).
+		Select("category, currency, SUM(total) AS total, SUM(count) AS count").
+		Where("owner_kind = ? AND owner_id = ? AND month >= ? AND month < ?",
+			ownerKind, ownerID, first.Format(time.DateOnly), last.Format(time.DateOnly)).
+		Group("category, currency").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.CategoryTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.CategoryTotal{
+			Currency: row.Currency,
+			Total:    row.Total.Minor(row.Currency),
+			Count:    row.Count,
+		}
+		// Uncategorized money flows are stored under an empty category
+		if row.Category != "" {
+			totals[i].Category = &rows[i].Category
+		}
+	}
+
+	return totals, nil
+}
+
+// wholeMonths returns the first and the end of the last whole UTC month in [start, end).
+// first is not before last when there is none.
+func wholeMonths(start, end time.Time) (first, last time.Time) {
+	first = monthStart(start)
+	if first.Before(start) {
+		first = first.AddDate(0, 1, 0)
+	}
+	return first, monthStart(end)
+}
+
+// monthStart returns the start of the UTC month of t
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// mergeCategoryTotals adds up the totals of the same category and currency, ordered by
+// category with uncategorized last, then currency, like the live aggregation
+func mergeCategoryTotals(parts ...[]*domain.CategoryTotal) []*domain.CategoryTotal {
+	type key struct {
+		category    string
+		categorized bool
+		currency    string
+	}
+	merged := []*domain.CategoryTotal{}
+	byKey := map[key]*domain.CategoryTotal{}
+	for _, part := range parts {
+		for _, total := range part {
+			k := key{currency: total.Currency}
+			if total.Category != nil {
+				k.category, k.categorized = *total.Category, true
+			}
+			if existing, ok := byKey[k]; ok {
+				existing.Total += total.Total
+				existing.Count += total.Count
+				continue
+			}
+			copied := *total
+			byKey[k] = &copied
+			merged = append(merged, &copied)
+		}
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if (a.Category == nil) != (b.Category == nil) {
+			return b.Category == nil
+		}
+		if a.Category != nil && *a.Category != *b.Category {
+			return *a.Category < *b.Category
+		}
+		return a.Currency < b.Currency
+	})
+
+	return merged
+}
@@ -0,0 +1,107 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type notificationRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewNotificationRepository creates a new notification repository implementation
+func NewNotificationRepository(db repository.DB) repository.NotificationRepository {
+	return &notificationRepositoryImpl{db: db}
+}
+
+func (r *notificationRepositoryImpl) Create(ctx context.Context, notification *domain.Notification) error {
+	model := r.domainToModel(notification)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	notification.ID = model.ID
+	notification.CreatedAt = model.CreatedAt
+	notification.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *notificationRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Notification, error) {
+	var models []NotificationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*domain.Notification, len(models))
+	for i, model := range models {
+		notifications[i] = r.modelToDomain(&model)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepositoryImpl) MarkRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&NotificationModel{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{
+			"read_at":    readAt,
+			"updated_at": readAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *notificationRepositoryImpl) domainToModel(notification *domain.Notification) *NotificationModel {
+	return &NotificationModel{
+		ID:        notification.ID,
+		UserID:    notification.UserID,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+		UpdatedAt: notification.UpdatedAt,
+	}
+}
+
+func (r *notificationRepositoryImpl) modelToDomain(model *NotificationModel) *domain.Notification {
+	return &domain.Notification{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		Title:     model.Title,
+		Body:      model.Body,
+		ReadAt:    model.ReadAt,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
@@ -0,0 +1,122 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type outboxStoreImpl struct {
+	db repository.DB
+}
+
+// NewOutboxStore creates a new PostgreSQL-backed event outbox.
+// Appending inside a transaction commits the event together with the caller's writes.
+func NewOutboxStore(db repository.DB) events.Store {
+	return &outboxStoreImpl{db: db}
+}
+
+func (s *outboxStoreImpl) Append(ctx context.Context, event *events.Event) error {
+	model := s.domainToModel(event)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, s.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	event.ID = model.ID
+	return nil
+}
+
+func (s *outboxStoreImpl) FindUndispatched(ctx context.Context, limit int) ([]*events.Event, error) {
+	var models []OutboxEventModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, s.db)
+
+	res := db.Where("dispatched_at IS NULL").
+		Order("occurred_at ASC").
+		Limit(limit).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*events.Event, len(models))
+	for i, model := range models {
+		result[i] = s.modelToDomain(&model)
+	}
+
+	return result, nil
+}
+
+func (s *outboxStoreImpl) MarkDispatched(ctx context.Context, event *events.Event, dispatchedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, s.db)
+
+	// Only one dispatcher wins the undispatched -> dispatched transition
+	result := db.Model(&OutboxEventModel{}).
+		Where("id = ? AND dispatched_at IS NULL", event.ID).
+		Updates(map[string]interface{}{"dispatched_at": dispatchedAt})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	event.DispatchedAt = &dispatchedAt
+	return nil
+}
+
+func (s *outboxStoreImpl) DeleteDispatched(ctx context.Context, dispatchedBefore time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, s.db)
+
+	result := db.Exec(
+		`DELETE FROM outbox_events WHERE id IN (
+			SELECT id FROM outbox_events WHERE dispatched_at < ? LIMIT ?
+		)`,
+		dispatchedBefore, limit,
+	)
+
+	return result.RowsAffected(), result.Error()
+}
+
+// Helper methods for conversion
+
+func (s *outboxStoreImpl) domainToModel(event *events.Event) *OutboxEventModel {
+	payload := string(event.Payload)
+	if payload == "" {
+		payload = "{}"
+	}
+
+	return &OutboxEventModel{
+		ID:           event.ID,
+		Type:         event.Type,
+		UserID:       event.UserID,
+		Payload:      payload,
+		OccurredAt:   event.OccurredAt,
+		DispatchedAt: event.DispatchedAt,
+	}
+}
+
+func (s *outboxStoreImpl) modelToDomain(model *OutboxEventModel) *events.Event {
+	return &events.Event{
+		ID:           model.ID,
+		Type:         model.Type,
+		UserID:       model.UserID,
+		Payload:      json.RawMessage(model.Payload),
+		OccurredAt:   model.OccurredAt,
+		DispatchedAt: model.DispatchedAt,
+	}
+}
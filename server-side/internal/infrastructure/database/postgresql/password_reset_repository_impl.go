@@ -0,0 +1,174 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type passwordResetRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewPasswordResetRepository creates a new password reset repository implementation
+func NewPasswordResetRepository(db repository.DB) repository.PasswordResetRepository {
+	return &passwordResetRepositoryImpl{db: db}
+}
+
+func (r *passwordResetRepositoryImpl) Create(ctx context.Context, reset *domain.PasswordReset) error {
+	model := r.domainToModel(reset)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		return err
+	}
+
+	// Update domain entity with generated values
+	reset.ID = model.ID
+	reset.CreatedAt = model.CreatedAt
+	reset.UpdatedAt = model.UpdatedAt
+
+	return nil
+}
+
+func (r *passwordResetRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.PasswordReset, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *passwordResetRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordReset, error) {
+	return r.findOne(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *passwordResetRepositoryImpl) CountSince(ctx context.Context, userAuthID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&PasswordResetModel{}).
+		Where("user_auth_id = ? AND created_at >= ?", userAuthID, since).
+		Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *passwordResetRepositoryImpl) MarkSent(ctx context.Context, id uuid.UUID, tokenHash string, sentAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&PasswordResetModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"token_hash": tokenHash,
+			"sent_at":    sentAt,
+			"updated_at": sentAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *passwordResetRepositoryImpl) MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&PasswordResetModel{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"used_at":    usedAt,
+			"updated_at": usedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *passwordResetRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Exec(`DELETE FROM password_resets WHERE id IN (
+		SELECT id FROM password_resets WHERE expires_at < ? LIMIT ?
+	)`, before, limit)
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (r *passwordResetRepositoryImpl) findOne(ctx context.Context, query string, args ...interface{}) (*domain.PasswordReset, error) {
+	var model PasswordResetModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where(query, args...).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *passwordResetRepositoryImpl) domainToModel(reset *domain.PasswordReset) *PasswordResetModel {
+	var tokenHash *string
+	if reset.TokenHash != "" {
+		tokenHash = &reset.TokenHash
+	}
+
+	return &PasswordResetModel{
+		ID:         reset.ID,
+		UserID:     reset.UserID,
+		UserAuthID: reset.UserAuthID,
+		Email:      reset.Email,
+		TokenHash:  tokenHash,
+		SentAt:     reset.SentAt,
+		ExpiresAt:  reset.ExpiresAt,
+		UsedAt:     reset.UsedAt,
+		CreatedAt:  reset.CreatedAt,
+		UpdatedAt:  reset.UpdatedAt,
+	}
+}
+
+func (r *passwordResetRepositoryImpl) modelToDomain(model *PasswordResetModel) *domain.PasswordReset {
+	reset := &domain.PasswordReset{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		UserAuthID: model.UserAuthID,
+		Email:      model.Email,
+		SentAt:     model.SentAt,
+		ExpiresAt:  model.ExpiresAt,
+		UsedAt:     model.UsedAt,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+	if model.TokenHash != nil {
+		reset.TokenHash = *model.TokenHash
+	}
+	return reset
+}
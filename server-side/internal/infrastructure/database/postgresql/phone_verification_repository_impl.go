@@ -0,0 +1,146 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type phoneVerificationRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewPhoneVerificationRepository creates a new phone verification repository implementation
+func NewPhoneVerificationRepository(db repository.DB) repository.PhoneVerificationRepository {
+	return &phoneVerificationRepositoryImpl{db: db}
+}
+
+func (r *phoneVerificationRepositoryImpl) Create(ctx context.Context, verification *domain.PhoneVerification) error {
+	model := r.domainToModel(verification)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		return err
+	}
+
+	// Update domain entity with generated values
+	verification.ID = model.ID
+	verification.CreatedAt = model.CreatedAt
+
+	return nil
+}
+
+func (r *phoneVerificationRepositoryImpl) FindLatest(ctx context.Context, userID uuid.UUID, phoneNumber string) (*domain.PhoneVerification, error) {
+	var model PhoneVerificationModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ? AND phone_number = ?", userID, phoneNumber).
+		Order("created_at DESC").
+		First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *phoneVerificationRepositoryImpl) CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&PhoneVerificationModel{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *phoneVerificationRepositoryImpl) IncrementAttempts(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&PhoneVerificationModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts": gorm.Expr("attempts + 1"),
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *phoneVerificationRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("id = ?", id).Delete(&PhoneVerificationModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *phoneVerificationRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Exec(`DELETE FROM phone_verifications WHERE id IN (
+		SELECT id FROM phone_verifications WHERE expires_at < ? LIMIT ?
+	)`, before, limit)
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (r *phoneVerificationRepositoryImpl) domainToModel(verification *domain.PhoneVerification) *PhoneVerificationModel {
+	return &PhoneVerificationModel{
+		ID:          verification.ID,
+		UserID:      verification.UserID,
+		PhoneNumber: verification.PhoneNumber,
+		CodeHash:    verification.CodeHash,
+		Attempts:    verification.Attempts,
+		ExpiresAt:   verification.ExpiresAt,
+		CreatedAt:   verification.CreatedAt,
+	}
+}
+
+func (r *phoneVerificationRepositoryImpl) modelToDomain(model *PhoneVerificationModel) *domain.PhoneVerification {
+	return &domain.PhoneVerification{
+		ID:          model.ID,
+		UserID:      model.UserID,
+		PhoneNumber: model.PhoneNumber,
+		CodeHash:    model.CodeHash,
+		Attempts:    model.Attempts,
+		ExpiresAt:   model.ExpiresAt,
+		CreatedAt:   model.CreatedAt,
+	}
+}
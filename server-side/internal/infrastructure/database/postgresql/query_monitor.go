@@ -0,0 +1,166 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/metrics"
+	"gorm.io/gorm"
+)
+
+const queryMonitorCallbackName = "catetin:query_monitor"
+
+// queryLatencyBuckets are histogram bucket upper bounds, in seconds, suited to queries
+var queryLatencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// sqlStateQueryCanceled is the SQLSTATE of statements cancelled by statement_timeout
+const sqlStateQueryCanceled = "57014"
+
+// wrapperFiles are the files of this package that sit between repositories and GORM,
+// skipped when looking for the caller of a query
+var wrapperFiles = map[string]bool{
+	"gorm_wrapper.go":  true,
+	"counting_db.go":   true,
+	"replica_db.go":    true,
+	"query_monitor.go": true,
+	"tracing.go":       true,
+}
+
+// QueryMonitor is a GORM plugin that times every query. Queries slower than the
+// threshold are logged with their parameterized SQL and the repository code that ran
+// them; counts, durations, slow queries, and timeouts go to the metrics registry.
+type QueryMonitor struct {
+	threshold time.Duration
+
+	// Metrics, nil when metrics are disabled
+	queries  *metrics.CounterVec
+	duration *metrics.HistogramVec
+	slow     *metrics.CounterVec
+	timeouts *metrics.CounterVec
+}
+
+// NewQueryMonitor creates a query monitor logging queries slower than threshold, 0 to
+// log none. Metrics are registered on registry unless it is nil. Register the monitor
+// with db.Use after opening each connection; one monitor may watch several.
+func NewQueryMonitor(threshold time.Duration, registry *metrics.Registry) *QueryMonitor {
+	m := &QueryMonitor{threshold: threshold}
+	if registry != nil {
+		m.queries = registry.NewCounterVec("catetin_db_queries_total",
+			"Database queries executed, by operation.",
+			"operation")
+		m.duration = registry.NewHistogramVec("catetin_db_query_duration_seconds",
+			"Duration of database queries, by operation.",
+			queryLatencyBuckets, "operation")
+		m.slow = registry.NewCounterVec("catetin_db_slow_queries_total",
+			"Database queries slower than the slow query threshold, by operation and table.",
+			"operation", "table")
+		m.timeouts = registry.NewCounterVec("catetin_db_query_timeouts_total",
+			"Database queries cancelled by the statement timeout or the request deadline, by operation.",
+			"operation")
+	}
+	return m
+}
+
+// Name implements gorm.Plugin
+func (m *QueryMonitor) Name() string {
+	return "query-monitor"
+}
+
+// Initialize implements gorm.Plugin by hooking around each GORM callback chain
+func (m *QueryMonitor) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register(queryMonitorCallbackName+":before_create", m.before),
+		callbacks.Create().After("gorm:create").Register(queryMonitorCallbackName+":after_create", m.after("create")),
+		callbacks.Query().Before("gorm:query").Register(queryMonitorCallbackName+":before_query", m.before),
+		callbacks.Query().After("gorm:query").Register(queryMonitorCallbackName+":after_query", m.after("select")),
+		callbacks.Update().Before("gorm:update").Register(queryMonitorCallbackName+":before_update", m.before),
+		callbacks.Update().After("gorm:update").Register(queryMonitorCallbackName+":after_update", m.after("update")),
+		callbacks.Delete().Before("gorm:delete").Register(queryMonitorCallbackName+":before_delete", m.before),
+		callbacks.Delete().After("gorm:delete").Register(queryMonitorCallbackName+":after_delete", m.after("delete")),
+		callbacks.Row().Before("gorm:row").Register(queryMonitorCallbackName+":before_row", m.before),
+		callbacks.Row().After("gorm:row").Register(queryMonitorCallbackName+":after_row", m.after("row")),
+		callbacks.Raw().Before("gorm:raw").Register(queryMonitorCallbackName+":before_raw", m.before),
+		callbacks.Raw().After("gorm:raw").Register(queryMonitorCallbackName+":after_raw", m.after("raw")),
+	)
+}
+
+func (m *QueryMonitor) before(db *gorm.DB) {
+	db.InstanceSet(queryMonitorCallbackName, time.Now())
+}
+
+// after records the query. Row queries are timed until their first row arrives,
+// since the rows are read after the callback returns.
+func (m *QueryMonitor) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryMonitorCallbackName)
+		if !ok {
+			return
+		}
+		elapsed := time.Since(value.(time.Time))
+
+		if m.queries != nil {
+			m.queries.Inc(operation)
+			m.duration.Observe(elapsed.Seconds(), operation)
+		}
+		if isQueryTimeout(db.Statement.Context, db.Error) && m.timeouts != nil {
+			m.timeouts.Inc(operation)
+		}
+
+		if m.threshold <= 0 || elapsed < m.threshold {
+			return
+		}
+		if m.slow != nil {
+			m.slow.Inc(operation, db.Statement.Table)
+		}
+
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		// Only the parameterized SQL is logged; bound values may hold personal data
+		logger.FromContext(ctx).Warn("slow database query",
+			"operation", operation,
+			"table", db.Statement.Table,
+			"duration_ms", elapsed.Milliseconds(),
+			"rows", db.Statement.RowsAffected,
+			"sql", db.Statement.SQL.String(),
+			"caller", queryCaller(),
+		)
+	}
+}
+
+// isQueryTimeout reports whether a query failed on the statement timeout or on the
+// deadline of its context
+func isQueryTimeout(ctx context.Context, err error) bool {
+	if err == nil {
+		return false
+	}
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) && pgErr.SQLState() == sqlStateQueryCanceled {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded) || (ctx != nil && errors.Is(ctx.Err(), context.DeadlineExceeded))
+}
+
+// queryCaller returns the file and line of the code that ran the query, the first
+// frame outside GORM and the wrappers of this package, usually a repository method
+func queryCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "gorm.io/") && !wrapperFiles[filepath.Base(frame.File)] {
+			return filepath.Base(filepath.Dir(frame.File)) + "/" + filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository implementation
+func NewRefreshTokenRepository(db repository.DB) repository.RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{db: db}
+}
+
+func (r *refreshTokenRepositoryImpl) Create(ctx context.Context, token *repository.RefreshToken) error {
+	model := r.domainToModel(token)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	token.ID = model.ID
+	token.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *refreshTokenRepositoryImpl) FindByHash(ctx context.Context, tokenHash string) (*repository.RefreshToken, error) {
+	var model RefreshTokenModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("token_hash = ?", tokenHash).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *refreshTokenRepositoryImpl) List(ctx context.Context, filter repository.RefreshTokenFilter, page repository.Page) ([]*repository.RefreshToken, error) {
+	var models []RefreshTokenModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := r.applyFilter(db, filter).
+		Order("created_at DESC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*repository.RefreshToken, len(models))
+	for i, model := range models {
+		tokens[i] = r.modelToDomain(&model)
+	}
+
+	return tokens, nil
+}
+
+func (r *refreshTokenRepositoryImpl) Count(ctx context.Context, filter repository.RefreshTokenFilter) (int64, error) {
+	var count int64
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := r.applyFilter(db.Model(&RefreshTokenModel{}), filter).
+		Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// applyFilter restricts a query to the refresh tokens matching the filter
+func (r *refreshTokenRepositoryImpl) applyFilter(db repository.DB, filter repository.RefreshTokenFilter) repository.DB {
+	db = db.Where("user_id = ?", filter.UserID)
+	switch filter.Status {
+	case repository.RefreshTokenActive:
+		db = db.Where("revoked_at IS NULL AND expires_at > ?", filter.At)
+	case repository.RefreshTokenRevoked:
+		db = db.Where("revoked_at IS NOT NULL")
+	case repository.RefreshTokenExpired:
+		db = db.Where("revoked_at IS NULL AND expires_at <= ?", filter.At)
+	}
+	return db
+}
+
+func (r *refreshTokenRepositoryImpl) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&RefreshTokenModel{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&RefreshTokenModel{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+
+	return result.Error()
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, revokedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&RefreshTokenModel{}).
+		Where("user_id = ? AND session_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, sessionID, revokedAt).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepositoryImpl) RevokeOtherSessions(ctx context.Context, userID, keepSessionID uuid.UUID, revokedAt time.Time) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&RefreshTokenModel{}).
+		Where("user_id = ? AND session_id <> ? AND revoked_at IS NULL AND expires_at > ?", userID, keepSessionID, revokedAt).
+		Updates(map[string]interface{}{
+			"revoked_at": revokedAt,
+			"updated_at": revokedAt,
+		})
+
+	return result.RowsAffected(), result.Error()
+}
+
+func (r *refreshTokenRepositoryImpl) DeleteInactive(ctx context.Context, before time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Exec(`DELETE FROM refresh_tokens WHERE id IN (
+		SELECT id FROM refresh_tokens WHERE expires_at < ? OR revoked_at < ? LIMIT ?
+	)`, before, before, limit)
+
+	return result.RowsAffected(), result.Error()
+}
+
+// Helper methods for conversion
+
+func (r *refreshTokenRepositoryImpl) domainToModel(token *repository.RefreshToken) *RefreshTokenModel {
+	return &RefreshTokenModel{
+		ID:         token.ID,
+		UserID:     token.UserID,
+		SessionID:  token.SessionID,
+		TokenHash:  token.TokenHash,
+		ExpiresAt:  token.ExpiresAt,
+		RevokedAt:  token.RevokedAt,
+		UserAgent:  token.UserAgent,
+		IPAddress:  token.IPAddress,
+		SignedInAt: token.SignedInAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func (r *refreshTokenRepositoryImpl) modelToDomain(model *RefreshTokenModel) *repository.RefreshToken {
+	return &repository.RefreshToken{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		SessionID:  model.SessionID,
+		TokenHash:  model.TokenHash,
+		ExpiresAt:  model.ExpiresAt,
+		RevokedAt:  model.RevokedAt,
+		UserAgent:  model.UserAgent,
+		IPAddress:  model.IPAddress,
+		SignedInAt: model.SignedInAt,
+		CreatedAt:  model.CreatedAt,
+	}
+}
@@ -0,0 +1,145 @@
+package postgresql
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// resolverDB is a repository.DB that sends the queries of contexts marked with
+// repository.PreferReplica to read replicas, round robin, and everything else to the
+// primary. Transactions always run on the primary.
+type resolverDB struct {
+	repository.DB // primary
+	replicas      []repository.DB
+	next          *atomic.Uint64
+}
+
+// NewResolverDB wraps the primary database and its read replicas into one
+// repository.DB. Without replicas it returns the primary as is.
+func NewResolverDB(primary repository.DB, replicas ...repository.DB) repository.DB {
+	if len(replicas) == 0 {
+		return primary
+	}
+	return &resolverDB{DB: primary, replicas: replicas, next: &atomic.Uint64{}}
+}
+
+func (r *resolverDB) WithContext(ctx context.Context) repository.DB {
+	primary := r.DB.WithContext(ctx)
+	if !repository.PrefersReplica(ctx) || repository.GetTransactionFromContext(ctx) != nil {
+		return primary
+	}
+
+	replica := r.replicas[r.next.Add(1)%uint64(len(r.replicas))]
+	return &replicaDB{primary: primary, replica: replica.WithContext(ctx)}
+}
+
+// replicaDB builds each query on both the primary and a replica, then runs reads on
+// the replica and writes on the primary, so a write made with a context marked with
+// repository.PreferReplica still succeeds
+type replicaDB struct {
+	primary repository.DB
+	replica repository.DB
+}
+
+func (r *replicaDB) WithContext(ctx context.Context) repository.DB {
+	return &replicaDB{primary: r.primary.WithContext(ctx), replica: r.replica.WithContext(ctx)}
+}
+
+func (r *replicaDB) Create(value interface{}) repository.Result {
+	return r.primary.Create(value)
+}
+
+func (r *replicaDB) CreateInBatches(value interface{}, batchSize int) repository.Result {
+	return r.primary.CreateInBatches(value, batchSize)
+}
+
+func (r *replicaDB) Where(query interface{}, args ...interface{}) repository.DB {
+	return &replicaDB{primary: r.primary.Where(query, args...), replica: r.replica.Where(query, args...)}
+}
+
+func (r *replicaDB) First(dest interface{}) repository.Result {
+	return r.replica.First(dest)
+}
+
+func (r *replicaDB) Limit(limit int) repository.DB {
+	return &replicaDB{primary: r.primary.Limit(limit), replica: r.replica.Limit(limit)}
+}
+
+func (r *replicaDB) Offset(offset int) repository.DB {
+	return &replicaDB{primary: r.primary.Offset(offset), replica: r.replica.Offset(offset)}
+}
+
+func (r *replicaDB) Order(value interface{}) repository.DB {
+	return &replicaDB{primary: r.primary.Order(value), replica: r.replica.Order(value)}
+}
+
+func (r *replicaDB) Group(name string) repository.DB {
+	return &replicaDB{primary: r.primary.Group(name), replica: r.replica.Group(name)}
+}
+
+func (r *replicaDB) Having(query interface{}, args ...interface{}) repository.DB {
+	return &replicaDB{primary: r.primary.Having(query, args...), replica: r.replica.Having(query, args...)}
+}
+
+func (r *replicaDB) Joins(query string, args ...interface{}) repository.DB {
+	return &replicaDB{primary: r.primary.Joins(query, args...), replica: r.replica.Joins(query, args...)}
+}
+
+func (r *replicaDB) Unscoped() repository.DB {
+	return &replicaDB{primary: r.primary.Unscoped(), replica: r.replica.Unscoped()}
+}
+
+func (r *replicaDB) Preload(query string, args ...interface{}) repository.DB {
+	return &replicaDB{primary: r.primary.Preload(query, args...), replica: r.replica.Preload(query, args...)}
+}
+
+func (r *replicaDB) Find(dest interface{}) repository.Result {
+	return r.replica.Find(dest)
+}
+
+func (r *replicaDB) Model(value interface{}) repository.DB {
+	return &replicaDB{primary: r.primary.Model(value), replica: r.replica.Model(value)}
+}
+
+func (r *replicaDB) Select(query interface{}) repository.DB {
+	return &replicaDB{primary: r.primary.Select(query), replica: r.replica.Select(query)}
+}
+
+func (r *replicaDB) Scan(dest interface{}) repository.Result {
+	return r.replica.Scan(dest)
+}
+
+func (r *replicaDB) Count(count *int64) repository.Result {
+	return r.replica.Count(count)
+}
+
+func (r *replicaDB) Updates(values interface{}) repository.Result {
+	return r.primary.Updates(values)
+}
+
+func (r *replicaDB) Delete(value interface{}, conds ...interface{}) repository.Result {
+	return r.primary.Delete(value, conds...)
+}
+
+// Exec runs raw SQL, which may write, on the primary
+func (r *replicaDB) Exec(sql string, values ...interface{}) repository.Result {
+	return r.primary.Exec(sql, values...)
+}
+
+func (r *replicaDB) Transaction(fn func(tx repository.DB) error) error {
+	return r.primary.Transaction(fn)
+}
+
+func (r *replicaDB) Begin() (repository.DB, error) {
+	return r.primary.Begin()
+}
+
+func (r *replicaDB) Commit() error {
+	return r.primary.Commit()
+}
+
+func (r *replicaDB) Rollback() error {
+	return r.primary.Rollback()
+}
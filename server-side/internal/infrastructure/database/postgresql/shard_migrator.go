@@ -0,0 +1,115 @@
+package postgresql
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+)
+
+// PrimaryShardName is the name of the shard backed by the primary DB_* configuration
+const PrimaryShardName = "primary"
+
+// Shard is a database that receives the same schema migrations as every other shard
+type Shard struct {
+	Name string
+	URL  string
+}
+
+// ShardVersion reports the migration state of a single shard
+type ShardVersion struct {
+	Shard   string
+	Version uint
+	Dirty   bool
+	Err     error
+}
+
+// ShardMigrator applies migrations across every configured shard.
+// Shards are processed in order and the run stops at the first failure so
+// the remaining shards are never migrated past a broken one.
+type ShardMigrator struct {
+	shards     []Shard
+	migrations fs.FS
+}
+
+// NewShardMigrator creates a new shard migrator
+func NewShardMigrator(shards []Shard, migrations fs.FS) *ShardMigrator {
+	return &ShardMigrator{
+		shards:     shards,
+		migrations: migrations,
+	}
+}
+
+// Shards returns the shards handled by the migrator
+func (sm *ShardMigrator) Shards() []Shard {
+	return sm.shards
+}
+
+// Only returns a migrator restricted to the named shard
+func (sm *ShardMigrator) Only(name string) (*ShardMigrator, error) {
+	for _, shard := range sm.shards {
+		if shard.Name == name {
+			return NewShardMigrator([]Shard{shard}, sm.migrations), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown shard %q", name)
+}
+
+// Up applies all pending migrations to every shard
+func (sm *ShardMigrator) Up() error {
+	for _, shard := range sm.shards {
+		slog.Info("Applying migrations", "shard", shard.Name)
+		if err := RunMigrations(shard.URL, sm.migrations); err != nil {
+			return fmt.Errorf("shard %s: %w", shard.Name, err)
+		}
+	}
+	return nil
+}
+
+// Down rolls back the given number of migrations on every shard
+func (sm *ShardMigrator) Down(steps int) error {
+	for _, shard := range sm.shards {
+		slog.Info("Rolling back migrations", "shard", shard.Name)
+		if err := RollbackMigration(shard.URL, sm.migrations, steps); err != nil {
+			return fmt.Errorf("shard %s: %w", shard.Name, err)
+		}
+	}
+	return nil
+}
+
+// Goto migrates every shard up or down to the given version
+func (sm *ShardMigrator) Goto(version uint) error {
+	for _, shard := range sm.shards {
+		slog.Info("Migrating to version", "shard", shard.Name)
+		if err := MigrateTo(shard.URL, sm.migrations, version); err != nil {
+			return fmt.Errorf("shard %s: %w", shard.Name, err)
+		}
+	}
+	return nil
+}
+
+// Force forces the migration version on every shard (use with caution)
+func (sm *ShardMigrator) Force(version int) error {
+	for _, shard := range sm.shards {
+		slog.Warn("Forcing migration version", "shard", shard.Name)
+		if err := ForceMigrationVersion(shard.URL, sm.migrations, version); err != nil {
+			return fmt.Errorf("shard %s: %w", shard.Name, err)
+		}
+	}
+	return nil
+}
+
+// Versions reports the migration version of every shard.
+// Errors are collected per shard so one unreachable shard does not hide the others.
+func (sm *ShardMigrator) Versions() []ShardVersion {
+	versions := make([]ShardVersion, 0, len(sm.shards))
+	for _, shard := range sm.shards {
+		version, dirty, err := MigrationVersion(shard.URL, sm.migrations)
+		versions = append(versions, ShardVersion{
+			Shard:   shard.Name,
+			Version: version,
+			Dirty:   dirty,
+			Err:     err,
+		})
+	}
+	return versions
+}
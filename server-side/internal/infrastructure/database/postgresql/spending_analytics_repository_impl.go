@@ -0,0 +1,115 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type spendingAnalyticsRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewSpendingAnalyticsRepository creates a new spending analytics repository implementation
+func NewSpendingAnalyticsRepository(db repository.DB) repository.SpendingAnalyticsRepository {
+	return &spendingAnalyticsRepositoryImpl{db: db}
+}
+
+// periodJoin computes the local start of each money flow's period. date_trunc starts
+// weeks on Monday, so times are shifted forward by the days from the week start to
+// Monday before truncating, and back after.
+const periodJoin = `CROSS JOIN LATERAL (
+  SELECT date_trunc(?, (money_flows.created_at AT TIME ZONE ?) + make_interval(days => ?)) - make_interval(days => ?) AS period_start
+) AS periods`
+
+func (r *spendingAnalyticsRepositoryImpl) GetTotalsByPeriod(ctx context.Context, query repository.SpendingQuery) ([]*domain.PeriodTotal, error) {
+	var rows []struct {
+		PeriodStart time.Time
+		Currency    string
+		Total       Decimal
+		Count       int64
+	}
+
+	shift := 0
+	if query.Interval == domain.TrendWeekly {
+		shift = (int(time.Monday) - int(query.WeekStart) + 7) % 7
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	owner := db.Model(&MoneyFlowModel{}).Where("user_id = ?", query.UserID)
+	if query.GroupID != nil {
+		owner = db.Model(&MoneyFlowModel{}).Where("group_id = ?", *query.GroupID)
+	}
+
+	res := owner.
+		Joins(periodJoin, query.Interval, query.Timezone, shift, shift).
+		Select("periods.period_start, currency, COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where("kind = ? AND created_at >= ? AND created_at < ?", domain.MoneyFlowKindExpense, query.Start, query.End).
+		Group("periods.period_start, currency").
+		Order("periods.period_start ASC, currency ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.PeriodTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.PeriodTotal{
+			PeriodStart: row.PeriodStart,
+			Currency:    row.Currency,
+			Total:       row.Total.Minor(row.Currency),
+			Count:       row.Count,
+		}
+	}
+
+	return totals, nil
+}
+
+func (r *spendingAnalyticsRepositoryImpl) GetTotalsByPlace(ctx context.Context, query repository.PlaceQuery) ([]*domain.PlaceTotal, error) {
+	var rows []struct {
+		PlaceName *string
+		Latitude  float64
+		Longitude float64
+		Currency  string
+		Total     Decimal
+		Count     int64
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	located := db.Model(&MoneyFlowModel{}).
+		Where("user_id = ? AND kind = ? AND latitude IS NOT NULL", query.UserID, domain.MoneyFlowKindExpense)
+	if box := query.Box; box != nil {
+		// Matches the expression of idx_money_flows_location, so the GiST index is used
+		located = located.Where("point(longitude, latitude) <@ box(point(?, ?), point(?, ?))",
+			box.MinLongitude, box.MinLatitude, box.MaxLongitude, box.MaxLatitude)
+	}
+
+	res := located.
+		Select("place_name, latitude, longitude, currency, COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", query.Start, query.End).
+		Group("place_name, latitude, longitude, currency").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.PlaceTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.PlaceTotal{
+			PlaceName: row.PlaceName,
+			Latitude:  row.Latitude,
+			Longitude: row.Longitude,
+			Currency:  row.Currency,
+			Total:     row.Total.Minor(row.Currency),
+			Count:     row.Count,
+		}
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,191 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type splitRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewSplitRepository creates a new split repository implementation
+func NewSplitRepository(db repository.DB) repository.SplitRepository {
+	return &splitRepositoryImpl{db: db}
+}
+
+func (r *splitRepositoryImpl) ReplaceForMoneyFlow(ctx context.Context, moneyFlowID uuid.UUID, splits []*domain.Split) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Where("money_flow_id = ?", moneyFlowID).Delete(&SplitModel{}).Error(); err != nil {
+		return err
+	}
+	if len(splits) == 0 {
+		return nil
+	}
+
+	models := make([]SplitModel, len(splits))
+	for i, split := range splits {
+		models[i] = SplitModel{
+			ID:          split.ID,
+			MoneyFlowID: split.MoneyFlowID,
+			GroupID:     split.GroupID,
+			UserID:      split.UserID,
+			Method:      split.Method,
+			Amount:      moneyDecimal(split.Amount, split.Currency),
+			Currency:    split.Currency,
+			CreatedAt:   split.CreatedAt,
+		}
+	}
+
+	return db.CreateInBatches(&models, len(models)).Error()
+}
+
+func (r *splitRepositoryImpl) FindByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) ([]*domain.Split, error) {
+	var models []SplitModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("money_flow_id = ?", moneyFlowID).
+		Order("created_at ASC, id ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	splits := make([]*domain.Split, len(models))
+	for i, model := range models {
+		splits[i] = r.splitToDomain(&model)
+	}
+
+	return splits, nil
+}
+
+func (r *splitRepositoryImpl) FindByGroupID(ctx context.Context, groupID uuid.UUID) ([]*domain.Split, error) {
+	var rows []struct {
+		SplitModel
+		PayerID uuid.UUID
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Splits of money flows deleted or moved out of the group no longer count
+	res := db.Model(&SplitModel{}).
+		Select("money_flow_splits.*, money_flows.user_id AS payer_id").
+		Joins("JOIN money_flows ON money_flows.id = money_flow_splits.money_flow_id "+
+			"AND money_flows.group_id = money_flow_splits.group_id AND money_flows.deleted_at IS NULL").
+		Where("money_flow_splits.group_id = ?", groupID).
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	splits := make([]*domain.Split, len(rows))
+	for i, row := range rows {
+		splits[i] = r.splitToDomain(&row.SplitModel)
+		splits[i].PayerID = row.PayerID
+	}
+
+	return splits, nil
+}
+
+func (r *splitRepositoryImpl) DeleteByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Where("money_flow_id = ?", moneyFlowID).Delete(&SplitModel{}).Error()
+}
+
+func (r *splitRepositoryImpl) CreateSettlement(ctx context.Context, settlement *domain.Settlement) error {
+	model := &SettlementModel{
+		ID:         settlement.ID,
+		GroupID:    settlement.GroupID,
+		FromUserID: settlement.FromUserID,
+		ToUserID:   settlement.ToUserID,
+		Amount:     moneyDecimal(settlement.Amount, settlement.Currency),
+		Currency:   settlement.Currency,
+		CreatedBy:  settlement.CreatedBy,
+		CreatedAt:  settlement.CreatedAt,
+	}
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	if err := db.Create(model).Error(); err != nil {
+		return err
+	}
+
+	settlement.ID = model.ID
+	settlement.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *splitRepositoryImpl) FindSettlementsByGroupID(ctx context.Context, groupID uuid.UUID, limit, offset int) ([]*domain.Settlement, error) {
+	var models []SettlementModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("group_id = ?", groupID).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	return r.settlementsToDomain(models), nil
+}
+
+func (r *splitRepositoryImpl) FindAllSettlementsByGroupID(ctx context.Context, groupID uuid.UUID) ([]*domain.Settlement, error) {
+	var models []SettlementModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("group_id = ?", groupID).Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	return r.settlementsToDomain(models), nil
+}
+
+// Helper methods for conversion
+
+func (r *splitRepositoryImpl) splitToDomain(model *SplitModel) *domain.Split {
+	return &domain.Split{
+		ID:          model.ID,
+		MoneyFlowID: model.MoneyFlowID,
+		GroupID:     model.GroupID,
+		UserID:      model.UserID,
+		Method:      model.Method,
+		Amount:      model.Amount.Minor(model.Currency),
+		Currency:    model.Currency,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+func (r *splitRepositoryImpl) settlementsToDomain(models []SettlementModel) []*domain.Settlement {
+	settlements := make([]*domain.Settlement, len(models))
+	for i, model := range models {
+		settlements[i] = &domain.Settlement{
+			ID:         model.ID,
+			GroupID:    model.GroupID,
+			FromUserID: model.FromUserID,
+			ToUserID:   model.ToUserID,
+			Amount:     model.Amount.Minor(model.Currency),
+			Currency:   model.Currency,
+			CreatedBy:  model.CreatedBy,
+			CreatedAt:  model.CreatedAt,
+		}
+	}
+	return settlements
+}
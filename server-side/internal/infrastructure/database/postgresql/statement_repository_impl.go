@@ -0,0 +1,62 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type statementRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewStatementRepository creates a new statement repository implementation
+func NewStatementRepository(db repository.DB) repository.StatementRepository {
+	return &statementRepositoryImpl{db: db}
+}
+
+// claimStatementSQL inserts nothing when the statement of the month was already claimed
+const claimStatementSQL = `
+INSERT INTO statements (user_id, period_start, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (user_id, period_start) DO NOTHING`
+
+func (r *statementRepositoryImpl) Claim(ctx context.Context, userID uuid.UUID, periodStart time.Time) (bool, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Exec(claimStatementSQL, userID, periodStart, time.Now())
+	if err := res.Error(); err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+func (r *statementRepositoryImpl) ListSubscribers(ctx context.Context, afterUserID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	var rows []struct {
+		UserID uuid.UUID
+	}
+	err := db.Model(&UserSettingsModel{}).
+		Select("user_settings.user_id").
+		Joins("JOIN users ON users.id = user_settings.user_id").
+		Where("user_settings.monthly_statement = ? AND user_settings.user_id > ?", true, afterUserID).
+		Where("users.deleted_at IS NULL AND users.disabled_at IS NULL AND users.demo_expires_at IS NULL").
+		Order("user_settings.user_id").
+		Limit(limit).
+		Scan(&rows).Error()
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		userIDs[i] = row.UserID
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,75 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type systemSettingRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewSystemSettingRepository creates a new system setting repository implementation
+func NewSystemSettingRepository(db repository.DB) repository.SystemSettingRepository {
+	return &systemSettingRepositoryImpl{db: db}
+}
+
+func (r *systemSettingRepositoryImpl) FindByKey(ctx context.Context, key string) (*domain.SystemSetting, error) {
+	var model SystemSettingModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("key = ?", key).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &domain.SystemSetting{
+		Key:       model.Key,
+		Value:     model.Value,
+		UpdatedBy: model.UpdatedBy,
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
+
+func (r *systemSettingRepositoryImpl) Save(ctx context.Context, setting *domain.SystemSetting) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&SystemSettingModel{}).
+		Where("key = ?", setting.Key).
+		Updates(map[string]interface{}{
+			"value":      setting.Value,
+			"updated_by": setting.UpdatedBy,
+			"updated_at": setting.UpdatedAt,
+		})
+	if err := res.Error(); err != nil {
+		return err
+	}
+	if res.RowsAffected() > 0 {
+		return nil
+	}
+
+	res = db.Create(&SystemSettingModel{
+		Key:       setting.Key,
+		Value:     setting.Value,
+		UpdatedBy: setting.UpdatedBy,
+		UpdatedAt: setting.UpdatedAt,
+	})
+	if err := res.Error(); err != nil {
+		// Another instance saved the setting first
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
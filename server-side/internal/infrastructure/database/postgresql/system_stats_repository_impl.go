@@ -0,0 +1,56 @@
+package postgresql
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type systemStatsRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewSystemStatsRepository creates a new system stats repository implementation
+func NewSystemStatsRepository(db repository.DB) repository.SystemStatsRepository {
+	return &systemStatsRepositoryImpl{db: db}
+}
+
+func (r *systemStatsRepositoryImpl) Get(ctx context.Context, since time.Time) (*domain.SystemStats, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Demo users are throwaway sandboxes and would skew every count
+	users := func() repository.DB {
+		return db.Model(&UserModel{}).Where("demo_expires_at IS NULL")
+	}
+	moneyFlows := func() repository.DB {
+		return db.Model(&MoneyFlowModel{}).
+			Where("user_id IN (SELECT id FROM users WHERE demo_expires_at IS NULL)")
+	}
+
+	stats := &domain.SystemStats{Since: since}
+	counts := []struct {
+		query  repository.DB
+		column string
+		dest   *int64
+	}{
+		{users(), "COUNT(*)", &stats.Users},
+		{users().Where("created_at >= ?", since), "COUNT(*)", &stats.NewUsers},
+		{users().Where("disabled_at IS NOT NULL"), "COUNT(*)", &stats.DisabledUsers},
+		{users().Where("role <> ?", domain.RoleUser), "COUNT(*)", &stats.StaffUsers},
+		{moneyFlows(), "COUNT(*)", &stats.MoneyFlows},
+		{moneyFlows().Where("created_at >= ?", since), "COUNT(*)", &stats.NewMoneyFlows},
+		{moneyFlows().Where("created_at >= ?", since), "COUNT(DISTINCT user_id)", &stats.ActiveUsers},
+		{db.Model(&WalletModel{}), "COUNT(*)", &stats.Wallets},
+		{db.Model(&GroupModel{}), "COUNT(*)", &stats.Groups},
+	}
+	for _, count := range counts {
+		if err := count.query.Select(count.column).Scan(count.dest).Error(); err != nil {
+			return nil, err
+		}
+	}
+
+	return stats, nil
+}
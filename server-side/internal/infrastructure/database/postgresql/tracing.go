@@ -0,0 +1,13 @@
+package postgresql
+
+import (
+	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+)
+
+// NewTracingPlugin creates the OpenTelemetry GORM plugin, which wraps every query in
+// a client span. Spans record the parameterized SQL only; bound values are never
+// exported. Register it with db.Use after opening the connection.
+func NewTracingPlugin() gorm.Plugin {
+	return gormtracing.NewPlugin(gormtracing.WithoutQueryVariables(), gormtracing.WithoutMetrics())
+}
@@ -21,6 +21,12 @@ func NewTransactionManager(db *gorm.DB) repository.TransactionManager {
 	return &transactionManager{db: NewDB(db)}
 }
 
+// NewTransactionManagerFromDB creates a transaction manager on top of an existing
+// repository.DB, e.g. one wrapped with NewCountingDB.
+func NewTransactionManagerFromDB(db repository.DB) repository.TransactionManager {
+	return &transactionManager{db: db}
+}
+
 // WithTransaction executes a function within a database transaction
 func (tm *transactionManager) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
 	// If already in a transaction, just execute the function
@@ -28,11 +34,20 @@ func (tm *transactionManager) WithTransaction(ctx context.Context, fn func(txCtx
 		return fn(ctx)
 	}
 
-	return tm.db.Transaction(func(tx repository.DB) error {
+	// The transaction is bound to the context, so it is rolled back when the context
+	// is cancelled or its deadline passes
+	var txCtx context.Context
+	err := tm.db.WithContext(ctx).Transaction(func(tx repository.DB) error {
 		// Create new context with transaction
-		txCtx := repository.SetTransactionInContext(ctx, tx)
+		txCtx = repository.WithAfterCommit(repository.SetTransactionInContext(ctx, tx))
 		return fn(txCtx)
 	})
+	if err != nil {
+		return err
+	}
+
+	repository.RunAfterCommit(txCtx)
+	return nil
 }
 
 // BeginTransaction starts a new transaction
@@ -41,12 +56,12 @@ func (tm *transactionManager) BeginTransaction(ctx context.Context) (context.Con
 		return ctx, nil
 	}
 
-	tx, err := tm.db.Begin()
+	tx, err := tm.db.WithContext(ctx).Begin()
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	txCtx := repository.SetTransactionInContext(ctx, tx)
+	txCtx := repository.WithAfterCommit(repository.SetTransactionInContext(ctx, tx))
 	return txCtx, nil
 }
 
@@ -66,6 +81,7 @@ func (tm *transactionManager) CommitTransaction(ctx context.Context) error {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	repository.RunAfterCommit(ctx)
 	return nil
 }
 
@@ -99,7 +115,9 @@ func GetDB(ctx context.Context, db repository.DB) repository.DB {
 	tx := repository.GetTransactionFromContext(ctx)
 	if tx != nil {
 		if dbTx, ok := tx.(repository.DB); ok {
-			return dbTx
+			// Rebind the request context so cancellation and per-request
+			// decorators (e.g. query counting) apply inside transactions too
+			return dbTx.WithContext(ctx)
 		}
 	}
 	return db.WithContext(ctx)
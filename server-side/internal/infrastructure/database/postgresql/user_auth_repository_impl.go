@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ingunawandra/catetin/internal/domain"
@@ -27,10 +28,14 @@ func (r *userAuthRepositoryImpl) Create(ctx context.Context, userAuth *repositor
 
 	res := db.Create(model)
 	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrDuplicateCredential
+		}
 		return err
 	}
 
 	userAuth.ID = model.ID
+	userAuth.CreatedAt = model.CreatedAt
 	return nil
 }
 
@@ -70,6 +75,149 @@ func (r *userAuthRepositoryImpl) FindByUserIDAndProvider(ctx context.Context, us
 	return r.modelToDomain(&model), nil
 }
 
+func (r *userAuthRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*repository.UserAuth, error) {
+	var models []UserAuthModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	userAuths := make([]*repository.UserAuth, len(models))
+	for i, model := range models {
+		userAuths[i] = r.modelToDomain(&model)
+	}
+
+	return userAuths, nil
+}
+
+func (r *userAuthRepositoryImpl) List(ctx context.Context, filter repository.UserAuthFilter, page repository.Page) ([]*repository.UserAuth, error) {
+	var models []UserAuthModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := r.applyFilter(db, filter).
+		Order("created_at ASC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	userAuths := make([]*repository.UserAuth, len(models))
+	for i, model := range models {
+		userAuths[i] = r.modelToDomain(&model)
+	}
+
+	return userAuths, nil
+}
+
+func (r *userAuthRepositoryImpl) Count(ctx context.Context, filter repository.UserAuthFilter) (int64, error) {
+	var count int64
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := r.applyFilter(db.Model(&UserAuthModel{}), filter).
+		Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *userAuthRepositoryImpl) UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Model(&UserAuthModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"last_used_at": lastUsedAt,
+		}).Error()
+}
+
+func (r *userAuthRepositoryImpl) RecordLoginFailure(ctx context.Context, id uuid.UUID, maxAttempts int, lockedUntil time.Time) (bool, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Model(&UserAuthModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"failed_login_attempts": gorm.Expr("failed_login_attempts + 1"),
+		})
+	if err := res.Error(); err != nil {
+		return false, err
+	}
+
+	// Only one of concurrent failures reaching the limit locks the credential
+	res = db.Model(&UserAuthModel{}).
+		Where("id = ? AND failed_login_attempts >= ?", id, maxAttempts).
+		Updates(map[string]interface{}{
+			"failed_login_attempts": 0,
+			"locked_until":          lockedUntil,
+		})
+	if err := res.Error(); err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+func (r *userAuthRepositoryImpl) ResetLoginFailures(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Model(&UserAuthModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"failed_login_attempts": 0,
+			"locked_until":          nil,
+		}).Error()
+}
+
+func (r *userAuthRepositoryImpl) ReplaceSecret(ctx context.Context, id uuid.UUID, oldSecret, newSecret string) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&UserAuthModel{}).
+		Where("id = ? AND credential_secret = ?", id, oldSecret).
+		Updates(map[string]interface{}{
+			"credential_secret": newSecret,
+			"updated_at":        time.Now(),
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+// applyFilter restricts a query to the user auth records matching the filter
+func (r *userAuthRepositoryImpl) applyFilter(db repository.DB, filter repository.UserAuthFilter) repository.DB {
+	if filter.IncludeDeleted {
+		db = db.Unscoped()
+	}
+	db = db.Where("user_id = ?", filter.UserID)
+	if filter.AuthProviderID != nil {
+		db = db.Where("auth_provider_id = ?", *filter.AuthProviderID)
+	}
+	return db
+}
+
 func (r *userAuthRepositoryImpl) Update(ctx context.Context, userAuth *repository.UserAuth) error {
 	model := r.domainToModel(userAuth)
 
@@ -113,6 +261,29 @@ func (r *userAuthRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+func (r *userAuthRepositoryImpl) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Delete(&UserAuthModel{}, "user_id = ?", userID)
+
+	return result.Error()
+}
+
+func (r *userAuthRepositoryImpl) AnonymizeByUserID(ctx context.Context, userID uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	return db.Unscoped().Model(&UserAuthModel{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"credential_id":      gorm.Expr("'erased-' || id"),
+			"credential_secret":  "",
+			"credential_refresh": nil,
+			"updated_at":         time.Now(),
+		}).Error()
+}
+
 // Helper methods for conversion
 
 func (r *userAuthRepositoryImpl) domainToModel(userAuth *repository.UserAuth) *UserAuthModel {
@@ -122,17 +293,32 @@ func (r *userAuthRepositoryImpl) domainToModel(userAuth *repository.UserAuth) *U
 		AuthProviderID:    userAuth.AuthProviderID,
 		CredentialID:      userAuth.CredentialID,
 		CredentialSecret:  userAuth.CredentialSecret,
-		CredentialRefresh: userAuth.CredentialRefresh,
+		CredentialRefresh: NewEncryptedString(userAuth.CredentialRefresh),
+		CreatedAt:         userAuth.CreatedAt,
+		LastUsedAt:        userAuth.LastUsedAt,
+		FailedLoginCount:  userAuth.FailedLoginAttempts,
+		LockedUntil:       userAuth.LockedUntil,
 	}
 }
 
 func (r *userAuthRepositoryImpl) modelToDomain(model *UserAuthModel) *repository.UserAuth {
+	var deletedAt *time.Time
+	if model.DeletedAt.Valid {
+		deletedAt = &model.DeletedAt.Time
+	}
+
 	return &repository.UserAuth{
 		ID:                model.ID,
 		UserID:            model.UserID,
 		AuthProviderID:    model.AuthProviderID,
 		CredentialID:      model.CredentialID,
 		CredentialSecret:  model.CredentialSecret,
-		CredentialRefresh: model.CredentialRefresh,
+		CredentialRefresh: model.CredentialRefresh.Ptr(),
+		CreatedAt:         model.CreatedAt,
+		LastUsedAt:        model.LastUsedAt,
+		DeletedAt:         deletedAt,
+
+		FailedLoginAttempts: model.FailedLoginCount,
+		LockedUntil:         model.LockedUntil,
 	}
 }
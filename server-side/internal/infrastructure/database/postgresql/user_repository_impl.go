@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -89,11 +90,17 @@ func (r *userRepositoryImpl) Update(ctx context.Context, user *domain.User) erro
 			"full_name":    model.FullName,
 			"phone_number": model.PhoneNumber,
 			"image":        model.Image,
+			"data_region":  model.DataRegion,
+			"role":         model.Role,
+			"disabled_at":  model.DisabledAt,
 			"version":      model.Version,
 			"updated_at":   model.UpdatedAt,
 		})
 
 	if err := result.Error(); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrDuplicatePhoneNumber
+		}
 		return err
 	}
 
@@ -121,6 +128,69 @@ func (r *userRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (r *userRepositoryImpl) Anonymize(ctx context.Context, id uuid.UUID, fullName, phoneNumber string) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Unscoped().Model(&UserModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"full_name":    fullName,
+			"phone_number": phoneNumber,
+			"image":        nil,
+			"version":      gorm.Expr("version + 1"),
+			"updated_at":   time.Now(),
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepositoryImpl) FindTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	var model UserModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Select("token_version").Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, err
+	}
+
+	return model.TokenVersion, nil
+}
+
+func (r *userRepositoryImpl) IncrementTokenVersion(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Model(&UserModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"token_version": gorm.Expr("token_version + 1"),
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *userRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*domain.User, error) {
 	var models []UserModel
 
@@ -143,6 +213,120 @@ func (r *userRepositoryImpl) List(ctx context.Context, limit, offset int) ([]*do
 	return users, nil
 }
 
+func (r *userRepositoryImpl) Search(ctx context.Context, filter repository.UserFilter, page repository.Page) ([]*domain.User, error) {
+	var models []UserModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := r.applyFilter(db, filter).
+		Order("created_at DESC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, len(models))
+	for i, model := range models {
+		users[i] = r.modelToDomain(&model)
+	}
+
+	return users, nil
+}
+
+func (r *userRepositoryImpl) Count(ctx context.Context, filter repository.UserFilter) (int64, error) {
+	var count int64
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := r.applyFilter(db.Model(&UserModel{}), filter).
+		Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *userRepositoryImpl) FindByAudience(ctx context.Context, audience domain.BroadcastAudience) ([]*domain.User, error) {
+	var models []UserModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Demo users are throwaway sandboxes and never receive broadcasts
+	db = db.Where("demo_expires_at IS NULL")
+
+	if len(audience.UserIDs) > 0 {
+		db = db.Where("id IN ?", audience.UserIDs)
+	}
+	if len(audience.Roles) > 0 {
+		db = db.Where("role IN ?", audience.Roles)
+	}
+	if audience.RegisteredAfter != nil {
+		db = db.Where("created_at >= ?", *audience.RegisteredAfter)
+	}
+	if audience.RegisteredBefore != nil {
+		db = db.Where("created_at < ?", *audience.RegisteredBefore)
+	}
+
+	res := db.Order("created_at ASC").Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	users := make([]*domain.User, len(models))
+	for i, model := range models {
+		users[i] = r.modelToDomain(&model)
+	}
+
+	return users, nil
+}
+
+func (r *userRepositoryImpl) DeleteExpiredDemoUsers(ctx context.Context, now time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Hard delete; foreign keys cascade to every row owned by the demo user
+	res := db.Exec(`DELETE FROM users WHERE id IN (
+		SELECT id FROM users WHERE demo_expires_at IS NOT NULL AND demo_expires_at <= ? LIMIT ?
+	)`, now, limit)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
+// applyFilter restricts a query to the users matching the filter
+func (r *userRepositoryImpl) applyFilter(db repository.DB, filter repository.UserFilter) repository.DB {
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		db = db.Where(`(full_name ILIKE ? OR phone_number ILIKE ? OR id IN (
+			SELECT user_id FROM user_auths WHERE credential_id ILIKE ? AND deleted_at IS NULL
+		))`, pattern, pattern, pattern)
+	}
+	if filter.Role != "" {
+		db = db.Where("role = ?", filter.Role)
+	}
+	if filter.Disabled != nil {
+		if *filter.Disabled {
+			db = db.Where("disabled_at IS NOT NULL")
+		} else {
+			db = db.Where("disabled_at IS NULL")
+		}
+	}
+	return db
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern so the value matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 // Helper methods for conversion between domain and model
 
 func (r *userRepositoryImpl) domainToModel(user *domain.User) *UserModel {
@@ -154,15 +338,26 @@ func (r *userRepositoryImpl) domainToModel(user *domain.User) *UserModel {
 		}
 	}
 
+	var dataRegion *string
+	if user.DataRegion != "" {
+		dataRegion = &user.DataRegion
+	}
+
 	return &UserModel{
 		ID:          user.ID,
 		FullName:    user.FullName,
 		PhoneNumber: user.PhoneNumber,
 		Image:       user.Image,
+		Role:        user.Role,
 		Version:     user.Version,
 		CreatedAt:   user.CreatedAt,
 		UpdatedAt:   user.UpdatedAt,
 		DeletedAt:   deletedAt,
+
+		DemoExpiresAt: user.DemoExpiresAt,
+		DataRegion:    dataRegion,
+		DisabledAt:    user.DisabledAt,
+		TokenVersion:  user.TokenVersion,
 	}
 }
 
@@ -172,14 +367,25 @@ func (r *userRepositoryImpl) modelToDomain(model *UserModel) *domain.User {
 		deletedAt = &model.DeletedAt.Time
 	}
 
+	var dataRegion string
+	if model.DataRegion != nil {
+		dataRegion = *model.DataRegion
+	}
+
 	return &domain.User{
 		ID:          model.ID,
 		FullName:    model.FullName,
 		PhoneNumber: model.PhoneNumber,
 		Image:       model.Image,
+		Role:        model.Role,
 		Version:     model.Version,
 		CreatedAt:   model.CreatedAt,
 		UpdatedAt:   model.UpdatedAt,
 		DeletedAt:   deletedAt,
+
+		DemoExpiresAt: model.DemoExpiresAt,
+		DataRegion:    dataRegion,
+		DisabledAt:    model.DisabledAt,
+		TokenVersion:  model.TokenVersion,
 	}
 }
@@ -0,0 +1,168 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type userSettingsRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewUserSettingsRepository creates a new user settings repository implementation
+func NewUserSettingsRepository(db repository.DB) repository.UserSettingsRepository {
+	return &userSettingsRepositoryImpl{db: db}
+}
+
+func (r *userSettingsRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	var model UserSettingsModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *userSettingsRepositoryImpl) Create(ctx context.Context, settings *domain.UserSettings) error {
+	model := r.domainToModel(settings)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		// Another request created the settings first
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	settings.CreatedAt = model.CreatedAt
+	settings.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *userSettingsRepositoryImpl) Update(ctx context.Context, settings *domain.UserSettings) error {
+	model := r.domainToModel(settings)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&UserSettingsModel{}).
+		Where("user_id = ? AND version = ?", settings.UserID, settings.Version-1).
+		Updates(map[string]interface{}{
+			"analytics_opt_out":     settings.AnalyticsOptOut,
+			"default_currency":      settings.DefaultCurrency,
+			"single_currency_mode":  settings.SingleCurrencyMode,
+			"locale":                settings.Locale,
+			"timezone":              settings.Timezone,
+			"week_start":            int(settings.WeekStart),
+			"notify_whatsapp":       settings.NotifyWhatsApp,
+			"notify_email":          settings.NotifyEmail,
+			"notify_telegram":       settings.NotifyTelegram,
+			"notify_push":           settings.NotifyPush,
+			"telegram_chat_id":      model.TelegramChatID,
+			"digest_frequency":      settings.DigestFrequency,
+			"duplicate_check":       settings.DuplicateCheck,
+			"monthly_statement":     settings.MonthlyStatement,
+			"notification_channels": model.NotificationChannels,
+			"version":               settings.Version,
+			"updated_at":            settings.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *userSettingsRepositoryImpl) domainToModel(settings *domain.UserSettings) *UserSettingsModel {
+	var telegramChatID *string
+	if settings.TelegramChatID != "" {
+		telegramChatID = &settings.TelegramChatID
+	}
+
+	channels := JSONB(settings.NotificationChannels)
+	if channels == nil {
+		channels = JSONB([]string{})
+	}
+
+	return &UserSettingsModel{
+		UserID:               settings.UserID,
+		AnalyticsOptOut:      settings.AnalyticsOptOut,
+		DefaultCurrency:      settings.DefaultCurrency,
+		SingleCurrencyMode:   settings.SingleCurrencyMode,
+		Locale:               settings.Locale,
+		Timezone:             settings.Timezone,
+		WeekStart:            int(settings.WeekStart),
+		NotifyWhatsApp:       settings.NotifyWhatsApp,
+		NotifyEmail:          settings.NotifyEmail,
+		NotifyTelegram:       settings.NotifyTelegram,
+		NotifyPush:           settings.NotifyPush,
+		TelegramChatID:       telegramChatID,
+		DigestFrequency:      settings.DigestFrequency,
+		DuplicateCheck:       settings.DuplicateCheck,
+		MonthlyStatement:     settings.MonthlyStatement,
+		NotificationChannels: channels,
+		Version:              settings.Version,
+		CreatedAt:            settings.CreatedAt,
+		UpdatedAt:            settings.UpdatedAt,
+	}
+}
+
+func (r *userSettingsRepositoryImpl) modelToDomain(model *UserSettingsModel) *domain.UserSettings {
+	var telegramChatID string
+	if model.TelegramChatID != nil {
+		telegramChatID = *model.TelegramChatID
+	}
+
+	var channels []string
+	if len(model.NotificationChannels) > 0 {
+		channels = []string(model.NotificationChannels)
+	}
+
+	return &domain.UserSettings{
+		UserID:               model.UserID,
+		AnalyticsOptOut:      model.AnalyticsOptOut,
+		DefaultCurrency:      model.DefaultCurrency,
+		SingleCurrencyMode:   model.SingleCurrencyMode,
+		Locale:               model.Locale,
+		Timezone:             model.Timezone,
+		WeekStart:            time.Weekday(model.WeekStart),
+		NotifyWhatsApp:       model.NotifyWhatsApp,
+		NotifyEmail:          model.NotifyEmail,
+		NotifyTelegram:       model.NotifyTelegram,
+		NotifyPush:           model.NotifyPush,
+		TelegramChatID:       telegramChatID,
+		DigestFrequency:      model.DigestFrequency,
+		DuplicateCheck:       model.DuplicateCheck,
+		MonthlyStatement:     model.MonthlyStatement,
+		NotificationChannels: channels,
+		Version:              model.Version,
+		CreatedAt:            model.CreatedAt,
+		UpdatedAt:            model.UpdatedAt,
+	}
+}
@@ -0,0 +1,155 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type walletRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewWalletRepository creates a new wallet repository implementation
+func NewWalletRepository(db repository.DB) repository.WalletRepository {
+	return &walletRepositoryImpl{db: db}
+}
+
+func (r *walletRepositoryImpl) Create(ctx context.Context, wallet *domain.Wallet) error {
+	model := r.domainToModel(wallet)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		// Wallet names are unique per user
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	wallet.ID = model.ID
+	wallet.CreatedAt = model.CreatedAt
+	wallet.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *walletRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Wallet, error) {
+	var model WalletModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *walletRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
+	var models []WalletModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("lower(name) ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	wallets := make([]*domain.Wallet, len(models))
+	for i, model := range models {
+		wallets[i] = r.modelToDomain(&model)
+	}
+
+	return wallets, nil
+}
+
+func (r *walletRepositoryImpl) Update(ctx context.Context, wallet *domain.Wallet) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&WalletModel{}).
+		Where("id = ? AND version = ?", wallet.ID, wallet.Version-1).
+		Updates(map[string]interface{}{
+			"name":            wallet.Name,
+			"type":            wallet.Type,
+			"opening_balance": moneyDecimal(wallet.OpeningBalance, wallet.Currency),
+			"version":         wallet.Version,
+			"updated_at":      wallet.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domain.ErrConflict
+		}
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *walletRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Where("id = ?", id).Delete(&WalletModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// Helper methods for conversion
+
+func (r *walletRepositoryImpl) domainToModel(wallet *domain.Wallet) *WalletModel {
+	return &WalletModel{
+		ID:             wallet.ID,
+		UserID:         wallet.UserID,
+		Name:           wallet.Name,
+		Type:           wallet.Type,
+		Currency:       wallet.Currency,
+		OpeningBalance: moneyDecimal(wallet.OpeningBalance, wallet.Currency),
+		Version:        wallet.Version,
+		CreatedAt:      wallet.CreatedAt,
+		UpdatedAt:      wallet.UpdatedAt,
+	}
+}
+
+func (r *walletRepositoryImpl) modelToDomain(model *WalletModel) *domain.Wallet {
+	return &domain.Wallet{
+		ID:             model.ID,
+		UserID:         model.UserID,
+		Name:           model.Name,
+		Type:           model.Type,
+		Currency:       model.Currency,
+		OpeningBalance: model.OpeningBalance.Minor(model.Currency),
+		Version:        model.Version,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
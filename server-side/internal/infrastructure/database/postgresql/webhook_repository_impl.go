@@ -0,0 +1,233 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"gorm.io/gorm"
+)
+
+type webhookRepositoryImpl struct {
+	db repository.DB
+}
+
+// NewWebhookRepository creates a new webhook repository implementation
+func NewWebhookRepository(db repository.DB) repository.WebhookRepository {
+	return &webhookRepositoryImpl{db: db}
+}
+
+func (r *webhookRepositoryImpl) Create(ctx context.Context, webhook *domain.Webhook) error {
+	model := r.domainToModel(webhook)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	webhook.ID = model.ID
+	webhook.CreatedAt = model.CreatedAt
+	webhook.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *webhookRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	var model WebhookModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("id = ?", id).First(&model)
+	if err := res.Error(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.modelToDomain(&model), nil
+}
+
+func (r *webhookRepositoryImpl) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Webhook, error) {
+	var models []WebhookModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*domain.Webhook, len(models))
+	for i, model := range models {
+		webhooks[i] = r.modelToDomain(&model)
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepositoryImpl) Update(ctx context.Context, webhook *domain.Webhook) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Optimistic locking: check version
+	result := db.Model(&WebhookModel{}).
+		Where("id = ? AND version = ?", webhook.ID, webhook.Version-1).
+		Updates(map[string]interface{}{
+			"url":        webhook.URL,
+			"events":     JSONB(webhook.Events),
+			"active":     webhook.Active,
+			"version":    webhook.Version,
+			"updated_at": webhook.UpdatedAt,
+		})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+
+	return nil
+}
+
+func (r *webhookRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	// Deliveries are deleted by the foreign key cascade
+	result := db.Where("id = ?", id).Delete(&WebhookModel{})
+
+	if err := result.Error(); err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *webhookRepositoryImpl) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	model := r.deliveryToModel(delivery)
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Create(model)
+	if err := res.Error(); err != nil {
+		return err
+	}
+
+	delivery.ID = model.ID
+	delivery.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *webhookRepositoryImpl) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	var models []WebhookDeliveryModel
+
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	res := db.Where("webhook_id = ?", webhookID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, len(models))
+	for i, model := range models {
+		deliveries[i] = r.modelToDelivery(&model)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepositoryImpl) DeleteDeliveriesBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	// Use GetDB to support transactions
+	db := GetDB(ctx, r.db)
+
+	result := db.Exec(
+		`DELETE FROM webhook_deliveries WHERE id IN (
+			SELECT id FROM webhook_deliveries WHERE created_at < ? LIMIT ?
+		)`,
+		before, limit,
+	)
+
+	return result.RowsAffected(), result.Error()
+}
+
+// Helper methods for conversion
+
+func (r *webhookRepositoryImpl) domainToModel(webhook *domain.Webhook) *WebhookModel {
+	return &WebhookModel{
+		ID:        webhook.ID,
+		UserID:    webhook.UserID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    JSONB(webhook.Events),
+		Active:    webhook.Active,
+		Version:   webhook.Version,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func (r *webhookRepositoryImpl) modelToDomain(model *WebhookModel) *domain.Webhook {
+	return &domain.Webhook{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		URL:       model.URL,
+		Secret:    model.Secret,
+		Events:    []string(model.Events),
+		Active:    model.Active,
+		Version:   model.Version,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+func (r *webhookRepositoryImpl) deliveryToModel(delivery *domain.WebhookDelivery) *WebhookDeliveryModel {
+	return &WebhookDeliveryModel{
+		ID:         delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Attempt:    delivery.Attempt,
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		DurationMs: delivery.Duration.Milliseconds(),
+		Succeeded:  delivery.Succeeded,
+		CreatedAt:  delivery.CreatedAt,
+	}
+}
+
+func (r *webhookRepositoryImpl) modelToDelivery(model *WebhookDeliveryModel) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:         model.ID,
+		WebhookID:  model.WebhookID,
+		EventID:    model.EventID,
+		EventType:  model.EventType,
+		Attempt:    model.Attempt,
+		StatusCode: model.StatusCode,
+		Error:      model.Error,
+		Duration:   time.Duration(model.DurationMs) * time.Millisecond,
+		Succeeded:  model.Succeeded,
+		CreatedAt:  model.CreatedAt,
+	}
+}
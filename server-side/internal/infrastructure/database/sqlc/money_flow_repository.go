@@ -0,0 +1,102 @@
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlc/queries"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type moneyFlowRepository struct {
+	repository.MoneyFlowRepository
+	queries *queries.Queries
+}
+
+// NewMoneyFlowRepository creates a money flow repository that finds, lists, and counts
+// a user's money flows with sqlc and leaves everything else to fallback
+func NewMoneyFlowRepository(pool *pgxpool.Pool, fallback repository.MoneyFlowRepository) repository.MoneyFlowRepository {
+	return &moneyFlowRepository{MoneyFlowRepository: fallback, queries: queries.New(pool)}
+}
+
+func (r *moneyFlowRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.MoneyFlow, error) {
+	if inTransaction(ctx) {
+		return r.MoneyFlowRepository.FindByID(ctx, id)
+	}
+
+	row, err := r.queries.GetMoneyFlow(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return moneyFlowToDomain(row), nil
+}
+
+func (r *moneyFlowRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	if inTransaction(ctx) {
+		return r.MoneyFlowRepository.FindByUserID(ctx, userID, limit, offset)
+	}
+
+	rows, err := r.queries.ListMoneyFlowsByUser(ctx, queries.ListMoneyFlowsByUserParams{
+		UserID: userID,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, len(rows))
+	for i, row := range rows {
+		moneyFlows[i] = moneyFlowToDomain(queries.GetMoneyFlowRow(row))
+	}
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	if inTransaction(ctx) {
+		return r.MoneyFlowRepository.CountByUserID(ctx, userID)
+	}
+
+	return r.queries.CountMoneyFlowsByUser(ctx, userID)
+}
+
+// moneyFlowToDomain converts a money flow row to the domain entity, as the GORM
+// implementation does
+func moneyFlowToDomain(row queries.GetMoneyFlowRow) *domain.MoneyFlow {
+	tags := []string{}
+	if len(row.Tags) > 0 {
+		// Tags are written as a JSON array of strings
+		_ = json.Unmarshal(row.Tags, &tags)
+	}
+
+	return &domain.MoneyFlow{
+		ID:          row.ID,
+		UserID:      row.UserID,
+		WalletID:    row.WalletID,
+		Kind:        row.Kind,
+		TransferID:  row.TransferID,
+		GroupID:     row.GroupID,
+		MerchantID:  row.MerchantID,
+		Category:    row.Category,
+		Amount:      row.Amount.Minor(row.Currency),
+		Currency:    row.Currency,
+		Description: row.Description,
+		Tags:        tags,
+		Latitude:    row.Latitude,
+		Longitude:   row.Longitude,
+		PlaceName:   row.PlaceName,
+		Version:     int(row.Version),
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
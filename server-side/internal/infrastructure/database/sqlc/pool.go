@@ -0,0 +1,43 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewPool connects a pgx pool for the queries to the database of dsn, in the
+// keyword/value or the URL form, with the settings of the GORM pool. MaxIdleConns has
+// no pgx equivalent and is ignored.
+func NewPool(ctx context.Context, dsn string, pool postgresql.PoolConfig) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database DSN: %w", err)
+	}
+	config.MaxConns = int32(pool.MaxOpenConns)
+	if pool.ConnMaxLifetime > 0 {
+		config.MaxConnLifetime = pool.ConnMaxLifetime
+	}
+	// As with database/sql, zero keeps idle connections until their lifetime ends
+	config.MaxConnIdleTime = config.MaxConnLifetime
+	if pool.ConnMaxIdleTime > 0 {
+		config.MaxConnIdleTime = pool.ConnMaxIdleTime
+	}
+	if pool.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(pool.StatementTimeout.Milliseconds(), 10)
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := db.Ping(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return db, nil
+}
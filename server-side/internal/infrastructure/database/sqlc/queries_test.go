@@ -0,0 +1,22 @@
+package sqlc
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// TestQueriesAreGenerated fails when the generated queries differ from what sqlc
+// generates from query/ and the migrations, such as after changing a query without
+// running "sqlc generate"
+func TestQueriesAreGenerated(t *testing.T) {
+	path, err := exec.LookPath("sqlc")
+	if err != nil {
+		t.Skip("sqlc is not installed")
+	}
+
+	cmd := exec.Command(path, "diff")
+	cmd.Dir = "../../../.." // server-side, with sqlc.yaml
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("sqlc diff: %v\n%s\nRun sqlc generate in server-side.", err, output)
+	}
+}
@@ -0,0 +1,20 @@
+-- name: GetMoneyFlow :one
+SELECT id, user_id, wallet_id, kind, transfer_id, group_id, merchant_id, category, amount,
+       currency, description, tags, latitude, longitude, place_name, version, created_at,
+       updated_at
+FROM money_flows
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListMoneyFlowsByUser :many
+SELECT id, user_id, wallet_id, kind, transfer_id, group_id, merchant_id, category, amount,
+       currency, description, tags, latitude, longitude, place_name, version, created_at,
+       updated_at
+FROM money_flows
+WHERE user_id = $1 AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountMoneyFlowsByUser :one
+SELECT count(*)
+FROM money_flows
+WHERE user_id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- name: GetRefreshTokenByHash :one
+SELECT id, user_id, session_id, token_hash, expires_at, revoked_at, user_agent, ip_address,
+       signed_in_at, created_at
+FROM refresh_tokens
+WHERE token_hash = $1;
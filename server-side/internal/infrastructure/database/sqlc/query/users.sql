@@ -0,0 +1,16 @@
+-- name: GetUser :one
+SELECT id, full_name, phone_number, image, role, version, created_at, updated_at,
+       demo_expires_at, data_region, disabled_at, token_version
+FROM users
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetUserByPhoneNumber :one
+SELECT id, full_name, phone_number, image, role, version, created_at, updated_at,
+       demo_expires_at, data_region, disabled_at, token_version
+FROM users
+WHERE phone_number = $1 AND deleted_at IS NULL;
+
+-- name: GetUserTokenVersion :one
+SELECT token_version
+FROM users
+WHERE id = $1 AND deleted_at IS NULL;
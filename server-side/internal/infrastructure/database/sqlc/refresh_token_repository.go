@@ -0,0 +1,50 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlc/queries"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type refreshTokenRepository struct {
+	repository.RefreshTokenRepository
+	queries *queries.Queries
+}
+
+// NewRefreshTokenRepository creates a refresh token repository that finds tokens by
+// hash with sqlc and leaves everything else to fallback
+func NewRefreshTokenRepository(pool *pgxpool.Pool, fallback repository.RefreshTokenRepository) repository.RefreshTokenRepository {
+	return &refreshTokenRepository{RefreshTokenRepository: fallback, queries: queries.New(pool)}
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*repository.RefreshToken, error) {
+	if inTransaction(ctx) {
+		return r.RefreshTokenRepository.FindByHash(ctx, tokenHash)
+	}
+
+	row, err := r.queries.GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &repository.RefreshToken{
+		ID:         row.ID,
+		UserID:     row.UserID,
+		SessionID:  row.SessionID,
+		TokenHash:  row.TokenHash,
+		ExpiresAt:  row.ExpiresAt,
+		RevokedAt:  row.RevokedAt,
+		UserAgent:  row.UserAgent,
+		IPAddress:  row.IpAddress,
+		SignedInAt: row.SignedInAt,
+		CreatedAt:  row.CreatedAt,
+	}, nil
+}
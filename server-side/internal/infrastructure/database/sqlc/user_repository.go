@@ -0,0 +1,108 @@
+// Package sqlc implements the repositories' most frequent reads with the queries sqlc
+// generates from query/ into queries/, run with pgx on a pool of its own. Reads in a
+// transaction, and every other method, are left to the GORM implementation the
+// repositories wrap, so they stay part of GORM's transactions. Regenerate the queries
+// with "sqlc generate" in server-side; the integration tests run them with
+// TEST_QUERY_ENGINE=sqlc.
+package sqlc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlc/queries"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type userRepository struct {
+	repository.UserRepository
+	queries *queries.Queries
+}
+
+// NewUserRepository creates a user repository that finds users and their token version
+// with sqlc and leaves everything else to fallback
+func NewUserRepository(pool *pgxpool.Pool, fallback repository.UserRepository) repository.UserRepository {
+	return &userRepository{UserRepository: fallback, queries: queries.New(pool)}
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if inTransaction(ctx) {
+		return r.UserRepository.FindByID(ctx, id)
+	}
+
+	row, err := r.queries.GetUser(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return userToDomain(row), nil
+}
+
+func (r *userRepository) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.User, error) {
+	if inTransaction(ctx) {
+		return r.UserRepository.FindByPhoneNumber(ctx, phoneNumber)
+	}
+
+	row, err := r.queries.GetUserByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return userToDomain(queries.GetUserRow(row)), nil
+}
+
+func (r *userRepository) FindTokenVersion(ctx context.Context, id uuid.UUID) (int, error) {
+	if inTransaction(ctx) {
+		return r.UserRepository.FindTokenVersion(ctx, id)
+	}
+
+	version, err := r.queries.GetUserTokenVersion(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, domain.ErrNotFound
+		}
+		return 0, err
+	}
+
+	return int(version), nil
+}
+
+// userToDomain converts a user row to the domain entity, as the GORM implementation does
+func userToDomain(row queries.GetUserRow) *domain.User {
+	var dataRegion string
+	if row.DataRegion != nil {
+		dataRegion = *row.DataRegion
+	}
+
+	return &domain.User{
+		ID:          row.ID,
+		FullName:    row.FullName,
+		PhoneNumber: row.PhoneNumber,
+		Image:       row.Image,
+		Role:        row.Role,
+		Version:     int(row.Version),
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+
+		DemoExpiresAt: row.DemoExpiresAt,
+		DataRegion:    dataRegion,
+		DisabledAt:    row.DisabledAt,
+		TokenVersion:  int(row.TokenVersion),
+	}
+}
+
+// inTransaction reports whether ctx carries a GORM transaction, which the queries on
+// the pgx pool cannot join
+func inTransaction(ctx context.Context) bool {
+	return repository.GetTransactionFromContext(ctx) != nil
+}
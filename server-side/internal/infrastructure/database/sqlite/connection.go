@@ -0,0 +1,100 @@
+// Package sqlite runs the repositories on an embedded SQLite database file, so the server
+// runs as a single binary without PostgreSQL. The GORM repositories of the postgresql
+// package work on SQLite as they are; the repositories here wrap them and override the
+// few queries written in PostgreSQL's dialect, such as JSONB containment and full-text
+// search.
+package sqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/glebarez/go-sqlite"
+	gormsqlite "github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func init() {
+	// Functions the schema defaults and the shared repositories' SQL call, which SQLite
+	// does not have
+	sqlite.MustRegisterScalarFunction("gen_random_uuid", 0, newUUID)
+	sqlite.MustRegisterScalarFunction("uuid_generate_v4", 0, newUUID)
+	sqlite.MustRegisterScalarFunction("now", 0, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return time.Now().UTC().Format(timeFormat), nil
+	})
+
+	// sql.Open does not connect, it only looks up the driver
+	db, err := sql.Open("sqlite", "")
+	if err != nil {
+		panic(err)
+	}
+	sql.Register(driverName, utcDriver{Driver: db.Driver()})
+	db.Close()
+}
+
+func newUUID(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+	return uuid.NewString(), nil
+}
+
+// NewConnection opens the SQLite database file at path, creating it if it does not exist
+func NewConnection(path string, env string) (*gorm.DB, error) {
+	// Configure GORM logger based on environment
+	logLevel := logger.Info
+	if env == "production" {
+		logLevel = logger.Warn
+	}
+
+	config := &gorm.Config{
+		// Slow queries are logged by the QueryMonitor instead, with the request's logger
+		Logger: logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+			LogLevel: logLevel,
+			Colorful: true,
+		}),
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+		// Translate driver errors (e.g. unique violations) into gorm.ErrDuplicatedKey
+		TranslateError: true,
+	}
+
+	db, err := gorm.Open(gormsqlite.Dialector{DriverName: driverName, DSN: dsn(path)}, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Get underlying SQL database
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+
+	// Test connection
+	if err := sqlDB.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	slog.Info("Successfully opened SQLite database", "path", path)
+
+	return db, nil
+}
+
+// dsn enforces foreign keys like PostgreSQL does, lets readers run beside the writer, and
+// makes writers wait for each other instead of failing. Transactions take the write lock
+// when they begin, so two of them cannot deadlock upgrading their read locks.
+func dsn(path string) string {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_time_format", "sqlite")
+	params.Set("_txlock", "immediate")
+	return "file:" + path + "?" + params.Encode()
+}
@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// driverName is the name the wrapped SQLite driver is registered under
+const driverName = "catetin-sqlite"
+
+// timeFormat is the format the driver writes times in with _time_format=sqlite
+const timeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// utcDriver wraps the SQLite driver to keep times comparable. SQLite stores times as
+// text, which compares correctly only when every time has the same offset, so times
+// are written in UTC. Times are read back only from columns declared DATETIME or
+// DATE, not from expressions such as MAX(created_at), so text in the driver's time
+// format is read as a time from those too.
+type utcDriver struct {
+	driver.Driver
+}
+
+// sqliteConn is the part of the SQLite driver's connection the wrapper forwards
+type sqliteConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+}
+
+// sqliteStmt is the part of the SQLite driver's statement the wrapper forwards
+type sqliteStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+}
+
+// sqliteRows is the part of the SQLite driver's rows the wrapper forwards
+type sqliteRows interface {
+	driver.Rows
+	driver.RowsColumnTypeDatabaseTypeName
+	driver.RowsColumnTypeLength
+	driver.RowsColumnTypeNullable
+	driver.RowsColumnTypePrecisionScale
+	driver.RowsColumnTypeScanType
+}
+
+func (d utcDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &utcConn{sqliteConn: conn.(sqliteConn)}, nil
+}
+
+type utcConn struct {
+	sqliteConn
+}
+
+func (c *utcConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *utcConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.sqliteConn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &utcStmt{sqliteStmt: stmt.(sqliteStmt)}, nil
+}
+
+func (c *utcConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.sqliteConn.ExecContext(ctx, query, utcArgs(args))
+}
+
+func (c *utcConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.sqliteConn.QueryContext(ctx, query, utcArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	return &timeRows{sqliteRows: rows.(sqliteRows)}, nil
+}
+
+type utcStmt struct {
+	sqliteStmt
+}
+
+func (s *utcStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.sqliteStmt.ExecContext(ctx, utcArgs(args))
+}
+
+func (s *utcStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.sqliteStmt.QueryContext(ctx, utcArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	return &timeRows{sqliteRows: rows.(sqliteRows)}, nil
+}
+
+// utcArgs converts the times among args to UTC
+func utcArgs(args []driver.NamedValue) []driver.NamedValue {
+	for i, arg := range args {
+		if t, ok := arg.Value.(time.Time); ok {
+			args[i].Value = t.UTC()
+		}
+	}
+	return args
+}
+
+type timeRows struct {
+	sqliteRows
+}
+
+// Next reads text in the time format as a time from columns without a declared type
+func (r *timeRows) Next(dest []driver.Value) error {
+	if err := r.sqliteRows.Next(dest); err != nil {
+		return err
+	}
+	for i, value := range dest {
+		text, ok := value.(string)
+		if !ok || r.ColumnTypeDatabaseTypeName(i) != "" {
+			continue
+		}
+		if t, err := time.Parse(timeFormat, text); err == nil {
+			dest[i] = t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migrations returns the SQLite migration files compiled into the binary. They mirror
+// the PostgreSQL migrations in SQLite's dialect, numbered on their own.
+func Migrations() fs.FS {
+	migrations, err := fs.Sub(embeddedMigrations, "migrations")
+	if err != nil {
+		// fs.Sub only fails on an invalid directory name
+		panic(err)
+	}
+	return migrations
+}
+
+// RunMigrations applies the migrations newer than the database's version, each in a
+// transaction. The version is kept in a schema_migrations table like golang-migrate's,
+// so postgresql.CheckMigrations and postgresql.LatestMigrationVersion work on SQLite too.
+func RunMigrations(db *gorm.DB, migrations fs.FS) error {
+	slog.Info("Running database migrations")
+
+	if err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`).Error; err != nil {
+		return fmt.Errorf("failed to create migration table: %w", err)
+	}
+
+	var current struct {
+		Version int64
+		Dirty   bool
+	}
+	if err := db.Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current).Error; err != nil {
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+	if current.Dirty {
+		return fmt.Errorf("database is in dirty state at version %d", current.Version)
+	}
+
+	// Sequential versions sort before timestamps, so file names sort by version
+	names, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		return fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	applied := int64(0)
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version <= current.Version {
+			continue
+		}
+
+		script, err := fs.ReadFile(migrations, name)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(string(script)).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+				return err
+			}
+			return tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", version).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", name, err)
+		}
+		applied = version
+	}
+
+	if applied == 0 {
+		slog.Info("No new migrations to apply")
+		return nil
+	}
+
+	slog.Info("Successfully applied migrations", "version", applied)
+	return nil
+}
@@ -0,0 +1,34 @@
+DROP TABLE IF EXISTS "digests";
+DROP TABLE IF EXISTS "devices";
+DROP TABLE IF EXISTS "webhook_deliveries";
+DROP TABLE IF EXISTS "webhooks";
+DROP TABLE IF EXISTS "audit_logs";
+DROP TABLE IF EXISTS "feedback";
+DROP TABLE IF EXISTS "exchange_rates";
+DROP TABLE IF EXISTS "system_settings";
+DROP TABLE IF EXISTS "conversations";
+DROP TABLE IF EXISTS "outbox_events";
+DROP TABLE IF EXISTS "jobs";
+DROP TABLE IF EXISTS "api_usage_daily";
+DROP TABLE IF EXISTS "analytics_events";
+DROP TABLE IF EXISTS "broadcast_deliveries";
+DROP TABLE IF EXISTS "broadcasts";
+DROP TABLE IF EXISTS "notifications";
+DROP TABLE IF EXISTS "budget_overrides";
+DROP TABLE IF EXISTS "budgets";
+DROP TABLE IF EXISTS "user_settings";
+DROP TABLE IF EXISTS "invitations";
+DROP TABLE IF EXISTS "password_resets";
+DROP TABLE IF EXISTS "refresh_tokens";
+DROP TABLE IF EXISTS "api_keys";
+DROP TABLE IF EXISTS "group_settlements";
+DROP TABLE IF EXISTS "money_flow_splits";
+DROP TABLE IF EXISTS "money_flow_notes";
+DROP TABLE IF EXISTS "money_flows";
+DROP TABLE IF EXISTS "group_invitations";
+DROP TABLE IF EXISTS "group_members";
+DROP TABLE IF EXISTS "groups";
+DROP TABLE IF EXISTS "wallets";
+DROP TABLE IF EXISTS "user_auths";
+DROP TABLE IF EXISTS "auth_providers";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,550 @@
+-- SQLite schema of every PostgreSQL migration up to 20261016115725_refresh_token_sessions.
+-- Types follow SQLite's affinities: uuid, varchar, and jsonb columns are TEXT,
+-- timestamptz columns DATETIME so the driver reads them as times, and numeric columns
+-- NUMERIC. gen_random_uuid() and now() are functions registered by the sqlite package.
+
+CREATE TABLE IF NOT EXISTS "users" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "full_name" TEXT NOT NULL,
+  "phone_number" TEXT NOT NULL,
+  "image" TEXT,
+  "role" TEXT NOT NULL DEFAULT 'user',
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "demo_expires_at" DATETIME,
+  "data_region" TEXT,
+  "disabled_at" DATETIME,
+  "token_version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  "deleted_at" DATETIME
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone_number_unique ON "users" ("phone_number") WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_phone_number ON "users" ("phone_number");
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON "users" ("deleted_at");
+CREATE INDEX IF NOT EXISTS idx_users_demo_expires_at ON "users" ("demo_expires_at") WHERE demo_expires_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_users_role ON "users" ("role") WHERE "role" <> 'user';
+
+CREATE TABLE IF NOT EXISTS "auth_providers" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "display_name" TEXT NOT NULL,
+  "name" TEXT,
+  "image" TEXT,
+  "client_id" TEXT,
+  "client_secret" TEXT,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  "deleted_at" DATETIME
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_providers_name_unique ON "auth_providers" ("name") WHERE deleted_at IS NULL AND name IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_auth_providers_deleted_at ON "auth_providers" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_auths" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "auth_provider_id" TEXT NOT NULL,
+  "credential_id" TEXT NOT NULL,
+  "credential_secret" TEXT NOT NULL,
+  "credential_refresh" TEXT,
+  "last_used_at" DATETIME,
+  "failed_login_attempts" INTEGER NOT NULL DEFAULT 0,
+  "locked_until" DATETIME,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  "deleted_at" DATETIME,
+  CONSTRAINT fk_user_auths_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_user_auths_auth_provider FOREIGN KEY ("auth_provider_id") REFERENCES "auth_providers" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_auths_user_provider ON "user_auths" ("user_id", "auth_provider_id") WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_auths_provider_credential_unique ON "user_auths" ("auth_provider_id", "credential_id") WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_auths_deleted_at ON "user_auths" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "wallets" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "name" TEXT NOT NULL,
+  "type" TEXT NOT NULL,
+  "currency" TEXT NOT NULL,
+  "opening_balance" NUMERIC NOT NULL DEFAULT 0,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_wallets_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_user_name_unique ON "wallets" ("user_id", lower("name"));
+
+CREATE TABLE IF NOT EXISTS "groups" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "name" TEXT NOT NULL,
+  "created_by" TEXT NOT NULL,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE IF NOT EXISTS "group_members" (
+  "group_id" TEXT NOT NULL,
+  "user_id" TEXT NOT NULL,
+  "role" TEXT NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("group_id", "user_id"),
+  CONSTRAINT fk_group_members_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_group_members_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_members_user_id ON "group_members" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "group_invitations" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "group_id" TEXT NOT NULL,
+  "invited_by" TEXT NOT NULL,
+  "role" TEXT NOT NULL,
+  "token_hash" TEXT NOT NULL,
+  "expires_at" DATETIME NOT NULL,
+  "accepted_by" TEXT,
+  "accepted_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_group_invitations_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_group_invitations_token_hash_unique ON "group_invitations" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_group_invitations_group_id ON "group_invitations" ("group_id");
+
+CREATE TABLE IF NOT EXISTS "money_flows" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "wallet_id" TEXT,
+  "kind" TEXT NOT NULL DEFAULT 'expense',
+  "transfer_id" TEXT,
+  "group_id" TEXT,
+  "category" TEXT,
+  "amount" NUMERIC NOT NULL,
+  "currency" TEXT NOT NULL DEFAULT 'IDR',
+  "description" TEXT,
+  "tags" TEXT DEFAULT '[]',
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  "deleted_at" DATETIME,
+  CONSTRAINT fk_money_flows_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_money_flows_wallet FOREIGN KEY ("wallet_id") REFERENCES "wallets" ("id") ON DELETE SET NULL,
+  CONSTRAINT fk_money_flows_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_money_flows_user_id ON "money_flows" ("user_id");
+CREATE INDEX IF NOT EXISTS idx_money_flows_created_at ON "money_flows" ("created_at");
+CREATE INDEX IF NOT EXISTS idx_money_flows_deleted_at ON "money_flows" ("deleted_at");
+CREATE INDEX IF NOT EXISTS idx_money_flows_category ON "money_flows" ("category");
+CREATE INDEX IF NOT EXISTS idx_money_flows_wallet_id ON "money_flows" ("wallet_id") WHERE wallet_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_money_flows_transfer_id ON "money_flows" ("transfer_id") WHERE transfer_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_money_flows_group_id ON "money_flows" ("group_id", "created_at" DESC) WHERE group_id IS NOT NULL;
+
+-- Without full-text search, notes are searched with LIKE
+CREATE TABLE IF NOT EXISTS "money_flow_notes" (
+  "money_flow_id" TEXT PRIMARY KEY,
+  "content" TEXT NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_money_flow_notes_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE,
+  CONSTRAINT chk_money_flow_notes_content_length CHECK (length(CAST("content" AS BLOB)) <= 10240)
+);
+
+CREATE TABLE IF NOT EXISTS "money_flow_splits" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "money_flow_id" TEXT NOT NULL,
+  "group_id" TEXT NOT NULL,
+  "user_id" TEXT NOT NULL,
+  "method" TEXT NOT NULL,
+  "amount" NUMERIC NOT NULL,
+  "currency" TEXT NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_money_flow_splits_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_money_flow_splits_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_money_flow_splits_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_money_flow_splits_money_flow_user_unique ON "money_flow_splits" ("money_flow_id", "user_id");
+CREATE INDEX IF NOT EXISTS idx_money_flow_splits_group_id ON "money_flow_splits" ("group_id");
+
+CREATE TABLE IF NOT EXISTS "group_settlements" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "group_id" TEXT NOT NULL,
+  "from_user_id" TEXT NOT NULL,
+  "to_user_id" TEXT NOT NULL,
+  "amount" NUMERIC NOT NULL,
+  "currency" TEXT NOT NULL,
+  "created_by" TEXT NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_group_settlements_group FOREIGN KEY ("group_id") REFERENCES "groups" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_group_settlements_group_id ON "group_settlements" ("group_id", "created_at" DESC);
+
+CREATE TABLE IF NOT EXISTS "api_keys" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "name" TEXT NOT NULL,
+  "prefix" TEXT NOT NULL,
+  "key_hash" TEXT NOT NULL,
+  "scopes" TEXT NOT NULL DEFAULT '[]',
+  "last_used_at" DATETIME,
+  "revoked_at" DATETIME,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  "deleted_at" DATETIME,
+  CONSTRAINT fk_api_keys_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash_unique ON "api_keys" ("key_hash");
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON "api_keys" ("user_id");
+CREATE INDEX IF NOT EXISTS idx_api_keys_deleted_at ON "api_keys" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "session_id" TEXT NOT NULL,
+  "token_hash" TEXT NOT NULL,
+  "expires_at" DATETIME NOT NULL,
+  "revoked_at" DATETIME,
+  "user_agent" TEXT,
+  "ip_address" TEXT,
+  "signed_in_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_refresh_tokens_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash_unique ON "refresh_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON "refresh_tokens" ("user_id") WHERE revoked_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_created ON "refresh_tokens" ("user_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON "refresh_tokens" ("session_id");
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON "refresh_tokens" ("expires_at");
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_revoked_at ON "refresh_tokens" ("revoked_at") WHERE revoked_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "password_resets" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "user_auth_id" TEXT NOT NULL,
+  "email" TEXT NOT NULL,
+  "token_hash" TEXT,
+  "sent_at" DATETIME,
+  "expires_at" DATETIME NOT NULL,
+  "used_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_password_resets_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_password_resets_user_auth FOREIGN KEY ("user_auth_id") REFERENCES "user_auths" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_resets_token_hash_unique ON "password_resets" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_password_resets_user_auth_id ON "password_resets" ("user_auth_id", "created_at");
+CREATE INDEX IF NOT EXISTS idx_password_resets_expires_at ON "password_resets" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "invitations" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "invited_by" TEXT,
+  "email" TEXT NOT NULL,
+  "channel" TEXT NOT NULL,
+  "token_hash" TEXT,
+  "sent_at" DATETIME,
+  "expires_at" DATETIME NOT NULL,
+  "accepted_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_invitations_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_invitations_invited_by FOREIGN KEY ("invited_by") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_email_unique ON "invitations" (lower("email"));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_token_hash_unique ON "invitations" ("token_hash");
+CREATE INDEX IF NOT EXISTS idx_invitations_user_id ON "invitations" ("user_id");
+CREATE INDEX IF NOT EXISTS idx_invitations_token_expires_at ON "invitations" ("expires_at") WHERE token_hash IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "user_settings" (
+  "user_id" TEXT PRIMARY KEY,
+  "analytics_opt_out" BOOLEAN NOT NULL DEFAULT false,
+  "default_currency" TEXT NOT NULL DEFAULT 'IDR',
+  "single_currency_mode" BOOLEAN NOT NULL DEFAULT false,
+  "locale" TEXT NOT NULL DEFAULT 'id-ID',
+  "timezone" TEXT NOT NULL DEFAULT 'UTC',
+  "week_start" INTEGER NOT NULL DEFAULT 1,
+  "notify_whatsapp" BOOLEAN NOT NULL DEFAULT true,
+  "notify_email" BOOLEAN NOT NULL DEFAULT true,
+  "notify_telegram" BOOLEAN NOT NULL DEFAULT true,
+  "notify_push" BOOLEAN NOT NULL DEFAULT true,
+  "telegram_chat_id" TEXT,
+  "notification_channels" TEXT NOT NULL DEFAULT '[]',
+  "digest_frequency" TEXT NOT NULL DEFAULT 'weekly',
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_user_settings_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS "budgets" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "category" TEXT NOT NULL,
+  "amount" NUMERIC NOT NULL,
+  "currency" TEXT NOT NULL DEFAULT 'IDR',
+  "hard" BOOLEAN NOT NULL DEFAULT false,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_budgets_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_user_category_unique ON "budgets" ("user_id", "category");
+
+CREATE TABLE IF NOT EXISTS "budget_overrides" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "budget_id" TEXT NOT NULL,
+  "user_id" TEXT NOT NULL,
+  "money_flow_id" TEXT NOT NULL,
+  "amount" NUMERIC NOT NULL,
+  "spent" NUMERIC NOT NULL,
+  "cap" NUMERIC NOT NULL,
+  "currency" TEXT NOT NULL DEFAULT 'IDR',
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_budget_overrides_budget FOREIGN KEY ("budget_id") REFERENCES "budgets" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_budget_overrides_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_budget_overrides_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_budget_overrides_user_id_created_at ON "budget_overrides" ("user_id", "created_at" DESC);
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "title" TEXT NOT NULL,
+  "body" TEXT NOT NULL,
+  "read_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_notifications_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id_created_at ON "notifications" ("user_id", "created_at" DESC);
+
+CREATE TABLE IF NOT EXISTS "broadcasts" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "created_by" TEXT NOT NULL,
+  "title" TEXT NOT NULL,
+  "body" TEXT NOT NULL,
+  "channels" TEXT NOT NULL DEFAULT '[]',
+  "audience" TEXT NOT NULL DEFAULT '{}',
+  "status" TEXT NOT NULL DEFAULT 'queued',
+  "recipient_count" INTEGER NOT NULL DEFAULT 0,
+  "completed_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_broadcasts_created_by FOREIGN KEY ("created_by") REFERENCES "users" ("id")
+);
+
+CREATE INDEX IF NOT EXISTS idx_broadcasts_created_at ON "broadcasts" ("created_at");
+
+CREATE TABLE IF NOT EXISTS "broadcast_deliveries" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "broadcast_id" TEXT NOT NULL,
+  "user_id" TEXT NOT NULL,
+  "channel" TEXT,
+  "status" TEXT NOT NULL DEFAULT 'pending',
+  "attempts" INTEGER NOT NULL DEFAULT 0,
+  "last_error" TEXT,
+  "sent_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_broadcast_deliveries_broadcast FOREIGN KEY ("broadcast_id") REFERENCES "broadcasts" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_broadcast_deliveries_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_broadcast_deliveries_broadcast_user ON "broadcast_deliveries" ("broadcast_id", "user_id");
+CREATE INDEX IF NOT EXISTS idx_broadcast_deliveries_pending ON "broadcast_deliveries" ("created_at") WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS "analytics_events" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "name" TEXT NOT NULL,
+  "anonymous_id" TEXT NOT NULL,
+  "properties" TEXT NOT NULL DEFAULT '{}',
+  "occurred_at" DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_events_name_occurred_at ON "analytics_events" ("name", "occurred_at");
+
+CREATE TABLE IF NOT EXISTS "api_usage_daily" (
+  "user_id" TEXT NOT NULL,
+  "day" DATE NOT NULL,
+  "method" TEXT NOT NULL,
+  "route" TEXT NOT NULL,
+  "request_count" INTEGER NOT NULL DEFAULT 0,
+  "error_count" INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY ("user_id", "day", "method", "route"),
+  CONSTRAINT fk_api_usage_daily_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_usage_daily_day ON "api_usage_daily" ("day");
+
+CREATE TABLE IF NOT EXISTS "jobs" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "type" TEXT NOT NULL,
+  "payload" TEXT NOT NULL DEFAULT '{}',
+  "status" TEXT NOT NULL DEFAULT 'pending',
+  "attempts" INTEGER NOT NULL DEFAULT 0,
+  "max_attempts" INTEGER NOT NULL DEFAULT 5,
+  "run_at" DATETIME NOT NULL DEFAULT (now()),
+  "last_error" TEXT,
+  "locked_at" DATETIME,
+  "finished_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_pending_type_run_at ON "jobs" ("type", "run_at") WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_running_locked_at ON "jobs" ("locked_at") WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_succeeded_finished_at ON "jobs" ("finished_at") WHERE status = 'succeeded';
+
+CREATE TABLE IF NOT EXISTS "outbox_events" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "type" TEXT NOT NULL,
+  "user_id" TEXT,
+  "payload" TEXT NOT NULL DEFAULT '{}',
+  "occurred_at" DATETIME NOT NULL DEFAULT (now()),
+  "dispatched_at" DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_undispatched ON "outbox_events" ("occurred_at") WHERE dispatched_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched_at ON "outbox_events" ("dispatched_at") WHERE dispatched_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "conversations" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "flow" TEXT NOT NULL,
+  "step" TEXT NOT NULL,
+  "status" TEXT NOT NULL DEFAULT 'active',
+  "data" TEXT NOT NULL DEFAULT '{}',
+  "source_message_id" TEXT,
+  "expires_at" DATETIME NOT NULL,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_conversations_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_conversations_user_active_unique ON "conversations" ("user_id") WHERE status = 'active';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_conversations_source_message_id_unique ON "conversations" ("source_message_id") WHERE source_message_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "system_settings" (
+  "key" TEXT PRIMARY KEY,
+  "value" TEXT NOT NULL,
+  "updated_by" TEXT,
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_system_settings_updated_by FOREIGN KEY ("updated_by") REFERENCES "users" ("id") ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS "exchange_rates" (
+  "base_currency" TEXT NOT NULL,
+  "currency" TEXT NOT NULL,
+  "rate_date" DATE NOT NULL,
+  "rate" NUMERIC NOT NULL,
+  "source" TEXT NOT NULL,
+  "fetched_at" DATETIME NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("base_currency", "currency", "rate_date")
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_base_date ON "exchange_rates" ("base_currency", "rate_date" DESC);
+
+CREATE TABLE IF NOT EXISTS "feedback" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "kind" TEXT NOT NULL,
+  "source" TEXT NOT NULL,
+  "message" TEXT NOT NULL,
+  "request_id" TEXT NOT NULL,
+  "last_request_id" TEXT,
+  "app_version" TEXT,
+  "user_agent" TEXT,
+  "forwarded_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_feedback_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_feedback_created_at ON "feedback" ("created_at" DESC);
+CREATE INDEX IF NOT EXISTS idx_feedback_user_id ON "feedback" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feedback_chat_request_id_unique ON "feedback" ("request_id") WHERE source = 'chat';
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "actor_id" TEXT NOT NULL,
+  "action" TEXT NOT NULL,
+  "entity_type" TEXT NOT NULL,
+  "entity_id" TEXT NOT NULL,
+  "before" TEXT,
+  "after" TEXT,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_audit_logs_actor FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_created_at ON "audit_logs" ("actor_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON "audit_logs" ("entity_type", "entity_id");
+
+CREATE TABLE IF NOT EXISTS "webhooks" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "url" TEXT NOT NULL,
+  "secret" TEXT NOT NULL,
+  "events" TEXT NOT NULL DEFAULT '[]',
+  "active" BOOLEAN NOT NULL DEFAULT true,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_webhooks_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON "webhooks" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "webhook_id" TEXT NOT NULL,
+  "event_id" TEXT NOT NULL,
+  "event_type" TEXT NOT NULL,
+  "attempt" INTEGER NOT NULL,
+  "status_code" INTEGER,
+  "error" TEXT,
+  "duration_ms" INTEGER NOT NULL DEFAULT 0,
+  "succeeded" BOOLEAN NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_webhook_deliveries_webhook FOREIGN KEY ("webhook_id") REFERENCES "webhooks" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_created_at ON "webhook_deliveries" ("webhook_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON "webhook_deliveries" ("created_at");
+
+CREATE TABLE IF NOT EXISTS "devices" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "token" TEXT NOT NULL,
+  "platform" TEXT NOT NULL,
+  "name" TEXT,
+  "last_seen_at" DATETIME NOT NULL DEFAULT (now()),
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_devices_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_devices_token ON "devices" ("token");
+CREATE INDEX IF NOT EXISTS idx_devices_user_id ON "devices" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "digests" (
+  "user_id" TEXT NOT NULL,
+  "frequency" TEXT NOT NULL,
+  "period_start" DATETIME NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("user_id", "frequency", "period_start"),
+  CONSTRAINT fk_digests_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals_update_new;
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals_update_old;
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals_delete;
+DROP TRIGGER IF EXISTS money_flows_monthly_category_totals_insert;
+DROP TABLE IF EXISTS "monthly_category_totals";
@@ -0,0 +1,84 @@
+-- Keep the expense totals of every user and group per UTC month, category, and currency.
+-- Times are stored as UTC text, so the month is their first seven characters.
+CREATE TABLE IF NOT EXISTS "monthly_category_totals" (
+  "owner_kind" TEXT NOT NULL,
+  "owner_id" TEXT NOT NULL,
+  "month" DATE NOT NULL,
+  "currency" TEXT NOT NULL,
+  "category" TEXT NOT NULL DEFAULT '',
+  "total" NUMERIC NOT NULL,
+  "count" INTEGER NOT NULL,
+  PRIMARY KEY ("owner_kind", "owner_id", "month", "currency", "category")
+);
+
+CREATE TRIGGER IF NOT EXISTS money_flows_monthly_category_totals_insert
+AFTER INSERT ON "money_flows"
+WHEN NEW."kind" = 'expense' AND NEW."deleted_at" IS NULL
+BEGIN
+  INSERT INTO "monthly_category_totals" ("owner_kind", "owner_id", "month", "currency", "category", "total", "count")
+  SELECT 'user', NEW."user_id", substr(NEW."created_at", 1, 7) || '-01', NEW."currency", COALESCE(NEW."category", ''), NEW."amount", 1
+  UNION ALL
+  SELECT 'group', NEW."group_id", substr(NEW."created_at", 1, 7) || '-01', NEW."currency", COALESCE(NEW."category", ''), NEW."amount", 1
+  WHERE NEW."group_id" IS NOT NULL
+  ON CONFLICT ("owner_kind", "owner_id", "month", "currency", "category")
+  DO UPDATE SET "total" = "total" + excluded."total", "count" = "count" + excluded."count";
+END;
+
+CREATE TRIGGER IF NOT EXISTS money_flows_monthly_category_totals_delete
+AFTER DELETE ON "money_flows"
+WHEN OLD."kind" = 'expense' AND OLD."deleted_at" IS NULL
+BEGIN
+  UPDATE "monthly_category_totals" SET "total" = "total" - OLD."amount", "count" = "count" - 1
+  WHERE "owner_kind" = 'user' AND "owner_id" = OLD."user_id"
+    AND "month" = substr(OLD."created_at", 1, 7) || '-01' AND "currency" = OLD."currency"
+    AND "category" = COALESCE(OLD."category", '');
+  UPDATE "monthly_category_totals" SET "total" = "total" - OLD."amount", "count" = "count" - 1
+  WHERE "owner_kind" = 'group' AND "owner_id" = OLD."group_id"
+    AND "month" = substr(OLD."created_at", 1, 7) || '-01' AND "currency" = OLD."currency"
+    AND "category" = COALESCE(OLD."category", '');
+  DELETE FROM "monthly_category_totals"
+  WHERE "owner_kind" IN ('user', 'group') AND "owner_id" IN (OLD."user_id", OLD."group_id") AND "count" = 0;
+END;
+
+-- An update moves the old version out of the totals and the new one in, like a delete
+-- followed by an insert
+CREATE TRIGGER IF NOT EXISTS money_flows_monthly_category_totals_update_old
+AFTER UPDATE OF "kind", "user_id", "group_id", "created_at", "currency", "category", "amount", "deleted_at" ON "money_flows"
+WHEN OLD."kind" = 'expense' AND OLD."deleted_at" IS NULL
+BEGIN
+  UPDATE "monthly_category_totals" SET "total" = "total" - OLD."amount", "count" = "count" - 1
+  WHERE "owner_kind" = 'user' AND "owner_id" = OLD."user_id"
+    AND "month" = substr(OLD."created_at", 1, 7) || '-01' AND "currency" = OLD."currency"
+    AND "category" = COALESCE(OLD."category", '');
+  UPDATE "monthly_category_totals" SET "total" = "total" - OLD."amount", "count" = "count" - 1
+  WHERE "owner_kind" = 'group' AND "owner_id" = OLD."group_id"
+    AND "month" = substr(OLD."created_at", 1, 7) || '-01' AND "currency" = OLD."currency"
+    AND "category" = COALESCE(OLD."category", '');
+  DELETE FROM "monthly_category_totals"
+  WHERE "owner_kind" IN ('user', 'group') AND "owner_id" IN (OLD."user_id", OLD."group_id") AND "count" = 0;
+END;
+
+CREATE TRIGGER IF NOT EXISTS money_flows_monthly_category_totals_update_new
+AFTER UPDATE OF "kind", "user_id", "group_id", "created_at", "currency", "category", "amount", "deleted_at" ON "money_flows"
+WHEN NEW."kind" = 'expense' AND NEW."deleted_at" IS NULL
+BEGIN
+  INSERT INTO "monthly_category_totals" ("owner_kind", "owner_id", "month", "currency", "category", "total", "count")
+  SELECT 'user', NEW."user_id", substr(NEW."created_at", 1, 7) || '-01', NEW."currency", COALESCE(NEW."category", ''), NEW."amount", 1
+  UNION ALL
+  SELECT 'group', NEW."group_id", substr(NEW."created_at", 1, 7) || '-01', NEW."currency", COALESCE(NEW."category", ''), NEW."amount", 1
+  WHERE NEW."group_id" IS NOT NULL
+  ON CONFLICT ("owner_kind", "owner_id", "month", "currency", "category")
+  DO UPDATE SET "total" = "total" + excluded."total", "count" = "count" + excluded."count";
+END;
+
+DELETE FROM "monthly_category_totals";
+INSERT INTO "monthly_category_totals" ("owner_kind", "owner_id", "month", "currency", "category", "total", "count")
+SELECT 'user', "user_id", substr("created_at", 1, 7) || '-01', "currency", COALESCE("category", ''), SUM("amount"), COUNT(*)
+FROM "money_flows"
+WHERE "kind" = 'expense' AND "deleted_at" IS NULL
+GROUP BY 2, 3, 4, 5
+UNION ALL
+SELECT 'group', "group_id", substr("created_at", 1, 7) || '-01', "currency", COALESCE("category", ''), SUM("amount"), COUNT(*)
+FROM "money_flows"
+WHERE "kind" = 'expense' AND "deleted_at" IS NULL AND "group_id" IS NOT NULL
+GROUP BY 2, 3, 4, 5;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "account_erasures" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "status" TEXT NOT NULL,
+  "erase_at" DATETIME NOT NULL,
+  "cancelled_at" DATETIME,
+  "completed_at" DATETIME,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_account_erasures_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_erasures_user_id ON "account_erasures" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_erasures_pending_unique ON "account_erasures" ("user_id") WHERE "status" = 'pending';
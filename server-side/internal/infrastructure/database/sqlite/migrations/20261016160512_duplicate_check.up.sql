@@ -0,0 +1 @@
+ALTER TABLE "user_settings" ADD COLUMN "duplicate_check" TEXT NOT NULL DEFAULT 'warn';
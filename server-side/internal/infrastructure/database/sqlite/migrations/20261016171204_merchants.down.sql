@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_money_flows_user_merchant;
+ALTER TABLE "money_flows" DROP COLUMN "merchant_id";
+
+DROP TABLE IF EXISTS "merchant_rules";
+DROP TABLE IF EXISTS "merchants";
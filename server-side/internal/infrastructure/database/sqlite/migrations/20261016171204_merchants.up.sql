@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS "merchants" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "name" TEXT NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_merchants_name_unique ON "merchants" (lower("name"));
+
+CREATE TABLE IF NOT EXISTS "merchant_rules" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "pattern" TEXT NOT NULL,
+  "merchant_id" TEXT NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_merchant_rules_merchant FOREIGN KEY ("merchant_id") REFERENCES "merchants" ("id") ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_merchant_rules_pattern_unique ON "merchant_rules" ("pattern");
+CREATE INDEX IF NOT EXISTS idx_merchant_rules_merchant_id ON "merchant_rules" ("merchant_id");
+
+ALTER TABLE "money_flows" ADD COLUMN "merchant_id" TEXT REFERENCES "merchants" ("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_money_flows_user_merchant ON "money_flows" ("user_id", "merchant_id", "created_at") WHERE merchant_id IS NOT NULL;
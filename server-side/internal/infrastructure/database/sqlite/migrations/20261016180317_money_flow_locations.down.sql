@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_money_flows_location;
+ALTER TABLE "money_flows" DROP COLUMN "place_name";
+ALTER TABLE "money_flows" DROP COLUMN "longitude";
+ALTER TABLE "money_flows" DROP COLUMN "latitude";
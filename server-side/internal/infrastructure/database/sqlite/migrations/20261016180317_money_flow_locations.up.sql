@@ -0,0 +1,5 @@
+ALTER TABLE "money_flows" ADD COLUMN "latitude" REAL;
+ALTER TABLE "money_flows" ADD COLUMN "longitude" REAL;
+ALTER TABLE "money_flows" ADD COLUMN "place_name" TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_money_flows_location ON "money_flows" ("latitude", "longitude") WHERE latitude IS NOT NULL;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "money_flow_shares" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "money_flow_id" TEXT NOT NULL,
+  "user_id" TEXT NOT NULL,
+  "include_note" BOOLEAN NOT NULL DEFAULT false,
+  "expires_at" DATETIME NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_money_flow_shares_money_flow FOREIGN KEY ("money_flow_id") REFERENCES "money_flows" ("id") ON DELETE CASCADE,
+  CONSTRAINT fk_money_flow_shares_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_money_flow_shares_money_flow_id ON "money_flow_shares" ("money_flow_id");
+CREATE INDEX IF NOT EXISTS idx_money_flow_shares_expires_at ON "money_flow_shares" ("expires_at");
@@ -0,0 +1,3 @@
+ALTER TABLE "user_settings" DROP COLUMN "monthly_statement";
+
+DROP TABLE IF EXISTS "statements";
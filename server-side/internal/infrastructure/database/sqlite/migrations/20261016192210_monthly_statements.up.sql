@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "statements" (
+  "user_id" TEXT NOT NULL,
+  "period_start" DATETIME NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("user_id", "period_start"),
+  CONSTRAINT fk_statements_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+ALTER TABLE "user_settings" ADD COLUMN "monthly_statement" BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS "phone_verifications" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "phone_number" TEXT NOT NULL,
+  "code_hash" TEXT NOT NULL,
+  "attempts" INTEGER NOT NULL DEFAULT 0,
+  "expires_at" DATETIME NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_phone_verifications_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_phone_verifications_user_id ON "phone_verifications" ("user_id", "created_at");
+CREATE INDEX IF NOT EXISTS idx_phone_verifications_expires_at ON "phone_verifications" ("expires_at");
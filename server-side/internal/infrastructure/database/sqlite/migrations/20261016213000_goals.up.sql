@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "goals" (
+  "id" TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+  "user_id" TEXT NOT NULL,
+  "name" TEXT NOT NULL,
+  "currency" TEXT NOT NULL,
+  "target_amount" NUMERIC NOT NULL,
+  "saved_amount" NUMERIC NOT NULL DEFAULT 0,
+  "deadline" DATETIME,
+  "archived_at" DATETIME,
+  "version" INTEGER NOT NULL DEFAULT 0,
+  "created_at" DATETIME NOT NULL DEFAULT (now()),
+  "updated_at" DATETIME NOT NULL DEFAULT (now()),
+  CONSTRAINT fk_goals_user FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_goals_user_id ON "goals" ("user_id", "deadline");
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type moneyFlowNoteRepository struct {
+	repository.MoneyFlowNoteRepository
+	db repository.DB
+}
+
+// NewMoneyFlowNoteRepository creates a money flow note repository that searches notes
+// with LIKE instead of full-text search and leaves everything else to fallback
+func NewMoneyFlowNoteRepository(db repository.DB, fallback repository.MoneyFlowNoteRepository) repository.MoneyFlowNoteRepository {
+	return &moneyFlowNoteRepository{MoneyFlowNoteRepository: fallback, db: db}
+}
+
+// SearchMoneyFlowIDs matches the notes containing every word of the query, case
+// insensitively for ASCII letters. Unlike full-text search, words match inside other
+// words too.
+func (r *moneyFlowNoteRepository) SearchMoneyFlowIDs(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]uuid.UUID, error) {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	var models []postgresql.MoneyFlowNoteModel
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	db = db.Model(&postgresql.MoneyFlowNoteModel{}).Select("money_flow_id")
+	for _, word := range words {
+		db = db.Where(`content LIKE ? ESCAPE '\'`, "%"+escapeLike(word)+"%")
+	}
+	res := db.
+		Where("money_flow_id IN (SELECT id FROM money_flows WHERE user_id = ? AND deleted_at IS NULL)", userID).
+		Order("updated_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(models))
+	for i, model := range models {
+		ids[i] = model.MoneyFlowID
+	}
+
+	return ids, nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern so the value matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// hasTag matches the money flows whose tags contain the tag argument
+const hasTag = "EXISTS (SELECT 1 FROM json_each(CAST(money_flows.tags AS TEXT)) WHERE value = ?)"
+
+type moneyFlowRepository struct {
+	repository.MoneyFlowRepository
+	db repository.DB
+}
+
+// NewMoneyFlowRepository creates a money flow repository that queries tags with SQLite's
+// JSON functions and leaves everything else to fallback
+func NewMoneyFlowRepository(db repository.DB, fallback repository.MoneyFlowRepository) repository.MoneyFlowRepository {
+	return &moneyFlowRepository{MoneyFlowRepository: fallback, db: db}
+}
+
+func (r *moneyFlowRepository) FindByUserIDAndTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.MoneyFlow, error) {
+	var models []postgresql.MoneyFlowModel
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	res := db.Model(&postgresql.MoneyFlowModel{}).
+		Select("id").
+		Where("user_id = ? AND "+hasTag, userID, tag).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC").
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(models))
+	for i, model := range models {
+		ids[i] = model.ID
+	}
+
+	found, err := r.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// FindByIDs does not keep the order of ids
+	byID := make(map[uuid.UUID]*domain.MoneyFlow, len(found))
+	for _, moneyFlow := range found {
+		byID[moneyFlow.ID] = moneyFlow
+	}
+	moneyFlows := make([]*domain.MoneyFlow, 0, len(ids))
+	for _, id := range ids {
+		if moneyFlow, ok := byID[id]; ok {
+			moneyFlows = append(moneyFlows, moneyFlow)
+		}
+	}
+
+	return moneyFlows, nil
+}
+
+func (r *moneyFlowRepository) CountByFilter(ctx context.Context, filter repository.MoneyFlowFilter) (int64, error) {
+	if filter.Tag == "" {
+		return r.MoneyFlowRepository.CountByFilter(ctx, filter)
+	}
+
+	var count int64
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db).Model(&postgresql.MoneyFlowModel{})
+	if filter.GroupID != nil {
+		db = db.Where("group_id = ?", *filter.GroupID)
+	} else {
+		db = db.Where("user_id = ?", filter.UserID)
+	}
+	if filter.WalletID != nil {
+		db = db.Where("wallet_id = ?", *filter.WalletID)
+	}
+
+	res := db.Where(hasTag, filter.Tag).Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *moneyFlowRepository) GetTagUsage(ctx context.Context, userID uuid.UUID) ([]*domain.TagUsage, error) {
+	var rows []struct {
+		Tag        string
+		Count      int64
+		LastUsedAt time.Time
+	}
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	res := db.Model(&postgresql.MoneyFlowModel{}).
+		Joins("CROSS JOIN json_each(CAST(money_flows.tags AS TEXT)) AS tag").
+		Select("tag.value AS tag, COUNT(DISTINCT money_flows.id) AS count, MAX(money_flows.created_at) AS last_used_at").
+		Where("money_flows.user_id = ?", userID).
+		Group("tag.value").
+		Order("count DESC, tag ASC").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	usage := make([]*domain.TagUsage, len(rows))
+	for i, row := range rows {
+		usage[i] = &domain.TagUsage{
+			Tag:        row.Tag,
+			Count:      row.Count,
+			LastUsedAt: row.LastUsedAt,
+		}
+	}
+
+	return usage, nil
+}
+
+func (r *moneyFlowRepository) RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (int64, error) {
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	// Keep the position of the first occurrence of each tag after the rename, and bump
+	// the version so clients holding the old tags get a conflict on update
+	result := db.Exec(`UPDATE money_flows SET
+		tags = (
+			SELECT COALESCE(json_group_array(renamed.name), '[]')
+			FROM (
+				SELECT CASE WHEN tag.value = ? THEN ? ELSE tag.value END AS name, MIN(tag.key) AS position
+				FROM json_each(CAST(money_flows.tags AS TEXT)) AS tag
+				GROUP BY 1
+				ORDER BY position
+			) renamed
+		),
+		version = version + 1,
+		updated_at = now()
+	WHERE user_id = ? AND deleted_at IS NULL AND `+hasTag,
+		from, to, userID, from)
+
+	return result.RowsAffected(), result.Error()
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type spendingAnalyticsRepository struct {
+	db repository.DB
+}
+
+// NewSpendingAnalyticsRepository creates a spending analytics repository that groups
+// expenses into periods in Go, as SQLite has no time zones
+func NewSpendingAnalyticsRepository(db repository.DB) repository.SpendingAnalyticsRepository {
+	return &spendingAnalyticsRepository{db: db}
+}
+
+func (r *spendingAnalyticsRepository) GetTotalsByPeriod(ctx context.Context, query repository.SpendingQuery) ([]*domain.PeriodTotal, error) {
+	location, err := time.LoadLocation(query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		CreatedAt time.Time
+		Currency  string
+		Amount    postgresql.Decimal
+	}
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	owner := db.Model(&postgresql.MoneyFlowModel{}).Where("user_id = ?", query.UserID)
+	if query.GroupID != nil {
+		owner = db.Model(&postgresql.MoneyFlowModel{}).Where("group_id = ?", *query.GroupID)
+	}
+
+	res := owner.
+		Select("created_at, currency, amount").
+		Where("kind = ? AND created_at >= ? AND created_at < ?", domain.MoneyFlowKindExpense, query.Start, query.End).
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		periodStart time.Time
+		currency    string
+	}
+	byPeriod := map[key]*domain.PeriodTotal{}
+	for _, row := range rows {
+		k := key{periodStart: periodStart(row.CreatedAt.In(location), query), currency: row.Currency}
+		total, ok := byPeriod[k]
+		if !ok {
+			total = &domain.PeriodTotal{PeriodStart: k.periodStart, Currency: k.currency}
+			byPeriod[k] = total
+		}
+		total.Total += row.Amount.Minor(row.Currency)
+		total.Count++
+	}
+
+	totals := make([]*domain.PeriodTotal, 0, len(byPeriod))
+	for _, total := range byPeriod {
+		totals = append(totals, total)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if !totals[i].PeriodStart.Equal(totals[j].PeriodStart) {
+			return totals[i].PeriodStart.Before(totals[j].PeriodStart)
+		}
+		return totals[i].Currency < totals[j].Currency
+	})
+
+	return totals, nil
+}
+
+// periodStart returns the first day of the period containing the local time t, as a
+// date in UTC like PostgreSQL's date_trunc returns it
+func periodStart(t time.Time, query repository.SpendingQuery) time.Time {
+	year, month, day := t.Date()
+	switch query.Interval {
+	case domain.TrendMonthly:
+		day = 1
+	case domain.TrendWeekly:
+		day -= (int(t.Weekday()) - int(query.WeekStart) + 7) % 7
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// GetTotalsByPlace narrows expenses down by comparing coordinates, as SQLite has no
+// geometric types
+func (r *spendingAnalyticsRepository) GetTotalsByPlace(ctx context.Context, query repository.PlaceQuery) ([]*domain.PlaceTotal, error) {
+	var rows []struct {
+		PlaceName *string
+		Latitude  float64
+		Longitude float64
+		Currency  string
+		Total     postgresql.Decimal
+		Count     int64
+	}
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	located := db.Model(&postgresql.MoneyFlowModel{}).
+		Where("user_id = ? AND kind = ? AND latitude IS NOT NULL", query.UserID, domain.MoneyFlowKindExpense)
+	if box := query.Box; box != nil {
+		located = located.Where("latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?",
+			box.MinLatitude, box.MaxLatitude, box.MinLongitude, box.MaxLongitude)
+	}
+
+	res := located.
+		Select("place_name, latitude, longitude, currency, COALESCE(SUM(amount), 0) AS total, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", query.Start, query.End).
+		Group("place_name, latitude, longitude, currency").
+		Scan(&rows)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*domain.PlaceTotal, len(rows))
+	for i, row := range rows {
+		totals[i] = &domain.PlaceTotal{
+			PlaceName: row.PlaceName,
+			Latitude:  row.Latitude,
+			Longitude: row.Longitude,
+			Currency:  row.Currency,
+			Total:     row.Total.Minor(row.Currency),
+			Count:     row.Count,
+		}
+	}
+
+	return totals, nil
+}
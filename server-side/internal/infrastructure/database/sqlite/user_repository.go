@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+type userRepository struct {
+	repository.UserRepository
+	db repository.DB
+}
+
+// NewUserRepository creates a user repository that searches users with SQLite's LIKE
+// and leaves everything else to fallback
+func NewUserRepository(db repository.DB, fallback repository.UserRepository) repository.UserRepository {
+	return &userRepository{UserRepository: fallback, db: db}
+}
+
+func (r *userRepository) Search(ctx context.Context, filter repository.UserFilter, page repository.Page) ([]*domain.User, error) {
+	var models []postgresql.UserModel
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	res := r.applyFilter(db.Model(&postgresql.UserModel{}).Select("id"), filter).
+		Order("created_at DESC").
+		Limit(page.Limit).
+		Offset(page.Offset).
+		Find(&models)
+	if err := res.Error(); err != nil {
+		return nil, err
+	}
+
+	// Pages are small; the admin console shows a few dozen users at a time
+	users := make([]*domain.User, 0, len(models))
+	for _, model := range models {
+		user, err := r.FindByID(ctx, model.ID)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (r *userRepository) Count(ctx context.Context, filter repository.UserFilter) (int64, error) {
+	var count int64
+
+	// Use GetDB to support transactions
+	db := postgresql.GetDB(ctx, r.db)
+
+	res := r.applyFilter(db.Model(&postgresql.UserModel{}), filter).
+		Count(&count)
+	if err := res.Error(); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// applyFilter restricts a query to the users matching the filter. SQLite's LIKE ignores
+// the case of ASCII letters, like PostgreSQL's ILIKE, but has no default escape character.
+func (r *userRepository) applyFilter(db repository.DB, filter repository.UserFilter) repository.DB {
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		db = db.Where(`(full_name LIKE ? ESCAPE '\' OR phone_number LIKE ? ESCAPE '\' OR id IN (
+			SELECT user_id FROM user_auths WHERE credential_id LIKE ? ESCAPE '\' AND deleted_at IS NULL
+		))`, pattern, pattern, pattern)
+	}
+	if filter.Role != "" {
+		db = db.Where("role = ?", filter.Role)
+	}
+	if filter.Disabled != nil {
+		if *filter.Disabled {
+			db = db.Where("disabled_at IS NOT NULL")
+		} else {
+			db = db.Where("disabled_at IS NULL")
+		}
+	}
+	return db
+}
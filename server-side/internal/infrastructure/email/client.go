@@ -0,0 +1,261 @@
+// Package email sends email through an SMTP server: plain text, or text with an HTML
+// alternative and attachments.
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// ErrNotConfigured is returned when no SMTP server is set
+var ErrNotConfigured = errors.New("email client is not configured")
+
+// Config holds the SMTP settings
+type Config struct {
+	Host     string
+	Port     int // defaults to 587
+	Username string
+	Password string
+	From     string // sender address, e.g. "Catetin <noreply@catetin.id>"
+}
+
+// Message is an email with a plain-text body, and optionally an HTML alternative of it
+// and attachments
+type Message struct {
+	To          string
+	Subject     string
+	Text        string
+	HTML        string // empty sends the text only
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Client sends email on behalf of the configured sender
+type Client struct {
+	config Config
+}
+
+// NewClient creates a new SMTP client
+func NewClient(config Config) *Client {
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	return &Client{config: config}
+}
+
+// Enabled reports whether the client has a server and a sender address
+func (c *Client) Enabled() bool {
+	return c.config.Host != "" && c.config.From != ""
+}
+
+// Send sends a plain-text message. STARTTLS is used when the server offers it.
+func (c *Client) Send(ctx context.Context, to, subject, body string) error {
+	return c.SendMessage(ctx, Message{To: to, Subject: subject, Text: body})
+}
+
+// SendMessage sends a message, as multipart MIME when it has an HTML alternative or
+// attachments. STARTTLS is used when the server offers it.
+func (c *Client) SendMessage(ctx context.Context, message Message) (err error) {
+	ctx, span := tracing.Start(ctx, "Email.Send")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+	content, err := compose(c.config.From, message)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.config.Username != "" {
+		auth = smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+	}
+
+	// net/smtp takes no context; a done context only stops the message before sending
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	if err := smtp.SendMail(addr, auth, senderAddress(c.config.From), []string{message.To}, content); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// compose returns a message from the sender in the format sent to the server: plain
+// text alone, else multipart with the HTML as an alternative of the text and the
+// attachments after them
+func compose(from string, message Message) ([]byte, error) {
+	if strings.ContainsAny(message.To+message.Subject, "\r\n") {
+		return nil, errors.New("recipient and subject must be a single line")
+	}
+	for _, attachment := range message.Attachments {
+		if strings.ContainsAny(attachment.Filename+attachment.ContentType, "\r\n") {
+			return nil, errors.New("attachment filename and content type must be a single line")
+		}
+	}
+
+	var out bytes.Buffer
+	for _, header := range []string{
+		"From: " + from,
+		"To: " + message.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+	} {
+		out.WriteString(header + "\r\n")
+	}
+
+	text := strings.ReplaceAll(message.Text, "\n", "\r\n")
+	if message.HTML == "" && len(message.Attachments) == 0 {
+		out.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n" + text)
+		return out.Bytes(), nil
+	}
+
+	// The body is the text, or the text and HTML as alternatives of one another
+	var body bytes.Buffer
+	bodyType := "text/plain; charset=utf-8"
+	if message.HTML == "" {
+		if err := writeQuotedPrintable(&body, text); err != nil {
+			return nil, err
+		}
+	} else {
+		alternative := multipart.NewWriter(&body)
+		bodyType = "multipart/alternative; boundary=" + alternative.Boundary()
+		for _, part := range []struct{ contentType, content string }{
+			{"text/plain; charset=utf-8", text},
+			{"text/html; charset=utf-8", strings.ReplaceAll(message.HTML, "\n", "\r\n")},
+		} {
+			w, err := alternative.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {part.contentType},
+				"Content-Transfer-Encoding": {"quoted-printable"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := writeQuotedPrintable(w, part.content); err != nil {
+				return nil, err
+			}
+		}
+		if err := alternative.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(message.Attachments) == 0 {
+		out.WriteString("Content-Type: " + bodyType + "\r\n\r\n")
+		out.Write(body.Bytes())
+		return out.Bytes(), nil
+	}
+
+	mixed := multipart.NewWriter(&out)
+	out.WriteString("Content-Type: multipart/mixed; boundary=" + mixed.Boundary() + "\r\n\r\n")
+	header := textproto.MIMEHeader{"Content-Type": {bodyType}}
+	if message.HTML == "" {
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+	}
+	w, err := mixed.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(body.Bytes()); err != nil {
+		return nil, err
+	}
+	for _, attachment := range message.Attachments {
+		w, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(w, attachment.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// writeQuotedPrintable writes content quoted-printable encoded
+func writeQuotedPrintable(w io.Writer, content string) error {
+	encoder := quotedprintable.NewWriter(w)
+	if _, err := io.WriteString(encoder, content); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// writeBase64 writes content base64 encoded in lines of 76 characters
+func writeBase64(w io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 0 {
+		line := encoded[:min(76, len(encoded))]
+		encoded = encoded[len(line):]
+		if _, err := io.WriteString(w, line+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// senderAddress returns the bare address of a From header value
+func senderAddress(from string) string {
+	if address, err := mail.ParseAddress(from); err == nil {
+		return address.Address
+	}
+	return from
+}
+
+// Ping checks that the SMTP server accepts connections and greets
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "Email.Ping")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+
+	var dialer net.Dialer
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("SMTP server did not greet: %w", err)
+	}
+	return client.Quit()
+}
@@ -0,0 +1,121 @@
+// Package exchangerate fetches reference exchange rates.
+package exchangerate
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// DefaultECBURL is the daily reference rates feed of the European Central Bank. It is
+// published around 16:00 CET on working days and covers about 30 currencies, IDR among them.
+const DefaultECBURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// Rates are the prices of one unit of Base in other currencies on a day
+type Rates struct {
+	Base  string
+	Date  time.Time
+	Rates map[string]float64
+}
+
+// ECBConfig holds the settings of the ECB feed
+type ECBConfig struct {
+	URL string // defaults to DefaultECBURL
+
+	// Timeout bounds a single request
+	Timeout time.Duration
+}
+
+// ECBClient reads the reference rates of the European Central Bank
+type ECBClient struct {
+	config     ECBConfig
+	httpClient *http.Client
+}
+
+// NewECBClient creates a new ECB client
+func NewECBClient(config ECBConfig) *ECBClient {
+	if config.URL == "" {
+		config.URL = DefaultECBURL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &ECBClient{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Name identifies the provider in stored rates
+func (c *ECBClient) Name() string {
+	return "ecb"
+}
+
+// Base is the currency the rates are quoted against
+func (c *ECBClient) Base() string {
+	return "EUR"
+}
+
+// ecbEnvelope is the layout of the feed: a day cube holding one cube per currency
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// Latest fetches the most recent rates, based on EUR
+func (c *ECBClient) Latest(ctx context.Context) (rates *Rates, err error) {
+	ctx, span := tracing.Start(ctx, "ECB.Latest")
+	defer func() { tracing.End(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ecb request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("ecb request failed: %s", resp.Status)
+	}
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(envelope.Days) == 0 {
+		return nil, fmt.Errorf("response contains no rates")
+	}
+
+	day := envelope.Days[0]
+	date, err := time.Parse("2006-01-02", day.Time)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate date %q: %w", day.Time, err)
+	}
+
+	rates = &Rates{Base: c.Base(), Date: date, Rates: make(map[string]float64, len(day.Rates))}
+	for _, r := range day.Rates {
+		rate, err := strconv.ParseFloat(r.Rate, 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s", r.Rate, r.Currency)
+		}
+		rates.Rates[r.Currency] = rate
+	}
+
+	return rates, nil
+}
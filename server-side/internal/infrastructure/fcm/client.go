@@ -0,0 +1,280 @@
+// Package fcm sends push notifications through the Firebase Cloud Messaging HTTP v1 API.
+//
+// The client authenticates as a Google service account: it signs a JWT with the
+// account's private key, exchanges it for an OAuth 2.0 access token, and reuses the
+// token until shortly before it expires.
+package fcm
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// DefaultBaseURL is the FCM API host
+const DefaultBaseURL = "https://fcm.googleapis.com"
+
+// messagingScope is the OAuth scope of the FCM send API
+const messagingScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// ErrNotConfigured is returned when no service account is set
+var ErrNotConfigured = errors.New("fcm client is not configured")
+
+// ErrTokenUnregistered is returned when the device token is no longer valid, e.g. the
+// app was uninstalled; the token should be forgotten
+var ErrTokenUnregistered = errors.New("fcm device token is not registered")
+
+// Config holds the FCM settings
+type Config struct {
+	// CredentialsFile is the path of the service account JSON key; the client is
+	// disabled when empty
+	CredentialsFile string
+
+	// ProjectID overrides the project of the service account
+	ProjectID string
+
+	BaseURL string // defaults to DefaultBaseURL
+
+	// Timeout bounds a single HTTP request
+	Timeout time.Duration
+}
+
+// Message is a notification shown on a device
+type Message struct {
+	Title string
+	Body  string
+
+	// Data is delivered to the app with the notification, e.g. the type of alert
+	Data map[string]string
+}
+
+// serviceAccount holds the fields of a service account JSON key the client uses
+type serviceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// Client sends push notifications on behalf of a Firebase project
+type Client struct {
+	config     Config
+	account    *serviceAccount
+	key        *rsa.PrivateKey
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewClient creates a new FCM client, reading the service account key when one is set
+func NewClient(config Config) (*Client, error) {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	c := &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+	if config.CredentialsFile == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(config.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account key: %w", err)
+	}
+	var account serviceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse service account key: %w", err)
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" || account.TokenURI == "" {
+		return nil, errors.New("service account key must have client_email, private_key, and token_uri")
+	}
+	if config.ProjectID == "" {
+		c.config.ProjectID = account.ProjectID
+	}
+	if c.config.ProjectID == "" {
+		return nil, errors.New("service account key has no project_id; set the project ID")
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse service account private key: %w", err)
+	}
+	c.account = &account
+	c.key = key
+	return c, nil
+}
+
+// Enabled reports whether the client has a service account
+func (c *Client) Enabled() bool {
+	return c.account != nil
+}
+
+// Send sends a notification to one device
+func (c *Client) Send(ctx context.Context, deviceToken string, message Message) (err error) {
+	ctx, span := tracing.Start(ctx, "FCM.Send")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+
+	accessToken, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"message": map[string]interface{}{
+			"token": deviceToken,
+			"notification": map[string]string{
+				"title": message.Title,
+				"body":  message.Body,
+			},
+			"data": message.Data,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", c.config.BaseURL, url.PathEscape(c.config.ProjectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fcm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var result struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(body, &result)
+
+	errorCode := result.Error.Status
+	for _, detail := range result.Error.Details {
+		if detail.ErrorCode != "" {
+			errorCode = detail.ErrorCode
+		}
+	}
+
+	switch {
+	case errorCode == "UNREGISTERED",
+		errorCode == "INVALID_ARGUMENT" && strings.Contains(result.Error.Message, "registration token"):
+		return fmt.Errorf("%w: %s", ErrTokenUnregistered, result.Error.Message)
+	case resp.StatusCode == http.StatusUnauthorized:
+		// The access token was revoked; fetch a new one on the next attempt
+		c.mu.Lock()
+		c.accessToken = ""
+		c.mu.Unlock()
+	}
+	return fmt.Errorf("fcm API error (status %d, %s): %s", resp.StatusCode, errorCode, result.Error.Message)
+}
+
+// Ping checks that the service account can obtain an access token
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "FCM.Ping")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+	_, err = c.token(ctx)
+	return err
+}
+
+// token returns a valid access token, exchanging a new signed JWT when the cached
+// token expires within a minute
+func (c *Client) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Until(c.expiresAt) > time.Minute {
+		return c.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   c.account.ClientEmail,
+		"scope": messagingScope,
+		"aud":   c.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	assertion.Header["kid"] = c.account.PrivateKeyID
+	signed, err := assertion.SignedString(c.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token request: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {signed},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		ErrorDescription string `json:"error_description"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	_ = json.Unmarshal(body, &result)
+
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		return "", fmt.Errorf("fcm token error (status %d): %s", resp.StatusCode, result.ErrorDescription)
+	}
+
+	c.accessToken = result.AccessToken
+	c.expiresAt = now.Add(time.Duration(result.ExpiresIn) * time.Second)
+	return c.accessToken, nil
+}
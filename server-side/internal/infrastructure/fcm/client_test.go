@@ -0,0 +1,112 @@
+package fcm
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newTestClient returns a client whose service account and API are served by server
+func newTestClient(t *testing.T, mux *http.ServeMux) (*Client, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	account, _ := json.Marshal(serviceAccount{
+		ProjectID:    "catetin-test",
+		PrivateKeyID: "key-1",
+		PrivateKey:   string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		ClientEmail:  "push@catetin-test.iam.gserviceaccount.com",
+		TokenURI:     server.URL + "/token",
+	})
+	path := filepath.Join(t.TempDir(), "service-account.json")
+	if err := os.WriteFile(path, account, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	client, err := NewClient(Config{CredentialsFile: path, BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, key
+}
+
+func TestSendAuthenticatesWithTheServiceAccount(t *testing.T) {
+	var key *rsa.PrivateKey
+	tokenRequests := 0
+	var sent map[string]map[string]interface{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		_, err := jwt.Parse(r.FormValue("assertion"), func(*jwt.Token) (interface{}, error) {
+			return &key.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"RS256"}))
+		if err != nil {
+			http.Error(w, `{"error_description":"bad assertion"}`, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "ya29.test", "expires_in": 3600})
+	})
+	mux.HandleFunc("POST /v1/projects/catetin-test/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ya29.test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		_, _ = w.Write([]byte(`{"name":"projects/catetin-test/messages/1"}`))
+	})
+
+	client, privateKey := newTestClient(t, mux)
+	key = privateKey
+
+	for i := 0; i < 2; i++ {
+		if err := client.Send(context.Background(), "device-token", Message{Title: "Budget exceeded", Body: "Makan is over budget"}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("%d token requests, want the access token reused", tokenRequests)
+	}
+	if sent["message"]["token"] != "device-token" {
+		t.Errorf("sent message = %v, want it addressed to the device token", sent)
+	}
+}
+
+func TestSendReportsUnregisteredTokens(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "ya29.test", "expires_in": 3600})
+	})
+	mux.HandleFunc("POST /v1/projects/catetin-test/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND",` +
+			`"details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"UNREGISTERED"}]}}`))
+	})
+
+	client, _ := newTestClient(t, mux)
+	if err := client.Send(context.Background(), "stale-token", Message{Title: "Hi"}); !errors.Is(err, ErrTokenUnregistered) {
+		t.Errorf("Send() error = %v, want %v", err, ErrTokenUnregistered)
+	}
+}
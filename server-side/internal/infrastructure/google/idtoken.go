@@ -0,0 +1,246 @@
+// Package google verifies Google ID tokens, which prove that a user signed in to the
+// Google account the token names.
+//
+// ID tokens are RS256 JWTs signed with keys Google publishes as a JSON Web Key Set. The
+// verifier caches the keys for as long as the Cache-Control header of the key set
+// allows, and fetches them again early when a token names a key it does not know.
+package google
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// DefaultCertsURL is the key set Google signs ID tokens with
+const DefaultCertsURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+const (
+	// defaultKeyTTL is how long keys are cached when the key set has no max-age
+	defaultKeyTTL = time.Hour
+
+	// refreshInterval is the least time between fetches for keys a token names but the
+	// key set lacks, so forged key IDs cannot make every request fetch the key set
+	refreshInterval = time.Minute
+
+	// leeway tolerates clock skew between Google and this server
+	leeway = time.Minute
+)
+
+// issuers are the iss claims of Google ID tokens
+var issuers = []string{"accounts.google.com", "https://accounts.google.com"}
+
+// ErrInvalidToken is returned when an ID token is malformed, expired, not signed by
+// Google, or issued to another client
+var ErrInvalidToken = errors.New("invalid google id token")
+
+// Config holds the Google ID token settings
+type Config struct {
+	CertsURL string // defaults to DefaultCertsURL
+
+	// Timeout bounds a single HTTP request
+	Timeout time.Duration
+}
+
+// Claims are the claims of a verified ID token that identify the account
+type Claims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// Verifier verifies Google ID tokens
+type Verifier struct {
+	config     Config
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	expiresAt time.Time
+	fetchedAt time.Time
+}
+
+// NewVerifier creates a new Google ID token verifier
+func NewVerifier(config Config) *Verifier {
+	if config.CertsURL == "" {
+		config.CertsURL = DefaultCertsURL
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	return &Verifier{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Verify checks the signature, expiry, issuer, and audience of an ID token issued to
+// the OAuth client audience, and returns its claims. A token failing a check returns an
+// error wrapping ErrInvalidToken; other errors mean the keys could not be fetched.
+func (v *Verifier) Verify(ctx context.Context, idToken, audience string) (claims *Claims, err error) {
+	ctx, span := tracing.Start(ctx, "Google.VerifyIDToken")
+	defer func() { tracing.End(span, err) }()
+
+	if audience == "" {
+		return nil, errors.New("google: audience is required")
+	}
+
+	var fetchErr error
+	claims = &Claims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := v.key(ctx, kid)
+		fetchErr = err
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	)
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !isIssuer(claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+func isIssuer(iss string) bool {
+	for _, issuer := range issuers {
+		if iss == issuer {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns the public key with the ID, fetching the key set when the cached one
+// expired or lacks the key. It returns nil when Google has no such key.
+func (v *Verifier) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	if now.Before(v.expiresAt) {
+		if key, ok := v.keys[kid]; ok || now.Sub(v.fetchedAt) < refreshInterval {
+			return key, nil
+		}
+	}
+
+	keys, ttl, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.keys = keys
+	v.fetchedAt = now
+	v.expiresAt = now.Add(ttl)
+	return keys[kid], nil
+}
+
+// jsonWebKey is an RSA key of a JSON Web Key Set
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// fetchKeys fetches the key set, returning the RSA keys by ID and how long they may be
+// cached
+func (v *Verifier) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.config.CertsURL, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("google certs request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("google certs error (status %d)", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &set); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode google certs: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		key, err := parseRSAKey(jwk)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse google key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, maxAge(resp.Header.Get("Cache-Control")), nil
+}
+
+func parseRSAKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("unsupported exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+// maxAge returns the max-age of a Cache-Control header, or defaultKeyTTL without one
+func maxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age=")
+		if !ok {
+			continue
+		}
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultKeyTTL
+}
@@ -0,0 +1,235 @@
+package google
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testAudience = "1234.apps.googleusercontent.com"
+
+// testKeys serves a key set and signs ID tokens with its keys
+type testKeys struct {
+	t       *testing.T
+	keys    map[string]*rsa.PrivateKey
+	served  []string // IDs of the keys in the key set
+	fetches int
+}
+
+func newTestKeys(t *testing.T, kids ...string) *testKeys {
+	t.Helper()
+	k := &testKeys{t: t, keys: make(map[string]*rsa.PrivateKey)}
+	for _, kid := range kids {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("GenerateKey() error = %v", err)
+		}
+		k.keys[kid] = key
+	}
+	k.served = kids
+	return k
+}
+
+// verifier returns a verifier fetching the key set from a test server
+func (k *testKeys) verifier(cacheControl string) *Verifier {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		k.fetches++
+		var set struct {
+			Keys []jsonWebKey `json:"keys"`
+		}
+		for _, kid := range k.served {
+			key := k.keys[kid].PublicKey
+			set.Keys = append(set.Keys, jsonWebKey{
+				Kid: kid,
+				Kty: "RSA",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		_ = json.NewEncoder(w).Encode(set)
+	}))
+	k.t.Cleanup(server.Close)
+	return NewVerifier(Config{CertsURL: server.URL})
+}
+
+// sign returns an ID token signed by the key with the ID
+func (k *testKeys) sign(kid string, claims jwt.MapClaims) string {
+	k.t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(k.keys[kid])
+	if err != nil {
+		k.t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"aud":            testAudience,
+		"sub":            "109876543210987654321",
+		"email":          "budi@gmail.com",
+		"email_verified": true,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestVerifyAcceptsGoogleTokens(t *testing.T) {
+	keys := newTestKeys(t, "key-1")
+	verifier := keys.verifier("public, max-age=3600")
+
+	for _, iss := range issuers {
+		claims := validClaims()
+		claims["iss"] = iss
+		got, err := verifier.Verify(context.Background(), keys.sign("key-1", claims), testAudience)
+		if err != nil {
+			t.Fatalf("Verify() with issuer %q error = %v", iss, err)
+		}
+		if got.Subject != "109876543210987654321" || got.Email != "budi@gmail.com" || !got.EmailVerified {
+			t.Errorf("Verify() = %+v, want the claims of the token", got)
+		}
+	}
+	if keys.fetches != 1 {
+		t.Errorf("key set fetched %d times, want once while cached", keys.fetches)
+	}
+}
+
+func TestVerifyRejectsInvalidTokens(t *testing.T) {
+	keys := newTestKeys(t, "key-1", "other")
+	keys.served = []string{"key-1"}
+	verifier := keys.verifier("")
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{"another audience", func() string {
+			claims := validClaims()
+			claims["aud"] = "5678.apps.googleusercontent.com"
+			return keys.sign("key-1", claims)
+		}},
+		{"another issuer", func() string {
+			claims := validClaims()
+			claims["iss"] = "https://evil.example.com"
+			return keys.sign("key-1", claims)
+		}},
+		{"expired", func() string {
+			claims := validClaims()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return keys.sign("key-1", claims)
+		}},
+		{"without expiry", func() string {
+			claims := validClaims()
+			delete(claims, "exp")
+			return keys.sign("key-1", claims)
+		}},
+		{"without subject", func() string {
+			claims := validClaims()
+			delete(claims, "sub")
+			return keys.sign("key-1", claims)
+		}},
+		{"unknown key", func() string {
+			return keys.sign("other", validClaims())
+		}},
+		{"signed by another key", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims())
+			token.Header["kid"] = "key-1"
+			signed, _ := token.SignedString(keys.keys["other"])
+			return signed
+		}},
+		{"HMAC", func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+			token.Header["kid"] = "key-1"
+			signed, _ := token.SignedString([]byte("secret"))
+			return signed
+		}},
+		{"malformed", func() string { return "not-a-token" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(), tt.token(), testAudience)
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestVerifyFetchesRotatedKeys(t *testing.T) {
+	keys := newTestKeys(t, "old", "new")
+	keys.served = []string{"old"}
+	verifier := keys.verifier("max-age=3600")
+
+	if _, err := verifier.Verify(context.Background(), keys.sign("old", validClaims()), testAudience); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	// Google rotates its keys; a token with a new key fetches the set again, but not
+	// more than once a minute
+	keys.served = []string{"old", "new"}
+	verifier.fetchedAt = verifier.fetchedAt.Add(-refreshInterval)
+	if _, err := verifier.Verify(context.Background(), keys.sign("new", validClaims()), testAudience); err != nil {
+		t.Fatalf("Verify() with a rotated key error = %v", err)
+	}
+	if keys.fetches != 2 {
+		t.Errorf("key set fetched %d times, want 2", keys.fetches)
+	}
+
+	claims := validClaims()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "forged"
+	signed, _ := token.SignedString(keys.keys["new"])
+	if _, err := verifier.Verify(context.Background(), signed, testAudience); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() with an unknown key error = %v, want ErrInvalidToken", err)
+	}
+	if keys.fetches != 2 {
+		t.Errorf("key set fetched %d times after an unknown key, want no fetch within a minute", keys.fetches)
+	}
+}
+
+func TestVerifyWithoutKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	keys := newTestKeys(t, "key-1")
+
+	verifier := NewVerifier(Config{CertsURL: server.URL})
+	_, err := verifier.Verify(context.Background(), keys.sign("key-1", validClaims()), testAudience)
+	if err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() error = %v, want a fetch error rather than ErrInvalidToken", err)
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"public, max-age=19204, must-revalidate, no-transform", 19204 * time.Second},
+		{"max-age=0", 0},
+		{"no-cache", defaultKeyTTL},
+		{"", defaultKeyTTL},
+	}
+	for _, tt := range tests {
+		if got := maxAge(tt.header); got != tt.want {
+			t.Errorf("maxAge(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
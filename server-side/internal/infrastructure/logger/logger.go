@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type contextKey struct{}
+
+// Log output formats
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Config holds the logger settings
+type Config struct {
+	Level  string // debug, info, warn, or error
+	Format string // FormatJSON or FormatConsole
+}
+
+// New creates the application logger together with the controller of its level.
+// JSON suits log aggregation; console emits human-readable text.
+func New(config Config) (*slog.Logger, *LevelController, error) {
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	controller := &LevelController{base: level}
+	controller.level.Set(level)
+
+	options := &slog.HandlerOptions{Level: &controller.level}
+	switch config.Format {
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(os.Stdout, options)), controller, nil
+	case FormatConsole:
+		return slog.New(slog.NewTextHandler(os.Stdout, options)), controller, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown log format %q, expected %s or %s", config.Format, FormatJSON, FormatConsole)
+	}
+}
+
+// ParseLevel parses debug, info, warn, or error (case-insensitive)
+func ParseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q, expected debug, info, warn, or error", value)
+	}
+	return level, nil
+}
+
+// LevelController changes the level of a running logger, e.g. to enable debug
+// logs during an incident without a restart
+type LevelController struct {
+	level slog.LevelVar
+	base  slog.Level // configured level, restored by Reset
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	resetAt *time.Time
+	changes int // lets a timer that fired during a newer change skip its reset
+}
+
+// Level returns the current level
+func (c *LevelController) Level() slog.Level {
+	return c.level.Level()
+}
+
+// Base returns the configured level
+func (c *LevelController) Base() slog.Level {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.base
+}
+
+// SetBase changes the configured level, e.g. when the configuration is reloaded. A
+// temporary level stays until it reverts, to the new configured level.
+func (c *LevelController) SetBase(level slog.Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.base = level
+	if c.timer == nil {
+		c.level.Set(level)
+	}
+}
+
+// ResetAt returns when a temporary level reverts to the configured one, if set
+func (c *LevelController) ResetAt() *time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resetAt
+}
+
+// Set changes the level. A positive duration reverts to the configured level once
+// it elapses; zero keeps the level until the next change or restart.
+func (c *LevelController) Set(level slog.Level, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopTimer()
+	c.level.Set(level)
+
+	if duration > 0 {
+		resetAt := time.Now().Add(duration)
+		change := c.changes
+		c.resetAt = &resetAt
+		c.timer = time.AfterFunc(duration, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.changes == change {
+				c.stopTimer()
+				c.level.Set(c.base)
+			}
+		})
+	}
+}
+
+// Reset restores the configured level
+func (c *LevelController) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopTimer()
+	c.level.Set(c.base)
+}
+
+func (c *LevelController) stopTimer() {
+	c.changes++
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	c.resetAt = nil
+}
+
+// WithContext returns a copy of ctx carrying the logger
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, falling back to slog.Default().
+// Loggers attached by the request middleware include the request ID.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,80 @@
+// Package metrics registers Prometheus metrics with client_golang and serves them for
+// scraping.
+//
+// Metrics are registered once at startup on a Registry; recording a value is safe
+// from any goroutine. Series are created on first use of their label values, so keep
+// label values to small, fixed sets.
+package metrics
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultLatencyBuckets are histogram bucket upper bounds, in seconds, suited to calls
+// of external APIs
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Registry holds the registered metrics
+type Registry struct {
+	registry *prometheus.Registry
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{registry: prometheus.NewRegistry()}
+}
+
+// NewCounterVec registers a counter with the label names. It panics when the name is
+// already registered.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	r.registry.MustRegister(vec)
+	return &CounterVec{vec: vec}
+}
+
+// NewHistogramVec registers a histogram with the bucket upper bounds and label names.
+// It panics when the name is already registered.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	buckets = slices.Sorted(slices.Values(buckets))
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+	r.registry.MustRegister(vec)
+	return &HistogramVec{vec: vec}
+}
+
+// Handler serves the metrics for scraping
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
+
+// CounterVec is a counter partitioned by labels
+type CounterVec struct {
+	vec *prometheus.CounterVec
+}
+
+// Inc adds one to the series of the label values
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.vec.WithLabelValues(labelValues...).Inc()
+}
+
+// Add adds a non-negative value to the series of the label values; negative values
+// are ignored
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+	c.vec.WithLabelValues(labelValues...).Add(value)
+}
+
+// HistogramVec is a histogram partitioned by labels
+type HistogramVec struct {
+	vec *prometheus.HistogramVec
+}
+
+// Observe records a value in the series of the label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.vec.WithLabelValues(labelValues...).Observe(value)
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryHandler(t *testing.T) {
+	registry := NewRegistry()
+	requests := registry.NewCounterVec("test_requests_total", "Requests handled.", "route")
+	requests.Inc("/a")
+	requests.Add(2, "/a")
+	requests.Add(-1, "/a")
+	requests.Inc(`/b"`)
+	duration := registry.NewHistogramVec("test_duration_seconds", "Request duration.", []float64{0.5, 0.1}, "route")
+	duration.Observe(0.2, "/a")
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := recorder.Body.String()
+	for _, want := range []string{
+		"# TYPE test_requests_total counter",
+		`test_requests_total{route="/a"} 3`,
+		`test_requests_total{route="/b\""} 1`,
+		`test_duration_seconds_bucket{route="/a",le="0.1"} 0`,
+		`test_duration_seconds_bucket{route="/a",le="0.5"} 1`,
+		`test_duration_seconds_bucket{route="/a",le="+Inf"} 1`,
+		`test_duration_seconds_count{route="/a"} 1`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("metrics lack %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestRegistryRejectsDuplicateNames(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounterVec("test_total", "A counter.")
+	defer func() {
+		if recover() == nil {
+			t.Error("registering test_total twice did not panic")
+		}
+	}()
+	registry.NewCounterVec("test_total", "The same counter.")
+}
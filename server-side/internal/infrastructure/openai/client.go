@@ -0,0 +1,254 @@
+// Package openai calls the OpenAI chat completions API.
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultBaseURL is the OpenAI API base URL
+const DefaultBaseURL = "https://api.openai.com/v1"
+
+// ErrNotConfigured is returned when no API key is set
+var ErrNotConfigured = errors.New("openai client is not configured")
+
+// Config holds the OpenAI API settings
+type Config struct {
+	APIKey  string
+	Model   string
+	BaseURL string // defaults to DefaultBaseURL
+
+	// VisionModel reads images; defaults to Model, which must then accept images
+	VisionModel string
+
+	// Timeout bounds a single request
+	Timeout time.Duration
+}
+
+// Client calls the chat completions API
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new OpenAI client
+func NewClient(config Config) *Client {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.Model == "" {
+		config.Model = "gpt-4o-mini"
+	}
+	if config.VisionModel == "" {
+		config.VisionModel = config.Model
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Enabled reports whether the client has an API key
+func (c *Client) Enabled() bool {
+	return c.config.APIKey != ""
+}
+
+// APIError is an error response of the OpenAI API
+type APIError struct {
+	StatusCode int
+	Type       string
+	Code       string
+	Message    string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("openai api error (http %d, %s): %s", e.StatusCode, e.Type, e.Message)
+}
+
+// Temporary reports whether retrying the request later may succeed
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// CompleteJSON sends the system and user messages and decodes the model's reply,
+// which is constrained to a JSON object, into out
+func (c *Client) CompleteJSON(ctx context.Context, system, user string, out interface{}) error {
+	return c.complete(ctx, c.config.Model, []chatMessage{
+		{Role: "system", Content: system},
+		{Role: "user", Content: user},
+	}, out)
+}
+
+// CompleteJSONWithImage is CompleteJSON with an image, such as a photo of a receipt,
+// attached to the user message. mediaType is the image's type, e.g. image/jpeg.
+func (c *Client) CompleteJSONWithImage(ctx context.Context, system, user string, image []byte, mediaType string, out interface{}) error {
+	return c.complete(ctx, c.config.VisionModel, []chatMessage{
+		{Role: "system", Content: system},
+		{Role: "user", Content: []contentPart{
+			{Type: "text", Text: user},
+			{Type: "image_url", ImageURL: &imageURL{
+				URL: "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(image),
+			}},
+		}},
+	}, out)
+}
+
+func (c *Client) complete(ctx context.Context, model string, messages []chatMessage, out interface{}) (err error) {
+	ctx, span := tracing.Start(ctx, "OpenAI.ChatCompletion", attribute.String("openai.model", model))
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+
+	payload, err := json.Marshal(chatRequest{
+		Model:          model,
+		Messages:       messages,
+		ResponseFormat: responseFormat{Type: "json_object"},
+		Temperature:    0,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("openai request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var errResp errorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.Error.Message != "" {
+			apiErr.Type = errResp.Error.Type
+			apiErr.Code = errResp.Error.Code
+			apiErr.Message = errResp.Error.Message
+		}
+		return apiErr
+	}
+
+	var completion chatResponse
+	if err := json.Unmarshal(body, &completion); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return errors.New("response contains no choices")
+	}
+
+	span.SetAttributes(
+		attribute.Int("openai.prompt_tokens", completion.Usage.PromptTokens),
+		attribute.Int("openai.completion_tokens", completion.Usage.CompletionTokens),
+	)
+
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), out); err != nil {
+		return fmt.Errorf("model reply is not the expected JSON: %w", err)
+	}
+	return nil
+}
+
+// Chat completions request and response payloads
+
+type chatRequest struct {
+	Model          string         `json:"model"`
+	Messages       []chatMessage  `json:"messages"`
+	ResponseFormat responseFormat `json:"response_format"`
+	Temperature    float64        `json:"temperature"`
+}
+
+// chatMessage is a request message; Content is a string or a []contentPart
+type chatMessage struct {
+	Role    string      `json:"role"`
+	Content interface{} `json:"content"`
+}
+
+type contentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *imageURL `json:"image_url,omitempty"`
+}
+
+type imageURL struct {
+	URL string `json:"url"`
+}
+
+type responseFormat struct {
+	Type string `json:"type"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    string `json:"code"`
+	} `json:"error"`
+}
+
+// Ping checks that the API is reachable and accepts the API key, by listing models
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "OpenAI.Ping")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+"/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("openai request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+	return nil
+}
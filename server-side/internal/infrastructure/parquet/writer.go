@@ -0,0 +1,230 @@
+// Package parquet writes flat tables in the Apache Parquet format.
+//
+// It is a small layer over parquet-go for what the analytics exports need: top-level
+// required or optional columns, kept in the order they are declared, with values
+// checked against the schema. The files are Snappy-compressed and can be read by
+// DuckDB, BigQuery, Spark, pandas, and any other Parquet reader.
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// DefaultRowGroupSize is the number of rows buffered in memory before they are written
+const DefaultRowGroupSize = 100000
+
+// Type is the type of a column
+type Type int
+
+const (
+	// String is UTF-8 text; values are string or *string
+	String Type = iota
+	// Int64 values are int, int64, or *int64
+	Int64
+	// Double values are float64 or *float64
+	Double
+	// Timestamp is an instant in UTC with microsecond precision; values are time.Time or *time.Time
+	Timestamp
+)
+
+// Name returns the name of the type, e.g. for schema manifests
+func (t Type) Name() string {
+	switch t {
+	case String:
+		return "string"
+	case Int64:
+		return "int64"
+	case Double:
+		return "double"
+	case Timestamp:
+		return "timestamp"
+	}
+	return fmt.Sprintf("type(%d)", int(t))
+}
+
+// node returns the Parquet schema node of the type
+func (t Type) node() parquet.Node {
+	switch t {
+	case String:
+		return parquet.String()
+	case Int64:
+		return parquet.Int(64)
+	case Double:
+		return parquet.Leaf(parquet.DoubleType)
+	default:
+		return parquet.Timestamp(parquet.Microsecond)
+	}
+}
+
+// Column is a column of the schema
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool // optional columns accept nil values
+}
+
+// Writer writes rows to a Parquet file. Rows are buffered per row group; call Close
+// to write the remaining rows and the file footer.
+type Writer struct {
+	file    *parquet.Writer
+	columns []Column
+	closed  bool
+}
+
+// NewWriter returns a writer of the columns to w.
+// A rowGroupSize of 0 uses DefaultRowGroupSize.
+func NewWriter(w io.Writer, columns []Column, rowGroupSize int) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("parquet: schema has no columns")
+	}
+	fields := make([]parquet.Field, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for i, column := range columns {
+		if column.Name == "" || seen[column.Name] {
+			return nil, fmt.Errorf("parquet: invalid or duplicate column name %q", column.Name)
+		}
+		seen[column.Name] = true
+
+		node := column.Type.node()
+		if column.Optional {
+			node = parquet.Optional(node)
+		}
+		fields[i] = field{Node: node, name: column.Name}
+	}
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultRowGroupSize
+	}
+
+	config, err := parquet.NewWriterConfig(
+		parquet.NewSchema("schema", group{fields: fields}),
+		parquet.MaxRowsPerRowGroup(int64(rowGroupSize)),
+		parquet.Compression(&parquet.Snappy),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parquet: %w", err)
+	}
+
+	return &Writer{file: parquet.NewWriter(w, config), columns: columns}, nil
+}
+
+// SetMetadata adds a key-value pair to the file footer
+func (w *Writer) SetMetadata(key, value string) {
+	w.file.SetKeyValueMetadata(key, value)
+}
+
+// Write appends a row with one value per column, in schema order
+func (w *Writer) Write(row ...interface{}) error {
+	if w.closed {
+		return errors.New("parquet: writer is closed")
+	}
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("parquet: row has %d values, schema has %d columns", len(row), len(w.columns))
+	}
+
+	values := make(parquet.Row, len(row))
+	for i, value := range row {
+		column := w.columns[i]
+		v, err := convert(column.Type, value)
+		if err != nil {
+			return fmt.Errorf("parquet: column %s: %w", column.Name, err)
+		}
+		definition := 0
+		switch {
+		case !v.IsNull() && column.Optional:
+			definition = 1
+		case v.IsNull() && !column.Optional:
+			return fmt.Errorf("parquet: column %s is required", column.Name)
+		}
+		values[i] = v.Level(0, definition, i)
+	}
+
+	_, err := w.file.WriteRows([]parquet.Row{values})
+	return err
+}
+
+// Close writes the buffered rows and the footer. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.file.Close()
+}
+
+// convert returns the Parquet value of a Go value, or a null value for nil
+func convert(t Type, value interface{}) (parquet.Value, error) {
+	switch t {
+	case String:
+		switch v := value.(type) {
+		case string:
+			return parquet.ByteArrayValue([]byte(v)), nil
+		case *string:
+			if v == nil {
+				return parquet.NullValue(), nil
+			}
+			return convert(t, *v)
+		}
+	case Int64:
+		switch v := value.(type) {
+		case int:
+			return parquet.Int64Value(int64(v)), nil
+		case int64:
+			return parquet.Int64Value(v), nil
+		case *int64:
+			if v == nil {
+				return parquet.NullValue(), nil
+			}
+			return convert(t, *v)
+		}
+	case Double:
+		switch v := value.(type) {
+		case float64:
+			return parquet.DoubleValue(v), nil
+		case *float64:
+			if v == nil {
+				return parquet.NullValue(), nil
+			}
+			return convert(t, *v)
+		}
+	case Timestamp:
+		switch v := value.(type) {
+		case time.Time:
+			return parquet.Int64Value(v.UnixMicro()), nil
+		case *time.Time:
+			if v == nil {
+				return parquet.NullValue(), nil
+			}
+			return convert(t, *v)
+		}
+	}
+
+	if value == nil {
+		return parquet.NullValue(), nil
+	}
+	return parquet.Value{}, fmt.Errorf("unsupported %T value for a %s column", value, t.Name())
+}
+
+// group is the root of the schema. Unlike parquet.Group, which sorts its fields by
+// name, it keeps the columns in the order they are declared.
+type group struct {
+	parquet.Group
+	fields []parquet.Field
+}
+
+func (g group) Fields() []parquet.Field { return g.fields }
+
+type field struct {
+	parquet.Node
+	name string
+}
+
+func (f field) Name() string { return f.name }
+
+// Value is only used to write Go values; the writer writes parquet.Row values
+func (f field) Value(base reflect.Value) reflect.Value { return reflect.Value{} }
@@ -0,0 +1,237 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: Int64},
+		{Name: "description", Type: String, Optional: true},
+		{Name: "created_at", Type: Timestamp},
+		{Name: "rate", Type: Double, Optional: true},
+		{Name: "deleted_at", Type: Timestamp, Optional: true},
+	}
+	jakarta := time.FixedZone("WIB", 7*3600)
+	created := time.Date(2026, 10, 16, 9, 30, 15, 123456789, jakarta)
+	text, rate := "Kopi susu", 1.25
+
+	var out bytes.Buffer
+	w, err := NewWriter(&out, columns, 2)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	w.SetMetadata("dataset", "money_flows")
+	rows := [][]interface{}{
+		{1, &text, created, &rate, nil},
+		{int64(2), nil, &created, nil, &created},
+		{int64(-3), "", created.Add(time.Hour), 0.5, (*time.Time)(nil)},
+		{4, "Ongkos parkir, Rp2.000", created, (*float64)(nil), nil},
+		{5, (*string)(nil), created, math.Inf(-1), created},
+	}
+	for _, row := range rows {
+		if err := w.Write(row...); err != nil {
+			t.Fatalf("Write(%v) error = %v", row, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	file := readFile(t, out.Bytes())
+	if file.numRows != 5 || !reflect.DeepEqual(file.rowGroups, []int64{2, 2, 1}) {
+		t.Errorf("file has %d rows in groups %v, want 5 in groups of 2", file.numRows, file.rowGroups)
+	}
+	if file.metadata["dataset"] != "money_flows" {
+		t.Errorf("metadata = %v, want the dataset", file.metadata)
+	}
+
+	wantSchema := []schemaColumn{
+		{name: "id", physical: "INT64", repetition: "REQUIRED", logical: "INT(64,true)"},
+		{name: "description", physical: "BYTE_ARRAY", repetition: "OPTIONAL", logical: "STRING"},
+		{name: "created_at", physical: "INT64", repetition: "REQUIRED", logical: "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)"},
+		{name: "rate", physical: "DOUBLE", repetition: "OPTIONAL"},
+		{name: "deleted_at", physical: "INT64", repetition: "OPTIONAL", logical: "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)"},
+	}
+	if !reflect.DeepEqual(file.schema, wantSchema) {
+		t.Errorf("schema = %+v\nwant %+v", file.schema, wantSchema)
+	}
+
+	micros := func(t time.Time) interface{} { return t.UnixMicro() }
+	want := map[string][]interface{}{
+		"id":          {int64(1), int64(2), int64(-3), int64(4), int64(5)},
+		"description": {text, nil, "", "Ongkos parkir, Rp2.000", nil},
+		"created_at":  {micros(created), micros(created), micros(created.Add(time.Hour)), micros(created), micros(created)},
+		"rate":        {rate, nil, 0.5, nil, math.Inf(-1)},
+		"deleted_at":  {nil, micros(created), nil, nil, micros(created)},
+	}
+	for name, values := range want {
+		if !reflect.DeepEqual(file.columns[name], values) {
+			t.Errorf("column %s = %v, want %v", name, file.columns[name], values)
+		}
+	}
+	// Timestamps keep the instant at microsecond precision, whatever the zone
+	if got := time.UnixMicro(file.columns["created_at"][0].(int64)).UTC(); !got.Equal(created.Truncate(time.Microsecond)) {
+		t.Errorf("created_at = %v, want %v", got, created.Truncate(time.Microsecond))
+	}
+}
+
+func TestWriterEncodesLongRunsOfNulls(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(&out, []Column{{Name: "note", Type: String, Optional: true}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs longer than 63 need more than one byte for their RLE header
+	var want []interface{}
+	for i := 0; i < 300; i++ {
+		var value interface{}
+		if i >= 100 && i < 103 || i == 299 {
+			value = fmt.Sprintf("note %d", i)
+		}
+		if err := w.Write(value); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		want = append(want, value)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file := readFile(t, out.Bytes())
+	if !reflect.DeepEqual(file.columns["note"], want) {
+		t.Errorf("note = %v, want %v", file.columns["note"], want)
+	}
+}
+
+func TestWriterWithoutRows(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(&out, []Column{{Name: "id", Type: Int64}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file := readFile(t, out.Bytes())
+	if file.numRows != 0 || len(file.rowGroups) != 0 || len(file.schema) != 1 {
+		t.Errorf("file = %+v, want the schema without row groups", file)
+	}
+}
+
+func TestWriterRejectsInvalidRows(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, []Column{{Name: "a", Type: Int64}, {Name: "a", Type: String}}, 0); err == nil {
+		t.Error("NewWriter() accepted duplicate column names")
+	}
+
+	var out bytes.Buffer
+	w, err := NewWriter(&out, []Column{{Name: "id", Type: Int64}, {Name: "note", Type: String, Optional: true}}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]interface{}{
+		{1},              // too few values
+		{nil, "note"},    // required value missing
+		{1, 2.5},         // wrong type
+		{"1", "note"},    // wrong type of a required column
+		{1, "a", "more"}, // too many values
+	} {
+		if err := w.Write(row...); err == nil {
+			t.Errorf("Write(%v) was accepted", row)
+		}
+	}
+	if err := w.Write(7, "kept"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(8, nil); err == nil {
+		t.Error("Write() after Close was accepted")
+	}
+
+	// Rejected rows leave no values behind that would misalign the columns
+	file := readFile(t, out.Bytes())
+	if !reflect.DeepEqual(file.columns["id"], []interface{}{int64(7)}) || !reflect.DeepEqual(file.columns["note"], []interface{}{"kept"}) {
+		t.Errorf("columns = %v, want the valid row only", file.columns)
+	}
+}
+
+// parquetFile is what the tests read back from a file with parquet-go's reader
+type parquetFile struct {
+	numRows   int64
+	rowGroups []int64 // rows per group
+	schema    []schemaColumn
+	metadata  map[string]string
+	columns   map[string][]interface{} // values of every row, nil for nulls
+}
+
+type schemaColumn struct {
+	name       string
+	physical   string
+	repetition string
+	logical    string
+}
+
+// readFile opens a file and reads back its schema, metadata, and values
+func readFile(t *testing.T, data []byte) *parquetFile {
+	t.Helper()
+
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("output is not a Parquet file: %v", err)
+	}
+
+	file := &parquetFile{numRows: f.NumRows(), metadata: map[string]string{}, columns: map[string][]interface{}{}}
+	for _, group := range f.RowGroups() {
+		file.rowGroups = append(file.rowGroups, group.NumRows())
+	}
+	for _, kv := range f.Metadata().KeyValueMetadata {
+		file.metadata[kv.Key] = kv.Value
+	}
+	for _, element := range f.Metadata().Schema[1:] {
+		column := schemaColumn{name: element.Name, physical: element.Type.String(), repetition: element.RepetitionType.String()}
+		if element.LogicalType != nil {
+			column.logical = element.LogicalType.String()
+		}
+		file.schema = append(file.schema, column)
+	}
+
+	reader := parquet.NewReader(f)
+	defer reader.Close()
+	rows := make([]parquet.Row, f.NumRows())
+	if n, err := reader.ReadRows(rows); n != len(rows) || err != nil && err != io.EOF {
+		t.Fatalf("read %d of %d rows: %v", n, len(rows), err)
+	}
+	for _, row := range rows {
+		for _, value := range row {
+			name := file.schema[value.Column()].name
+			file.columns[name] = append(file.columns[name], goValue(value))
+		}
+	}
+	return file
+}
+
+// goValue converts a value read back to the Go type the tests compare against
+func goValue(value parquet.Value) interface{} {
+	switch {
+	case value.IsNull():
+		return nil
+	case value.Kind() == parquet.ByteArray:
+		return string(value.ByteArray())
+	case value.Kind() == parquet.Double:
+		return value.Double()
+	default:
+		return value.Int64()
+	}
+}
@@ -0,0 +1,115 @@
+// Package pdf writes simple text documents as PDF files.
+//
+// It is a small layer over fpdf: A4 pages of lines flowing top to bottom, set in
+// the standard PDF fonts, which every viewer has built in, so no fonts are
+// embedded. Text is encoded as WinAnsi (Latin-1 plus typographic punctuation);
+// other characters print as "?". Use a Courier font for columns that must line up.
+package pdf
+
+import (
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// ContentType is the media type of PDF files
+const ContentType = "application/pdf"
+
+// Font is one of the standard fonts
+type Font string
+
+// Standard fonts
+const (
+	Helvetica     Font = "Helvetica"
+	HelveticaBold Font = "Helvetica-Bold"
+	Courier       Font = "Courier"
+	CourierBold   Font = "Courier-Bold"
+)
+
+// margin is the page margin, in points
+const margin = 50.0
+
+// lineSpacing is the line height as a multiple of the font size
+const lineSpacing = 1.4
+
+// Document is a PDF document under construction
+type Document struct {
+	pdf *fpdf.Fpdf
+}
+
+// New creates a document with one empty page
+func New(title string) *Document {
+	f := fpdf.New("P", "pt", "A4", "")
+	f.SetMargins(margin, margin, margin)
+	f.SetAutoPageBreak(true, margin)
+	f.SetTitle(title, true)
+	f.SetProducer("Catetin", false)
+	f.AddPage()
+	return &Document{pdf: f}
+}
+
+// Text adds a line of text, starting a new page when the current one is full
+func (d *Document) Text(font Font, size float64, text string) {
+	family, style, _ := strings.Cut(string(font), "-")
+	if style == "Bold" {
+		style = "B"
+	}
+	d.pdf.SetFont(family, style, size)
+	d.pdf.CellFormat(0, size*lineSpacing, encode(text), "", 1, "L", false, 0, "")
+}
+
+// Rule adds a horizontal line across the page
+func (d *Document) Rule() {
+	width, height := d.pdf.GetPageSize()
+	if d.pdf.GetY()+6 > height-margin {
+		d.pdf.AddPage()
+	}
+	y := d.pdf.GetY()
+	d.pdf.SetLineWidth(0.5)
+	d.pdf.Line(margin, y+3, width-margin, y+3)
+	d.pdf.SetY(y + 6)
+}
+
+// Space adds vertical space, in points
+func (d *Document) Space(height float64) {
+	_, pageHeight := d.pdf.GetPageSize()
+	if d.pdf.GetY()+height > pageHeight-margin {
+		d.pdf.AddPage()
+		return
+	}
+	d.pdf.SetY(d.pdf.GetY() + height)
+}
+
+// Write writes the document as a PDF file
+func (d *Document) Write(w io.Writer) error {
+	return d.pdf.Output(w)
+}
+
+// winAnsi maps the characters of WinAnsiEncoding outside Latin-1 to their codes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e,
+	'‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// encode converts text to WinAnsi, the encoding fpdf expects for the standard fonts
+func encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r >= 0x20 && r <= 0x7e, r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		case r == '\t':
+			b.WriteByte(' ')
+		default:
+			if code, ok := winAnsi[r]; ok {
+				b.WriteByte(code)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
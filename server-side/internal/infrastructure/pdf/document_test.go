@@ -0,0 +1,116 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	pdfreader "github.com/ledongthuc/pdf"
+)
+
+func TestDocumentWrite(t *testing.T) {
+	d := New("Statement (October)")
+	d.Text(HelveticaBold, 16, `Catetin \ statement (Oct)`)
+	d.Rule()
+	d.Space(12)
+	d.Text(Courier, 10, "Kopi\tRp25.000 – “café” €1")
+
+	r := read(t, d)
+	if r.NumPage() != 1 {
+		t.Fatalf("pages = %d, want 1", r.NumPage())
+	}
+	if title := r.Trailer().Key("Info").Key("Title").Text(); title != "Statement (October)" {
+		t.Errorf("title = %q", title)
+	}
+
+	want := []line{
+		{HelveticaBold, 16, `Catetin \ statement (Oct)`},
+		{Courier, 10, "Kopi Rp25.000 – “café” €1"},
+	}
+	got := lines(r.Page(1))
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("lines = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDocumentFlowsOntoNewPages(t *testing.T) {
+	d := New("Long")
+	for i := 0; i < 100; i++ {
+		d.Text(Helvetica, 10, fmt.Sprintf("line %d", i))
+	}
+
+	r := read(t, d)
+	if r.NumPage() < 2 {
+		t.Fatalf("pages = %d, want the lines to flow onto more pages", r.NumPage())
+	}
+	var texts []string
+	for i := 1; i <= r.NumPage(); i++ {
+		page := r.Page(i)
+		height := page.V.Key("MediaBox").Index(3).Float64()
+		if height == 0 {
+			height = page.V.Key("Parent").Key("MediaBox").Index(3).Float64()
+		}
+		for _, text := range page.Content().Text {
+			if text.Y < margin || text.Y > height-margin {
+				t.Errorf("%q at y=%.1f is outside the margins", text.S, text.Y)
+			}
+		}
+		for _, l := range lines(page) {
+			texts = append(texts, l.text)
+		}
+	}
+	if len(texts) != 100 || texts[0] != "line 0" || texts[99] != "line 99" {
+		t.Errorf("lines = %d from %q to %q, want all 100 in order", len(texts), texts[0], texts[len(texts)-1])
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for text, want := range map[string]string{
+		"plain":         "plain",
+		`a\b(c)d`:       `a\b(c)d`,
+		"tab\there":     "tab here",
+		"Rp1.000,00 ±½": "Rp1.000,00 \xb1\xbd",
+		"‘single’ • ™":  "\x91single\x92 \x95 \x99",
+		"emoji 🙂 日本 \n": "emoji ? ?? ?",
+	} {
+		if got := encode(text); got != want {
+			t.Errorf("encode(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+// line is a line of text as a PDF reader sees it
+type line struct {
+	font Font
+	size float64
+	text string
+}
+
+// read writes the document and opens the result
+func read(t *testing.T, d *Document) *pdfreader.Reader {
+	t.Helper()
+
+	var out bytes.Buffer
+	if err := d.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	r, err := pdfreader.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("output is not a PDF: %v", err)
+	}
+	return r
+}
+
+// lines joins the characters drawn on a page into lines, top to bottom
+func lines(page pdfreader.Page) []line {
+	var result []line
+	y := -1.0
+	for _, text := range page.Content().Text {
+		if text.Y != y || len(result) == 0 {
+			result = append(result, line{font: Font(text.Font), size: text.FontSize})
+			y = text.Y
+		}
+		result[len(result)-1].text += text.S
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// APIKeyPrefix identifies catetin API keys in headers and logs
+	APIKeyPrefix = "ctn_"
+
+	apiKeyRandomBytes  = 32
+	apiKeyDisplayChars = 8
+)
+
+// GenerateAPIKey generates a new random API key.
+// It returns the plaintext key (shown to the user once), a short display prefix,
+// and the hash that should be stored.
+func GenerateAPIKey() (plaintext, displayPrefix, hash string, err error) {
+	buf := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	plaintext = APIKeyPrefix + hex.EncodeToString(buf)
+	displayPrefix = plaintext[:len(APIKeyPrefix)+apiKeyDisplayChars]
+	return plaintext, displayPrefix, HashAPIKey(plaintext), nil
+}
+
+// HashAPIKey hashes a plaintext API key for storage and lookup
+func HashAPIKey(plaintext string) string {
+	return HashToken(plaintext)
+}
+
+// IsAPIKey checks if a token looks like an API key rather than a JWT
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
@@ -0,0 +1,208 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptedFieldPrefix starts every value encrypted by FieldCipher. The value goes on
+// with the ID of the key that wrapped its data key, the wrapped data key, and the
+// ciphertext, separated by colons.
+const encryptedFieldPrefix = "enc:v1:"
+
+// dataKeySize is the size of the AES-256 key generated for every value
+const dataKeySize = 32
+
+// KeyWrapper encrypts the data keys of FieldCipher. LocalKey wraps them with a key
+// from the environment; a KMS can implement it to keep the key out of the process.
+type KeyWrapper interface {
+	// KeyID identifies the key, so values stay readable after a newer one is added
+	KeyID() string
+
+	WrapKey(dataKey []byte) ([]byte, error)
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// LocalKey is a KeyWrapper with an AES-256 key held in memory
+type LocalKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewLocalKey creates a KeyWrapper for a 32-byte key
+func NewLocalKey(id string, key []byte) (*LocalKey, error) {
+	if id == "" || strings.Contains(id, ":") {
+		return nil, fmt.Errorf("key ID %q must be set and must not contain a colon", id)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key %s must be 32 bytes, got %d", id, len(key))
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalKey{id: id, aead: aead}, nil
+}
+
+// ParseLocalKeys parses keys written as id=base64, e.g. from an environment variable
+func ParseLocalKeys(specs []string) ([]KeyWrapper, error) {
+	keys := make([]KeyWrapper, 0, len(specs))
+	for i, spec := range specs {
+		id, encoded, ok := strings.Cut(spec, "=")
+		if !ok {
+			// Never print the spec, which may be a bare key
+			return nil, fmt.Errorf("key %d must be written as id=base64", i+1)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %s is not valid base64: %w", id, err)
+		}
+		local, err := NewLocalKey(id, key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, local)
+	}
+	return keys, nil
+}
+
+// KeyID returns the ID of the key
+func (k *LocalKey) KeyID() string {
+	return k.id
+}
+
+// WrapKey encrypts a data key
+func (k *LocalKey) WrapKey(dataKey []byte) ([]byte, error) {
+	return seal(k.aead, dataKey)
+}
+
+// UnwrapKey decrypts a data key encrypted by WrapKey
+func (k *LocalKey) UnwrapKey(wrapped []byte) ([]byte, error) {
+	return open(k.aead, wrapped)
+}
+
+// FieldCipher encrypts database fields with envelope encryption: every value is
+// encrypted with its own data key, stored next to it wrapped by a key encryption key.
+// Rotating the key encryption key therefore never requires re-encrypting every value.
+type FieldCipher struct {
+	current KeyWrapper
+	keys    map[string]KeyWrapper
+}
+
+// NewFieldCipher creates a field cipher. The first key wraps the data keys of new
+// values; the others are kept to read values written before it was added.
+func NewFieldCipher(keys ...KeyWrapper) (*FieldCipher, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("field cipher needs at least one key")
+	}
+
+	byID := make(map[string]KeyWrapper, len(keys))
+	for _, key := range keys {
+		if _, ok := byID[key.KeyID()]; ok {
+			return nil, fmt.Errorf("key %s is listed twice", key.KeyID())
+		}
+		byID[key.KeyID()] = key
+	}
+	return &FieldCipher{current: keys[0], keys: byID}, nil
+}
+
+// Encrypt encrypts a value
+func (c *FieldCipher) Encrypt(plaintext string) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := c.current.WrapKey(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(aead, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawStdEncoding
+	return encryptedFieldPrefix + c.current.KeyID() + ":" + encoding.EncodeToString(wrapped) + ":" +
+		encoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts a value encrypted by Encrypt with any of the cipher's keys
+func (c *FieldCipher) Decrypt(value string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(value, encryptedFieldPrefix), ":")
+	if !IsEncryptedField(value) || len(parts) != 3 {
+		return "", errors.New("value is not an encrypted field")
+	}
+
+	key, ok := c.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("value is encrypted with unknown key %s", parts[0])
+	}
+	encoding := base64.RawStdEncoding
+	wrapped, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid data key: %w", err)
+	}
+	ciphertext, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	dataKey, err := key.UnwrapKey(wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsEncryptedField reports whether a value was encrypted by a FieldCipher
+func IsEncryptedField(value string) bool {
+	return strings.HasPrefix(value, encryptedFieldPrefix)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext with a random nonce, which it prepends to the ciphertext
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a ciphertext written by seal
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt: the value or key is corrupted")
+	}
+	return plaintext, nil
+}
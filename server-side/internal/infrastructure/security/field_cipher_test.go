@@ -0,0 +1,62 @@
+package security
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestFieldCipherKeepsValuesReadableAfterKeyRotation(t *testing.T) {
+	keys, err := ParseLocalKeys([]string{
+		"2026-10=" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)),
+		"2026-01=" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)),
+	})
+	if err != nil {
+		t.Fatalf("ParseLocalKeys() error = %v", err)
+	}
+	oldCipher, _ := NewFieldCipher(keys[1])
+	rotated, _ := NewFieldCipher(keys...)
+
+	old, err := oldCipher.Encrypt("refresh-token")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !IsEncryptedField(old) || strings.Contains(old, "refresh-token") {
+		t.Fatalf("Encrypt() = %q, want an encrypted field", old)
+	}
+	if again, _ := oldCipher.Encrypt("refresh-token"); again == old {
+		t.Error("Encrypt() returned the same ciphertext twice, want a fresh data key and nonce")
+	}
+
+	fresh, err := rotated.Encrypt("refresh-token")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !strings.HasPrefix(fresh, encryptedFieldPrefix+"2026-10:") {
+		t.Errorf("Encrypt() = %q, want it wrapped by the first key", fresh)
+	}
+	for _, value := range []string{old, fresh} {
+		if plaintext, err := rotated.Decrypt(value); err != nil || plaintext != "refresh-token" {
+			t.Errorf("Decrypt(%q) = %q, %v, want the plaintext", value, plaintext, err)
+		}
+	}
+
+	if _, err := oldCipher.Decrypt(fresh); err == nil {
+		t.Error("Decrypt() with a missing key succeeded")
+	}
+	tampered := []byte(fresh)
+	tampered[len(tampered)-5] ^= 1 // another base64 character
+	if _, err := rotated.Decrypt(string(tampered)); err == nil {
+		t.Error("Decrypt() of a tampered value succeeded")
+	}
+}
+
+func TestParseLocalKeysRejectsInvalidKeys(t *testing.T) {
+	short := base64.StdEncoding.EncodeToString([]byte("too-short"))
+	for _, spec := range []string{"no-id", "a:b=" + short, "k1=" + short, "k1=not base64"} {
+		if _, err := ParseLocalKeys([]string{spec}); err == nil {
+			t.Errorf("ParseLocalKeys(%q) succeeded", spec)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashToken returns the hex-encoded SHA-256 hash of a high-entropy token.
+// It is suitable for random tokens (API keys, refresh tokens), not passwords.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
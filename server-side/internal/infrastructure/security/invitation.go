@@ -0,0 +1,26 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// InvitationTokenPrefix identifies invitation tokens in links and logs
+	InvitationTokenPrefix = "inv_"
+
+	invitationRandomBytes = 32
+)
+
+// GenerateInvitationToken generates a random invitation token. It returns the
+// plaintext token, sent to the invitee once, and the hash that should be stored.
+func GenerateInvitationToken() (plaintext, hash string, err error) {
+	buf := make([]byte, invitationRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	plaintext = InvitationTokenPrefix + hex.EncodeToString(buf)
+	return plaintext, HashToken(plaintext), nil
+}
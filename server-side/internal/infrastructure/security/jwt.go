@@ -1,12 +1,17 @@
 package security
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/metrics"
 )
 
 var (
@@ -14,75 +19,198 @@ var (
 	ErrExpiredToken = errors.New("token has expired")
 )
 
+const (
+	// TokenTypeAccess marks tokens used to authenticate API requests
+	TokenTypeAccess = "access"
+
+	// TokenTypeRefresh marks tokens used to obtain new access tokens
+	TokenTypeRefresh = "refresh"
+)
+
+// Audiences name the kind of client a token is issued to (the aud claim), so routes
+// can be limited to some clients
+const (
+	AudienceWeb      = "web"
+	AudienceWebAdmin = "web-admin"
+	AudienceMobile   = "mobile"
+	AudienceBot      = "bot"
+
+	// DefaultAudience is given to tokens requested without a client type
+	DefaultAudience = AudienceWeb
+)
+
+// Audiences lists every client type tokens can be issued to
+var Audiences = []string{AudienceWeb, AudienceWebAdmin, AudienceMobile, AudienceBot}
+
 // JWTClaims represents the JWT claims
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Email    string `json:"email"`
-	FullName string `json:"full_name"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	FullName  string `json:"full_name"`
+	TokenType string `json:"token_type,omitempty"`
+
+	// AuthTime is when the user last proved who they are by signing in or re-entering
+	// their password. Refreshing tokens carries it over, so it ages with the session.
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+
+	// TokenVersion is the token version of the user when the access token was issued;
+	// the token is revoked once the user's version moves past it
+	TokenVersion int `json:"token_version,omitempty"`
+
+	// SessionID identifies the session, the chain of refresh tokens, an access token
+	// was issued in; empty for tokens issued without one, e.g. to demo users
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// JWTManager handles JWT token generation and validation
+// AuthenticatedAt returns AuthTime, or the zero time for tokens issued without it
+func (c *JWTClaims) AuthenticatedAt() time.Time {
+	if c.AuthTime == nil {
+		return time.Time{}
+	}
+	return c.AuthTime.Time
+}
+
+// Session returns SessionID, or uuid.Nil for tokens issued without one
+func (c *JWTClaims) Session() uuid.UUID {
+	id, err := uuid.Parse(c.SessionID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
+
+// Client returns the audience of the token, or "" for tokens issued without one
+func (c *JWTClaims) Client() string {
+	if len(c.Audience) == 0 {
+		return ""
+	}
+	return c.Audience[0]
+}
+
+// minRecommendedSecretLength is the shortest HMAC secret considered safe for HS256
+const minRecommendedSecretLength = 32
+
+// signingKey is an HMAC secret identified by a key ID (kid header)
+type signingKey struct {
+	id     string
+	secret []byte
+	used   atomic.Bool // whether the key verified a token since startup
+}
+
+// JWTManager handles JWT token generation and validation.
+// Tokens are signed with the newest key and verified against all configured keys,
+// which allows rotating secrets without invalidating tokens issued before the rotation.
 type JWTManager struct {
-	secretKey       string
+	keys            []*signingKey
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+
+	// verifications counts verified tokens by key ID, nil when metrics are disabled
+	verifications *metrics.CounterVec
 }
 
-// NewJWTManager creates a new JWT manager
-func NewJWTManager(secretKey string, accessTokenTTL, refreshTokenTTL time.Duration) *JWTManager {
-	return &JWTManager{
-		secretKey:       secretKey,
+// NewJWTManager creates a new JWT manager.
+// secretKeys must be ordered newest first; the first key is used for signing.
+// Metrics are registered on registry unless it is nil; they show when tokens signed
+// with a rotated key stop being used, so the key can be removed.
+func NewJWTManager(secretKeys []string, accessTokenTTL, refreshTokenTTL time.Duration, registry *metrics.Registry) *JWTManager {
+	keys := make([]*signingKey, 0, len(secretKeys))
+	for _, secret := range secretKeys {
+		if secret == "" {
+			continue
+		}
+		keys = append(keys, &signingKey{
+			id:     keyID(secret),
+			secret: []byte(secret),
+		})
+	}
+
+	jm := &JWTManager{
+		keys:            keys,
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
 	}
+	if registry != nil {
+		jm.verifications = registry.NewCounterVec("catetin_jwt_verifications_total",
+			"Tokens verified, by the ID of the key that verified them.",
+			"kid")
+	}
+	return jm
+}
+
+// keyID derives a stable, non-reversible identifier for a secret
+func keyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:4])
 }
 
-// GenerateAccessToken generates a new access token
-func (jm *JWTManager) GenerateAccessToken(userID uuid.UUID, email, fullName string) (string, int64, error) {
+// GenerateAccessToken generates a new access token for a user who authenticated at
+// authTime, issued to the client audience at the user's current token version in the
+// session, or outside one when sessionID is uuid.Nil
+func (jm *JWTManager) GenerateAccessToken(userID uuid.UUID, email, fullName string, authTime time.Time, audience string, tokenVersion int, sessionID uuid.UUID) (string, int64, error) {
+	return jm.GenerateAccessTokenWithTTL(userID, email, fullName, authTime, audience, tokenVersion, sessionID, jm.accessTokenTTL)
+}
+
+// GenerateAccessTokenWithTTL generates a new access token valid for ttl instead of
+// the configured duration, e.g. for short-lived demo sessions
+func (jm *JWTManager) GenerateAccessTokenWithTTL(userID uuid.UUID, email, fullName string, authTime time.Time, audience string, tokenVersion int, sessionID uuid.UUID, ttl time.Duration) (string, int64, error) {
 	now := time.Now()
-	expiresAt := now.Add(jm.accessTokenTTL)
+	expiresAt := now.Add(ttl)
+
+	var sid string
+	if sessionID != uuid.Nil {
+		sid = sessionID.String()
+	}
 
 	claims := &JWTClaims{
-		UserID:   userID.String(),
-		Email:    email,
-		FullName: fullName,
+		UserID:       userID.String(),
+		Email:        email,
+		FullName:     fullName,
+		TokenType:    TokenTypeAccess,
+		AuthTime:     authTimeClaim(authTime),
+		TokenVersion: tokenVersion,
+		SessionID:    sid,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "catetin-api",
 			Subject:   userID.String(),
+			Audience:  audienceClaim(audience),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(jm.secretKey))
+	tokenString, err := jm.sign(claims)
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to sign token: %w", err)
 	}
 
-	return tokenString, int64(jm.accessTokenTTL.Seconds()), nil
+	return tokenString, int64(ttl.Seconds()), nil
 }
 
-// GenerateRefreshToken generates a new refresh token
-func (jm *JWTManager) GenerateRefreshToken(userID uuid.UUID) (string, error) {
+// GenerateRefreshToken generates a new refresh token for a user who authenticated at
+// authTime. Its audience is carried over to the tokens it is exchanged for.
+func (jm *JWTManager) GenerateRefreshToken(userID uuid.UUID, authTime time.Time, audience string) (string, error) {
 	now := time.Now()
 	expiresAt := now.Add(jm.refreshTokenTTL)
 
 	claims := &JWTClaims{
-		UserID: userID.String(),
+		UserID:    userID.String(),
+		TokenType: TokenTypeRefresh,
+		AuthTime:  authTimeClaim(authTime),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "catetin-api",
 			Subject:   userID.String(),
+			Audience:  audienceClaim(audience),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(jm.secretKey))
+	tokenString, err := jm.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign refresh token: %w", err)
 	}
@@ -90,31 +218,91 @@ func (jm *JWTManager) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 	return tokenString, nil
 }
 
+// authTimeClaim omits the auth_time claim when the authentication time is unknown
+func authTimeClaim(authTime time.Time) *jwt.NumericDate {
+	if authTime.IsZero() {
+		return nil
+	}
+	return jwt.NewNumericDate(authTime)
+}
+
+// audienceClaim issues tokens requested without a client type to DefaultAudience
+func audienceClaim(audience string) jwt.ClaimStrings {
+	if audience == "" {
+		audience = DefaultAudience
+	}
+	return jwt.ClaimStrings{audience}
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func (jm *JWTManager) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	for _, key := range jm.candidateKeys(tokenString) {
+		token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+			// Verify signing method
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return key.secret, nil
+		})
+
+		if err != nil {
+			// Expiry is only reported once the signature has been verified
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				return nil, ErrExpiredToken
+			}
+			continue
 		}
-		return []byte(jm.secretKey), nil
-	})
 
-	if err != nil {
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, ErrExpiredToken
+		claims, ok := token.Claims.(*JWTClaims)
+		if !ok || !token.Valid {
+			return nil, ErrInvalidToken
 		}
+
+		if jm.verifications != nil {
+			jm.verifications.Inc(key.id)
+		}
+		if !key.used.Swap(true) && key != jm.keys[0] {
+			slog.Info("JWT verified with rotated key; tokens signed with it are still in use", "kid", key.id)
+		}
+		return claims, nil
+	}
+
+	return nil, ErrInvalidToken
+}
+
+// ValidateAccessToken validates a JWT token and ensures it is an access token
+func (jm *JWTManager) ValidateAccessToken(tokenString string) (*JWTClaims, error) {
+	claims, err := jm.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != TokenTypeAccess {
 		return nil, ErrInvalidToken
 	}
 
-	claims, ok := token.Claims.(*JWTClaims)
-	if !ok || !token.Valid {
+	return claims, nil
+}
+
+// ValidateRefreshToken validates a JWT token and ensures it is a refresh token
+func (jm *JWTManager) ValidateRefreshToken(tokenString string) (*JWTClaims, error) {
+	claims, err := jm.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != TokenTypeRefresh {
 		return nil, ErrInvalidToken
 	}
 
 	return claims, nil
 }
 
+// RefreshTokenTTL returns the lifetime of refresh tokens
+func (jm *JWTManager) RefreshTokenTTL() time.Duration {
+	return jm.refreshTokenTTL
+}
+
 // ExtractUserID extracts user ID from token without full validation
 func (jm *JWTManager) ExtractUserID(tokenString string) (string, error) {
 	claims, err := jm.ValidateToken(tokenString)
@@ -123,3 +311,92 @@ func (jm *JWTManager) ExtractUserID(tokenString string) (string, error) {
 	}
 	return claims.UserID, nil
 }
+
+// SigningKeyID returns the key ID used to sign new tokens
+func (jm *JWTManager) SigningKeyID() string {
+	if len(jm.keys) == 0 {
+		return ""
+	}
+	return jm.keys[0].id
+}
+
+// SelfTest checks the key configuration by signing and verifying a probe token.
+// It fails only when no usable signing key exists; weak or duplicate keys are
+// reported as warnings so a rotation in progress does not block startup.
+func (jm *JWTManager) SelfTest() ([]string, error) {
+	if len(jm.keys) == 0 {
+		return nil, errors.New("no JWT signing key configured")
+	}
+
+	var warnings []string
+	seen := make(map[string]int, len(jm.keys))
+	for i, key := range jm.keys {
+		if len(key.secret) < minRecommendedSecretLength {
+			warnings = append(warnings, fmt.Sprintf("JWT key %s (position %d) is shorter than %d characters", key.id, i, minRecommendedSecretLength))
+		}
+		if first, ok := seen[key.id]; ok {
+			warnings = append(warnings, fmt.Sprintf("JWT key %s at position %d duplicates position %d", key.id, i, first))
+			continue
+		}
+		seen[key.id] = i
+	}
+
+	probe, err := jm.sign(&JWTClaims{
+		UserID:    uuid.Nil.String(),
+		TokenType: TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			Issuer:    "catetin-api",
+		},
+	})
+	if err != nil {
+		return warnings, fmt.Errorf("failed to sign probe token: %w", err)
+	}
+
+	parsed, err := jwt.ParseWithClaims(probe, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return jm.keys[0].secret, nil
+	})
+	if err != nil || !parsed.Valid {
+		return warnings, fmt.Errorf("failed to verify probe token: %v", err)
+	}
+
+	return warnings, nil
+}
+
+// sign signs the claims with the newest key and records its key ID in the header
+func (jm *JWTManager) sign(claims *JWTClaims) (string, error) {
+	if len(jm.keys) == 0 {
+		return "", errors.New("no JWT signing key configured")
+	}
+
+	key := jm.keys[0]
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.secret)
+}
+
+// candidateKeys orders keys for verification, trying the key named by the
+// token's kid header first and falling back to every other configured key
+func (jm *JWTManager) candidateKeys(tokenString string) []*signingKey {
+	var kid string
+	if unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, &JWTClaims{}); err == nil {
+		kid, _ = unverified.Header["kid"].(string)
+	}
+
+	if kid == "" {
+		return jm.keys
+	}
+
+	ordered := make([]*signingKey, 0, len(jm.keys))
+	for _, key := range jm.keys {
+		if key.id == kid {
+			ordered = append(ordered, key)
+		}
+	}
+	for _, key := range jm.keys {
+		if key.id != kid {
+			ordered = append(ordered, key)
+		}
+	}
+	return ordered
+}
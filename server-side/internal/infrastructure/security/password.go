@@ -1,33 +1,93 @@
 package security
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// PasswordHasher handles password hashing and verification
+// Password hashing algorithms
+const (
+	HashBcrypt   = "bcrypt"
+	HashArgon2id = "argon2id"
+)
+
+// HashAlgorithms lists every supported password hashing algorithm
+var HashAlgorithms = []string{HashBcrypt, HashArgon2id}
+
+// ErrPasswordMismatch is returned by Verify when the password does not match the hash
+var ErrPasswordMismatch = errors.New("password does not match")
+
+// errUnknownHash is returned by Verify for hashes of no supported algorithm
+var errUnknownHash = errors.New("unknown password hash format")
+
+// PasswordHashConfig selects the algorithm new passwords are hashed with and its
+// parameters. Zero values take the defaults.
+type PasswordHashConfig struct {
+	Algorithm string // HashBcrypt (default) or HashArgon2id
+
+	BcryptCost int // defaults to bcrypt.DefaultCost
+
+	Argon2Memory      uint32 // in KiB, defaults to 19456 (19 MiB)
+	Argon2Iterations  uint32 // defaults to 2
+	Argon2Parallelism uint8  // defaults to 1
+}
+
+// Hasher hashes and verifies passwords with one algorithm
+type Hasher interface {
+	// Hash hashes a plain text password with a random salt
+	Hash(password string) (string, error)
+
+	// Verify returns ErrPasswordMismatch unless the password matches the hash
+	Verify(hashedPassword, plainPassword string) error
+
+	// Recognizes reports whether the hash was made by this algorithm
+	Recognizes(hashedPassword string) bool
+
+	// NeedsRehash reports whether a hash of this algorithm was made with other
+	// parameters than the hasher's
+	NeedsRehash(hashedPassword string) bool
+}
+
+// PasswordHasher handles password hashing and verification. New passwords are hashed
+// with the configured algorithm; hashes of every supported algorithm are verified, so
+// the algorithm or its parameters can change without locking anyone out.
 type PasswordHasher struct {
-	cost int
+	hasher  Hasher
+	hashers []Hasher
 }
 
-// NewPasswordHasher creates a new password hasher
-func NewPasswordHasher() *PasswordHasher {
-	return &PasswordHasher{
-		cost: bcrypt.DefaultCost, // Cost of 10
+// NewPasswordHasher creates a new password hasher. The configuration is validated when
+// it is loaded; algorithms other than HashArgon2id hash with bcrypt.
+func NewPasswordHasher(config PasswordHashConfig) *PasswordHasher {
+	bcryptHasher := NewBcryptHasher(config.BcryptCost)
+	argon2Hasher := NewArgon2idHasher(config.Argon2Memory, config.Argon2Iterations, config.Argon2Parallelism)
+
+	if config.Algorithm == HashArgon2id {
+		return &PasswordHasher{hasher: argon2Hasher, hashers: []Hasher{argon2Hasher, bcryptHasher}}
 	}
+	return &PasswordHasher{hasher: bcryptHasher, hashers: []Hasher{bcryptHasher, argon2Hasher}}
 }
 
 // Hash hashes a plain text password
 func (ph *PasswordHasher) Hash(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), ph.cost)
-	if err != nil {
-		return "", err
-	}
-	return string(hashedBytes), nil
+	return ph.hasher.Hash(password)
 }
 
 // Verify verifies a plain text password against a hashed password
 func (ph *PasswordHasher) Verify(hashedPassword, plainPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
+	for _, hasher := range ph.hashers {
+		if hasher.Recognizes(hashedPassword) {
+			return hasher.Verify(hashedPassword, plainPassword)
+		}
+	}
+	return errUnknownHash
 }
 
 // IsValidPassword checks if a password is valid (returns true if valid)
@@ -35,3 +95,156 @@ func (ph *PasswordHasher) IsValidPassword(hashedPassword, plainPassword string)
 	err := ph.Verify(hashedPassword, plainPassword)
 	return err == nil
 }
+
+// NeedsRehash reports whether a hash was made with another algorithm or other
+// parameters than new passwords are, so it should be replaced once the password is known
+func (ph *PasswordHasher) NeedsRehash(hashedPassword string) bool {
+	return !ph.hasher.Recognizes(hashedPassword) || ph.hasher.NeedsRehash(hashedPassword)
+}
+
+// BcryptHasher hashes passwords with bcrypt
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates a bcrypt hasher; a cost of 0 takes bcrypt.DefaultCost
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+// Hash hashes a plain text password
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedBytes), nil
+}
+
+// Verify verifies a plain text password against a bcrypt hash
+func (h *BcryptHasher) Verify(hashedPassword, plainPassword string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrPasswordMismatch
+	}
+	return err
+}
+
+// Recognizes reports whether the hash is a bcrypt hash
+func (h *BcryptHasher) Recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2a$") || strings.HasPrefix(hashedPassword, "$2b$") ||
+		strings.HasPrefix(hashedPassword, "$2y$")
+}
+
+// NeedsRehash reports whether the hash was made with another cost
+func (h *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != h.cost
+}
+
+const (
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// Argon2idHasher hashes passwords with Argon2id, encoded in the PHC string format
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<hash>
+type Argon2idHasher struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// NewArgon2idHasher creates an Argon2id hasher; zero parameters take the defaults
+// recommended by OWASP: 19 MiB of memory, 2 iterations, and 1 lane
+func NewArgon2idHasher(memory, iterations uint32, parallelism uint8) *Argon2idHasher {
+	if memory == 0 {
+		memory = 19 * 1024
+	}
+	if iterations == 0 {
+		iterations = 2
+	}
+	if parallelism == 0 {
+		parallelism = 1
+	}
+	return &Argon2idHasher{memory: memory, iterations: iterations, parallelism: parallelism}
+}
+
+// argon2Hash is a decoded Argon2id hash
+type argon2Hash struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+// Hash hashes a plain text password
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.iterations, h.memory, h.parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, h.memory, h.iterations, h.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify verifies a plain text password against an Argon2id hash, with the parameters
+// stored in the hash
+func (h *Argon2idHasher) Verify(hashedPassword, plainPassword string) error {
+	decoded, err := decodeArgon2Hash(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	key := argon2.IDKey([]byte(plainPassword), decoded.salt, decoded.iterations, decoded.memory, decoded.parallelism, uint32(len(decoded.key)))
+	if subtle.ConstantTimeCompare(key, decoded.key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// Recognizes reports whether the hash is an Argon2id hash
+func (h *Argon2idHasher) Recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$argon2id$")
+}
+
+// NeedsRehash reports whether the hash was made with other parameters
+func (h *Argon2idHasher) NeedsRehash(hashedPassword string) bool {
+	decoded, err := decodeArgon2Hash(hashedPassword)
+	return err != nil || decoded.memory != h.memory || decoded.iterations != h.iterations ||
+		decoded.parallelism != h.parallelism || len(decoded.key) != argon2KeyLength
+}
+
+// decodeArgon2Hash parses an Argon2id hash in the PHC string format
+func decodeArgon2Hash(hashedPassword string) (*argon2Hash, error) {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != HashArgon2id {
+		return nil, errUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, fmt.Errorf("unsupported argon2 version %q", parts[2])
+	}
+
+	decoded := &argon2Hash{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &decoded.memory, &decoded.iterations, &decoded.parallelism); err != nil {
+		return nil, fmt.Errorf("invalid argon2 parameters: %w", err)
+	}
+
+	var err error
+	if decoded.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+	if decoded.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(decoded.key) == 0 {
+		return nil, errors.New("invalid argon2 hash")
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,26 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	// PasswordResetTokenPrefix identifies password reset tokens in links and logs
+	PasswordResetTokenPrefix = "pwr_"
+
+	passwordResetRandomBytes = 32
+)
+
+// GeneratePasswordResetToken generates a random password reset token. It returns the
+// plaintext token, emailed to the user once, and the hash that should be stored.
+func GeneratePasswordResetToken() (plaintext, hash string, err error) {
+	buf := make([]byte, passwordResetRandomBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	plaintext = PasswordResetTokenPrefix + hex.EncodeToString(buf)
+	return plaintext, HashToken(plaintext), nil
+}
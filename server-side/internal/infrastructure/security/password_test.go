@@ -0,0 +1,56 @@
+package security
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPasswordHasherVerifiesEveryAlgorithm(t *testing.T) {
+	bcryptHasher := NewPasswordHasher(PasswordHashConfig{Algorithm: HashBcrypt, BcryptCost: 4})
+	argon2Hasher := NewPasswordHasher(PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 64, Argon2Iterations: 1})
+
+	for _, hasher := range []*PasswordHasher{bcryptHasher, argon2Hasher} {
+		hashed, err := hasher.Hash("rahasia123")
+		if err != nil {
+			t.Fatalf("Hash() error = %v", err)
+		}
+
+		// Switching the algorithm keeps existing hashes working
+		for _, verifier := range []*PasswordHasher{bcryptHasher, argon2Hasher} {
+			if err := verifier.Verify(hashed, "rahasia123"); err != nil {
+				t.Errorf("Verify(%q) error = %v", hashed, err)
+			}
+			if err := verifier.Verify(hashed, "rahasia124"); !errors.Is(err, ErrPasswordMismatch) {
+				t.Errorf("Verify(%q) with a wrong password error = %v, want %v", hashed, err, ErrPasswordMismatch)
+			}
+		}
+		if hasher.NeedsRehash(hashed) {
+			t.Errorf("NeedsRehash(%q) = true for a hash of the configured parameters", hashed)
+		}
+	}
+}
+
+func TestPasswordHasherNeedsRehash(t *testing.T) {
+	old := NewPasswordHasher(PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 64, Argon2Iterations: 1})
+	hashed, err := old.Hash("rahasia123")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		config PasswordHashConfig
+		want   bool
+	}{
+		{name: "same parameters", config: PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 64, Argon2Iterations: 1}, want: false},
+		{name: "more iterations", config: PasswordHashConfig{Algorithm: HashArgon2id, Argon2Memory: 64, Argon2Iterations: 2}, want: true},
+		{name: "other algorithm", config: PasswordHashConfig{Algorithm: HashBcrypt, BcryptCost: 4}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPasswordHasher(tt.config).NeedsRehash(hashed); got != tt.want {
+				t.Errorf("NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package sentry reports errors to Sentry with sentry-go.
+package sentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// modulePrefix marks the frames of this module as application code in Sentry
+const modulePrefix = "github.com/ingunawandra/catetin/"
+
+// queueSize bounds the reports waiting to be sent; more are dropped
+const queueSize = 100
+
+// reportedHeaders are the request headers sent with a report. Others, such as
+// Authorization, Cookie, and X-API-Key, may carry credentials.
+var reportedHeaders = []string{"Accept", "Accept-Language", "Content-Type", "User-Agent", "X-Request-ID"}
+
+// Config holds the Sentry settings
+type Config struct {
+	DSN         string // reporting is disabled when empty
+	Environment string
+	Release     string
+
+	// Timeout bounds a single HTTP request, and flushing the queue on shutdown
+	Timeout time.Duration
+}
+
+// Client sends error reports to a Sentry project. Reports are queued and sent in the
+// background by sentry-go's transport, so reporting never slows down a request.
+type Client struct {
+	client  *sentry.Client // nil when disabled
+	timeout time.Duration
+}
+
+// NewClient creates a new Sentry client; an empty DSN creates a disabled client
+func NewClient(config Config) (*Client, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	c := &Client{timeout: config.Timeout}
+	if config.DSN == "" {
+		return c, nil
+	}
+
+	transport := sentry.NewHTTPTransport()
+	transport.BufferSize = queueSize
+	transport.Timeout = config.Timeout
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         config.DSN,
+		Environment: config.Environment,
+		Release:     config.Release,
+		Transport:   transport,
+		// Report frames without the source lines around them
+		Integrations: func(integrations []sentry.Integration) []sentry.Integration {
+			return slices.DeleteFunc(integrations, func(i sentry.Integration) bool {
+				return i.Name() == "ContextifyFrames"
+			})
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SENTRY_DSN must be https://<key>@<host>/<project ID>: %w", err)
+	}
+	c.client = client
+	return c, nil
+}
+
+// Enabled reports whether the client has a DSN
+func (c *Client) Enabled() bool {
+	return c.client != nil
+}
+
+// ReportError queues a report of an error that failed a request. The stack trace and
+// fields of an AppError are included; the request is reduced to its method, URL
+// without query, and reportedHeaders.
+func (c *Client) ReportError(ctx context.Context, err error, request *http.Request, tags map[string]string) {
+	if !c.Enabled() || err == nil {
+		return
+	}
+
+	e := newEvent(err, tags)
+	if request != nil {
+		requestURL := *request.URL
+		requestURL.RawQuery, requestURL.Fragment = "", ""
+		if requestURL.Host == "" {
+			requestURL.Host = request.Host
+		}
+		if requestURL.Scheme == "" {
+			requestURL.Scheme = "http"
+			if request.TLS != nil {
+				requestURL.Scheme = "https"
+			}
+		}
+		headers := make(map[string]string)
+		for _, name := range reportedHeaders {
+			if value := request.Header.Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		e.Request = &sentry.Request{Method: request.Method, URL: requestURL.String(), Headers: headers}
+	}
+	if userID := tags["user_id"]; userID != "" {
+		e.User = sentry.User{ID: userID}
+	}
+
+	c.client.CaptureEvent(e, &sentry.EventHint{Context: ctx, OriginalException: err}, nil)
+}
+
+// Run waits until the context is cancelled, then sends the reports still queued
+// within the timeout
+func (c *Client) Run(ctx context.Context) {
+	<-ctx.Done()
+	if c.Enabled() {
+		c.client.Flush(c.timeout)
+	}
+}
+
+// newEvent describes err and the errors it wraps, the innermost first as Sentry
+// expects
+func newEvent(err error, tags map[string]string) *sentry.Event {
+	e := sentry.NewEvent()
+	e.Level = sentry.LevelError
+	for k, v := range tags {
+		e.Tags[k] = v
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		exception := sentry.Exception{Type: fmt.Sprintf("%T", err), Value: err.Error()}
+		if appErr, ok := err.(*appErrors.AppError); ok {
+			exception.Type = string(appErr.Code)
+			exception.Value = appErr.Message
+			exception.Stacktrace = stacktrace(appErr.StackTrace())
+			for k, v := range appErr.Fields {
+				e.Extra[k] = v
+			}
+		}
+		e.Exception = append([]sentry.Exception{exception}, e.Exception...)
+	}
+	return e
+}
+
+// stacktrace converts a stack, innermost call first, to Sentry's frames, outermost first
+func stacktrace(stack []runtime.Frame) *sentry.Stacktrace {
+	if len(stack) == 0 {
+		return nil
+	}
+
+	frames := make([]sentry.Frame, 0, len(stack))
+	for i := len(stack) - 1; i >= 0; i-- {
+		frame := sentry.NewFrame(stack[i])
+		frame.Filename = strings.TrimPrefix(frame.Module, modulePrefix) + "/" + baseName(stack[i].File)
+		frame.InApp = strings.HasPrefix(frame.Module, modulePrefix)
+		frames = append(frames, frame)
+	}
+	return &sentry.Stacktrace{Frames: frames}
+}
+
+func baseName(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,126 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+func TestNewClientParsesTheDSN(t *testing.T) {
+	tests := []struct {
+		dsn     string
+		wantErr bool
+	}{
+		{dsn: "https://public@o1.ingest.sentry.io/42"},
+		{dsn: "http://public@sentry.internal:9000/errors/7"},
+		{dsn: "https://o1.ingest.sentry.io/42", wantErr: true},
+		{dsn: "https://public@o1.ingest.sentry.io/", wantErr: true},
+	}
+	for _, tt := range tests {
+		client, err := NewClient(Config{DSN: tt.dsn})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NewClient(%q) error = nil, want an error", tt.dsn)
+			}
+			continue
+		}
+		if err != nil || !client.Enabled() {
+			t.Errorf("NewClient(%q) = enabled %v, error %v; want enabled", tt.dsn, client != nil && client.Enabled(), err)
+		}
+	}
+
+	client, err := NewClient(Config{})
+	if err != nil || client.Enabled() {
+		t.Errorf("NewClient without DSN = enabled %v, error %v; want disabled", client.Enabled(), err)
+	}
+}
+
+func TestReportErrorSendsAnEnvelope(t *testing.T) {
+	var path, auth string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("X-Sentry-Auth")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{DSN: strings.Replace(server.URL, "://", "://public@", 1) + "/42", Environment: "test"})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	cause := fmt.Errorf("connection refused")
+	err = appErrors.Wrap(cause, appErrors.ErrCodeInternal, "failed to save", http.StatusInternalServerError,
+		appErrors.WithFields(map[string]interface{}{"wallet_id": "w1"}))
+	request := httptest.NewRequest(http.MethodPost, "http://api.example.com/api/v1/wallets?token=secret", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	request.Header.Set("User-Agent", "test")
+	client.ReportError(context.Background(), err, request, map[string]string{"user_id": "u1", "code": "INTERNAL_ERROR"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.Run(ctx) // sends the queued report and returns
+
+	if path != "/api/42/envelope/" {
+		t.Errorf("report posted to %s, want the envelope endpoint of project 42", path)
+	}
+	if want := "sentry_key=public"; !strings.Contains(auth, want) {
+		t.Errorf("X-Sentry-Auth = %q, want it to contain %q", auth, want)
+	}
+	lines := bytes.Split(bytes.TrimSpace(body), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("envelope has %d lines, want 3:\n%s", len(lines), body)
+	}
+	if bytes.Contains(body, []byte("secret")) {
+		t.Errorf("envelope contains the query or Authorization header:\n%s", body)
+	}
+
+	var e struct {
+		Environment string
+		User        *struct{ ID string }
+		Extra       map[string]interface{}
+		Request     *struct {
+			URL     string
+			Headers map[string]string
+		}
+		Exception []struct {
+			Type       string
+			Value      string
+			Stacktrace *struct {
+				Frames []struct {
+					Function string
+					Filename string
+					InApp    bool `json:"in_app"`
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(lines[2], &e); err != nil {
+		t.Fatalf("event does not decode: %v", err)
+	}
+	if e.Environment != "test" || e.User == nil || e.User.ID != "u1" || e.Extra["wallet_id"] != "w1" {
+		t.Errorf("event = %+v, want environment test, user u1, and wallet_id in extra", e)
+	}
+	if e.Request == nil || e.Request.URL != "http://api.example.com/api/v1/wallets" || e.Request.Headers["User-Agent"] != "test" {
+		t.Errorf("request = %+v, want the URL without query and the User-Agent", e.Request)
+	}
+
+	values := e.Exception
+	if len(values) != 2 || values[0].Value != "connection refused" || values[1].Type != "INTERNAL_ERROR" {
+		t.Fatalf("exceptions = %+v, want the cause, then the INTERNAL_ERROR wrapping it", values)
+	}
+	frames := values[1].Stacktrace.Frames
+	last := frames[len(frames)-1]
+	if last.Function != "TestReportErrorSendsAnEnvelope" || !last.InApp || last.Filename != "internal/infrastructure/sentry/client_test.go" {
+		t.Errorf("innermost frame = %+v, want the test calling Wrap", last)
+	}
+}
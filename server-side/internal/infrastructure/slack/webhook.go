@@ -0,0 +1,88 @@
+// Package slack posts messages to Slack through incoming webhooks.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// ErrNotConfigured is returned when no webhook URL is set
+var ErrNotConfigured = errors.New("slack webhook is not configured")
+
+// Config holds the incoming webhook settings
+type Config struct {
+	// WebhookURL is the incoming webhook of the channel messages are posted to
+	WebhookURL string
+
+	// Timeout bounds a single HTTP request
+	Timeout time.Duration
+}
+
+// Webhook posts messages to a single Slack channel
+type Webhook struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewWebhook creates a new Slack webhook client
+func NewWebhook(config Config) *Webhook {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	return &Webhook{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Enabled reports whether the webhook has a URL
+func (w *Webhook) Enabled() bool {
+	return w.config.WebhookURL != ""
+}
+
+// Post posts a message in Slack's mrkdwn format to the channel
+func (w *Webhook) Post(ctx context.Context, text string) (err error) {
+	ctx, span := tracing.Start(ctx, "Slack.Post")
+	defer func() { tracing.End(span, err) }()
+
+	if !w.Enabled() {
+		return ErrNotConfigured
+	}
+
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		// The error contains the URL, which is the webhook's secret
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return errors.New("slack request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("slack webhook error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
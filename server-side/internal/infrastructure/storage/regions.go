@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Regions holds one storage per data region, so that a user's files can be kept in the
+// region they chose
+type Regions struct {
+	defaultRegion string
+	stores        map[string]Storage
+}
+
+// NewRegions creates the storage of every configured region. The default region is
+// stored in config.LocalDir.
+func NewRegions(config Config) (*Regions, error) {
+	if config.DefaultRegion == "" {
+		return nil, fmt.Errorf("default storage region is not set")
+	}
+
+	defaultStore, err := New(config)
+	if err != nil {
+		return nil, err
+	}
+	regions := &Regions{
+		defaultRegion: config.DefaultRegion,
+		stores:        map[string]Storage{config.DefaultRegion: defaultStore},
+	}
+
+	for region, dir := range config.Regions {
+		if region == config.DefaultRegion {
+			continue
+		}
+		store, err := New(Config{Driver: config.Driver, LocalDir: dir})
+		if err != nil {
+			return nil, fmt.Errorf("storage region %q: %w", region, err)
+		}
+		regions.stores[region] = store
+	}
+	return regions, nil
+}
+
+// DefaultRegion returns the region of users without a preference
+func (r *Regions) DefaultRegion() string {
+	return r.defaultRegion
+}
+
+// Names returns the configured regions, sorted
+func (r *Regions) Names() []string {
+	names := make([]string, 0, len(r.stores))
+	for name := range r.stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has reports whether region is configured
+func (r *Regions) Has(region string) bool {
+	_, ok := r.stores[region]
+	return ok
+}
+
+// Get returns the storage of region; an empty region is the default region
+func (r *Regions) Get(region string) (Storage, error) {
+	if region == "" {
+		region = r.defaultRegion
+	}
+	store, ok := r.stores[region]
+	if !ok {
+		return nil, fmt.Errorf("unknown storage region %q", region)
+	}
+	return store, nil
+}
+
+// Move copies every object below prefix from one storage to another and deletes it
+// from the source once copied, returning the number of objects moved. An interrupted
+// move can be run again; objects already moved are no longer listed in the source.
+func Move(ctx context.Context, from, to Storage, prefix string) (int, error) {
+	keys, err := from.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, key := range keys {
+		if err := copyObject(ctx, from, to, key); err != nil {
+			return i, fmt.Errorf("failed to copy %s: %w", key, err)
+		}
+		if err := from.Delete(ctx, key); err != nil {
+			return i, fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+	return len(keys), nil
+}
+
+func copyObject(ctx context.Context, from, to Storage, key string) error {
+	r, err := from.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return to.Put(ctx, key, r)
+}
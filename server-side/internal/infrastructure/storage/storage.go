@@ -0,0 +1,55 @@
+// Package storage stores files, such as exports, outside the database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Storage drivers
+const (
+	DriverLocal = "local"
+)
+
+// ErrNotFound is returned when no object is stored under a key
+var ErrNotFound = errors.New("storage object not found")
+
+// Storage stores objects under slash-separated keys, e.g. "exports/2026-09/data.parquet"
+type Storage interface {
+	// Put stores the content read from r under key, replacing any existing object.
+	// Readers never see a partially written object.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get opens the object stored under key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// List returns the keys of the objects below prefix, e.g. "users/<id>/", sorted
+	List(ctx context.Context, prefix string) ([]string, error)
+
+	// Delete removes the object stored under key; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Config holds the storage settings
+type Config struct {
+	Driver   string // DriverLocal
+	LocalDir string // root directory of the local driver
+
+	// DefaultRegion names the data region stored in LocalDir
+	DefaultRegion string
+
+	// Regions maps the other data regions to the root directory of their storage
+	Regions map[string]string
+}
+
+// New creates the storage of the configured driver
+func New(config Config) (Storage, error) {
+	switch config.Driver {
+	case DriverLocal:
+		return NewLocal(config.LocalDir)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", config.Driver)
+	}
+}
@@ -0,0 +1,152 @@
+// Package telegram sends messages through the Telegram Bot API.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// DefaultBaseURL is the Bot API host
+const DefaultBaseURL = "https://api.telegram.org"
+
+// ErrNotConfigured is returned when no bot token is set
+var ErrNotConfigured = errors.New("telegram client is not configured")
+
+// ErrChatUnreachable is returned when the chat does not exist or the user blocked the bot
+var ErrChatUnreachable = errors.New("telegram chat cannot be reached")
+
+// Config holds the Telegram bot settings
+type Config struct {
+	BotToken string
+	BaseURL  string // defaults to DefaultBaseURL
+
+	// Timeout bounds a single HTTP request
+	Timeout time.Duration
+}
+
+// Client sends Telegram messages on behalf of the configured bot
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new Telegram client
+func NewClient(config Config) *Client {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Enabled reports whether the client has a bot token
+func (c *Client) Enabled() bool {
+	return c.config.BotToken != ""
+}
+
+// SendMessage sends a plain-text message to a chat. The bot can only message users who
+// started a chat with it.
+func (c *Client) SendMessage(ctx context.Context, chatID, text string) (err error) {
+	ctx, span := tracing.Start(ctx, "Telegram.SendMessage")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"chat_id": chatID,
+		"text":    text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", c.config.BaseURL, c.config.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The error contains the URL, and with it the bot token
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return errors.New("telegram request failed")
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	_ = json.Unmarshal(body, &result)
+
+	switch {
+	case result.OK:
+		return nil
+	case resp.StatusCode == http.StatusForbidden,
+		resp.StatusCode == http.StatusBadRequest && strings.Contains(result.Description, "chat not found"):
+		return fmt.Errorf("%w: %s", ErrChatUnreachable, result.Description)
+	default:
+		return fmt.Errorf("telegram API error (status %d): %s", resp.StatusCode, result.Description)
+	}
+}
+
+// Ping checks that the Bot API is reachable and accepts the bot token
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "Telegram.Ping")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return ErrNotConfigured
+	}
+
+	url := fmt.Sprintf("%s/bot%s/getMe", c.config.BaseURL, c.config.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// The error contains the URL, and with it the bot token
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return errors.New("telegram request failed")
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	_ = json.Unmarshal(body, &result)
+
+	if !result.OK {
+		return fmt.Errorf("telegram API error (status %d): %s", resp.StatusCode, result.Description)
+	}
+	return nil
+}
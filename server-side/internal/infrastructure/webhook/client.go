@@ -0,0 +1,224 @@
+// Package webhook sends signed events to URLs registered by users.
+//
+// Every request is a JSON POST carrying these headers:
+//
+//	X-Catetin-Event:     the event type, e.g. money_flow.created
+//	X-Catetin-Delivery:  the event ID, the same on every retry so receivers can drop duplicates
+//	X-Catetin-Timestamp: the Unix time the request was signed
+//	X-Catetin-Signature: sha256=<hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret>
+//
+// Users choose the URLs, so the client refuses to connect to loopback, private, and
+// link-local addresses, checked on the resolved address of every connection, and does
+// not follow redirects.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+)
+
+// Request headers
+const (
+	HeaderEvent     = "X-Catetin-Event"
+	HeaderDelivery  = "X-Catetin-Delivery"
+	HeaderTimestamp = "X-Catetin-Timestamp"
+	HeaderSignature = "X-Catetin-Signature"
+)
+
+// SecretPrefix identifies catetin webhook secrets
+const SecretPrefix = "whsec_"
+
+// ErrForbiddenAddress is returned when a URL points to an address webhooks may not reach
+var ErrForbiddenAddress = errors.New("webhook: destination address is not allowed")
+
+// sharedAddressSpace is the carrier-grade NAT range, private in practice
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Config holds the settings of the webhook client
+type Config struct {
+	// Timeout bounds a single request, including reading the response status
+	Timeout time.Duration
+
+	// AllowPrivateNetworks lets webhooks reach loopback and private addresses over plain
+	// HTTP, for development. Keep it off in production.
+	AllowPrivateNetworks bool
+
+	// UserAgent is sent with every request
+	UserAgent string
+}
+
+// Request is an event sent to one webhook
+type Request struct {
+	URL       string
+	Secret    string
+	EventType string
+	EventID   uuid.UUID
+	Body      []byte
+}
+
+// Response is what the webhook answered
+type Response struct {
+	StatusCode int
+	Duration   time.Duration
+}
+
+// Client sends events to webhooks
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new webhook client
+func NewClient(config Config) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = "Catetin-Webhooks/1.0"
+	}
+
+	c := &Client{config: config}
+	dialer := &net.Dialer{Timeout: config.Timeout, Control: c.checkDial}
+	c.httpClient = &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			// No proxy: it would connect on our behalf, bypassing the address check
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: config.Timeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return c
+}
+
+// GenerateSecret generates a new random signing secret
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return SecretPrefix + hex.EncodeToString(buf), nil
+}
+
+// Sign returns the X-Catetin-Signature header of a body signed at the Unix timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CheckURL checks that a webhook may be registered with the URL: it must use HTTPS and
+// must not name a private host or address. Hostnames are checked again once resolved,
+// when a request is sent.
+func (c *Client) CheckURL(rawURL string) error {
+	if c.config.AllowPrivateNetworks {
+		return nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "https" {
+		return errors.New("url must use https")
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".local") || strings.HasSuffix(host, ".internal") {
+		return ErrForbiddenAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublic(addr) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// Send posts a signed event to a webhook. It returns the response together with an
+// error when the webhook answers with a status other than 2xx.
+func (c *Client) Send(ctx context.Context, req Request) (resp *Response, err error) {
+	ctx, span := tracing.Start(ctx, "Webhook.Send")
+	defer func() { tracing.End(span, err) }()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", c.config.UserAgent)
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderDelivery, req.EventID.String())
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Body))
+
+	start := time.Now()
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		// The url.Error repeats the URL, which may carry a token of the receiver
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(httpResp.Body, 64<<10))
+
+	resp = &Response{
+		StatusCode: httpResp.StatusCode,
+		Duration:   time.Since(start),
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return resp, fmt.Errorf("webhook responded with status %d", httpResp.StatusCode)
+	}
+	return resp, nil
+}
+
+// checkDial refuses connections to addresses webhooks may not reach. It runs on the
+// resolved address, so a public hostname resolving to a private address is refused too.
+func (c *Client) checkDial(network, address string, _ syscall.RawConn) error {
+	if c.config.AllowPrivateNetworks {
+		return nil
+	}
+
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublic(addrPort.Addr()) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// isPublic checks if an address is routable on the internet
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSendSignsTheBody(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{AllowPrivateNetworks: true})
+	eventID := uuid.New()
+	resp, err := client.Send(context.Background(), Request{
+		URL:       server.URL,
+		Secret:    "whsec_test",
+		EventType: "money_flow.created",
+		EventID:   eventID,
+		Body:      []byte(`{"type":"money_flow.created"}`),
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	timestamp, err := strconv.ParseInt(received.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%s header = %q, want a Unix time", HeaderTimestamp, received.Header.Get(HeaderTimestamp))
+	}
+	if got, want := received.Header.Get(HeaderSignature), Sign("whsec_test", timestamp, body); got != want {
+		t.Errorf("%s header = %q, want %q", HeaderSignature, got, want)
+	}
+	if got := received.Header.Get(HeaderDelivery); got != eventID.String() {
+		t.Errorf("%s header = %q, want %q", HeaderDelivery, got, eventID)
+	}
+	if got := received.Header.Get(HeaderEvent); got != "money_flow.created" {
+		t.Errorf("%s header = %q, want money_flow.created", HeaderEvent, got)
+	}
+}
+
+func TestSendFailsOnErrorStatusAndPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://example.com/", http.StatusFound)
+	}))
+	defer server.Close()
+	req := Request{URL: server.URL, Secret: "whsec_test", EventType: "ping", EventID: uuid.New(), Body: []byte(`{}`)}
+
+	// Redirects are not followed, so they count as a failed delivery
+	resp, err := NewClient(Config{AllowPrivateNetworks: true}).Send(context.Background(), req)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusFound {
+		t.Errorf("Send(redirect) = %+v, %v; want status 302 and an error", resp, err)
+	}
+
+	if _, err := NewClient(Config{}).Send(context.Background(), req); !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Send(loopback) error = %v, want %v", err, ErrForbiddenAddress)
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	client := NewClient(Config{})
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://hooks.example.com/catetin", false},
+		{"https://93.184.216.34/hook", false},
+		{"http://hooks.example.com/catetin", true},
+		{"https://localhost/hook", true},
+		{"https://127.0.0.1/hook", true},
+		{"https://10.0.0.8/hook", true},
+		{"https://169.254.169.254/latest/meta-data", true},
+		{"https://[::1]/hook", true},
+		{"https://metadata.google.internal/", true},
+	}
+	for _, tt := range tests {
+		if err := client.CheckURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("CheckURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+
+	if err := NewClient(Config{AllowPrivateNetworks: true}).CheckURL("http://localhost:8080/hook"); err != nil {
+		t.Errorf("CheckURL(localhost, private networks allowed) error = %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+// Package whatsapp sends messages through the WhatsApp Business Cloud API (Graph API).
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultBaseURL is the Graph API host
+const DefaultBaseURL = "https://graph.facebook.com"
+
+// Config holds the WhatsApp Business API settings
+type Config struct {
+	PhoneNumberID string // sender phone number ID
+	AccessToken   string
+	APIVersion    string // e.g. v21.0
+	BaseURL       string // defaults to DefaultBaseURL
+
+	// Timeout bounds a single HTTP attempt
+	Timeout time.Duration
+
+	// MaxRetries is the number of retries after a temporary failure
+	MaxRetries int
+
+	// BaseBackoff is the delay before the first retry; it doubles on every retry
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the retry delay, including delays asked for with Retry-After
+	MaxBackoff time.Duration
+}
+
+// Client sends WhatsApp messages on behalf of the configured phone number
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new WhatsApp client
+func NewClient(config Config) *Client {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.APIVersion == "" {
+		config.APIVersion = "v21.0"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = 500 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 10 * time.Second
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// Enabled reports whether the client has credentials to send messages
+func (c *Client) Enabled() bool {
+	return c.config.PhoneNumberID != "" && c.config.AccessToken != ""
+}
+
+// SendText sends a free-form text message. WhatsApp only delivers it within 24 hours
+// of the recipient's last message; outside that window use SendTemplate.
+func (c *Client) SendText(ctx context.Context, to, body string) (string, error) {
+	return c.send(ctx, "text", &message{
+		To:   to,
+		Type: "text",
+		Text: &textContent{Body: body},
+	})
+}
+
+// SendTemplate sends a pre-approved template message
+func (c *Client) SendTemplate(ctx context.Context, to string, template Template) (string, error) {
+	return c.send(ctx, "template", &message{
+		To:       to,
+		Type:     "template",
+		Template: template.content(),
+	})
+}
+
+// SendInteractive sends a message with up to three reply buttons
+func (c *Client) SendInteractive(ctx context.Context, to string, interactive Interactive) (string, error) {
+	if len(interactive.Buttons) == 0 || len(interactive.Buttons) > MaxReplyButtons {
+		return "", fmt.Errorf("interactive message needs 1 to %d buttons, got %d", MaxReplyButtons, len(interactive.Buttons))
+	}
+
+	return c.send(ctx, "interactive", &message{
+		To:          to,
+		Type:        "interactive",
+		Interactive: interactive.content(),
+	})
+}
+
+// send posts the message, retrying temporary failures, and returns the message ID
+func (c *Client) send(ctx context.Context, kind string, msg *message) (id string, err error) {
+	ctx, span := tracing.Start(ctx, "WhatsApp.Send", attribute.String("whatsapp.message_type", kind))
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return "", ErrNotConfigured
+	}
+
+	msg.MessagingProduct = "whatsapp"
+	msg.RecipientType = "individual"
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		var retryAfter time.Duration
+		id, retryAfter, err = c.post(ctx, payload)
+		if err == nil || attempt >= c.config.MaxRetries || !isTemporary(err) {
+			return id, err
+		}
+
+		delay := c.backoff(attempt, retryAfter)
+		logger.FromContext(ctx).Warn("whatsapp send failed, retrying",
+			"message_type", kind,
+			"attempt", attempt+1,
+			"retry_in", delay,
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// post makes one request. The returned duration is the server's Retry-After, if any.
+// Ping checks that the Graph API is reachable and the access token can read the
+// sender phone number
+func (c *Client) Ping(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "WhatsApp.Ping")
+	defer func() { tracing.End(span, err) }()
+
+	if !c.Enabled() {
+		return errors.New("whatsapp client is not configured")
+	}
+
+	url := fmt.Sprintf("%s/%s/%s?fields=id", c.config.BaseURL, c.config.APIVersion, c.config.PhoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.AccessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("whatsapp request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode >= 300 {
+		return parseError(resp.StatusCode, body)
+	}
+	return nil
+}
+
+func (c *Client) post(ctx context.Context, payload []byte) (string, time.Duration, error) {
+	url := fmt.Sprintf("%s/%s/%s/messages", c.config.BaseURL, c.config.APIVersion, c.config.PhoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Cancellation by the caller is final; anything else is a network failure
+		if ctx.Err() != nil {
+			return "", 0, ctx.Err()
+		}
+		return "", 0, &temporaryError{err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", 0, &temporaryError{err: fmt.Errorf("failed to read response: %w", err)}
+	}
+
+	if resp.StatusCode >= 300 {
+		return "", retryAfter(resp.Header.Get("Retry-After")), parseError(resp.StatusCode, body)
+	}
+
+	var sent sendResponse
+	if err := json.Unmarshal(body, &sent); err != nil {
+		return "", 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(sent.Messages) == 0 {
+		return "", 0, errors.New("response contains no message ID")
+	}
+
+	return sent.Messages[0].ID, 0, nil
+}
+
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	delay := c.config.BaseBackoff
+	for i := 0; i < attempt && delay < c.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = max(delay, retryAfter)
+	return min(delay, c.config.MaxBackoff)
+}
+
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
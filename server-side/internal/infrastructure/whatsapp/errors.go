@@ -0,0 +1,145 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Errors a failed send maps to; match them with errors.Is
+var (
+	// ErrNotConfigured is returned when the phone number ID or access token is missing
+	ErrNotConfigured = errors.New("whatsapp client is not configured")
+
+	// ErrUnauthorized means the access token is invalid, expired, or lacks permissions
+	ErrUnauthorized = errors.New("whatsapp access token rejected")
+
+	// ErrRateLimited means too many messages were sent; the client retries these
+	ErrRateLimited = errors.New("whatsapp rate limit reached")
+
+	// ErrOutsideWindow means more than 24 hours passed since the recipient's last
+	// message, so only template messages can be delivered
+	ErrOutsideWindow = errors.New("whatsapp customer service window closed")
+
+	// ErrRecipientUnreachable means the number cannot receive WhatsApp messages
+	ErrRecipientUnreachable = errors.New("whatsapp recipient unreachable")
+
+	// ErrInvalidTemplate means the template does not exist or its parameters do not match
+	ErrInvalidTemplate = errors.New("whatsapp template rejected")
+
+	// ErrInvalidRequest means the message was rejected as malformed
+	ErrInvalidRequest = errors.New("whatsapp request rejected")
+
+	// ErrUnavailable means the API failed on its side; the client retries these
+	ErrUnavailable = errors.New("whatsapp service unavailable")
+)
+
+// APIError is an error returned by the Graph API
+type APIError struct {
+	StatusCode int
+	Code       int
+	Subcode    int
+	Type       string
+	Message    string
+	Details    string
+	TraceID    string
+
+	kind error
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("whatsapp api error %d (http %d): %s", e.Code, e.StatusCode, e.Message)
+	if e.Details != "" {
+		msg += ": " + e.Details
+	}
+	return msg
+}
+
+// Unwrap returns the error kind, e.g. ErrRateLimited
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+type errorResponse struct {
+	Error struct {
+		Message   string `json:"message"`
+		Type      string `json:"type"`
+		Code      int    `json:"code"`
+		Subcode   int    `json:"error_subcode"`
+		ErrorData struct {
+			Details string `json:"details"`
+		} `json:"error_data"`
+		TraceID string `json:"fbtrace_id"`
+	} `json:"error"`
+}
+
+// parseError maps an error response to an APIError
+func parseError(statusCode int, body []byte) error {
+	apiErr := &APIError{StatusCode: statusCode, Message: http.StatusText(statusCode)}
+
+	var resp errorResponse
+	if err := json.Unmarshal(body, &resp); err == nil && resp.Error.Code != 0 {
+		apiErr.Code = resp.Error.Code
+		apiErr.Subcode = resp.Error.Subcode
+		apiErr.Type = resp.Error.Type
+		apiErr.Message = resp.Error.Message
+		apiErr.Details = resp.Error.ErrorData.Details
+		apiErr.TraceID = resp.Error.TraceID
+	}
+
+	apiErr.kind = errorKind(statusCode, apiErr.Code)
+	return apiErr
+}
+
+// errorKind classifies Graph API error codes, see
+// https://developers.facebook.com/docs/whatsapp/cloud-api/support/error-codes
+func errorKind(statusCode, code int) error {
+	switch {
+	case code == 0 || code == 190:
+		if statusCode == http.StatusUnauthorized || code == 190 {
+			return ErrUnauthorized
+		}
+	case code == 10 || (code >= 200 && code <= 299):
+		return ErrUnauthorized
+	case code == 4 || code == 80007 || code == 130429 || code == 131048 || code == 131056:
+		return ErrRateLimited
+	case code == 131047:
+		return ErrOutsideWindow
+	case code == 131026 || code == 131030:
+		return ErrRecipientUnreachable
+	case code >= 132000 && code <= 132999:
+		return ErrInvalidTemplate
+	case code == 1 || code == 2 || code == 131000 || code == 131016 || code == 133004:
+		return ErrUnavailable
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode >= 500:
+		return ErrUnavailable
+	}
+	return ErrInvalidRequest
+}
+
+// temporaryError wraps network failures, which are retried
+type temporaryError struct {
+	err error
+}
+
+func (e *temporaryError) Error() string {
+	return "whatsapp request failed: " + e.err.Error()
+}
+
+func (e *temporaryError) Unwrap() error {
+	return e.err
+}
+
+func isTemporary(err error) bool {
+	var netErr *temporaryError
+	return errors.As(err, &netErr) || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUnavailable)
+}
@@ -0,0 +1,140 @@
+package whatsapp
+
+// MaxReplyButtons is the most reply buttons an interactive message can have
+const MaxReplyButtons = 3
+
+// Template is a pre-approved message template
+type Template struct {
+	Name     string
+	Language string // template language code, e.g. "id" or "en_US"
+
+	// BodyParameters fill the {{1}}, {{2}}, ... placeholders of the template body
+	BodyParameters []string
+}
+
+// Interactive is a message with reply buttons. Header and Footer are optional.
+type Interactive struct {
+	Header  string
+	Body    string
+	Footer  string
+	Buttons []Button
+}
+
+// Button is a reply button. The ID is sent back in the webhook when the user taps it.
+type Button struct {
+	ID    string
+	Title string // at most 20 characters
+}
+
+// Graph API request and response payloads
+
+type message struct {
+	MessagingProduct string              `json:"messaging_product"`
+	RecipientType    string              `json:"recipient_type"`
+	To               string              `json:"to"`
+	Type             string              `json:"type"`
+	Text             *textContent        `json:"text,omitempty"`
+	Template         *templateContent    `json:"template,omitempty"`
+	Interactive      *interactiveContent `json:"interactive,omitempty"`
+}
+
+type textContent struct {
+	Body string `json:"body"`
+}
+
+type templateContent struct {
+	Name       string              `json:"name"`
+	Language   templateLanguage    `json:"language"`
+	Components []templateComponent `json:"components,omitempty"`
+}
+
+type templateLanguage struct {
+	Code string `json:"code"`
+}
+
+type templateComponent struct {
+	Type       string              `json:"type"`
+	Parameters []templateParameter `json:"parameters"`
+}
+
+type templateParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type interactiveContent struct {
+	Type   string             `json:"type"`
+	Header *interactiveHeader `json:"header,omitempty"`
+	Body   interactiveText    `json:"body"`
+	Footer *interactiveText   `json:"footer,omitempty"`
+	Action interactiveAction  `json:"action"`
+}
+
+type interactiveHeader struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type interactiveText struct {
+	Text string `json:"text"`
+}
+
+type interactiveAction struct {
+	Buttons []interactiveButton `json:"buttons"`
+}
+
+type interactiveButton struct {
+	Type  string      `json:"type"`
+	Reply replyButton `json:"reply"`
+}
+
+type replyButton struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type sendResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+}
+
+func (t Template) content() *templateContent {
+	content := &templateContent{
+		Name:     t.Name,
+		Language: templateLanguage{Code: t.Language},
+	}
+
+	if len(t.BodyParameters) > 0 {
+		parameters := make([]templateParameter, len(t.BodyParameters))
+		for i, text := range t.BodyParameters {
+			parameters[i] = templateParameter{Type: "text", Text: text}
+		}
+		content.Components = []templateComponent{{Type: "body", Parameters: parameters}}
+	}
+
+	return content
+}
+
+func (i Interactive) content() *interactiveContent {
+	content := &interactiveContent{
+		Type: "button",
+		Body: interactiveText{Text: i.Body},
+	}
+	if i.Header != "" {
+		content.Header = &interactiveHeader{Type: "text", Text: i.Header}
+	}
+	if i.Footer != "" {
+		content.Footer = &interactiveText{Text: i.Footer}
+	}
+
+	content.Action.Buttons = make([]interactiveButton, len(i.Buttons))
+	for n, button := range i.Buttons {
+		content.Action.Buttons[n] = interactiveButton{
+			Type:  "reply",
+			Reply: replyButton{ID: button.ID, Title: button.Title},
+		}
+	}
+
+	return content
+}
@@ -0,0 +1,29 @@
+package whatsapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader is the header carrying the webhook payload signature
+const SignatureHeader = "X-Hub-Signature-256"
+
+// VerifySignature checks that a webhook body was signed with the app secret.
+// The header has the form "sha256=<hex HMAC-SHA256 of the raw body>".
+func VerifySignature(appSecret string, body []byte, header string) bool {
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok || appSecret == "" {
+		return false
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
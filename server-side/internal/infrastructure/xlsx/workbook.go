@@ -0,0 +1,195 @@
+// Package xlsx writes Office Open XML spreadsheets (.xlsx).
+//
+// It is a small layer over excelize for what the exports need: several sheets of
+// text, numbers, and date-times, with a bold header row. Rows are kept in memory
+// until Write, so sheets can be filled in any order.
+package xlsx
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ContentType is the media type of .xlsx files
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// maxSheetNameLength is Excel's limit on sheet names
+const maxSheetNameLength = 31
+
+// Built-in Excel number formats
+const (
+	numFmtNumber   = 4  // #,##0.00
+	numFmtDateTime = 22 // m/d/yy h:mm
+)
+
+// excelEpoch is day zero of Excel's date serial numbers
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Workbook is a spreadsheet of one or more sheets
+type Workbook struct {
+	sheets []*Sheet
+	names  map[string]bool
+}
+
+// Sheet is a worksheet; rows are written in the order they are added
+type Sheet struct {
+	name string
+	rows [][]cell
+}
+
+type cell struct {
+	value interface{} // nil for an empty cell
+	style style
+}
+
+type style int
+
+const (
+	styleDefault style = iota
+	styleHeader
+	styleNumber
+	styleDateTime
+)
+
+// New creates an empty workbook
+func New() *Workbook {
+	return &Workbook{names: map[string]bool{}}
+}
+
+// AddSheet adds a sheet. The name is adjusted to Excel's rules: characters Excel
+// rejects are replaced, it is cut to 31 characters, and a suffix keeps it unique.
+func (wb *Workbook) AddSheet(name string) *Sheet {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, strings.Trim(strings.TrimSpace(name), "'"))
+	if name == "" {
+		name = "Sheet"
+	}
+
+	unique := truncate(name, maxSheetNameLength)
+	for i := 2; wb.names[strings.ToLower(unique)]; i++ {
+		suffix := " (" + strconv.Itoa(i) + ")"
+		unique = truncate(name, maxSheetNameLength-len([]rune(suffix))) + suffix
+	}
+	wb.names[strings.ToLower(unique)] = true
+
+	sheet := &Sheet{name: unique}
+	wb.sheets = append(wb.sheets, sheet)
+	return sheet
+}
+
+// Name returns the sheet name as it appears in the workbook
+func (s *Sheet) Name() string {
+	return s.name
+}
+
+// AddHeader adds a row of bold text
+func (s *Sheet) AddHeader(titles ...string) {
+	row := make([]cell, len(titles))
+	for i, title := range titles {
+		row[i] = cell{value: title, style: styleHeader}
+	}
+	s.rows = append(s.rows, row)
+}
+
+// AddRow adds a row. Values may be string, *string, int, int64, float64, time.Time,
+// or nil for an empty cell; other values are written with fmt.Sprint.
+func (s *Sheet) AddRow(values ...interface{}) {
+	row := make([]cell, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case string, int, int64:
+			row[i] = cell{value: v}
+		case *string:
+			if v != nil {
+				row[i] = cell{value: *v}
+			}
+		case float64:
+			row[i] = cell{value: v, style: styleNumber}
+		case time.Time:
+			days := v.UTC().Sub(excelEpoch).Seconds() / 86400
+			row[i] = cell{value: days, style: styleDateTime}
+		default:
+			row[i] = cell{value: fmt.Sprint(v)}
+		}
+	}
+	s.rows = append(s.rows, row)
+}
+
+// Write writes the workbook as an .xlsx file
+func (wb *Workbook) Write(w io.Writer) error {
+	if len(wb.sheets) == 0 {
+		wb.AddSheet("Sheet")
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	styles := map[style]int{}
+	for s, definition := range map[style]*excelize.Style{
+		styleHeader:   {Font: &excelize.Font{Bold: true}},
+		styleNumber:   {NumFmt: numFmtNumber},
+		styleDateTime: {NumFmt: numFmtDateTime},
+	} {
+		id, err := f.NewStyle(definition)
+		if err != nil {
+			return err
+		}
+		styles[s] = id
+	}
+
+	for i, sheet := range wb.sheets {
+		if i == 0 {
+			if err := f.SetSheetName(f.GetSheetName(0), sheet.name); err != nil {
+				return err
+			}
+		} else if _, err := f.NewSheet(sheet.name); err != nil {
+			return err
+		}
+		if err := sheet.write(f, styles); err != nil {
+			return fmt.Errorf("sheet %q: %w", sheet.name, err)
+		}
+	}
+
+	return f.Write(w)
+}
+
+func (s *Sheet) write(f *excelize.File, styles map[style]int) error {
+	stream, err := f.NewStreamWriter(s.name)
+	if err != nil {
+		return err
+	}
+	for r, row := range s.rows {
+		values := make([]interface{}, len(row))
+		for c, cell := range row {
+			if cell.value != nil {
+				values[c] = excelize.Cell{StyleID: styles[cell.style], Value: cell.value}
+			}
+		}
+		ref, err := excelize.CoordinatesToCellName(1, r+1)
+		if err != nil {
+			return err
+		}
+		if err := stream.SetRow(ref, values); err != nil {
+			return err
+		}
+	}
+	return stream.Flush()
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,119 @@
+package xlsx
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestWorkbookWrite(t *testing.T) {
+	wb := New()
+	flows := wb.AddSheet("Money flows")
+	flows.AddHeader("Date", "Description", "Amount", "Count", "Note")
+	note := "<b>bold</b> & \"quoted\"\nsecond line"
+	flows.AddRow(time.Date(2026, 10, 16, 18, 0, 0, 0, time.FixedZone("WIB", 7*3600)), "Kopi", 25000.5, 3, &note)
+	flows.AddRow(nil, (*string)(nil), int64(-12), struct{ A int }{7})
+	wb.AddSheet("Summary").AddRow("Total")
+
+	f := read(t, wb)
+	if sheets := f.GetSheetList(); !reflect.DeepEqual(sheets, []string{"Money flows", "Summary"}) {
+		t.Fatalf("sheets = %q, want Money flows and Summary", sheets)
+	}
+
+	want := []struct {
+		ref    string
+		typ    excelize.CellType
+		value  string
+		bold   bool
+		numFmt int
+	}{
+		{"A1", excelize.CellTypeInlineString, "Date", true, 0},
+		{"E1", excelize.CellTypeInlineString, "Note", true, 0},
+		{"A2", excelize.CellTypeUnset, "46311.458333333336", false, numFmtDateTime},
+		{"B2", excelize.CellTypeInlineString, "Kopi", false, 0},
+		{"C2", excelize.CellTypeUnset, "25000.5", false, numFmtNumber},
+		{"D2", excelize.CellTypeUnset, "3", false, 0},
+		{"E2", excelize.CellTypeInlineString, note, false, 0},
+		{"A3", excelize.CellTypeUnset, "", false, 0},
+		{"B3", excelize.CellTypeUnset, "", false, 0},
+		{"C3", excelize.CellTypeUnset, "-12", false, 0},
+		{"D3", excelize.CellTypeInlineString, "{7}", false, 0},
+	}
+	for _, tt := range want {
+		value, err := f.GetCellValue("Money flows", tt.ref, excelize.Options{RawCellValue: true})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.ref, err)
+		}
+		typ, _ := f.GetCellType("Money flows", tt.ref)
+		if value != tt.value || typ != tt.typ {
+			t.Errorf("%s = %q of type %v, want %q of type %v", tt.ref, value, typ, tt.value, tt.typ)
+		}
+
+		id, _ := f.GetCellStyle("Money flows", tt.ref)
+		style, err := f.GetStyle(id)
+		if err != nil {
+			t.Fatalf("%s style: %v", tt.ref, err)
+		}
+		bold := style.Font != nil && style.Font.Bold
+		if bold != tt.bold || style.NumFmt != tt.numFmt {
+			t.Errorf("%s is bold=%v with format %d, want bold=%v with format %d", tt.ref, bold, style.NumFmt, tt.bold, tt.numFmt)
+		}
+	}
+
+	if date, _ := f.GetCellValue("Money flows", "A2"); date != "10/16/26 11:00" {
+		t.Errorf("A2 shows as %q, want the UTC date-time", date)
+	}
+	if rows, _ := f.GetRows("Summary"); !reflect.DeepEqual(rows, [][]string{{"Total"}}) {
+		t.Errorf("Summary rows = %q", rows)
+	}
+}
+
+func TestWorkbookWriteWithoutSheets(t *testing.T) {
+	if sheets := read(t, New()).GetSheetList(); len(sheets) != 1 {
+		t.Errorf("sheets = %q, want one; Excel refuses to open a workbook without sheets", sheets)
+	}
+}
+
+func TestAddSheetNames(t *testing.T) {
+	wb := New()
+	long := strings.Repeat("Pengeluaran ", 4)
+	names := []string{}
+	for _, name := range []string{"Jan/Feb [2026]", "  'quoted'  ", "", long, long, "sheet", "SHEET"} {
+		names = append(names, wb.AddSheet(name).Name())
+	}
+	want := []string{
+		"Jan_Feb _2026_",
+		"quoted",
+		"Sheet",
+		"Pengeluaran Pengeluaran Pengelu",
+		"Pengeluaran Pengeluaran Pen (2)",
+		"sheet (2)",
+		"SHEET (3)",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+	if sheets := read(t, wb).GetSheetList(); !reflect.DeepEqual(sheets, want) {
+		t.Errorf("written sheets = %q, want %q", sheets, want)
+	}
+}
+
+// read writes the workbook and opens the result
+func read(t *testing.T, wb *Workbook) *excelize.File {
+	t.Helper()
+
+	var out bytes.Buffer
+	if err := wb.Write(&out); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	f, err := excelize.OpenReader(&out)
+	if err != nil {
+		t.Fatalf("output is not a workbook: %v", err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
@@ -0,0 +1,117 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/storage"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+func TestAccountErasureAnonymizesPersonalDataAndKeepsMoneyFlows(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+
+	if _, err := env.MoneyFlowService().Create(ctx, user.ID, service.MoneyFlowInput{Amount: 45000, Category: ptr("Food")}); err != nil {
+		t.Fatalf("create money flow: %v", err)
+	}
+
+	regions, err := storage.NewRegions(storage.Config{Driver: storage.DriverLocal, LocalDir: t.TempDir(), DefaultRegion: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, _ := regions.Get("default")
+	attachment := service.UserFilesPrefix(user.ID) + "receipts/lunch.txt"
+	if err := store.Put(ctx, attachment, strings.NewReader("receipt")); err != nil {
+		t.Fatal(err)
+	}
+
+	conn := postgresql.NewDB(env.DB)
+	jobs := &recordedJobs{}
+	erasureService := service.NewAccountErasureService(postgresql.NewAccountErasureRepository(conn),
+		env.Repos.Users, env.Repos.UserAuths, env.Repos.UserSettings, postgresql.NewDeviceRepository(conn),
+		env.Repos.RefreshTokens, postgresql.NewAPIKeyRepository(conn),
+		service.NewDataResidencyService(env.Repos.Users, regions, jobs), service.NewAuditor(env.Repos.AuditLogs),
+		nil, env.TxManager, jobs, service.AccountErasureConfig{})
+
+	// A cancelled erasure is skipped when its job runs
+	if _, err := erasureService.RequestErasure(ctx, user.ID); err != nil {
+		t.Fatalf("RequestErasure() error = %v", err)
+	}
+	_, err = erasureService.RequestErasure(ctx, user.ID)
+	expectCode(t, err, appErrors.ErrCodeConflict)
+	if err := erasureService.CancelErasure(ctx, user.ID); err != nil {
+		t.Fatalf("CancelErasure() error = %v", err)
+	}
+	if err := erasureService.HandleEraseJob(ctx, jobs.jobs[0]); err != nil {
+		t.Fatalf("HandleEraseJob() of the cancelled erasure error = %v", err)
+	}
+	if _, err := env.Repos.Users.FindByID(ctx, user.ID); err != nil {
+		t.Fatalf("user after the cancelled erasure: %v", err)
+	}
+
+	erasure, err := erasureService.RequestErasure(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("RequestErasure() error = %v", err)
+	}
+	if len(jobs.jobs) != 2 || jobs.jobs[1].Type != service.JobEraseAccount || !jobs.jobs[1].RunAt.Equal(erasure.EraseAt) {
+		t.Fatalf("jobs = %+v, want the erasure scheduled at %v", jobs.jobs, erasure.EraseAt)
+	}
+	if err := erasureService.HandleEraseJob(ctx, jobs.jobs[1]); err != nil {
+		t.Fatalf("HandleEraseJob() error = %v", err)
+	}
+
+	if _, err := env.Repos.Users.FindByID(ctx, user.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("FindByID() after the erasure error = %v, want ErrNotFound", err)
+	}
+	var erased struct {
+		FullName    string
+		PhoneNumber string
+	}
+	if err := env.DB.Table("users").Select("full_name, phone_number").Where("id = ?", user.ID).Take(&erased).Error; err != nil {
+		t.Fatal(err)
+	}
+	if erased.FullName == user.FullName || erased.PhoneNumber == user.PhoneNumber {
+		t.Errorf("erased user = %+v, want the name and phone number replaced", erased)
+	}
+	var credentials []string
+	if err := env.DB.Table("user_auths").Where("user_id = ?", user.ID).Pluck("credential_id", &credentials).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(credentials) == 0 {
+		t.Error("the sign-in methods of the erased user are gone, want them kept anonymized")
+	}
+	for _, credential := range credentials {
+		if strings.Contains(credential, "budi") {
+			t.Errorf("credential ID %q was not anonymized", credential)
+		}
+	}
+
+	var moneyFlows int64
+	if err := env.DB.Table("money_flows").Where("user_id = ?", user.ID).Count(&moneyFlows).Error; err != nil {
+		t.Fatal(err)
+	}
+	if moneyFlows != 1 {
+		t.Errorf("money flows after the erasure = %d, want 1", moneyFlows)
+	}
+	if keys, _ := store.List(ctx, service.UserFilesPrefix(user.ID)); len(keys) != 0 {
+		t.Errorf("files after the erasure = %v, want none", keys)
+	}
+
+	logs, err := env.Repos.AuditLogs.FindByActorID(ctx, user.ID, domain.AuditLogFilter{EntityType: domain.AuditEntityAccountErasure}, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 4 || !strings.Contains(string(logs[0].After), domain.ErasureCompleted) {
+		t.Errorf("audit logs = %d, want the requests, the cancellation, and the completed erasure", len(logs))
+	}
+}
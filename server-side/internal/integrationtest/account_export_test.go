@@ -0,0 +1,160 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/storage"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// recordedJobs records enqueued jobs instead of running them
+type recordedJobs struct {
+	jobs []*worker.Job
+}
+
+func (q *recordedJobs) Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...worker.EnqueueOption) (*worker.Job, error) {
+	job, err := worker.NewJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	q.jobs = append(q.jobs, job)
+	return job, nil
+}
+
+func TestAccountExportDeliversAllDataBehindAnExpiringLink(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+
+	for _, amount := range []float64{45000, 12500} {
+		if _, err := env.MoneyFlowService().Create(ctx, user.ID, service.MoneyFlowInput{Amount: amount, Category: ptr("Food")}); err != nil {
+			t.Fatalf("create money flow: %v", err)
+		}
+	}
+	budget, err := domain.NewBudget(user.ID, "Food", 1000000, "IDR", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.Repos.Budgets.Create(ctx, budget); err != nil {
+		t.Fatalf("create budget: %v", err)
+	}
+
+	regions, err := storage.NewRegions(storage.Config{Driver: storage.DriverLocal, LocalDir: t.TempDir(), DefaultRegion: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, _ := regions.Get("default")
+	attachment := service.UserFilesPrefix(user.ID) + "receipts/lunch.txt"
+	if err := store.Put(ctx, attachment, strings.NewReader("receipt")); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs := &recordedJobs{}
+	exportService := service.NewAccountExportService(env.Repos.Users, env.Repos.UserAuths, env.Repos.AuthProviders,
+		env.Repos.UserSettings, env.Repos.MoneyFlows, env.Repos.Budgets, env.Repos.Wallets,
+		service.NewDataResidencyService(env.Repos.Users, regions, jobs), nil, jobs, service.AccountExportConfig{
+			URL:        "https://api.example.com/api/v1/account-exports",
+			TTL:        time.Hour,
+			SigningKey: "test-signing-key",
+		})
+
+	export, err := exportService.Export(ctx, user.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(jobs.jobs) != 1 || jobs.jobs[0].Type != service.JobDeleteAccountExport || !jobs.jobs[0].RunAt.Equal(export.ExpiresAt) {
+		t.Fatalf("jobs = %+v, want the deletion scheduled at %v", jobs.jobs, export.ExpiresAt)
+	}
+
+	link, err := url.Parse(export.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/api/v1/account-exports/" + user.ID.String() + "/" + export.ID.String(); link.Path != want {
+		t.Errorf("link path = %s, want %s", link.Path, want)
+	}
+	expires, _ := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	signature := link.Query().Get("signature")
+
+	open := func(expires int64, signature string) ([]byte, error) {
+		file, err := exportService.Open(ctx, user.ID, export.ID, expires, signature)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	content, err := open(expires, signature)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("export is not a ZIP: %v", err)
+	}
+	files := map[string]string{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(r)
+		r.Close()
+		files[file.Name] = string(data)
+	}
+
+	for _, name := range []string{"profile.json", "wallets.json", "wallets.csv", "budgets.json", "budgets.csv", "money_flows.json", "money_flows.csv"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("export is missing %s", name)
+		}
+	}
+	if files["attachments/receipts/lunch.txt"] != "receipt" {
+		t.Errorf("attachment = %q, want the stored file", files["attachments/receipts/lunch.txt"])
+	}
+	auths, err := env.Repos.UserAuths.FindByUserID(ctx, user.ID)
+	if err != nil || len(auths) != 1 {
+		t.Fatalf("FindByUserID() = %v, %v", auths, err)
+	}
+	if !strings.Contains(files["profile.json"], "budi@example.com") || strings.Contains(files["profile.json"], auths[0].CredentialSecret) {
+		t.Errorf("profile.json = %s, want the sign-in email without the password hash", files["profile.json"])
+	}
+	rows, err := csv.NewReader(strings.NewReader(files["money_flows.csv"])).ReadAll()
+	if err != nil || len(rows) != 3 {
+		t.Errorf("money_flows.csv has %d rows (%v), want a header and 2 money flows", len(rows), err)
+	}
+
+	if _, err := open(expires, strings.Repeat("0", len(signature))); !errors.Is(err, appErrors.ErrInvalidDownloadLink) {
+		t.Errorf("Open() with a forged signature error = %v, want ErrInvalidDownloadLink", err)
+	}
+	if _, err := open(expires+60, signature); !errors.Is(err, appErrors.ErrInvalidDownloadLink) {
+		t.Errorf("Open() with an extended expiry error = %v, want ErrInvalidDownloadLink", err)
+	}
+
+	if err := exportService.HandleDeleteJob(ctx, jobs.jobs[0]); err != nil {
+		t.Fatalf("HandleDeleteJob() error = %v", err)
+	}
+	if _, err := open(expires, signature); !errors.Is(err, appErrors.ErrInvalidDownloadLink) {
+		t.Errorf("Open() after the deletion error = %v, want ErrInvalidDownloadLink", err)
+	}
+}
@@ -0,0 +1,251 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// expectCode fails the test unless err is an AppError with the code
+func expectCode(t *testing.T, err error, code appErrors.ErrorCode) {
+	t.Helper()
+
+	var appErr *appErrors.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+	if appErr.Code != code {
+		t.Fatalf("expected %s, got %s", code, appErr.Code)
+	}
+}
+
+func TestRegisterLoginAndRefresh(t *testing.T) {
+	env := integrationtest.Setup(t)
+	authService := env.AuthService()
+	ctx := context.Background()
+
+	registered, err := authService.Register(ctx, "Budi", "budi@example.com", "password123", "")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	stored, err := env.Repos.Users.FindByID(ctx, registered.User.ID)
+	if err != nil {
+		t.Fatalf("find registered user: %v", err)
+	}
+	if stored.FullName != "Budi" || stored.PhoneNumber != "budi@example.com" {
+		t.Errorf("stored user is %q (%q), expected Budi (budi@example.com)", stored.FullName, stored.PhoneNumber)
+	}
+
+	login, err := authService.Login(ctx, "budi@example.com", "password123", "")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if login.User.ID != registered.User.ID {
+		t.Errorf("logged in as %s, expected %s", login.User.ID, registered.User.ID)
+	}
+
+	refreshed, err := authService.Refresh(ctx, login.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Error("refresh returned the same refresh token")
+	}
+
+	// Refresh tokens rotate, so the first one is revoked
+	_, err = authService.Refresh(ctx, login.RefreshToken)
+	expectCode(t, err, appErrors.ErrCodeInvalidToken)
+}
+
+func TestLoginRejectsWrongPassword(t *testing.T) {
+	env := integrationtest.Setup(t)
+	env.CreateUser(t, "sari@example.com", "password123")
+
+	_, err := env.AuthService().Login(context.Background(), "sari@example.com", "wrong-password", "")
+	expectCode(t, err, appErrors.ErrCodeInvalidCredentials)
+}
+
+func TestRegisterRejectsDuplicateEmail(t *testing.T) {
+	env := integrationtest.Setup(t)
+	env.CreateUser(t, "dewi@example.com", "password123")
+
+	_, err := env.AuthService().Register(context.Background(), "Dewi", "dewi@example.com", "password123", "")
+	expectCode(t, err, appErrors.ErrCodeEmailAlreadyExists)
+}
+
+func TestChangePasswordRevokesAccessTokens(t *testing.T) {
+	env := integrationtest.Setup(t)
+	authService := env.AuthService()
+	ctx := context.Background()
+	env.CreateUser(t, "rina@example.com", "password123")
+
+	login, err := authService.Login(ctx, "rina@example.com", "password123", "")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	claims, err := env.JWT.ValidateAccessToken(login.AccessToken)
+	if err != nil {
+		t.Fatalf("validate access token: %v", err)
+	}
+
+	if err := authService.ChangePassword(ctx, login.User.ID, "password123", "password456"); err != nil {
+		t.Fatalf("change password: %v", err)
+	}
+
+	version, err := env.Repos.Users.FindTokenVersion(ctx, login.User.ID)
+	if err != nil {
+		t.Fatalf("find token version: %v", err)
+	}
+	if version == claims.TokenVersion {
+		t.Errorf("token version is still %d, expected the access token to be revoked", version)
+	}
+
+	login, err = authService.Login(ctx, "rina@example.com", "password456", "")
+	if err != nil {
+		t.Fatalf("login with the new password: %v", err)
+	}
+	claims, err = env.JWT.ValidateAccessToken(login.AccessToken)
+	if err != nil {
+		t.Fatalf("validate access token: %v", err)
+	}
+	if claims.TokenVersion != version {
+		t.Errorf("new access token has version %d, expected %d", claims.TokenVersion, version)
+	}
+}
+
+func TestRepeatedFailedLoginsLockCredential(t *testing.T) {
+	env := integrationtest.Setup(t)
+	authService := env.AuthService()
+	ctx := context.Background()
+	env.CreateUser(t, "dewi@example.com", "password123")
+
+	// The default lockout allows 5 failed sign-ins in a row
+	for i := 0; i < 4; i++ {
+		_, err := authService.Login(ctx, "dewi@example.com", "wrong-password", "")
+		expectCode(t, err, appErrors.ErrCodeInvalidCredentials)
+	}
+	_, err := authService.Login(ctx, "dewi@example.com", "wrong-password", "")
+	expectCode(t, err, appErrors.ErrCodeAccountLocked)
+
+	// The right password is refused while the credential is locked
+	_, err = authService.Login(ctx, "dewi@example.com", "password123", "")
+	expectCode(t, err, appErrors.ErrCodeAccountLocked)
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	env := integrationtest.Setup(t)
+	authService := env.AuthService()
+	ctx := context.Background()
+	env.CreateUser(t, "tono@example.com", "password123")
+
+	phone, err := authService.Login(ctx, "tono@example.com", "password123", "mobile")
+	if err != nil {
+		t.Fatalf("login on the phone: %v", err)
+	}
+	laptop, err := authService.Login(ctx, "tono@example.com", "password123", "")
+	if err != nil {
+		t.Fatalf("login on the laptop: %v", err)
+	}
+
+	// Refreshing continues the session
+	refreshed, err := authService.Refresh(ctx, laptop.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	before, err := env.JWT.ValidateAccessToken(laptop.AccessToken)
+	if err != nil {
+		t.Fatalf("validate access token: %v", err)
+	}
+	after, err := env.JWT.ValidateAccessToken(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("validate access token: %v", err)
+	}
+	if after.Session() == uuid.Nil || after.Session() != before.Session() {
+		t.Fatalf("refreshed session is %q, expected %q", after.SessionID, before.SessionID)
+	}
+
+	revoked, err := authService.RevokeOtherSessions(ctx, refreshed.User.ID, after.Session())
+	if err != nil {
+		t.Fatalf("revoke other sessions: %v", err)
+	}
+	// Registering signed the user in too, so that session goes with the phone's
+	if revoked != 2 {
+		t.Errorf("revoked %d sessions, expected 2", revoked)
+	}
+
+	_, err = authService.Refresh(ctx, phone.RefreshToken)
+	expectCode(t, err, appErrors.ErrCodeInvalidToken)
+	if _, err := authService.Refresh(ctx, refreshed.RefreshToken); err != nil {
+		t.Errorf("refresh the current session: %v", err)
+	}
+}
+
+// codeSender keeps the last code sent to each phone number instead of sending it
+type codeSender map[string]string
+
+func (s codeSender) SendTemplate(ctx context.Context, to string, template whatsapp.Template) (string, error) {
+	s[to] = template.BodyParameters[0]
+	return "wamid.test", nil
+}
+
+func TestLinkPhoneNumberWithCode(t *testing.T) {
+	env := integrationtest.Setup(t)
+	ctx := context.Background()
+	user := env.CreateUser(t, "wati@example.com", "password123")
+	sent := codeSender{}
+	phones := env.PhoneVerificationService(sent)
+	authService := env.AuthService(phones)
+
+	// Only the latest code works, and a wrong one counts as an attempt
+	if _, err := phones.SendCode(ctx, user.ID, "+62 812 1111 2222"); err != nil {
+		t.Fatalf("send code: %v", err)
+	}
+	first := sent["6281211112222"]
+	if _, err := phones.SendCode(ctx, user.ID, "6281211112222"); err != nil {
+		t.Fatalf("send another code: %v", err)
+	}
+	latest := sent["6281211112222"]
+	if first != latest {
+		_, err := authService.LinkProvider(ctx, user.ID, service.PhoneOTPProviderName, "6281211112222", first)
+		expectCode(t, err, appErrors.ErrCodeValidation)
+		verification, err := env.Repos.PhoneVerifications.FindLatest(ctx, user.ID, "6281211112222")
+		if err != nil {
+			t.Fatalf("find verification: %v", err)
+		}
+		if verification.Attempts != 1 {
+			t.Errorf("verification has %d attempts, expected 1", verification.Attempts)
+		}
+	}
+
+	linked, err := authService.LinkProvider(ctx, user.ID, service.PhoneOTPProviderName, "+6281211112222", latest)
+	if err != nil {
+		t.Fatalf("link phone number: %v", err)
+	}
+	if linked.UserAuth.CredentialID != "6281211112222" {
+		t.Errorf("linked %q, expected 6281211112222", linked.UserAuth.CredentialID)
+	}
+	_, err = authService.LinkProvider(ctx, user.ID, service.PhoneOTPProviderName, "6281211112222", latest)
+	expectCode(t, err, appErrors.ErrCodeProviderAlreadyLinked)
+
+	// Codes left unused are purged once expired
+	if _, err := phones.SendCode(ctx, user.ID, "6281233334444"); err != nil {
+		t.Fatalf("send code: %v", err)
+	}
+	purged, err := env.Repos.PhoneVerifications.DeleteExpired(ctx, time.Now().Add(time.Hour), 100)
+	if err != nil {
+		t.Fatalf("delete expired verifications: %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("purged %d verifications, expected the 2 unused codes", purged)
+	}
+}
@@ -0,0 +1,80 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+func TestDashboardAssemblesThisMonth(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+
+	// Twelve expenses this month in six categories, the first spent on most
+	for i := 0; i < 12; i++ {
+		moneyFlow, err := domain.NewMoneyFlow(user.ID, float64(1000*(12-i)), domain.DefaultCurrency)
+		if err != nil {
+			t.Fatalf("new money flow: %v", err)
+		}
+		moneyFlow.SetCategory(fmt.Sprintf("category-%d", i%6))
+		if err := env.Repos.MoneyFlows.Create(ctx, moneyFlow); err != nil {
+			t.Fatalf("create money flow: %v", err)
+		}
+	}
+	budget, err := domain.NewBudget(user.ID, "category-0", 50000, domain.DefaultCurrency, false)
+	if err != nil {
+		t.Fatalf("new budget: %v", err)
+	}
+	if err := env.Repos.Budgets.Create(ctx, budget); err != nil {
+		t.Fatalf("create budget: %v", err)
+	}
+	goals := service.NewGoalService(env.Repos.Goals, env.Repos.UserSettings, service.NewAuditor(env.Repos.AuditLogs), env.TxManager)
+	holiday, err := goals.Create(ctx, user.ID, "", service.GoalInput{Name: "Holiday", TargetAmount: 5000000})
+	if err != nil {
+		t.Fatalf("create goal: %v", err)
+	}
+	if _, err := goals.Update(ctx, user.ID, holiday.ID, holiday.Version, service.GoalInput{Name: "Holiday", TargetAmount: 5000000, Archived: true}); err != nil {
+		t.Fatalf("archive goal: %v", err)
+	}
+	if _, err := goals.Create(ctx, user.ID, "", service.GoalInput{Name: "Emergency fund", TargetAmount: 30000000}); err != nil {
+		t.Fatalf("create goal: %v", err)
+	}
+
+	exchangeRates := service.NewExchangeRateService(postgresql.NewExchangeRateRepository(postgresql.NewDB(env.DB)), nil, service.ExchangeRateConfig{})
+	dashboards := service.NewDashboardService(
+		service.NewReportService(env.Repos.MoneyFlows, env.Repos.UserSettings, env.Repos.Groups, env.Repos.Spending, env.Repos.Merchants, exchangeRates),
+		service.NewBudgetService(env.Repos.Budgets, env.Repos.MoneyFlows, env.Repos.UserSettings, nil, env.TxManager),
+		env.MoneyFlowService(),
+		goals,
+	)
+
+	dashboard, err := dashboards.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("dashboard: %v", err)
+	}
+
+	if dashboard.Count != 12 || dashboard.Currency != domain.DefaultCurrency {
+		t.Errorf("dashboard counts %d money flows in %s, expected 12 in %s", dashboard.Count, dashboard.Currency, domain.DefaultCurrency)
+	}
+	if len(dashboard.TopCategories) != 5 || *dashboard.TopCategories[0].Category != "category-0" {
+		t.Errorf("top categories are %d, expected 5 led by category-0", len(dashboard.TopCategories))
+	}
+	if len(dashboard.RecentMoneyFlows) != 10 {
+		t.Errorf("recent money flows are %d, expected 10", len(dashboard.RecentMoneyFlows))
+	}
+	spent, _ := domain.MinorUnits(12000+6000, domain.DefaultCurrency)
+	if len(dashboard.Budgets) != 1 || dashboard.Budgets[0].Spent != spent {
+		t.Errorf("budgets are %v, expected one with %d spent", dashboard.Budgets, spent)
+	}
+	if len(dashboard.ActiveGoals) != 1 || dashboard.ActiveGoals[0].Name != "Emergency fund" {
+		t.Errorf("active goals are %v, expected the emergency fund without the archived holiday", dashboard.ActiveGoals)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+)
+
+func TestRefreshCredentialsAreEncryptedAtRest(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+
+	// Written before encryption was enabled
+	auths, err := env.Repos.UserAuths.FindByUserID(ctx, user.ID)
+	if err != nil || len(auths) != 1 {
+		t.Fatalf("FindByUserID() = %v, %v", auths, err)
+	}
+	auth := auths[0]
+	auth.CredentialRefresh = ptr("plaintext-refresh")
+	if err := env.Repos.UserAuths.Update(ctx, auth); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	keys, err := security.ParseLocalKeys([]string{"k1=" + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fieldCipher, err := security.NewFieldCipher(keys...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	postgresql.SetFieldCipher(fieldCipher)
+	t.Cleanup(func() { postgresql.SetFieldCipher(nil) })
+
+	stored, err := env.Repos.UserAuths.FindByUserIDAndProvider(ctx, user.ID, auth.AuthProviderID)
+	if err != nil || stored.CredentialRefresh == nil || *stored.CredentialRefresh != "plaintext-refresh" {
+		t.Fatalf("user auth with a plaintext value = %+v, %v", stored, err)
+	}
+
+	stored.CredentialRefresh = ptr("refresh-token")
+	if err := env.Repos.UserAuths.Update(ctx, stored); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	var raw string
+	if err := env.DB.Table("user_auths").Where("id = ?", auth.ID).Pluck("credential_refresh", &raw).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !security.IsEncryptedField(raw) {
+		t.Errorf("stored credential_refresh = %q, want it encrypted", raw)
+	}
+
+	stored, err = env.Repos.UserAuths.FindByUserIDAndProvider(ctx, user.ID, auth.AuthProviderID)
+	if err != nil || stored.CredentialRefresh == nil || *stored.CredentialRefresh != "refresh-token" {
+		t.Errorf("user auth = %+v, %v, want the decrypted refresh credential", stored, err)
+	}
+}
@@ -0,0 +1,183 @@
+//go:build integration
+
+package integrationtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/config"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlc"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlite"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/service"
+	"gorm.io/gorm"
+)
+
+// jwtSecretKey signs the tokens issued in tests
+const jwtSecretKey = "integration-test-secret-key-with-enough-length"
+
+// Repositories are the repositories of an Env
+type Repositories struct {
+	Users          repository.UserRepository
+	UserAuths      repository.UserAuthRepository
+	AuthProviders  repository.AuthProviderRepository
+	RefreshTokens  repository.RefreshTokenRepository
+	UserSettings   repository.UserSettingsRepository
+	MoneyFlows     repository.MoneyFlowRepository
+	MoneyFlowNotes repository.MoneyFlowNoteRepository
+	Budgets        repository.BudgetRepository
+	Wallets        repository.WalletRepository
+	Goals          repository.GoalRepository
+	Groups         repository.GroupRepository
+	Splits         repository.SplitRepository
+	Merchants      repository.MerchantRepository
+	AuditLogs      repository.AuditLogRepository
+	Spending       repository.SpendingAnalyticsRepository
+
+	PhoneVerifications repository.PhoneVerificationRepository
+}
+
+// Env is the database of one test with its repositories, wired like cmd/api wires them
+type Env struct {
+	DB        *gorm.DB
+	Repos     *Repositories
+	TxManager repository.TransactionManager
+	JWT       *security.JWTManager
+}
+
+// Setup empties the database and seeds the default auth providers. Tests sharing the
+// database of a package must not run in parallel.
+func Setup(t testing.TB) *Env {
+	t.Helper()
+
+	if database == nil {
+		t.Fatal("integrationtest: the database is not started; call integrationtest.Main from TestMain")
+	}
+	if err := truncate(database); err != nil {
+		t.Fatalf("integrationtest: %v", err)
+	}
+
+	conn := postgresql.NewDB(database)
+	env := &Env{
+		DB: database,
+		Repos: &Repositories{
+			Users:          postgresql.NewUserRepository(conn),
+			UserAuths:      postgresql.NewUserAuthRepository(conn),
+			AuthProviders:  postgresql.NewAuthProviderRepository(conn),
+			RefreshTokens:  postgresql.NewRefreshTokenRepository(conn),
+			UserSettings:   postgresql.NewUserSettingsRepository(conn),
+			MoneyFlows:     postgresql.NewMoneyFlowRepository(conn),
+			MoneyFlowNotes: postgresql.NewMoneyFlowNoteRepository(conn),
+			Budgets:        postgresql.NewBudgetRepository(conn),
+			Wallets:        postgresql.NewWalletRepository(conn),
+			Goals:          postgresql.NewGoalRepository(conn),
+			Groups:         postgresql.NewGroupRepository(conn),
+			Splits:         postgresql.NewSplitRepository(conn),
+			Merchants:      postgresql.NewMerchantRepository(conn),
+			AuditLogs:      postgresql.NewAuditLogRepository(conn),
+			Spending:       postgresql.NewSpendingAnalyticsRepository(conn),
+
+			PhoneVerifications: postgresql.NewPhoneVerificationRepository(conn),
+		},
+		TxManager: postgresql.NewTransactionManagerFromDB(conn),
+		JWT:       security.NewJWTManager([]string{jwtSecretKey}, 15*time.Minute, 24*time.Hour, nil),
+	}
+	if queryEngine == config.QueryEngineSQLC {
+		repos := env.Repos
+		repos.Users = sqlc.NewUserRepository(sqlcPool, repos.Users)
+		repos.MoneyFlows = sqlc.NewMoneyFlowRepository(sqlcPool, repos.MoneyFlows)
+		repos.RefreshTokens = sqlc.NewRefreshTokenRepository(sqlcPool, repos.RefreshTokens)
+	}
+	if usesSQLite {
+		repos := env.Repos
+		repos.Users = sqlite.NewUserRepository(conn, repos.Users)
+		repos.MoneyFlows = sqlite.NewMoneyFlowRepository(conn, repos.MoneyFlows)
+		repos.MoneyFlowNotes = sqlite.NewMoneyFlowNoteRepository(conn, repos.MoneyFlowNotes)
+		repos.Spending = sqlite.NewSpendingAnalyticsRepository(conn)
+	}
+	// Read expense totals from the read model, like cmd/api does by default
+	env.Repos.MoneyFlows = postgresql.NewMonthlyCategoryTotalsRepository(conn, env.Repos.MoneyFlows)
+
+	if _, err := env.SeedService().Seed(context.Background(), service.SeedOptions{}); err != nil {
+		t.Fatalf("integrationtest: failed to seed: %v", err)
+	}
+	return env
+}
+
+// SeedService returns a seed service writing to the database of the test
+func (e *Env) SeedService() *service.SeedService {
+	return service.NewSeedService(
+		e.Repos.Users,
+		e.Repos.UserAuths,
+		e.Repos.AuthProviders,
+		e.Repos.MoneyFlows,
+		e.Repos.Budgets,
+		security.NewPasswordHasher(security.PasswordHashConfig{}),
+		e.TxManager,
+	)
+}
+
+// AuthService returns an auth service that records audit logs, without notifications
+// or domain events, linking the credentials the verifiers prove
+func (e *Env) AuthService(verifiers ...service.CredentialVerifier) *service.AuthService {
+	return service.NewAuthService(
+		e.Repos.Users,
+		e.Repos.UserAuths,
+		e.Repos.AuthProviders,
+		e.Repos.RefreshTokens,
+		security.NewPasswordHasher(security.PasswordHashConfig{}),
+		e.JWT,
+		e.TxManager,
+		nil,
+		nil,
+		service.NewAuditor(e.Repos.AuditLogs),
+		service.LockoutConfig{},
+		verifiers...,
+	)
+}
+
+// PhoneVerificationService returns a phone verification service sending codes with the
+// sender
+func (e *Env) PhoneVerificationService(sender service.WhatsAppTemplateSender) *service.PhoneVerificationService {
+	return service.NewPhoneVerificationService(
+		e.Repos.PhoneVerifications,
+		e.Repos.UserAuths,
+		e.Repos.AuthProviders,
+		sender,
+		service.PhoneVerificationConfig{},
+	)
+}
+
+// MoneyFlowService returns a money flow service that records audit logs
+func (e *Env) MoneyFlowService() *service.MoneyFlowService {
+	return service.NewMoneyFlowService(
+		e.Repos.MoneyFlows,
+		e.Repos.MoneyFlowNotes,
+		e.Repos.UserSettings,
+		e.Repos.Budgets,
+		e.Repos.Wallets,
+		e.Repos.Groups,
+		e.Repos.Splits,
+		e.Repos.Merchants,
+		service.NewAuditor(e.Repos.AuditLogs),
+		nil,
+		nil,
+		e.TxManager,
+	)
+}
+
+// CreateUser registers a user signing in with the email and password
+func (e *Env) CreateUser(t testing.TB, email, password string) *domain.User {
+	t.Helper()
+
+	registered, err := e.AuthService().Register(context.Background(), "Test User", email, password, "")
+	if err != nil {
+		t.Fatalf("integrationtest: failed to register %s: %v", email, err)
+	}
+	return registered.User
+}
@@ -0,0 +1,87 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+func TestGoalsAreListedByDeadlineUntilArchived(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	other := env.CreateUser(t, "siti@example.com", "password123")
+	ctx := context.Background()
+	goals := service.NewGoalService(env.Repos.Goals, env.Repos.UserSettings, service.NewAuditor(env.Repos.AuditLogs), env.TxManager)
+
+	create := func(name string, deadline *time.Time) *domain.Goal {
+		t.Helper()
+		goal, err := goals.Create(ctx, user.ID, "", service.GoalInput{Name: name, TargetAmount: 1000000, SavedAmount: 250000, Deadline: deadline})
+		if err != nil {
+			t.Fatalf("create goal %q: %v", name, err)
+		}
+		return goal
+	}
+	june := time.Date(2027, 6, 30, 15, 4, 5, 0, time.FixedZone("WIB", 7*3600))
+	march := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	laptop := create("Laptop", nil)
+	holiday := create("Holiday", &june)
+	phone := create("Phone", &march)
+
+	if holiday.Currency != domain.DefaultCurrency || !holiday.Deadline.Equal(time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("holiday is in %s by %v, expected the default currency by 2027-06-30", holiday.Currency, holiday.Deadline)
+	}
+
+	names := func(includeArchived bool) []string {
+		t.Helper()
+		list, err := goals.List(ctx, user.ID, includeArchived)
+		if err != nil {
+			t.Fatalf("list goals: %v", err)
+		}
+		result := make([]string, len(list))
+		for i, goal := range list {
+			result[i] = goal.Name
+		}
+		return result
+	}
+	if got := names(false); len(got) != 3 || got[0] != "Phone" || got[1] != "Holiday" || got[2] != "Laptop" {
+		t.Fatalf("goals are %q, expected the nearest deadline first and no deadline last", got)
+	}
+
+	// Saving the rest reaches the goal; archiving takes it off the active goals
+	updated, err := goals.Update(ctx, user.ID, phone.ID, phone.Version, service.GoalInput{Name: "Phone", TargetAmount: 1000000, SavedAmount: 1000000, Deadline: &march, Archived: true})
+	if err != nil {
+		t.Fatalf("update goal: %v", err)
+	}
+	if !updated.Reached() || updated.Active() || updated.Version != 1 {
+		t.Errorf("updated goal is reached=%v active=%v at version %d, expected reached and archived at 1", updated.Reached(), updated.Active(), updated.Version)
+	}
+	if got := names(false); len(got) != 2 || got[0] != "Holiday" {
+		t.Errorf("active goals are %q, expected the phone archived", got)
+	}
+	if got := names(true); len(got) != 3 {
+		t.Errorf("all goals are %q, expected the archived phone included", got)
+	}
+
+	_, err = goals.Update(ctx, user.ID, phone.ID, phone.Version, service.GoalInput{Name: "Phone", TargetAmount: 1000000})
+	expectCode(t, err, appErrors.ErrCodeVersionConflict)
+	_, err = goals.Create(ctx, user.ID, "", service.GoalInput{Name: "Car", TargetAmount: 0})
+	expectCode(t, err, appErrors.ErrCodeValidation)
+
+	// Other users' goals are not found
+	_, err = goals.Get(ctx, other.ID, laptop.ID)
+	expectCode(t, err, appErrors.ErrCodeResourceNotFound)
+	expectCode(t, goals.Delete(ctx, other.ID, laptop.ID), appErrors.ErrCodeResourceNotFound)
+
+	if err := goals.Delete(ctx, user.ID, laptop.ID); err != nil {
+		t.Fatalf("delete goal: %v", err)
+	}
+	_, err = goals.Get(ctx, user.ID, laptop.ID)
+	expectCode(t, err, appErrors.ErrCodeResourceNotFound)
+}
@@ -0,0 +1,183 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/worker"
+)
+
+func TestJobQueueHandsOutDueJobsOnce(t *testing.T) {
+	env := integrationtest.Setup(t)
+	queue := postgresql.NewJobQueue(postgresql.NewDB(env.DB))
+	ctx := context.Background()
+	now := time.Now()
+
+	enqueue := func(jobType string, runAt time.Time) *worker.Job {
+		t.Helper()
+		job, err := worker.NewJob(jobType, map[string]string{"type": jobType})
+		if err != nil {
+			t.Fatal(err)
+		}
+		worker.WithRunAt(runAt)(job)
+		if err := queue.Enqueue(ctx, job); err != nil {
+			t.Fatalf("enqueue %s: %v", jobType, err)
+		}
+		return job
+	}
+	later := enqueue("email", now.Add(-time.Minute))
+	earlier := enqueue("email", now.Add(-time.Hour))
+	enqueue("email", now.Add(time.Hour))
+	enqueue("export", now.Add(-time.Hour))
+
+	due, err := queue.FindDue(ctx, []string{"email"}, now, 10)
+	if err != nil {
+		t.Fatalf("find due: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != earlier.ID || due[1].ID != later.ID {
+		t.Fatalf("due jobs = %+v, want the two past email jobs, oldest first", due)
+	}
+	var payload map[string]string
+	if err := due[0].Decode(&payload); err != nil || payload["type"] != "email" {
+		t.Errorf("payload = %v (%v), want the enqueued one", payload, err)
+	}
+
+	// Two workers found the same job; only the first claim wins
+	first, second := *due[0], *due[0]
+	if err := queue.Claim(ctx, &first); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if first.Status != worker.StatusRunning || first.Attempts != 1 || first.LockedAt == nil {
+		t.Errorf("claimed job is %s after %d attempts, locked at %v", first.Status, first.Attempts, first.LockedAt)
+	}
+	if err := queue.Claim(ctx, &second); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("second claim error = %v, want ErrConflict", err)
+	}
+
+	due, err = queue.FindDue(ctx, []string{"email"}, now, 10)
+	if err != nil {
+		t.Fatalf("find due: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != later.ID {
+		t.Errorf("due jobs after the claim = %+v, want the later one", due)
+	}
+}
+
+func TestJobQueueSavesAttemptOutcomes(t *testing.T) {
+	env := integrationtest.Setup(t)
+	queue := postgresql.NewJobQueue(postgresql.NewDB(env.DB))
+	ctx := context.Background()
+
+	job, err := worker.NewJob("email", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worker.WithMaxAttempts(2)(job)
+	if err := queue.Enqueue(ctx, job); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	// A failed first attempt goes back to pending until the retry time
+	if err := queue.Claim(ctx, job); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	retryAt := time.Now().Add(time.Minute)
+	job.Fail(errors.New("smtp timeout"), retryAt)
+	if err := queue.Update(ctx, job); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if due, _ := queue.FindDue(ctx, []string{"email"}, time.Now(), 10); len(due) != 0 {
+		t.Errorf("due jobs before the retry time = %+v, want none", due)
+	}
+	due, err := queue.FindDue(ctx, []string{"email"}, retryAt.Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("find due: %v", err)
+	}
+	if len(due) != 1 || due[0].Attempts != 1 || due[0].LastError == nil || *due[0].LastError != "smtp timeout" {
+		t.Fatalf("due jobs after the retry time = %+v, want the job with its error", due)
+	}
+
+	// The last attempt dead-letters it
+	job = due[0]
+	if err := queue.Claim(ctx, job); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	job.Fail(errors.New("smtp timeout"), time.Now())
+	if job.Status != worker.StatusDead {
+		t.Fatalf("status after the last attempt = %s, want dead", job.Status)
+	}
+	if err := queue.Update(ctx, job); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if due, _ := queue.FindDue(ctx, []string{"email"}, time.Now().Add(time.Hour), 10); len(due) != 0 {
+		t.Errorf("due jobs = %+v, want none once dead-lettered", due)
+	}
+
+	// A worker saving a job it no longer holds is refused
+	job.Status = worker.StatusRunning
+	if err := queue.Update(ctx, job); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("update of an unclaimed job error = %v, want ErrConflict", err)
+	}
+}
+
+func TestJobQueueReleasesStaleJobs(t *testing.T) {
+	env := integrationtest.Setup(t)
+	queue := postgresql.NewJobQueue(postgresql.NewDB(env.DB))
+	ctx := context.Background()
+
+	job, err := worker.NewJob("email", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.Enqueue(ctx, job); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	crashed := *job
+	if err := queue.Claim(ctx, &crashed); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	if released, err := queue.ReleaseStale(ctx, crashed.LockedAt.Add(-time.Second)); err != nil || released != 0 {
+		t.Fatalf("released %d (%v) of the jobs claimed before the claim, want none", released, err)
+	}
+	released, err := queue.ReleaseStale(ctx, crashed.LockedAt.Add(time.Second))
+	if err != nil || released != 1 {
+		t.Fatalf("released %d (%v), want the claimed job", released, err)
+	}
+
+	// Another worker claims it again; the crashed worker's result is then ignored
+	due, err := queue.FindDue(ctx, []string{"email"}, time.Now(), 10)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("due jobs = %+v (%v), want the released job", due, err)
+	}
+	retried := due[0]
+	if err := queue.Claim(ctx, retried); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if retried.Attempts != 2 {
+		t.Errorf("attempts = %d, want the crashed attempt counted", retried.Attempts)
+	}
+	crashed.Succeed()
+	if err := queue.Update(ctx, &crashed); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("update by the crashed worker error = %v, want ErrConflict", err)
+	}
+	retried.Succeed()
+	if err := queue.Update(ctx, retried); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	// Succeeded jobs are deleted once past retention
+	if deleted, err := queue.DeleteSucceeded(ctx, retried.FinishedAt.Add(-time.Second), 10); err != nil || deleted != 0 {
+		t.Errorf("deleted %d (%v) jobs within retention, want none", deleted, err)
+	}
+	if deleted, err := queue.DeleteSucceeded(ctx, retried.FinishedAt.Add(time.Second), 10); err != nil || deleted != 1 {
+		t.Errorf("deleted %d (%v) jobs past retention, want the job", deleted, err)
+	}
+}
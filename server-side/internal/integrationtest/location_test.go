@@ -0,0 +1,71 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+func TestPlaceReportSumsSpendingNearAPoint(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+	moneyFlows := env.MoneyFlowService()
+
+	record := func(amount, latitude, longitude float64, place string) *service.MoneyFlowDetail {
+		t.Helper()
+		detail, err := moneyFlows.Create(ctx, user.ID, service.MoneyFlowInput{
+			Amount: amount, Latitude: &latitude, Longitude: &longitude, PlaceName: &place, AllowDuplicate: true,
+		})
+		if err != nil {
+			t.Fatalf("create money flow at %q: %v", place, err)
+		}
+		return detail
+	}
+	// Two coffees at the same shop in Sudirman, lunch 300 m away, and dinner in Bandung
+	record(30000, -6.2250, 106.8020, "Kopi Kenangan Sudirman")
+	coffee := record(25000, -6.2251, 106.8021, "kopi kenangan  sudirman")
+	record(45000, -6.2225, 106.8030, "Warteg Bahari")
+	dinner := record(80000, -6.9175, 107.6191, "Warung Nasi Ampera")
+
+	if coffee.MoneyFlow.Latitude == nil || *coffee.MoneyFlow.Latitude != -6.2251 {
+		t.Fatalf("coffee latitude is %v, expected -6.2251", coffee.MoneyFlow.Latitude)
+	}
+	if _, err := moneyFlows.Create(ctx, user.ID, service.MoneyFlowInput{Amount: 1000, Latitude: coffee.MoneyFlow.Latitude}); err == nil {
+		t.Error("a latitude without a longitude was accepted")
+	}
+
+	exchangeRates := service.NewExchangeRateService(postgresql.NewExchangeRateRepository(postgresql.NewDB(env.DB)), nil, service.ExchangeRateConfig{})
+	reports := service.NewReportService(env.Repos.MoneyFlows, env.Repos.UserSettings, env.Repos.Groups, env.Repos.Spending, env.Repos.Merchants, exchangeRates)
+	near := &service.Nearby{Latitude: -6.2250, Longitude: 106.8020, Radius: 1000}
+	report, err := reports.Places(ctx, user.ID, "", service.ReportPeriod{}, near, 10)
+	if err != nil {
+		t.Fatalf("place report: %v", err)
+	}
+
+	coffees, _ := domain.MinorUnits(55000, domain.DefaultCurrency)
+	if len(report.Places) != 2 || report.Count != 3 {
+		t.Fatalf("report has %d places over %d money flows, expected 2 over 3 near Sudirman", len(report.Places), report.Count)
+	}
+	if place := report.Places[0]; place.Total != coffees || place.Count != 2 || place.Distance == nil || *place.Distance > 20 {
+		t.Errorf("top place is %+v, expected the coffee shop with %d over 2 within 20 m", place, coffees)
+	}
+
+	// Removing the location of dinner takes it out of the report of every place
+	if _, err := moneyFlows.Update(ctx, user.ID, dinner.MoneyFlow.ID, dinner.MoneyFlow.Version, service.MoneyFlowInput{Amount: 80000}); err != nil {
+		t.Fatalf("replace dinner: %v", err)
+	}
+	report, err = reports.Places(ctx, user.ID, "", service.ReportPeriod{}, nil, 10)
+	if err != nil {
+		t.Fatalf("place report: %v", err)
+	}
+	if len(report.Places) != 2 || report.Count != 3 {
+		t.Errorf("report has %d places over %d money flows, expected the 2 in Sudirman over 3", len(report.Places), report.Count)
+	}
+}
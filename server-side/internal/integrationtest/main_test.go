@@ -0,0 +1,13 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+)
+
+func TestMain(m *testing.M) {
+	integrationtest.Main(m)
+}
@@ -0,0 +1,72 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+)
+
+func TestMoneyFlowsAreLinkedToNormalizedMerchants(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+	moneyFlows := env.MoneyFlowService()
+	merchants := service.NewMerchantService(env.Repos.Merchants)
+
+	if _, err := merchants.CreateRule(ctx, service.MerchantRuleInput{Pattern: "alfa mart", Merchant: "Alfamart"}); err != nil {
+		t.Fatalf("create rule: %v", err)
+	}
+
+	record := func(amount float64, payee string) *service.MoneyFlowDetail {
+		t.Helper()
+		detail, err := moneyFlows.Create(ctx, user.ID, service.MoneyFlowInput{Amount: amount, Merchant: &payee, AllowDuplicate: true})
+		if err != nil {
+			t.Fatalf("create money flow at %q: %v", payee, err)
+		}
+		return detail
+	}
+	first := record(10000, "INDOMARET TBK 0123")
+	second := record(5000, "Indomaret 0456")
+	third := record(20000, "PT ALFA MART SUDIRMAN")
+
+	if first.Merchant == nil || first.Merchant.Name != "Indomaret" {
+		t.Fatalf("first merchant is %v, expected Indomaret", first.Merchant)
+	}
+	if second.MoneyFlow.MerchantID == nil || *second.MoneyFlow.MerchantID != first.Merchant.ID {
+		t.Errorf("second money flow is linked to %v, expected Indomaret %s", second.MoneyFlow.MerchantID, first.Merchant.ID)
+	}
+	if third.Merchant == nil || third.Merchant.Name != "Alfamart" {
+		t.Errorf("third merchant is %v, expected Alfamart by the rule", third.Merchant)
+	}
+
+	exchangeRates := service.NewExchangeRateService(postgresql.NewExchangeRateRepository(postgresql.NewDB(env.DB)), nil, service.ExchangeRateConfig{})
+	reports := service.NewReportService(env.Repos.MoneyFlows, env.Repos.UserSettings, env.Repos.Groups, env.Repos.Spending, env.Repos.Merchants, exchangeRates)
+	report, err := reports.Merchants(ctx, user.ID, "", service.ReportPeriod{}, 10)
+	if err != nil {
+		t.Fatalf("merchant report: %v", err)
+	}
+
+	indomaret, _ := domain.MinorUnits(15000, domain.DefaultCurrency)
+	if len(report.Merchants) != 2 || report.Merchants[1].Merchant.Name != "Indomaret" || report.Merchants[1].Total != indomaret || report.Merchants[1].Count != 2 {
+		t.Fatalf("report merchants are %+v, expected Alfamart then Indomaret with %d over 2", report.Merchants, indomaret)
+	}
+
+	// Clearing the merchant takes the money flow out of the report
+	cleared := ""
+	if _, err := moneyFlows.Patch(ctx, user.ID, third.MoneyFlow.ID, third.MoneyFlow.Version, service.MoneyFlowPatch{Merchant: &cleared}); err != nil {
+		t.Fatalf("clear merchant: %v", err)
+	}
+	report, err = reports.Merchants(ctx, user.ID, "", service.ReportPeriod{}, 10)
+	if err != nil {
+		t.Fatalf("merchant report: %v", err)
+	}
+	if len(report.Merchants) != 1 || report.Count != 2 {
+		t.Errorf("report has %d merchants over %d money flows, expected Indomaret over 2", len(report.Merchants), report.Count)
+	}
+}
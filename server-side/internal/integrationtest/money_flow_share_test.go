@@ -0,0 +1,115 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+func TestShareLinkShowsMoneyFlowUntilRevoked(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	other := env.CreateUser(t, "siti@example.com", "password123")
+	ctx := context.Background()
+
+	detail, err := env.MoneyFlowService().Create(ctx, user.ID, service.MoneyFlowInput{
+		Amount: 125000, Category: ptr("Health"), Description: ptr("Dentist"), Note: ptr("Claim from insurance"),
+	})
+	if err != nil {
+		t.Fatalf("create money flow: %v", err)
+	}
+
+	shareRepo := postgresql.NewMoneyFlowShareRepository(postgresql.NewDB(env.DB))
+	shares := service.NewMoneyFlowShareService(env.MoneyFlowService(), shareRepo, env.Repos.Users, env.Repos.UserSettings,
+		service.MoneyFlowShareConfig{
+			URL:        "https://api.example.com/api/v1/shared/money-flows/",
+			TTL:        time.Hour,
+			SigningKey: "test-signing-key",
+		})
+
+	open := func(link *service.MoneyFlowShareLink) (*service.SharedMoneyFlow, error) {
+		t.Helper()
+		parsed, err := url.Parse(link.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "/api/v1/shared/money-flows/" + link.Share.ID.String(); parsed.Path != want {
+			t.Errorf("link path = %s, want %s", parsed.Path, want)
+		}
+		expires, _ := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+		return shares.Open(ctx, link.Share.ID, expires, parsed.Query().Get("signature"))
+	}
+
+	withoutNote, err := shares.Create(ctx, user.ID, detail.MoneyFlow.ID, 0, false)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if until := time.Until(withoutNote.Share.ExpiresAt); until <= 59*time.Minute || until > time.Hour {
+		t.Errorf("link expires in %v, want the configured hour", until)
+	}
+	shared, err := open(withoutNote)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if shared.MoneyFlow.ID != detail.MoneyFlow.ID || shared.SharedBy != user.FullName || shared.Note != nil {
+		t.Errorf("shared = %+v, want the money flow by %s without its note", shared, user.FullName)
+	}
+
+	withNote, err := shares.Create(ctx, user.ID, detail.MoneyFlow.ID, 24*time.Hour, true)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if shared, err = open(withNote); err != nil || shared.Note == nil || shared.Note.Content != "Claim from insurance" {
+		t.Errorf("Open() = %+v, %v, want the money flow with its note", shared, err)
+	}
+
+	// Altered links and links to the money flows of others do not work
+	expires := withNote.Share.ExpiresAt.Unix()
+	signature := withNote.URL[strings.LastIndex(withNote.URL, "=")+1:]
+	if _, err := shares.Open(ctx, withNote.Share.ID, expires+3600, signature); !errors.Is(err, appErrors.ErrInvalidShareLink) {
+		t.Errorf("Open() with a later expiry error = %v, want ErrInvalidShareLink", err)
+	}
+	if _, err := shares.Open(ctx, uuid.New(), expires, signature); !errors.Is(err, appErrors.ErrInvalidShareLink) {
+		t.Errorf("Open() of another share error = %v, want ErrInvalidShareLink", err)
+	}
+	if _, err := shares.Create(ctx, other.ID, detail.MoneyFlow.ID, 0, false); !errors.Is(err, appErrors.ErrResourceNotFound) {
+		t.Errorf("Create() by another user error = %v, want ErrResourceNotFound", err)
+	}
+
+	revoked, err := shares.Revoke(ctx, user.ID, detail.MoneyFlow.ID)
+	if err != nil || revoked != 2 {
+		t.Fatalf("Revoke() = %d, %v, want both links revoked", revoked, err)
+	}
+	if _, err := open(withoutNote); !errors.Is(err, appErrors.ErrInvalidShareLink) {
+		t.Errorf("Open() after revoking error = %v, want ErrInvalidShareLink", err)
+	}
+
+	// Deleting the money flow ends its links too
+	link, err := shares.Create(ctx, user.ID, detail.MoneyFlow.ID, 0, false)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := env.MoneyFlowService().Delete(ctx, user.ID, detail.MoneyFlow.ID); err != nil {
+		t.Fatalf("delete money flow: %v", err)
+	}
+	if _, err := open(link); !errors.Is(err, appErrors.ErrInvalidShareLink) {
+		t.Errorf("Open() of a deleted money flow error = %v, want ErrInvalidShareLink", err)
+	}
+
+	// Expired shares are purged with the other expired security artifacts
+	if purged, err := shareRepo.DeleteExpired(ctx, time.Now().Add(2*time.Hour), 10); err != nil || purged != 1 {
+		t.Errorf("DeleteExpired() = %d, %v, want the remaining share purged", purged, err)
+	}
+}
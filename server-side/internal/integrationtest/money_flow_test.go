@@ -0,0 +1,325 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/service"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestMoneyFlowLifecycle(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	moneyFlowService := env.MoneyFlowService()
+	ctx := context.Background()
+
+	created, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{
+		Amount:      45000,
+		Category:    ptr("Food"),
+		Description: ptr("Nasi goreng"),
+		Tags:        []string{"lunch", "office"},
+		Note:        ptr("Paid in cash"),
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Every mapped column survives the round trip
+	got, err := moneyFlowService.Get(ctx, user.ID, created.MoneyFlow.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	moneyFlow := got.MoneyFlow
+	// Inputs are in major units and amounts in minor units
+	if moneyFlow.Amount != 4500000 || moneyFlow.Currency != domain.DefaultCurrency {
+		t.Errorf("amount is %d %s, expected 4500000 %s", moneyFlow.Amount, moneyFlow.Currency, domain.DefaultCurrency)
+	}
+	if moneyFlow.Category == nil || *moneyFlow.Category != "Food" {
+		t.Errorf("category is %v, expected Food", moneyFlow.Category)
+	}
+	if moneyFlow.Description == nil || *moneyFlow.Description != "Nasi goreng" {
+		t.Errorf("description is %v, expected Nasi goreng", moneyFlow.Description)
+	}
+	if !slices.Equal(moneyFlow.Tags, []string{"lunch", "office"}) {
+		t.Errorf("tags are %v, expected [lunch office]", moneyFlow.Tags)
+	}
+	if got.Note == nil || got.Note.Content != "Paid in cash" {
+		t.Errorf("note is %v, expected Paid in cash", got.Note)
+	}
+
+	updated, err := moneyFlowService.Update(ctx, user.ID, moneyFlow.ID, moneyFlow.Version, service.MoneyFlowInput{
+		Amount:   50000,
+		Category: ptr("Food"),
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.MoneyFlow.Version != moneyFlow.Version+1 {
+		t.Errorf("version is %d after update, expected %d", updated.MoneyFlow.Version, moneyFlow.Version+1)
+	}
+
+	// The version read before the update is stale now
+	_, err = moneyFlowService.Update(ctx, user.ID, moneyFlow.ID, moneyFlow.Version, service.MoneyFlowInput{Amount: 60000})
+	expectCode(t, err, appErrors.ErrCodeVersionConflict)
+
+	summary, err := moneyFlowService.Summary(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	if len(summary.Totals) != 1 || summary.Totals[0].Total != 5000000 || summary.Totals[0].Count != 1 {
+		t.Errorf("summary totals are %+v, expected one total of 5000000", summary.Totals)
+	}
+
+	if err := moneyFlowService.Delete(ctx, user.ID, moneyFlow.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = moneyFlowService.Get(ctx, user.ID, moneyFlow.ID)
+	expectCode(t, err, appErrors.ErrCodeResourceNotFound)
+}
+
+func TestMoneyFlowPatchChangesOnlySentFields(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	moneyFlowService := env.MoneyFlowService()
+	ctx := context.Background()
+
+	created, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{
+		Amount:      450,
+		Category:    ptr("Food"),
+		Description: ptr("Nasi goreng"),
+		Tags:        []string{"lunch"},
+		Note:        ptr("Paid in cash"),
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	version := created.MoneyFlow.Version
+
+	patched, err := moneyFlowService.Patch(ctx, user.ID, created.MoneyFlow.ID, version, service.MoneyFlowPatch{
+		Category: ptr("Transport"),
+		Currency: ptr("JPY"),
+		Tags:     []string{},
+	})
+	if err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	moneyFlow := patched.MoneyFlow
+	if moneyFlow.Category == nil || *moneyFlow.Category != "Transport" || len(moneyFlow.Tags) != 0 {
+		t.Errorf("category is %v and tags are %v, expected Transport and none", moneyFlow.Category, moneyFlow.Tags)
+	}
+	// JPY has no minor unit, so 450 IDR in cents becomes 450 JPY
+	if moneyFlow.Amount != 450 || moneyFlow.Currency != "JPY" {
+		t.Errorf("amount is %d %s, expected 450 JPY", moneyFlow.Amount, moneyFlow.Currency)
+	}
+	if moneyFlow.Description == nil || *moneyFlow.Description != "Nasi goreng" {
+		t.Errorf("description is %v, expected Nasi goreng", moneyFlow.Description)
+	}
+	if patched.Note == nil || patched.Note.Content != "Paid in cash" {
+		t.Errorf("note is %v, expected Paid in cash", patched.Note)
+	}
+	if moneyFlow.Version != version+1 {
+		t.Errorf("version is %d after patch, expected %d", moneyFlow.Version, version+1)
+	}
+
+	// The version read before the patch is stale now
+	_, err = moneyFlowService.Patch(ctx, user.ID, moneyFlow.ID, version, service.MoneyFlowPatch{Description: ptr("")})
+	expectCode(t, err, appErrors.ErrCodeVersionConflict)
+
+	patched, err = moneyFlowService.Patch(ctx, user.ID, moneyFlow.ID, moneyFlow.Version, service.MoneyFlowPatch{Description: ptr("")})
+	if err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	if patched.MoneyFlow.Description != nil || patched.MoneyFlow.Category == nil {
+		t.Errorf("description is %v and category %v, expected no description and a category", patched.MoneyFlow.Description, patched.MoneyFlow.Category)
+	}
+}
+
+func TestMoneyFlowBusinessRulesNameTheInvalidFields(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	moneyFlowService := env.MoneyFlowService()
+	ctx := context.Background()
+
+	// Chat and import callers skip request binding, so the rules hold in the service
+	_, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{
+		Amount:   10,
+		Currency: "ABC",
+		Tags:     []string{"ok", ""},
+	})
+	expectCode(t, err, appErrors.ErrCodeValidation)
+	fields, _ := err.(*appErrors.AppError).Details["validation_errors"].(map[string]interface{})
+	for field, code := range map[string]string{"currency": "currency", "tags[1]": "min"} {
+		fieldError, _ := fields[field].(map[string]string)
+		if fieldError["code"] != code {
+			t.Errorf("%s error is %v, expected code %s", field, fields[field], code)
+		}
+	}
+
+	created, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{Amount: 10})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	_, err = moneyFlowService.Patch(ctx, user.ID, created.MoneyFlow.ID, created.MoneyFlow.Version, service.MoneyFlowPatch{
+		Description: ptr(strings.Repeat("x", domain.MaxDescriptionLength+1)),
+	})
+	expectCode(t, err, appErrors.ErrCodeValidation)
+}
+
+func TestMoneyFlowDuplicateCheck(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	moneyFlowService := env.MoneyFlowService()
+	ctx := context.Background()
+
+	first, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{Amount: 25000, Description: ptr("Lunch at warung")})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Users who have not changed the setting are warned
+	created, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{Amount: 25000, Description: ptr("lunch  warung")})
+	if err != nil {
+		t.Fatalf("create duplicate: %v", err)
+	}
+	if created.Duplicate == nil || created.Duplicate.ID != first.MoneyFlow.ID {
+		t.Errorf("duplicate is %v, expected %s", created.Duplicate, first.MoneyFlow.ID)
+	}
+	other, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{Amount: 25000, Description: ptr("Taxi home")})
+	if err != nil || other.Duplicate != nil {
+		t.Errorf("different description: duplicate is %v (%v), expected none", other.Duplicate, err)
+	}
+
+	settings := domain.DefaultUserSettings(user.ID)
+	settings.DuplicateCheck = domain.DuplicateCheckBlock
+	if err := env.Repos.UserSettings.Create(ctx, settings); err != nil {
+		t.Fatalf("create settings: %v", err)
+	}
+	_, err = moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{Amount: 25000, Description: ptr("Lunch at warung")})
+	expectCode(t, err, appErrors.ErrCodeDuplicateMoneyFlow)
+	if _, err := moneyFlowService.Create(ctx, user.ID, service.MoneyFlowInput{Amount: 25000, Description: ptr("Lunch at warung"), AllowDuplicate: true}); err != nil {
+		t.Errorf("create allowed duplicate: %v", err)
+	}
+}
+
+func TestMoneyFlowIsHiddenFromOtherUsers(t *testing.T) {
+	env := integrationtest.Setup(t)
+	owner := env.CreateUser(t, "budi@example.com", "password123")
+	other := env.CreateUser(t, "sari@example.com", "password123")
+	moneyFlowService := env.MoneyFlowService()
+	ctx := context.Background()
+
+	created, err := moneyFlowService.Create(ctx, owner.ID, service.MoneyFlowInput{Amount: 10000})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	_, err = moneyFlowService.Get(ctx, other.ID, created.MoneyFlow.ID)
+	expectCode(t, err, appErrors.ErrCodeResourceNotFound)
+}
+
+func TestMoneyFlowRepositoryOptimisticLocking(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+
+	moneyFlow, err := domain.NewMoneyFlow(user.ID, 25000, "")
+	if err != nil {
+		t.Fatalf("new money flow: %v", err)
+	}
+	if err := env.Repos.MoneyFlows.Create(ctx, moneyFlow); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Two writers read the same version; only the first update wins
+	first, err := env.Repos.MoneyFlows.FindByID(ctx, moneyFlow.ID)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	second, err := env.Repos.MoneyFlows.FindByID(ctx, moneyFlow.ID)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+
+	first.SetDescription("first")
+	first.IncrementVersion()
+	if err := env.Repos.MoneyFlows.Update(ctx, first); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+
+	second.SetDescription("second")
+	second.IncrementVersion()
+	if err := env.Repos.MoneyFlows.Update(ctx, second); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second update returned %v, expected %v", err, domain.ErrConflict)
+	}
+}
+
+func TestMoneyFlowCountsAndPeriodSummary(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	other := env.CreateUser(t, "sari@example.com", "password123")
+	ctx := context.Background()
+
+	var moneyFlows []*domain.MoneyFlow
+	for i, createdAt := range []string{
+		"2026-09-30T23:00:00Z",
+		"2026-10-01T00:00:00Z",
+		"2026-10-15T12:00:00Z",
+		"2026-10-31T23:59:59Z",
+		"2026-11-01T00:00:00Z",
+	} {
+		moneyFlow, err := domain.NewMoneyFlow(user.ID, float64(1000*(i+1)), domain.DefaultCurrency)
+		if err != nil {
+			t.Fatalf("new money flow: %v", err)
+		}
+		if i%2 == 0 {
+			moneyFlow.Tags = []string{"lunch"}
+		}
+		moneyFlow.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		moneyFlow.UpdatedAt = moneyFlow.CreatedAt
+		if err := env.Repos.MoneyFlows.Create(ctx, moneyFlow); err != nil {
+			t.Fatalf("create money flow: %v", err)
+		}
+		moneyFlows = append(moneyFlows, moneyFlow)
+	}
+	if err := env.Repos.MoneyFlows.Delete(ctx, moneyFlows[3].ID); err != nil {
+		t.Fatalf("delete money flow: %v", err)
+	}
+	othersFlow, _ := domain.NewMoneyFlow(other.ID, 9000, domain.DefaultCurrency)
+	if err := env.Repos.MoneyFlows.Create(ctx, othersFlow); err != nil {
+		t.Fatalf("create money flow: %v", err)
+	}
+
+	count, err := env.Repos.MoneyFlows.CountByUserID(ctx, user.ID)
+	if err != nil || count != 4 {
+		t.Errorf("count is %d (%v), expected 4", count, err)
+	}
+	count, err = env.Repos.MoneyFlows.CountByFilter(ctx, repository.MoneyFlowFilter{UserID: user.ID, Tag: "lunch"})
+	if err != nil || count != 3 {
+		t.Errorf("count tagged lunch is %d (%v), expected 3", count, err)
+	}
+
+	// October without the deleted money flow
+	start, _ := time.Parse(time.RFC3339, "2026-10-01T00:00:00Z")
+	summaries, err := env.Repos.MoneyFlows.SummaryByUserIDAndDateRange(ctx, user.ID, start, start.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	expected := domain.FlowSummary{Currency: domain.DefaultCurrency, Expense: 500000, Net: -500000, Count: 2}
+	if len(summaries) != 1 || *summaries[0] != expected {
+		t.Errorf("summary is %v, expected [%v]", summaries, expected)
+	}
+}
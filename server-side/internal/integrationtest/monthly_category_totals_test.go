@@ -0,0 +1,104 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+)
+
+func TestMonthlyCategoryTotalsMatchLiveTotals(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+	live := postgresql.NewMoneyFlowRepository(postgresql.NewDB(env.DB))
+
+	var moneyFlows []*domain.MoneyFlow
+	for i, createdAt := range []string{
+		"2026-07-31T23:00:00Z",
+		"2026-08-01T00:00:00Z",
+		"2026-08-15T12:00:00Z",
+		"2026-09-30T18:00:00Z",
+		"2026-10-03T16:00:00Z",
+		"2026-10-10T10:00:00Z",
+	} {
+		moneyFlow, err := domain.NewMoneyFlow(user.ID, float64(1000*(i+1)), domain.DefaultCurrency)
+		if err != nil {
+			t.Fatalf("new money flow: %v", err)
+		}
+		if i%2 == 0 {
+			moneyFlow.Category = ptr("Food")
+		}
+		moneyFlow.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		moneyFlow.UpdatedAt = moneyFlow.CreatedAt
+		if err := env.Repos.MoneyFlows.Create(ctx, moneyFlow); err != nil {
+			t.Fatalf("create money flow: %v", err)
+		}
+		moneyFlows = append(moneyFlows, moneyFlow)
+	}
+
+	// Moving a money flow to another category and deleting one update the read model
+	moneyFlows[1].Category = ptr("Transport")
+	moneyFlows[1].Version++
+	if err := env.Repos.MoneyFlows.Update(ctx, moneyFlows[1]); err != nil {
+		t.Fatalf("update money flow: %v", err)
+	}
+	if err := env.Repos.MoneyFlows.Delete(ctx, moneyFlows[2].ID); err != nil {
+		t.Fatalf("delete money flow: %v", err)
+	}
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	periods := []struct {
+		name       string
+		start, end time.Time
+	}{
+		{"all time", time.Time{}, time.Now()},
+		{"UTC months", time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{"Jakarta month", time.Date(2026, 10, 1, 0, 0, 0, 0, jakarta), time.Date(2026, 11, 1, 0, 0, 0, 0, jakarta)},
+		{"mid-month to mid-month", time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, period := range periods {
+		got, err := env.Repos.MoneyFlows.GetTotalsByCategory(ctx, user.ID, period.start, period.end)
+		if err != nil {
+			t.Fatalf("%s totals: %v", period.name, err)
+		}
+		want, err := live.GetTotalsByCategory(ctx, user.ID, period.start, period.end)
+		if err != nil {
+			t.Fatalf("%s live totals: %v", period.name, err)
+		}
+		if formatTotals(got) != formatTotals(want) {
+			t.Errorf("%s totals are %s, expected %s", period.name, formatTotals(got), formatTotals(want))
+		}
+	}
+
+	got, err := env.Repos.MoneyFlows.GetTotalsByCurrency(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("currency totals: %v", err)
+	}
+	want, err := live.GetTotalsByCurrency(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("live currency totals: %v", err)
+	}
+	if len(got) != 1 || *got[0] != *want[0] {
+		t.Errorf("currency totals are %+v, expected %+v", got, want)
+	}
+}
+
+// formatTotals prints category totals in order, for comparing them
+func formatTotals(totals []*domain.CategoryTotal) string {
+	s := ""
+	for _, total := range totals {
+		category := "<none>"
+		if total.Category != nil {
+			category = *total.Category
+		}
+		s += fmt.Sprintf("[%s %s %d/%d]", category, total.Currency, total.Total, total.Count)
+	}
+	return s
+}
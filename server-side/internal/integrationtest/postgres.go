@@ -0,0 +1,153 @@
+//go:build integration
+
+// Package integrationtest runs tests against a real PostgreSQL database, so mistakes in
+// GORM mappings, SQL, and migrations that fakes cannot catch fail a test. Each test
+// package starts one database from its TestMain, and every test starts from empty tables.
+//
+// PostgreSQL is started in a container with testcontainers-go, which needs Docker. Set
+// TEST_DATABASE_URL to use an existing database instead, such as a CI service container;
+// its tables are emptied by the tests. Set TEST_DATABASE=sqlite to run them on a SQLite
+// file in a temporary directory instead, quickly and without Docker. Set
+// TEST_QUERY_ENGINE=sqlc to run the hottest reads with sqlc, like DB_QUERY_ENGINE=sqlc
+// does, on PostgreSQL only. The tests only build with the integration tag:
+//
+//	go test -tags integration ./internal/integrationtest/...
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/config"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"gorm.io/gorm"
+)
+
+// postgresImage matches the database of deployment/local/docker-compose.yml
+const postgresImage = "postgres:15-alpine"
+
+// database is the migrated database shared by the tests of a package
+var database *gorm.DB
+
+// queryEngine is TEST_QUERY_ENGINE, the config.QueryEngine* the repositories use
+var queryEngine string
+
+// sqlcPool is the pgx pool of the sqlc queries with TEST_QUERY_ENGINE=sqlc
+var sqlcPool *pgxpool.Pool
+
+// Main starts and migrates the database, runs the tests of a package, and stops the
+// database. Call it from TestMain:
+//
+//	func TestMain(m *testing.M) { integrationtest.Main(m) }
+func Main(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	queryEngine = os.Getenv("TEST_QUERY_ENGINE")
+	if queryEngine != "" && queryEngine != config.QueryEngineGORM && queryEngine != config.QueryEngineSQLC {
+		log.Printf("TEST_QUERY_ENGINE must be %s or %s", config.QueryEngineGORM, config.QueryEngineSQLC)
+		return 1
+	}
+
+	if os.Getenv("TEST_DATABASE") == "sqlite" {
+		if queryEngine == config.QueryEngineSQLC {
+			log.Printf("TEST_QUERY_ENGINE=sqlc requires PostgreSQL")
+			return 1
+		}
+		return runSQLite(m)
+	}
+
+	ctx := context.Background()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		container, err := tcpostgres.Run(ctx, postgresImage,
+			tcpostgres.WithDatabase("catetin_test"),
+			tcpostgres.WithUsername("postgres"),
+			tcpostgres.WithPassword("postgres"),
+			tcpostgres.BasicWaitStrategies(),
+		)
+		defer func() {
+			if err := testcontainers.TerminateContainer(container); err != nil {
+				log.Printf("Failed to stop PostgreSQL container: %v", err)
+			}
+		}()
+		if err != nil {
+			log.Printf("Failed to start PostgreSQL container: %v", err)
+			return 1
+		}
+
+		databaseURL, err = container.ConnectionString(ctx, "sslmode=disable")
+		if err != nil {
+			log.Printf("Failed to get PostgreSQL connection string: %v", err)
+			return 1
+		}
+	}
+
+	if err := postgresql.RunMigrations(databaseURL, postgresql.Migrations()); err != nil {
+		log.Printf("Failed to run migrations: %v", err)
+		return 1
+	}
+
+	// The production log level keeps every query out of the test output
+	db, err := postgresql.NewConnection(databaseURL, "production", postgresql.PoolConfig{
+		MaxIdleConns: 5,
+		MaxOpenConns: 10,
+	})
+	if err != nil {
+		log.Printf("Failed to connect to database: %v", err)
+		return 1
+	}
+	defer postgresql.Close(db)
+
+	if queryEngine == config.QueryEngineSQLC {
+		pool, err := sqlc.NewPool(ctx, databaseURL, postgresql.PoolConfig{MaxOpenConns: 10})
+		if err != nil {
+			log.Printf("Failed to connect the sqlc query engine: %v", err)
+			return 1
+		}
+		defer pool.Close()
+		sqlcPool = pool
+	}
+
+	database = db
+	return m.Run()
+}
+
+// truncate empties every table except the migration version, so a test starts from
+// the state of a freshly migrated database
+func truncate(db *gorm.DB) error {
+	if usesSQLite {
+		return truncateSQLite(db)
+	}
+
+	var tables []string
+	err := db.Table("pg_tables").
+		Where("schemaname = ?", "public").
+		Where("tablename <> ?", "schema_migrations").
+		Pluck("tablename", &tables).Error
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	quoted := make([]string, len(tables))
+	for i, table := range tables {
+		quoted[i] = `"` + table + `"`
+	}
+	if err := db.Exec("TRUNCATE " + strings.Join(quoted, ", ") + " CASCADE").Error; err != nil {
+		return fmt.Errorf("failed to truncate tables: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+func TestSpendingTotalsFollowTheUserCalendar(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	ctx := context.Background()
+
+	// Times in UTC; Asia/Jakarta is 7 hours ahead
+	for _, createdAt := range []string{
+		"2026-09-30T18:00:00Z", // Thursday 1 October, 01:00 in Jakarta
+		"2026-10-03T16:00:00Z", // Saturday 3 October, 23:00
+		"2026-10-03T18:00:00Z", // Sunday 4 October, 01:00
+		"2026-10-10T10:00:00Z", // Saturday 10 October
+	} {
+		moneyFlow, err := domain.NewMoneyFlow(user.ID, 10000, domain.DefaultCurrency)
+		if err != nil {
+			t.Fatalf("new money flow: %v", err)
+		}
+		moneyFlow.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		moneyFlow.UpdatedAt = moneyFlow.CreatedAt
+		if err := env.Repos.MoneyFlows.Create(ctx, moneyFlow); err != nil {
+			t.Fatalf("create money flow: %v", err)
+		}
+	}
+
+	perMoneyFlow, _ := domain.MinorUnits(10000, domain.DefaultCurrency)
+	query := repository.SpendingQuery{
+		UserID:    user.ID,
+		Timezone:  "Asia/Jakarta",
+		WeekStart: time.Sunday,
+		Start:     time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		End:       time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		interval string
+		want     map[string]int64 // count per period start
+	}{
+		{domain.TrendDaily, map[string]int64{"2026-10-01": 1, "2026-10-03": 1, "2026-10-04": 1, "2026-10-10": 1}},
+		{domain.TrendWeekly, map[string]int64{"2026-09-27": 2, "2026-10-04": 2}},
+		{domain.TrendMonthly, map[string]int64{"2026-10-01": 4}},
+	}
+	for _, tt := range tests {
+		query.Interval = tt.interval
+		totals, err := env.Repos.Spending.GetTotalsByPeriod(ctx, query)
+		if err != nil {
+			t.Fatalf("%s totals: %v", tt.interval, err)
+		}
+
+		got := map[string]int64{}
+		for _, total := range totals {
+			got[total.PeriodStart.Format(time.DateOnly)] += total.Count
+			if total.Total != total.Count*perMoneyFlow {
+				t.Errorf("%s total of %s is %d for %d money flows", tt.interval, total.PeriodStart.Format(time.DateOnly), total.Total, total.Count)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s periods are %v, expected %v", tt.interval, got, tt.want)
+			continue
+		}
+		for period, count := range tt.want {
+			if got[period] != count {
+				t.Errorf("%s periods are %v, expected %v", tt.interval, got, tt.want)
+				break
+			}
+		}
+	}
+}
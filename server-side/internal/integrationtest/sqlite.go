@@ -0,0 +1,72 @@
+//go:build integration
+
+package integrationtest
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/sqlite"
+	"gorm.io/gorm"
+)
+
+// usesSQLite is set when the tests run on SQLite, whose repositories override some of
+// the PostgreSQL ones
+var usesSQLite bool
+
+// runSQLite runs the tests of a package on a migrated SQLite database in a temporary
+// directory
+func runSQLite(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "catetin-integrationtest-")
+	if err != nil {
+		log.Printf("Failed to create database directory: %v", err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	// The production log level keeps every query out of the test output
+	db, err := sqlite.NewConnection(filepath.Join(dir, "catetin.db"), "production")
+	if err != nil {
+		log.Printf("Failed to open database: %v", err)
+		return 1
+	}
+	defer postgresql.Close(db)
+
+	if err := sqlite.RunMigrations(db, sqlite.Migrations()); err != nil {
+		log.Printf("Failed to run migrations: %v", err)
+		return 1
+	}
+
+	database = db
+	usesSQLite = true
+	return m.Run()
+}
+
+// truncateSQLite deletes the rows of every table except the migration version. Foreign
+// keys are checked when the transaction commits, once every table is empty.
+func truncateSQLite(db *gorm.DB) error {
+	var tables []string
+	err := db.Table("sqlite_master").
+		Where("type = ?", "table").
+		Where("name NOT LIKE ? AND name <> ?", "sqlite_%", "schema_migrations").
+		Pluck("name", &tables).Error
+	if err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("PRAGMA defer_foreign_keys = ON").Error; err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if err := tx.Exec(`DELETE FROM "` + table + `"`).Error; err != nil {
+				return fmt.Errorf("failed to empty %s: %w", table, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,130 @@
+//go:build integration
+
+package integrationtest_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/postgresql"
+	"github.com/ingunawandra/catetin/internal/infrastructure/email"
+	"github.com/ingunawandra/catetin/internal/integrationtest"
+	"github.com/ingunawandra/catetin/internal/service"
+	"github.com/ingunawandra/catetin/internal/worker"
+)
+
+// recordedMail records sent email instead of sending it
+type recordedMail struct {
+	messages []email.Message
+}
+
+func (m *recordedMail) SendMessage(ctx context.Context, message email.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func TestMonthlyStatementIsEmailedOnceToOptedInUsers(t *testing.T) {
+	env := integrationtest.Setup(t)
+	user := env.CreateUser(t, "budi@example.com", "password123")
+	env.CreateUser(t, "siti@example.com", "password123") // did not opt in
+	ctx := context.Background()
+
+	for _, amount := range []float64{45000, 12500} {
+		if _, err := env.MoneyFlowService().Create(ctx, user.ID, service.MoneyFlowInput{
+			Amount: amount, Category: ptr("Food"), AllowDuplicate: true,
+		}); err != nil {
+			t.Fatalf("create money flow: %v", err)
+		}
+	}
+
+	optIn := func(userID uuid.UUID) *domain.UserSettings {
+		settings := domain.DefaultUserSettings(userID)
+		settings.Locale = "id-ID"
+		settings.MonthlyStatement = true
+		if err := env.Repos.UserSettings.Create(ctx, settings); err != nil {
+			t.Fatalf("create settings: %v", err)
+		}
+		return settings
+	}
+	settings := optIn(user.ID)
+
+	// Disabled users are not sent statements they opted in to
+	disabled := env.CreateUser(t, "rina@example.com", "password123")
+	optIn(disabled.ID)
+	disabledAt := time.Now()
+	disabled.DisabledAt = &disabledAt
+	disabled.Version++
+	if err := env.Repos.Users.Update(ctx, disabled); err != nil {
+		t.Fatalf("disable user: %v", err)
+	}
+
+	jobs := &recordedJobs{}
+	mail := &recordedMail{}
+	exports := service.NewMoneyFlowExportService(env.Repos.MoneyFlows, env.Repos.Users, service.PDFExporter{})
+	statements := service.NewStatementService(env.Repos.UserSettings,
+		postgresql.NewStatementRepository(postgresql.NewDB(env.DB)), env.Repos.UserAuths, env.Repos.AuthProviders,
+		exports, mail, jobs, env.TxManager, service.StatementConfig{BatchSize: 1})
+
+	now := time.Now().UTC()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	preview, err := statements.Preview(ctx, user.ID, month, i18n.English)
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if preview.Count != 2 || !strings.Contains(preview.HTML, "Monthly statement") || !strings.Contains(preview.HTML, "IDR 57.500") {
+		t.Errorf("preview of %d money flows = %s, want both in the summary table", preview.Count, preview.HTML)
+	}
+	if !bytes.HasPrefix(preview.PDF.Content, []byte("%PDF")) {
+		t.Error("preview attachment is not a PDF")
+	}
+
+	// The statement of the month before is claimed once, for the opted-in user only
+	if queued := statements.Schedule(ctx); queued != 1 {
+		t.Fatalf("Schedule() = %d, want the opted-in user's statement queued", queued)
+	}
+	if queued := statements.Schedule(ctx); queued != 0 {
+		t.Errorf("Schedule() again = %d, want the statement queued once", queued)
+	}
+	if len(jobs.jobs) != 1 || jobs.jobs[0].Type != service.JobSendStatement {
+		t.Fatalf("jobs = %+v, want one JobSendStatement", jobs.jobs)
+	}
+
+	// Nothing was recorded in the month before, so that statement is not sent
+	if err := statements.HandleSendJob(ctx, jobs.jobs[0]); err != nil || len(mail.messages) != 0 {
+		t.Errorf("HandleSendJob() of an empty month = %v with %d emails, want none sent", err, len(mail.messages))
+	}
+
+	job, err := worker.NewJob(service.JobSendStatement, map[string]interface{}{"user_id": user.ID, "period_start": month})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := statements.HandleSendJob(ctx, job); err != nil {
+		t.Fatalf("HandleSendJob() error = %v", err)
+	}
+	if len(mail.messages) != 1 {
+		t.Fatalf("sent %d emails, want the statement", len(mail.messages))
+	}
+	message := mail.messages[0]
+	if message.To != "budi@example.com" || !strings.Contains(message.Subject, "Laporan") || !strings.Contains(message.Text, "Food: IDR 57.500 (2)") {
+		t.Errorf("email to %s = %q\n%s\nwant the statement in Indonesian", message.To, message.Subject, message.Text)
+	}
+	if len(message.Attachments) != 1 || message.Attachments[0].Filename != "catetin-"+month.Format(service.ExportMonthLayout)+".pdf" {
+		t.Errorf("attachments = %+v, want the PDF statement", message.Attachments)
+	}
+
+	// Users who opt out before the job runs are not sent it
+	settings.MonthlyStatement = false
+	settings.Version++
+	if err := env.Repos.UserSettings.Update(ctx, settings); err != nil {
+		t.Fatalf("update settings: %v", err)
+	}
+	if err := statements.HandleSendJob(ctx, job); err != nil || len(mail.messages) != 1 {
+		t.Errorf("HandleSendJob() after opting out = %v with %d emails, want no more sent", err, len(mail.messages))
+	}
+}
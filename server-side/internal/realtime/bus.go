@@ -0,0 +1,191 @@
+// Package realtime pushes events to the connected clients of a user, so an app can show
+// an expense recorded over WhatsApp or a budget going over its cap without polling.
+//
+// Services publish events in the transaction of the change; the Bus delivers them once
+// the transaction commits, to the subscriptions of this process. Delivery is best effort:
+// clients reconnect after a disconnect and reload what they show, since events published
+// while they were away are not replayed.
+package realtime
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// Event types
+const (
+	EventMoneyFlowCreated = "money_flow.created"
+	EventMoneyFlowUpdated = "money_flow.updated"
+	EventMoneyFlowDeleted = "money_flow.deleted"
+	EventBudgetExceeded   = "budget.exceeded"
+)
+
+var (
+	// ErrTooManySubscriptions is returned when the user has the maximum number of open
+	// subscriptions
+	ErrTooManySubscriptions = errors.New("realtime: too many subscriptions")
+
+	// ErrClosed is returned when subscribing to a closed bus
+	ErrClosed = errors.New("realtime: bus closed")
+)
+
+// Event is something that happened to a user's data
+type Event struct {
+	ID         uuid.UUID
+	Type       string
+	UserID     uuid.UUID
+	Data       any // encoded as JSON for clients
+	OccurredAt time.Time
+}
+
+// Config holds the limits of a Bus
+type Config struct {
+	// MaxSubscriptionsPerUser caps the open subscriptions of a user, e.g. browser tabs
+	MaxSubscriptionsPerUser int
+
+	// Buffer is how many events a subscription holds for a slow client before the bus
+	// closes it
+	Buffer int
+}
+
+// Bus fans events out to the subscriptions of their user.
+// A nil *Bus publishes nothing.
+type Bus struct {
+	config Config
+
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]map[*Subscription]struct{}
+	closed        bool
+}
+
+// NewBus creates a new bus
+func NewBus(config Config) *Bus {
+	if config.Buffer <= 0 {
+		config.Buffer = 16
+	}
+	return &Bus{
+		config:        config,
+		subscriptions: make(map[uuid.UUID]map[*Subscription]struct{}),
+	}
+}
+
+// Publish sends an event to the user's subscriptions once the transaction of ctx commits,
+// or right away outside a transaction. Events of a transaction that rolls back are dropped.
+func (b *Bus) Publish(ctx context.Context, userID uuid.UUID, eventType string, data any) {
+	if b == nil {
+		return
+	}
+
+	event := Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		UserID:     userID,
+		Data:       data,
+		OccurredAt: time.Now(),
+	}
+	repository.AfterCommit(ctx, func() {
+		b.deliver(event)
+	})
+}
+
+// deliver hands an event to the subscriptions of its user. A subscription whose buffer is
+// full is closed rather than blocking the publisher; its client reconnects and reloads.
+func (b *Bus) deliver(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscriptions[event.UserID] {
+		select {
+		case sub.events <- event:
+		default:
+			b.remove(sub)
+		}
+	}
+}
+
+// Subscribe opens a subscription to the user's events. Close it when the client goes away.
+func (b *Bus) Subscribe(userID uuid.UUID) (*Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+	subs := b.subscriptions[userID]
+	if b.config.MaxSubscriptionsPerUser > 0 && len(subs) >= b.config.MaxSubscriptionsPerUser {
+		return nil, ErrTooManySubscriptions
+	}
+	if subs == nil {
+		subs = make(map[*Subscription]struct{})
+		b.subscriptions[userID] = subs
+	}
+
+	sub := &Subscription{
+		bus:    b,
+		userID: userID,
+		events: make(chan Event, b.config.Buffer),
+	}
+	subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// Close closes every subscription and rejects new ones, so open streams end when the
+// server shuts down
+func (b *Bus) Close() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, subs := range b.subscriptions {
+		for sub := range subs {
+			b.remove(sub)
+		}
+	}
+}
+
+// remove closes a subscription; the caller holds b.mu
+func (b *Bus) remove(sub *Subscription) {
+	subs, ok := b.subscriptions[sub.userID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscriptions, sub.userID)
+	}
+	close(sub.events)
+}
+
+// Subscription receives the events of one user
+type Subscription struct {
+	bus    *Bus
+	userID uuid.UUID
+	events chan Event
+}
+
+// Events returns the events of the subscription. The channel is closed when the
+// subscription is closed, by Close, a slow client, or the bus shutting down.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close stops the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.remove(s)
+}
@@ -0,0 +1,95 @@
+package realtime_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/memory"
+	"github.com/ingunawandra/catetin/internal/realtime"
+)
+
+func TestPublishDeliversCommittedEventsOnly(t *testing.T) {
+	ctx := context.Background()
+	bus := realtime.NewBus(realtime.Config{})
+	txManager := memory.NewTransactionManager(memory.NewStore())
+
+	userID := uuid.New()
+	sub, err := bus.Subscribe(userID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer sub.Close()
+	other, err := bus.Subscribe(uuid.New())
+	if err != nil {
+		t.Fatalf("Subscribe(other user) error = %v", err)
+	}
+	defer other.Close()
+
+	errAbort := errors.New("abort")
+	_ = txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		bus.Publish(txCtx, userID, realtime.EventMoneyFlowCreated, "rolled back")
+		return errAbort
+	})
+	err = txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		bus.Publish(txCtx, userID, realtime.EventMoneyFlowCreated, "committed")
+		if len(sub.Events()) != 0 {
+			t.Error("event delivered before the transaction committed")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTransaction() error = %v", err)
+	}
+
+	select {
+	case event := <-sub.Events():
+		if event.Data != "committed" || event.Type != realtime.EventMoneyFlowCreated || event.UserID != userID {
+			t.Errorf("event = %+v, want the committed money_flow.created event", event)
+		}
+	default:
+		t.Fatal("no event delivered after commit")
+	}
+	if n := len(sub.Events()); n != 0 {
+		t.Errorf("%d more events delivered, want only the committed one", n)
+	}
+	if n := len(other.Events()); n != 0 {
+		t.Errorf("%d events delivered to another user", n)
+	}
+}
+
+func TestSubscriptionsAreLimitedAndClosed(t *testing.T) {
+	ctx := context.Background()
+	bus := realtime.NewBus(realtime.Config{MaxSubscriptionsPerUser: 1, Buffer: 1})
+	userID := uuid.New()
+
+	sub, err := bus.Subscribe(userID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if _, err := bus.Subscribe(userID); !errors.Is(err, realtime.ErrTooManySubscriptions) {
+		t.Errorf("Subscribe(second) error = %v, want %v", err, realtime.ErrTooManySubscriptions)
+	}
+
+	// A client that falls behind is dropped, freeing its slot
+	bus.Publish(ctx, userID, realtime.EventBudgetExceeded, 1)
+	bus.Publish(ctx, userID, realtime.EventBudgetExceeded, 2)
+	<-sub.Events()
+	if _, ok := <-sub.Events(); ok {
+		t.Error("slow subscription still open after its buffer overflowed")
+	}
+	sub.Close() // closing again is harmless
+
+	next, err := bus.Subscribe(userID)
+	if err != nil {
+		t.Fatalf("Subscribe(after drop) error = %v", err)
+	}
+	bus.Close()
+	if _, ok := <-next.Events(); ok {
+		t.Error("subscription still open after the bus closed")
+	}
+	if _, err := bus.Subscribe(userID); !errors.Is(err, realtime.ErrClosed) {
+		t.Errorf("Subscribe(closed bus) error = %v, want %v", err, realtime.ErrClosed)
+	}
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// AccountErasureRepository defines the interface for account erasure data access
+type AccountErasureRepository interface {
+	// Create creates a new account erasure. Returns domain.ErrConflict if the user
+	// already has a pending one.
+	Create(ctx context.Context, erasure *domain.AccountErasure) error
+
+	// FindByID finds an account erasure by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.AccountErasure, error)
+
+	// FindPendingByUserID finds the pending account erasure of a user
+	FindPendingByUserID(ctx context.Context, userID uuid.UUID) (*domain.AccountErasure, error)
+
+	// Finish moves a pending account erasure to status, ErasureCancelled or
+	// ErasureCompleted; it returns domain.ErrNotFound when it is no longer pending
+	Finish(ctx context.Context, id uuid.UUID, status string, at time.Time) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// AnalyticsEventRepository defines the interface for analytics event storage
+type AnalyticsEventRepository interface {
+	// CreateBatch stores a batch of analytics events
+	CreateBatch(ctx context.Context, events []*domain.AnalyticsEvent) error
+}
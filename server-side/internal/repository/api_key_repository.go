@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	// Create creates a new API key
+	Create(ctx context.Context, apiKey *domain.APIKey) error
+
+	// FindByID finds an API key by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+
+	// FindByHash finds an API key by the hash of its plaintext value
+	FindByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+
+	// FindByUserID finds all API keys issued by a user
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.APIKey, error)
+
+	// Revoke marks an API key as revoked
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+
+	// RevokeAllByUserID revokes every active API key of a user
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error
+
+	// UpdateLastUsed records the last time an API key was used
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error
+}
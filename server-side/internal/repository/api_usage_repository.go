@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// APIUsageRepository defines the interface for API usage counter data access
+type APIUsageRepository interface {
+	// Increment adds the counts to the stored counters, creating missing rows
+	Increment(ctx context.Context, usages []*domain.APIUsage) error
+
+	// FindByUserID retrieves a user's daily counters since the given day, oldest first
+	FindByUserID(ctx context.Context, userID uuid.UUID, since time.Time) ([]*domain.APIUsage, error)
+
+	// SummarizeByUser totals counters per user since the given day, busiest first, with pagination
+	SummarizeByUser(ctx context.Context, since time.Time, limit, offset int) ([]*domain.UserAPIUsage, error)
+
+	// SummarizeByRoute totals counters per endpoint across all users since the given day, busiest first
+	SummarizeByRoute(ctx context.Context, since time.Time) ([]*domain.RouteAPIUsage, error)
+}
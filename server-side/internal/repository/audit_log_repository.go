@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// AuditLogRepository defines the interface for audit log data access
+type AuditLogRepository interface {
+	// Create creates a new audit log entry
+	Create(ctx context.Context, log *domain.AuditLog) error
+
+	// FindByActorID retrieves the changes a user made matching the filter, newest first,
+	// with pagination
+	FindByActorID(ctx context.Context, actorID uuid.UUID, filter domain.AuditLogFilter, limit, offset int) ([]*domain.AuditLog, error)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// BroadcastRepository defines the interface for broadcast data access
+type BroadcastRepository interface {
+	// Create creates a new broadcast
+	Create(ctx context.Context, broadcast *domain.Broadcast) error
+
+	// FindByID finds a broadcast by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Broadcast, error)
+
+	// List retrieves broadcasts, newest first, with pagination
+	List(ctx context.Context, limit, offset int) ([]*domain.Broadcast, error)
+
+	// UpdateStatus updates the status of a broadcast
+	UpdateStatus(ctx context.Context, broadcast *domain.Broadcast) error
+
+	// CreateDeliveries creates pending deliveries for a broadcast
+	CreateDeliveries(ctx context.Context, deliveries []*domain.BroadcastDelivery) error
+
+	// FindPendingDeliveries retrieves the oldest pending deliveries across all broadcasts
+	FindPendingDeliveries(ctx context.Context, limit int) ([]*domain.BroadcastDelivery, error)
+
+	// ClaimDelivery moves a pending delivery to sending and increments its attempts.
+	// It returns domain.ErrConflict if another dispatcher claimed it first.
+	ClaimDelivery(ctx context.Context, delivery *domain.BroadcastDelivery) error
+
+	// ReleaseStaleDeliveries returns deliveries stuck in sending since before the
+	// given time (e.g. after a crash) to pending so they are retried
+	ReleaseStaleDeliveries(ctx context.Context, claimedBefore time.Time) (int64, error)
+
+	// UpdateDelivery persists the outcome of a delivery attempt
+	UpdateDelivery(ctx context.Context, delivery *domain.BroadcastDelivery) error
+
+	// ListDeliveries retrieves the deliveries of a broadcast, optionally filtered by status
+	ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string, limit, offset int) ([]*domain.BroadcastDelivery, error)
+
+	// CountDeliveriesByStatus counts the deliveries of a broadcast grouped by status
+	CountDeliveriesByStatus(ctx context.Context, broadcastID uuid.UUID) (map[string]int, error)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// BudgetRepository defines the interface for budget data access
+type BudgetRepository interface {
+	// Create creates a new budget. Returns domain.ErrConflict if the user
+	// already has a budget for the category.
+	Create(ctx context.Context, budget *domain.Budget) error
+
+	// FindByID finds a budget by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Budget, error)
+
+	// FindByUserIDAndCategory finds the budget of a user for a category
+	FindByUserIDAndCategory(ctx context.Context, userID uuid.UUID, category string) (*domain.Budget, error)
+
+	// FindByUserID finds all budgets of a user, ordered by category
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Budget, error)
+
+	// Update updates an existing budget (optimistic locking on version)
+	Update(ctx context.Context, budget *domain.Budget) error
+
+	// Delete deletes a budget and its override history
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateOverride records a money flow that exceeded a hard budget
+	CreateOverride(ctx context.Context, override *domain.BudgetOverride) error
+
+	// FindOverridesByUserID finds the budget overrides of a user, newest first
+	FindOverridesByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.BudgetOverride, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// ConversationRepository defines the interface for chat conversation data access
+type ConversationRepository interface {
+	// Create creates a new conversation. Returns domain.ErrConflict if the user already
+	// has an active conversation or the source message already started one.
+	Create(ctx context.Context, conversation *domain.Conversation) error
+
+	// FindByID finds a conversation by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Conversation, error)
+
+	// FindBySourceMessageID finds the conversation started by a chat message
+	FindBySourceMessageID(ctx context.Context, messageID string) (*domain.Conversation, error)
+
+	// FindActiveByUserID finds the active conversation of a user
+	FindActiveByUserID(ctx context.Context, userID uuid.UUID) (*domain.Conversation, error)
+
+	// Update updates an existing conversation (optimistic locking on version)
+	Update(ctx context.Context, conversation *domain.Conversation) error
+}
@@ -8,17 +8,25 @@ import "context"
 type DB interface {
 	WithContext(ctx context.Context) DB
 	Create(value interface{}) Result
+	CreateInBatches(value interface{}, batchSize int) Result // value is a pointer to a slice
 	Where(query interface{}, args ...interface{}) DB
 	First(dest interface{}) Result
 	Limit(limit int) DB
 	Offset(offset int) DB
 	Order(value interface{}) DB
+	Group(name string) DB
+	Having(query interface{}, args ...interface{}) DB
+	Joins(query string, args ...interface{}) DB
+	Unscoped() DB // includes soft-deleted rows
+	Preload(query string, args ...interface{}) DB
 	Find(dest interface{}) Result
 	Model(value interface{}) DB
 	Select(query interface{}) DB
 	Scan(dest interface{}) Result
+	Count(count *int64) Result
 	Updates(values interface{}) Result
 	Delete(value interface{}, conds ...interface{}) Result
+	Exec(sql string, values ...interface{}) Result
 
 	// Transaction helpers
 	Transaction(fn func(tx DB) error) error
@@ -33,3 +41,18 @@ type Result interface {
 	Error() error
 	RowsAffected() int64
 }
+
+type replicaKey struct{}
+
+// PreferReplica marks ctx as tolerating replication lag: reads made with it outside a
+// transaction may be served by a read replica. Use it for reporting and admin reads,
+// not for reads that must see a write made just before.
+func PreferReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaKey{}, true)
+}
+
+// PrefersReplica reports whether ctx was marked with PreferReplica
+func PrefersReplica(ctx context.Context) bool {
+	prefers, _ := ctx.Value(replicaKey{}).(bool)
+	return prefers
+}
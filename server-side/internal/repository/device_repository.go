@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// DeviceRepository defines the interface for push notification device data access
+type DeviceRepository interface {
+	// Register saves a device. A token that is already registered moves to the
+	// device's user and is refreshed; the device then takes the stored ID.
+	Register(ctx context.Context, device *domain.Device) error
+
+	// FindByID finds a device by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Device, error)
+
+	// FindByUserID finds all devices of a user, most recently seen first
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error)
+
+	// Delete deletes a device
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByToken deletes the device with the token, e.g. after the push service
+	// reported it unregistered
+	DeleteByToken(ctx context.Context, token string) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DigestRepository records the spending digests sent to users
+type DigestRepository interface {
+	// Claim records that the digest of a period is being sent to the user. It reports
+	// false when the digest was already claimed, e.g. by another instance.
+	Claim(ctx context.Context, userID uuid.UUID, frequency string, periodStart time.Time) (bool, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// ExchangeRateRepository defines the interface for exchange rate data access
+type ExchangeRateRepository interface {
+	// Save creates or replaces rates, keyed by base currency, currency, and date
+	Save(ctx context.Context, rates []*domain.ExchangeRate) error
+
+	// FindLatest finds the rates of a base currency on the latest day on or before the date.
+	// It returns domain.ErrNotFound if no rates were saved by then.
+	FindLatest(ctx context.Context, base string, on time.Time) ([]*domain.ExchangeRate, error)
+}
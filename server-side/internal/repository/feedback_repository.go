@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// FeedbackRepository defines the interface for user feedback data access
+type FeedbackRepository interface {
+	// Create creates a new feedback. It returns domain.ErrConflict if feedback from the
+	// same chat message exists, e.g. when WhatsApp redelivers the message.
+	Create(ctx context.Context, feedback *domain.Feedback) error
+
+	// FindByID finds a feedback by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Feedback, error)
+
+	// List retrieves feedback matching the filter, newest first, with pagination
+	List(ctx context.Context, filter domain.FeedbackFilter, limit, offset int) ([]*domain.Feedback, error)
+
+	// MarkForwarded records when a feedback was posted to Slack
+	MarkForwarded(ctx context.Context, id uuid.UUID, forwardedAt time.Time) error
+}
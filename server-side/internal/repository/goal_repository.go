@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// GoalRepository defines the interface for savings goal data access
+type GoalRepository interface {
+	// Create creates a new goal
+	Create(ctx context.Context, goal *domain.Goal) error
+
+	// FindByID finds a goal by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Goal, error)
+
+	// FindByUserID finds the goals of a user, the nearest deadline first and goals
+	// without a deadline last. Archived goals are left out unless includeArchived.
+	FindByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*domain.Goal, error)
+
+	// Update updates an existing goal (optimistic locking on version).
+	// Returns domain.ErrConflict on a version mismatch.
+	Update(ctx context.Context, goal *domain.Goal) error
+
+	// Delete deletes a goal
+	Delete(ctx context.Context, id uuid.UUID) error
+}
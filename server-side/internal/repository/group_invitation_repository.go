@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// GroupInvitationRepository defines the interface for group invitation data access
+type GroupInvitationRepository interface {
+	// Create creates a new group invitation
+	Create(ctx context.Context, invitation *domain.GroupInvitation) error
+
+	// FindByTokenHash finds a group invitation by the hash of its token
+	FindByTokenHash(ctx context.Context, tokenHash string) (*domain.GroupInvitation, error)
+
+	// FindPendingByGroupID finds the invitations of a group that were neither accepted
+	// nor expired at the given time, newest first
+	FindPendingByGroupID(ctx context.Context, groupID uuid.UUID, now time.Time) ([]*domain.GroupInvitation, error)
+
+	// MarkAccepted marks an invitation as accepted by a user; it returns
+	// domain.ErrNotFound when the invitation was accepted already
+	MarkAccepted(ctx context.Context, id, userID uuid.UUID, acceptedAt time.Time) error
+
+	// Delete deletes an invitation of a group
+	Delete(ctx context.Context, groupID, id uuid.UUID) error
+}
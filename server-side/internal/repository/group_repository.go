@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// GroupRepository defines the interface for group and membership data access
+type GroupRepository interface {
+	// Create creates a new group
+	Create(ctx context.Context, group *domain.Group) error
+
+	// FindByID finds a group by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Group, error)
+
+	// FindByUserID finds the groups a user is a member of, ordered by name
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Group, error)
+
+	// Update updates an existing group (optimistic locking on version)
+	Update(ctx context.Context, group *domain.Group) error
+
+	// Delete deletes a group with its members and invitations; its money flows are
+	// kept as personal money flows of their authors
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddMember adds a member to a group. Returns domain.ErrConflict if the user is
+	// a member already.
+	AddMember(ctx context.Context, member *domain.GroupMember) error
+
+	// FindMember finds the membership of a user in a group
+	FindMember(ctx context.Context, groupID, userID uuid.UUID) (*domain.GroupMember, error)
+
+	// FindMembers finds the members of a group with their names, oldest member first
+	FindMembers(ctx context.Context, groupID uuid.UUID) ([]*domain.GroupMember, error)
+
+	// UpdateMemberRole changes the role of a member
+	UpdateMemberRole(ctx context.Context, groupID, userID uuid.UUID, role string) error
+
+	// RemoveMember removes a member from a group
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+}
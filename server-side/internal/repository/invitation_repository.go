@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// InvitationRepository defines the interface for invitation data access
+type InvitationRepository interface {
+	// Create creates a new invitation; an invitation for the same email already
+	// exists when it returns domain.ErrConflict
+	Create(ctx context.Context, invitation *domain.Invitation) error
+
+	// FindByID finds an invitation by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Invitation, error)
+
+	// FindByEmail finds the invitation sent to an email, compared case-insensitively
+	FindByEmail(ctx context.Context, email string) (*domain.Invitation, error)
+
+	// FindByTokenHash finds an invitation by the hash of its token
+	FindByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error)
+
+	// MarkSent stores the hash of a newly sent token
+	MarkSent(ctx context.Context, id uuid.UUID, tokenHash string, sentAt time.Time) error
+
+	// MarkAccepted marks an invitation as accepted; it returns domain.ErrNotFound when
+	// the invitation was accepted already
+	MarkAccepted(ctx context.Context, id uuid.UUID, acceptedAt time.Time) error
+
+	// ClearTokens removes the token hash of up to limit invitations that were accepted
+	// or expired before the given time, and returns the number cleared
+	ClearTokens(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// MerchantRepository defines the interface for merchant and merchant rule data access
+type MerchantRepository interface {
+	// FindOrCreate returns the merchant with the name (case-insensitive), creating it
+	// when there is none
+	FindOrCreate(ctx context.Context, name string) (*domain.Merchant, error)
+
+	// FindByID finds a merchant by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Merchant, error)
+
+	// FindByIDs finds the merchants with the given IDs; unknown IDs are skipped
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Merchant, error)
+
+	// List lists the merchants whose name contains query (case-insensitive; empty
+	// matches all), ordered by name
+	List(ctx context.Context, query string, limit, offset int) ([]*domain.Merchant, error)
+
+	// Update updates the name of a merchant. Returns domain.ErrConflict if another
+	// merchant has the name.
+	Update(ctx context.Context, merchant *domain.Merchant) error
+
+	// CreateRule creates a merchant rule. Returns domain.ErrConflict if a rule has the
+	// pattern.
+	CreateRule(ctx context.Context, rule *domain.MerchantRule) error
+
+	// FindRuleByID finds a merchant rule by ID, with its merchant
+	FindRuleByID(ctx context.Context, id uuid.UUID) (*domain.MerchantRule, error)
+
+	// ListRules lists all merchant rules with their merchants, ordered by pattern
+	ListRules(ctx context.Context) ([]*domain.MerchantRule, error)
+
+	// UpdateRule updates the pattern and merchant of a rule. Returns domain.ErrConflict
+	// if another rule has the pattern.
+	UpdateRule(ctx context.Context, rule *domain.MerchantRule) error
+
+	// DeleteRule deletes a merchant rule
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// GetTotals calculates the total expenses of a user per merchant and currency
+	// created in [start, end), largest total first; money flows without a merchant
+	// are left out
+	GetTotals(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.MerchantTotal, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// MoneyFlowNoteRepository defines the interface for money flow note data access
+type MoneyFlowNoteRepository interface {
+	// Save creates or replaces the note of a money flow
+	Save(ctx context.Context, note *domain.MoneyFlowNote) error
+
+	// FindByMoneyFlowID finds the note of a money flow
+	FindByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) (*domain.MoneyFlowNote, error)
+
+	// Delete removes the note of a money flow
+	Delete(ctx context.Context, moneyFlowID uuid.UUID) error
+
+	// SearchMoneyFlowIDs returns IDs of the user's money flows whose note matches the full-text query
+	SearchMoneyFlowIDs(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]uuid.UUID, error)
+}
@@ -8,29 +8,119 @@ import (
 	"github.com/ingunawandra/catetin/internal/domain"
 )
 
+// MoneyFlowFilter selects the money flows counted by CountByFilter: those of UserID, or
+// the shared ledger of GroupID when set, narrowed down by the other non-empty fields
+type MoneyFlowFilter struct {
+	UserID   uuid.UUID
+	GroupID  *uuid.UUID
+	WalletID *uuid.UUID
+	Tag      string
+}
+
+// Fields of a money flow that PartialUpdate can write, named after their columns
+const (
+	MoneyFlowFieldWalletID    = "wallet_id"
+	MoneyFlowFieldGroupID     = "group_id"
+	MoneyFlowFieldMerchantID  = "merchant_id"
+	MoneyFlowFieldCategory    = "category"
+	MoneyFlowFieldAmount      = "amount"
+	MoneyFlowFieldCurrency    = "currency"
+	MoneyFlowFieldDescription = "description"
+	MoneyFlowFieldTags        = "tags"
+	MoneyFlowFieldLatitude    = "latitude"
+	MoneyFlowFieldLongitude   = "longitude"
+	MoneyFlowFieldPlaceName   = "place_name"
+)
+
 // MoneyFlowRepository defines the interface for money flow data access
 type MoneyFlowRepository interface {
 	// Create creates a new money flow
 	Create(ctx context.Context, moneyFlow *domain.MoneyFlow) error
 
+	// CreateBatch creates several money flows with multi-row inserts
+	CreateBatch(ctx context.Context, moneyFlows []*domain.MoneyFlow) error
+
 	// FindByID finds a money flow by ID
 	FindByID(ctx context.Context, id uuid.UUID) (*domain.MoneyFlow, error)
 
+	// FindByIDs finds money flows by IDs (result order is unspecified)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.MoneyFlow, error)
+
 	// FindByUserID finds all money flows for a specific user
 	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error)
 
+	// FindByUserIDAndTag finds the money flows of a user tagged with tag, newest first
+	FindByUserIDAndTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.MoneyFlow, error)
+
+	// FindByUserIDAndWallet finds the money flows of a user recorded in a wallet, newest first
+	FindByUserIDAndWallet(ctx context.Context, userID, walletID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error)
+
+	// FindByGroupID finds the money flows in the shared ledger of a group, newest first
+	FindByGroupID(ctx context.Context, groupID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error)
+
+	// FindByTransferID finds the two money flows of a transfer, the outgoing one first
+	FindByTransferID(ctx context.Context, transferID uuid.UUID) ([]*domain.MoneyFlow, error)
+
 	// FindByUserIDAndDateRange finds money flows for a user within a date range
 	FindByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*domain.MoneyFlow, error)
 
+	// CountByUserID counts the money flows of a user, transfers included
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// CountByFilter counts the money flows matching the filter
+	CountByFilter(ctx context.Context, filter MoneyFlowFilter) (int64, error)
+
+	// SummaryByUserIDAndDateRange sums the money flows of a user created in [start, end)
+	// per currency, largest count first
+	SummaryByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.FlowSummary, error)
+
+	// FindCreatedBetween finds money flows of all users created in [start, end), oldest first
+	FindCreatedBetween(ctx context.Context, start, end time.Time, limit, offset int) ([]*domain.MoneyFlow, error)
+
+	// FindCreatedBetweenWithDeleted is FindCreatedBetween including soft-deleted money flows
+	FindCreatedBetweenWithDeleted(ctx context.Context, start, end time.Time, limit, offset int) ([]*domain.MoneyFlow, error)
+
 	// Update updates an existing money flow
 	Update(ctx context.Context, moneyFlow *domain.MoneyFlow) error
 
+	// PartialUpdate writes only the given MoneyFlowField* fields of an existing money
+	// flow, along with its version and update time. Like Update, the version must have
+	// been incremented and the stored one must be the version before it.
+	PartialUpdate(ctx context.Context, moneyFlow *domain.MoneyFlow, fields []string) error
+
 	// Delete soft deletes a money flow
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// GetTotalByUserID calculates total expenses for a user
-	GetTotalByUserID(ctx context.Context, userID uuid.UUID) (float64, error)
+	// DeleteByTransferID soft deletes both money flows of a transfer
+	DeleteByTransferID(ctx context.Context, transferID uuid.UUID) error
+
+	// DeleteByUserID soft deletes all money flows of a user
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// GetCategoryTotalInPeriod calculates total expenses, in minor units, in a category and currency
+	// created in [start, end), leaving out excludeID (uuid.Nil excludes nothing)
+	GetCategoryTotalInPeriod(ctx context.Context, userID uuid.UUID, category, currency string, start, end time.Time, excludeID uuid.UUID) (int64, error)
+
+	// GetTotalsByCurrency calculates total expenses of a user per currency, largest count first
+	GetTotalsByCurrency(ctx context.Context, userID uuid.UUID) ([]*domain.CurrencyTotal, error)
+
+	// GetTotalsByCategory calculates total expenses of a user per category and currency created in [start, end)
+	GetTotalsByCategory(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error)
+
+	// GetGroupTotalsByCategory calculates total expenses in a group's shared ledger per category and currency created in [start, end)
+	GetGroupTotalsByCategory(ctx context.Context, groupID uuid.UUID, start, end time.Time) ([]*domain.CategoryTotal, error)
+
+	// GetTotalsByWallet sums the money flows of a user per wallet, including transfers
+	GetTotalsByWallet(ctx context.Context, userID uuid.UUID) ([]*domain.WalletTotal, error)
+
+	// GetTagUsage counts the money flows of a user per tag, most used first
+	GetTagUsage(ctx context.Context, userID uuid.UUID) ([]*domain.TagUsage, error)
+
+	// GetDescriptionCategories counts the categorized expenses of a user per lowercased
+	// description and category, most recently used first, up to limit
+	GetDescriptionCategories(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DescriptionCategory, error)
 
-	// GetTotalByUserIDAndCategory calculates total expenses by category
-	GetTotalByUserIDAndCategory(ctx context.Context, userID uuid.UUID, category string) (float64, error)
+	// RenameTag replaces tag from with to on every money flow of a user, dropping to
+	// where it is already present, and returns the number of money flows changed
+	RenameTag(ctx context.Context, userID uuid.UUID, from, to string) (int64, error)
 }
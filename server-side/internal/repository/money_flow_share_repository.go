@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// MoneyFlowShareRepository defines the interface for money flow share data access
+type MoneyFlowShareRepository interface {
+	// Create creates a new share
+	Create(ctx context.Context, share *domain.MoneyFlowShare) error
+
+	// FindByID finds a share by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.MoneyFlowShare, error)
+
+	// DeleteByMoneyFlowID deletes every share of a money flow, and returns the number deleted
+	DeleteByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) (int64, error)
+
+	// DeleteExpired deletes up to limit shares that expired before the given time, and
+	// returns the number deleted
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
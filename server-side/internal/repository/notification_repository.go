@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// NotificationRepository defines the interface for in-app notification data access
+type NotificationRepository interface {
+	// Create creates a new notification
+	Create(ctx context.Context, notification *domain.Notification) error
+
+	// FindByUserID retrieves a user's notifications, newest first, with pagination
+	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Notification, error)
+
+	// MarkRead marks a user's notification as read
+	MarkRead(ctx context.Context, userID, id uuid.UUID, readAt time.Time) error
+}
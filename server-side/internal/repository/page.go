@@ -0,0 +1,7 @@
+package repository
+
+// Page selects a slice of an ordered result set
+type Page struct {
+	Limit  int
+	Offset int
+}
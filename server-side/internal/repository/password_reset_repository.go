@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// PasswordResetRepository defines the interface for password reset data access
+type PasswordResetRepository interface {
+	// Create creates a new password reset
+	Create(ctx context.Context, reset *domain.PasswordReset) error
+
+	// FindByID finds a password reset by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.PasswordReset, error)
+
+	// FindByTokenHash finds a password reset by the hash of its token
+	FindByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordReset, error)
+
+	// CountSince counts the password resets of a user auth created since the given time
+	CountSince(ctx context.Context, userAuthID uuid.UUID, since time.Time) (int64, error)
+
+	// MarkSent stores the hash of the sent token
+	MarkSent(ctx context.Context, id uuid.UUID, tokenHash string, sentAt time.Time) error
+
+	// MarkUsed marks a password reset as used; it returns domain.ErrNotFound when the
+	// reset was used already
+	MarkUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+
+	// DeleteExpired deletes up to limit password resets that expired before the given
+	// time, and returns the number deleted
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// PhoneVerificationRepository defines the interface for phone verification data access
+type PhoneVerificationRepository interface {
+	// Create creates a new phone verification
+	Create(ctx context.Context, verification *domain.PhoneVerification) error
+
+	// FindLatest finds the verification last sent to a phone number for a user
+	FindLatest(ctx context.Context, userID uuid.UUID, phoneNumber string) (*domain.PhoneVerification, error)
+
+	// CountSince counts the verifications sent for a user since the given time
+	CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error)
+
+	// IncrementAttempts records a wrong code entered for a verification
+	IncrementAttempts(ctx context.Context, id uuid.UUID) error
+
+	// Delete deletes a verification once its code is used; it returns
+	// domain.ErrNotFound when the code was used already
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteExpired deletes up to limit phone verifications that expired before the
+	// given time, and returns the number deleted
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrQueryBudgetExceeded is returned by a counting DB when a strict query budget is exceeded
+var ErrQueryBudgetExceeded = errors.New("query budget exceeded")
+
+// QueryCounter counts database queries executed on behalf of a single request
+type QueryCounter struct {
+	count  atomic.Int64
+	budget int64
+	strict bool
+}
+
+// queryCounterKey is the context key for storing the query counter
+type queryCounterKey struct{}
+
+// WithQueryCounter returns a context carrying a new query counter.
+// When strict is true, queries beyond the budget fail with ErrQueryBudgetExceeded.
+func WithQueryCounter(ctx context.Context, budget int, strict bool) (context.Context, *QueryCounter) {
+	counter := &QueryCounter{
+		budget: int64(budget),
+		strict: strict,
+	}
+	return context.WithValue(ctx, queryCounterKey{}, counter), counter
+}
+
+// QueryCounterFromContext retrieves the query counter from context
+// Returns nil if the request is not being counted
+func QueryCounterFromContext(ctx context.Context) *QueryCounter {
+	counter, _ := ctx.Value(queryCounterKey{}).(*QueryCounter)
+	return counter
+}
+
+// Add records one query. It returns ErrQueryBudgetExceeded when the counter is
+// strict and the budget has been exceeded.
+func (c *QueryCounter) Add() error {
+	n := c.count.Add(1)
+	if c.strict && c.budget > 0 && n > c.budget {
+		return ErrQueryBudgetExceeded
+	}
+	return nil
+}
+
+// Count returns the number of queries recorded so far
+func (c *QueryCounter) Count() int {
+	return int(c.count.Load())
+}
+
+// Budget returns the configured query budget
+func (c *QueryCounter) Budget() int {
+	return int(c.budget)
+}
+
+// Exceeded checks if more queries than the budget have been recorded
+func (c *QueryCounter) Exceeded() bool {
+	return c.budget > 0 && c.count.Load() > c.budget
+}
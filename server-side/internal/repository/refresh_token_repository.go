@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken represents an issued refresh token record. Exchanging a refresh token
+// revokes it and issues the next one of the same session, so a session is the chain of
+// refresh tokens sharing a SessionID, and its active token was created when the session
+// was last used.
+type RefreshToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+
+	// Device the token was issued to, and when the session signed in; nil for tokens
+	// issued before they were recorded
+	UserAgent  *string
+	IPAddress  *string
+	SignedInAt *time.Time
+}
+
+// IsActive checks if the refresh token is neither revoked nor expired
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// Refresh token statuses used to filter sessions
+const (
+	RefreshTokenActive  = "active"
+	RefreshTokenRevoked = "revoked"
+	RefreshTokenExpired = "expired"
+)
+
+// RefreshTokenFilter selects the refresh tokens returned by List and Count
+type RefreshTokenFilter struct {
+	UserID uuid.UUID
+	Status string    // one of the RefreshToken statuses; empty selects every token
+	At     time.Time // reference time of Status
+}
+
+// RefreshTokenRepository defines the interface for refresh token data access
+type RefreshTokenRepository interface {
+	// Create creates a new refresh token record
+	Create(ctx context.Context, token *RefreshToken) error
+
+	// FindByHash finds a refresh token by the hash of the token string
+	FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+
+	// List finds the refresh tokens matching the filter, newest first
+	List(ctx context.Context, filter RefreshTokenFilter, page Page) ([]*RefreshToken, error)
+
+	// Count counts the refresh tokens matching the filter
+	Count(ctx context.Context, filter RefreshTokenFilter) (int64, error)
+
+	// Revoke revokes a single refresh token
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+
+	// RevokeAllByUserID revokes every outstanding refresh token of a user
+	RevokeAllByUserID(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error
+
+	// RevokeSession revokes the outstanding refresh tokens of one of a user's sessions;
+	// it returns domain.ErrNotFound when the user has no such active session
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, revokedAt time.Time) error
+
+	// RevokeOtherSessions revokes the outstanding refresh tokens of a user except those
+	// of one session, and returns the number revoked
+	RevokeOtherSessions(ctx context.Context, userID, keepSessionID uuid.UUID, revokedAt time.Time) (int64, error)
+
+	// DeleteInactive deletes up to limit refresh tokens that expired or were revoked
+	// before the given time, and returns the number deleted
+	DeleteInactive(ctx context.Context, before time.Time, limit int) (int64, error)
+}
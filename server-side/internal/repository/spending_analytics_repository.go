@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// SpendingQuery selects the expenses aggregated by GetTotalsByPeriod
+type SpendingQuery struct {
+	UserID uuid.UUID
+
+	// GroupID aggregates the shared ledger of a group instead of the user's money flows
+	GroupID *uuid.UUID
+
+	// Interval is domain.TrendDaily, domain.TrendWeekly, or domain.TrendMonthly
+	Interval string
+
+	// Periods follow the calendar of Timezone, an IANA time zone, and weeks start on WeekStart
+	Timezone  string
+	WeekStart time.Weekday
+
+	Start time.Time
+	End   time.Time // exclusive
+}
+
+// PlaceQuery selects the located expenses aggregated by GetTotalsByPlace
+type PlaceQuery struct {
+	UserID uuid.UUID
+
+	// Box narrows the expenses down to those located in it; nil keeps every located one
+	Box *domain.BoundingBox
+
+	Start time.Time
+	End   time.Time // exclusive
+}
+
+// SpendingAnalyticsRepository defines the interface for aggregating spending over time
+// and place
+type SpendingAnalyticsRepository interface {
+	// GetTotalsByPeriod sums the expenses created in [query.Start, query.End) per period
+	// and currency, oldest period first. Periods without expenses are left out.
+	GetTotalsByPeriod(ctx context.Context, query SpendingQuery) ([]*domain.PeriodTotal, error)
+
+	// GetTotalsByPlace sums the user's located expenses created in [query.Start,
+	// query.End) per point, place name, and currency, in no particular order
+	GetTotalsByPlace(ctx context.Context, query PlaceQuery) ([]*domain.PlaceTotal, error)
+}
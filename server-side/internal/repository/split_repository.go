@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// SplitRepository defines the interface for split and settlement data access
+type SplitRepository interface {
+	// ReplaceForMoneyFlow replaces the splits of a money flow
+	ReplaceForMoneyFlow(ctx context.Context, moneyFlowID uuid.UUID, splits []*domain.Split) error
+
+	// FindByMoneyFlowID finds the splits of a money flow
+	FindByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) ([]*domain.Split, error)
+
+	// FindByGroupID finds the splits of the money flows still recorded in a group,
+	// with the author of each money flow as PayerID
+	FindByGroupID(ctx context.Context, groupID uuid.UUID) ([]*domain.Split, error)
+
+	// DeleteByMoneyFlowID deletes the splits of a money flow, if any
+	DeleteByMoneyFlowID(ctx context.Context, moneyFlowID uuid.UUID) error
+
+	// CreateSettlement creates a new settlement
+	CreateSettlement(ctx context.Context, settlement *domain.Settlement) error
+
+	// FindSettlementsByGroupID finds the settlements of a group, newest first
+	FindSettlementsByGroupID(ctx context.Context, groupID uuid.UUID, limit, offset int) ([]*domain.Settlement, error)
+
+	// FindAllSettlementsByGroupID finds every settlement of a group
+	FindAllSettlementsByGroupID(ctx context.Context, groupID uuid.UUID) ([]*domain.Settlement, error)
+}
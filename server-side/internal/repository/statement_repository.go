@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatementRepository records the monthly statements emailed to users
+type StatementRepository interface {
+	// Claim records that the statement of the month starting at periodStart is being
+	// sent to the user. It reports false when the statement was already claimed, e.g. by
+	// another instance.
+	Claim(ctx context.Context, userID uuid.UUID, periodStart time.Time) (bool, error)
+
+	// ListSubscribers returns up to limit IDs, in order, of the users after afterUserID
+	// who opted in to monthly statements. Deleted and disabled users and demo users are
+	// left out. Start with uuid.Nil.
+	ListSubscribers(ctx context.Context, afterUserID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
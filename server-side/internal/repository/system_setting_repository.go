@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// SystemSettingRepository defines the interface for system setting data access
+type SystemSettingRepository interface {
+	// FindByKey finds a setting.
+	// It returns domain.ErrNotFound if the setting was never saved.
+	FindByKey(ctx context.Context, key string) (*domain.SystemSetting, error)
+
+	// Save creates or replaces a setting
+	Save(ctx context.Context, setting *domain.SystemSetting) error
+}
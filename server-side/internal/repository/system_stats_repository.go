@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// SystemStatsRepository defines the interface for counting data across every user
+type SystemStatsRepository interface {
+	// Get counts users and their data, with the New and Active counts covering the
+	// period from since until now
+	Get(ctx context.Context, since time.Time) (*domain.SystemStats, error)
+}
@@ -1,6 +1,9 @@
 package repository
 
-import "context"
+import (
+	"context"
+	"sync"
+)
 
 // TransactionManager defines the interface for managing database transactions
 // This abstraction allows the service layer to use transactions without knowing
@@ -54,3 +57,51 @@ func GetTransactionFromContext(ctx context.Context) interface{} {
 func SetTransactionInContext(ctx context.Context, tx interface{}) context.Context {
 	return context.WithValue(ctx, TxKey, tx)
 }
+
+type afterCommitKey struct{}
+
+// afterCommitHooks collects the functions to run once a transaction commits
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// WithAfterCommit returns a transaction context that collects the functions registered
+// with AfterCommit. TransactionManager implementations call it when a transaction starts
+// and RunAfterCommit once it commits.
+func WithAfterCommit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, afterCommitKey{}, &afterCommitHooks{})
+}
+
+// RunAfterCommit runs the functions registered with AfterCommit on the transaction
+// context, in the order they were registered
+func RunAfterCommit(ctx context.Context) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok {
+		return
+	}
+
+	hooks.mu.Lock()
+	fns := hooks.fns
+	hooks.fns = nil
+	hooks.mu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// AfterCommit runs fn once the transaction of ctx commits, or right away outside a
+// transaction. Functions registered in a transaction that rolls back never run.
+// It suits side effects that must not see uncommitted data, such as cache invalidation.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok || GetTransactionFromContext(ctx) == nil {
+		fn()
+		return
+	}
+
+	hooks.mu.Lock()
+	hooks.fns = append(hooks.fns, fn)
+	hooks.mu.Unlock()
+}
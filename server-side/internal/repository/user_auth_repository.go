@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,6 +15,26 @@ type UserAuth struct {
 	CredentialID      string // email for email-password auth
 	CredentialSecret  string // hashed password
 	CredentialRefresh *string
+	CreatedAt         time.Time
+	LastUsedAt        *time.Time
+	DeletedAt         *time.Time
+
+	// FailedLoginAttempts counts the failed sign-ins since the last successful one or
+	// lockout; LockedUntil is set while too many of them lock the credential
+	FailedLoginAttempts int
+	LockedUntil         *time.Time
+}
+
+// IsLocked checks if failed sign-ins lock the credential at the given time
+func (a *UserAuth) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+// UserAuthFilter selects the user auth records returned by List and Count
+type UserAuthFilter struct {
+	UserID         uuid.UUID
+	AuthProviderID *uuid.UUID // nil selects every provider
+	IncludeDeleted bool       // include removed credentials
 }
 
 // UserAuthRepository defines the interface for user auth data access
@@ -27,9 +48,39 @@ type UserAuthRepository interface {
 	// FindByUserIDAndProvider finds a user auth by user ID and provider
 	FindByUserIDAndProvider(ctx context.Context, userID, authProviderID uuid.UUID) (*UserAuth, error)
 
+	// FindByUserID finds all user auth records linked to a user
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*UserAuth, error)
+
+	// List finds the user auth records matching the filter, oldest first
+	List(ctx context.Context, filter UserAuthFilter, page Page) ([]*UserAuth, error)
+
+	// Count counts the user auth records matching the filter
+	Count(ctx context.Context, filter UserAuthFilter) (int64, error)
+
+	// UpdateLastUsed records a successful sign-in with a user auth
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error
+
 	// Update updates a user auth record
 	Update(ctx context.Context, userAuth *UserAuth) error
 
+	// RecordLoginFailure counts a failed sign-in with a user auth. The maxAttempts-th
+	// failure in a row locks it until lockedUntil, starts the count over, and returns true.
+	RecordLoginFailure(ctx context.Context, id uuid.UUID, maxAttempts int, lockedUntil time.Time) (bool, error)
+
+	// ResetLoginFailures clears the failed sign-ins and the lock of a user auth
+	ResetLoginFailures(ctx context.Context, id uuid.UUID) error
+
+	// ReplaceSecret replaces the credential secret of a user auth with newSecret if it is
+	// still oldSecret, and returns domain.ErrConflict otherwise
+	ReplaceSecret(ctx context.Context, id uuid.UUID, oldSecret, newSecret string) error
+
 	// Delete soft deletes a user auth record
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// DeleteByUserID soft deletes all user auth records of a user
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// AnonymizeByUserID replaces the credential IDs of all user auth records of a user,
+	// including deleted ones, with "erased-<record ID>" and clears their secrets
+	AnonymizeByUserID(ctx context.Context, userID uuid.UUID) error
 }
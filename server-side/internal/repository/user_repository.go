@@ -2,11 +2,20 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ingunawandra/catetin/internal/domain"
 )
 
+// UserFilter selects the users returned by Search and Count; empty fields match every user
+type UserFilter struct {
+	// Query matches part of the name, phone number, or a sign-in credential such as the email
+	Query    string
+	Role     string
+	Disabled *bool
+}
+
 // UserRepository defines the interface for user data access
 type UserRepository interface {
 	// Create creates a new user
@@ -24,6 +33,31 @@ type UserRepository interface {
 	// Delete soft deletes a user
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Anonymize replaces the name and phone number of a user, including a deleted one,
+	// and clears their image
+	Anonymize(ctx context.Context, id uuid.UUID, fullName, phoneNumber string) error
+
+	// FindTokenVersion returns the token version of a user, checked on every request
+	// authenticated with an access token
+	FindTokenVersion(ctx context.Context, id uuid.UUID) (int, error)
+
+	// IncrementTokenVersion increments the token version of a user, revoking the
+	// access tokens issued before
+	IncrementTokenVersion(ctx context.Context, id uuid.UUID) error
+
 	// List retrieves all users with pagination
 	List(ctx context.Context, limit, offset int) ([]*domain.User, error)
+
+	// Search retrieves the users matching the filter, newest first
+	Search(ctx context.Context, filter UserFilter, page Page) ([]*domain.User, error)
+
+	// Count counts the users matching the filter
+	Count(ctx context.Context, filter UserFilter) (int64, error)
+
+	// FindByAudience retrieves every user matching a broadcast audience
+	FindByAudience(ctx context.Context, audience domain.BroadcastAudience) ([]*domain.User, error)
+
+	// DeleteExpiredDemoUsers permanently deletes up to limit demo users that expired
+	// at or before now, including their data, and returns the number deleted
+	DeleteExpiredDemoUsers(ctx context.Context, now time.Time, limit int) (int64, error)
 }
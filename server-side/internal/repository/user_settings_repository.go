@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// UserSettingsRepository defines the interface for user settings data access
+type UserSettingsRepository interface {
+	// FindByUserID finds the settings of a user.
+	// It returns domain.ErrNotFound if the user never changed a preference.
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error)
+
+	// Create creates the settings of a user
+	Create(ctx context.Context, settings *domain.UserSettings) error
+
+	// Update updates the settings of a user (optimistic locking)
+	Update(ctx context.Context, settings *domain.UserSettings) error
+}
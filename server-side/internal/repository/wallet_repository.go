@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// WalletRepository defines the interface for wallet data access
+type WalletRepository interface {
+	// Create creates a new wallet. Returns domain.ErrConflict if the user already
+	// has a wallet with the name (case-insensitive).
+	Create(ctx context.Context, wallet *domain.Wallet) error
+
+	// FindByID finds a wallet by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Wallet, error)
+
+	// FindByUserID finds all wallets of a user, ordered by name
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error)
+
+	// Update updates an existing wallet (optimistic locking on version). Returns
+	// domain.ErrConflict on a version mismatch or a name already in use.
+	Update(ctx context.Context, wallet *domain.Wallet) error
+
+	// Delete deletes a wallet; its money flows are kept without a wallet
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// WebhookRepository defines the interface for webhook and webhook delivery data access
+type WebhookRepository interface {
+	// Create creates a new webhook
+	Create(ctx context.Context, webhook *domain.Webhook) error
+
+	// FindByID finds a webhook by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+
+	// FindByUserID finds all webhooks of a user, oldest first
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Webhook, error)
+
+	// Update updates an existing webhook (optimistic locking on version)
+	Update(ctx context.Context, webhook *domain.Webhook) error
+
+	// Delete deletes a webhook together with its deliveries
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateDelivery records an attempt to send an event to a webhook
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+
+	// ListDeliveries retrieves the deliveries of a webhook, newest first
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error)
+
+	// DeleteDeliveriesBefore deletes up to limit deliveries recorded before the given time
+	DeleteDeliveriesBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobEraseAccount anonymizes a user's personal data once the grace period of their
+// erasure request ended
+const JobEraseAccount = "account.erase"
+
+// errErasureNotPending aborts an erasure that was cancelled while it was carried out
+var errErasureNotPending = errors.New("account erasure is no longer pending")
+
+// erasedFullName replaces the full name of erased users
+const erasedFullName = "Deleted user"
+
+// AccountErasureConfig holds the settings of the account erasures
+type AccountErasureConfig struct {
+	// GracePeriod is how long a user can cancel an erasure before it is carried out
+	GracePeriod time.Duration
+}
+
+// AccountErasureService erases the personal data of users who ask for it. Erasures wait
+// for a grace period, then anonymize the user and their sign-in methods. Money flows,
+// budgets, wallets, and goals are kept, so aggregate statistics stay correct, but no
+// longer lead back to a person.
+type AccountErasureService struct {
+	erasureRepo      repository.AccountErasureRepository
+	userRepo         repository.UserRepository
+	userAuthRepo     repository.UserAuthRepository
+	settingsRepo     repository.UserSettingsRepository
+	deviceRepo       repository.DeviceRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	apiKeyRepo       repository.APIKeyRepository
+	files            *DataResidencyService
+	auditor          *Auditor
+	notifier         *Notifier
+	txManager        repository.TransactionManager
+	jobs             JobEnqueuer
+	config           AccountErasureConfig
+}
+
+// NewAccountErasureService creates a new account erasure service
+func NewAccountErasureService(
+	erasureRepo repository.AccountErasureRepository,
+	userRepo repository.UserRepository,
+	userAuthRepo repository.UserAuthRepository,
+	settingsRepo repository.UserSettingsRepository,
+	deviceRepo repository.DeviceRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	files *DataResidencyService,
+	auditor *Auditor,
+	notifier *Notifier,
+	txManager repository.TransactionManager,
+	jobs JobEnqueuer,
+	config AccountErasureConfig,
+) *AccountErasureService {
+	if config.GracePeriod < 0 {
+		config.GracePeriod = 0
+	}
+
+	return &AccountErasureService{
+		erasureRepo:      erasureRepo,
+		userRepo:         userRepo,
+		userAuthRepo:     userAuthRepo,
+		settingsRepo:     settingsRepo,
+		deviceRepo:       deviceRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		apiKeyRepo:       apiKeyRepo,
+		files:            files,
+		auditor:          auditor,
+		notifier:         notifier,
+		txManager:        txManager,
+		jobs:             jobs,
+		config:           config,
+	}
+}
+
+// accountErasurePayload is the payload of JobEraseAccount
+type accountErasurePayload struct {
+	ErasureID uuid.UUID `json:"erasure_id"`
+}
+
+// RequestErasure schedules the erasure of the user's personal data after the grace
+// period and tells them how to cancel it
+func (s *AccountErasureService) RequestErasure(ctx context.Context, userID uuid.UUID) (*domain.AccountErasure, error) {
+	ctx, span := tracing.Start(ctx, "AccountErasureService.RequestErasure")
+	defer span.End()
+
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user", 500)
+	}
+
+	erasure := domain.NewAccountErasure(userID, s.config.GracePeriod)
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.erasureRepo.Create(txCtx, erasure); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrConflict.WithDetails(map[string]interface{}{
+					"erasure": "An erasure of the account is already pending",
+				})
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create account erasure", 500)
+		}
+
+		if err := s.auditor.Record(txCtx, userID, nil, accountErasureAudit(erasure)); err != nil {
+			return err
+		}
+
+		// Enqueued in the transaction, so the job only runs once the erasure is saved
+		_, err := s.jobs.Enqueue(txCtx, JobEraseAccount, accountErasurePayload{ErasureID: erasure.ID},
+			worker.WithRunAt(erasure.EraseAt))
+		if err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to schedule account erasure", 500)
+		}
+
+		return s.notifier.Notify(txCtx, userID, Notification{
+			Type:  NotificationAccountErasure,
+			Title: "Your Catetin account will be erased",
+			Body: fmt.Sprintf("Your personal data will be erased on %s UTC. Until then you can cancel the erasure from your account settings.",
+				erasure.EraseAt.UTC().Format("2006-01-02 15:04")),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return erasure, nil
+}
+
+// GetErasure returns the pending erasure of the user
+func (s *AccountErasureService) GetErasure(ctx context.Context, userID uuid.UUID) (*domain.AccountErasure, error) {
+	erasure, err := s.erasureRepo.FindPendingByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get account erasure", 500)
+	}
+	return erasure, nil
+}
+
+// CancelErasure cancels the pending erasure of the user. The scheduled job finds it
+// cancelled and does nothing.
+func (s *AccountErasureService) CancelErasure(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "AccountErasureService.CancelErasure")
+	defer span.End()
+
+	erasure, err := s.GetErasure(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now()
+		if err := s.erasureRepo.Finish(txCtx, erasure.ID, domain.ErasureCancelled, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				// Carried out or cancelled since it was read
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to cancel account erasure", 500)
+		}
+
+		before := accountErasureAudit(erasure)
+		cancelled := *erasure
+		cancelled.Status = domain.ErasureCancelled
+		cancelled.CancelledAt = &now
+		return s.auditor.Record(txCtx, userID, before, accountErasureAudit(&cancelled))
+	})
+}
+
+// HandleEraseJob processes a JobEraseAccount
+func (s *AccountErasureService) HandleEraseJob(ctx context.Context, job *worker.Job) error {
+	var payload accountErasurePayload
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+	return s.Erase(ctx, payload.ErasureID)
+}
+
+// Erase carries out a pending erasure: it deletes the user's stored files, anonymizes
+// the user and their sign-in methods, signs them out everywhere, and deletes the
+// account. Erasures that were cancelled or already carried out are skipped.
+func (s *AccountErasureService) Erase(ctx context.Context, erasureID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountErasureService.Erase")
+	defer func() { tracing.End(span, err) }()
+
+	erasure, err := s.erasureRepo.FindByID(ctx, erasureID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return worker.Permanent(err)
+		}
+		return err
+	}
+	if !erasure.IsPending() {
+		return nil
+	}
+	if time.Now().Before(erasure.EraseAt) {
+		// The job ran early; retrying reschedules it
+		return fmt.Errorf("account erasure %s is due at %s", erasure.ID, erasure.EraseAt)
+	}
+
+	userID := erasure.UserID
+
+	// Files cannot be deleted in the transaction. Deleting them again on a retry is
+	// harmless.
+	deleted, err := s.files.DeleteUserFiles(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	devices, err := s.deviceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now()
+
+		if err := s.userRepo.Anonymize(txCtx, userID, erasedFullName, "erased-"+userID.String()); err != nil {
+			if !errors.Is(err, domain.ErrNotFound) {
+				return err
+			}
+		}
+		if err := s.userAuthRepo.AnonymizeByUserID(txCtx, userID); err != nil {
+			return err
+		}
+
+		if err := s.clearSettings(txCtx, userID, now); err != nil {
+			return err
+		}
+		for _, device := range devices {
+			if err := s.deviceRepo.Delete(txCtx, device.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return err
+			}
+		}
+
+		if err := s.refreshTokenRepo.RevokeAllByUserID(txCtx, userID, now); err != nil {
+			return err
+		}
+		if err := s.apiKeyRepo.RevokeAllByUserID(txCtx, userID, now); err != nil {
+			return err
+		}
+		if err := s.userAuthRepo.DeleteByUserID(txCtx, userID); err != nil {
+			return err
+		}
+		// The user may have deleted their account during the grace period
+		if err := s.userRepo.Delete(txCtx, userID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+
+		if err := s.erasureRepo.Finish(txCtx, erasure.ID, domain.ErasureCompleted, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return errErasureNotPending
+			}
+			return err
+		}
+
+		before := accountErasureAudit(erasure)
+		completed := *erasure
+		completed.Status = domain.ErasureCompleted
+		completed.CompletedAt = &now
+		return s.auditor.Record(txCtx, userID, before, accountErasureAudit(&completed))
+	})
+	if errors.Is(err, errErasureNotPending) {
+		// Cancelled while the data was being erased; the files are gone already
+		logger.FromContext(ctx).Warn("account erasure cancelled while being carried out", "erasure_id", erasure.ID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("account erased", "erasure_id", erasure.ID, "user_id", userID, "deleted_files", deleted)
+	return nil
+}
+
+// clearSettings removes the personal data of the user's settings, the chat with the
+// Telegram bot, and keeps their preferences
+func (s *AccountErasureService) clearSettings(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if settings.TelegramChatID == "" {
+		return nil
+	}
+
+	settings.TelegramChatID = ""
+	settings.Version++
+	settings.UpdatedAt = now
+	return s.settingsRepo.Update(ctx, settings)
+}
@@ -0,0 +1,643 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/storage"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobExportAccount writes the export of all of a user's data and sends them its link
+const JobExportAccount = "account.export"
+
+// JobDeleteAccountExport deletes an account export once its link expired
+const JobDeleteAccountExport = "account.delete_export"
+
+// accountExportsDir is the directory of the account exports below UserFilesPrefix
+const accountExportsDir = "exports/"
+
+// AccountExportConfig holds the settings of the account exports
+type AccountExportConfig struct {
+	// URL is the download endpoint the links point to, e.g.
+	// https://api.catetin.id/api/v1/account-exports
+	URL string
+
+	// TTL is how long a link is valid; the export is deleted when it expires
+	TTL time.Duration
+
+	// SigningKey signs the links
+	SigningKey string
+
+	// BatchSize is the number of money flows loaded per query
+	BatchSize int
+}
+
+// AccountExport is a written account export
+type AccountExport struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	URL       string // signed download link
+	ExpiresAt time.Time
+}
+
+// AccountExportService exports all of a user's data as a ZIP of JSON and CSV files,
+// delivered as a signed download link that expires
+type AccountExportService struct {
+	userRepo         repository.UserRepository
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+	settingsRepo     repository.UserSettingsRepository
+	moneyFlowRepo    repository.MoneyFlowRepository
+	budgetRepo       repository.BudgetRepository
+	walletRepo       repository.WalletRepository
+	files            *DataResidencyService
+	notifier         *Notifier
+	jobs             JobEnqueuer
+	config           AccountExportConfig
+}
+
+// NewAccountExportService creates a new account export service
+func NewAccountExportService(
+	userRepo repository.UserRepository,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	settingsRepo repository.UserSettingsRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+	budgetRepo repository.BudgetRepository,
+	walletRepo repository.WalletRepository,
+	files *DataResidencyService,
+	notifier *Notifier,
+	jobs JobEnqueuer,
+	config AccountExportConfig,
+) *AccountExportService {
+	if config.TTL <= 0 {
+		config.TTL = 72 * time.Hour
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+
+	return &AccountExportService{
+		userRepo:         userRepo,
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+		settingsRepo:     settingsRepo,
+		moneyFlowRepo:    moneyFlowRepo,
+		budgetRepo:       budgetRepo,
+		walletRepo:       walletRepo,
+		files:            files,
+		notifier:         notifier,
+		jobs:             jobs,
+		config:           config,
+	}
+}
+
+// RequestExport queues the export of the user's data and returns its ID. The user is
+// sent the download link once it is written.
+func (s *AccountExportService) RequestExport(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	if _, err := s.findUser(ctx, userID); err != nil {
+		return uuid.Nil, err
+	}
+
+	exportID := uuid.New()
+	_, err := s.jobs.Enqueue(ctx, JobExportAccount, accountExportPayload{UserID: userID, ExportID: exportID})
+	if err != nil {
+		return uuid.Nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to schedule export", 500)
+	}
+	return exportID, nil
+}
+
+// accountExportPayload is the payload of JobExportAccount and JobDeleteAccountExport
+type accountExportPayload struct {
+	UserID   uuid.UUID `json:"user_id"`
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+// HandleExportJob processes a JobExportAccount
+func (s *AccountExportService) HandleExportJob(ctx context.Context, job *worker.Job) error {
+	var payload accountExportPayload
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	_, err := s.Export(ctx, payload.UserID, payload.ExportID)
+	if errors.Is(err, appErrors.ErrUserNotFound) {
+		return worker.Permanent(err)
+	}
+	return err
+}
+
+// HandleDeleteJob processes a JobDeleteAccountExport
+func (s *AccountExportService) HandleDeleteJob(ctx context.Context, job *worker.Job) error {
+	var payload accountExportPayload
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+	return s.files.DeleteUserFile(ctx, accountExportKey(payload.UserID, payload.ExportID))
+}
+
+// Export writes the ZIP of the user's data to their storage region, schedules its
+// deletion when the link expires, and sends them the link. Retries rewrite the file.
+func (s *AccountExportService) Export(ctx context.Context, userID, exportID uuid.UUID) (export *AccountExport, err error) {
+	ctx, span := tracing.Start(ctx, "AccountExportService.Export")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	store, err := s.files.UserStorage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stream the file into storage instead of holding it in memory
+	key := accountExportKey(userID, exportID)
+	reader, writer := io.Pipe()
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		writer.CloseWithError(s.writeArchive(ctx, writer, store, user)) // closes normally when nil
+	}()
+
+	if err := store.Put(ctx, key, reader); err != nil {
+		reader.CloseWithError(err)
+		<-written
+		return nil, err
+	}
+	<-written
+
+	expiresAt := time.Now().Add(s.config.TTL).Truncate(time.Second)
+	_, err = s.jobs.Enqueue(ctx, JobDeleteAccountExport, accountExportPayload{UserID: userID, ExportID: exportID},
+		worker.WithRunAt(expiresAt))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to schedule export deletion", 500)
+	}
+
+	export = &AccountExport{
+		ID:        exportID,
+		UserID:    userID,
+		URL:       s.downloadURL(userID, exportID, expiresAt),
+		ExpiresAt: expiresAt,
+	}
+	err = s.notifier.Notify(ctx, userID, Notification{
+		Type:  NotificationAccountExport,
+		Title: "Your data export is ready",
+		Body: fmt.Sprintf("Download all of your Catetin data before %s UTC: %s",
+			expiresAt.UTC().Format("2006-01-02 15:04"), export.URL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("account exported", "user_id", userID, "key", key)
+	return export, nil
+}
+
+// Open opens the export a download link points to, after checking its signature and
+// expiry. Invalid and expired links, and deleted exports, are ErrInvalidDownloadLink.
+func (s *AccountExportService) Open(ctx context.Context, userID, exportID uuid.UUID, expires int64, signature string) (io.ReadCloser, error) {
+	expected := s.sign(userID, exportID, expires)
+	if !hmac.Equal([]byte(signature), []byte(expected)) || !time.Now().Before(time.Unix(expires, 0)) {
+		return nil, appErrors.ErrInvalidDownloadLink
+	}
+
+	store, err := s.files.UserStorage(ctx, userID)
+	if errors.Is(err, appErrors.ErrUserNotFound) {
+		return nil, appErrors.ErrInvalidDownloadLink
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := store.Get(ctx, accountExportKey(userID, exportID))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, appErrors.ErrInvalidDownloadLink
+	}
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to open export", 500)
+	}
+	return file, nil
+}
+
+// AccountExportFilename returns the name users download an export as
+func AccountExportFilename(exportID uuid.UUID) string {
+	return "catetin-export-" + exportID.String() + ".zip"
+}
+
+// downloadURL returns the signed link to an export, valid until expiresAt
+func (s *AccountExportService) downloadURL(userID, exportID uuid.UUID, expiresAt time.Time) string {
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expiresAt.Unix(), 10)},
+		"signature": {s.sign(userID, exportID, expiresAt.Unix())},
+	}
+	return fmt.Sprintf("%s/%s/%s?%s", strings.TrimSuffix(s.config.URL, "/"), userID, exportID, query.Encode())
+}
+
+// sign returns the hex HMAC-SHA256 of the link to an export, keyed with the signing key
+func (s *AccountExportService) sign(userID, exportID uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.config.SigningKey))
+	fmt.Fprintf(mac, "%s.%s.%d", userID, exportID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// accountExportKey returns the storage key of an export
+func accountExportKey(userID, exportID uuid.UUID) string {
+	return UserFilesPrefix(userID) + accountExportsDir + exportID.String() + ".zip"
+}
+
+func (s *AccountExportService) findUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user", 500)
+	}
+	return user, nil
+}
+
+// The types below are the records of the export's JSON files. docs/AUTH_API.md
+// describes the files; keep it up to date when adding one.
+type exportedProfile struct {
+	ID          uuid.UUID              `json:"id"`
+	FullName    string                 `json:"full_name"`
+	PhoneNumber string                 `json:"phone_number"`
+	Image       *string                `json:"image"`
+	Role        string                 `json:"role"`
+	DataRegion  string                 `json:"data_region"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+	Settings    exportedSettings       `json:"settings"`
+	SignIns     []exportedSignInMethod `json:"sign_in_methods"`
+}
+
+type exportedSettings struct {
+	DefaultCurrency      string   `json:"default_currency"`
+	SingleCurrencyMode   bool     `json:"single_currency_mode"`
+	Locale               string   `json:"locale"`
+	Timezone             string   `json:"timezone"`
+	WeekStart            string   `json:"week_start"`
+	NotifyWhatsApp       bool     `json:"notify_whatsapp"`
+	NotifyEmail          bool     `json:"notify_email"`
+	NotifyTelegram       bool     `json:"notify_telegram"`
+	NotifyPush           bool     `json:"notify_push"`
+	NotificationChannels []string `json:"notification_channels"`
+	TelegramLinked       bool     `json:"telegram_linked"`
+	DigestFrequency      string   `json:"digest_frequency"`
+	DuplicateCheck       string   `json:"duplicate_check"`
+	MonthlyStatement     bool     `json:"monthly_statement"`
+	AnalyticsOptOut      bool     `json:"analytics_opt_out"`
+}
+
+// exportedSignInMethod is a credential of the user without its secrets
+type exportedSignInMethod struct {
+	Provider   string     `json:"provider"`
+	Identifier string     `json:"identifier"` // email, phone number, or provider account ID
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+type exportedMoneyFlow struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	WalletID    *uuid.UUID `json:"wallet_id"`
+	GroupID     *uuid.UUID `json:"group_id"`
+	TransferID  *uuid.UUID `json:"transfer_id"`
+	Category    *string    `json:"category"`
+	Amount      float64    `json:"amount"`
+	Currency    string     `json:"currency"`
+	Description *string    `json:"description"`
+	Tags        []string   `json:"tags"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type exportedBudget struct {
+	ID        uuid.UUID `json:"id"`
+	Category  string    `json:"category"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Hard      bool      `json:"hard"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type exportedWallet struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Currency       string    `json:"currency"`
+	OpeningBalance float64   `json:"opening_balance"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// writeArchive writes the ZIP of the user's data: their profile with settings and sign-in
+// methods, their wallets, money flows, and budgets as JSON and CSV, and their files,
+// such as attachments, below attachments/
+func (s *AccountExportService) writeArchive(ctx context.Context, w io.Writer, store storage.Storage, user *domain.User) error {
+	archive := zip.NewWriter(w)
+
+	profile, err := s.profile(ctx, user)
+	if err != nil {
+		return err
+	}
+	if err := writeZipJSON(archive, "profile.json", profile); err != nil {
+		return err
+	}
+
+	wallets, err := s.walletRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	exportedWallets := make([]exportedWallet, len(wallets))
+	walletRows := make([][]string, len(wallets))
+	for i, wallet := range wallets {
+		opening := domain.Money{Minor: wallet.OpeningBalance, Currency: wallet.Currency}
+		exportedWallets[i] = exportedWallet{
+			ID: wallet.ID, Name: wallet.Name, Type: wallet.Type, Currency: wallet.Currency,
+			OpeningBalance: opening.Float64(), CreatedAt: wallet.CreatedAt, UpdatedAt: wallet.UpdatedAt,
+		}
+		walletRows[i] = []string{wallet.ID.String(), wallet.Name, wallet.Type, wallet.Currency,
+			opening.String(), formatExportTime(wallet.CreatedAt)}
+	}
+	if err := writeZipJSON(archive, "wallets.json", exportedWallets); err != nil {
+		return err
+	}
+	if err := writeZipCSV(archive, "wallets.csv",
+		[]string{"ID", "Name", "Type", "Currency", "Opening Balance", "Created"}, walletRows); err != nil {
+		return err
+	}
+
+	budgets, err := s.budgetRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	exportedBudgets := make([]exportedBudget, len(budgets))
+	budgetRows := make([][]string, len(budgets))
+	for i, budget := range budgets {
+		amount := domain.Money{Minor: budget.Amount, Currency: budget.Currency}
+		exportedBudgets[i] = exportedBudget{
+			ID: budget.ID, Category: budget.Category, Amount: amount.Float64(), Currency: budget.Currency,
+			Hard: budget.Hard, CreatedAt: budget.CreatedAt, UpdatedAt: budget.UpdatedAt,
+		}
+		budgetRows[i] = []string{budget.ID.String(), budget.Category, amount.String(), budget.Currency,
+			strconv.FormatBool(budget.Hard), formatExportTime(budget.CreatedAt)}
+	}
+	if err := writeZipJSON(archive, "budgets.json", exportedBudgets); err != nil {
+		return err
+	}
+	if err := writeZipCSV(archive, "budgets.csv",
+		[]string{"ID", "Category", "Amount", "Currency", "Hard", "Created"}, budgetRows); err != nil {
+		return err
+	}
+
+	if err := s.writeMoneyFlows(ctx, archive, user.ID); err != nil {
+		return err
+	}
+	if err := s.writeAttachments(ctx, archive, store, user.ID); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// profile returns the user's profile with their settings and sign-in methods
+func (s *AccountExportService) profile(ctx context.Context, user *domain.User) (*exportedProfile, error) {
+	// Users who never changed a preference have no settings stored
+	settings, err := s.settingsRepo.FindByUserID(ctx, user.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		settings, err = domain.DefaultUserSettings(user.ID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	auths, err := s.userAuthRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	signIns := make([]exportedSignInMethod, len(auths))
+	for i, auth := range auths {
+		provider := auth.AuthProviderID.String()
+		if found, err := s.authProviderRepo.FindByID(ctx, auth.AuthProviderID); err == nil {
+			provider = found.DisplayName
+		}
+		signIns[i] = exportedSignInMethod{
+			Provider:   provider,
+			Identifier: auth.CredentialID,
+			CreatedAt:  auth.CreatedAt,
+			LastUsedAt: auth.LastUsedAt,
+		}
+	}
+
+	return &exportedProfile{
+		ID:          user.ID,
+		FullName:    user.FullName,
+		PhoneNumber: user.PhoneNumber,
+		Image:       user.Image,
+		Role:        user.Role,
+		DataRegion:  user.DataRegion,
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		Settings: exportedSettings{
+			DefaultCurrency:      settings.DefaultCurrency,
+			SingleCurrencyMode:   settings.SingleCurrencyMode,
+			Locale:               settings.Locale,
+			Timezone:             settings.Timezone,
+			WeekStart:            settings.WeekStart.String(),
+			NotifyWhatsApp:       settings.NotifyWhatsApp,
+			NotifyEmail:          settings.NotifyEmail,
+			NotifyTelegram:       settings.NotifyTelegram,
+			NotifyPush:           settings.NotifyPush,
+			NotificationChannels: settings.NotificationChannels,
+			TelegramLinked:       settings.TelegramChatID != "",
+			DigestFrequency:      settings.DigestFrequency,
+			DuplicateCheck:       settings.DuplicateCheck,
+			MonthlyStatement:     settings.MonthlyStatement,
+			AnalyticsOptOut:      settings.AnalyticsOptOut,
+		},
+		SignIns: signIns,
+	}, nil
+}
+
+// writeMoneyFlows writes money_flows.json and money_flows.csv, newest first. The money
+// flows are read in batches twice, once per file, instead of being held in memory.
+func (s *AccountExportService) writeMoneyFlows(ctx context.Context, archive *zip.Writer, userID uuid.UUID) error {
+	file, err := archive.Create("money_flows.json")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(file, "["); err != nil {
+		return err
+	}
+	first := true
+	err = s.eachMoneyFlow(ctx, userID, func(moneyFlow *domain.MoneyFlow) error {
+		encoded, err := json.Marshal(exportedMoneyFlow{
+			ID: moneyFlow.ID, Kind: moneyFlow.Kind, WalletID: moneyFlow.WalletID, GroupID: moneyFlow.GroupID,
+			TransferID: moneyFlow.TransferID, Category: moneyFlow.Category, Amount: moneyFlow.Money().Float64(),
+			Currency: moneyFlow.Currency, Description: moneyFlow.Description, Tags: moneyFlow.Tags,
+			CreatedAt: moneyFlow.CreatedAt, UpdatedAt: moneyFlow.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(file, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = file.Write(encoded)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(file, "]"); err != nil {
+		return err
+	}
+
+	file, err = archive.Create("money_flows.csv")
+	if err != nil {
+		return err
+	}
+	out := csv.NewWriter(file)
+	if err := out.Write([]string{"ID", "Date", "Kind", "Category", "Description", "Amount", "Currency", "Tags", "Wallet ID", "Group ID"}); err != nil {
+		return err
+	}
+	err = s.eachMoneyFlow(ctx, userID, func(moneyFlow *domain.MoneyFlow) error {
+		return out.Write([]string{
+			moneyFlow.ID.String(),
+			formatExportTime(moneyFlow.CreatedAt),
+			moneyFlow.Kind,
+			valueOrEmpty(moneyFlow.Category),
+			valueOrEmpty(moneyFlow.Description),
+			moneyFlow.Money().String(),
+			moneyFlow.Currency,
+			strings.Join(moneyFlow.Tags, ","),
+			idOrEmpty(moneyFlow.WalletID),
+			idOrEmpty(moneyFlow.GroupID),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// eachMoneyFlow calls fn with every money flow of the user, newest first
+func (s *AccountExportService) eachMoneyFlow(ctx context.Context, userID uuid.UUID, fn func(*domain.MoneyFlow) error) error {
+	for offset := 0; ; offset += s.config.BatchSize {
+		moneyFlows, err := s.moneyFlowRepo.FindByUserID(ctx, userID, s.config.BatchSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, moneyFlow := range moneyFlows {
+			if err := fn(moneyFlow); err != nil {
+				return err
+			}
+		}
+		if len(moneyFlows) < s.config.BatchSize {
+			return nil
+		}
+	}
+}
+
+// writeAttachments copies the user's stored files, except for their exports, below
+// attachments/
+func (s *AccountExportService) writeAttachments(ctx context.Context, archive *zip.Writer, store storage.Storage, userID uuid.UUID) error {
+	prefix := UserFilesPrefix(userID)
+	keys, err := store.List(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, prefix)
+		if strings.HasPrefix(name, accountExportsDir) {
+			continue
+		}
+
+		if err := copyToZip(ctx, archive, store, key, "attachments/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyToZip copies a stored file into the archive; files deleted meanwhile are skipped
+func copyToZip(ctx context.Context, archive *zip.Writer, store storage.Storage, key, name string) error {
+	content, err := store.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, content)
+	return err
+}
+
+func writeZipJSON(archive *zip.Writer, name string, v interface{}) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeZipCSV(archive *zip.Writer, name string, header []string, rows [][]string) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	out := csv.NewWriter(file)
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	if err := out.WriteAll(rows); err != nil {
+		return err
+	}
+	return out.Error()
+}
+
+func formatExportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func idOrEmpty(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
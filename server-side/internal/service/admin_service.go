@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// AdminService handles the user management and statistics of the admin endpoints
+type AdminService struct {
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	statsRepo        repository.SystemStatsRepository
+	auditor          *Auditor
+	txManager        repository.TransactionManager
+}
+
+// NewAdminService creates a new admin service
+func NewAdminService(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	statsRepo repository.SystemStatsRepository,
+	auditor *Auditor,
+	txManager repository.TransactionManager,
+) *AdminService {
+	return &AdminService{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		statsRepo:        statsRepo,
+		auditor:          auditor,
+		txManager:        txManager,
+	}
+}
+
+// ListUsers returns the users matching the filter, newest first, and how many match
+func (s *AdminService) ListUsers(ctx context.Context, filter repository.UserFilter, page repository.Page) ([]*domain.User, int64, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.ListUsers")
+	defer span.End()
+
+	// Searching every user is the heaviest admin read; a lagging replica is fine for it
+	ctx = repository.PreferReplica(ctx)
+
+	users, err := s.userRepo.Search(ctx, filter, page)
+	if err != nil {
+		return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list users", 500)
+	}
+
+	total, err := s.userRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count users", 500)
+	}
+
+	return users, total, nil
+}
+
+// GetUser returns a user
+func (s *AdminService) GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.GetUser")
+	defer span.End()
+
+	return s.findUser(ctx, userID)
+}
+
+// DisableUser disables a user's account and revokes their sessions, including the access
+// tokens issued so far. API keys stop working while the account is disabled. Admins
+// cannot disable their own account.
+func (s *AdminService) DisableUser(ctx context.Context, adminID, userID uuid.UUID) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.DisableUser")
+	defer span.End()
+
+	if adminID == userID {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"id": "you cannot disable your own account",
+		})
+	}
+
+	return s.updateUser(ctx, adminID, userID, func(user *domain.User) bool {
+		if user.IsDisabled() {
+			return false
+		}
+		user.Disable(time.Now())
+		return true
+	})
+}
+
+// EnableUser enables a disabled account again
+func (s *AdminService) EnableUser(ctx context.Context, adminID, userID uuid.UUID) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.EnableUser")
+	defer span.End()
+
+	return s.updateUser(ctx, adminID, userID, func(user *domain.User) bool {
+		if !user.IsDisabled() {
+			return false
+		}
+		user.Enable()
+		return true
+	})
+}
+
+// SetRole changes the role of a user. Admins cannot change their own role, so an
+// admin cannot lock everyone out by mistake.
+func (s *AdminService) SetRole(ctx context.Context, adminID, userID uuid.UUID, role string) (*domain.User, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.SetRole")
+	defer span.End()
+
+	if !domain.IsValidRole(role) {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"role": "unknown role",
+		})
+	}
+	if adminID == userID {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"id": "you cannot change your own role",
+		})
+	}
+
+	return s.updateUser(ctx, adminID, userID, func(user *domain.User) bool {
+		if user.Role == role {
+			return false
+		}
+		user.Role = role
+		return true
+	})
+}
+
+// Stats counts users and their data, with the new and active counts covering the last days
+func (s *AdminService) Stats(ctx context.Context, days int) (*domain.SystemStats, error) {
+	ctx, span := tracing.Start(ctx, "AdminService.Stats")
+	defer span.End()
+
+	ctx = repository.PreferReplica(ctx)
+
+	stats, err := s.statsRepo.Get(ctx, UsagePeriodStart(days))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count system stats", 500)
+	}
+	return stats, nil
+}
+
+// updateUser applies change to a user and saves it with an audit log entry, unless
+// change reports that there is nothing to change. Disabled users are signed out.
+func (s *AdminService) updateUser(ctx context.Context, adminID, userID uuid.UUID, change func(user *domain.User) bool) (*domain.User, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := userAudit(user)
+	if !change(user) {
+		return user, nil
+	}
+	user.IncrementVersion()
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrVersionConflict
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update user", 500)
+		}
+
+		if user.IsDisabled() {
+			if err := s.refreshTokenRepo.RevokeAllByUserID(txCtx, user.ID, time.Now()); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke refresh tokens", 500)
+			}
+			if err := s.userRepo.IncrementTokenVersion(txCtx, user.ID); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke access tokens", 500)
+			}
+		}
+
+		return s.auditor.Record(txCtx, adminID, before, userAudit(user))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *AdminService) findUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+	return user, nil
+}
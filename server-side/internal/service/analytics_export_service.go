@@ -0,0 +1,310 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/parquet"
+	"github.com/ingunawandra/catetin/internal/infrastructure/storage"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// JobExportMoneyFlows exports the money flows of one month to Parquet
+const JobExportMoneyFlows = "export.money_flows"
+
+// ExportMonthLayout is the format of export months, e.g. "2026-09"
+const ExportMonthLayout = "2006-01"
+
+// moneyFlowDataset is the storage prefix of the money flow export
+const moneyFlowDataset = "money_flows"
+
+// moneyFlowExportColumns is the schema of the money flow export. Columns may only be
+// appended, as optional columns: readers merge the partitions by column name, so
+// partitions written before a column existed read it as null. Never rename, remove,
+// reorder, or retype a column; add a new one instead.
+var moneyFlowExportColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "user_id", Type: parquet.String},
+	{Name: "category", Type: parquet.String, Optional: true},
+	{Name: "amount", Type: parquet.Double},
+	{Name: "currency", Type: parquet.String},
+	{Name: "tags", Type: parquet.String}, // JSON array
+	{Name: "version", Type: parquet.Int64},
+	{Name: "created_at", Type: parquet.Timestamp},
+	{Name: "updated_at", Type: parquet.Timestamp},
+	{Name: "deleted_at", Type: parquet.Timestamp, Optional: true},
+	{Name: "amount_minor", Type: parquet.Int64, Optional: true}, // exact amount in minor units of currency
+}
+
+// moneyFlowExportRow returns the values of a money flow in moneyFlowExportColumns order
+func moneyFlowExportRow(moneyFlow *domain.MoneyFlow) ([]interface{}, error) {
+	tags, err := json.Marshal(moneyFlow.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{
+		moneyFlow.ID.String(),
+		moneyFlow.UserID.String(),
+		moneyFlow.Category,
+		moneyFlow.Money().Float64(),
+		moneyFlow.Currency,
+		string(tags),
+		moneyFlow.Version,
+		moneyFlow.CreatedAt,
+		moneyFlow.UpdatedAt,
+		moneyFlow.DeletedAt,
+		moneyFlow.Amount,
+	}, nil
+}
+
+// AnalyticsExportConfig holds the settings of the analytics exports
+type AnalyticsExportConfig struct {
+	// Enabled turns the scheduled exports on; months can always be exported on request
+	Enabled bool
+
+	// Interval is how often the current and the previous month are exported again
+	Interval time.Duration
+
+	// BatchSize is the number of money flows loaded per query
+	BatchSize int
+}
+
+// AnalyticsExportResult describes a written export file
+type AnalyticsExportResult struct {
+	Key  string
+	Rows int
+}
+
+// exportSchema is the manifest stored next to a dataset's partitions
+type exportSchema struct {
+	Columns []exportSchemaColumn `json:"columns"`
+}
+
+type exportSchemaColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Optional bool   `json:"optional"`
+}
+
+// AnalyticsExportService writes money flows to Parquet files partitioned by month,
+// so analytics can run on the files instead of the production database
+type AnalyticsExportService struct {
+	moneyFlowRepo repository.MoneyFlowRepository
+	storage       storage.Storage
+	jobs          JobEnqueuer
+	config        AnalyticsExportConfig
+}
+
+// NewAnalyticsExportService creates a new analytics export service
+func NewAnalyticsExportService(
+	moneyFlowRepo repository.MoneyFlowRepository,
+	storage storage.Storage,
+	jobs JobEnqueuer,
+	config AnalyticsExportConfig,
+) *AnalyticsExportService {
+	if config.Interval <= 0 {
+		config.Interval = 24 * time.Hour
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+
+	return &AnalyticsExportService{
+		moneyFlowRepo: moneyFlowRepo,
+		storage:       storage,
+		jobs:          jobs,
+		config:        config,
+	}
+}
+
+// ScheduleMoneyFlowExport queues the export of a month, e.g. to backfill history
+func (s *AnalyticsExportService) ScheduleMoneyFlowExport(ctx context.Context, month time.Time) (*worker.Job, error) {
+	job, err := s.jobs.Enqueue(ctx, JobExportMoneyFlows, map[string]string{
+		"month": month.UTC().Format(ExportMonthLayout),
+	})
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to schedule export", 500)
+	}
+	return job, nil
+}
+
+// HandleExportJob processes a JobExportMoneyFlows
+func (s *AnalyticsExportService) HandleExportJob(ctx context.Context, job *worker.Job) error {
+	var payload struct {
+		Month string `json:"month"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+	month, err := time.Parse(ExportMonthLayout, payload.Month)
+	if err != nil {
+		return worker.Permanent(err)
+	}
+
+	_, err = s.ExportMoneyFlows(ctx, month)
+	return err
+}
+
+// ExportMoneyFlows writes every money flow created in the month, including deleted ones,
+// to money_flows/month=YYYY-MM/part-0.parquet, replacing the previous export of the month
+func (s *AnalyticsExportService) ExportMoneyFlows(ctx context.Context, month time.Time) (result *AnalyticsExportResult, err error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	ctx, span := tracing.Start(ctx, "AnalyticsExportService.ExportMoneyFlows",
+		attribute.String("export.month", start.Format(ExportMonthLayout)))
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.updateSchema(ctx, moneyFlowDataset, moneyFlowExportColumns); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s/month=%s/part-0.parquet", moneyFlowDataset, start.Format(ExportMonthLayout))
+
+	// Stream the file into storage instead of holding it in memory
+	reader, writer := io.Pipe()
+	written := make(chan int, 1)
+	go func() {
+		rows, err := s.writeMoneyFlows(ctx, writer, start, end)
+		writer.CloseWithError(err) // closes normally when err is nil
+		written <- rows
+	}()
+
+	if err := s.storage.Put(ctx, key, reader); err != nil {
+		reader.CloseWithError(err)
+		<-written
+		return nil, err
+	}
+	rows := <-written
+
+	logger.FromContext(ctx).Info("money flows exported", "key", key, "rows", rows)
+	return &AnalyticsExportResult{Key: key, Rows: rows}, nil
+}
+
+// Run exports the current and the previous month every interval until ctx is done.
+// The previous month is included because money flows are edited and deleted after the
+// month ends. Exports replace whole months, so runs of several instances are harmless.
+func (s *AnalyticsExportService) Run(ctx context.Context) {
+	if !s.config.Enabled {
+		return
+	}
+
+	log := logger.FromContext(ctx).With("component", "analytics_export")
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now().UTC()
+		for _, month := range []time.Time{now.AddDate(0, 0, -now.Day()), now} {
+			if _, err := s.ScheduleMoneyFlowExport(ctx, month); err != nil && ctx.Err() == nil {
+				log.Warn("failed to schedule money flow export", "month", month.Format(ExportMonthLayout), "error", err)
+			}
+		}
+	}
+}
+
+// writeMoneyFlows writes the money flows created in [start, end) as a Parquet file
+func (s *AnalyticsExportService) writeMoneyFlows(ctx context.Context, w io.Writer, start, end time.Time) (int, error) {
+	file, err := parquet.NewWriter(w, moneyFlowExportColumns, 0)
+	if err != nil {
+		return 0, err
+	}
+	file.SetMetadata("catetin.dataset", moneyFlowDataset)
+	file.SetMetadata("catetin.columns", strconv.Itoa(len(moneyFlowExportColumns)))
+
+	// Exports read a past month in batches, keeping the scan off the primary
+	ctx = repository.PreferReplica(ctx)
+
+	rows := 0
+	for offset := 0; ; offset += s.config.BatchSize {
+		moneyFlows, err := s.moneyFlowRepo.FindCreatedBetweenWithDeleted(ctx, start, end, s.config.BatchSize, offset)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, moneyFlow := range moneyFlows {
+			row, err := moneyFlowExportRow(moneyFlow)
+			if err != nil {
+				return 0, err
+			}
+			if err := file.Write(row...); err != nil {
+				return 0, err
+			}
+		}
+		rows += len(moneyFlows)
+
+		if len(moneyFlows) < s.config.BatchSize {
+			break
+		}
+	}
+
+	return rows, file.Close()
+}
+
+// updateSchema checks that the columns extend the dataset's stored schema and stores
+// them as the new schema. It refuses changes that would break readers of existing
+// partitions, and a build whose schema is older than the stored one.
+func (s *AnalyticsExportService) updateSchema(ctx context.Context, dataset string, columns []parquet.Column) error {
+	key := dataset + "/_schema.json"
+
+	current := exportSchema{Columns: make([]exportSchemaColumn, len(columns))}
+	for i, column := range columns {
+		current.Columns[i] = exportSchemaColumn{Name: column.Name, Type: column.Type.Name(), Optional: column.Optional}
+	}
+
+	stored, err := s.storage.Get(ctx, key)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		var previous exportSchema
+		err := json.NewDecoder(stored).Decode(&previous)
+		stored.Close()
+		if err != nil {
+			return worker.Permanent(fmt.Errorf("invalid schema manifest %s: %w", key, err))
+		}
+
+		if len(previous.Columns) > len(current.Columns) {
+			return worker.Permanent(fmt.Errorf("%s has %d columns but this build exports %d; deploy the newer build", dataset, len(previous.Columns), len(current.Columns)))
+		}
+		for i, column := range previous.Columns {
+			if current.Columns[i] != column {
+				return worker.Permanent(fmt.Errorf("%s column %d changed from %+v to %+v; columns may only be appended", dataset, i, column, current.Columns[i]))
+			}
+		}
+		for _, column := range current.Columns[len(previous.Columns):] {
+			if !column.Optional {
+				return worker.Permanent(fmt.Errorf("%s column %s must be optional since older partitions lack it", dataset, column.Name))
+			}
+		}
+		if len(previous.Columns) == len(current.Columns) {
+			return nil
+		}
+	}
+
+	encoded, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.storage.Put(ctx, key, bytes.NewReader(encoded))
+}
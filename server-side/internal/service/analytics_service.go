@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+)
+
+// AnalyticsSink receives batches of anonymized analytics events
+type AnalyticsSink interface {
+	Write(ctx context.Context, events []*domain.AnalyticsEvent) error
+}
+
+// DatabaseAnalyticsSink stores analytics events in the analytics_events table
+type DatabaseAnalyticsSink struct {
+	eventRepo repository.AnalyticsEventRepository
+}
+
+// NewDatabaseAnalyticsSink creates a new database analytics sink
+func NewDatabaseAnalyticsSink(eventRepo repository.AnalyticsEventRepository) *DatabaseAnalyticsSink {
+	return &DatabaseAnalyticsSink{eventRepo: eventRepo}
+}
+
+// Write stores the events in a single batch
+func (s *DatabaseAnalyticsSink) Write(ctx context.Context, events []*domain.AnalyticsEvent) error {
+	return s.eventRepo.CreateBatch(ctx, events)
+}
+
+// LogAnalyticsSink writes analytics events to the structured log, useful in development
+type LogAnalyticsSink struct{}
+
+// Write logs every event
+func (LogAnalyticsSink) Write(ctx context.Context, events []*domain.AnalyticsEvent) error {
+	log := logger.FromContext(ctx)
+	for _, event := range events {
+		log.Info("analytics event", "name", event.Name, "anonymous_id", event.AnonymousID, "properties", event.Properties)
+	}
+	return nil
+}
+
+// AnalyticsConfig holds the settings of the analytics pipeline
+type AnalyticsConfig struct {
+	// Enabled turns the pipeline on; when false Track is a no-op
+	Enabled bool
+
+	// Salt keys the hash that anonymizes user IDs
+	Salt string
+
+	// SampleRate is the fraction of events recorded, between 0 and 1
+	SampleRate float64
+
+	// BufferSize is the number of events buffered before new events are dropped
+	BufferSize int
+
+	// FlushInterval is how often buffered events are written to the sink
+	FlushInterval time.Duration
+}
+
+// AnalyticsService records anonymized product analytics events.
+// Tracking never blocks or fails a request: events are buffered and written
+// to the sink in the background, and dropped when the buffer is full.
+type AnalyticsService struct {
+	sink         AnalyticsSink
+	settingsRepo repository.UserSettingsRepository
+	config       AnalyticsConfig
+	events       chan *domain.AnalyticsEvent
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(sink AnalyticsSink, settingsRepo repository.UserSettingsRepository, config AnalyticsConfig) *AnalyticsService {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1000
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 10 * time.Second
+	}
+
+	return &AnalyticsService{
+		sink:         sink,
+		settingsRepo: settingsRepo,
+		config:       config,
+		events:       make(chan *domain.AnalyticsEvent, config.BufferSize),
+	}
+}
+
+// Track records an event for a user unless analytics is disabled, the event is
+// sampled out, or the user opted out. Properties must not contain personal data.
+func (s *AnalyticsService) Track(ctx context.Context, userID uuid.UUID, name string, properties map[string]string) {
+	if s == nil || !s.config.Enabled {
+		return
+	}
+
+	if s.config.SampleRate < 1 && rand.Float64() >= s.config.SampleRate {
+		return
+	}
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		// Without the preference we cannot know the user did not opt out
+		logger.FromContext(ctx).Warn("analytics opt-out lookup failed", "error", err)
+		return
+	}
+	if settings != nil && settings.AnalyticsOptOut {
+		return
+	}
+
+	event := domain.NewAnalyticsEvent(name, s.anonymize(userID), properties, time.Now())
+	select {
+	case s.events <- event:
+	default:
+		logger.FromContext(ctx).Debug("analytics buffer full, event dropped", "name", name)
+	}
+}
+
+// HandleEvent tracks domain events, subscribed to events.UserRegistered and
+// events.MoneyFlowCreated
+func (s *AnalyticsService) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case events.UserRegistered:
+		var payload events.UserRegisteredPayload
+		if err := event.Decode(&payload); err != nil {
+			return worker.Permanent(err)
+		}
+		s.Track(ctx, payload.UserID, domain.EventSignedUp, map[string]string{
+			"provider": payload.Provider,
+		})
+
+	case events.MoneyFlowCreated:
+		var payload events.MoneyFlowPayload
+		if err := event.Decode(&payload); err != nil {
+			return worker.Permanent(err)
+		}
+		s.Track(ctx, payload.UserID, domain.EventMoneyFlowRecorded, map[string]string{
+			"kind":     payload.Kind,
+			"currency": payload.Currency,
+		})
+	}
+	return nil
+}
+
+// Run writes buffered events to the sink until the context is cancelled,
+// flushing whatever is still buffered before returning
+func (s *AnalyticsService) Run(ctx context.Context) {
+	if !s.config.Enabled {
+		return
+	}
+
+	log := logger.FromContext(ctx).With("component", "analytics")
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*domain.AnalyticsEvent, 0, s.config.BufferSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.sink.Write(ctx, batch); err != nil {
+			log.Warn("failed to write analytics events", "count", len(batch), "error", err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case event := <-s.events:
+			batch = append(batch, event)
+			if len(batch) >= s.config.BufferSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		case <-ctx.Done():
+			// Drain without blocking, then flush with a fresh context
+		drain:
+			for {
+				select {
+				case event := <-s.events:
+					batch = append(batch, event)
+				default:
+					break drain
+				}
+			}
+			flushCtx, cancel := context.WithTimeout(logger.WithContext(context.Background(), log), 5*time.Second)
+			flush(flushCtx)
+			cancel()
+			return
+		}
+	}
+}
+
+// anonymize derives a stable identifier that cannot be linked back to the user without the salt
+func (s *AnalyticsService) anonymize(userID uuid.UUID) string {
+	mac := hmac.New(sha256.New, []byte(s.config.Salt))
+	mac.Write(userID[:])
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// APIKeyService handles API key business logic
+type APIKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// CreateAPIKeyResponse represents a newly issued API key
+type CreateAPIKeyResponse struct {
+	APIKey    *domain.APIKey
+	Plaintext string
+}
+
+// CreateKey issues a new API key with the given scopes for a user
+func (s *APIKeyService) CreateKey(ctx context.Context, userID uuid.UUID, name string, scopes []string) (*CreateAPIKeyResponse, error) {
+	if len(scopes) == 0 {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"scopes": "at least one scope is required",
+		})
+	}
+
+	uniqueScopes := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !domain.IsValidScope(scope) {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"scopes": "unknown scope: " + scope,
+			})
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			uniqueScopes = append(uniqueScopes, scope)
+		}
+	}
+
+	plaintext, prefix, hash, err := security.GenerateAPIKey()
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate API key", 500)
+	}
+
+	apiKey := domain.NewAPIKey(userID, name, prefix, hash, uniqueScopes)
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create API key", 500)
+	}
+
+	return &CreateAPIKeyResponse{
+		APIKey:    apiKey,
+		Plaintext: plaintext,
+	}, nil
+}
+
+// ListKeys returns all API keys issued by a user
+func (s *APIKeyService) ListKeys(ctx context.Context, userID uuid.UUID) ([]*domain.APIKey, error) {
+	apiKeys, err := s.apiKeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list API keys", 500)
+	}
+	return apiKeys, nil
+}
+
+// RevokeKey revokes an API key owned by the user
+func (s *APIKeyService) RevokeKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	apiKey, err := s.apiKeyRepo.FindByID(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find API key", 500)
+	}
+
+	// Do not leak the existence of other users' keys
+	if apiKey.UserID != userID {
+		return appErrors.ErrResourceNotFound
+	}
+
+	if apiKey.IsRevoked() {
+		return nil
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, apiKey.ID, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke API key", 500)
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey resolves a plaintext API key into its active key record
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, plaintext string) (*domain.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.FindByHash(ctx, security.HashAPIKey(plaintext))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrInvalidAPIKey
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find API key", 500)
+	}
+
+	if apiKey.IsRevoked() {
+		return nil, appErrors.ErrInvalidAPIKey
+	}
+
+	// Keys of disabled accounts stop working without being revoked, so enabling the
+	// account again restores them
+	owner, err := s.userRepo.FindByID(ctx, apiKey.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrInvalidAPIKey
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find API key owner", 500)
+	}
+	if owner.IsDisabled() {
+		return nil, appErrors.ErrAccountDisabled
+	}
+
+	// Last-used tracking is best effort and must not block the request
+	now := time.Now()
+	if err := s.apiKeyRepo.UpdateLastUsed(ctx, apiKey.ID, now); err != nil {
+		logger.FromContext(ctx).Warn("failed to update API key last used", "api_key_id", apiKey.ID, "error", err)
+	} else {
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey, nil
+}
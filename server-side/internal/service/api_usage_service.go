@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// APIUsageConfig holds the settings of API usage tracking
+type APIUsageConfig struct {
+	// Enabled turns counting on; when false Record is a no-op
+	Enabled bool
+
+	// FlushInterval is how often pending counters are added to the database
+	FlushInterval time.Duration
+
+	// MaxPending is the number of distinct counters held in memory before new ones are dropped
+	MaxPending int
+}
+
+// APIUsageReport is a user's API usage over a period
+type APIUsageReport struct {
+	Since        time.Time
+	RequestCount int64
+	ErrorCount   int64
+	Endpoints    []*domain.RouteAPIUsage
+	Daily        []*domain.APIUsage
+}
+
+type apiUsageKey struct {
+	userID uuid.UUID
+	day    time.Time
+	method string
+	route  string
+}
+
+// APIUsageService counts API requests per user and endpoint.
+// Counting never blocks a request: counters are aggregated in memory and
+// added to the database in the background.
+type APIUsageService struct {
+	usageRepo repository.APIUsageRepository
+	config    APIUsageConfig
+
+	mu      sync.Mutex
+	pending map[apiUsageKey]*domain.APIUsage
+}
+
+// NewAPIUsageService creates a new API usage service
+func NewAPIUsageService(usageRepo repository.APIUsageRepository, config APIUsageConfig) *APIUsageService {
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 10 * time.Second
+	}
+	if config.MaxPending <= 0 {
+		config.MaxPending = 10000
+	}
+
+	return &APIUsageService{
+		usageRepo: usageRepo,
+		config:    config,
+		pending:   make(map[apiUsageKey]*domain.APIUsage),
+	}
+}
+
+// Record counts one request of a user to an endpoint. Responses with a 4xx or
+// 5xx status are also counted as errors.
+func (s *APIUsageService) Record(userID uuid.UUID, method, route string, status int) {
+	if s == nil || !s.config.Enabled {
+		return
+	}
+
+	usage := domain.NewAPIUsage(userID, method, route, status >= 400, time.Now())
+	key := apiUsageKey{userID: usage.UserID, day: usage.Day, method: usage.Method, route: usage.Route}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.pending[key]; ok {
+		existing.RequestCount += usage.RequestCount
+		existing.ErrorCount += usage.ErrorCount
+		return
+	}
+	if len(s.pending) >= s.config.MaxPending {
+		return
+	}
+	s.pending[key] = usage
+}
+
+// Run adds pending counters to the database until the context is cancelled,
+// flushing whatever is still pending before returning
+func (s *APIUsageService) Run(ctx context.Context) {
+	if !s.config.Enabled {
+		return
+	}
+
+	log := logger.FromContext(ctx).With("component", "api_usage")
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush(ctx, log)
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(logger.WithContext(context.Background(), log), 5*time.Second)
+			s.flush(flushCtx, log)
+			cancel()
+			return
+		}
+	}
+}
+
+// GetUserUsage returns a user's usage over the last days, including today
+func (s *APIUsageService) GetUserUsage(ctx context.Context, userID uuid.UUID, days int) (*APIUsageReport, error) {
+	since := UsagePeriodStart(days)
+
+	usages, err := s.usageRepo.FindByUserID(ctx, userID, since)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find API usage", 500)
+	}
+
+	report := &APIUsageReport{
+		Since:     since,
+		Endpoints: []*domain.RouteAPIUsage{},
+		Daily:     []*domain.APIUsage{},
+	}
+	endpoints := make(map[[2]string]*domain.RouteAPIUsage)
+	daily := make(map[time.Time]*domain.APIUsage)
+
+	for _, usage := range usages {
+		report.RequestCount += usage.RequestCount
+		report.ErrorCount += usage.ErrorCount
+
+		endpointKey := [2]string{usage.Method, usage.Route}
+		endpoint, ok := endpoints[endpointKey]
+		if !ok {
+			endpoint = &domain.RouteAPIUsage{Method: usage.Method, Route: usage.Route}
+			endpoints[endpointKey] = endpoint
+			report.Endpoints = append(report.Endpoints, endpoint)
+		}
+		endpoint.RequestCount += usage.RequestCount
+		endpoint.ErrorCount += usage.ErrorCount
+
+		// Usages are ordered by day, so days are appended in order
+		day, ok := daily[usage.Day]
+		if !ok {
+			day = &domain.APIUsage{UserID: userID, Day: usage.Day}
+			daily[usage.Day] = day
+			report.Daily = append(report.Daily, day)
+		}
+		day.RequestCount += usage.RequestCount
+		day.ErrorCount += usage.ErrorCount
+	}
+
+	sort.SliceStable(report.Endpoints, func(i, j int) bool {
+		return report.Endpoints[i].RequestCount > report.Endpoints[j].RequestCount
+	})
+
+	return report, nil
+}
+
+// ListUserUsage returns the users with the most requests over the last days
+func (s *APIUsageService) ListUserUsage(ctx context.Context, days, limit, offset int) ([]*domain.UserAPIUsage, error) {
+	// Usage is flushed every few seconds anyway, so replica lag goes unnoticed
+	ctx = repository.PreferReplica(ctx)
+	summaries, err := s.usageRepo.SummarizeByUser(ctx, UsagePeriodStart(days), limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize API usage", 500)
+	}
+	return summaries, nil
+}
+
+// ListRouteUsage returns the request totals of every endpoint over the last days
+func (s *APIUsageService) ListRouteUsage(ctx context.Context, days int) ([]*domain.RouteAPIUsage, error) {
+	ctx = repository.PreferReplica(ctx)
+	summaries, err := s.usageRepo.SummarizeByRoute(ctx, UsagePeriodStart(days))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize API usage", 500)
+	}
+	return summaries, nil
+}
+
+// flush swaps out the pending counters and adds them to the database.
+// Counters that fail to save are dropped; usage figures are best effort.
+func (s *APIUsageService) flush(ctx context.Context, log *slog.Logger) {
+	s.mu.Lock()
+	if len(s.pending) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	pending := s.pending
+	s.pending = make(map[apiUsageKey]*domain.APIUsage, len(pending))
+	s.mu.Unlock()
+
+	usages := make([]*domain.APIUsage, 0, len(pending))
+	for _, usage := range pending {
+		usages = append(usages, usage)
+	}
+
+	// Save in key order so concurrent flushes from several instances lock rows in the same order
+	sort.Slice(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if a.UserID != b.UserID {
+			return a.UserID.String() < b.UserID.String()
+		}
+		if !a.Day.Equal(b.Day) {
+			return a.Day.Before(b.Day)
+		}
+		if a.Route != b.Route {
+			return a.Route < b.Route
+		}
+		return a.Method < b.Method
+	})
+
+	if err := s.usageRepo.Increment(ctx, usages); err != nil {
+		log.Warn("failed to save API usage", "count", len(usages), "error", err)
+	}
+}
+
+// UsagePeriodStart returns the first day of a period of the given number of days ending today
+func UsagePeriodStart(days int) time.Time {
+	return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// AuditLogService lists the audit log to the users it is about
+type AuditLogService struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// NewAuditLogService creates a new audit log service
+func NewAuditLogService(auditLogRepo repository.AuditLogRepository) *AuditLogService {
+	return &AuditLogService{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// List returns the changes a user made, newest first
+func (s *AuditLogService) List(ctx context.Context, userID uuid.UUID, filter domain.AuditLogFilter, limit, offset int) ([]*domain.AuditLog, error) {
+	logs, err := s.auditLogRepo.FindByActorID(ctx, userID, filter, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list audit logs", 500)
+	}
+	return logs, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// AuditEntity is the snapshot of an entity recorded in the audit log
+type AuditEntity struct {
+	Type     string
+	ID       uuid.UUID
+	Snapshot map[string]interface{}
+}
+
+// Auditor records the create, update, and delete actions of services in the audit log.
+// Services pass the entity as it was before and after the change; called in the
+// transaction of the change, the entry is only kept if the change commits.
+// A nil *Auditor records nothing.
+type Auditor struct {
+	auditLogRepo repository.AuditLogRepository
+}
+
+// NewAuditor creates a new auditor
+func NewAuditor(auditLogRepo repository.AuditLogRepository) *Auditor {
+	return &Auditor{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// Record records that the actor changed an entity from before to after. Before is nil
+// when the entity was created and after is nil when it was deleted.
+func (a *Auditor) Record(ctx context.Context, actorID uuid.UUID, before, after *AuditEntity) error {
+	if a == nil || (before == nil && after == nil) {
+		return nil
+	}
+
+	entity := after
+	if entity == nil {
+		entity = before
+	}
+
+	var beforeSnapshot, afterSnapshot interface{}
+	if before != nil {
+		beforeSnapshot = before.Snapshot
+	}
+	if after != nil {
+		afterSnapshot = after.Snapshot
+	}
+
+	log, err := domain.NewAuditLog(actorID, entity.Type, entity.ID, beforeSnapshot, afterSnapshot)
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record audit log", 500)
+	}
+	if err := a.auditLogRepo.Create(ctx, log); err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record audit log", 500)
+	}
+	return nil
+}
+
+// Snapshots of audited entities. They are taken when called, so take the snapshot
+// before an update changes the entity.
+
+func moneyFlowAudit(moneyFlow *domain.MoneyFlow) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityMoneyFlow,
+		ID:   moneyFlow.ID,
+		Snapshot: map[string]interface{}{
+			"kind":        moneyFlow.Kind,
+			"amount":      moneyFlow.Money().Float64(),
+			"currency":    moneyFlow.Currency,
+			"category":    cloneValue(moneyFlow.Category),
+			"description": cloneValue(moneyFlow.Description),
+			"tags":        slices.Clone(moneyFlow.Tags),
+			"wallet_id":   cloneValue(moneyFlow.WalletID),
+			"group_id":    cloneValue(moneyFlow.GroupID),
+			"merchant_id": cloneValue(moneyFlow.MerchantID),
+			"latitude":    cloneValue(moneyFlow.Latitude),
+			"longitude":   cloneValue(moneyFlow.Longitude),
+			"place_name":  cloneValue(moneyFlow.PlaceName),
+			"transfer_id": cloneValue(moneyFlow.TransferID),
+			"version":     moneyFlow.Version,
+		},
+	}
+}
+
+func walletAudit(wallet *domain.Wallet) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityWallet,
+		ID:   wallet.ID,
+		Snapshot: map[string]interface{}{
+			"name":            wallet.Name,
+			"type":            wallet.Type,
+			"currency":        wallet.Currency,
+			"opening_balance": domain.MajorUnits(wallet.OpeningBalance, wallet.Currency),
+			"version":         wallet.Version,
+		},
+	}
+}
+
+func budgetAudit(budget *domain.Budget) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityBudget,
+		ID:   budget.ID,
+		Snapshot: map[string]interface{}{
+			"category": budget.Category,
+			"amount":   domain.MajorUnits(budget.Amount, budget.Currency),
+			"currency": budget.Currency,
+			"hard":     budget.Hard,
+			"version":  budget.Version,
+		},
+	}
+}
+
+func goalAudit(goal *domain.Goal) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityGoal,
+		ID:   goal.ID,
+		Snapshot: map[string]interface{}{
+			"name":          goal.Name,
+			"currency":      goal.Currency,
+			"target_amount": domain.MajorUnits(goal.TargetAmount, goal.Currency),
+			"saved_amount":  domain.MajorUnits(goal.SavedAmount, goal.Currency),
+			"deadline":      cloneValue(goal.Deadline),
+			"archived_at":   cloneValue(goal.ArchivedAt),
+			"version":       goal.Version,
+		},
+	}
+}
+
+func groupAudit(group *domain.Group) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityGroup,
+		ID:   group.ID,
+		Snapshot: map[string]interface{}{
+			"name":    group.Name,
+			"version": group.Version,
+		},
+	}
+}
+
+func userAudit(user *domain.User) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityUser,
+		ID:   user.ID,
+		Snapshot: map[string]interface{}{
+			"role":        user.Role,
+			"disabled_at": cloneValue(user.DisabledAt),
+			"version":     user.Version,
+		},
+	}
+}
+
+func userAuthAudit(userAuth *repository.UserAuth) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityUserAuth,
+		ID:   userAuth.ID,
+		Snapshot: map[string]interface{}{
+			"failed_login_attempts": userAuth.FailedLoginAttempts,
+			"locked_until":          cloneValue(userAuth.LockedUntil),
+		},
+	}
+}
+
+func accountErasureAudit(erasure *domain.AccountErasure) *AuditEntity {
+	return &AuditEntity{
+		Type: domain.AuditEntityAccountErasure,
+		ID:   erasure.ID,
+		Snapshot: map[string]interface{}{
+			"status":       erasure.Status,
+			"erase_at":     erasure.EraseAt,
+			"cancelled_at": cloneValue(erasure.CancelledAt),
+			"completed_at": cloneValue(erasure.CompletedAt),
+		},
+	}
+}
+
+// cloneValue copies the value behind a pointer, so later changes to it do not
+// change a snapshot
+func cloneValue[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	clone := *value
+	return &clone
+}
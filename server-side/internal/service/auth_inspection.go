@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// Reasons a credential cannot be used to sign in
+const (
+	LockReasonAccountDeleted    = "account_deleted"
+	LockReasonCredentialRemoved = "credential_removed"
+	LockReasonTooManyAttempts   = "too_many_attempts"
+)
+
+// UserAuthInfo describes a user's credential for support
+type UserAuthInfo struct {
+	UserAuth *repository.UserAuth
+	Provider *repository.AuthProvider // nil if the provider no longer exists
+
+	// LockReason tells why the credential cannot sign in; empty when it can
+	LockReason string
+}
+
+// UserAuthFilter selects the credentials listed by ListUserAuths
+type UserAuthFilter struct {
+	Provider       string // provider name; empty selects every provider
+	IncludeDeleted bool
+}
+
+// ListUserAuths returns a page of a user's credentials with their sign-in state, and
+// the total number matching the filter, so support can tell why a user cannot sign in
+func (s *AuthService) ListUserAuths(ctx context.Context, userID uuid.UUID, filter UserAuthFilter, page repository.Page) ([]*UserAuthInfo, int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.ListUserAuths")
+	defer span.End()
+
+	accountDeleted := false
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+		}
+		accountDeleted = true
+	}
+
+	repoFilter := repository.UserAuthFilter{
+		UserID:         userID,
+		IncludeDeleted: filter.IncludeDeleted,
+	}
+	if filter.Provider != "" {
+		provider, err := s.authProviderRepo.FindByName(ctx, filter.Provider)
+		if err != nil {
+			return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+		}
+		if provider == nil {
+			return nil, 0, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"provider": "unknown auth provider",
+			})
+		}
+		repoFilter.AuthProviderID = &provider.ID
+	}
+
+	total, err := s.userAuthRepo.Count(ctx, repoFilter)
+	if err != nil {
+		return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count user auths", 500)
+	}
+	if accountDeleted && total == 0 {
+		return nil, 0, appErrors.ErrUserNotFound
+	}
+
+	userAuths, err := s.userAuthRepo.List(ctx, repoFilter, page)
+	if err != nil {
+		return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list user auths", 500)
+	}
+
+	providers := map[uuid.UUID]*repository.AuthProvider{}
+	infos := make([]*UserAuthInfo, len(userAuths))
+	for i, userAuth := range userAuths {
+		provider, ok := providers[userAuth.AuthProviderID]
+		if !ok {
+			provider, err = s.authProviderRepo.FindByID(ctx, userAuth.AuthProviderID)
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+			}
+			providers[userAuth.AuthProviderID] = provider
+		}
+
+		info := &UserAuthInfo{UserAuth: userAuth, Provider: provider}
+		switch {
+		case accountDeleted:
+			info.LockReason = LockReasonAccountDeleted
+		case userAuth.DeletedAt != nil:
+			info.LockReason = LockReasonCredentialRemoved
+		case userAuth.IsLocked(time.Now()):
+			info.LockReason = LockReasonTooManyAttempts
+		}
+		infos[i] = info
+	}
+
+	return infos, total, nil
+}
+
+// ListSessions returns a page of a user's sessions (refresh tokens), newest first,
+// optionally filtered by status, and the total number matching the filter
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID, status string, page repository.Page) ([]*repository.RefreshToken, int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.ListSessions")
+	defer span.End()
+
+	filter := repository.RefreshTokenFilter{
+		UserID: userID,
+		Status: status,
+		At:     time.Now(),
+	}
+
+	total, err := s.refreshTokenRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count sessions", 500)
+	}
+
+	sessions, err := s.refreshTokenRepo.List(ctx, filter, page)
+	if err != nil {
+		return nil, 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list sessions", 500)
+	}
+
+	return sessions, total, nil
+}
@@ -4,42 +4,96 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
 	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
 	"github.com/ingunawandra/catetin/internal/repository"
 	appErrors "github.com/ingunawandra/catetin/pkg/errors"
 )
 
-const EmailPasswordProviderName = "email-password"
+const (
+	EmailPasswordProviderName = "email-password"
+	GoogleProviderName        = "google"
+	PhoneOTPProviderName      = "phone-otp"
+)
+
+// defaultAuthProviders lists the providers that must exist for registration and account linking
+var defaultAuthProviders = []struct {
+	Name        string
+	DisplayName string
+}{
+	{Name: EmailPasswordProviderName, DisplayName: "Email & Password"},
+	{Name: GoogleProviderName, DisplayName: "Google"},
+	{Name: PhoneOTPProviderName, DisplayName: "Phone OTP"},
+}
+
+// LockoutConfig holds the sign-in lockout settings
+type LockoutConfig struct {
+	// MaxAttempts is the number of failed sign-ins in a row that lock a credential
+	MaxAttempts int
+
+	// Duration is how long a locked credential refuses sign-ins
+	Duration time.Duration
+}
 
 // AuthService handles authentication business logic
 type AuthService struct {
 	userRepo         repository.UserRepository
 	userAuthRepo     repository.UserAuthRepository
 	authProviderRepo repository.AuthProviderRepository
+	refreshTokenRepo repository.RefreshTokenRepository
 	passwordHasher   *security.PasswordHasher
 	jwtManager       *security.JWTManager
 	txManager        repository.TransactionManager
+	notifier         *Notifier
+	outbox           *events.Dispatcher
+	auditor          *Auditor
+	lockout          LockoutConfig
+	verifiers        map[string]CredentialVerifier
 }
 
-// NewAuthService creates a new authentication service
+// NewAuthService creates a new authentication service. Credentials of providers other
+// than email-password are only linked when a verifier for the provider is given.
 func NewAuthService(
 	userRepo repository.UserRepository,
 	userAuthRepo repository.UserAuthRepository,
 	authProviderRepo repository.AuthProviderRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
 	passwordHasher *security.PasswordHasher,
 	jwtManager *security.JWTManager,
 	txManager repository.TransactionManager,
+	notifier *Notifier,
+	outbox *events.Dispatcher,
+	auditor *Auditor,
+	lockout LockoutConfig,
+	verifiers ...CredentialVerifier,
 ) *AuthService {
+	if lockout.MaxAttempts <= 0 {
+		lockout.MaxAttempts = 5
+	}
+	if lockout.Duration <= 0 {
+		lockout.Duration = 15 * time.Minute
+	}
+
 	return &AuthService{
 		userRepo:         userRepo,
 		userAuthRepo:     userAuthRepo,
 		authProviderRepo: authProviderRepo,
+		refreshTokenRepo: refreshTokenRepo,
 		passwordHasher:   passwordHasher,
 		jwtManager:       jwtManager,
 		txManager:        txManager,
+		notifier:         notifier,
+		outbox:           outbox,
+		auditor:          auditor,
+		lockout:          lockout,
+		verifiers:        verifiersByProvider(verifiers),
 	}
 }
 
@@ -54,13 +108,24 @@ type RegisterResponse struct {
 // LoginResponse represents the login response
 type LoginResponse struct {
 	User         *domain.User
+	Email        string
 	AccessToken  string
 	RefreshToken string
 	ExpiresIn    int64
 }
 
-// Register registers a new user with email and password
-func (s *AuthService) Register(ctx context.Context, fullName, email, password string) (*RegisterResponse, error) {
+// LinkedProvider represents an auth provider linked to a user account
+type LinkedProvider struct {
+	UserAuth *repository.UserAuth
+	Provider *repository.AuthProvider
+}
+
+// Register registers a new user with email and password. The tokens are issued to the
+// client audience, security.DefaultAudience when empty.
+func (s *AuthService) Register(ctx context.Context, fullName, email, password, client string) (*RegisterResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer span.End()
+
 	// Get email-password auth provider
 	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
 	if err != nil {
@@ -70,7 +135,8 @@ func (s *AuthService) Register(ctx context.Context, fullName, email, password st
 		return nil, appErrors.New(appErrors.ErrCodeInternal, "Authentication provider not configured", 500)
 	}
 
-	// Check if email already exists
+	// Check if email already exists. This is only a fast path: a concurrent registration
+	// can pass it too, and is then rejected by the unique indexes inside the transaction.
 	existingAuth, err := s.userAuthRepo.FindByCredentialID(ctx, email, provider.ID)
 	if err != nil && !errors.Is(err, domain.ErrNotFound) {
 		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check existing email", 500)
@@ -92,6 +158,9 @@ func (s *AuthService) Register(ctx context.Context, fullName, email, password st
 		// Create user
 		user = domain.NewUser(fullName, email) // Use email as phone_number for now
 		if err := s.userRepo.Create(txCtx, user); err != nil {
+			if errors.Is(err, domain.ErrDuplicatePhoneNumber) {
+				return appErrors.ErrEmailAlreadyExists
+			}
 			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create user", 500)
 		}
 
@@ -104,9 +173,17 @@ func (s *AuthService) Register(ctx context.Context, fullName, email, password st
 			CredentialSecret: hashedPassword,
 		}
 		if err := s.userAuthRepo.Create(txCtx, userAuth); err != nil {
+			if errors.Is(err, domain.ErrDuplicateCredential) {
+				return appErrors.ErrEmailAlreadyExists
+			}
 			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create user auth", 500)
 		}
 
+		payload := events.UserRegisteredPayload{UserID: user.ID, Provider: EmailPasswordProviderName}
+		if err := s.outbox.Emit(txCtx, events.UserRegistered, &user.ID, payload); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record event", 500)
+		}
+
 		return nil // Commit transaction
 	})
 
@@ -115,26 +192,25 @@ func (s *AuthService) Register(ctx context.Context, fullName, email, password st
 	}
 
 	// Generate tokens (outside transaction)
-	accessToken, expiresIn, err := s.jwtManager.GenerateAccessToken(user.ID, email, fullName)
-	if err != nil {
-		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate access token", 500)
-	}
-
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	tokens, err := s.issueTokens(ctx, user, email, time.Now(), client, uuid.Nil)
 	if err != nil {
-		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate refresh token", 500)
+		return nil, err
 	}
 
 	return &RegisterResponse{
 		User:         user,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresIn:    expiresIn,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
 	}, nil
 }
 
-// Login authenticates a user with email and password
-func (s *AuthService) Login(ctx context.Context, email, password string) (*LoginResponse, error) {
+// Login authenticates a user with email and password. The tokens are issued to the
+// client audience, security.DefaultAudience when empty.
+func (s *AuthService) Login(ctx context.Context, email, password, client string) (*LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
 	// Get email-password auth provider
 	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
 	if err != nil {
@@ -153,9 +229,28 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*Login
 		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user auth", 500)
 	}
 
+	// A locked credential is refused before the password is checked, so guessing
+	// cannot go on while it is locked
+	now := time.Now()
+	if userAuth.IsLocked(now) {
+		return nil, accountLockedError(*userAuth.LockedUntil, now)
+	}
+
 	// Verify password
 	if !s.passwordHasher.IsValidPassword(userAuth.CredentialSecret, password) {
-		return nil, appErrors.ErrInvalidCredentials
+		return nil, s.recordLoginFailure(ctx, userAuth, now)
+	}
+	s.rehashPassword(ctx, userAuth, password)
+
+	if userAuth.FailedLoginAttempts > 0 || userAuth.LockedUntil != nil {
+		if err := s.userAuthRepo.ResetLoginFailures(ctx, userAuth.ID); err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to reset failed sign-ins", 500)
+		}
+	}
+
+	// Only shown to support, so a failure does not fail the login
+	if err := s.userAuthRepo.UpdateLastUsed(ctx, userAuth.ID, time.Now()); err != nil {
+		logger.FromContext(ctx).Warn("failed to record credential use", "user_auth_id", userAuth.ID, "error", err)
 	}
 
 	// Get user details
@@ -165,43 +260,547 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*Login
 	}
 
 	// Generate tokens
-	accessToken, expiresIn, err := s.jwtManager.GenerateAccessToken(user.ID, email, user.FullName)
+	tokens, err := s.issueTokens(ctx, user, email, time.Now(), client, uuid.Nil)
 	if err != nil {
-		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate access token", 500)
+		return nil, err
 	}
 
-	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID)
+	return &LoginResponse{
+		User:         user,
+		Email:        email,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+// recordLoginFailure counts a failed sign-in with a credential and returns the error of
+// the login: appErrors.ErrInvalidCredentials, or appErrors.ErrAccountLocked when the
+// failure locks the credential. The lockout is audited and the user is told about it.
+func (s *AuthService) recordLoginFailure(ctx context.Context, userAuth *repository.UserAuth, now time.Time) error {
+	lockedUntil := now.Add(s.lockout.Duration)
+
+	var locked bool
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		locked, err = s.userAuthRepo.RecordLoginFailure(txCtx, userAuth.ID, s.lockout.MaxAttempts, lockedUntil)
+		if err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record failed sign-in", 500)
+		}
+		if !locked {
+			return nil
+		}
+
+		before := userAuthAudit(userAuth)
+		after := userAuthAudit(&repository.UserAuth{ID: userAuth.ID, LockedUntil: &lockedUntil})
+		if err := s.auditor.Record(txCtx, userAuth.UserID, before, after); err != nil {
+			return err
+		}
+
+		return s.notifier.Notify(txCtx, userAuth.UserID, Notification{
+			Type:  NotificationAccountLocked,
+			Title: "Your Catetin account was locked",
+			Body:  fmt.Sprintf("Signing in to your account failed %d times in a row, so it is locked for %d minutes. If this was not you, reset your password.", s.lockout.MaxAttempts, int(s.lockout.Duration.Minutes())),
+		})
+	})
 	if err != nil {
-		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate refresh token", 500)
+		return err
+	}
+
+	if locked {
+		logger.FromContext(ctx).Warn("credential locked after failed sign-ins", "user_auth_id", userAuth.ID, "locked_until", lockedUntil)
+		return accountLockedError(lockedUntil, now)
+	}
+	return appErrors.ErrInvalidCredentials
+}
+
+// accountLockedError tells a client when a locked credential accepts sign-ins again
+func accountLockedError(lockedUntil, now time.Time) error {
+	return appErrors.ErrAccountLocked.WithDetails(map[string]interface{}{
+		"locked_until":        lockedUntil.UTC().Format(time.RFC3339),
+		"retry_after_seconds": int64(math.Ceil(lockedUntil.Sub(now).Seconds())),
+	})
+}
+
+// rehashPassword hashes a verified password again when its hash was made with another
+// algorithm or other parameters than configured, so hashes are upgraded as users sign
+// in. The login goes on without it when it fails.
+func (s *AuthService) rehashPassword(ctx context.Context, userAuth *repository.UserAuth, password string) {
+	if !s.passwordHasher.NeedsRehash(userAuth.CredentialSecret) {
+		return
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(password)
+	if err == nil {
+		// A password changed in the meantime is left alone
+		err = s.userAuthRepo.ReplaceSecret(ctx, userAuth.ID, userAuth.CredentialSecret, hashedPassword)
+	}
+	if err != nil && !errors.Is(err, domain.ErrConflict) {
+		logger.FromContext(ctx).Warn("failed to rehash password", "user_auth_id", userAuth.ID, "error", err)
+	}
+}
+
+// Refresh exchanges a valid refresh token for a new token pair.
+// The presented refresh token is revoked (rotation), so it can only be used once.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Refresh")
+	defer span.End()
+
+	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		if errors.Is(err, security.ErrExpiredToken) {
+			return nil, appErrors.ErrExpiredToken
+		}
+		return nil, appErrors.ErrInvalidToken
+	}
+
+	stored, err := s.refreshTokenRepo.FindByHash(ctx, security.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrInvalidToken
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find refresh token", 500)
+	}
+	if !stored.IsActive(time.Now()) || stored.UserID.String() != claims.UserID {
+		return nil, appErrors.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrInvalidToken
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+
+	email, err := findEmail(ctx, s.userAuthRepo, s.authProviderRepo, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.Revoke(ctx, stored.ID, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			// Another request rotated this token first
+			return nil, appErrors.ErrInvalidToken
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke refresh token", 500)
+	}
+
+	// Refreshing is not signing in again, so the new tokens keep the authentication time
+	tokens, err := s.issueTokens(ctx, user, email, claims.AuthenticatedAt(), claims.Client(), stored.SessionID)
+	if err != nil {
+		return nil, err
 	}
 
 	return &LoginResponse{
 		User:         user,
+		Email:        email,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+// ChangePassword verifies the current password, stores the new one, and revokes
+// all outstanding refresh and access tokens so every session must log in again
+func (s *AuthService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "AuthService.ChangePassword")
+	defer span.End()
+
+	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
+	if provider == nil {
+		return appErrors.New(appErrors.ErrCodeInternal, "Authentication provider not configured", 500)
+	}
+
+	userAuth, err := s.userAuthRepo.FindByUserIDAndProvider(ctx, userID, provider.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrPasswordNotSet
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user auth", 500)
+	}
+
+	// Verify current password
+	if !s.passwordHasher.IsValidPassword(userAuth.CredentialSecret, currentPassword) {
+		return appErrors.ErrInvalidCurrentPassword
+	}
+
+	if currentPassword == newPassword {
+		return appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"new_password": "new password must be different from the current password",
+		})
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to hash password", 500)
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		userAuth.CredentialSecret = hashedPassword
+		if err := s.userAuthRepo.Update(txCtx, userAuth); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update password", 500)
+		}
+
+		if err := s.revokeSessions(txCtx, userID); err != nil {
+			return err
+		}
+
+		return s.notifier.Notify(txCtx, userID, Notification{
+			Type:  NotificationPasswordChanged,
+			Title: "Your Catetin password was changed",
+			Body:  "Your password was changed and you were signed out of all your sessions. If you did not do this, reset your password and contact support.",
+		})
+	})
+}
+
+// Reauthenticate verifies the password of a signed-in user and returns an access token
+// with a fresh authentication time, for endpoints that require recent authentication.
+// No refresh token is issued, so the session's authentication time is unchanged once
+// the access token expires. The token keeps the client audience and ID of the session.
+func (s *AuthService) Reauthenticate(ctx context.Context, userID uuid.UUID, password, client string, sessionID uuid.UUID) (*LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Reauthenticate")
+	defer span.End()
+
+	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
+	if provider == nil {
+		return nil, appErrors.New(appErrors.ErrCodeInternal, "Authentication provider not configured", 500)
+	}
+
+	userAuth, err := s.userAuthRepo.FindByUserIDAndProvider(ctx, userID, provider.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrPasswordNotSet
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user auth", 500)
+	}
+
+	if !s.passwordHasher.IsValidPassword(userAuth.CredentialSecret, password) {
+		return nil, appErrors.ErrInvalidCurrentPassword
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+	if user.IsDisabled() {
+		return nil, appErrors.ErrAccountDisabled
+	}
+
+	accessToken, expiresIn, err := s.jwtManager.GenerateAccessToken(user.ID, userAuth.CredentialID, user.FullName, time.Now(), client, user.TokenVersion, sessionID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate access token", 500)
+	}
+
+	return &LoginResponse{
+		User:        user,
+		Email:       userAuth.CredentialID,
+		AccessToken: accessToken,
+		ExpiresIn:   expiresIn,
+	}, nil
+}
+
+// issuedTokens holds a freshly generated token pair
+type issuedTokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+}
+
+// issueTokens generates an access/refresh token pair for a user who authenticated at
+// authTime, issued to the client audience, and persists the refresh token with the
+// client info of the request. The tokens continue the session, or start a new one when
+// sessionID is uuid.Nil. Disabled accounts get no tokens.
+func (s *AuthService) issueTokens(ctx context.Context, user *domain.User, email string, authTime time.Time, client string, sessionID uuid.UUID) (*issuedTokens, error) {
+	if user.IsDisabled() {
+		return nil, appErrors.ErrAccountDisabled
+	}
+
+	tokenID := uuid.New()
+	if sessionID == uuid.Nil {
+		sessionID = tokenID
+	}
+
+	accessToken, expiresIn, err := s.jwtManager.GenerateAccessToken(user.ID, email, user.FullName, authTime, client, user.TokenVersion, sessionID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate access token", 500)
+	}
+
+	refreshToken, err := s.jwtManager.GenerateRefreshToken(user.ID, authTime, client)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate refresh token", 500)
+	}
+
+	clientInfo := security.ClientInfoFromContext(ctx)
+	record := &repository.RefreshToken{
+		ID:        tokenID,
+		UserID:    user.ID,
+		SessionID: sessionID,
+		TokenHash: security.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.jwtManager.RefreshTokenTTL()),
+		UserAgent: optionalString(clientInfo.UserAgent),
+		IPAddress: optionalString(clientInfo.IPAddress),
+	}
+	if !authTime.IsZero() {
+		record.SignedInAt = &authTime
+	}
+	if err := s.refreshTokenRepo.Create(ctx, record); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to store refresh token", 500)
+	}
+
+	return &issuedTokens{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresIn:    expiresIn,
 	}, nil
 }
 
-// EnsureEmailPasswordProvider ensures the email-password auth provider exists
-func (s *AuthService) EnsureEmailPasswordProvider(ctx context.Context) error {
-	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
+// findEmail returns the email a user signs in with, the credential of their
+// email-password login, or empty if none is linked
+func findEmail(
+	ctx context.Context,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	userID uuid.UUID,
+) (string, error) {
+	provider, err := authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
 	if err != nil {
-		return fmt.Errorf("failed to check auth provider: %w", err)
+		return "", appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
+	if provider == nil {
+		return "", nil
 	}
 
+	userAuth, err := userAuthRepo.FindByUserIDAndProvider(ctx, userID, provider.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", nil
+		}
+		return "", appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user auth", 500)
+	}
+	return userAuth.CredentialID, nil
+}
+
+// LinkProvider attaches an additional authentication credential to an existing user.
+// An email-password credential takes the password as its secret; other credentials are
+// proven by their provider's CredentialVerifier, e.g. with a Google ID token or a code
+// sent to the phone number, and store no secret.
+func (s *AuthService) LinkProvider(ctx context.Context, userID uuid.UUID, providerName, credentialID, credentialSecret string) (*LinkedProvider, error) {
+	// Demo users are deleted on expiry, so credentials linked to them would be lost
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+	if user.IsDemo() {
+		return nil, appErrors.ErrDemoAccountRestricted
+	}
+
+	provider, err := s.authProviderRepo.FindByName(ctx, providerName)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
 	if provider == nil {
-		// Create the email-password provider
-		name := EmailPasswordProviderName
-		provider = &repository.AuthProvider{
-			ID:          uuid.New(),
-			DisplayName: "Email & Password",
-			Name:        &name,
+		return nil, appErrors.ErrUnsupportedAuthProvider
+	}
+
+	verifier, verified := s.verifiers[providerName]
+	if providerName == EmailPasswordProviderName {
+		if credentialSecret == "" {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"credential_secret": "password is required for email-password provider",
+			})
+		}
+	} else if !verified {
+		return nil, appErrors.ErrUnsupportedAuthProvider
+	}
+
+	// A user can only hold one credential per provider
+	existingAuth, err := s.userAuthRepo.FindByUserIDAndProvider(ctx, userID, provider.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check linked providers", 500)
+	}
+	if existingAuth != nil {
+		return nil, appErrors.ErrProviderAlreadyLinked
+	}
+
+	// Verifying may use up the proof, e.g. a one-time code, so it comes after the
+	// checks that do not depend on the credential
+	if verified {
+		credentialID, err = verifier.VerifyCredential(ctx, userID, provider, credentialID, credentialSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Reject credentials that already belong to any account
+	existingAuth, err = s.userAuthRepo.FindByCredentialID(ctx, credentialID, provider.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check existing credential", 500)
+	}
+	if existingAuth != nil {
+		return nil, appErrors.ErrCredentialAlreadyLinked
+	}
+
+	var hashedSecret string
+	if providerName == EmailPasswordProviderName {
+		hashedSecret, err = s.passwordHasher.Hash(credentialSecret)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to hash credential secret", 500)
+		}
+	}
+
+	userAuth := &repository.UserAuth{
+		ID:               uuid.New(),
+		UserID:           userID,
+		AuthProviderID:   provider.ID,
+		CredentialID:     credentialID,
+		CredentialSecret: hashedSecret,
+	}
+	if err := s.userAuthRepo.Create(ctx, userAuth); err != nil {
+		// A concurrent request linked the credential or the provider after the checks
+		if errors.Is(err, domain.ErrDuplicateCredential) {
+			if _, err := s.userAuthRepo.FindByUserIDAndProvider(ctx, userID, provider.ID); err == nil {
+				return nil, appErrors.ErrProviderAlreadyLinked
+			}
+			return nil, appErrors.ErrCredentialAlreadyLinked
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to link auth provider", 500)
+	}
+
+	return &LinkedProvider{
+		UserAuth: userAuth,
+		Provider: provider,
+	}, nil
+}
+
+// ListLinkedProviders returns all auth providers linked to a user
+func (s *AuthService) ListLinkedProviders(ctx context.Context, userID uuid.UUID) ([]*LinkedProvider, error) {
+	userAuths, err := s.userAuthRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list linked providers", 500)
+	}
+
+	linked := make([]*LinkedProvider, 0, len(userAuths))
+	for _, userAuth := range userAuths {
+		provider, err := s.authProviderRepo.FindByID(ctx, userAuth.AuthProviderID)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+		}
+		if provider == nil {
+			continue
+		}
+
+		linked = append(linked, &LinkedProvider{
+			UserAuth: userAuth,
+			Provider: provider,
+		})
+	}
+
+	return linked, nil
+}
+
+// UnlinkProvider removes one credential of a user. The last credential cannot be
+// removed, so the account stays reachable. All refresh and access tokens are revoked,
+// so sessions signed in with the removed credential must sign in again.
+func (s *AuthService) UnlinkProvider(ctx context.Context, userID, userAuthID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "AuthService.UnlinkProvider")
+	defer span.End()
+
+	userAuths, err := s.userAuthRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list linked providers", 500)
+	}
+
+	var found bool
+	for _, userAuth := range userAuths {
+		if userAuth.ID == userAuthID {
+			found = true
+			break
 		}
-		if err := s.authProviderRepo.Create(ctx, provider); err != nil {
-			return fmt.Errorf("failed to create auth provider: %w", err)
+	}
+	if !found {
+		return appErrors.ErrResourceNotFound
+	}
+	if len(userAuths) == 1 {
+		return appErrors.ErrLastCredential
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userAuthRepo.Delete(txCtx, userAuthID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to unlink auth provider", 500)
 		}
+
+		return s.revokeSessions(txCtx, userID)
+	})
+}
+
+// revokeSessions signs a user out everywhere: refresh tokens can no longer be exchanged
+// and the access tokens issued so far stop working immediately
+func (s *AuthService) revokeSessions(ctx context.Context, userID uuid.UUID) error {
+	if err := s.refreshTokenRepo.RevokeAllByUserID(ctx, userID, time.Now()); err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke refresh tokens", 500)
+	}
+
+	if err := s.userRepo.IncrementTokenVersion(ctx, userID); err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke access tokens", 500)
 	}
 
 	return nil
 }
+
+// EnsureAuthProviders ensures all default auth providers exist
+func (s *AuthService) EnsureAuthProviders(ctx context.Context) error {
+	for _, p := range defaultAuthProviders {
+		if err := s.ensureProvider(ctx, p.Name, p.DisplayName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnsureEmailPasswordProvider ensures the email-password auth provider exists
+func (s *AuthService) EnsureEmailPasswordProvider(ctx context.Context) error {
+	return s.ensureProvider(ctx, EmailPasswordProviderName, "Email & Password")
+}
+
+func (s *AuthService) ensureProvider(ctx context.Context, providerName, displayName string) error {
+	_, err := ensureAuthProvider(ctx, s.authProviderRepo, providerName, displayName)
+	return err
+}
+
+// ensureAuthProvider creates an auth provider unless it exists, and reports whether it did
+func ensureAuthProvider(ctx context.Context, repo repository.AuthProviderRepository, providerName, displayName string) (bool, error) {
+	provider, err := repo.FindByName(ctx, providerName)
+	if err != nil {
+		return false, fmt.Errorf("failed to check auth provider: %w", err)
+	}
+	if provider != nil {
+		return false, nil
+	}
+
+	name := providerName
+	provider = &repository.AuthProvider{
+		ID:          uuid.New(),
+		DisplayName: displayName,
+		Name:        &name,
+	}
+	if err := repo.Create(ctx, provider); err != nil {
+		return false, fmt.Errorf("failed to create auth provider: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// fakeUserRepo stores users in memory and enforces the unique phone number index
+type fakeUserRepo struct {
+	repository.UserRepository
+
+	mu     sync.Mutex
+	phones map[string]*domain.User
+}
+
+func (r *fakeUserRepo) Create(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.phones[user.PhoneNumber]; exists {
+		return domain.ErrDuplicatePhoneNumber
+	}
+	r.phones[user.PhoneNumber] = user
+	return nil
+}
+
+// fakeUserAuthRepo stores user auths in memory and enforces the unique credential index.
+// FindByCredentialID waits until every expected caller has looked up the credential,
+// so all concurrent registrations pass the pre-check before any of them inserts.
+type fakeUserAuthRepo struct {
+	repository.UserAuthRepository
+
+	mu          sync.Mutex
+	credentials map[string]*repository.UserAuth
+	lookups     sync.WaitGroup
+}
+
+func (r *fakeUserAuthRepo) FindByCredentialID(ctx context.Context, credentialID string, authProviderID uuid.UUID) (*repository.UserAuth, error) {
+	r.lookups.Done()
+	r.lookups.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if userAuth, ok := r.credentials[authProviderID.String()+credentialID]; ok {
+		return userAuth, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeUserAuthRepo) Create(ctx context.Context, userAuth *repository.UserAuth) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := userAuth.AuthProviderID.String() + userAuth.CredentialID
+	if _, exists := r.credentials[key]; exists {
+		return domain.ErrDuplicateCredential
+	}
+	r.credentials[key] = userAuth
+	return nil
+}
+
+type fakeAuthProviderRepo struct {
+	repository.AuthProviderRepository
+	provider *repository.AuthProvider
+}
+
+func (r *fakeAuthProviderRepo) FindByName(ctx context.Context, name string) (*repository.AuthProvider, error) {
+	return r.provider, nil
+}
+
+type fakeRefreshTokenRepo struct {
+	repository.RefreshTokenRepository
+}
+
+func (r *fakeRefreshTokenRepo) Create(ctx context.Context, token *repository.RefreshToken) error {
+	return nil
+}
+
+// fakeTxManager runs the function without a transaction; the fakes reject
+// duplicates before writing, so there is nothing to roll back
+type fakeTxManager struct {
+	repository.TransactionManager
+}
+
+func (m *fakeTxManager) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestRegisterConcurrentDuplicateEmail(t *testing.T) {
+	const concurrency = 8
+
+	providerName := EmailPasswordProviderName
+	userAuthRepo := &fakeUserAuthRepo{credentials: make(map[string]*repository.UserAuth)}
+	userAuthRepo.lookups.Add(concurrency)
+
+	authService := NewAuthService(
+		&fakeUserRepo{phones: make(map[string]*domain.User)},
+		userAuthRepo,
+		&fakeAuthProviderRepo{provider: &repository.AuthProvider{ID: uuid.New(), Name: &providerName}},
+		&fakeRefreshTokenRepo{},
+		security.NewPasswordHasher(security.PasswordHashConfig{}),
+		security.NewJWTManager([]string{"test-secret-key-with-enough-length"}, time.Minute, time.Hour, nil),
+		&fakeTxManager{},
+		nil,
+		nil,
+		nil,
+		LockoutConfig{},
+	)
+
+	errs := make([]error, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = authService.Register(context.Background(), "Racer", "racer@example.com", "password123", "")
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+
+		var appErr *appErrors.AppError
+		if !errors.As(err, &appErr) {
+			t.Fatalf("expected an AppError, got %v", err)
+		}
+		if appErr.Code != appErrors.ErrCodeEmailAlreadyExists || appErr.HTTPStatus != http.StatusConflict {
+			t.Errorf("expected %s (409), got %s (%d)", appErrors.ErrCodeEmailAlreadyExists, appErr.Code, appErr.HTTPStatus)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("expected exactly 1 successful registration, got %d", succeeded)
+	}
+}
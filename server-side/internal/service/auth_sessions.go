@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// ListActiveSessions returns a page of the sessions a user is signed in to, most
+// recently used first, and the total number. Each session is its active refresh token.
+func (s *AuthService) ListActiveSessions(ctx context.Context, userID uuid.UUID, page repository.Page) ([]*repository.RefreshToken, int64, error) {
+	return s.ListSessions(ctx, userID, repository.RefreshTokenActive, page)
+}
+
+// RevokeSession signs a user out of one of their sessions: its refresh token can no
+// longer be exchanged. Access tokens already issued in it work until they expire.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "AuthService.RevokeSession")
+	defer span.End()
+
+	if err := s.refreshTokenRepo.RevokeSession(ctx, userID, sessionID, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke session", 500)
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions signs a user out of every session but the current one and
+// returns the number of sessions ended. Tokens issued before sessions were tracked
+// carry no session, so for them every session is ended.
+func (s *AuthService) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uuid.UUID) (int64, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.RevokeOtherSessions")
+	defer span.End()
+
+	revoked, err := s.refreshTokenRepo.RevokeOtherSessions(ctx, userID, currentSessionID, time.Now())
+	if err != nil {
+		return 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke sessions", 500)
+	}
+
+	return revoked, nil
+}
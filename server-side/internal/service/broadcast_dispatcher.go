@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// BroadcastDispatcherConfig holds the throttling settings of the dispatcher
+type BroadcastDispatcherConfig struct {
+	// RatePerSecond is the maximum number of messages sent per second
+	RatePerSecond int
+
+	// BatchSize is the number of pending deliveries loaded per poll
+	BatchSize int
+
+	// MaxAttempts is the number of send attempts before a delivery is marked failed
+	MaxAttempts int
+
+	// PollInterval is how long to wait before polling again when nothing is pending
+	PollInterval time.Duration
+
+	// StaleAfter releases deliveries left in sending (e.g. after a crash) back to pending
+	StaleAfter time.Duration
+}
+
+// BroadcastDispatcher drains pending broadcast deliveries in the background,
+// sending each through the first broadcast channel that can reach the recipient and
+// that the recipient has not turned off
+type BroadcastDispatcher struct {
+	broadcastRepo repository.BroadcastRepository
+	userRepo      repository.UserRepository
+	settingsRepo  repository.UserSettingsRepository
+	senders       map[string]NotificationSender
+	config        BroadcastDispatcherConfig
+}
+
+// NewBroadcastDispatcher creates a new broadcast dispatcher
+func NewBroadcastDispatcher(
+	broadcastRepo repository.BroadcastRepository,
+	userRepo repository.UserRepository,
+	settingsRepo repository.UserSettingsRepository,
+	config BroadcastDispatcherConfig,
+	senders ...NotificationSender,
+) *BroadcastDispatcher {
+	if config.RatePerSecond <= 0 {
+		config.RatePerSecond = 10
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 3
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.StaleAfter <= 0 {
+		config.StaleAfter = 10 * time.Minute
+	}
+
+	return &BroadcastDispatcher{
+		broadcastRepo: broadcastRepo,
+		userRepo:      userRepo,
+		settingsRepo:  settingsRepo,
+		senders:       sendersByChannel(senders),
+		config:        config,
+	}
+}
+
+// Run dispatches pending deliveries until the context is cancelled
+func (d *BroadcastDispatcher) Run(ctx context.Context) {
+	log := logger.FromContext(ctx).With("component", "broadcast_dispatcher")
+	throttle := time.NewTicker(time.Second / time.Duration(d.config.RatePerSecond))
+	defer throttle.Stop()
+
+	for {
+		released, err := d.broadcastRepo.ReleaseStaleDeliveries(ctx, time.Now().Add(-d.config.StaleAfter))
+		if err != nil && ctx.Err() == nil {
+			log.Warn("failed to release stale broadcast deliveries", "error", err)
+		} else if released > 0 {
+			log.Info("released stale broadcast deliveries", "count", released)
+		}
+
+		processed, err := d.dispatchBatch(ctx, throttle.C)
+		if err != nil && ctx.Err() == nil {
+			log.Warn("broadcast dispatch failed", "error", err)
+		}
+
+		wait := time.Duration(0)
+		if processed == 0 {
+			wait = d.config.PollInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// dispatchBatch sends one batch of pending deliveries and returns how many were processed
+func (d *BroadcastDispatcher) dispatchBatch(ctx context.Context, throttle <-chan time.Time) (int, error) {
+	deliveries, err := d.broadcastRepo.FindPendingDeliveries(ctx, d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	broadcasts := make(map[uuid.UUID]*domain.Broadcast)
+	processed := 0
+	for _, delivery := range deliveries {
+		select {
+		case <-ctx.Done():
+			return processed, ctx.Err()
+		case <-throttle:
+		}
+
+		broadcast, ok := broadcasts[delivery.BroadcastID]
+		if !ok {
+			broadcast, err = d.broadcastRepo.FindByID(ctx, delivery.BroadcastID)
+			if err != nil {
+				return processed, err
+			}
+			broadcasts[broadcast.ID] = broadcast
+		}
+
+		if err := d.broadcastRepo.ClaimDelivery(ctx, delivery); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				continue
+			}
+			return processed, err
+		}
+
+		d.deliver(ctx, broadcast, delivery)
+		if err := d.broadcastRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+
+	for _, broadcast := range broadcasts {
+		if err := d.refreshStatus(ctx, broadcast); err != nil {
+			logger.FromContext(ctx).Warn("failed to update broadcast status", "broadcast_id", broadcast.ID, "error", err)
+		}
+	}
+
+	return processed, nil
+}
+
+// deliver tries the broadcast channels in order of preference and records the outcome on the delivery
+func (d *BroadcastDispatcher) deliver(ctx context.Context, broadcast *domain.Broadcast, delivery *domain.BroadcastDelivery) {
+	recipient, err := d.userRepo.FindByID(ctx, delivery.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			delivery.MarkSkipped("recipient no longer exists")
+			return
+		}
+		delivery.MarkAttemptFailed(err.Error(), d.config.MaxAttempts)
+		return
+	}
+
+	settings, err := d.settingsRepo.FindByUserID(ctx, delivery.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		settings, err = domain.DefaultUserSettings(delivery.UserID), nil
+	}
+	if err != nil {
+		delivery.MarkAttemptFailed(err.Error(), d.config.MaxAttempts)
+		return
+	}
+
+	// Broadcasts use the admin's order of channels, leaving out those the user turned off
+	channels := make([]string, 0, len(broadcast.Channels))
+	for _, channel := range broadcast.Channels {
+		if settings.AcceptsChannel(channel) {
+			channels = append(channels, channel)
+		}
+	}
+
+	channel, err := sendFirst(ctx, d.senders, channels, recipient, broadcast.Title, broadcast.Body)
+	switch {
+	case errors.Is(err, ErrRecipientUnreachable):
+		delivery.MarkSkipped("recipient is not reachable on any broadcast channel they accept")
+	case err != nil:
+		delivery.MarkAttemptFailed(channel+": "+err.Error(), d.config.MaxAttempts)
+	default:
+		delivery.MarkSent(channel)
+	}
+}
+
+// refreshStatus moves a broadcast to sending, or to completed once no delivery is outstanding
+func (d *BroadcastDispatcher) refreshStatus(ctx context.Context, broadcast *domain.Broadcast) error {
+	counts, err := d.broadcastRepo.CountDeliveriesByStatus(ctx, broadcast.ID)
+	if err != nil {
+		return err
+	}
+
+	status := domain.BroadcastStatusSending
+	if counts[domain.DeliveryStatusPending]+counts[domain.DeliveryStatusSending] == 0 {
+		status = domain.BroadcastStatusCompleted
+	}
+	if status == broadcast.Status {
+		return nil
+	}
+
+	now := time.Now()
+	broadcast.Status = status
+	broadcast.UpdatedAt = now
+	if status == domain.BroadcastStatusCompleted {
+		broadcast.CompletedAt = &now
+	}
+
+	return d.broadcastRepo.UpdateStatus(ctx, broadcast)
+}
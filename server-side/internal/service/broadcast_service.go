@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// BroadcastService handles admin broadcast business logic.
+// Creating a broadcast only records it and its pending deliveries; the
+// BroadcastDispatcher sends them in the background.
+type BroadcastService struct {
+	broadcastRepo repository.BroadcastRepository
+	userRepo      repository.UserRepository
+	txManager     repository.TransactionManager
+	senders       map[string]NotificationSender
+}
+
+// NewBroadcastService creates a new broadcast service.
+// Only channels with a registered sender can be used in broadcasts.
+func NewBroadcastService(
+	broadcastRepo repository.BroadcastRepository,
+	userRepo repository.UserRepository,
+	txManager repository.TransactionManager,
+	senders ...NotificationSender,
+) *BroadcastService {
+	return &BroadcastService{
+		broadcastRepo: broadcastRepo,
+		userRepo:      userRepo,
+		txManager:     txManager,
+		senders:       sendersByChannel(senders),
+	}
+}
+
+// BroadcastInput holds the fields of a new broadcast
+type BroadcastInput struct {
+	Title    string
+	Body     string
+	Channels []string
+	Audience domain.BroadcastAudience
+}
+
+// BroadcastSummary represents a broadcast together with its delivery counts by status
+type BroadcastSummary struct {
+	Broadcast      *domain.Broadcast
+	DeliveryCounts map[string]int
+}
+
+// CreateBroadcast records a broadcast and queues a delivery for every matching user
+func (s *BroadcastService) CreateBroadcast(ctx context.Context, adminID uuid.UUID, input BroadcastInput) (*BroadcastSummary, error) {
+	ctx, span := tracing.Start(ctx, "BroadcastService.CreateBroadcast")
+	defer span.End()
+
+	channels, err := s.validateChannels(input.Channels)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range input.Audience.Roles {
+		if !domain.IsValidRole(role) {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"audience.roles": "unknown role: " + role,
+			})
+		}
+	}
+
+	recipients, err := s.userRepo.FindByAudience(ctx, input.Audience)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find broadcast recipients", 500)
+	}
+
+	broadcast := domain.NewBroadcast(adminID, input.Title, input.Body, channels, input.Audience)
+	broadcast.RecipientCount = len(recipients)
+	if len(recipients) == 0 {
+		broadcast.Status = domain.BroadcastStatusCompleted
+		broadcast.CompletedAt = &broadcast.CreatedAt
+	}
+
+	deliveries := make([]*domain.BroadcastDelivery, len(recipients))
+	for i, recipient := range recipients {
+		deliveries[i] = domain.NewBroadcastDelivery(broadcast.ID, recipient.ID)
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.broadcastRepo.Create(txCtx, broadcast); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create broadcast", 500)
+		}
+		if err := s.broadcastRepo.CreateDeliveries(txCtx, deliveries); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to queue broadcast deliveries", 500)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BroadcastSummary{
+		Broadcast:      broadcast,
+		DeliveryCounts: map[string]int{domain.DeliveryStatusPending: len(deliveries)},
+	}, nil
+}
+
+// GetBroadcast returns a broadcast with its delivery counts
+func (s *BroadcastService) GetBroadcast(ctx context.Context, broadcastID uuid.UUID) (*BroadcastSummary, error) {
+	broadcast, err := s.findBroadcast(ctx, broadcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.broadcastRepo.CountDeliveriesByStatus(ctx, broadcast.ID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count broadcast deliveries", 500)
+	}
+
+	return &BroadcastSummary{
+		Broadcast:      broadcast,
+		DeliveryCounts: counts,
+	}, nil
+}
+
+// ListBroadcasts returns broadcasts, newest first
+func (s *BroadcastService) ListBroadcasts(ctx context.Context, limit, offset int) ([]*domain.Broadcast, error) {
+	broadcasts, err := s.broadcastRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list broadcasts", 500)
+	}
+	return broadcasts, nil
+}
+
+// ListDeliveries returns the per-recipient deliveries of a broadcast, optionally filtered by status
+func (s *BroadcastService) ListDeliveries(ctx context.Context, broadcastID uuid.UUID, status string, limit, offset int) ([]*domain.BroadcastDelivery, error) {
+	if _, err := s.findBroadcast(ctx, broadcastID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.broadcastRepo.ListDeliveries(ctx, broadcastID, status, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list broadcast deliveries", 500)
+	}
+	return deliveries, nil
+}
+
+// validateChannels checks that every channel has a registered sender and removes duplicates
+func (s *BroadcastService) validateChannels(channels []string) ([]string, error) {
+	if len(channels) == 0 {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"channels": "at least one channel is required",
+		})
+	}
+
+	unique := make([]string, 0, len(channels))
+	seen := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		if _, ok := s.senders[channel]; !ok {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"channels": "channel not available: " + channel,
+			})
+		}
+		if !seen[channel] {
+			seen[channel] = true
+			unique = append(unique, channel)
+		}
+	}
+
+	return unique, nil
+}
+
+func (s *BroadcastService) findBroadcast(ctx context.Context, broadcastID uuid.UUID) (*domain.Broadcast, error) {
+	broadcast, err := s.broadcastRepo.FindByID(ctx, broadcastID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find broadcast", 500)
+	}
+	return broadcast, nil
+}
+
+func sendersByChannel(senders []NotificationSender) map[string]NotificationSender {
+	byChannel := make(map[string]NotificationSender, len(senders))
+	for _, sender := range senders {
+		byChannel[sender.Channel()] = sender
+	}
+	return byChannel
+}
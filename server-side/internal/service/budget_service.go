@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// BudgetService handles category budget business logic
+type BudgetService struct {
+	budgetRepo    repository.BudgetRepository
+	moneyFlowRepo repository.MoneyFlowRepository
+	settingsRepo  repository.UserSettingsRepository
+	auditor       *Auditor
+	txManager     repository.TransactionManager
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(
+	budgetRepo repository.BudgetRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+	settingsRepo repository.UserSettingsRepository,
+	auditor *Auditor,
+	txManager repository.TransactionManager,
+) *BudgetService {
+	return &BudgetService{
+		budgetRepo:    budgetRepo,
+		moneyFlowRepo: moneyFlowRepo,
+		settingsRepo:  settingsRepo,
+		auditor:       auditor,
+		txManager:     txManager,
+	}
+}
+
+// BudgetInput holds the fields of a budget that can be changed after creation
+type BudgetInput struct {
+	Amount   float64
+	Currency string
+	Hard     bool
+}
+
+// BudgetStatus represents a budget together with its spending in the current period,
+// in minor units of the budget's currency
+type BudgetStatus struct {
+	Budget      *domain.Budget
+	Spent       int64
+	Remaining   int64
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+}
+
+// Create creates a budget for a category. The currency defaults to the user's default currency.
+func (s *BudgetService) Create(ctx context.Context, userID uuid.UUID, category string, input BudgetInput) (*BudgetStatus, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.Create")
+	defer span.End()
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Currency == "" {
+		input.Currency = settings.DefaultCurrency
+	}
+
+	budget, err := domain.NewBudget(userID, category, input.Amount, input.Currency, input.Hard)
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.budgetRepo.Create(txCtx, budget); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrBudgetAlreadyExists
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create budget", 500)
+		}
+		return s.auditor.Record(txCtx, userID, nil, budgetAudit(budget))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.status(ctx, budget, settings, time.Now())
+}
+
+// Set creates the category's budget, or updates it if the user already has one
+func (s *BudgetService) Set(ctx context.Context, userID uuid.UUID, category string, input BudgetInput) (*BudgetStatus, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.Set")
+	defer span.End()
+
+	existing, err := s.budgetRepo.FindByUserIDAndCategory(ctx, userID, strings.TrimSpace(category))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return s.Create(ctx, userID, category, input)
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find budget", 500)
+	}
+
+	return s.Update(ctx, userID, existing.ID, existing.Version, input)
+}
+
+// List returns the user's budgets with their spending in the current period
+func (s *BudgetService) List(ctx context.Context, userID uuid.UUID) ([]*BudgetStatus, error) {
+	return s.ListAt(ctx, userID, time.Now())
+}
+
+// ListAt returns the user's budgets with their spending in the period containing at
+func (s *BudgetService) ListAt(ctx context.Context, userID uuid.UUID, at time.Time) ([]*BudgetStatus, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.ListAt")
+	defer span.End()
+
+	budgets, err := s.budgetRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list budgets", 500)
+	}
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*BudgetStatus, len(budgets))
+	for i, budget := range budgets {
+		statuses[i], err = s.status(ctx, budget, settings, at)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return statuses, nil
+}
+
+// Update changes the cap, currency, or hardness of a budget using optimistic locking
+func (s *BudgetService) Update(ctx context.Context, userID, id uuid.UUID, version int, input BudgetInput) (*BudgetStatus, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.Update")
+	defer span.End()
+
+	budget, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if budget.Version != version {
+		return nil, appErrors.ErrVersionConflict
+	}
+	before := budgetAudit(budget)
+
+	currency := budget.Currency
+	if input.Currency != "" {
+		currency = input.Currency
+	}
+	if err := budget.SetAmount(input.Amount, currency); err != nil {
+		return nil, validationError(err)
+	}
+	if err := budget.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+	budget.Hard = input.Hard
+	budget.IncrementVersion()
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.budgetRepo.Update(txCtx, budget); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrVersionConflict
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update budget", 500)
+		}
+		return s.auditor.Record(txCtx, userID, before, budgetAudit(budget))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.status(ctx, budget, settings, time.Now())
+}
+
+// Delete deletes a budget owned by the user
+func (s *BudgetService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "BudgetService.Delete")
+	defer span.End()
+
+	budget, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.budgetRepo.Delete(txCtx, budget.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete budget", 500)
+		}
+		return s.auditor.Record(txCtx, userID, budgetAudit(budget), nil)
+	})
+}
+
+// ListOverrides returns the money flows the user recorded over a hard budget, newest first
+func (s *BudgetService) ListOverrides(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.BudgetOverride, error) {
+	ctx, span := tracing.Start(ctx, "BudgetService.ListOverrides")
+	defer span.End()
+
+	overrides, err := s.budgetRepo.FindOverridesByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list budget overrides", 500)
+	}
+	return overrides, nil
+}
+
+// status computes the spending of a budget in the period containing at, in the
+// user's time zone
+func (s *BudgetService) status(ctx context.Context, budget *domain.Budget, settings *domain.UserSettings, at time.Time) (*BudgetStatus, error) {
+	start, end := domain.BudgetPeriod(at, settings)
+
+	spent, err := s.moneyFlowRepo.GetCategoryTotalInPeriod(ctx, budget.UserID, budget.Category, budget.Currency, start, end, uuid.Nil)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to calculate budget spending", 500)
+	}
+
+	return &BudgetStatus{
+		Budget:      budget,
+		Spent:       spent,
+		Remaining:   budget.Amount - spent,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}, nil
+}
+
+func (s *BudgetService) findOwned(ctx context.Context, userID, id uuid.UUID) (*domain.Budget, error) {
+	budget, err := s.budgetRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find budget", 500)
+	}
+
+	// Do not leak the existence of other users' budgets
+	if budget.UserID != userID {
+		return nil, appErrors.ErrResourceNotFound
+	}
+
+	return budget, nil
+}
+
+func (s *BudgetService) findSettings(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultUserSettings(userID), nil
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user settings", 500)
+	}
+	return settings, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// Sources of a category suggestion
+const (
+	SuggestionSourceHistory = "history"
+	SuggestionSourceAI      = "ai"
+)
+
+const (
+	// suggestionHistorySize is the number of past descriptions matched against
+	suggestionHistorySize = 500
+
+	// maxCategorySuggestions is the number of suggestions returned
+	maxCategorySuggestions = 3
+)
+
+const categorySuggestionPrompt = `You categorize expenses described in Indonesian or English.
+Reply with a JSON object with one field:
+- "category": the best category for the expense, preferably one of the user's categories listed below, otherwise one short lowercase Indonesian word such as "makanan", "transportasi", "belanja", "tagihan", "hiburan", "kesehatan", or "lainnya"
+The user's categories: %s`
+
+// CategorySuggestion is a category proposed for a money flow description.
+// Confidence is between 0 and 1.
+type CategorySuggestion struct {
+	Category   string
+	Confidence float64
+	Source     string // SuggestionSourceHistory or SuggestionSourceAI
+}
+
+// CategorySuggestionService proposes categories for money flow descriptions from the
+// categories the user filed similar descriptions in, asking a language model when the
+// history has no match
+type CategorySuggestionService struct {
+	moneyFlowRepo repository.MoneyFlowRepository
+	completer     JSONCompleter
+}
+
+// NewCategorySuggestionService creates a new category suggestion service
+func NewCategorySuggestionService(moneyFlowRepo repository.MoneyFlowRepository, completer JSONCompleter) *CategorySuggestionService {
+	return &CategorySuggestionService{
+		moneyFlowRepo: moneyFlowRepo,
+		completer:     completer,
+	}
+}
+
+// Suggest returns up to three categories for the text, most likely first. Past
+// descriptions sharing words with the text vote for their category, weighted by how
+// closely they match and how often they were used. Without a match, the language model
+// picks one, if configured; otherwise there is no suggestion.
+func (s *CategorySuggestionService) Suggest(ctx context.Context, userID uuid.UUID, text string) (suggestions []*CategorySuggestion, err error) {
+	ctx, span := tracing.Start(ctx, "CategorySuggestionService.Suggest")
+	defer func() { tracing.End(span, err) }()
+
+	history, err := s.moneyFlowRepo.GetDescriptionCategories(ctx, userID, suggestionHistorySize)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to load category history", 500)
+	}
+
+	words := suggestionWords(text)
+	scores := map[string]float64{}
+	var categories []string
+	total := 0.0
+	for _, past := range history {
+		if !slices.Contains(categories, past.Category) {
+			categories = append(categories, past.Category)
+		}
+
+		pastWords := suggestionWords(past.Description)
+		shared := 0
+		for _, word := range words {
+			if slices.Contains(pastWords, word) {
+				shared++
+			}
+		}
+		if shared == 0 {
+			continue
+		}
+
+		// Jaccard similarity of the words, so an identical description counts most
+		similarity := float64(shared) / float64(len(words)+len(pastWords)-shared)
+		score := similarity * (1 + math.Log(float64(past.Count)))
+		scores[past.Category] += score
+		total += score
+	}
+
+	for category, score := range scores {
+		suggestions = append(suggestions, &CategorySuggestion{
+			Category:   category,
+			Confidence: math.Round(score/total*100) / 100,
+			Source:     SuggestionSourceHistory,
+		})
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Confidence != suggestions[j].Confidence {
+			return suggestions[i].Confidence > suggestions[j].Confidence
+		}
+		return suggestions[i].Category < suggestions[j].Category
+	})
+	if len(suggestions) > maxCategorySuggestions {
+		suggestions = suggestions[:maxCategorySuggestions]
+	}
+	if len(suggestions) > 0 || len(words) == 0 || !s.completer.Enabled() {
+		return suggestions, nil
+	}
+
+	var reply struct {
+		Category string `json:"category"`
+	}
+	known := "none yet"
+	if len(categories) > 0 {
+		known = strings.Join(categories, ", ")
+	}
+	if err := s.completer.CompleteJSON(ctx, fmt.Sprintf(categorySuggestionPrompt, known), text, &reply); err != nil {
+		// The suggestion is a convenience; the user can still pick a category
+		logger.FromContext(ctx).Warn("failed to suggest a category with the language model", "error", err)
+		return suggestions, nil
+	}
+	if category := strings.TrimSpace(reply.Category); category != "" {
+		// The model does not tell how sure it is
+		suggestions = append(suggestions, &CategorySuggestion{
+			Category:   category,
+			Confidence: 0.5,
+			Source:     SuggestionSourceAI,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// suggestionWords splits text into distinct lowercase words, leaving out numbers such
+// as amounts and single letters
+func suggestionWords(text string) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) < 2 || strings.IndexFunc(word, unicode.IsLetter) < 0 || slices.Contains(words, word) {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
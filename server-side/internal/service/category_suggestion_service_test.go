@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// fakeDescriptionHistory serves a fixed category history
+type fakeDescriptionHistory struct {
+	repository.MoneyFlowRepository
+
+	history []*domain.DescriptionCategory
+}
+
+func (r *fakeDescriptionHistory) GetDescriptionCategories(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.DescriptionCategory, error) {
+	return r.history, nil
+}
+
+func TestCategorySuggestionServiceSuggest(t *testing.T) {
+	repo := &fakeDescriptionHistory{history: []*domain.DescriptionCategory{
+		{Description: "kopi susu", Category: "makanan", Count: 8},
+		{Description: "grab ke kantor", Category: "transportasi", Count: 3},
+		{Description: "grab food kopi", Category: "makanan", Count: 1},
+	}}
+	suggestions := NewCategorySuggestionService(repo, &fakeCompleter{reply: map[string]interface{}{"category": "lainnya"}})
+
+	tests := []struct {
+		text       string
+		categories []string
+		source     string
+	}{
+		{text: "Kopi susu 25rb", categories: []string{"makanan"}, source: SuggestionSourceHistory},
+		{text: "grab pulang", categories: []string{"transportasi", "makanan"}, source: SuggestionSourceHistory},
+		{text: "bayar listrik", categories: []string{"lainnya"}, source: SuggestionSourceAI},
+		{text: "50000", categories: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := suggestions.Suggest(context.Background(), uuid.New(), tt.text)
+			if err != nil {
+				t.Fatalf("Suggest() error = %v", err)
+			}
+			if len(got) != len(tt.categories) {
+				t.Fatalf("Suggest() = %d suggestions, want %v", len(got), tt.categories)
+			}
+			for i, suggestion := range got {
+				if suggestion.Category != tt.categories[i] || suggestion.Source != tt.source {
+					t.Errorf("suggestion %d = %s from %s, want %s from %s", i, suggestion.Category, suggestion.Source, tt.categories[i], tt.source)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// Steps of the budget setup flow
+const (
+	stepBudgetCategory = "awaiting_category"
+	stepBudgetAmount   = "awaiting_amount"
+	stepBudgetHardness = "awaiting_hardness"
+	stepBudgetMore     = "awaiting_more"
+)
+
+// Reply button actions of the budget setup flow
+const (
+	actionBudgetHard = "budget_hard"
+	actionBudgetSoft = "budget_soft"
+	actionBudgetMore = "budget_more"
+	actionBudgetDone = "budget_done"
+)
+
+// budgetSetupCommands start the budget setup flow
+var budgetSetupCommands = []string{"atur budget", "set budget"}
+
+// maxCategoryLength bounds categories typed in chat
+const maxCategoryLength = 50
+
+func isBudgetSetupCommand(text string) bool {
+	normalized := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	for _, command := range budgetSetupCommands {
+		if normalized == command {
+			return true
+		}
+	}
+	return false
+}
+
+func isCancelCommand(text string) bool {
+	normalized := strings.ToLower(strings.TrimSpace(text))
+	return normalized == "batal" || normalized == "cancel"
+}
+
+// startBudgetSetup starts the budget setup flow by asking for a category
+func (s *ChatService) startBudgetSetup(ctx context.Context, user *domain.User, msg IncomingMessage) error {
+	conversation, err := s.startConversation(ctx, user, msg.ID, domain.FlowBudgetSetup, stepBudgetCategory, domain.BudgetSetup{})
+	if err != nil {
+		return err
+	}
+
+	if err := s.reply(ctx, msg.From, chatText(ctx, "chat.budget.start")); err != nil {
+		return err
+	}
+	return s.sendBudgetSetupQuestion(ctx, msg.From, conversation)
+}
+
+// handleBudgetSetupText applies the answer to a category or amount question
+func (s *ChatService) handleBudgetSetupText(ctx context.Context, msg IncomingMessage, conversation *domain.Conversation) error {
+	var setup domain.BudgetSetup
+	if err := conversation.Decode(&setup); err != nil {
+		return worker.Permanent(err)
+	}
+	if setup.LastMessageID == msg.ID {
+		return nil
+	}
+
+	if isCancelCommand(msg.Text) {
+		return s.finishBudgetSetup(ctx, msg.From, conversation, setup)
+	}
+
+	step := conversation.Step
+	switch conversation.Step {
+	case stepBudgetCategory:
+		category := strings.ToLower(strings.Join(strings.Fields(msg.Text), " "))
+		if len(category) > maxCategoryLength {
+			return s.reply(ctx, msg.From, chatText(ctx, "chat.budget.category_too_long", maxCategoryLength))
+		}
+		setup.Category = category
+		step = stepBudgetAmount
+
+	case stepBudgetAmount:
+		amount, ok := parseChatAmount(msg.Text)
+		if !ok {
+			s.metrics.parse(chatParserAmount, chatParseRejected)
+			return s.reply(ctx, msg.From, chatText(ctx, "chat.budget.amount_unreadable"))
+		}
+		s.metrics.parse(chatParserAmount, chatParseSuccess)
+		setup.Amount = amount
+		step = stepBudgetHardness
+
+	default:
+		// The current question is answered with buttons
+		return s.sendBudgetSetupQuestion(ctx, msg.From, conversation)
+	}
+
+	setup.LastMessageID = msg.ID
+	if err := s.advanceBudgetSetup(ctx, conversation, step, setup); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil
+		}
+		return err
+	}
+	return s.sendBudgetSetupQuestion(ctx, msg.From, conversation)
+}
+
+// handleBudgetSetupButton applies a reply button of the budget setup flow
+func (s *ChatService) handleBudgetSetupButton(ctx context.Context, to, action string, conversation *domain.Conversation) error {
+	var setup domain.BudgetSetup
+	if err := conversation.Decode(&setup); err != nil {
+		return worker.Permanent(err)
+	}
+
+	switch {
+	case conversation.Step == stepBudgetHardness && (action == actionBudgetHard || action == actionBudgetSoft):
+		return s.saveBudget(ctx, to, conversation, setup, action == actionBudgetHard)
+
+	case conversation.Step == stepBudgetMore && action == actionBudgetMore:
+		setup.Category, setup.Amount = "", 0
+		if err := s.advanceBudgetSetup(ctx, conversation, stepBudgetCategory, setup); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return nil
+			}
+			return err
+		}
+		return s.sendBudgetSetupQuestion(ctx, to, conversation)
+
+	case conversation.Step == stepBudgetMore && action == actionBudgetDone:
+		return s.finishBudgetSetup(ctx, to, conversation, setup)
+	}
+
+	// A button of an earlier question
+	return nil
+}
+
+// saveBudget sets the budget through the budget service and moves on to asking for
+// another one, in one transaction so a double tap saves it once
+func (s *ChatService) saveBudget(ctx context.Context, to string, conversation *domain.Conversation, setup domain.BudgetSetup, hard bool) error {
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		status, err := s.budgetService.Set(txCtx, conversation.UserID, setup.Category, BudgetInput{
+			Amount: setup.Amount,
+			Hard:   hard,
+		})
+		if err != nil {
+			return err
+		}
+
+		setup.Saved = append(setup.Saved, domain.BudgetSetupItem{
+			Category: status.Budget.Category,
+			Amount:   domain.MajorUnits(status.Budget.Amount, status.Budget.Currency),
+			Currency: status.Budget.Currency,
+			Hard:     status.Budget.Hard,
+		})
+		setup.Category, setup.Amount = "", 0
+		return s.advanceBudgetSetup(txCtx, conversation, stepBudgetMore, setup)
+	})
+
+	switch {
+	case err == nil:
+		return s.sendBudgetSetupQuestion(ctx, to, conversation)
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrConversationClosed):
+		return nil
+	}
+
+	if appErr, ok := appErrors.IsAppError(err); ok && appErr.HTTPStatus < 500 {
+		return s.reply(ctx, to, chatText(ctx, "chat.budget.not_saved", chatText(ctx, appErr.Message)))
+	}
+	return err
+}
+
+// finishBudgetSetup completes the flow and summarizes the budgets set in it
+func (s *ChatService) finishBudgetSetup(ctx context.Context, to string, conversation *domain.Conversation, setup domain.BudgetSetup) error {
+	if err := conversation.Complete(setup); err != nil {
+		return nil
+	}
+	if err := s.conversationRepo.Update(ctx, conversation); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil
+		}
+		return err
+	}
+
+	if len(setup.Saved) == 0 {
+		return s.reply(ctx, to, chatText(ctx, "chat.budget.unchanged"))
+	}
+
+	lines := []string{chatText(ctx, "chat.budget.saved")}
+	for _, item := range setup.Saved {
+		kind := chatText(ctx, "chat.budget.soft")
+		if item.Hard {
+			kind = chatText(ctx, "chat.budget.hard")
+		}
+		lines = append(lines, chatText(ctx, "chat.budget.saved_item", item.Category, item.Currency, formatAmount(item.Amount), kind))
+	}
+	lines = append(lines, "", chatText(ctx, "chat.budget.saved_footer"))
+	return s.reply(ctx, to, strings.Join(lines, "\n"))
+}
+
+// advanceBudgetSetup moves the flow to the next step and gives the user another TTL to answer
+func (s *ChatService) advanceBudgetSetup(ctx context.Context, conversation *domain.Conversation, step string, setup domain.BudgetSetup) error {
+	if err := conversation.Advance(step, setup, s.config.ConfirmationTTL); err != nil {
+		return err
+	}
+	return s.conversationRepo.Update(ctx, conversation)
+}
+
+// sendBudgetSetupQuestion asks the question of the current step
+func (s *ChatService) sendBudgetSetupQuestion(ctx context.Context, to string, conversation *domain.Conversation) error {
+	var setup domain.BudgetSetup
+	if err := conversation.Decode(&setup); err != nil {
+		return worker.Permanent(err)
+	}
+
+	id := conversation.ID.String()
+	switch conversation.Step {
+	case stepBudgetCategory:
+		return s.reply(ctx, to, chatText(ctx, "chat.budget.ask_category"))
+
+	case stepBudgetAmount:
+		return s.reply(ctx, to, chatText(ctx, "chat.budget.ask_amount", setup.Category))
+
+	case stepBudgetHardness:
+		_, err := s.messenger.SendInteractive(ctx, to, whatsapp.Interactive{
+			Body: chatText(ctx, "chat.budget.ask_hardness", setup.Category, formatAmount(setup.Amount)),
+			Buttons: []whatsapp.Button{
+				{ID: actionBudgetHard + ":" + id, Title: chatText(ctx, "chat.button.budget_hard")},
+				{ID: actionBudgetSoft + ":" + id, Title: chatText(ctx, "chat.button.budget_soft")},
+			},
+		})
+		return sendError(err)
+
+	case stepBudgetMore:
+		_, err := s.messenger.SendInteractive(ctx, to, whatsapp.Interactive{
+			Body: chatText(ctx, "chat.budget.ask_more", setup.Saved[len(setup.Saved)-1].Category),
+			Buttons: []whatsapp.Button{
+				{ID: actionBudgetMore + ":" + id, Title: chatText(ctx, "chat.button.budget_more")},
+				{ID: actionBudgetDone + ":" + id, Title: chatText(ctx, "chat.button.budget_done")},
+			},
+		})
+		return sendError(err)
+	}
+
+	return nil
+}
+
+// parseChatAmount reads an amount typed in chat: "1500000", "1.500.000", "1,5jt", "500rb", "rp 25k"
+func parseChatAmount(text string) (float64, bool) {
+	value := strings.ToLower(strings.Join(strings.Fields(text), ""))
+	for _, prefix := range []string{"rp.", "rp", "idr"} {
+		value = strings.TrimPrefix(value, prefix)
+	}
+
+	multiplier := 1.0
+	for _, suffix := range []struct {
+		text  string
+		value float64
+	}{
+		{"juta", 1e6}, {"jt", 1e6}, {"ribu", 1e3}, {"rb", 1e3}, {"k", 1e3},
+	} {
+		if trimmed, ok := strings.CutSuffix(value, suffix.text); ok {
+			value, multiplier = trimmed, suffix.value
+			break
+		}
+	}
+
+	if multiplier > 1 {
+		// "1,5jt" and "1.5jt" both mean one and a half million
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		// Indonesian formatting: "." groups thousands, "," starts the decimals
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+	return amount * multiplier, true
+}
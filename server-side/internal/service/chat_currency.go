@@ -0,0 +1,144 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultCurrencyAliases maps currency symbols, codes, and names written in chat, in
+// lowercase, to ISO 4217 codes
+var DefaultCurrencyAliases = map[string]string{
+	"rp": "IDR", "rp.": "IDR", "idr": "IDR", "rupiah": "IDR",
+	"$": "USD", "us$": "USD", "usd": "USD", "dollar": "USD", "dollars": "USD", "dolar": "USD",
+	"us dollar": "USD", "us dollars": "USD", "dolar as": "USD",
+	"s$": "SGD", "sgd": "SGD", "singapore dollar": "SGD", "singapore dollars": "SGD", "dolar singapura": "SGD",
+	"rm": "MYR", "myr": "MYR", "ringgit": "MYR",
+	"a$": "AUD", "aud": "AUD", "australian dollar": "AUD", "australian dollars": "AUD", "dolar australia": "AUD",
+	"hk$": "HKD", "hkd": "HKD",
+	"€": "EUR", "eur": "EUR", "euro": "EUR", "euros": "EUR",
+	"£": "GBP", "gbp": "GBP", "pound": "GBP", "pounds": "GBP", "poundsterling": "GBP",
+	"¥": "JPY", "jpy": "JPY", "yen": "JPY",
+	"cny": "CNY", "rmb": "CNY", "yuan": "CNY", "renminbi": "CNY",
+	"₩": "KRW", "krw": "KRW", "won": "KRW",
+	"฿": "THB", "thb": "THB", "baht": "THB",
+	"₱": "PHP", "php": "PHP", "peso": "PHP", "pesos": "PHP",
+	"₫": "VND", "vnd": "VND", "dong": "VND",
+	"₹": "INR", "inr": "INR", "rupee": "INR", "rupees": "INR",
+	"sar": "SAR", "riyal": "SAR",
+}
+
+var (
+	chatNumberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+
+	// chatMultipliers are the shorthand written between an amount and its currency,
+	// as in "5k yen" or "1,5jt rupiah"
+	chatMultipliers = []string{"thousand", "juta", "ribu", "jt", "rb", "k", "m"}
+)
+
+// CurrencyDetector finds the currency of an amount in chat text, such as "$5",
+// "SGD 12", or "20 ringgit". A currency only counts when it is written right before
+// or after a number, so words like "won" or "dong" elsewhere in a sentence are ignored.
+type CurrencyDetector struct {
+	aliases map[string]string
+	// longest first, so "us$" is tried before "$"
+	ordered []string
+}
+
+// NewCurrencyDetector creates a detector for DefaultCurrencyAliases with the extra
+// aliases added. An extra alias with an empty code removes a default one.
+func NewCurrencyDetector(extra map[string]string) *CurrencyDetector {
+	d := &CurrencyDetector{aliases: make(map[string]string, len(DefaultCurrencyAliases)+len(extra))}
+	for alias, code := range DefaultCurrencyAliases {
+		d.aliases[alias] = code
+	}
+	for alias, code := range extra {
+		alias = strings.ToLower(strings.Join(strings.Fields(alias), " "))
+		if code == "" {
+			delete(d.aliases, alias)
+			continue
+		}
+		if alias != "" {
+			d.aliases[alias] = strings.ToUpper(code)
+		}
+	}
+
+	for alias := range d.aliases {
+		d.ordered = append(d.ordered, alias)
+	}
+	sort.Slice(d.ordered, func(i, j int) bool {
+		if len(d.ordered[i]) != len(d.ordered[j]) {
+			return len(d.ordered[i]) > len(d.ordered[j])
+		}
+		return d.ordered[i] < d.ordered[j]
+	})
+
+	return d
+}
+
+// Detect returns the ISO 4217 code of the first amount in the text that names its
+// currency, or "" when none does
+func (d *CurrencyDetector) Detect(text string) string {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+
+	for _, match := range chatNumberPattern.FindAllStringIndex(text, -1) {
+		if code := d.before(text[:match[0]]); code != "" {
+			return code
+		}
+		if code := d.after(text[match[1]:]); code != "" {
+			return code
+		}
+	}
+	return ""
+}
+
+// before finds an alias ending the text in front of a number
+func (d *CurrencyDetector) before(text string) string {
+	text = strings.TrimRight(text, " ")
+	for _, alias := range d.ordered {
+		if strings.HasSuffix(text, alias) && boundaryBefore(text[:len(text)-len(alias)], alias) {
+			return d.aliases[alias]
+		}
+	}
+	return ""
+}
+
+// after finds an alias starting the text behind a number, skipping a multiplier
+func (d *CurrencyDetector) after(text string) string {
+	text = strings.TrimLeft(text, " ")
+	for _, multiplier := range chatMultipliers {
+		if rest, ok := strings.CutPrefix(text, multiplier); ok && boundaryAfter(rest, multiplier) {
+			if code := d.startsWith(strings.TrimLeft(rest, " ")); code != "" {
+				return code
+			}
+		}
+	}
+	return d.startsWith(text)
+}
+
+func (d *CurrencyDetector) startsWith(text string) string {
+	for _, alias := range d.ordered {
+		if strings.HasPrefix(text, alias) && boundaryAfter(text[len(alias):], alias) {
+			return d.aliases[alias]
+		}
+	}
+	return ""
+}
+
+// boundaryBefore reports whether an alias starting with a letter is not the end of a
+// longer word, as "rm" in "farm"
+func boundaryBefore(preceding, alias string) bool {
+	first, _ := utf8.DecodeRuneInString(alias)
+	last, _ := utf8.DecodeLastRuneInString(preceding)
+	return !unicode.IsLetter(first) || !unicode.IsLetter(last)
+}
+
+// boundaryAfter reports whether an alias ending with a letter is not the start of a
+// longer word, as "won" in "wonderful"
+func boundaryAfter(following, alias string) bool {
+	last, _ := utf8.DecodeLastRuneInString(alias)
+	next, _ := utf8.DecodeRuneInString(following)
+	return !unicode.IsLetter(last) || !unicode.IsLetter(next)
+}
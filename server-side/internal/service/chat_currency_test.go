@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestCurrencyDetectorDetect(t *testing.T) {
+	detector := NewCurrencyDetector(nil)
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		// Symbols before the amount
+		{"coffee $5", "USD"},
+		{"Taxi US$12.50", "USD"},
+		{"kopi S$4.50", "SGD"},
+		{"lunch €15", "EUR"},
+		{"tiket kereta ¥2000", "JPY"},
+		{"parkir Rp 5.000", "IDR"},
+		{"rp25k bensin", "IDR"},
+
+		// Codes and names before or after the amount
+		{"SGD 12 makan siang", "SGD"},
+		{"makan siang 12 sgd", "SGD"},
+		{"12sgd hawker", "SGD"},
+		{"20 ringgit nasi lemak", "MYR"},
+		{"RM 8 teh tarik", "MYR"},
+		{"bayar hotel 100 dolar singapura", "SGD"},
+		{"beli oleh-oleh 30 dolar", "USD"},
+		{"souvenir 50 euro", "EUR"},
+		{"fish and chips 9 pounds", "GBP"},
+		{"ramen 1200 yen", "JPY"},
+		{"bibimbap 12000 won", "KRW"},
+		{"pad thai 80 baht", "THB"},
+		{"pho 50000 dong", "VND"},
+		{"jollibee 150 pesos", "PHP"},
+
+		// Shorthand between the amount and the currency
+		{"hotel 5k yen", "JPY"},
+		{"sewa mobil 1,5jt rupiah", "IDR"},
+
+		// The first amount that names its currency wins
+		{"2 porsi sate 30 ringgit", "MYR"},
+
+		// No currency hint
+		{"makan siang 25rb", ""},
+		{"bensin 50000", ""},
+		{"uang kos bulan ini", ""},
+
+		// Aliases inside other words or away from an amount do not count
+		{"farm 5000", ""},
+		{"we won the lottery, spent 50", ""},
+		{"5 museum tickets", ""},
+		{"bus 5", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := detector.Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyDetectorConfiguredAliases(t *testing.T) {
+	detector := NewCurrencyDetector(map[string]string{
+		"Sing":             "sgd",
+		"ringgit malaysia": "MYR",
+		"$":                "SGD",
+		"won":              "",
+	})
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"chicken rice 5 sing", "SGD"},
+		{"nasi lemak 10 ringgit malaysia", "MYR"},
+		{"kopi $3", "SGD"},
+		{"US$3", "USD"},
+		{"bibimbap 12000 won", ""},
+		{"bibimbap 12000 krw", "KRW"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := detector.Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeCompleter answers every completion with reply
+type fakeCompleter struct {
+	reply map[string]interface{}
+}
+
+func (c *fakeCompleter) Enabled() bool { return true }
+
+func (c *fakeCompleter) CompleteJSON(ctx context.Context, system, user string, out interface{}) error {
+	data, err := json.Marshal(c.reply)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func TestAIExpenseParserCurrency(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		modelCurrency string
+		want          string
+	}{
+		{"detected when the model names none", "coffee $5", "", "USD"},
+		{"detected over the model", "SGD 12 makan siang", "USD", "SGD"},
+		{"model answer without a hint", "makan di Singapura 12", "SGD", "SGD"},
+		{"empty without either, for the user's default", "makan siang 25rb", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewAIExpenseParser(&fakeCompleter{reply: map[string]interface{}{
+				"is_expense": true,
+				"amount":     12,
+				"currency":   tt.modelCurrency,
+			}}, NewCurrencyDetector(nil))
+
+			parsed, err := parser.Parse(context.Background(), tt.text)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if parsed.Currency != tt.want {
+				t.Errorf("Parse(%q).Currency = %q, want %q", tt.text, parsed.Currency, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// feedbackCommand starts a message sent to the team as feedback, e.g.
+// "lapor tombol simpan tidak bisa ditekan"
+const feedbackCommand = "lapor"
+
+// parseFeedbackCommand reports whether text is the feedback command and returns the
+// feedback that follows it, which is empty when the user sent the command alone
+func parseFeedbackCommand(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if len(text) < len(feedbackCommand) || !strings.EqualFold(text[:len(feedbackCommand)], feedbackCommand) {
+		return "", false
+	}
+
+	rest := text[len(feedbackCommand):]
+	if rest != "" && !strings.ContainsAny(rest[:1], " \t\n:") {
+		return "", false // a word starting with "lapor", e.g. "laporan"
+	}
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), ":")), true
+}
+
+// handleFeedback records the feedback sent with the "lapor" command
+func (s *ChatService) handleFeedback(ctx context.Context, user *domain.User, msg IncomingMessage, message string) error {
+	if message == "" {
+		return s.reply(ctx, msg.From, chatText(ctx, "chat.feedback.empty"))
+	}
+
+	_, err := s.feedbackService.Submit(ctx, user.ID, FeedbackInput{
+		Kind:      domain.FeedbackKindFeedback,
+		Source:    domain.FeedbackSourceChat,
+		Message:   message,
+		RequestID: msg.ID,
+	})
+	if err != nil {
+		// WhatsApp redelivered the message; the user was already thanked
+		if errors.Is(err, domain.ErrConflict) {
+			return nil
+		}
+		return err
+	}
+
+	return s.reply(ctx, msg.From, chatText(ctx, "chat.feedback.thanks"))
+}
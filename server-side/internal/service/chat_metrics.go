@@ -0,0 +1,89 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/metrics"
+)
+
+// Kinds of chat input counted by ChatMetrics
+const (
+	chatInputText   = "text"
+	chatInputButton = "button"
+	chatInputOther  = "other"
+)
+
+// Chat commands counted by ChatMetrics. Text that matches no command falls back to the
+// language model as an expense.
+const (
+	chatCommandExpense           = "expense"
+	chatCommandBudgetSetup       = "budget_setup"
+	chatCommandBudgetSetupAnswer = "budget_setup_answer"
+	chatCommandFeedback          = "feedback"
+	chatCommandRedelivery        = "redelivery"
+	chatCommandConfirm           = "confirm"
+	chatCommandOverride          = "override"
+	chatCommandCancel            = "cancel"
+	chatCommandBudgetSetupButton = "budget_setup_button"
+	chatCommandExpiredButton     = "expired_button"
+	chatCommandIgnoredButton     = "ignored_button"
+	chatCommandUnlinked          = "unlinked_number"
+	chatCommandDisabled          = "disabled_account"
+	chatCommandUnsupported       = "unsupported_message"
+)
+
+// Parsers and outcomes counted by ChatMetrics
+const (
+	chatParserLLM    = "llm"
+	chatParserAmount = "amount"
+
+	chatParseSuccess     = "success"
+	chatParseRejected    = "rejected"    // read, but not an expense or not an amount
+	chatParseError       = "error"       // the parser failed
+	chatParseUnavailable = "unavailable" // the language model is not configured
+)
+
+// ChatMetrics counts chat commands and parse outcomes and times the language model.
+// A nil *ChatMetrics records nothing.
+type ChatMetrics struct {
+	commands      *metrics.CounterVec
+	parses        *metrics.CounterVec
+	parseDuration *metrics.HistogramVec
+}
+
+// NewChatMetrics registers the chat metrics on the registry
+func NewChatMetrics(registry *metrics.Registry) *ChatMetrics {
+	return &ChatMetrics{
+		commands: registry.NewCounterVec("catetin_chat_commands_total",
+			"Chat messages handled, by input kind and the command they were routed to.",
+			"input", "command"),
+		parses: registry.NewCounterVec("catetin_chat_parses_total",
+			"Chat messages parsed, by parser and outcome.",
+			"parser", "result"),
+		parseDuration: registry.NewHistogramVec("catetin_chat_llm_parse_duration_seconds",
+			"Time the language model took to parse a chat message.",
+			metrics.DefaultLatencyBuckets, "result"),
+	}
+}
+
+func (m *ChatMetrics) command(input, command string) {
+	if m == nil {
+		return
+	}
+	m.commands.Inc(input, command)
+}
+
+func (m *ChatMetrics) parse(parser, result string) {
+	if m == nil {
+		return
+	}
+	m.parses.Inc(parser, result)
+}
+
+func (m *ChatMetrics) llmParse(result string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.parses.Inc(chatParserLLM, result)
+	m.parseDuration.Observe(duration.Seconds(), result)
+}
@@ -0,0 +1,638 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// Chat job types
+const (
+	// JobChatMessage processes an incoming chat message
+	JobChatMessage = "chat.message"
+
+	// JobChatConversationExpiry expires a conversation the user did not finish in time
+	JobChatConversationExpiry = "chat.conversation_expiry"
+)
+
+// Steps of the expense confirmation flow
+const (
+	stepAwaitingConfirmation = "awaiting_confirmation"
+	stepAwaitingOverride     = "awaiting_override"
+)
+
+// Reply button actions; button IDs are "<action>:<conversation ID>"
+const (
+	actionConfirm  = "expense_confirm"
+	actionOverride = "expense_override"
+	actionCancel   = "expense_cancel"
+)
+
+// ChatMessenger sends chat replies to a phone number
+type ChatMessenger interface {
+	SendText(ctx context.Context, to, body string) (string, error)
+	SendInteractive(ctx context.Context, to string, interactive whatsapp.Interactive) (string, error)
+}
+
+// JobEnqueuer stores background jobs
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...worker.EnqueueOption) (*worker.Job, error)
+}
+
+// IncomingMessage is a chat message received from a user
+type IncomingMessage struct {
+	ID   string `json:"id"`
+	From string `json:"from"` // sender phone number in international format without "+"
+	Text string `json:"text,omitempty"`
+
+	// ButtonID is set when the user tapped a reply button
+	ButtonID string `json:"button_id,omitempty"`
+}
+
+// ChatConfig holds the chat settings
+type ChatConfig struct {
+	// ConfirmationTTL is how long a conversation waits for the user's next reply
+	ConfirmationTTL time.Duration
+}
+
+// ChatService turns chat messages into money flows and budgets through conversations
+type ChatService struct {
+	userRepo         repository.UserRepository
+	conversationRepo repository.ConversationRepository
+	settingsRepo     repository.UserSettingsRepository
+	moneyFlowService *MoneyFlowService
+	budgetService    *BudgetService
+	feedbackService  *FeedbackService
+	parser           ExpenseParser
+	messenger        ChatMessenger
+	jobs             JobEnqueuer
+	txManager        repository.TransactionManager
+	metrics          *ChatMetrics
+	config           ChatConfig
+}
+
+// NewChatService creates a new chat service
+func NewChatService(
+	userRepo repository.UserRepository,
+	conversationRepo repository.ConversationRepository,
+	settingsRepo repository.UserSettingsRepository,
+	moneyFlowService *MoneyFlowService,
+	budgetService *BudgetService,
+	feedbackService *FeedbackService,
+	parser ExpenseParser,
+	messenger ChatMessenger,
+	jobs JobEnqueuer,
+	txManager repository.TransactionManager,
+	metrics *ChatMetrics,
+	config ChatConfig,
+) *ChatService {
+	if config.ConfirmationTTL <= 0 {
+		config.ConfirmationTTL = 10 * time.Minute
+	}
+
+	return &ChatService{
+		userRepo:         userRepo,
+		conversationRepo: conversationRepo,
+		settingsRepo:     settingsRepo,
+		moneyFlowService: moneyFlowService,
+		budgetService:    budgetService,
+		feedbackService:  feedbackService,
+		parser:           parser,
+		messenger:        messenger,
+		jobs:             jobs,
+		txManager:        txManager,
+		metrics:          metrics,
+		config:           config,
+	}
+}
+
+// Receive queues an incoming message for processing, so the webhook can answer quickly
+func (s *ChatService) Receive(ctx context.Context, msg IncomingMessage) error {
+	if _, err := s.jobs.Enqueue(ctx, JobChatMessage, msg); err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to queue chat message", 500)
+	}
+	return nil
+}
+
+// HandleMessageJob processes a JobChatMessage
+func (s *ChatService) HandleMessageJob(ctx context.Context, job *worker.Job) error {
+	var msg IncomingMessage
+	if err := job.Decode(&msg); err != nil {
+		return worker.Permanent(err)
+	}
+	return s.HandleMessage(ctx, msg)
+}
+
+// ExpireConversationJob processes a JobChatConversationExpiry
+func (s *ChatService) ExpireConversationJob(ctx context.Context, job *worker.Job) error {
+	var payload struct {
+		ConversationID uuid.UUID `json:"conversation_id"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+	return s.ExpireConversation(ctx, payload.ConversationID)
+}
+
+// HandleMessage replies to a message, in the language of the user's locale setting. Text
+// answers the question of an active budget setup, starts one on "atur budget", records
+// feedback sent with "lapor", and otherwise starts an expense confirmation; button taps
+// move the conversation they belong to.
+func (s *ChatService) HandleMessage(ctx context.Context, msg IncomingMessage) error {
+	ctx, span := tracing.Start(ctx, "ChatService.HandleMessage")
+	defer span.End()
+
+	user, err := s.findUser(ctx, msg.From)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.metrics.command(chatInputOther, chatCommandUnlinked)
+			ctx = i18n.WithLanguage(ctx, i18n.FromLocale(domain.DefaultLocale))
+			return s.reply(ctx, msg.From, chatText(ctx, "chat.unlinked"))
+		}
+		return err
+	}
+	if ctx, err = s.withUserLanguage(ctx, user.ID); err != nil {
+		return err
+	}
+	if user.IsDisabled() {
+		s.metrics.command(chatInputOther, chatCommandDisabled)
+		return s.reply(ctx, msg.From, chatText(ctx, "chat.disabled"))
+	}
+
+	if msg.ButtonID != "" {
+		return s.handleButton(ctx, user, msg.ButtonID)
+	}
+
+	if strings.TrimSpace(msg.Text) == "" {
+		s.metrics.command(chatInputOther, chatCommandUnsupported)
+		return s.reply(ctx, msg.From, chatText(ctx, "chat.text_only"))
+	}
+
+	return s.handleText(ctx, user, msg)
+}
+
+// ExpireConversation ends a conversation that is still waiting for the user after its TTL
+func (s *ChatService) ExpireConversation(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "ChatService.ExpireConversation")
+	defer span.End()
+
+	conversation, err := s.conversationRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if conversation.Status != domain.ConversationActive {
+		return nil
+	}
+	// The conversation was extended after this job was queued
+	if time.Now().Before(conversation.ExpiresAt) {
+		return s.scheduleExpiry(ctx, conversation)
+	}
+
+	if err := conversation.Expire(); err != nil {
+		return nil
+	}
+	if err := s.conversationRepo.Update(ctx, conversation); err != nil {
+		// The user answered while the job ran
+		if errors.Is(err, domain.ErrConflict) {
+			return nil
+		}
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, conversation.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if ctx, err = s.withUserLanguage(ctx, user.ID); err != nil {
+		return err
+	}
+	message := chatText(ctx, "chat.expense.expired")
+	if conversation.Flow == domain.FlowBudgetSetup {
+		message = chatText(ctx, "chat.budget.expired")
+	}
+	return s.reply(ctx, whatsAppNumber(user.PhoneNumber), message)
+}
+
+func (s *ChatService) handleText(ctx context.Context, user *domain.User, msg IncomingMessage) error {
+	// WhatsApp redelivers messages until the webhook answers; ask again instead of
+	// starting a second conversation
+	existing, err := s.conversationRepo.FindBySourceMessageID(ctx, msg.ID)
+	if err == nil {
+		s.metrics.command(chatInputText, chatCommandRedelivery)
+		if existing.IsActive(time.Now()) {
+			return s.resendQuestion(ctx, msg.From, existing)
+		}
+		return nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	if isBudgetSetupCommand(msg.Text) {
+		s.metrics.command(chatInputText, chatCommandBudgetSetup)
+		return s.startBudgetSetup(ctx, user, msg)
+	}
+
+	if feedback, ok := parseFeedbackCommand(msg.Text); ok {
+		s.metrics.command(chatInputText, chatCommandFeedback)
+		return s.handleFeedback(ctx, user, msg, feedback)
+	}
+
+	active, err := s.conversationRepo.FindActiveByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if active != nil && active.IsActive(time.Now()) && active.Flow == domain.FlowBudgetSetup {
+		s.metrics.command(chatInputText, chatCommandBudgetSetupAnswer)
+		return s.handleBudgetSetupText(ctx, msg, active)
+	}
+
+	s.metrics.command(chatInputText, chatCommandExpense)
+	return s.handleExpenseText(ctx, user, msg)
+}
+
+// handleExpenseText parses an expense and asks the user to confirm it
+func (s *ChatService) handleExpenseText(ctx context.Context, user *domain.User, msg IncomingMessage) error {
+	parsed, err := s.parseExpense(ctx, msg.Text)
+	if err != nil {
+		if errors.Is(err, ErrParserUnavailable) {
+			return s.reply(ctx, msg.From, chatText(ctx, "chat.parser_unavailable"))
+		}
+		return err
+	}
+
+	if !parsed.IsExpense {
+		return s.reply(ctx, msg.From, chatText(ctx, "chat.not_an_expense"))
+	}
+
+	pending := domain.PendingExpense{
+		Amount:      parsed.Amount,
+		Currency:    parsed.Currency,
+		Category:    parsed.Category,
+		Description: parsed.Description,
+	}
+	if pending.Currency == "" {
+		pending.Currency, err = s.defaultCurrency(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	conversation, err := s.startConversation(ctx, user, msg.ID, domain.FlowExpenseConfirmation, stepAwaitingConfirmation, pending)
+	if err != nil {
+		return err
+	}
+
+	return s.sendPrompt(ctx, msg.From, conversation)
+}
+
+// parseExpense runs the parser and records its outcome and latency
+func (s *ChatService) parseExpense(ctx context.Context, text string) (*ParsedExpense, error) {
+	start := time.Now()
+	parsed, err := s.parser.Parse(ctx, text)
+
+	result := chatParseSuccess
+	switch {
+	case errors.Is(err, ErrParserUnavailable):
+		// Nothing was parsed, so there is no latency to record
+		s.metrics.parse(chatParserLLM, chatParseUnavailable)
+		return nil, err
+	case err != nil:
+		result = chatParseError
+	case !parsed.IsExpense:
+		result = chatParseRejected
+	}
+	s.metrics.llmParse(result, time.Since(start))
+
+	return parsed, err
+}
+
+// startConversation starts a flow for the message, replacing the user's active
+// conversation, and schedules its expiry
+func (s *ChatService) startConversation(ctx context.Context, user *domain.User, messageID, flow, step string, data interface{}) (*domain.Conversation, error) {
+	conversation, err := domain.NewConversation(user.ID, flow, step, data, s.config.ConfirmationTTL)
+	if err != nil {
+		return nil, worker.Permanent(err)
+	}
+	conversation.SourceMessageID = &messageID
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		active, err := s.conversationRepo.FindActiveByUserID(txCtx, user.ID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if active != nil && active.Cancel() == nil {
+			if err := s.conversationRepo.Update(txCtx, active); err != nil {
+				return err
+			}
+		}
+
+		if err := s.conversationRepo.Create(txCtx, conversation); err != nil {
+			return err
+		}
+		return s.scheduleExpiry(txCtx, conversation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return conversation, nil
+}
+
+// scheduleExpiry queues the expiry of a conversation at its ExpiresAt
+func (s *ChatService) scheduleExpiry(ctx context.Context, conversation *domain.Conversation) error {
+	_, err := s.jobs.Enqueue(ctx, JobChatConversationExpiry,
+		map[string]interface{}{"conversation_id": conversation.ID},
+		worker.WithRunAt(conversation.ExpiresAt),
+	)
+	return err
+}
+
+// resendQuestion repeats the current question of a conversation
+func (s *ChatService) resendQuestion(ctx context.Context, to string, conversation *domain.Conversation) error {
+	if conversation.Flow == domain.FlowBudgetSetup {
+		return s.sendBudgetSetupQuestion(ctx, to, conversation)
+	}
+	return s.sendPrompt(ctx, to, conversation)
+}
+
+func (s *ChatService) handleButton(ctx context.Context, user *domain.User, buttonID string) error {
+	to := whatsAppNumber(user.PhoneNumber)
+
+	action, rawID, _ := strings.Cut(buttonID, ":")
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		s.metrics.command(chatInputButton, chatCommandIgnoredButton)
+		return nil
+	}
+
+	conversation, err := s.conversationRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.metrics.command(chatInputButton, chatCommandIgnoredButton)
+			return nil
+		}
+		return err
+	}
+
+	// Ignore taps on buttons sent to someone else, and repeated taps
+	if conversation.UserID != user.ID {
+		s.metrics.command(chatInputButton, chatCommandIgnoredButton)
+		return nil
+	}
+	if !conversation.IsActive(time.Now()) {
+		s.metrics.command(chatInputButton, chatCommandExpiredButton)
+		if conversation.Status == domain.ConversationActive || conversation.Status == domain.ConversationExpired {
+			return s.reply(ctx, to, chatText(ctx, "chat.option_expired"))
+		}
+		return nil
+	}
+
+	if conversation.Flow == domain.FlowBudgetSetup {
+		s.metrics.command(chatInputButton, chatCommandBudgetSetupButton)
+		return s.handleBudgetSetupButton(ctx, to, action, conversation)
+	}
+
+	switch action {
+	case actionConfirm:
+		s.metrics.command(chatInputButton, chatCommandConfirm)
+		return s.recordExpense(ctx, to, conversation, false)
+	case actionOverride:
+		s.metrics.command(chatInputButton, chatCommandOverride)
+		if conversation.Step != stepAwaitingOverride {
+			return nil
+		}
+		return s.recordExpense(ctx, to, conversation, true)
+	case actionCancel:
+		s.metrics.command(chatInputButton, chatCommandCancel)
+		if err := conversation.Cancel(); err != nil {
+			return nil
+		}
+		if err := s.conversationRepo.Update(ctx, conversation); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return nil
+			}
+			return err
+		}
+		return s.reply(ctx, to, chatText(ctx, "chat.expense.cancelled"))
+	}
+
+	s.metrics.command(chatInputButton, chatCommandIgnoredButton)
+	return nil
+}
+
+// recordExpense creates the money flow of a confirmed conversation and completes it
+// in the same transaction, so a double tap records the expense once
+func (s *ChatService) recordExpense(ctx context.Context, to string, conversation *domain.Conversation, overrideBudget bool) error {
+	var pending domain.PendingExpense
+	if err := conversation.Decode(&pending); err != nil {
+		return worker.Permanent(err)
+	}
+
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		detail, err := s.moneyFlowService.Create(txCtx, conversation.UserID, MoneyFlowInput{
+			Amount:         pending.Amount,
+			Currency:       pending.Currency,
+			Category:       pending.Category,
+			Description:    pending.Description,
+			OverrideBudget: overrideBudget,
+			// The user confirmed the expense in the chat already
+			AllowDuplicate: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		pending.MoneyFlowID = &detail.MoneyFlow.ID
+		if err := conversation.Complete(pending); err != nil {
+			return err
+		}
+		return s.conversationRepo.Update(txCtx, conversation)
+	})
+
+	switch {
+	case err == nil:
+		return s.reply(ctx, to, chatText(ctx, "chat.expense.recorded", describeExpense(ctx, pending)))
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrConversationClosed):
+		// Another tap got there first
+		return nil
+	case appErrors.GetErrorCode(err) == appErrors.ErrCodeBudgetExceeded:
+		return s.askOverride(ctx, to, conversation, pending)
+	}
+
+	if appErr, ok := appErrors.IsAppError(err); ok && appErr.HTTPStatus < 500 {
+		logger.FromContext(ctx).Info("chat expense rejected", "conversation_id", conversation.ID, "code", appErr.Code)
+		if cancelErr := conversation.Cancel(); cancelErr == nil {
+			if err := s.conversationRepo.Update(ctx, conversation); err != nil && !errors.Is(err, domain.ErrConflict) {
+				return err
+			}
+		}
+		return s.reply(ctx, to, chatText(ctx, "chat.expense.rejected", chatText(ctx, appErr.Message)))
+	}
+
+	return err
+}
+
+// askOverride moves a confirmation over a hard budget to the override step
+func (s *ChatService) askOverride(ctx context.Context, to string, conversation *domain.Conversation, pending domain.PendingExpense) error {
+	pending.MoneyFlowID = nil
+	if err := conversation.Advance(stepAwaitingOverride, pending, s.config.ConfirmationTTL); err != nil {
+		return nil
+	}
+	if err := s.conversationRepo.Update(ctx, conversation); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil
+		}
+		return err
+	}
+
+	category := chatText(ctx, "chat.expense.this_category")
+	if pending.Category != nil {
+		category = chatText(ctx, "chat.expense.category", *pending.Category)
+	}
+
+	_, err := s.messenger.SendInteractive(ctx, to, whatsapp.Interactive{
+		Body: chatText(ctx, "chat.expense.over_budget", category, describeExpense(ctx, pending)),
+		Buttons: []whatsapp.Button{
+			{ID: actionOverride + ":" + conversation.ID.String(), Title: chatText(ctx, "chat.button.record_anyway")},
+			{ID: actionCancel + ":" + conversation.ID.String(), Title: chatText(ctx, "chat.button.cancel")},
+		},
+	})
+	return sendError(err)
+}
+
+// sendPrompt asks the user to confirm the pending expense of a conversation
+func (s *ChatService) sendPrompt(ctx context.Context, to string, conversation *domain.Conversation) error {
+	var pending domain.PendingExpense
+	if err := conversation.Decode(&pending); err != nil {
+		return worker.Permanent(err)
+	}
+
+	_, err := s.messenger.SendInteractive(ctx, to, whatsapp.Interactive{
+		Body:   chatText(ctx, "chat.expense.prompt", describeExpense(ctx, pending)),
+		Footer: chatText(ctx, "chat.expense.valid_for", int(s.config.ConfirmationTTL.Minutes())),
+		Buttons: []whatsapp.Button{
+			{ID: actionConfirm + ":" + conversation.ID.String(), Title: chatText(ctx, "chat.button.save")},
+			{ID: actionCancel + ":" + conversation.ID.String(), Title: chatText(ctx, "chat.button.cancel")},
+		},
+	})
+	return sendError(err)
+}
+
+// chatText returns a chat message in the language of the user being replied to, set by
+// withUserLanguage
+func chatText(ctx context.Context, key string, args ...interface{}) string {
+	return i18n.T(i18n.FromContext(ctx), key, args...)
+}
+
+func (s *ChatService) reply(ctx context.Context, to, body string) error {
+	_, err := s.messenger.SendText(ctx, to, body)
+	return sendError(err)
+}
+
+// sendError marks send failures that a retry cannot fix as permanent
+func sendError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, whatsapp.ErrNotConfigured),
+		errors.Is(err, whatsapp.ErrOutsideWindow),
+		errors.Is(err, whatsapp.ErrRecipientUnreachable),
+		errors.Is(err, whatsapp.ErrInvalidRequest):
+		return worker.Permanent(err)
+	}
+	return err
+}
+
+// findUser finds the user of a WhatsApp number, stored with or without the leading "+"
+func (s *ChatService) findUser(ctx context.Context, from string) (*domain.User, error) {
+	for _, phoneNumber := range []string{"+" + from, from} {
+		user, err := s.userRepo.FindByPhoneNumber(ctx, phoneNumber)
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+// withUserLanguage returns a context carrying the language of the user's locale setting,
+// which replies to the user are written in
+func (s *ChatService) withUserLanguage(ctx context.Context, userID uuid.UUID) (context.Context, error) {
+	locale := domain.DefaultLocale
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		locale = settings.Locale
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return ctx, err
+	}
+	return i18n.WithLanguage(ctx, i18n.FromLocale(locale)), nil
+}
+
+func (s *ChatService) defaultCurrency(ctx context.Context, userID uuid.UUID) (string, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultCurrency, nil
+		}
+		return "", err
+	}
+	return settings.DefaultCurrency, nil
+}
+
+// whatsAppNumber converts a stored phone number to the format WhatsApp expects
+func whatsAppNumber(phoneNumber string) string {
+	return strings.TrimPrefix(phoneNumber, "+")
+}
+
+// describeExpense renders a pending expense for chat messages
+func describeExpense(ctx context.Context, pending domain.PendingExpense) string {
+	lines := []string{chatText(ctx, "chat.expense.amount_line", pending.Currency, formatAmount(pending.Amount))}
+	if pending.Category != nil {
+		lines = append(lines, chatText(ctx, "chat.expense.category_line", *pending.Category))
+	}
+	if pending.Description != nil {
+		lines = append(lines, chatText(ctx, "chat.expense.description_line", *pending.Description))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatAmount formats an amount the Indonesian way, e.g. 1.250.000,5
+func formatAmount(amount float64) string {
+	whole, fraction, _ := strings.Cut(strconv.FormatFloat(amount, 'f', -1, 64), ".")
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if fraction != "" {
+		return grouped.String() + "," + fraction
+	}
+	return grouped.String()
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/infrastructure/google"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// CredentialVerifier proves that a user holds a credential of an auth provider before
+// AuthService.LinkProvider links it to their account
+type CredentialVerifier interface {
+	// Provider returns the name of the auth provider whose credentials are verified
+	Provider() string
+
+	// VerifyCredential checks the proof sent with a credential, e.g. an ID token or a
+	// one-time code, and returns the credential ID to store
+	VerifyCredential(ctx context.Context, userID uuid.UUID, provider *repository.AuthProvider, credentialID, proof string) (string, error)
+}
+
+func verifiersByProvider(verifiers []CredentialVerifier) map[string]CredentialVerifier {
+	byProvider := make(map[string]CredentialVerifier, len(verifiers))
+	for _, verifier := range verifiers {
+		byProvider[verifier.Provider()] = verifier
+	}
+	return byProvider
+}
+
+// IDTokenVerifier verifies Google ID tokens issued to an OAuth client
+type IDTokenVerifier interface {
+	Verify(ctx context.Context, idToken, audience string) (*google.Claims, error)
+}
+
+// GoogleCredentialVerifier links Google accounts by the ID token the app received when
+// the user signed in with Google. The credential is the token's subject, which stays
+// the same when the account's email changes.
+type GoogleCredentialVerifier struct {
+	tokens   IDTokenVerifier
+	clientID string
+}
+
+// NewGoogleCredentialVerifier creates a verifier of ID tokens issued to the OAuth client
+func NewGoogleCredentialVerifier(tokens IDTokenVerifier, clientID string) *GoogleCredentialVerifier {
+	return &GoogleCredentialVerifier{
+		tokens:   tokens,
+		clientID: clientID,
+	}
+}
+
+// Provider returns GoogleProviderName
+func (v *GoogleCredentialVerifier) Provider() string {
+	return GoogleProviderName
+}
+
+// VerifyCredential verifies the ID token sent as the proof and returns its subject. A
+// credential ID, when sent, must be that subject.
+func (v *GoogleCredentialVerifier) VerifyCredential(ctx context.Context, userID uuid.UUID, provider *repository.AuthProvider, credentialID, proof string) (string, error) {
+	if proof == "" {
+		return "", appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"credential_secret": "Google ID token is required for google provider",
+		})
+	}
+
+	claims, err := v.tokens.Verify(ctx, proof, v.clientID)
+	if err != nil {
+		if errors.Is(err, google.ErrInvalidToken) {
+			return "", appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"credential_secret": "Google ID token is invalid or expired",
+			})
+		}
+		return "", appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to verify Google ID token", 500)
+	}
+
+	if credentialID != "" && credentialID != claims.Subject {
+		return "", appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"credential_id": "does not match the subject of the Google ID token",
+		})
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// dashboardTopCategories is the number of categories a dashboard lists
+	dashboardTopCategories = 5
+
+	// dashboardRecentMoneyFlows is the number of latest money flows a dashboard lists
+	dashboardRecentMoneyFlows = 10
+)
+
+// Dashboard is what the apps show on their home screen: a user's spending this month
+// converted to their default currency, their budgets, their latest money flows, and
+// their active goals. Amounts are in minor units of Currency, except for the budgets,
+// money flows, and goals.
+type Dashboard struct {
+	Currency    string
+	PeriodStart time.Time
+	PeriodEnd   time.Time // exclusive
+
+	Total int64
+	Count int64
+
+	// TopCategories are the categories spent most on this month
+	TopCategories []*CategoryReport
+
+	// Budgets are the user's budgets with their spending and what remains this month
+	Budgets []*BudgetStatus
+
+	// RecentMoneyFlows are the user's latest money flows, newest first
+	RecentMoneyFlows []*domain.MoneyFlow
+
+	// ActiveGoals are the user's goals that are not archived, the nearest deadline first
+	ActiveGoals []*domain.Goal
+
+	// Unconverted lists the currencies without an exchange rate, left out of the totals
+	Unconverted []string
+}
+
+// DashboardService assembles a user's dashboard from the reports, budgets, money flows,
+// and goals in one call, so the apps start with one request instead of several
+type DashboardService struct {
+	reports    *ReportService
+	budgets    *BudgetService
+	moneyFlows *MoneyFlowService
+	goals      *GoalService
+}
+
+// NewDashboardService creates a new dashboard service
+func NewDashboardService(reports *ReportService, budgets *BudgetService, moneyFlows *MoneyFlowService, goals *GoalService) *DashboardService {
+	return &DashboardService{
+		reports:    reports,
+		budgets:    budgets,
+		moneyFlows: moneyFlows,
+		goals:      goals,
+	}
+}
+
+// Get assembles the user's dashboard. Its parts are loaded concurrently; the first
+// that fails cancels the others and is returned.
+func (s *DashboardService) Get(ctx context.Context, userID uuid.UUID) (dashboard *Dashboard, err error) {
+	ctx, span := tracing.Start(ctx, "DashboardService.Get")
+	defer func() { tracing.End(span, err) }()
+
+	var (
+		summary    *ReportSummary
+		budgets    []*BudgetStatus
+		moneyFlows []*domain.MoneyFlow
+		goals      []*domain.Goal
+	)
+	now := time.Now()
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.Go(func() (err error) {
+		summary, err = s.reports.Summary(groupCtx, userID, "", ReportPeriod{Month: &now})
+		return err
+	})
+	group.Go(func() (err error) {
+		budgets, err = s.budgets.ListAt(groupCtx, userID, now)
+		return err
+	})
+	group.Go(func() (err error) {
+		moneyFlows, err = s.moneyFlows.List(groupCtx, userID, dashboardRecentMoneyFlows, 0)
+		return err
+	})
+	group.Go(func() (err error) {
+		goals, err = s.goals.List(groupCtx, userID, false)
+		return err
+	})
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &Dashboard{
+		Currency:         summary.Currency,
+		PeriodStart:      *summary.PeriodStart,
+		PeriodEnd:        summary.PeriodEnd,
+		Total:            summary.Total,
+		Count:            summary.Count,
+		TopCategories:    summary.Categories[:min(len(summary.Categories), dashboardTopCategories)],
+		Budgets:          budgets,
+		RecentMoneyFlows: moneyFlows,
+		ActiveGoals:      goals,
+		Unconverted:      summary.Unconverted,
+	}, nil
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/storage"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// JobRelocateUserFiles moves a user's files into the region of their data region preference
+const JobRelocateUserFiles = "storage.relocate_user_files"
+
+// userFilesRoot is the key prefix below which every user's files are stored
+const userFilesRoot = "users/"
+
+// UserFilesPrefix returns the key prefix of a user's files, such as attachments and
+// exports. Files below it are moved when the user changes their data region.
+func UserFilesPrefix(userID uuid.UUID) string {
+	return userFilesRoot + userID.String() + "/"
+}
+
+// DataResidencyService keeps each user's files in the storage region they chose
+type DataResidencyService struct {
+	userRepo repository.UserRepository
+	regions  *storage.Regions
+	jobs     JobEnqueuer
+}
+
+// NewDataResidencyService creates a new data residency service
+func NewDataResidencyService(
+	userRepo repository.UserRepository,
+	regions *storage.Regions,
+	jobs JobEnqueuer,
+) *DataResidencyService {
+	return &DataResidencyService{
+		userRepo: userRepo,
+		regions:  regions,
+		jobs:     jobs,
+	}
+}
+
+// ValidateRegion checks that users may choose region; empty selects the default region
+func (s *DataResidencyService) ValidateRegion(region string) error {
+	if region == "" || s.regions.Has(region) {
+		return nil
+	}
+	return appErrors.ErrValidation.WithDetails(map[string]interface{}{
+		"data_region":     "must be one of " + strings.Join(s.regions.Names(), ", "),
+		"allowed_regions": s.regions.Names(),
+	})
+}
+
+// Region returns the region the user's files are stored in. Users whose preference is
+// no longer configured are stored in the default region.
+func (s *DataResidencyService) Region(user *domain.User) string {
+	if s.regions.Has(user.DataRegion) {
+		return user.DataRegion
+	}
+	return s.regions.DefaultRegion()
+}
+
+// UserStorage returns the storage of the user's region; keep their files below
+// UserFilesPrefix
+func (s *DataResidencyService) UserStorage(ctx context.Context, userID uuid.UUID) (storage.Storage, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+	return s.regions.Get(s.Region(user))
+}
+
+// DeleteUserFile deletes a file of a user, stored below UserFilesPrefix, from every
+// region, so it is also deleted when a relocation moved it or the user was deleted
+func (s *DataResidencyService) DeleteUserFile(ctx context.Context, key string) error {
+	for _, region := range s.regions.Names() {
+		store, err := s.regions.Get(region)
+		if err != nil {
+			return err
+		}
+		if err := store.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteUserFiles deletes every file of a user from every region and returns the number
+// deleted
+func (s *DataResidencyService) DeleteUserFiles(ctx context.Context, userID uuid.UUID) (int, error) {
+	deleted := 0
+	for _, region := range s.regions.Names() {
+		store, err := s.regions.Get(region)
+		if err != nil {
+			return deleted, err
+		}
+		keys, err := store.List(ctx, UserFilesPrefix(userID))
+		if err != nil {
+			return deleted, err
+		}
+		for _, key := range keys {
+			if err := store.Delete(ctx, key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// ScheduleRelocation queues moving the user's files into their current region
+func (s *DataResidencyService) ScheduleRelocation(ctx context.Context, userID uuid.UUID) error {
+	_, err := s.jobs.Enqueue(ctx, JobRelocateUserFiles, map[string]string{
+		"user_id": userID.String(),
+	})
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to schedule file relocation", 500)
+	}
+	return nil
+}
+
+// HandleRelocationJob processes a JobRelocateUserFiles
+func (s *DataResidencyService) HandleRelocationJob(ctx context.Context, job *worker.Job) error {
+	var payload struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	_, err := s.Relocate(ctx, payload.UserID)
+	if errors.Is(err, appErrors.ErrUserNotFound) {
+		return worker.Permanent(err)
+	}
+	return err
+}
+
+// Relocate moves the user's files from every other region into their current region and
+// returns the number of files moved. It reads the preference when it runs, so after
+// several changes the files end up in the latest region.
+func (s *DataResidencyService) Relocate(ctx context.Context, userID uuid.UUID) (moved int, err error) {
+	ctx, span := tracing.Start(ctx, "DataResidencyService.Relocate")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return 0, appErrors.ErrUserNotFound
+		}
+		return 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+
+	target := s.Region(user)
+	to, err := s.regions.Get(target)
+	if err != nil {
+		return 0, err
+	}
+
+	prefix := UserFilesPrefix(userID)
+	for _, region := range s.regions.Names() {
+		if region == target {
+			continue
+		}
+		from, err := s.regions.Get(region)
+		if err != nil {
+			return moved, err
+		}
+
+		n, err := storage.Move(ctx, from, to, prefix)
+		moved += n
+		if err != nil {
+			return moved, err
+		}
+		if n > 0 {
+			logger.FromContext(ctx).Info("user files relocated",
+				"user_id", userID, "from", region, "to", target, "files", n)
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("storage.region", target),
+		attribute.Int("storage.moved", moved),
+	)
+	return moved, nil
+}
+
+// RelocateAll moves the files of every user stored outside their region, e.g. after the
+// default region changed or a relocation job failed, and returns the number moved
+func (s *DataResidencyService) RelocateAll(ctx context.Context) (int, error) {
+	users := make(map[uuid.UUID]bool)
+	for _, region := range s.regions.Names() {
+		store, err := s.regions.Get(region)
+		if err != nil {
+			return 0, err
+		}
+		keys, err := store.List(ctx, userFilesRoot)
+		if err != nil {
+			return 0, err
+		}
+		for _, key := range keys {
+			id, _, _ := strings.Cut(strings.TrimPrefix(key, userFilesRoot), "/")
+			if userID, err := uuid.Parse(id); err == nil {
+				users[userID] = true
+			}
+		}
+	}
+
+	moved := 0
+	for userID := range users {
+		n, err := s.Relocate(ctx, userID)
+		moved += n
+		if errors.Is(err, appErrors.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return moved, err
+		}
+	}
+	return moved, nil
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// DemoConfig holds the settings of demo mode
+type DemoConfig struct {
+	// Enabled allows creating demo sessions
+	Enabled bool
+
+	// TTL is how long a demo user and its token live
+	TTL time.Duration
+
+	// CleanupInterval is how often expired demo users are deleted
+	CleanupInterval time.Duration
+
+	// CleanupBatchSize is the maximum number of demo users deleted per statement
+	CleanupBatchSize int
+}
+
+// DemoSession represents a freshly created demo sandbox
+type DemoSession struct {
+	User        *domain.User
+	AccessToken string
+	ExpiresIn   int64
+	ExpiresAt   time.Time
+}
+
+// demoMoneyFlow is a sample transaction preloaded into every demo sandbox
+type demoMoneyFlow struct {
+	amount      float64
+	category    string
+	description string
+	daysAgo     int
+}
+
+// demoMoneyFlows is the seed data of a demo sandbox, spread over the past weeks
+var demoMoneyFlows = []demoMoneyFlow{
+	{amount: 8500000, category: "Salary", description: "Monthly salary", daysAgo: 20},
+	{amount: 2500000, category: "Housing", description: "Rent", daysAgo: 19},
+	{amount: 45000, category: "Food", description: "Nasi goreng and iced tea", daysAgo: 12},
+	{amount: 150000, category: "Transport", description: "Fuel", daysAgo: 9},
+	{amount: 320000, category: "Groceries", description: "Weekly groceries", daysAgo: 6},
+	{amount: 75000, category: "Entertainment", description: "Movie tickets", daysAgo: 3},
+	{amount: 28000, category: "Food", description: "Coffee", daysAgo: 1},
+}
+
+// DemoService creates ephemeral demo users so the API can be tried without registering
+type DemoService struct {
+	userRepo      repository.UserRepository
+	moneyFlowRepo repository.MoneyFlowRepository
+	jwtManager    *security.JWTManager
+	txManager     repository.TransactionManager
+	config        DemoConfig
+}
+
+// NewDemoService creates a new demo service
+func NewDemoService(
+	userRepo repository.UserRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+	jwtManager *security.JWTManager,
+	txManager repository.TransactionManager,
+	config DemoConfig,
+) *DemoService {
+	if config.TTL <= 0 {
+		config.TTL = time.Hour
+	}
+	if config.CleanupInterval <= 0 {
+		config.CleanupInterval = 10 * time.Minute
+	}
+	if config.CleanupBatchSize <= 0 {
+		config.CleanupBatchSize = 100
+	}
+
+	return &DemoService{
+		userRepo:      userRepo,
+		moneyFlowRepo: moneyFlowRepo,
+		jwtManager:    jwtManager,
+		txManager:     txManager,
+		config:        config,
+	}
+}
+
+// CreateSession creates a demo user preloaded with sample money flows and returns
+// an access token that expires together with the user. No refresh token is issued.
+func (s *DemoService) CreateSession(ctx context.Context) (*DemoSession, error) {
+	ctx, span := tracing.Start(ctx, "DemoService.CreateSession")
+	defer span.End()
+
+	if !s.config.Enabled {
+		return nil, appErrors.ErrDemoDisabled
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.TTL)
+	user := domain.NewDemoUser(expiresAt)
+
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.Create(txCtx, user); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create demo user", 500)
+		}
+
+		for _, seed := range demoMoneyFlows {
+			moneyFlow, err := domain.NewMoneyFlow(user.ID, seed.amount, domain.DefaultCurrency)
+			if err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to build demo money flow", 500)
+			}
+			category, description := seed.category, seed.description
+			moneyFlow.Category = &category
+			moneyFlow.Description = &description
+			moneyFlow.Tags = []string{"demo"}
+			moneyFlow.CreatedAt = now.AddDate(0, 0, -seed.daysAgo)
+			moneyFlow.UpdatedAt = moneyFlow.CreatedAt
+
+			if err := s.moneyFlowRepo.Create(txCtx, moneyFlow); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create demo money flow", 500)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresIn, err := s.jwtManager.GenerateAccessTokenWithTTL(user.ID, "", user.FullName, time.Now(), security.DefaultAudience, user.TokenVersion, uuid.Nil, s.config.TTL)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate access token", 500)
+	}
+
+	return &DemoSession{
+		User:        user,
+		AccessToken: accessToken,
+		ExpiresIn:   expiresIn,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// RunCleanup deletes expired demo users until the context is cancelled.
+// It also runs when demo mode is disabled, so sandboxes created before disabling are removed.
+func (s *DemoService) RunCleanup(ctx context.Context) {
+	log := logger.FromContext(ctx).With("component", "demo_cleanup")
+	ticker := time.NewTicker(s.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var total int64
+		for {
+			deleted, err := s.userRepo.DeleteExpiredDemoUsers(ctx, time.Now(), s.config.CleanupBatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Warn("failed to delete expired demo users", "error", err)
+				}
+				break
+			}
+			total += deleted
+			if deleted < int64(s.config.CleanupBatchSize) {
+				break
+			}
+		}
+		if total > 0 {
+			log.Info("expired demo users deleted", "count", total)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// maxDevicesPerUser bounds the devices a user receives push notifications on. Tokens
+// of reinstalled apps are not always unregistered, so the least recently seen devices
+// beyond it are forgotten.
+const maxDevicesPerUser = 10
+
+// DeviceService handles the devices users receive push notifications on
+type DeviceService struct {
+	deviceRepo repository.DeviceRepository
+}
+
+// NewDeviceService creates a new device service
+func NewDeviceService(deviceRepo repository.DeviceRepository) *DeviceService {
+	return &DeviceService{
+		deviceRepo: deviceRepo,
+	}
+}
+
+// Register registers a device of the user for push notifications. Apps call it on every
+// start, since the push service may rotate the token.
+func (s *DeviceService) Register(ctx context.Context, userID uuid.UUID, token, platform string, name *string) (*domain.Device, error) {
+	ctx, span := tracing.Start(ctx, "DeviceService.Register")
+	defer span.End()
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"token": "token is required",
+		})
+	}
+
+	device := domain.NewDevice(userID, token, platform, name)
+	if err := s.deviceRepo.Register(ctx, device); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to register device", 500)
+	}
+
+	// Forgetting stale devices is best effort and must not fail the registration
+	devices, err := s.deviceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to list devices", "error", err)
+		return device, nil
+	}
+	for i := maxDevicesPerUser; i < len(devices); i++ {
+		if err := s.deviceRepo.Delete(ctx, devices[i].ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			logger.FromContext(ctx).Warn("failed to forget stale device", "device_id", devices[i].ID, "error", err)
+		}
+	}
+
+	return device, nil
+}
+
+// List returns the devices of a user, most recently seen first
+func (s *DeviceService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error) {
+	ctx, span := tracing.Start(ctx, "DeviceService.List")
+	defer span.End()
+
+	devices, err := s.deviceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list devices", 500)
+	}
+	return devices, nil
+}
+
+// Unregister stops push notifications to a device of the user, e.g. when they sign out
+func (s *DeviceService) Unregister(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "DeviceService.Unregister")
+	defer span.End()
+
+	device, err := s.deviceRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find device", 500)
+	}
+
+	// Do not leak the existence of other users' devices
+	if device.UserID != userID {
+		return appErrors.ErrResourceNotFound
+	}
+
+	if err := s.deviceRepo.Delete(ctx, device.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to unregister device", 500)
+	}
+	return nil
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobSendDigest compiles the spending digest of a period and sends it to one user
+const JobSendDigest = "digest.send"
+
+// digestTopCategories is the number of categories a digest lists
+const digestTopCategories = 3
+
+// DigestConfig holds the schedule of the spending digests
+type DigestConfig struct {
+	// Interval is how often users are checked for a digest that is due
+	Interval time.Duration
+
+	// Hour is the hour of the day, in the user's time zone, from which the digest of
+	// the period that just ended is sent
+	Hour int
+
+	// BatchSize is the number of users loaded per query
+	BatchSize int
+}
+
+// Digest summarizes a user's spending in a week or month, converted to their default
+// currency. Amounts are in minor units of Currency, except for the budgets.
+type Digest struct {
+	Frequency   string
+	Currency    string
+	PeriodStart time.Time
+	PeriodEnd   time.Time // exclusive
+
+	Total int64
+	Count int64
+
+	// PreviousTotal is the spending in the period before, to compare with
+	PreviousTotal int64
+
+	// TopCategories are the categories spent most on
+	TopCategories []*DigestCategory
+
+	// Budgets are the user's budgets in the month the period ends in
+	Budgets []*BudgetStatus
+
+	// Unconverted lists the currencies without an exchange rate, left out of the totals
+	Unconverted []string
+}
+
+// DigestCategory is the spending in a category, and in the period before
+type DigestCategory struct {
+	Category      *string // nil for uncategorized
+	Total         int64
+	PreviousTotal int64
+}
+
+// DigestService compiles weekly and monthly spending digests and sends them to users
+// on their preferred notification channel
+type DigestService struct {
+	userRepo     repository.UserRepository
+	settingsRepo repository.UserSettingsRepository
+	digestRepo   repository.DigestRepository
+	reports      *ReportService
+	budgets      *BudgetService
+	notifier     *Notifier
+	jobs         JobEnqueuer
+	txManager    repository.TransactionManager
+	config       DigestConfig
+}
+
+// NewDigestService creates a new digest service
+func NewDigestService(
+	userRepo repository.UserRepository,
+	settingsRepo repository.UserSettingsRepository,
+	digestRepo repository.DigestRepository,
+	reports *ReportService,
+	budgets *BudgetService,
+	notifier *Notifier,
+	jobs JobEnqueuer,
+	txManager repository.TransactionManager,
+	config DigestConfig,
+) *DigestService {
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+
+	return &DigestService{
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		digestRepo:   digestRepo,
+		reports:      reports,
+		budgets:      budgets,
+		notifier:     notifier,
+		jobs:         jobs,
+		txManager:    txManager,
+		config:       config,
+	}
+}
+
+// Preview compiles the digest of the last week or month that ended, as it is sent. An
+// empty frequency is the user's digest frequency, or weekly when they turned digests off.
+func (s *DigestService) Preview(ctx context.Context, userID uuid.UUID, frequency string) (digest *Digest, err error) {
+	ctx, span := tracing.Start(ctx, "DigestService.Preview")
+	defer func() { tracing.End(span, err) }()
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user settings", 500)
+	}
+	if frequency == "" {
+		frequency = settings.DigestFrequency
+	}
+	if frequency != domain.DigestMonthly {
+		frequency = domain.DigestWeekly
+	}
+
+	start, _ := domain.DigestPeriod(frequency, time.Now(), settings)
+	return s.compile(ctx, userID, frequency, start)
+}
+
+// Run queues the digests that are due every interval until ctx is done
+func (s *DigestService) Run(ctx context.Context) {
+	log := logger.FromContext(ctx).With("component", "digests")
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if queued := s.Schedule(ctx); queued > 0 {
+			log.Info("spending digests queued", "count", queued)
+		}
+	}
+}
+
+// Schedule queues a JobSendDigest for every user whose digest of the period that just
+// ended is due and was not queued yet, and returns the number queued. Each digest is
+// claimed before it is queued, so runs of several instances send it once.
+func (s *DigestService) Schedule(ctx context.Context) int {
+	log := logger.FromContext(ctx).With("component", "digests")
+	now := time.Now()
+
+	queued := 0
+	for offset := 0; ; offset += s.config.BatchSize {
+		users, err := s.userRepo.List(ctx, s.config.BatchSize, offset)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Warn("failed to list users for digests", "error", err)
+			}
+			return queued
+		}
+
+		for _, user := range users {
+			// Disabled accounts cannot sign in, and demo users are deleted soon
+			if user.DisabledAt != nil || user.DemoExpiresAt != nil {
+				continue
+			}
+
+			ok, err := s.schedule(ctx, user.ID, now)
+			if err != nil {
+				if ctx.Err() != nil {
+					return queued
+				}
+				log.Warn("failed to queue spending digest", "user_id", user.ID, "error", err)
+				continue
+			}
+			if ok {
+				queued++
+			}
+		}
+
+		if len(users) < s.config.BatchSize {
+			return queued
+		}
+	}
+}
+
+// HandleSendJob processes a JobSendDigest. Digests of periods without spending, and of
+// a frequency the user no longer wants, are not sent.
+func (s *DigestService) HandleSendJob(ctx context.Context, job *worker.Job) error {
+	var payload struct {
+		UserID      uuid.UUID `json:"user_id"`
+		Frequency   string    `json:"frequency"`
+		PeriodStart time.Time `json:"period_start"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	settings, err := s.findSettings(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if settings.DigestFrequency != payload.Frequency {
+		return nil
+	}
+
+	digest, err := s.compile(ctx, payload.UserID, payload.Frequency, payload.PeriodStart.In(settings.Location()))
+	if err != nil {
+		return err
+	}
+	if digest.Count == 0 {
+		logger.FromContext(ctx).Debug("spending digest skipped; nothing was spent", "user_id", payload.UserID)
+		return nil
+	}
+
+	return s.notifier.deliver(ctx, payload.UserID, digest.Notification(i18n.FromLocale(settings.Locale)))
+}
+
+// schedule queues the user's digest if it is due, reporting whether it was queued
+func (s *DigestService) schedule(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if settings.DigestFrequency != domain.DigestWeekly && settings.DigestFrequency != domain.DigestMonthly {
+		return false, nil
+	}
+
+	start, end := domain.DigestPeriod(settings.DigestFrequency, now, settings)
+	if now.Before(time.Date(end.Year(), end.Month(), end.Day(), s.config.Hour, 0, 0, 0, end.Location())) {
+		return false, nil
+	}
+
+	queued := false
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		claimed, err := s.digestRepo.Claim(txCtx, userID, settings.DigestFrequency, start)
+		if err != nil || !claimed {
+			return err
+		}
+
+		_, err = s.jobs.Enqueue(txCtx, JobSendDigest, map[string]interface{}{
+			"user_id":      userID,
+			"frequency":    settings.DigestFrequency,
+			"period_start": start,
+		})
+		queued = err == nil
+		return err
+	})
+	return queued, err
+}
+
+// compile summarizes the week or month starting at start
+func (s *DigestService) compile(ctx context.Context, userID uuid.UUID, frequency string, start time.Time) (*Digest, error) {
+	previousStart := start.AddDate(0, 0, -7)
+	period := func(day time.Time) ReportPeriod { return ReportPeriod{Week: &day} }
+	if frequency == domain.DigestMonthly {
+		previousStart = start.AddDate(0, -1, 0)
+		period = func(day time.Time) ReportPeriod { return ReportPeriod{Month: &day} }
+	}
+
+	current, err := s.reports.Summary(ctx, userID, "", period(start))
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.reports.Summary(ctx, userID, current.Currency, period(previousStart))
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := s.budgets.ListAt(ctx, userID, current.PeriodEnd.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	digest := &Digest{
+		Frequency:     frequency,
+		Currency:      current.Currency,
+		PeriodStart:   *current.PeriodStart,
+		PeriodEnd:     current.PeriodEnd,
+		Total:         current.Total,
+		Count:         current.Count,
+		PreviousTotal: previous.Total,
+		TopCategories: []*DigestCategory{},
+		Budgets:       budgets,
+		Unconverted:   current.Unconverted,
+	}
+
+	previousTotals := make(map[string]int64, len(previous.Categories))
+	for _, category := range previous.Categories {
+		previousTotals[categoryKey(category.Category)] = category.Total
+	}
+	for _, category := range current.Categories[:min(len(current.Categories), digestTopCategories)] {
+		digest.TopCategories = append(digest.TopCategories, &DigestCategory{
+			Category:      category.Category,
+			Total:         category.Total,
+			PreviousTotal: previousTotals[categoryKey(category.Category)],
+		})
+	}
+
+	return digest, nil
+}
+
+func (s *DigestService) findSettings(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.DefaultUserSettings(userID), nil
+	}
+	return settings, err
+}
+
+// categoryKey tells uncategorized spending apart from a category named ""
+func categoryKey(category *string) string {
+	if category == nil {
+		return ""
+	}
+	return "\x00" + *category
+}
+
+// Notification describes the digest in a language as the notification it is sent as
+func (d *Digest) Notification(language i18n.Language) Notification {
+	amount := func(minor int64, currency string) string {
+		return currency + " " + formatAmount(domain.MajorUnits(minor, currency))
+	}
+	day := func(t time.Time) string {
+		return fmt.Sprintf("%d %s", t.Day(), i18n.T(language, fmt.Sprintf("month.short.%d", t.Month())))
+	}
+
+	unit, period := "week", day(d.PeriodStart)+" - "+day(d.PeriodEnd.AddDate(0, 0, -1))
+	if d.Frequency == domain.DigestMonthly {
+		unit = "month"
+		period = fmt.Sprintf("%s %d", i18n.T(language, fmt.Sprintf("month.%d", d.PeriodStart.Month())), d.PeriodStart.Year())
+	}
+
+	var body strings.Builder
+	body.WriteString(i18n.T(language, "digest.spent", period, amount(d.Total, d.Currency), d.Count))
+	if d.PreviousTotal > 0 {
+		change := math.Round(float64(d.Total-d.PreviousTotal) * 100 / float64(d.PreviousTotal))
+		switch {
+		case change > 0:
+			body.WriteString(i18n.T(language, "digest.more."+unit, change))
+		case change < 0:
+			body.WriteString(i18n.T(language, "digest.less."+unit, -change))
+		default:
+			body.WriteString(i18n.T(language, "digest.same."+unit))
+		}
+	}
+	body.WriteString(".")
+
+	if len(d.TopCategories) > 0 {
+		top := make([]string, len(d.TopCategories))
+		for i, category := range d.TopCategories {
+			name := i18n.T(language, "digest.uncategorized")
+			if category.Category != nil {
+				name = *category.Category
+			}
+			top[i] = name + " " + amount(category.Total, d.Currency)
+		}
+		body.WriteString("\n" + i18n.T(language, "digest.top_categories", strings.Join(top, ", ")))
+	}
+
+	if len(d.Budgets) > 0 {
+		budgets := make([]string, len(d.Budgets))
+		for i, status := range d.Budgets {
+			budget := status.Budget
+			if status.Remaining < 0 {
+				budgets[i] = i18n.T(language, "digest.budget_over", budget.Category, amount(-status.Remaining, budget.Currency))
+			} else {
+				budgets[i] = i18n.T(language, "digest.budget_spent", budget.Category,
+					amount(status.Spent, budget.Currency), amount(budget.Amount, budget.Currency))
+			}
+		}
+		body.WriteString("\n" + i18n.T(language, "digest.budgets", strings.Join(budgets, ", ")))
+	}
+
+	return Notification{
+		Type:  NotificationDigest,
+		Title: i18n.T(language, "digest.title."+unit),
+		Body:  body.String(),
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+)
+
+func budgetExceededPayload(budget *domain.Budget, moneyFlow *domain.MoneyFlow, spent int64) *events.BudgetExceededPayload {
+	return &events.BudgetExceededPayload{
+		BudgetID:    budget.ID,
+		UserID:      budget.UserID,
+		Category:    budget.Category,
+		Currency:    budget.Currency,
+		Hard:        budget.Hard,
+		Cap:         domain.MajorUnits(budget.Amount, budget.Currency),
+		Spent:       domain.MajorUnits(spent+moneyFlow.Amount, budget.Currency),
+		MoneyFlowID: moneyFlow.ID,
+	}
+}
+
+func moneyFlowPayload(moneyFlow *domain.MoneyFlow) *events.MoneyFlowPayload {
+	tags := moneyFlow.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &events.MoneyFlowPayload{
+		MoneyFlowID: moneyFlow.ID,
+		UserID:      moneyFlow.UserID,
+		Kind:        moneyFlow.Kind,
+		Amount:      moneyFlow.Money().Float64(),
+		Currency:    moneyFlow.Currency,
+		Category:    moneyFlow.Category,
+		Description: moneyFlow.Description,
+		Tags:        tags,
+		WalletID:    moneyFlow.WalletID,
+		GroupID:     moneyFlow.GroupID,
+		Version:     moneyFlow.Version,
+		CreatedAt:   moneyFlow.CreatedAt,
+	}
+}
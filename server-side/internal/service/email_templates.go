@@ -0,0 +1,37 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/ingunawandra/catetin/internal/i18n"
+)
+
+// emailTemplateFiles holds the templates of the emails sent to users, as
+// templates/<name>.html.tmpl and templates/<name>.txt.tmpl. Templates call
+// {{t .Language "key" args...}} to look up their texts in the i18n catalogs.
+//
+//go:embed templates/*.tmpl
+var emailTemplateFiles embed.FS
+
+var (
+	htmlEmailTemplates = htmltemplate.Must(
+		htmltemplate.New("").Funcs(htmltemplate.FuncMap{"t": i18n.T}).ParseFS(emailTemplateFiles, "templates/*.html.tmpl"))
+	textEmailTemplates = texttemplate.Must(
+		texttemplate.New("").Funcs(texttemplate.FuncMap{"t": i18n.T}).ParseFS(emailTemplateFiles, "templates/*.txt.tmpl"))
+)
+
+// renderEmail renders the plain-text and HTML templates of an email with data
+func renderEmail(name string, data interface{}) (text, html string, err error) {
+	var textOut, htmlOut bytes.Buffer
+	if err := textEmailTemplates.ExecuteTemplate(&textOut, name+".txt.tmpl", data); err != nil {
+		return "", "", err
+	}
+	if err := htmlEmailTemplates.ExecuteTemplate(&htmlOut, name+".html.tmpl", data); err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(textOut.String()) + "\n", htmlOut.String(), nil
+}
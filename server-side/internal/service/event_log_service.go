@@ -0,0 +1,219 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// Replay event types
+const (
+	// ReplayEventMoneyFlowRecorded records that a user recorded a money flow
+	ReplayEventMoneyFlowRecorded = "money_flow.recorded"
+)
+
+// replayUserPrefix marks users created by a replay; the phone number is a placeholder
+const replayUserPrefix = "replay-"
+
+// ReplayEvent is one line of an exported event log. It carries no PII: the actor
+// is a keyed hash of the user ID, and free text (descriptions, notes, tags) is dropped.
+type ReplayEvent struct {
+	Type       string    `json:"type"`
+	Actor      string    `json:"actor"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Amount     float64   `json:"amount"`
+	Currency   string    `json:"currency"`
+	Category   *string   `json:"category,omitempty"`
+}
+
+// ExportOptions controls which events are exported and how they are sanitized
+type ExportOptions struct {
+	Since time.Time
+	Until time.Time
+
+	// Salt keys the hash that replaces user IDs. Exports with the same salt
+	// map a user to the same actor; use a fresh salt to make them unlinkable.
+	Salt string
+
+	// KeepCategories exports category names, which are user-entered text
+	KeepCategories bool
+
+	// BatchSize is the number of rows read per query
+	BatchSize int
+}
+
+// ReplayResult summarizes a replay
+type ReplayResult struct {
+	Users  int
+	Events int
+}
+
+// EventLogService exports a sanitized log of domain events from one environment
+// and replays it into another, so aggregation and notification bugs can be
+// reproduced locally with realistic data volumes
+type EventLogService struct {
+	userRepo      repository.UserRepository
+	moneyFlowRepo repository.MoneyFlowRepository
+}
+
+// NewEventLogService creates a new event log service
+func NewEventLogService(
+	userRepo repository.UserRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+) *EventLogService {
+	return &EventLogService{
+		userRepo:      userRepo,
+		moneyFlowRepo: moneyFlowRepo,
+	}
+}
+
+// Export writes the events that occurred in [Since, Until) as JSON lines, oldest
+// first, and returns the number of events written
+func (s *EventLogService) Export(ctx context.Context, w io.Writer, opts ExportOptions) (int, error) {
+	if opts.Salt == "" {
+		return 0, errors.New("export salt is required")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+
+	encoder := json.NewEncoder(w)
+	written := 0
+	for offset := 0; ; offset += opts.BatchSize {
+		moneyFlows, err := s.moneyFlowRepo.FindCreatedBetween(ctx, opts.Since, opts.Until, opts.BatchSize, offset)
+		if err != nil {
+			return written, fmt.Errorf("failed to read money flows: %w", err)
+		}
+
+		for _, moneyFlow := range moneyFlows {
+			event := &ReplayEvent{
+				Type:  ReplayEventMoneyFlowRecorded,
+				Actor: pseudonymize(opts.Salt, moneyFlow.UserID),
+				// Second precision is enough for ordering and avoids exact request correlation
+				OccurredAt: moneyFlow.CreatedAt.UTC().Truncate(time.Second),
+				Amount:     moneyFlow.Money().Float64(),
+				Currency:   moneyFlow.Currency,
+			}
+			if opts.KeepCategories {
+				event.Category = moneyFlow.Category
+			}
+			if err := encoder.Encode(event); err != nil {
+				return written, fmt.Errorf("failed to write event: %w", err)
+			}
+			written++
+		}
+
+		if len(moneyFlows) < opts.BatchSize {
+			return written, nil
+		}
+	}
+}
+
+// Replay reads an exported event log and applies it in order. Each actor becomes a
+// local placeholder user, reused across replays of logs exported with the same salt.
+// Original timestamps are kept so date-based aggregations see the same distribution.
+func (s *EventLogService) Replay(ctx context.Context, r io.Reader) (*ReplayResult, error) {
+	result := &ReplayResult{}
+	users := make(map[string]uuid.UUID)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var event ReplayEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return result, fmt.Errorf("line %d: invalid event: %w", line, err)
+		}
+
+		userID, ok := users[event.Actor]
+		if !ok {
+			var created bool
+			var err error
+			userID, created, err = s.replayUser(ctx, event.Actor)
+			if err != nil {
+				return result, fmt.Errorf("line %d: %w", line, err)
+			}
+			users[event.Actor] = userID
+			if created {
+				result.Users++
+			}
+		}
+
+		if err := s.apply(ctx, userID, &event); err != nil {
+			return result, fmt.Errorf("line %d: %w", line, err)
+		}
+		result.Events++
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read event log: %w", err)
+	}
+
+	return result, nil
+}
+
+// apply replays a single event as the given user
+func (s *EventLogService) apply(ctx context.Context, userID uuid.UUID, event *ReplayEvent) error {
+	switch event.Type {
+	case ReplayEventMoneyFlowRecorded:
+		moneyFlow, err := domain.NewMoneyFlow(userID, event.Amount, event.Currency)
+		if err != nil {
+			return fmt.Errorf("invalid money flow: %w", err)
+		}
+		moneyFlow.Category = event.Category
+		moneyFlow.CreatedAt = event.OccurredAt
+		moneyFlow.UpdatedAt = event.OccurredAt
+		if err := moneyFlow.Validate(); err != nil {
+			return fmt.Errorf("invalid money flow: %w", err)
+		}
+
+		if err := s.moneyFlowRepo.Create(ctx, moneyFlow); err != nil {
+			return fmt.Errorf("failed to create money flow: %w", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown event type %q", event.Type)
+	}
+}
+
+// replayUser finds or creates the placeholder user of an actor
+func (s *EventLogService) replayUser(ctx context.Context, actor string) (uuid.UUID, bool, error) {
+	if actor == "" {
+		return uuid.Nil, false, errors.New("event has no actor")
+	}
+	phoneNumber := replayUserPrefix + actor
+
+	user, err := s.userRepo.FindByPhoneNumber(ctx, phoneNumber)
+	if err == nil {
+		return user.ID, false, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return uuid.Nil, false, fmt.Errorf("failed to find replay user: %w", err)
+	}
+
+	user = domain.NewUser("Replay "+actor[:min(8, len(actor))], phoneNumber)
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to create replay user: %w", err)
+	}
+	return user.ID, true, nil
+}
+
+// pseudonymize derives a stable actor identifier that cannot be linked back to the user without the salt
+func pseudonymize(salt string, userID uuid.UUID) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write(userID[:])
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/exchangerate"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// RateProvider publishes daily exchange rates against one base currency
+type RateProvider interface {
+	// Name identifies the provider in stored rates
+	Name() string
+
+	// Base is the currency the rates are quoted against
+	Base() string
+
+	// Latest fetches the most recent rates
+	Latest(ctx context.Context) (*exchangerate.Rates, error)
+}
+
+// ExchangeRateConfig holds the settings of exchange rate refreshes
+type ExchangeRateConfig struct {
+	// Enabled fetches rates from the provider; without it, only rates already stored are used
+	Enabled bool
+
+	// RefreshInterval is how often the provider is asked for new rates
+	RefreshInterval time.Duration
+}
+
+// ExchangeRateService keeps the rates of a provider and converts between currencies
+// with them. Every instance refreshes the rates in the background; saving a day twice
+// replaces it, so they do not conflict.
+type ExchangeRateService struct {
+	rateRepo repository.ExchangeRateRepository
+	provider RateProvider
+	config   ExchangeRateConfig
+}
+
+// NewExchangeRateService creates a new exchange rate service
+func NewExchangeRateService(rateRepo repository.ExchangeRateRepository, provider RateProvider, config ExchangeRateConfig) *ExchangeRateService {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = 6 * time.Hour
+	}
+
+	return &ExchangeRateService{
+		rateRepo: rateRepo,
+		provider: provider,
+		config:   config,
+	}
+}
+
+// Refresh fetches the latest rates from the provider and stores them
+func (s *ExchangeRateService) Refresh(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "ExchangeRateService.Refresh")
+	defer func() { tracing.End(span, err) }()
+
+	latest, err := s.provider.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rates := make([]*domain.ExchangeRate, 0, len(latest.Rates))
+	for currency, rate := range latest.Rates {
+		rates = append(rates, &domain.ExchangeRate{
+			Base:      latest.Base,
+			Currency:  currency,
+			Date:      latest.Date,
+			Rate:      rate,
+			Source:    s.provider.Name(),
+			FetchedAt: now,
+		})
+	}
+
+	return s.rateRepo.Save(ctx, rates)
+}
+
+// Run refreshes the rates now and then every RefreshInterval until the context is
+// cancelled. It returns at once when fetching is disabled.
+func (s *ExchangeRateService) Run(ctx context.Context) {
+	if !s.config.Enabled {
+		return
+	}
+
+	log := logger.FromContext(ctx).With("component", "exchange_rates")
+	refresh := func() {
+		if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Warn("failed to refresh exchange rates", "error", err)
+		}
+	}
+
+	refresh()
+
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			refresh()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Rates returns the rates of the latest day on or before the date. Before any rates
+// are stored, it returns a table that only converts a currency to itself.
+func (s *ExchangeRateService) Rates(ctx context.Context, on time.Time) (*domain.ExchangeRates, error) {
+	base := s.provider.Base()
+
+	rates, err := s.rateRepo.FindLatest(ctx, base, on)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.NewExchangeRates(base, time.Time{}, nil), nil
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get exchange rates", 500)
+	}
+
+	return domain.NewExchangeRates(base, rates[0].Date, rates), nil
+}
+
+// ExchangeRateQuote is the price of one unit of Base in other currencies
+type ExchangeRateQuote struct {
+	Base   string
+	Date   *time.Time // nil before any rates are stored
+	Source string
+	Rates  map[string]float64
+}
+
+// Quote returns the latest rates against a base currency
+func (s *ExchangeRateService) Quote(ctx context.Context, base string) (*ExchangeRateQuote, error) {
+	ctx, span := tracing.Start(ctx, "ExchangeRateService.Quote")
+	defer span.End()
+
+	rates, err := s.Rates(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &ExchangeRateQuote{Base: base, Source: s.provider.Name(), Rates: map[string]float64{}}
+	if rates.Date.IsZero() {
+		return quote, nil
+	}
+	if _, ok := rates.Rate(base, rates.Base); !ok {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"base": "has no exchange rate",
+		})
+	}
+
+	quote.Date = &rates.Date
+	for _, currency := range rates.Currencies() {
+		if currency == base {
+			continue
+		}
+		quote.Rates[currency], _ = rates.Rate(base, currency)
+	}
+
+	return quote, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrParserUnavailable is returned by an ExpenseParser that is not configured
+var ErrParserUnavailable = errors.New("expense parser is not available")
+
+// ParsedExpense is an expense interpreted from a chat message
+type ParsedExpense struct {
+	// IsExpense is false when the message does not describe an expense
+	IsExpense   bool
+	Amount      float64
+	Currency    string // ISO 4217 code; empty when the message names none
+	Category    *string
+	Description *string
+}
+
+// ExpenseParser interprets a free-form chat message as an expense
+type ExpenseParser interface {
+	Parse(ctx context.Context, text string) (*ParsedExpense, error)
+}
+
+// JSONCompleter asks a language model for a JSON object answer
+type JSONCompleter interface {
+	Enabled() bool
+	CompleteJSON(ctx context.Context, system, user string, out interface{}) error
+}
+
+const expenseParserPrompt = `You extract expenses from chat messages written in Indonesian or English.
+Reply with a JSON object with these fields:
+- "is_expense": true only if the message records money that was spent
+- "amount": the amount as a number, expanding shorthand such as "25rb" or "25k" to 25000 and "1,5jt" to 1500000
+- "currency": the ISO 4217 code if the message names a currency, otherwise ""
+- "category": one short lowercase Indonesian word such as "makanan", "transportasi", "belanja", "tagihan", "hiburan", "kesehatan", or "lainnya"
+- "description": what the money was spent on, in the user's words, without the amount
+If "is_expense" is false, the other fields may be empty.`
+
+// AIExpenseParser parses expenses with a language model. A currency the detector
+// finds in the message takes precedence over the model's answer.
+type AIExpenseParser struct {
+	completer  JSONCompleter
+	currencies *CurrencyDetector
+}
+
+// NewAIExpenseParser creates a new language model backed expense parser
+func NewAIExpenseParser(completer JSONCompleter, currencies *CurrencyDetector) *AIExpenseParser {
+	return &AIExpenseParser{
+		completer:  completer,
+		currencies: currencies,
+	}
+}
+
+// Parse asks the model to interpret the message
+func (p *AIExpenseParser) Parse(ctx context.Context, text string) (*ParsedExpense, error) {
+	if !p.completer.Enabled() {
+		return nil, ErrParserUnavailable
+	}
+
+	var reply struct {
+		IsExpense   bool    `json:"is_expense"`
+		Amount      float64 `json:"amount"`
+		Currency    string  `json:"currency"`
+		Category    string  `json:"category"`
+		Description string  `json:"description"`
+	}
+	if err := p.completer.CompleteJSON(ctx, expenseParserPrompt, text, &reply); err != nil {
+		return nil, err
+	}
+
+	parsed := &ParsedExpense{
+		IsExpense: reply.IsExpense && reply.Amount > 0,
+		Amount:    reply.Amount,
+		Currency:  strings.ToUpper(strings.TrimSpace(reply.Currency)),
+	}
+	if len(parsed.Currency) != 3 {
+		parsed.Currency = ""
+	}
+	if detected := p.currencies.Detect(text); detected != "" {
+		parsed.Currency = detected
+	}
+	if category := strings.ToLower(strings.TrimSpace(reply.Category)); category != "" {
+		parsed.Category = &category
+	}
+	if description := strings.TrimSpace(reply.Description); description != "" {
+		parsed.Description = &description
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobForwardFeedback posts a feedback to the team's Slack channel
+const JobForwardFeedback = "feedback.forward"
+
+// FeedbackForwarder posts messages to the channel the team follows feedback in
+type FeedbackForwarder interface {
+	Post(ctx context.Context, text string) error
+}
+
+// FeedbackInput is a feedback sent by a user with its context
+type FeedbackInput struct {
+	Kind      string
+	Source    string
+	Message   string
+	RequestID string
+
+	// Optional context sent by the client
+	LastRequestID string
+	AppVersion    string
+	UserAgent     string
+}
+
+// FeedbackService records feedback and bug reports and forwards them to the team
+type FeedbackService struct {
+	feedbackRepo repository.FeedbackRepository
+	txManager    repository.TransactionManager
+	jobs         JobEnqueuer
+
+	// forwarder is nil when feedback is not forwarded
+	forwarder FeedbackForwarder
+}
+
+// NewFeedbackService creates a new feedback service. A nil forwarder only records feedback.
+func NewFeedbackService(
+	feedbackRepo repository.FeedbackRepository,
+	txManager repository.TransactionManager,
+	jobs JobEnqueuer,
+	forwarder FeedbackForwarder,
+) *FeedbackService {
+	return &FeedbackService{
+		feedbackRepo: feedbackRepo,
+		txManager:    txManager,
+		jobs:         jobs,
+		forwarder:    forwarder,
+	}
+}
+
+// Submit records a feedback of the user and queues forwarding it. Feedback from a chat
+// message that was already recorded fails with a CONFLICT error wrapping domain.ErrConflict.
+func (s *FeedbackService) Submit(ctx context.Context, userID uuid.UUID, input FeedbackInput) (*domain.Feedback, error) {
+	ctx, span := tracing.Start(ctx, "FeedbackService.Submit")
+	defer span.End()
+
+	message := strings.TrimSpace(input.Message)
+	if message == "" {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"message": "is required",
+		})
+	}
+
+	kind := input.Kind
+	if kind == "" {
+		kind = domain.FeedbackKindFeedback
+	}
+
+	feedback := domain.NewFeedback(userID, kind, input.Source, message, input.RequestID)
+	feedback.LastRequestID = optionalString(input.LastRequestID)
+	feedback.AppVersion = optionalString(input.AppVersion)
+	feedback.UserAgent = optionalString(input.UserAgent)
+
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.feedbackRepo.Create(txCtx, feedback); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.Wrap(err, appErrors.ErrCodeConflict, "Feedback already recorded", 409)
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record feedback", 500)
+		}
+
+		if s.forwarder == nil {
+			return nil // Commit transaction
+		}
+		_, err := s.jobs.Enqueue(txCtx, JobForwardFeedback, map[string]interface{}{
+			"feedback_id": feedback.ID,
+		})
+		if err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to queue feedback forwarding", 500)
+		}
+		return nil // Commit transaction
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return feedback, nil
+}
+
+// List returns feedback matching the filter, newest first
+func (s *FeedbackService) List(ctx context.Context, filter domain.FeedbackFilter, limit, offset int) ([]*domain.Feedback, error) {
+	feedback, err := s.feedbackRepo.List(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list feedback", 500)
+	}
+	return feedback, nil
+}
+
+// HandleForwardJob processes a JobForwardFeedback
+func (s *FeedbackService) HandleForwardJob(ctx context.Context, job *worker.Job) (err error) {
+	ctx, span := tracing.Start(ctx, "FeedbackService.Forward")
+	defer func() { tracing.End(span, err) }()
+
+	var payload struct {
+		FeedbackID uuid.UUID `json:"feedback_id"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+	if s.forwarder == nil {
+		return nil // forwarding was turned off after the job was queued
+	}
+
+	feedback, err := s.feedbackRepo.FindByID(ctx, payload.FeedbackID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil // the user was deleted
+		}
+		return err
+	}
+	if feedback.ForwardedAt != nil {
+		return nil
+	}
+
+	if err := s.forwarder.Post(ctx, feedbackSlackText(feedback)); err != nil {
+		return err
+	}
+	return s.feedbackRepo.MarkForwarded(ctx, feedback.ID, time.Now())
+}
+
+// feedbackSlackText formats a feedback as a Slack message. Users are named by ID only,
+// so no contact details leave the database.
+func feedbackSlackText(feedback *domain.Feedback) string {
+	var b strings.Builder
+	title := "New feedback"
+	if feedback.Kind == domain.FeedbackKindBug {
+		title = "New bug report"
+	}
+	fmt.Fprintf(&b, "*%s* via %s from user `%s`\n", title, feedback.Source, feedback.UserID)
+	for _, line := range strings.Split(feedback.Message, "\n") {
+		fmt.Fprintf(&b, ">%s\n", line)
+	}
+
+	details := []string{"feedback `" + feedback.ID.String() + "`"}
+	if feedback.AppVersion != nil {
+		details = append(details, "app "+*feedback.AppVersion)
+	}
+	if feedback.LastRequestID != nil {
+		details = append(details, "last request `"+*feedback.LastRequestID+"`")
+	}
+	b.WriteString(strings.Join(details, " · "))
+	return b.String()
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
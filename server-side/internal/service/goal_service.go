@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// GoalService handles savings goal business logic
+type GoalService struct {
+	goalRepo     repository.GoalRepository
+	settingsRepo repository.UserSettingsRepository
+	auditor      *Auditor
+	txManager    repository.TransactionManager
+}
+
+// NewGoalService creates a new goal service
+func NewGoalService(
+	goalRepo repository.GoalRepository,
+	settingsRepo repository.UserSettingsRepository,
+	auditor *Auditor,
+	txManager repository.TransactionManager,
+) *GoalService {
+	return &GoalService{
+		goalRepo:     goalRepo,
+		settingsRepo: settingsRepo,
+		auditor:      auditor,
+		txManager:    txManager,
+	}
+}
+
+// GoalInput holds the fields of a goal that can be changed after creation, with the
+// amounts in major units of its currency. The currency of a goal is set when it is
+// created and cannot be changed.
+type GoalInput struct {
+	Name         string
+	TargetAmount float64
+	SavedAmount  float64
+	Deadline     *time.Time
+	Archived     bool
+}
+
+// Create creates an active goal. The currency defaults to the user's default currency.
+func (s *GoalService) Create(ctx context.Context, userID uuid.UUID, currency string, input GoalInput) (*domain.Goal, error) {
+	ctx, span := tracing.Start(ctx, "GoalService.Create")
+	defer span.End()
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		settings, err = domain.DefaultUserSettings(userID), nil
+	}
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user settings", 500)
+	}
+	if currency == "" {
+		currency = settings.DefaultCurrency
+	}
+	if err := checkCurrency(settings, currency); err != nil {
+		return nil, err
+	}
+
+	goal, err := domain.NewGoal(userID, input.Name, currency, input.TargetAmount, input.Deadline)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	if err := s.apply(goal, input); err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.goalRepo.Create(txCtx, goal); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create goal", 500)
+		}
+		return s.auditor.Record(txCtx, userID, nil, goalAudit(goal))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+// List returns the user's goals, the nearest deadline first. Archived goals are left
+// out unless includeArchived.
+func (s *GoalService) List(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*domain.Goal, error) {
+	ctx, span := tracing.Start(ctx, "GoalService.List")
+	defer span.End()
+
+	goals, err := s.goalRepo.FindByUserID(ctx, userID, includeArchived)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list goals", 500)
+	}
+	return goals, nil
+}
+
+// Get returns a goal owned by the user
+func (s *GoalService) Get(ctx context.Context, userID, id uuid.UUID) (*domain.Goal, error) {
+	ctx, span := tracing.Start(ctx, "GoalService.Get")
+	defer span.End()
+
+	return s.findOwned(ctx, userID, id)
+}
+
+// Update replaces the fields of a goal using optimistic locking
+func (s *GoalService) Update(ctx context.Context, userID, id uuid.UUID, version int, input GoalInput) (*domain.Goal, error) {
+	ctx, span := tracing.Start(ctx, "GoalService.Update")
+	defer span.End()
+
+	goal, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.Version != version {
+		return nil, appErrors.ErrVersionConflict
+	}
+	before := goalAudit(goal)
+
+	if err := s.apply(goal, input); err != nil {
+		return nil, err
+	}
+	goal.IncrementVersion()
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.goalRepo.Update(txCtx, goal); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrVersionConflict
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update goal", 500)
+		}
+		return s.auditor.Record(txCtx, userID, before, goalAudit(goal))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+// Delete deletes a goal owned by the user
+func (s *GoalService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "GoalService.Delete")
+	defer span.End()
+
+	goal, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.goalRepo.Delete(txCtx, goal.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete goal", 500)
+		}
+		return s.auditor.Record(txCtx, userID, goalAudit(goal), nil)
+	})
+}
+
+// apply sets the fields of the input on the goal and validates it
+func (s *GoalService) apply(goal *domain.Goal, input GoalInput) error {
+	if err := goal.Set(input.Name, input.TargetAmount, input.SavedAmount, input.Deadline); err != nil {
+		return validationError(err)
+	}
+	if err := goal.Validate(); err != nil {
+		return validationError(err)
+	}
+	goal.Archive(input.Archived)
+	return nil
+}
+
+func (s *GoalService) findOwned(ctx context.Context, userID, id uuid.UUID) (*domain.Goal, error) {
+	goal, err := s.goalRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find goal", 500)
+	}
+
+	// Do not leak the existence of other users' goals
+	if goal.UserID != userID {
+		return nil, appErrors.ErrResourceNotFound
+	}
+
+	return goal, nil
+}
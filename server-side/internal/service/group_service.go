@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// groupInvitationTTL is how long a group invitation can be accepted
+const groupInvitationTTL = 7 * 24 * time.Hour
+
+// GroupService handles shared ledger groups, their members, and invitations
+type GroupService struct {
+	groupRepo      repository.GroupRepository
+	invitationRepo repository.GroupInvitationRepository
+	auditor        *Auditor
+	txManager      repository.TransactionManager
+}
+
+// NewGroupService creates a new group service
+func NewGroupService(
+	groupRepo repository.GroupRepository,
+	invitationRepo repository.GroupInvitationRepository,
+	auditor *Auditor,
+	txManager repository.TransactionManager,
+) *GroupService {
+	return &GroupService{
+		groupRepo:      groupRepo,
+		invitationRepo: invitationRepo,
+		auditor:        auditor,
+		txManager:      txManager,
+	}
+}
+
+// GroupDetail represents a group together with the role of the requesting user.
+// Members is only populated on detail endpoints.
+type GroupDetail struct {
+	Group   *domain.Group
+	Role    string
+	Members []*domain.GroupMember
+}
+
+// GroupInvitationToken is a newly created invitation and its token, which is not stored
+// and can only be shown once
+type GroupInvitationToken struct {
+	Invitation *domain.GroupInvitation
+	Token      string
+}
+
+// Create creates a group owned by the user
+func (s *GroupService) Create(ctx context.Context, userID uuid.UUID, name string) (*GroupDetail, error) {
+	ctx, span := tracing.Start(ctx, "GroupService.Create")
+	defer span.End()
+
+	group, err := domain.NewGroup(name, userID)
+	if err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"name": err.Error(),
+		})
+	}
+	owner := domain.NewGroupMember(group.ID, userID, domain.GroupRoleOwner)
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.groupRepo.Create(txCtx, group); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create group", 500)
+		}
+		owner.GroupID = group.ID
+		if err := s.groupRepo.AddMember(txCtx, owner); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to add group owner", 500)
+		}
+		return s.auditor.Record(txCtx, userID, nil, groupAudit(group))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, group.ID)
+}
+
+// List returns the groups the user is a member of, ordered by name
+func (s *GroupService) List(ctx context.Context, userID uuid.UUID) ([]*GroupDetail, error) {
+	ctx, span := tracing.Start(ctx, "GroupService.List")
+	defer span.End()
+
+	groups, err := s.groupRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list groups", 500)
+	}
+
+	details := make([]*GroupDetail, 0, len(groups))
+	for _, group := range groups {
+		member, err := s.groupRepo.FindMember(ctx, group.ID, userID)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue // left the group meanwhile
+		}
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find group member", 500)
+		}
+		details = append(details, &GroupDetail{Group: group, Role: member.Role})
+	}
+	return details, nil
+}
+
+// Get returns a group the user is a member of, with its members
+func (s *GroupService) Get(ctx context.Context, userID, id uuid.UUID) (*GroupDetail, error) {
+	ctx, span := tracing.Start(ctx, "GroupService.Get")
+	defer span.End()
+
+	member, err := findGroupMember(ctx, s.groupRepo, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.findGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.groupRepo.FindMembers(ctx, id)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list group members", 500)
+	}
+
+	return &GroupDetail{Group: group, Role: member.Role, Members: members}, nil
+}
+
+// Rename renames a group using optimistic locking; owners and admins only
+func (s *GroupService) Rename(ctx context.Context, userID, id uuid.UUID, version int, name string) (*GroupDetail, error) {
+	ctx, span := tracing.Start(ctx, "GroupService.Rename")
+	defer span.End()
+
+	if _, err := s.findManager(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	group, err := s.findGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.Version != version {
+		return nil, appErrors.ErrVersionConflict
+	}
+	before := groupAudit(group)
+
+	if err := group.Rename(name); err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"name": err.Error(),
+		})
+	}
+	group.IncrementVersion()
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.groupRepo.Update(txCtx, group); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrVersionConflict
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update group", 500)
+		}
+		return s.auditor.Record(txCtx, userID, before, groupAudit(group))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, id)
+}
+
+// Delete deletes a group; owner only. Money flows in its ledger stay with their
+// authors as personal money flows.
+func (s *GroupService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "GroupService.Delete")
+	defer span.End()
+
+	member, err := findGroupMember(ctx, s.groupRepo, id, userID)
+	if err != nil {
+		return err
+	}
+	if !member.IsOwner() {
+		return appErrors.ErrGroupRoleRequired
+	}
+
+	group, err := s.findGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.groupRepo.Delete(txCtx, id); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete group", 500)
+		}
+		return s.auditor.Record(txCtx, userID, groupAudit(group), nil)
+	})
+}
+
+// Invite creates an invitation to join a group with the admin or member role; owners
+// and admins only. Whoever holds the returned token can accept it once.
+func (s *GroupService) Invite(ctx context.Context, userID, groupID uuid.UUID, role string) (*GroupInvitationToken, error) {
+	ctx, span := tracing.Start(ctx, "GroupService.Invite")
+	defer span.End()
+
+	if _, err := s.findManager(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := security.GenerateInvitationToken()
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate invitation token", 500)
+	}
+
+	invitation, err := domain.NewGroupInvitation(groupID, userID, role, tokenHash, groupInvitationTTL)
+	if err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"role": err.Error(),
+		})
+	}
+
+	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create invitation", 500)
+	}
+
+	return &GroupInvitationToken{Invitation: invitation, Token: token}, nil
+}
+
+// ListInvitations returns the pending invitations of a group; owners and admins only
+func (s *GroupService) ListInvitations(ctx context.Context, userID, groupID uuid.UUID) ([]*domain.GroupInvitation, error) {
+	ctx, span := tracing.Start(ctx, "GroupService.ListInvitations")
+	defer span.End()
+
+	if _, err := s.findManager(ctx, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.FindPendingByGroupID(ctx, groupID, time.Now())
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list invitations", 500)
+	}
+	return invitations, nil
+}
+
+// RevokeInvitation deletes an invitation of a group; owners and admins only
+func (s *GroupService) RevokeInvitation(ctx context.Context, userID, groupID, invitationID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "GroupService.RevokeInvitation")
+	defer span.End()
+
+	if _, err := s.findManager(ctx, groupID, userID); err != nil {
+		return err
+	}
+
+	if err := s.invitationRepo.Delete(ctx, groupID, invitationID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke invitation", 500)
+	}
+	return nil
+}
+
+// AcceptInvitation adds the user to the group of an invitation token
+func (s *GroupService) AcceptInvitation(ctx context.Context, userID uuid.UUID, token string) (*GroupDetail, error) {
+	ctx, span := tracing.Start(ctx, "GroupService.AcceptInvitation")
+	defer span.End()
+
+	invitation, err := s.invitationRepo.FindByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrInvalidInvitation
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find invitation", 500)
+	}
+	now := time.Now()
+	if invitation.IsAccepted() || invitation.IsExpired(now) {
+		return nil, appErrors.ErrInvalidInvitation
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Marking it first lets only one of two concurrent requests continue
+		if err := s.invitationRepo.MarkAccepted(txCtx, invitation.ID, userID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrInvalidInvitation
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to accept invitation", 500)
+		}
+
+		// Members accepting again roll back, leaving the invitation to someone else
+		member := domain.NewGroupMember(invitation.GroupID, userID, invitation.Role)
+		if err := s.groupRepo.AddMember(txCtx, member); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrAlreadyGroupMember
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to add group member", 500)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, invitation.GroupID)
+}
+
+// SetMemberRole makes a member an admin or a plain member; owner only
+func (s *GroupService) SetMemberRole(ctx context.Context, userID, groupID, memberID uuid.UUID, role string) error {
+	ctx, span := tracing.Start(ctx, "GroupService.SetMemberRole")
+	defer span.End()
+
+	if role != domain.GroupRoleAdmin && role != domain.GroupRoleMember {
+		return appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"role": "role must be admin or member",
+		})
+	}
+
+	requester, err := findGroupMember(ctx, s.groupRepo, groupID, userID)
+	if err != nil {
+		return err
+	}
+	if !requester.IsOwner() {
+		return appErrors.ErrGroupRoleRequired
+	}
+	if memberID == userID {
+		return appErrors.ErrOperationNotAllowed.WithDetails(map[string]interface{}{
+			"reason": "the owner's role cannot be changed",
+		})
+	}
+
+	if err := s.groupRepo.UpdateMemberRole(ctx, groupID, memberID, role); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update group member", 500)
+	}
+	return nil
+}
+
+// RemoveMember removes a member from a group. Members can leave on their own; owners
+// remove anyone else, admins remove plain members. The owner cannot leave.
+func (s *GroupService) RemoveMember(ctx context.Context, userID, groupID, memberID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "GroupService.RemoveMember")
+	defer span.End()
+
+	requester, err := findGroupMember(ctx, s.groupRepo, groupID, userID)
+	if err != nil {
+		return err
+	}
+
+	member := requester
+	if memberID != userID {
+		member, err = s.groupRepo.FindMember(ctx, groupID, memberID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find group member", 500)
+		}
+		allowed := requester.IsOwner() || (requester.CanManage() && member.Role == domain.GroupRoleMember)
+		if !allowed {
+			return appErrors.ErrGroupRoleRequired
+		}
+	}
+	if member.IsOwner() {
+		return appErrors.ErrOperationNotAllowed.WithDetails(map[string]interface{}{
+			"reason": "the owner cannot leave the group; delete it instead",
+		})
+	}
+
+	if err := s.groupRepo.RemoveMember(ctx, groupID, memberID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to remove group member", 500)
+	}
+	return nil
+}
+
+func (s *GroupService) findGroup(ctx context.Context, id uuid.UUID) (*domain.Group, error) {
+	group, err := s.groupRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find group", 500)
+	}
+	return group, nil
+}
+
+// findManager returns the membership of a user allowed to manage the group
+func (s *GroupService) findManager(ctx context.Context, groupID, userID uuid.UUID) (*domain.GroupMember, error) {
+	member, err := findGroupMember(ctx, s.groupRepo, groupID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.CanManage() {
+		return nil, appErrors.ErrGroupRoleRequired
+	}
+	return member, nil
+}
+
+// findGroupMember returns the membership of a user in a group. Groups the user is not
+// a member of are reported as not found, so their existence is not leaked.
+func findGroupMember(ctx context.Context, groupRepo repository.GroupRepository, groupID, userID uuid.UUID) (*domain.GroupMember, error) {
+	member, err := groupRepo.FindMember(ctx, groupID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find group member", 500)
+	}
+	return member, nil
+}
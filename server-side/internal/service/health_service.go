@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Health check statuses
+const (
+	HealthStatusOK    = "ok"
+	HealthStatusError = "error"
+)
+
+// HealthCheck checks one dependency of the API
+type HealthCheck struct {
+	Name string
+
+	// Critical checks make the instance not ready when they fail; the others are only
+	// reported, so an outage of an optional service does not take the API down
+	Critical bool
+
+	// Interval reuses the last result for this long, for dependencies that should not
+	// be called on every probe; zero checks on every probe
+	Interval time.Duration
+
+	Check func(ctx context.Context) error
+}
+
+// HealthCheckResult is the outcome of a HealthCheck
+type HealthCheckResult struct {
+	Name      string
+	Status    string
+	Critical  bool
+	Error     string
+	Duration  time.Duration
+	CheckedAt time.Time
+}
+
+// HealthReport is the outcome of every check; Ready is false when a critical check failed
+type HealthReport struct {
+	Ready  bool
+	Checks []*HealthCheckResult
+}
+
+// HealthService runs the readiness checks of the API's dependencies
+type HealthService struct {
+	checks  []HealthCheck
+	timeout time.Duration
+
+	mu   sync.Mutex
+	last map[string]*HealthCheckResult
+}
+
+// NewHealthService creates a new health service. timeout bounds each check.
+func NewHealthService(timeout time.Duration, checks ...HealthCheck) *HealthService {
+	return &HealthService{
+		checks:  checks,
+		timeout: timeout,
+		last:    map[string]*HealthCheckResult{},
+	}
+}
+
+// Readiness runs the checks concurrently and reports whether the instance can serve traffic
+func (s *HealthService) Readiness(ctx context.Context) *HealthReport {
+	report := &HealthReport{Ready: true, Checks: make([]*HealthCheckResult, len(s.checks))}
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = s.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Critical && result.Status != HealthStatusOK {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+// run runs a check, or returns its last result while it is within the check's interval
+func (s *HealthService) run(ctx context.Context, check HealthCheck) *HealthCheckResult {
+	if check.Interval > 0 {
+		s.mu.Lock()
+		last := s.last[check.Name]
+		s.mu.Unlock()
+		if last != nil && time.Since(last.CheckedAt) < check.Interval {
+			return last
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+	result := &HealthCheckResult{
+		Name:      check.Name,
+		Status:    HealthStatusOK,
+		Critical:  check.Critical,
+		Duration:  time.Since(start),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = HealthStatusError
+		result.Error = err.Error()
+	}
+
+	if check.Interval > 0 {
+		s.mu.Lock()
+		s.last[check.Name] = result
+		s.mu.Unlock()
+	}
+	return result
+}
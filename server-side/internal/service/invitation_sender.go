@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+)
+
+// InvitationSender delivers invitation links through a single channel
+type InvitationSender interface {
+	// Channel returns the channel name (domain.ChannelWhatsApp or domain.ChannelEmail)
+	Channel() string
+
+	// SendInvitation sends the link that accepts the invitation to the invitee
+	SendInvitation(ctx context.Context, invitation *domain.Invitation, invitee *domain.User, link string) error
+}
+
+// WhatsAppTemplateSender sends pre-approved WhatsApp template messages
+type WhatsAppTemplateSender interface {
+	SendTemplate(ctx context.Context, to string, template whatsapp.Template) (string, error)
+}
+
+// WhatsAppInvitationSender sends invitations as a WhatsApp template message, since
+// invitees have not messaged the business yet. The template body takes the invitee's
+// name as {{1}} and the link as {{2}}.
+type WhatsAppInvitationSender struct {
+	client   WhatsAppTemplateSender
+	template string
+	language string
+}
+
+// NewWhatsAppInvitationSender creates a new WhatsApp invitation sender
+func NewWhatsAppInvitationSender(client WhatsAppTemplateSender, template, language string) *WhatsAppInvitationSender {
+	return &WhatsAppInvitationSender{
+		client:   client,
+		template: template,
+		language: language,
+	}
+}
+
+// Channel returns domain.ChannelWhatsApp
+func (s *WhatsAppInvitationSender) Channel() string {
+	return domain.ChannelWhatsApp
+}
+
+// SendInvitation sends the template to the invitee's phone number
+func (s *WhatsAppInvitationSender) SendInvitation(ctx context.Context, invitation *domain.Invitation, invitee *domain.User, link string) error {
+	_, err := s.client.SendTemplate(ctx, invitee.PhoneNumber, whatsapp.Template{
+		Name:           s.template,
+		Language:       s.language,
+		BodyParameters: []string{invitee.FullName, link},
+	})
+	if errors.Is(err, whatsapp.ErrRecipientUnreachable) {
+		return ErrRecipientUnreachable
+	}
+	return err
+}
+
+// EmailSender sends plain-text email
+type EmailSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// EmailInvitationSender sends invitations by email
+type EmailInvitationSender struct {
+	client EmailSender
+}
+
+// NewEmailInvitationSender creates a new email invitation sender
+func NewEmailInvitationSender(client EmailSender) *EmailInvitationSender {
+	return &EmailInvitationSender{
+		client: client,
+	}
+}
+
+// Channel returns domain.ChannelEmail
+func (s *EmailInvitationSender) Channel() string {
+	return domain.ChannelEmail
+}
+
+// SendInvitation emails the link to the invitation's address
+func (s *EmailInvitationSender) SendInvitation(ctx context.Context, invitation *domain.Invitation, invitee *domain.User, link string) error {
+	body := fmt.Sprintf("Hi %s,\n\n"+
+		"An account has been created for you on Catetin. Open the link below to set your\n"+
+		"password; you can then sign in with this email address.\n\n"+
+		"%s\n\n"+
+		"The link expires on %s.\n",
+		invitee.FullName, link, invitation.ExpiresAt.UTC().Format("2 January 2006 15:04 MST"))
+	return s.client.Send(ctx, invitation.Email, "You're invited to Catetin", body)
+}
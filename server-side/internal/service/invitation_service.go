@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobSendInvitation sends the link of an invitation to the invitee
+const JobSendInvitation = "invitation.send"
+
+// InvitationConfig holds the invitation settings
+type InvitationConfig struct {
+	// URL is the page that accepts invitations; the token is added as the token
+	// query parameter
+	URL string
+
+	// TTL is how long an invitation can be accepted
+	TTL time.Duration
+}
+
+// InvitationService creates accounts for invited users, sends their invitations, and
+// lets them set a password. Invitations are sent by background jobs queued in the
+// transaction that creates the account, so no account is left without an invitation.
+type InvitationService struct {
+	userRepo         repository.UserRepository
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+	invitationRepo   repository.InvitationRepository
+	passwordHasher   *security.PasswordHasher
+	authService      *AuthService
+	txManager        repository.TransactionManager
+	jobs             JobEnqueuer
+	senders          map[string]InvitationSender
+	config           InvitationConfig
+}
+
+// NewInvitationService creates a new invitation service. Invitations are sent on the
+// channels of the given senders.
+func NewInvitationService(
+	userRepo repository.UserRepository,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	invitationRepo repository.InvitationRepository,
+	passwordHasher *security.PasswordHasher,
+	authService *AuthService,
+	txManager repository.TransactionManager,
+	jobs JobEnqueuer,
+	config InvitationConfig,
+	senders ...InvitationSender,
+) *InvitationService {
+	if config.TTL <= 0 {
+		config.TTL = 7 * 24 * time.Hour
+	}
+
+	senderMap := make(map[string]InvitationSender, len(senders))
+	for _, sender := range senders {
+		senderMap[sender.Channel()] = sender
+	}
+
+	return &InvitationService{
+		userRepo:         userRepo,
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+		invitationRepo:   invitationRepo,
+		passwordHasher:   passwordHasher,
+		authService:      authService,
+		txManager:        txManager,
+		jobs:             jobs,
+		senders:          senderMap,
+		config:           config,
+	}
+}
+
+// HandleSendJob processes a JobSendInvitation. Every attempt sends a new token, so a
+// link from an earlier, failed attempt stops working.
+func (s *InvitationService) HandleSendJob(ctx context.Context, job *worker.Job) (err error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Send")
+	defer func() { tracing.End(span, err) }()
+
+	var payload struct {
+		InvitationID uuid.UUID `json:"invitation_id"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	invitation, err := s.invitationRepo.FindByID(ctx, payload.InvitationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return worker.Permanent(err) // the invitee was deleted
+		}
+		return err
+	}
+	if invitation.IsAccepted() || invitation.IsExpired(time.Now()) {
+		return nil
+	}
+
+	invitee, err := s.userRepo.FindByID(ctx, invitation.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return worker.Permanent(err)
+		}
+		return err
+	}
+
+	token, tokenHash, err := security.GenerateInvitationToken()
+	if err != nil {
+		return err
+	}
+	if err := s.invitationRepo.MarkSent(ctx, invitation.ID, tokenHash, time.Now()); err != nil {
+		return err
+	}
+
+	err = s.send(ctx, invitation.Channel, invitation, invitee, s.link(token))
+	if errors.Is(err, ErrRecipientUnreachable) && invitation.Channel == domain.ChannelWhatsApp {
+		// The phone number is not on WhatsApp; fall back to email
+		logger.FromContext(ctx).Info("invitation not deliverable by WhatsApp, sending by email", "invitation_id", invitation.ID)
+		err = s.send(ctx, domain.ChannelEmail, invitation, invitee, s.link(token))
+	}
+	if errors.Is(err, ErrRecipientUnreachable) {
+		return worker.Permanent(err)
+	}
+	return err
+}
+
+func (s *InvitationService) send(ctx context.Context, channel string, invitation *domain.Invitation, invitee *domain.User, link string) error {
+	sender, ok := s.senders[channel]
+	if !ok {
+		return ErrRecipientUnreachable
+	}
+	return sender.SendInvitation(ctx, invitation, invitee, link)
+}
+
+// link returns the URL that accepts the invitation of the token
+func (s *InvitationService) link(token string) string {
+	link, err := url.Parse(s.config.URL)
+	if err != nil {
+		return s.config.URL + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// Accept sets the password of an invited user, who can then sign in with the email of
+// the invitation, and signs them in. The tokens are issued to the client audience,
+// security.DefaultAudience when empty.
+func (s *InvitationService) Accept(ctx context.Context, token, password, client string) (*LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.Accept")
+	defer span.End()
+
+	invitation, err := s.invitationRepo.FindByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrInvalidInvitation
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find invitation", 500)
+	}
+	if invitation.IsAccepted() || invitation.IsExpired(time.Now()) {
+		return nil, appErrors.ErrInvalidInvitation
+	}
+
+	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
+	if provider == nil {
+		return nil, appErrors.New(appErrors.ErrCodeInternal, "Authentication provider not configured", 500)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, invitation.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrInvalidInvitation
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to hash password", 500)
+	}
+
+	now := time.Now()
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Marking it first lets only one of two concurrent requests continue
+		if err := s.invitationRepo.MarkAccepted(txCtx, invitation.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrInvalidInvitation
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to accept invitation", 500)
+		}
+
+		userAuth := &repository.UserAuth{
+			ID:               uuid.New(),
+			UserID:           user.ID,
+			AuthProviderID:   provider.ID,
+			CredentialID:     invitation.Email,
+			CredentialSecret: hashedPassword,
+		}
+		if err := s.userAuthRepo.Create(txCtx, userAuth); err != nil {
+			if errors.Is(err, domain.ErrDuplicateCredential) {
+				return appErrors.ErrEmailAlreadyExists
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create user auth", 500)
+		}
+
+		return nil // Commit transaction
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.authService.issueTokens(ctx, user, invitation.Email, now, client, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		User:         user,
+		Email:        invitation.Email,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// MerchantService handles the merchants money flows are linked to and the rules that
+// normalize payee names to them, maintained by admins
+type MerchantService struct {
+	merchantRepo repository.MerchantRepository
+}
+
+// NewMerchantService creates a new merchant service
+func NewMerchantService(merchantRepo repository.MerchantRepository) *MerchantService {
+	return &MerchantService{
+		merchantRepo: merchantRepo,
+	}
+}
+
+// MerchantRuleInput holds the fields of a merchant rule: the payee names containing the
+// words of Pattern are linked to the merchant named Merchant, created when new
+type MerchantRuleInput struct {
+	Pattern  string
+	Merchant string
+}
+
+// List lists the merchants whose name contains query, ordered by name
+func (s *MerchantService) List(ctx context.Context, query string, limit, offset int) ([]*domain.Merchant, error) {
+	ctx, span := tracing.Start(ctx, "MerchantService.List")
+	defer span.End()
+
+	merchants, err := s.merchantRepo.List(ctx, query, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list merchants", 500)
+	}
+	return merchants, nil
+}
+
+// Rename changes the name of a merchant, for every money flow linked to it
+func (s *MerchantService) Rename(ctx context.Context, id uuid.UUID, name string) (*domain.Merchant, error) {
+	ctx, span := tracing.Start(ctx, "MerchantService.Rename")
+	defer span.End()
+
+	merchant, err := s.merchantRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find merchant", 500)
+	}
+
+	if err := merchant.Rename(name); err != nil {
+		return nil, validationError(err)
+	}
+
+	if err := s.merchantRepo.Update(ctx, merchant); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrConflict):
+			return nil, appErrors.ErrMerchantAlreadyExists
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update merchant", 500)
+	}
+
+	return merchant, nil
+}
+
+// ListRules lists the merchant rules with their merchants, ordered by pattern
+func (s *MerchantService) ListRules(ctx context.Context) ([]*domain.MerchantRule, error) {
+	ctx, span := tracing.Start(ctx, "MerchantService.ListRules")
+	defer span.End()
+
+	rules, err := s.merchantRepo.ListRules(ctx)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list merchant rules", 500)
+	}
+	return rules, nil
+}
+
+// CreateRule creates a merchant rule. It applies to the money flows recorded afterwards.
+func (s *MerchantService) CreateRule(ctx context.Context, input MerchantRuleInput) (*domain.MerchantRule, error) {
+	ctx, span := tracing.Start(ctx, "MerchantService.CreateRule")
+	defer span.End()
+
+	merchant, err := s.findOrCreate(ctx, input.Merchant)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := domain.NewMerchantRule(input.Pattern, merchant)
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	if err := s.merchantRepo.CreateRule(ctx, rule); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, appErrors.ErrMerchantRuleAlreadyExists
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create merchant rule", 500)
+	}
+
+	return rule, nil
+}
+
+// UpdateRule replaces the pattern and merchant of a merchant rule
+func (s *MerchantService) UpdateRule(ctx context.Context, id uuid.UUID, input MerchantRuleInput) (*domain.MerchantRule, error) {
+	ctx, span := tracing.Start(ctx, "MerchantService.UpdateRule")
+	defer span.End()
+
+	rule, err := s.merchantRepo.FindRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find merchant rule", 500)
+	}
+
+	merchant, err := s.findOrCreate(ctx, input.Merchant)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.Change(input.Pattern, merchant); err != nil {
+		return nil, validationError(err)
+	}
+
+	if err := s.merchantRepo.UpdateRule(ctx, rule); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrConflict):
+			return nil, appErrors.ErrMerchantRuleAlreadyExists
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update merchant rule", 500)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule deletes a merchant rule; money flows already linked keep their merchant
+func (s *MerchantService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "MerchantService.DeleteRule")
+	defer span.End()
+
+	if err := s.merchantRepo.DeleteRule(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete merchant rule", 500)
+	}
+	return nil
+}
+
+// findOrCreate returns the merchant with the name, creating it when new
+func (s *MerchantService) findOrCreate(ctx context.Context, name string) (*domain.Merchant, error) {
+	// Validate the name before it reaches the repository
+	if _, err := domain.NewMerchant(name); err != nil {
+		return nil, validationError(err)
+	}
+
+	merchant, err := s.merchantRepo.FindOrCreate(ctx, name)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to save merchant", 500)
+	}
+	return merchant, nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/realtime"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// BulkCreateResult is the outcome of one item of CreateBulk: the created money flow,
+// or the error that kept it from being created
+type BulkCreateResult struct {
+	Detail *MoneyFlowDetail
+	Err    *appErrors.AppError
+}
+
+// CreateBulk creates the money flows of several inputs, validating each like Create.
+// Items that fail validation or exceed a hard budget are reported in their result and
+// the others are inserted together in one transaction. Results are in input order.
+// An error is only returned when the batch as a whole fails.
+func (s *MoneyFlowService) CreateBulk(ctx context.Context, userID uuid.UUID, inputs []MoneyFlowInput) (results []*BulkCreateResult, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.CreateBulk",
+		attribute.Int("bulk.items", len(inputs)))
+	defer func() { tracing.End(span, err) }()
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := s.bulkWallets(ctx, userID, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := map[uuid.UUID]error{}
+	merchants := s.merchants()
+	results = make([]*BulkCreateResult, len(inputs))
+	for i, input := range inputs {
+		if input.GroupID != nil {
+			groupErr, checked := groups[*input.GroupID]
+			if !checked {
+				groupErr = s.checkGroup(ctx, userID, input.GroupID)
+				if appErr, ok := appErrors.IsAppError(groupErr); ok && appErr.Code == appErrors.ErrCodeInternal {
+					return nil, groupErr
+				}
+				groups[*input.GroupID] = groupErr
+			}
+			if groupErr != nil {
+				results[i] = bulkError(groupErr)
+				continue
+			}
+		}
+		results[i] = s.prepareBulkItem(userID, settings, wallets, input)
+		if results[i].Err != nil {
+			continue
+		}
+
+		merchant, err := merchants.resolve(ctx, input.Merchant)
+		if err != nil {
+			return nil, err
+		}
+		if merchant != nil {
+			results[i].Detail.MoneyFlow.MerchantID = &merchant.ID
+			results[i].Detail.Merchant = merchant
+		}
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Spending of earlier items counts toward the budgets of later ones
+		pending := map[[2]string]int64{}
+		var (
+			moneyFlows []*domain.MoneyFlow
+			overrides  []*domain.BudgetOverride
+		)
+		for i, result := range results {
+			if result.Err != nil {
+				continue
+			}
+			moneyFlow := result.Detail.MoneyFlow
+
+			var key [2]string
+			if moneyFlow.Category != nil {
+				key = [2]string{*moneyFlow.Category, moneyFlow.Currency}
+			}
+			override, err := s.checkBudget(txCtx, moneyFlow, inputs[i].OverrideBudget, pending[key])
+			if err != nil {
+				if appErr, ok := appErrors.IsAppError(err); ok && appErr.Code != appErrors.ErrCodeInternal {
+					results[i] = &BulkCreateResult{Err: appErr}
+					continue
+				}
+				return err
+			}
+
+			pending[key] += moneyFlow.Amount
+			moneyFlows = append(moneyFlows, moneyFlow)
+			if override != nil {
+				overrides = append(overrides, override)
+			}
+		}
+
+		if err := s.moneyFlowRepo.CreateBatch(txCtx, moneyFlows); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create money flows", 500)
+		}
+		for _, moneyFlow := range moneyFlows {
+			if err := s.auditor.Record(txCtx, userID, nil, moneyFlowAudit(moneyFlow)); err != nil {
+				return err
+			}
+			if err := s.outbox.Emit(txCtx, events.MoneyFlowCreated, &userID, moneyFlowPayload(moneyFlow)); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record event", 500)
+			}
+			s.events.Publish(txCtx, userID, realtime.EventMoneyFlowCreated, moneyFlowEvent(moneyFlow))
+		}
+
+		for _, override := range overrides {
+			if err := s.recordOverride(txCtx, override); err != nil {
+				return err
+			}
+		}
+
+		for _, result := range results {
+			if result.Err != nil || result.Detail.Note == nil {
+				continue
+			}
+			if err := s.noteRepo.Save(txCtx, result.Detail.Note); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to save note", 500)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// bulkWallets returns the user's wallets by ID, or nil if no input of CreateBulk
+// is recorded in a wallet
+func (s *MoneyFlowService) bulkWallets(ctx context.Context, userID uuid.UUID, inputs []MoneyFlowInput) (map[uuid.UUID]*domain.Wallet, error) {
+	if !slices.ContainsFunc(inputs, func(input MoneyFlowInput) bool { return input.WalletID != nil }) {
+		return nil, nil
+	}
+
+	wallets, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find wallets", 500)
+	}
+
+	byID := make(map[uuid.UUID]*domain.Wallet, len(wallets))
+	for _, wallet := range wallets {
+		byID[wallet.ID] = wallet
+	}
+	return byID, nil
+}
+
+// prepareBulkItem validates an input of CreateBulk and builds its money flow and note
+func (s *MoneyFlowService) prepareBulkItem(userID uuid.UUID, settings *domain.UserSettings, wallets map[uuid.UUID]*domain.Wallet, input MoneyFlowInput) *BulkCreateResult {
+	var wallet *domain.Wallet
+	if input.WalletID != nil {
+		wallet = wallets[*input.WalletID]
+		if wallet == nil {
+			return &BulkCreateResult{Err: appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"wallet_id": "wallet not found",
+			})}
+		}
+		if input.Currency == "" {
+			input.Currency = wallet.Currency
+		}
+	}
+	if input.Currency == "" {
+		input.Currency = settings.DefaultCurrency
+	}
+	if err := checkCurrency(settings, input.Currency); err != nil {
+		return bulkError(err)
+	}
+	if err := checkWalletCurrency(wallet, input.Currency); err != nil {
+		return bulkError(err)
+	}
+
+	moneyFlow, err := domain.NewMoneyFlow(userID, input.Amount, input.Currency)
+	if err != nil {
+		return bulkError(validationError(err))
+	}
+	applyMoneyFlowInput(moneyFlow, input)
+	if err := moneyFlow.Validate(); err != nil {
+		return bulkError(validationError(err))
+	}
+
+	detail := &MoneyFlowDetail{MoneyFlow: moneyFlow}
+	if input.Note != nil && *input.Note != "" {
+		detail.Note, err = newNote(moneyFlow.ID, *input.Note)
+		if err != nil {
+			return bulkError(err)
+		}
+	}
+
+	return &BulkCreateResult{Detail: detail}
+}
+
+func bulkError(err error) *BulkCreateResult {
+	appErr, ok := appErrors.IsAppError(err)
+	if !ok {
+		appErr = appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create money flow", 500)
+	}
+	return &BulkCreateResult{Err: appErr}
+}
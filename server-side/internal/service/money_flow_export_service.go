@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MoneyFlowStatement is the data of a user's export: their money flows of one month,
+// oldest first
+type MoneyFlowStatement struct {
+	User        *domain.User
+	PeriodStart time.Time
+	PeriodEnd   time.Time // exclusive
+	MoneyFlows  []*domain.MoneyFlow
+}
+
+// Exporter writes a statement in one file format. Register new formats with
+// NewMoneyFlowExportService; the handler serves every registered format.
+type Exporter interface {
+	// Format is the name of the format in requests and the file extension, e.g. "csv"
+	Format() string
+
+	// ContentType is the media type of the files
+	ContentType() string
+
+	// Export writes the statement
+	Export(w io.Writer, statement *MoneyFlowStatement) error
+}
+
+// ExportFile is a generated export
+type ExportFile struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// MoneyFlowExportService exports a user's money flows as downloadable files
+type MoneyFlowExportService struct {
+	moneyFlowRepo repository.MoneyFlowRepository
+	userRepo      repository.UserRepository
+	exporters     map[string]Exporter
+	formats       []string
+}
+
+// NewMoneyFlowExportService creates a new money flow export service serving the formats
+// of the exporters
+func NewMoneyFlowExportService(
+	moneyFlowRepo repository.MoneyFlowRepository,
+	userRepo repository.UserRepository,
+	exporters ...Exporter,
+) *MoneyFlowExportService {
+	s := &MoneyFlowExportService{
+		moneyFlowRepo: moneyFlowRepo,
+		userRepo:      userRepo,
+		exporters:     make(map[string]Exporter, len(exporters)),
+	}
+	for _, exporter := range exporters {
+		s.exporters[exporter.Format()] = exporter
+		s.formats = append(s.formats, exporter.Format())
+	}
+	return s
+}
+
+// Formats returns the supported formats in registration order
+func (s *MoneyFlowExportService) Formats() []string {
+	return s.formats
+}
+
+// Export generates the file of a user's money flows recorded in the month
+func (s *MoneyFlowExportService) Export(ctx context.Context, userID uuid.UUID, format string, month time.Time) (file *ExportFile, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowExportService.Export",
+		attribute.String("export.format", format))
+	defer func() { tracing.End(span, err) }()
+
+	exporter, ok := s.exporters[strings.ToLower(format)]
+	if !ok {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"format": "must be one of " + strings.Join(s.formats, ", "),
+		})
+	}
+
+	statement, err := s.Statement(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+	if err := exporter.Export(&content, statement); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate export", 500)
+	}
+
+	return &ExportFile{
+		Filename:    "catetin-" + statement.PeriodStart.Format(ExportMonthLayout) + "." + exporter.Format(),
+		ContentType: exporter.ContentType(),
+		Content:     content.Bytes(),
+	}, nil
+}
+
+// Statement returns the data of the export of a user's money flows recorded in the
+// month, in UTC
+func (s *MoneyFlowExportService) Statement(ctx context.Context, userID uuid.UUID, month time.Time) (statement *MoneyFlowStatement, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowExportService.Statement")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user", 500)
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	// The date range is inclusive on both ends
+	moneyFlows, err := s.moneyFlowRepo.FindByUserIDAndDateRange(ctx, userID, start, end.Add(-time.Microsecond))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get money flows", 500)
+	}
+	// Statements list spending; transfers between wallets only move money around
+	moneyFlows = slices.DeleteFunc(moneyFlows, (*domain.MoneyFlow).IsTransfer)
+	sort.SliceStable(moneyFlows, func(i, j int) bool {
+		return moneyFlows[i].CreatedAt.Before(moneyFlows[j].CreatedAt)
+	})
+
+	return &MoneyFlowStatement{
+		User:        user,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		MoneyFlows:  moneyFlows,
+	}, nil
+}
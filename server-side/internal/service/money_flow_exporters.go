@@ -0,0 +1,259 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/pdf"
+	"github.com/ingunawandra/catetin/internal/infrastructure/xlsx"
+)
+
+// uncategorized labels money flows without a category in exports
+const uncategorized = "Uncategorized"
+
+// exportColumns are the columns of the transaction tables in CSV and XLSX exports
+var exportColumns = []string{"Date", "Category", "Description", "Amount", "Currency", "Tags"}
+
+// CSVExporter exports money flows as a CSV file with one row per money flow
+type CSVExporter struct{}
+
+// Format implements Exporter
+func (CSVExporter) Format() string { return "csv" }
+
+// ContentType implements Exporter
+func (CSVExporter) ContentType() string { return "text/csv; charset=utf-8" }
+
+// Export implements Exporter
+func (CSVExporter) Export(w io.Writer, statement *MoneyFlowStatement) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(exportColumns); err != nil {
+		return err
+	}
+	for _, moneyFlow := range statement.MoneyFlows {
+		if err := out.Write([]string{
+			moneyFlow.CreatedAt.UTC().Format(time.RFC3339),
+			valueOrEmpty(moneyFlow.Category),
+			valueOrEmpty(moneyFlow.Description),
+			moneyFlow.Money().String(),
+			moneyFlow.Currency,
+			strings.Join(moneyFlow.Tags, ","),
+		}); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// XLSXExporter exports money flows as a spreadsheet with a summary sheet, a sheet of
+// all money flows, and a sheet per category
+type XLSXExporter struct{}
+
+// Format implements Exporter
+func (XLSXExporter) Format() string { return "xlsx" }
+
+// ContentType implements Exporter
+func (XLSXExporter) ContentType() string { return xlsx.ContentType }
+
+// Export implements Exporter
+func (XLSXExporter) Export(w io.Writer, statement *MoneyFlowStatement) error {
+	workbook := xlsx.New()
+	totals := statementTotals(statement)
+
+	summary := workbook.AddSheet("Summary")
+	summary.AddRow("Statement", statement.User.FullName)
+	summary.AddRow("Period", statementPeriod(statement))
+	summary.AddRow()
+	summary.AddHeader("Category", "Currency", "Total", "Transactions")
+	for _, total := range totals {
+		summary.AddRow(total.category, total.currency, total.money().Float64(), total.count)
+	}
+
+	all := workbook.AddSheet("All")
+	all.AddHeader(exportColumns...)
+	for _, moneyFlow := range statement.MoneyFlows {
+		addMoneyFlowRow(all, moneyFlow)
+	}
+
+	sheets := map[string]*xlsx.Sheet{}
+	for _, moneyFlow := range statement.MoneyFlows {
+		category := categoryLabel(moneyFlow)
+		sheet, ok := sheets[category]
+		if !ok {
+			sheet = workbook.AddSheet(category)
+			sheet.AddHeader(exportColumns...)
+			sheets[category] = sheet
+		}
+		addMoneyFlowRow(sheet, moneyFlow)
+	}
+
+	return workbook.Write(w)
+}
+
+func addMoneyFlowRow(sheet *xlsx.Sheet, moneyFlow *domain.MoneyFlow) {
+	sheet.AddRow(
+		moneyFlow.CreatedAt,
+		moneyFlow.Category,
+		moneyFlow.Description,
+		moneyFlow.Money().Float64(),
+		moneyFlow.Currency,
+		strings.Join(moneyFlow.Tags, ", "),
+	)
+}
+
+// PDFExporter exports money flows as a printable monthly statement
+type PDFExporter struct{}
+
+// Format implements Exporter
+func (PDFExporter) Format() string { return "pdf" }
+
+// ContentType implements Exporter
+func (PDFExporter) ContentType() string { return pdf.ContentType }
+
+// Export implements Exporter
+func (PDFExporter) Export(w io.Writer, statement *MoneyFlowStatement) error {
+	doc := pdf.New("Catetin statement " + statement.PeriodStart.Format(ExportMonthLayout))
+
+	doc.Text(pdf.HelveticaBold, 18, "Monthly Statement")
+	doc.Text(pdf.Helvetica, 11, statementPeriod(statement))
+	doc.Text(pdf.Helvetica, 11, statement.User.FullName)
+	doc.Space(12)
+
+	// Columns are padded in a monospace font so they line up
+	doc.Text(pdf.HelveticaBold, 12, "Summary")
+	doc.Text(pdf.CourierBold, 9, fmt.Sprintf("%-40s %-8s %20s %6s", "Category", "Currency", "Total", "Count"))
+	doc.Rule()
+	for _, total := range statementTotals(statement) {
+		font := pdf.Courier
+		if total.overall {
+			font = pdf.CourierBold
+		}
+		doc.Text(font, 9, fmt.Sprintf("%-40s %-8s %20s %6d",
+			clip(total.category, 40), total.currency, formatStatementAmount(total.money().Float64()), total.count))
+	}
+	doc.Space(12)
+
+	doc.Text(pdf.HelveticaBold, 12, "Transactions")
+	if len(statement.MoneyFlows) == 0 {
+		doc.Text(pdf.Helvetica, 10, "No money flows were recorded in this period.")
+	} else {
+		doc.Text(pdf.CourierBold, 9, fmt.Sprintf("%-16s %-18s %-24s %17s %-3s", "Date", "Category", "Description", "Amount", ""))
+		doc.Rule()
+		for _, moneyFlow := range statement.MoneyFlows {
+			doc.Text(pdf.Courier, 9, fmt.Sprintf("%-16s %-18s %-24s %17s %-3s",
+				moneyFlow.CreatedAt.UTC().Format("2006-01-02 15:04"),
+				clip(categoryLabel(moneyFlow), 18),
+				clip(valueOrEmpty(moneyFlow.Description), 24),
+				formatStatementAmount(moneyFlow.Money().Float64()),
+				moneyFlow.Currency))
+		}
+	}
+	doc.Space(12)
+	doc.Text(pdf.Helvetica, 8, "Times are in UTC. Generated by Catetin on "+time.Now().UTC().Format("2006-01-02 15:04")+".")
+
+	return doc.Write(w)
+}
+
+// statementTotal is the total of a category in one currency. The totals over all
+// categories are overall, with the category "Total".
+type statementTotal struct {
+	category string
+	currency string
+	amount   int64 // in minor units of currency
+	count    int
+	overall  bool
+}
+
+func (t *statementTotal) money() domain.Money {
+	return domain.Money{Minor: t.amount, Currency: t.currency}
+}
+
+// statementTotals returns the totals per category and currency, sorted by category,
+// followed by the totals per currency
+func statementTotals(statement *MoneyFlowStatement) []*statementTotal {
+	byCategory := map[[2]string]*statementTotal{}
+	byCurrency := map[string]*statementTotal{}
+	for _, moneyFlow := range statement.MoneyFlows {
+		key := [2]string{categoryLabel(moneyFlow), moneyFlow.Currency}
+		if byCategory[key] == nil {
+			byCategory[key] = &statementTotal{category: key[0], currency: key[1]}
+		}
+		if byCurrency[moneyFlow.Currency] == nil {
+			byCurrency[moneyFlow.Currency] = &statementTotal{category: "Total", currency: moneyFlow.Currency, overall: true}
+		}
+		for _, total := range []*statementTotal{byCategory[key], byCurrency[moneyFlow.Currency]} {
+			total.amount += moneyFlow.Amount
+			total.count++
+		}
+	}
+
+	categories := make([]*statementTotal, 0, len(byCategory))
+	for _, total := range byCategory {
+		categories = append(categories, total)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].category != categories[j].category {
+			return categories[i].category < categories[j].category
+		}
+		return categories[i].currency < categories[j].currency
+	})
+
+	currencies := make([]*statementTotal, 0, len(byCurrency))
+	for _, total := range byCurrency {
+		currencies = append(currencies, total)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i].currency < currencies[j].currency
+	})
+
+	return append(categories, currencies...)
+}
+
+func statementPeriod(statement *MoneyFlowStatement) string {
+	return statement.PeriodStart.Format("2 January 2006") + " - " +
+		statement.PeriodEnd.AddDate(0, 0, -1).Format("2 January 2006")
+}
+
+func categoryLabel(moneyFlow *domain.MoneyFlow) string {
+	if moneyFlow.Category == nil || strings.TrimSpace(*moneyFlow.Category) == "" {
+		return uncategorized
+	}
+	return *moneyFlow.Category
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// formatStatementAmount formats an amount with thousands separators and two decimals,
+// e.g. 1,500,000.00
+func formatStatementAmount(amount float64) string {
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, fraction := s[:len(s)-3], s[len(s)-3:]
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	return sign + whole + fraction
+}
+
+// clip cuts text to n characters, marking the cut with "~"
+func clip(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "~"
+}
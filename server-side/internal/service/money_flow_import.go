@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MaxImportRows is the maximum number of data rows in an imported file
+const MaxImportRows = 5000
+
+// ImportDateFormats maps the accepted date formats of imports to Go layouts
+var ImportDateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"DD/MM/YYYY": "02/01/2006",
+	"MM/DD/YYYY": "01/02/2006",
+	"DD-MM-YYYY": "02-01-2006",
+	"DD.MM.YYYY": "02.01.2006",
+}
+
+// Import row statuses
+const (
+	ImportRowValid     = "valid"     // would be imported (dry run)
+	ImportRowImported  = "imported"  // recorded as a money flow
+	ImportRowDuplicate = "duplicate" // matches a recorded money flow or an earlier row
+	ImportRowSkipped   = "skipped"   // income row when only negative amounts are expenses
+	ImportRowInvalid   = "invalid"   // failed validation; see Errors
+)
+
+// ImportMapping tells which CSV columns hold the money flow fields. Columns are
+// named by their header, case-insensitively.
+type ImportMapping struct {
+	DateColumn        string
+	AmountColumn      string
+	DescriptionColumn string // optional
+	CategoryColumn    string // optional
+	CurrencyColumn    string // optional; rows without one use Currency
+
+	DateFormat       string // a key of ImportDateFormats; defaults to YYYY-MM-DD
+	Delimiter        rune   // defaults to ','
+	DecimalSeparator rune   // '.' (default) or ','
+
+	// Currency is the currency of rows without one; defaults to the user's default currency
+	Currency string
+
+	// NegativeExpenses is set for bank statements that list expenses as negative
+	// amounts. Rows with positive amounts (income) are then skipped.
+	NegativeExpenses bool
+}
+
+// ImportRow is the outcome of one data row
+type ImportRow struct {
+	Line      int // line number in the file, the header being line 1
+	Status    string
+	MoneyFlow *domain.MoneyFlow // parsed money flow; nil when invalid
+	Income    float64           // amount of a skipped income row
+	Errors    map[string]string
+}
+
+// ImportResult is the outcome of an import
+type ImportResult struct {
+	DryRun     bool
+	Rows       []*ImportRow
+	Imported   int
+	Valid      int
+	Duplicates int
+	Skipped    int
+	Invalid    int
+}
+
+// Import records the money flows of a CSV file, e.g. a bank statement export. Rows
+// that fail validation are reported and left out; rows matching a money flow already
+// recorded on the same day with the same amount and description are reported as
+// duplicates, so a statement can be imported again after fixing invalid rows. The
+// valid rows are inserted in one transaction. With dryRun set, nothing is recorded
+// and the result previews the import.
+//
+// Hard budgets are not enforced, since imported money flows were already spent.
+func (s *MoneyFlowService) Import(ctx context.Context, userID uuid.UUID, file io.Reader, mapping ImportMapping, dryRun bool) (result *ImportResult, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Import",
+		attribute.Bool("import.dry_run", dryRun))
+	defer func() { tracing.End(span, err) }()
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Currency == "" {
+		mapping.Currency = settings.DefaultCurrency
+	}
+
+	rows, err := parseImport(file, userID, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	result = &ImportResult{DryRun: dryRun, Rows: rows}
+	if err := s.markDuplicates(ctx, userID, rows); err != nil {
+		return nil, err
+	}
+
+	var moneyFlows []*domain.MoneyFlow
+	for _, row := range rows {
+		if row.Status != ImportRowValid {
+			continue
+		}
+		if err := checkCurrency(settings, row.MoneyFlow.Currency); err != nil {
+			row.Status = ImportRowInvalid
+			row.Errors = map[string]string{"currency": "must be " + settings.DefaultCurrency + " in single-currency mode"}
+			continue
+		}
+		moneyFlows = append(moneyFlows, row.MoneyFlow)
+	}
+
+	if !dryRun && len(moneyFlows) > 0 {
+		err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			return s.moneyFlowRepo.CreateBatch(txCtx, moneyFlows)
+		})
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to import money flows", 500)
+		}
+		for _, row := range rows {
+			if row.Status == ImportRowValid {
+				row.Status = ImportRowImported
+			}
+		}
+	}
+
+	for _, row := range rows {
+		switch row.Status {
+		case ImportRowImported:
+			result.Imported++
+		case ImportRowValid:
+			result.Valid++
+		case ImportRowDuplicate:
+			result.Duplicates++
+		case ImportRowSkipped:
+			result.Skipped++
+		case ImportRowInvalid:
+			result.Invalid++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("import.rows", len(rows)),
+		attribute.Int("import.imported", result.Imported),
+	)
+	return result, nil
+}
+
+// markDuplicates marks valid rows that match a recorded money flow, or an earlier row
+// of the file, as duplicates
+func (s *MoneyFlowService) markDuplicates(ctx context.Context, userID uuid.UUID, rows []*ImportRow) error {
+	var first, last time.Time
+	for _, row := range rows {
+		if row.Status != ImportRowValid {
+			continue
+		}
+		date := row.MoneyFlow.CreatedAt
+		if first.IsZero() || date.Before(first) {
+			first = date
+		}
+		if date.After(last) {
+			last = date
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	// The date range is inclusive on both ends
+	recorded, err := s.moneyFlowRepo.FindByUserIDAndDateRange(ctx, userID, first, last.AddDate(0, 0, 1).Add(-time.Microsecond))
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find money flows", 500)
+	}
+
+	seen := make(map[string]bool, len(recorded)+len(rows))
+	for _, moneyFlow := range recorded {
+		seen[importHash(moneyFlow)] = true
+	}
+	for _, row := range rows {
+		if row.Status != ImportRowValid {
+			continue
+		}
+		hash := importHash(row.MoneyFlow)
+		if seen[hash] {
+			row.Status = ImportRowDuplicate
+		}
+		seen[hash] = true
+	}
+	return nil
+}
+
+// importHash identifies a money flow for duplicate detection by its date (UTC), amount,
+// and description
+func importHash(moneyFlow *domain.MoneyFlow) string {
+	description := ""
+	if moneyFlow.Description != nil {
+		description = strings.ToLower(strings.Join(strings.Fields(*moneyFlow.Description), " "))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		moneyFlow.CreatedAt.UTC().Format("2006-01-02"),
+		moneyFlow.Money().String(),
+		description,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseImport reads the rows of a CSV file. Problems with the file as a whole, such as
+// a missing column, are returned as validation errors; problems with a row are
+// recorded on the row.
+func parseImport(file io.Reader, userID uuid.UUID, mapping ImportMapping) ([]*ImportRow, error) {
+	layout, ok := ImportDateFormats[mapping.DateFormat]
+	if mapping.DateFormat == "" {
+		layout, ok = ImportDateFormats["YYYY-MM-DD"], true
+	}
+	if !ok {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"date_format": "unsupported date format",
+		})
+	}
+
+	reader := csv.NewReader(file)
+	if mapping.Delimiter != 0 {
+		reader.Comma = mapping.Delimiter
+	}
+	reader.FieldsPerRecord = -1 // short rows are reported per row
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "must be a CSV file with a header row",
+		})
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // byte order mark written by spreadsheets
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	details := map[string]interface{}{}
+	column := func(field, name string, required bool) int {
+		if name == "" {
+			if required {
+				details[field] = "is required"
+			}
+			return -1
+		}
+		index, ok := columns[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			details[field] = fmt.Sprintf("column %q not found in the header", name)
+			return -1
+		}
+		return index
+	}
+	indexes := importColumns{
+		date:        column("date_column", mapping.DateColumn, true),
+		amount:      column("amount_column", mapping.AmountColumn, true),
+		description: column("description_column", mapping.DescriptionColumn, false),
+		category:    column("category_column", mapping.CategoryColumn, false),
+		currency:    column("currency_column", mapping.CurrencyColumn, false),
+	}
+	if len(details) > 0 {
+		return nil, appErrors.ErrValidation.WithDetails(details)
+	}
+
+	var rows []*ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil && isBlank(record) {
+			continue
+		}
+
+		if len(rows) == MaxImportRows {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"file": fmt.Sprintf("must have at most %d rows", MaxImportRows),
+			})
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+					"file": "could not be read",
+				})
+			}
+			rows = append(rows, &ImportRow{
+				Line:   parseErr.StartLine,
+				Status: ImportRowInvalid,
+				Errors: map[string]string{"row": parseErr.Err.Error()},
+			})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := &ImportRow{Line: line, Status: ImportRowValid}
+		parseImportRow(row, record, userID, mapping, layout, indexes)
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "has no rows",
+		})
+	}
+	return rows, nil
+}
+
+// importColumns holds the indexes of the mapped columns; -1 for unmapped ones
+type importColumns struct {
+	date, amount, description, category, currency int
+}
+
+// parseImportRow validates a record and sets the money flow of the row
+func parseImportRow(row *ImportRow, record []string, userID uuid.UUID, mapping ImportMapping, layout string, columns importColumns) {
+	errs := map[string]string{}
+	field := func(index int) string {
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	date, err := time.Parse(layout, field(columns.date))
+	if err != nil {
+		errs["date"] = fmt.Sprintf("%q does not match the date format", field(columns.date))
+	}
+
+	amount, err := parseImportAmount(field(columns.amount), mapping.DecimalSeparator)
+	if err != nil {
+		errs["amount"] = fmt.Sprintf("%q is not a number", field(columns.amount))
+	} else if mapping.NegativeExpenses {
+		if amount > 0 {
+			row.Status = ImportRowSkipped
+			row.Income = amount
+			return
+		}
+		amount = -amount
+	}
+
+	currency := strings.ToUpper(field(columns.currency))
+	if currency == "" {
+		currency = mapping.Currency
+	}
+
+	// An amount that did not parse keeps its error rather than the one of the rules
+	moneyFlow, err := domain.NewMoneyFlow(userID, amount, currency)
+	if err == nil {
+		moneyFlow.Description = optionalString(field(columns.description))
+		moneyFlow.Category = optionalString(field(columns.category))
+		err = moneyFlow.Validate()
+	}
+	var invalid *domain.ValidationError
+	if errors.As(err, &invalid) {
+		for _, fieldError := range invalid.Fields {
+			if _, exists := errs[fieldError.Field]; !exists {
+				errs[fieldError.Field] = strings.TrimPrefix(fieldError.Message, fieldError.Field+" ")
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		row.Status = ImportRowInvalid
+		row.Errors = errs
+		return
+	}
+
+	moneyFlow.CreatedAt = date
+	row.MoneyFlow = moneyFlow
+}
+
+// parseImportAmount parses amounts such as "1,500,000.00", "-25.5", "(25.50)", or,
+// with ',' as the decimal separator, "1.500.000,00"
+func parseImportAmount(value string, decimalSeparator rune) (float64, error) {
+	thousands := ","
+	if decimalSeparator == ',' {
+		thousands = "."
+	}
+
+	negative := strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")
+	value = strings.Trim(value, "()")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "Rp"), "IDR")
+	value = strings.NewReplacer(thousands, "", " ", "", "\u00a0", "").Replace(value)
+	if decimalSeparator == ',' {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
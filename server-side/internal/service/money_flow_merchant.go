@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// merchantResolver turns the payees written on money flows into merchants. The merchant
+// rules are loaded once, when first needed, and each payee is resolved once.
+type merchantResolver struct {
+	merchantRepo repository.MerchantRepository
+	rules        []*domain.MerchantRule
+	loaded       bool
+	resolved     map[string]*domain.Merchant
+}
+
+func (s *MoneyFlowService) merchants() *merchantResolver {
+	return &merchantResolver{
+		merchantRepo: s.merchantRepo,
+		resolved:     map[string]*domain.Merchant{},
+	}
+}
+
+// resolve returns the merchant of a payee: the merchant of the rule it matches, else the
+// merchant named by its cleaned up name, created when new. It returns nil for a nil or
+// blank payee, or one with nothing left after the cleanup.
+func (r *merchantResolver) resolve(ctx context.Context, payee *string) (*domain.Merchant, error) {
+	if payee == nil || strings.TrimSpace(*payee) == "" {
+		return nil, nil
+	}
+	if merchant, ok := r.resolved[*payee]; ok {
+		return merchant, nil
+	}
+
+	if !r.loaded {
+		rules, err := r.merchantRepo.ListRules(ctx)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find merchant rules", 500)
+		}
+		r.rules = rules
+		r.loaded = true
+	}
+
+	var merchant *domain.Merchant
+	if rule := domain.MatchMerchantRule(*payee, r.rules); rule != nil {
+		merchant = rule.Merchant
+	} else if name := domain.CleanMerchantName(*payee); name != "" {
+		var err error
+		merchant, err = r.merchantRepo.FindOrCreate(ctx, name)
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to save merchant", 500)
+		}
+	}
+
+	r.resolved[*payee] = merchant
+	return merchant, nil
+}
+
+// findMerchant returns the merchant a money flow is linked to, or nil if none
+func (s *MoneyFlowService) findMerchant(ctx context.Context, moneyFlow *domain.MoneyFlow) (*domain.Merchant, error) {
+	if moneyFlow.MerchantID == nil {
+		return nil, nil
+	}
+
+	merchant, err := s.merchantRepo.FindByID(ctx, *moneyFlow.MerchantID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find merchant", 500)
+	}
+	return merchant, nil
+}
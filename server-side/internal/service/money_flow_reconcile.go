@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ReconcileMatchWindow is how many days a statement line may be posted before or after
+// the money flow it matches, since banks post card payments a few days late
+const ReconcileMatchWindow = 3
+
+// ReconcileInput is a bank statement to compare with the recorded money flows
+type ReconcileInput struct {
+	PeriodStart time.Time // first day
+	PeriodEnd   time.Time // last day, inclusive
+
+	// Currency of the statement; defaults to the user's default currency. Only money
+	// flows in it are compared.
+	Currency string
+
+	OpeningBalance float64
+	ClosingBalance float64
+
+	// Statement optionally holds the lines of the statement as CSV, read with Mapping.
+	// Positive amounts are income when Mapping.NegativeExpenses is set.
+	Statement io.Reader
+	Mapping   ImportMapping
+}
+
+// StatementLine is an expense line of a statement that matches no recorded money flow
+type StatementLine struct {
+	Line int
+	Date time.Time
+
+	// Draft is the money flow to record if the line was missed
+	Draft MoneyFlowInput
+}
+
+// Reconciliation compares a statement with the money flows recorded in its period.
+// Money flows are expenses only, so income is taken from the statement lines, or
+// assumed to be zero without them. Amounts are in minor units of Currency.
+type Reconciliation struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Currency    string
+
+	OpeningBalance int64
+	ClosingBalance int64
+
+	RecordedTotal int64
+	RecordedCount int
+
+	// Statement totals; zero without statement lines
+	HasStatement      bool
+	StatementIncome   int64
+	StatementExpenses int64
+
+	// ExpectedClosingBalance is the opening balance plus income minus recorded spending
+	ExpectedClosingBalance int64
+
+	// Discrepancy is the closing balance minus the expected one. A negative
+	// discrepancy is spending missing from the records.
+	Discrepancy int64
+	Balanced    bool
+
+	// Suggestions are statement expenses that match no recorded money flow
+	Suggestions []*StatementLine
+
+	// Unmatched are recorded money flows that match no statement expense
+	Unmatched []*domain.MoneyFlow
+
+	// Ignored are statement rows left out: invalid, in another currency, or outside the period
+	Ignored []*ImportRow
+}
+
+// Reconcile compares a statement's closing balance with the money flows recorded in
+// its period, and with its lines when given, to find spending missing from the
+// records. Nothing is recorded; suggestions are drafts for the user to confirm.
+//
+// A statement expense matches a recorded money flow of the same amount created within
+// ReconcileMatchWindow days of it, each money flow matching at most one line.
+func (s *MoneyFlowService) Reconcile(ctx context.Context, userID uuid.UUID, input ReconcileInput) (result *Reconciliation, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Reconcile",
+		attribute.Bool("reconcile.statement", input.Statement != nil))
+	defer func() { tracing.End(span, err) }()
+
+	if input.PeriodEnd.Before(input.PeriodStart) {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"period_end": "must not be before period_start",
+		})
+	}
+
+	if input.Currency == "" {
+		settings, err := s.findSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		input.Currency = settings.DefaultCurrency
+	}
+
+	start := truncateDay(input.PeriodStart)
+	end := truncateDay(input.PeriodEnd).AddDate(0, 0, 1)
+
+	// The date range is inclusive on both ends
+	moneyFlows, err := s.moneyFlowRepo.FindByUserIDAndDateRange(ctx, userID, start, end.Add(-time.Microsecond))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find money flows", 500)
+	}
+
+	result = &Reconciliation{
+		PeriodStart:  start,
+		PeriodEnd:    end.AddDate(0, 0, -1),
+		Currency:     input.Currency,
+		HasStatement: input.Statement != nil,
+		Suggestions:  []*StatementLine{},
+		Unmatched:    []*domain.MoneyFlow{},
+		Ignored:      []*ImportRow{},
+	}
+
+	if result.OpeningBalance, err = domain.MinorUnits(input.OpeningBalance, input.Currency); err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"opening_balance": err.Error(),
+		})
+	}
+	if result.ClosingBalance, err = domain.MinorUnits(input.ClosingBalance, input.Currency); err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"closing_balance": err.Error(),
+		})
+	}
+
+	var recorded []*domain.MoneyFlow
+	for _, moneyFlow := range moneyFlows {
+		if moneyFlow.Currency != input.Currency {
+			continue
+		}
+		recorded = append(recorded, moneyFlow)
+		result.RecordedTotal += moneyFlow.Amount
+	}
+	result.RecordedCount = len(recorded)
+
+	var expenses []*ImportRow
+	if input.Statement != nil {
+		mapping := input.Mapping
+		mapping.Currency = input.Currency
+		rows, err := parseImport(input.Statement, userID, mapping)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			switch {
+			case row.Status == ImportRowSkipped:
+				income, err := domain.MinorUnits(row.Income, input.Currency)
+				if err == nil {
+					result.StatementIncome += income
+					continue
+				}
+				row.Status = ImportRowInvalid
+				row.Errors = map[string]string{"amount": err.Error()}
+			case row.Status != ImportRowValid:
+			case row.MoneyFlow.Currency != input.Currency:
+				row.Status = ImportRowInvalid
+				row.Errors = map[string]string{"currency": "must be " + input.Currency}
+			case row.MoneyFlow.CreatedAt.Before(start), !row.MoneyFlow.CreatedAt.Before(end):
+				row.Status = ImportRowInvalid
+				row.Errors = map[string]string{"date": "is outside the period"}
+			default:
+				expenses = append(expenses, row)
+				result.StatementExpenses += row.MoneyFlow.Amount
+				continue
+			}
+			result.Ignored = append(result.Ignored, row)
+		}
+	}
+
+	result.ExpectedClosingBalance = result.OpeningBalance + result.StatementIncome - result.RecordedTotal
+	result.Discrepancy = result.ClosingBalance - result.ExpectedClosingBalance
+	result.Balanced = result.Discrepancy == 0
+
+	if input.Statement != nil {
+		matchStatement(result, expenses, recorded)
+	}
+
+	span.SetAttributes(
+		attribute.Int("reconcile.recorded", result.RecordedCount),
+		attribute.Int("reconcile.suggestions", len(result.Suggestions)),
+	)
+	return result, nil
+}
+
+// matchStatement pairs statement expenses with recorded money flows, closest date
+// first, and reports what is left on either side
+func matchStatement(result *Reconciliation, expenses []*ImportRow, recorded []*domain.MoneyFlow) {
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].MoneyFlow.CreatedAt.Before(expenses[j].MoneyFlow.CreatedAt)
+	})
+
+	matched := make([]bool, len(recorded))
+	for _, row := range expenses {
+		line := row.MoneyFlow
+		best, bestDistance := -1, 0.0
+		for i, moneyFlow := range recorded {
+			if matched[i] || moneyFlow.Amount != line.Amount {
+				continue
+			}
+			distance := math.Abs(truncateDay(moneyFlow.CreatedAt).Sub(line.CreatedAt).Hours() / 24)
+			if distance <= ReconcileMatchWindow && (best < 0 || distance < bestDistance) {
+				best, bestDistance = i, distance
+			}
+		}
+		if best >= 0 {
+			matched[best] = true
+			continue
+		}
+
+		result.Suggestions = append(result.Suggestions, &StatementLine{
+			Line: row.Line,
+			Date: line.CreatedAt,
+			Draft: MoneyFlowInput{
+				Amount:      line.Money().Float64(),
+				Currency:    line.Currency,
+				Category:    line.Category,
+				Description: line.Description,
+			},
+		})
+	}
+
+	for i, moneyFlow := range recorded {
+		if !matched[i] {
+			result.Unmatched = append(result.Unmatched, moneyFlow)
+		}
+	}
+}
+
+// truncateDay returns midnight UTC of the day of t
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,1016 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/realtime"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// MoneyFlowService handles money flow business logic
+type MoneyFlowService struct {
+	moneyFlowRepo repository.MoneyFlowRepository
+	noteRepo      repository.MoneyFlowNoteRepository
+	settingsRepo  repository.UserSettingsRepository
+	budgetRepo    repository.BudgetRepository
+	walletRepo    repository.WalletRepository
+	groupRepo     repository.GroupRepository
+	splitRepo     repository.SplitRepository
+	merchantRepo  repository.MerchantRepository
+	auditor       *Auditor
+	events        *realtime.Bus
+	outbox        *events.Dispatcher
+	txManager     repository.TransactionManager
+}
+
+// NewMoneyFlowService creates a new money flow service
+func NewMoneyFlowService(
+	moneyFlowRepo repository.MoneyFlowRepository,
+	noteRepo repository.MoneyFlowNoteRepository,
+	settingsRepo repository.UserSettingsRepository,
+	budgetRepo repository.BudgetRepository,
+	walletRepo repository.WalletRepository,
+	groupRepo repository.GroupRepository,
+	splitRepo repository.SplitRepository,
+	merchantRepo repository.MerchantRepository,
+	auditor *Auditor,
+	events *realtime.Bus,
+	outbox *events.Dispatcher,
+	txManager repository.TransactionManager,
+) *MoneyFlowService {
+	return &MoneyFlowService{
+		moneyFlowRepo: moneyFlowRepo,
+		noteRepo:      noteRepo,
+		settingsRepo:  settingsRepo,
+		budgetRepo:    budgetRepo,
+		walletRepo:    walletRepo,
+		groupRepo:     groupRepo,
+		splitRepo:     splitRepo,
+		merchantRepo:  merchantRepo,
+		auditor:       auditor,
+		events:        events,
+		outbox:        outbox,
+		txManager:     txManager,
+	}
+}
+
+// MoneyFlowInput holds the fields used to create or replace a money flow
+type MoneyFlowInput struct {
+	Amount      float64
+	Currency    string
+	Category    *string
+	Description *string
+	Tags        []string
+	Note        *string
+
+	// WalletID is the wallet the money flow is paid from; the currency defaults to its currency
+	WalletID *uuid.UUID
+
+	// GroupID records the money flow in the shared ledger of a group the user is a member of
+	GroupID *uuid.UUID
+
+	// Merchant is the payee as written, e.g. "INDOMARET TBK 0123"; the money flow is
+	// linked to the merchant it normalizes to
+	Merchant *string
+
+	// Latitude and Longitude locate where the money was spent, both or neither set;
+	// PlaceName names the place
+	Latitude  *float64
+	Longitude *float64
+	PlaceName *string
+
+	// OverrideBudget records the money flow even if it exceeds a hard budget
+	OverrideBudget bool
+
+	// AllowDuplicate records the money flow without checking for a likely duplicate
+	AllowDuplicate bool
+}
+
+// MoneyFlowPatch holds the fields of a money flow to change; nil fields are left as they
+// are. Empty strings clear Category, Description, Merchant, and PlaceName, uuid.Nil takes
+// the money flow out of its wallet or group, and an empty Note removes the note.
+// Latitude and Longitude are changed together.
+type MoneyFlowPatch struct {
+	Amount      *float64
+	Currency    *string
+	Category    *string
+	Description *string
+	Tags        []string
+	Note        *string
+	WalletID    *uuid.UUID
+	GroupID     *uuid.UUID
+	Merchant    *string
+	Latitude    *float64
+	Longitude   *float64
+	PlaceName   *string
+
+	// RemoveLocation removes the latitude and longitude
+	RemoveLocation bool
+
+	// OverrideBudget saves the change even if it takes a hard budget over its cap
+	OverrideBudget bool
+}
+
+// replacement returns the patch replacing a money flow with the input. Fields left out
+// of the input are cleared, except the currency, tags, and note, which are kept.
+func (input MoneyFlowInput) replacement() MoneyFlowPatch {
+	patch := MoneyFlowPatch{
+		Amount:         &input.Amount,
+		Category:       input.Category,
+		Description:    input.Description,
+		Tags:           input.Tags,
+		Note:           input.Note,
+		WalletID:       input.WalletID,
+		GroupID:        input.GroupID,
+		Merchant:       input.Merchant,
+		Latitude:       input.Latitude,
+		Longitude:      input.Longitude,
+		PlaceName:      input.PlaceName,
+		RemoveLocation: input.Latitude == nil && input.Longitude == nil,
+		OverrideBudget: input.OverrideBudget,
+	}
+	if input.Currency != "" {
+		patch.Currency = &input.Currency
+	}
+	if patch.Category == nil {
+		patch.Category = new(string)
+	}
+	if patch.Description == nil {
+		patch.Description = new(string)
+	}
+	if patch.WalletID == nil {
+		patch.WalletID = new(uuid.UUID)
+	}
+	if patch.GroupID == nil {
+		patch.GroupID = new(uuid.UUID)
+	}
+	if patch.Merchant == nil {
+		patch.Merchant = new(string)
+	}
+	if patch.PlaceName == nil {
+		patch.PlaceName = new(string)
+	}
+	return patch
+}
+
+// MoneyFlowDetail represents a money flow together with its note and merchant
+type MoneyFlowDetail struct {
+	MoneyFlow *domain.MoneyFlow
+	Note      *domain.MoneyFlowNote
+	Merchant  *domain.Merchant
+
+	// Duplicate is a money flow recorded the same day that the created one likely
+	// duplicates, when the user's duplicate check warns about it
+	Duplicate *domain.MoneyFlow
+}
+
+// MoneyFlowSummary holds a user's totals, one per currency since amounts in
+// different currencies cannot be added up
+type MoneyFlowSummary struct {
+	Totals             []*domain.CurrencyTotal
+	DefaultCurrency    string
+	SingleCurrencyMode bool
+	MixedCurrencies    bool
+
+	// Warning explains mixed currencies to users not in single-currency mode
+	Warning string
+}
+
+// MoneyFlowPeriodSummary holds a user's headline numbers per currency over the days
+// from PeriodStart up to PeriodEnd, in the user's time zone
+type MoneyFlowPeriodSummary struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Totals      []*domain.FlowSummary
+}
+
+// Create creates a new money flow (and its note, if provided) for a user
+func (s *MoneyFlowService) Create(ctx context.Context, userID uuid.UUID, input MoneyFlowInput) (*MoneyFlowDetail, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Create")
+	defer span.End()
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallet *domain.Wallet
+	if input.WalletID != nil {
+		wallet, err = s.findWallet(ctx, userID, *input.WalletID)
+		if err != nil {
+			return nil, err
+		}
+		if input.Currency == "" {
+			input.Currency = wallet.Currency
+		}
+	}
+	if input.Currency == "" {
+		input.Currency = settings.DefaultCurrency
+	}
+	if err := checkCurrency(settings, input.Currency); err != nil {
+		return nil, err
+	}
+	if err := checkWalletCurrency(wallet, input.Currency); err != nil {
+		return nil, err
+	}
+	if err := s.checkGroup(ctx, userID, input.GroupID); err != nil {
+		return nil, err
+	}
+
+	moneyFlow, err := domain.NewMoneyFlow(userID, input.Amount, input.Currency)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	applyMoneyFlowInput(moneyFlow, input)
+	if err := moneyFlow.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+
+	var note *domain.MoneyFlowNote
+	if input.Note != nil && *input.Note != "" {
+		note, err = newNote(moneyFlow.ID, *input.Note)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merchant, err := s.merchants().resolve(ctx, input.Merchant)
+	if err != nil {
+		return nil, err
+	}
+	if merchant != nil {
+		moneyFlow.MerchantID = &merchant.ID
+	}
+
+	var duplicate *domain.MoneyFlow
+	if !input.AllowDuplicate {
+		duplicate, err = s.checkDuplicate(ctx, moneyFlow, settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		override, err := s.checkBudget(txCtx, moneyFlow, input.OverrideBudget, 0)
+		if err != nil {
+			return err
+		}
+
+		if err := s.moneyFlowRepo.Create(txCtx, moneyFlow); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create money flow", 500)
+		}
+
+		if err := s.auditor.Record(txCtx, userID, nil, moneyFlowAudit(moneyFlow)); err != nil {
+			return err
+		}
+
+		if err := s.recordOverride(txCtx, override); err != nil {
+			return err
+		}
+
+		if note != nil {
+			note.MoneyFlowID = moneyFlow.ID
+			if err := s.noteRepo.Save(txCtx, note); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to save note", 500)
+			}
+		}
+
+		if err := s.outbox.Emit(txCtx, events.MoneyFlowCreated, &userID, moneyFlowPayload(moneyFlow)); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record event", 500)
+		}
+
+		s.events.Publish(txCtx, userID, realtime.EventMoneyFlowCreated, moneyFlowEvent(moneyFlow))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoneyFlowDetail{
+		MoneyFlow: moneyFlow,
+		Note:      note,
+		Merchant:  merchant,
+		Duplicate: duplicate,
+	}, nil
+}
+
+// Get returns a money flow owned by the user, including its note and merchant
+func (s *MoneyFlowService) Get(ctx context.Context, userID, id uuid.UUID) (*MoneyFlowDetail, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Get")
+	defer span.End()
+
+	moneyFlow, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := s.noteRepo.FindByMoneyFlowID(ctx, moneyFlow.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find note", 500)
+	}
+
+	merchant, err := s.findMerchant(ctx, moneyFlow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoneyFlowDetail{
+		MoneyFlow: moneyFlow,
+		Note:      note,
+		Merchant:  merchant,
+	}, nil
+}
+
+// List returns the user's money flows, newest first. Notes are not included.
+func (s *MoneyFlowService) List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.List")
+	defer span.End()
+
+	moneyFlows, err := s.moneyFlowRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list money flows", 500)
+	}
+	return moneyFlows, nil
+}
+
+// ListByWallet returns the user's money flows recorded in a wallet, newest first
+func (s *MoneyFlowService) ListByWallet(ctx context.Context, userID, walletID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.ListByWallet")
+	defer span.End()
+
+	moneyFlows, err := s.moneyFlowRepo.FindByUserIDAndWallet(ctx, userID, walletID, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list money flows", 500)
+	}
+	return moneyFlows, nil
+}
+
+// ListByGroup returns the money flows in the shared ledger of a group the user is a
+// member of, newest first
+func (s *MoneyFlowService) ListByGroup(ctx context.Context, userID, groupID uuid.UUID, limit, offset int) ([]*domain.MoneyFlow, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.ListByGroup")
+	defer span.End()
+
+	if _, err := findGroupMember(ctx, s.groupRepo, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	moneyFlows, err := s.moneyFlowRepo.FindByGroupID(ctx, groupID, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list money flows", 500)
+	}
+	return moneyFlows, nil
+}
+
+// ListByTag returns the user's money flows tagged with tag, newest first
+func (s *MoneyFlowService) ListByTag(ctx context.Context, userID uuid.UUID, tag string, limit, offset int) ([]*domain.MoneyFlow, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.ListByTag")
+	defer span.End()
+
+	moneyFlows, err := s.moneyFlowRepo.FindByUserIDAndTag(ctx, userID, tag, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list money flows", 500)
+	}
+	return moneyFlows, nil
+}
+
+// SearchByNote returns the user's money flows whose note matches the full-text query
+func (s *MoneyFlowService) SearchByNote(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]*domain.MoneyFlow, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.SearchByNote")
+	defer span.End()
+
+	ids, err := s.noteRepo.SearchMoneyFlowIDs(ctx, userID, query, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to search notes", 500)
+	}
+
+	found, err := s.moneyFlowRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find money flows", 500)
+	}
+
+	// Keep the relevance order returned by the search
+	byID := make(map[uuid.UUID]*domain.MoneyFlow, len(found))
+	for _, moneyFlow := range found {
+		byID[moneyFlow.ID] = moneyFlow
+	}
+
+	moneyFlows := make([]*domain.MoneyFlow, 0, len(ids))
+	for _, id := range ids {
+		if moneyFlow, ok := byID[id]; ok {
+			moneyFlows = append(moneyFlows, moneyFlow)
+		}
+	}
+
+	return moneyFlows, nil
+}
+
+// Count returns how many money flows match the filter. The filter is restricted to the
+// user's money flows, or to a group's shared ledger the user is a member of.
+func (s *MoneyFlowService) Count(ctx context.Context, userID uuid.UUID, filter repository.MoneyFlowFilter) (int64, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Count")
+	defer span.End()
+
+	filter.UserID = userID
+	if filter.GroupID != nil {
+		if _, err := findGroupMember(ctx, s.groupRepo, *filter.GroupID, userID); err != nil {
+			return 0, err
+		}
+	}
+
+	count, err := s.moneyFlowRepo.CountByFilter(ctx, filter)
+	if err != nil {
+		return 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count money flows", 500)
+	}
+	return count, nil
+}
+
+// SummaryBetween returns the user's headline numbers per currency from the day of from
+// through the day of to, both taken as calendar days in the user's time zone
+func (s *MoneyFlowService) SummaryBetween(ctx context.Context, userID uuid.UUID, from, to time.Time) (*MoneyFlowPeriodSummary, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.SummaryBetween")
+	defer span.End()
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	location := settings.Location()
+	summary := &MoneyFlowPeriodSummary{
+		PeriodStart: localDay(from, location),
+		PeriodEnd:   localDay(to, location).AddDate(0, 0, 1),
+	}
+	if !summary.PeriodStart.Before(summary.PeriodEnd) {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"from": "from must not be after to",
+		})
+	}
+
+	summary.Totals, err = s.moneyFlowRepo.SummaryByUserIDAndDateRange(ctx, userID, summary.PeriodStart, summary.PeriodEnd)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize money flows", 500)
+	}
+
+	return summary, nil
+}
+
+// Summary returns the user's totals per currency and flags mixed currencies
+func (s *MoneyFlowService) Summary(ctx context.Context, userID uuid.UUID) (*MoneyFlowSummary, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Summary")
+	defer span.End()
+
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.moneyFlowRepo.GetTotalsByCurrency(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize money flows", 500)
+	}
+
+	summary := &MoneyFlowSummary{
+		Totals:             totals,
+		DefaultCurrency:    settings.DefaultCurrency,
+		SingleCurrencyMode: settings.SingleCurrencyMode,
+		MixedCurrencies:    len(totals) > 1,
+	}
+
+	if summary.MixedCurrencies && !settings.SingleCurrencyMode {
+		currencies := make([]string, len(totals))
+		for i, total := range totals {
+			currencies[i] = total.Currency
+		}
+		summary.Warning = fmt.Sprintf(
+			"Money flows are recorded in %d currencies (%s); totals are reported per currency. "+
+				"Enable single-currency mode in settings to reject currencies other than %s.",
+			len(totals), strings.Join(currencies, ", "), settings.DefaultCurrency,
+		)
+	}
+
+	return summary, nil
+}
+
+// Update replaces the fields of a money flow using optimistic locking.
+// A nil note leaves the existing note untouched; an empty note removes it.
+// Transfers between wallets cannot be updated.
+func (s *MoneyFlowService) Update(ctx context.Context, userID, id uuid.UUID, version int, input MoneyFlowInput) (*MoneyFlowDetail, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Update")
+	defer span.End()
+
+	return s.update(ctx, userID, id, version, input.replacement())
+}
+
+// Patch changes only the fields set in the patch using optimistic locking, so clients
+// can send the fields they changed. Transfers between wallets cannot be updated.
+func (s *MoneyFlowService) Patch(ctx context.Context, userID, id uuid.UUID, version int, patch MoneyFlowPatch) (*MoneyFlowDetail, error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Patch")
+	defer span.End()
+
+	return s.update(ctx, userID, id, version, patch)
+}
+
+// update applies a patch to a money flow of the user and writes the fields it changes
+func (s *MoneyFlowService) update(ctx context.Context, userID, id uuid.UUID, version int, patch MoneyFlowPatch) (*MoneyFlowDetail, error) {
+	moneyFlow, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if moneyFlow.IsTransfer() {
+		return nil, appErrors.ErrTransferNotEditable
+	}
+
+	if moneyFlow.Version != version {
+		return nil, appErrors.ErrVersionConflict
+	}
+	before := moneyFlowAudit(moneyFlow)
+
+	previousAmount := moneyFlow.Amount
+	previousCurrency := moneyFlow.Currency
+	previousCategory := moneyFlow.Category
+	previousGroupID := moneyFlow.GroupID
+	var fields []string
+
+	// Flows recorded before single-currency mode was turned on keep their
+	// currency unless the update changes it
+	if patch.Currency != nil && *patch.Currency != moneyFlow.Currency {
+		settings, err := s.findSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCurrency(settings, *patch.Currency); err != nil {
+			return nil, err
+		}
+		moneyFlow.Currency = *patch.Currency
+		fields = append(fields, repository.MoneyFlowFieldCurrency)
+	}
+
+	if patch.WalletID != nil {
+		moneyFlow.WalletID = optionalID(*patch.WalletID)
+		fields = append(fields, repository.MoneyFlowFieldWalletID)
+	}
+	if moneyFlow.WalletID != nil && (patch.WalletID != nil || moneyFlow.Currency != previousCurrency) {
+		wallet, err := s.findWallet(ctx, userID, *moneyFlow.WalletID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkWalletCurrency(wallet, moneyFlow.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	if patch.GroupID != nil {
+		moneyFlow.GroupID = optionalID(*patch.GroupID)
+		if err := s.checkGroup(ctx, userID, moneyFlow.GroupID); err != nil {
+			return nil, err
+		}
+		fields = append(fields, repository.MoneyFlowFieldGroupID)
+	}
+
+	if patch.Merchant != nil {
+		merchant, err := s.merchants().resolve(ctx, patch.Merchant)
+		if err != nil {
+			return nil, err
+		}
+		moneyFlow.MerchantID = nil
+		if merchant != nil {
+			moneyFlow.MerchantID = &merchant.ID
+		}
+		fields = append(fields, repository.MoneyFlowFieldMerchantID)
+	}
+
+	// A new currency keeps the amount in major units unless the patch changes it too
+	amount := patch.Amount
+	if amount == nil && moneyFlow.Currency != previousCurrency {
+		major := domain.MajorUnits(previousAmount, previousCurrency)
+		amount = &major
+	}
+	if amount != nil {
+		if err := moneyFlow.SetAmount(*amount); err != nil {
+			return nil, validationError(err)
+		}
+		fields = append(fields, repository.MoneyFlowFieldAmount)
+	}
+
+	if patch.Category != nil {
+		moneyFlow.Category = optionalString(*patch.Category)
+		fields = append(fields, repository.MoneyFlowFieldCategory)
+	}
+	if patch.Description != nil {
+		moneyFlow.Description = optionalString(*patch.Description)
+		fields = append(fields, repository.MoneyFlowFieldDescription)
+	}
+	if patch.Tags != nil {
+		moneyFlow.SetTags(patch.Tags)
+		fields = append(fields, repository.MoneyFlowFieldTags)
+	}
+	if patch.Latitude != nil || patch.Longitude != nil || patch.RemoveLocation {
+		moneyFlow.Latitude = patch.Latitude
+		moneyFlow.Longitude = patch.Longitude
+		fields = append(fields, repository.MoneyFlowFieldLatitude, repository.MoneyFlowFieldLongitude)
+	}
+	if patch.PlaceName != nil {
+		moneyFlow.PlaceName = optionalString(*patch.PlaceName)
+		fields = append(fields, repository.MoneyFlowFieldPlaceName)
+	}
+	if err := moneyFlow.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+	moneyFlow.IncrementVersion()
+
+	// Flows already over budget can still be edited as long as the edit does
+	// not add to the spending of a budget
+	budgetAffected := !sameCategory(moneyFlow.Category, previousCategory) ||
+		moneyFlow.Currency != previousCurrency || moneyFlow.Amount > previousAmount
+
+	// Splits no longer add up once the amount changes or the flow leaves its group
+	splitsStale := moneyFlow.Amount != previousAmount || moneyFlow.Currency != previousCurrency ||
+		!sameGroup(moneyFlow.GroupID, previousGroupID)
+
+	var note *domain.MoneyFlowNote
+	if patch.Note != nil && *patch.Note != "" {
+		note, err = newNote(moneyFlow.ID, *patch.Note)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var override *domain.BudgetOverride
+		if budgetAffected {
+			override, err = s.checkBudget(txCtx, moneyFlow, patch.OverrideBudget, 0)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.moneyFlowRepo.PartialUpdate(txCtx, moneyFlow, fields); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrVersionConflict
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update money flow", 500)
+		}
+
+		if err := s.auditor.Record(txCtx, userID, before, moneyFlowAudit(moneyFlow)); err != nil {
+			return err
+		}
+
+		if splitsStale {
+			if err := s.splitRepo.DeleteByMoneyFlowID(txCtx, moneyFlow.ID); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete splits", 500)
+			}
+		}
+
+		if err := s.recordOverride(txCtx, override); err != nil {
+			return err
+		}
+
+		if err := s.outbox.Emit(txCtx, events.MoneyFlowUpdated, &userID, moneyFlowPayload(moneyFlow)); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record event", 500)
+		}
+		s.events.Publish(txCtx, userID, realtime.EventMoneyFlowUpdated, moneyFlowEvent(moneyFlow))
+
+		if patch.Note == nil {
+			return nil
+		}
+
+		if note == nil {
+			if err := s.noteRepo.Delete(txCtx, moneyFlow.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete note", 500)
+			}
+			return nil
+		}
+
+		if err := s.noteRepo.Save(txCtx, note); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to save note", 500)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Note == nil {
+		return s.Get(ctx, userID, moneyFlow.ID)
+	}
+
+	merchant, err := s.findMerchant(ctx, moneyFlow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoneyFlowDetail{
+		MoneyFlow: moneyFlow,
+		Note:      note,
+		Merchant:  merchant,
+	}, nil
+}
+
+// Delete soft deletes a money flow owned by the user. Deleting either money flow of a
+// transfer between wallets deletes the whole transfer.
+func (s *MoneyFlowService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "MoneyFlowService.Delete")
+	defer span.End()
+
+	moneyFlow, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	deleted := []*domain.MoneyFlow{moneyFlow}
+	if moneyFlow.TransferID != nil {
+		deleted, err = s.moneyFlowRepo.FindByTransferID(ctx, *moneyFlow.TransferID)
+		if err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find transfer", 500)
+		}
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		if moneyFlow.TransferID != nil {
+			err = s.moneyFlowRepo.DeleteByTransferID(txCtx, *moneyFlow.TransferID)
+		} else {
+			err = s.moneyFlowRepo.Delete(txCtx, moneyFlow.ID)
+		}
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete money flow", 500)
+		}
+
+		for _, moneyFlow := range deleted {
+			if err := s.auditor.Record(txCtx, userID, moneyFlowAudit(moneyFlow), nil); err != nil {
+				return err
+			}
+			if err := s.outbox.Emit(txCtx, events.MoneyFlowDeleted, &userID, moneyFlowPayload(moneyFlow)); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record event", 500)
+			}
+			s.events.Publish(txCtx, userID, realtime.EventMoneyFlowDeleted, moneyFlowEvent(moneyFlow))
+		}
+		return nil
+	})
+}
+
+func (s *MoneyFlowService) findOwned(ctx context.Context, userID, id uuid.UUID) (*domain.MoneyFlow, error) {
+	moneyFlow, err := s.moneyFlowRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find money flow", 500)
+	}
+
+	// Do not leak the existence of other users' money flows
+	if moneyFlow.UserID != userID {
+		return nil, appErrors.ErrResourceNotFound
+	}
+
+	return moneyFlow, nil
+}
+
+// findWallet returns a wallet owned by the user for a money flow to be recorded in
+func (s *MoneyFlowService) findWallet(ctx context.Context, userID, walletID uuid.UUID) (*domain.Wallet, error) {
+	wallet, err := s.walletRepo.FindByID(ctx, walletID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find wallet", 500)
+	}
+	if wallet == nil || wallet.UserID != userID {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"wallet_id": "wallet not found",
+		})
+	}
+	return wallet, nil
+}
+
+// checkGroup rejects recording a money flow in a group the user is not a member of
+func (s *MoneyFlowService) checkGroup(ctx context.Context, userID uuid.UUID, groupID *uuid.UUID) error {
+	if groupID == nil {
+		return nil
+	}
+	_, err := findGroupMember(ctx, s.groupRepo, *groupID, userID)
+	if errors.Is(err, appErrors.ErrResourceNotFound) {
+		return appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"group_id": "group not found",
+		})
+	}
+	return err
+}
+
+// findSettings returns the settings of a user, or the defaults if none were saved
+func (s *MoneyFlowService) findSettings(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultUserSettings(userID), nil
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user settings", 500)
+	}
+	return settings, nil
+}
+
+// checkCurrency rejects currencies other than the default one in single-currency mode
+func checkCurrency(settings *domain.UserSettings, currency string) error {
+	if settings.AcceptsCurrency(currency) {
+		return nil
+	}
+	return appErrors.ErrCurrencyMismatch.WithDetails(map[string]interface{}{
+		"expected_currency": settings.DefaultCurrency,
+		"currency":          currency,
+	})
+}
+
+// checkWalletCurrency rejects money flows in a currency other than the one of their
+// wallet, so wallet balances stay in a single currency. A nil wallet accepts any currency.
+func checkWalletCurrency(wallet *domain.Wallet, currency string) error {
+	if wallet == nil || wallet.Currency == currency {
+		return nil
+	}
+	return appErrors.ErrWalletCurrencyMismatch.WithDetails(map[string]interface{}{
+		"wallet_currency": wallet.Currency,
+		"currency":        currency,
+	})
+}
+
+// checkBudget rejects a money flow that takes the hard budget of its category over
+// the cap in the month the flow was created. With override set, the flow is allowed
+// and the override to record is returned instead. Pending is spending in the category
+// and currency that is not saved yet, such as earlier items of a bulk create, in minor units.
+// The flow that first takes a hard or soft budget over its cap publishes and emits
+// budget.exceeded, so the checked flow must be saved in the transaction of the context.
+func (s *MoneyFlowService) checkBudget(ctx context.Context, moneyFlow *domain.MoneyFlow, override bool, pending int64) (*domain.BudgetOverride, error) {
+	if moneyFlow.Category == nil || *moneyFlow.Category == "" {
+		return nil, nil
+	}
+
+	budget, err := s.budgetRepo.FindByUserIDAndCategory(ctx, moneyFlow.UserID, *moneyFlow.Category)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find budget", 500)
+	}
+
+	if !budget.Applies(moneyFlow.Currency) {
+		return nil, nil
+	}
+
+	settings, err := s.findSettings(ctx, moneyFlow.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The flow itself is left out so updates do not count its old amount
+	start, end := domain.BudgetPeriod(moneyFlow.CreatedAt, settings)
+	spent, err := s.moneyFlowRepo.GetCategoryTotalInPeriod(ctx, moneyFlow.UserID, budget.Category, budget.Currency, start, end, moneyFlow.ID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to calculate budget spending", 500)
+	}
+	spent += pending
+
+	if !budget.Exceeded(spent, moneyFlow.Amount) {
+		return nil, nil
+	}
+
+	if budget.Hard && !override {
+		return nil, appErrors.ErrBudgetExceeded.WithDetails(map[string]interface{}{
+			"budget_id": budget.ID.String(),
+			"category":  budget.Category,
+			"currency":  budget.Currency,
+			"cap":       domain.MajorUnits(budget.Amount, budget.Currency),
+			"spent":     domain.MajorUnits(spent, budget.Currency),
+			"amount":    moneyFlow.Money().Float64(),
+			"remaining": domain.MajorUnits(budget.Amount-spent, budget.Currency),
+		})
+	}
+
+	if !budget.Exceeded(spent, 0) {
+		if err := s.outbox.Emit(ctx, events.BudgetExceeded, &moneyFlow.UserID, budgetExceededPayload(budget, moneyFlow, spent)); err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record event", 500)
+		}
+		s.events.Publish(ctx, moneyFlow.UserID, realtime.EventBudgetExceeded, budgetExceededEvent(budget, moneyFlow, spent))
+	}
+
+	if !budget.Hard {
+		return nil, nil
+	}
+	return domain.NewBudgetOverride(budget, moneyFlow, spent), nil
+}
+
+// checkDuplicate looks for a money flow of the same day that the new one likely
+// duplicates, following the user's duplicate check. It returns the latest one found to
+// warn about it, or an ErrDuplicateMoneyFlow when the user blocks duplicates.
+func (s *MoneyFlowService) checkDuplicate(ctx context.Context, moneyFlow *domain.MoneyFlow, settings *domain.UserSettings) (*domain.MoneyFlow, error) {
+	if settings.DuplicateCheck != domain.DuplicateCheckWarn && settings.DuplicateCheck != domain.DuplicateCheckBlock {
+		return nil, nil
+	}
+
+	location := settings.Location()
+	year, month, day := moneyFlow.CreatedAt.In(location).Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, location)
+
+	// The date range is inclusive on both ends
+	recorded, err := s.moneyFlowRepo.FindByUserIDAndDateRange(ctx, moneyFlow.UserID, start, start.AddDate(0, 0, 1).Add(-time.Microsecond))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find money flows", 500)
+	}
+
+	var duplicate *domain.MoneyFlow
+	for _, other := range recorded {
+		if moneyFlow.LikelyDuplicateOf(other, location) && (duplicate == nil || other.CreatedAt.After(duplicate.CreatedAt)) {
+			duplicate = other
+		}
+	}
+	if duplicate == nil || settings.DuplicateCheck == domain.DuplicateCheckWarn {
+		return duplicate, nil
+	}
+
+	details := map[string]interface{}{
+		"duplicate_id": duplicate.ID.String(),
+		"amount":       duplicate.Money().Float64(),
+		"currency":     duplicate.Currency,
+		"created_at":   duplicate.CreatedAt,
+	}
+	if duplicate.Description != nil {
+		details["description"] = *duplicate.Description
+	}
+	return nil, appErrors.ErrDuplicateMoneyFlow.WithDetails(details)
+}
+
+// recordOverride saves the audit record of a money flow allowed over a hard budget
+func (s *MoneyFlowService) recordOverride(ctx context.Context, override *domain.BudgetOverride) error {
+	if override == nil {
+		return nil
+	}
+
+	if err := s.budgetRepo.CreateOverride(ctx, override); err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record budget override", 500)
+	}
+
+	logger.FromContext(ctx).Info("hard budget overridden",
+		"budget_id", override.BudgetID,
+		"money_flow_id", override.MoneyFlowID,
+		"currency", override.Currency,
+		"cap", domain.MajorUnits(override.Cap, override.Currency),
+		"spent", domain.MajorUnits(override.Spent, override.Currency),
+		"amount", domain.MajorUnits(override.Amount, override.Currency),
+	)
+	return nil
+}
+
+func sameCategory(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameGroup(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// optionalID returns nil for uuid.Nil
+func optionalID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+func applyMoneyFlowInput(moneyFlow *domain.MoneyFlow, input MoneyFlowInput) {
+	moneyFlow.WalletID = input.WalletID
+	moneyFlow.GroupID = input.GroupID
+	moneyFlow.Category = input.Category
+	moneyFlow.Description = input.Description
+	if input.Tags != nil {
+		moneyFlow.SetTags(input.Tags)
+	}
+	moneyFlow.Latitude = input.Latitude
+	moneyFlow.Longitude = input.Longitude
+	if input.PlaceName != nil {
+		moneyFlow.PlaceName = optionalString(*input.PlaceName)
+	}
+}
+
+func newNote(moneyFlowID uuid.UUID, content string) (*domain.MoneyFlowNote, error) {
+	note, err := domain.NewMoneyFlowNote(moneyFlowID, content)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	return note, nil
+}
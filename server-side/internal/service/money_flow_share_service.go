@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// MaxShareTTL is the longest a share link can stay valid
+const MaxShareTTL = 30 * 24 * time.Hour
+
+// MoneyFlowShareConfig holds the settings of the money flow share links
+type MoneyFlowShareConfig struct {
+	// URL is the endpoint the links open, e.g.
+	// https://api.catetin.id/api/v1/shared/money-flows
+	URL string
+
+	// TTL is how long a link is valid unless its creator picks otherwise
+	TTL time.Duration
+
+	// SigningKey signs the links
+	SigningKey string
+}
+
+// MoneyFlowShareLink is a created share with its signed link
+type MoneyFlowShareLink struct {
+	Share *domain.MoneyFlowShare
+	URL   string
+}
+
+// SharedMoneyFlow is what a share link shows: the money flow, its merchant, and its
+// note when the share includes it
+type SharedMoneyFlow struct {
+	MoneyFlow *domain.MoneyFlow
+	Merchant  *domain.Merchant
+	Note      *domain.MoneyFlowNote // nil unless the share includes the note
+	SharedBy  string                // full name of the owner
+	ExpiresAt time.Time
+
+	// Location is the owner's time zone, to show the times in
+	Location *time.Location
+}
+
+// MoneyFlowShareService lets users share a read-only view of a money flow through a
+// signed public link, e.g. with family or an accountant. Links expire, and their owner
+// can revoke them before.
+type MoneyFlowShareService struct {
+	moneyFlows   *MoneyFlowService
+	shareRepo    repository.MoneyFlowShareRepository
+	userRepo     repository.UserRepository
+	settingsRepo repository.UserSettingsRepository
+	config       MoneyFlowShareConfig
+}
+
+// NewMoneyFlowShareService creates a new money flow share service
+func NewMoneyFlowShareService(
+	moneyFlows *MoneyFlowService,
+	shareRepo repository.MoneyFlowShareRepository,
+	userRepo repository.UserRepository,
+	settingsRepo repository.UserSettingsRepository,
+	config MoneyFlowShareConfig,
+) *MoneyFlowShareService {
+	if config.TTL <= 0 {
+		config.TTL = 7 * 24 * time.Hour
+	}
+
+	return &MoneyFlowShareService{
+		moneyFlows:   moneyFlows,
+		shareRepo:    shareRepo,
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		config:       config,
+	}
+}
+
+// Create creates a link to the user's money flow valid for ttl, or the configured TTL
+// when zero, at most MaxShareTTL. The view shows the money flow's note only when
+// includeNote is set.
+func (s *MoneyFlowShareService) Create(ctx context.Context, userID, moneyFlowID uuid.UUID, ttl time.Duration, includeNote bool) (link *MoneyFlowShareLink, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowShareService.Create")
+	defer func() { tracing.End(span, err) }()
+
+	if ttl <= 0 {
+		ttl = s.config.TTL
+	}
+	ttl = min(ttl, MaxShareTTL)
+
+	detail, err := s.moneyFlows.Get(ctx, userID, moneyFlowID)
+	if err != nil {
+		return nil, err
+	}
+
+	share := domain.NewMoneyFlowShare(detail.MoneyFlow, ttl, includeNote)
+	if err := s.shareRepo.Create(ctx, share); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create share link", 500)
+	}
+
+	return &MoneyFlowShareLink{
+		Share: share,
+		URL:   s.shareURL(share.ID, share.ExpiresAt),
+	}, nil
+}
+
+// Revoke revokes every link to the user's money flow, and returns the number revoked
+func (s *MoneyFlowShareService) Revoke(ctx context.Context, userID, moneyFlowID uuid.UUID) (revoked int64, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowShareService.Revoke")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := s.moneyFlows.Get(ctx, userID, moneyFlowID); err != nil {
+		return 0, err
+	}
+
+	revoked, err = s.shareRepo.DeleteByMoneyFlowID(ctx, moneyFlowID)
+	if err != nil {
+		return 0, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke share links", 500)
+	}
+	return revoked, nil
+}
+
+// Open returns the money flow a share link points to, after checking its signature and
+// expiry. Invalid, expired, and revoked links, and links to deleted money flows, are
+// ErrInvalidShareLink.
+func (s *MoneyFlowShareService) Open(ctx context.Context, shareID uuid.UUID, expires int64, signature string) (shared *SharedMoneyFlow, err error) {
+	ctx, span := tracing.Start(ctx, "MoneyFlowShareService.Open")
+	defer func() { tracing.End(span, err) }()
+
+	expected := s.sign(shareID, expires)
+	now := time.Now()
+	if !hmac.Equal([]byte(signature), []byte(expected)) || !now.Before(time.Unix(expires, 0)) {
+		return nil, appErrors.ErrInvalidShareLink
+	}
+
+	share, err := s.shareRepo.FindByID(ctx, shareID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.ErrInvalidShareLink
+	}
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find share link", 500)
+	}
+	if share.ExpiresAt.Unix() != expires || share.IsExpired(now) {
+		return nil, appErrors.ErrInvalidShareLink
+	}
+
+	detail, err := s.moneyFlows.Get(ctx, share.UserID, share.MoneyFlowID)
+	if errors.Is(err, appErrors.ErrResourceNotFound) {
+		return nil, appErrors.ErrInvalidShareLink
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userRepo.FindByID(ctx, share.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.ErrInvalidShareLink
+	}
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user", 500)
+	}
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, share.UserID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		settings = domain.DefaultUserSettings(share.UserID)
+	case err != nil:
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user settings", 500)
+	}
+
+	shared = &SharedMoneyFlow{
+		MoneyFlow: detail.MoneyFlow,
+		Merchant:  detail.Merchant,
+		SharedBy:  owner.FullName,
+		ExpiresAt: share.ExpiresAt,
+		Location:  settings.Location(),
+	}
+	if share.IncludeNote {
+		shared.Note = detail.Note
+	}
+	return shared, nil
+}
+
+// shareURL returns the signed link to a share, valid until expiresAt
+func (s *MoneyFlowShareService) shareURL(shareID uuid.UUID, expiresAt time.Time) string {
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expiresAt.Unix(), 10)},
+		"signature": {s.sign(shareID, expiresAt.Unix())},
+	}
+	return fmt.Sprintf("%s/%s?%s", strings.TrimSuffix(s.config.URL, "/"), shareID, query.Encode())
+}
+
+// sign returns the hex HMAC-SHA256 of the link to a share, keyed with the signing key
+func (s *MoneyFlowShareService) sign(shareID uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.config.SigningKey))
+	fmt.Fprintf(mac, "share.%s.%d", shareID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
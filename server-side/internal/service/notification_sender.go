@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/fcm"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/telegram"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrRecipientUnreachable is returned by a NotificationSender when the recipient
+// has no address on its channel, so the next preferred channel should be tried
+var ErrRecipientUnreachable = errors.New("recipient cannot be reached on this channel")
+
+// NotificationSender delivers a message to a user through a single channel
+type NotificationSender interface {
+	// Channel returns the channel name (e.g. domain.ChannelInApp)
+	Channel() string
+
+	// Send delivers the message to the recipient
+	Send(ctx context.Context, recipient *domain.User, title, body string) error
+}
+
+// sendFirst tries the channels in order and returns the channel that delivered the
+// message. Channels without a sender, or that cannot reach the recipient, are skipped;
+// any other error stops and is returned with its channel. When no channel reaches the
+// recipient it returns ErrRecipientUnreachable.
+func sendFirst(ctx context.Context, senders map[string]NotificationSender, channels []string, recipient *domain.User, title, body string) (string, error) {
+	for _, channel := range channels {
+		sender, ok := senders[channel]
+		if !ok {
+			continue
+		}
+
+		sendCtx, span := tracing.Start(ctx, "NotificationSender.Send",
+			attribute.String("notification.channel", channel),
+		)
+		err := sender.Send(sendCtx, recipient, title, body)
+		tracing.End(span, err)
+		if errors.Is(err, ErrRecipientUnreachable) {
+			continue
+		}
+		return channel, err
+	}
+	return "", ErrRecipientUnreachable
+}
+
+// InAppSender delivers messages as in-app notifications
+type InAppSender struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewInAppSender creates a new in-app notification sender
+func NewInAppSender(notificationRepo repository.NotificationRepository) *InAppSender {
+	return &InAppSender{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Channel returns domain.ChannelInApp
+func (s *InAppSender) Channel() string {
+	return domain.ChannelInApp
+}
+
+// Send stores the message as an unread notification of the recipient
+func (s *InAppSender) Send(ctx context.Context, recipient *domain.User, title, body string) error {
+	return s.notificationRepo.Create(ctx, domain.NewNotification(recipient.ID, title, body))
+}
+
+// WhatsAppNotificationSender delivers messages as a WhatsApp template message, since the
+// recipient may not have messaged the business in the last 24 hours. The template body
+// takes the title as {{1}} and the body as {{2}}.
+type WhatsAppNotificationSender struct {
+	client   WhatsAppTemplateSender
+	template string
+	language string
+}
+
+// NewWhatsAppNotificationSender creates a new WhatsApp notification sender
+func NewWhatsAppNotificationSender(client WhatsAppTemplateSender, template, language string) *WhatsAppNotificationSender {
+	return &WhatsAppNotificationSender{
+		client:   client,
+		template: template,
+		language: language,
+	}
+}
+
+// Channel returns domain.ChannelWhatsApp
+func (s *WhatsAppNotificationSender) Channel() string {
+	return domain.ChannelWhatsApp
+}
+
+// Send sends the template to the recipient's phone number
+func (s *WhatsAppNotificationSender) Send(ctx context.Context, recipient *domain.User, title, body string) error {
+	// Users registered without a phone number have their email as a placeholder
+	if strings.Contains(recipient.PhoneNumber, "@") {
+		return ErrRecipientUnreachable
+	}
+
+	_, err := s.client.SendTemplate(ctx, recipient.PhoneNumber, whatsapp.Template{
+		Name:           s.template,
+		Language:       s.language,
+		BodyParameters: []string{title, body},
+	})
+	if errors.Is(err, whatsapp.ErrRecipientUnreachable) {
+		return ErrRecipientUnreachable
+	}
+	return err
+}
+
+// EmailNotificationSender delivers messages to the email the recipient signs in with
+type EmailNotificationSender struct {
+	client           EmailSender
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+}
+
+// NewEmailNotificationSender creates a new email notification sender
+func NewEmailNotificationSender(
+	client EmailSender,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+) *EmailNotificationSender {
+	return &EmailNotificationSender{
+		client:           client,
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+	}
+}
+
+// Channel returns domain.ChannelEmail
+func (s *EmailNotificationSender) Channel() string {
+	return domain.ChannelEmail
+}
+
+// Send emails the message; recipients without an email credential are unreachable
+func (s *EmailNotificationSender) Send(ctx context.Context, recipient *domain.User, title, body string) error {
+	address, err := findEmail(ctx, s.userAuthRepo, s.authProviderRepo, recipient.ID)
+	if err != nil {
+		return err
+	}
+	if address == "" {
+		return ErrRecipientUnreachable
+	}
+
+	return s.client.Send(ctx, address, title, body)
+}
+
+// TelegramMessageSender sends plain-text Telegram messages
+type TelegramMessageSender interface {
+	SendMessage(ctx context.Context, chatID, text string) error
+}
+
+// TelegramNotificationSender delivers messages to the recipient's chat with the bot
+type TelegramNotificationSender struct {
+	client       TelegramMessageSender
+	settingsRepo repository.UserSettingsRepository
+}
+
+// NewTelegramNotificationSender creates a new Telegram notification sender
+func NewTelegramNotificationSender(client TelegramMessageSender, settingsRepo repository.UserSettingsRepository) *TelegramNotificationSender {
+	return &TelegramNotificationSender{
+		client:       client,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// Channel returns domain.ChannelTelegram
+func (s *TelegramNotificationSender) Channel() string {
+	return domain.ChannelTelegram
+}
+
+// Send sends the title and body as one message; recipients who have not linked a chat
+// or blocked the bot are unreachable
+func (s *TelegramNotificationSender) Send(ctx context.Context, recipient *domain.User, title, body string) error {
+	settings, err := s.settingsRepo.FindByUserID(ctx, recipient.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return ErrRecipientUnreachable
+	}
+	if err != nil {
+		return err
+	}
+	if settings.TelegramChatID == "" {
+		return ErrRecipientUnreachable
+	}
+
+	err = s.client.SendMessage(ctx, settings.TelegramChatID, title+"\n\n"+body)
+	if errors.Is(err, telegram.ErrChatUnreachable) {
+		return ErrRecipientUnreachable
+	}
+	return err
+}
+
+// PushSender sends push notifications to a device token
+type PushSender interface {
+	Send(ctx context.Context, deviceToken string, message fcm.Message) error
+}
+
+// PushNotificationSender delivers messages as push notifications to every device the
+// recipient registered
+type PushNotificationSender struct {
+	client     PushSender
+	deviceRepo repository.DeviceRepository
+}
+
+// NewPushNotificationSender creates a new push notification sender
+func NewPushNotificationSender(client PushSender, deviceRepo repository.DeviceRepository) *PushNotificationSender {
+	return &PushNotificationSender{
+		client:     client,
+		deviceRepo: deviceRepo,
+	}
+}
+
+// Channel returns domain.ChannelPush
+func (s *PushNotificationSender) Channel() string {
+	return domain.ChannelPush
+}
+
+// Send pushes the message to the recipient's devices and succeeds when one of them
+// received it. Devices whose token is no longer registered are forgotten; recipients
+// without devices are unreachable.
+func (s *PushNotificationSender) Send(ctx context.Context, recipient *domain.User, title, body string) error {
+	devices, err := s.deviceRepo.FindByUserID(ctx, recipient.ID)
+	if err != nil {
+		return err
+	}
+
+	delivered := false
+	var failed error
+	for _, device := range devices {
+		err := s.client.Send(ctx, device.Token, fcm.Message{Title: title, Body: body})
+		switch {
+		case err == nil:
+			delivered = true
+		case errors.Is(err, fcm.ErrTokenUnregistered):
+			if err := s.deviceRepo.DeleteByToken(ctx, device.Token); err != nil {
+				logger.FromContext(ctx).Warn("failed to forget unregistered device", "device_id", device.ID, "error", err)
+			}
+		default:
+			failed = errors.Join(failed, err)
+		}
+	}
+
+	if delivered {
+		return nil
+	}
+	if failed != nil {
+		return failed
+	}
+	return ErrRecipientUnreachable
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// NotificationService handles in-app notification business logic
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(notificationRepo repository.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// List returns a user's notifications, newest first
+func (s *NotificationService) List(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Notification, error) {
+	notifications, err := s.notificationRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list notifications", 500)
+	}
+	return notifications, nil
+}
+
+// MarkRead marks a user's notification as read
+func (s *NotificationService) MarkRead(ctx context.Context, userID, notificationID uuid.UUID) error {
+	if err := s.notificationRepo.MarkRead(ctx, userID, notificationID, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrResourceNotFound
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to mark notification as read", 500)
+	}
+	return nil
+}
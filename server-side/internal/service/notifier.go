@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobDeliverNotification delivers a notification on the first channel that reaches the user
+const JobDeliverNotification = "notification.deliver"
+
+// Notification types, naming the alert in logs
+const (
+	NotificationPasswordChanged = "password_changed"
+	NotificationWelcome         = "welcome"
+	NotificationBudgetExceeded  = "budget_exceeded"
+	NotificationDigest          = "digest"
+	NotificationAccountLocked   = "account_locked"
+	NotificationAccountExport   = "account_export"
+	NotificationAccountErasure  = "account_erasure"
+)
+
+// Notification is an alert to a single user
+type Notification struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Notifier sends alerts to users on the channels they prefer, falling back to the next
+// channel when one cannot reach them or fails, e.g. WhatsApp, then email, then in-app.
+// Features only describe the alert; channels are added by registering a sender.
+// A nil *Notifier sends nothing.
+type Notifier struct {
+	userRepo     repository.UserRepository
+	settingsRepo repository.UserSettingsRepository
+	jobs         JobEnqueuer
+	senders      map[string]NotificationSender
+}
+
+// NewNotifier creates a new notifier delivering on the channels of the given senders
+func NewNotifier(
+	userRepo repository.UserRepository,
+	settingsRepo repository.UserSettingsRepository,
+	jobs JobEnqueuer,
+	senders ...NotificationSender,
+) *Notifier {
+	return &Notifier{
+		userRepo:     userRepo,
+		settingsRepo: settingsRepo,
+		jobs:         jobs,
+		senders:      sendersByChannel(senders),
+	}
+}
+
+// Notify queues delivering the notification to the user. Called in a transaction, the
+// notification is only sent once the transaction commits.
+func (n *Notifier) Notify(ctx context.Context, userID uuid.UUID, notification Notification) error {
+	if n == nil {
+		return nil
+	}
+
+	_, err := n.jobs.Enqueue(ctx, JobDeliverNotification, map[string]interface{}{
+		"user_id":      userID,
+		"notification": notification,
+	})
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to queue notification", 500)
+	}
+	return nil
+}
+
+// HandleDeliveryJob processes a JobDeliverNotification. The job is retried when every
+// channel that could reach the user failed.
+func (n *Notifier) HandleDeliveryJob(ctx context.Context, job *worker.Job) error {
+	var payload struct {
+		UserID       uuid.UUID    `json:"user_id"`
+		Notification Notification `json:"notification"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+	return n.deliver(ctx, payload.UserID, payload.Notification)
+}
+
+// HandleUserRegistered welcomes a user who signed up, subscribed to events.UserRegistered
+func (n *Notifier) HandleUserRegistered(ctx context.Context, event *events.Event) error {
+	var payload events.UserRegisteredPayload
+	if err := event.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	return n.deliver(ctx, payload.UserID, Notification{
+		Type:  NotificationWelcome,
+		Title: "Welcome to Catetin",
+		Body:  "Your account is ready. Record an expense by sending a message like \"lunch 25000\", and set a budget to be warned before you overspend.",
+	})
+}
+
+// HandleBudgetExceeded alerts a user whose spending went over a budget, subscribed to
+// events.BudgetExceeded
+func (n *Notifier) HandleBudgetExceeded(ctx context.Context, event *events.Event) error {
+	var payload events.BudgetExceededPayload
+	if err := event.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	return n.deliver(ctx, payload.UserID, Notification{
+		Type:  NotificationBudgetExceeded,
+		Title: "Budget exceeded: " + payload.Category,
+		Body: fmt.Sprintf("You have spent %s %s on %s this period, over your budget of %s %s.",
+			payload.Currency, formatAmount(payload.Spent), payload.Category, payload.Currency, formatAmount(payload.Cap)),
+	})
+}
+
+// deliver sends a notification on the first channel that reaches the user. It fails
+// when every channel that could reach the user failed, so the caller retries.
+func (n *Notifier) deliver(ctx context.Context, userID uuid.UUID, notification Notification) error {
+	log := logger.FromContext(ctx).With("user_id", userID, "notification_type", notification.Type)
+
+	recipient, err := n.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil // the user was deleted
+		}
+		return err
+	}
+
+	settings, err := n.settingsRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		settings, err = domain.DefaultUserSettings(userID), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var failed error
+	channels := settings.ChannelOrder()
+	for len(channels) > 0 {
+		channel, err := sendFirst(ctx, n.senders, channels, recipient, notification.Title, notification.Body)
+		if err == nil {
+			log.Debug("notification delivered", "channel", channel)
+			return nil
+		}
+		if errors.Is(err, ErrRecipientUnreachable) {
+			break
+		}
+
+		log.Warn("notification channel failed, trying the next", "channel", channel, "error", err)
+		failed = errors.Join(failed, fmt.Errorf("%s: %w", channel, err))
+		channels = channels[slices.Index(channels, channel)+1:]
+	}
+
+	if failed != nil {
+		return failed
+	}
+	log.Info("notification not delivered; no channel reaches the user")
+	return nil
+}
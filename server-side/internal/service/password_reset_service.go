@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobSendPasswordReset emails the link of a password reset
+const JobSendPasswordReset = "password_reset.send"
+
+// PasswordResetConfig holds the password reset settings
+type PasswordResetConfig struct {
+	// URL is the page that sets the new password; the token is added as the token
+	// query parameter
+	URL string
+
+	// TTL is how long a reset link works
+	TTL time.Duration
+
+	// MaxPerHour is the number of resets sent for one credential per hour
+	MaxPerHour int
+}
+
+// PasswordResetService lets users who forgot their password set a new one through a
+// link emailed to the address they sign in with. Setting it also unlocks a credential
+// locked by failed sign-ins and signs the user out everywhere.
+type PasswordResetService struct {
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+	resetRepo        repository.PasswordResetRepository
+	passwordHasher   *security.PasswordHasher
+	authService      *AuthService
+	auditor          *Auditor
+	notifier         *Notifier
+	txManager        repository.TransactionManager
+	jobs             JobEnqueuer
+	email            EmailSender
+	config           PasswordResetConfig
+}
+
+// NewPasswordResetService creates a new password reset service. Resets are refused
+// when email is nil.
+func NewPasswordResetService(
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	resetRepo repository.PasswordResetRepository,
+	passwordHasher *security.PasswordHasher,
+	authService *AuthService,
+	auditor *Auditor,
+	notifier *Notifier,
+	txManager repository.TransactionManager,
+	jobs JobEnqueuer,
+	email EmailSender,
+	config PasswordResetConfig,
+) *PasswordResetService {
+	if config.TTL <= 0 {
+		config.TTL = time.Hour
+	}
+	if config.MaxPerHour <= 0 {
+		config.MaxPerHour = 3
+	}
+
+	return &PasswordResetService{
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+		resetRepo:        resetRepo,
+		passwordHasher:   passwordHasher,
+		authService:      authService,
+		auditor:          auditor,
+		notifier:         notifier,
+		txManager:        txManager,
+		jobs:             jobs,
+		email:            email,
+		config:           config,
+	}
+}
+
+// Request emails a password reset link to the address if an account signs in with it.
+// It succeeds either way, so the response does not tell which emails have an account;
+// requests beyond the hourly limit are ignored the same way.
+func (s *PasswordResetService) Request(ctx context.Context, email string) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordResetService.Request")
+	defer func() { tracing.End(span, err) }()
+
+	if s.email == nil {
+		return appErrors.ErrPasswordResetUnavailable
+	}
+
+	provider, err := s.emailPasswordProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	userAuth, err := s.userAuthRepo.FindByCredentialID(ctx, email, provider.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user auth", 500)
+	}
+
+	sent, err := s.resetRepo.CountSince(ctx, userAuth.ID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count password resets", 500)
+	}
+	if sent >= int64(s.config.MaxPerHour) {
+		logger.FromContext(ctx).Info("password reset limit reached", "user_auth_id", userAuth.ID)
+		return nil
+	}
+
+	reset := domain.NewPasswordReset(userAuth.UserID, userAuth.ID, userAuth.CredentialID, s.config.TTL)
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.resetRepo.Create(txCtx, reset); err != nil {
+			return err
+		}
+		_, err := s.jobs.Enqueue(txCtx, JobSendPasswordReset, map[string]string{
+			"password_reset_id": reset.ID.String(),
+		})
+		return err
+	})
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to request password reset", 500)
+	}
+
+	return nil
+}
+
+// HandleSendJob processes a JobSendPasswordReset. Every attempt sends a new token, so a
+// link from an earlier, failed attempt stops working.
+func (s *PasswordResetService) HandleSendJob(ctx context.Context, job *worker.Job) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordResetService.Send")
+	defer func() { tracing.End(span, err) }()
+
+	var payload struct {
+		PasswordResetID uuid.UUID `json:"password_reset_id"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	reset, err := s.resetRepo.FindByID(ctx, payload.PasswordResetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return worker.Permanent(err) // the user was deleted
+		}
+		return err
+	}
+	if reset.IsUsed() || reset.IsExpired(time.Now()) {
+		return nil
+	}
+	if s.email == nil {
+		return worker.Permanent(errors.New("email is not configured"))
+	}
+
+	token, tokenHash, err := security.GeneratePasswordResetToken()
+	if err != nil {
+		return err
+	}
+	if err := s.resetRepo.MarkSent(ctx, reset.ID, tokenHash, time.Now()); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi,\n\n"+
+		"Someone asked to reset the password of your Catetin account. Open the link below\n"+
+		"to set a new password; it also unlocks your account if failed sign-ins locked it.\n\n"+
+		"%s\n\n"+
+		"The link expires on %s. If you did not ask for it, you can ignore this email.\n",
+		s.link(token), reset.ExpiresAt.UTC().Format("2 January 2006 15:04 MST"))
+	return s.email.Send(ctx, reset.Email, "Reset your Catetin password", body)
+}
+
+// link returns the URL that sets a new password with the token
+func (s *PasswordResetService) link(token string) string {
+	link, err := url.Parse(s.config.URL)
+	if err != nil {
+		return s.config.URL + "?token=" + url.QueryEscape(token)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// Confirm sets a new password with the token of a password reset. The credential is
+// unlocked and all sessions are revoked, so the user signs in again with the new password.
+func (s *PasswordResetService) Confirm(ctx context.Context, token, newPassword string) (err error) {
+	ctx, span := tracing.Start(ctx, "PasswordResetService.Confirm")
+	defer func() { tracing.End(span, err) }()
+
+	reset, err := s.resetRepo.FindByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrInvalidPasswordReset
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find password reset", 500)
+	}
+	if reset.IsUsed() || reset.IsExpired(time.Now()) {
+		return appErrors.ErrInvalidPasswordReset
+	}
+
+	provider, err := s.emailPasswordProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The credential may have been removed or given another email since
+	userAuth, err := s.userAuthRepo.FindByCredentialID(ctx, reset.Email, provider.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrInvalidPasswordReset
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user auth", 500)
+	}
+	if userAuth.ID != reset.UserAuthID {
+		return appErrors.ErrInvalidPasswordReset
+	}
+
+	hashedPassword, err := s.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to hash password", 500)
+	}
+
+	now := time.Now()
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Marking it first lets only one of two concurrent requests continue
+		if err := s.resetRepo.MarkUsed(txCtx, reset.ID, now); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrInvalidPasswordReset
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to use password reset", 500)
+		}
+
+		before := userAuthAudit(userAuth)
+		userAuth.CredentialSecret = hashedPassword
+		if err := s.userAuthRepo.Update(txCtx, userAuth); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update password", 500)
+		}
+
+		if userAuth.FailedLoginAttempts > 0 || userAuth.LockedUntil != nil {
+			if err := s.userAuthRepo.ResetLoginFailures(txCtx, userAuth.ID); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to reset failed sign-ins", 500)
+			}
+		}
+		if userAuth.IsLocked(now) {
+			after := userAuthAudit(&repository.UserAuth{ID: userAuth.ID})
+			if err := s.auditor.Record(txCtx, userAuth.UserID, before, after); err != nil {
+				return err
+			}
+		}
+
+		if err := s.authService.revokeSessions(txCtx, userAuth.UserID); err != nil {
+			return err
+		}
+
+		return s.notifier.Notify(txCtx, userAuth.UserID, Notification{
+			Type:  NotificationPasswordChanged,
+			Title: "Your Catetin password was reset",
+			Body:  "Your password was reset with a link sent to your email and you were signed out of all your sessions. If you did not do this, contact support.",
+		})
+	})
+}
+
+func (s *PasswordResetService) emailPasswordProvider(ctx context.Context) (*repository.AuthProvider, error) {
+	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
+	if provider == nil {
+		return nil, appErrors.New(appErrors.ErrCodeInternal, "Authentication provider not configured", 500)
+	}
+	return provider, nil
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// PhoneVerificationConfig holds the phone verification settings
+type PhoneVerificationConfig struct {
+	// WhatsAppTemplate is the approved template codes are sent with; its body takes the
+	// code as {{1}}
+	WhatsAppTemplate string
+	WhatsAppLanguage string
+
+	// TTL is how long a code works
+	TTL time.Duration
+
+	// MaxAttempts is the number of wrong codes after which a code stops working
+	MaxAttempts int
+
+	// MaxPerHour is the number of codes sent for one user per hour
+	MaxPerHour int
+}
+
+// PhoneVerificationService proves that users receive messages at a phone number before
+// it is linked as a phone-otp credential: it sends a one-time code by WhatsApp, which
+// the user sends back as the credential secret when linking the number.
+type PhoneVerificationService struct {
+	verificationRepo repository.PhoneVerificationRepository
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+	whatsapp         WhatsAppTemplateSender
+	config           PhoneVerificationConfig
+}
+
+// NewPhoneVerificationService creates a new phone verification service. Codes are not
+// sent when whatsapp is nil.
+func NewPhoneVerificationService(
+	verificationRepo repository.PhoneVerificationRepository,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	whatsapp WhatsAppTemplateSender,
+	config PhoneVerificationConfig,
+) *PhoneVerificationService {
+	if config.TTL <= 0 {
+		config.TTL = 10 * time.Minute
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 5
+	}
+	if config.MaxPerHour <= 0 {
+		config.MaxPerHour = 5
+	}
+
+	return &PhoneVerificationService{
+		verificationRepo: verificationRepo,
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+		whatsapp:         whatsapp,
+		config:           config,
+	}
+}
+
+// Enabled reports whether codes can be sent
+func (s *PhoneVerificationService) Enabled() bool {
+	return s.whatsapp != nil
+}
+
+// SendCode sends a verification code to the phone number by WhatsApp. A new code
+// replaces the codes sent before.
+func (s *PhoneVerificationService) SendCode(ctx context.Context, userID uuid.UUID, phoneNumber string) (verification *domain.PhoneVerification, err error) {
+	ctx, span := tracing.Start(ctx, "PhoneVerificationService.SendCode")
+	defer func() { tracing.End(span, err) }()
+
+	if !s.Enabled() {
+		return nil, appErrors.ErrUnsupportedAuthProvider
+	}
+	phoneNumber = normalizePhoneNumber(phoneNumber)
+
+	// A number linked already cannot be linked again, so no code is sent for it
+	provider, err := s.authProviderRepo.FindByName(ctx, PhoneOTPProviderName)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
+	if provider == nil {
+		return nil, appErrors.ErrUnsupportedAuthProvider
+	}
+	existingAuth, err := s.userAuthRepo.FindByCredentialID(ctx, phoneNumber, provider.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check existing credential", 500)
+	}
+	if existingAuth != nil {
+		if existingAuth.UserID != userID {
+			return nil, appErrors.ErrCredentialAlreadyLinked
+		}
+		return nil, appErrors.ErrProviderAlreadyLinked
+	}
+
+	sent, err := s.verificationRepo.CountSince(ctx, userID, time.Now().Add(-time.Hour))
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to count phone verifications", 500)
+	}
+	if sent >= int64(s.config.MaxPerHour) {
+		return nil, appErrors.ErrTooManyVerificationCodes
+	}
+
+	code, err := security.GenerateVerificationCode()
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate verification code", 500)
+	}
+	verification = domain.NewPhoneVerification(userID, phoneNumber, s.config.TTL)
+	verification.CodeHash = security.HashVerificationCode(verification.ID.String(), code)
+	if err := s.verificationRepo.Create(ctx, verification); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to store phone verification", 500)
+	}
+
+	_, err = s.whatsapp.SendTemplate(ctx, phoneNumber, whatsapp.Template{
+		Name:           s.config.WhatsAppTemplate,
+		Language:       s.config.WhatsAppLanguage,
+		BodyParameters: []string{code},
+	})
+	if err != nil {
+		// A code that never arrived does not count against the hourly limit
+		if err := s.verificationRepo.Delete(ctx, verification.ID); err != nil {
+			logger.FromContext(ctx).Warn("failed to delete unsent phone verification", "id", verification.ID, "error", err)
+		}
+		if errors.Is(err, whatsapp.ErrRecipientUnreachable) {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"phone_number": "WhatsApp cannot deliver messages to the phone number",
+			})
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to send verification code", 500)
+	}
+
+	return verification, nil
+}
+
+// Provider returns PhoneOTPProviderName
+func (s *PhoneVerificationService) Provider() string {
+	return PhoneOTPProviderName
+}
+
+// VerifyCredential checks the code last sent to the phone number for the user and uses
+// it up, returning the normalized phone number. Wrong codes count toward the attempts
+// after which the code stops working.
+func (s *PhoneVerificationService) VerifyCredential(ctx context.Context, userID uuid.UUID, provider *repository.AuthProvider, credentialID, proof string) (string, error) {
+	if proof == "" {
+		return "", appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"credential_secret": "verification code is required for phone-otp provider",
+		})
+	}
+	invalidCode := appErrors.ErrValidation.WithDetails(map[string]interface{}{
+		"credential_secret": "verification code is invalid or expired",
+	})
+
+	phoneNumber := normalizePhoneNumber(credentialID)
+	verification, err := s.verificationRepo.FindLatest(ctx, userID, phoneNumber)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", invalidCode
+		}
+		return "", appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find phone verification", 500)
+	}
+	if verification.IsExpired(time.Now()) || verification.Attempts >= s.config.MaxAttempts {
+		return "", invalidCode
+	}
+
+	hash := security.HashVerificationCode(verification.ID.String(), proof)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(verification.CodeHash)) != 1 {
+		if err := s.verificationRepo.IncrementAttempts(ctx, verification.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return "", appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record verification attempt", 500)
+		}
+		return "", invalidCode
+	}
+
+	// Deleting the verification uses the code up; a concurrent request that deleted it
+	// first used it
+	if err := s.verificationRepo.Delete(ctx, verification.ID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", invalidCode
+		}
+		return "", appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to use phone verification", 500)
+	}
+	return phoneNumber, nil
+}
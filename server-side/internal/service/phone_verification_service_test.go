@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/database/memory"
+	"github.com/ingunawandra/catetin/internal/infrastructure/google"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/infrastructure/whatsapp"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// fakePhoneVerificationRepo stores phone verifications in memory
+type fakePhoneVerificationRepo struct {
+	mu            sync.Mutex
+	verifications []*domain.PhoneVerification
+}
+
+func (r *fakePhoneVerificationRepo) Create(ctx context.Context, verification *domain.PhoneVerification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clone := *verification
+	r.verifications = append(r.verifications, &clone)
+	return nil
+}
+
+func (r *fakePhoneVerificationRepo) FindLatest(ctx context.Context, userID uuid.UUID, phoneNumber string) (*domain.PhoneVerification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.verifications) - 1; i >= 0; i-- {
+		if v := r.verifications[i]; v.UserID == userID && v.PhoneNumber == phoneNumber {
+			clone := *v
+			return &clone, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakePhoneVerificationRepo) CountSince(ctx context.Context, userID uuid.UUID, since time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for _, v := range r.verifications {
+		if v.UserID == userID && !v.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakePhoneVerificationRepo) IncrementAttempts(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range r.verifications {
+		if v.ID == id {
+			v.Attempts++
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (r *fakePhoneVerificationRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, v := range r.verifications {
+		if v.ID == id {
+			r.verifications = append(r.verifications[:i], r.verifications[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (r *fakePhoneVerificationRepo) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// fakeTemplateSender records the codes of the templates it sends, or fails with err
+type fakeTemplateSender struct {
+	codes map[string]string // last code by phone number
+	err   error
+}
+
+func (s *fakeTemplateSender) SendTemplate(ctx context.Context, to string, template whatsapp.Template) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.codes[to] = template.BodyParameters[0]
+	return "wamid.test", nil
+}
+
+// fakeIDTokens verifies the ID tokens it was given the claims of
+type fakeIDTokens struct {
+	subjects map[string]string // subject by token
+}
+
+func (v *fakeIDTokens) Verify(ctx context.Context, idToken, audience string) (*google.Claims, error) {
+	subject, ok := v.subjects[idToken]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown token", google.ErrInvalidToken)
+	}
+	claims := &google.Claims{}
+	claims.Subject = subject
+	return claims, nil
+}
+
+// linkTest holds in-memory repositories with a user to link credentials to
+type linkTest struct {
+	userAuths repository.UserAuthRepository
+	providers repository.AuthProviderRepository
+	users     repository.UserRepository
+	store     *memory.Store
+	user      *domain.User
+}
+
+func newLinkTest(t *testing.T) *linkTest {
+	t.Helper()
+	store := memory.NewStore()
+	lt := &linkTest{
+		userAuths: memory.NewUserAuthRepository(store),
+		providers: memory.NewAuthProviderRepository(store),
+		users:     memory.NewUserRepository(store),
+		store:     store,
+		user:      domain.NewUser("Budi", "6281234567890"),
+	}
+	if err := lt.users.Create(context.Background(), lt.user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := lt.authService().EnsureAuthProviders(context.Background()); err != nil {
+		t.Fatalf("EnsureAuthProviders() error = %v", err)
+	}
+	return lt
+}
+
+func (lt *linkTest) authService(verifiers ...CredentialVerifier) *AuthService {
+	return NewAuthService(
+		lt.users,
+		lt.userAuths,
+		lt.providers,
+		&fakeRefreshTokenRepo{},
+		security.NewPasswordHasher(security.PasswordHashConfig{}),
+		security.NewJWTManager([]string{"test-secret-key-with-enough-length"}, time.Minute, time.Hour, nil),
+		memory.NewTransactionManager(lt.store),
+		nil,
+		nil,
+		nil,
+		LockoutConfig{},
+		verifiers...,
+	)
+}
+
+// wantAppError fails unless err is an AppError with the code
+func wantAppError(t *testing.T, err error, code appErrors.ErrorCode) {
+	t.Helper()
+	var appErr *appErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != code {
+		t.Fatalf("error = %v, want %s", err, code)
+	}
+}
+
+func TestPhoneVerificationLinksPhoneNumber(t *testing.T) {
+	ctx := context.Background()
+	lt := newLinkTest(t)
+	sender := &fakeTemplateSender{codes: make(map[string]string)}
+	phones := NewPhoneVerificationService(&fakePhoneVerificationRepo{}, lt.userAuths, lt.providers, sender, PhoneVerificationConfig{})
+	auth := lt.authService(phones)
+
+	verification, err := phones.SendCode(ctx, lt.user.ID, "+62 812-3456-7890")
+	if err != nil {
+		t.Fatalf("SendCode() error = %v", err)
+	}
+	if verification.PhoneNumber != "6281234567890" {
+		t.Errorf("PhoneNumber = %q, want the normalized number", verification.PhoneNumber)
+	}
+	code := sender.codes["6281234567890"]
+
+	_, err = auth.LinkProvider(ctx, lt.user.ID, PhoneOTPProviderName, "+6281234567890", "")
+	wantAppError(t, err, appErrors.ErrCodeValidation)
+	_, err = auth.LinkProvider(ctx, lt.user.ID, PhoneOTPProviderName, "+6281234567890", wrongCode(code))
+	wantAppError(t, err, appErrors.ErrCodeValidation)
+
+	linked, err := auth.LinkProvider(ctx, lt.user.ID, PhoneOTPProviderName, "+6281234567890", code)
+	if err != nil {
+		t.Fatalf("LinkProvider() error = %v", err)
+	}
+	if linked.UserAuth.CredentialID != "6281234567890" || linked.UserAuth.CredentialSecret != "" {
+		t.Errorf("linked credential = %q with secret %q, want the normalized number without a secret",
+			linked.UserAuth.CredentialID, linked.UserAuth.CredentialSecret)
+	}
+
+	// The code was used up; sending another one is refused since the number is linked
+	_, err = phones.SendCode(ctx, lt.user.ID, "6281234567890")
+	wantAppError(t, err, appErrors.ErrCodeProviderAlreadyLinked)
+	_, err = phones.SendCode(ctx, uuid.New(), "6281234567890")
+	wantAppError(t, err, appErrors.ErrCodeCredentialAlreadyLinked)
+}
+
+func TestPhoneVerificationLimitsAttempts(t *testing.T) {
+	ctx := context.Background()
+	lt := newLinkTest(t)
+	sender := &fakeTemplateSender{codes: make(map[string]string)}
+	phones := NewPhoneVerificationService(&fakePhoneVerificationRepo{}, lt.userAuths, lt.providers, sender, PhoneVerificationConfig{MaxAttempts: 3})
+	auth := lt.authService(phones)
+
+	if _, err := phones.SendCode(ctx, lt.user.ID, "6281234567890"); err != nil {
+		t.Fatalf("SendCode() error = %v", err)
+	}
+	code := sender.codes["6281234567890"]
+	for i := 0; i < 3; i++ {
+		_, err := auth.LinkProvider(ctx, lt.user.ID, PhoneOTPProviderName, "6281234567890", wrongCode(code))
+		wantAppError(t, err, appErrors.ErrCodeValidation)
+	}
+
+	_, err := auth.LinkProvider(ctx, lt.user.ID, PhoneOTPProviderName, "6281234567890", code)
+	wantAppError(t, err, appErrors.ErrCodeValidation)
+}
+
+func TestPhoneVerificationRejectsExpiredCodes(t *testing.T) {
+	ctx := context.Background()
+	lt := newLinkTest(t)
+	repo := &fakePhoneVerificationRepo{}
+	sender := &fakeTemplateSender{codes: make(map[string]string)}
+	phones := NewPhoneVerificationService(repo, lt.userAuths, lt.providers, sender, PhoneVerificationConfig{})
+	auth := lt.authService(phones)
+
+	if _, err := phones.SendCode(ctx, lt.user.ID, "6281234567890"); err != nil {
+		t.Fatalf("SendCode() error = %v", err)
+	}
+	repo.verifications[0].ExpiresAt = time.Now().Add(-time.Second)
+
+	_, err := auth.LinkProvider(ctx, lt.user.ID, PhoneOTPProviderName, "6281234567890", sender.codes["6281234567890"])
+	wantAppError(t, err, appErrors.ErrCodeValidation)
+}
+
+func TestPhoneVerificationLimitsCodesPerHour(t *testing.T) {
+	ctx := context.Background()
+	lt := newLinkTest(t)
+	sender := &fakeTemplateSender{codes: make(map[string]string)}
+	phones := NewPhoneVerificationService(&fakePhoneVerificationRepo{}, lt.userAuths, lt.providers, sender, PhoneVerificationConfig{MaxPerHour: 2})
+
+	for i := 0; i < 2; i++ {
+		if _, err := phones.SendCode(ctx, lt.user.ID, "6281234567890"); err != nil {
+			t.Fatalf("SendCode() error = %v", err)
+		}
+	}
+	_, err := phones.SendCode(ctx, lt.user.ID, "6289876543210")
+	wantAppError(t, err, appErrors.ErrCodeTooManyVerificationCodes)
+}
+
+func TestPhoneVerificationUnreachableNumber(t *testing.T) {
+	ctx := context.Background()
+	lt := newLinkTest(t)
+	repo := &fakePhoneVerificationRepo{}
+	sender := &fakeTemplateSender{err: fmt.Errorf("%w: not on WhatsApp", whatsapp.ErrRecipientUnreachable)}
+	phones := NewPhoneVerificationService(repo, lt.userAuths, lt.providers, sender, PhoneVerificationConfig{})
+
+	_, err := phones.SendCode(ctx, lt.user.ID, "6281234567890")
+	wantAppError(t, err, appErrors.ErrCodeValidation)
+	if len(repo.verifications) != 0 {
+		t.Errorf("%d verifications kept, want the unsent code deleted", len(repo.verifications))
+	}
+}
+
+func TestLinkProviderVerifiesGoogleIDToken(t *testing.T) {
+	ctx := context.Background()
+	lt := newLinkTest(t)
+	tokens := &fakeIDTokens{subjects: map[string]string{"id-token": "109876543210987654321"}}
+
+	// Without a verifier, Google accounts cannot be linked at all
+	_, err := lt.authService().LinkProvider(ctx, lt.user.ID, GoogleProviderName, "109876543210987654321", "id-token")
+	wantAppError(t, err, appErrors.ErrCodeUnsupportedAuthProvider)
+
+	auth := lt.authService(NewGoogleCredentialVerifier(tokens, "1234.apps.googleusercontent.com"))
+	tests := []struct {
+		name         string
+		credentialID string
+		token        string
+	}{
+		{"without a token", "109876543210987654321", ""},
+		{"with an invalid token", "109876543210987654321", "forged"},
+		{"with another account's ID", "123", "id-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.LinkProvider(ctx, lt.user.ID, GoogleProviderName, tt.credentialID, tt.token)
+			wantAppError(t, err, appErrors.ErrCodeValidation)
+		})
+	}
+
+	linked, err := auth.LinkProvider(ctx, lt.user.ID, GoogleProviderName, "109876543210987654321", "id-token")
+	if err != nil {
+		t.Fatalf("LinkProvider() error = %v", err)
+	}
+	if linked.UserAuth.CredentialID != "109876543210987654321" || linked.UserAuth.CredentialSecret != "" {
+		t.Errorf("linked credential = %q with secret %q, want the token's subject without a secret",
+			linked.UserAuth.CredentialID, linked.UserAuth.CredentialSecret)
+	}
+}
+
+// racingUserAuthRepo misses the credentials another request links between the checks
+// of LinkProvider and its insert
+type racingUserAuthRepo struct {
+	repository.UserAuthRepository
+}
+
+func (r *racingUserAuthRepo) FindByCredentialID(ctx context.Context, credentialID string, authProviderID uuid.UUID) (*repository.UserAuth, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (r *racingUserAuthRepo) FindByUserIDAndProvider(ctx context.Context, userID, authProviderID uuid.UUID) (*repository.UserAuth, error) {
+	return nil, domain.ErrNotFound
+}
+
+func TestLinkProviderConcurrentDuplicateCredential(t *testing.T) {
+	ctx := context.Background()
+	lt := newLinkTest(t)
+	other := domain.NewUser("Sari", "6289876543210")
+	if err := lt.users.Create(ctx, other); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := lt.authService().LinkProvider(ctx, other.ID, EmailPasswordProviderName, "sari@example.com", "password123"); err != nil {
+		t.Fatalf("LinkProvider() error = %v", err)
+	}
+
+	lt.userAuths = &racingUserAuthRepo{UserAuthRepository: lt.userAuths}
+	_, err := lt.authService().LinkProvider(ctx, lt.user.ID, EmailPasswordProviderName, "sari@example.com", "password123")
+	wantAppError(t, err, appErrors.ErrCodeCredentialAlreadyLinked)
+
+	var appErr *appErrors.AppError
+	if errors.As(err, &appErr) && appErr.HTTPStatus != http.StatusConflict {
+		t.Errorf("HTTPStatus = %d, want 409", appErr.HTTPStatus)
+	}
+}
+
+// wrongCode returns a code that differs from code
+func wrongCode(code string) string {
+	if code == "000000" {
+		return "000001"
+	}
+	return "000000"
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// ReadOnlyConfig holds the settings of read-only mode
+type ReadOnlyConfig struct {
+	// Forced keeps this instance read-only whatever the stored switch says
+	Forced bool
+
+	// RefreshInterval is how often the stored switch is re-read, so a change made on
+	// one instance reaches the others
+	RefreshInterval time.Duration
+}
+
+// ReadOnlyStatus is the state of read-only mode on this instance
+type ReadOnlyStatus struct {
+	// Enabled is whether writes are rejected: the stored switch is on or Forced is set
+	Enabled bool
+	Forced  bool
+	Reason  string
+
+	UpdatedBy *uuid.UUID
+	UpdatedAt *time.Time
+}
+
+// ReadOnlyService holds the read-only switch. ReadOnly answers from memory so the
+// middleware adds no query to each request; the stored switch is re-read in the
+// background. A failed read keeps the last known state.
+type ReadOnlyService struct {
+	settingRepo repository.SystemSettingRepository
+	config      ReadOnlyConfig
+
+	mu     sync.RWMutex
+	status ReadOnlyStatus
+}
+
+// NewReadOnlyService creates a new read-only mode service
+func NewReadOnlyService(settingRepo repository.SystemSettingRepository, config ReadOnlyConfig) *ReadOnlyService {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = 10 * time.Second
+	}
+
+	return &ReadOnlyService{
+		settingRepo: settingRepo,
+		config:      config,
+		status:      ReadOnlyStatus{Enabled: config.Forced, Forced: config.Forced},
+	}
+}
+
+// ReadOnly reports whether writes are currently rejected
+func (s *ReadOnlyService) ReadOnly() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status.Enabled
+}
+
+// Status re-reads the stored switch and returns the state of this instance
+func (s *ReadOnlyService) Status(ctx context.Context) (*ReadOnlyStatus, error) {
+	if err := s.Refresh(ctx); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get read-only mode", 500)
+	}
+	return s.current(), nil
+}
+
+// Set turns the stored switch on or off. Turning it off does not lift Forced.
+func (s *ReadOnlyService) Set(ctx context.Context, userID uuid.UUID, enabled bool, reason string) (*ReadOnlyStatus, error) {
+	mode := domain.ReadOnlyMode{Enabled: enabled}
+	if enabled {
+		mode.Reason = reason
+	}
+	value, err := json.Marshal(mode)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to set read-only mode", 500)
+	}
+
+	setting := &domain.SystemSetting{
+		Key:       domain.SettingReadOnly,
+		Value:     string(value),
+		UpdatedBy: &userID,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.settingRepo.Save(ctx, setting); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, appErrors.ErrVersionConflict
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to set read-only mode", 500)
+	}
+
+	s.apply(ctx, setting)
+	return s.current(), nil
+}
+
+// Refresh re-reads the stored switch
+func (s *ReadOnlyService) Refresh(ctx context.Context) error {
+	setting, err := s.settingRepo.FindByKey(ctx, domain.SettingReadOnly)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.apply(ctx, nil)
+			return nil
+		}
+		return err
+	}
+
+	s.apply(ctx, setting)
+	return nil
+}
+
+// SetForced changes Forced, e.g. when the configuration is reloaded
+func (s *ReadOnlyService) SetForced(ctx context.Context, forced bool) error {
+	s.mu.Lock()
+	s.config.Forced = forced
+	s.mu.Unlock()
+
+	return s.Refresh(ctx)
+}
+
+// Run re-reads the stored switch every RefreshInterval until the context is cancelled
+func (s *ReadOnlyService) Run(ctx context.Context) {
+	log := logger.FromContext(ctx).With("component", "read_only")
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Warn("failed to refresh read-only mode", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// apply updates the state from the stored switch; nil means it was never set
+func (s *ReadOnlyService) apply(ctx context.Context, setting *domain.SystemSetting) {
+	var status ReadOnlyStatus
+	if setting != nil {
+		var mode domain.ReadOnlyMode
+		if err := json.Unmarshal([]byte(setting.Value), &mode); err == nil {
+			status.Enabled = mode.Enabled
+			status.Reason = mode.Reason
+		}
+		updatedAt := setting.UpdatedAt
+		status.UpdatedBy = setting.UpdatedBy
+		status.UpdatedAt = &updatedAt
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status.Forced = s.config.Forced
+	status.Enabled = status.Enabled || status.Forced
+
+	if status.Enabled != s.status.Enabled {
+		logger.FromContext(ctx).Warn("read-only mode changed", "enabled", status.Enabled, "forced", status.Forced, "reason", status.Reason)
+	}
+	s.status = status
+}
+
+func (s *ReadOnlyService) current() *ReadOnlyStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := s.status
+	return &status
+}
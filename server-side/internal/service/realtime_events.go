@@ -0,0 +1,68 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// MoneyFlowEvent is the data of the money flow events pushed to clients, enough to update
+// a list without fetching the money flow again
+type MoneyFlowEvent struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	Amount      float64    `json:"amount"`
+	Currency    string     `json:"currency"`
+	Category    *string    `json:"category"`
+	Description *string    `json:"description"`
+	Tags        []string   `json:"tags"`
+	WalletID    *uuid.UUID `json:"wallet_id"`
+	GroupID     *uuid.UUID `json:"group_id"`
+	Version     int        `json:"version"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// BudgetExceededEvent is the data of the event pushed when a money flow takes the
+// spending of a budget over its cap
+type BudgetExceededEvent struct {
+	BudgetID    uuid.UUID `json:"budget_id"`
+	Category    string    `json:"category"`
+	Currency    string    `json:"currency"`
+	Hard        bool      `json:"hard"`
+	Cap         float64   `json:"cap"`
+	Spent       float64   `json:"spent"` // including the money flow
+	MoneyFlowID uuid.UUID `json:"money_flow_id"`
+}
+
+func moneyFlowEvent(moneyFlow *domain.MoneyFlow) *MoneyFlowEvent {
+	tags := moneyFlow.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return &MoneyFlowEvent{
+		ID:          moneyFlow.ID,
+		Kind:        moneyFlow.Kind,
+		Amount:      moneyFlow.Money().Float64(),
+		Currency:    moneyFlow.Currency,
+		Category:    moneyFlow.Category,
+		Description: moneyFlow.Description,
+		Tags:        tags,
+		WalletID:    moneyFlow.WalletID,
+		GroupID:     moneyFlow.GroupID,
+		Version:     moneyFlow.Version,
+		CreatedAt:   moneyFlow.CreatedAt,
+	}
+}
+
+func budgetExceededEvent(budget *domain.Budget, moneyFlow *domain.MoneyFlow, spent int64) *BudgetExceededEvent {
+	return &BudgetExceededEvent{
+		BudgetID:    budget.ID,
+		Category:    budget.Category,
+		Currency:    budget.Currency,
+		Hard:        budget.Hard,
+		Cap:         domain.MajorUnits(budget.Amount, budget.Currency),
+		Spent:       domain.MajorUnits(spent+moneyFlow.Amount, budget.Currency),
+		MoneyFlowID: moneyFlow.ID,
+	}
+}
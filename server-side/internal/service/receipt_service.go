@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// ImageJSONCompleter asks a language model that reads images for a JSON object answer
+type ImageJSONCompleter interface {
+	Enabled() bool
+	CompleteJSONWithImage(ctx context.Context, system, user string, image []byte, mediaType string, out interface{}) error
+}
+
+const receiptPrompt = `You read photos of shopping receipts, invoices, and bills, in Indonesian or English.
+Reply with a JSON object with these fields:
+- "is_receipt": true only if the image is a receipt whose total can be read
+- "merchant": the name of the shop or company, or ""
+- "date": the purchase date as YYYY-MM-DD, or "" if it cannot be read
+- "total": the grand total paid, as a number without thousands separators, after discounts, taxes, and service charges
+- "currency": the ISO 4217 code of the amounts, or "" if the receipt does not show it
+- "category": one short lowercase Indonesian word such as "makanan", "transportasi", "belanja", "tagihan", "hiburan", "kesehatan", or "lainnya"
+- "items": the purchased lines, each {"name": string, "quantity": number, "amount": number}, where amount is the line total; [] if unreadable
+Read numbers as printed: on Indonesian receipts "." groups thousands and "," starts the decimals.`
+
+// ReceiptItem is a line of a scanned receipt
+type ReceiptItem struct {
+	Name     string
+	Quantity float64
+	Amount   float64 // line total
+}
+
+// ReceiptScan is what was read from a receipt photo, with the money flow it suggests.
+// Nothing is saved: the user reviews the draft and creates the money flow.
+type ReceiptScan struct {
+	Merchant *string
+	Date     *time.Time // as printed, without a time zone
+	Total    float64
+	Currency string
+	Items    []ReceiptItem
+
+	// Draft is the suggested input of MoneyFlowService.Create
+	Draft MoneyFlowInput
+}
+
+// ReceiptService reads receipt photos into money flow drafts with a vision model
+type ReceiptService struct {
+	completer    ImageJSONCompleter
+	settingsRepo repository.UserSettingsRepository
+}
+
+// NewReceiptService creates a new receipt service
+func NewReceiptService(completer ImageJSONCompleter, settingsRepo repository.UserSettingsRepository) *ReceiptService {
+	return &ReceiptService{
+		completer:    completer,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// Scan reads a receipt image. The currency defaults to the user's default currency when
+// the receipt shows none.
+func (s *ReceiptService) Scan(ctx context.Context, userID uuid.UUID, image []byte, mediaType string) (scan *ReceiptScan, err error) {
+	ctx, span := tracing.Start(ctx, "ReceiptService.Scan")
+	defer func() { tracing.End(span, err) }()
+
+	if !s.completer.Enabled() {
+		return nil, appErrors.ErrReceiptScanUnavailable
+	}
+
+	var reply struct {
+		IsReceipt bool    `json:"is_receipt"`
+		Merchant  string  `json:"merchant"`
+		Date      string  `json:"date"`
+		Total     float64 `json:"total"`
+		Currency  string  `json:"currency"`
+		Category  string  `json:"category"`
+		Items     []struct {
+			Name     string  `json:"name"`
+			Quantity float64 `json:"quantity"`
+			Amount   float64 `json:"amount"`
+		} `json:"items"`
+	}
+	if err := s.completer.CompleteJSONWithImage(ctx, receiptPrompt, "Read this receipt.", image, mediaType, &reply); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to scan receipt", 500)
+	}
+	if !reply.IsReceipt || reply.Total <= 0 {
+		return nil, appErrors.ErrReceiptUnreadable
+	}
+
+	scan = &ReceiptScan{
+		Total:    reply.Total,
+		Currency: strings.ToUpper(strings.TrimSpace(reply.Currency)),
+	}
+	if merchant := strings.TrimSpace(reply.Merchant); merchant != "" {
+		scan.Merchant = &merchant
+	}
+	if date, err := time.Parse("2006-01-02", strings.TrimSpace(reply.Date)); err == nil {
+		scan.Date = &date
+	}
+	for _, item := range reply.Items {
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			continue
+		}
+		if item.Quantity <= 0 {
+			item.Quantity = 1
+		}
+		scan.Items = append(scan.Items, ReceiptItem{Name: name, Quantity: item.Quantity, Amount: item.Amount})
+	}
+
+	if len(scan.Currency) != 3 {
+		scan.Currency, err = s.defaultCurrency(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scan.Draft = MoneyFlowInput{
+		Amount:      scan.Total,
+		Currency:    scan.Currency,
+		Description: scan.Merchant,
+		Note:        receiptNote(scan),
+	}
+	if category := strings.ToLower(strings.TrimSpace(reply.Category)); category != "" {
+		scan.Draft.Category = &category
+	}
+
+	return scan, nil
+}
+
+func (s *ReceiptService) defaultCurrency(ctx context.Context, userID uuid.UUID) (string, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultCurrency, nil
+		}
+		return "", appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user settings", 500)
+	}
+	return settings.DefaultCurrency, nil
+}
+
+// receiptNote lists the date and items of a receipt, for the note of its money flow
+func receiptNote(scan *ReceiptScan) *string {
+	var lines []string
+	if scan.Date != nil {
+		lines = append(lines, "Receipt date: "+scan.Date.Format("2006-01-02"))
+	}
+	for _, item := range scan.Items {
+		lines = append(lines, fmt.Sprintf("%s x %s: %s",
+			formatQuantity(item.Quantity), item.Name, formatStatementAmount(item.Amount)))
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	note := strings.Join(lines, "\n")
+	return &note
+}
+
+func formatQuantity(quantity float64) string {
+	if quantity == float64(int64(quantity)) {
+		return fmt.Sprintf("%d", int64(quantity))
+	}
+	return fmt.Sprintf("%g", quantity)
+}
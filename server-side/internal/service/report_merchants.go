@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// MerchantsReport is a user's spending per merchant in a period, converted to one
+// currency. Amounts are in minor units of Currency.
+type MerchantsReport struct {
+	Currency    string
+	PeriodStart *time.Time // nil for all time
+	PeriodEnd   time.Time  // exclusive
+
+	// Total and Count cover the money flows linked to a merchant whose currency could
+	// be converted
+	Total int64
+	Count int64
+
+	// RateDate is the day of the exchange rates used; nil when no rates were needed
+	// or none are stored
+	RateDate *time.Time
+
+	// Merchants are spent at most first, at most the limit of the report
+	Merchants []*MerchantReport
+
+	// Unconverted lists the currencies without an exchange rate, left out of the totals
+	Unconverted []string
+}
+
+// MerchantReport is the converted spending at one merchant
+type MerchantReport struct {
+	Merchant *domain.Merchant
+	Total    int64
+	Count    int64
+}
+
+// Merchants reports the user's own money flows of a period per merchant in a currency;
+// an empty currency is the user's default currency. Money flows without a merchant are
+// left out. Amounts are converted like Summary's. The period's GroupID is ignored.
+func (s *ReportService) Merchants(ctx context.Context, userID uuid.UUID, currency string, period ReportPeriod, limit int) (report *MerchantsReport, err error) {
+	ctx, span := tracing.Start(ctx, "ReportService.Merchants")
+	defer func() { tracing.End(span, err) }()
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		settings = domain.DefaultUserSettings(userID)
+	case err != nil:
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user settings", 500)
+	}
+	if currency == "" {
+		currency = settings.DefaultCurrency
+	}
+
+	now := time.Now()
+	report = &MerchantsReport{
+		Currency:    currency,
+		PeriodEnd:   now,
+		Merchants:   []*MerchantReport{},
+		Unconverted: []string{},
+	}
+	var start time.Time
+	switch {
+	case period.Month != nil:
+		start, report.PeriodEnd = settings.MonthRange(localDay(*period.Month, settings.Location()))
+		report.PeriodStart = &start
+	case period.Week != nil:
+		start, report.PeriodEnd = settings.WeekRange(localDay(*period.Week, settings.Location()))
+		report.PeriodStart = &start
+	}
+
+	totals, err := s.merchantRepo.GetTotals(ctx, userID, start, report.PeriodEnd)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize money flows", 500)
+	}
+
+	var rates *domain.ExchangeRates
+	for _, total := range totals {
+		if total.Currency != currency {
+			rateDay := report.PeriodEnd.Add(-time.Nanosecond)
+			if rateDay.After(now) {
+				rateDay = now
+			}
+			if rates, err = s.exchangeRates.Rates(ctx, rateDay); err != nil {
+				return nil, err
+			}
+			if !rates.Date.IsZero() {
+				report.RateDate = &rates.Date
+			}
+			break
+		}
+	}
+
+	byMerchant := map[uuid.UUID]*MerchantReport{}
+	unconverted := map[string]bool{}
+	for _, total := range totals {
+		rate, ok := rates.Rate(total.Currency, currency)
+		if !ok {
+			if !unconverted[total.Currency] {
+				unconverted[total.Currency] = true
+				report.Unconverted = append(report.Unconverted, total.Currency)
+			}
+			continue
+		}
+
+		converted := convertMinor(total.Total, total.Currency, rate, currency)
+		report.Total += converted
+		report.Count += total.Count
+
+		merchant, ok := byMerchant[total.MerchantID]
+		if !ok {
+			merchant = &MerchantReport{Merchant: &domain.Merchant{ID: total.MerchantID}}
+			byMerchant[total.MerchantID] = merchant
+			report.Merchants = append(report.Merchants, merchant)
+		}
+		merchant.Total += converted
+		merchant.Count += total.Count
+	}
+
+	sort.SliceStable(report.Merchants, func(i, j int) bool {
+		return report.Merchants[i].Total > report.Merchants[j].Total
+	})
+	if limit > 0 && len(report.Merchants) > limit {
+		report.Merchants = report.Merchants[:limit]
+	}
+
+	ids := make([]uuid.UUID, len(report.Merchants))
+	for i, merchant := range report.Merchants {
+		ids[i] = merchant.Merchant.ID
+	}
+	merchants, err := s.merchantRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find merchants", 500)
+	}
+	for _, merchant := range merchants {
+		byMerchant[merchant.ID].Merchant = merchant
+	}
+
+	return report, nil
+}
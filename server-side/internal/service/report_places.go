@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// Distances in meters within which located money flows are grouped into one place: wide
+// for money flows sharing a place name, yet narrow enough to keep the branches of a
+// chain apart, and narrow for those without one
+const (
+	namedPlaceRadius   = 1000
+	unnamedPlaceRadius = 100
+)
+
+// PlacesReport is a user's located spending in a period per place, converted to one
+// currency. Amounts are in minor units of Currency.
+type PlacesReport struct {
+	Currency    string
+	PeriodStart *time.Time // nil for all time
+	PeriodEnd   time.Time  // exclusive
+
+	// Near is the area the report is narrowed down to; nil for every located money flow
+	Near *Nearby
+
+	// Total and Count cover the located money flows whose currency could be converted
+	Total int64
+	Count int64
+
+	// RateDate is the day of the exchange rates used; nil when no rates were needed
+	// or none are stored
+	RateDate *time.Time
+
+	// Places are spent at most first, at most the limit of the report
+	Places []*PlaceReport
+
+	// Unconverted lists the currencies without an exchange rate, left out of the totals
+	Unconverted []string
+}
+
+// Nearby is the area within Radius meters of a point
+type Nearby struct {
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+}
+
+// PlaceReport is the converted spending at one place: the money flows located close
+// together with the same place name, or without one at nearly the same point. Latitude
+// and Longitude are the center of its money flows.
+type PlaceReport struct {
+	Name      *string
+	Latitude  float64
+	Longitude float64
+
+	// Distance is the distance in meters from the center of the report's area; nil
+	// when the report is not narrowed down to one
+	Distance *float64
+
+	Total int64
+	Count int64
+}
+
+// Places reports the user's own located money flows of a period per place in a
+// currency; an empty currency is the user's default currency. A non-nil near keeps the
+// money flows located within its radius only. Amounts are converted like Summary's.
+// The period's GroupID is ignored.
+func (s *ReportService) Places(ctx context.Context, userID uuid.UUID, currency string, period ReportPeriod, near *Nearby, limit int) (report *PlacesReport, err error) {
+	ctx, span := tracing.Start(ctx, "ReportService.Places")
+	defer func() { tracing.End(span, err) }()
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		settings = domain.DefaultUserSettings(userID)
+	case err != nil:
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user settings", 500)
+	}
+	if currency == "" {
+		currency = settings.DefaultCurrency
+	}
+
+	now := time.Now()
+	report = &PlacesReport{
+		Currency:    currency,
+		PeriodEnd:   now,
+		Near:        near,
+		Places:      []*PlaceReport{},
+		Unconverted: []string{},
+	}
+	var start time.Time
+	switch {
+	case period.Month != nil:
+		start, report.PeriodEnd = settings.MonthRange(localDay(*period.Month, settings.Location()))
+		report.PeriodStart = &start
+	case period.Week != nil:
+		start, report.PeriodEnd = settings.WeekRange(localDay(*period.Week, settings.Location()))
+		report.PeriodStart = &start
+	}
+
+	query := repository.PlaceQuery{UserID: userID, Start: start, End: report.PeriodEnd}
+	if near != nil {
+		box := domain.BoundingBoxAround(near.Latitude, near.Longitude, near.Radius)
+		query.Box = &box
+	}
+	totals, err := s.spendingRepo.GetTotalsByPlace(ctx, query)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize money flows", 500)
+	}
+
+	// The bounding box holds more than the circle; its corners are left out here
+	if near != nil {
+		within := totals[:0]
+		for _, total := range totals {
+			if domain.Distance(near.Latitude, near.Longitude, total.Latitude, total.Longitude) <= near.Radius {
+				within = append(within, total)
+			}
+		}
+		totals = within
+	}
+
+	var rates *domain.ExchangeRates
+	for _, total := range totals {
+		if total.Currency != currency {
+			rateDay := report.PeriodEnd.Add(-time.Nanosecond)
+			if rateDay.After(now) {
+				rateDay = now
+			}
+			if rates, err = s.exchangeRates.Rates(ctx, rateDay); err != nil {
+				return nil, err
+			}
+			if !rates.Date.IsZero() {
+				report.RateDate = &rates.Date
+			}
+			break
+		}
+	}
+
+	// Places are anchored at their most used point, and take in the points of the same
+	// name within their radius of it
+	sort.SliceStable(totals, func(i, j int) bool {
+		return totals[i].Count > totals[j].Count
+	})
+	type anchored struct {
+		place     *PlaceReport
+		anchor    *domain.PlaceTotal
+		latitude  float64 // sums of the coordinates weighted by count, to center the place
+		longitude float64
+	}
+	byName := map[string][]*anchored{}
+
+	unconverted := map[string]bool{}
+	for _, total := range totals {
+		rate, ok := rates.Rate(total.Currency, currency)
+		if !ok {
+			if !unconverted[total.Currency] {
+				unconverted[total.Currency] = true
+				report.Unconverted = append(report.Unconverted, total.Currency)
+			}
+			continue
+		}
+
+		converted := convertMinor(total.Total, total.Currency, rate, currency)
+		report.Total += converted
+		report.Count += total.Count
+
+		name := placeName(total)
+		radius := float64(unnamedPlaceRadius)
+		if name != "" {
+			radius = namedPlaceRadius
+		}
+		var found *anchored
+		for _, candidate := range byName[name] {
+			if domain.Distance(candidate.anchor.Latitude, candidate.anchor.Longitude, total.Latitude, total.Longitude) <= radius {
+				found = candidate
+				break
+			}
+		}
+		if found == nil {
+			found = &anchored{place: &PlaceReport{}, anchor: total}
+			if name != "" {
+				found.place.Name = total.PlaceName
+			}
+			byName[name] = append(byName[name], found)
+			report.Places = append(report.Places, found.place)
+		}
+		found.place.Total += converted
+		found.place.Count += total.Count
+		found.latitude += total.Latitude * float64(total.Count)
+		found.longitude += total.Longitude * float64(total.Count)
+	}
+
+	for _, places := range byName {
+		for _, entry := range places {
+			place := entry.place
+			place.Latitude = entry.latitude / float64(place.Count)
+			place.Longitude = entry.longitude / float64(place.Count)
+			if near == nil {
+				continue
+			}
+			distance := domain.Distance(near.Latitude, near.Longitude, place.Latitude, place.Longitude)
+			place.Distance = &distance
+		}
+	}
+
+	sort.SliceStable(report.Places, func(i, j int) bool {
+		return report.Places[i].Total > report.Places[j].Total
+	})
+	if limit > 0 && len(report.Places) > limit {
+		report.Places = report.Places[:limit]
+	}
+
+	return report, nil
+}
+
+// placeName returns the place name of a total lowercased with its spaces collapsed, or
+// an empty string when it has none
+func placeName(total *domain.PlaceTotal) string {
+	if total.PlaceName == nil {
+		return ""
+	}
+	return strings.ToLower(strings.Join(strings.Fields(*total.PlaceName), " "))
+}
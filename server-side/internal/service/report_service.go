@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// ReportSummary is a user's spending in a period converted to one currency. Amounts are
+// in minor units: of Currency, or of the recorded currency for CurrencyReport.Total.
+type ReportSummary struct {
+	Currency    string
+	PeriodStart *time.Time // nil for all time
+	PeriodEnd   time.Time  // exclusive
+
+	// Total and Count cover the money flows whose currency could be converted
+	Total int64
+	Count int64
+
+	// RateDate is the day of the exchange rates used; nil when no rates were needed
+	// or none are stored
+	RateDate *time.Time
+
+	Categories []*CategoryReport
+	Currencies []*CurrencyReport
+
+	// Unconverted lists the currencies without an exchange rate, left out of the totals
+	Unconverted []string
+}
+
+// CategoryReport is the converted spending in one category, nil for uncategorized
+type CategoryReport struct {
+	Category *string
+	Total    int64
+	Count    int64
+}
+
+// CurrencyReport is the spending recorded in one currency. Rate and Converted are nil
+// when the currency has no exchange rate.
+type CurrencyReport struct {
+	Currency  string
+	Total     int64
+	Count     int64
+	Rate      *float64
+	Converted *int64
+}
+
+// ReportPeriod selects the period of a report; the zero value is all time. Dates are
+// read as calendar days in the user's time zone.
+type ReportPeriod struct {
+	Month *time.Time // the calendar month of the date
+	Week  *time.Time // the week of the date, starting on the user's week start day
+
+	// GroupID reports on the shared ledger of a group the user is a member of instead
+	// of the user's own money flows
+	GroupID *uuid.UUID
+}
+
+// ReportService reports spending across currencies, converted with exchange rates
+type ReportService struct {
+	moneyFlowRepo repository.MoneyFlowRepository
+	settingsRepo  repository.UserSettingsRepository
+	groupRepo     repository.GroupRepository
+	spendingRepo  repository.SpendingAnalyticsRepository
+	merchantRepo  repository.MerchantRepository
+	exchangeRates *ExchangeRateService
+}
+
+// NewReportService creates a new report service
+func NewReportService(
+	moneyFlowRepo repository.MoneyFlowRepository,
+	settingsRepo repository.UserSettingsRepository,
+	groupRepo repository.GroupRepository,
+	spendingRepo repository.SpendingAnalyticsRepository,
+	merchantRepo repository.MerchantRepository,
+	exchangeRates *ExchangeRateService,
+) *ReportService {
+	return &ReportService{
+		moneyFlowRepo: moneyFlowRepo,
+		settingsRepo:  settingsRepo,
+		groupRepo:     groupRepo,
+		spendingRepo:  spendingRepo,
+		merchantRepo:  merchantRepo,
+		exchangeRates: exchangeRates,
+	}
+}
+
+// Summary reports the money flows of a period in a currency; an empty currency is the
+// user's default currency. Amounts are converted with the rates of the period's last
+// day, or the latest rates for a running period.
+func (s *ReportService) Summary(ctx context.Context, userID uuid.UUID, currency string, period ReportPeriod) (report *ReportSummary, err error) {
+	ctx, span := tracing.Start(ctx, "ReportService.Summary")
+	defer func() { tracing.End(span, err) }()
+
+	if period.GroupID != nil {
+		if _, err := findGroupMember(ctx, s.groupRepo, *period.GroupID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		settings = domain.DefaultUserSettings(userID)
+	case err != nil:
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user settings", 500)
+	}
+	if currency == "" {
+		currency = settings.DefaultCurrency
+	}
+
+	now := time.Now()
+	report = &ReportSummary{
+		Currency:    currency,
+		PeriodEnd:   now,
+		Categories:  []*CategoryReport{},
+		Currencies:  []*CurrencyReport{},
+		Unconverted: []string{},
+	}
+	var start time.Time
+	switch {
+	case period.Month != nil:
+		start, report.PeriodEnd = settings.MonthRange(localDay(*period.Month, settings.Location()))
+		report.PeriodStart = &start
+	case period.Week != nil:
+		start, report.PeriodEnd = settings.WeekRange(localDay(*period.Week, settings.Location()))
+		report.PeriodStart = &start
+	}
+
+	var totals []*domain.CategoryTotal
+	if period.GroupID != nil {
+		totals, err = s.moneyFlowRepo.GetGroupTotalsByCategory(ctx, *period.GroupID, start, report.PeriodEnd)
+	} else {
+		totals, err = s.moneyFlowRepo.GetTotalsByCategory(ctx, userID, start, report.PeriodEnd)
+	}
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize money flows", 500)
+	}
+
+	var rates *domain.ExchangeRates
+	for _, total := range totals {
+		if total.Currency != currency {
+			rateDay := report.PeriodEnd.Add(-time.Nanosecond)
+			if rateDay.After(now) {
+				rateDay = now
+			}
+			if rates, err = s.exchangeRates.Rates(ctx, rateDay); err != nil {
+				return nil, err
+			}
+			if !rates.Date.IsZero() {
+				report.RateDate = &rates.Date
+			}
+			break
+		}
+	}
+
+	categories := map[string]*CategoryReport{}
+	currencies := map[string]*CurrencyReport{}
+	for _, total := range totals {
+		byCurrency, ok := currencies[total.Currency]
+		if !ok {
+			byCurrency = &CurrencyReport{Currency: total.Currency}
+			if rate, ok := rates.Rate(total.Currency, currency); ok {
+				byCurrency.Rate = &rate
+				byCurrency.Converted = new(int64)
+			} else {
+				report.Unconverted = append(report.Unconverted, total.Currency)
+			}
+			currencies[total.Currency] = byCurrency
+			report.Currencies = append(report.Currencies, byCurrency)
+		}
+		byCurrency.Total += total.Total
+		byCurrency.Count += total.Count
+		if byCurrency.Rate == nil {
+			continue
+		}
+
+		// Each category's total is converted and rounded on its own, so the totals add up
+		converted := convertMinor(total.Total, total.Currency, *byCurrency.Rate, currency)
+		*byCurrency.Converted += converted
+		report.Total += converted
+		report.Count += total.Count
+
+		key := ""
+		if total.Category != nil {
+			key = "\x00" + *total.Category
+		}
+		byCategory, ok := categories[key]
+		if !ok {
+			byCategory = &CategoryReport{Category: total.Category}
+			categories[key] = byCategory
+			report.Categories = append(report.Categories, byCategory)
+		}
+		byCategory.Total += converted
+		byCategory.Count += total.Count
+	}
+
+	sort.SliceStable(report.Categories, func(i, j int) bool {
+		return report.Categories[i].Total > report.Categories[j].Total
+	})
+	sort.SliceStable(report.Currencies, func(i, j int) bool {
+		return report.Currencies[i].Count > report.Currencies[j].Count
+	})
+
+	return report, nil
+}
+
+// convertMinor converts minor units of one currency to the nearest minor unit of another
+func convertMinor(minor int64, from string, rate float64, to string) int64 {
+	return int64(math.Round(domain.MajorUnits(minor, from) * rate * math.Pow10(domain.CurrencyExponent(to))))
+}
+
+// localDay returns midnight of t's calendar day in location, so a date parsed without a
+// time zone stays on the same day
+func localDay(t time.Time, location *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+}
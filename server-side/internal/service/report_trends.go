@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// maxTrendPeriods is the number of periods a trend may cover, a year of days
+const maxTrendPeriods = 366
+
+// defaultTrendPeriods is the number of periods of a trend without a start day
+var defaultTrendPeriods = map[string]int{
+	domain.TrendDaily:   30,
+	domain.TrendWeekly:  12,
+	domain.TrendMonthly: 12,
+}
+
+// TrendQuery selects the periods of a spending trend. From and To are calendar days in
+// the user's time zone; the trend covers the periods containing them.
+type TrendQuery struct {
+	Interval string     // domain.TrendDaily, domain.TrendWeekly, or domain.TrendMonthly
+	From     *time.Time // defaults to 30 days, 12 weeks, or 12 months before To
+	To       *time.Time // defaults to today
+	Currency string     // defaults to the user's default currency
+
+	// GroupID reports on the shared ledger of a group the user is a member of instead
+	// of the user's own money flows
+	GroupID *uuid.UUID
+}
+
+// TrendReport is a user's spending in consecutive periods, converted to one currency
+type TrendReport struct {
+	Currency string
+	Interval string
+
+	// RateDate is the day of the exchange rates used; nil when no rates were needed
+	// or none are stored
+	RateDate *time.Time
+
+	// Points has one entry per period, oldest first, including periods without spending
+	Points []*TrendPoint
+
+	// Unconverted lists the currencies without an exchange rate, left out of the totals
+	Unconverted []string
+}
+
+// TrendPoint is the spending in one period and its change from the period before, in
+// minor units of the report currency
+type TrendPoint struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time // exclusive
+	Total       int64
+	Count       int64
+	Change      int64
+
+	// ChangePercent is rounded to one decimal, nil when nothing was spent in the period before
+	ChangePercent *float64
+}
+
+// Trends reports the spending per day, week, or month, converted with the rates of the
+// last day of the trend, so the periods compare without exchange rate noise
+func (s *ReportService) Trends(ctx context.Context, userID uuid.UUID, query TrendQuery) (report *TrendReport, err error) {
+	ctx, span := tracing.Start(ctx, "ReportService.Trends")
+	defer func() { tracing.End(span, err) }()
+
+	if query.GroupID != nil {
+		if _, err := findGroupMember(ctx, s.groupRepo, *query.GroupID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		settings = domain.DefaultUserSettings(userID)
+	case err != nil:
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to get user settings", 500)
+	}
+	if query.Currency == "" {
+		query.Currency = settings.DefaultCurrency
+	}
+
+	location := settings.Location()
+	periodStart := func(day time.Time) time.Time {
+		switch query.Interval {
+		case domain.TrendMonthly:
+			start, _ := settings.MonthRange(day)
+			return start
+		case domain.TrendWeekly:
+			start, _ := settings.WeekRange(day)
+			return start
+		default:
+			return localDay(day.In(location), location)
+		}
+	}
+	addPeriods := func(start time.Time, n int) time.Time {
+		switch query.Interval {
+		case domain.TrendMonthly:
+			return start.AddDate(0, n, 0)
+		case domain.TrendWeekly:
+			return start.AddDate(0, 0, 7*n)
+		default:
+			return start.AddDate(0, 0, n)
+		}
+	}
+
+	now := time.Now()
+	last := periodStart(now)
+	if query.To != nil {
+		last = periodStart(localDay(*query.To, location))
+	}
+	first := addPeriods(last, 1-defaultTrendPeriods[query.Interval])
+	if query.From != nil {
+		first = periodStart(localDay(*query.From, location))
+	}
+
+	if first.After(last) {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"from": "from must not be after to",
+		})
+	}
+	periods := 1
+	for start := first; start.Before(last); start = addPeriods(start, 1) {
+		if periods++; periods > maxTrendPeriods {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"from": fmt.Sprintf("a trend covers at most %d periods", maxTrendPeriods),
+			})
+		}
+	}
+
+	// The period before the first is loaded to compute the first change
+	start, end := addPeriods(first, -1), addPeriods(last, 1)
+	totals, err := s.spendingRepo.GetTotalsByPeriod(repository.PreferReplica(ctx), repository.SpendingQuery{
+		UserID:    userID,
+		GroupID:   query.GroupID,
+		Interval:  query.Interval,
+		Timezone:  location.String(),
+		WeekStart: settings.WeekStart,
+		Start:     start,
+		End:       end,
+	})
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to summarize money flows", 500)
+	}
+
+	report = &TrendReport{
+		Currency:    query.Currency,
+		Interval:    query.Interval,
+		Points:      make([]*TrendPoint, 0, periods),
+		Unconverted: []string{},
+	}
+
+	var rates *domain.ExchangeRates
+	for _, total := range totals {
+		if total.Currency != query.Currency {
+			rateDay := end.Add(-time.Nanosecond)
+			if rateDay.After(now) {
+				rateDay = now
+			}
+			if rates, err = s.exchangeRates.Rates(ctx, rateDay); err != nil {
+				return nil, err
+			}
+			if !rates.Date.IsZero() {
+				report.RateDate = &rates.Date
+			}
+			break
+		}
+	}
+
+	byPeriod := map[string]*TrendPoint{}
+	for _, total := range totals {
+		rate, ok := rates.Rate(total.Currency, query.Currency)
+		if !ok {
+			if !slices.Contains(report.Unconverted, total.Currency) {
+				report.Unconverted = append(report.Unconverted, total.Currency)
+			}
+			continue
+		}
+
+		key := total.PeriodStart.Format(time.DateOnly)
+		point, ok := byPeriod[key]
+		if !ok {
+			point = &TrendPoint{}
+			byPeriod[key] = point
+		}
+		point.Total += convertMinor(total.Total, total.Currency, rate, query.Currency)
+		point.Count += total.Count
+	}
+
+	var previous int64
+	if point, ok := byPeriod[start.Format(time.DateOnly)]; ok {
+		previous = point.Total
+	}
+	for day := first; !day.After(last); day = addPeriods(day, 1) {
+		point, ok := byPeriod[day.Format(time.DateOnly)]
+		if !ok {
+			point = &TrendPoint{}
+		}
+		point.PeriodStart = day
+		point.PeriodEnd = addPeriods(day, 1)
+		point.Change = point.Total - previous
+		if previous != 0 {
+			percent := math.Round(float64(point.Change)*1000/float64(previous)) / 10
+			point.ChangePercent = &percent
+		}
+
+		report.Points = append(report.Points, point)
+		previous = point.Total
+	}
+
+	return report, nil
+}
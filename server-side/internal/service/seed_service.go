@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/security"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// seedDemoMonths is how many months of sample money flows each seeded demo user gets
+const seedDemoMonths = 3
+
+// seedDemoEmailDomain is the email domain of seeded demo users; it is reserved, so the
+// addresses can never belong to a real user
+const seedDemoEmailDomain = "demo.catetin.local"
+
+// demoBudgets are the monthly budgets of seeded demo users, in IDR
+var demoBudgets = []struct {
+	category string
+	amount   float64
+}{
+	{category: "Food", amount: 1500000},
+	{category: "Groceries", amount: 1500000},
+	{category: "Transport", amount: 750000},
+	{category: "Entertainment", amount: 500000},
+}
+
+// SeedOptions selects the data created by SeedService.Seed
+type SeedOptions struct {
+	// Demo creates demo users that sign in with a password, with sample money flows
+	// and budgets
+	Demo bool
+
+	// DemoUsers is the number of demo users
+	DemoUsers int
+
+	// DemoPassword is the password of every demo user
+	DemoPassword string
+}
+
+// SeedResult counts the records created by a seed run
+type SeedResult struct {
+	AuthProviders int
+	Users         int
+	MoneyFlows    int
+	Budgets       int
+}
+
+// SeedService populates a database with the data the API needs to run, and optionally
+// with demo data for local development and integration tests. Seeding is idempotent:
+// records that already exist are left as they are. Categories are free text on each
+// money flow and budget, so there are none to seed on their own.
+type SeedService struct {
+	userRepo         repository.UserRepository
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+	moneyFlowRepo    repository.MoneyFlowRepository
+	budgetRepo       repository.BudgetRepository
+	passwordHasher   *security.PasswordHasher
+	txManager        repository.TransactionManager
+}
+
+// NewSeedService creates a new seed service
+func NewSeedService(
+	userRepo repository.UserRepository,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+	budgetRepo repository.BudgetRepository,
+	passwordHasher *security.PasswordHasher,
+	txManager repository.TransactionManager,
+) *SeedService {
+	return &SeedService{
+		userRepo:         userRepo,
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+		moneyFlowRepo:    moneyFlowRepo,
+		budgetRepo:       budgetRepo,
+		passwordHasher:   passwordHasher,
+		txManager:        txManager,
+	}
+}
+
+// Seed creates the default auth providers and, with opts.Demo, the demo users
+func (s *SeedService) Seed(ctx context.Context, opts SeedOptions) (*SeedResult, error) {
+	result := &SeedResult{}
+
+	for _, p := range defaultAuthProviders {
+		created, err := ensureAuthProvider(ctx, s.authProviderRepo, p.Name, p.DisplayName)
+		if err != nil {
+			return result, err
+		}
+		if created {
+			result.AuthProviders++
+		}
+	}
+
+	if !opts.Demo {
+		return result, nil
+	}
+	if opts.DemoPassword == "" {
+		return result, errors.New("demo password is required")
+	}
+
+	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
+	if err != nil {
+		return result, fmt.Errorf("failed to find auth provider: %w", err)
+	}
+	hashedPassword, err := s.passwordHasher.Hash(opts.DemoPassword)
+	if err != nil {
+		return result, fmt.Errorf("failed to hash demo password: %w", err)
+	}
+
+	for i := 1; i <= opts.DemoUsers; i++ {
+		if err := s.seedDemoUser(ctx, i, provider.ID, hashedPassword, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// SeedDemoEmail returns the email of the i-th seeded demo user, starting from 1
+func SeedDemoEmail(i int) string {
+	return fmt.Sprintf("demo%d@%s", i, seedDemoEmailDomain)
+}
+
+// seedDemoUser creates the i-th demo user with its sample data, unless the user exists
+func (s *SeedService) seedDemoUser(ctx context.Context, i int, providerID uuid.UUID, hashedPassword string, result *SeedResult) error {
+	email := SeedDemoEmail(i)
+
+	existing, err := s.userAuthRepo.FindByCredentialID(ctx, email, providerID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("failed to check demo user %s: %w", email, err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	now := time.Now()
+	var moneyFlows, budgets int
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Like registration, the email doubles as the phone number
+		user := domain.NewUser(fmt.Sprintf("Demo User %d", i), email)
+		if err := s.userRepo.Create(txCtx, user); err != nil {
+			return fmt.Errorf("failed to create demo user %s: %w", email, err)
+		}
+
+		userAuth := &repository.UserAuth{
+			ID:               uuid.New(),
+			UserID:           user.ID,
+			AuthProviderID:   providerID,
+			CredentialID:     email,
+			CredentialSecret: hashedPassword,
+		}
+		if err := s.userAuthRepo.Create(txCtx, userAuth); err != nil {
+			return fmt.Errorf("failed to create demo user auth %s: %w", email, err)
+		}
+
+		// The demo sandbox data, repeated for each of the past months
+		for month := 0; month < seedDemoMonths; month++ {
+			for _, seed := range demoMoneyFlows {
+				moneyFlow, err := domain.NewMoneyFlow(user.ID, seed.amount, domain.DefaultCurrency)
+				if err != nil {
+					return fmt.Errorf("invalid demo money flow: %w", err)
+				}
+				category, description := seed.category, seed.description
+				moneyFlow.Category = &category
+				moneyFlow.Description = &description
+				moneyFlow.Tags = []string{"demo"}
+				moneyFlow.CreatedAt = now.AddDate(0, -month, -seed.daysAgo)
+				moneyFlow.UpdatedAt = moneyFlow.CreatedAt
+
+				if err := s.moneyFlowRepo.Create(txCtx, moneyFlow); err != nil {
+					return fmt.Errorf("failed to create demo money flow: %w", err)
+				}
+				moneyFlows++
+			}
+		}
+
+		for _, seed := range demoBudgets {
+			budget, err := domain.NewBudget(user.ID, seed.category, seed.amount, domain.DefaultCurrency, false)
+			if err != nil {
+				return fmt.Errorf("invalid demo budget: %w", err)
+			}
+			if err := s.budgetRepo.Create(txCtx, budget); err != nil {
+				return fmt.Errorf("failed to create demo budget: %w", err)
+			}
+			budgets++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	result.Users++
+	result.MoneyFlows += moneyFlows
+	result.Budgets += budgets
+	return nil
+}
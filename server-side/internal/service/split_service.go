@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// SplitService splits group money flows between members and works out who owes whom
+type SplitService struct {
+	splitRepo     repository.SplitRepository
+	moneyFlowRepo repository.MoneyFlowRepository
+	groupRepo     repository.GroupRepository
+	txManager     repository.TransactionManager
+}
+
+// NewSplitService creates a new split service
+func NewSplitService(
+	splitRepo repository.SplitRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+	groupRepo repository.GroupRepository,
+	txManager repository.TransactionManager,
+) *SplitService {
+	return &SplitService{
+		splitRepo:     splitRepo,
+		moneyFlowRepo: moneyFlowRepo,
+		groupRepo:     groupRepo,
+		txManager:     txManager,
+	}
+}
+
+// MoneyFlowSplits are the shares of a money flow and the member who paid it
+type MoneyFlowSplits struct {
+	MoneyFlowID uuid.UUID
+	PaidBy      uuid.UUID
+	Splits      []*domain.Split
+}
+
+// SettlementInput holds the fields of a payment between two members of a group
+type SettlementInput struct {
+	FromUserID uuid.UUID
+	ToUserID   uuid.UUID
+	Amount     float64
+	Currency   string
+}
+
+// MemberBalance is what a member is owed (positive) or owes (negative) in one
+// currency, in minor units
+type MemberBalance struct {
+	UserID   uuid.UUID
+	Currency string
+	Amount   int64
+}
+
+// GroupBalances are the balances of a group's members and the payments that settle them
+type GroupBalances struct {
+	Balances []*MemberBalance
+	Debts    []*domain.Debt
+}
+
+// Split divides a money flow the user recorded in a group between members of the group,
+// replacing its earlier splits. Equal splits without shares go to every member.
+func (s *SplitService) Split(ctx context.Context, userID, moneyFlowID uuid.UUID, method string, shares []domain.SplitShare) (*MoneyFlowSplits, error) {
+	ctx, span := tracing.Start(ctx, "SplitService.Split")
+	defer span.End()
+
+	moneyFlow, err := s.findMoneyFlow(ctx, moneyFlowID)
+	if err != nil {
+		return nil, err
+	}
+	// Only the author paid, so only the author splits
+	if moneyFlow.UserID != userID {
+		return nil, appErrors.ErrResourceNotFound
+	}
+	if moneyFlow.GroupID == nil || moneyFlow.IsTransfer() {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"money_flow": "only expenses recorded in a group can be split",
+		})
+	}
+
+	members, err := s.groupRepo.FindMembers(ctx, *moneyFlow.GroupID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list group members", 500)
+	}
+	isMember := make(map[uuid.UUID]bool, len(members))
+	for _, member := range members {
+		isMember[member.UserID] = true
+	}
+
+	if method == domain.SplitMethodEqual && len(shares) == 0 {
+		for _, member := range members {
+			shares = append(shares, domain.SplitShare{UserID: member.UserID})
+		}
+	}
+	for _, share := range shares {
+		if !isMember[share.UserID] {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"shares": "user " + share.UserID.String() + " is not a member of the group",
+			})
+		}
+	}
+
+	splits, err := domain.NewSplits(moneyFlow, method, shares)
+	if err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"shares": err.Error(),
+		})
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.splitRepo.ReplaceForMoneyFlow(txCtx, moneyFlow.ID, splits); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to save splits", 500)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MoneyFlowSplits{MoneyFlowID: moneyFlow.ID, PaidBy: moneyFlow.UserID, Splits: splits}, nil
+}
+
+// GetSplits returns the splits of a money flow in a group the user is a member of
+func (s *SplitService) GetSplits(ctx context.Context, userID, moneyFlowID uuid.UUID) (*MoneyFlowSplits, error) {
+	ctx, span := tracing.Start(ctx, "SplitService.GetSplits")
+	defer span.End()
+
+	moneyFlow, err := s.findMoneyFlow(ctx, moneyFlowID)
+	if err != nil {
+		return nil, err
+	}
+	if moneyFlow.GroupID == nil {
+		if moneyFlow.UserID != userID {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return &MoneyFlowSplits{MoneyFlowID: moneyFlow.ID, PaidBy: moneyFlow.UserID, Splits: []*domain.Split{}}, nil
+	}
+	if _, err := findGroupMember(ctx, s.groupRepo, *moneyFlow.GroupID, userID); err != nil {
+		return nil, err
+	}
+
+	splits, err := s.splitRepo.FindByMoneyFlowID(ctx, moneyFlow.ID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find splits", 500)
+	}
+	for _, split := range splits {
+		split.PayerID = moneyFlow.UserID
+	}
+	return &MoneyFlowSplits{MoneyFlowID: moneyFlow.ID, PaidBy: moneyFlow.UserID, Splits: splits}, nil
+}
+
+// Settle records a payment between two members of a group, one of whom is the user
+func (s *SplitService) Settle(ctx context.Context, userID, groupID uuid.UUID, input SettlementInput) (*domain.Settlement, error) {
+	ctx, span := tracing.Start(ctx, "SplitService.Settle")
+	defer span.End()
+
+	if _, err := findGroupMember(ctx, s.groupRepo, groupID, userID); err != nil {
+		return nil, err
+	}
+	if input.FromUserID != userID && input.ToUserID != userID {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"from_user_id": "you can only record payments you made or received",
+		})
+	}
+	for field, memberID := range map[string]uuid.UUID{"from_user_id": input.FromUserID, "to_user_id": input.ToUserID} {
+		_, err := s.groupRepo.FindMember(ctx, groupID, memberID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				field: "user is not a member of the group",
+			})
+		}
+		if err != nil {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find group member", 500)
+		}
+	}
+
+	settlement, err := domain.NewSettlement(groupID, input.FromUserID, input.ToUserID, userID, input.Amount, input.Currency)
+	if err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"settlement": err.Error(),
+		})
+	}
+
+	if err := s.splitRepo.CreateSettlement(ctx, settlement); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to record settlement", 500)
+	}
+	return settlement, nil
+}
+
+// ListSettlements returns the payments recorded in a group the user is a member of, newest first
+func (s *SplitService) ListSettlements(ctx context.Context, userID, groupID uuid.UUID, limit, offset int) ([]*domain.Settlement, error) {
+	ctx, span := tracing.Start(ctx, "SplitService.ListSettlements")
+	defer span.End()
+
+	if _, err := findGroupMember(ctx, s.groupRepo, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	settlements, err := s.splitRepo.FindSettlementsByGroupID(ctx, groupID, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list settlements", 500)
+	}
+	return settlements, nil
+}
+
+// Balances works out what each member of a group the user is a member of owes or is
+// owed, and the fewest payments, per currency, that settle everyone up
+func (s *SplitService) Balances(ctx context.Context, userID, groupID uuid.UUID) (*GroupBalances, error) {
+	ctx, span := tracing.Start(ctx, "SplitService.Balances")
+	defer span.End()
+
+	if _, err := findGroupMember(ctx, s.groupRepo, groupID, userID); err != nil {
+		return nil, err
+	}
+
+	splits, err := s.splitRepo.FindByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find splits", 500)
+	}
+	settlements, err := s.splitRepo.FindAllSettlementsByGroupID(ctx, groupID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find settlements", 500)
+	}
+
+	result := &GroupBalances{Balances: []*MemberBalance{}, Debts: []*domain.Debt{}}
+	net := domain.NetBalances(splits, settlements)
+
+	currencies := make([]string, 0, len(net))
+	for currency := range net {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		for memberID, amount := range net[currency] {
+			if amount != 0 {
+				result.Balances = append(result.Balances, &MemberBalance{UserID: memberID, Currency: currency, Amount: amount})
+			}
+		}
+		result.Debts = append(result.Debts, domain.SimplifyDebts(net[currency], currency)...)
+	}
+	sort.SliceStable(result.Balances, func(i, j int) bool {
+		a, b := result.Balances[i], result.Balances[j]
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		return a.Amount > b.Amount
+	})
+
+	return result, nil
+}
+
+func (s *SplitService) findMoneyFlow(ctx context.Context, id uuid.UUID) (*domain.MoneyFlow, error) {
+	moneyFlow, err := s.moneyFlowRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find money flow", 500)
+	}
+	return moneyFlow, nil
+}
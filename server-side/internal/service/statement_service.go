@@ -0,0 +1,337 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/i18n"
+	"github.com/ingunawandra/catetin/internal/infrastructure/email"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobSendStatement renders the statement of a month and emails it to one user
+const JobSendStatement = "statement.send"
+
+// StatementConfig holds the schedule of the monthly statements
+type StatementConfig struct {
+	// Interval is how often users are checked for a statement that is due
+	Interval time.Duration
+
+	// Hour is the hour of the 1st, in the user's time zone, from which the statement of
+	// the month that just ended is sent
+	Hour int
+
+	// BatchSize is the number of users loaded per query
+	BatchSize int
+}
+
+// EmailMessageSender sends email with an HTML alternative and attachments
+type EmailMessageSender interface {
+	SendMessage(ctx context.Context, message email.Message) error
+}
+
+// Statement is the email of a user's monthly statement: a summary table in the body
+// and the full statement as a PDF attachment
+type Statement struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time // exclusive
+	Count       int       // money flows in the statement
+
+	Subject string
+	Text    string
+	HTML    string
+	PDF     *ExportFile
+}
+
+// statementEmail is the data of the statement email templates
+type statementEmail struct {
+	Language i18n.Language
+	Subject  string
+	Name     string
+	Period   string
+	Rows     []statementEmailRow
+}
+
+// statementEmailRow is a line of the summary table of a statement email
+type statementEmailRow struct {
+	Category string
+	Amount   string
+	Count    int
+	Overall  bool
+}
+
+// StatementService emails users who opt in a statement of each month, the month in UTC
+// like the money flow exports
+type StatementService struct {
+	settingsRepo     repository.UserSettingsRepository
+	statementRepo    repository.StatementRepository
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+	exports          *MoneyFlowExportService
+	mailer           EmailMessageSender
+	jobs             JobEnqueuer
+	txManager        repository.TransactionManager
+	config           StatementConfig
+}
+
+// NewStatementService creates a new statement service
+func NewStatementService(
+	settingsRepo repository.UserSettingsRepository,
+	statementRepo repository.StatementRepository,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	exports *MoneyFlowExportService,
+	mailer EmailMessageSender,
+	jobs JobEnqueuer,
+	txManager repository.TransactionManager,
+	config StatementConfig,
+) *StatementService {
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 500
+	}
+
+	return &StatementService{
+		settingsRepo:     settingsRepo,
+		statementRepo:    statementRepo,
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+		exports:          exports,
+		mailer:           mailer,
+		jobs:             jobs,
+		txManager:        txManager,
+		config:           config,
+	}
+}
+
+// Preview renders the user's statement of the month in a language as it is emailed,
+// whether or not they opted in
+func (s *StatementService) Preview(ctx context.Context, userID uuid.UUID, month time.Time, language i18n.Language) (statement *Statement, err error) {
+	ctx, span := tracing.Start(ctx, "StatementService.Preview")
+	defer func() { tracing.End(span, err) }()
+
+	return s.render(ctx, userID, month, language)
+}
+
+// Run queues the statements that are due every interval until ctx is done
+func (s *StatementService) Run(ctx context.Context) {
+	log := logger.FromContext(ctx).With("component", "statements")
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if queued := s.Schedule(ctx); queued > 0 {
+			log.Info("monthly statements queued", "count", queued)
+		}
+	}
+}
+
+// Schedule queues a JobSendStatement for every opted-in user whose statement of the
+// month that just ended is due and was not queued yet, and returns the number queued.
+// Each statement is claimed before it is queued, so runs of several instances send it
+// once.
+func (s *StatementService) Schedule(ctx context.Context) int {
+	log := logger.FromContext(ctx).With("component", "statements")
+	now := time.Now()
+
+	queued := 0
+	var after uuid.UUID
+	for {
+		// Disabled accounts cannot sign in, and demo users are deleted soon, so neither
+		// is listed
+		userIDs, err := s.statementRepo.ListSubscribers(ctx, after, s.config.BatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Warn("failed to list users for statements", "error", err)
+			}
+			return queued
+		}
+
+		for _, userID := range userIDs {
+			ok, err := s.schedule(ctx, userID, now)
+			if err != nil {
+				if ctx.Err() != nil {
+					return queued
+				}
+				log.Warn("failed to queue monthly statement", "user_id", userID, "error", err)
+				continue
+			}
+			if ok {
+				queued++
+			}
+		}
+
+		if len(userIDs) < s.config.BatchSize {
+			return queued
+		}
+		after = userIDs[len(userIDs)-1]
+	}
+}
+
+// HandleSendJob processes a JobSendStatement. Statements of months without money flows,
+// of users who opted out since, and of users without an email are not sent.
+func (s *StatementService) HandleSendJob(ctx context.Context, job *worker.Job) error {
+	var payload struct {
+		UserID      uuid.UUID `json:"user_id"`
+		PeriodStart time.Time `json:"period_start"`
+	}
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+	log := logger.FromContext(ctx).With("user_id", payload.UserID)
+
+	settings, err := s.findSettings(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if !settings.MonthlyStatement {
+		return nil
+	}
+
+	statement, err := s.render(ctx, payload.UserID, payload.PeriodStart, i18n.FromLocale(settings.Locale))
+	if errors.Is(err, appErrors.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if statement.Count == 0 {
+		log.Debug("monthly statement skipped; nothing was recorded")
+		return nil
+	}
+
+	address, err := findEmail(ctx, s.userAuthRepo, s.authProviderRepo, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if address == "" {
+		log.Info("monthly statement skipped; the user signs in without an email")
+		return nil
+	}
+
+	return s.mailer.SendMessage(ctx, email.Message{
+		To:      address,
+		Subject: statement.Subject,
+		Text:    statement.Text,
+		HTML:    statement.HTML,
+		Attachments: []email.Attachment{{
+			Filename:    statement.PDF.Filename,
+			ContentType: statement.PDF.ContentType,
+			Content:     statement.PDF.Content,
+		}},
+	})
+}
+
+// schedule queues the user's statement of the month before now if it is due, reporting
+// whether it was queued
+func (s *StatementService) schedule(ctx context.Context, userID uuid.UUID, now time.Time) (bool, error) {
+	settings, err := s.findSettings(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if !settings.MonthlyStatement {
+		return false, nil
+	}
+
+	// The month is in UTC; it is sent from the hour on the 1st in the user's time zone
+	end := time.Date(now.UTC().Year(), now.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -1, 0)
+	if now.Before(time.Date(end.Year(), end.Month(), end.Day(), s.config.Hour, 0, 0, 0, settings.Location())) {
+		return false, nil
+	}
+
+	queued := false
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		claimed, err := s.statementRepo.Claim(txCtx, userID, start)
+		if err != nil || !claimed {
+			return err
+		}
+
+		_, err = s.jobs.Enqueue(txCtx, JobSendStatement, map[string]interface{}{
+			"user_id":      userID,
+			"period_start": start,
+		})
+		queued = err == nil
+		return err
+	})
+	return queued, err
+}
+
+// render renders the email of the user's statement of the month
+func (s *StatementService) render(ctx context.Context, userID uuid.UUID, month time.Time, language i18n.Language) (*Statement, error) {
+	data, err := s.exports.Statement(ctx, userID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	var pdf bytes.Buffer
+	exporter := PDFExporter{}
+	if err := exporter.Export(&pdf, data); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate export", 500)
+	}
+
+	period := fmt.Sprintf("%s %d", i18n.T(language, fmt.Sprintf("month.%d", data.PeriodStart.Month())), data.PeriodStart.Year())
+	content := statementEmail{
+		Language: language,
+		Subject:  i18n.T(language, "statement.subject", period),
+		Name:     data.User.FullName,
+		Period:   period,
+		Rows:     []statementEmailRow{},
+	}
+	for _, total := range statementTotals(data) {
+		category := total.category
+		if category == uncategorized {
+			category = i18n.T(language, "statement.uncategorized")
+		}
+		content.Rows = append(content.Rows, statementEmailRow{
+			Category: category,
+			Amount:   total.currency + " " + formatAmount(total.money().Float64()),
+			Count:    total.count,
+			Overall:  total.overall,
+		})
+	}
+
+	text, html, err := renderEmail("statement", content)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to render statement", 500)
+	}
+
+	return &Statement{
+		PeriodStart: data.PeriodStart,
+		PeriodEnd:   data.PeriodEnd,
+		Count:       len(data.MoneyFlows),
+		Subject:     content.Subject,
+		Text:        text,
+		HTML:        html,
+		PDF: &ExportFile{
+			Filename:    "catetin-" + data.PeriodStart.Format(ExportMonthLayout) + "." + exporter.Format(),
+			ContentType: exporter.ContentType(),
+			Content:     pdf.Bytes(),
+		},
+	}, nil
+}
+
+func (s *StatementService) findSettings(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.DefaultUserSettings(userID), nil
+	}
+	return settings, err
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// TagService lists, renames, and merges the tags of a user's money flows. Tags are
+// not stored on their own; a tag exists while a money flow has it.
+type TagService struct {
+	moneyFlowRepo repository.MoneyFlowRepository
+	txManager     repository.TransactionManager
+}
+
+// NewTagService creates a new tag service
+func NewTagService(moneyFlowRepo repository.MoneyFlowRepository, txManager repository.TransactionManager) *TagService {
+	return &TagService{
+		moneyFlowRepo: moneyFlowRepo,
+		txManager:     txManager,
+	}
+}
+
+// List returns the user's tags with the number of money flows having each, most used first
+func (s *TagService) List(ctx context.Context, userID uuid.UUID) ([]*domain.TagUsage, error) {
+	ctx, span := tracing.Start(ctx, "TagService.List")
+	defer span.End()
+
+	usage, err := s.moneyFlowRepo.GetTagUsage(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list tags", 500)
+	}
+	return usage, nil
+}
+
+// Rename renames a tag on every money flow of the user and returns the usage of the
+// new name. Renaming to a tag already in use fails; use Merge to combine tags.
+func (s *TagService) Rename(ctx context.Context, userID uuid.UUID, from, to string) (*domain.TagUsage, error) {
+	ctx, span := tracing.Start(ctx, "TagService.Rename")
+	defer span.End()
+
+	if from == to {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"name": "must differ from the current name",
+		})
+	}
+
+	var usage *domain.TagUsage
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		tags, err := s.usage(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if tags[from] == nil {
+			return appErrors.ErrTagNotFound
+		}
+		if tags[to] != nil {
+			return appErrors.ErrTagAlreadyExists
+		}
+
+		if _, err := s.moneyFlowRepo.RenameTag(txCtx, userID, from, to); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to rename tag", 500)
+		}
+
+		usage = tags[from]
+		usage.Tag = to
+		return nil // Commit transaction
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// Merge replaces each of the from tags with to on every money flow of the user, so a
+// money flow with several of them keeps to once, and returns the usage of to. Tags in
+// from that no money flow has are ignored, as long as one of them is in use.
+func (s *TagService) Merge(ctx context.Context, userID uuid.UUID, from []string, to string) (*domain.TagUsage, error) {
+	ctx, span := tracing.Start(ctx, "TagService.Merge")
+	defer span.End()
+
+	var usage *domain.TagUsage
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		tags, err := s.usage(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(from, func(tag string) bool { return tag != to && tags[tag] != nil }) {
+			return appErrors.ErrTagNotFound
+		}
+
+		for _, tag := range from {
+			if tag == to || tags[tag] == nil {
+				continue
+			}
+			if _, err := s.moneyFlowRepo.RenameTag(txCtx, userID, tag, to); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to merge tags", 500)
+			}
+		}
+
+		merged, err := s.usage(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		usage = merged[to]
+		return nil // Commit transaction
+	})
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+// usage returns the user's tags by name
+func (s *TagService) usage(ctx context.Context, userID uuid.UUID) (map[string]*domain.TagUsage, error) {
+	usage, err := s.moneyFlowRepo.GetTagUsage(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list tags", 500)
+	}
+
+	tags := make(map[string]*domain.TagUsage, len(usage))
+	for _, tag := range usage {
+		tags[tag.Tag] = tag
+	}
+	return tags, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/metrics"
+	"github.com/ingunawandra/catetin/internal/repository"
+)
+
+// Security artifacts purged by TokenCleanupService, used as the artifact metric label
+const (
+	artifactRefreshTokens      = "refresh_tokens"
+	artifactInvitationTokens   = "invitation_tokens"
+	artifactPasswordResets     = "password_resets"
+	artifactShareLinks         = "share_links"
+	artifactPhoneVerifications = "phone_verifications"
+)
+
+// TokenCleanupConfig holds the token cleanup settings
+type TokenCleanupConfig struct {
+	// Interval is how often the cleanup runs
+	Interval time.Duration
+
+	// BatchSize is the maximum number of rows purged per statement, keeping each
+	// statement's locks short
+	BatchSize int
+
+	// Retention is how long expired and revoked tokens are kept, e.g. so recently
+	// ended sessions are still listed
+	Retention time.Duration
+}
+
+// TokenCleanupMetrics counts the purged security artifacts. A nil *TokenCleanupMetrics
+// records nothing.
+type TokenCleanupMetrics struct {
+	purged   *metrics.CounterVec
+	failures *metrics.CounterVec
+}
+
+// NewTokenCleanupMetrics registers the token cleanup metrics on the registry
+func NewTokenCleanupMetrics(registry *metrics.Registry) *TokenCleanupMetrics {
+	return &TokenCleanupMetrics{
+		purged: registry.NewCounterVec("catetin_token_cleanup_purged_total",
+			"Expired or revoked security artifacts purged, by artifact.",
+			"artifact"),
+		failures: registry.NewCounterVec("catetin_token_cleanup_failures_total",
+			"Cleanup runs that failed, by artifact.",
+			"artifact"),
+	}
+}
+
+func (m *TokenCleanupMetrics) purge(artifact string, count int64) {
+	if m == nil || count == 0 {
+		return
+	}
+	m.purged.Add(float64(count), artifact)
+}
+
+func (m *TokenCleanupMetrics) failure(artifact string) {
+	if m == nil {
+		return
+	}
+	m.failures.Inc(artifact)
+}
+
+// tokenPurger deletes up to limit artifacts that became unusable before the given time
+type tokenPurger func(ctx context.Context, before time.Time, limit int) (int64, error)
+
+// TokenCleanupService periodically purges security artifacts that can no longer be
+// used, keeping the auth tables and their indexes small
+type TokenCleanupService struct {
+	purgers map[string]tokenPurger
+	metrics *TokenCleanupMetrics
+	config  TokenCleanupConfig
+}
+
+// NewTokenCleanupService creates a new token cleanup service
+func NewTokenCleanupService(
+	refreshTokenRepo repository.RefreshTokenRepository,
+	invitationRepo repository.InvitationRepository,
+	resetRepo repository.PasswordResetRepository,
+	shareRepo repository.MoneyFlowShareRepository,
+	phoneVerificationRepo repository.PhoneVerificationRepository,
+	metrics *TokenCleanupMetrics,
+	config TokenCleanupConfig,
+) *TokenCleanupService {
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1000
+	}
+	if config.Retention < 0 {
+		config.Retention = 0
+	}
+
+	return &TokenCleanupService{
+		purgers: map[string]tokenPurger{
+			artifactRefreshTokens:      refreshTokenRepo.DeleteInactive,
+			artifactInvitationTokens:   invitationRepo.ClearTokens,
+			artifactPasswordResets:     resetRepo.DeleteExpired,
+			artifactShareLinks:         shareRepo.DeleteExpired,
+			artifactPhoneVerifications: phoneVerificationRepo.DeleteExpired,
+		},
+		metrics: metrics,
+		config:  config,
+	}
+}
+
+// Run purges expired security artifacts every interval until the context is cancelled
+func (s *TokenCleanupService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.Cleanup(ctx)
+	}
+}
+
+// Cleanup purges every artifact that became unusable more than the retention ago and
+// returns the number purged per artifact. Failures are logged, so one artifact does
+// not hold up the others.
+func (s *TokenCleanupService) Cleanup(ctx context.Context) map[string]int64 {
+	log := logger.FromContext(ctx).With("component", "token_cleanup")
+	before := time.Now().Add(-s.config.Retention)
+
+	purged := make(map[string]int64, len(s.purgers))
+	for artifact, purge := range s.purgers {
+		for {
+			count, err := purge(ctx, before, s.config.BatchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.metrics.failure(artifact)
+					log.Warn("failed to purge expired security artifacts", "artifact", artifact, "error", err)
+				}
+				break
+			}
+			purged[artifact] += count
+			s.metrics.purge(artifact, count)
+			if count < int64(s.config.BatchSize) {
+				break
+			}
+		}
+		if purged[artifact] > 0 {
+			log.Info("expired security artifacts purged", "artifact", artifact, "count", purged[artifact])
+		}
+	}
+	return purged
+}
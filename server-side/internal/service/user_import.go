@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MaxUserImportRows is the maximum number of data rows in a user import
+const MaxUserImportRows = 1000
+
+// User import row statuses
+const (
+	UserImportCreated   = "created"   // account created and invitation queued
+	UserImportExisting  = "existing"  // email or phone already belongs to a user or invitation
+	UserImportDuplicate = "duplicate" // repeats the email or phone of an earlier row
+	UserImportInvalid   = "invalid"   // failed validation; see Errors
+)
+
+// userImportColumns lists the accepted headers of each column, compared case-insensitively
+var userImportColumns = map[string][]string{
+	"name":  {"name", "full_name"},
+	"email": {"email"},
+	"phone": {"phone", "phone_number"},
+}
+
+// UserImportRow is the outcome of one data row
+type UserImportRow struct {
+	Line        int // line number in the file, the header being line 1
+	Status      string
+	FullName    string
+	Email       string
+	PhoneNumber string
+
+	// UserID is the created or existing user; nil for duplicate and invalid rows
+	UserID *uuid.UUID
+
+	// Channel is the channel the invitation is sent on; set for created rows
+	Channel string
+
+	Errors map[string]string
+}
+
+// UserImportResult is the outcome of a user import
+type UserImportResult struct {
+	Rows       []*UserImportRow
+	Created    int
+	Existing   int
+	Duplicates int
+	Invalid    int
+}
+
+// ImportUsers creates an account for each row of a CSV file with name, email, and an
+// optional phone column, and queues an invitation to set a password. Invitations are
+// sent by WhatsApp to rows with a phone number when WhatsApp is configured, otherwise
+// by email.
+//
+// Rows whose email or phone number is already known are reported as existing and left
+// untouched, so a file can be imported again, e.g. after fixing invalid rows or an
+// interrupted import. Each account is created in its own transaction.
+func (s *InvitationService) ImportUsers(ctx context.Context, adminID uuid.UUID, file io.Reader) (result *UserImportResult, err error) {
+	ctx, span := tracing.Start(ctx, "InvitationService.ImportUsers")
+	defer func() { tracing.End(span, err) }()
+
+	if len(s.senders) == 0 {
+		return nil, appErrors.ErrInvitationUnavailable
+	}
+
+	provider, err := s.authProviderRepo.FindByName(ctx, EmailPasswordProviderName)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find auth provider", 500)
+	}
+	if provider == nil {
+		return nil, appErrors.New(appErrors.ErrCodeInternal, "Authentication provider not configured", 500)
+	}
+
+	rows, err := parseUserImport(file)
+	if err != nil {
+		return nil, err
+	}
+	result = &UserImportResult{Rows: rows}
+
+	seen := make(map[string]int, 2*len(rows))
+	for _, row := range rows {
+		if row.Status == UserImportInvalid {
+			continue
+		}
+
+		if line, ok := firstSeen(seen, row); ok {
+			row.Status = UserImportDuplicate
+			row.Errors = map[string]string{"row": fmt.Sprintf("repeats line %d", line)}
+			continue
+		}
+
+		row.Channel = s.invitationChannel(row)
+		if row.Channel == "" {
+			row.Status = UserImportInvalid
+			row.Errors = map[string]string{"phone": "is required to send the invitation by WhatsApp"}
+			continue
+		}
+
+		existing, err := s.findExisting(ctx, row, provider.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			row.Status = UserImportExisting
+			row.UserID = existing
+			row.Channel = ""
+			continue
+		}
+
+		if err := s.createInvitedUser(ctx, adminID, row); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, row := range rows {
+		switch row.Status {
+		case UserImportCreated:
+			result.Created++
+		case UserImportExisting:
+			result.Existing++
+		case UserImportDuplicate:
+			result.Duplicates++
+		case UserImportInvalid:
+			result.Invalid++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("import.rows", len(rows)),
+		attribute.Int("import.created", result.Created),
+	)
+	return result, nil
+}
+
+// firstSeen returns the line of an earlier row with the same email or phone number,
+// and records the row otherwise
+func firstSeen(seen map[string]int, row *UserImportRow) (int, bool) {
+	keys := []string{"email:" + strings.ToLower(row.Email)}
+	if row.PhoneNumber != "" {
+		keys = append(keys, "phone:"+row.PhoneNumber)
+	}
+
+	for _, key := range keys {
+		if line, ok := seen[key]; ok {
+			return line, true
+		}
+	}
+	for _, key := range keys {
+		seen[key] = row.Line
+	}
+	return 0, false
+}
+
+// invitationChannel picks WhatsApp for rows with a phone number, then email; empty
+// when no configured channel can reach the row
+func (s *InvitationService) invitationChannel(row *UserImportRow) string {
+	if _, ok := s.senders[domain.ChannelWhatsApp]; ok && row.PhoneNumber != "" {
+		return domain.ChannelWhatsApp
+	}
+	if _, ok := s.senders[domain.ChannelEmail]; ok {
+		return domain.ChannelEmail
+	}
+	return ""
+}
+
+// findExisting returns the ID of a user who already has the row's email, as a
+// credential or an invitation, or its phone number
+func (s *InvitationService) findExisting(ctx context.Context, row *UserImportRow, providerID uuid.UUID) (*uuid.UUID, error) {
+	invitation, err := s.invitationRepo.FindByEmail(ctx, row.Email)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check existing invitation", 500)
+	}
+	if invitation != nil {
+		return &invitation.UserID, nil
+	}
+
+	userAuth, err := s.userAuthRepo.FindByCredentialID(ctx, row.Email, providerID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check existing email", 500)
+	}
+	if userAuth != nil {
+		return &userAuth.UserID, nil
+	}
+
+	user, err := s.userRepo.FindByPhoneNumber(ctx, importPhoneNumber(row))
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check phone number", 500)
+	}
+	if user != nil {
+		return &user.ID, nil
+	}
+	return nil, nil
+}
+
+// createInvitedUser creates the user and invitation of a row and queues sending the
+// invitation in the same transaction
+func (s *InvitationService) createInvitedUser(ctx context.Context, adminID uuid.UUID, row *UserImportRow) error {
+	user := domain.NewUser(row.FullName, importPhoneNumber(row))
+	invitation := domain.NewInvitation(user.ID, adminID, row.Email, row.Channel, s.config.TTL)
+
+	err := s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.Create(txCtx, user); err != nil {
+			return err
+		}
+		if err := s.invitationRepo.Create(txCtx, invitation); err != nil {
+			return err
+		}
+		_, err := s.jobs.Enqueue(txCtx, JobSendInvitation, map[string]string{
+			"invitation_id": invitation.ID.String(),
+		})
+		return err
+	})
+
+	switch {
+	case err == nil:
+		row.Status = UserImportCreated
+		row.UserID = &user.ID
+		return nil
+	case errors.Is(err, domain.ErrDuplicatePhoneNumber), errors.Is(err, domain.ErrConflict):
+		// Created concurrently, e.g. by another import of the same file
+		row.Status = UserImportExisting
+		row.Channel = ""
+		return nil
+	default:
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create user", 500)
+	}
+}
+
+// importPhoneNumber returns the phone number to store for a row. Like registration,
+// users without one get their email as a unique placeholder.
+func importPhoneNumber(row *UserImportRow) string {
+	if row.PhoneNumber != "" {
+		return row.PhoneNumber
+	}
+	return row.Email
+}
+
+// parseUserImport reads the rows of a user import. Problems with the file as a whole
+// are returned as validation errors; problems with a row are recorded on the row.
+func parseUserImport(file io.Reader) ([]*UserImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // short rows are reported per row
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "must be a CSV file with a header row",
+		})
+	}
+
+	columns := map[string]int{"name": -1, "email": -1, "phone": -1}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // byte order mark written by spreadsheets
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		for column, aliases := range userImportColumns {
+			for _, alias := range aliases {
+				if name == alias && columns[column] < 0 {
+					columns[column] = i
+				}
+			}
+		}
+	}
+
+	details := map[string]interface{}{}
+	for _, column := range []string{"name", "email"} {
+		if columns[column] < 0 {
+			details[column] = "column not found in the header"
+		}
+	}
+	if len(details) > 0 {
+		return nil, appErrors.ErrValidation.WithDetails(details)
+	}
+
+	var rows []*UserImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err == nil && isBlank(record) {
+			continue
+		}
+
+		if len(rows) == MaxUserImportRows {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"file": fmt.Sprintf("must have at most %d rows", MaxUserImportRows),
+			})
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+					"file": "could not be read",
+				})
+			}
+			rows = append(rows, &UserImportRow{
+				Line:   parseErr.StartLine,
+				Status: UserImportInvalid,
+				Errors: map[string]string{"row": parseErr.Err.Error()},
+			})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseUserImportRow(line, record, columns))
+	}
+
+	if len(rows) == 0 {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"file": "has no rows",
+		})
+	}
+	return rows, nil
+}
+
+// parseUserImportRow validates a record
+func parseUserImportRow(line int, record []string, columns map[string]int) *UserImportRow {
+	field := func(column string) string {
+		index := columns[column]
+		if index < 0 || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	row := &UserImportRow{
+		Line:     line,
+		FullName: field("name"),
+		Email:    field("email"),
+	}
+	errs := map[string]string{}
+
+	if length := utf8.RuneCountInString(row.FullName); length < 2 || length > 100 {
+		errs["name"] = "must be between 2 and 100 characters"
+	}
+
+	if address, err := mail.ParseAddress(row.Email); err != nil || address.Address != row.Email || len(row.Email) > 255 {
+		errs["email"] = fmt.Sprintf("%q is not an email address", row.Email)
+	}
+
+	if phone := field("phone"); phone != "" {
+		row.PhoneNumber = normalizePhoneNumber(phone)
+		if length := len(row.PhoneNumber); length < 6 || length > 20 || strings.Trim(row.PhoneNumber, "0123456789") != "" {
+			errs["phone"] = fmt.Sprintf("%q is not a phone number", phone)
+		}
+	}
+
+	if len(errs) > 0 {
+		row.Status = UserImportInvalid
+		row.Errors = errs
+	}
+	return row
+}
+
+// normalizePhoneNumber removes the leading plus sign and separators, leaving the
+// digits in international format as WhatsApp reports them, e.g. 6281234567890
+func normalizePhoneNumber(phone string) string {
+	phone = strings.TrimPrefix(strings.TrimSpace(phone), "+")
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, phone)
+}
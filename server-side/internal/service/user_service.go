@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// UserService handles user profile business logic
+type UserService struct {
+	userRepo         repository.UserRepository
+	userAuthRepo     repository.UserAuthRepository
+	authProviderRepo repository.AuthProviderRepository
+	moneyFlowRepo    repository.MoneyFlowRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	apiKeyRepo       repository.APIKeyRepository
+	settingsRepo     repository.UserSettingsRepository
+	txManager        repository.TransactionManager
+	residency        *DataResidencyService
+}
+
+// NewUserService creates a new user service
+func NewUserService(
+	userRepo repository.UserRepository,
+	userAuthRepo repository.UserAuthRepository,
+	authProviderRepo repository.AuthProviderRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	settingsRepo repository.UserSettingsRepository,
+	txManager repository.TransactionManager,
+	residency *DataResidencyService,
+) *UserService {
+	return &UserService{
+		userRepo:         userRepo,
+		userAuthRepo:     userAuthRepo,
+		authProviderRepo: authProviderRepo,
+		moneyFlowRepo:    moneyFlowRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		apiKeyRepo:       apiKeyRepo,
+		settingsRepo:     settingsRepo,
+		txManager:        txManager,
+		residency:        residency,
+	}
+}
+
+// Profile represents a user together with their login email
+type Profile struct {
+	User  *domain.User
+	Email string
+
+	// DataRegion is the region the user's files are stored in
+	DataRegion string
+}
+
+// UpdateProfileInput holds the optional fields of a profile update.
+// Nil fields are left unchanged. Version, when set, must match the stored version.
+type UpdateProfileInput struct {
+	FullName    *string
+	PhoneNumber *string
+	Image       *string
+	DataRegion  *string // empty selects the default region
+	Version     *int
+}
+
+// UpdateSettingsInput holds the optional fields of a settings update.
+// Nil fields are left unchanged. Version, when set, must match the stored version.
+type UpdateSettingsInput struct {
+	AnalyticsOptOut    *bool
+	DefaultCurrency    *string
+	SingleCurrencyMode *bool
+	Locale             *string
+	Timezone           *string
+	WeekStart          *time.Weekday
+	NotifyWhatsApp     *bool
+	NotifyEmail        *bool
+	NotifyTelegram     *bool
+	NotifyPush         *bool
+	TelegramChatID     *string
+	DigestFrequency    *string
+	DuplicateCheck     *string
+	MonthlyStatement   *bool
+	Channels           *[]string // order of notification channels; empty restores the default
+	Version            *int
+}
+
+// GetProfile returns the profile of a user
+func (s *UserService) GetProfile(ctx context.Context, userID uuid.UUID) (*Profile, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := findEmail(ctx, s.userAuthRepo, s.authProviderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Profile{
+		User:       user,
+		Email:      email,
+		DataRegion: s.residency.Region(user),
+	}, nil
+}
+
+// UpdateProfile updates the full name, phone number, image, and data region of a user.
+// Changing the data region queues moving the user's files into the new region.
+func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, input UpdateProfileInput) (*Profile, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateProfile")
+	defer span.End()
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Version != nil && *input.Version != user.Version {
+		return nil, appErrors.ErrVersionConflict
+	}
+
+	if input.PhoneNumber != nil && *input.PhoneNumber != user.PhoneNumber {
+		existing, err := s.userRepo.FindByPhoneNumber(ctx, *input.PhoneNumber)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check phone number", 500)
+		}
+		if existing != nil && existing.ID != user.ID {
+			return nil, appErrors.ErrPhoneNumberTaken
+		}
+		user.PhoneNumber = *input.PhoneNumber
+	}
+
+	if input.FullName != nil {
+		user.FullName = *input.FullName
+	}
+
+	if input.Image != nil {
+		if *input.Image == "" {
+			user.Image = nil
+		} else {
+			image := *input.Image
+			user.Image = &image
+		}
+	}
+
+	previousRegion := s.residency.Region(user)
+	if input.DataRegion != nil {
+		if err := s.residency.ValidateRegion(*input.DataRegion); err != nil {
+			return nil, err
+		}
+		user.DataRegion = *input.DataRegion
+	}
+
+	// Repository update matches on the previous version (optimistic locking)
+	user.IncrementVersion()
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrConflict):
+			return nil, appErrors.ErrVersionConflict
+		case errors.Is(err, domain.ErrDuplicatePhoneNumber):
+			return nil, appErrors.ErrPhoneNumberTaken
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update user", 500)
+	}
+
+	// The preference is saved either way; storage relocate picks up files left behind
+	if region := s.residency.Region(user); region != previousRegion {
+		if err := s.residency.ScheduleRelocation(ctx, userID); err != nil {
+			logger.FromContext(ctx).Warn("failed to schedule file relocation", "user_id", userID, "region", region, "error", err)
+		}
+	}
+
+	email, err := findEmail(ctx, s.userAuthRepo, s.authProviderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Profile{
+		User:       user,
+		Email:      email,
+		DataRegion: s.residency.Region(user),
+	}, nil
+}
+
+// DeleteAccount soft deletes the user together with their credentials and money flows
+// in a single transaction. Deleted credentials can no longer be used to log in, and
+// outstanding refresh tokens and API keys are revoked.
+func (s *UserService) DeleteAccount(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteAccount")
+	defer span.End()
+
+	if _, err := s.findUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		now := time.Now()
+
+		if err := s.moneyFlowRepo.DeleteByUserID(txCtx, userID); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete money flows", 500)
+		}
+
+		if err := s.userAuthRepo.DeleteByUserID(txCtx, userID); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete user auths", 500)
+		}
+
+		if err := s.refreshTokenRepo.RevokeAllByUserID(txCtx, userID, now); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke refresh tokens", 500)
+		}
+
+		if err := s.apiKeyRepo.RevokeAllByUserID(txCtx, userID, now); err != nil {
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to revoke API keys", 500)
+		}
+
+		if err := s.userRepo.Delete(txCtx, userID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrUserNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete user", 500)
+		}
+
+		return nil
+	})
+}
+
+// GetSettings returns the settings of a user, or the defaults if none were saved
+func (s *UserService) GetSettings(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.DefaultUserSettings(userID), nil
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user settings", 500)
+	}
+	return settings, nil
+}
+
+// UserLocale returns the locale of a user's settings, which API messages are localized
+// into unless the client asks for a language
+func (s *UserService) UserLocale(ctx context.Context, userID uuid.UUID) (string, error) {
+	settings, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return settings.Locale, nil
+}
+
+// UpdateSettings updates the preferences of a user
+func (s *UserService) UpdateSettings(ctx context.Context, userID uuid.UUID, input UpdateSettingsInput) (*domain.UserSettings, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateSettings")
+	defer span.End()
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	exists := err == nil
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user settings", 500)
+		}
+		settings = domain.DefaultUserSettings(userID)
+	}
+
+	if input.Version != nil && *input.Version != settings.Version {
+		return nil, appErrors.ErrVersionConflict
+	}
+
+	if input.AnalyticsOptOut != nil {
+		settings.AnalyticsOptOut = *input.AnalyticsOptOut
+	}
+	if input.DefaultCurrency != nil {
+		settings.DefaultCurrency = *input.DefaultCurrency
+	}
+	if input.SingleCurrencyMode != nil {
+		settings.SingleCurrencyMode = *input.SingleCurrencyMode
+	}
+	if input.Locale != nil {
+		settings.Locale = *input.Locale
+	}
+	if input.Timezone != nil {
+		if _, err := time.LoadLocation(*input.Timezone); err != nil {
+			return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+				"timezone": "must be an IANA time zone such as Asia/Jakarta",
+			})
+		}
+		settings.Timezone = *input.Timezone
+	}
+	if input.WeekStart != nil {
+		settings.WeekStart = *input.WeekStart
+	}
+	if input.NotifyWhatsApp != nil {
+		settings.NotifyWhatsApp = *input.NotifyWhatsApp
+	}
+	if input.NotifyEmail != nil {
+		settings.NotifyEmail = *input.NotifyEmail
+	}
+	if input.NotifyTelegram != nil {
+		settings.NotifyTelegram = *input.NotifyTelegram
+	}
+	if input.NotifyPush != nil {
+		settings.NotifyPush = *input.NotifyPush
+	}
+	if input.TelegramChatID != nil {
+		settings.TelegramChatID = strings.TrimSpace(*input.TelegramChatID)
+	}
+	if input.DigestFrequency != nil {
+		settings.DigestFrequency = *input.DigestFrequency
+	}
+	if input.DuplicateCheck != nil {
+		settings.DuplicateCheck = *input.DuplicateCheck
+	}
+	if input.MonthlyStatement != nil {
+		settings.MonthlyStatement = *input.MonthlyStatement
+	}
+	if input.Channels != nil {
+		settings.NotificationChannels = *input.Channels
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+
+	if exists {
+		// Repository update matches on the previous version (optimistic locking)
+		settings.IncrementVersion()
+		err = s.settingsRepo.Update(ctx, settings)
+	} else {
+		err = s.settingsRepo.Create(ctx, settings)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, appErrors.ErrVersionConflict
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update user settings", 500)
+	}
+
+	return settings, nil
+}
+
+// UserRole returns the authorization role of a user
+func (s *UserService) UserRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if user.IsDisabled() {
+		return "", appErrors.ErrAccountDisabled
+	}
+	return user.Role, nil
+}
+
+// CheckTokenVersion returns ErrTokenRevoked unless an access token issued to the user at
+// version is still valid: the user exists and has not had their tokens revoked since
+func (s *UserService) CheckTokenVersion(ctx context.Context, userID uuid.UUID, version int) error {
+	current, err := s.userRepo.FindTokenVersion(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return appErrors.ErrTokenRevoked
+		}
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to check token version", 500)
+	}
+	if version != current {
+		return appErrors.ErrTokenRevoked
+	}
+	return nil
+}
+
+func (s *UserService) findUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrUserNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user", 500)
+	}
+	return user, nil
+}
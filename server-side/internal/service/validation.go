@@ -0,0 +1,30 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// validationError converts a *domain.ValidationError into ErrValidation. Its
+// "validation_errors" detail has the shape of the one of request binding errors, so
+// clients handle a broken business rule like an invalid request field.
+func validationError(err error) error {
+	var invalid *domain.ValidationError
+	if !errors.As(err, &invalid) {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to validate input", 500)
+	}
+
+	fields := make(map[string]interface{}, len(invalid.Fields))
+	for _, field := range invalid.Fields {
+		fieldError := map[string]string{"code": field.Code, "message": field.Message}
+		if field.Param != "" {
+			fieldError["param"] = field.Param
+		}
+		fields[field.Field] = fieldError
+	}
+	return appErrors.ErrValidation.WithDetails(map[string]interface{}{
+		"validation_errors": fields,
+	})
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/repository"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// WalletService handles wallet business logic
+type WalletService struct {
+	walletRepo    repository.WalletRepository
+	moneyFlowRepo repository.MoneyFlowRepository
+	settingsRepo  repository.UserSettingsRepository
+	auditor       *Auditor
+	txManager     repository.TransactionManager
+}
+
+// NewWalletService creates a new wallet service
+func NewWalletService(
+	walletRepo repository.WalletRepository,
+	moneyFlowRepo repository.MoneyFlowRepository,
+	settingsRepo repository.UserSettingsRepository,
+	auditor *Auditor,
+	txManager repository.TransactionManager,
+) *WalletService {
+	return &WalletService{
+		walletRepo:    walletRepo,
+		moneyFlowRepo: moneyFlowRepo,
+		settingsRepo:  settingsRepo,
+		auditor:       auditor,
+		txManager:     txManager,
+	}
+}
+
+// WalletInput holds the fields of a wallet that can be changed after creation.
+// The currency of a wallet is set when it is created and cannot be changed.
+type WalletInput struct {
+	Name           string
+	Type           string
+	OpeningBalance float64
+}
+
+// WalletStatus represents a wallet together with its balance, in minor units of its currency
+type WalletStatus struct {
+	Wallet  *domain.Wallet
+	Balance int64
+	Inflow  int64
+	Outflow int64
+	Count   int64
+}
+
+// TransferInput holds the fields of a transfer between two wallets of a user.
+// ToAmount is the amount received, required between wallets of different currencies.
+type TransferInput struct {
+	FromWalletID uuid.UUID
+	ToWalletID   uuid.UUID
+	Amount       float64
+	ToAmount     float64
+	Description  *string
+}
+
+// Transfer represents the two money flows of a transfer between wallets
+type Transfer struct {
+	ID  uuid.UUID
+	Out *domain.MoneyFlow
+	In  *domain.MoneyFlow
+}
+
+// Create creates a wallet. The currency defaults to the user's default currency.
+func (s *WalletService) Create(ctx context.Context, userID uuid.UUID, currency string, input WalletInput) (*WalletStatus, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.Create")
+	defer span.End()
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		settings, err = domain.DefaultUserSettings(userID), nil
+	}
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find user settings", 500)
+	}
+	if currency == "" {
+		currency = settings.DefaultCurrency
+	}
+	if err := checkCurrency(settings, currency); err != nil {
+		return nil, err
+	}
+
+	wallet, err := domain.NewWallet(userID, input.Name, input.Type, currency, input.OpeningBalance)
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.walletRepo.Create(txCtx, wallet); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrWalletAlreadyExists
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create wallet", 500)
+		}
+		return s.auditor.Record(txCtx, userID, nil, walletAudit(wallet))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WalletStatus{Wallet: wallet, Balance: wallet.OpeningBalance}, nil
+}
+
+// List returns the user's wallets with their balances, ordered by name
+func (s *WalletService) List(ctx context.Context, userID uuid.UUID) ([]*WalletStatus, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.List")
+	defer span.End()
+
+	wallets, err := s.walletRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list wallets", 500)
+	}
+
+	totals, err := s.totals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*WalletStatus, len(wallets))
+	for i, wallet := range wallets {
+		statuses[i] = walletStatus(wallet, totals[wallet.ID])
+	}
+	return statuses, nil
+}
+
+// Get returns a wallet owned by the user with its balance
+func (s *WalletService) Get(ctx context.Context, userID, id uuid.UUID) (*WalletStatus, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.Get")
+	defer span.End()
+
+	wallet, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.totals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletStatus(wallet, totals[wallet.ID]), nil
+}
+
+// Update renames a wallet or changes its type or opening balance using optimistic locking
+func (s *WalletService) Update(ctx context.Context, userID, id uuid.UUID, version int, input WalletInput) (*WalletStatus, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.Update")
+	defer span.End()
+
+	wallet, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if wallet.Version != version {
+		return nil, appErrors.ErrVersionConflict
+	}
+	before := walletAudit(wallet)
+
+	if err := wallet.Rename(input.Name, input.Type); err != nil {
+		return nil, validationError(err)
+	}
+	if err := wallet.SetOpeningBalance(input.OpeningBalance); err != nil {
+		return nil, validationError(err)
+	}
+	if err := wallet.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+	if err := s.checkNameAvailable(ctx, wallet); err != nil {
+		return nil, err
+	}
+	wallet.IncrementVersion()
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.walletRepo.Update(txCtx, wallet); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				return appErrors.ErrVersionConflict
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update wallet", 500)
+		}
+		return s.auditor.Record(txCtx, userID, before, walletAudit(wallet))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, userID, wallet.ID)
+}
+
+// Delete deletes a wallet owned by the user. Wallets with money flows cannot be deleted.
+func (s *WalletService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "WalletService.Delete")
+	defer span.End()
+
+	wallet, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	totals, err := s.totals(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if total := totals[wallet.ID]; total != nil && total.Count > 0 {
+		return appErrors.ErrWalletNotEmpty.WithDetails(map[string]interface{}{
+			"money_flows": total.Count,
+		})
+	}
+
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.walletRepo.Delete(txCtx, wallet.ID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return appErrors.ErrResourceNotFound
+			}
+			return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete wallet", 500)
+		}
+		return s.auditor.Record(txCtx, userID, walletAudit(wallet), nil)
+	})
+}
+
+// Transfer moves money between two wallets of the user, recording a money flow out of
+// one and into the other in a single transaction. Transfers are not expenses and are
+// left out of summaries, reports, and budgets.
+func (s *WalletService) Transfer(ctx context.Context, userID uuid.UUID, input TransferInput) (*Transfer, error) {
+	ctx, span := tracing.Start(ctx, "WalletService.Transfer")
+	defer span.End()
+
+	from, err := s.findTransferWallet(ctx, userID, input.FromWalletID, "from_wallet_id")
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findTransferWallet(ctx, userID, input.ToWalletID, "to_wallet_id")
+	if err != nil {
+		return nil, err
+	}
+
+	out, in, err := domain.NewTransfer(from, to, input.Amount, input.ToAmount, input.Description)
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	err = s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		for _, moneyFlow := range []*domain.MoneyFlow{out, in} {
+			if err := s.moneyFlowRepo.Create(txCtx, moneyFlow); err != nil {
+				return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create transfer", 500)
+			}
+			if err := s.auditor.Record(txCtx, userID, nil, moneyFlowAudit(moneyFlow)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transfer{ID: *out.TransferID, Out: out, In: in}, nil
+}
+
+// totals returns the sums of the user's money flows by wallet
+func (s *WalletService) totals(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]*domain.WalletTotal, error) {
+	totals, err := s.moneyFlowRepo.GetTotalsByWallet(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to calculate wallet balances", 500)
+	}
+
+	byWallet := make(map[uuid.UUID]*domain.WalletTotal, len(totals))
+	for _, total := range totals {
+		// Money flows are kept in the wallet's currency, so there is one total per wallet
+		byWallet[total.WalletID] = total
+	}
+	return byWallet, nil
+}
+
+// checkNameAvailable rejects renaming a wallet to the name of another wallet of the user,
+// so a conflict on update can be reported as a version conflict
+func (s *WalletService) checkNameAvailable(ctx context.Context, wallet *domain.Wallet) error {
+	wallets, err := s.walletRepo.FindByUserID(ctx, wallet.UserID)
+	if err != nil {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list wallets", 500)
+	}
+	for _, other := range wallets {
+		if other.ID != wallet.ID && strings.EqualFold(other.Name, wallet.Name) {
+			return appErrors.ErrWalletAlreadyExists
+		}
+	}
+	return nil
+}
+
+func (s *WalletService) findTransferWallet(ctx context.Context, userID, id uuid.UUID, field string) (*domain.Wallet, error) {
+	wallet, err := s.findOwned(ctx, userID, id)
+	if errors.Is(err, appErrors.ErrResourceNotFound) {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			field: "wallet not found",
+		})
+	}
+	return wallet, err
+}
+
+func (s *WalletService) findOwned(ctx context.Context, userID, id uuid.UUID) (*domain.Wallet, error) {
+	wallet, err := s.walletRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find wallet", 500)
+	}
+
+	// Do not leak the existence of other users' wallets
+	if wallet.UserID != userID {
+		return nil, appErrors.ErrResourceNotFound
+	}
+
+	return wallet, nil
+}
+
+func walletStatus(wallet *domain.Wallet, total *domain.WalletTotal) *WalletStatus {
+	status := &WalletStatus{Wallet: wallet, Balance: wallet.Balance(total)}
+	if total != nil {
+		status.Inflow, status.Outflow, status.Count = total.Inflow, total.Outflow, total.Count
+	}
+	return status
+}
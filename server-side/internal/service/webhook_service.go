@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/events"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"github.com/ingunawandra/catetin/internal/infrastructure/webhook"
+	"github.com/ingunawandra/catetin/internal/repository"
+	"github.com/ingunawandra/catetin/internal/worker"
+	appErrors "github.com/ingunawandra/catetin/pkg/errors"
+)
+
+// JobDeliverWebhook sends one event to one webhook
+const JobDeliverWebhook = "webhook.deliver"
+
+// webhookCleanupBatchSize bounds the delivery logs purged per statement
+const webhookCleanupBatchSize = 1000
+
+// WebhookConfig holds the outgoing webhook settings
+type WebhookConfig struct {
+	// MaxAttempts is how many times an event is sent before its delivery is dead-lettered
+	MaxAttempts int
+
+	// MaxPerUser is the number of webhooks a user may register
+	MaxPerUser int
+
+	// DeliveryRetention is how long delivery logs are kept
+	DeliveryRetention time.Duration
+}
+
+// WebhookInput represents the settings of a webhook a user can change
+type WebhookInput struct {
+	URL    string
+	Events []string
+	Active bool
+}
+
+// WebhookBody is the JSON document POSTed to webhooks
+type WebhookBody struct {
+	ID        uuid.UUID       `json:"id"` // the event ID, the same on every retry
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// webhookDelivery is the payload of a JobDeliverWebhook
+type webhookDelivery struct {
+	WebhookID uuid.UUID   `json:"webhook_id"`
+	Body      WebhookBody `json:"body"`
+}
+
+// WebhookService sends the events of users to the webhooks they registered, so other
+// apps can follow their money flows
+type WebhookService struct {
+	webhookRepo repository.WebhookRepository
+	jobs        JobEnqueuer
+	client      *webhook.Client
+	txManager   repository.TransactionManager
+	config      WebhookConfig
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	webhookRepo repository.WebhookRepository,
+	jobs JobEnqueuer,
+	client *webhook.Client,
+	txManager repository.TransactionManager,
+	config WebhookConfig,
+) *WebhookService {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 8
+	}
+	if config.MaxPerUser <= 0 {
+		config.MaxPerUser = 5
+	}
+	if config.DeliveryRetention <= 0 {
+		config.DeliveryRetention = 30 * 24 * time.Hour
+	}
+
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		jobs:        jobs,
+		client:      client,
+		txManager:   txManager,
+		config:      config,
+	}
+}
+
+// Create registers a new webhook with a generated signing secret
+func (s *WebhookService) Create(ctx context.Context, userID uuid.UUID, rawURL string, eventTypes []string) (*domain.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Create")
+	defer span.End()
+
+	existing, err := s.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list webhooks", 500)
+	}
+	if len(existing) >= s.config.MaxPerUser {
+		return nil, appErrors.ErrWebhookLimit
+	}
+
+	if err := s.checkURL(rawURL); err != nil {
+		return nil, err
+	}
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to generate webhook secret", 500)
+	}
+
+	hook := domain.NewWebhook(userID, secret)
+	if err := hook.SetURL(rawURL); err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"url": err.Error(),
+		})
+	}
+	if err := hook.SetEvents(eventTypes); err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"events": err.Error(),
+		})
+	}
+
+	if err := s.webhookRepo.Create(ctx, hook); err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to create webhook", 500)
+	}
+	return hook, nil
+}
+
+// List returns the webhooks of a user, oldest first
+func (s *WebhookService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.List")
+	defer span.End()
+
+	hooks, err := s.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list webhooks", 500)
+	}
+	return hooks, nil
+}
+
+// Get returns a webhook owned by the user
+func (s *WebhookService) Get(ctx context.Context, userID, id uuid.UUID) (*domain.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Get")
+	defer span.End()
+
+	return s.findOwned(ctx, userID, id)
+}
+
+// Update replaces the settings of a webhook owned by the user. Events already queued
+// for the webhook are sent to the new URL.
+func (s *WebhookService) Update(ctx context.Context, userID, id uuid.UUID, version int, input WebhookInput) (*domain.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Update")
+	defer span.End()
+
+	hook, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if hook.Version != version {
+		return nil, appErrors.ErrVersionConflict
+	}
+
+	if err := s.checkURL(input.URL); err != nil {
+		return nil, err
+	}
+	if err := hook.SetURL(input.URL); err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"url": err.Error(),
+		})
+	}
+	if err := hook.SetEvents(input.Events); err != nil {
+		return nil, appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"events": err.Error(),
+		})
+	}
+	hook.Active = input.Active
+	hook.IncrementVersion()
+
+	if err := s.webhookRepo.Update(ctx, hook); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, appErrors.ErrVersionConflict
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to update webhook", 500)
+	}
+	return hook, nil
+}
+
+// Delete deletes a webhook owned by the user together with its delivery logs. Events
+// still queued for it are dropped.
+func (s *WebhookService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.Delete")
+	defer span.End()
+
+	hook, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webhookRepo.Delete(ctx, hook.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to delete webhook", 500)
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery logs of a webhook owned by the user, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, userID, id uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ListDeliveries")
+	defer span.End()
+
+	hook, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.webhookRepo.ListDeliveries(ctx, hook.ID, limit, offset)
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to list webhook deliveries", 500)
+	}
+	return deliveries, nil
+}
+
+// Ping sends a ping event to a webhook owned by the user right away, so they can check
+// their receiver, and returns the logged delivery. A failed ping is not retried.
+func (s *WebhookService) Ping(ctx context.Context, userID, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Ping")
+	defer span.End()
+
+	hook, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"webhook_id": hook.ID})
+	if err != nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to encode ping", 500)
+	}
+	body := WebhookBody{ID: uuid.New(), Type: domain.WebhookEventPing, CreatedAt: time.Now(), Data: data}
+
+	// A failed ping is reported by the delivery, not as an error of the request
+	delivery, err := s.send(ctx, hook, body, 1)
+	if delivery == nil {
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to send ping", 500)
+	}
+	return delivery, nil
+}
+
+// HandleEvent queues sending a domain event to every webhook of its user that
+// subscribes to it. It is subscribed to the money flow events.
+func (s *WebhookService) HandleEvent(ctx context.Context, event *events.Event) error {
+	if event.UserID == nil {
+		return nil
+	}
+
+	hooks, err := s.webhookRepo.FindByUserID(ctx, *event.UserID)
+	if err != nil {
+		return err
+	}
+
+	body := WebhookBody{ID: event.ID, Type: event.Type, CreatedAt: event.OccurredAt, Data: event.Payload}
+
+	// Queued together, so a retry does not queue some webhooks twice
+	return s.txManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		for _, hook := range hooks {
+			if !hook.Subscribes(event.Type) {
+				continue
+			}
+			payload := webhookDelivery{WebhookID: hook.ID, Body: body}
+			if _, err := s.jobs.Enqueue(txCtx, JobDeliverWebhook, payload, worker.WithMaxAttempts(s.config.MaxAttempts)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// HandleDeliveryJob processes a JobDeliverWebhook. Every attempt is logged; a failed
+// attempt is retried with exponential backoff. Events for webhooks deleted, disabled,
+// or unsubscribed since they were queued are dropped.
+func (s *WebhookService) HandleDeliveryJob(ctx context.Context, job *worker.Job) error {
+	var payload webhookDelivery
+	if err := job.Decode(&payload); err != nil {
+		return worker.Permanent(err)
+	}
+
+	hook, err := s.webhookRepo.FindByID(ctx, payload.WebhookID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !hook.Subscribes(payload.Body.Type) {
+		return nil
+	}
+
+	_, err = s.send(ctx, hook, payload.Body, job.Attempts)
+	return err
+}
+
+// Run purges delivery logs older than the retention every hour until the context is
+// cancelled
+func (s *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.purgeDeliveries(ctx)
+	}
+}
+
+// purgeDeliveries deletes the delivery logs older than the retention, in batches
+func (s *WebhookService) purgeDeliveries(ctx context.Context) {
+	before := time.Now().Add(-s.config.DeliveryRetention)
+	for ctx.Err() == nil {
+		deleted, err := s.webhookRepo.DeleteDeliveriesBefore(ctx, before, webhookCleanupBatchSize)
+		if err != nil {
+			logger.FromContext(ctx).Warn("failed to purge webhook deliveries", "error", err)
+			return
+		}
+		if deleted < webhookCleanupBatchSize {
+			return
+		}
+	}
+}
+
+// send sends an event to a webhook and logs the attempt. The returned error is the
+// reason the attempt failed.
+func (s *WebhookService) send(ctx context.Context, hook *domain.Webhook, body WebhookBody, attempt int) (*domain.WebhookDelivery, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, worker.Permanent(err)
+	}
+
+	resp, sendErr := s.client.Send(ctx, webhook.Request{
+		URL:       hook.URL,
+		Secret:    hook.Secret,
+		EventType: body.Type,
+		EventID:   body.ID,
+		Body:      data,
+	})
+
+	delivery := &domain.WebhookDelivery{
+		ID:        uuid.New(),
+		WebhookID: hook.ID,
+		EventID:   body.ID,
+		EventType: body.Type,
+		Attempt:   attempt,
+		Succeeded: sendErr == nil,
+		CreatedAt: time.Now(),
+	}
+	if resp != nil {
+		delivery.StatusCode = &resp.StatusCode
+		delivery.Duration = resp.Duration
+	}
+	if sendErr != nil {
+		message := sendErr.Error()
+		delivery.Error = &message
+	}
+
+	// The log is best effort: failing to write it must not send the event again
+	if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+		logger.FromContext(ctx).Warn("failed to log webhook delivery", "webhook_id", hook.ID, "event_id", body.ID, "error", err)
+	}
+	return delivery, sendErr
+}
+
+// checkURL checks that webhooks may be sent to the URL, e.g. that it is not an
+// address on the server's network
+func (s *WebhookService) checkURL(rawURL string) error {
+	if err := s.client.CheckURL(rawURL); err != nil {
+		return appErrors.ErrValidation.WithDetails(map[string]interface{}{
+			"url": err.Error(),
+		})
+	}
+	return nil
+}
+
+// findOwned finds a webhook and checks that it belongs to the user
+func (s *WebhookService) findOwned(ctx context.Context, userID, id uuid.UUID) (*domain.Webhook, error) {
+	hook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, appErrors.ErrResourceNotFound
+		}
+		return nil, appErrors.Wrap(err, appErrors.ErrCodeInternal, "Failed to find webhook", 500)
+	}
+
+	// Do not leak the existence of other users' webhooks
+	if hook.UserID != userID {
+		return nil, appErrors.ErrResourceNotFound
+	}
+	return hook, nil
+}
@@ -0,0 +1,39 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+)
+
+// Group runs long-lived background processes and stops them together
+type Group struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+// NewGroup creates a group whose processes run with the logger of ctx
+func NewGroup(ctx context.Context) *Group {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Go starts a process that must return once its context is cancelled
+func (g *Group) Go(name string, run func(ctx context.Context)) {
+	g.running.Add(1)
+	go func() {
+		defer g.running.Done()
+		log := logger.FromContext(g.ctx)
+		log.Debug("background worker started", "worker", name)
+		run(g.ctx)
+		log.Debug("background worker stopped", "worker", name)
+	}()
+}
+
+// Stop cancels every process and waits for them to return
+func (g *Group) Stop() {
+	g.cancel()
+	g.running.Wait()
+}
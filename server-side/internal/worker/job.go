@@ -0,0 +1,173 @@
+// Package worker runs background jobs from a persistent queue and manages the
+// lifecycle of long-running background processes
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job statuses
+const (
+	// StatusPending jobs wait for their run time
+	StatusPending = "pending"
+
+	// StatusRunning jobs are claimed by a worker
+	StatusRunning = "running"
+
+	// StatusSucceeded jobs finished without error
+	StatusSucceeded = "succeeded"
+
+	// StatusDead jobs exhausted their attempts or failed permanently (the dead-letter queue)
+	StatusDead = "dead"
+)
+
+// DefaultMaxAttempts is the number of attempts of a job enqueued without WithMaxAttempts
+const DefaultMaxAttempts = 5
+
+// Job is a unit of background work. Payload is the JSON document the handler decodes.
+type Job struct {
+	ID          uuid.UUID
+	Type        string
+	Payload     json.RawMessage
+	Status      string
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	LastError   *string
+	LockedAt    *time.Time
+	FinishedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewJob creates a new pending Job that runs as soon as a worker is free
+func NewJob(jobType string, payload interface{}) (*Job, error) {
+	if jobType == "" {
+		return nil, errors.New("job type is required")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Job{
+		ID:          uuid.New(),
+		Type:        jobType,
+		Payload:     data,
+		Status:      StatusPending,
+		MaxAttempts: DefaultMaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Decode unmarshals the payload into v
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Succeed marks the job as finished
+func (j *Job) Succeed() {
+	now := time.Now()
+	j.Status = StatusSucceeded
+	j.LastError = nil
+	j.LockedAt = nil
+	j.FinishedAt = &now
+	j.UpdatedAt = now
+}
+
+// Fail records a failed attempt. The job is retried at retryAt unless it has no
+// attempts left or the error is permanent, in which case it is dead-lettered.
+func (j *Job) Fail(err error, retryAt time.Time) {
+	now := time.Now()
+	message := err.Error()
+	j.LastError = &message
+	j.LockedAt = nil
+	j.UpdatedAt = now
+
+	if j.Attempts >= j.MaxAttempts || IsPermanent(err) {
+		j.Status = StatusDead
+		j.FinishedAt = &now
+		return
+	}
+	j.Status = StatusPending
+	j.RunAt = retryAt
+}
+
+// EnqueueOption customizes a job before it is enqueued
+type EnqueueOption func(*Job)
+
+// WithDelay runs the job no earlier than the delay from now
+func WithDelay(delay time.Duration) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = time.Now().Add(delay)
+	}
+}
+
+// WithRunAt runs the job no earlier than the given time
+func WithRunAt(runAt time.Time) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = runAt
+	}
+}
+
+// WithMaxAttempts sets how many times the job is attempted before it is dead-lettered
+func WithMaxAttempts(maxAttempts int) EnqueueOption {
+	return func(j *Job) {
+		if maxAttempts > 0 {
+			j.MaxAttempts = maxAttempts
+		}
+	}
+}
+
+// Queue stores jobs and hands them out to workers. Implementations must make
+// Claim safe across processes so a job runs on one worker at a time.
+type Queue interface {
+	// Enqueue stores a new job
+	Enqueue(ctx context.Context, job *Job) error
+
+	// FindDue finds pending jobs of the given types whose run time has passed, oldest first
+	FindDue(ctx context.Context, types []string, now time.Time, limit int) ([]*Job, error)
+
+	// Claim moves a pending job to running and counts the attempt.
+	// Returns domain.ErrConflict if another worker claimed it first.
+	Claim(ctx context.Context, job *Job) error
+
+	// Update saves the outcome of an attempt
+	Update(ctx context.Context, job *Job) error
+
+	// ReleaseStale moves jobs claimed before the given time back to pending,
+	// recovering jobs whose worker crashed
+	ReleaseStale(ctx context.Context, claimedBefore time.Time) (int64, error)
+
+	// DeleteSucceeded deletes up to limit jobs that succeeded before the given time
+	DeleteSucceeded(ctx context.Context, finishedBefore time.Time, limit int) (int64, error)
+}
+
+// permanentError marks an error that retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error so the job is dead-lettered without further retries,
+// e.g. when the payload cannot be decoded
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent checks if an error was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
@@ -0,0 +1,244 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ingunawandra/catetin/internal/domain"
+	"github.com/ingunawandra/catetin/internal/infrastructure/logger"
+	"github.com/ingunawandra/catetin/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maintenanceInterval is how often stale jobs are released and old jobs deleted
+const maintenanceInterval = time.Minute
+
+// Handler processes one job. Returning an error retries the job with backoff;
+// wrap the error with Permanent to dead-letter it immediately.
+type Handler func(ctx context.Context, job *Job) error
+
+// Config holds the settings of the job runner
+type Config struct {
+	// Concurrency is the number of jobs processed at the same time
+	Concurrency int
+
+	// PollInterval is how long to wait before polling again when no job is due
+	PollInterval time.Duration
+
+	// JobTimeout bounds a single attempt
+	JobTimeout time.Duration
+
+	// StaleAfter releases jobs left running (e.g. after a crash) back to pending.
+	// It must be longer than JobTimeout.
+	StaleAfter time.Duration
+
+	// BaseBackoff is the delay before the first retry; it doubles on every attempt
+	BaseBackoff time.Duration
+
+	// MaxBackoff caps the retry delay
+	MaxBackoff time.Duration
+
+	// Retention is how long succeeded jobs are kept before deletion
+	Retention time.Duration
+}
+
+// Runner claims due jobs from the queue and runs them with their registered handlers
+type Runner struct {
+	queue    Queue
+	config   Config
+	handlers map[string]Handler
+}
+
+// NewRunner creates a new job runner
+func NewRunner(queue Queue, config Config) *Runner {
+	if config.Concurrency <= 0 {
+		config.Concurrency = 4
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.JobTimeout <= 0 {
+		config.JobTimeout = time.Minute
+	}
+	if config.StaleAfter <= config.JobTimeout {
+		config.StaleAfter = 2 * config.JobTimeout
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = 10 * time.Second
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = time.Hour
+	}
+	if config.Retention <= 0 {
+		config.Retention = 7 * 24 * time.Hour
+	}
+
+	return &Runner{
+		queue:    queue,
+		config:   config,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle registers the handler of a job type. Register every handler before Run;
+// jobs of types without a handler stay pending.
+func (r *Runner) Handle(jobType string, handler Handler) {
+	r.handlers[jobType] = handler
+}
+
+// Enqueue stores a job of the given type with a JSON-encoded payload
+func (r *Runner) Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...EnqueueOption) (*Job, error) {
+	job, err := NewJob(jobType, payload)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	if err := r.queue.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Run processes due jobs until the context is cancelled. Jobs already started are
+// given their timeout to finish; the rest stay in the queue.
+func (r *Runner) Run(ctx context.Context) {
+	if len(r.handlers) == 0 {
+		return
+	}
+
+	log := logger.FromContext(ctx).With("component", "job_runner")
+	types := make([]string, 0, len(r.handlers))
+	for jobType := range r.handlers {
+		types = append(types, jobType)
+	}
+
+	// Attempts in flight finish under their own timeout rather than the run context
+	jobCtx := logger.WithContext(context.WithoutCancel(ctx), log)
+	slots := make(chan struct{}, r.config.Concurrency)
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	lastMaintenance := time.Time{}
+	for {
+		if time.Since(lastMaintenance) >= maintenanceInterval {
+			r.maintain(ctx, log)
+			lastMaintenance = time.Now()
+		}
+
+		jobs, err := r.queue.FindDue(ctx, types, time.Now(), r.config.Concurrency)
+		if err != nil && ctx.Err() == nil {
+			log.Warn("failed to poll jobs", "error", err)
+		}
+
+		started := 0
+		for _, job := range jobs {
+			select {
+			case <-ctx.Done():
+				return
+			case slots <- struct{}{}:
+			}
+
+			if err := r.queue.Claim(ctx, job); err != nil {
+				<-slots
+				if !errors.Is(err, domain.ErrConflict) && ctx.Err() == nil {
+					log.Warn("failed to claim job", "job_id", job.ID, "error", err)
+				}
+				continue
+			}
+
+			started++
+			running.Add(1)
+			go func(job *Job) {
+				defer func() {
+					<-slots
+					running.Done()
+				}()
+				r.process(jobCtx, log, job)
+			}(job)
+		}
+
+		wait := time.Duration(0)
+		if started == 0 {
+			wait = r.config.PollInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// process runs one attempt of a claimed job and saves the outcome
+func (r *Runner) process(ctx context.Context, log *slog.Logger, job *Job) {
+	ctx, span := tracing.Start(ctx, "Job "+job.Type,
+		attribute.String("job.id", job.ID.String()),
+		attribute.Int("job.attempt", job.Attempts),
+	)
+	defer span.End()
+
+	attemptCtx, cancel := context.WithTimeout(ctx, r.config.JobTimeout)
+	err := r.run(attemptCtx, job)
+	cancel()
+	tracing.End(span, err)
+
+	if err == nil {
+		job.Succeed()
+	} else {
+		job.Fail(err, time.Now().Add(r.backoff(job.Attempts)))
+		if job.Status == StatusDead {
+			log.Error("job dead-lettered", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts, "error", err)
+		} else {
+			log.Warn("job failed, will retry", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts, "retry_at", job.RunAt, "error", err)
+		}
+	}
+
+	if err := r.queue.Update(ctx, job); err != nil {
+		// The job is released by ReleaseStale and attempted again
+		log.Warn("failed to save job result", "job_id", job.ID, "error", err)
+	}
+}
+
+// run calls the handler, turning a panic into a failed attempt
+func (r *Runner) run(ctx context.Context, job *Job) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job handler panicked: %v", recovered)
+		}
+	}()
+	return r.handlers[job.Type](ctx, job)
+}
+
+// maintain releases jobs of crashed workers and deletes old succeeded jobs
+func (r *Runner) maintain(ctx context.Context, log *slog.Logger) {
+	released, err := r.queue.ReleaseStale(ctx, time.Now().Add(-r.config.StaleAfter))
+	if err != nil && ctx.Err() == nil {
+		log.Warn("failed to release stale jobs", "error", err)
+	} else if released > 0 {
+		log.Info("released stale jobs", "count", released)
+	}
+
+	deleted, err := r.queue.DeleteSucceeded(ctx, time.Now().Add(-r.config.Retention), 1000)
+	if err != nil && ctx.Err() == nil {
+		log.Warn("failed to delete succeeded jobs", "error", err)
+	} else if deleted > 0 {
+		log.Info("deleted succeeded jobs", "count", deleted)
+	}
+}
+
+// backoff returns the delay before the next attempt, doubling from BaseBackoff
+func (r *Runner) backoff(attempts int) time.Duration {
+	delay := r.config.BaseBackoff
+	for i := 1; i < attempts && delay < r.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.config.MaxBackoff)
+}
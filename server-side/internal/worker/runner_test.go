@@ -0,0 +1,316 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ingunawandra/catetin/internal/domain"
+)
+
+// fakeQueue keeps jobs in memory and reports every saved outcome on updates
+type fakeQueue struct {
+	mu        sync.Mutex
+	jobs      map[uuid.UUID]*Job
+	conflicts map[uuid.UUID]bool // claims that another worker wins
+	released  []time.Time        // claimedBefore of every ReleaseStale
+	updates   chan Job
+}
+
+func newFakeQueue() *fakeQueue {
+	return &fakeQueue{
+		jobs:      map[uuid.UUID]*Job{},
+		conflicts: map[uuid.UUID]bool{},
+		updates:   make(chan Job, 10),
+	}
+}
+
+func (q *fakeQueue) Enqueue(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stored := *job
+	q.jobs[job.ID] = &stored
+	return nil
+}
+
+func (q *fakeQueue) FindDue(ctx context.Context, types []string, now time.Time, limit int) ([]*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var due []*Job
+	for _, job := range q.jobs {
+		for _, jobType := range types {
+			if job.Type == jobType && job.Status == StatusPending && !job.RunAt.After(now) && len(due) < limit {
+				found := *job
+				due = append(due, &found)
+			}
+		}
+	}
+	return due, nil
+}
+
+func (q *fakeQueue) Claim(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stored := q.jobs[job.ID]
+	if q.conflicts[job.ID] || stored.Status != StatusPending {
+		return domain.ErrConflict
+	}
+	now := time.Now()
+	stored.Status = StatusRunning
+	stored.Attempts++
+	stored.LockedAt = &now
+	*job = *stored
+	return nil
+}
+
+func (q *fakeQueue) Update(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	stored := *job
+	q.jobs[job.ID] = &stored
+	q.mu.Unlock()
+	q.updates <- stored
+	return nil
+}
+
+func (q *fakeQueue) ReleaseStale(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.released = append(q.released, claimedBefore)
+	return 0, nil
+}
+
+func (q *fakeQueue) DeleteSucceeded(ctx context.Context, finishedBefore time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// start runs the runner until the test ends, returning a channel closed when Run returns
+func start(t *testing.T, r *Runner) (cancel func(), stopped <-chan struct{}) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return cancel, done
+}
+
+// outcome waits for the runner to save the result of an attempt
+func (q *fakeQueue) outcome(t *testing.T) Job {
+	t.Helper()
+	select {
+	case job := <-q.updates:
+		return job
+	case <-time.After(5 * time.Second):
+		t.Fatal("no job outcome was saved")
+		return Job{}
+	}
+}
+
+func testConfig() Config {
+	return Config{
+		PollInterval: 10 * time.Millisecond,
+		JobTimeout:   time.Second,
+		BaseBackoff:  time.Minute,
+		MaxBackoff:   time.Hour,
+	}
+}
+
+func TestRunnerRunsJobs(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     Handler
+		maxAttempts int
+		wantStatus  string
+		wantError   string
+		wantRetry   bool
+	}{
+		{
+			name:       "success",
+			handler:    func(ctx context.Context, job *Job) error { return nil },
+			wantStatus: StatusSucceeded,
+		},
+		{
+			name:       "failure is retried with backoff",
+			handler:    func(ctx context.Context, job *Job) error { return errors.New("smtp timeout") },
+			wantStatus: StatusPending,
+			wantError:  "smtp timeout",
+			wantRetry:  true,
+		},
+		{
+			name:       "permanent failure is dead-lettered",
+			handler:    func(ctx context.Context, job *Job) error { return Permanent(errors.New("bad payload")) },
+			wantStatus: StatusDead,
+			wantError:  "bad payload",
+		},
+		{
+			name:        "last attempt is dead-lettered",
+			handler:     func(ctx context.Context, job *Job) error { return errors.New("smtp timeout") },
+			maxAttempts: 1,
+			wantStatus:  StatusDead,
+			wantError:   "smtp timeout",
+		},
+		{
+			name:       "panic fails the attempt",
+			handler:    func(ctx context.Context, job *Job) error { panic("nil map") },
+			wantStatus: StatusPending,
+			wantError:  "job handler panicked: nil map",
+			wantRetry:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := newFakeQueue()
+			runner := NewRunner(queue, testConfig())
+			runner.Handle("email", tt.handler)
+			job, err := runner.Enqueue(context.Background(), "email", map[string]string{"to": "budi@example.com"}, WithMaxAttempts(tt.maxAttempts))
+			if err != nil {
+				t.Fatalf("Enqueue() error = %v", err)
+			}
+
+			startedAt := time.Now()
+			start(t, runner)
+			saved := queue.outcome(t)
+
+			if saved.ID != job.ID || saved.Status != tt.wantStatus || saved.Attempts != 1 {
+				t.Fatalf("saved %s after %d attempts, want %s after 1", saved.Status, saved.Attempts, tt.wantStatus)
+			}
+			lastError := ""
+			if saved.LastError != nil {
+				lastError = *saved.LastError
+			}
+			if lastError != tt.wantError {
+				t.Errorf("last error = %q, want %q", lastError, tt.wantError)
+			}
+			if retryAt := startedAt.Add(time.Minute); tt.wantRetry && (saved.RunAt.Before(retryAt) || saved.RunAt.After(retryAt.Add(time.Second))) {
+				t.Errorf("retry at %v, want BaseBackoff after the attempt at %v", saved.RunAt, startedAt)
+			}
+			if saved.LockedAt != nil {
+				t.Errorf("job is still locked at %v", saved.LockedAt)
+			}
+		})
+	}
+}
+
+func TestRunnerSkipsJobsClaimedElsewhere(t *testing.T) {
+	queue := newFakeQueue()
+	runner := NewRunner(queue, testConfig())
+	ran := make(chan string, 2)
+	runner.Handle("email", func(ctx context.Context, job *Job) error {
+		ran <- string(job.Payload)
+		return nil
+	})
+	taken, err := runner.Enqueue(context.Background(), "email", "taken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.Enqueue(context.Background(), "email", "free"); err != nil {
+		t.Fatal(err)
+	}
+	queue.conflicts[taken.ID] = true
+
+	start(t, runner)
+	queue.outcome(t)
+	if payload := <-ran; payload != `"free"` {
+		t.Errorf("ran %s, want only the job this runner claimed", payload)
+	}
+	select {
+	case payload := <-ran:
+		t.Errorf("ran %s, which another worker claimed", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRunnerReleasesStaleJobs(t *testing.T) {
+	queue := newFakeQueue()
+	config := testConfig()
+	config.StaleAfter = 10 * time.Minute
+	runner := NewRunner(queue, config)
+	runner.Handle("email", func(ctx context.Context, job *Job) error { return nil })
+	if _, err := runner.Enqueue(context.Background(), "email", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	startedAt := time.Now()
+	start(t, runner)
+	queue.outcome(t)
+
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	if len(queue.released) != 1 {
+		t.Fatalf("ReleaseStale called %d times, want once on start", len(queue.released))
+	}
+	if want := startedAt.Add(-10 * time.Minute); queue.released[0].Before(want) || queue.released[0].After(want.Add(time.Second)) {
+		t.Errorf("released jobs claimed before %v, want StaleAfter before the start at %v", queue.released[0], startedAt)
+	}
+}
+
+func TestRunnerFinishesStartedJobsOnShutdown(t *testing.T) {
+	queue := newFakeQueue()
+	runner := NewRunner(queue, testConfig())
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	runner.Handle("email", func(ctx context.Context, job *Job) error {
+		close(started)
+		<-finish
+		return ctx.Err()
+	})
+	if _, err := runner.Enqueue(context.Background(), "email", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel, stopped := start(t, runner)
+	<-started
+	cancel()
+	select {
+	case <-stopped:
+		t.Fatal("Run returned before the started job finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(finish)
+	if saved := queue.outcome(t); saved.Status != StatusSucceeded {
+		t.Errorf("job finished as %s with %v, want it to succeed despite the shutdown", saved.Status, saved.LastError)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after the started job finished")
+	}
+}
+
+func TestRunnerWithoutHandlersReturns(t *testing.T) {
+	_, stopped := start(t, NewRunner(newFakeQueue(), testConfig()))
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run without handlers did not return")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	runner := NewRunner(newFakeQueue(), Config{BaseBackoff: 10 * time.Second, MaxBackoff: time.Minute})
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, time.Minute},
+		{50, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := runner.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
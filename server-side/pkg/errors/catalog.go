@@ -0,0 +1,126 @@
+package errors
+
+import "net/http"
+
+// docsPath serves the documentation of each error code, relative to the API's base URL
+const docsPath = "/api/v1/meta/errors/"
+
+// CodeInfo documents an error code for API clients
+type CodeInfo struct {
+	Code        ErrorCode
+	HTTPStatus  int
+	Description string
+}
+
+// DocURL returns the stable documentation URL of an error code, relative to the API's
+// base URL
+func DocURL(code ErrorCode) string {
+	return docsPath + string(code)
+}
+
+// describe documents the code of a predefined error with its status
+func describe(err *AppError, description string) CodeInfo {
+	return CodeInfo{Code: err.Code, HTTPStatus: err.HTTPStatus, Description: description}
+}
+
+// catalog documents every ErrorCode in the order they are declared. The status is the
+// one of the predefined error; a code missing here fails TestCatalogListsEveryCode.
+var catalog = []CodeInfo{
+	// General errors
+	describe(ErrInternal, "An unexpected error occurred; quote the request_id when reporting it"),
+	describe(ErrBadRequest, "The request is malformed"),
+	describe(ErrUnauthorized, "The request needs a valid access token or API key"),
+	describe(ErrForbidden, "The caller is not allowed to do this, e.g. a role without the admin permission"),
+	describe(ErrNotFound, "The resource does not exist or belongs to another user"),
+	describe(ErrConflict, "The request conflicts with the current state, e.g. an erasure that is already pending"),
+	describe(ErrValidation, "Fields of the request are invalid; validation_errors describes each of them"),
+	{Code: ErrCodeUnprocessable, HTTPStatus: http.StatusUnprocessableEntity, Description: "Reserved for valid requests that cannot be processed"},
+	describe(ErrRequestTooLarge, "The request body is larger than the server accepts; max_bytes holds the limit"),
+
+	// Authentication errors
+	describe(ErrInvalidCredentials, "The email or password is wrong"),
+	describe(ErrEmailAlreadyExists, "An account already uses the email"),
+	describe(ErrInvalidToken, "The access or refresh token is malformed, forged, or unknown"),
+	describe(ErrExpiredToken, "The access token has expired; refresh it"),
+	describe(ErrTokenRevoked, "The token was revoked by a password change, an unlinked credential, or a disabled or deleted account; sign in again"),
+	describe(ErrInvalidCurrentPassword, "The current password confirming the change is wrong"),
+	describe(ErrPasswordNotSet, "The account signs in without a password, so there is none to confirm or change"),
+	describe(ErrReauthRequired, "The action needs a recent sign-in; confirm the password with POST /api/v1/users/me/reauthenticate and retry"),
+	describe(ErrLastCredential, "The only sign-in method of an account cannot be removed"),
+	describe(ErrClientNotAllowed, "The token was issued to a client the endpoint does not accept"),
+	describe(ErrInvalidInvitation, "The invitation is unknown, expired, or already accepted"),
+	describe(ErrAccountDisabled, "An admin disabled the account; it cannot sign in or use its sessions and API keys"),
+	describe(ErrAccountLocked, "Too many wrong passwords in a row locked the email for a while; the details tell until when"),
+	describe(ErrInvalidPasswordReset, "The password reset link is unknown, expired, or already used"),
+	describe(ErrInvalidDownloadLink, "The export download link is expired or altered, or the export was deleted; request a new export"),
+	describe(ErrInvalidShareLink, "The money flow share link is expired or altered, or its owner revoked it or deleted the money flow; ask the owner for a new link"),
+
+	// Account linking errors
+	describe(ErrCredentialAlreadyLinked, "The credential is already linked to another account"),
+	describe(ErrProviderAlreadyLinked, "The account is already linked to the provider"),
+	describe(ErrUnsupportedAuthProvider, "The sign-in provider is not supported, or linking it is not configured on this server"),
+	describe(ErrTooManyVerificationCodes, "The user was sent the maximum number of phone verification codes this hour"),
+
+	// Demo mode errors
+	describe(ErrDemoDisabled, "Demo mode is not enabled on this server"),
+	describe(ErrDemoAccountRestricted, "Demo accounts cannot do this; register to keep the data"),
+
+	// API key errors
+	describe(ErrInvalidAPIKey, "The API key is unknown or revoked"),
+	describe(ErrInsufficientScope, "The API key does not grant the scope the endpoint requires"),
+
+	// Resource errors
+	describe(ErrUserNotFound, "The user does not exist"),
+	describe(ErrPhoneNumberTaken, "Another account already uses the phone number"),
+	describe(ErrResourceNotFound, "The resource does not exist"),
+	describe(ErrVersionConflict, "The resource was changed since it was read; the details carry the current version to merge with"),
+	describe(ErrPreconditionFailed, "The resource does not match the ETag sent in If-Match"),
+	describe(ErrTagNotFound, "None of the user's money flows has the tag to rename or merge"),
+	describe(ErrTagAlreadyExists, "A tag cannot be renamed to a tag already in use; merge them instead"),
+
+	// Business logic errors
+	describe(ErrInvalidInput, "A value of the request is not acceptable, e.g. an unknown log level"),
+	{Code: ErrCodeInsufficientFunds, HTTPStatus: http.StatusUnprocessableEntity, Description: "Reserved for transfers exceeding the balance of a wallet"},
+	describe(ErrOperationNotAllowed, "The operation is not allowed in the current state"),
+	describe(ErrCurrencyMismatch, "The currency differs from the default currency while single-currency mode is on"),
+	describe(ErrBudgetExceeded, "The money flow would exceed a hard category budget; resend it with override_budget to record it anyway"),
+	describe(ErrDuplicateMoneyFlow, "The money flow has the amount, day, and a similar description of one already recorded, and the user blocks duplicates; resend it with allow_duplicate to record it anyway"),
+	describe(ErrBudgetAlreadyExists, "The user already has a budget for the category"),
+	describe(ErrWalletAlreadyExists, "The user already has a wallet with the name"),
+	describe(ErrMerchantAlreadyExists, "Another merchant already has the name"),
+	describe(ErrMerchantRuleAlreadyExists, "Another merchant rule already has the pattern"),
+	describe(ErrWalletNotEmpty, "A wallet cannot be deleted while money flows are recorded in it"),
+	describe(ErrWalletCurrencyMismatch, "The currency differs from the currency of the wallet"),
+	describe(ErrTransferNotEditable, "Money flows of a transfer between wallets cannot be updated, only deleted"),
+	describe(ErrGroupRoleRequired, "The user's role in the group does not allow the change"),
+	describe(ErrAlreadyGroupMember, "The user accepting a group invitation is already a member"),
+	describe(ErrWebhookLimit, "The user already has the maximum number of webhooks"),
+
+	// Receipt scanning errors
+	describe(ErrReceiptUnreadable, "No receipt with a readable total was found in the image"),
+	describe(ErrReceiptScanUnavailable, "Receipt scanning is not configured on this server"),
+
+	// Availability errors
+	describe(ErrReadOnly, "The API is in read-only mode and rejects writes; reads keep working"),
+	describe(ErrInvitationUnavailable, "Users cannot be imported because no invitation channel is configured"),
+	describe(ErrTooManyEventStreams, "The user already has the maximum number of real-time event streams open"),
+	describe(ErrPasswordResetUnavailable, "Passwords cannot be reset because email is not configured"),
+	describe(ErrRequestTimeout, "The request ran past its deadline and its remaining work was cancelled; retry later"),
+}
+
+// Catalog returns the documentation of every error code
+func Catalog() []CodeInfo {
+	codes := make([]CodeInfo, len(catalog))
+	copy(codes, catalog)
+	return codes
+}
+
+// Describe returns the documentation of an error code
+func Describe(code ErrorCode) (CodeInfo, bool) {
+	for _, info := range catalog {
+		if info.Code == code {
+			return info, true
+		}
+	}
+	return CodeInfo{}, false
+}
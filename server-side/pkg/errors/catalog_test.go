@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+// declaredCodes returns the ErrorCode constants declared in errors.go
+func declaredCodes(t *testing.T) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "errors.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var codes []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			if ident, ok := value.Type.(*ast.Ident); ok && ident.Name == "ErrorCode" {
+				for _, name := range value.Names {
+					codes = append(codes, name.Name)
+				}
+			}
+		}
+	}
+	return codes
+}
+
+func TestCatalogListsEveryCode(t *testing.T) {
+	declared := declaredCodes(t)
+	if len(declared) != len(catalog) {
+		t.Errorf("catalog documents %d codes, errors.go declares %d", len(catalog), len(declared))
+	}
+
+	seen := make(map[ErrorCode]bool, len(catalog))
+	for _, info := range catalog {
+		if seen[info.Code] {
+			t.Errorf("%s is documented twice", info.Code)
+		}
+		seen[info.Code] = true
+
+		if info.HTTPStatus < 400 || info.Description == "" {
+			t.Errorf("%s has status %d and description %q", info.Code, info.HTTPStatus, info.Description)
+		}
+	}
+}
+
+func TestDocURLIsStable(t *testing.T) {
+	if got, want := ErrInvalidToken.DocURL, "/api/v1/meta/errors/INVALID_TOKEN"; got != want {
+		t.Errorf("DocURL = %q, want %q", got, want)
+	}
+	if got := ErrValidation.WithDetails(nil).DocURL; got != ErrValidation.DocURL {
+		t.Errorf("DocURL of a copy with details = %q, want %q", got, ErrValidation.DocURL)
+	}
+}
@@ -3,37 +3,98 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"runtime"
 )
 
+// maxStackDepth bounds the frames captured by Wrap
+const maxStackDepth = 32
+
 // ErrorCode represents a unique error code
 type ErrorCode string
 
 const (
 	// General errors
-	ErrCodeInternal        ErrorCode = "INTERNAL_ERROR"
-	ErrCodeBadRequest      ErrorCode = "BAD_REQUEST"
-	ErrCodeUnauthorized    ErrorCode = "UNAUTHORIZED"
-	ErrCodeForbidden       ErrorCode = "FORBIDDEN"
-	ErrCodeNotFound        ErrorCode = "NOT_FOUND"
-	ErrCodeConflict        ErrorCode = "CONFLICT"
-	ErrCodeValidation      ErrorCode = "VALIDATION_ERROR"
-	ErrCodeUnprocessable   ErrorCode = "UNPROCESSABLE_ENTITY"
+	ErrCodeInternal      ErrorCode = "INTERNAL_ERROR"
+	ErrCodeBadRequest    ErrorCode = "BAD_REQUEST"
+	ErrCodeUnauthorized  ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden     ErrorCode = "FORBIDDEN"
+	ErrCodeNotFound      ErrorCode = "NOT_FOUND"
+	ErrCodeConflict      ErrorCode = "CONFLICT"
+	ErrCodeValidation    ErrorCode = "VALIDATION_ERROR"
+	ErrCodeUnprocessable ErrorCode = "UNPROCESSABLE_ENTITY"
+	ErrCodeTooLarge      ErrorCode = "REQUEST_TOO_LARGE"
 
 	// Authentication errors
-	ErrCodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
-	ErrCodeEmailAlreadyExists ErrorCode = "EMAIL_ALREADY_EXISTS"
-	ErrCodeInvalidToken       ErrorCode = "INVALID_TOKEN"
-	ErrCodeExpiredToken       ErrorCode = "EXPIRED_TOKEN"
+	ErrCodeInvalidCredentials     ErrorCode = "INVALID_CREDENTIALS"
+	ErrCodeEmailAlreadyExists     ErrorCode = "EMAIL_ALREADY_EXISTS"
+	ErrCodeInvalidToken           ErrorCode = "INVALID_TOKEN"
+	ErrCodeExpiredToken           ErrorCode = "EXPIRED_TOKEN"
+	ErrCodeTokenRevoked           ErrorCode = "TOKEN_REVOKED"
+	ErrCodeInvalidCurrentPassword ErrorCode = "INVALID_CURRENT_PASSWORD"
+	ErrCodePasswordNotSet         ErrorCode = "PASSWORD_NOT_SET"
+	ErrCodeReauthRequired         ErrorCode = "REAUTHENTICATION_REQUIRED"
+	ErrCodeLastCredential         ErrorCode = "LAST_CREDENTIAL"
+	ErrCodeClientNotAllowed       ErrorCode = "CLIENT_NOT_ALLOWED"
+	ErrCodeInvalidInvitation      ErrorCode = "INVALID_INVITATION"
+	ErrCodeAccountDisabled        ErrorCode = "ACCOUNT_DISABLED"
+	ErrCodeAccountLocked          ErrorCode = "ACCOUNT_LOCKED"
+	ErrCodeInvalidPasswordReset   ErrorCode = "INVALID_PASSWORD_RESET"
+	ErrCodeInvalidDownloadLink    ErrorCode = "INVALID_DOWNLOAD_LINK"
+	ErrCodeInvalidShareLink       ErrorCode = "INVALID_SHARE_LINK"
+
+	// Account linking errors
+	ErrCodeCredentialAlreadyLinked  ErrorCode = "CREDENTIAL_ALREADY_LINKED"
+	ErrCodeProviderAlreadyLinked    ErrorCode = "PROVIDER_ALREADY_LINKED"
+	ErrCodeUnsupportedAuthProvider  ErrorCode = "UNSUPPORTED_AUTH_PROVIDER"
+	ErrCodeTooManyVerificationCodes ErrorCode = "TOO_MANY_VERIFICATION_CODES"
+
+	// Demo mode errors
+	ErrCodeDemoDisabled          ErrorCode = "DEMO_DISABLED"
+	ErrCodeDemoAccountRestricted ErrorCode = "DEMO_ACCOUNT_RESTRICTED"
+
+	// API key errors
+	ErrCodeInvalidAPIKey     ErrorCode = "INVALID_API_KEY"
+	ErrCodeInsufficientScope ErrorCode = "INSUFFICIENT_SCOPE"
 
 	// Resource errors
 	ErrCodeUserNotFound     ErrorCode = "USER_NOT_FOUND"
+	ErrCodePhoneNumberTaken ErrorCode = "PHONE_NUMBER_ALREADY_EXISTS"
 	ErrCodeResourceNotFound ErrorCode = "RESOURCE_NOT_FOUND"
 	ErrCodeVersionConflict  ErrorCode = "VERSION_CONFLICT"
+	ErrCodePrecondition     ErrorCode = "PRECONDITION_FAILED"
+	ErrCodeTagNotFound      ErrorCode = "TAG_NOT_FOUND"
+	ErrCodeTagAlreadyExists ErrorCode = "TAG_ALREADY_EXISTS"
 
 	// Business logic errors
-	ErrCodeInvalidInput       ErrorCode = "INVALID_INPUT"
-	ErrCodeInsufficientFunds  ErrorCode = "INSUFFICIENT_FUNDS"
+	ErrCodeInvalidInput        ErrorCode = "INVALID_INPUT"
+	ErrCodeInsufficientFunds   ErrorCode = "INSUFFICIENT_FUNDS"
 	ErrCodeOperationNotAllowed ErrorCode = "OPERATION_NOT_ALLOWED"
+	ErrCodeCurrencyMismatch    ErrorCode = "CURRENCY_MISMATCH"
+	ErrCodeBudgetExceeded      ErrorCode = "BUDGET_EXCEEDED"
+	ErrCodeDuplicateMoneyFlow  ErrorCode = "DUPLICATE_MONEY_FLOW"
+	ErrCodeBudgetAlreadyExists ErrorCode = "BUDGET_ALREADY_EXISTS"
+	ErrCodeWalletAlreadyExists ErrorCode = "WALLET_ALREADY_EXISTS"
+	ErrCodeWalletNotEmpty      ErrorCode = "WALLET_NOT_EMPTY"
+	ErrCodeWalletCurrency      ErrorCode = "WALLET_CURRENCY_MISMATCH"
+	ErrCodeTransferNotEditable ErrorCode = "TRANSFER_NOT_EDITABLE"
+	ErrCodeGroupRoleRequired   ErrorCode = "GROUP_ROLE_REQUIRED"
+	ErrCodeAlreadyGroupMember  ErrorCode = "ALREADY_GROUP_MEMBER"
+	ErrCodeWebhookLimit        ErrorCode = "WEBHOOK_LIMIT_REACHED"
+
+	// Merchant errors
+	ErrCodeMerchantAlreadyExists     ErrorCode = "MERCHANT_ALREADY_EXISTS"
+	ErrCodeMerchantRuleAlreadyExists ErrorCode = "MERCHANT_RULE_ALREADY_EXISTS"
+
+	// Receipt scanning errors
+	ErrCodeReceiptUnreadable      ErrorCode = "RECEIPT_UNREADABLE"
+	ErrCodeReceiptScanUnavailable ErrorCode = "RECEIPT_SCAN_UNAVAILABLE"
+
+	// Availability errors
+	ErrCodeReadOnly                 ErrorCode = "READ_ONLY"
+	ErrCodeInvitationUnavailable    ErrorCode = "INVITATION_DELIVERY_UNAVAILABLE"
+	ErrCodeTooManyEventStreams      ErrorCode = "TOO_MANY_EVENT_STREAMS"
+	ErrCodePasswordResetUnavailable ErrorCode = "PASSWORD_RESET_UNAVAILABLE"
+	ErrCodeRequestTimeout           ErrorCode = "REQUEST_TIMEOUT"
 )
 
 // AppError represents an application error with code and HTTP status
@@ -41,8 +102,30 @@ type AppError struct {
 	Code       ErrorCode              `json:"code"`
 	Message    string                 `json:"message"`
 	HTTPStatus int                    `json:"-"`
+	DocURL     string                 `json:"doc_url"` // documentation of the code, see DocURL
 	Details    map[string]interface{} `json:"details,omitempty"`
 	Err        error                  `json:"-"`
+
+	// Fields describe the failure for error reports, such as the IDs of the records
+	// involved; unlike Details they are never sent to clients
+	Fields map[string]interface{} `json:"-"`
+
+	stack []uintptr // where Wrap was called
+}
+
+// Option adds context to an error created by Wrap
+type Option func(*AppError)
+
+// WithFields attaches fields describing the failure to the error reports of an error
+func WithFields(fields map[string]interface{}) Option {
+	return func(e *AppError) {
+		if e.Fields == nil {
+			e.Fields = make(map[string]interface{}, len(fields))
+		}
+		for k, v := range fields {
+			e.Fields[k] = v
+		}
+	}
 }
 
 // Error implements the error interface
@@ -58,16 +141,38 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
-// WithDetails adds details to the error
+// StackTrace returns the call stack where the error was wrapped, innermost call first;
+// predefined errors and errors made by New have none
+func (e *AppError) StackTrace() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	var stack []runtime.Frame
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			return stack
+		}
+	}
+}
+
+// WithDetails returns a copy of the error with details, leaving the predefined
+// errors untouched for other requests
 func (e *AppError) WithDetails(details map[string]interface{}) *AppError {
-	e.Details = details
-	return e
+	copied := *e
+	copied.Details = details
+	return &copied
 }
 
-// WithError wraps an underlying error
+// WithError returns a copy of the error wrapping an underlying error, leaving the
+// predefined errors untouched for other requests
 func (e *AppError) WithError(err error) *AppError {
-	e.Err = err
-	return e
+	copied := *e
+	copied.Err = err
+	return &copied
 }
 
 // New creates a new AppError
@@ -76,17 +181,27 @@ func New(code ErrorCode, message string, httpStatus int) *AppError {
 		Code:       code,
 		Message:    message,
 		HTTPStatus: httpStatus,
+		DocURL:     DocURL(code),
 	}
 }
 
-// Wrap wraps an existing error with AppError
-func Wrap(err error, code ErrorCode, message string, httpStatus int) *AppError {
-	return &AppError{
+// Wrap wraps an existing error with AppError, capturing the call stack for error reports
+func Wrap(err error, code ErrorCode, message string, httpStatus int, opts ...Option) *AppError {
+	stack := make([]uintptr, maxStackDepth)
+	stack = stack[:runtime.Callers(2, stack)]
+
+	appErr := &AppError{
 		Code:       code,
 		Message:    message,
 		HTTPStatus: httpStatus,
+		DocURL:     DocURL(code),
 		Err:        err,
+		stack:      stack,
+	}
+	for _, opt := range opts {
+		opt(appErr)
 	}
+	return appErr
 }
 
 // IsAppError checks if an error is an AppError
@@ -158,6 +273,12 @@ var (
 		"Validation failed",
 		http.StatusBadRequest,
 	)
+
+	ErrRequestTooLarge = New(
+		ErrCodeTooLarge,
+		"Request body is too large",
+		http.StatusRequestEntityTooLarge,
+	)
 )
 
 // Predefined errors - Authentication
@@ -185,6 +306,135 @@ var (
 		"Authentication token has expired",
 		http.StatusUnauthorized,
 	)
+
+	ErrTokenRevoked = New(
+		ErrCodeTokenRevoked,
+		"Authentication token has been revoked; sign in again",
+		http.StatusUnauthorized,
+	)
+
+	ErrInvalidCurrentPassword = New(
+		ErrCodeInvalidCurrentPassword,
+		"Current password is incorrect",
+		http.StatusBadRequest,
+	)
+
+	ErrPasswordNotSet = New(
+		ErrCodePasswordNotSet,
+		"No password is set for this account",
+		http.StatusBadRequest,
+	)
+
+	ErrReauthRequired = New(
+		ErrCodeReauthRequired,
+		"This action requires recent authentication; confirm your password or sign in again",
+		http.StatusForbidden,
+	)
+
+	ErrLastCredential = New(
+		ErrCodeLastCredential,
+		"The last sign-in method of an account cannot be removed",
+		http.StatusConflict,
+	)
+
+	ErrClientNotAllowed = New(
+		ErrCodeClientNotAllowed,
+		"This endpoint is not available to this client; sign in from an allowed client",
+		http.StatusForbidden,
+	)
+
+	ErrInvalidInvitation = New(
+		ErrCodeInvalidInvitation,
+		"The invitation is invalid, expired, or already accepted",
+		http.StatusBadRequest,
+	)
+
+	ErrAccountDisabled = New(
+		ErrCodeAccountDisabled,
+		"This account has been disabled; contact support",
+		http.StatusForbidden,
+	)
+
+	ErrAccountLocked = New(
+		ErrCodeAccountLocked,
+		"Too many failed sign-ins; try again later or reset your password",
+		http.StatusLocked,
+	)
+
+	ErrInvalidPasswordReset = New(
+		ErrCodeInvalidPasswordReset,
+		"The password reset link is invalid, expired, or already used",
+		http.StatusBadRequest,
+	)
+
+	ErrInvalidDownloadLink = New(
+		ErrCodeInvalidDownloadLink,
+		"The download link is invalid or expired; request a new export",
+		http.StatusForbidden,
+	)
+
+	ErrInvalidShareLink = New(
+		ErrCodeInvalidShareLink,
+		"The share link is invalid, expired, or revoked",
+		http.StatusForbidden,
+	)
+)
+
+// Predefined errors - Account linking
+var (
+	ErrCredentialAlreadyLinked = New(
+		ErrCodeCredentialAlreadyLinked,
+		"Credential is already linked to another account",
+		http.StatusConflict,
+	)
+
+	ErrProviderAlreadyLinked = New(
+		ErrCodeProviderAlreadyLinked,
+		"Authentication provider is already linked to this account",
+		http.StatusConflict,
+	)
+
+	ErrUnsupportedAuthProvider = New(
+		ErrCodeUnsupportedAuthProvider,
+		"Authentication provider is not supported",
+		http.StatusBadRequest,
+	)
+
+	ErrTooManyVerificationCodes = New(
+		ErrCodeTooManyVerificationCodes,
+		"Too many verification codes were sent in the last hour; try again later",
+		http.StatusTooManyRequests,
+	)
+)
+
+// Predefined errors - Demo mode
+var (
+	ErrDemoDisabled = New(
+		ErrCodeDemoDisabled,
+		"Demo mode is not enabled",
+		http.StatusNotFound,
+	)
+
+	ErrDemoAccountRestricted = New(
+		ErrCodeDemoAccountRestricted,
+		"Demo accounts cannot do this; register to keep your data",
+		http.StatusForbidden,
+	)
+)
+
+// Predefined errors - API keys
+var (
+	ErrInvalidAPIKey = New(
+		ErrCodeInvalidAPIKey,
+		"Invalid or revoked API key",
+		http.StatusUnauthorized,
+	)
+
+	ErrInsufficientScope = New(
+		ErrCodeInsufficientScope,
+		"API key does not grant the required scope",
+		http.StatusForbidden,
+	)
 )
 
 // Predefined errors - Resources
@@ -206,6 +456,30 @@ var (
 		"Resource version conflict",
 		http.StatusConflict,
 	)
+
+	ErrPreconditionFailed = New(
+		ErrCodePrecondition,
+		"Resource does not match If-Match",
+		http.StatusPreconditionFailed,
+	)
+
+	ErrPhoneNumberTaken = New(
+		ErrCodePhoneNumberTaken,
+		"Phone number already registered",
+		http.StatusConflict,
+	)
+
+	ErrTagNotFound = New(
+		ErrCodeTagNotFound,
+		"No money flow has this tag",
+		http.StatusNotFound,
+	)
+
+	ErrTagAlreadyExists = New(
+		ErrCodeTagAlreadyExists,
+		"The tag is already in use; merge the tags instead",
+		http.StatusConflict,
+	)
 )
 
 // Predefined errors - Business Logic
@@ -221,4 +495,130 @@ var (
 		"Operation not allowed",
 		http.StatusForbidden,
 	)
+
+	ErrCurrencyMismatch = New(
+		ErrCodeCurrencyMismatch,
+		"Currency does not match the default currency required by single-currency mode",
+		http.StatusUnprocessableEntity,
+	)
+
+	ErrBudgetExceeded = New(
+		ErrCodeBudgetExceeded,
+		"Money flow exceeds the category budget; resend with override_budget to record it anyway",
+		http.StatusUnprocessableEntity,
+	)
+
+	ErrDuplicateMoneyFlow = New(
+		ErrCodeDuplicateMoneyFlow,
+		"Money flow looks like one already recorded today; resend with allow_duplicate to record it anyway",
+		http.StatusUnprocessableEntity,
+	)
+
+	ErrBudgetAlreadyExists = New(
+		ErrCodeBudgetAlreadyExists,
+		"A budget for this category already exists",
+		http.StatusConflict,
+	)
+
+	ErrWalletAlreadyExists = New(
+		ErrCodeWalletAlreadyExists,
+		"A wallet with this name already exists",
+		http.StatusConflict,
+	)
+
+	ErrMerchantAlreadyExists = New(
+		ErrCodeMerchantAlreadyExists,
+		"A merchant with this name already exists",
+		http.StatusConflict,
+	)
+
+	ErrMerchantRuleAlreadyExists = New(
+		ErrCodeMerchantRuleAlreadyExists,
+		"A merchant rule with this pattern already exists",
+		http.StatusConflict,
+	)
+
+	ErrWalletNotEmpty = New(
+		ErrCodeWalletNotEmpty,
+		"The wallet still has money flows; delete or move them first",
+		http.StatusConflict,
+	)
+
+	ErrWalletCurrencyMismatch = New(
+		ErrCodeWalletCurrency,
+		"Currency does not match the currency of the wallet",
+		http.StatusUnprocessableEntity,
+	)
+
+	ErrTransferNotEditable = New(
+		ErrCodeTransferNotEditable,
+		"Transfers between wallets cannot be edited; delete the transfer and record it again",
+		http.StatusUnprocessableEntity,
+	)
+
+	ErrGroupRoleRequired = New(
+		ErrCodeGroupRoleRequired,
+		"Your role in the group does not allow this",
+		http.StatusForbidden,
+	)
+
+	ErrAlreadyGroupMember = New(
+		ErrCodeAlreadyGroupMember,
+		"You are already a member of this group",
+		http.StatusConflict,
+	)
+
+	ErrWebhookLimit = New(
+		ErrCodeWebhookLimit,
+		"You have reached the maximum number of webhooks; delete one to add another",
+		http.StatusConflict,
+	)
+)
+
+// Predefined errors - Receipt Scanning
+var (
+	ErrReceiptUnreadable = New(
+		ErrCodeReceiptUnreadable,
+		"No receipt with a readable total was found in the image",
+		http.StatusUnprocessableEntity,
+	)
+
+	ErrReceiptScanUnavailable = New(
+		ErrCodeReceiptScanUnavailable,
+		"Receipt scanning is not available on this server",
+		http.StatusServiceUnavailable,
+	)
+)
+
+// Predefined errors - Availability
+var (
+	ErrReadOnly = New(
+		ErrCodeReadOnly,
+		"The service is temporarily read-only; changes cannot be saved right now",
+		http.StatusServiceUnavailable,
+	)
+
+	ErrInvitationUnavailable = New(
+		ErrCodeInvitationUnavailable,
+		"Invitations cannot be delivered because neither WhatsApp nor email is configured",
+		http.StatusServiceUnavailable,
+	)
+
+	ErrTooManyEventStreams = New(
+		ErrCodeTooManyEventStreams,
+		"Too many event streams are open for this account; close one and try again",
+		http.StatusTooManyRequests,
+	)
+
+	ErrPasswordResetUnavailable = New(
+		ErrCodePasswordResetUnavailable,
+		"Passwords cannot be reset because email is not configured",
+		http.StatusServiceUnavailable,
+	)
+
+	ErrRequestTimeout = New(
+		ErrCodeRequestTimeout,
+		"The request took too long to process; try again later",
+		http.StatusGatewayTimeout,
+	)
 )
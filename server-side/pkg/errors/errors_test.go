@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestWithDetailsLeavesPredefinedErrorsUnchanged runs concurrent requests failing with
+// the same predefined errors; run with -race to catch writes to the shared values
+func TestWithDetailsLeavesPredefinedErrorsUnchanged(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			detailed := ErrValidation.WithDetails(map[string]interface{}{"request": i})
+			if detailed == ErrValidation || detailed.Details["request"] != i {
+				t.Errorf("WithDetails() = %+v, want a copy with the details of request %d", detailed, i)
+			}
+
+			cause := fmt.Errorf("cause of request %d", i)
+			wrapped := ErrInternal.WithError(cause)
+			if wrapped == ErrInternal || !errors.Is(wrapped, cause) {
+				t.Errorf("WithError() = %+v, want a copy wrapping the cause of request %d", wrapped, i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if ErrValidation.Details != nil {
+		t.Errorf("ErrValidation.Details = %v, want the predefined error unchanged", ErrValidation.Details)
+	}
+	if ErrInternal.Err != nil {
+		t.Errorf("ErrInternal.Err = %v, want the predefined error unchanged", ErrInternal.Err)
+	}
+}